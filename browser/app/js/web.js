@@ -93,6 +93,9 @@ class Web {
   PresignedGet(args) {
     return this.makeCall('PresignedGet', args)
   }
+  PresignedPost(args) {
+    return this.makeCall('PresignedPost', args)
+  }
   PutObjectURL(args) {
     return this.makeCall('PutObjectURL', args)
   }
@@ -19,6 +19,7 @@ package cmd
 import (
 	"encoding/xml"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/pkg/policy"
@@ -136,6 +137,17 @@ func (api objectAPIHandlers) GetObjectACLHandler(w http.ResponseWriter, r *http.
 		},
 		Permission: "FULL_CONTROL",
 	})
+	if isObjectPublicRead(bucket, object) {
+		acl.AccessControlList.Grants = append(acl.AccessControlList.Grants, grant{
+			Grantee: grantee{
+				XMLNS:  "http://www.w3.org/2001/XMLSchema-instance",
+				XMLXSI: "Group",
+				Type:   "Group",
+				URI:    allUsersGranteeURI,
+			},
+			Permission: "READ",
+		})
+	}
 	if err := xml.NewEncoder(w).Encode(acl); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
@@ -143,3 +155,55 @@ func (api objectAPIHandlers) GetObjectACLHandler(w http.ResponseWriter, r *http.
 
 	w.(http.Flusher).Flush()
 }
+
+// PutObjectACLHandler - PUT Object ACL
+// -----------------
+// This operation uses the ACL subresource to set the ACL of a specified
+// object. Only the private and public-read canned ACLs are supported, which
+// are mapped onto a statement of the bucket policy.
+func (api objectAPIHandlers) PutObjectACLHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutObjectACL")
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// As the ACL is stored in the bucket policy, setting it requires the
+	// permission to set the bucket policy.
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	// Wildcards of the object name would match other objects in the
+	// bucket policy.
+	if strings.ContainsAny(object, "*?") {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	acl, s3Error := getObjectACL(r)
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	// Before proceeding validate if object exists.
+	if _, err := objAPI.GetObjectInfo(ctx, bucket, object); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := updateObjectACL(ctx, objAPI, bucket, object, acl); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
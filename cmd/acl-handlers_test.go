@@ -0,0 +1,273 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// Wrapper for calling object ACL handler tests for both XL multiple disks and single node setup.
+func TestObjectACLHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testObjectACLHandlers, []string{"PutObjectACL", "GetObjectACL", "CopyObject", "PutObjectPart",
+		"NewMultipart", "CompleteMultipart", "PutObject", "GetObject", "DeleteObject", "GetBucketPolicy"})
+}
+
+func testObjectACLHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+
+	serve := func(method, urlStr string, header http.Header, data []byte, signed bool) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if signed {
+			if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+				t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+			}
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	isPublicRead := func(objectName string) bool {
+		rec := serve("GET", getObjectACLURL("", bucketName, objectName), nil, nil, true)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		var acl accessControlPolicy
+		if err := xml.Unmarshal(rec.Body.Bytes(), &acl); err != nil {
+			t.Fatalf("%s: Failed to parse access control policy: <ERROR> %v", instanceType, err)
+		}
+		for _, grant := range acl.AccessControlList.Grants {
+			if grant.Grantee.URI == allUsersGranteeURI && grant.Permission == "READ" {
+				return true
+			}
+		}
+		return false
+	}
+
+	objectName := "acl-object"
+	if rec := serve("PUT", getPutObjectURL("", bucketName, objectName), nil, []byte("hello"), true); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if rec := serve("PUT", getPutObjectURL("", bucketName, "other-object"), nil, []byte("hello"), true); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	publicReadData := []byte(`<AccessControlPolicy><AccessControlList>` +
+		`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>owner</ID></Grantee><Permission>FULL_CONTROL</Permission></Grant>` +
+		`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>` + allUsersGranteeURI + `</URI></Grantee><Permission>READ</Permission></Grant>` +
+		`</AccessControlList></AccessControlPolicy>`)
+	writeData := []byte(`<AccessControlPolicy><AccessControlList>` +
+		`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>` + allUsersGranteeURI + `</URI></Grantee><Permission>WRITE</Permission></Grant>` +
+		`</AccessControlList></AccessControlPolicy>`)
+
+	testCases := []struct {
+		objectName         string
+		acl                string
+		data               []byte
+		signed             bool
+		expectedCode       int
+		expectedPublicRead bool
+	}{
+		{objectName, "public-read", nil, true, http.StatusOK, true},
+		// Setting the same ACL again is a no-op.
+		{objectName, "public-read", nil, true, http.StatusOK, true},
+		{objectName, "private", nil, true, http.StatusOK, false},
+		{objectName, "", publicReadData, true, http.StatusOK, true},
+		{objectName, "public-read-write", nil, true, http.StatusNotImplemented, true},
+		{objectName, "", writeData, true, http.StatusNotImplemented, true},
+		{objectName, "", []byte(`<AccessControlPolicy>`), true, http.StatusBadRequest, true},
+		{objectName, "private", nil, false, http.StatusForbidden, true},
+		{"missing-object", "public-read", nil, true, http.StatusNotFound, true},
+		{objectName, "private", nil, true, http.StatusOK, false},
+	}
+	for i, testCase := range testCases {
+		header := http.Header{}
+		if testCase.acl != "" {
+			header.Set(amzACL, testCase.acl)
+		}
+		rec := serve("PUT", getObjectACLURL("", bucketName, testCase.objectName), header, testCase.data, testCase.signed)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if publicRead := isPublicRead(objectName); publicRead != testCase.expectedPublicRead {
+			t.Errorf("%s: Test %d: Expected public-read `%v`, but instead found `%v`", instanceType, i+1, testCase.expectedPublicRead, publicRead)
+		}
+
+		// Anonymous users may only read the object with the public-read ACL.
+		expectedCode := http.StatusForbidden
+		if testCase.expectedPublicRead {
+			expectedCode = http.StatusOK
+		}
+		if rec = serve("GET", getGetObjectURL("", bucketName, objectName), nil, nil, false); rec.Code != expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, expectedCode, rec.Code)
+		}
+		if rec = serve("GET", getGetObjectURL("", bucketName, "other-object"), nil, nil, false); rec.Code != http.StatusForbidden {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, http.StatusForbidden, rec.Code)
+		}
+	}
+
+	// The bucket policy is removed along with the last ACL.
+	if rec := serve("GET", getGetPolicyURL("", bucketName), nil, nil, true); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	publicReadHeader := http.Header{}
+	publicReadHeader.Set(amzACL, objectACLPublicRead)
+	isAnonReadable := func(objectName string) bool {
+		return serve("GET", getGetObjectURL("", bucketName, objectName), nil, nil, false).Code == http.StatusOK
+	}
+
+	// Uploads set the ACL of the object, replacing the one of the object
+	// they overwrite.
+	if rec := serve("PUT", getPutObjectURL("", bucketName, objectName), publicReadHeader, []byte("hello"), true); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if !isAnonReadable(objectName) {
+		t.Errorf("%s: Expected an object uploaded with the public-read ACL to be readable by anonymous users", instanceType)
+	}
+	if rec := serve("PUT", getPutObjectURL("", bucketName, objectName), nil, []byte("hello"), true); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if isAnonReadable(objectName) {
+		t.Errorf("%s: Expected an overwritten object to lose its public-read ACL", instanceType)
+	}
+
+	copyHeader := http.Header{}
+	copyHeader.Set(amzACL, objectACLPublicRead)
+	copyHeader.Set("X-Amz-Copy-Source", bucketName+"/"+objectName)
+	if rec := serve("PUT", getCopyObjectURL("", bucketName, "copied-object"), copyHeader, nil, true); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if !isAnonReadable("copied-object") {
+		t.Errorf("%s: Expected an object copied with the public-read ACL to be readable by anonymous users", instanceType)
+	}
+
+	// Removing an object removes its ACL.
+	if rec := serve("DELETE", getDeleteObjectURL("", bucketName, "copied-object"), nil, nil, true); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec := serve("GET", getGetPolicyURL("", bucketName), nil, nil, true); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	// The ACL of a multipart upload is set once it is completed.
+	rec := serve("POST", getNewMultipartURL("", bucketName, "multipart-object"), publicReadHeader, nil, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var upload InitiateMultipartUploadResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &upload); err != nil {
+		t.Fatalf("%s: Failed to parse multipart upload: <ERROR> %v", instanceType, err)
+	}
+	rec = serve("PUT", getPutObjectPartURL("", bucketName, "multipart-object", upload.UploadID, "1"), nil, []byte("hello"), true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if isAnonReadable("multipart-object") {
+		t.Errorf("%s: Expected an incomplete multipart upload not to be readable by anonymous users", instanceType)
+	}
+	completeData, err := xml.Marshal(CompleteMultipartUpload{Parts: []CompletePart{{PartNumber: 1, ETag: rec.Header().Get("ETag")}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec = serve("POST", getCompleteMultipartUploadURL("", bucketName, "multipart-object", upload.UploadID), nil, completeData, true); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if !isAnonReadable("multipart-object") {
+		t.Errorf("%s: Expected an object uploaded with the public-read ACL to be readable by anonymous users", instanceType)
+	}
+
+	// Unsupported ACLs of uploads are ignored, the object is private.
+	// Anonymous requests for the public-read ACL are rejected.
+	for _, acl := range []string{"public-read-write", "authenticated-read"} {
+		unsupportedHeader := http.Header{}
+		unsupportedHeader.Set(amzACL, acl)
+		if rec = serve("PUT", getPutObjectURL("", bucketName, "other-object"), unsupportedHeader, []byte("hello"), true); rec.Code != http.StatusOK {
+			t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		if isAnonReadable("other-object") {
+			t.Errorf("%s: Expected an object uploaded with the %s ACL not to be readable by anonymous users", instanceType, acl)
+		}
+	}
+	if rec = serve("PUT", getPutObjectURL("", bucketName, "other-object"), publicReadHeader, []byte("hello"), false); rec.Code != http.StatusForbidden {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+
+	// The bucket policy holding the ACLs is limited in size.
+	var bucketPolicy *policy.Policy
+	for i := 0; ; i++ {
+		newPolicy, _ := setObjectACL(bucketPolicy, bucketName, fmt.Sprintf("object-%d", i), objectACLPublicRead)
+		data, err := json.Marshal(newPolicy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) > maxBucketPolicySize {
+			break
+		}
+		bucketPolicy = newPolicy
+	}
+	if err = obj.SetBucketPolicy(context.Background(), bucketName, bucketPolicy); err != nil {
+		t.Fatal(err)
+	}
+	globalPolicySys.Set(bucketName, *bucketPolicy)
+	if err = updateObjectACL(context.Background(), obj, bucketName, "one-more-object", objectACLPublicRead); err != errPolicyTooLarge {
+		t.Errorf("%s: Expected error `%v`, but instead found `%v`", instanceType, errPolicyTooLarge, err)
+	}
+
+	// An upload whose ACL doesn't fit in the bucket policy is rejected
+	// before the object is written.
+	if rec = serve("PUT", getPutObjectURL("", bucketName, "one-more-object"), publicReadHeader, []byte("hello"), true); rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if _, err = obj.GetObjectInfo(context.Background(), bucketName, "one-more-object"); !isErrObjectNotFound(err) {
+		t.Errorf("%s: Expected the object not to be written, but instead found `%v`", instanceType, err)
+	}
+}
+
+func TestSetObjectACL(t *testing.T) {
+	bucketPolicy, changed := setObjectACL(nil, "mybucket", "myobject", objectACLPublicRead)
+	if !changed || len(bucketPolicy.Statements) != 1 {
+		t.Fatalf("Expected the statement of the ACL to be added")
+	}
+	if _, changed = setObjectACL(bucketPolicy, "mybucket", "myobject", objectACLPublicRead); changed {
+		t.Fatalf("Expected the bucket policy to be unchanged")
+	}
+	if _, changed = setObjectACL(bucketPolicy, "mybucket", "yourobject", objectACLPrivate); changed {
+		t.Fatalf("Expected the bucket policy to be unchanged")
+	}
+	if bucketPolicy, changed = setObjectACL(bucketPolicy, "mybucket", "myobject", objectACLPrivate); !changed || !bucketPolicy.IsEmpty() {
+		t.Fatalf("Expected the statement of the ACL to be removed")
+	}
+}
@@ -19,12 +19,16 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -33,7 +37,9 @@ import (
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/policy"
 	"github.com/minio/minio/pkg/quick"
+	sha256 "github.com/minio/sha256-simd"
 )
 
 const (
@@ -129,7 +135,10 @@ func (a adminAPIHandlers) ServiceStatusHandler(w http.ResponseWriter, r *http.Re
 // Body: {"action": <restart-action>}
 // ----------
 // Restarts/Stops minio server gracefully. In a distributed setup,
-// restarts all the servers in the cluster.
+// restarts all the servers in the cluster. Rolling restart restarts
+// them one after another, waiting for each server to be back and for
+// read quorum before restarting the next one. Freeze makes all servers
+// reject new S3 calls with SlowDown until unfreeze is sent.
 func (a adminAPIHandlers) ServiceStopNRestartHandler(w http.ResponseWriter, r *http.Request) {
 	adminAPIErr := checkAdminRequestAuthType(r, "")
 	if adminAPIErr != ErrNone {
@@ -147,22 +156,322 @@ func (a adminAPIHandlers) ServiceStopNRestartHandler(w http.ResponseWriter, r *h
 
 	var serviceSig serviceSignal
 	switch sa.Action {
-	case madmin.ServiceActionValueRestart:
+	case madmin.ServiceActionValueRestart, madmin.ServiceActionValueRollingRestart:
 		serviceSig = serviceRestart
 	case madmin.ServiceActionValueStop:
 		serviceSig = serviceStop
+	case madmin.ServiceActionValueFreeze:
+		serviceSig = serviceFreeze
+	case madmin.ServiceActionValueUnfreeze:
+		serviceSig = serviceUnFreeze
 	default:
 		writeErrorResponseJSON(w, ErrMalformedPOSTRequest, r.URL)
 		logger.LogIf(context.Background(), errors.New("Invalid service action received"))
 		return
 	}
 
+	if serviceSig == serviceFreeze || serviceSig == serviceUnFreeze {
+		// Reply once all servers applied the signal, servers
+		// which failed to are reported to the client.
+		errs := sendServiceCmd(globalAdminPeers, serviceSig)
+		if err = newPeersError(globalAdminPeers, errs, string(sa.Action)); err != nil {
+			logger.LogIf(context.Background(), err)
+			writeCustomErrorResponseJSON(w, ErrInternalError, err.Error(), r.URL)
+			return
+		}
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
 	// Reply to the client before restarting minio server.
 	writeSuccessResponseHeadersOnly(w)
 
+	if sa.Action == madmin.ServiceActionValueRollingRestart {
+		// Restarting servers one by one takes a while, errors can
+		// only be logged once the client got its reply.
+		go func() {
+			errs := sendRollingServiceRestart(globalAdminPeers)
+			logger.LogIf(context.Background(), newPeersError(globalAdminPeers, errs, string(sa.Action)))
+		}()
+		return
+	}
+
 	sendServiceCmd(globalAdminPeers, serviceSig)
 }
 
+// ServerUpdateResult holds the update result of one node.
+type ServerUpdateResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+}
+
+// ServerUpdateStatus holds the update result of all nodes, nodes are
+// only restarted when all of them have been updated successfully.
+type ServerUpdateStatus struct {
+	Restarted bool                 `json:"restarted"`
+	Servers   []ServerUpdateResult `json:"servers"`
+}
+
+// ServerUpdateHandler - POST /minio/admin/v1/update?updateURL=<binary-url>&sha256=<hex-checksum>
+// ----------
+// Makes all servers download the binary at updateURL and replace their
+// binary with it once its SHA256 checksum is verified. Once all servers
+// are updated they are restarted to run the new binary.
+func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	updateURL := vars.Get("updateURL")
+	u, err := url.Parse(updateURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		writeErrorResponseJSON(w, ErrAdminInvalidUpdateURL, r.URL)
+		return
+	}
+
+	sha256Sum, err := hex.DecodeString(vars.Get("sha256"))
+	if err != nil || len(sha256Sum) != sha256.Size {
+		writeErrorResponseJSON(w, ErrAdminInvalidUpdateChecksum, r.URL)
+		return
+	}
+
+	status := ServerUpdateStatus{
+		Servers: make([]ServerUpdateResult, len(globalAdminPeers)),
+	}
+
+	var wg sync.WaitGroup
+
+	// Update all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			status.Servers[idx] = ServerUpdateResult{Addr: peer.addr}
+
+			if err := peer.cmdRunner.ServerUpdate(updateURL, sha256Sum); err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				status.Servers[idx].Error = err.Error()
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	// Restart only when every node runs the same binary
+	// afterwards, failed nodes may be retried by the client.
+	status.Restarted = true
+	for _, server := range status.Servers {
+		if server.Error != "" {
+			status.Restarted = false
+			break
+		}
+	}
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	// Reply to the client before restarting minio server.
+	writeSuccessResponseJSON(w, jsonBytes)
+
+	if status.Restarted {
+		sendServiceCmd(globalAdminPeers, serviceRestart)
+	}
+}
+
+// ReloadCertsResult holds the TLS certificate reload result of one node.
+type ReloadCertsResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+}
+
+// ReloadCertsHandler - POST /minio/admin/v1/reload-certs
+// ----------
+// Makes all servers load their TLS certificate and private key again,
+// so that renewed certificates are served without a restart. Servers
+// failing to load them keep serving their current certificate.
+func (a adminAPIHandlers) ReloadCertsHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	if !globalIsSSL {
+		writeErrorResponseJSON(w, ErrAdminTLSNotEnabled, r.URL)
+		return
+	}
+
+	results := make([]ReloadCertsResult, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Reload certificates of all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			results[idx] = ReloadCertsResult{Addr: peer.addr}
+
+			if err := peer.cmdRunner.ReloadCerts(); err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				results[idx].Error = err.Error()
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetLogLevelResult holds the log verbosity change result of one node.
+type SetLogLevelResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+}
+
+// SetLogLevelHandler - POST /minio/admin/v1/log-level?subsystem=<subsystem>&level=<level>
+// ----------
+// Changes the log verbosity of a subsystem (http, storage, rpc or iam)
+// to error, info or debug on all servers. The change lasts until the
+// servers are restarted.
+func (a adminAPIHandlers) SetLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	subsystem, err := logger.ParseSubsystem(vars.Get("subsystem"))
+	if err != nil {
+		writeErrorResponseJSON(w, ErrAdminInvalidLogLevel, r.URL)
+		return
+	}
+	level, err := logger.ParseLevel(vars.Get("level"))
+	if err != nil {
+		writeErrorResponseJSON(w, ErrAdminInvalidLogLevel, r.URL)
+		return
+	}
+
+	results := make([]SetLogLevelResult, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Change log verbosity of all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			results[idx] = SetLogLevelResult{Addr: peer.addr}
+
+			if err := peer.cmdRunner.SetLogLevel(subsystem, level); err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				results[idx].Error = err.Error()
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetScannerSpeedResult holds the scanner speed change result of one node.
+type SetScannerSpeedResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+}
+
+// SetScannerSpeedHandler - POST /minio/admin/v1/scanner-speed?speed=<slow|default|fast|off>
+// ----------
+// Changes the pace of the disk usage crawler and heal sequences on all
+// servers, so that scans don't compete with client traffic on busy
+// clusters. The change lasts until the servers are restarted.
+func (a adminAPIHandlers) SetScannerSpeedHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	speed := ScannerSpeed(r.URL.Query().Get("speed"))
+	if !speed.IsValid() {
+		writeErrorResponseJSON(w, ErrAdminInvalidScannerSpeed, r.URL)
+		return
+	}
+
+	results := make([]SetScannerSpeedResult, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Change scanner speed of all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			results[idx] = SetScannerSpeedResult{Addr: peer.addr}
+
+			if err := peer.cmdRunner.SetScannerSpeed(speed); err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				results[idx].Error = err.Error()
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
 // ServerProperties holds some server information such as, version, region
 // uptime, etc..
 type ServerProperties struct {
@@ -173,11 +482,14 @@ type ServerProperties struct {
 	SQSARN   []string      `json:"sqsARN"`
 }
 
-// ServerConnStats holds transferred bytes from/to the server
+// ServerConnStats holds transferred bytes from/to the server, internode
+// counters hold bytes of RPC calls served to other servers.
 type ServerConnStats struct {
-	TotalInputBytes  uint64 `json:"transferred"`
-	TotalOutputBytes uint64 `json:"received"`
-	Throughput       uint64 `json:"throughput,omitempty"`
+	TotalInputBytes      uint64 `json:"transferred"`
+	TotalOutputBytes     uint64 `json:"received"`
+	Throughput           uint64 `json:"throughput,omitempty"`
+	InternodeInputBytes  uint64 `json:"internodeInputBytes"`
+	InternodeOutputBytes uint64 `json:"internodeOutputBytes"`
 }
 
 // ServerHTTPMethodStats holds total number of HTTP operations from/to the server,
@@ -202,6 +514,19 @@ type ServerHTTPStats struct {
 	SuccessDELETEStats ServerHTTPMethodStats `json:"successDELETEs"`
 }
 
+// ServerDiskInfo holds space usage and state of a local disk of a
+// server. State is one of madmin.DriveState* values, Healing is set
+// while a heal sequence started on this server formats the disk.
+type ServerDiskInfo struct {
+	Endpoint  string `json:"endpoint"`
+	State     string `json:"state"`
+	Healing   bool   `json:"healing"`
+	Total     uint64 `json:"total"`
+	Used      uint64 `json:"used"`
+	Available uint64 `json:"available"`
+	Error     string `json:"error,omitempty"`
+}
+
 // ServerInfoData holds storage, connections and other
 // information of a given server.
 type ServerInfoData struct {
@@ -209,6 +534,7 @@ type ServerInfoData struct {
 	ConnStats   ServerConnStats  `json:"network"`
 	HTTPStats   ServerHTTPStats  `json:"http"`
 	Properties  ServerProperties `json:"server"`
+	Disks       []ServerDiskInfo `json:"disks"`
 }
 
 // ServerInfo holds server information result of one node
@@ -218,6 +544,21 @@ type ServerInfo struct {
 	Data  *ServerInfoData `json:"data"`
 }
 
+// KMSKeyStatus holds the result of generating and decrypting a
+// data key with a KMS master key.
+type KMSKeyStatus struct {
+	KeyID         string `json:"keyID"`
+	EncryptionErr string `json:"encryptionError,omitempty"`
+	DecryptionErr string `json:"decryptionError,omitempty"`
+}
+
+// ServerKMSKeyStatus holds KMS key status result of one node
+type ServerKMSKeyStatus struct {
+	Error string        `json:"error"`
+	Addr  string        `json:"addr"`
+	Data  *KMSKeyStatus `json:"data"`
+}
+
 // ServerInfoHandler - GET /minio/admin/v1/info
 // ----------
 // Get server information
@@ -232,12 +573,29 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Web service response
-	reply := make([]ServerInfo, len(globalAdminPeers))
+	reply := getPeersServerInfo(globalAdminPeers)
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	// Reply with storage information (across nodes in a
+	// distributed setup) as json.
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// getPeersServerInfo - gathers server information of all nodes.
+func getPeersServerInfo(peers adminPeers) []ServerInfo {
+	reply := make([]ServerInfo, len(peers))
 
 	var wg sync.WaitGroup
 
 	// Gather server information for all nodes
-	for i, p := range globalAdminPeers {
+	for i, p := range peers {
 		wg.Add(1)
 
 		// Gather information from a peer in a goroutine
@@ -261,87 +619,2326 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	wg.Wait()
+	return reply
+}
 
-	// Marshal API response
-	jsonBytes, err := json.Marshal(reply)
+// ClusterHealthHandler - GET /minio/admin/v1/health
+// ----------
+// Polls all nodes for their disks and replies whether the cluster is
+// healthy, degraded, read-only or unavailable, along with the health
+// of each server and erasure set.
+func (a adminAPIHandlers) ClusterHealthHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// FS and single disk setups have a single set.
+	drivesPerSet := globalXLSetDriveCount
+	if drivesPerSet == 0 {
+		drivesPerSet = len(globalEndpoints)
+	}
+	readQuorum, writeQuorum := getClusterQuorums(drivesPerSet)
+	health := getClusterHealth(globalEndpoints, drivesPerSet, readQuorum, writeQuorum,
+		getPeersServerInfo(globalAdminPeers))
+
+	jsonBytes, err := json.Marshal(health)
 	if err != nil {
 		writeErrorResponseJSON(w, ErrInternalError, r.URL)
 		logger.LogIf(context.Background(), err)
 		return
 	}
 
-	// Reply with storage information (across nodes in a
-	// distributed setup) as json.
 	writeSuccessResponseJSON(w, jsonBytes)
 }
 
-// extractHealInitParams - Validates params for heal init API.
-func extractHealInitParams(r *http.Request) (bucket, objPrefix string,
-	hs madmin.HealOpts, clientToken string, forceStart bool,
-	err APIErrorCode) {
-
-	vars := mux.Vars(r)
-	bucket = vars[string(mgmtBucket)]
-	objPrefix = vars[string(mgmtPrefix)]
-
-	if bucket == "" {
-		if objPrefix != "" {
-			// Bucket is required if object-prefix is given
-			err = ErrHealMissingBucket
-			return
-		}
-	} else if !IsValidBucketName(bucket) {
-		err = ErrInvalidBucketName
+// BandwidthInfoHandler - GET /minio/admin/v1/bandwidth
+// ----------
+// Get per bucket bandwidth aggregated across all nodes
+func (a adminAPIHandlers) BandwidthInfoHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// empty prefix is valid.
-	if !IsValidObjectPrefix(objPrefix) {
-		err = ErrInvalidObjectName
-		return
-	}
+	servers := make([]ServerBandwidth, len(globalAdminPeers))
 
-	qParms := r.URL.Query()
-	if len(qParms[string(mgmtClientToken)]) > 0 {
-		clientToken = qParms[string(mgmtClientToken)][0]
-	}
-	if _, ok := qParms[string(mgmtForceStart)]; ok {
-		forceStart = true
+	var wg sync.WaitGroup
+
+	// Gather bandwidth information for all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		// Gather information from a peer in a goroutine
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			// Initialize bandwidth info at index
+			servers[idx] = ServerBandwidth{Addr: peer.addr}
+
+			bandwidthData, err := peer.cmdRunner.BandwidthInfo()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				servers[idx].Error = err.Error()
+				return
+			}
+
+			servers[idx].Data = &bandwidthData
+		}(i, p)
 	}
 
-	// ignore body if clientToken is provided
-	if clientToken == "" {
-		jerr := json.NewDecoder(r.Body).Decode(&hs)
-		if jerr != nil {
-			logger.LogIf(context.Background(), jerr)
-			err = ErrRequestBodyParse
+	wg.Wait()
+
+	reply := ClusterBandwidthInfo{
+		Buckets: aggregateBucketBandwidth(servers),
+		Servers: servers,
+	}
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	// Reply with per bucket bandwidth (across nodes in a
+	// distributed setup) as json.
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// TopAPIHandler - GET /minio/admin/v1/top/api?count=<number-of-apis>
+// ----------
+// Get per S3 API call statistics aggregated across all nodes, busiest
+// APIs of the last minute first. If count is set only the count
+// busiest APIs are returned in the aggregated statistics.
+func (a adminAPIHandlers) TopAPIHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	count := 0
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		var err error
+		if count, err = strconv.Atoi(countStr); err != nil || count <= 0 {
+			writeErrorResponseJSON(w, ErrInvalidQueryParams, r.URL)
 			return
 		}
 	}
 
-	err = ErrNone
-	return
+	servers := make([]ServerAPIStats, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Gather API call statistics of all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			servers[idx] = ServerAPIStats{Addr: peer.addr}
+
+			apiStats, err := peer.cmdRunner.TopAPI()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				servers[idx].Error = err.Error()
+				return
+			}
+
+			servers[idx].Data = apiStats
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	reply := ClusterAPIStats{
+		APIs:    aggregateAPIStats(servers, count),
+		Servers: servers,
+	}
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// RuntimeInfoHandler - GET /minio/admin/v1/runtime
+// ----------
+// Get Go runtime metrics of all nodes, i.e. goroutines, heap in use,
+// garbage collection pauses and open file descriptors, along with
+// their totals across all nodes.
+func (a adminAPIHandlers) RuntimeInfoHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	servers := make([]ServerRuntimeInfo, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Gather runtime metrics of all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			servers[idx] = ServerRuntimeInfo{Addr: peer.addr}
+
+			runtimeData, err := peer.cmdRunner.RuntimeInfo()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				servers[idx].Error = err.Error()
+				return
+			}
+
+			servers[idx].Data = &runtimeData
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(aggregateRuntimeInfo(servers))
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ListSessionsHandler - GET /minio/admin/v1/sessions
+// ----------
+// Lists the S3 API calls in progress on all nodes, with the remote host,
+// access key and API of each call, along with the clients having the
+// most calls in progress across all nodes.
+func (a adminAPIHandlers) ListSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	servers := make([]ServerSessions, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Gather S3 API calls in progress of all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			servers[idx] = ServerSessions{Addr: peer.addr}
+
+			sessions, err := peer.cmdRunner.ListSessions()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				servers[idx].Error = err.Error()
+				return
+			}
+
+			servers[idx].Data = sessions
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	reply := ClusterSessions{
+		Clients: aggregateSessions(servers),
+		Servers: servers,
+	}
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// MaintenanceHandler - POST /minio/admin/v1/maintenance?node=<host:port>&action=enter&drain=<duration>
+// POST /minio/admin/v1/maintenance?node=<host:port>&action=exit
+// ----------
+// Puts a single node in maintenance or makes it leave maintenance. A
+// node entering maintenance rejects new S3 calls, fails its readiness
+// probe and waits for calls in progress to complete, up to drain or
+// one minute, before the reply is sent. The reply holds the number of
+// calls still in progress. Other nodes keep serving S3 calls.
+func (a adminAPIHandlers) MaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	vars := r.URL.Query()
+	var peer *adminPeer
+	for i := range globalAdminPeers {
+		if globalAdminPeers[i].addr == vars.Get("node") {
+			peer = &globalAdminPeers[i]
+		}
+	}
+	drainTimeout, err := parseMaintenanceDrainTimeout(vars.Get("drain"))
+	if peer == nil || err != nil {
+		writeErrorResponseJSON(w, ErrAdminInvalidMaintenance, r.URL)
+		return
+	}
+
+	status := MaintenanceStatus{Addr: peer.addr}
+	switch vars.Get("action") {
+	case "enter":
+		status.InMaintenance = true
+		status.InFlight, err = peer.cmdRunner.MaintenanceEnter(drainTimeout)
+	case "exit":
+		err = peer.cmdRunner.MaintenanceExit()
+	default:
+		writeErrorResponseJSON(w, ErrAdminInvalidMaintenance, r.URL)
+		return
+	}
+	if err != nil {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+		ctx := logger.SetReqInfo(context.Background(), reqInfo)
+		logger.LogIf(ctx, err)
+		writeCustomErrorResponseJSON(w, ErrInternalError, err.Error(), r.URL)
+		return
+	}
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// KMSKeyStatusHandler - GET /minio/admin/v1/kms/key/status?key-id=<master-key-id>
+// ----------
+// Verifies on all nodes that the configured KMS can generate and
+// decrypt a data key with the given master key. If no key-id is
+// provided the default master key of each node is used.
+func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	keyID := r.URL.Query().Get("key-id")
+
+	reply := make([]ServerKMSKeyStatus, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Check KMS key status on all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			// Initialize KMS key status at index
+			reply[idx] = ServerKMSKeyStatus{Addr: peer.addr}
+
+			keyStatus, err := peer.cmdRunner.KMSKeyStatus(keyID)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reply[idx].Error = err.Error()
+				return
+			}
+
+			reply[idx].Data = &keyStatus
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// HealthInfoHandler - GET /minio/admin/v1/healthinfo
+// ----------
+// Runs health diagnostics on all nodes and replies with a zip
+// archive holding the diagnostics of each node.
+func (a adminAPIHandlers) HealthInfoHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	healthInfo := make([]ServerHealthInfo, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Gather health diagnostics from all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			// Initialize health info at index
+			healthInfo[idx] = ServerHealthInfo{Addr: peer.addr}
+
+			healthInfoData, err := peer.cmdRunner.HealthInfo()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				healthInfo[idx].Error = err.Error()
+				return
+			}
+
+			healthInfo[idx].Data = &healthInfoData
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	archive, err := newHealthInfoArchive(healthInfo)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "attachment; filename=\"health-info.zip\"")
+	writeResponse(w, http.StatusOK, archive, mimeZip)
+}
+
+// NetPerfHandler - GET /minio/admin/v1/netperf?size=<payload-size-in-bytes>
+// ----------
+// Measures latency and throughput of the links between all pairs of
+// nodes. Nodes run their tests one after another so that the result
+// of a link is not skewed by the traffic of other tests.
+func (a adminAPIHandlers) NetPerfHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	size := healthInfoNetPayloadSize
+	if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+		var err error
+		if size, err = strconv.ParseInt(sizeStr, 10, 64); err != nil || size <= 0 || size > netPerfMaxPayloadSize {
+			writeErrorResponseJSON(w, ErrAdminInvalidPayloadSize, r.URL)
+			return
+		}
+	}
+
+	reply := make([]ServerNetPerf, len(globalAdminPeers))
+	for i, peer := range globalAdminPeers {
+		reply[i] = ServerNetPerf{Addr: peer.addr}
+
+		netPerf, err := peer.cmdRunner.NetPerf(size)
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+			ctx := logger.SetReqInfo(context.Background(), reqInfo)
+			logger.LogIf(ctx, err)
+			reply[i].Error = err.Error()
+			continue
+		}
+
+		reply[i].Data = netPerf
+	}
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// DrivePerfHandler - GET /minio/admin/v1/driveperf?size=<test-file-size-in-bytes>
+// ----------
+// Runs sequential and random read/write tests on the local drives of
+// all nodes in parallel. Tests only use the temporary area of drives.
+func (a adminAPIHandlers) DrivePerfHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	size := healthInfoDrivePayloadSize
+	if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+		var err error
+		if size, err = strconv.ParseInt(sizeStr, 10, 64); err != nil || size < drivePerfBlockSize || size > drivePerfMaxFileSize {
+			writeErrorResponseJSON(w, ErrAdminInvalidPayloadSize, r.URL)
+			return
+		}
+	}
+
+	reply := make([]ServerDrivesPerf, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Run drive tests on all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			reply[idx] = ServerDrivesPerf{Addr: peer.addr}
+
+			drivesPerf, err := peer.cmdRunner.DrivePerf(size)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				reply[idx].Error = err.Error()
+				return
+			}
+
+			reply[idx].Data = drivesPerf
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// InspectHandler - GET /minio/admin/v1/inspect?bucket=<bucket-name>&object=<object-name>
+// ----------
+// Collects the raw xl.json and the part layout of an object from every
+// drive of all nodes and returns them as an encrypted zip archive, to
+// debug quorum and corruption issues without shell access to servers.
+func (a adminAPIHandlers) InspectHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Erasure metadata only exists in XL mode.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	object := r.URL.Query().Get("object")
+	if !IsValidBucketName(bucket) {
+		writeErrorResponseJSON(w, ErrInvalidBucketName, r.URL)
+		return
+	}
+	if !IsValidObjectName(object) {
+		writeErrorResponseJSON(w, ErrInvalidObjectName, r.URL)
+		return
+	}
+
+	inspectData := make([]ServerInspectData, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Collect erasure metadata from all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			inspectData[idx] = ServerInspectData{Addr: peer.addr}
+
+			drivesData, err := peer.cmdRunner.Inspect(bucket, object)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				inspectData[idx].Error = err.Error()
+				return
+			}
+
+			inspectData[idx].Data = drivesData
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	archive, err := newInspectArchive(inspectData)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "attachment; filename=\"inspect.zip.enc\"")
+	writeResponse(w, http.StatusOK, archive, mimeNone)
+}
+
+// extractHealInitParams - Validates params for heal init API.
+func extractHealInitParams(r *http.Request) (bucket, objPrefix string,
+	hs madmin.HealOpts, clientToken string, forceStart bool,
+	err APIErrorCode) {
+
+	vars := mux.Vars(r)
+	bucket = vars[string(mgmtBucket)]
+	objPrefix = vars[string(mgmtPrefix)]
+
+	if bucket == "" {
+		if objPrefix != "" {
+			// Bucket is required if object-prefix is given
+			err = ErrHealMissingBucket
+			return
+		}
+	} else if !IsValidBucketName(bucket) {
+		err = ErrInvalidBucketName
+		return
+	}
+
+	// empty prefix is valid.
+	if !IsValidObjectPrefix(objPrefix) {
+		err = ErrInvalidObjectName
+		return
+	}
+
+	qParms := r.URL.Query()
+	if len(qParms[string(mgmtClientToken)]) > 0 {
+		clientToken = qParms[string(mgmtClientToken)][0]
+	}
+	if _, ok := qParms[string(mgmtForceStart)]; ok {
+		forceStart = true
+	}
+
+	// ignore body if clientToken is provided
+	if clientToken == "" {
+		jerr := json.NewDecoder(r.Body).Decode(&hs)
+		if jerr != nil {
+			logger.LogIf(context.Background(), jerr)
+			err = ErrRequestBodyParse
+			return
+		}
+	}
+
+	err = ErrNone
+	return
+}
+
+// HealHandler - POST /minio/admin/v1/heal/
+// -----------
+// Start heal processing and return heal status items.
+//
+// On a successful heal sequence start, a unique client token is
+// returned. Subsequent requests to this endpoint providing the client
+// token will receive heal status records from the running heal
+// sequence.
+//
+// If no client token is provided, and a heal sequence is in progress
+// an error is returned with information about the running heal
+// sequence. However, if the force-start flag is provided, the server
+// aborts the running heal sequence and starts a new one.
+func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Heal")
+
+	// Get object layer instance.
+	objLayer := newObjectLayerFn()
+	if objLayer == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsXL {
+		writeErrorResponseJSON(w, ErrHealNotImplemented, r.URL)
+		return
+	}
+
+	bucket, objPrefix, hs, clientToken, forceStart, apiErr := extractHealInitParams(r)
+	if apiErr != ErrNone {
+		writeErrorResponseJSON(w, apiErr, r.URL)
+		return
+	}
+
+	type healResp struct {
+		respBytes []byte
+		errCode   APIErrorCode
+		errBody   string
+	}
+
+	// Define a closure to start sending whitespace to client
+	// after 10s unless a response item comes in
+	keepConnLive := func(w http.ResponseWriter, respCh chan healResp) {
+		ticker := time.NewTicker(time.Second * 10)
+		defer ticker.Stop()
+		started := false
+	forLoop:
+		for {
+			select {
+			case <-ticker.C:
+				if !started {
+					// Start writing response to client
+					started = true
+					setCommonHeaders(w)
+					w.Header().Set("Content-Type", string(mimeJSON))
+					// Set 200 OK status
+					w.WriteHeader(200)
+				}
+				// Send whitespace and keep connection open
+				w.Write([]byte("\n\r"))
+				w.(http.Flusher).Flush()
+			case hr := <-respCh:
+				switch {
+				case hr.errCode == ErrNone:
+					writeSuccessResponseJSON(w, hr.respBytes)
+				case hr.errBody == "":
+					writeErrorResponseJSON(w, hr.errCode, r.URL)
+				default:
+					writeCustomErrorResponseJSON(w, hr.errCode, hr.errBody, r.URL)
+				}
+				break forLoop
+			}
+		}
+	}
+
+	// find number of disks in the setup
+	info := objLayer.StorageInfo(ctx)
+	numDisks := info.Backend.OfflineDisks + info.Backend.OnlineDisks
+
+	if clientToken == "" {
+		// Not a status request
+		nh := newHealSequence(bucket, objPrefix, handlers.GetSourceIP(r),
+			numDisks, hs, forceStart)
+
+		respCh := make(chan healResp)
+		go func() {
+			respBytes, errCode, errMsg := globalAllHealState.LaunchNewHealSequence(nh)
+			hr := healResp{respBytes, errCode, errMsg}
+			respCh <- hr
+		}()
+
+		// Due to the force-starting functionality, the Launch
+		// call above can take a long time - to keep the
+		// connection alive, we start sending whitespace
+		keepConnLive(w, respCh)
+	} else {
+		// Since clientToken is given, fetch heal status from running
+		// heal sequence.
+		path := bucket + "/" + objPrefix
+		respBytes, errCode := globalAllHealState.PopHealStatusJSON(
+			path, clientToken)
+		if errCode != ErrNone {
+			writeErrorResponseJSON(w, errCode, r.URL)
+		} else {
+			writeSuccessResponseJSON(w, respBytes)
+		}
+	}
+}
+
+// GetConfigHandler - GET /minio/admin/v1/config
+// Get config.json of this minio setup.
+func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Take a read lock on minio/config.json. NB minio is a
+	// reserved bucket name and wouldn't conflict with normal
+	// object operations.
+	configLock := globalNSMutex.NewNSLock(minioReservedBucket, minioConfigFile)
+	if configLock.GetRLock(globalObjectTimeout) != nil {
+		writeErrorResponseJSON(w, ErrOperationTimedOut, r.URL)
+		return
+	}
+	defer configLock.RUnlock()
+
+	// Get config.json - in distributed mode, the configuration
+	// occurring on a quorum of the servers is returned.
+	configBytes, err := getPeerConfig(globalAdminPeers)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, configBytes)
+}
+
+// toAdminAPIErrCode - converts errXLWriteQuorum error to admin API
+// specific error.
+func toAdminAPIErrCode(err error) APIErrorCode {
+	switch err {
+	case errXLWriteQuorum:
+		return ErrAdminConfigNoQuorum
+	}
+	return toAPIErrorCode(err)
+}
+
+// SetConfigResult - represents detailed results of a set-config
+// operation.
+type nodeSummary struct {
+	Name           string                  `json:"name"`
+	ErrSet         bool                    `json:"errSet"`
+	ErrMsg         string                  `json:"errMsg"`
+	ValidationErrs []ConfigValidationError `json:"validationErrors,omitempty"`
+}
+
+type setConfigResult struct {
+	NodeResults []nodeSummary `json:"nodeResults"`
+	Status      bool          `json:"status"`
+}
+
+// writeSetConfigResponse - writes setConfigResult value as json
+// depending on the status.
+func writeSetConfigResponse(w http.ResponseWriter, peers adminPeers,
+	errs []error, status bool, reqURL *url.URL) {
+
+	var nodeResults []nodeSummary
+	// Build nodeResults based on error values received during
+	// set-config operation.
+	for i := range errs {
+		nodeResults = append(nodeResults, nodeSummary{
+			Name:   peers[i].addr,
+			ErrSet: errs[i] != nil,
+			ErrMsg: fmt.Sprintf("%v", errs[i]),
+		})
+
+	}
+
+	writeSetConfigResult(w, setConfigResult{
+		Status:      status,
+		NodeResults: nodeResults,
+	}, reqURL)
+}
+
+// writeConfigValidationResponse - writes the errors of nodes which can't
+// use the candidate config as a failed setConfigResult.
+func writeConfigValidationResponse(w http.ResponseWriter, peers adminPeers,
+	validationErrs [][]ConfigValidationError, reqURL *url.URL) {
+
+	var nodeResults []nodeSummary
+	for i := range validationErrs {
+		nodeResult := nodeSummary{
+			Name:   peers[i].addr,
+			ErrMsg: "<nil>",
+		}
+		if len(validationErrs[i]) > 0 {
+			nodeResult.ErrSet = true
+			nodeResult.ErrMsg = "config validation failed"
+			nodeResult.ValidationErrs = validationErrs[i]
+		}
+		nodeResults = append(nodeResults, nodeResult)
+	}
+
+	writeSetConfigResult(w, setConfigResult{
+		Status:      false,
+		NodeResults: nodeResults,
+	}, reqURL)
+}
+
+// writeSetConfigResult - writes setConfigResult value as json.
+func writeSetConfigResult(w http.ResponseWriter, result setConfigResult, reqURL *url.URL) {
+	// The following elaborate json encoding is to avoid escaping
+	// '<', '>' in <nil>. Note: json.Encoder.Encode() adds a
+	// gratuitous "\n".
+	var resultBuf bytes.Buffer
+	enc := json.NewEncoder(&resultBuf)
+	enc.SetEscapeHTML(false)
+	jsonErr := enc.Encode(result)
+	if jsonErr != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(jsonErr), reqURL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, resultBuf.Bytes())
+	return
+}
+
+// SetConfigHandler - PUT /minio/admin/v1/config
+func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := context.Background()
+	// Get current object layer instance.
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		writeErrorResponseJSON(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Read configuration bytes from request body.
+	configBuf := make([]byte, maxConfigJSONSize+1)
+	n, err := io.ReadFull(r.Body, configBuf)
+	if err == nil {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(w, ErrAdminConfigTooLarge, r.URL)
+		return
+	}
+	if err != io.ErrUnexpectedEOF {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	setServerConfig(ctx, w, r, configBuf[:n])
+}
+
+// setServerConfig - validates configBytes then writes them to
+// config.json of all nodes and restarts them.
+func setServerConfig(ctx context.Context, w http.ResponseWriter, r *http.Request, configBytes []byte) {
+	// Validate JSON provided in the request body: check the
+	// client has not sent JSON objects with duplicate keys.
+	if err := quick.CheckDuplicateKeys(string(configBytes)); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrAdminConfigBadJSON, r.URL)
+		return
+	}
+
+	var config serverConfig
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		logger.LogIf(ctx, err)
+		writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL)
+		return
+	}
+
+	// If credentials for the server are provided via environment,
+	// then credentials in the provided configuration must match.
+	if globalIsEnvCreds {
+		creds := globalServerConfig.GetCredential()
+		if config.Credential.AccessKey != creds.AccessKey ||
+			config.Credential.SecretKey != creds.SecretKey {
+			writeErrorResponseJSON(w, ErrAdminCredentialsMismatch, r.URL)
+			return
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL)
+		return
+	}
+
+	// Make sure all nodes can use the config before it is written,
+	// nodes which can't be reached fail writing it below.
+	validationErrs, _ := validateConfigPeers(globalAdminPeers, configBytes)
+	for _, nodeErrs := range validationErrs {
+		if len(nodeErrs) > 0 {
+			writeConfigValidationResponse(w, globalAdminPeers, validationErrs, r.URL)
+			return
+		}
+	}
+
+	// Write config received from request onto a temporary file on
+	// all nodes.
+	tmpFileName := fmt.Sprintf(minioConfigTmpFormat, mustGetUUID())
+	errs := writeTmpConfigPeers(globalAdminPeers, tmpFileName, configBytes)
+
+	// Check if the operation succeeded in quorum or more nodes.
+	rErr := reduceWriteQuorumErrs(ctx, errs, nil, len(globalAdminPeers)/2+1)
+	if rErr != nil {
+		writeSetConfigResponse(w, globalAdminPeers, errs, false, r.URL)
+		return
+	}
+
+	// Take a lock on minio/config.json. NB minio is a reserved
+	// bucket name and wouldn't conflict with normal object
+	// operations.
+	configLock := globalNSMutex.NewNSLock(minioReservedBucket, minioConfigFile)
+	if configLock.GetLock(globalObjectTimeout) != nil {
+		writeErrorResponseJSON(w, ErrOperationTimedOut, r.URL)
+		return
+	}
+	defer configLock.Unlock()
+
+	// Rename the temporary config file to config.json
+	errs = commitConfigPeers(globalAdminPeers, tmpFileName)
+	rErr = reduceWriteQuorumErrs(ctx, errs, nil, len(globalAdminPeers)/2+1)
+	if rErr != nil {
+		writeSetConfigResponse(w, globalAdminPeers, errs, false, r.URL)
+		return
+	}
+
+	// serverMux (cmd/server-mux.go) implements graceful shutdown,
+	// where all listeners are closed and process restart/shutdown
+	// happens after 5s or completion of all ongoing http
+	// requests, whichever is earlier.
+	writeSetConfigResponse(w, globalAdminPeers, errs, true, r.URL)
+
+	// Restart all node for the modified config to take effect.
+	sendServiceCmd(globalAdminPeers, serviceRestart)
+}
+
+// ExportConfigHandler - GET /minio/admin/v1/config/export?format=<kv|yaml>
+// ----------
+// Get config.json of this minio setup as flat key=value lines, the
+// default, or as YAML.
+func (a adminAPIHandlers) ExportConfigHandler(w http.ResponseWriter, r *http.Request) {
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = configFormatKV
+	}
+	if format != configFormatKV && format != configFormatYAML {
+		writeErrorResponseJSON(w, ErrAdminInvalidConfigFormat, r.URL)
+		return
+	}
+
+	// Take a read lock on minio/config.json.
+	configLock := globalNSMutex.NewNSLock(minioReservedBucket, minioConfigFile)
+	if configLock.GetRLock(globalObjectTimeout) != nil {
+		writeErrorResponseJSON(w, ErrOperationTimedOut, r.URL)
+		return
+	}
+	defer configLock.RUnlock()
+
+	configBytes, err := getPeerConfig(globalAdminPeers)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	data, err := exportConfig(configBytes, format)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		writeCustomErrorResponseJSON(w, ErrInternalError, err.Error(), r.URL)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, data, mimeText)
+}
+
+// ImportConfigHandler - PUT /minio/admin/v1/config/import?format=<kv|yaml>
+// ----------
+// Set config.json of all servers from key=value lines, the default, or
+// from YAML as exported by ExportConfigHandler. Unknown keys are
+// rejected, then the config is applied like SetConfigHandler does.
+func (a adminAPIHandlers) ImportConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	// Get current object layer instance.
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		writeErrorResponseJSON(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = configFormatKV
+	}
+	if format != configFormatKV && format != configFormatYAML {
+		writeErrorResponseJSON(w, ErrAdminInvalidConfigFormat, r.URL)
+		return
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxConfigJSONSize+1))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	if len(data) > maxConfigJSONSize {
+		writeErrorResponseJSON(w, ErrAdminConfigTooLarge, r.URL)
+		return
+	}
+
+	configBytes, err := importConfig(data, format)
+	if err != nil {
+		writeCustomErrorResponseJSON(w, ErrAdminConfigBadFormat, err.Error(), r.URL)
+		return
+	}
+
+	setServerConfig(ctx, w, r, configBytes)
+}
+
+// ConfigCredsHandler - POST /minio/admin/v1/config/credential
+// ----------
+// Update credentials in a minio server. In a distributed setup,
+// update all the servers in the cluster.
+func (a adminAPIHandlers) UpdateCredentialsHandler(w http.ResponseWriter,
+	r *http.Request) {
+
+	// Get current object layer instance.
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Authenticate request
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Avoid setting new credentials when they are already passed
+	// by the environment. Deny if WORM is enabled.
+	if globalIsEnvCreds || globalWORMEnabled {
+		writeErrorResponseJSON(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	// Decode request body
+	var req madmin.SetCredsReq
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+
+	creds, err := auth.CreateCredentials(req.AccessKey, req.SecretKey)
+	if err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Take a lock on minio/config.json. Prevents concurrent
+	// config file updates.
+	configLock := globalNSMutex.NewNSLock(minioReservedBucket, minioConfigFile)
+	if configLock.GetLock(globalObjectTimeout) != nil {
+		writeErrorResponseJSON(w, ErrOperationTimedOut, r.URL)
+		return
+	}
+	defer configLock.Unlock()
+
+	// Configuration data holding credentials is encrypted with a key
+	// derived from the server credentials, re-encrypt it with the new
+	// credentials before switching to them.
+	ctx := context.Background()
+	err = reencryptConfigs(ctx, objectAPI, globalServerConfig.GetCredential().SecretKey, creds.SecretKey, func() error {
+		// Acquire lock before updating global configuration.
+		globalServerConfigMu.Lock()
+		defer globalServerConfigMu.Unlock()
+
+		// Update local credentials in memory.
+		prevCred := globalServerConfig.SetCredential(creds)
+		if err := globalServerConfig.Save(getConfigFile()); err != nil {
+			globalServerConfig.SetCredential(prevCred)
+			return err
+		}
+
+		// Notify all other Minio peers to update credentials
+		for host, err := range globalNotificationSys.SetCredentials(creds) {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", host.String())
+			ctx := logger.SetReqInfo(context.Background(), reqInfo)
+			logger.LogIf(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		return
+	}
+
+	// At this stage, the operation is successful, return 200 OK
+	w.WriteHeader(http.StatusOK)
+}
+
+// AddServiceAccountHandler - POST /minio/admin/v1/service-accounts
+// Body: {"parent": <parent-user>, "policy": <inline-policy>}
+// ----------
+// Creates a new service account derived from the parent user and
+// returns its credentials. In a distributed setup, all the servers in
+// the cluster are notified to load the new service account.
+func (a adminAPIHandlers) AddServiceAccountHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddServiceAccount")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalIAMSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		writeErrorResponseJSON(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	var req madmin.AddServiceAccountReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+
+	// Service accounts inherit from the server credential
+	// when no parent user is specified.
+	if req.Parent == "" {
+		req.Parent = globalServerConfig.GetCredential().AccessKey
+	}
+
+	var sessionPolicy *policy.Policy
+	if len(req.Policy) > 0 {
+		sessionPolicy = &policy.Policy{}
+		if err := json.Unmarshal(req.Policy, sessionPolicy); err != nil || sessionPolicy.IsEmpty() {
+			writeErrorResponseJSON(w, ErrMalformedPolicy, r.URL)
+			return
+		}
+	}
+
+	cred, err := globalIAMSys.NewServiceAccount(objectAPI, req.Parent, sessionPolicy)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	// Notify all other Minio peers to reload service accounts
+	globalNotificationSys.LoadServiceAccounts(ctx)
+
+	jsonBytes, err := json.Marshal(madmin.ServiceAccountCreds{
+		AccessKey: cred.AccessKey,
+		SecretKey: cred.SecretKey,
+	})
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ListServiceAccountsHandler - GET /minio/admin/v1/service-accounts
+// ----------
+// Lists all service accounts along with their parent user and inline
+// policy, secret keys are never returned.
+func (a adminAPIHandlers) ListServiceAccountsHandler(w http.ResponseWriter, r *http.Request) {
+	if globalIAMSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	infos := []madmin.ServiceAccountInfo{}
+	for _, sa := range globalIAMSys.ListServiceAccounts() {
+		info := madmin.ServiceAccountInfo{
+			AccessKey:  sa.Credentials.AccessKey,
+			ParentUser: sa.ParentUser,
+		}
+		if sa.Policy != nil {
+			policyBytes, err := json.Marshal(sa.Policy)
+			if err != nil {
+				writeErrorResponseJSON(w, ErrInternalError, r.URL)
+				logger.LogIf(context.Background(), err)
+				return
+			}
+			info.Policy = policyBytes
+		}
+		infos = append(infos, info)
+	}
+
+	jsonBytes, err := json.Marshal(infos)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// DeleteServiceAccountHandler - DELETE /minio/admin/v1/service-accounts/{accessKey}
+// ----------
+// Deletes a service account. In a distributed setup, all the servers
+// in the cluster are notified to drop the service account.
+func (a adminAPIHandlers) DeleteServiceAccountHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteServiceAccount")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalIAMSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		writeErrorResponseJSON(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	accessKey := mux.Vars(r)["accessKey"]
+	if err := globalIAMSys.DeleteServiceAccount(objectAPI, accessKey); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	// Notify all other Minio peers to reload service accounts
+	globalNotificationSys.LoadServiceAccounts(ctx)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ExportIAMHandler - GET /minio/admin/v1/iam/export
+// ----------
+// Replies with a zip archive of all service accounts along with their
+// secret keys, to be backed up or imported into another cluster.
+func (a adminAPIHandlers) ExportIAMHandler(w http.ResponseWriter, r *http.Request) {
+	if globalIAMSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	archive, err := globalIAMSys.ExportIAM()
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "attachment; filename=\"iam-export.zip\"")
+	writeResponse(w, http.StatusOK, archive, mimeZip)
+}
+
+// ImportIAMHandler - PUT /minio/admin/v1/iam/import
+// Body: <zip archive made by IAM export>
+// ----------
+// Adds the service accounts of the archive, replacing existing ones
+// having the same access key. In a distributed setup, all the servers
+// in the cluster are notified to load the imported service accounts.
+func (a adminAPIHandlers) ImportIAMHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ImportIAM")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalIAMSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		writeErrorResponseJSON(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	archive, err := ioutil.ReadAll(io.LimitReader(r.Body, maxIAMArchiveSize+1))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	if len(archive) > maxIAMArchiveSize {
+		writeErrorResponseJSON(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	if _, err = globalIAMSys.ImportIAM(objectAPI, archive); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	// Notify all other Minio peers to reload service accounts
+	globalNotificationSys.LoadServiceAccounts(ctx)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// PutBucketQuotaConfigHandler - PUT /minio/admin/v1/set-bucket-quota?bucket=<bucket-name>
+// Body: {"quota": <size-in-bytes>, "quotatype": "hard"|"fifo"}
+// ----------
+// Sets the quota of a bucket, a quota of zero removes the quota.
+func (a adminAPIHandlers) PutBucketQuotaConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketQuotaConfig")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalBucketQuotaSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	var quota BucketQuota
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBucketQuotaConfigSize)).Decode(&quota); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+
+	if quota.Quota == 0 {
+		err := removeBucketQuotaConfig(ctx, objectAPI, bucket)
+		if _, ok := err.(BucketQuotaConfigNotFound); err != nil && !ok {
+			writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+			return
+		}
+	} else {
+		if !quota.IsValid() {
+			writeErrorResponseJSON(w, ErrAdminInvalidBucketQuota, r.URL)
+			return
+		}
+		if err := saveBucketQuotaConfig(objectAPI, bucket, quota); err != nil {
+			writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+			return
+		}
+	}
+
+	globalBucketQuotaSys.Set(bucket, quota)
+
+	// Notify all other Minio peers to update the bucket quota
+	globalNotificationSys.SetBucketQuota(ctx, bucket, quota)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketQuotaConfigHandler - GET /minio/admin/v1/get-bucket-quota?bucket=<bucket-name>
+// ----------
+// Returns the quota of a bucket.
+func (a adminAPIHandlers) GetBucketQuotaConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketQuotaConfig")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	quota, err := getBucketQuotaConfig(objectAPI, bucket)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(quota)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// PutBucketAccessHandler - PUT /minio/admin/v1/set-bucket-access?bucket=<bucket-name>&prefix=<prefix>
+// Body: {"access": "none"|"download"|"upload"|"public"}
+// ----------
+// Grants the canned anonymous access to the objects of a bucket under a
+// prefix, by updating the bucket policy with its equivalent statements.
+func (a adminAPIHandlers) PutBucketAccessHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketAccess")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	var access BucketAccess
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBucketPolicySize)).Decode(&access); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+	if !access.IsValid() {
+		writeErrorResponseJSON(w, ErrAdminInvalidBucketAccess, r.URL)
+		return
+	}
+
+	prefix := r.URL.Query().Get("prefix")
+	err := setBucketPolicyType(ctx, objectAPI, bucket, prefix, bucketAccessPolicies[access.Access])
+	if _, ok := err.(BucketPolicyNotFound); err != nil && !ok {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketAccessHandler - GET /minio/admin/v1/get-bucket-access?bucket=<bucket-name>&prefix=<prefix>
+// ----------
+// Returns the canned anonymous access to the objects of a bucket under a
+// prefix granted by the bucket policy.
+func (a adminAPIHandlers) GetBucketAccessHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketAccess")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	access, err := getBucketAccess(ctx, objectAPI, bucket, r.URL.Query().Get("prefix"))
+	if err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(access)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// PutBucketDefaultTagsHandler - PUT /minio/admin/v1/set-bucket-default-tags?bucket=<bucket-name>
+// Body: {"tags": {<key>: <value>, ...}}
+// ----------
+// Sets the tags of the objects uploaded to a bucket without tags, no
+// tags removes the default tags of the bucket.
+func (a adminAPIHandlers) PutBucketDefaultTagsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketDefaultTags")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	if !objectAPI.IsTaggingSupported() {
+		writeErrorResponseJSON(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	var defaultTags BucketDefaultTags
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBucketDefaultTagsConfigSize)).Decode(&defaultTags); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+	if _, err := defaultTags.tagging(); err != nil {
+		writeErrorResponseJSON(w, ErrAdminInvalidBucketDefaultTags, r.URL)
+		return
+	}
+
+	if err := saveBucketDefaultTags(ctx, objectAPI, bucket, defaultTags); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketDefaultTagsHandler - GET /minio/admin/v1/get-bucket-default-tags?bucket=<bucket-name>
+// ----------
+// Returns the tags of the objects uploaded to a bucket without tags.
+func (a adminAPIHandlers) GetBucketDefaultTagsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketDefaultTags")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	defaultTags, ok := getBucketDefaultTagsConfig(bucket)
+	if !ok {
+		defaultTags.Tags = map[string]string{}
+	}
+
+	jsonBytes, err := json.Marshal(defaultTags)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// PutBucketIntegrityHandler - PUT /minio/admin/v1/set-bucket-integrity?bucket=<bucket-name>
+// Body: {"strict": true|false}
+// ----------
+// Sets whether uploads to a bucket must be verified with a Content-MD5
+// or a signed SHA256 sum of their data.
+func (a adminAPIHandlers) PutBucketIntegrityHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketIntegrity")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	var integrity BucketIntegrity
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBucketIntegrityConfigSize)).Decode(&integrity); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+
+	if err := saveBucketIntegrity(ctx, objectAPI, bucket, integrity); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketIntegrityHandler - GET /minio/admin/v1/get-bucket-integrity?bucket=<bucket-name>
+// ----------
+// Returns whether uploads to a bucket must be verified, uploads to all
+// buckets are if the server runs in strict integrity mode.
+func (a adminAPIHandlers) GetBucketIntegrityHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketIntegrity")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(BucketIntegrity{Strict: isStrictIntegrity(bucket)})
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// PutBucketMetadataIndexHandler - PUT /minio/admin/v1/set-bucket-metadata-index?bucket=<bucket-name>
+// Body: {"enabled": true|false}
+// ----------
+// Enables or disables the metadata index of a bucket searched by search
+// requests. An enabled index is built right away and then periodically,
+// a disabled index is removed.
+func (a adminAPIHandlers) PutBucketMetadataIndexHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketMetadataIndex")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	var config BucketMetadataIndex
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBucketMetadataIndexConfigSize)).Decode(&config); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+
+	if err := saveBucketMetadataIndexConfig(ctx, objectAPI, bucket, config); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	if config.Enabled {
+		go func() {
+			ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{BucketName: bucket})
+			if err := buildBucketMetadataIndex(ctx, objectAPI, bucket, globalServiceDoneCh); err != errMetadataIndexScanStopped {
+				logger.LogIf(ctx, err)
+			}
+		}()
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketMetadataIndexHandler - GET /minio/admin/v1/get-bucket-metadata-index?bucket=<bucket-name>
+// ----------
+// Returns whether the metadata index of a bucket is enabled, along with
+// the time it was last built and its number of objects.
+func (a adminAPIHandlers) GetBucketMetadataIndexHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketMetadataIndex")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	config, _ := getBucketMetadataIndexConfig(bucket)
+	status := madmin.BucketMetadataIndexStatus{Enabled: config.Enabled}
+	if index, ok := getBucketMetadataIndex(ctx, objectAPI, bucket); ok {
+		status.Updated = index.Updated
+		status.Objects = len(index.Objects)
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ListBucketPolicyVersionsHandler - GET /minio/admin/v1/list-bucket-policy-versions?bucket=<bucket-name>
+// ----------
+// Returns the prior access policies of a bucket, the most recently
+// replaced first.
+func (a adminAPIHandlers) ListBucketPolicyVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketPolicyVersions")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	versions, err := readBucketPolicyHistory(ctx, objectAPI, bucket)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+	if versions == nil {
+		versions = []BucketPolicyVersion{}
+	}
+
+	jsonBytes, err := json.Marshal(versions)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// RestoreBucketPolicyVersionHandler - POST /minio/admin/v1/restore-bucket-policy-version?bucket=<bucket-name>&versionId=<version-id>
+// ----------
+// Replaces the access policy of a bucket by one of its prior policies,
+// restoring a version without policy removes the policy of the bucket.
+func (a adminAPIHandlers) RestoreBucketPolicyVersionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RestoreBucketPolicyVersion")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err := restoreBucketPolicyVersion(ctx, objectAPI, bucket, r.URL.Query().Get("versionId")); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// SetRemoteTargetHandler - PUT /minio/admin/v1/set-remote-target?bucket=<bucket-name>
+// Body: {"endpoint": <url>, "credentials": {...}, "targetbucket": <bucket-name>, "type": "replication"|"ilm"}
+// ----------
+// Registers a remote target of a bucket and returns its ARN, registering
+// an already known remote bucket updates its credentials.
+func (a adminAPIHandlers) SetRemoteTargetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetRemoteTarget")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalBucketTargetSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	var target BucketTarget
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRemoteTargetSize)).Decode(&target); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+	target.SourceBucket = bucket
+
+	arn, targets, err := setBucketTarget(objectAPI, target)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	globalBucketTargetSys.Set(bucket, targets)
+
+	// Notify all other Minio peers to reload remote targets
+	globalNotificationSys.LoadBucketTargets(ctx, bucket)
+
+	jsonBytes, err := json.Marshal(struct {
+		Arn string `json:"arn"`
+	}{Arn: arn})
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ListRemoteTargetsHandler - GET /minio/admin/v1/list-remote-targets?bucket=<bucket-name>&type=<type>
+// ----------
+// Returns remote targets of a bucket, secret keys are never returned.
+func (a adminAPIHandlers) ListRemoteTargetsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListRemoteTargets")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalBucketTargetSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	targetType := BucketTargetType(r.URL.Query().Get("type"))
+	if targetType != "" && !targetType.IsValid() {
+		writeErrorResponseJSON(w, ErrAdminInvalidRemoteTarget, r.URL)
+		return
+	}
+
+	targets := globalBucketTargetSys.ListTargets(bucket, targetType)
+	for i := range targets {
+		targets[i].Credentials.SecretKey = ""
+	}
+
+	jsonBytes, err := json.Marshal(targets)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// RemoveRemoteTargetHandler - DELETE /minio/admin/v1/remove-remote-target?bucket=<bucket-name>&arn=<arn>
+// ----------
+// Removes a remote target of a bucket.
+func (a adminAPIHandlers) RemoveRemoteTargetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveRemoteTarget")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalBucketTargetSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	targets, err := removeBucketTarget(objectAPI, bucket, r.URL.Query().Get("arn"))
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	globalBucketTargetSys.Set(bucket, targets)
+
+	// Notify all other Minio peers to reload remote targets
+	globalNotificationSys.LoadBucketTargets(ctx, bucket)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// AddTierHandler - PUT /minio/admin/v1/tier
+// Body: {"name": <tier-name>, "type": "s3"|"minio", "endpoint": <url>, "bucket": <bucket-name>, "prefix": <prefix>, "credentials": {...}}
+// ----------
+// Adds a remote storage tier that lifecycle transition rules can move
+// objects to. In a distributed setup, all the servers in the cluster
+// are notified to load the new tier.
+func (a adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddTier")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalTierConfigSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	var tier TierConfig
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxTierConfigSize)).Decode(&tier); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+
+	if err := globalTierConfigSys.AddTier(objectAPI, tier); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	// Notify all other Minio peers to reload tiers
+	globalNotificationSys.LoadTiers(ctx)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ListTiersHandler - GET /minio/admin/v1/tier
+// ----------
+// Lists all tiers, secret keys are never returned.
+func (a adminAPIHandlers) ListTiersHandler(w http.ResponseWriter, r *http.Request) {
+	if globalTierConfigSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	tiers := globalTierConfigSys.ListTiers()
+	for i := range tiers {
+		tiers[i].Credentials.SecretKey = ""
+	}
+
+	jsonBytes, err := json.Marshal(tiers)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// EditTierHandler - POST /minio/admin/v1/tier/{tier}
+// Body: {"accessKey": <access-key>, "secretKey": <secret-key>}
+// ----------
+// Replaces the credentials of a tier. In a distributed setup, all the
+// servers in the cluster are notified to reload tiers.
+func (a adminAPIHandlers) EditTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "EditTier")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalTierConfigSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	var creds auth.Credentials
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxTierConfigSize)).Decode(&creds); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+
+	if err := globalTierConfigSys.EditTier(objectAPI, mux.Vars(r)["tier"], creds); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	// Notify all other Minio peers to reload tiers
+	globalNotificationSys.LoadTiers(ctx)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// StartBatchJobHandler - POST /minio/admin/v1/batch-job/start
+// Body: {"type": "keyrotate"|"replicate"|"expire", "bucket": <bucket-name>, "prefix": <prefix>, ...}
+// ----------
+// Starts a batch job on all servers, each server processes its share of
+// the objects under prefix. The job is persisted so that servers resume
+// it after a restart. SSE-C keys of keyrotate jobs are never returned.
+func (a adminAPIHandlers) StartBatchJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartBatchJob")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalBatchJobSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
+
+	var req BatchJobRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBatchJobRequestSize)).Decode(&req); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+	if _, err := objectAPI.GetBucketInfo(ctx, req.Bucket); err != nil {
+		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	job := BatchJob{
+		ID:      mustGetUUID(),
+		Request: req,
+		Nodes:   make([]string, len(globalAdminPeers)),
+		Started: UTCNow(),
+	}
+	for i, peer := range globalAdminPeers {
+		job.Nodes[i] = peer.addr
+	}
+
+	if err := saveBatchJob(objectAPI, job); err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	info := BatchJobInfo{
+		ID:      job.ID,
+		Request: req.redacted(),
+		Started: job.Started,
+		Servers: make([]ServerBatchJobProgress, len(globalAdminPeers)),
+	}
+
+	var wg sync.WaitGroup
+
+	// Start the job on all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			info.Servers[idx] = ServerBatchJobProgress{Addr: peer.addr}
+
+			if err := peer.cmdRunner.StartBatchJob(job, idx); err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				info.Servers[idx].Error = err.Error()
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
 }
 
-// HealHandler - POST /minio/admin/v1/heal/
-// -----------
-// Start heal processing and return heal status items.
-//
-// On a successful heal sequence start, a unique client token is
-// returned. Subsequent requests to this endpoint providing the client
-// token will receive heal status records from the running heal
-// sequence.
-//
-// If no client token is provided, and a heal sequence is in progress
-// an error is returned with information about the running heal
-// sequence. However, if the force-start flag is provided, the server
-// aborts the running heal sequence and starts a new one.
-func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := newContext(r, w, "Heal")
+// DescribeBatchJobHandler - GET /minio/admin/v1/batch-job/describe?id=<job-id>
+// ----------
+// Returns a batch job along with its progress on each server.
+func (a adminAPIHandlers) DescribeBatchJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DescribeBatchJob")
 
-	// Get object layer instance.
-	objLayer := newObjectLayerFn()
-	if objLayer == nil {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalBatchJobSys == nil {
 		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
 		return
 	}
@@ -353,96 +2950,79 @@ func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if this setup has an erasure coded backend.
-	if !globalIsXL {
-		writeErrorResponseJSON(w, ErrHealNotImplemented, r.URL)
+	jobs, err := readBatchJobs(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
 		return
 	}
-
-	bucket, objPrefix, hs, clientToken, forceStart, apiErr := extractHealInitParams(r)
-	if apiErr != ErrNone {
-		writeErrorResponseJSON(w, apiErr, r.URL)
+	job, ok := jobs[r.URL.Query().Get("id")]
+	if !ok {
+		writeErrorResponseJSON(w, ErrAdminNoSuchBatchJob, r.URL)
 		return
 	}
 
-	type healResp struct {
-		respBytes []byte
-		errCode   APIErrorCode
-		errBody   string
+	info := BatchJobInfo{
+		ID:      job.ID,
+		Request: job.Request.redacted(),
+		Started: job.Started,
+		Servers: make([]ServerBatchJobProgress, len(globalAdminPeers)),
 	}
 
-	// Define a closure to start sending whitespace to client
-	// after 10s unless a response item comes in
-	keepConnLive := func(w http.ResponseWriter, respCh chan healResp) {
-		ticker := time.NewTicker(time.Second * 10)
-		defer ticker.Stop()
-		started := false
-	forLoop:
-		for {
-			select {
-			case <-ticker.C:
-				if !started {
-					// Start writing response to client
-					started = true
-					setCommonHeaders(w)
-					w.Header().Set("Content-Type", string(mimeJSON))
-					// Set 200 OK status
-					w.WriteHeader(200)
-				}
-				// Send whitespace and keep connection open
-				w.Write([]byte("\n\r"))
-				w.(http.Flusher).Flush()
-			case hr := <-respCh:
-				switch {
-				case hr.errCode == ErrNone:
-					writeSuccessResponseJSON(w, hr.respBytes)
-				case hr.errBody == "":
-					writeErrorResponseJSON(w, hr.errCode, r.URL)
-				default:
-					writeCustomErrorResponseJSON(w, hr.errCode, hr.errBody, r.URL)
+	var wg sync.WaitGroup
+
+	// Gather progress of the job from all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			info.Servers[idx] = ServerBatchJobProgress{Addr: peer.addr}
+
+			progress, err := peer.cmdRunner.DescribeBatchJob(job.ID)
+			if err != nil {
+				if err.Error() != errNoSuchBatchJob.Error() {
+					reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+					ctx := logger.SetReqInfo(context.Background(), reqInfo)
+					logger.LogIf(ctx, err)
 				}
-				break forLoop
+				info.Servers[idx].Error = err.Error()
+				return
 			}
-		}
+			info.Servers[idx].Data = &progress
+		}(i, p)
 	}
 
-	// find number of disks in the setup
-	info := objLayer.StorageInfo(ctx)
-	numDisks := info.Backend.OfflineDisks + info.Backend.OnlineDisks
+	wg.Wait()
 
-	if clientToken == "" {
-		// Not a status request
-		nh := newHealSequence(bucket, objPrefix, handlers.GetSourceIP(r),
-			numDisks, hs, forceStart)
+	jsonBytes, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
 
-		respCh := make(chan healResp)
-		go func() {
-			respBytes, errCode, errMsg := globalAllHealState.LaunchNewHealSequence(nh)
-			hr := healResp{respBytes, errCode, errMsg}
-			respCh <- hr
-		}()
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
-		// Due to the force-starting functionality, the Launch
-		// call above can take a long time - to keep the
-		// connection alive, we start sending whitespace
-		keepConnLive(w, respCh)
-	} else {
-		// Since clientToken is given, fetch heal status from running
-		// heal sequence.
-		path := bucket + "/" + objPrefix
-		respBytes, errCode := globalAllHealState.PopHealStatusJSON(
-			path, clientToken)
-		if errCode != ErrNone {
-			writeErrorResponseJSON(w, errCode, r.URL)
-		} else {
-			writeSuccessResponseJSON(w, respBytes)
-		}
-	}
+// CancelBatchJobResult holds the batch job cancellation result of one node.
+type CancelBatchJobResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
 }
 
-// GetConfigHandler - GET /minio/admin/v1/config
-// Get config.json of this minio setup.
-func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Request) {
+// CancelBatchJobHandler - POST /minio/admin/v1/batch-job/cancel?id=<job-id>
+// ----------
+// Stops a batch job on all servers, the progress made so far is kept.
+func (a adminAPIHandlers) CancelBatchJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelBatchJob")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalBatchJobSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
 	// Validate request signature.
 	adminAPIErr := checkAdminRequestAuthType(r, "")
 	if adminAPIErr != ErrNone {
@@ -450,264 +3030,447 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Take a read lock on minio/config.json. NB minio is a
-	// reserved bucket name and wouldn't conflict with normal
-	// object operations.
-	configLock := globalNSMutex.NewNSLock(minioReservedBucket, minioConfigFile)
-	if configLock.GetRLock(globalObjectTimeout) != nil {
-		writeErrorResponseJSON(w, ErrOperationTimedOut, r.URL)
+	jobs, err := readBatchJobs(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+	job, ok := jobs[r.URL.Query().Get("id")]
+	if !ok {
+		writeErrorResponseJSON(w, ErrAdminNoSuchBatchJob, r.URL)
 		return
 	}
-	defer configLock.RUnlock()
 
-	// Get config.json - in distributed mode, the configuration
-	// occurring on a quorum of the servers is returned.
-	configBytes, err := getPeerConfig(globalAdminPeers)
+	results := make([]CancelBatchJobResult, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Cancel the job on all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			results[idx] = CancelBatchJobResult{Addr: peer.addr}
+
+			if err := peer.cmdRunner.CancelBatchJob(job.ID); err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				results[idx].Error = err.Error()
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	jsonBytes, err := json.Marshal(results)
 	if err != nil {
-		logger.LogIf(context.Background(), err)
-		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
 		return
 	}
 
-	writeSuccessResponseJSON(w, configBytes)
+	writeSuccessResponseJSON(w, jsonBytes)
 }
 
-// toAdminAPIErrCode - converts errXLWriteQuorum error to admin API
-// specific error.
-func toAdminAPIErrCode(err error) APIErrorCode {
-	switch err {
-	case errXLWriteQuorum:
-		return ErrAdminConfigNoQuorum
+// ListBatchJobsHandler - GET /minio/admin/v1/batch-job/list
+// ----------
+// Returns all batch jobs along with their progress on each server, most
+// recently started first.
+func (a adminAPIHandlers) ListBatchJobsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBatchJobs")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalBatchJobSys == nil {
+		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
+		return
 	}
-	return toAPIErrorCode(err)
-}
 
-// SetConfigResult - represents detailed results of a set-config
-// operation.
-type nodeSummary struct {
-	Name   string `json:"name"`
-	ErrSet bool   `json:"errSet"`
-	ErrMsg string `json:"errMsg"`
-}
+	// Validate request signature.
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
 
-type setConfigResult struct {
-	NodeResults []nodeSummary `json:"nodeResults"`
-	Status      bool          `json:"status"`
+	jobs, err := readBatchJobs(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(w, toAdminAPIErrCode(err), r.URL)
+		return
+	}
+
+	type peerBatchJobs struct {
+		addr     string
+		err      error
+		progress []BatchJobProgress
+	}
+	peerJobs := make([]peerBatchJobs, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Gather progress of all jobs from all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			peerJobs[idx].addr = peer.addr
+			peerJobs[idx].progress, peerJobs[idx].err = peer.cmdRunner.ListBatchJobs()
+			if peerJobs[idx].err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, peerJobs[idx].err)
+			}
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	infos := make([]BatchJobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		info := BatchJobInfo{
+			ID:      job.ID,
+			Request: job.Request.redacted(),
+			Started: job.Started,
+			Servers: make([]ServerBatchJobProgress, len(peerJobs)),
+		}
+		for i, peer := range peerJobs {
+			info.Servers[i] = ServerBatchJobProgress{Addr: peer.addr}
+			if peer.err != nil {
+				info.Servers[i].Error = peer.err.Error()
+				continue
+			}
+			info.Servers[i].Error = errNoSuchBatchJob.Error()
+			for j := range peer.progress {
+				if peer.progress[j].ID == job.ID {
+					info.Servers[i].Error = ""
+					info.Servers[i].Data = &peer.progress[j]
+					break
+				}
+			}
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Started.After(infos[j].Started)
+	})
+
+	jsonBytes, err := json.Marshal(infos)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
 }
 
-// writeSetConfigResponse - writes setConfigResult value as json
-// depending on the status.
-func writeSetConfigResponse(w http.ResponseWriter, peers adminPeers,
-	errs []error, status bool, reqURL *url.URL) {
+// ListMultipartUploadsHandler - GET /minio/admin/v1/multipart-uploads
+// ----------
+// Lists all in-progress multipart uploads found on the drives of all
+// nodes, oldest first. Uploads found on several nodes are reported once
+// in the aggregated list.
+func (a adminAPIHandlers) ListMultipartUploadsHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
+		return
+	}
 
-	var nodeResults []nodeSummary
-	// Build nodeResults based on error values received during
-	// set-config operation.
-	for i := range errs {
-		nodeResults = append(nodeResults, nodeSummary{
-			Name:   peers[i].addr,
-			ErrSet: errs[i] != nil,
-			ErrMsg: fmt.Sprintf("%v", errs[i]),
-		})
+	servers := make([]ServerMultipartUploads, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Gather multipart uploads of all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
 
-	}
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
 
-	result := setConfigResult{
-		Status:      status,
-		NodeResults: nodeResults,
-	}
+			servers[idx] = ServerMultipartUploads{Addr: peer.addr}
 
-	// The following elaborate json encoding is to avoid escaping
-	// '<', '>' in <nil>. Note: json.Encoder.Encode() adds a
-	// gratuitous "\n".
-	var resultBuf bytes.Buffer
-	enc := json.NewEncoder(&resultBuf)
-	enc.SetEscapeHTML(false)
-	jsonErr := enc.Encode(result)
-	if jsonErr != nil {
-		writeErrorResponseJSON(w, toAPIErrorCode(jsonErr), reqURL)
-		return
-	}
+			uploads, err := peer.cmdRunner.ListMultipartUploads()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				servers[idx].Error = err.Error()
+				return
+			}
 
-	writeSuccessResponseJSON(w, resultBuf.Bytes())
-	return
-}
+			servers[idx].Data = uploads
+		}(i, p)
+	}
 
-// SetConfigHandler - PUT /minio/admin/v1/config
-func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Request) {
+	wg.Wait()
 
-	ctx := context.Background()
-	// Get current object layer instance.
-	objectAPI := newObjectLayerFn()
-	if objectAPI == nil {
-		writeErrorResponseJSON(w, ErrServerNotInitialized, r.URL)
-		return
+	reply := ClusterMultipartUploads{
+		Uploads: aggregateMultipartUploads(servers),
+		Servers: servers,
 	}
 
-	// Deny if WORM is enabled
-	if globalWORMEnabled {
-		writeErrorResponseJSON(w, ErrMethodNotAllowed, r.URL)
+	// Marshal API response
+	jsonBytes, err := json.Marshal(reply)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
 		return
 	}
 
-	// Validate request signature.
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// AbortStaleMultipartUploadsResult holds the number of multipart uploads
+// removed from the drives of one node.
+type AbortStaleMultipartUploadsResult struct {
+	Error   string `json:"error"`
+	Addr    string `json:"addr"`
+	Aborted int    `json:"aborted"`
+}
+
+// AbortStaleMultipartUploadsHandler - POST /minio/admin/v1/multipart-uploads/abort-stale?older-than=<duration>
+// ----------
+// Removes multipart uploads initiated longer than the given duration ago,
+// e.g. "72h", from the drives of all nodes to free the capacity used by
+// their parts. Uploads initiated by older releases are removed if no part
+// was uploaded for that duration.
+func (a adminAPIHandlers) AbortStaleMultipartUploadsHandler(w http.ResponseWriter, r *http.Request) {
 	adminAPIErr := checkAdminRequestAuthType(r, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Read configuration bytes from request body.
-	configBuf := make([]byte, maxConfigJSONSize+1)
-	n, err := io.ReadFull(r.Body, configBuf)
-	if err == nil {
-		// More than maxConfigSize bytes were available
-		writeErrorResponseJSON(w, ErrAdminConfigTooLarge, r.URL)
-		return
-	}
-	if err != io.ErrUnexpectedEOF {
-		logger.LogIf(ctx, err)
-		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+	olderThan, err := time.ParseDuration(r.URL.Query().Get("older-than"))
+	if err != nil || olderThan <= 0 {
+		writeErrorResponseJSON(w, ErrInvalidDuration, r.URL)
 		return
 	}
 
-	configBytes := configBuf[:n]
+	results := make([]AbortStaleMultipartUploadsResult, len(globalAdminPeers))
 
-	// Validate JSON provided in the request body: check the
-	// client has not sent JSON objects with duplicate keys.
-	if err = quick.CheckDuplicateKeys(string(configBytes)); err != nil {
-		logger.LogIf(ctx, err)
-		writeErrorResponseJSON(w, ErrAdminConfigBadJSON, r.URL)
-		return
+	var wg sync.WaitGroup
+
+	// Abort stale uploads on all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			results[idx] = AbortStaleMultipartUploadsResult{Addr: peer.addr}
+
+			aborted, err := peer.cmdRunner.AbortStaleMultipartUploads(olderThan)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				results[idx].Error = err.Error()
+				return
+			}
+
+			results[idx].Aborted = aborted
+		}(i, p)
 	}
 
-	var config serverConfig
-	err = json.Unmarshal(configBytes, &config)
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(results)
 	if err != nil {
-		logger.LogIf(ctx, err)
-		writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL)
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
 		return
 	}
 
-	// If credentials for the server are provided via environment,
-	// then credentials in the provided configuration must match.
-	if globalIsEnvCreds {
-		creds := globalServerConfig.GetCredential()
-		if config.Credential.AccessKey != creds.AccessKey ||
-			config.Credential.SecretKey != creds.SecretKey {
-			writeErrorResponseJSON(w, ErrAdminCredentialsMismatch, r.URL)
-			return
-		}
-	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
-	if err := config.Validate(); err != nil {
-		writeCustomErrorResponseJSON(w, ErrAdminConfigBadJSON, err.Error(), r.URL)
+// ServerFailedEvents holds the events one node failed to deliver.
+type ServerFailedEvents struct {
+	Error string        `json:"error"`
+	Addr  string        `json:"addr"`
+	Data  []FailedEvent `json:"data"`
+}
+
+// ListFailedEventsHandler - GET /minio/admin/v1/failed-events
+// ----------
+// Lists the bucket notification events each node failed to deliver to
+// its targets, oldest first.
+func (a adminAPIHandlers) ListFailedEventsHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Write config received from request onto a temporary file on
-	// all nodes.
-	tmpFileName := fmt.Sprintf(minioConfigTmpFormat, mustGetUUID())
-	errs := writeTmpConfigPeers(globalAdminPeers, tmpFileName, configBytes)
+	servers := make([]ServerFailedEvents, len(globalAdminPeers))
 
-	// Check if the operation succeeded in quorum or more nodes.
-	rErr := reduceWriteQuorumErrs(ctx, errs, nil, len(globalAdminPeers)/2+1)
-	if rErr != nil {
-		writeSetConfigResponse(w, globalAdminPeers, errs, false, r.URL)
-		return
-	}
+	var wg sync.WaitGroup
 
-	// Take a lock on minio/config.json. NB minio is a reserved
-	// bucket name and wouldn't conflict with normal object
-	// operations.
-	configLock := globalNSMutex.NewNSLock(minioReservedBucket, minioConfigFile)
-	if configLock.GetLock(globalObjectTimeout) != nil {
-		writeErrorResponseJSON(w, ErrOperationTimedOut, r.URL)
-		return
+	// Gather failed events of all nodes
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			servers[idx] = ServerFailedEvents{Addr: peer.addr}
+
+			events, err := peer.cmdRunner.ListFailedEvents()
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				servers[idx].Error = err.Error()
+				return
+			}
+
+			servers[idx].Data = events
+		}(i, p)
 	}
-	defer configLock.Unlock()
 
-	// Rename the temporary config file to config.json
-	errs = commitConfigPeers(globalAdminPeers, tmpFileName)
-	rErr = reduceWriteQuorumErrs(ctx, errs, nil, len(globalAdminPeers)/2+1)
-	if rErr != nil {
-		writeSetConfigResponse(w, globalAdminPeers, errs, false, r.URL)
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(servers)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
 		return
 	}
 
-	// serverMux (cmd/server-mux.go) implements graceful shutdown,
-	// where all listeners are closed and process restart/shutdown
-	// happens after 5s or completion of all ongoing http
-	// requests, whichever is earlier.
-	writeSetConfigResponse(w, globalAdminPeers, errs, true, r.URL)
+	writeSuccessResponseJSON(w, jsonBytes)
+}
 
-	// Restart all node for the modified config to take effect.
-	sendServiceCmd(globalAdminPeers, serviceRestart)
+// ReplayFailedEventsResult holds the outcome of replaying the failed
+// events of one node.
+type ReplayFailedEventsResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+	FailedEventsReplay
 }
 
-// ConfigCredsHandler - POST /minio/admin/v1/config/credential
+// ReplayFailedEventsHandler - POST /minio/admin/v1/failed-events/replay?id=<event-id>
 // ----------
-// Update credentials in a minio server. In a distributed setup,
-// update all the servers in the cluster.
-func (a adminAPIHandlers) UpdateCredentialsHandler(w http.ResponseWriter,
-	r *http.Request) {
-
-	// Authenticate request
+// Sends the failed event having the given ID, or all failed events if
+// no ID is given, again to their targets. Delivered events are removed,
+// events of targets which are still unreachable are kept.
+func (a adminAPIHandlers) ReplayFailedEventsHandler(w http.ResponseWriter, r *http.Request) {
 	adminAPIErr := checkAdminRequestAuthType(r, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Avoid setting new credentials when they are already passed
-	// by the environment. Deny if WORM is enabled.
-	if globalIsEnvCreds || globalWORMEnabled {
-		writeErrorResponseJSON(w, ErrMethodNotAllowed, r.URL)
-		return
+	id := r.URL.Query().Get("id")
+
+	results := make([]ReplayFailedEventsResult, len(globalAdminPeers))
+
+	var wg sync.WaitGroup
+
+	// Replay failed events on all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			results[idx] = ReplayFailedEventsResult{Addr: peer.addr}
+
+			replay, err := peer.cmdRunner.ReplayFailedEvents(id)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				results[idx].Error = err.Error()
+				return
+			}
+
+			results[idx].FailedEventsReplay = replay
+		}(i, p)
 	}
 
-	// Decode request body
-	var req madmin.SetCredsReq
-	err := json.NewDecoder(r.Body).Decode(&req)
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(results)
 	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
 		logger.LogIf(context.Background(), err)
-		writeErrorResponseJSON(w, ErrRequestBodyParse, r.URL)
 		return
 	}
 
-	creds, err := auth.CreateCredentials(req.AccessKey, req.SecretKey)
-	if err != nil {
-		writeErrorResponseJSON(w, toAPIErrorCode(err), r.URL)
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ForceUnlockResult holds the number of locks released by one node.
+type ForceUnlockResult struct {
+	Error    string `json:"error"`
+	Addr     string `json:"addr"`
+	Released int    `json:"released"`
+}
+
+// ForceUnlockHandler - POST /minio/admin/v1/force-unlock?bucket=<bucket>&object=<object>
+// POST /minio/admin/v1/force-unlock?client=<host:port>
+// ----------
+// Releases the lock held on the given bucket or object, or all locks
+// held by the given client e.g. a server which hung or went down, on
+// all nodes. Operations waiting for a released lock still time out, new
+// operations proceed normally.
+func (a adminAPIHandlers) ForceUnlockHandler(w http.ResponseWriter, r *http.Request) {
+	adminAPIErr := checkAdminRequestAuthType(r, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(w, adminAPIErr, r.URL)
 		return
 	}
 
-	// Take a lock on minio/config.json. Prevents concurrent
-	// config file updates.
-	configLock := globalNSMutex.NewNSLock(minioReservedBucket, minioConfigFile)
-	if configLock.GetLock(globalObjectTimeout) != nil {
-		writeErrorResponseJSON(w, ErrOperationTimedOut, r.URL)
+	vars := r.URL.Query()
+	bucket, object, client := vars.Get("bucket"), vars.Get("object"), vars.Get("client")
+	if err := validateForceUnlock(bucket, object, client); err != nil {
+		writeErrorResponseJSON(w, ErrAdminInvalidForceUnlock, r.URL)
 		return
 	}
-	defer configLock.Unlock()
 
-	// Acquire lock before updating global configuration.
-	globalServerConfigMu.Lock()
-	defer globalServerConfigMu.Unlock()
+	results := make([]ForceUnlockResult, len(globalAdminPeers))
 
-	// Update local credentials in memory.
-	globalServerConfig.SetCredential(creds)
-	if err = globalServerConfig.Save(getConfigFile()); err != nil {
-		writeErrorResponseJSON(w, ErrInternalError, r.URL)
-		return
+	var wg sync.WaitGroup
+
+	// Release locks on all nodes in parallel
+	for i, p := range globalAdminPeers {
+		wg.Add(1)
+
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+
+			results[idx] = ForceUnlockResult{Addr: peer.addr}
+
+			released, err := peer.cmdRunner.ForceUnlock(bucket, object, client)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", peer.addr)
+				ctx := logger.SetReqInfo(context.Background(), reqInfo)
+				logger.LogIf(ctx, err)
+				results[idx].Error = err.Error()
+				return
+			}
+
+			results[idx].Released = released
+		}(i, p)
 	}
 
-	// Notify all other Minio peers to update credentials
-	for host, err := range globalNotificationSys.SetCredentials(creds) {
-		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", host.String())
-		ctx := logger.SetReqInfo(context.Background(), reqInfo)
-		logger.LogIf(ctx, err)
+	wg.Wait()
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(w, ErrInternalError, r.URL)
+		logger.LogIf(context.Background(), err)
+		return
 	}
 
-	// At this stage, the operation is successful, return 200 OK
-	w.WriteHeader(http.StatusOK)
+	writeSuccessResponseJSON(w, jsonBytes)
 }
@@ -17,8 +17,10 @@
 package cmd
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,13 +29,22 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/tagging"
+	sha256 "github.com/minio/sha256-simd"
 )
 
 var (
@@ -225,6 +236,24 @@ func prepareAdminXLTestBed() (*adminXLTestBed, error) {
 	// Create new policy system.
 	globalPolicySys = NewPolicySys()
 
+	// Create new bucket quota system.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	// Create new remote targets system.
+	globalBucketTargetSys = NewBucketTargetSys()
+
+	// Create new bucket metadata system.
+	globalBucketMetadataSys = NewBucketMetadataSys()
+
+	// Create new tiers system.
+	globalTierConfigSys = NewTierConfigSys()
+
+	// Create new batch jobs system.
+	globalBatchJobSys = NewBatchJobSys()
+
+	// Create new IAM system.
+	globalIAMSys = NewIAMSys()
+
 	// Setup admin mgmt REST API handlers.
 	adminRouter := mux.NewRouter()
 	registerAdminRouter(adminRouter)
@@ -536,6 +565,54 @@ func TestServiceRestartHandler(t *testing.T) {
 	testServicesCmdHandler(restartCmd, t)
 }
 
+// Test for service freeze and unfreeze management REST API.
+func TestServiceFreezeHandler(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+	defer unfreezeServices()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	credentials := globalServerConfig.GetCredential()
+
+	testCases := []struct {
+		action         madmin.ServiceActionValue
+		expectedCode   int
+		expectedFrozen bool
+	}{
+		{madmin.ServiceActionValueFreeze, http.StatusOK, true},
+		{"invalid", http.StatusBadRequest, true},
+		{madmin.ServiceActionValueUnfreeze, http.StatusOK, false},
+	}
+
+	for i, testCase := range testCases {
+		body, err := json.Marshal(madmin.ServiceAction{Action: testCase.action})
+		if err != nil {
+			t.Fatalf("JSONify error: %v", err)
+		}
+
+		req, err := getServiceCmdRequest(stopCmd, credentials, body)
+		if err != nil {
+			t.Fatalf("Failed to build service action request %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+
+		if frozen := isServiceFrozen(); frozen != testCase.expectedFrozen {
+			t.Fatalf("Test %d: Expected frozen to be %v, got %v", i+1, testCase.expectedFrozen, frozen)
+		}
+	}
+}
+
 // Test for service set creds management REST API.
 func TestServiceSetCreds(t *testing.T) {
 	adminTestBed, err := prepareAdminXLTestBed()
@@ -739,6 +816,35 @@ func TestSetConfigHandler(t *testing.T) {
 			t.Errorf("Got unexpected response code or body %d - %s", rec.Code, respBody)
 		}
 	}
+
+	// Check that a config with an unreachable notification target
+	// is not committed.
+	{
+		invalidCfg := newTestUnreachableTargetConfig(t)
+		req, err := buildAdminRequest(queryVal, http.MethodPut, "/config",
+			int64(len(invalidCfg)), bytes.NewReader(invalidCfg))
+		if err != nil {
+			t.Fatalf("Failed to construct set-config object request - %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected to succeed but failed with %d", rec.Code)
+		}
+
+		result := setConfigResult{}
+		if err = json.NewDecoder(rec.Body).Decode(&result); err != nil {
+			t.Fatalf("Failed to decode set config result json %v", err)
+		}
+		if result.Status {
+			t.Fatal("Expected set-config to fail, but succeeded")
+		}
+		if len(result.NodeResults) != 1 || len(result.NodeResults[0].ValidationErrs) != 1 ||
+			result.NodeResults[0].ValidationErrs[0].Subsystem != "notify.amqp.1" {
+			t.Errorf("Expected validation error of notify.amqp.1, got %v", result.NodeResults)
+		}
+	}
 }
 
 func TestAdminServerInfo(t *testing.T) {
@@ -790,168 +896,2129 @@ func TestAdminServerInfo(t *testing.T) {
 	}
 }
 
-// TestToAdminAPIErr - test for toAdminAPIErr helper function.
-func TestToAdminAPIErr(t *testing.T) {
-	testCases := []struct {
-		err            error
-		expectedAPIErr APIErrorCode
-	}{
-		// 1. Server not in quorum.
-		{
-			err:            errXLWriteQuorum,
-			expectedAPIErr: ErrAdminConfigNoQuorum,
-		},
-		// 2. No error.
-		{
-			err:            nil,
-			expectedAPIErr: ErrNone,
-		},
-		// 3. Non-admin API specific error.
-		{
-			err:            errDiskNotFound,
-			expectedAPIErr: toAPIErrorCode(errDiskNotFound),
-		},
+func TestAdminBandwidthInfo(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
 	}
+	defer adminTestBed.TearDown()
 
-	for i, test := range testCases {
-		actualErr := toAdminAPIErrCode(test.err)
-		if actualErr != test.expectedAPIErr {
-			t.Errorf("Test %d: Expected %v but received %v",
-				i+1, test.expectedAPIErr, actualErr)
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	tmpGlobalBucketBandwidthStats := globalBucketBandwidthStats
+	defer func() {
+		globalBucketBandwidthStats = tmpGlobalBucketBandwidthStats
+	}()
+	globalBucketBandwidthStats = newBucketBandwidthStats()
+	globalBucketBandwidthStats.incInputBytes("mybucket", 100)
+	globalBucketBandwidthStats.incOutputBytes("mybucket", 200)
+
+	req, err := buildAdminRequest(url.Values{}, http.MethodGet, "/bandwidth", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct bandwidth info request - %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected to succeed but failed with %d", rec.Code)
+	}
+
+	result := ClusterBandwidthInfo{}
+	if err = json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode bandwidth info result json %v", err)
+	}
+
+	if len(result.Servers) == 0 {
+		t.Fatal("Expected at least one server bandwidth result")
+	}
+	for _, server := range result.Servers {
+		if server.Error != "" {
+			t.Errorf("Unexpected error = %v\n", server.Error)
 		}
 	}
+
+	bandwidth, ok := result.Buckets["mybucket"]
+	if !ok {
+		t.Fatal("Expected bandwidth of mybucket to be reported")
+	}
+	if bandwidth.BytesIn != 100 || bandwidth.BytesOut != 200 {
+		t.Errorf("Expected 100 bytes in and 200 bytes out, got %d and %d", bandwidth.BytesIn, bandwidth.BytesOut)
+	}
 }
 
-func TestWriteSetConfigResponse(t *testing.T) {
-	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+func TestAdminTopAPI(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
 	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(rootPath)
-	testCases := []struct {
-		status bool
-		errs   []error
-	}{
-		// 1. all nodes returned success.
-		{
-			status: true,
-			errs:   []error{nil, nil, nil, nil},
-		},
-		// 2. some nodes returned errors.
-		{
-			status: false,
-			errs:   []error{errDiskNotFound, nil, errDiskAccessDenied, errFaultyDisk},
-		},
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
 	}
+	defer adminTestBed.TearDown()
 
-	testPeers := []adminPeer{
-		{
-			addr: "localhost:9001",
-		},
-		{
-			addr: "localhost:9002",
-		},
-		{
-			addr: "localhost:9003",
-		},
-		{
-			addr: "localhost:9004",
-		},
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	tmpGlobalAPICallStats := globalAPICallStats
+	defer func() {
+		globalAPICallStats = tmpGlobalAPICallStats
+	}()
+	globalAPICallStats = newAPICallStats()
+	for _, api := range []string{"GetObject", "GetObject", "PutObject"} {
+		globalAPICallStats.callStarted(api)
+		globalAPICallStats.callDone(api, http.StatusOK, time.Millisecond, UTCNow())
 	}
 
-	testURL, err := url.Parse("http://dummy.com")
-	if err != nil {
-		t.Fatalf("Failed to parse a place-holder url")
+	testCases := []struct {
+		count         string
+		expectedCode  int
+		expectedNames []string
+	}{
+		{"", http.StatusOK, []string{"GetObject", "PutObject"}},
+		{"1", http.StatusOK, []string{"GetObject"}},
+		{"0", http.StatusBadRequest, nil},
+		{"abc", http.StatusBadRequest, nil},
 	}
 
-	var actualResult setConfigResult
-	for i, test := range testCases {
-		rec := httptest.NewRecorder()
-		writeSetConfigResponse(rec, testPeers, test.errs, test.status, testURL)
-		resp := rec.Result()
-		jsonBytes, err := ioutil.ReadAll(resp.Body)
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		if testCase.count != "" {
+			queryVal.Set("count", testCase.count)
+		}
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/top/api", 0, nil)
 		if err != nil {
-			t.Fatalf("Test %d: Failed to read response %v", i+1, err)
+			t.Fatalf("Test %d: Failed to construct top api request - %v", i+1, err)
 		}
 
-		err = json.Unmarshal(jsonBytes, &actualResult)
-		if err != nil {
-			t.Fatalf("Test %d: Failed to unmarshal json %v", i+1, err)
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
 		}
-		if actualResult.Status != test.status {
-			t.Errorf("Test %d: Expected status %v but received %v", i+1, test.status, actualResult.Status)
+		if rec.Code != http.StatusOK {
+			continue
 		}
-		for p, res := range actualResult.NodeResults {
-			if res.Name != testPeers[p].addr {
-				t.Errorf("Test %d: Expected node name %s but received %s", i+1, testPeers[p].addr, res.Name)
-			}
-			expectedErrMsg := fmt.Sprintf("%v", test.errs[p])
-			if res.ErrMsg != expectedErrMsg {
-				t.Errorf("Test %d: Expected error %s but received %s", i+1, expectedErrMsg, res.ErrMsg)
+
+		result := ClusterAPIStats{}
+		if err = json.NewDecoder(rec.Body).Decode(&result); err != nil {
+			t.Fatalf("Test %d: Failed to decode top api result json %v", i+1, err)
+		}
+		for _, server := range result.Servers {
+			if server.Error != "" {
+				t.Fatalf("Test %d: Unexpected error = %v", i+1, server.Error)
 			}
-			expectedErrSet := test.errs[p] != nil
-			if res.ErrSet != expectedErrSet {
-				t.Errorf("Test %d: Expected ErrSet %v but received %v", i+1, expectedErrSet, res.ErrSet)
+		}
+		if len(result.APIs) != len(testCase.expectedNames) {
+			t.Fatalf("Test %d: Expected %v, got %v", i+1, testCase.expectedNames, result.APIs)
+		}
+		for j, name := range testCase.expectedNames {
+			if result.APIs[j].Name != name {
+				t.Fatalf("Test %d: Expected %s, got %s", i+1, name, result.APIs[j].Name)
 			}
 		}
 	}
 }
 
-func mkHealStartReq(t *testing.T, bucket, prefix string,
-	opts madmin.HealOpts) *http.Request {
-
-	body, err := json.Marshal(opts)
+func TestClusterHealthHandler(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
 	if err != nil {
-		t.Fatalf("Unable marshal heal opts")
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
 	}
+	defer adminTestBed.TearDown()
 
-	path := fmt.Sprintf("/minio/admin/v1/heal/%s", bucket)
-	if bucket != "" && prefix != "" {
-		path += "/" + prefix
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	checkHealth := func(expectedStatus string, expectedOnline int) {
+		req, err := buildAdminRequest(url.Values{}, http.MethodGet, "/health", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct cluster health request - %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+		}
+
+		health := ClusterHealth{}
+		if err = json.NewDecoder(rec.Body).Decode(&health); err != nil {
+			t.Fatalf("Failed to decode cluster health json %v", err)
+		}
+		if health.Status != expectedStatus {
+			t.Fatalf("Expected cluster to be %s, got %v", expectedStatus, health)
+		}
+		if len(health.Servers) != 1 || !health.Servers[0].Online {
+			t.Fatalf("Unexpected servers %v", health.Servers)
+		}
+		if len(health.Sets) != 1 || health.Sets[0].OnlineDisks != expectedOnline ||
+			health.Sets[0].TotalDisks != len(adminTestBed.xlDirs) {
+			t.Fatalf("Unexpected sets %v", health.Sets)
+		}
 	}
 
-	req, err := newTestRequest("POST", path,
-		int64(len(body)), bytes.NewReader(body))
-	if err != nil {
-		t.Fatalf("Failed to construct request - %v", err)
+	checkHealth(clusterHealthy, len(adminTestBed.xlDirs))
+
+	// A disk losing its format makes the cluster degraded.
+	if err = os.RemoveAll(filepath.Join(adminTestBed.xlDirs[0], minioMetaBucket, formatConfigFile)); err != nil {
+		t.Fatal(err)
 	}
-	cred := globalServerConfig.GetCredential()
-	err = signRequestV4(req, cred.AccessKey, cred.SecretKey)
+	checkHealth(clusterDegraded, len(adminTestBed.xlDirs)-1)
+}
+
+func TestAdminRuntimeInfo(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
 	if err != nil {
-		t.Fatalf("Failed to sign request - %v", err)
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
 	}
+	defer adminTestBed.TearDown()
 
-	return req
-}
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
 
-func mkHealStatusReq(t *testing.T, bucket, prefix,
-	clientToken string) *http.Request {
+	req, err := buildAdminRequest(url.Values{}, http.MethodGet, "/runtime", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct runtime info request - %v", err)
+	}
 
-	path := fmt.Sprintf("/minio/admin/v1/heal/%s", bucket)
-	if bucket != "" && prefix != "" {
-		path += "/" + prefix
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
 	}
-	path += fmt.Sprintf("?clientToken=%s", clientToken)
 
-	req, err := newTestRequest("POST", path, 0, nil)
-	if err != nil {
-		t.Fatalf("Failed to construct request - %v", err)
+	result := ClusterRuntimeInfo{}
+	if err = json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode runtime info result json %v", err)
 	}
-	cred := globalServerConfig.GetCredential()
-	err = signRequestV4(req, cred.AccessKey, cred.SecretKey)
-	if err != nil {
-		t.Fatalf("Failed to sign request - %v", err)
+	if len(result.Servers) != len(globalAdminPeers) {
+		t.Fatalf("Expected %d servers, got %d", len(globalAdminPeers), len(result.Servers))
+	}
+	for _, server := range result.Servers {
+		if server.Error != "" || server.Data == nil {
+			t.Fatalf("Unexpected error = %v", server.Error)
+		}
+	}
+	if result.Goroutines != result.Servers[0].Data.Goroutines || result.HeapInuse == 0 {
+		t.Fatalf("Unexpected totals %v", result)
 	}
-
-	return req
 }
 
-func collectHealResults(t *testing.T, adminTestBed *adminXLTestBed, bucket,
-	prefix, clientToken string, timeLimitSecs int) madmin.HealTaskStatus {
+func TestAdminListSessions(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
 
-	var res, cur madmin.HealTaskStatus
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
 
-	// loop and fetch heal status. have a time-limit to loop over
+	tmpGlobalSessions := globalSessions
+	defer func() {
+		globalSessions = tmpGlobalSessions
+	}()
+	globalSessions = newSessionTracker()
+	now := UTCNow()
+	globalSessions.start(SessionInfo{RemoteHost: "10.0.0.1:1000", AccessKey: "alice", API: "GetObject", Started: now})
+	globalSessions.start(SessionInfo{RemoteHost: "10.0.0.1:1001", AccessKey: "alice", API: "PutObject", Started: now})
+	globalSessions.start(SessionInfo{RemoteHost: "10.0.0.2:1000", API: "ListBuckets", Started: now})
+
+	req, err := buildAdminRequest(url.Values{}, http.MethodGet, "/sessions", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct list sessions request - %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+
+	result := ClusterSessions{}
+	if err = json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode list sessions result json %v", err)
+	}
+	if len(result.Servers) != len(globalAdminPeers) {
+		t.Fatalf("Expected %d servers, got %d", len(globalAdminPeers), len(result.Servers))
+	}
+	for _, server := range result.Servers {
+		if server.Error != "" {
+			t.Fatalf("Unexpected error = %v", server.Error)
+		}
+		if len(server.Data) != 3 {
+			t.Fatalf("Expected 3 sessions of %s, got %v", server.Addr, server.Data)
+		}
+	}
+	if len(result.Clients) != 2 || result.Clients[0].AccessKey != "alice" || result.Clients[0].Sessions != 2 ||
+		result.Clients[1].RemoteIP != "10.0.0.2" || result.Clients[1].Sessions != 1 {
+		t.Fatalf("Unexpected clients %v", result.Clients)
+	}
+}
+
+func TestAdminMaintenance(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+	defer exitMaintenance()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+	localAddr := globalAdminPeers[0].addr
+
+	testCases := []struct {
+		node, action, drain   string
+		expectedCode          int
+		expectedInMaintenance bool
+	}{
+		{localAddr, "enter", "1s", http.StatusOK, true},
+		{localAddr, "exit", "", http.StatusOK, false},
+		{localAddr, "enter", "", http.StatusOK, true},
+		{localAddr, "exit", "", http.StatusOK, false},
+		{"10.0.0.1:9000", "enter", "", http.StatusBadRequest, false},
+		{localAddr, "pause", "", http.StatusBadRequest, false},
+		{localAddr, "enter", "1h", http.StatusBadRequest, false},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("node", testCase.node)
+		queryVal.Set("action", testCase.action)
+		if testCase.drain != "" {
+			queryVal.Set("drain", testCase.drain)
+		}
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/maintenance", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct maintenance request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if isInMaintenance() != testCase.expectedInMaintenance {
+			t.Fatalf("Test %d: Expected maintenance to be %v", i+1, testCase.expectedInMaintenance)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		status := MaintenanceStatus{}
+		if err = json.NewDecoder(rec.Body).Decode(&status); err != nil {
+			t.Fatalf("Test %d: Failed to decode maintenance result json %v", i+1, err)
+		}
+		if status.Addr != testCase.node || status.InMaintenance != testCase.expectedInMaintenance || status.InFlight != 0 {
+			t.Fatalf("Test %d: Unexpected status %v", i+1, status)
+		}
+	}
+}
+
+func TestAdminKMSKeyStatus(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	tmpGlobalKMS := globalKMS
+	tmpGlobalKMSKeyID := globalKMSKeyID
+	defer func() {
+		globalKMS = tmpGlobalKMS
+		globalKMSKeyID = tmpGlobalKMSKeyID
+	}()
+
+	testCases := []struct {
+		kms           crypto.KMS
+		keyID         string
+		expectedKeyID string
+		expectErr     bool
+	}{
+		{crypto.NewKMS([32]byte{}), "", "default-key", false},
+		{crypto.NewKMS([32]byte{}), "my-key", "my-key", false},
+		{nil, "", "", true},
+	}
+
+	for i, testCase := range testCases {
+		globalKMS, globalKMSKeyID = testCase.kms, "default-key"
+
+		queryVal := url.Values{}
+		if testCase.keyID != "" {
+			queryVal.Set("key-id", testCase.keyID)
+		}
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/kms/key/status", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct KMS key status request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: Expected to succeed but failed with %d", i+1, rec.Code)
+		}
+
+		var results []ServerKMSKeyStatus
+		if err = json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("Test %d: Failed to decode KMS key status result json %v", i+1, err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Test %d: Expected one KMS key status result, got %d", i+1, len(results))
+		}
+		if expectErr := results[0].Error != ""; expectErr != testCase.expectErr {
+			t.Fatalf("Test %d: Expected error %v, got %v", i+1, testCase.expectErr, results[0].Error)
+		}
+		if !testCase.expectErr && results[0].Data.KeyID != testCase.expectedKeyID {
+			t.Errorf("Test %d: Expected key ID %s, got %s", i+1, testCase.expectedKeyID, results[0].Data.KeyID)
+		}
+	}
+}
+
+func TestAdminHealthInfo(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	req, err := buildAdminRequest(url.Values{}, http.MethodGet, "/healthinfo", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct health info request - %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected to succeed but failed with %d", rec.Code)
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != string(mimeZip) {
+		t.Fatalf("Expected content type %s, got %s", mimeZip, contentType)
+	}
+
+	body := rec.Body.Bytes()
+	zipReader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("Failed to read health info archive %v", err)
+	}
+	if len(zipReader.File) != len(globalAdminPeers) {
+		t.Errorf("Expected %d files in health info archive, found %d", len(globalAdminPeers), len(zipReader.File))
+	}
+}
+
+func TestAdminNetPerf(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	testCases := []struct {
+		size         string
+		expectedCode int
+	}{
+		{"", http.StatusOK},
+		{"1024", http.StatusOK},
+		{"0", http.StatusBadRequest},
+		{"-1", http.StatusBadRequest},
+		{"abc", http.StatusBadRequest},
+		{strconv.FormatInt(netPerfMaxPayloadSize+1, 10), http.StatusBadRequest},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		if testCase.size != "" {
+			queryVal.Set("size", testCase.size)
+		}
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/netperf", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct net perf request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var results []ServerNetPerf
+		if err = json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("Test %d: Failed to decode net perf result json %v", i+1, err)
+		}
+		if len(results) != len(globalAdminPeers) {
+			t.Fatalf("Test %d: Expected %d results, got %d", i+1, len(globalAdminPeers), len(results))
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				t.Errorf("Test %d: Unexpected error = %v", i+1, result.Error)
+			}
+		}
+	}
+}
+
+func TestAdminDrivePerf(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	testCases := []struct {
+		size         string
+		expectedCode int
+	}{
+		{"", http.StatusOK},
+		{"65536", http.StatusOK},
+		{"0", http.StatusBadRequest},
+		{"abc", http.StatusBadRequest},
+		{strconv.FormatInt(drivePerfMaxFileSize+1, 10), http.StatusBadRequest},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		if testCase.size != "" {
+			queryVal.Set("size", testCase.size)
+		}
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/driveperf", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct drive perf request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var results []ServerDrivesPerf
+		if err = json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("Test %d: Failed to decode drive perf result json %v", i+1, err)
+		}
+		if len(results) != len(globalAdminPeers) {
+			t.Fatalf("Test %d: Expected %d results, got %d", i+1, len(globalAdminPeers), len(results))
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				t.Fatalf("Test %d: Unexpected error = %v", i+1, result.Error)
+			}
+			if len(result.Data) != len(globalEndpoints) {
+				t.Fatalf("Test %d: Expected %d drives, got %d", i+1, len(globalEndpoints), len(result.Data))
+			}
+		}
+	}
+}
+
+func TestAdminInspect(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	ctx := context.Background()
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(ctx, "mybucket", ""); err != nil {
+		t.Fatalf("Failed to create bucket - %v", err)
+	}
+	if _, err = adminTestBed.objLayer.PutObject(ctx, "mybucket", "myobject", mustGetHashReader(t, bytes.NewReader([]byte("hello")), 5, "", ""), nil); err != nil {
+		t.Fatalf("Failed to create object - %v", err)
+	}
+
+	testCases := []struct {
+		bucket       string
+		object       string
+		expectedCode int
+	}{
+		{"mybucket", "myobject", http.StatusOK},
+		{"..", "myobject", http.StatusBadRequest},
+		{"mybucket", "", http.StatusBadRequest},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", testCase.bucket)
+		queryVal.Set("object", testCase.object)
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/inspect", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct inspect request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		archive, err := decryptConfigData(rec.Body.Bytes())
+		if err != nil {
+			t.Fatalf("Test %d: Failed to decrypt inspect archive - %v", i+1, err)
+		}
+		zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to read inspect archive - %v", i+1, err)
+		}
+		// One xl.json per drive and the layout of the server.
+		if len(zipReader.File) != len(globalEndpoints)+1 {
+			t.Fatalf("Test %d: Expected %d files, got %d", i+1, len(globalEndpoints)+1, len(zipReader.File))
+		}
+	}
+}
+
+func TestAdminServerUpdate(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	httpServer := newTestUpdateServer()
+	defer httpServer.Close()
+
+	_, restore := newTestMinioBinary(t)
+	defer restore()
+
+	sha256Sum := sha256.Sum256(testUpdateBinary)
+	sha256Hex := hex.EncodeToString(sha256Sum[:])
+
+	testCases := []struct {
+		updateURL         string
+		sha256Hex         string
+		expectedCode      int
+		expectedRestarted bool
+	}{
+		{"", sha256Hex, http.StatusBadRequest, false},
+		{"ftp://localhost/minio", sha256Hex, http.StatusBadRequest, false},
+		{httpServer.URL + "/minio", "", http.StatusBadRequest, false},
+		{httpServer.URL + "/minio", "abcd", http.StatusBadRequest, false},
+		// Download failure on any node prevents the restart.
+		{httpServer.URL + "/missing", sha256Hex, http.StatusOK, false},
+		{httpServer.URL + "/minio", sha256Hex, http.StatusOK, true},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("updateURL", testCase.updateURL)
+		queryVal.Set("sha256", testCase.sha256Hex)
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/update", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct update request - %v", i+1, err)
+		}
+
+		// Successful update restarts minio, start a signal
+		// receiver to receive on globalServiceSignalCh.
+		if testCase.expectedRestarted {
+			go testServiceSignalReceiver(restartCmd, t)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var status ServerUpdateStatus
+		if err = json.NewDecoder(rec.Body).Decode(&status); err != nil {
+			t.Fatalf("Test %d: Failed to decode update status json %v", i+1, err)
+		}
+		if status.Restarted != testCase.expectedRestarted {
+			t.Fatalf("Test %d: Expected restarted to be %v, got %v", i+1, testCase.expectedRestarted, status.Restarted)
+		}
+		if len(status.Servers) != len(globalAdminPeers) {
+			t.Fatalf("Test %d: Expected %d results, got %d", i+1, len(globalAdminPeers), len(status.Servers))
+		}
+	}
+}
+
+func TestAdminReloadCerts(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	_, restore := newTestTLSCerts(t)
+	defer restore()
+
+	prevIsSSL := globalIsSSL
+	defer func() {
+		globalIsSSL = prevIsSSL
+	}()
+
+	testCases := []struct {
+		isSSL        bool
+		expectedCode int
+	}{
+		{false, http.StatusBadRequest},
+		{true, http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		globalIsSSL = testCase.isSSL
+
+		req, err := buildAdminRequest(url.Values{}, http.MethodPost, "/reload-certs", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct reload certs request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var results []ReloadCertsResult
+		if err = json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("Test %d: Failed to decode reload certs results json %v", i+1, err)
+		}
+		if len(results) != len(globalAdminPeers) {
+			t.Fatalf("Test %d: Expected %d results, got %d", i+1, len(globalAdminPeers), len(results))
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				t.Fatalf("Test %d: Unexpected error reloading certs of %s: %s", i+1, result.Addr, result.Error)
+			}
+		}
+	}
+}
+
+func TestAdminSetLogLevel(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	prevLevels := logger.GetLevels()
+	defer func() {
+		for subsystem, level := range prevLevels {
+			logger.SetLevel(subsystem, level)
+		}
+	}()
+
+	testCases := []struct {
+		subsystem    string
+		level        string
+		expectedCode int
+	}{
+		{"", "debug", http.StatusBadRequest},
+		{"storage", "verbose", http.StatusBadRequest},
+		{"storage", "debug", http.StatusOK},
+		{"http", "INFO", http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("subsystem", testCase.subsystem)
+		queryVal.Set("level", testCase.level)
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/log-level", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct set log level request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var results []SetLogLevelResult
+		if err = json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("Test %d: Failed to decode set log level results json %v", i+1, err)
+		}
+		if len(results) != len(globalAdminPeers) {
+			t.Fatalf("Test %d: Expected %d results, got %d", i+1, len(globalAdminPeers), len(results))
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				t.Fatalf("Test %d: Unexpected error setting log level of %s: %s", i+1, result.Addr, result.Error)
+			}
+		}
+	}
+
+	levels := logger.GetLevels()
+	if levels[logger.StorageSubsystem] != logger.DebugLvl || levels[logger.HTTPSubsystem] != logger.InformationLvl {
+		t.Fatalf("Unexpected log levels %v", levels)
+	}
+}
+
+func TestAdminSetScannerSpeed(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+	defer setScannerSpeed(ScannerSpeedDefault)
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	testCases := []struct {
+		speed        string
+		expectedCode int
+	}{
+		{"", http.StatusBadRequest},
+		{"turbo", http.StatusBadRequest},
+		{"slow", http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("speed", testCase.speed)
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/scanner-speed", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct set scanner speed request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var results []SetScannerSpeedResult
+		if err = json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("Test %d: Failed to decode set scanner speed results json %v", i+1, err)
+		}
+		if len(results) != len(globalAdminPeers) {
+			t.Fatalf("Test %d: Expected %d results, got %d", i+1, len(globalAdminPeers), len(results))
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				t.Fatalf("Test %d: Unexpected error setting scanner speed of %s: %s", i+1, result.Addr, result.Error)
+			}
+		}
+	}
+
+	if speed := getScannerSpeed(); speed != ScannerSpeedSlow {
+		t.Fatalf("Expected scanner speed %s, got %s", ScannerSpeedSlow, speed)
+	}
+}
+
+// TestBatchJobHandlers - test for start, describe, cancel and list batch job handlers.
+func TestBatchJobHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(context.Background(), "mybucket", ""); err != nil {
+		t.Fatalf("Failed to make bucket - %v", err)
+	}
+
+	key := bytes.Repeat([]byte{1}, SSECustomerKeySize)
+	testCases := []struct {
+		req          BatchJobRequest
+		expectedCode int
+	}{
+		{BatchJobRequest{Type: BatchJobExpire, Bucket: "mybucket"}, http.StatusBadRequest},
+		{BatchJobRequest{Type: BatchJobExpire, Bucket: "nosuchbucket", OlderThan: time.Hour}, http.StatusNotFound},
+		{BatchJobRequest{Type: BatchJobKeyRotate, Bucket: "mybucket", OldKey: key, NewKey: key}, http.StatusOK},
+	}
+
+	var info BatchJobInfo
+	for i, testCase := range testCases {
+		body, err := json.Marshal(testCase.req)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to marshal batch job request - %v", i+1, err)
+		}
+		req, err := buildAdminRequest(url.Values{}, http.MethodPost, "/batch-job/start", int64(len(body)), bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct start batch job request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		if err = json.NewDecoder(rec.Body).Decode(&info); err != nil {
+			t.Fatalf("Test %d: Failed to decode batch job info json %v", i+1, err)
+		}
+		if info.ID == "" || info.Request.OldKey != nil || info.Request.NewKey != nil {
+			t.Fatalf("Test %d: Unexpected batch job info %v", i+1, info)
+		}
+		for _, server := range info.Servers {
+			if server.Error != "" {
+				t.Fatalf("Test %d: Unexpected error starting batch job on %s: %s", i+1, server.Addr, server.Error)
+			}
+		}
+	}
+
+	queryVal := url.Values{}
+	queryVal.Set("id", info.ID)
+	req, err := buildAdminRequest(queryVal, http.MethodGet, "/batch-job/describe", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct describe batch job request - %v", err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+	var describeInfo BatchJobInfo
+	if err = json.NewDecoder(rec.Body).Decode(&describeInfo); err != nil {
+		t.Fatalf("Failed to decode batch job info json %v", err)
+	}
+	if len(describeInfo.Servers) != 1 || describeInfo.Servers[0].Data == nil || describeInfo.Servers[0].Data.ID != info.ID {
+		t.Fatalf("Unexpected batch job info %v", describeInfo)
+	}
+
+	req, err = buildAdminRequest(queryVal, http.MethodPost, "/batch-job/cancel", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct cancel batch job request - %v", err)
+	}
+	rec = httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+
+	req, err = buildAdminRequest(url.Values{}, http.MethodGet, "/batch-job/list", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct list batch jobs request - %v", err)
+	}
+	rec = httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+	var infos []BatchJobInfo
+	if err = json.NewDecoder(rec.Body).Decode(&infos); err != nil {
+		t.Fatalf("Failed to decode batch jobs json %v", err)
+	}
+	if len(infos) != 1 || infos[0].ID != info.ID || infos[0].Request.OldKey != nil {
+		t.Fatalf("Unexpected batch jobs %v", infos)
+	}
+
+	queryVal.Set("id", "unknown")
+	req, err = buildAdminRequest(queryVal, http.MethodGet, "/batch-job/describe", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct describe batch job request - %v", err)
+	}
+	rec = httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusNotFound, rec.Code)
+	}
+}
+
+// TestBucketQuotaHandlers - test for set and get bucket quota handlers.
+func TestBucketQuotaHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(context.Background(), "mybucket", ""); err != nil {
+		t.Fatalf("Failed to create bucket - %v", err)
+	}
+
+	getQuota := func(bucket string) *httptest.ResponseRecorder {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", bucket)
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/get-bucket-quota", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct get-bucket-quota request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	testCases := []struct {
+		bucket        string
+		body          string
+		expectedCode  int
+		expectedQuota *BucketQuota
+	}{
+		// 1. Hard quota.
+		{"mybucket", `{"quota":1024,"quotatype":"hard"}`, http.StatusOK, &BucketQuota{Quota: 1024, Type: HardQuota}},
+		// 2. FIFO quota replaces the hard quota.
+		{"mybucket", `{"quota":2048,"quotatype":"fifo"}`, http.StatusOK, &BucketQuota{Quota: 2048, Type: FIFOQuota}},
+		// 3. Unknown quota type.
+		{"mybucket", `{"quota":1024,"quotatype":"soft"}`, http.StatusBadRequest, nil},
+		// 4. Malformed request body.
+		{"mybucket", `{`, http.StatusBadRequest, nil},
+		// 5. Non-existent bucket.
+		{"nobucket", `{"quota":1024,"quotatype":"hard"}`, http.StatusNotFound, nil},
+		// 6. Quota of zero removes the quota.
+		{"mybucket", `{"quota":0}`, http.StatusOK, nil},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", testCase.bucket)
+		req, err := buildAdminRequest(queryVal, http.MethodPut, "/set-bucket-quota",
+			int64(len(testCase.body)), strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct set-bucket-quota request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		rec = getQuota(testCase.bucket)
+		if testCase.expectedQuota == nil {
+			if rec.Code != http.StatusNotFound {
+				t.Fatalf("Test %d: Expected quota to be removed, got status `%d`", i+1, rec.Code)
+			}
+			if _, ok := globalBucketQuotaSys.Get(testCase.bucket); ok {
+				t.Fatalf("Test %d: Expected in-memory quota to be removed", i+1)
+			}
+			continue
+		}
+
+		var quota BucketQuota
+		if err = json.NewDecoder(rec.Body).Decode(&quota); err != nil {
+			t.Fatalf("Test %d: Failed to decode bucket quota %v", i+1, err)
+		}
+		if quota != *testCase.expectedQuota {
+			t.Fatalf("Test %d: Expected quota %v, got %v", i+1, *testCase.expectedQuota, quota)
+		}
+		if quota, ok := globalBucketQuotaSys.Get(testCase.bucket); !ok || quota != *testCase.expectedQuota {
+			t.Fatalf("Test %d: Expected in-memory quota %v, got %v", i+1, *testCase.expectedQuota, quota)
+		}
+	}
+}
+
+// TestBucketAccessHandlers - test for set and get bucket access handlers.
+func TestBucketAccessHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(context.Background(), "mybucket", ""); err != nil {
+		t.Fatalf("Failed to create bucket - %v", err)
+	}
+
+	getAccess := func(bucket, prefix string) string {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", bucket)
+		queryVal.Set("prefix", prefix)
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/get-bucket-access", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct get-bucket-access request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+		}
+		var access BucketAccess
+		if err = json.NewDecoder(rec.Body).Decode(&access); err != nil {
+			t.Fatalf("Failed to decode bucket access %v", err)
+		}
+		return access.Access
+	}
+
+	testCases := []struct {
+		bucket        string
+		prefix        string
+		body          string
+		expectedCode  int
+		expectedRead  bool
+		expectedWrite bool
+	}{
+		// 1. Download access of a prefix.
+		{"mybucket", "public/", `{"access":"download"}`, http.StatusOK, true, false},
+		// 2. Public access replaces the download access.
+		{"mybucket", "public/", `{"access":"public"}`, http.StatusOK, true, true},
+		// 3. Unknown access.
+		{"mybucket", "", `{"access":"private"}`, http.StatusBadRequest, false, false},
+		// 4. Malformed request body.
+		{"mybucket", "", `{`, http.StatusBadRequest, false, false},
+		// 5. Non-existent bucket.
+		{"nobucket", "", `{"access":"download"}`, http.StatusNotFound, false, false},
+		// 6. No access removes the statements of the prefix.
+		{"mybucket", "public/", `{"access":"none"}`, http.StatusOK, false, false},
+		// 7. No access of a bucket without bucket policy.
+		{"mybucket", "", `{"access":"none"}`, http.StatusOK, false, false},
+		// 8. Upload access of the whole bucket applies to the prefix.
+		{"mybucket", "", `{"access":"upload"}`, http.StatusOK, false, true},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", testCase.bucket)
+		queryVal.Set("prefix", testCase.prefix)
+		req, err := buildAdminRequest(queryVal, http.MethodPut, "/set-bucket-access",
+			int64(len(testCase.body)), strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct set-bucket-access request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var access BucketAccess
+		if err = json.Unmarshal([]byte(testCase.body), &access); err != nil {
+			t.Fatal(err)
+		}
+		if got := getAccess(testCase.bucket, testCase.prefix); got != access.Access {
+			t.Fatalf("Test %d: Expected access %s, got %s", i+1, access.Access, got)
+		}
+
+		// The access is granted to anonymous requests on objects of the prefix.
+		for action, expected := range map[policy.Action]bool{
+			policy.GetObjectAction: testCase.expectedRead,
+			policy.PutObjectAction: testCase.expectedWrite,
+		} {
+			allowed := globalPolicySys.IsAllowed(policy.Args{
+				Action:     action,
+				BucketName: testCase.bucket,
+				ObjectName: testCase.prefix + "object",
+			})
+			if allowed != expected {
+				t.Fatalf("Test %d: Expected %s to be allowed %v, got %v", i+1, action, expected, allowed)
+			}
+		}
+	}
+
+	// The upload access of the whole bucket is the access of its prefixes.
+	if got := getAccess("mybucket", "public/"); got != bucketAccessUpload {
+		t.Fatalf("Expected access %s, got %s", bucketAccessUpload, got)
+	}
+}
+
+// TestBucketDefaultTagsHandlers - test for set and get bucket default tags handlers.
+func TestBucketDefaultTagsHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(context.Background(), "mybucket", ""); err != nil {
+		t.Fatalf("Failed to create bucket - %v", err)
+	}
+
+	getDefaultTags := func(bucket string) map[string]string {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", bucket)
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/get-bucket-default-tags", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct get-bucket-default-tags request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+		}
+		var defaultTags BucketDefaultTags
+		if err = json.NewDecoder(rec.Body).Decode(&defaultTags); err != nil {
+			t.Fatalf("Failed to decode bucket default tags %v", err)
+		}
+		return defaultTags.Tags
+	}
+
+	testCases := []struct {
+		bucket       string
+		body         string
+		expectedCode int
+		expectedTags string
+	}{
+		// 1. Default tags of a bucket.
+		{"mybucket", `{"tags":{"project":"minio","env":"test"}}`, http.StatusOK, "env=test&project=minio"},
+		// 2. Invalid tag key.
+		{"mybucket", `{"tags":{"aws:project":"minio"}}`, http.StatusBadRequest, "env=test&project=minio"},
+		// 3. Malformed request body.
+		{"mybucket", `{`, http.StatusBadRequest, "env=test&project=minio"},
+		// 4. Non-existent bucket.
+		{"nobucket", `{"tags":{"project":"minio"}}`, http.StatusNotFound, ""},
+		// 5. No tags removes the default tags.
+		{"mybucket", `{"tags":{}}`, http.StatusOK, ""},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", testCase.bucket)
+		req, err := buildAdminRequest(queryVal, http.MethodPut, "/set-bucket-default-tags",
+			int64(len(testCase.body)), strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct set-bucket-default-tags request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if tags := getBucketDefaultTags(testCase.bucket); tags != testCase.expectedTags {
+			t.Fatalf("Test %d: Expected default tags %s, got %s", i+1, testCase.expectedTags, tags)
+		}
+		if testCase.bucket == "mybucket" {
+			tags, err := tagging.ParseTags(testCase.expectedTags, tagging.MaxObjectTags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := getDefaultTags(testCase.bucket); !reflect.DeepEqual(got, tags.ToMap()) {
+				t.Fatalf("Test %d: Expected default tags %v, got %v", i+1, tags.ToMap(), got)
+			}
+		}
+	}
+}
+
+// TestBucketIntegrityHandlers - test for set and get bucket integrity handlers.
+func TestBucketIntegrityHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(context.Background(), "mybucket", ""); err != nil {
+		t.Fatalf("Failed to create bucket - %v", err)
+	}
+
+	getIntegrity := func(bucket string) bool {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", bucket)
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/get-bucket-integrity", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct get-bucket-integrity request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+		}
+		var integrity BucketIntegrity
+		if err = json.NewDecoder(rec.Body).Decode(&integrity); err != nil {
+			t.Fatalf("Failed to decode bucket integrity %v", err)
+		}
+		return integrity.Strict
+	}
+
+	testCases := []struct {
+		bucket         string
+		body           string
+		expectedCode   int
+		expectedStrict bool
+	}{
+		// 1. Strict integrity mode of a bucket.
+		{"mybucket", `{"strict":true}`, http.StatusOK, true},
+		// 2. Malformed request body.
+		{"mybucket", `{`, http.StatusBadRequest, true},
+		// 3. Non-existent bucket.
+		{"nobucket", `{"strict":true}`, http.StatusNotFound, false},
+		// 4. Turning off strict integrity mode.
+		{"mybucket", `{"strict":false}`, http.StatusOK, false},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", testCase.bucket)
+		req, err := buildAdminRequest(queryVal, http.MethodPut, "/set-bucket-integrity",
+			int64(len(testCase.body)), strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct set-bucket-integrity request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if strict := isStrictIntegrity(testCase.bucket); strict != testCase.expectedStrict {
+			t.Fatalf("Test %d: Expected strict integrity %t, got %t", i+1, testCase.expectedStrict, strict)
+		}
+		if testCase.bucket == "mybucket" {
+			if strict := getIntegrity(testCase.bucket); strict != testCase.expectedStrict {
+				t.Fatalf("Test %d: Expected strict integrity %t, got %t", i+1, testCase.expectedStrict, strict)
+			}
+		}
+	}
+}
+
+// TestBucketMetadataIndexHandlers - test for set and get bucket metadata index handlers.
+func TestBucketMetadataIndexHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	objLayer := adminTestBed.objLayer
+	if err = objLayer.MakeBucketWithLocation(context.Background(), "mybucket", ""); err != nil {
+		t.Fatalf("Failed to create bucket - %v", err)
+	}
+	if _, err = objLayer.PutObject(context.Background(), "mybucket", "object", mustGetHashReader(t, bytes.NewReader([]byte("hello")), 5, "", ""), nil); err != nil {
+		t.Fatalf("Failed to create object - %v", err)
+	}
+
+	setIndex := func(bucket, body string) int {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", bucket)
+		req, err := buildAdminRequest(queryVal, http.MethodPut, "/set-bucket-metadata-index",
+			int64(len(body)), strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Failed to construct set-bucket-metadata-index request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	getIndex := func() madmin.BucketMetadataIndexStatus {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", "mybucket")
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/get-bucket-metadata-index", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct get-bucket-metadata-index request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+		}
+		var status madmin.BucketMetadataIndexStatus
+		if err = json.NewDecoder(rec.Body).Decode(&status); err != nil {
+			t.Fatalf("Failed to decode bucket metadata index status %v", err)
+		}
+		return status
+	}
+
+	if code := setIndex("nobucket", `{"enabled":true}`); code != http.StatusNotFound {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusNotFound, code)
+	}
+	if code := setIndex("mybucket", `{`); code != http.StatusBadRequest {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusBadRequest, code)
+	}
+
+	// The index is built in background once enabled.
+	if code := setIndex("mybucket", `{"enabled":true}`); code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, code)
+	}
+	var status madmin.BucketMetadataIndexStatus
+	for i := 0; i < 50; i++ {
+		if status = getIndex(); !status.Updated.IsZero() {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !status.Enabled || status.Updated.IsZero() || status.Objects != 1 {
+		t.Fatalf("Expected an enabled index of 1 object, got %+v", status)
+	}
+
+	// Disabling the index removes it.
+	if code := setIndex("mybucket", `{"enabled":false}`); code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, code)
+	}
+	if _, err = readBucketMetadataIndex(context.Background(), objLayer, "mybucket"); err != errConfigNotFound {
+		t.Fatalf("Expected the index to be removed, got %v", err)
+	}
+	if status = getIndex(); status.Enabled || status.Objects != 0 {
+		t.Fatalf("Expected a disabled index, got %+v", status)
+	}
+}
+
+// TestBucketPolicyVersionHandlers - test for list and restore bucket policy version handlers.
+func TestBucketPolicyVersionHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	objLayer := adminTestBed.objLayer
+	ctx := context.Background()
+	if err = objLayer.MakeBucketWithLocation(ctx, "mybucket", ""); err != nil {
+		t.Fatalf("Failed to create bucket - %v", err)
+	}
+
+	setPolicy := func(policyStr string) {
+		bucketPolicy, err := policy.ParseConfig(strings.NewReader(policyStr), "mybucket")
+		if err != nil {
+			t.Fatalf("Failed to parse bucket policy - %v", err)
+		}
+		if err = addBucketPolicyVersion(ctx, objLayer, "mybucket"); err != nil {
+			t.Fatalf("Failed to record bucket policy version - %v", err)
+		}
+		if err = objLayer.SetBucketPolicy(ctx, "mybucket", bucketPolicy); err != nil {
+			t.Fatalf("Failed to set bucket policy - %v", err)
+		}
+	}
+
+	listVersions := func() []BucketPolicyVersion {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", "mybucket")
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/list-bucket-policy-versions", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct list-bucket-policy-versions request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+		}
+		var versions []BucketPolicyVersion
+		if err = json.NewDecoder(rec.Body).Decode(&versions); err != nil {
+			t.Fatalf("Failed to decode bucket policy versions %v", err)
+		}
+		return versions
+	}
+
+	restoreVersion := func(bucket, versionID string) int {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", bucket)
+		queryVal.Set("versionId", versionID)
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/restore-bucket-policy-version", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct restore-bucket-policy-version request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if versions := listVersions(); len(versions) != 0 {
+		t.Fatalf("Expected no bucket policy versions, got %v", versions)
+	}
+
+	readOnlyPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`
+	publicPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject","s3:PutObject"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`
+	setPolicy(readOnlyPolicy)
+	setPolicy(publicPolicy)
+
+	versions := listVersions()
+	if len(versions) != 2 {
+		t.Fatalf("Expected 2 bucket policy versions, got %d", len(versions))
+	}
+	if versions[1].Policy != nil {
+		t.Fatalf("Expected the oldest version to have no policy, got %s", versions[1].Policy)
+	}
+	readOnlyVersion, err := policy.ParseConfig(bytes.NewReader(versions[0].Policy), "mybucket")
+	if err != nil {
+		t.Fatalf("Failed to parse bucket policy version - %v", err)
+	}
+
+	// Roll back to the read-only policy.
+	if code := restoreVersion("mybucket", versions[0].VersionID); code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, code)
+	}
+	bucketPolicy, err := objLayer.GetBucketPolicy(ctx, "mybucket")
+	if err != nil {
+		t.Fatalf("Failed to get bucket policy - %v", err)
+	}
+	if !reflect.DeepEqual(bucketPolicy, readOnlyVersion) {
+		t.Fatalf("Expected restored bucket policy %v, got %v", readOnlyVersion, bucketPolicy)
+	}
+	if versions = listVersions(); len(versions) != 3 {
+		t.Fatalf("Expected 3 bucket policy versions, got %d", len(versions))
+	}
+
+	// Roll back to no policy.
+	if code := restoreVersion("mybucket", versions[2].VersionID); code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, code)
+	}
+	if _, err = objLayer.GetBucketPolicy(ctx, "mybucket"); err == nil {
+		t.Fatal("Expected the bucket policy to be removed")
+	}
+
+	// Unknown versions and buckets.
+	if code := restoreVersion("mybucket", "unknown"); code != http.StatusNotFound {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusNotFound, code)
+	}
+	if code := restoreVersion("nobucket", versions[0].VersionID); code != http.StatusNotFound {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusNotFound, code)
+	}
+
+	// Only the latest versions are kept.
+	for i := 0; i < maxBucketPolicyVersions; i++ {
+		setPolicy(readOnlyPolicy)
+	}
+	if versions = listVersions(); len(versions) != maxBucketPolicyVersions {
+		t.Fatalf("Expected %d bucket policy versions, got %d", maxBucketPolicyVersions, len(versions))
+	}
+
+	// Bucket access and object ACL updates are recorded as well.
+	checkLatestVersion := func(expected *policy.Policy) {
+		versions := listVersions()
+		version, err := policy.ParseConfig(bytes.NewReader(versions[0].Policy), "mybucket")
+		if err != nil {
+			t.Fatalf("Failed to parse bucket policy version - %v", err)
+		}
+		if !reflect.DeepEqual(version, expected) {
+			t.Fatalf("Expected latest bucket policy version %v, got %v", expected, version)
+		}
+	}
+	if err = setBucketPolicyType(ctx, objLayer, "mybucket", "public/", bucketAccessPolicies[bucketAccessPublic]); err != nil {
+		t.Fatalf("Failed to set bucket access - %v", err)
+	}
+	checkLatestVersion(readOnlyVersion)
+	if bucketPolicy, err = objLayer.GetBucketPolicy(ctx, "mybucket"); err != nil {
+		t.Fatalf("Failed to get bucket policy - %v", err)
+	}
+	if err = updateObjectACL(ctx, objLayer, "mybucket", "object", objectACLPublicRead); err != nil {
+		t.Fatalf("Failed to set object ACL - %v", err)
+	}
+	checkLatestVersion(bucketPolicy)
+}
+
+// TestRemoteTargetHandlers - test for set, list and remove remote target handlers.
+func TestRemoteTargetHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(context.Background(), "mybucket", ""); err != nil {
+		t.Fatalf("Failed to create bucket - %v", err)
+	}
+
+	targetJSON := `{"endpoint":"https://remote:9000","credentials":{"accessKey":"accesskey","secretKey":"secretkey"},"targetbucket":"replica","type":"replication"}`
+
+	testCases := []struct {
+		bucket       string
+		body         string
+		expectedCode int
+	}{
+		// 1. Valid replication target.
+		{"mybucket", targetJSON, http.StatusOK},
+		// 2. Unknown target type.
+		{"mybucket", `{"endpoint":"https://remote:9000","credentials":{"accessKey":"accesskey","secretKey":"secretkey"},"targetbucket":"replica","type":"backup"}`, http.StatusBadRequest},
+		// 3. Missing credentials.
+		{"mybucket", `{"endpoint":"https://remote:9000","targetbucket":"replica","type":"ilm"}`, http.StatusBadRequest},
+		// 4. Malformed request body.
+		{"mybucket", `{`, http.StatusBadRequest},
+		// 5. Non-existent bucket.
+		{"nobucket", targetJSON, http.StatusNotFound},
+	}
+
+	var arn string
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", testCase.bucket)
+		req, err := buildAdminRequest(queryVal, http.MethodPut, "/set-remote-target",
+			int64(len(testCase.body)), strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct set-remote-target request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var result struct {
+			Arn string `json:"arn"`
+		}
+		if err = json.NewDecoder(rec.Body).Decode(&result); err != nil {
+			t.Fatalf("Test %d: Failed to decode set-remote-target response %v", i+1, err)
+		}
+		if _, ok := globalBucketTargetSys.GetTarget(result.Arn); !ok {
+			t.Fatalf("Test %d: Expected target %s to be registered", i+1, result.Arn)
+		}
+		arn = result.Arn
+	}
+
+	queryVal := url.Values{}
+	queryVal.Set("bucket", "mybucket")
+	queryVal.Set("type", "replication")
+	req, err := buildAdminRequest(queryVal, http.MethodGet, "/list-remote-targets", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct list-remote-targets request - %v", err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+	var targets []BucketTarget
+	if err = json.NewDecoder(rec.Body).Decode(&targets); err != nil {
+		t.Fatalf("Failed to decode remote targets %v", err)
+	}
+	if len(targets) != 1 || targets[0].Arn != arn || targets[0].Credentials.AccessKey != "accesskey" {
+		t.Fatalf("Expected target %s, got %v", arn, targets)
+	}
+	if targets[0].Credentials.SecretKey != "" {
+		t.Fatal("Expected secret key of remote target to be redacted")
+	}
+
+	for i, expectedCode := range []int{http.StatusOK, http.StatusNotFound} {
+		queryVal = url.Values{}
+		queryVal.Set("bucket", "mybucket")
+		queryVal.Set("arn", arn)
+		req, err = buildAdminRequest(queryVal, http.MethodDelete, "/remove-remote-target", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct remove-remote-target request - %v", i+1, err)
+		}
+		rec = httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, expectedCode, rec.Code)
+		}
+	}
+	if _, ok := globalBucketTargetSys.GetTarget(arn); ok {
+		t.Fatal("Expected remote target to be removed")
+	}
+}
+
+// TestTierHandlers - test for add, list and edit tier handlers.
+func TestTierHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	testCases := []struct {
+		method       string
+		path         string
+		body         string
+		expectedCode int
+	}{
+		// 1. Valid tier.
+		{http.MethodPut, "/tier", `{"name":"warm","type":"s3","endpoint":"https://s3.amazonaws.com","bucket":"archive","credentials":{"accessKey":"accesskey","secretKey":"secretkey"}}`, http.StatusOK},
+		// 2. Tier already exists.
+		{http.MethodPut, "/tier", `{"name":"WARM","type":"minio","endpoint":"http://remote:9000","bucket":"archive","credentials":{"accessKey":"accesskey","secretKey":"secretkey"}}`, http.StatusConflict},
+		// 3. Invalid tier.
+		{http.MethodPut, "/tier", `{"name":"cold","type":"tape","endpoint":"https://s3.amazonaws.com","bucket":"archive","credentials":{"accessKey":"accesskey","secretKey":"secretkey"}}`, http.StatusBadRequest},
+		// 4. Malformed request body.
+		{http.MethodPut, "/tier", `{`, http.StatusBadRequest},
+		// 5. Edit tier credentials.
+		{http.MethodPost, "/tier/WARM", `{"accessKey":"accesskey","secretKey":"newsecretkey"}`, http.StatusOK},
+		// 6. Edit non-existent tier.
+		{http.MethodPost, "/tier/COLD", `{"accessKey":"accesskey","secretKey":"newsecretkey"}`, http.StatusNotFound},
+	}
+
+	for i, testCase := range testCases {
+		req, err := buildAdminRequest(url.Values{}, testCase.method, testCase.path,
+			int64(len(testCase.body)), strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct tier request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+
+	req, err := buildAdminRequest(url.Values{}, http.MethodGet, "/tier", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct list tiers request - %v", err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+	var tiers []TierConfig
+	if err = json.NewDecoder(rec.Body).Decode(&tiers); err != nil {
+		t.Fatalf("Failed to decode tiers %v", err)
+	}
+	if len(tiers) != 1 || tiers[0].Name != "WARM" || tiers[0].Credentials.SecretKey != "" {
+		t.Fatalf("Expected tier WARM without secret key, got %v", tiers)
+	}
+	if tier, ok := globalTierConfigSys.Get("WARM"); !ok || tier.Credentials.SecretKey != "newsecretkey" {
+		t.Fatalf("Expected credentials of tier WARM to be updated, got %v", tier)
+	}
+}
+
+// TestServiceAccountHandlers - test for add, list and delete service account handlers.
+func TestServiceAccountHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	policyJSON := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`
+
+	testCases := []struct {
+		body         string
+		expectedCode int
+	}{
+		// 1. Service account without inline policy.
+		{`{}`, http.StatusOK},
+		// 2. Service account with inline policy.
+		{`{"policy":` + policyJSON + `}`, http.StatusOK},
+		// 3. Unknown parent user.
+		{`{"parent":"unknownuser"}`, http.StatusBadRequest},
+		// 4. Malformed inline policy.
+		{`{"policy":{"Version":"2012-10-17"}}`, http.StatusBadRequest},
+		// 5. Malformed request body.
+		{`{`, http.StatusBadRequest},
+	}
+
+	var creds []madmin.ServiceAccountCreds
+	for i, testCase := range testCases {
+		req, err := buildAdminRequest(url.Values{}, http.MethodPost, "/service-accounts",
+			int64(len(testCase.body)), strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct add-service-account request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+
+		if rec.Code == http.StatusOK {
+			var cred madmin.ServiceAccountCreds
+			if err = json.NewDecoder(rec.Body).Decode(&cred); err != nil {
+				t.Fatalf("Test %d: Failed to decode service account credentials %v", i+1, err)
+			}
+			creds = append(creds, cred)
+		}
+	}
+
+	// Service accounts must not be allowed to perform admin operations.
+	req, err := newTestRequest(http.MethodGet, "/minio/admin/v1/service-accounts", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct list-service-accounts request - %v", err)
+	}
+	if err = signRequestV4(req, creds[0].AccessKey, creds[0].SecretKey); err != nil {
+		t.Fatalf("Failed to sign list-service-accounts request - %v", err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected service account to be denied admin access, but found `%d`", rec.Code)
+	}
+
+	req, err = buildAdminRequest(url.Values{}, http.MethodGet, "/service-accounts", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct list-service-accounts request - %v", err)
+	}
+	rec = httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected to succeed but failed with %d", rec.Code)
+	}
+
+	var infos []madmin.ServiceAccountInfo
+	if err = json.NewDecoder(rec.Body).Decode(&infos); err != nil {
+		t.Fatalf("Failed to decode service accounts list %v", err)
+	}
+	if len(infos) != len(creds) {
+		t.Fatalf("Expected %d service accounts, found %d", len(creds), len(infos))
+	}
+
+	deleteCases := []struct {
+		accessKey    string
+		expectedCode int
+	}{
+		{creds[0].AccessKey, http.StatusOK},
+		{creds[0].AccessKey, http.StatusNotFound},
+		{creds[1].AccessKey, http.StatusOK},
+	}
+
+	for i, testCase := range deleteCases {
+		req, err = buildAdminRequest(url.Values{}, http.MethodDelete, "/service-accounts/"+testCase.accessKey, 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct delete-service-account request - %v", i+1, err)
+		}
+
+		rec = httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+
+	if serviceAccounts := globalIAMSys.ListServiceAccounts(); len(serviceAccounts) != 0 {
+		t.Errorf("Expected all service accounts to be deleted, found %d", len(serviceAccounts))
+	}
+}
+
+func TestIAMExportImportHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	cred, err := globalIAMSys.NewServiceAccount(adminTestBed.objLayer, globalServerConfig.GetCredential().AccessKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create service account - %v", err)
+	}
+
+	req, err := buildAdminRequest(url.Values{}, http.MethodGet, "/iam/export", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct IAM export request - %v", err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != string(mimeZip) {
+		t.Fatalf("Expected content type %s, got %s", mimeZip, contentType)
+	}
+	archive := rec.Body.Bytes()
+
+	if err = globalIAMSys.DeleteServiceAccount(adminTestBed.objLayer, cred.AccessKey); err != nil {
+		t.Fatalf("Failed to delete service account - %v", err)
+	}
+
+	testCases := []struct {
+		body         []byte
+		expectedCode int
+	}{
+		// 1. Archive made by IAM export.
+		{archive, http.StatusOK},
+		// 2. Body not being a zip archive.
+		{[]byte("not a zip archive"), http.StatusBadRequest},
+	}
+
+	for i, testCase := range testCases {
+		req, err = buildAdminRequest(url.Values{}, http.MethodPut, "/iam/import",
+			int64(len(testCase.body)), bytes.NewReader(testCase.body))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct IAM import request - %v", i+1, err)
+		}
+		rec = httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+
+	if sa, ok := globalIAMSys.GetServiceAccount(cred.AccessKey); !ok || !sa.Credentials.Equal(cred) {
+		t.Fatalf("Expected service account %s to be imported, got %v", cred.AccessKey, sa)
+	}
+}
+
+// TestToAdminAPIErr - test for toAdminAPIErr helper function.
+func TestToAdminAPIErr(t *testing.T) {
+	testCases := []struct {
+		err            error
+		expectedAPIErr APIErrorCode
+	}{
+		// 1. Server not in quorum.
+		{
+			err:            errXLWriteQuorum,
+			expectedAPIErr: ErrAdminConfigNoQuorum,
+		},
+		// 2. No error.
+		{
+			err:            nil,
+			expectedAPIErr: ErrNone,
+		},
+		// 3. Non-admin API specific error.
+		{
+			err:            errDiskNotFound,
+			expectedAPIErr: toAPIErrorCode(errDiskNotFound),
+		},
+	}
+
+	for i, test := range testCases {
+		actualErr := toAdminAPIErrCode(test.err)
+		if actualErr != test.expectedAPIErr {
+			t.Errorf("Test %d: Expected %v but received %v",
+				i+1, test.expectedAPIErr, actualErr)
+		}
+	}
+}
+
+func TestExportImportConfigHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	// Check that an unknown format is rejected.
+	queryVal := url.Values{}
+	queryVal.Set("format", "toml")
+	req, err := buildAdminRequest(queryVal, http.MethodGet, "/config/export", 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct export-config request - %v", err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusBadRequest, rec.Code)
+	}
+
+	for _, format := range []string{configFormatKV, configFormatYAML} {
+		queryVal.Set("format", format)
+		req, err = buildAdminRequest(queryVal, http.MethodGet, "/config/export", 0, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to construct export-config request - %v", format, err)
+		}
+		rec = httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected to succeed but failed with %d", format, rec.Code)
+		}
+		if contentType := rec.Header().Get("Content-Type"); contentType != string(mimeText) {
+			t.Fatalf("%s: Expected content type %s, got %s", format, mimeText, contentType)
+		}
+		data := rec.Body.Bytes()
+
+		// Unknown keys must be rejected without changing the config.
+		invalidData := append([]byte{}, data...)
+		if format == configFormatKV {
+			invalidData = append(invalidData, []byte("regoin=\"us-east-1\"\n")...)
+		} else {
+			invalidData = append(invalidData, []byte("regoin: us-east-1\n")...)
+		}
+		req, err = buildAdminRequest(queryVal, http.MethodPut, "/config/import",
+			int64(len(invalidData)), bytes.NewReader(invalidData))
+		if err != nil {
+			t.Fatalf("%s: Failed to construct import-config request - %v", format, err)
+		}
+		rec = httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "XMinioAdminConfigBadFormat") {
+			t.Fatalf("%s: Got unexpected response code or body %d - %s", format, rec.Code, rec.Body.String())
+		}
+
+		// ImportConfigHandler restarts minio setup - need to start a
+		// signal receiver to receive on globalServiceSignalCh.
+		go testServiceSignalReceiver(restartCmd, t)
+
+		req, err = buildAdminRequest(queryVal, http.MethodPut, "/config/import",
+			int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to construct import-config request - %v", format, err)
+		}
+		rec = httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected to succeed but failed with %d - %s", format, rec.Code, rec.Body.String())
+		}
+
+		result := setConfigResult{}
+		if err = json.NewDecoder(rec.Body).Decode(&result); err != nil {
+			t.Fatalf("%s: Failed to decode import config result json %v", format, err)
+		}
+		if !result.Status {
+			t.Fatalf("%s: Expected import-config to succeed, but failed", format)
+		}
+	}
+}
+
+func TestWriteSetConfigResponse(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootPath)
+	testCases := []struct {
+		status bool
+		errs   []error
+	}{
+		// 1. all nodes returned success.
+		{
+			status: true,
+			errs:   []error{nil, nil, nil, nil},
+		},
+		// 2. some nodes returned errors.
+		{
+			status: false,
+			errs:   []error{errDiskNotFound, nil, errDiskAccessDenied, errFaultyDisk},
+		},
+	}
+
+	testPeers := []adminPeer{
+		{
+			addr: "localhost:9001",
+		},
+		{
+			addr: "localhost:9002",
+		},
+		{
+			addr: "localhost:9003",
+		},
+		{
+			addr: "localhost:9004",
+		},
+	}
+
+	testURL, err := url.Parse("http://dummy.com")
+	if err != nil {
+		t.Fatalf("Failed to parse a place-holder url")
+	}
+
+	var actualResult setConfigResult
+	for i, test := range testCases {
+		rec := httptest.NewRecorder()
+		writeSetConfigResponse(rec, testPeers, test.errs, test.status, testURL)
+		resp := rec.Result()
+		jsonBytes, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to read response %v", i+1, err)
+		}
+
+		err = json.Unmarshal(jsonBytes, &actualResult)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to unmarshal json %v", i+1, err)
+		}
+		if actualResult.Status != test.status {
+			t.Errorf("Test %d: Expected status %v but received %v", i+1, test.status, actualResult.Status)
+		}
+		for p, res := range actualResult.NodeResults {
+			if res.Name != testPeers[p].addr {
+				t.Errorf("Test %d: Expected node name %s but received %s", i+1, testPeers[p].addr, res.Name)
+			}
+			expectedErrMsg := fmt.Sprintf("%v", test.errs[p])
+			if res.ErrMsg != expectedErrMsg {
+				t.Errorf("Test %d: Expected error %s but received %s", i+1, expectedErrMsg, res.ErrMsg)
+			}
+			expectedErrSet := test.errs[p] != nil
+			if res.ErrSet != expectedErrSet {
+				t.Errorf("Test %d: Expected ErrSet %v but received %v", i+1, expectedErrSet, res.ErrSet)
+			}
+		}
+	}
+}
+
+func mkHealStartReq(t *testing.T, bucket, prefix string,
+	opts madmin.HealOpts) *http.Request {
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		t.Fatalf("Unable marshal heal opts")
+	}
+
+	path := fmt.Sprintf("/minio/admin/v1/heal/%s", bucket)
+	if bucket != "" && prefix != "" {
+		path += "/" + prefix
+	}
+
+	req, err := newTestRequest("POST", path,
+		int64(len(body)), bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to construct request - %v", err)
+	}
+	cred := globalServerConfig.GetCredential()
+	err = signRequestV4(req, cred.AccessKey, cred.SecretKey)
+	if err != nil {
+		t.Fatalf("Failed to sign request - %v", err)
+	}
+
+	return req
+}
+
+func mkHealStatusReq(t *testing.T, bucket, prefix,
+	clientToken string) *http.Request {
+
+	path := fmt.Sprintf("/minio/admin/v1/heal/%s", bucket)
+	if bucket != "" && prefix != "" {
+		path += "/" + prefix
+	}
+	path += fmt.Sprintf("?clientToken=%s", clientToken)
+
+	req, err := newTestRequest("POST", path, 0, nil)
+	if err != nil {
+		t.Fatalf("Failed to construct request - %v", err)
+	}
+	cred := globalServerConfig.GetCredential()
+	err = signRequestV4(req, cred.AccessKey, cred.SecretKey)
+	if err != nil {
+		t.Fatalf("Failed to sign request - %v", err)
+	}
+
+	return req
+}
+
+func collectHealResults(t *testing.T, adminTestBed *adminXLTestBed, bucket,
+	prefix, clientToken string, timeLimitSecs int) madmin.HealTaskStatus {
+
+	var res, cur madmin.HealTaskStatus
+
+	// loop and fetch heal status. have a time-limit to loop over
 	// all statuses.
 	timeLimit := UTCNow().Add(time.Second * time.Duration(timeLimitSecs))
 	for cur.Summary != healStoppedStatus && cur.Summary != healFinishedStatus {
@@ -1061,3 +3128,257 @@ func TestHealStartNStatusHandler(t *testing.T) {
 		}
 	}
 }
+
+// TestMultipartUploadsHandlers - test for list and abort stale multipart uploads handlers.
+func TestMultipartUploadsHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(context.Background(), "bucket", ""); err != nil {
+		t.Fatalf("Failed to create bucket - %v", err)
+	}
+	uploadID, err := adminTestBed.objLayer.NewMultipartUpload(context.Background(), "bucket", "object", nil)
+	if err != nil {
+		t.Fatalf("Failed to initiate multipart upload - %v", err)
+	}
+
+	listUploads := func() ClusterMultipartUploads {
+		req, err := buildAdminRequest(url.Values{}, http.MethodGet, "/multipart-uploads", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct list multipart uploads request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+		}
+		var uploads ClusterMultipartUploads
+		if err = json.NewDecoder(rec.Body).Decode(&uploads); err != nil {
+			t.Fatalf("Failed to decode multipart uploads json %v", err)
+		}
+		if len(uploads.Servers) != len(globalAdminPeers) {
+			t.Fatalf("Expected %d servers, got %d", len(globalAdminPeers), len(uploads.Servers))
+		}
+		return uploads
+	}
+
+	uploads := listUploads()
+	if len(uploads.Uploads) != 1 || uploads.Uploads[0].UploadID != uploadID || uploads.Uploads[0].Bucket != "bucket" || uploads.Uploads[0].Object != "object" {
+		t.Fatalf("Expected upload %s of bucket/object, got %v", uploadID, uploads.Uploads)
+	}
+
+	testCases := []struct {
+		olderThan       string
+		expectedCode    int
+		expectedAborted int
+	}{
+		{"", http.StatusBadRequest, 0},
+		{"week", http.StatusBadRequest, 0},
+		{"-1h", http.StatusBadRequest, 0},
+		{"1h", http.StatusOK, 0},
+		{"1ns", http.StatusOK, 1},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("older-than", testCase.olderThan)
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/multipart-uploads/abort-stale", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct abort stale multipart uploads request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var results []AbortStaleMultipartUploadsResult
+		if err = json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("Test %d: Failed to decode abort stale multipart uploads results json %v", i+1, err)
+		}
+		if len(results) != len(globalAdminPeers) {
+			t.Fatalf("Test %d: Expected %d results, got %d", i+1, len(globalAdminPeers), len(results))
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				t.Fatalf("Test %d: Unexpected error aborting multipart uploads of %s: %s", i+1, result.Addr, result.Error)
+			}
+			if result.Aborted != testCase.expectedAborted {
+				t.Fatalf("Test %d: Expected %d aborted uploads, got %d", i+1, testCase.expectedAborted, result.Aborted)
+			}
+		}
+	}
+
+	if uploads = listUploads(); len(uploads.Uploads) != 0 {
+		t.Fatalf("Expected no multipart uploads, got %v", uploads.Uploads)
+	}
+}
+
+// TestFailedEventsHandlers - test for list and replay failed events handlers.
+func TestFailedEventsHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	tmpGlobalNotificationSys := globalNotificationSys
+	defer func() {
+		globalNotificationSys = tmpGlobalNotificationSys
+	}()
+	target := &testNotificationTarget{id: event.TargetID{ID: "1", Name: "webhook"}, down: true}
+	globalNotificationSys = newTestFailedEventsNotificationSys(t, target)
+	globalNotificationSys.send("bucket", event.Event{EventName: event.ObjectCreatedPut}, target.id)
+
+	listEvents := func() []ServerFailedEvents {
+		req, err := buildAdminRequest(url.Values{}, http.MethodGet, "/failed-events", 0, nil)
+		if err != nil {
+			t.Fatalf("Failed to construct list failed events request - %v", err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+		}
+		var servers []ServerFailedEvents
+		if err = json.NewDecoder(rec.Body).Decode(&servers); err != nil {
+			t.Fatalf("Failed to decode failed events json %v", err)
+		}
+		if len(servers) != len(globalAdminPeers) {
+			t.Fatalf("Expected %d servers, got %d", len(globalAdminPeers), len(servers))
+		}
+		return servers
+	}
+
+	if servers := listEvents(); len(servers[0].Data) != 1 || servers[0].Data[0].TargetID != target.id {
+		t.Fatalf("Expected 1 failed event of %s, got %v", target.id, servers[0].Data)
+	}
+
+	testCases := []struct {
+		targetDown bool
+		expected   FailedEventsReplay
+	}{
+		{true, FailedEventsReplay{Failed: 1}},
+		{false, FailedEventsReplay{Replayed: 1}},
+		{false, FailedEventsReplay{}},
+	}
+
+	for i, testCase := range testCases {
+		target.setDown(testCase.targetDown)
+		req, err := buildAdminRequest(url.Values{}, http.MethodPost, "/failed-events/replay", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct replay failed events request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, http.StatusOK, rec.Code)
+		}
+
+		var results []ReplayFailedEventsResult
+		if err = json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("Test %d: Failed to decode replay failed events results json %v", i+1, err)
+		}
+		if len(results) != len(globalAdminPeers) {
+			t.Fatalf("Test %d: Expected %d results, got %d", i+1, len(globalAdminPeers), len(results))
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				t.Fatalf("Test %d: Unexpected error replaying failed events of %s: %s", i+1, result.Addr, result.Error)
+			}
+			if result.FailedEventsReplay != testCase.expected {
+				t.Fatalf("Test %d: Expected %v, got %v", i+1, testCase.expected, result.FailedEventsReplay)
+			}
+		}
+	}
+
+	if servers := listEvents(); len(servers[0].Data) != 0 {
+		t.Fatalf("Expected no failed events, got %v", servers[0].Data)
+	}
+}
+
+// TestForceUnlockHandler - test for force unlock handler.
+func TestForceUnlockHandler(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// Initialize admin peers to make admin RPC calls.
+	globalMinioAddr = "127.0.0.1:9000"
+	initGlobalAdminPeers(mustGetNewEndpointList("http://127.0.0.1:9000/d1"))
+
+	// Lock held by an operation of the object layer which hung.
+	nsMutex := adminTestBed.objLayer.(nsLockMapHolder).getNSLockMap()
+	if err = nsMutex.NewNSLock("bucket", "object").GetLock(newDynamicTimeout(time.Second, time.Second)); err != nil {
+		t.Fatalf("Failed to lock bucket/object - %v", err)
+	}
+
+	testCases := []struct {
+		bucket, object, client string
+		expectedCode           int
+		expectedReleased       int
+	}{
+		{"", "", "", http.StatusBadRequest, 0},
+		{"", "object", "", http.StatusBadRequest, 0},
+		{"bucket", "", "127.0.0.1:9001", http.StatusBadRequest, 0},
+		{"bucket", "object", "", http.StatusOK, 1},
+		{"bucket", "object", "", http.StatusOK, 0},
+		{"", "", "127.0.0.1:9001", http.StatusOK, 0},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		for key, value := range map[string]string{"bucket": testCase.bucket, "object": testCase.object, "client": testCase.client} {
+			if value != "" {
+				queryVal.Set(key, value)
+			}
+		}
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/force-unlock", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to construct force unlock request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var results []ForceUnlockResult
+		if err = json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("Test %d: Failed to decode force unlock results json %v", i+1, err)
+		}
+		if len(results) != len(globalAdminPeers) {
+			t.Fatalf("Test %d: Expected %d results, got %d", i+1, len(globalAdminPeers), len(results))
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				t.Fatalf("Test %d: Unexpected error force unlocking on %s: %s", i+1, result.Addr, result.Error)
+			}
+			if result.Released != testCase.expectedReleased {
+				t.Fatalf("Test %d: Expected %d released locks, got %d", i+1, testCase.expectedReleased, result.Released)
+			}
+		}
+	}
+}
@@ -112,6 +112,18 @@ func initAllHealState(isErasureMode bool) {
 	}
 }
 
+// isHealing - returns true if a heal sequence is running on this server.
+func (ahs *allHealState) isHealing() bool {
+	ahs.Lock()
+	defer ahs.Unlock()
+	for _, h := range ahs.healSeqMap {
+		if !h.hasEnded() {
+			return true
+		}
+	}
+	return false
+}
+
 // getHealSequence - Retrieve a heal sequence by path. The second
 // argument returns if a heal sequence actually exists.
 func (ahs *allHealState) getHealSequence(path string) (h *healSequence, exists bool) {
@@ -547,12 +559,27 @@ func (h *healSequence) healDiskFormat() error {
 
 	// Healing succeeded notify the peers to reload format and re-initialize disks.
 	// We will not notify peers only if healing succeeded.
+	quorum, peersErr := true, error(nil)
 	if err == nil {
-		peersReInitFormat(globalAdminPeers, h.settings.DryRun)
+		quorum, peersErr = peersReInitFormat(globalAdminPeers, h.settings.DryRun)
+	}
+
+	// Nodes which failed to re-initialize their format are always
+	// reported in the format heal result.
+	if peersErr != nil {
+		res.Detail = peersErr.Error()
 	}
 
 	// Push format heal result
-	return h.pushHealResultItem(res)
+	if err = h.pushHealResultItem(res); err != nil {
+		return err
+	}
+
+	// Healing fails if a majority of nodes did not re-initialize.
+	if !quorum {
+		return peersErr
+	}
+	return nil
 }
 
 // healBuckets - check for all buckets heal or just particular bucket.
@@ -645,6 +672,10 @@ func (h *healSequence) healBucket(bucket string) error {
 
 // healObject - heal the given object and record result
 func (h *healSequence) healObject(bucket, object string) error {
+	// Throttle healing according to the scanner speed.
+	if !waitForScanner(h.stopSignalCh) {
+		return errHealStopSignalled
+	}
 	if h.isQuitting() {
 		return errHealStopSignalled
 	}
@@ -52,6 +52,136 @@ func registerAdminRouter(router *mux.Router) {
 
 	// Info operations
 	adminV1Router.Methods(http.MethodGet).Path("/info").HandlerFunc(httpTraceAll(adminAPI.ServerInfoHandler))
+	// Cluster health
+	adminV1Router.Methods(http.MethodGet).Path("/health").HandlerFunc(httpTraceAll(adminAPI.ClusterHealthHandler))
+	// Per bucket bandwidth
+	adminV1Router.Methods(http.MethodGet).Path("/bandwidth").HandlerFunc(httpTraceAll(adminAPI.BandwidthInfoHandler))
+	// Get per API call statistics
+	adminV1Router.Methods(http.MethodGet).Path("/top/api").HandlerFunc(httpTraceAll(adminAPI.TopAPIHandler))
+	// Go runtime metrics
+	adminV1Router.Methods(http.MethodGet).Path("/runtime").HandlerFunc(httpTraceAll(adminAPI.RuntimeInfoHandler))
+	// S3 API calls in progress
+	adminV1Router.Methods(http.MethodGet).Path("/sessions").HandlerFunc(httpTraceAll(adminAPI.ListSessionsHandler))
+	// Per node maintenance mode
+	adminV1Router.Methods(http.MethodPost).Path("/maintenance").HandlerFunc(httpTraceAll(adminAPI.MaintenanceHandler))
+
+	// Health diagnostics
+	adminV1Router.Methods(http.MethodGet).Path("/healthinfo").HandlerFunc(httpTraceHdrs(adminAPI.HealthInfoHandler))
+
+	// Network performance
+	adminV1Router.Methods(http.MethodGet).Path("/netperf").HandlerFunc(httpTraceAll(adminAPI.NetPerfHandler))
+
+	// Drive performance
+	adminV1Router.Methods(http.MethodGet).Path("/driveperf").HandlerFunc(httpTraceAll(adminAPI.DrivePerfHandler))
+	// Download raw erasure metadata of an object
+	adminV1Router.Methods(http.MethodGet).Path("/inspect").HandlerFunc(httpTraceHdrs(adminAPI.InspectHandler))
+
+	// Update all servers and restart them
+	adminV1Router.Methods(http.MethodPost).Path("/update").HandlerFunc(httpTraceAll(adminAPI.ServerUpdateHandler))
+
+	// Reload TLS certificates of all servers
+	adminV1Router.Methods(http.MethodPost).Path("/reload-certs").HandlerFunc(httpTraceAll(adminAPI.ReloadCertsHandler))
+
+	// Change log verbosity of a subsystem on all servers
+	adminV1Router.Methods(http.MethodPost).Path("/log-level").HandlerFunc(httpTraceAll(adminAPI.SetLogLevelHandler))
+
+	// Change pace of background scans on all servers
+	adminV1Router.Methods(http.MethodPost).Path("/scanner-speed").HandlerFunc(httpTraceAll(adminAPI.SetScannerSpeedHandler))
+
+	/// Batch job operations
+
+	// Start a batch job on all servers
+	adminV1Router.Methods(http.MethodPost).Path("/batch-job/start").HandlerFunc(httpTraceHdrs(adminAPI.StartBatchJobHandler))
+	// Describe a batch job
+	adminV1Router.Methods(http.MethodGet).Path("/batch-job/describe").HandlerFunc(httpTraceAll(adminAPI.DescribeBatchJobHandler))
+	// Cancel a batch job on all servers
+	adminV1Router.Methods(http.MethodPost).Path("/batch-job/cancel").HandlerFunc(httpTraceAll(adminAPI.CancelBatchJobHandler))
+	// List batch jobs
+	adminV1Router.Methods(http.MethodGet).Path("/batch-job/list").HandlerFunc(httpTraceAll(adminAPI.ListBatchJobsHandler))
+
+	/// Multipart upload operations
+
+	// List in-progress multipart uploads of all servers
+	adminV1Router.Methods(http.MethodGet).Path("/multipart-uploads").HandlerFunc(httpTraceAll(adminAPI.ListMultipartUploadsHandler))
+	// Abort stale multipart uploads on all servers
+	adminV1Router.Methods(http.MethodPost).Path("/multipart-uploads/abort-stale").HandlerFunc(httpTraceAll(adminAPI.AbortStaleMultipartUploadsHandler))
+
+	/// Lock operations
+
+	// Release stuck locks on all servers
+	adminV1Router.Methods(http.MethodPost).Path("/force-unlock").HandlerFunc(httpTraceAll(adminAPI.ForceUnlockHandler))
+
+	/// Bucket notification operations
+
+	// List events all servers failed to deliver
+	adminV1Router.Methods(http.MethodGet).Path("/failed-events").HandlerFunc(httpTraceAll(adminAPI.ListFailedEventsHandler))
+	// Send failed events again to their targets
+	adminV1Router.Methods(http.MethodPost).Path("/failed-events/replay").HandlerFunc(httpTraceAll(adminAPI.ReplayFailedEventsHandler))
+
+	/// Bucket quota operations
+
+	// Set bucket quota
+	adminV1Router.Methods(http.MethodPut).Path("/set-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.PutBucketQuotaConfigHandler))
+	// Get bucket quota
+	adminV1Router.Methods(http.MethodGet).Path("/get-bucket-quota").HandlerFunc(httpTraceAll(adminAPI.GetBucketQuotaConfigHandler))
+
+	/// Bucket access operations
+
+	// Set canned anonymous access of a bucket
+	adminV1Router.Methods(http.MethodPut).Path("/set-bucket-access").HandlerFunc(httpTraceHdrs(adminAPI.PutBucketAccessHandler))
+	// Get canned anonymous access of a bucket
+	adminV1Router.Methods(http.MethodGet).Path("/get-bucket-access").HandlerFunc(httpTraceAll(adminAPI.GetBucketAccessHandler))
+
+	/// Bucket default tags operations
+
+	// Set default tags of new objects of a bucket
+	adminV1Router.Methods(http.MethodPut).Path("/set-bucket-default-tags").HandlerFunc(httpTraceHdrs(adminAPI.PutBucketDefaultTagsHandler))
+	// Get default tags of new objects of a bucket
+	adminV1Router.Methods(http.MethodGet).Path("/get-bucket-default-tags").HandlerFunc(httpTraceAll(adminAPI.GetBucketDefaultTagsHandler))
+
+	/// Bucket integrity operations
+
+	// Set strict integrity mode of a bucket
+	adminV1Router.Methods(http.MethodPut).Path("/set-bucket-integrity").HandlerFunc(httpTraceHdrs(adminAPI.PutBucketIntegrityHandler))
+	// Get strict integrity mode of a bucket
+	adminV1Router.Methods(http.MethodGet).Path("/get-bucket-integrity").HandlerFunc(httpTraceAll(adminAPI.GetBucketIntegrityHandler))
+
+	/// Bucket metadata index operations
+
+	// Enable or disable the metadata index of a bucket
+	adminV1Router.Methods(http.MethodPut).Path("/set-bucket-metadata-index").HandlerFunc(httpTraceHdrs(adminAPI.PutBucketMetadataIndexHandler))
+	// Get the status of the metadata index of a bucket
+	adminV1Router.Methods(http.MethodGet).Path("/get-bucket-metadata-index").HandlerFunc(httpTraceAll(adminAPI.GetBucketMetadataIndexHandler))
+
+	/// Bucket policy history operations
+
+	// List prior access policies of a bucket
+	adminV1Router.Methods(http.MethodGet).Path("/list-bucket-policy-versions").HandlerFunc(httpTraceAll(adminAPI.ListBucketPolicyVersionsHandler))
+	// Restore a prior access policy of a bucket
+	adminV1Router.Methods(http.MethodPost).Path("/restore-bucket-policy-version").HandlerFunc(httpTraceAll(adminAPI.RestoreBucketPolicyVersionHandler))
+
+	/// Remote target operations
+
+	// Set remote target
+	adminV1Router.Methods(http.MethodPut).Path("/set-remote-target").HandlerFunc(httpTraceHdrs(adminAPI.SetRemoteTargetHandler))
+	// List remote targets
+	adminV1Router.Methods(http.MethodGet).Path("/list-remote-targets").HandlerFunc(httpTraceAll(adminAPI.ListRemoteTargetsHandler))
+	// Remove remote target
+	adminV1Router.Methods(http.MethodDelete).Path("/remove-remote-target").HandlerFunc(httpTraceAll(adminAPI.RemoveRemoteTargetHandler))
+
+	/// Tier operations
+
+	// Add tier
+	adminV1Router.Methods(http.MethodPut).Path("/tier").HandlerFunc(httpTraceHdrs(adminAPI.AddTierHandler))
+	// List tiers
+	adminV1Router.Methods(http.MethodGet).Path("/tier").HandlerFunc(httpTraceAll(adminAPI.ListTiersHandler))
+	// Edit tier credentials
+	adminV1Router.Methods(http.MethodPost).Path("/tier/{tier}").HandlerFunc(httpTraceHdrs(adminAPI.EditTierHandler))
+
+	/// KMS operations
+
+	// KMS key status
+	adminV1Router.Methods(http.MethodGet).Path("/kms/key/status").HandlerFunc(httpTraceAll(adminAPI.KMSKeyStatusHandler))
 
 	/// Heal operations
 
@@ -64,8 +194,25 @@ func registerAdminRouter(router *mux.Router) {
 
 	// Update credentials
 	adminV1Router.Methods(http.MethodPut).Path("/config/credential").HandlerFunc(httpTraceAll(adminAPI.UpdateCredentialsHandler))
+	// Export config as key=value lines or YAML
+	adminV1Router.Methods(http.MethodGet).Path("/config/export").HandlerFunc(httpTraceHdrs(adminAPI.ExportConfigHandler))
+	// Import config from key=value lines or YAML
+	adminV1Router.Methods(http.MethodPut).Path("/config/import").HandlerFunc(httpTraceHdrs(adminAPI.ImportConfigHandler))
 	// Get config
 	adminV1Router.Methods(http.MethodGet).Path("/config").HandlerFunc(httpTraceAll(adminAPI.GetConfigHandler))
 	// Set config
 	adminV1Router.Methods(http.MethodPut).Path("/config").HandlerFunc(httpTraceAll(adminAPI.SetConfigHandler))
+
+	/// IAM operations
+
+	// Add service account
+	adminV1Router.Methods(http.MethodPost).Path("/service-accounts").HandlerFunc(httpTraceHdrs(adminAPI.AddServiceAccountHandler))
+	// List service accounts
+	adminV1Router.Methods(http.MethodGet).Path("/service-accounts").HandlerFunc(httpTraceAll(adminAPI.ListServiceAccountsHandler))
+	// Delete service account
+	adminV1Router.Methods(http.MethodDelete).Path("/service-accounts/{accessKey}").HandlerFunc(httpTraceAll(adminAPI.DeleteServiceAccountHandler))
+	// Export service accounts as a zip archive
+	adminV1Router.Methods(http.MethodGet).Path("/iam/export").HandlerFunc(httpTraceHdrs(adminAPI.ExportIAMHandler))
+	// Import service accounts from a zip archive
+	adminV1Router.Methods(http.MethodPut).Path("/iam/import").HandlerFunc(httpTraceHdrs(adminAPI.ImportIAMHandler))
 }
@@ -0,0 +1,161 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var errPeerOffline = errors.New("peer is offline")
+
+var (
+	// Number of consecutive failed calls after which a peer is
+	// considered offline.
+	adminRPCBreakerThreshold = 2
+
+	// Time during which calls to an offline peer fail fast, a single
+	// trial call is let through afterwards.
+	adminRPCBreakerCoolDown = 30 * time.Second
+
+	// Wait before the first retry of a call, doubled for each retry.
+	adminRPCRetryBackoff = 250 * time.Millisecond
+)
+
+// Deadline of admin RPC calls running benchmarks or downloads, which
+// take longer than globalAdminRPCTimeout.
+const adminRPCLongCallTimeout = 15 * time.Minute
+
+// adminRPCCallOpts - deadline and retry policy of an admin RPC call.
+type adminRPCCallOpts struct {
+	timeout    time.Duration
+	idempotent bool
+}
+
+// getAdminRPCCallOpts - returns the policy of the given method. Calls
+// which only read state or can be repeated without changing the outcome
+// are idempotent and retried after network errors, other calls are
+// made once.
+func getAdminRPCCallOpts(method string) adminRPCCallOpts {
+	switch method {
+	case "ServerInfo", "BandwidthInfo", "TopAPI", "RuntimeInfo", "KMSKeyStatus", "GetConfig", "Inspect", "WriteTmpConfig", "ReloadCerts", "SetLogLevel",
+		"StartBatchJob", "DescribeBatchJob", "CancelBatchJob", "ListBatchJobs", "SetScannerSpeed",
+		"ListMultipartUploads", "AbortStaleMultipartUploads", "ListFailedEvents", "ForceUnlock", "ListSessions",
+		"ValidateConfig", "MaintenanceExit":
+		return adminRPCCallOpts{timeout: globalAdminRPCTimeout, idempotent: true}
+	case "HealthInfo", "MaintenanceEnter":
+		return adminRPCCallOpts{timeout: adminRPCLongCallTimeout, idempotent: true}
+	case "NetPerf", "DrivePerf", "ServerUpdate":
+		return adminRPCCallOpts{timeout: adminRPCLongCallTimeout}
+	default:
+		return adminRPCCallOpts{timeout: globalAdminRPCTimeout}
+	}
+}
+
+// circuitBreaker - tracks failed calls to a peer. After threshold
+// consecutive failures the peer is considered offline and calls fail
+// fast during coolDown, then one trial call decides whether the peer
+// is back online.
+type circuitBreaker struct {
+	sync.Mutex
+	threshold int
+	coolDown  time.Duration
+	failures  int
+	openedAt  time.Time
+}
+
+// allow - returns true if a call to the peer may be made.
+func (cb *circuitBreaker) allow() bool {
+	cb.Lock()
+	defer cb.Unlock()
+
+	if cb.failures < cb.threshold {
+		return true
+	}
+
+	// Let a single trial call through once coolDown has passed,
+	// concurrent calls keep failing fast until it completes.
+	if UTCNow().Sub(cb.openedAt) >= cb.coolDown {
+		cb.openedAt = UTCNow()
+		return true
+	}
+	return false
+}
+
+// succeeded - records a call which reached the peer.
+func (cb *circuitBreaker) succeeded() {
+	cb.Lock()
+	defer cb.Unlock()
+
+	cb.failures = 0
+}
+
+// failed - records a call which did not reach the peer.
+func (cb *circuitBreaker) failed() {
+	cb.Lock()
+	defer cb.Unlock()
+
+	cb.failures++
+	if cb.failures >= cb.threshold {
+		cb.openedAt = UTCNow()
+	}
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{
+		threshold: adminRPCBreakerThreshold,
+		coolDown:  adminRPCBreakerCoolDown,
+	}
+}
+
+// callWithRetry - makes a call through fn under the deadline of opts,
+// retrying idempotent calls with exponential backoff after network
+// errors. Calls fail with errPeerOffline without being made while cb
+// considers the peer offline.
+func callWithRetry(cb *circuitBreaker, opts adminRPCCallOpts, fn func(ctx context.Context) error) error {
+	if !cb.allow() {
+		return errPeerOffline
+	}
+
+	retries := 0
+	if opts.idempotent {
+		retries = globalAdminRPCMaxRetries
+	}
+
+	backoff := adminRPCRetryBackoff
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+		err := fn(ctx)
+		cancel()
+
+		if !isNetError(err) {
+			// The peer replied, errors are returned as is.
+			cb.succeeded()
+			return err
+		}
+
+		if attempt >= retries {
+			cb.failed()
+			return err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	cb := &circuitBreaker{threshold: 2, coolDown: time.Hour}
+
+	cb.failed()
+	if !cb.allow() {
+		t.Fatal("expected calls to be allowed below threshold")
+	}
+	cb.succeeded()
+	cb.failed()
+	if !cb.allow() {
+		t.Fatal("expected failures to be reset after a successful call")
+	}
+
+	cb.failed()
+	if cb.allow() {
+		t.Fatal("expected calls to fail fast after threshold failures")
+	}
+
+	// Only one trial call is let through after coolDown.
+	cb.openedAt = UTCNow().Add(-2 * time.Hour)
+	if !cb.allow() {
+		t.Fatal("expected a trial call after cool down")
+	}
+	if cb.allow() {
+		t.Fatal("expected calls to fail fast during the trial call")
+	}
+
+	cb.succeeded()
+	if !cb.allow() {
+		t.Fatal("expected calls to be allowed after a successful trial call")
+	}
+}
+
+func TestCallWithRetry(t *testing.T) {
+	tmpRetryBackoff := adminRPCRetryBackoff
+	tmpMaxRetries := globalAdminRPCMaxRetries
+	defer func() {
+		adminRPCRetryBackoff = tmpRetryBackoff
+		globalAdminRPCMaxRetries = tmpMaxRetries
+	}()
+	adminRPCRetryBackoff = time.Millisecond
+	globalAdminRPCMaxRetries = 2
+
+	netErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	appErr := errors.New("application error")
+
+	testCases := []struct {
+		idempotent    bool
+		errs          []error
+		expectedCalls int
+		expectedErr   error
+		expectFailure bool
+	}{
+		// Successful call.
+		{true, []error{nil}, 1, nil, false},
+		// Errors returned by the peer are not retried.
+		{true, []error{appErr}, 1, appErr, false},
+		// Idempotent calls are retried after network errors.
+		{true, []error{netErr, netErr, nil}, 3, nil, false},
+		{true, []error{netErr, netErr, netErr}, 3, netErr, true},
+		// Other calls are made once.
+		{false, []error{netErr}, 1, netErr, true},
+	}
+
+	for i, testCase := range testCases {
+		cb := &circuitBreaker{threshold: 1, coolDown: time.Hour}
+		calls := 0
+		err := callWithRetry(cb, adminRPCCallOpts{timeout: time.Minute, idempotent: testCase.idempotent}, func(ctx context.Context) error {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Errorf("case %v: expected call deadline", i+1)
+			}
+			err := testCase.errs[calls]
+			calls++
+			return err
+		})
+
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if calls != testCase.expectedCalls {
+			t.Fatalf("case %v: expected %v calls, got: %v", i+1, testCase.expectedCalls, calls)
+		}
+		if offline := !cb.allow(); offline != testCase.expectFailure {
+			t.Fatalf("case %v: expected peer offline: %v, got: %v", i+1, testCase.expectFailure, offline)
+		}
+	}
+}
+
+// Tests that calls to an unreachable peer fail fast once it is considered offline.
+func TestAdminRPCClientOffline(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	tmpRetryBackoff := adminRPCRetryBackoff
+	defer func() {
+		adminRPCRetryBackoff = tmpRetryBackoff
+	}()
+	adminRPCRetryBackoff = time.Millisecond
+
+	for i := 0; i < adminRPCBreakerThreshold; i++ {
+		if _, err := rpcClient.ServerInfo(); err == nil || err == errPeerOffline {
+			t.Fatalf("case %v: expected network error, got: %v", i+1, err)
+		}
+	}
+
+	if _, err := rpcClient.ServerInfo(); err != errPeerOffline {
+		t.Fatalf("expected: %v, got: %v", errPeerOffline, err)
+	}
+	if err := rpcClient.ReInitFormat(true); err != errPeerOffline {
+		t.Fatalf("expected: %v, got: %v", errPeerOffline, err)
+	}
+}
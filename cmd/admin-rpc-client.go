@@ -31,11 +31,21 @@ import (
 	xnet "github.com/minio/minio/pkg/net"
 )
 
-var errUnsupportedSignal = fmt.Errorf("unsupported signal: only restart and stop signals are supported")
+var errUnsupportedSignal = fmt.Errorf("unsupported signal: only restart, stop, freeze and unfreeze signals are supported")
 
 // AdminRPCClient - admin RPC client talks to admin RPC server.
 type AdminRPCClient struct {
 	*RPCClient
+	breaker *circuitBreaker
+}
+
+// call - calls the admin RPC method under its deadline and retry policy.
+func (rpcClient *AdminRPCClient) call(method string, args interface {
+	SetAuthArgs(args AuthArgs)
+}, reply interface{}) error {
+	return callWithRetry(rpcClient.breaker, getAdminRPCCallOpts(method), func(ctx context.Context) error {
+		return rpcClient.CallWithContext(ctx, adminServiceName+"."+method, args, reply)
+	})
 }
 
 // SignalService - calls SignalService RPC.
@@ -43,7 +53,7 @@ func (rpcClient *AdminRPCClient) SignalService(signal serviceSignal) (err error)
 	args := SignalServiceArgs{Sig: signal}
 	reply := VoidReply{}
 
-	return rpcClient.Call(adminServiceName+".SignalService", &args, &reply)
+	return rpcClient.call("SignalService", &args, &reply)
 }
 
 // ReInitFormat - re-initialize disk format, remotely.
@@ -51,21 +61,195 @@ func (rpcClient *AdminRPCClient) ReInitFormat(dryRun bool) error {
 	args := ReInitFormatArgs{DryRun: dryRun}
 	reply := VoidReply{}
 
-	return rpcClient.Call(adminServiceName+".ReInitFormat", &args, &reply)
+	return rpcClient.call("ReInitFormat", &args, &reply)
 }
 
 // ServerInfo - returns the server info of the server to which the RPC call is made.
 func (rpcClient *AdminRPCClient) ServerInfo() (sid ServerInfoData, err error) {
-	err = rpcClient.Call(adminServiceName+".ServerInfo", &AuthArgs{}, &sid)
+	err = rpcClient.call("ServerInfo", &AuthArgs{}, &sid)
 	return sid, err
 }
 
+// BandwidthInfo - returns the per bucket bandwidth of the server to which the RPC call is made.
+func (rpcClient *AdminRPCClient) BandwidthInfo() (sbd ServerBandwidthData, err error) {
+	err = rpcClient.call("BandwidthInfo", &AuthArgs{}, &sbd)
+	return sbd, err
+}
+
+// TopAPI - returns the per API call statistics of the server to which the RPC call is made.
+func (rpcClient *AdminRPCClient) TopAPI() (apiStats map[string]APIStats, err error) {
+	err = rpcClient.call("TopAPI", &AuthArgs{}, &apiStats)
+	return apiStats, err
+}
+
+// RuntimeInfo - returns the Go runtime metrics of the server to which the RPC call is made.
+func (rpcClient *AdminRPCClient) RuntimeInfo() (data ServerRuntimeData, err error) {
+	err = rpcClient.call("RuntimeInfo", &AuthArgs{}, &data)
+	return data, err
+}
+
+// KMSKeyStatus - verifies the KMS master key on the remote server.
+func (rpcClient *AdminRPCClient) KMSKeyStatus(keyID string) (status KMSKeyStatus, err error) {
+	args := KMSKeyStatusArgs{KeyID: keyID}
+	err = rpcClient.call("KMSKeyStatus", &args, &status)
+	return status, err
+}
+
+// HealthInfo - returns health diagnostics of the remote server.
+func (rpcClient *AdminRPCClient) HealthInfo() (shid ServerHealthInfoData, err error) {
+	err = rpcClient.call("HealthInfo", &AuthArgs{}, &shid)
+	return shid, err
+}
+
+// SendPayload - sends the payload to the remote server, used to
+// measure network throughput.
+func (rpcClient *AdminRPCClient) SendPayload(payload []byte) error {
+	args := SendPayloadArgs{Payload: payload}
+	reply := VoidReply{}
+
+	return rpcClient.call("SendPayload", &args, &reply)
+}
+
+// NetPerf - runs network tests from the remote server to all other servers.
+func (rpcClient *AdminRPCClient) NetPerf(size int64) (netPerf []NetPerfInfo, err error) {
+	args := NetPerfArgs{Size: size}
+	err = rpcClient.call("NetPerf", &args, &netPerf)
+	return netPerf, err
+}
+
+// DrivePerf - runs drive tests on the local drives of the remote server.
+func (rpcClient *AdminRPCClient) DrivePerf(size int64) (drivesPerf []DriveSpeedInfo, err error) {
+	args := DrivePerfArgs{Size: size}
+	err = rpcClient.call("DrivePerf", &args, &drivesPerf)
+	return drivesPerf, err
+}
+
+// Inspect - collects erasure metadata of an object from the local drives of the remote server.
+func (rpcClient *AdminRPCClient) Inspect(bucket, object string) (drivesData []InspectDriveData, err error) {
+	args := InspectArgs{Bucket: bucket, Object: object}
+	err = rpcClient.call("Inspect", &args, &drivesData)
+	return drivesData, err
+}
+
+// ServerUpdate - updates the binary of the remote server.
+func (rpcClient *AdminRPCClient) ServerUpdate(updateURL string, sha256Sum []byte) error {
+	args := ServerUpdateArgs{UpdateURL: updateURL, Sha256Sum: sha256Sum}
+	reply := VoidReply{}
+	return rpcClient.call("ServerUpdate", &args, &reply)
+}
+
+// ReloadCerts - reloads the TLS certificate of the remote server.
+func (rpcClient *AdminRPCClient) ReloadCerts() error {
+	args := AuthArgs{}
+	reply := VoidReply{}
+	return rpcClient.call("ReloadCerts", &args, &reply)
+}
+
+// SetLogLevel - changes the log verbosity of a subsystem of the remote server.
+func (rpcClient *AdminRPCClient) SetLogLevel(subsystem logger.Subsystem, level logger.Level) error {
+	args := SetLogLevelArgs{Subsystem: subsystem, Level: level}
+	reply := VoidReply{}
+	return rpcClient.call("SetLogLevel", &args, &reply)
+}
+
+// StartBatchJob - starts processing the share of a batch job of the remote server.
+func (rpcClient *AdminRPCClient) StartBatchJob(job BatchJob, nodeIndex int) error {
+	args := StartBatchJobArgs{Job: job, NodeIndex: nodeIndex}
+	reply := VoidReply{}
+	return rpcClient.call("StartBatchJob", &args, &reply)
+}
+
+// DescribeBatchJob - returns the progress of a batch job on the remote server.
+func (rpcClient *AdminRPCClient) DescribeBatchJob(id string) (progress BatchJobProgress, err error) {
+	args := BatchJobArgs{ID: id}
+	err = rpcClient.call("DescribeBatchJob", &args, &progress)
+	return progress, err
+}
+
+// CancelBatchJob - stops a batch job on the remote server.
+func (rpcClient *AdminRPCClient) CancelBatchJob(id string) error {
+	args := BatchJobArgs{ID: id}
+	reply := VoidReply{}
+	return rpcClient.call("CancelBatchJob", &args, &reply)
+}
+
+// ListBatchJobs - returns the progress of all batch jobs of the remote server.
+func (rpcClient *AdminRPCClient) ListBatchJobs() (progress []BatchJobProgress, err error) {
+	args := AuthArgs{}
+	err = rpcClient.call("ListBatchJobs", &args, &progress)
+	return progress, err
+}
+
+// SetScannerSpeed - changes the pace of background scans of the remote server.
+func (rpcClient *AdminRPCClient) SetScannerSpeed(speed ScannerSpeed) error {
+	args := SetScannerSpeedArgs{Speed: speed}
+	reply := VoidReply{}
+	return rpcClient.call("SetScannerSpeed", &args, &reply)
+}
+
+// ListMultipartUploads - returns the multipart uploads found on the drives of the remote server.
+func (rpcClient *AdminRPCClient) ListMultipartUploads() (uploads []MultipartUploadInfo, err error) {
+	args := AuthArgs{}
+	err = rpcClient.call("ListMultipartUploads", &args, &uploads)
+	return uploads, err
+}
+
+// AbortStaleMultipartUploads - removes multipart uploads older than olderThan from the drives of the remote server.
+func (rpcClient *AdminRPCClient) AbortStaleMultipartUploads(olderThan time.Duration) (aborted int, err error) {
+	args := AbortStaleMultipartUploadsArgs{OlderThan: olderThan}
+	err = rpcClient.call("AbortStaleMultipartUploads", &args, &aborted)
+	return aborted, err
+}
+
+// ListFailedEvents - returns the events the remote server failed to deliver.
+func (rpcClient *AdminRPCClient) ListFailedEvents() (events []FailedEvent, err error) {
+	args := AuthArgs{}
+	err = rpcClient.call("ListFailedEvents", &args, &events)
+	return events, err
+}
+
+// ReplayFailedEvents - sends failed events of the remote server again to their targets.
+func (rpcClient *AdminRPCClient) ReplayFailedEvents(id string) (replay FailedEventsReplay, err error) {
+	args := ReplayFailedEventsArgs{ID: id}
+	err = rpcClient.call("ReplayFailedEvents", &args, &replay)
+	return replay, err
+}
+
+// ForceUnlock - releases locks held on bucket/object, or by client, on the remote server.
+func (rpcClient *AdminRPCClient) ForceUnlock(bucket, object, client string) (released int, err error) {
+	args := ForceUnlockArgs{Bucket: bucket, Object: object, Client: client}
+	err = rpcClient.call("ForceUnlock", &args, &released)
+	return released, err
+}
+
+// ListSessions - returns the S3 API calls in progress on the remote server.
+func (rpcClient *AdminRPCClient) ListSessions() (sessions []SessionInfo, err error) {
+	args := AuthArgs{}
+	err = rpcClient.call("ListSessions", &args, &sessions)
+	return sessions, err
+}
+
+// MaintenanceEnter - puts the remote server in maintenance, returns the
+// number of S3 calls still in progress once draining ended.
+func (rpcClient *AdminRPCClient) MaintenanceEnter(drainTimeout time.Duration) (inFlight int, err error) {
+	args := MaintenanceEnterArgs{DrainTimeout: drainTimeout}
+	err = rpcClient.call("MaintenanceEnter", &args, &inFlight)
+	return inFlight, err
+}
+
+// MaintenanceExit - makes the remote server leave maintenance.
+func (rpcClient *AdminRPCClient) MaintenanceExit() error {
+	args := AuthArgs{}
+	reply := VoidReply{}
+	return rpcClient.call("MaintenanceExit", &args, &reply)
+}
+
 // GetConfig - returns config.json of the remote server.
 func (rpcClient *AdminRPCClient) GetConfig() ([]byte, error) {
 	args := AuthArgs{}
 	var reply []byte
 
-	err := rpcClient.Call(adminServiceName+".GetConfig", &args, &reply)
+	err := rpcClient.call("GetConfig", &args, &reply)
 	return reply, err
 }
 
@@ -77,7 +261,7 @@ func (rpcClient *AdminRPCClient) WriteTmpConfig(tmpFileName string, configBytes
 	}
 	reply := VoidReply{}
 
-	err := rpcClient.Call(adminServiceName+".WriteTmpConfig", &args, &reply)
+	err := rpcClient.call("WriteTmpConfig", &args, &reply)
 	logger.LogIf(context.Background(), err)
 	return err
 }
@@ -87,11 +271,18 @@ func (rpcClient *AdminRPCClient) CommitConfig(tmpFileName string) error {
 	args := CommitConfigArgs{FileName: tmpFileName}
 	reply := VoidReply{}
 
-	err := rpcClient.Call(adminServiceName+".CommitConfig", &args, &reply)
+	err := rpcClient.call("CommitConfig", &args, &reply)
 	logger.LogIf(context.Background(), err)
 	return err
 }
 
+// ValidateConfig - checks that the remote node can use the candidate config.
+func (rpcClient *AdminRPCClient) ValidateConfig(configBytes []byte) (validationErrs []ConfigValidationError, err error) {
+	args := ValidateConfigArgs{Buf: configBytes}
+	err = rpcClient.call("ValidateConfig", &args, &validationErrs)
+	return validationErrs, err
+}
+
 // NewAdminRPCClient - returns new admin RPC client.
 func NewAdminRPCClient(host *xnet.Host) (*AdminRPCClient, error) {
 	scheme := "http"
@@ -126,7 +317,7 @@ func NewAdminRPCClient(host *xnet.Host) (*AdminRPCClient, error) {
 		return nil, err
 	}
 
-	return &AdminRPCClient{rpcClient}, nil
+	return &AdminRPCClient{rpcClient, newCircuitBreaker()}, nil
 }
 
 // adminCmdRunner - abstracts local and remote execution of admin
@@ -135,9 +326,35 @@ type adminCmdRunner interface {
 	SignalService(s serviceSignal) error
 	ReInitFormat(dryRun bool) error
 	ServerInfo() (ServerInfoData, error)
+	BandwidthInfo() (ServerBandwidthData, error)
+	TopAPI() (map[string]APIStats, error)
+	RuntimeInfo() (ServerRuntimeData, error)
+	KMSKeyStatus(keyID string) (KMSKeyStatus, error)
+	HealthInfo() (ServerHealthInfoData, error)
+	SendPayload(payload []byte) error
+	NetPerf(size int64) ([]NetPerfInfo, error)
+	DrivePerf(size int64) ([]DriveSpeedInfo, error)
+	Inspect(bucket, object string) ([]InspectDriveData, error)
+	ServerUpdate(updateURL string, sha256Sum []byte) error
+	ReloadCerts() error
+	SetLogLevel(subsystem logger.Subsystem, level logger.Level) error
+	StartBatchJob(job BatchJob, nodeIndex int) error
+	DescribeBatchJob(id string) (BatchJobProgress, error)
+	CancelBatchJob(id string) error
+	ListBatchJobs() ([]BatchJobProgress, error)
+	SetScannerSpeed(speed ScannerSpeed) error
+	ListMultipartUploads() ([]MultipartUploadInfo, error)
+	AbortStaleMultipartUploads(olderThan time.Duration) (int, error)
+	ListFailedEvents() ([]FailedEvent, error)
+	ReplayFailedEvents(id string) (FailedEventsReplay, error)
+	ForceUnlock(bucket, object, client string) (int, error)
+	ListSessions() ([]SessionInfo, error)
+	MaintenanceEnter(drainTimeout time.Duration) (int, error)
+	MaintenanceExit() error
 	GetConfig() ([]byte, error)
 	WriteTmpConfig(tmpFileName string, configBytes []byte) error
 	CommitConfig(tmpFileName string) error
+	ValidateConfig(configBytes []byte) ([]ConfigValidationError, error)
 }
 
 // adminPeer - represents an entity that implements admin API RPCs.
@@ -177,8 +394,55 @@ func makeAdminPeers(endpoints EndpointList) (adminPeerList adminPeers) {
 	return adminPeerList
 }
 
-// peersReInitFormat - reinitialize remote object layers to new format.
-func peersReInitFormat(peers adminPeers, dryRun bool) error {
+// PeersError - failure of an admin operation on some nodes, holding
+// the error returned by each failed node keyed by its address.
+type PeersError struct {
+	Op   string
+	Errs map[string]error
+}
+
+func (e PeersError) Error() string {
+	addrs := make([]string, 0, len(e.Errs))
+	for addr := range e.Errs {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	nodeErrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		nodeErrs[i] = addr + ": " + e.Errs[addr].Error()
+	}
+	return fmt.Sprintf("%s failed on %d node(s): %s", e.Op, len(addrs), strings.Join(nodeErrs, ", "))
+}
+
+// newPeersError - returns PeersError holding errors of each failed
+// peer, or nil if the operation succeeded on all peers.
+func newPeersError(peers adminPeers, errs []error, op string) error {
+	peersErr := PeersError{Op: op, Errs: make(map[string]error)}
+	for i, err := range errs {
+		if err != nil {
+			peersErr.Errs[peers[i].addr] = err
+		}
+	}
+	if len(peersErr.Errs) == 0 {
+		return nil
+	}
+	return peersErr
+}
+
+// reducePeersErrs - returns PeersError holding the error of each failed
+// peer, or nil if the operation succeeded on all peers. The returned
+// boolean reports whether the operation succeeded on a majority of peers.
+func reducePeersErrs(peers adminPeers, errs []error, op string) (bool, error) {
+	err := newPeersError(peers, errs, op)
+	maxCount, maxErr := reduceErrs(errs, nil)
+	return maxErr == nil && maxCount >= len(peers)/2+1, err
+}
+
+// peersReInitFormat - reinitialize remote object layers to new format,
+// returns whether a majority of peers did along with errors of each
+// failed peer.
+func peersReInitFormat(peers adminPeers, dryRun bool) (bool, error) {
 	errs := make([]error, len(peers))
 
 	// Send ReInitFormat RPC call to all nodes.
@@ -194,7 +458,7 @@ func peersReInitFormat(peers adminPeers, dryRun bool) error {
 		}(i, peer)
 	}
 	wg.Wait()
-	return nil
+	return reducePeersErrs(peers, errs, "ReInitFormat")
 }
 
 // Initialize global adminPeer collection.
@@ -202,18 +466,29 @@ func initGlobalAdminPeers(endpoints EndpointList) {
 	globalAdminPeers = makeAdminPeers(endpoints)
 }
 
-// invokeServiceCmd - Invoke Restart/Stop command.
+// getLocalAdminPeerAddr - returns the address other nodes reach this node at.
+func getLocalAdminPeerAddr() string {
+	for _, peer := range globalAdminPeers {
+		if peer.isLocal {
+			return peer.addr
+		}
+	}
+	return ""
+}
+
+// invokeServiceCmd - Invoke Restart/Stop/Freeze/UnFreeze command.
 func invokeServiceCmd(cp adminPeer, cmd serviceSignal) (err error) {
 	switch cmd {
-	case serviceRestart, serviceStop:
+	case serviceRestart, serviceStop, serviceFreeze, serviceUnFreeze:
 		err = cp.cmdRunner.SignalService(cmd)
 	}
 	return err
 }
 
 // sendServiceCmd - Invoke Restart command on remote peers
-// adminPeer followed by on the local peer.
-func sendServiceCmd(cps adminPeers, cmd serviceSignal) {
+// adminPeer followed by on the local peer, returns the error
+// of each peer.
+func sendServiceCmd(cps adminPeers, cmd serviceSignal) []error {
 	// Send service command like stop or restart to all remote nodes and finally run on local node.
 	errs := make([]error, len(cps))
 	var wg sync.WaitGroup
@@ -228,6 +503,86 @@ func sendServiceCmd(cps adminPeers, cmd serviceSignal) {
 	}
 	wg.Wait()
 	errs[0] = invokeServiceCmd(cps[0], cmd)
+	return errs
+}
+
+var (
+	// Interval at which a restarted peer is polled until it is back.
+	rollingRestartPollInterval = time.Second
+
+	// Maximum time to wait for a restarted peer to be back and for
+	// read quorum to be re-established.
+	rollingRestartTimeout = 5 * time.Minute
+)
+
+var errRollingRestartAborted = fmt.Errorf("rolling restart aborted, a previous server did not come back")
+
+// waitForPeerRestart - polls the peer until it reports an uptime
+// shorter than the time since restarted, i.e. it is running again,
+// and until read quorum of the cluster is available again.
+func waitForPeerRestart(cps adminPeers, peer adminPeer, restarted time.Time) error {
+	deadline := restarted.Add(rollingRestartTimeout)
+	var err error
+	for {
+		var serverInfo ServerInfoData
+		if serverInfo, err = peer.cmdRunner.ServerInfo(); err == nil {
+			if serverInfo.Properties.Uptime > UTCNow().Sub(restarted) {
+				err = fmt.Errorf("server %s has not restarted yet", peer.addr)
+			} else if _, err = getPeerUptimes(cps); err == nil {
+				return nil
+			}
+		}
+		if UTCNow().After(deadline) {
+			return err
+		}
+		time.Sleep(rollingRestartPollInterval)
+	}
+}
+
+// sendRollingServiceRestart - restarts remote peers one after another,
+// each one is restarted once the previous one is back and read quorum is
+// available, the local peer is restarted last. The remaining peers are
+// not restarted once a peer is not back in time, returns the error of
+// each peer.
+func sendRollingServiceRestart(cps adminPeers) []error {
+	errs := make([]error, len(cps))
+	aborted := false
+	for i, peer := range cps {
+		if peer.isLocal {
+			continue
+		}
+		if aborted {
+			errs[i] = errRollingRestartAborted
+			continue
+		}
+
+		// Don't take down another server while read quorum is
+		// unavailable.
+		if _, errs[i] = getPeerUptimes(cps); errs[i] != nil {
+			aborted = true
+			continue
+		}
+
+		restarted := UTCNow()
+		if errs[i] = invokeServiceCmd(peer, serviceRestart); errs[i] != nil {
+			continue
+		}
+		if errs[i] = waitForPeerRestart(cps, peer, restarted); errs[i] != nil {
+			aborted = true
+		}
+	}
+
+	for i, peer := range cps {
+		if !peer.isLocal {
+			continue
+		}
+		if aborted {
+			errs[i] = errRollingRestartAborted
+			continue
+		}
+		errs[i] = invokeServiceCmd(peer, serviceRestart)
+	}
+	return errs
 }
 
 // uptimeSlice - used to sort uptimes in chronological order.
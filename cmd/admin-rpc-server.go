@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"path"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/logger"
@@ -52,6 +53,239 @@ func (receiver *adminRPCReceiver) ServerInfo(args *AuthArgs, reply *ServerInfoDa
 	return err
 }
 
+// BandwidthInfo - returns the per bucket bandwidth of this server.
+func (receiver *adminRPCReceiver) BandwidthInfo(args *AuthArgs, reply *ServerBandwidthData) (err error) {
+	*reply, err = receiver.local.BandwidthInfo()
+	return err
+}
+
+// TopAPI - returns the per API call statistics of this server.
+func (receiver *adminRPCReceiver) TopAPI(args *AuthArgs, reply *map[string]APIStats) (err error) {
+	*reply, err = receiver.local.TopAPI()
+	return err
+}
+
+// RuntimeInfo - returns the Go runtime metrics of this server.
+func (receiver *adminRPCReceiver) RuntimeInfo(args *AuthArgs, reply *ServerRuntimeData) (err error) {
+	*reply, err = receiver.local.RuntimeInfo()
+	return err
+}
+
+// KMSKeyStatusArgs - provides the master key ID to KMSKeyStatus RPC
+type KMSKeyStatusArgs struct {
+	AuthArgs
+	KeyID string
+}
+
+// KMSKeyStatus - verifies the KMS master key on this server.
+func (receiver *adminRPCReceiver) KMSKeyStatus(args *KMSKeyStatusArgs, reply *KMSKeyStatus) (err error) {
+	*reply, err = receiver.local.KMSKeyStatus(args.KeyID)
+	return err
+}
+
+// HealthInfo - returns health diagnostics of this server.
+func (receiver *adminRPCReceiver) HealthInfo(args *AuthArgs, reply *ServerHealthInfoData) (err error) {
+	*reply, err = receiver.local.HealthInfo()
+	return err
+}
+
+// SendPayloadArgs - holds the payload sent to measure network throughput.
+type SendPayloadArgs struct {
+	AuthArgs
+	Payload []byte
+}
+
+// SendPayload - receives and discards the payload.
+func (receiver *adminRPCReceiver) SendPayload(args *SendPayloadArgs, reply *VoidReply) error {
+	return receiver.local.SendPayload(args.Payload)
+}
+
+// NetPerfArgs - provides the payload size to NetPerf RPC
+type NetPerfArgs struct {
+	AuthArgs
+	Size int64
+}
+
+// NetPerf - runs network tests from this server to all other servers.
+func (receiver *adminRPCReceiver) NetPerf(args *NetPerfArgs, reply *[]NetPerfInfo) (err error) {
+	*reply, err = receiver.local.NetPerf(args.Size)
+	return err
+}
+
+// DrivePerfArgs - provides the test file size to DrivePerf RPC
+type DrivePerfArgs struct {
+	AuthArgs
+	Size int64
+}
+
+// DrivePerf - runs drive tests on the local drives of this server.
+func (receiver *adminRPCReceiver) DrivePerf(args *DrivePerfArgs, reply *[]DriveSpeedInfo) (err error) {
+	*reply, err = receiver.local.DrivePerf(args.Size)
+	return err
+}
+
+// InspectArgs - provides the object name to Inspect RPC
+type InspectArgs struct {
+	AuthArgs
+	Bucket string
+	Object string
+}
+
+// Inspect - collects erasure metadata of an object from the local drives of this server.
+func (receiver *adminRPCReceiver) Inspect(args *InspectArgs, reply *[]InspectDriveData) (err error) {
+	*reply, err = receiver.local.Inspect(args.Bucket, args.Object)
+	return err
+}
+
+// ServerUpdateArgs - provides the binary URL and its checksum to ServerUpdate RPC
+type ServerUpdateArgs struct {
+	AuthArgs
+	UpdateURL string
+	Sha256Sum []byte
+}
+
+// ServerUpdate - updates the binary of this server.
+func (receiver *adminRPCReceiver) ServerUpdate(args *ServerUpdateArgs, reply *VoidReply) error {
+	return receiver.local.ServerUpdate(args.UpdateURL, args.Sha256Sum)
+}
+
+// ReloadCerts - reloads the TLS certificate of this server.
+func (receiver *adminRPCReceiver) ReloadCerts(args *AuthArgs, reply *VoidReply) error {
+	return receiver.local.ReloadCerts()
+}
+
+// SetLogLevelArgs - provides the subsystem and its new verbosity to SetLogLevel RPC
+type SetLogLevelArgs struct {
+	AuthArgs
+	Subsystem logger.Subsystem
+	Level     logger.Level
+}
+
+// SetLogLevel - changes the log verbosity of a subsystem of this server.
+func (receiver *adminRPCReceiver) SetLogLevel(args *SetLogLevelArgs, reply *VoidReply) error {
+	return receiver.local.SetLogLevel(args.Subsystem, args.Level)
+}
+
+// StartBatchJobArgs - provides the batch job and the index of the node to StartBatchJob RPC
+type StartBatchJobArgs struct {
+	AuthArgs
+	Job       BatchJob
+	NodeIndex int
+}
+
+// StartBatchJob - starts processing the share of a batch job of this server.
+func (receiver *adminRPCReceiver) StartBatchJob(args *StartBatchJobArgs, reply *VoidReply) error {
+	return receiver.local.StartBatchJob(args.Job, args.NodeIndex)
+}
+
+// BatchJobArgs - provides the batch job ID to batch job RPCs
+type BatchJobArgs struct {
+	AuthArgs
+	ID string
+}
+
+// DescribeBatchJob - returns the progress of a batch job on this server.
+func (receiver *adminRPCReceiver) DescribeBatchJob(args *BatchJobArgs, reply *BatchJobProgress) (err error) {
+	*reply, err = receiver.local.DescribeBatchJob(args.ID)
+	return err
+}
+
+// CancelBatchJob - stops a batch job on this server.
+func (receiver *adminRPCReceiver) CancelBatchJob(args *BatchJobArgs, reply *VoidReply) error {
+	return receiver.local.CancelBatchJob(args.ID)
+}
+
+// ListBatchJobs - returns the progress of all batch jobs of this server.
+func (receiver *adminRPCReceiver) ListBatchJobs(args *AuthArgs, reply *[]BatchJobProgress) (err error) {
+	*reply, err = receiver.local.ListBatchJobs()
+	return err
+}
+
+// SetScannerSpeedArgs - provides the scanner speed to SetScannerSpeed RPC
+type SetScannerSpeedArgs struct {
+	AuthArgs
+	Speed ScannerSpeed
+}
+
+// SetScannerSpeed - changes the pace of background scans of this server.
+func (receiver *adminRPCReceiver) SetScannerSpeed(args *SetScannerSpeedArgs, reply *VoidReply) error {
+	return receiver.local.SetScannerSpeed(args.Speed)
+}
+
+// ListMultipartUploads - returns the multipart uploads found on the drives of this server.
+func (receiver *adminRPCReceiver) ListMultipartUploads(args *AuthArgs, reply *[]MultipartUploadInfo) (err error) {
+	*reply, err = receiver.local.ListMultipartUploads()
+	return err
+}
+
+// AbortStaleMultipartUploadsArgs - provides the minimum age of uploads to AbortStaleMultipartUploads RPC
+type AbortStaleMultipartUploadsArgs struct {
+	AuthArgs
+	OlderThan time.Duration
+}
+
+// AbortStaleMultipartUploads - removes multipart uploads older than args.OlderThan from the drives of this server.
+func (receiver *adminRPCReceiver) AbortStaleMultipartUploads(args *AbortStaleMultipartUploadsArgs, reply *int) (err error) {
+	*reply, err = receiver.local.AbortStaleMultipartUploads(args.OlderThan)
+	return err
+}
+
+// ListFailedEvents - returns the events this server failed to deliver.
+func (receiver *adminRPCReceiver) ListFailedEvents(args *AuthArgs, reply *[]FailedEvent) (err error) {
+	*reply, err = receiver.local.ListFailedEvents()
+	return err
+}
+
+// ReplayFailedEventsArgs - provides the failed event to ReplayFailedEvents RPC, all events if ID is empty
+type ReplayFailedEventsArgs struct {
+	AuthArgs
+	ID string
+}
+
+// ReplayFailedEvents - sends failed events of this server again to their targets.
+func (receiver *adminRPCReceiver) ReplayFailedEvents(args *ReplayFailedEventsArgs, reply *FailedEventsReplay) (err error) {
+	*reply, err = receiver.local.ReplayFailedEvents(args.ID)
+	return err
+}
+
+// ForceUnlockArgs - provides the lock, or the client whose locks, to release to ForceUnlock RPC
+type ForceUnlockArgs struct {
+	AuthArgs
+	Bucket string
+	Object string
+	Client string
+}
+
+// ForceUnlock - releases locks held on args.Bucket/args.Object, or by args.Client, on this server.
+func (receiver *adminRPCReceiver) ForceUnlock(args *ForceUnlockArgs, reply *int) (err error) {
+	*reply, err = receiver.local.ForceUnlock(args.Bucket, args.Object, args.Client)
+	return err
+}
+
+// ListSessions - returns the S3 API calls in progress on this server.
+func (receiver *adminRPCReceiver) ListSessions(args *AuthArgs, reply *[]SessionInfo) (err error) {
+	*reply, err = receiver.local.ListSessions()
+	return err
+}
+
+// MaintenanceEnterArgs - provides the time to wait for S3 calls in
+// progress to MaintenanceEnter RPC
+type MaintenanceEnterArgs struct {
+	AuthArgs
+	DrainTimeout time.Duration
+}
+
+// MaintenanceEnter - puts this server in maintenance.
+func (receiver *adminRPCReceiver) MaintenanceEnter(args *MaintenanceEnterArgs, reply *int) (err error) {
+	*reply, err = receiver.local.MaintenanceEnter(args.DrainTimeout)
+	return err
+}
+
+// MaintenanceExit - makes this server leave maintenance.
+func (receiver *adminRPCReceiver) MaintenanceExit(args *AuthArgs, reply *VoidReply) error {
+	return receiver.local.MaintenanceExit()
+}
+
 // GetConfig - returns the config.json of this server.
 func (receiver *adminRPCReceiver) GetConfig(args *AuthArgs, reply *[]byte) (err error) {
 	*reply, err = receiver.local.GetConfig()
@@ -94,6 +328,18 @@ func (receiver *adminRPCReceiver) CommitConfig(args *CommitConfigArgs, reply *Vo
 	return receiver.local.CommitConfig(args.FileName)
 }
 
+// ValidateConfigArgs - wraps the candidate config to be validated.
+type ValidateConfigArgs struct {
+	AuthArgs
+	Buf []byte
+}
+
+// ValidateConfig - checks that this node can use the candidate config.
+func (receiver *adminRPCReceiver) ValidateConfig(args *ValidateConfigArgs, reply *[]ConfigValidationError) (err error) {
+	*reply, err = receiver.local.ValidateConfig(args.Buf)
+	return err
+}
+
 // NewAdminRPCServer - returns new admin RPC server.
 func NewAdminRPCServer() (*xrpc.Server, error) {
 	rpcServer := xrpc.NewServer()
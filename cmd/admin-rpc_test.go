@@ -17,17 +17,27 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/certs"
+	"github.com/minio/minio/pkg/event"
 	xnet "github.com/minio/minio/pkg/net"
+	sha256 "github.com/minio/sha256-simd"
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -49,16 +59,21 @@ func testAdminCmdRunnerSignalService(t *testing.T, client adminCmdRunner) {
 	globalServiceSignalCh = make(chan serviceSignal, 10)
 	defer func() {
 		globalServiceSignalCh = tmpGlobalServiceSignalCh
+		unfreezeServices()
 	}()
 
 	testCases := []struct {
-		signal    serviceSignal
-		expectErr bool
+		signal         serviceSignal
+		expectErr      bool
+		expectedFrozen bool
 	}{
-		{serviceRestart, false},
-		{serviceStop, false},
-		{serviceStatus, true},
-		{serviceSignal(100), true},
+		{serviceRestart, false, false},
+		{serviceStop, false, false},
+		{serviceFreeze, false, true},
+		{serviceFreeze, false, true},
+		{serviceUnFreeze, false, false},
+		{serviceStatus, true, false},
+		{serviceSignal(100), true, false},
 	}
 
 	for i, testCase := range testCases {
@@ -68,6 +83,10 @@ func testAdminCmdRunnerSignalService(t *testing.T, client adminCmdRunner) {
 		if expectErr != testCase.expectErr {
 			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
 		}
+
+		if frozen := isServiceFrozen(); frozen != testCase.expectedFrozen {
+			t.Fatalf("case %v: expected frozen: %v, got: %v", i+1, testCase.expectedFrozen, frozen)
+		}
 	}
 }
 
@@ -145,6 +164,410 @@ func testAdminCmdRunnerServerInfo(t *testing.T, client adminCmdRunner) {
 	}
 }
 
+func testAdminCmdRunnerBandwidthInfo(t *testing.T, client adminCmdRunner) {
+	tmpGlobalBootTime := globalBootTime
+	tmpGlobalBucketBandwidthStats := globalBucketBandwidthStats
+	defer func() {
+		globalBootTime = tmpGlobalBootTime
+		globalBucketBandwidthStats = tmpGlobalBucketBandwidthStats
+	}()
+
+	bandwidthStats := newBucketBandwidthStats()
+	bandwidthStats.incInputBytes("mybucket", 10)
+
+	testCases := []struct {
+		bootTime       time.Time
+		bandwidthStats *BucketBandwidthStats
+		expectedBytes  uint64
+		expectErr      bool
+	}{
+		{UTCNow(), bandwidthStats, 10, false},
+		{UTCNow(), newBucketBandwidthStats(), 0, false},
+		{time.Time{}, bandwidthStats, 0, true},
+		{UTCNow(), nil, 0, true},
+	}
+
+	for i, testCase := range testCases {
+		globalBootTime = testCase.bootTime
+		globalBucketBandwidthStats = testCase.bandwidthStats
+		bandwidthData, err := client.BandwidthInfo()
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if bytesIn := bandwidthData.Buckets["mybucket"].BytesIn; bytesIn != testCase.expectedBytes {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedBytes, bytesIn)
+		}
+	}
+}
+
+func testAdminCmdRunnerTopAPI(t *testing.T, client adminCmdRunner) {
+	tmpGlobalAPICallStats := globalAPICallStats
+	defer func() {
+		globalAPICallStats = tmpGlobalAPICallStats
+	}()
+
+	apiCallStats := newAPICallStats()
+	apiCallStats.callStarted("GetObject")
+	apiCallStats.callDone("GetObject", http.StatusOK, time.Second, UTCNow())
+
+	testCases := []struct {
+		apiCallStats  *APICallStats
+		expectedCalls uint64
+		expectErr     bool
+	}{
+		{apiCallStats, 1, false},
+		{newAPICallStats(), 0, false},
+		{nil, 0, true},
+	}
+
+	for i, testCase := range testCases {
+		globalAPICallStats = testCase.apiCallStats
+		apiStats, err := client.TopAPI()
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if calls := apiStats["GetObject"].Calls; calls != testCase.expectedCalls {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedCalls, calls)
+		}
+	}
+}
+
+// kmsUnsealErr is a crypto.KMS which fails to unseal any data key.
+type kmsUnsealErr struct{ crypto.KMS }
+
+func (kms kmsUnsealErr) UnsealKey(keyID string, sealedKey []byte, context crypto.Context) (key [32]byte, err error) {
+	return key, errors.New("unseal failed")
+}
+
+func testAdminCmdRunnerKMSKeyStatus(t *testing.T, client adminCmdRunner) {
+	tmpGlobalKMS := globalKMS
+	tmpGlobalKMSKeyID := globalKMSKeyID
+	defer func() {
+		globalKMS = tmpGlobalKMS
+		globalKMSKeyID = tmpGlobalKMSKeyID
+	}()
+	globalKMSKeyID = "default-key"
+
+	testCases := []struct {
+		kms              crypto.KMS
+		keyID            string
+		expectedKeyID    string
+		expectDecryptErr bool
+		expectErr        bool
+	}{
+		{crypto.NewKMS([32]byte{}), "", "default-key", false, false},
+		{crypto.NewKMS([32]byte{}), "my-key", "my-key", false, false},
+		{kmsUnsealErr{crypto.NewKMS([32]byte{})}, "my-key", "my-key", true, false},
+		{nil, "my-key", "", false, true},
+	}
+
+	for i, testCase := range testCases {
+		globalKMS = testCase.kms
+		status, err := client.KMSKeyStatus(testCase.keyID)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if status.KeyID != testCase.expectedKeyID {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedKeyID, status.KeyID)
+		}
+		if status.EncryptionErr != "" {
+			t.Fatalf("case %v: unexpected encryption error %v", i+1, status.EncryptionErr)
+		}
+		if decryptErr := (status.DecryptionErr != ""); decryptErr != testCase.expectDecryptErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectDecryptErr, decryptErr)
+		}
+	}
+}
+
+func testAdminCmdRunnerHealthInfo(t *testing.T, client adminCmdRunner) {
+	tmpGlobalBootTime := globalBootTime
+	tmpGlobalEndpoints := globalEndpoints
+	defer func() {
+		globalBootTime = tmpGlobalBootTime
+		globalEndpoints = tmpGlobalEndpoints
+	}()
+
+	tmpDir, err := ioutil.TempDir("", ".AdminCmdRunnerHealthInfo.")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	globalEndpoints = mustGetNewEndpointList(tmpDir)
+
+	testCases := []struct {
+		bootTime  time.Time
+		expectErr bool
+	}{
+		{UTCNow(), false},
+		{time.Time{}, true},
+	}
+
+	for i, testCase := range testCases {
+		globalBootTime = testCase.bootTime
+		healthInfoData, err := client.HealthInfo()
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if expectErr {
+			continue
+		}
+
+		if healthInfoData.CPU.NumCPU == 0 || healthInfoData.OS.OS == "" {
+			t.Fatalf("case %v: expected system information, got: %v", i+1, healthInfoData)
+		}
+		if len(healthInfoData.Drives) != 1 || healthInfoData.Drives[0].Error != "" {
+			t.Fatalf("case %v: expected one successful drive test, got: %v", i+1, healthInfoData.Drives)
+		}
+	}
+}
+
+func testAdminCmdRunnerSendPayload(t *testing.T, client adminCmdRunner) {
+	testCases := [][]byte{
+		nil,
+		[]byte("hello"),
+		make([]byte, 1024*1024),
+	}
+
+	for i, payload := range testCases {
+		if err := client.SendPayload(payload); err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func testAdminCmdRunnerNetPerf(t *testing.T, client adminCmdRunner) {
+	tmpGlobalAdminPeers := globalAdminPeers
+	defer func() {
+		globalAdminPeers = tmpGlobalAdminPeers
+	}()
+
+	// Only the local peer, which is never tested.
+	globalAdminPeers = adminPeers{{addr: "local", cmdRunner: localAdminClient{}, isLocal: true}}
+
+	testCases := []struct {
+		size      int64
+		expectErr bool
+	}{
+		{1024, false},
+		{netPerfMaxPayloadSize, false},
+		{0, true},
+		{-1, true},
+		{netPerfMaxPayloadSize + 1, true},
+	}
+
+	for i, testCase := range testCases {
+		netPerf, err := client.NetPerf(testCase.size)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if len(netPerf) != 0 {
+			t.Fatalf("case %v: expected no links to be tested, got: %v", i+1, netPerf)
+		}
+	}
+}
+
+func testAdminCmdRunnerDrivePerf(t *testing.T, client adminCmdRunner) {
+	tmpDir, err := ioutil.TempDir("", "drive-perf-")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpGlobalEndpoints := globalEndpoints
+	defer func() {
+		globalEndpoints = tmpGlobalEndpoints
+	}()
+	globalEndpoints = EndpointList{{URL: &url.URL{Path: tmpDir}, IsLocal: true}}
+
+	testCases := []struct {
+		size      int64
+		expectErr bool
+	}{
+		{drivePerfBlockSize, false},
+		{64 * drivePerfBlockSize, false},
+		{0, true},
+		{drivePerfBlockSize - 1, true},
+		{drivePerfMaxFileSize + 1, true},
+	}
+
+	for i, testCase := range testCases {
+		drivesPerf, err := client.DrivePerf(testCase.size)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if expectErr {
+			continue
+		}
+		if len(drivesPerf) != 1 || drivesPerf[0].Path != tmpDir || drivesPerf[0].Error != "" {
+			t.Fatalf("case %v: unexpected result %v", i+1, drivesPerf)
+		}
+	}
+}
+
+func testAdminCmdRunnerInspect(t *testing.T, client adminCmdRunner) {
+	tmpDir, err := ioutil.TempDir("", "inspect-")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpGlobalEndpoints := globalEndpoints
+	defer func() {
+		globalEndpoints = tmpGlobalEndpoints
+	}()
+	globalEndpoints = EndpointList{{URL: &url.URL{Path: tmpDir}, IsLocal: true}}
+
+	xlMeta := []byte(`{"format":"xl"}`)
+	if err = os.MkdirAll(filepath.Join(tmpDir, "bucket", "object"), 0777); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(tmpDir, "bucket", "object", xlMetaJSONFile), xlMeta, 0644); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	testCases := []struct {
+		bucket    string
+		object    string
+		expectErr bool
+	}{
+		{"bucket", "object", false},
+		{"bucket", "../bucket/object", true},
+		{"..", "object", true},
+		{"bucket", "", true},
+	}
+
+	for i, testCase := range testCases {
+		drivesData, err := client.Inspect(testCase.bucket, testCase.object)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if expectErr {
+			continue
+		}
+		if len(drivesData) != 1 || drivesData[0].Path != tmpDir || !bytes.Equal(drivesData[0].XLMeta, xlMeta) {
+			t.Fatalf("case %v: unexpected result %v", i+1, drivesData)
+		}
+	}
+}
+
+func testAdminCmdRunnerServerUpdate(t *testing.T, client adminCmdRunner) {
+	httpServer := newTestUpdateServer()
+	defer httpServer.Close()
+
+	binaryPath, restore := newTestMinioBinary(t)
+	defer restore()
+
+	sha256Sum := sha256.Sum256(testUpdateBinary)
+
+	testCases := []struct {
+		updateURL string
+		sha256Sum []byte
+		expectErr bool
+	}{
+		{httpServer.URL + "/minio", sha256Sum[:1], true},
+		{httpServer.URL + "/missing", sha256Sum[:], true},
+		{httpServer.URL + "/minio", sha256Sum[:], false},
+	}
+
+	for i, testCase := range testCases {
+		err := client.ServerUpdate(testCase.updateURL, testCase.sha256Sum)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+	}
+
+	binary, err := ioutil.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(binary, testUpdateBinary) {
+		t.Fatalf("expected binary to be updated, got: %q", binary)
+	}
+}
+
+// newTestTLSCerts - sets globalTLSCerts to a certificate manager serving
+// a self-signed certificate, returns the key file path and a function
+// restoring globalTLSCerts.
+func newTestTLSCerts(t *testing.T) (keyFile string, restore func()) {
+	certsDir, err := ioutil.TempDir(globalTestTmpDir, "minio-certs-")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	certFile := filepath.Join(certsDir, publicCertFile)
+	keyFile = filepath.Join(certsDir, privateKeyFile)
+	certPEM, keyPEM, err := generateTLSCertKey("127.0.0.1")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	tlsCerts, err := certs.New(certFile, keyFile, loadX509KeyPair)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	prevTLSCerts := globalTLSCerts
+	globalTLSCerts = tlsCerts
+	return keyFile, func() {
+		tlsCerts.Stop()
+		globalTLSCerts = prevTLSCerts
+		os.RemoveAll(certsDir)
+	}
+}
+
+func testAdminCmdRunnerReloadCerts(t *testing.T, client adminCmdRunner) {
+	prevTLSCerts := globalTLSCerts
+	globalTLSCerts = nil
+	err := client.ReloadCerts()
+	globalTLSCerts = prevTLSCerts
+	if err == nil || err.Error() != errTLSNotConfigured.Error() {
+		t.Fatalf("expected: %v, got: %v", errTLSNotConfigured, err)
+	}
+
+	keyFile, restore := newTestTLSCerts(t)
+	defer restore()
+
+	if err = client.ReloadCerts(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// An invalid private key is reported and the current certificate is kept.
+	if err = ioutil.WriteFile(keyFile, []byte("invalid"), 0600); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = client.ReloadCerts(); err == nil {
+		t.Fatalf("expected an error loading an invalid private key")
+	}
+	cert, err := globalTLSCerts.GetCertificate(nil)
+	if err != nil || len(cert.Certificate) == 0 {
+		t.Fatalf("expected the current certificate to be kept, got: %v, %v", cert, err)
+	}
+}
+
 func testAdminCmdRunnerGetConfig(t *testing.T, client adminCmdRunner) {
 	tmpGlobalServerConfig := globalServerConfig
 	defer func() {
@@ -288,24 +711,725 @@ func TestAdminRPCClientSignalService(t *testing.T) {
 	testAdminCmdRunnerSignalService(t, rpcClient)
 }
 
-func TestAdminRPCClientReInitFormat(t *testing.T) {
+func TestPeersReInitFormat(t *testing.T) {
 	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
 	defer httpServer.Close()
 	defer func() {
 		globalServerConfig = prevGlobalServerConfig
 	}()
 
-	testAdminCmdRunnerReInitFormat(t, rpcClient)
-}
-
-func TestAdminRPCClientServerInfo(t *testing.T) {
-	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
-	defer httpServer.Close()
+	tmpGlobalObjectAPI := globalObjectAPI
 	defer func() {
-		globalServerConfig = prevGlobalServerConfig
+		globalObjectAPI = tmpGlobalObjectAPI
 	}()
+	globalObjectAPI = &DummyObjectLayer{}
 
-	testAdminCmdRunnerServerInfo(t, rpcClient)
+	newOfflinePeer := func() adminPeer {
+		offlineServer, offlineClient, _ := newAdminRPCHTTPServerClient(t)
+		offlineServer.Close()
+		return adminPeer{addr: offlineServer.Listener.Addr().String(), cmdRunner: offlineClient}
+	}
+	localPeer := adminPeer{addr: "local", cmdRunner: localAdminClient{}, isLocal: true}
+	onlinePeer := adminPeer{addr: httpServer.Listener.Addr().String(), cmdRunner: rpcClient}
+	offlinePeer1, offlinePeer2 := newOfflinePeer(), newOfflinePeer()
+
+	testCases := []struct {
+		peers          adminPeers
+		expectedQuorum bool
+		expectedFailed []string
+	}{
+		{adminPeers{localPeer}, true, nil},
+		{adminPeers{localPeer, onlinePeer}, true, nil},
+		// Failures of a minority of nodes are tolerated but reported.
+		{adminPeers{localPeer, onlinePeer, offlinePeer1}, true, []string{offlinePeer1.addr}},
+		{adminPeers{localPeer, offlinePeer1, offlinePeer2}, false, []string{offlinePeer1.addr, offlinePeer2.addr}},
+	}
+
+	for i, testCase := range testCases {
+		quorum, err := peersReInitFormat(testCase.peers, true)
+		if quorum != testCase.expectedQuorum {
+			t.Fatalf("case %v: expected quorum: %v, got: %v", i+1, testCase.expectedQuorum, quorum)
+		}
+		if testCase.expectedFailed == nil {
+			if err != nil {
+				t.Fatalf("case %v: unexpected error %v", i+1, err)
+			}
+			continue
+		}
+
+		peersErr, ok := err.(PeersError)
+		if !ok {
+			t.Fatalf("case %v: expected PeersError, got: %v", i+1, err)
+		}
+		if len(peersErr.Errs) != len(testCase.expectedFailed) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedFailed, peersErr)
+		}
+		for _, addr := range testCase.expectedFailed {
+			if _, ok = peersErr.Errs[addr]; !ok {
+				t.Fatalf("case %v: expected error of %v, got: %v", i+1, addr, peersErr)
+			}
+		}
+	}
+}
+
+// rollingRestartTestRunner - records when it is restarted, servers
+// which are down never come back once restarted.
+type rollingRestartTestRunner struct {
+	adminCmdRunner
+	mu       *sync.Mutex
+	booted   time.Time
+	down     bool
+	restarts *[]string
+	addr     string
+}
+
+func (runner *rollingRestartTestRunner) SignalService(s serviceSignal) error {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	runner.booted = UTCNow()
+	*runner.restarts = append(*runner.restarts, runner.addr)
+	return nil
+}
+
+func (runner *rollingRestartTestRunner) ServerInfo() (sid ServerInfoData, err error) {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	if runner.down && len(*runner.restarts) > 0 {
+		return sid, errors.New("server down")
+	}
+	sid.Properties.Uptime = UTCNow().Sub(runner.booted)
+	return sid, nil
+}
+
+func TestSendRollingServiceRestart(t *testing.T) {
+	prevIsDistXL := globalIsDistXL
+	prevPollInterval, prevTimeout := rollingRestartPollInterval, rollingRestartTimeout
+	defer func() {
+		globalIsDistXL = prevIsDistXL
+		rollingRestartPollInterval, rollingRestartTimeout = prevPollInterval, prevTimeout
+	}()
+	globalIsDistXL = true
+	rollingRestartPollInterval, rollingRestartTimeout = 10*time.Millisecond, 500*time.Millisecond
+
+	newPeers := func(downAddr string) (adminPeers, *[]string) {
+		var mu sync.Mutex
+		var restarts []string
+		var peers adminPeers
+		for i, addr := range []string{"local", "remote1", "remote2"} {
+			runner := &rollingRestartTestRunner{
+				mu:       &mu,
+				booted:   UTCNow().Add(-time.Hour),
+				down:     addr == downAddr,
+				restarts: &restarts,
+				addr:     addr,
+			}
+			peers = append(peers, adminPeer{addr: addr, cmdRunner: runner, isLocal: i == 0})
+		}
+		return peers, &restarts
+	}
+
+	// All servers are restarted one after another, the local one last.
+	peers, restarts := newPeers("")
+	for i, err := range sendRollingServiceRestart(peers) {
+		if err != nil {
+			t.Fatalf("unexpected error of %s: %v", peers[i].addr, err)
+		}
+	}
+	if !reflect.DeepEqual(*restarts, []string{"remote1", "remote2", "local"}) {
+		t.Fatalf("unexpected restart order %v", *restarts)
+	}
+
+	// Remaining servers are not restarted once a server is not back.
+	peers, restarts = newPeers("remote1")
+	errs := sendRollingServiceRestart(peers)
+	if errs[1] == nil {
+		t.Fatal("expected an error of the server which is not back")
+	}
+	if errs[0] != errRollingRestartAborted || errs[2] != errRollingRestartAborted {
+		t.Fatalf("expected servers to be left running, got: %v", errs)
+	}
+	if !reflect.DeepEqual(*restarts, []string{"remote1"}) {
+		t.Fatalf("unexpected restarts %v", *restarts)
+	}
+}
+
+func TestAdminRPCClientReInitFormat(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerReInitFormat(t, rpcClient)
+}
+
+func TestAdminRPCClientServerInfo(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerServerInfo(t, rpcClient)
+}
+
+func TestAdminRPCClientTopAPI(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerTopAPI(t, rpcClient)
+}
+
+func TestAdminRPCClientBandwidthInfo(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerBandwidthInfo(t, rpcClient)
+}
+
+func TestAdminRPCClientKMSKeyStatus(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerKMSKeyStatus(t, rpcClient)
+}
+
+func TestAdminRPCClientHealthInfo(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerHealthInfo(t, rpcClient)
+}
+
+func TestAdminRPCClientSendPayload(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerSendPayload(t, rpcClient)
+}
+
+func TestAdminRPCClientNetPerf(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerNetPerf(t, rpcClient)
+}
+
+func TestAdminRPCClientDrivePerf(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerDrivePerf(t, rpcClient)
+}
+
+func TestAdminRPCClientInspect(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerInspect(t, rpcClient)
+}
+
+func TestAdminRPCClientServerUpdate(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerServerUpdate(t, rpcClient)
+}
+
+func testAdminCmdRunnerSetLogLevel(t *testing.T, client adminCmdRunner) {
+	prevLevels := logger.GetLevels()
+	defer func() {
+		for subsystem, level := range prevLevels {
+			logger.SetLevel(subsystem, level)
+		}
+	}()
+
+	testCases := []struct {
+		subsystem logger.Subsystem
+		level     logger.Level
+		expectErr bool
+	}{
+		{logger.RPCSubsystem, logger.DebugLvl, false},
+		{logger.IAMSubsystem, logger.InformationLvl, false},
+		{logger.Subsystem("unknown"), logger.DebugLvl, true},
+		{logger.HTTPSubsystem, logger.FatalLvl, true},
+	}
+
+	for i, testCase := range testCases {
+		err := client.SetLogLevel(testCase.subsystem, testCase.level)
+		expectErr := (err != nil)
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if expectErr {
+			continue
+		}
+		if level := logger.GetLevels()[testCase.subsystem]; level != testCase.level {
+			t.Fatalf("case %v: expected level %v, got %v", i+1, testCase.level, level)
+		}
+	}
+
+	if !logger.IsEnabled(logger.RPCSubsystem, logger.DebugLvl) {
+		t.Fatalf("expected debug logs of rpc subsystem to be enabled")
+	}
+	if logger.IsEnabled(logger.IAMSubsystem, logger.DebugLvl) || !logger.IsEnabled(logger.IAMSubsystem, logger.ErrorLvl) {
+		t.Fatalf("expected only info and error logs of iam subsystem to be enabled")
+	}
+}
+
+func TestAdminRPCClientSetLogLevel(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerSetLogLevel(t, rpcClient)
+}
+
+func testAdminCmdRunnerSetScannerSpeed(t *testing.T, client adminCmdRunner) {
+	defer setScannerSpeed(ScannerSpeedDefault)
+
+	testCases := []struct {
+		speed     ScannerSpeed
+		expectErr bool
+	}{
+		{ScannerSpeedSlow, false},
+		{ScannerSpeed("turbo"), true},
+		{ScannerSpeedOff, false},
+	}
+
+	for i, testCase := range testCases {
+		err := client.SetScannerSpeed(testCase.speed)
+		expectErr := (err != nil)
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if expectErr {
+			continue
+		}
+		if speed := getScannerSpeed(); speed != testCase.speed {
+			t.Fatalf("case %v: expected speed %v, got %v", i+1, testCase.speed, speed)
+		}
+	}
+}
+
+func TestAdminRPCClientSetScannerSpeed(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerSetScannerSpeed(t, rpcClient)
+}
+
+func testAdminCmdRunnerMultipartUploads(t *testing.T, client adminCmdRunner) {
+	tmpGlobalEndpoints := globalEndpoints
+	defer func() {
+		globalEndpoints = tmpGlobalEndpoints
+	}()
+
+	initNSLock(false)
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+	globalEndpoints = mustGetNewEndpointList(fsDir)
+
+	if err = objLayer.MakeBucketWithLocation(context.Background(), "bucket", ""); err != nil {
+		t.Fatalf("unable to create bucket, %s", err)
+	}
+	uploadID, err := objLayer.NewMultipartUpload(context.Background(), "bucket", "dir/object", nil)
+	if err != nil {
+		t.Fatalf("unable to initiate multipart upload, %s", err)
+	}
+
+	uploads, err := client.ListMultipartUploads()
+	if err != nil {
+		t.Fatalf("unable to list multipart uploads, %s", err)
+	}
+	if len(uploads) != 1 || uploads[0].UploadID != uploadID || uploads[0].Bucket != "bucket" || uploads[0].Object != "dir/object" {
+		t.Fatalf("expected upload %s of bucket/dir/object, got %v", uploadID, uploads)
+	}
+
+	testCases := []struct {
+		olderThan       time.Duration
+		expectedAborted int
+		expectErr       bool
+	}{
+		{0, 0, true},
+		{time.Hour, 0, false},
+		{time.Nanosecond, 1, false},
+	}
+
+	for i, testCase := range testCases {
+		aborted, err := client.AbortStaleMultipartUploads(testCase.olderThan)
+		expectErr := (err != nil)
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if aborted != testCase.expectedAborted {
+			t.Fatalf("case %v: expected %d aborted uploads, got %d", i+1, testCase.expectedAborted, aborted)
+		}
+	}
+
+	if uploads, err = client.ListMultipartUploads(); err != nil || len(uploads) != 0 {
+		t.Fatalf("expected no multipart uploads, got %v, %v", uploads, err)
+	}
+}
+
+func TestAdminRPCClientMultipartUploads(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerMultipartUploads(t, rpcClient)
+}
+
+func testAdminCmdRunnerFailedEvents(t *testing.T, client adminCmdRunner) {
+	tmpGlobalNotificationSys := globalNotificationSys
+	defer func() {
+		globalNotificationSys = tmpGlobalNotificationSys
+	}()
+
+	globalNotificationSys = nil
+	if _, err := client.ListFailedEvents(); err == nil {
+		t.Fatalf("expected error when notification system is not initialized")
+	}
+
+	target := &testNotificationTarget{id: event.TargetID{ID: "1", Name: "webhook"}, down: true}
+	globalNotificationSys = newTestFailedEventsNotificationSys(t, target)
+	globalNotificationSys.send("bucket", event.Event{EventName: event.ObjectCreatedPut}, target.id)
+
+	events, err := client.ListFailedEvents()
+	if err != nil {
+		t.Fatalf("unable to list failed events, %s", err)
+	}
+	if len(events) != 1 || events[0].TargetID != target.id {
+		t.Fatalf("expected 1 failed event of %s, got %v", target.id, events)
+	}
+
+	target.setDown(false)
+	replay, err := client.ReplayFailedEvents(events[0].ID)
+	if err != nil {
+		t.Fatalf("unable to replay failed events, %s", err)
+	}
+	if replay != (FailedEventsReplay{Replayed: 1}) {
+		t.Fatalf("expected 1 replayed event, got %v", replay)
+	}
+	if events, err = client.ListFailedEvents(); err != nil || len(events) != 0 {
+		t.Fatalf("expected no failed events, got %v, %v", events, err)
+	}
+}
+
+func TestAdminRPCClientFailedEvents(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerFailedEvents(t, rpcClient)
+}
+
+func testAdminCmdRunnerForceUnlock(t *testing.T, client adminCmdRunner) {
+	initNSLock(false)
+	if err := globalNSMutex.NewNSLock("bucket", "object").GetLock(newDynamicTimeout(time.Second, time.Second)); err != nil {
+		t.Fatalf("unable to lock bucket/object, %s", err)
+	}
+
+	testCases := []struct {
+		bucket, object, client string
+		expectedReleased       int
+		expectErr              bool
+	}{
+		{"", "", "", 0, true},
+		{"bucket", "object", "", 1, false},
+		{"bucket", "object", "", 0, false},
+		{"", "", "192.168.1.12:9000", 0, false},
+	}
+
+	for i, testCase := range testCases {
+		released, err := client.ForceUnlock(testCase.bucket, testCase.object, testCase.client)
+		expectErr := (err != nil)
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if released != testCase.expectedReleased {
+			t.Fatalf("case %v: expected %d released locks, got %d", i+1, testCase.expectedReleased, released)
+		}
+	}
+}
+
+func TestAdminRPCClientForceUnlock(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerForceUnlock(t, rpcClient)
+}
+
+func testAdminCmdRunnerRuntimeInfo(t *testing.T, client adminCmdRunner) {
+	data, err := client.RuntimeInfo()
+	if err != nil {
+		t.Fatalf("unable to get runtime info, %s", err)
+	}
+	if data.Goroutines <= 0 || data.HeapInuse == 0 {
+		t.Fatalf("expected goroutines and heap in use, got %v", data)
+	}
+}
+
+func TestAdminRPCClientRuntimeInfo(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerRuntimeInfo(t, rpcClient)
+}
+
+func testAdminCmdRunnerListSessions(t *testing.T, client adminCmdRunner) {
+	tmpGlobalSessions := globalSessions
+	defer func() {
+		globalSessions = tmpGlobalSessions
+	}()
+	globalSessions = newSessionTracker()
+
+	started := UTCNow().Add(-time.Minute)
+	id := globalSessions.start(SessionInfo{RemoteHost: "10.0.0.1:1000", AccessKey: "alice", API: "PutObject", Bucket: "bucket", Object: "object", Started: started})
+
+	sessions, err := client.ListSessions()
+	if err != nil {
+		t.Fatalf("unable to list sessions, %s", err)
+	}
+	if len(sessions) != 1 || sessions[0].AccessKey != "alice" || sessions[0].API != "PutObject" || !sessions[0].Started.Equal(started) {
+		t.Fatalf("expected the PutObject session of alice, got %v", sessions)
+	}
+	if sessions[0].Duration < time.Minute {
+		t.Fatalf("expected a session of at least 1 minute, got %v", sessions[0].Duration)
+	}
+
+	globalSessions.done(id)
+	if sessions, err = client.ListSessions(); err != nil || len(sessions) != 0 {
+		t.Fatalf("expected no sessions, got %v, %v", sessions, err)
+	}
+}
+
+func TestAdminRPCClientListSessions(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerListSessions(t, rpcClient)
+}
+
+func testAdminCmdRunnerValidateConfig(t *testing.T, client adminCmdRunner) {
+	configBytes, err := json.Marshal(globalServerConfig)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	validationErrs, err := client.ValidateConfig(configBytes)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(validationErrs) != 0 {
+		t.Fatalf("expected no validation errors, got: %v", validationErrs)
+	}
+
+	validationErrs, err = client.ValidateConfig(newTestUnreachableTargetConfig(t))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(validationErrs) != 1 || validationErrs[0].Subsystem != "notify.amqp.1" {
+		t.Fatalf("expected error of notify.amqp.1, got: %v", validationErrs)
+	}
+}
+
+func TestAdminRPCClientValidateConfig(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerValidateConfig(t, rpcClient)
+}
+
+func testAdminCmdRunnerMaintenance(t *testing.T, client adminCmdRunner) {
+	tmpGlobalSessions := globalSessions
+	defer func() {
+		globalSessions = tmpGlobalSessions
+		exitMaintenance()
+	}()
+	globalSessions = newSessionTracker()
+	globalSessions.start(SessionInfo{RemoteHost: "10.0.0.1:1000", API: "GetObject", Started: UTCNow()})
+
+	inFlight, err := client.MaintenanceEnter(10 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("unable to enter maintenance, %s", err)
+	}
+	if inFlight != 1 || !isInMaintenance() {
+		t.Fatalf("expected maintenance with 1 call in progress, got %v, %d", isInMaintenance(), inFlight)
+	}
+
+	if err = client.MaintenanceExit(); err != nil {
+		t.Fatalf("unable to exit maintenance, %s", err)
+	}
+	if isInMaintenance() {
+		t.Fatal("expected the server to leave maintenance")
+	}
+}
+
+func TestAdminRPCClientMaintenance(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerMaintenance(t, rpcClient)
+}
+
+func testAdminCmdRunnerBatchJobs(t *testing.T, client adminCmdRunner) {
+	tmpGlobalObjectAPI := globalObjectAPI
+	tmpGlobalBatchJobSys := globalBatchJobSys
+	defer func() {
+		globalObjectAPI = tmpGlobalObjectAPI
+		globalBatchJobSys = tmpGlobalBatchJobSys
+	}()
+
+	globalBatchJobSys = nil
+	if _, err := client.ListBatchJobs(); err == nil {
+		t.Fatalf("expected error when batch jobs are not initialized")
+	}
+
+	initNSLock(false)
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = objLayer.MakeBucketWithLocation(context.Background(), "bucket", ""); err != nil {
+		t.Fatalf("unable to create bucket, %s", err)
+	}
+	globalObjectAPI = objLayer
+	globalBatchJobSys = NewBatchJobSys()
+
+	job := BatchJob{
+		ID:      mustGetUUID(),
+		Request: BatchJobRequest{Type: BatchJobExpire, Bucket: "bucket", OlderThan: time.Hour},
+		Nodes:   []string{"127.0.0.1:9000"},
+		Started: UTCNow(),
+	}
+
+	testCases := []struct {
+		job       BatchJob
+		nodeIndex int
+		expectErr bool
+	}{
+		{job, 1, true},
+		{BatchJob{ID: mustGetUUID(), Request: BatchJobRequest{Type: BatchJobExpire, Bucket: "bucket"}, Nodes: job.Nodes}, 0, true},
+		{job, 0, false},
+		// Starting a known job again does nothing.
+		{job, 0, false},
+	}
+
+	for i, testCase := range testCases {
+		err := client.StartBatchJob(testCase.job, testCase.nodeIndex)
+		expectErr := (err != nil)
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+	}
+
+	for i := 0; ; i++ {
+		progress, err := client.DescribeBatchJob(job.ID)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if progress.Status == BatchJobCompleted {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("unexpected progress %v", progress)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	progress, err := client.ListBatchJobs()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(progress) != 1 || progress[0].ID != job.ID || progress[0].Addr != job.Nodes[0] {
+		t.Fatalf("unexpected progress %v", progress)
+	}
+
+	if err = client.CancelBatchJob(job.ID); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = client.CancelBatchJob("unknown"); err == nil || err.Error() != errNoSuchBatchJob.Error() {
+		t.Fatalf("expected: %v, got: %v", errNoSuchBatchJob, err)
+	}
+	if _, err = client.DescribeBatchJob("unknown"); err == nil || err.Error() != errNoSuchBatchJob.Error() {
+		t.Fatalf("expected: %v, got: %v", errNoSuchBatchJob, err)
+	}
+}
+
+func TestAdminRPCClientBatchJobs(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerBatchJobs(t, rpcClient)
+}
+
+func TestAdminRPCClientReloadCerts(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	testAdminCmdRunnerReloadCerts(t, rpcClient)
 }
 
 func TestAdminRPCClientGetConfig(t *testing.T) {
@@ -25,9 +25,11 @@ const (
 	responseRequestIDKey = "x-amz-request-id"
 )
 
-// ObjectIdentifier carries key name for the object to delete.
+// ObjectIdentifier carries key name for the object to delete, and the
+// version to delete if any.
 type ObjectIdentifier struct {
 	ObjectName string `xml:"Key"`
+	VersionID  string `xml:"VersionId,omitempty"`
 }
 
 // createBucketConfiguration container for bucket configuration request from client.
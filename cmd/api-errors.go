@@ -26,6 +26,7 @@ import (
 	"github.com/minio/minio/pkg/dns"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/tagging"
 )
 
 // APIError structure
@@ -55,11 +56,34 @@ const (
 	ErrNone APIErrorCode = iota
 	ErrAccessDenied
 	ErrBadDigest
+	ErrChecksumMismatch
+	ErrInvalidChecksum
+	ErrInvalidTrailer
+	ErrInvalidObjectAttributes
+	ErrInvalidPartNumber
+	ErrInvalidPartNumberArgument
+	ErrRangeWithPartNumber
+	ErrInvalidAppendPosition
+	ErrAppendPositionMismatch
+	ErrTooManyAppendedParts
+	ErrTooManyComposeSources
+	ErrComposeSourceIsDestination
+	ErrInvalidSearchQuery
+	ErrTooManySortedListObjects
+	ErrInvalidCopySourceTarget
 	ErrEntityTooSmall
 	ErrEntityTooLarge
 	ErrIncompleteBody
 	ErrInternalError
 	ErrInvalidAccessKeyID
+	ErrInvalidToken
+	ErrExpiredToken
+	ErrSTSMissingParameter
+	ErrSTSInvalidParameterValue
+	ErrSTSInvalidAction
+	ErrSTSMalformedPolicyDocument
+	ErrSTSInvalidIdentityToken
+	ErrSTSNotInitialized
 	ErrInvalidBucketName
 	ErrInvalidDigest
 	ErrInvalidRange
@@ -68,6 +92,8 @@ const (
 	ErrInvalidMaxKeys
 	ErrInvalidMaxUploads
 	ErrInvalidMaxParts
+	ErrInvalidMaxContentLength
+	ErrUnsignedMaxContentLength
 	ErrInvalidPartNumberMarker
 	ErrInvalidRequestBody
 	ErrInvalidCopySource
@@ -83,11 +109,43 @@ const (
 	ErrNoSuchBucketPolicy
 	ErrNoSuchKey
 	ErrNoSuchUpload
+	ErrNoSuchVersion
+	ErrInvalidVersionID
+	ErrInvalidVersionIDMarker
 	ErrNotImplemented
 	ErrPreconditionFailed
 	ErrRequestTimeTooSkewed
 	ErrSignatureDoesNotMatch
 	ErrMethodNotAllowed
+	ErrObjectLocked
+	ErrInvalidBucketObjectLockConfiguration
+	ErrNoSuchObjectLockConfiguration
+	ErrObjectLockConfigurationNotFound
+	ErrObjectLockInvalidHeaders
+	ErrUnknownWORMModeDirective
+	ErrInvalidRetentionDate
+	ErrPastObjectLockRetainDate
+	ErrUnknownLegalHoldStatus
+	ErrInvalidTag
+	ErrInvalidTaggingDirective
+	ErrNoSuchTagSet
+	ErrNoSuchLifecycleConfiguration
+	ErrObjectLockVersioningState
+	ErrNoSuchReplicationConfiguration
+	ErrReplicationVersioningState
+	ErrReplicationNeedsVersioning
+	ErrReplicationInvalidRole
+	ErrNoSuchBucketSSEConfig
+	ErrNoSuchCORSConfiguration
+	ErrCORSNotEnabled
+	ErrCORSForbidden
+	ErrNoSuchInventoryConfiguration
+	ErrInvalidInventoryID
+	ErrInvalidInventoryDestination
+	ErrTooManyInventoryConfigurations
+	ErrNoSuchWebsiteConfiguration
+	ErrInvalidRedirectLocation
+	ErrInvalidTargetBucketForLogging
 	ErrInvalidPart
 	ErrInvalidPartOrder
 	ErrAuthorizationHeaderMalformed
@@ -97,6 +155,7 @@ const (
 	ErrBucketNotEmpty
 	ErrAllAccessDisabled
 	ErrMalformedPolicy
+	ErrPolicyTooLarge
 	ErrMissingFields
 	ErrMissingCredTag
 	ErrCredMalformed
@@ -140,11 +199,14 @@ const (
 	ErrMissingSSECustomerKeyMD5
 	ErrSSECustomerKeyMD5Mismatch
 	ErrInvalidSSECustomerParameters
+	ErrKMSNotConfigured
 
 	// Bucket notification related errors.
 	ErrEventNotification
 	ErrARNNotification
 	ErrRegionNotification
+	ErrInvalidListenToken
+	ErrInvalidListenPing
 	ErrOverlappingFilterNotification
 	ErrFilterNameInvalid
 	ErrFilterNamePrefix
@@ -184,6 +246,32 @@ const (
 	ErrAdminConfigTooLarge
 	ErrAdminConfigBadJSON
 	ErrAdminCredentialsMismatch
+	ErrAdminNoSuchServiceAccount
+	ErrAdminInvalidParentUser
+	ErrAdminInvalidPayloadSize
+	ErrAdminInvalidUpdateURL
+	ErrAdminInvalidUpdateChecksum
+	ErrAdminInvalidBucketQuota
+	ErrAdminNoSuchQuotaConfiguration
+	ErrAdminBucketQuotaExceeded
+	ErrAdminInvalidBucketAccess
+	ErrAdminInvalidBucketDefaultTags
+	ErrAdminNoSuchBucketPolicyVersion
+	ErrAdminInvalidRemoteTarget
+	ErrAdminNoSuchRemoteTarget
+	ErrAdminInvalidTier
+	ErrAdminTierAlreadyExists
+	ErrAdminNoSuchTier
+	ErrAdminTLSNotEnabled
+	ErrAdminInvalidLogLevel
+	ErrAdminInvalidConfigFormat
+	ErrAdminConfigBadFormat
+	ErrAdminInvalidBatchJob
+	ErrAdminNoSuchBatchJob
+	ErrAdminInvalidScannerSpeed
+	ErrAdminInvalidForceUnlock
+	ErrAdminInvalidIAMArchive
+	ErrAdminInvalidMaintenance
 	ErrInsecureClientRequest
 	ErrObjectTampered
 	ErrHealNotImplemented
@@ -238,6 +326,16 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "Argument max-parts must be an integer between 0 and 2147483647",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidMaxContentLength: {
+		Code:           "InvalidArgument",
+		Description:    "Argument X-Minio-Max-Content-Length must be a non-negative integer",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrUnsignedMaxContentLength: {
+		Code:           "InvalidArgument",
+		Description:    "Argument X-Minio-Max-Content-Length is not signed by signature V2 presigned requests, use signature V4",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidPartNumberMarker: {
 		Code:           "InvalidArgument",
 		Description:    "Argument partNumberMarker must be an integer.",
@@ -258,6 +356,81 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "The Content-Md5 you specified did not match what we received.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrChecksumMismatch: {
+		Code:           "BadDigest",
+		Description:    "The checksum you specified did not match what we received.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidChecksum: {
+		Code:           "InvalidRequest",
+		Description:    "The x-amz-checksum header you specified is invalid, a single checksum header with a base64 encoded checksum is expected.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidTrailer: {
+		Code:           "InvalidRequest",
+		Description:    "The x-amz-trailer header you specified is invalid, a single x-amz-checksum header is expected.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidObjectAttributes: {
+		Code:           "InvalidArgument",
+		Description:    "The x-amz-object-attributes header you specified is invalid, a comma separated list of ETag, Checksum, ObjectParts, StorageClass and ObjectSize is expected.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidPartNumber: {
+		Code:           "InvalidPartNumber",
+		Description:    "The requested partnumber is not satisfiable",
+		HTTPStatusCode: http.StatusRequestedRangeNotSatisfiable,
+	},
+	ErrInvalidPartNumberArgument: {
+		Code:           "InvalidArgument",
+		Description:    "Part number must be an integer between 1 and 10000, inclusive",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrRangeWithPartNumber: {
+		Code:           "InvalidRequest",
+		Description:    "Cannot specify both Range header and partNumber query parameter",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidAppendPosition: {
+		Code:           "InvalidArgument",
+		Description:    "Argument X-Minio-Append-Position must be a non-negative integer",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAppendPositionMismatch: {
+		Code:           "InvalidAppendPosition",
+		Description:    "Data can only be appended at the end of the object, the position must be the size of the object.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrTooManyAppendedParts: {
+		Code:           "InvalidRequest",
+		Description:    "The object already has the maximum of 10000 parts, no more data can be appended to it.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrTooManyComposeSources: {
+		Code:           "InvalidRequest",
+		Description:    "A compose request may have at most 32 source objects.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrComposeSourceIsDestination: {
+		Code:           "InvalidRequest",
+		Description:    "The destination object of a compose request cannot be one of its source objects.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidSearchQuery: {
+		Code:           "InvalidArgument",
+		Description:    "A tag or metadata predicate of the search request is invalid, at most 10 predicates of the form tag=<key>=<value> or metadata=<key><op><value> are expected.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrTooManySortedListObjects: {
+		Code:           "InvalidArgument",
+		Description:    "Too many objects under the prefix to be sorted by modification time, list them by name or under a longer prefix.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidCopySourceTarget: {
+		Code:           "InvalidArgument",
+		Description:    "X-Minio-Copy-Source-Target must be the ARN of a copy target of the destination bucket for the source bucket.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrEntityTooSmall: {
 		Code:           "EntityTooSmall",
 		Description:    "Your proposed upload is smaller than the minimum allowed object size.",
@@ -283,6 +456,46 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "The access key ID you provided does not exist in our records.",
 		HTTPStatusCode: http.StatusForbidden,
 	},
+	ErrInvalidToken: {
+		Code:           "InvalidToken",
+		Description:    "The provided token is malformed or otherwise invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrExpiredToken: {
+		Code:           "ExpiredToken",
+		Description:    "The provided token has expired.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSTSMissingParameter: {
+		Code:           "MissingParameter",
+		Description:    "A required parameter for the specified action is not supplied.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSTSInvalidParameterValue: {
+		Code:           "InvalidParameterValue",
+		Description:    "An invalid or out-of-range value was supplied for the input parameter.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSTSInvalidAction: {
+		Code:           "InvalidAction",
+		Description:    "The action or operation requested is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSTSMalformedPolicyDocument: {
+		Code:           "MalformedPolicyDocument",
+		Description:    "The request was rejected because the policy document was malformed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSTSInvalidIdentityToken: {
+		Code:           "InvalidIdentityToken",
+		Description:    "The web identity token that was passed could not be validated.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSTSNotInitialized: {
+		Code:           "STSNotInitialized",
+		Description:    "STS API not initialized, please configure an OpenID Connect provider.",
+		HTTPStatusCode: http.StatusNotImplemented,
+	},
 	ErrInvalidBucketName: {
 		Code:           "InvalidBucketName",
 		Description:    "The specified bucket is not valid.",
@@ -338,6 +551,21 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "The specified multipart upload does not exist. The upload ID may be invalid, or the upload may have been aborted or completed.",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrNoSuchVersion: {
+		Code:           "NoSuchVersion",
+		Description:    "The specified version does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidVersionID: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid version id specified",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidVersionIDMarker: {
+		Code:           "InvalidArgument",
+		Description:    "A version-id marker cannot be specified without a key marker.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrNotImplemented: {
 		Code:           "NotImplemented",
 		Description:    "A header you provided implies functionality that is not implemented",
@@ -363,6 +591,151 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "The specified method is not allowed against this resource.",
 		HTTPStatusCode: http.StatusMethodNotAllowed,
 	},
+	ErrObjectLocked: {
+		Code:           "AccessDenied",
+		Description:    "Access Denied because object protected by object lock.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrInvalidBucketObjectLockConfiguration: {
+		Code:           "InvalidRequest",
+		Description:    "Bucket is missing ObjectLockConfiguration",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchObjectLockConfiguration: {
+		Code:           "NoSuchObjectLockConfiguration",
+		Description:    "The specified object does not have a ObjectLock configuration",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrObjectLockConfigurationNotFound: {
+		Code:           "ObjectLockConfigurationNotFoundError",
+		Description:    "Object Lock configuration does not exist for this bucket",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrObjectLockInvalidHeaders: {
+		Code:           "InvalidRequest",
+		Description:    "x-amz-object-lock-retain-until-date and x-amz-object-lock-mode must both be supplied",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrUnknownWORMModeDirective: {
+		Code:           "InvalidRequest",
+		Description:    "unknown wormMode directive",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidRetentionDate: {
+		Code:           "InvalidRequest",
+		Description:    "Date must be provided in ISO 8601 format",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrPastObjectLockRetainDate: {
+		Code:           "InvalidRequest",
+		Description:    "the retain until date must be in the future",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrUnknownLegalHoldStatus: {
+		Code:           "InvalidArgument",
+		Description:    "Legal Hold must be either of 'ON' or 'OFF'",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidTag: {
+		Code:           "InvalidTag",
+		Description:    "The tag provided was not a valid tag. This error can occur if the tag did not pass input validation.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidTaggingDirective: {
+		Code:           "InvalidArgument",
+		Description:    "Unknown tagging directive.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchTagSet: {
+		Code:           "NoSuchTagSet",
+		Description:    "The TagSet does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchLifecycleConfiguration: {
+		Code:           "NoSuchLifecycleConfiguration",
+		Description:    "The lifecycle configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrObjectLockVersioningState: {
+		Code:           "InvalidBucketState",
+		Description:    "An Object Lock configuration is present on this bucket, so the versioning state cannot be changed.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrNoSuchReplicationConfiguration: {
+		Code:           "ReplicationConfigurationNotFoundError",
+		Description:    "The replication configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrReplicationVersioningState: {
+		Code:           "InvalidBucketState",
+		Description:    "A replication configuration is present on this bucket, so the versioning state cannot be changed.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrReplicationNeedsVersioning: {
+		Code:           "InvalidRequest",
+		Description:    "Versioning must be 'Enabled' on the bucket to apply a replication configuration",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrReplicationInvalidRole: {
+		Code:           "InvalidArgument",
+		Description:    "The role must be the ARN of a replication target of the bucket for the destination bucket",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchBucketSSEConfig: {
+		Code:           "ServerSideEncryptionConfigurationNotFoundError",
+		Description:    "The server side encryption configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchCORSConfiguration: {
+		Code:           "NoSuchCORSConfiguration",
+		Description:    "The CORS configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrCORSNotEnabled: {
+		Code:           "AccessForbidden",
+		Description:    "CORSResponse: CORS is not enabled for this bucket.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrCORSForbidden: {
+		Code:           "AccessForbidden",
+		Description:    "CORSResponse: This CORS request is not allowed. This is usually because the evaluation of Origin, request method / Access-Control-Request-Method or Access-Control-Request-Headers are not whitelisted by the resource's CORS spec.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrNoSuchInventoryConfiguration: {
+		Code:           "NoSuchConfiguration",
+		Description:    "The specified configuration does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidInventoryID: {
+		Code:           "InvalidArgument",
+		Description:    "The inventory ID must be given by the id query parameter and match the ID of the configuration.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidInventoryDestination: {
+		Code:           "InvalidArgument",
+		Description:    "The destination bucket of the inventory configuration does not exist.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrTooManyInventoryConfigurations: {
+		Code:           "TooManyConfigurations",
+		Description:    "You are attempting to create a new configuration but have already reached the 1,000-configuration limit.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchWebsiteConfiguration: {
+		Code:           "NoSuchWebsiteConfiguration",
+		Description:    "The specified bucket does not have a website configuration",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidRedirectLocation: {
+		Code:           "InvalidRedirectLocation",
+		Description:    "The website redirect location must have a prefix of 'http://' or 'https://' or '/'.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidPart: {
 		Code:           "InvalidPart",
 		Description:    "One or more of the specified parts could not be found.  The part may not have been uploaded, or the specified entity tag may not match the part's entity tag.",
@@ -418,6 +791,11 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "Policy has invalid resource.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrPolicyTooLarge: {
+		Code:           "PolicyTooLarge",
+		Description:    "The bucket policy holding the object ACLs would exceed the maximum allowed size of 20 KiB.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrMissingFields: {
 		Code:           "MissingFields",
 		Description:    "Missing fields in request.",
@@ -579,6 +957,16 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "A specified destination is in a different region than the bucket. You must use a destination that resides in the same region as the bucket.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidListenToken: {
+		Code:           "InvalidArgument",
+		Description:    "The token you specified is invalid, the sequencer of the last event received is expected.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidListenPing: {
+		Code:           "InvalidArgument",
+		Description:    "The ping interval you specified is invalid, a number of seconds between 1 and 3600 is expected.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrOverlappingFilterNotification: {
 		Code:           "InvalidArgument",
 		Description:    "An object key name filtering rule defined with overlapping prefixes, overlapping suffixes, or overlapping combinations of prefixes and suffixes for the same event types.",
@@ -679,6 +1067,11 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "The provided encryption parameters did not match the ones used originally.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrKMSNotConfigured: {
+		Code:           "InvalidArgument",
+		Description:    "Server side encryption specified but KMS is not configured",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	/// S3 extensions.
 	ErrContentSHA256Mismatch: {
@@ -769,6 +1162,136 @@ var errorCodeResponse = map[APIErrorCode]APIError{
 		Description:    "Credentials in config mismatch with server environment variables",
 		HTTPStatusCode: http.StatusServiceUnavailable,
 	},
+	ErrAdminNoSuchServiceAccount: {
+		Code:           "XMinioAdminNoSuchServiceAccount",
+		Description:    "The specified service account does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminInvalidParentUser: {
+		Code:           "XMinioAdminInvalidParentUser",
+		Description:    "The parent user of a service account must be an existing user.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidPayloadSize: {
+		Code:           "XMinioAdminInvalidPayloadSize",
+		Description:    "The specified payload size is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidUpdateURL: {
+		Code:           "XMinioAdminInvalidUpdateURL",
+		Description:    "The specified update URL is not a valid http or https URL.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidUpdateChecksum: {
+		Code:           "XMinioAdminInvalidUpdateChecksum",
+		Description:    "The specified update checksum is not a valid hex encoded SHA256 sum.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidBucketQuota: {
+		Code:           "XMinioAdminInvalidBucketQuota",
+		Description:    "The specified bucket quota is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchQuotaConfiguration: {
+		Code:           "XMinioAdminNoSuchQuotaConfiguration",
+		Description:    "The quota configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminBucketQuotaExceeded: {
+		Code:           "XMinioAdminBucketQuotaExceeded",
+		Description:    "Bucket quota exceeded",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidBucketAccess: {
+		Code:           "XMinioAdminInvalidBucketAccess",
+		Description:    "The specified bucket access is invalid, none, download, upload or public is expected.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidBucketDefaultTags: {
+		Code:           "XMinioAdminInvalidBucketDefaultTags",
+		Description:    "The specified default tags are invalid, at most 10 tags with valid keys and values are expected.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchBucketPolicyVersion: {
+		Code:           "XMinioAdminNoSuchBucketPolicyVersion",
+		Description:    "The specified bucket policy version does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminInvalidRemoteTarget: {
+		Code:           "XMinioAdminInvalidRemoteTarget",
+		Description:    "The specified remote target is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchRemoteTarget: {
+		Code:           "XMinioAdminNoSuchRemoteTarget",
+		Description:    "The remote target does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminInvalidTier: {
+		Code:           "XMinioAdminInvalidTier",
+		Description:    "The specified tier is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminTierAlreadyExists: {
+		Code:           "XMinioAdminTierAlreadyExists",
+		Description:    "The specified tier already exists.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminNoSuchTier: {
+		Code:           "XMinioAdminNoSuchTier",
+		Description:    "The specified tier does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminTLSNotEnabled: {
+		Code:           "XMinioAdminTLSNotEnabled",
+		Description:    "The server is not configured to serve TLS.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidLogLevel: {
+		Code:           "XMinioAdminInvalidLogLevel",
+		Description:    "The specified log subsystem or level is not valid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidConfigFormat: {
+		Code:           "XMinioAdminInvalidConfigFormat",
+		Description:    "The specified config format is not valid, expected kv or yaml.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminConfigBadFormat: {
+		Code:           "XMinioAdminConfigBadFormat",
+		Description:    "The configuration provided is not valid in the specified format.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidBatchJob: {
+		Code:           "XMinioAdminInvalidBatchJob",
+		Description:    "The specified batch job is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchBatchJob: {
+		Code:           "XMinioAdminNoSuchBatchJob",
+		Description:    "The specified batch job does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminInvalidScannerSpeed: {
+		Code:           "XMinioAdminInvalidScannerSpeed",
+		Description:    "The specified scanner speed is not valid, expected slow, default, fast or off.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidForceUnlock: {
+		Code:           "XMinioAdminInvalidForceUnlock",
+		Description:    "Either a bucket or the address of a client is required to force unlock.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidIAMArchive: {
+		Code:           "XMinioAdminInvalidIAMArchive",
+		Description:    "The IAM archive is not a valid zip archive made by IAM export.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminInvalidMaintenance: {
+		Code:           "XMinioAdminInvalidMaintenance",
+		Description:    "The address of a node of the cluster, an action of enter or exit and a drain timeout of at most 10 minutes are expected.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -886,6 +1409,38 @@ func toAPIErrorCode(err error) (apiErr APIErrorCode) {
 		apiErr = ErrInvalidSSECustomerParameters
 	case errSSEKeyMismatch:
 		apiErr = ErrAccessDenied // no access without correct key
+	case errKMSNotConfigured:
+		apiErr = ErrKMSNotConfigured
+	case tagging.ErrTooManyTags, tagging.ErrInvalidTagKey, tagging.ErrInvalidTagValue, tagging.ErrDuplicateTagKey:
+		apiErr = ErrInvalidTag
+	case errNoSuchServiceAccount:
+		apiErr = ErrAdminNoSuchServiceAccount
+	case errInvalidParentUser:
+		apiErr = ErrAdminInvalidParentUser
+	case errInvalidIAMArchive:
+		apiErr = ErrAdminInvalidIAMArchive
+	case errInvalidSearchQuery:
+		apiErr = ErrInvalidSearchQuery
+	case errInvalidSortedListToken:
+		apiErr = ErrIncorrectContinuationToken
+	case errTooManySortedListObjects:
+		apiErr = ErrTooManySortedListObjects
+	case errNoSuchBucketPolicyVersion:
+		apiErr = ErrAdminNoSuchBucketPolicyVersion
+	case errPolicyTooLarge:
+		apiErr = ErrPolicyTooLarge
+	case errInvalidRemoteTarget:
+		apiErr = ErrAdminInvalidRemoteTarget
+	case errInvalidTier:
+		apiErr = ErrAdminInvalidTier
+	case errTierAlreadyExists:
+		apiErr = ErrAdminTierAlreadyExists
+	case errNoSuchTier:
+		apiErr = ErrAdminNoSuchTier
+	case errInvalidBatchJob:
+		apiErr = ErrAdminInvalidBatchJob
+	case errNoSuchBatchJob:
+		apiErr = ErrAdminNoSuchBatchJob
 	case context.Canceled, context.DeadlineExceeded:
 		apiErr = ErrOperationTimedOut
 	}
@@ -906,6 +1461,10 @@ func toAPIErrorCode(err error) (apiErr APIErrorCode) {
 		apiErr = ErrStorageFull
 	case hash.BadDigest:
 		apiErr = ErrBadDigest
+	case hash.ChecksumMismatch:
+		apiErr = ErrChecksumMismatch
+	case hash.InvalidChecksum:
+		apiErr = ErrInvalidChecksum
 	case AllAccessDisabled:
 		apiErr = ErrAllAccessDisabled
 	case IncompleteBody:
@@ -928,6 +1487,20 @@ func toAPIErrorCode(err error) (apiErr APIErrorCode) {
 		apiErr = ErrBucketAlreadyOwnedByYou
 	case ObjectNotFound:
 		apiErr = ErrNoSuchKey
+	case VersionNotFound:
+		apiErr = ErrNoSuchVersion
+	case MethodNotAllowed:
+		apiErr = ErrMethodNotAllowed
+	case ObjectLocked:
+		apiErr = ErrObjectLocked
+	case PreconditionFailed:
+		apiErr = ErrPreconditionFailed
+	case InvalidAppendPosition:
+		apiErr = ErrAppendPositionMismatch
+	case TooManyAppendedParts:
+		apiErr = ErrTooManyAppendedParts
+	case ObjectNotTransitioned:
+		apiErr = ErrInvalidObjectState
 	case ObjectAlreadyExists:
 		apiErr = ErrMethodNotAllowed
 	case ObjectNameInvalid:
@@ -966,6 +1539,12 @@ func toAPIErrorCode(err error) (apiErr APIErrorCode) {
 		apiErr = ErrUnsupportedMetadata
 	case BucketPolicyNotFound:
 		apiErr = ErrNoSuchBucketPolicy
+	case BucketQuotaConfigNotFound:
+		apiErr = ErrAdminNoSuchQuotaConfiguration
+	case BucketRemoteTargetNotFound:
+		apiErr = ErrAdminNoSuchRemoteTarget
+	case BucketQuotaExceeded:
+		apiErr = ErrAdminBucketQuotaExceeded
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
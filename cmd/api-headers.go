@@ -36,8 +36,9 @@ func mustGetRequestID(t time.Time) string {
 func setCommonHeaders(w http.ResponseWriter) {
 	w.Header().Set("Server", globalServerUserAgent)
 	// Set `x-amz-bucket-region` only if region is set on the server
-	// by default minio uses an empty region.
-	if region := globalServerConfig.GetRegion(); region != "" {
+	// by default minio uses an empty region, handlers of bucket
+	// requests may have set the region of the bucket already.
+	if region := globalServerConfig.GetRegion(); region != "" && w.Header().Get("X-Amz-Bucket-Region") == "" {
 		w.Header().Set("X-Amz-Bucket-Region", region)
 	}
 	w.Header().Set("Accept-Ranges", "bytes")
@@ -95,11 +96,47 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, contentRange *h
 		w.Header().Set(k, v)
 	}
 
+	// Set version ID of objects in versioned buckets.
+	if objInfo.VersionID != "" {
+		w.Header().Set(amzVersionID, objInfo.VersionID)
+	}
+
+	// Set the number of tags of tagged objects.
+	if _, ok := objInfo.UserDefined[objectTaggingKey]; ok {
+		w.Header().Set(amzTaggingCount, strconv.Itoa(len(getObjectTags(objInfo.UserDefined).TagSet.Tags)))
+	}
+
+	// Set the replication status of replicated objects and replicas.
+	if status := getObjectReplicationStatus(objInfo.UserDefined); status != "" {
+		w.Header().Set(amzReplicationStatus, string(status))
+	}
+
+	// Set the expiry date of objects expired by a lifecycle rule.
+	setObjectExpirationHeader(w, objInfo)
+
+	// Set the restore status of restored transitioned objects.
+	if transition, ok := getObjectTransition(objInfo.UserDefined); ok && transition.IsRestored() {
+		w.Header().Set(amzRestore, fmt.Sprintf(`ongoing-request="false", expiry-date="%s"`, transition.RestoreExpiry.UTC().Format(http.TimeFormat)))
+	}
+
+	// Set the additional checksum of objects uploaded with one, it is
+	// the checksum of the whole object and not sent for ranges.
+	if checksumHeader, checksum := getObjectChecksum(objInfo.UserDefined); checksumHeader != "" && (contentRange == nil || contentRange.offsetBegin <= -1) {
+		w.Header().Set(checksumHeader, checksum)
+	}
+
 	// for providing ranged content
 	if contentRange != nil && contentRange.offsetBegin > -1 {
 		// Override content-length
 		w.Header().Set("Content-Length", strconv.FormatInt(contentRange.getLength(), 10))
 		w.Header().Set("Content-Range", contentRange.String())
+	}
+}
+
+// writePartialContentStatus - writes the partial content status of a
+// response providing ranged content, once all its headers are set.
+func writePartialContentStatus(w http.ResponseWriter, contentRange *httpRange) {
+	if contentRange != nil && contentRange.offsetBegin > -1 {
 		w.WriteHeader(http.StatusPartialContent)
 	}
 }
@@ -175,6 +175,77 @@ func TestListObjectsV1Resources(t *testing.T) {
 	}
 }
 
+// Validates extracting information for list object versions.
+func TestListObjectVersionsResources(t *testing.T) {
+	versionID := mustGetUUID()
+	testCases := []struct {
+		values                                        url.Values
+		prefix, keyMarker, versionIDMarker, delimiter string
+		maxKeys                                       int
+		errCode                                       APIErrorCode
+	}{
+		{
+			values: url.Values{
+				"prefix":            []string{"photos/"},
+				"key-marker":        []string{"photos/a"},
+				"version-id-marker": []string{versionID},
+				"delimiter":         []string{"/"},
+				"max-keys":          []string{"100"},
+			},
+			prefix:          "photos/",
+			keyMarker:       "photos/a",
+			versionIDMarker: versionID,
+			delimiter:       "/",
+			maxKeys:         100,
+			errCode:         ErrNone,
+		},
+		{
+			values: url.Values{
+				"prefix":     []string{"photos/"},
+				"key-marker": []string{"photos/a"},
+			},
+			prefix:    "photos/",
+			keyMarker: "photos/a",
+			maxKeys:   1000,
+			errCode:   ErrNone,
+		},
+		{
+			values: url.Values{
+				"version-id-marker": []string{versionID},
+			},
+			versionIDMarker: versionID,
+			maxKeys:         1000,
+			errCode:         ErrInvalidVersionIDMarker,
+		},
+		{
+			values: url.Values{
+				"key-marker":        []string{"photos/a"},
+				"version-id-marker": []string{"invalid"},
+			},
+			keyMarker:       "photos/a",
+			versionIDMarker: "invalid",
+			maxKeys:         1000,
+			errCode:         ErrInvalidVersionID,
+		},
+	}
+
+	for i, testCase := range testCases {
+		prefix, keyMarker, versionIDMarker, delimiter, maxKeys, _, errCode := getListObjectVersionsArgs(testCase.values)
+		if errCode != testCase.errCode {
+			t.Errorf("Test %d: Expected error code:%d, got %d", i+1, testCase.errCode, errCode)
+		}
+		if prefix != testCase.prefix || keyMarker != testCase.keyMarker || versionIDMarker != testCase.versionIDMarker {
+			t.Errorf("Test %d: Expected %s %s %s, got %s %s %s", i+1, testCase.prefix, testCase.keyMarker, testCase.versionIDMarker, prefix, keyMarker, versionIDMarker)
+		}
+		if delimiter != testCase.delimiter {
+			t.Errorf("Test %d: Expected %s, got %s", i+1, testCase.delimiter, delimiter)
+		}
+		if maxKeys != testCase.maxKeys {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, testCase.maxKeys, maxKeys)
+		}
+	}
+}
+
 // Validates extracting information for object resources.
 func TestGetObjectsResources(t *testing.T) {
 	testCases := []struct {
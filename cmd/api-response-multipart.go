@@ -43,6 +43,7 @@ type completeMultipartAPIError struct {
 func writePartSmallErrorResponse(w http.ResponseWriter, r *http.Request, err PartTooSmall) {
 
 	apiError := getAPIError(toAPIErrorCode(err))
+	recordErrorCode(w, apiError.Code)
 	// Generate complete multipart error response.
 	errorResponse := getAPIErrorResponse(apiError, r.URL.Path, w.Header().Get(responseRequestIDKey))
 	cmpErrResp := completeMultipartAPIError{err.PartSize, int64(5242880), err.PartNumber, err.PartETag, errorResponse}
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"encoding/xml"
+	"net"
 	"net/http"
 	"net/url"
 	"path"
@@ -201,6 +202,54 @@ type Object struct {
 	StorageClass string
 }
 
+// ListVersionsResponse - format for list bucket versions response.
+type ListVersionsResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult" json:"-"`
+
+	Name            string
+	Prefix          string
+	KeyMarker       string
+	VersionIDMarker string `xml:"VersionIdMarker"`
+
+	// When response is truncated (the IsTruncated element value in the response
+	// is true), you can use the key name and version ID in these fields as key
+	// marker and version ID marker in the subsequent request to get next set of
+	// versions.
+	NextKeyMarker       string `xml:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string `xml:"NextVersionIdMarker,omitempty"`
+
+	MaxKeys   int
+	Delimiter string
+	// A flag that indicates whether or not ListObjectVersions returned all of
+	// the results that satisfied the search criteria.
+	IsTruncated bool
+
+	Versions       []ObjectVersion
+	CommonPrefixes []CommonPrefix
+
+	// Encoding type used to encode object keys in the response.
+	EncodingType string `xml:"EncodingType,omitempty"`
+}
+
+// ObjectVersion container for a version or a delete marker of an object,
+// XMLName is either Version or DeleteMarker so that both are listed in
+// order. Delete markers have no ETag, Size or StorageClass.
+type ObjectVersion struct {
+	XMLName      xml.Name
+	Key          string
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool
+	LastModified string // time string of format "2006-01-02T15:04:05.000Z"
+	ETag         string `xml:"ETag,omitempty"`
+	Size         *int64 `xml:"Size,omitempty"`
+
+	// Owner of the object.
+	Owner Owner
+
+	// The class of storage used to store the object.
+	StorageClass string `xml:"StorageClass,omitempty"`
+}
+
 // CopyObjectResponse container returns ETag and LastModified of the successfully copied object
 type CopyObjectResponse struct {
 	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyObjectResult" json:"-"`
@@ -245,9 +294,19 @@ type CompleteMultipartUploadResponse struct {
 
 // DeleteError structure.
 type DeleteError struct {
-	Code    string
-	Message string
-	Key     string
+	Code      string
+	Message   string
+	Key       string
+	VersionID string `xml:"VersionId,omitempty"`
+}
+
+// DeletedObject container for a deleted object, the version removed or
+// the delete marker added in versioned buckets.
+type DeletedObject struct {
+	ObjectName            string `xml:"Key"`
+	VersionID             string `xml:"VersionId,omitempty"`
+	DeleteMarker          bool   `xml:"DeleteMarker,omitempty"`
+	DeleteMarkerVersionID string `xml:"DeleteMarkerVersionId,omitempty"`
 }
 
 // DeleteObjectsResponse container for multiple object deletes.
@@ -255,7 +314,7 @@ type DeleteObjectsResponse struct {
 	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteResult" json:"-"`
 
 	// Collection of all deleted objects
-	DeletedObjects []ObjectIdentifier `xml:"Deleted,omitempty"`
+	DeletedObjects []DeletedObject `xml:"Deleted,omitempty"`
 
 	// Collection of errors deleting certain objects.
 	Errors []DeleteError `xml:"Error,omitempty"`
@@ -292,9 +351,16 @@ func getObjectLocation(r *http.Request, domain, bucket, object string) string {
 		Path:   path.Join(slashSeparator, bucket, object),
 		Scheme: proto,
 	}
-	// If domain is set then we need to use bucket DNS style.
+	// If domain is set then we need to use bucket DNS style, the host of
+	// virtual-host-style requests already has the bucket.
 	if domain != "" {
-		if strings.Contains(r.Host, domain) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if strings.HasSuffix(host, "."+domain) {
+			u.Path = path.Join(slashSeparator, object)
+		} else if strings.Contains(r.Host, domain) {
 			u.Host = bucket + "." + r.Host
 			u.Path = path.Join(slashSeparator, object)
 		}
@@ -366,6 +432,58 @@ func generateListObjectsV1Response(bucket, prefix, marker, delimiter string, max
 	return data
 }
 
+// generates an ListObjectVersions response for the said bucket with other enumerated options.
+func generateListVersionsResponse(bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int, resp ListObjectVersionsInfo) ListVersionsResponse {
+	var versions []ObjectVersion
+	var prefixes []CommonPrefix
+	var owner = Owner{}
+	var data = ListVersionsResponse{}
+
+	owner.ID = globalMinioDefaultOwnerID
+	for _, object := range resp.Objects {
+		var version = ObjectVersion{}
+		if object.Name == "" {
+			continue
+		}
+		version.Key = object.Name
+		version.VersionID = object.VersionID
+		version.IsLatest = object.IsLatest
+		version.LastModified = object.ModTime.UTC().Format(timeFormatAMZLong)
+		version.Owner = owner
+		if object.DeleteMarker {
+			version.XMLName.Local = "DeleteMarker"
+		} else {
+			version.XMLName.Local = "Version"
+			if object.ETag != "" {
+				version.ETag = "\"" + object.ETag + "\""
+			}
+			size := object.Size
+			version.Size = &size
+			version.StorageClass = object.StorageClass
+		}
+		versions = append(versions, version)
+	}
+	data.Name = bucket
+	data.Versions = versions
+
+	data.Prefix = prefix
+	data.KeyMarker = keyMarker
+	data.VersionIDMarker = versionIDMarker
+	data.Delimiter = delimiter
+	data.MaxKeys = maxKeys
+
+	data.NextKeyMarker = resp.NextKeyMarker
+	data.NextVersionIDMarker = resp.NextVersionIDMarker
+	data.IsTruncated = resp.IsTruncated
+	for _, prefix := range resp.Prefixes {
+		var prefixItem = CommonPrefix{}
+		prefixItem.Prefix = prefix
+		prefixes = append(prefixes, prefixItem)
+	}
+	data.CommonPrefixes = prefixes
+	return data
+}
+
 // generates an ListObjectsV2 response for the said bucket with other enumerated options.
 func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter, delimiter string, fetchOwner, isTruncated bool, maxKeys int, objects []ObjectInfo, prefixes []string) ListObjectsV2Response {
 	var contents []Object
@@ -507,7 +625,7 @@ func generateListMultipartUploadsResponse(bucket string, multipartsInfo ListMult
 }
 
 // generate multi objects delete response.
-func generateMultiDeleteResponse(quiet bool, deletedObjects []ObjectIdentifier, errs []DeleteError) DeleteObjectsResponse {
+func generateMultiDeleteResponse(quiet bool, deletedObjects []DeletedObject, errs []DeleteError) DeleteObjectsResponse {
 	deleteResp := DeleteObjectsResponse{}
 	if !quiet {
 		deleteResp.DeletedObjects = deletedObjects
@@ -538,6 +656,10 @@ const (
 	mimeJSON mimeType = "application/json"
 	// Means response type is XML.
 	mimeXML mimeType = "application/xml"
+	// Means response type is a zip archive.
+	mimeZip mimeType = "application/zip"
+	// Means response type is plain text.
+	mimeText mimeType = "text/plain"
 )
 
 // writeSuccessResponseJSON writes success headers and response if any,
@@ -576,6 +698,7 @@ func writeErrorResponse(w http.ResponseWriter, errorCode APIErrorCode, reqURL *u
 		w.Header().Set("Retry-After", "120")
 	}
 	apiError := getAPIError(errorCode)
+	recordErrorCode(w, apiError.Code)
 	// Generate error response.
 	errorResponse := getAPIErrorResponse(apiError, reqURL.Path, w.Header().Get(responseRequestIDKey))
 	encodedErrorResponse := encodeResponse(errorResponse)
@@ -584,6 +707,7 @@ func writeErrorResponse(w http.ResponseWriter, errorCode APIErrorCode, reqURL *u
 
 func writeErrorResponseHeadersOnly(w http.ResponseWriter, errorCode APIErrorCode) {
 	apiError := getAPIError(errorCode)
+	recordErrorCode(w, apiError.Code)
 	writeResponse(w, apiError.HTTPStatusCode, nil, mimeNone)
 }
 
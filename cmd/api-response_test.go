@@ -97,6 +97,17 @@ func TestObjectLocation(t *testing.T) {
 			object:           "test/1.txt",
 			expectedLocation: "https://mybucket.mys3.bucket.org/test/1.txt",
 		},
+		// Virtual-host-style request, the host already has the bucket.
+		{
+			request: &http.Request{
+				Host:   "mybucket.mys3.bucket.org:9000",
+				Header: map[string][]string{},
+			},
+			domain:           "mys3.bucket.org",
+			bucket:           "mybucket",
+			object:           "test/1.txt",
+			expectedLocation: "http://mybucket.mys3.bucket.org:9000/test/1.txt",
+		},
 	}
 	for i, testCase := range testCases {
 		gotLocation := getObjectLocation(testCase.request, testCase.domain, testCase.bucket, testCase.object)
@@ -47,6 +47,14 @@ func registerAPIRouter(router *mux.Router) {
 
 	// API Router
 	apiRouter := router.PathPrefix("/").Subrouter()
+
+	// Website endpoints of buckets, registered first as their domain may
+	// be a sub domain of the API domain.
+	if globalWebsiteDomainName != "" {
+		websiteRouter := apiRouter.Host("{bucket:.+}." + globalWebsiteDomainName).Subrouter()
+		websiteRouter.NewRoute().HandlerFunc(collectAPIStats("Website", httpTraceHdrs(api.WebsiteHandler)))
+	}
+
 	var routers []*mux.Router
 	if globalDomainName != "" {
 		routers = append(routers, apiRouter.Host("{bucket:.+}."+globalDomainName).Subrouter())
@@ -56,71 +64,155 @@ func registerAPIRouter(router *mux.Router) {
 	for _, bucket := range routers {
 		// Object operations
 		// HeadObject
-		bucket.Methods("HEAD").Path("/{object:.+}").HandlerFunc(httpTraceAll(api.HeadObjectHandler))
+		bucket.Methods("HEAD").Path("/{object:.+}").HandlerFunc(collectAPIStats("HeadObject", httpTraceAll(api.HeadObjectHandler)))
 		// CopyObjectPart
-		bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(httpTraceAll(api.CopyObjectPartHandler)).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
+		bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(collectAPIStats("CopyObjectPart", httpTraceAll(api.CopyObjectPartHandler))).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
 		// PutObjectPart
-		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(httpTraceHdrs(api.PutObjectPartHandler)).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(collectAPIStats("PutObjectPart", httpTraceHdrs(api.PutObjectPartHandler))).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
 		// ListObjectPxarts
-		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(httpTraceAll(api.ListObjectPartsHandler)).Queries("uploadId", "{uploadId:.*}")
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(collectAPIStats("ListObjectParts", httpTraceAll(api.ListObjectPartsHandler))).Queries("uploadId", "{uploadId:.*}")
+		// SelectObjectContent
+		bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(collectAPIStats("SelectObjectContent", httpTraceHdrs(api.SelectObjectContentHandler))).Queries("select", "", "select-type", "2")
+		// RestoreObject
+		bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(collectAPIStats("RestoreObject", httpTraceAll(api.RestoreObjectHandler))).Queries("restore", "")
 		// CompleteMultipartUpload
-		bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(httpTraceAll(api.CompleteMultipartUploadHandler)).Queries("uploadId", "{uploadId:.*}")
+		bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(collectAPIStats("CompleteMultipartUpload", httpTraceAll(api.CompleteMultipartUploadHandler))).Queries("uploadId", "{uploadId:.*}")
 		// NewMultipartUpload
-		bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(httpTraceAll(api.NewMultipartUploadHandler)).Queries("uploads", "")
+		bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(collectAPIStats("NewMultipartUpload", httpTraceAll(api.NewMultipartUploadHandler))).Queries("uploads", "")
 		// AbortMultipartUpload
-		bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(httpTraceAll(api.AbortMultipartUploadHandler)).Queries("uploadId", "{uploadId:.*}")
-		// GetObjectACL - this is a dummy call.
-		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(httpTraceHdrs(api.GetObjectACLHandler)).Queries("acl", "")
+		bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(collectAPIStats("AbortMultipartUpload", httpTraceAll(api.AbortMultipartUploadHandler))).Queries("uploadId", "{uploadId:.*}")
+		// GetObjectRetention
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(collectAPIStats("GetObjectRetention", httpTraceAll(api.GetObjectRetentionHandler))).Queries("retention", "")
+		// PutObjectRetention
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(collectAPIStats("PutObjectRetention", httpTraceAll(api.PutObjectRetentionHandler))).Queries("retention", "")
+		// GetObjectLegalHold
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(collectAPIStats("GetObjectLegalHold", httpTraceAll(api.GetObjectLegalHoldHandler))).Queries("legal-hold", "")
+		// PutObjectLegalHold
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(collectAPIStats("PutObjectLegalHold", httpTraceAll(api.PutObjectLegalHoldHandler))).Queries("legal-hold", "")
+		// GetObjectAttributes
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(collectAPIStats("GetObjectAttributes", httpTraceAll(api.GetObjectAttributesHandler))).Queries("attributes", "")
+		// GetObjectTagging
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(collectAPIStats("GetObjectTagging", httpTraceAll(api.GetObjectTaggingHandler))).Queries("tagging", "")
+		// PutObjectTagging
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(collectAPIStats("PutObjectTagging", httpTraceAll(api.PutObjectTaggingHandler))).Queries("tagging", "")
+		// DeleteObjectTagging
+		bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(collectAPIStats("DeleteObjectTagging", httpTraceAll(api.DeleteObjectTaggingHandler))).Queries("tagging", "")
+		// GetObjectACL
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(collectAPIStats("GetObjectACL", httpTraceHdrs(api.GetObjectACLHandler))).Queries("acl", "")
+		// PutObjectACL
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(collectAPIStats("PutObjectACL", httpTraceAll(api.PutObjectACLHandler))).Queries("acl", "")
 		// GetObject
-		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(httpTraceHdrs(api.GetObjectHandler))
+		bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(collectAPIStats("GetObject", httpTraceHdrs(api.GetObjectHandler)))
+		// ComposeObject
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(collectAPIStats("ComposeObject", httpTraceAll(api.ComposeObjectHandler))).Queries("compose", "")
 		// CopyObject
-		bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(httpTraceAll(api.CopyObjectHandler))
+		bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(collectAPIStats("CopyObject", httpTraceAll(api.CopyObjectHandler)))
 		// PutObject
-		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(httpTraceHdrs(api.PutObjectHandler))
+		bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(collectAPIStats("PutObject", httpTraceHdrs(api.PutObjectHandler)))
 		// DeleteObject
-		bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(httpTraceAll(api.DeleteObjectHandler))
+		bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(collectAPIStats("DeleteObject", httpTraceAll(api.DeleteObjectHandler)))
 
 		/// Bucket operations
 		// GetBucketLocation
-		bucket.Methods("GET").HandlerFunc(httpTraceAll(api.GetBucketLocationHandler)).Queries("location", "")
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketLocation", httpTraceAll(api.GetBucketLocationHandler))).Queries("location", "")
 		// GetBucketPolicy
-		bucket.Methods("GET").HandlerFunc(httpTraceAll(api.GetBucketPolicyHandler)).Queries("policy", "")
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketPolicy", httpTraceAll(api.GetBucketPolicyHandler))).Queries("policy", "")
 
 		// GetBucketACL -- this is a dummy call.
-		bucket.Methods("GET").HandlerFunc(httpTraceAll(api.GetBucketACLHandler)).Queries("acl", "")
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketACL", httpTraceAll(api.GetBucketACLHandler))).Queries("acl", "")
 
 		// GetBucketNotification
-		bucket.Methods("GET").HandlerFunc(httpTraceAll(api.GetBucketNotificationHandler)).Queries("notification", "")
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketNotification", httpTraceAll(api.GetBucketNotificationHandler))).Queries("notification", "")
+		// GetBucketCors
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketCors", httpTraceAll(api.GetBucketCorsHandler))).Queries("cors", "")
+		// GetBucketInventoryConfiguration
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketInventoryConfiguration", httpTraceAll(api.GetBucketInventoryConfigurationHandler))).Queries("inventory", "", "id", "{id:.*}")
+		// ListBucketInventoryConfigurations
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("ListBucketInventoryConfigurations", httpTraceAll(api.ListBucketInventoryConfigurationsHandler))).Queries("inventory", "")
+		// GetBucketEncryption
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketEncryption", httpTraceAll(api.GetBucketEncryptionHandler))).Queries("encryption", "")
+		// GetBucketLifecycle
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketLifecycle", httpTraceAll(api.GetBucketLifecycleHandler))).Queries("lifecycle", "")
+		// GetBucketReplication
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketReplication", httpTraceAll(api.GetBucketReplicationHandler))).Queries("replication", "")
+		// GetBucketTagging
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketTagging", httpTraceAll(api.GetBucketTaggingHandler))).Queries("tagging", "")
+		// GetBucketObjectLockConfig
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketObjectLockConfig", httpTraceAll(api.GetBucketObjectLockConfigHandler))).Queries("object-lock", "")
+		// GetBucketVersioning
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketVersioning", httpTraceAll(api.GetBucketVersioningHandler))).Queries("versioning", "")
+		// GetBucketLogging
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketLogging", httpTraceAll(api.GetBucketLoggingHandler))).Queries("logging", "")
+		// GetBucketWebsite
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("GetBucketWebsite", httpTraceAll(api.GetBucketWebsiteHandler))).Queries("website", "")
 		// ListenBucketNotification
-		bucket.Methods("GET").HandlerFunc(httpTraceAll(api.ListenBucketNotificationHandler)).Queries("events", "{events:.*}")
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("ListenBucketNotification", httpTraceAll(api.ListenBucketNotificationHandler))).Queries("events", "{events:.*}")
 		// ListMultipartUploads
-		bucket.Methods("GET").HandlerFunc(httpTraceAll(api.ListMultipartUploadsHandler)).Queries("uploads", "")
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("ListMultipartUploads", httpTraceAll(api.ListMultipartUploadsHandler))).Queries("uploads", "")
+		// ListObjectVersions
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("ListObjectVersions", httpTraceAll(api.ListObjectVersionsHandler))).Queries("versions", "")
+		// SearchObjects
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("SearchObjects", httpTraceAll(api.SearchObjectsHandler))).Queries("search", "")
 		// ListObjectsV2
-		bucket.Methods("GET").HandlerFunc(httpTraceAll(api.ListObjectsV2Handler)).Queries("list-type", "2")
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("ListObjectsV2", httpTraceAll(api.ListObjectsV2Handler))).Queries("list-type", "2")
 		// ListObjectsV1 (Legacy)
-		bucket.Methods("GET").HandlerFunc(httpTraceAll(api.ListObjectsV1Handler))
+		bucket.Methods("GET").HandlerFunc(collectAPIStats("ListObjectsV1", httpTraceAll(api.ListObjectsV1Handler)))
 		// PutBucketPolicy
-		bucket.Methods("PUT").HandlerFunc(httpTraceAll(api.PutBucketPolicyHandler)).Queries("policy", "")
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketPolicy", httpTraceAll(api.PutBucketPolicyHandler))).Queries("policy", "")
 		// PutBucketNotification
-		bucket.Methods("PUT").HandlerFunc(httpTraceAll(api.PutBucketNotificationHandler)).Queries("notification", "")
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketNotification", httpTraceAll(api.PutBucketNotificationHandler))).Queries("notification", "")
+		// PutBucketCors
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketCors", httpTraceAll(api.PutBucketCorsHandler))).Queries("cors", "")
+		// PutBucketInventoryConfiguration
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketInventoryConfiguration", httpTraceAll(api.PutBucketInventoryConfigurationHandler))).Queries("inventory", "", "id", "{id:.*}")
+		// PutBucketEncryption
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketEncryption", httpTraceAll(api.PutBucketEncryptionHandler))).Queries("encryption", "")
+		// PutBucketLifecycle
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketLifecycle", httpTraceAll(api.PutBucketLifecycleHandler))).Queries("lifecycle", "")
+		// PutBucketReplication
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketReplication", httpTraceAll(api.PutBucketReplicationHandler))).Queries("replication", "")
+		// PutBucketTagging
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketTagging", httpTraceAll(api.PutBucketTaggingHandler))).Queries("tagging", "")
+		// PutBucketObjectLockConfig
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketObjectLockConfig", httpTraceAll(api.PutBucketObjectLockConfigHandler))).Queries("object-lock", "")
+		// PutBucketVersioning
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketVersioning", httpTraceAll(api.PutBucketVersioningHandler))).Queries("versioning", "")
+		// PutBucketLogging
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketLogging", httpTraceAll(api.PutBucketLoggingHandler))).Queries("logging", "")
+		// PutBucketWebsite
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucketWebsite", httpTraceAll(api.PutBucketWebsiteHandler))).Queries("website", "")
 		// PutBucket
-		bucket.Methods("PUT").HandlerFunc(httpTraceAll(api.PutBucketHandler))
+		bucket.Methods("PUT").HandlerFunc(collectAPIStats("PutBucket", httpTraceAll(api.PutBucketHandler)))
 		// HeadBucket
-		bucket.Methods("HEAD").HandlerFunc(httpTraceAll(api.HeadBucketHandler))
+		bucket.Methods("HEAD").HandlerFunc(collectAPIStats("HeadBucket", httpTraceAll(api.HeadBucketHandler)))
 		// PostPolicy
-		bucket.Methods("POST").HeadersRegexp("Content-Type", "multipart/form-data*").HandlerFunc(httpTraceHdrs(api.PostPolicyBucketHandler))
+		bucket.Methods("POST").HeadersRegexp("Content-Type", "multipart/form-data*").HandlerFunc(collectAPIStats("PostPolicyBucket", httpTraceHdrs(api.PostPolicyBucketHandler)))
 		// DeleteMultipleObjects
-		bucket.Methods("POST").HandlerFunc(httpTraceAll(api.DeleteMultipleObjectsHandler)).Queries("delete", "")
+		bucket.Methods("POST").HandlerFunc(collectAPIStats("DeleteMultipleObjects", httpTraceAll(api.DeleteMultipleObjectsHandler))).Queries("delete", "")
 		// DeleteBucketPolicy
-		bucket.Methods("DELETE").HandlerFunc(httpTraceAll(api.DeleteBucketPolicyHandler)).Queries("policy", "")
+		bucket.Methods("DELETE").HandlerFunc(collectAPIStats("DeleteBucketPolicy", httpTraceAll(api.DeleteBucketPolicyHandler))).Queries("policy", "")
+		// DeleteBucketCors
+		bucket.Methods("DELETE").HandlerFunc(collectAPIStats("DeleteBucketCors", httpTraceAll(api.DeleteBucketCorsHandler))).Queries("cors", "")
+		// DeleteBucketInventoryConfiguration
+		bucket.Methods("DELETE").HandlerFunc(collectAPIStats("DeleteBucketInventoryConfiguration", httpTraceAll(api.DeleteBucketInventoryConfigurationHandler))).Queries("inventory", "", "id", "{id:.*}")
+		// DeleteBucketEncryption
+		bucket.Methods("DELETE").HandlerFunc(collectAPIStats("DeleteBucketEncryption", httpTraceAll(api.DeleteBucketEncryptionHandler))).Queries("encryption", "")
+		// DeleteBucketLifecycle
+		bucket.Methods("DELETE").HandlerFunc(collectAPIStats("DeleteBucketLifecycle", httpTraceAll(api.DeleteBucketLifecycleHandler))).Queries("lifecycle", "")
+		// DeleteBucketReplication
+		bucket.Methods("DELETE").HandlerFunc(collectAPIStats("DeleteBucketReplication", httpTraceAll(api.DeleteBucketReplicationHandler))).Queries("replication", "")
+		// DeleteBucketTagging
+		bucket.Methods("DELETE").HandlerFunc(collectAPIStats("DeleteBucketTagging", httpTraceAll(api.DeleteBucketTaggingHandler))).Queries("tagging", "")
+		// DeleteBucketWebsite
+		bucket.Methods("DELETE").HandlerFunc(collectAPIStats("DeleteBucketWebsite", httpTraceAll(api.DeleteBucketWebsiteHandler))).Queries("website", "")
 		// DeleteBucket
-		bucket.Methods("DELETE").HandlerFunc(httpTraceAll(api.DeleteBucketHandler))
+		bucket.Methods("DELETE").HandlerFunc(collectAPIStats("DeleteBucket", httpTraceAll(api.DeleteBucketHandler)))
 	}
 
 	/// Root operation
 
 	// ListBuckets
-	apiRouter.Methods("GET").Path("/").HandlerFunc(httpTraceAll(api.ListBucketsHandler))
+	apiRouter.Methods("GET").Path("/").HandlerFunc(collectAPIStats("ListBuckets", httpTraceAll(api.ListBucketsHandler)))
 
 	// If none of the routes match.
 	apiRouter.NotFoundHandler = http.HandlerFunc(httpTraceAll(notFoundHandler))
@@ -0,0 +1,249 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Number of one second slots during which recent calls are accounted.
+const apiStatsWindowSecs = 60
+
+// APIStats holds call statistics of one S3 API. Recent counters cover
+// the last minute, other counters the whole life of the server.
+type APIStats struct {
+	InFlight       int64         `json:"inFlight"`
+	Calls          uint64        `json:"calls"`
+	ClientErrors   uint64        `json:"clientErrors"`
+	ServerErrors   uint64        `json:"serverErrors"`
+	TotalDuration  time.Duration `json:"totalDuration"`
+	MaxDuration    time.Duration `json:"maxDuration"`
+	RecentCalls    uint64        `json:"recentCalls"`
+	RecentErrors   uint64        `json:"recentErrors"`
+	RecentDuration time.Duration `json:"recentDuration"`
+}
+
+// add - accumulates counters of o.
+func (s *APIStats) add(o APIStats) {
+	s.InFlight += o.InFlight
+	s.Calls += o.Calls
+	s.ClientErrors += o.ClientErrors
+	s.ServerErrors += o.ServerErrors
+	s.TotalDuration += o.TotalDuration
+	if o.MaxDuration > s.MaxDuration {
+		s.MaxDuration = o.MaxDuration
+	}
+	s.RecentCalls += o.RecentCalls
+	s.RecentErrors += o.RecentErrors
+	s.RecentDuration += o.RecentDuration
+}
+
+// NamedAPIStats holds call statistics of the API with the given name.
+type NamedAPIStats struct {
+	Name string `json:"name"`
+	APIStats
+}
+
+// ServerAPIStats holds API call statistics result of one node.
+type ServerAPIStats struct {
+	Error string              `json:"error"`
+	Addr  string              `json:"addr"`
+	Data  map[string]APIStats `json:"data"`
+}
+
+// ClusterAPIStats holds API call statistics aggregated across all
+// nodes, busiest APIs first, along with the statistics of each node.
+type ClusterAPIStats struct {
+	APIs    []NamedAPIStats  `json:"apis"`
+	Servers []ServerAPIStats `json:"servers"`
+}
+
+// apiStatsSlot - calls completed during one second.
+type apiStatsSlot struct {
+	sec      int64
+	calls    uint64
+	errors   uint64
+	duration time.Duration
+}
+
+// apiCallStats - call statistics of one API.
+type apiCallStats struct {
+	sync.Mutex
+	stats APIStats
+	slots [apiStatsWindowSecs]apiStatsSlot
+}
+
+// APICallStats - counts in-flight and completed calls of each S3 API
+// during the server's life.
+type APICallStats struct {
+	sync.RWMutex
+	apis map[string]*apiCallStats
+}
+
+// Returns statistics of the API, creating them if necessary.
+func (s *APICallStats) get(api string) *apiCallStats {
+	s.RLock()
+	callStats, ok := s.apis[api]
+	s.RUnlock()
+	if ok {
+		return callStats
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if callStats, ok = s.apis[api]; !ok {
+		callStats = &apiCallStats{}
+		s.apis[api] = callStats
+	}
+	return callStats
+}
+
+// Account a new in-flight call of the API.
+func (s *APICallStats) callStarted(api string) {
+	callStats := s.get(api)
+	callStats.Lock()
+	callStats.stats.InFlight++
+	callStats.Unlock()
+}
+
+// Account a call of the API that completed at the given time.
+func (s *APICallStats) callDone(api string, statusCode int, duration time.Duration, now time.Time) {
+	callStats := s.get(api)
+	callStats.Lock()
+	defer callStats.Unlock()
+
+	stats := &callStats.stats
+	stats.InFlight--
+	stats.Calls++
+	stats.TotalDuration += duration
+	if duration > stats.MaxDuration {
+		stats.MaxDuration = duration
+	}
+
+	isError := statusCode >= http.StatusBadRequest
+	if statusCode >= http.StatusInternalServerError {
+		stats.ServerErrors++
+	} else if isError {
+		stats.ClientErrors++
+	}
+
+	sec := now.Unix()
+	slot := &callStats.slots[sec%apiStatsWindowSecs]
+	if slot.sec != sec {
+		*slot = apiStatsSlot{sec: sec}
+	}
+	slot.calls++
+	slot.duration += duration
+	if isError {
+		slot.errors++
+	}
+}
+
+// Return API call statistics of this server as of the given time.
+func (s *APICallStats) toServerAPIStats(now time.Time) map[string]APIStats {
+	s.RLock()
+	defer s.RUnlock()
+
+	sec := now.Unix()
+	apis := make(map[string]APIStats, len(s.apis))
+	for api, callStats := range s.apis {
+		callStats.Lock()
+		stats := callStats.stats
+		for _, slot := range callStats.slots {
+			if slot.sec > sec-apiStatsWindowSecs && slot.sec <= sec {
+				stats.RecentCalls += slot.calls
+				stats.RecentErrors += slot.errors
+				stats.RecentDuration += slot.duration
+			}
+		}
+		callStats.Unlock()
+		apis[api] = stats
+	}
+	return apis
+}
+
+// Prepare new APICallStats structure
+func newAPICallStats() *APICallStats {
+	return &APICallStats{
+		apis: make(map[string]*apiCallStats),
+	}
+}
+
+// collectAPIStats - wraps the handler of the named S3 API to account its calls.
+func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		globalAPICallStats.callStarted(api)
+
+		// Wraps w to record the response status code
+		ww := &httpResponseRecorder{ResponseWriter: w}
+
+		start := UTCNow()
+		sessionID := globalSessions.start(newSessionInfo(api, r, start))
+		f.ServeHTTP(ww, r)
+		globalSessions.done(sessionID)
+		now := UTCNow()
+
+		statusCode := ww.respStatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+		globalAPICallStats.callDone(api, statusCode, now.Sub(start), now)
+
+		vars := mux.Vars(r)
+		logBucketAccess(r, ww, vars["bucket"], vars["object"], start, now)
+	}
+}
+
+// aggregateAPIStats - sums up API call statistics reported by all nodes
+// and returns the busiest APIs of the cluster first, i.e. the APIs having
+// the most recent calls, then the most calls. At most count APIs are
+// returned if count is positive.
+func aggregateAPIStats(servers []ServerAPIStats, count int) []NamedAPIStats {
+	apis := make(map[string]APIStats)
+	for _, server := range servers {
+		for api, stats := range server.Data {
+			total := apis[api]
+			total.add(stats)
+			apis[api] = total
+		}
+	}
+
+	namedStats := make([]NamedAPIStats, 0, len(apis))
+	for api, stats := range apis {
+		namedStats = append(namedStats, NamedAPIStats{Name: api, APIStats: stats})
+	}
+	sort.Slice(namedStats, func(i, j int) bool {
+		a, b := namedStats[i], namedStats[j]
+		if a.RecentCalls != b.RecentCalls {
+			return a.RecentCalls > b.RecentCalls
+		}
+		if a.Calls != b.Calls {
+			return a.Calls > b.Calls
+		}
+		return a.Name < b.Name
+	})
+
+	if count > 0 && len(namedStats) > count {
+		namedStats = namedStats[:count]
+	}
+	return namedStats
+}
@@ -0,0 +1,137 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Tests accounting of calls passing through collectAPIStats.
+func TestCollectAPIStats(t *testing.T) {
+	tmpGlobalAPICallStats := globalAPICallStats
+	defer func() {
+		globalAPICallStats = tmpGlobalAPICallStats
+	}()
+	globalAPICallStats = newAPICallStats()
+
+	statusCodes := []int{0, http.StatusOK, http.StatusNotFound, http.StatusInternalServerError}
+	for _, statusCode := range statusCodes {
+		handler := collectAPIStats("GetObject", func(w http.ResponseWriter, r *http.Request) {
+			// Calls are in flight while handlers run.
+			if inFlight := globalAPICallStats.toServerAPIStats(UTCNow())["GetObject"].InFlight; inFlight != 1 {
+				t.Errorf("expected 1 call in flight, got %d", inFlight)
+			}
+			if statusCode != 0 {
+				w.WriteHeader(statusCode)
+			}
+			w.Write([]byte("hello"))
+		})
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:9000/bucket/object", nil)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	expected := APIStats{Calls: 4, ClientErrors: 1, ServerErrors: 1, RecentCalls: 4, RecentErrors: 2}
+	stats := globalAPICallStats.toServerAPIStats(UTCNow())["GetObject"]
+	if stats.InFlight != expected.InFlight || stats.Calls != expected.Calls ||
+		stats.ClientErrors != expected.ClientErrors || stats.ServerErrors != expected.ServerErrors ||
+		stats.RecentCalls != expected.RecentCalls || stats.RecentErrors != expected.RecentErrors {
+		t.Fatalf("expected: %v, got: %v", expected, stats)
+	}
+	if stats.TotalDuration != stats.RecentDuration || stats.MaxDuration > stats.TotalDuration {
+		t.Fatalf("unexpected durations %v", stats)
+	}
+}
+
+// Tests that recent counters only cover the last minute.
+func TestAPICallStatsWindow(t *testing.T) {
+	apiCallStats := newAPICallStats()
+	now := UTCNow()
+
+	calls := []struct {
+		at       time.Time
+		duration time.Duration
+	}{
+		{now.Add(-2 * time.Minute), 4 * time.Second},
+		{now.Add(-30 * time.Second), time.Second},
+		{now, 2 * time.Second},
+	}
+	for _, call := range calls {
+		apiCallStats.callStarted("PutObject")
+		apiCallStats.callDone("PutObject", http.StatusOK, call.duration, call.at)
+	}
+
+	stats := apiCallStats.toServerAPIStats(now)["PutObject"]
+	expected := APIStats{
+		Calls:          3,
+		TotalDuration:  7 * time.Second,
+		MaxDuration:    4 * time.Second,
+		RecentCalls:    2,
+		RecentDuration: 3 * time.Second,
+	}
+	if stats != expected {
+		t.Fatalf("expected: %v, got: %v", expected, stats)
+	}
+
+	// Slots reused by later seconds drop older calls.
+	apiCallStats.callStarted("PutObject")
+	apiCallStats.callDone("PutObject", http.StatusOK, time.Second, now.Add(apiStatsWindowSecs*time.Second))
+	stats = apiCallStats.toServerAPIStats(now.Add(apiStatsWindowSecs * time.Second))["PutObject"]
+	if stats.RecentCalls != 1 || stats.RecentDuration != time.Second {
+		t.Fatalf("expected 1 recent call, got: %v", stats)
+	}
+}
+
+// Tests cluster-wide aggregation and ordering of API call statistics.
+func TestAggregateAPIStats(t *testing.T) {
+	servers := []ServerAPIStats{
+		{Addr: "server1", Data: map[string]APIStats{
+			"GetObject":   {Calls: 10, RecentCalls: 1, MaxDuration: time.Second},
+			"PutObject":   {Calls: 5, RecentCalls: 5, InFlight: 1},
+			"ListBuckets": {Calls: 20},
+		}},
+		{Addr: "server2", Data: map[string]APIStats{
+			"GetObject": {Calls: 10, RecentCalls: 1, MaxDuration: 3 * time.Second},
+		}},
+		{Addr: "server3", Error: "unreachable"},
+	}
+
+	expected := []NamedAPIStats{
+		{Name: "PutObject", APIStats: APIStats{Calls: 5, RecentCalls: 5, InFlight: 1}},
+		{Name: "GetObject", APIStats: APIStats{Calls: 20, RecentCalls: 2, MaxDuration: 3 * time.Second}},
+		{Name: "ListBuckets", APIStats: APIStats{Calls: 20}},
+	}
+
+	apis := aggregateAPIStats(servers, 0)
+	if len(apis) != len(expected) {
+		t.Fatalf("expected: %v, got: %v", expected, apis)
+	}
+	for i := range expected {
+		if apis[i] != expected[i] {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, expected[i], apis[i])
+		}
+	}
+
+	if apis = aggregateAPIStats(servers, 2); len(apis) != 2 || apis[1].Name != "GetObject" {
+		t.Fatalf("expected 2 busiest APIs, got: %v", apis)
+	}
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
@@ -68,7 +69,8 @@ func isRequestPostPolicySignatureV4(r *http.Request) bool {
 
 // Verify if the request has AWS Streaming Signature Version '4'. This is only valid for 'PUT' operation.
 func isRequestSignStreamingV4(r *http.Request) bool {
-	return r.Header.Get("x-amz-content-sha256") == streamingContentSHA256 &&
+	payload := r.Header.Get("x-amz-content-sha256")
+	return (payload == streamingContentSHA256 || payload == streamingContentSHA256Trailer) &&
 		r.Method == http.MethodPut
 }
 
@@ -117,6 +119,10 @@ func checkAdminRequestAuthType(r *http.Request, region string) APIErrorCode {
 	if _, ok := r.Header["X-Amz-Content-Sha256"]; ok && getRequestAuthType(r) == authTypeSigned && !skipContentSha256Cksum(r) { // we only support V4 (no presign) with auth. body
 		s3Err = isReqAuthenticated(r, region)
 	}
+	// Service accounts are meant for applications, deny admin operations.
+	if s3Err == ErrNone && getReqAccessKey(r) != globalServerConfig.GetCredential().AccessKey {
+		s3Err = ErrAccessDenied
+	}
 	if s3Err != ErrNone {
 		reqInfo := (&logger.ReqInfo{}).AppendTags("requestHeaders", dumpRequest(r))
 		ctx := logger.SetReqInfo(context.Background(), reqInfo)
@@ -137,7 +143,7 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 			return errorCode
 		}
 	case authTypeSigned, authTypePresigned:
-		region := globalServerConfig.GetRegion()
+		region := getBucketRegion(bucketName)
 		switch action {
 		case policy.GetBucketLocationAction, policy.ListAllMyBucketsAction:
 			region = ""
@@ -174,20 +180,140 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 		r.Body = ioutil.NopCloser(bytes.NewReader(payload))
 	}
 
-	if globalPolicySys.IsAllowed(policy.Args{
+	args := policy.Args{
 		AccountName:     accountName,
 		Action:          action,
 		BucketName:      bucketName,
 		ConditionValues: getConditionValues(r, locationConstraint),
 		IsOwner:         isOwner,
 		ObjectName:      objectName,
-	}) {
+	}
+	if action == policy.PutObjectTaggingAction {
+		if tags := readRequestTagging(r); tags != nil {
+			setRequestObjectTagValues(args.ConditionValues, tags)
+		}
+	}
+	setExistingObjectTagValues(ctx, r, action, bucketName, objectName, args.ConditionValues)
+
+	// Requests signed by a service account are further restricted
+	// by its inline policy, if any.
+	if isOwner && !globalIAMSys.IsAllowed(getReqAccessKey(r), args) {
+		return ErrAccessDenied
+	}
+
+	if globalPolicySys.IsAllowed(args) {
 		return ErrNone
 	}
 
 	return ErrAccessDenied
 }
 
+// isPutObjectAllowed - checks whether an authenticated PutObject or
+// PutObjectPart request is permitted by the inline policy of the
+// service account which signed it, if any.
+func isPutObjectAllowed(r *http.Request, bucket, object string) bool {
+	return globalIAMSys.IsAllowed(getReqAccessKey(r), policy.Args{
+		Action:          policy.PutObjectAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, ""),
+		IsOwner:         true,
+		ObjectName:      object,
+	})
+}
+
+// isObjectActionAllowed - checks whether a request, already authenticated
+// by checkRequestAuthType, is also permitted given action on an object.
+func isObjectActionAllowed(r *http.Request, action policy.Action, bucket, object string) bool {
+	isOwner := true
+	accountName := globalServerConfig.GetCredential().AccessKey
+	switch getRequestAuthType(r) {
+	case authTypePresignedV2, authTypeSignedV2, authTypeSigned, authTypePresigned, authTypeStreamingSigned:
+	default:
+		isOwner = false
+		accountName = ""
+	}
+
+	args := policy.Args{
+		AccountName:     accountName,
+		Action:          action,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, ""),
+		IsOwner:         isOwner,
+		ObjectName:      object,
+	}
+	if isOwner && !globalIAMSys.IsAllowed(getReqAccessKey(r), args) {
+		return false
+	}
+	return globalPolicySys.IsAllowed(args)
+}
+
+// isCopySourceAllowed - checks whether a copy request, already
+// authenticated by checkRequestAuthType, is also permitted to read the
+// given version, if any, of its source object.
+func isCopySourceAllowed(r *http.Request, bucket, object, versionID string) bool {
+	var action policy.Action = policy.GetObjectAction
+	if versionID != "" {
+		action = policy.GetObjectVersionAction
+	}
+	return isObjectActionAllowed(r, action, bucket, object)
+}
+
+// getReqAccessKey - returns the access key used to sign the request,
+// returns empty string for anonymous or malformed requests.
+func getReqAccessKey(r *http.Request) string {
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypeStreamingSigned:
+		signV4Values, errCode := parseSignV4(r.Header.Get("Authorization"), "", serviceS3)
+		if errCode == ErrNone {
+			return signV4Values.Credential.accessKey
+		}
+	case authTypePresigned:
+		return strings.SplitN(r.URL.Query().Get("X-Amz-Credential"), "/", 2)[0]
+	case authTypeSignedV2:
+		authFields := strings.Split(r.Header.Get("Authorization"), " ")
+		if len(authFields) == 2 {
+			return strings.SplitN(strings.TrimSpace(authFields[1]), ":", 2)[0]
+		}
+	case authTypePresignedV2:
+		return r.URL.Query().Get("AWSAccessKeyId")
+	}
+	return ""
+}
+
+// getReqSessionToken - returns the session token sent along with the
+// request, either as a header or as a query parameter of presigned requests.
+func getReqSessionToken(r *http.Request) string {
+	if token := r.Header.Get(amzSecurityToken); token != "" {
+		return token
+	}
+	return r.URL.Query().Get(amzSecurityToken)
+}
+
+// checkSessionToken - verifies that requests made with temporary
+// credentials carry their session token and that the credentials have
+// not expired. Session tokens are not accepted with other credentials.
+func checkSessionToken(accessKey, sessionToken string) APIErrorCode {
+	if accessKey == "" {
+		return ErrNone
+	}
+
+	ta, ok := globalIAMSys.GetTempAccount(accessKey)
+	if !ok {
+		if sessionToken != "" {
+			return ErrInvalidToken
+		}
+		return ErrNone
+	}
+
+	if subtle.ConstantTimeCompare([]byte(sessionToken), []byte(ta.SessionToken)) != 1 {
+		return ErrInvalidToken
+	}
+	if ta.IsExpired() {
+		return ErrExpiredToken
+	}
+	return ErrNone
+}
+
 // Verify if request has valid AWS Signature Version '2'.
 func isReqAuthenticatedV2(r *http.Request) (s3Error APIErrorCode) {
 	if isRequestSignatureV2(r) {
@@ -200,7 +326,7 @@ func reqSignatureV4Verify(r *http.Request, region string) (s3Error APIErrorCode)
 	sha256sum := getContentSha256Cksum(r)
 	switch {
 	case isRequestSignatureV4(r):
-		return doesSignatureMatch(sha256sum, r, region)
+		return doesSignatureMatch(sha256sum, r, region, serviceS3)
 	case isRequestPresignedSignatureV4(r):
 		return doesPresignedSignatureMatch(sha256sum, r, region)
 	default:
@@ -283,6 +409,11 @@ func isSupportedS3AuthType(aType authType) bool {
 func (a authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	aType := getRequestAuthType(r)
 	if isSupportedS3AuthType(aType) {
+		// Temporary credentials are only valid along with their session token.
+		if s3Error := checkSessionToken(getReqAccessKey(r), getReqSessionToken(r)); s3Error != ErrNone {
+			writeErrorResponse(w, s3Error, r.URL)
+			return
+		}
 		// Let top level caller validate for anonymous and known signed requests.
 		a.handler.ServeHTTP(w, r)
 		return
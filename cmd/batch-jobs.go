@@ -0,0 +1,688 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/tagging"
+)
+
+const (
+	batchJobsConfigPrefix = "batch-jobs"
+	batchJobsIndexFile    = "jobs.json"
+
+	// Maximum size of a batch job in a start-batch-job request.
+	maxBatchJobRequestSize = 64 * 1024
+)
+
+// List of batch job related errors.
+var (
+	errInvalidBatchJob = errors.New("Specified batch job is invalid")
+	errNoSuchBatchJob  = errors.New("Specified batch job does not exist")
+)
+
+// BatchJobType - operation a batch job applies to objects.
+type BatchJobType string
+
+const (
+	// BatchJobKeyRotate - rotates the SSE-C key of encrypted objects.
+	BatchJobKeyRotate BatchJobType = "keyrotate"
+
+	// BatchJobReplicate - copies objects to a replication target.
+	BatchJobReplicate BatchJobType = "replicate"
+
+	// BatchJobExpire - deletes objects older than a given age.
+	BatchJobExpire BatchJobType = "expire"
+
+	// BatchJobTag - sets and removes tags of objects.
+	BatchJobTag BatchJobType = "tag"
+)
+
+// BatchJobRequest - definition of a batch job processing all objects
+// under prefix in bucket. OldKey and NewKey are the SSE-C keys of
+// keyrotate jobs, TargetArn the remote target of replicate jobs,
+// OlderThan the minimum age of objects deleted by expire jobs and Tags
+// and RemoveTags the tags set and the tag keys removed by tag jobs.
+type BatchJobRequest struct {
+	Type       BatchJobType      `json:"type"`
+	Bucket     string            `json:"bucket"`
+	Prefix     string            `json:"prefix,omitempty"`
+	OldKey     []byte            `json:"oldKey,omitempty"`
+	NewKey     []byte            `json:"newKey,omitempty"`
+	TargetArn  string            `json:"targetArn,omitempty"`
+	OlderThan  time.Duration     `json:"olderThan,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	RemoveTags []string          `json:"removeTags,omitempty"`
+}
+
+// validate - verifies that the job is usable.
+func (req BatchJobRequest) validate() error {
+	if !IsValidBucketName(req.Bucket) {
+		return errInvalidBatchJob
+	}
+
+	switch req.Type {
+	case BatchJobKeyRotate:
+		if len(req.OldKey) != SSECustomerKeySize || len(req.NewKey) != SSECustomerKeySize {
+			return errInvalidBatchJob
+		}
+	case BatchJobReplicate:
+		if globalBucketTargetSys == nil {
+			return errInvalidBatchJob
+		}
+		target, ok := globalBucketTargetSys.GetTarget(req.TargetArn)
+		if !ok || target.Type != ReplicationService || target.SourceBucket != req.Bucket {
+			return errInvalidBatchJob
+		}
+	case BatchJobExpire:
+		if req.OlderThan <= 0 {
+			return errInvalidBatchJob
+		}
+	case BatchJobTag:
+		if len(req.Tags)+len(req.RemoveTags) == 0 || len(req.Tags) > tagging.MaxObjectTags {
+			return errInvalidBatchJob
+		}
+		for k, v := range req.Tags {
+			if (tagging.Tag{Key: k, Value: v}).Validate() != nil {
+				return errInvalidBatchJob
+			}
+		}
+		for _, k := range req.RemoveTags {
+			if _, ok := req.Tags[k]; ok {
+				return errInvalidBatchJob
+			}
+		}
+	default:
+		return errInvalidBatchJob
+	}
+	return nil
+}
+
+// redacted - returns the request without its SSE-C keys.
+func (req BatchJobRequest) redacted() BatchJobRequest {
+	req.OldKey = nil
+	req.NewKey = nil
+	return req
+}
+
+// BatchJob - batch job run by all nodes in parallel, each node only
+// processes the objects whose name hashes to its index in Nodes.
+type BatchJob struct {
+	ID      string          `json:"id"`
+	Request BatchJobRequest `json:"request"`
+	Nodes   []string        `json:"nodes"`
+	Started time.Time       `json:"started"`
+}
+
+// isNodeObject - returns true if the object is processed by the node
+// having the given index.
+func (job BatchJob) isNodeObject(nodeIndex int, object string) bool {
+	return int(crc32.ChecksumIEEE([]byte(object))%uint32(len(job.Nodes))) == nodeIndex
+}
+
+// BatchJobStatus - state of a batch job on a node.
+type BatchJobStatus string
+
+// Batch job states.
+const (
+	BatchJobRunning   BatchJobStatus = "running"
+	BatchJobCompleted BatchJobStatus = "completed"
+	BatchJobFailed    BatchJobStatus = "failed"
+	BatchJobCanceled  BatchJobStatus = "canceled"
+)
+
+// BatchJobProgress - progress of a batch job on one node. Objects are
+// listed in order, a job interrupted by a restart resumes after
+// LastObject.
+type BatchJobProgress struct {
+	ID         string         `json:"id"`
+	Type       BatchJobType   `json:"type"`
+	Addr       string         `json:"addr"`
+	NodeIndex  int            `json:"nodeIndex"`
+	Status     BatchJobStatus `json:"status"`
+	Processed  uint64         `json:"processed"`
+	Skipped    uint64         `json:"skipped"`
+	Failed     uint64         `json:"failed"`
+	LastObject string         `json:"lastObject,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	Updated    time.Time      `json:"updated"`
+}
+
+// ServerBatchJobProgress holds progress of a batch job on one node.
+type ServerBatchJobProgress struct {
+	Error string            `json:"error"`
+	Addr  string            `json:"addr"`
+	Data  *BatchJobProgress `json:"data"`
+}
+
+// BatchJobInfo holds a batch job along with its progress on each node,
+// SSE-C keys are never returned.
+type BatchJobInfo struct {
+	ID      string                   `json:"id"`
+	Request BatchJobRequest          `json:"request"`
+	Started time.Time                `json:"started"`
+	Servers []ServerBatchJobProgress `json:"servers"`
+}
+
+// batchJobRun - batch job processed by this node.
+type batchJobRun struct {
+	sync.Mutex
+	job      BatchJob
+	progress BatchJobProgress
+	cancel   context.CancelFunc
+}
+
+// getProgress - returns a copy of the progress.
+func (r *batchJobRun) getProgress() BatchJobProgress {
+	r.Lock()
+	defer r.Unlock()
+	return r.progress
+}
+
+// BatchJobSys - batch jobs subsystem, tracks the jobs of this node.
+type BatchJobSys struct {
+	sync.Mutex
+	runs map[string]*batchJobRun
+}
+
+// Start - starts processing the share of the node having the given
+// index, starting a job already known to this node does nothing.
+func (sys *BatchJobSys) Start(objAPI ObjectLayer, job BatchJob, nodeIndex int) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+	if nodeIndex < 0 || nodeIndex >= len(job.Nodes) {
+		return errInvalidBatchJob
+	}
+	if err := job.Request.validate(); err != nil {
+		return err
+	}
+
+	progress := BatchJobProgress{
+		ID:        job.ID,
+		Type:      job.Request.Type,
+		Addr:      job.Nodes[nodeIndex],
+		NodeIndex: nodeIndex,
+		Status:    BatchJobRunning,
+		Updated:   UTCNow(),
+	}
+	return sys.start(objAPI, job, progress)
+}
+
+func (sys *BatchJobSys) start(objAPI ObjectLayer, job BatchJob, progress BatchJobProgress) error {
+	sys.Lock()
+	defer sys.Unlock()
+
+	if _, ok := sys.runs[job.ID]; ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &batchJobRun{job: job, progress: progress, cancel: cancel}
+	sys.runs[job.ID] = r
+	if err := saveBatchJobProgress(objAPI, progress); err != nil {
+		cancel()
+		delete(sys.runs, job.ID)
+		return err
+	}
+
+	go runBatchJob(ctx, objAPI, r)
+	return nil
+}
+
+// Describe - returns the progress of the job on this node.
+func (sys *BatchJobSys) Describe(id string) (BatchJobProgress, error) {
+	sys.Lock()
+	r, ok := sys.runs[id]
+	sys.Unlock()
+	if !ok {
+		return BatchJobProgress{}, errNoSuchBatchJob
+	}
+	return r.getProgress(), nil
+}
+
+// Cancel - stops the job on this node, its progress is kept.
+func (sys *BatchJobSys) Cancel(id string) error {
+	sys.Lock()
+	r, ok := sys.runs[id]
+	sys.Unlock()
+	if !ok {
+		return errNoSuchBatchJob
+	}
+	r.cancel()
+	return nil
+}
+
+// List - returns the progress of all jobs of this node, most recently
+// updated first.
+func (sys *BatchJobSys) List() []BatchJobProgress {
+	sys.Lock()
+	progress := make([]BatchJobProgress, 0, len(sys.runs))
+	for _, r := range sys.runs {
+		progress = append(progress, r.getProgress())
+	}
+	sys.Unlock()
+
+	sort.Slice(progress, func(i, j int) bool {
+		if !progress[i].Updated.Equal(progress[j].Updated) {
+			return progress[i].Updated.After(progress[j].Updated)
+		}
+		return progress[i].ID < progress[j].ID
+	})
+	return progress
+}
+
+// Init - loads the jobs of this node from the backend and resumes the
+// ones interrupted by a restart.
+func (sys *BatchJobSys) Init(objAPI ObjectLayer, localAddr string) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	jobs, err := readBatchJobs(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		for nodeIndex, addr := range job.Nodes {
+			if addr != localAddr {
+				continue
+			}
+			progress, err := readBatchJobProgress(context.Background(), objAPI, job.ID, nodeIndex)
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+			if progress.Status == BatchJobRunning {
+				if err = sys.start(objAPI, job, progress); err != nil {
+					logger.LogIf(context.Background(), err)
+				}
+				continue
+			}
+
+			sys.Lock()
+			sys.runs[job.ID] = &batchJobRun{job: job, progress: progress, cancel: func() {}}
+			sys.Unlock()
+		}
+	}
+	return nil
+}
+
+// NewBatchJobSys - creates new batch jobs system.
+func NewBatchJobSys() *BatchJobSys {
+	return &BatchJobSys{
+		runs: make(map[string]*batchJobRun),
+	}
+}
+
+// batchJobProcessor - applies the operation of a job to an object.
+// Objects the operation doesn't apply to are reported as skipped.
+type batchJobProcessor func(ctx context.Context, object ObjectInfo) (skipped bool, err error)
+
+// newBatchJobProcessor - returns the processor of the given job.
+func newBatchJobProcessor(objAPI ObjectLayer, req BatchJobRequest) (batchJobProcessor, error) {
+	switch req.Type {
+	case BatchJobKeyRotate:
+		return func(ctx context.Context, object ObjectInfo) (bool, error) {
+			return batchRotateKey(ctx, objAPI, req, object)
+		}, nil
+	case BatchJobReplicate:
+		target, ok := globalBucketTargetSys.GetTarget(req.TargetArn)
+		if !ok {
+			return nil, errInvalidBatchJob
+		}
+		u, err := url.Parse(target.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		client, err := miniogo.New(u.Host, target.Credentials.AccessKey, target.Credentials.SecretKey, u.Scheme == "https")
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, object ObjectInfo) (bool, error) {
+			return batchReplicate(ctx, objAPI, client, target, object)
+		}, nil
+	case BatchJobExpire:
+		return func(ctx context.Context, object ObjectInfo) (bool, error) {
+			if UTCNow().Sub(object.ModTime) < req.OlderThan {
+				return true, nil
+			}
+			// Objects of versioned buckets are kept as noncurrent
+			// versions behind a delete marker.
+			var err error
+			if getBucketVersioning(object.Bucket) != "" {
+				_, err = deleteObjectVersion(ctx, objAPI, object.Bucket, object.Name, "", nil)
+			} else {
+				err = objAPI.DeleteObject(ctx, object.Bucket, object.Name)
+			}
+			if _, ok := err.(ObjectNotFound); ok {
+				return true, nil
+			}
+			return false, err
+		}, nil
+	case BatchJobTag:
+		if !objAPI.IsTaggingSupported() {
+			return nil, errInvalidBatchJob
+		}
+		return func(ctx context.Context, object ObjectInfo) (bool, error) {
+			return batchTag(ctx, objAPI, req, object)
+		}, nil
+	}
+	return nil, errInvalidBatchJob
+}
+
+// batchRotateKey - re-seals the object key of an object encrypted with
+// OldKey using NewKey, the object data is left untouched.
+func batchRotateKey(ctx context.Context, objAPI ObjectLayer, req BatchJobRequest, object ObjectInfo) (bool, error) {
+	info, err := objAPI.GetObjectInfo(ctx, object.Bucket, object.Name)
+	if err != nil {
+		return false, err
+	}
+	if !crypto.SSEC.IsEncrypted(info.UserDefined) {
+		return true, nil
+	}
+
+	metadata := make(map[string]string, len(info.UserDefined))
+	for k, v := range info.UserDefined {
+		metadata[k] = v
+	}
+	if err = rotateKey(req.OldKey, req.NewKey, object.Bucket, object.Name, metadata); err != nil {
+		if err == errSSEKeyMismatch {
+			// Encrypted with another key.
+			return true, nil
+		}
+		return false, err
+	}
+
+	info.UserDefined = metadata
+	info.metadataOnly = true
+	_, err = objAPI.CopyObject(ctx, object.Bucket, object.Name, object.Bucket, object.Name, info)
+	return false, err
+}
+
+// batchTag - sets the tags of the job on the object and removes its
+// tag keys, objects already tagged accordingly are skipped. The tags of
+// an object may not exceed the limit of tags per object.
+func batchTag(ctx context.Context, objAPI ObjectLayer, req BatchJobRequest, object ObjectInfo) (bool, error) {
+	// The tags are updated under the object lock, so that tags set in
+	// the meantime are not lost.
+	changed := false
+	updateFn := func(current string) (string, error) {
+		tags := getObjectTags(map[string]string{objectTaggingKey: current}).ToMap()
+		changed = false
+		for k, v := range req.Tags {
+			if value, ok := tags[k]; !ok || value != v {
+				tags[k] = v
+				changed = true
+			}
+		}
+		for _, k := range req.RemoveTags {
+			if _, ok := tags[k]; ok {
+				delete(tags, k)
+				changed = true
+			}
+		}
+		if !changed {
+			return current, nil
+		}
+
+		values := url.Values{}
+		for k, v := range tags {
+			values.Set(k, v)
+		}
+		t, err := tagging.ParseTags(values.Encode(), tagging.MaxObjectTags)
+		if err != nil {
+			return "", err
+		}
+		return t.String(), nil
+	}
+
+	info, err := objAPI.UpdateObjectTags(ctx, object.Bucket, object.Name, "", updateFn)
+	if err != nil {
+		return false, err
+	}
+	if !changed {
+		return true, nil
+	}
+
+	// Tags are replicated along with the object.
+	scheduleReplication(ctx, objAPI, info)
+	return false, nil
+}
+
+// batchReplicate - uploads the object to the remote target, encrypted
+// objects can't be read without their key and are skipped.
+func batchReplicate(ctx context.Context, objAPI ObjectLayer, client *miniogo.Client, target BucketTarget, object ObjectInfo) (bool, error) {
+	info, err := objAPI.GetObjectInfo(ctx, object.Bucket, object.Name)
+	if err != nil {
+		return false, err
+	}
+	if info.IsEncrypted() {
+		return true, nil
+	}
+
+	userMetadata := make(map[string]string)
+	for k, v := range info.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			userMetadata[k] = v
+		}
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.CloseWithError(objAPI.GetObject(ctx, info.Bucket, info.Name, 0, info.Size, pipeWriter, info.ETag))
+	}()
+	defer pipeReader.Close()
+
+	_, err = client.PutObjectWithContext(ctx, target.TargetBucket, info.Name, pipeReader, info.Size, miniogo.PutObjectOptions{
+		UserMetadata:    userMetadata,
+		ContentType:     info.ContentType,
+		ContentEncoding: info.ContentEncoding,
+	})
+	return false, err
+}
+
+// runBatchJob - applies the job to the objects of this node, progress
+// is saved after each listed page and when the job stops.
+func runBatchJob(ctx context.Context, objAPI ObjectLayer, r *batchJobRun) {
+	finish := func(status BatchJobStatus, err error) {
+		r.Lock()
+		r.progress.Status = status
+		if err != nil {
+			r.progress.Error = err.Error()
+		}
+		r.progress.Updated = UTCNow()
+		progress := r.progress
+		r.Unlock()
+		logger.LogIf(context.Background(), saveBatchJobProgress(objAPI, progress))
+	}
+
+	req := r.job.Request
+	process, err := newBatchJobProcessor(objAPI, req)
+	if err != nil {
+		finish(BatchJobFailed, err)
+		return
+	}
+
+	progress := r.getProgress()
+	nodeIndex, marker := progress.NodeIndex, progress.LastObject
+	for {
+		if ctx.Err() != nil {
+			finish(BatchJobCanceled, nil)
+			return
+		}
+
+		result, err := objAPI.ListObjects(ctx, req.Bucket, req.Prefix, marker, "", maxObjectList)
+		if err != nil {
+			finish(BatchJobFailed, err)
+			return
+		}
+
+		for _, object := range result.Objects {
+			if ctx.Err() != nil {
+				finish(BatchJobCanceled, nil)
+				return
+			}
+			if !r.job.isNodeObject(nodeIndex, object.Name) {
+				continue
+			}
+
+			skipped, err := process(ctx, object)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("batchJob", r.job.ID)
+				logger.LogIf(logger.SetReqInfo(context.Background(), reqInfo), err)
+			}
+
+			r.Lock()
+			switch {
+			case err != nil:
+				r.progress.Failed++
+			case skipped:
+				r.progress.Skipped++
+			default:
+				r.progress.Processed++
+			}
+			r.Unlock()
+		}
+
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			finish(BatchJobCompleted, nil)
+			return
+		}
+
+		marker = result.NextMarker
+		if marker == "" {
+			marker = result.Objects[len(result.Objects)-1].Name
+		}
+
+		r.Lock()
+		r.progress.LastObject = marker
+		r.progress.Updated = UTCNow()
+		progress = r.progress
+		r.Unlock()
+		logger.LogIf(context.Background(), saveBatchJobProgress(objAPI, progress))
+	}
+}
+
+// getBatchJobsIndexFile - returns the path to the batch jobs index in minioMetaBucket.
+func getBatchJobsIndexFile() string {
+	return path.Join(batchJobsConfigPrefix, batchJobsIndexFile)
+}
+
+// getBatchJobProgressFile - returns the path to the progress of a job
+// on the node having the given index in minioMetaBucket.
+func getBatchJobProgressFile(id string, nodeIndex int) string {
+	return path.Join(batchJobsConfigPrefix, id, fmt.Sprintf("node-%d.json", nodeIndex))
+}
+
+// readBatchJobs - reads all batch jobs from the backend, the index is
+// encrypted as it holds SSE-C keys of keyrotate jobs.
+func readBatchJobs(ctx context.Context, objAPI ObjectLayer) (map[string]BatchJob, error) {
+	jobs := make(map[string]BatchJob)
+
+	reader, err := readConfig(ctx, objAPI, getBatchJobsIndexFile())
+	if err != nil {
+		if err == errConfigNotFound {
+			return jobs, nil
+		}
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err = decryptConfigData(data); err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// saveBatchJob - adds the job to the batch jobs index.
+func saveBatchJob(objAPI ObjectLayer, job BatchJob) error {
+	transactionConfigFile := getBatchJobsIndexFile() + ".transaction"
+
+	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
+	// and configFile, take a transaction lock to avoid data race between readConfig()
+	// and saveConfig().
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, transactionConfigFile)
+	if err := objLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer objLock.Unlock()
+
+	jobs, err := readBatchJobs(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+	jobs[job.ID] = job
+
+	data, err := json.Marshal(jobs)
+	if err != nil {
+		return err
+	}
+
+	if data, err = encryptConfigData(data); err != nil {
+		return err
+	}
+
+	return saveConfig(objAPI, getBatchJobsIndexFile(), data)
+}
+
+// readBatchJobProgress - reads the progress of a job on the node having
+// the given index from the backend.
+func readBatchJobProgress(ctx context.Context, objAPI ObjectLayer, id string, nodeIndex int) (progress BatchJobProgress, err error) {
+	reader, err := readConfig(ctx, objAPI, getBatchJobProgressFile(id, nodeIndex))
+	if err != nil {
+		return progress, err
+	}
+
+	err = json.NewDecoder(reader).Decode(&progress)
+	return progress, err
+}
+
+// saveBatchJobProgress - writes the progress of a job on a node to the backend.
+func saveBatchJobProgress(objAPI ObjectLayer, progress BatchJobProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(objAPI, getBatchJobProgressFile(progress.ID, progress.NodeIndex), data)
+}
@@ -0,0 +1,332 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// waitForBatchJob - waits until the job stops running on sys.
+func waitForBatchJob(t *testing.T, sys *BatchJobSys, id string) BatchJobProgress {
+	for i := 0; i < 100; i++ {
+		progress, err := sys.Describe(id)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if progress.Status != BatchJobRunning {
+			return progress
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("batch job %s did not stop", id)
+	return BatchJobProgress{}
+}
+
+func TestBatchJobRequestValidate(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, SSECustomerKeySize)
+
+	testCases := []struct {
+		req       BatchJobRequest
+		expectErr bool
+	}{
+		{BatchJobRequest{Type: BatchJobExpire, Bucket: "bucket", OlderThan: time.Hour}, false},
+		{BatchJobRequest{Type: BatchJobExpire, Bucket: "bucket"}, true},
+		{BatchJobRequest{Type: BatchJobExpire, Bucket: "b", OlderThan: time.Hour}, true},
+		{BatchJobRequest{Type: BatchJobKeyRotate, Bucket: "bucket", OldKey: key, NewKey: key}, false},
+		{BatchJobRequest{Type: BatchJobKeyRotate, Bucket: "bucket", OldKey: key}, true},
+		{BatchJobRequest{Type: BatchJobReplicate, Bucket: "bucket", TargetArn: "arn:minio:replication::unknown:replica"}, true},
+		{BatchJobRequest{Type: BatchJobTag, Bucket: "bucket", Tags: map[string]string{"project": "alpha"}}, false},
+		{BatchJobRequest{Type: BatchJobTag, Bucket: "bucket", RemoveTags: []string{"project"}}, false},
+		{BatchJobRequest{Type: BatchJobTag, Bucket: "bucket"}, true},
+		{BatchJobRequest{Type: BatchJobTag, Bucket: "bucket", Tags: map[string]string{"aws:project": "alpha"}}, true},
+		{BatchJobRequest{Type: BatchJobTag, Bucket: "bucket", Tags: map[string]string{"project": "alpha"}, RemoveTags: []string{"project"}}, true},
+		{BatchJobRequest{Type: "compact", Bucket: "bucket"}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.req.validate()
+		if expectErr := (err != nil); expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+	}
+
+	redacted := testCases[3].req.redacted()
+	if redacted.OldKey != nil || redacted.NewKey != nil {
+		t.Fatalf("expected keys to be removed, got %v", redacted)
+	}
+}
+
+func TestBatchJobIsNodeObject(t *testing.T) {
+	job := BatchJob{Nodes: []string{"node1:9000", "node2:9000", "node3:9000"}}
+	for i := 0; i < 100; i++ {
+		object := fmt.Sprintf("object%d", i)
+		count := 0
+		for nodeIndex := range job.Nodes {
+			if job.isNodeObject(nodeIndex, object) {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Fatalf("%s: expected to be processed by one node, got %d", object, count)
+		}
+	}
+}
+
+// Tests running an expire job split across two nodes and resuming jobs
+// from the backend.
+func TestBatchJobSys(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	ctx := context.Background()
+	if err = objLayer.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatalf("unable to create bucket, %s", err)
+	}
+	for _, object := range []string{"logs/a", "logs/b", "logs/c", "logs/d", "data/e"} {
+		if _, err = objLayer.PutObject(ctx, "bucket", object, mustGetHashReader(t, bytes.NewReader([]byte("data")), 4, "", ""), nil); err != nil {
+			t.Fatalf("unable to create object, %s", err)
+		}
+	}
+
+	job := BatchJob{
+		ID:      mustGetUUID(),
+		Request: BatchJobRequest{Type: BatchJobExpire, Bucket: "bucket", Prefix: "logs/", OlderThan: time.Nanosecond},
+		Nodes:   []string{"node1:9000", "node2:9000"},
+		Started: UTCNow(),
+	}
+	if err = saveBatchJob(objLayer, job); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	sys := NewBatchJobSys()
+	if err = sys.Start(objLayer, job, 2); err != errInvalidBatchJob {
+		t.Fatalf("expected: %v, got: %v", errInvalidBatchJob, err)
+	}
+	if _, err = sys.Describe(job.ID); err != errNoSuchBatchJob {
+		t.Fatalf("expected: %v, got: %v", errNoSuchBatchJob, err)
+	}
+
+	var processed uint64
+	for nodeIndex := range job.Nodes {
+		nodeSys := NewBatchJobSys()
+		if err = nodeSys.Start(objLayer, job, nodeIndex); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		progress := waitForBatchJob(t, nodeSys, job.ID)
+		if progress.Status != BatchJobCompleted || progress.Failed != 0 {
+			t.Fatalf("node %d: unexpected progress %v", nodeIndex, progress)
+		}
+		processed += progress.Processed
+	}
+	if processed != 4 {
+		t.Fatalf("expected 4 objects to be expired, got %d", processed)
+	}
+
+	result, err := objLayer.ListObjects(ctx, "bucket", "", "", "", maxObjectList)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Name != "data/e" {
+		t.Fatalf("expected only data/e to be left, got %v", result.Objects)
+	}
+
+	// A job left running by a restart is resumed by Init, completed
+	// jobs are only loaded.
+	interrupted := BatchJob{
+		ID:      mustGetUUID(),
+		Request: BatchJobRequest{Type: BatchJobExpire, Bucket: "bucket", OlderThan: time.Nanosecond},
+		Nodes:   []string{"node1:9000"},
+		Started: UTCNow(),
+	}
+	if err = saveBatchJob(objLayer, interrupted); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = saveBatchJobProgress(objLayer, BatchJobProgress{ID: interrupted.ID, NodeIndex: 0, Status: BatchJobRunning}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err = sys.Init(objLayer, "node1:9000"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if progress := waitForBatchJob(t, sys, interrupted.ID); progress.Status != BatchJobCompleted || progress.Processed != 1 {
+		t.Fatalf("unexpected progress %v", progress)
+	}
+	if progress, err := sys.Describe(job.ID); err != nil || progress.Status != BatchJobCompleted {
+		t.Fatalf("unexpected progress %v, %v", progress, err)
+	}
+	if progress := sys.List(); len(progress) != 2 {
+		t.Fatalf("expected 2 jobs, got %v", progress)
+	}
+	if err = sys.Cancel("unknown"); err != errNoSuchBatchJob {
+		t.Fatalf("expected: %v, got: %v", errNoSuchBatchJob, err)
+	}
+
+	// An index stored with former server credentials fails Init and
+	// is not overwritten, and is readable again once re-encrypted.
+	prevCred := globalServerConfig.GetCredential()
+	serverCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("unable to generate credentials, %s", err)
+	}
+	globalServerConfig.SetCredential(serverCred)
+	if err = NewBatchJobSys().Init(objLayer, "node1:9000"); err == nil {
+		t.Fatal("expected an index stored with former credentials to fail Init")
+	}
+	if err = saveBatchJob(objLayer, job); err == nil {
+		t.Fatal("expected an index stored with former credentials not to be overwritten")
+	}
+	globalServerConfig.SetCredential(prevCred)
+	if err = reencryptConfigs(ctx, objLayer, prevCred.SecretKey, serverCred.SecretKey, func() error {
+		globalServerConfig.SetCredential(serverCred)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if jobs, err := readBatchJobs(ctx, objLayer); err != nil || len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got: %v, %v", jobs, err)
+	}
+}
+
+// Tests setting and removing tags of objects under a prefix.
+func TestBatchJobTag(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	ctx := context.Background()
+	if err = objLayer.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatalf("unable to create bucket, %s", err)
+	}
+	objects := map[string]string{
+		"logs/a": "team=web&tmp=1",
+		"logs/b": "project=alpha",
+		"data/c": "tmp=1",
+	}
+	for object, tags := range objects {
+		metadata := map[string]string{objectTaggingKey: tags}
+		if _, err = objLayer.PutObject(ctx, "bucket", object, mustGetHashReader(t, bytes.NewReader([]byte("data")), 4, "", ""), metadata); err != nil {
+			t.Fatalf("unable to create object, %s", err)
+		}
+	}
+
+	job := BatchJob{
+		ID: mustGetUUID(),
+		Request: BatchJobRequest{Type: BatchJobTag, Bucket: "bucket", Prefix: "logs/",
+			Tags: map[string]string{"project": "alpha"}, RemoveTags: []string{"tmp"}},
+		Nodes:   []string{"node1:9000"},
+		Started: UTCNow(),
+	}
+	sys := NewBatchJobSys()
+	if err = sys.Start(objLayer, job, 0); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	progress := waitForBatchJob(t, sys, job.ID)
+	if progress.Status != BatchJobCompleted || progress.Processed != 1 || progress.Skipped != 1 || progress.Failed != 0 {
+		t.Fatalf("unexpected progress %v", progress)
+	}
+
+	expectedTags := map[string]string{
+		"logs/a": "project=alpha&team=web",
+		"logs/b": "project=alpha",
+		"data/c": "tmp=1",
+	}
+	for object, tags := range expectedTags {
+		info, err := objLayer.GetObjectInfo(ctx, "bucket", object)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if info.UserDefined[objectTaggingKey] != tags {
+			t.Fatalf("%s: expected tags %s, got %s", object, tags, info.UserDefined[objectTaggingKey])
+		}
+	}
+}
+
+// Tests that expire jobs add delete markers to objects of versioned
+// buckets instead of removing them.
+func TestBatchJobExpireVersioned(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatalf("unable to initialize XL backend, %s", err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	if err = objLayer.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatalf("unable to create bucket, %s", err)
+	}
+	globalBucketMetadataSys.Set("bucket", bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+
+	metadata := make(map[string]string)
+	setObjectVersionID("bucket", metadata)
+	objInfo, err := objLayer.PutObject(ctx, "bucket", "object", mustGetHashReader(t, bytes.NewReader([]byte("data")), 4, "", ""), metadata)
+	if err != nil {
+		t.Fatalf("unable to create object, %s", err)
+	}
+
+	expire, err := newBatchJobProcessor(objLayer, BatchJobRequest{Type: BatchJobExpire, Bucket: "bucket", OlderThan: time.Nanosecond})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if skipped, err := expire(ctx, objInfo); skipped || err != nil {
+		t.Fatalf("unexpected result %v, %v", skipped, err)
+	}
+
+	result, err := objLayer.ListObjectVersions(ctx, "bucket", "", "", "", "", maxObjectList)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(result.Objects) != 2 || !result.Objects[0].DeleteMarker || result.Objects[1].VersionID != objInfo.VersionID {
+		t.Fatalf("expected a delete marker in front of the version, got %v", result.Objects)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+// BucketBandwidth holds bytes received and sent for a bucket, throughput
+// is the average number of bytes per second since server start.
+type BucketBandwidth struct {
+	BytesIn          uint64  `json:"bytesIn"`
+	BytesOut         uint64  `json:"bytesOut"`
+	InputThroughput  float64 `json:"inputThroughput"`
+	OutputThroughput float64 `json:"outputThroughput"`
+}
+
+// ServerBandwidthData holds per bucket bandwidth of a given server.
+type ServerBandwidthData struct {
+	Uptime  time.Duration              `json:"uptime"`
+	Buckets map[string]BucketBandwidth `json:"buckets"`
+}
+
+// ServerBandwidth holds bandwidth information result of one node.
+type ServerBandwidth struct {
+	Error string               `json:"error"`
+	Addr  string               `json:"addr"`
+	Data  *ServerBandwidthData `json:"data"`
+}
+
+// ClusterBandwidthInfo holds per bucket bandwidth aggregated across
+// all nodes along with the bandwidth reported by each node.
+type ClusterBandwidthInfo struct {
+	Buckets map[string]BucketBandwidth `json:"buckets"`
+	Servers []ServerBandwidth          `json:"servers"`
+}
+
+// bucketTraffic - bytes transferred from/to a single bucket.
+type bucketTraffic struct {
+	inputBytes  atomic.Uint64
+	outputBytes atomic.Uint64
+}
+
+// BucketBandwidthStats - counts input/output transferred bytes per
+// bucket during the server's life.
+type BucketBandwidthStats struct {
+	sync.RWMutex
+	buckets map[string]*bucketTraffic
+}
+
+// Returns traffic counters of the bucket, creating them if necessary.
+func (s *BucketBandwidthStats) getTraffic(bucket string) *bucketTraffic {
+	s.RLock()
+	traffic, ok := s.buckets[bucket]
+	s.RUnlock()
+	if ok {
+		return traffic
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if traffic, ok = s.buckets[bucket]; !ok {
+		traffic = &bucketTraffic{}
+		s.buckets[bucket] = traffic
+	}
+	return traffic
+}
+
+// Increase input bytes of the bucket
+func (s *BucketBandwidthStats) incInputBytes(bucket string, n uint64) {
+	s.getTraffic(bucket).inputBytes.Add(n)
+}
+
+// Increase output bytes of the bucket
+func (s *BucketBandwidthStats) incOutputBytes(bucket string, n uint64) {
+	s.getTraffic(bucket).outputBytes.Add(n)
+}
+
+// Remove counters of a deleted bucket
+func (s *BucketBandwidthStats) deleteBucket(bucket string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.buckets, bucket)
+}
+
+// Update bandwidth statistics of the bucket addressed by the request.
+// Failed requests are not accounted so that requests made to arbitrary
+// non-existent buckets don't grow the statistics.
+func (s *BucketBandwidthStats) updateStats(r *http.Request, w *httpResponseRecorder, bytesRead uint64) {
+	if w.respStatusCode >= http.StatusBadRequest {
+		return
+	}
+
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainName)
+	if err != nil {
+		return
+	}
+
+	bucket, _ := urlPath2BucketObjectName(resource)
+	if bucket == "" || bucket == minioReservedBucket {
+		return
+	}
+
+	if bytesRead > 0 {
+		s.incInputBytes(bucket, bytesRead)
+	}
+	if w.bytesWritten > 0 {
+		s.incOutputBytes(bucket, w.bytesWritten)
+	}
+}
+
+// Return per bucket bandwidth of this server.
+func (s *BucketBandwidthStats) toServerBandwidthData(uptime time.Duration) ServerBandwidthData {
+	s.RLock()
+	defer s.RUnlock()
+
+	buckets := make(map[string]BucketBandwidth, len(s.buckets))
+	for bucket, traffic := range s.buckets {
+		bandwidth := BucketBandwidth{
+			BytesIn:  traffic.inputBytes.Load(),
+			BytesOut: traffic.outputBytes.Load(),
+		}
+		if seconds := uptime.Seconds(); seconds > 0 {
+			bandwidth.InputThroughput = float64(bandwidth.BytesIn) / seconds
+			bandwidth.OutputThroughput = float64(bandwidth.BytesOut) / seconds
+		}
+		buckets[bucket] = bandwidth
+	}
+
+	return ServerBandwidthData{
+		Uptime:  uptime,
+		Buckets: buckets,
+	}
+}
+
+// Prepare new BucketBandwidthStats structure
+func newBucketBandwidthStats() *BucketBandwidthStats {
+	return &BucketBandwidthStats{
+		buckets: make(map[string]*bucketTraffic),
+	}
+}
+
+// aggregateBucketBandwidth - sums up per bucket bandwidth reported by all
+// nodes, the resulting throughput is the cluster-wide throughput.
+func aggregateBucketBandwidth(servers []ServerBandwidth) map[string]BucketBandwidth {
+	buckets := make(map[string]BucketBandwidth)
+	for _, server := range servers {
+		if server.Data == nil {
+			continue
+		}
+		for bucket, bandwidth := range server.Data.Buckets {
+			total := buckets[bucket]
+			total.BytesIn += bandwidth.BytesIn
+			total.BytesOut += bandwidth.BytesOut
+			total.InputThroughput += bandwidth.InputThroughput
+			total.OutputThroughput += bandwidth.OutputThroughput
+			buckets[bucket] = total
+		}
+	}
+	return buckets
+}
+
+// countingReadCloser - wraps request body to count the bytes read.
+type countingReadCloser struct {
+	io.ReadCloser
+	bytesRead uint64
+}
+
+func (c *countingReadCloser) Read(p []byte) (n int, err error) {
+	n, err = c.ReadCloser.Read(p)
+	c.bytesRead += uint64(n)
+	return n, err
+}
@@ -0,0 +1,124 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests bandwidth accounting of requests passing through httpStatsHandler.
+func TestBucketBandwidthStatsHandler(t *testing.T) {
+	tmpGlobalBucketBandwidthStats := globalBucketBandwidthStats
+	defer func() {
+		globalBucketBandwidthStats = tmpGlobalBucketBandwidthStats
+	}()
+	globalBucketBandwidthStats = newBucketBandwidthStats()
+
+	handler := setHTTPStatsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.Copy(ioutil.Discard, r.Body)
+		w.Write([]byte("hello"))
+	}))
+
+	testCases := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPut, "/bucket1/object", "hello world"},
+		{http.MethodGet, "/bucket1/object", ""},
+		{http.MethodGet, "/bucket2", ""},
+		// Failed requests are not accounted.
+		{http.MethodGet, "/bucket3/missing", ""},
+		// Requests to reserved bucket are not accounted.
+		{http.MethodPost, minioReservedBucketPath + "/admin", "rpc"},
+		// Requests without bucket are not accounted.
+		{http.MethodGet, "/", ""},
+	}
+
+	for i, testCase := range testCases {
+		req, err := http.NewRequest(testCase.method, "http://localhost:9000"+testCase.path, strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	expected := map[string]BucketBandwidth{
+		"bucket1": {BytesIn: 11, BytesOut: 10},
+		"bucket2": {BytesIn: 0, BytesOut: 5},
+	}
+
+	bandwidthData := globalBucketBandwidthStats.toServerBandwidthData(0)
+	if len(bandwidthData.Buckets) != len(expected) {
+		t.Fatalf("expected: %v, got: %v", expected, bandwidthData.Buckets)
+	}
+	for bucket, bandwidth := range expected {
+		if bandwidthData.Buckets[bucket] != bandwidth {
+			t.Errorf("%s: expected: %v, got: %v", bucket, bandwidth, bandwidthData.Buckets[bucket])
+		}
+	}
+
+	globalBucketBandwidthStats.deleteBucket("bucket1")
+	if _, ok := globalBucketBandwidthStats.toServerBandwidthData(0).Buckets["bucket1"]; ok {
+		t.Error("expected bandwidth of bucket1 to be removed")
+	}
+}
+
+// Tests throughput computation and cluster-wide aggregation.
+func TestAggregateBucketBandwidth(t *testing.T) {
+	stats1 := newBucketBandwidthStats()
+	stats1.incInputBytes("bucket", 100)
+	stats1.incOutputBytes("bucket", 200)
+
+	stats2 := newBucketBandwidthStats()
+	stats2.incInputBytes("bucket", 300)
+	stats2.incInputBytes("other", 50)
+
+	data1 := stats1.toServerBandwidthData(10 * time.Second)
+	data2 := stats2.toServerBandwidthData(100 * time.Second)
+
+	servers := []ServerBandwidth{
+		{Addr: "server1", Data: &data1},
+		{Addr: "server2", Data: &data2},
+		{Addr: "server3", Error: "unreachable"},
+	}
+
+	expected := map[string]BucketBandwidth{
+		"bucket": {BytesIn: 400, BytesOut: 200, InputThroughput: 13, OutputThroughput: 20},
+		"other":  {BytesIn: 50, InputThroughput: 0.5},
+	}
+
+	buckets := aggregateBucketBandwidth(servers)
+	if len(buckets) != len(expected) {
+		t.Fatalf("expected: %v, got: %v", expected, buckets)
+	}
+	for bucket, bandwidth := range expected {
+		if buckets[bucket] != bandwidth {
+			t.Errorf("%s: expected: %v, got: %v", bucket, bandwidth, buckets[bucket])
+		}
+	}
+}
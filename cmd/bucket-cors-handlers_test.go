@@ -0,0 +1,161 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/cors"
+)
+
+// Wrapper for calling bucket CORS handler tests for both XL multiple disks and single node setup.
+func TestBucketCorsHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketCorsHandlers, []string{"PutBucketCors", "GetBucketCors", "DeleteBucketCors", "PutObject", "GetObject"})
+}
+
+func testBucketCorsHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	corsRouter := setCorsHandler(apiRouter)
+	serve := func(method, urlStr string, data []byte, header http.Header, signed bool) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if signed {
+			if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+				t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+			}
+		}
+		rec := httptest.NewRecorder()
+		corsRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	preflight := func(origin, method, headers string) *httptest.ResponseRecorder {
+		header := http.Header{"Origin": {origin}, "Access-Control-Request-Method": {method}}
+		if headers != "" {
+			header["Access-Control-Request-Headers"] = []string{headers}
+		}
+		return serve("OPTIONS", getGetObjectURL("", bucketName, "object"), nil, header, false)
+	}
+
+	if rec := serve("GET", getBucketCorsURL("", bucketName), nil, nil, true); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec := preflight("https://www.example.com", "GET", ""); rec.Code != http.StatusForbidden {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+
+	config := `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule>` +
+		`<CORSRule><ID>write</ID><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedHeader>content-*</AllowedHeader>` +
+		`<ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`
+	testCases := []struct {
+		bucketName   string
+		data         string
+		expectedCode int
+	}{
+		{bucketName, `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>OPTIONS</AllowedMethod></CORSRule></CORSConfiguration>`, http.StatusBadRequest},
+		{bucketName, `<CORSConfiguration><CORSRule>`, http.StatusBadRequest},
+		{"missing-bucket", config, http.StatusNotFound},
+		{bucketName, config, http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getBucketCorsURL("", testCase.bucketName), []byte(testCase.data), nil, true)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+
+	rec := serve("GET", getBucketCorsURL("", bucketName), nil, nil, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var corsConfig cors.Config
+	if err := xml.Unmarshal(rec.Body.Bytes(), &corsConfig); err != nil {
+		t.Fatalf("%s: Failed to parse CORS configuration: <ERROR> %v", instanceType, err)
+	}
+	if len(corsConfig.Rules) != 2 || corsConfig.Rules[1].ID != "write" {
+		t.Fatalf("%s: Unexpected CORS configuration %v", instanceType, corsConfig)
+	}
+
+	preflightCases := []struct {
+		origin              string
+		method              string
+		headers             string
+		expectedCode        int
+		expectedAllowOrigin string
+	}{
+		{"http://any.where", "GET", "", http.StatusOK, "*"},
+		{"https://www.example.com", "PUT", "Content-Type, Content-MD5", http.StatusOK, "https://www.example.com"},
+		{"https://www.example.com", "PUT", "Authorization", http.StatusForbidden, ""},
+		{"http://www.example.com", "PUT", "", http.StatusForbidden, ""},
+		{"https://www.example.com", "DELETE", "", http.StatusForbidden, ""},
+	}
+	for i, testCase := range preflightCases {
+		rec = preflight(testCase.origin, testCase.method, testCase.headers)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if allowOrigin := rec.Header().Get("Access-Control-Allow-Origin"); allowOrigin != testCase.expectedAllowOrigin {
+			t.Errorf("%s: Test %d: Expected the allowed origin %q, but instead found %q", instanceType, i+1, testCase.expectedAllowOrigin, allowOrigin)
+		}
+	}
+
+	rec = preflight("https://www.example.com", "PUT", "Content-Type")
+	if rec.Header().Get("Access-Control-Allow-Methods") != "PUT" || rec.Header().Get("Access-Control-Allow-Headers") != "Content-Type" ||
+		rec.Header().Get("Access-Control-Max-Age") != "3000" || rec.Header().Get("Access-Control-Allow-Credentials") != "true" {
+		t.Errorf("%s: Unexpected preflight response headers %v", instanceType, rec.Header())
+	}
+
+	// Actual requests are served with the headers of the matching rule.
+	rec = serve("PUT", getPutObjectURL("", bucketName, "object"), []byte("hello"), http.Header{"Origin": {"https://www.example.com"}}, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if rec.Header().Get("Access-Control-Allow-Origin") != "https://www.example.com" || rec.Header().Get("Access-Control-Expose-Headers") != "ETag" {
+		t.Errorf("%s: Unexpected response headers %v", instanceType, rec.Header())
+	}
+	rec = serve("GET", getGetObjectURL("", bucketName, "object"), nil, http.Header{"Origin": {"http://any.where"}}, true)
+	if rec.Code != http.StatusOK || rec.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("%s: Unexpected response `%d` %v", instanceType, rec.Code, rec.Header())
+	}
+	rec = serve("GET", getGetObjectURL("", bucketName, "object"), nil, nil, true)
+	if rec.Code != http.StatusOK || rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("%s: Unexpected response `%d` %v", instanceType, rec.Code, rec.Header())
+	}
+
+	if rec = serve("DELETE", getBucketCorsURL("", bucketName), nil, nil, true); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec = serve("GET", getBucketCorsURL("", bucketName), nil, nil, true); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec = preflight("http://any.where", "GET", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+}
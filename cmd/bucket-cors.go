@@ -0,0 +1,133 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/pkg/cors"
+)
+
+const (
+	// Bucket CORS configuration file.
+	bucketCorsConfig = "cors.xml"
+
+	// Maximum size of a CORS configuration in a put-bucket-cors request.
+	maxCorsConfigSize = 64 * 1024
+)
+
+// getBucketCors - returns the CORS configuration of given bucket name,
+// false if the bucket has none.
+func getBucketCors(bucketName string) (*cors.Config, bool) {
+	if globalBucketMetadataSys == nil {
+		return nil, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketCorsConfig)
+	if !ok {
+		return nil, false
+	}
+	var config cors.Config
+	if err := xml.Unmarshal(data, &config); err != nil {
+		return nil, false
+	}
+	return &config, true
+}
+
+// bucketCorsHandler - answers the cross-origin requests on a bucket as per
+// its CORS configuration. Requests to the server itself, like the ones
+// of the browser and the admin API, are left to the default handler.
+type bucketCorsHandler struct {
+	handler        http.Handler
+	defaultHandler http.Handler
+}
+
+// setBucketCorsHeaders - sets the Access-Control headers of a response
+// to a request from given origin allowed by the rule.
+func setBucketCorsHeaders(w http.ResponseWriter, rule *cors.Rule, origin string) {
+	w.Header().Add("Vary", "Origin")
+	if rule.AllowsAnyOrigin() {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+	if len(rule.ExposeHeaders) > 0 {
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(rule.ExposeHeaders, ", "))
+	}
+}
+
+func (h bucketCorsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainName)
+	if err != nil {
+		writeErrorResponse(w, ErrInvalidRequest, r.URL)
+		return
+	}
+	bucket, _ := urlPath2BucketObjectName(resource)
+	if bucket == "" || isMinioReservedBucket(bucket) {
+		h.defaultHandler.ServeHTTP(w, r)
+		return
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	config, ok := getBucketCors(bucket)
+
+	// Answer preflight requests without calling the handler.
+	if method := r.Header.Get("Access-Control-Request-Method"); r.Method == http.MethodOptions && method != "" {
+		if !ok {
+			writeErrorResponse(w, ErrCORSNotEnabled, r.URL)
+			return
+		}
+		var headers []string
+		for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				headers = append(headers, header)
+			}
+		}
+		rule := config.Match(origin, method, headers)
+		if rule == nil {
+			writeErrorResponse(w, ErrCORSForbidden, r.URL)
+			return
+		}
+		setBucketCorsHeaders(w, rule, origin)
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(rule.AllowedMethods, ", "))
+		if len(headers) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+		}
+		if rule.MaxAgeSeconds > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(rule.MaxAgeSeconds))
+		}
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	if ok {
+		if rule := config.Match(origin, r.Method, nil); rule != nil {
+			setBucketCorsHeaders(w, rule, origin)
+		}
+	}
+	h.handler.ServeHTTP(w, r)
+}
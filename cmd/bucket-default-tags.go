@@ -0,0 +1,100 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/minio/minio/pkg/tagging"
+)
+
+const (
+	// Default tags configuration file.
+	bucketDefaultTagsConfig = "default-tags.json"
+
+	// Maximum size of a default tags configuration.
+	maxBucketDefaultTagsConfigSize = 16 * 1024
+)
+
+// BucketDefaultTags - tags of the objects uploaded to a bucket without
+// tags of their own.
+type BucketDefaultTags struct {
+	Tags map[string]string `json:"tags"`
+}
+
+// tagging - returns the default tags, validated as the tags of an object.
+func (defaultTags BucketDefaultTags) tagging() (*tagging.Tagging, error) {
+	values := url.Values{}
+	for key, value := range defaultTags.Tags {
+		values.Set(key, value)
+	}
+	return tagging.ParseTags(values.Encode(), tagging.MaxObjectTags)
+}
+
+// getBucketDefaultTagsConfig - returns the default tags configuration of
+// given bucket name, false if the bucket has none.
+func getBucketDefaultTagsConfig(bucketName string) (BucketDefaultTags, bool) {
+	var defaultTags BucketDefaultTags
+	if globalBucketMetadataSys == nil {
+		return defaultTags, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketDefaultTagsConfig)
+	if !ok {
+		return defaultTags, false
+	}
+	if err := json.Unmarshal(data, &defaultTags); err != nil {
+		return BucketDefaultTags{}, false
+	}
+	return defaultTags, true
+}
+
+// getBucketDefaultTags - returns the default tags of given bucket name,
+// URL query encoded, or an empty string if the bucket has none.
+func getBucketDefaultTags(bucketName string) string {
+	defaultTags, ok := getBucketDefaultTagsConfig(bucketName)
+	if !ok {
+		return ""
+	}
+	tags, err := defaultTags.tagging()
+	if err != nil {
+		return ""
+	}
+	return tags.String()
+}
+
+// setBucketDefaultTags - records the default tags of given bucket name
+// in the metadata of a new object without tags.
+func setBucketDefaultTags(objAPI ObjectLayer, bucketName string, metadata map[string]string) {
+	if tags := getBucketDefaultTags(bucketName); tags != "" && objAPI.IsTaggingSupported() {
+		setObjectTags(metadata, tags)
+	}
+}
+
+// saveBucketDefaultTags - saves the default tags of given bucket name,
+// no tags removes them.
+func saveBucketDefaultTags(ctx context.Context, objAPI ObjectLayer, bucketName string, defaultTags BucketDefaultTags) error {
+	if len(defaultTags.Tags) == 0 {
+		return saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketDefaultTagsConfig, nil)
+	}
+	data, err := json.Marshal(defaultTags)
+	if err != nil {
+		return err
+	}
+	return saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketDefaultTagsConfig, data)
+}
@@ -0,0 +1,220 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/sse"
+)
+
+// Wrapper for calling bucket encryption handler tests for both XL multiple disks and single node setup.
+func TestBucketEncryptionHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketEncryptionHandlers, []string{"PutBucketEncryption", "GetBucketEncryption", "DeleteBucketEncryption",
+		"CopyObject", "PutObjectPart", "NewMultipart", "CompleteMultipart", "PutObject", "GetObject", "HeadObject"})
+}
+
+func testBucketEncryptionHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	tmpGlobalKMS, tmpGlobalKMSKeyID := globalKMS, globalKMSKeyID
+	defer func() { globalKMS, globalKMSKeyID = tmpGlobalKMS, tmpGlobalKMSKeyID }()
+	globalKMS, globalKMSKeyID = nil, ""
+
+	serve := func(method, urlStr string, data []byte, header http.Header) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	config := `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`
+
+	// Default encryption needs a KMS.
+	if rec := serve("PUT", getBucketEncryptionURL("", bucketName), []byte(config), nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	globalKMS, globalKMSKeyID = crypto.NewKMS([32]byte{}), "default-key"
+
+	if rec := serve("GET", getBucketEncryptionURL("", bucketName), nil, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	testCases := []struct {
+		bucketName        string
+		data              string
+		expectedCode      int
+		expectedAlgorithm sse.Algorithm
+	}{
+		{bucketName, `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`, http.StatusOK, sse.AWSKMS},
+		{bucketName, `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>DES</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`, http.StatusBadRequest, sse.AWSKMS},
+		{bucketName, `<ServerSideEncryptionConfiguration><Rule>`, http.StatusBadRequest, sse.AWSKMS},
+		{"missing-bucket", config, http.StatusNotFound, sse.AWSKMS},
+		{bucketName, config, http.StatusOK, sse.AES256},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getBucketEncryptionURL("", testCase.bucketName), []byte(testCase.data), nil)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+
+		rec = serve("GET", getBucketEncryptionURL("", bucketName), nil, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, http.StatusOK, rec.Code)
+		}
+		var config sse.Config
+		if err := xml.Unmarshal(rec.Body.Bytes(), &config); err != nil {
+			t.Fatalf("%s: Test %d: Failed to parse encryption configuration: <ERROR> %v", instanceType, i+1, err)
+		}
+		if algorithm := config.DefaultEncryption().SSEAlgorithm; algorithm != testCase.expectedAlgorithm {
+			t.Errorf("%s: Test %d: Expected the algorithm %s, but instead found %s", instanceType, i+1, testCase.expectedAlgorithm, algorithm)
+		}
+	}
+
+	// New objects are encrypted, they are read as if they were not.
+	data := bytes.Repeat([]byte("encrypted-by-default"), 10000)
+	rec := serve("PUT", getPutObjectURL("", bucketName, "object"), data, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if algorithm := rec.Header().Get(crypto.SSEHeader); algorithm != "AES256" {
+		t.Errorf("%s: Expected the encryption header AES256, but instead found %s", instanceType, algorithm)
+	}
+
+	checkEncrypted := func(bucket, object string, expected bool) {
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucket, object)
+		if err != nil {
+			t.Fatalf("%s: %s: Failed to get object info: <ERROR> %v", instanceType, object, err)
+		}
+		if encrypted := crypto.S3.IsEncrypted(objInfo.UserDefined); encrypted != expected {
+			t.Fatalf("%s: %s: Expected the object encrypted to be %v, but instead found %v", instanceType, object, expected, encrypted)
+		}
+		var buffer bytes.Buffer
+		if err = obj.GetObject(context.Background(), bucket, object, 0, objInfo.Size, &buffer, ""); err != nil {
+			t.Fatalf("%s: %s: Failed to get object: <ERROR> %v", instanceType, object, err)
+		}
+		if stored := bytes.Equal(buffer.Bytes(), data); stored == expected {
+			t.Errorf("%s: %s: Expected the data stored in plain to be %v, but instead found %v", instanceType, object, !expected, stored)
+		}
+
+		rec := serve("GET", getGetObjectURL("", bucket, object), nil, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, object, http.StatusOK, rec.Code)
+		}
+		if !bytes.Equal(rec.Body.Bytes(), data) {
+			t.Errorf("%s: %s: Expected the object data to be read decrypted", instanceType, object)
+		}
+		if algorithm := rec.Header().Get(crypto.SSEHeader); (algorithm == "AES256") != expected {
+			t.Errorf("%s: %s: Unexpected encryption header %s", instanceType, object, algorithm)
+		}
+	}
+	checkEncrypted(bucketName, "object", true)
+
+	rec = serve("GET", getGetObjectURL("", bucketName, "object"), nil, http.Header{"Range": {"bytes=65530-65549"}})
+	if rec.Code != http.StatusPartialContent || !bytes.Equal(rec.Body.Bytes(), data[65530:65550]) {
+		t.Errorf("%s: Expected the range of the object to be read decrypted, but instead found `%d` %q", instanceType, rec.Code, rec.Body.String())
+	}
+
+	rec = serve("HEAD", getHeadObjectURL("", bucketName, "object"), nil, nil)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Length") != "200000" || rec.Header().Get(crypto.SSEHeader) != "AES256" {
+		t.Errorf("%s: Expected the decrypted size and the encryption header, but instead found `%d` %v", instanceType, rec.Code, rec.Header())
+	}
+
+	// Objects uploaded in parts are encrypted too.
+	rec = serve("POST", getNewMultipartURL("", bucketName, "multipart"), nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var upload InitiateMultipartUploadResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &upload); err != nil {
+		t.Fatalf("%s: Failed to parse multipart upload: <ERROR> %v", instanceType, err)
+	}
+	rec = serve("PUT", getPartUploadURL("", bucketName, "multipart", upload.UploadID, "1"), data, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	complete, _ := xml.Marshal(CompleteMultipartUpload{Parts: []CompletePart{{PartNumber: 1, ETag: rec.Header().Get("ETag")}}})
+	rec = serve("POST", getCompleteMultipartUploadURL("", bucketName, "multipart", upload.UploadID), complete, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	checkEncrypted(bucketName, "multipart", true)
+
+	// Copies are encrypted as per the default encryption of their bucket,
+	// the XL object layer of the tests copies stored data without the
+	// reader of the handler, unlike the erasure sets.
+	if instanceType == FSTestStr {
+		plainBucket := getRandomBucketName()
+		if err := obj.MakeBucketWithLocation(context.Background(), plainBucket, ""); err != nil {
+			t.Fatalf("%s: Failed to make bucket: <ERROR> %v", instanceType, err)
+		}
+		for _, source := range []string{"object", "multipart"} {
+			rec = serve("PUT", getCopyObjectURL("", plainBucket, source), nil, http.Header{"X-Amz-Copy-Source": {"/" + bucketName + "/" + source}})
+			if rec.Code != http.StatusOK {
+				t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+			}
+			checkEncrypted(plainBucket, source, false)
+		}
+		rec = serve("PUT", getCopyObjectURL("", bucketName, "copy"), nil, http.Header{"X-Amz-Copy-Source": {"/" + plainBucket + "/object"}})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		checkEncrypted(bucketName, "copy", true)
+	}
+
+	// Metadata updates keep the object encrypted.
+	rec = serve("PUT", getCopyObjectURL("", bucketName, "object"), nil, http.Header{
+		"X-Amz-Copy-Source":        {"/" + bucketName + "/object"},
+		"X-Amz-Metadata-Directive": {"REPLACE"},
+		"X-Amz-Meta-Color":         {"blue"},
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	checkEncrypted(bucketName, "object", true)
+
+	if rec = serve("DELETE", getBucketEncryptionURL("", bucketName), nil, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec = serve("GET", getBucketEncryptionURL("", bucketName), nil, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec = serve("PUT", getPutObjectURL("", bucketName, "plain"), data, nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	checkEncrypted(bucketName, "plain", false)
+	checkEncrypted(bucketName, "object", true)
+}
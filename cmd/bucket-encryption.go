@@ -0,0 +1,109 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/sse"
+	"github.com/minio/sio"
+)
+
+const (
+	// Bucket encryption configuration file.
+	bucketEncryptionConfig = "encryption.xml"
+
+	// Maximum size of an encryption configuration in a put-bucket-encryption request.
+	maxEncryptionConfigSize = 1024 * 1024
+
+	// Header giving the KMS key objects encrypted with aws:kms are sealed with.
+	amzServerSideEncryptionKMSKeyID = crypto.SSEHeader + "-Aws-Kms-Key-Id"
+)
+
+// getBucketEncryption - returns the default encryption of new objects of
+// given bucket name, false if the bucket has none.
+func getBucketEncryption(bucketName string) (sse.ApplyServerSideEncryptionByDefault, bool) {
+	if globalBucketMetadataSys == nil {
+		return sse.ApplyServerSideEncryptionByDefault{}, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketEncryptionConfig)
+	if !ok {
+		return sse.ApplyServerSideEncryptionByDefault{}, false
+	}
+	var config sse.Config
+	if err := xml.Unmarshal(data, &config); err != nil {
+		return sse.ApplyServerSideEncryptionByDefault{}, false
+	}
+	return config.DefaultEncryption(), true
+}
+
+// newBucketEncryptMetadata - seals a new object encryption key into the
+// metadata as per the default encryption of the bucket. Objects are
+// encrypted with SSE-S3 using the KMS key of the rule, if any, or the
+// master key of the server.
+func newBucketEncryptMetadata(encryption sse.ApplyServerSideEncryptionByDefault, bucket, object string, metadata map[string]string) ([]byte, error) {
+	keyID := encryption.KMSMasterKeyID
+	if keyID == "" {
+		keyID = globalKMSKeyID
+	}
+	objectEncryptionKey, err := newEncryptMetadataS3(keyID, bucket, object, metadata)
+	if err != nil {
+		return nil, err
+	}
+	metadata[crypto.SSEHeader] = string(encryption.SSEAlgorithm)
+	if encryption.SSEAlgorithm == sse.AWSKMS {
+		metadata[amzServerSideEncryptionKMSKeyID] = keyID
+	}
+	return objectEncryptionKey, nil
+}
+
+// newBucketEncryptReader - encrypts the content of a new object as per the
+// default encryption of its bucket.
+func newBucketEncryptReader(content io.Reader, encryption sse.ApplyServerSideEncryptionByDefault, bucket, object string, metadata map[string]string) (io.Reader, error) {
+	objectEncryptionKey, err := newBucketEncryptMetadata(encryption, bucket, object, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := sio.EncryptReader(content, sio.Config{Key: objectEncryptionKey})
+	if err != nil {
+		return nil, errInvalidSSEKey
+	}
+
+	return reader, nil
+}
+
+// removeBucketEncryptMetadata - removes the encryption headers recorded
+// for an object encrypted with SSE-S3, from the metadata of its copies
+// which are stored decrypted.
+func removeBucketEncryptMetadata(metadata map[string]string) {
+	delete(metadata, crypto.SSEHeader)
+	delete(metadata, amzServerSideEncryptionKMSKeyID)
+}
+
+// setBucketEncryptionHeaders - sets the encryption headers of an object
+// encrypted as per the default encryption of its bucket.
+func setBucketEncryptionHeaders(w http.ResponseWriter, metadata map[string]string) {
+	for _, key := range []string{crypto.SSEHeader, amzServerSideEncryptionKMSKeyID} {
+		if value, ok := metadata[key]; ok {
+			w.Header().Set(key, value)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Minio extension request header of DeleteBucket, all objects, object
+// versions and incomplete multipart uploads of the bucket are removed
+// before the bucket if set to "true".
+const minioForceDelete = "X-Minio-Force-Delete"
+
+// isForceDeleteRequested - returns true if the request headers ask to
+// remove a bucket along with its contents.
+func isForceDeleteRequested(header http.Header) bool {
+	return strings.EqualFold(header.Get(minioForceDelete), "true")
+}
+
+// deleteBucketContents - removes the objects of given bucket, all their
+// versions if the bucket is versioned, and its incomplete multipart
+// uploads. Deletion stops at the first object version which is locked.
+func deleteBucketContents(ctx context.Context, objAPI ObjectLayer, cache CacheObjectLayer, bucket string, r *http.Request) error {
+	if getBucketVersioning(bucket) != "" {
+		if err := deleteBucketVersions(ctx, objAPI, bucket, r); err != nil {
+			return err
+		}
+	}
+	if err := deleteBucketObjects(ctx, objAPI, cache, bucket, r); err != nil {
+		return err
+	}
+	return abortBucketMultipartUploads(ctx, objAPI, bucket)
+}
+
+// deleteBucketObjects - removes the objects of given bucket.
+func deleteBucketObjects(ctx context.Context, objAPI ObjectLayer, cache CacheObjectLayer, bucket string, r *http.Request) error {
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, object := range result.Objects {
+			if err = deleteObject(ctx, objAPI, cache, bucket, object.Name, r); err != nil {
+				if _, ok := err.(ObjectNotFound); !ok {
+					return err
+				}
+			}
+		}
+
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			return nil
+		}
+		marker = result.NextMarker
+		if marker == "" {
+			marker = result.Objects[len(result.Objects)-1].Name
+		}
+	}
+}
+
+// deleteBucketVersions - permanently removes the versions and delete
+// markers of the objects of given versioned bucket.
+func deleteBucketVersions(ctx context.Context, objAPI ObjectLayer, bucket string, r *http.Request) error {
+	keyMarker, versionIDMarker := "", ""
+	for {
+		result, err := objAPI.ListObjectVersions(ctx, bucket, "", keyMarker, versionIDMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, version := range result.Objects {
+			if _, err = deleteObjectVersion(ctx, objAPI, bucket, version.Name, version.VersionID, r); err != nil {
+				if _, ok := err.(VersionNotFound); !ok {
+					return err
+				}
+			}
+		}
+
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			return nil
+		}
+		keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+	}
+}
+
+// abortBucketMultipartUploads - aborts the incomplete multipart uploads
+// of given bucket. Backends which can't list the uploads of a bucket
+// only abort those found on the local drives.
+func abortBucketMultipartUploads(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	abort := func(object, uploadID string) error {
+		// Uploads may be completed or aborted meanwhile.
+		switch err := objAPI.AbortMultipartUpload(ctx, bucket, object, uploadID); err.(type) {
+		case nil, InvalidUploadID:
+			return nil
+		default:
+			return err
+		}
+	}
+
+	keyMarker, uploadIDMarker := "", ""
+	for {
+		result, err := objAPI.ListMultipartUploads(ctx, bucket, "", keyMarker, uploadIDMarker, "", maxObjectList)
+		if err != nil {
+			if _, ok := err.(NotImplemented); ok {
+				break
+			}
+			return err
+		}
+
+		for _, upload := range result.Uploads {
+			if err = abort(upload.Object, upload.UploadID); err != nil {
+				return err
+			}
+		}
+
+		if !result.IsTruncated || len(result.Uploads) == 0 {
+			break
+		}
+		keyMarker, uploadIDMarker = result.NextKeyMarker, result.NextUploadIDMarker
+	}
+
+	for _, upload := range getLocalMultipartUploads(globalEndpoints) {
+		if upload.Bucket != bucket {
+			continue
+		}
+		if err := abort(upload.Object, upload.UploadID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
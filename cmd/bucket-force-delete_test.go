@@ -0,0 +1,109 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
+)
+
+// Wrapper for calling force delete bucket tests for both XL multiple disks and single node setup.
+func TestForceDeleteBucketHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testForceDeleteBucketHandler, []string{"DeleteBucket"})
+}
+
+func testForceDeleteBucketHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalBucketTargetSys = NewBucketTargetSys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	for _, object := range []string{"a", "dir/b", "dir/c"} {
+		data := []byte("hello, world")
+		if _, err := obj.PutObject(ctx, bucketName, object, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+			t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+		}
+	}
+	if _, err := obj.NewMultipartUpload(ctx, bucketName, "multipart", nil); err != nil {
+		t.Fatalf("%s: Failed to create multipart upload: <ERROR> %v", instanceType, err)
+	}
+
+	// Anonymous requests only allowed to delete the bucket may not remove its contents.
+	globalPolicySys.Set(bucketName, policy.Policy{
+		Version: policy.DefaultVersion,
+		Statements: []policy.Statement{policy.NewStatement(
+			policy.Allow,
+			policy.NewPrincipal("*"),
+			policy.NewActionSet(policy.DeleteBucketAction),
+			policy.NewResourceSet(policy.NewResource(bucketName, "")),
+			condition.NewFunctions(),
+		)},
+	})
+
+	testCases := []struct {
+		anonymous          bool
+		forceDelete        string
+		expectedRespStatus int
+	}{
+		// 1. Non-empty buckets are not deleted without the header.
+		{false, "", http.StatusConflict},
+		{false, "false", http.StatusConflict},
+		// 2. Removing the contents requires its own permission.
+		{true, "true", http.StatusForbidden},
+		// 3. The bucket is deleted along with its contents.
+		{false, "true", http.StatusNoContent},
+		// 4. The bucket does not exist anymore.
+		{false, "true", http.StatusNotFound},
+	}
+
+	for i, testCase := range testCases {
+		var req *http.Request
+		var err error
+		if testCase.anonymous {
+			req, err = newTestRequest("DELETE", getDeleteBucketURL("", bucketName), 0, nil)
+		} else {
+			req, err = newTestSignedRequestV4("DELETE", getDeleteBucketURL("", bucketName), 0, nil, credentials.AccessKey, credentials.SecretKey)
+		}
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		if testCase.forceDelete != "" {
+			req.Header.Set(minioForceDelete, testCase.forceDelete)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s", i+1, instanceType, testCase.expectedRespStatus, rec.Code, rec.Body.String())
+		}
+	}
+
+	if _, err := obj.GetBucketInfo(ctx, bucketName); err == nil {
+		t.Errorf("%s: Expected bucket to be deleted", instanceType)
+	}
+}
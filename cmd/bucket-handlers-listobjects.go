@@ -53,6 +53,10 @@ func validateListObjectsArgs(prefix, marker, delimiter string, maxKeys int) APIE
 //
 // NOTE: It is recommended that this API to be used for application development.
 // Minio continues to support ListObjectsV1 for supporting legacy tools.
+//
+// As a Minio extension, the sort=mtime query parameter lists the newest
+// objects first. Such listings support neither delimiter nor start-after
+// and are continued with the returned continuation token.
 func (api objectAPIHandlers) ListObjectsV2Handler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ListObjectsV2")
 
@@ -86,14 +90,29 @@ func (api objectAPIHandlers) ListObjectsV2Handler(w http.ResponseWriter, r *http
 		writeErrorResponse(w, s3Error, r.URL)
 		return
 	}
-	listObjectsV2 := objectAPI.ListObjectsV2
-	if api.CacheAPI() != nil {
-		listObjectsV2 = api.CacheAPI().ListObjectsV2
+
+	var listObjectsV2Info ListObjectsV2Info
+	var err error
+	if sortBy := urlValues.Get("sort"); sortBy != "" {
+		if sortBy != listObjectsSortModTime || delimiter != "" || startAfter != "" {
+			writeErrorResponse(w, ErrNotImplemented, r.URL)
+			return
+		}
+		listObjects := objectAPI.ListObjects
+		if api.CacheAPI() != nil {
+			listObjects = api.CacheAPI().ListObjects
+		}
+		listObjectsV2Info, err = listObjectsSortedByModTime(ctx, listObjects, bucket, prefix, token, maxKeys)
+	} else {
+		listObjectsV2 := objectAPI.ListObjectsV2
+		if api.CacheAPI() != nil {
+			listObjectsV2 = api.CacheAPI().ListObjectsV2
+		}
+		// Inititate a list objects operation based on the input params.
+		// On success would return back ListObjectsInfo object to be
+		// marshaled into S3 compatible XML header.
+		listObjectsV2Info, err = listObjectsV2(ctx, bucket, prefix, token, delimiter, maxKeys, fetchOwner, startAfter)
 	}
-	// Inititate a list objects operation based on the input params.
-	// On success would return back ListObjectsInfo object to be
-	// marshaled into S3 compatible XML header.
-	listObjectsV2Info, err := listObjectsV2(ctx, bucket, prefix, token, delimiter, maxKeys, fetchOwner, startAfter)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
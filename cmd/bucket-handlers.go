@@ -113,8 +113,8 @@ func (api objectAPIHandlers) GetBucketLocationHandler(w http.ResponseWriter, r *
 
 	// Generate response.
 	encodedSuccessResponse := encodeResponse(LocationResponse{})
-	// Get current region.
-	region := globalServerConfig.GetRegion()
+	// Get the region of the bucket.
+	region := getBucketRegion(bucket)
 	if region != globalMinioDefaultRegion {
 		encodedSuccessResponse = encodeResponse(LocationResponse{
 			Location: region,
@@ -155,6 +155,9 @@ func (api objectAPIHandlers) ListMultipartUploadsHandler(w http.ResponseWriter,
 		writeErrorResponse(w, ErrInvalidMaxUploads, r.URL)
 		return
 	}
+	if maxUploads > maxUploadsList {
+		maxUploads = maxUploadsList
+	}
 	if keyMarker != "" {
 		// Marker not common with prefix is not implemented.
 		if !hasPrefix(keyMarker, prefix) {
@@ -309,7 +312,12 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		deleteObject = api.CacheAPI().DeleteObject
 	}
 
+	// Objects of versioned buckets are deleted by adding a delete
+	// marker, versions are only removed when deleted by their version ID.
+	isVersioned := getBucketVersioning(bucket) != ""
+
 	var dErrs = make([]error, len(deleteObjects.Objects))
+	var dInfos = make([]ObjectInfo, len(deleteObjects.Objects))
 	for index, object := range deleteObjects.Objects {
 		// If the request is denied access, each item
 		// should be marked as 'AccessDenied'
@@ -320,30 +328,79 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 			}
 			continue
 		}
-		dErrs[index] = deleteObject(ctx, bucket, object.ObjectName)
+		// Bypassing governance retention requires its own permission.
+		objCtx := ctx
+		if isGovernanceBypassRequested(r.Header) {
+			if !isObjectActionAllowed(r, policy.BypassGovernanceRetentionAction, bucket, object.ObjectName) {
+				dErrs[index] = PrefixAccessDenied{
+					Bucket: bucket,
+					Object: object.ObjectName,
+				}
+				continue
+			}
+			objCtx = withGovernanceBypass(ctx)
+		}
+		if object.VersionID == "" && !isVersioned {
+			if dErrs[index] = deleteObject(objCtx, bucket, object.ObjectName); dErrs[index] == nil {
+				logger.LogIf(ctx, updateObjectACL(ctx, objectAPI, bucket, object.ObjectName, objectACLPrivate))
+			}
+			continue
+		}
+		if object.VersionID != "" {
+			if !isValidVersionID(object.VersionID) {
+				dErrs[index] = VersionNotFound{Bucket: bucket, Object: object.ObjectName, VersionID: object.VersionID}
+				continue
+			}
+			if !isObjectActionAllowed(r, policy.DeleteObjectVersionAction, bucket, object.ObjectName) {
+				dErrs[index] = PrefixAccessDenied{
+					Bucket: bucket,
+					Object: object.ObjectName,
+				}
+				continue
+			}
+		}
+		dInfos[index], dErrs[index] = deleteObjectVersion(objCtx, objectAPI, bucket, object.ObjectName, object.VersionID, r)
+		if _, ok := dErrs[index].(VersionNotFound); ok {
+			// Removing a missing version succeeds as per S3 spec.
+			dErrs[index] = nil
+		}
 	}
 
 	// Collect deleted objects and errors if any.
-	var deletedObjects []ObjectIdentifier
+	var deletedObjects []DeletedObject
 	var deleteErrors []DeleteError
+	var notifyObjects []string
 	for index, err := range dErrs {
 		object := deleteObjects.Objects[index]
+		deletedObject := DeletedObject{
+			ObjectName: object.ObjectName,
+			VersionID:  object.VersionID,
+		}
 		// Success deleted objects are collected separately.
 		if err == nil {
-			deletedObjects = append(deletedObjects, object)
+			if dInfos[index].DeleteMarker {
+				deletedObject.DeleteMarker = true
+				deletedObject.DeleteMarkerVersionID = dInfos[index].VersionID
+			}
+			deletedObjects = append(deletedObjects, deletedObject)
+			if object.VersionID == "" && !isVersioned {
+				notifyObjects = append(notifyObjects, object.ObjectName)
+			}
 			continue
 		}
 		if _, ok := err.(ObjectNotFound); ok {
 			// If the object is not found it should be
 			// accounted as deleted as per S3 spec.
-			deletedObjects = append(deletedObjects, object)
+			deletedObjects = append(deletedObjects, deletedObject)
+			notifyObjects = append(notifyObjects, object.ObjectName)
 			continue
 		}
 		// Error during delete should be collected separately.
 		deleteErrors = append(deleteErrors, DeleteError{
-			Code:    errorCodeResponse[toAPIErrorCode(err)].Code,
-			Message: errorCodeResponse[toAPIErrorCode(err)].Description,
-			Key:     object.ObjectName,
+			Code:      errorCodeResponse[toAPIErrorCode(err)].Code,
+			Message:   errorCodeResponse[toAPIErrorCode(err)].Description,
+			Key:       object.ObjectName,
+			VersionID: object.VersionID,
 		})
 	}
 
@@ -361,13 +418,14 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		host, port = "", ""
 	}
 
-	// Notify deleted event for objects.
-	for _, dobj := range deletedObjects {
+	// Notify deleted event for objects, versions and delete markers are
+	// notified as they are deleted.
+	for _, objectName := range notifyObjects {
 		sendEvent(eventArgs{
 			EventName:  event.ObjectRemovedDelete,
 			BucketName: bucket,
 			Object: ObjectInfo{
-				Name: dobj.ObjectName,
+				Name: objectName,
 			},
 			ReqParams: extractReqParams(r),
 			UserAgent: r.UserAgent(),
@@ -411,6 +469,14 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Object lock can only be enabled when creating a bucket and
+	// requires versioning.
+	lockEnabled := r.Header.Get(amzBucketObjectLockEnabled) == "true"
+	if lockEnabled && !objectAPI.IsVersioningSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
 	if globalDNSConfig != nil {
 		if _, err := globalDNSConfig.Get(bucket); err != nil {
 			if err == dns.ErrNoEntriesFound {
@@ -424,6 +490,16 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 					writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 					return
 				}
+				if err = saveBucketLocation(ctx, objectAPI, bucket, location); err != nil {
+					writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+					return
+				}
+				if lockEnabled {
+					if err = enableBucketObjectLock(ctx, objectAPI, bucket); err != nil {
+						writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+						return
+					}
+				}
 
 				// Make sure to add Location information here only for bucket
 				w.Header().Set("Location", getObjectLocation(r, globalDomainName, bucket, ""))
@@ -446,6 +522,18 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err = saveBucketLocation(ctx, objectAPI, bucket, location); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if lockEnabled {
+		if err = enableBucketObjectLock(ctx, objectAPI, bucket); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+	}
+
 	// Make sure to add Location information here only for bucket
 	w.Header().Set("Location", path.Clean(r.URL.Path)) // Clean any trailing slashes.
 
@@ -531,6 +619,10 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	object := formValues.Get("Key")
 
 	successRedirect := formValues.Get("success_action_redirect")
+	if successRedirect == "" {
+		// redirect is the deprecated name of success_action_redirect.
+		successRedirect = formValues.Get("redirect")
+	}
 	successStatus := formValues.Get("success_action_status")
 	var redirectURL *url.URL
 	if successRedirect != "" {
@@ -548,6 +640,48 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	// Temporary credentials are only valid along with their session token.
+	accessKey := formValues.Get("AWSAccessKeyId")
+	if credential := formValues.Get("X-Amz-Credential"); credential != "" {
+		accessKey = strings.SplitN(credential, "/", 2)[0]
+	}
+	if apiErr = checkSessionToken(accessKey, formValues.Get(amzSecurityToken)); apiErr != ErrNone {
+		writeErrorResponse(w, apiErr, r.URL)
+		return
+	}
+
+	// Uploads signed by a service account or temporary credentials are
+	// further restricted by their policies, if any.
+	if !globalIAMSys.IsAllowed(accessKey, policy.Args{
+		Action:          policy.PutObjectAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, ""),
+		IsOwner:         true,
+		ObjectName:      object,
+	}) {
+		writeErrorResponse(w, ErrAccessDenied, r.URL)
+		return
+	}
+
+	// Making the object readable by anonymous users requires the
+	// permission to set the bucket policy, like PutObjectACL.
+	acl := getUploadCannedACL(formValues.Get("Acl"))
+	if acl == objectACLPublicRead {
+		if strings.ContainsAny(object, "*?") {
+			writeErrorResponse(w, ErrNotImplemented, r.URL)
+			return
+		}
+		if !globalIAMSys.IsAllowed(accessKey, policy.Args{
+			Action:          policy.PutBucketPolicyAction,
+			BucketName:      bucket,
+			ConditionValues: getConditionValues(r, ""),
+			IsOwner:         true,
+		}) {
+			writeErrorResponse(w, ErrAccessDenied, r.URL)
+			return
+		}
+	}
+
 	policyBytes, err := base64.StdEncoding.DecodeString(formValues.Get("Policy"))
 	if err != nil {
 		writeErrorResponse(w, ErrMalformedPOSTRequest, r.URL)
@@ -575,11 +709,21 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 			return
 		}
 
-		if fileSize > lengthRange.Max || isMaxObjectSize(fileSize) {
+		if fileSize > lengthRange.Max {
 			writeErrorResponse(w, toAPIErrorCode(errDataTooLarge), r.URL)
 			return
 		}
 	}
+	if isMaxObjectSize(fileSize) {
+		writeErrorResponse(w, toAPIErrorCode(errDataTooLarge), r.URL)
+		return
+	}
+
+	// Validate website redirect location metadata if present
+	if apiErr = checkWebsiteRedirectLocation(formValues); apiErr != ErrNone {
+		writeErrorResponse(w, apiErr, r.URL)
+		return
+	}
 
 	// Extract metadata to be saved from received Form.
 	metadata := make(map[string]string)
@@ -588,6 +732,13 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		writeErrorResponse(w, ErrInternalError, r.URL)
 		return
 	}
+	setBucketDefaultTags(objectAPI, bucket, metadata)
+	setObjectVersionID(bucket, metadata)
+	if s3Error := setObjectLockMetadata(bucket, formValues, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	setObjectReplicationMetadata(objectAPI, r, formValues, bucket, object, metadata)
 
 	hashReader, err := hash.NewReader(fileBody, fileSize, "", "")
 	if err != nil {
@@ -616,18 +767,43 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 				return
 			}
+		} else if encryption, ok := getBucketEncryption(bucket); ok && !hasSuffix(object, slashSeparator) { // handle default bucket encryption
+			var reader io.Reader
+			reader, err = newBucketEncryptReader(hashReader, encryption, bucket, object, metadata)
+			if err != nil {
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
+			info := ObjectInfo{Size: fileSize}
+			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "") // do not try to verify encrypted content
+			if err != nil {
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
 		}
 	}
 
+	// Check the ACL fits in the bucket policy before writing the object.
+	aclUpdate, err := newObjectACLUpdate(ctx, objectAPI, bucket, object, acl)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	defer aclUpdate.Unlock()
+
 	objInfo, err := objectAPI.PutObject(ctx, bucket, object, hashReader, metadata)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
 
+	logger.LogIf(ctx, aclUpdate.Apply(ctx, objectAPI))
+	aclUpdate.Unlock()
+
 	location := getObjectLocation(r, globalDomainName, bucket, object)
 	w.Header().Set("ETag", `"`+objInfo.ETag+`"`)
 	w.Header().Set("Location", location)
+	setObjectVersionHeaders(w, objInfo)
 
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
@@ -635,6 +811,8 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		host, port = "", ""
 	}
 
+	queueReplication(objInfo)
+
 	// Notify object created event.
 	defer sendEvent(eventArgs{
 		EventName:  event.ObjectCreatedPost,
@@ -647,8 +825,8 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	})
 
 	if successRedirect != "" {
-		// Replace raw query params..
-		redirectURL.RawQuery = getRedirectPostRawQuery(objInfo)
+		// Add the object to the raw query params of the redirect.
+		redirectURL.RawQuery = getRedirectPostRawQuery(redirectURL.Query(), objInfo)
 		writeRedirectSeeOther(w, redirectURL.String())
 		return
 	}
@@ -676,6 +854,8 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 // The operation returns a 200 OK if the bucket exists and you
 // have permission to access it. Otherwise, the operation might
 // return responses such as 404 Not Found and 403 Forbidden.
+// The region of the bucket and Minio extension headers summarizing
+// the bucket are returned with the response.
 func (api objectAPIHandlers) HeadBucketHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "HeadBucket")
 
@@ -697,15 +877,24 @@ func (api objectAPIHandlers) HeadBucketHandler(w http.ResponseWriter, r *http.Re
 	if api.CacheAPI() != nil {
 		getBucketInfo = api.CacheAPI().GetBucketInfo
 	}
-	if _, err := getBucketInfo(ctx, bucket); err != nil {
+	bucketInfo, err := getBucketInfo(ctx, bucket)
+	if err != nil {
 		writeErrorResponseHeadersOnly(w, toAPIErrorCode(err))
 		return
 	}
 
+	if region := getBucketRegion(bucket); region != "" {
+		w.Header().Set("X-Amz-Bucket-Region", region)
+	}
+	setBucketSummaryHeaders(ctx, w, objectAPI, bucketInfo)
 	writeSuccessResponseHeadersOnly(w)
 }
 
 // DeleteBucketHandler - Delete bucket
+// ----------
+// With the X-Minio-Force-Delete header, a Minio extension, all objects,
+// object versions and incomplete multipart uploads of the bucket are
+// removed first. This requires the s3:ForceDeleteBucket permission.
 func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "DeleteBucket")
 
@@ -723,6 +912,25 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Removing the contents of the bucket requires its own permission.
+	if isForceDeleteRequested(r.Header) {
+		if !isObjectActionAllowed(r, policy.ForceDeleteBucketAction, bucket, "") {
+			writeErrorResponse(w, ErrAccessDenied, r.URL)
+			return
+		}
+
+		// Deny if WORM is enabled
+		if globalWORMEnabled {
+			writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
+			return
+		}
+
+		if err := deleteBucketContents(ctx, objectAPI, api.CacheAPI(), bucket, r); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+	}
+
 	deleteBucket := objectAPI.DeleteBucket
 	if api.CacheAPI() != nil {
 		deleteBucket = api.CacheAPI().DeleteBucket
@@ -735,6 +943,11 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 
 	globalNotificationSys.RemoveNotification(bucket)
 	globalPolicySys.Remove(bucket)
+	globalBucketQuotaSys.Remove(bucket)
+	globalBucketTargetSys.Remove(bucket)
+	globalBucketMetadataSys.Remove(bucket)
+	globalBucketBandwidthStats.deleteBucket(bucket)
+	globalBucketObjectCounts.deleteBucket(bucket)
 	globalNotificationSys.DeleteBucket(ctx, bucket)
 
 	if globalDNSConfig != nil {
@@ -173,6 +173,69 @@ func testGetBucketLocationHandler(obj ObjectLayer, instanceType, bucketName stri
 	ExecObjectLayerAPINilTest(t, nilBucket, "", instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling bucket region tests for both XL multiple disks and single node setup.
+func TestBucketRegionHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketRegionHandlers, []string{"PutBucket", "GetBucketLocation", "HeadBucket"})
+}
+
+func testBucketRegionHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+	serverRegion := globalServerConfig.GetRegion()
+	defer globalServerConfig.SetRegion(serverRegion)
+
+	// serve - signs the request with the credential scope of region.
+	serve := func(method, urlStr, region string, body []byte) *httptest.ResponseRecorder {
+		globalServerConfig.SetRegion(region)
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(body)), bytes.NewReader(body), credentials.AccessKey, credentials.SecretKey)
+		globalServerConfig.SetRegion(serverRegion)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	regionBucket := "region-bucket"
+	locationConfig := []byte(`<CreateBucketConfiguration><LocationConstraint>eu-west-1</LocationConstraint></CreateBucketConfiguration>`)
+	if rec := serve("PUT", getMakeBucketURL("", regionBucket), serverRegion, locationConfig); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the bucket to be created, but instead found `%d` %s", instanceType, rec.Code, rec.Body.String())
+	}
+
+	// The location is returned whatever the region of the request.
+	rec := serve("GET", getBucketLocationURL("", regionBucket), serverRegion, nil)
+	expected := `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">eu-west-1</LocationConstraint>`
+	if rec.Code != http.StatusOK || !bytes.HasSuffix(rec.Body.Bytes(), []byte(expected)) {
+		t.Errorf("%s: Expected the location `eu-west-1`, but instead found `%d` %s", instanceType, rec.Code, rec.Body.String())
+	}
+
+	// Other requests must be signed for the region of the bucket.
+	if rec = serve("HEAD", getHEADBucketURL("", regionBucket), serverRegion, nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	rec = serve("HEAD", getHEADBucketURL("", regionBucket), "eu-west-1", nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if region := rec.Header().Get("X-Amz-Bucket-Region"); region != "eu-west-1" {
+		t.Errorf("%s: Expected the bucket region `eu-west-1`, but instead found `%s`", instanceType, region)
+	}
+
+	// Buckets of the server region are unchanged.
+	if rec = serve("HEAD", getHEADBucketURL("", bucketName), serverRegion, nil); rec.Code != http.StatusOK {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	invalidConfig := []byte(`<CreateBucketConfiguration><LocationConstraint>EU West</LocationConstraint></CreateBucketConfiguration>`)
+	if rec = serve("PUT", getMakeBucketURL("", "invalid-region-bucket"), serverRegion, invalidConfig); rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+}
+
 // Wrapper for calling HeadBucket HTTP handler tests for both XL multiple disks and single node setup.
 func TestHeadBucketHandler(t *testing.T) {
 	ExecObjectLayerAPITest(t, testHeadBucketHandler, []string{"HeadBucket"})
@@ -637,11 +700,18 @@ func testAPIDeleteMultipleObjectsHandler(obj ObjectLayer, instanceType, bucketNa
 
 	getObjectIdentifierList := func(objectNames []string) (objectIdentifierList []ObjectIdentifier) {
 		for _, objectName := range objectNames {
-			objectIdentifierList = append(objectIdentifierList, ObjectIdentifier{objectName})
+			objectIdentifierList = append(objectIdentifierList, ObjectIdentifier{ObjectName: objectName})
 		}
 
 		return objectIdentifierList
 	}
+	getDeletedObjectList := func(objects []ObjectIdentifier) (deletedObjectList []DeletedObject) {
+		for _, obj := range objects {
+			deletedObjectList = append(deletedObjectList, DeletedObject{ObjectName: obj.ObjectName})
+		}
+
+		return deletedObjectList
+	}
 	getDeleteErrorList := func(objects []ObjectIdentifier) (deleteErrorList []DeleteError) {
 		for _, obj := range objects {
 			deleteErrorList = append(deleteErrorList, DeleteError{
@@ -661,16 +731,16 @@ func testAPIDeleteMultipleObjectsHandler(obj ObjectLayer, instanceType, bucketNa
 
 	// generate multi objects delete response.
 	successRequest0 := encodeResponse(requestList[0])
-	successResponse0 := generateMultiDeleteResponse(requestList[0].Quiet, requestList[0].Objects, nil)
+	successResponse0 := generateMultiDeleteResponse(requestList[0].Quiet, getDeletedObjectList(requestList[0].Objects), nil)
 	encodedSuccessResponse0 := encodeResponse(successResponse0)
 
 	successRequest1 := encodeResponse(requestList[1])
-	successResponse1 := generateMultiDeleteResponse(requestList[1].Quiet, requestList[1].Objects, nil)
+	successResponse1 := generateMultiDeleteResponse(requestList[1].Quiet, getDeletedObjectList(requestList[1].Objects), nil)
 	encodedSuccessResponse1 := encodeResponse(successResponse1)
 
 	// generate multi objects delete response for errors.
 	// errorRequest := encodeResponse(requestList[1])
-	errorResponse := generateMultiDeleteResponse(requestList[1].Quiet, requestList[1].Objects, nil)
+	errorResponse := generateMultiDeleteResponse(requestList[1].Quiet, getDeletedObjectList(requestList[1].Objects), nil)
 	encodedErrorResponse := encodeResponse(errorResponse)
 
 	anonRequest := encodeResponse(requestList[0])
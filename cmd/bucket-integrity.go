@@ -0,0 +1,91 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	// Integrity configuration file.
+	bucketIntegrityConfig = "integrity.json"
+
+	// Maximum size of an integrity configuration.
+	maxBucketIntegrityConfigSize = 1024
+)
+
+// BucketIntegrity - data integrity requirements of the uploads to a bucket.
+type BucketIntegrity struct {
+	// Uploads must be verified with a Content-MD5 or a signed SHA256
+	// sum of their data.
+	Strict bool `json:"strict"`
+}
+
+// getBucketIntegrityConfig - returns the integrity configuration of
+// given bucket name, false if the bucket has none.
+func getBucketIntegrityConfig(bucketName string) (BucketIntegrity, bool) {
+	var integrity BucketIntegrity
+	if globalBucketMetadataSys == nil {
+		return integrity, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketIntegrityConfig)
+	if !ok {
+		return integrity, false
+	}
+	if err := json.Unmarshal(data, &integrity); err != nil {
+		return BucketIntegrity{}, false
+	}
+	return integrity, true
+}
+
+// isStrictIntegrity - returns true if uploads to given bucket name must
+// be verified, either for all buckets of the server or for this bucket.
+func isStrictIntegrity(bucketName string) bool {
+	if globalStrictIntegrity {
+		return true
+	}
+	integrity, _ := getBucketIntegrityConfig(bucketName)
+	return integrity.Strict
+}
+
+// checkRequestIntegrity - returns an error if the data of an upload to
+// a bucket in strict integrity mode is verified neither with the
+// Content-MD5 of the request, nor with its signed SHA256 sum or the
+// signatures of its chunks.
+func checkRequestIntegrity(bucketName string, rAuthType authType, md5hex, sha256hex string) APIErrorCode {
+	if md5hex != "" || sha256hex != "" || rAuthType == authTypeStreamingSigned {
+		return ErrNone
+	}
+	if isStrictIntegrity(bucketName) {
+		return ErrMissingContentMD5
+	}
+	return ErrNone
+}
+
+// saveBucketIntegrity - saves the integrity configuration of given
+// bucket name, a configuration which is not strict removes it.
+func saveBucketIntegrity(ctx context.Context, objAPI ObjectLayer, bucketName string, integrity BucketIntegrity) error {
+	if !integrity.Strict {
+		return saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketIntegrityConfig, nil)
+	}
+	data, err := json.Marshal(integrity)
+	if err != nil {
+		return err
+	}
+	return saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketIntegrityConfig, data)
+}
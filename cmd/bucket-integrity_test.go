@@ -0,0 +1,99 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling strict integrity tests for both XL multiple disks and single node setup.
+func TestStrictIntegrityHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testStrictIntegrityHandlers, []string{"PutObjectPart", "PutObject"})
+}
+
+func testStrictIntegrityHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+	defer func(strictIntegrity bool) { globalStrictIntegrity = strictIntegrity }(globalStrictIntegrity)
+
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, "multipart", nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create multipart upload: <ERROR> %v", instanceType, err)
+	}
+
+	data := []byte("hello, world")
+	testCases := []struct {
+		urlStr             string
+		strictBucket       bool
+		strictServer       bool
+		contentMD5         string
+		signedPayload      bool
+		expectedRespStatus int
+	}{
+		// 1. Uploads without integrity information are accepted by default.
+		{getPutObjectURL("", bucketName, "object"), false, false, "", false, http.StatusOK},
+		// 2. Rejected in strict integrity mode of the bucket.
+		{getPutObjectURL("", bucketName, "object"), true, false, "", false, http.StatusBadRequest},
+		// 3. Rejected in strict integrity mode of the server.
+		{getPutObjectURL("", bucketName, "object"), false, true, "", false, http.StatusBadRequest},
+		// 4-5. Content-MD5 is verified.
+		{getPutObjectURL("", bucketName, "object"), true, false, getMD5HashBase64(data), false, http.StatusOK},
+		{getPutObjectURL("", bucketName, "object"), true, false, getMD5HashBase64([]byte("hello")), false, http.StatusBadRequest},
+		// 6. Signed SHA256 sum is verified.
+		{getPutObjectURL("", bucketName, "object"), true, false, "", true, http.StatusOK},
+		// 7-8. Parts of multipart uploads.
+		{getPutObjectPartURL("", bucketName, "multipart", uploadID, "1"), true, false, "", false, http.StatusBadRequest},
+		{getPutObjectPartURL("", bucketName, "multipart", uploadID, "1"), true, false, getMD5HashBase64(data), false, http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		globalBucketMetadataSys.Set(bucketName, bucketIntegrityConfig, nil)
+		if testCase.strictBucket {
+			globalBucketMetadataSys.Set(bucketName, bucketIntegrityConfig, []byte(`{"strict":true}`))
+		}
+		globalStrictIntegrity = testCase.strictServer
+
+		req, err := newTestRequest("PUT", testCase.urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		req.Header.Del("Content-Md5")
+		if testCase.contentMD5 != "" {
+			req.Header.Set("Content-Md5", testCase.contentMD5)
+		}
+		if !testCase.signedPayload {
+			req.Header.Set("x-amz-content-sha256", unsignedPayload)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("Test %d: %s: Failed to sign HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s", i+1, instanceType, testCase.expectedRespStatus, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,264 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/inventory"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// PutBucketInventoryConfigurationHandler - This HTTP handler adds or
+// replaces an inventory configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTInventoryConfig.html
+// Reports are written to a bucket of this server, ORC reports and
+// encrypted reports are not supported.
+func (api objectAPIHandlers) PutBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketInventoryConfiguration")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsInventorySupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketInventoryAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// PutBucketInventoryConfiguration always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+
+	if r.ContentLength > maxInventoryConfigSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	config, err := inventory.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	if config.ID != r.URL.Query().Get("id") {
+		writeErrorResponse(w, ErrInvalidInventoryID, r.URL)
+		return
+	}
+
+	destination := config.Destination.S3BucketDestination
+	if destination.Format == inventory.ORC || destination.Encryption != nil {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	if _, err = objectAPI.GetBucketInfo(ctx, destination.BucketName()); err != nil {
+		writeErrorResponse(w, ErrInvalidInventoryDestination, r.URL)
+		return
+	}
+
+	configs := getBucketInventoryConfigs(bucket)
+	replaced := false
+	for i := range configs {
+		if configs[i].ID == config.ID {
+			configs[i] = *config
+			replaced = true
+		}
+	}
+	if !replaced {
+		if len(configs) >= maxInventoryConfigs {
+			writeErrorResponse(w, ErrTooManyInventoryConfigurations, r.URL)
+			return
+		}
+		configs = append(configs, *config)
+	}
+
+	if err = saveBucketInventoryConfigs(ctx, objectAPI, bucket, configs); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketInventoryConfigurationHandler - This HTTP handler returns an
+// inventory configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketGETInventoryConfig.html
+func (api objectAPIHandlers) GetBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketInventoryConfiguration")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsInventorySupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketInventoryAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeErrorResponse(w, ErrInvalidInventoryID, r.URL)
+		return
+	}
+
+	for _, config := range getBucketInventoryConfigs(bucket) {
+		if config.ID == id {
+			config.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+			writeSuccessResponseXML(w, encodeResponse(config))
+			return
+		}
+	}
+	writeErrorResponse(w, ErrNoSuchInventoryConfiguration, r.URL)
+}
+
+// ListBucketInventoryConfigurationsHandler - This HTTP handler returns the
+// inventory configurations of a bucket ordered by ID, 100 at a time, as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketListInventoryConfigs.html
+// The continuation token is the ID of the last configuration returned.
+func (api objectAPIHandlers) ListBucketInventoryConfigurationsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketInventoryConfigurations")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsInventorySupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketInventoryAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	result := inventory.ListResult{
+		XMLNS:             "http://s3.amazonaws.com/doc/2006-03-01/",
+		ContinuationToken: r.URL.Query().Get("continuation-token"),
+	}
+	for _, config := range getBucketInventoryConfigs(bucket) {
+		if config.ID <= result.ContinuationToken {
+			continue
+		}
+		if len(result.Configs) == maxInventoryConfigList {
+			result.IsTruncated = true
+			result.NextContinuationToken = result.Configs[len(result.Configs)-1].ID
+			break
+		}
+		result.Configs = append(result.Configs, config)
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(result))
+}
+
+// DeleteBucketInventoryConfigurationHandler - This HTTP handler removes an
+// inventory configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketDELETEInventoryConfiguration.html
+// Reports already written are left in the destination bucket.
+func (api objectAPIHandlers) DeleteBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketInventoryConfiguration")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsInventorySupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketInventoryAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeErrorResponse(w, ErrInvalidInventoryID, r.URL)
+		return
+	}
+
+	configs := getBucketInventoryConfigs(bucket)
+	for i, config := range configs {
+		if config.ID != id {
+			continue
+		}
+		configs = append(configs[:i], configs[i+1:]...)
+		if err := saveBucketInventoryConfigs(ctx, objectAPI, bucket, configs); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+		writeSuccessNoContent(w)
+		return
+	}
+	writeErrorResponse(w, ErrNoSuchInventoryConfiguration, r.URL)
+}
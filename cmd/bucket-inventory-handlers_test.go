@@ -0,0 +1,145 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/inventory"
+)
+
+func testInventoryConfig(id, destination, format string) string {
+	return `<InventoryConfiguration><Id>` + id + `</Id><IsEnabled>true</IsEnabled>` +
+		`<Destination><S3BucketDestination><Bucket>arn:aws:s3:::` + destination + `</Bucket><Format>` + format + `</Format></S3BucketDestination></Destination>` +
+		`<IncludedObjectVersions>Current</IncludedObjectVersions><Schedule><Frequency>Daily</Frequency></Schedule></InventoryConfiguration>`
+}
+
+// Wrapper for calling bucket inventory handler tests for both XL multiple disks and single node setup.
+func TestBucketInventoryHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketInventoryHandlers, []string{"PutBucketInventoryConfiguration", "GetBucketInventoryConfiguration",
+		"ListBucketInventoryConfigurations", "DeleteBucketInventoryConfiguration"})
+}
+
+func testBucketInventoryHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	if err := obj.MakeBucketWithLocation(context.Background(), "reports", ""); err != nil {
+		t.Fatalf("%s: Failed to create the destination bucket: <ERROR> %v", instanceType, err)
+	}
+
+	serve := func(method, urlStr string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	testCases := []struct {
+		bucketName   string
+		id           string
+		data         string
+		expectedCode int
+	}{
+		{bucketName, "report", `<InventoryConfiguration>`, http.StatusBadRequest},
+		{bucketName, "report", testInventoryConfig("report", "reports", "JSON"), http.StatusBadRequest},
+		{bucketName, "other", testInventoryConfig("report", "reports", "CSV"), http.StatusBadRequest},
+		{bucketName, "report", testInventoryConfig("report", "missing-bucket", "CSV"), http.StatusBadRequest},
+		{bucketName, "report", testInventoryConfig("report", "reports", "ORC"), http.StatusNotImplemented},
+		{"missing-bucket", "report", testInventoryConfig("report", "reports", "CSV"), http.StatusNotFound},
+		{bucketName, "report", testInventoryConfig("report", "reports", "CSV"), http.StatusOK},
+		{bucketName, "report", testInventoryConfig("report", "reports", "Parquet"), http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getBucketInventoryURL("", testCase.bucketName, testCase.id), []byte(testCase.data))
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+
+	// The configuration was replaced by the last request.
+	rec := serve("GET", getBucketInventoryURL("", bucketName, "report"), nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var config inventory.Config
+	if err := xml.Unmarshal(rec.Body.Bytes(), &config); err != nil {
+		t.Fatalf("%s: Failed to parse inventory configuration: <ERROR> %v", instanceType, err)
+	}
+	if config.ID != "report" || config.Destination.S3BucketDestination.Format != inventory.Parquet {
+		t.Fatalf("%s: Unexpected inventory configuration %v", instanceType, config)
+	}
+	if rec = serve("GET", getBucketInventoryURL("", bucketName, "missing"), nil); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	// Configurations are listed by ID, 100 at a time.
+	for i := 0; i < maxInventoryConfigList; i++ {
+		id := fmt.Sprintf("report-%03d", i)
+		if rec = serve("PUT", getBucketInventoryURL("", bucketName, id), []byte(testInventoryConfig(id, "reports", "CSV"))); rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+	}
+	listCases := []struct {
+		continuationToken string
+		expectedFirstID   string
+		expectedCount     int
+		expectedNextToken string
+	}{
+		{"", "report", maxInventoryConfigList, "report-098"},
+		{"report-098", "report-099", 1, ""},
+	}
+	for i, testCase := range listCases {
+		rec = serve("GET", getListBucketInventoryURL("", bucketName, testCase.continuationToken), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, http.StatusOK, rec.Code)
+		}
+		var result inventory.ListResult
+		if err := xml.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+			t.Fatalf("%s: Test %d: Failed to parse inventory configurations: <ERROR> %v", instanceType, i+1, err)
+		}
+		if len(result.Configs) != testCase.expectedCount || result.Configs[0].ID != testCase.expectedFirstID ||
+			result.NextContinuationToken != testCase.expectedNextToken || result.IsTruncated != (testCase.expectedNextToken != "") {
+			t.Errorf("%s: Test %d: Unexpected inventory configurations %v", instanceType, i+1, result)
+		}
+	}
+
+	if rec = serve("DELETE", getBucketInventoryURL("", bucketName, "report"), nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec = serve("DELETE", getBucketInventoryURL("", bucketName, "report"), nil); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec = serve("GET", getBucketInventoryURL("", bucketName, "report"), nil); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if configs := getBucketInventoryConfigs(bucketName); len(configs) != maxInventoryConfigList {
+		t.Errorf("%s: Expected %d inventory configurations, found %d", instanceType, maxInventoryConfigList, len(configs))
+	}
+}
@@ -0,0 +1,564 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"hash/crc32"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/inventory"
+	"github.com/minio/minio/pkg/s3select/parquet"
+)
+
+const (
+	// Bucket inventory configurations file.
+	bucketInventoryConfig = "inventory.xml"
+
+	// Maximum size of an inventory configuration in a put-bucket-inventory request.
+	maxInventoryConfigSize = 64 * 1024
+
+	// Maximum number of inventory configurations of a bucket.
+	maxInventoryConfigs = 1000
+
+	// Maximum number of inventory configurations returned per list
+	// bucket inventory request.
+	maxInventoryConfigList = 100
+
+	// Data files of inventory reports are written in memory, a new
+	// file is started once this size is reached.
+	maxInventoryFileSize = 16 * 1024 * 1024
+
+	// Version of the manifests of inventory reports.
+	inventoryManifestVersion = "2016-11-30"
+)
+
+// Interval between two checks for inventory reports due.
+var globalInventoryInterval = 1 * time.Hour
+
+var errInventoryStopped = errors.New("inventory report stopped")
+
+// bucketInventoryConfigs - inventory configurations of a bucket, kept
+// sorted by ID in a single config file.
+type bucketInventoryConfigs struct {
+	XMLName xml.Name           `xml:"InventoryConfigurations"`
+	Configs []inventory.Config `xml:"InventoryConfiguration"`
+}
+
+// getBucketInventoryConfigs - returns the inventory configurations of
+// given bucket name sorted by ID.
+func getBucketInventoryConfigs(bucketName string) []inventory.Config {
+	if globalBucketMetadataSys == nil {
+		return nil
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketInventoryConfig)
+	if !ok {
+		return nil
+	}
+	var configs bucketInventoryConfigs
+	if err := xml.Unmarshal(data, &configs); err != nil {
+		return nil
+	}
+	return configs.Configs
+}
+
+// saveBucketInventoryConfigs - replaces the inventory configurations of
+// given bucket name, the config file is removed if there are none.
+func saveBucketInventoryConfigs(ctx context.Context, objAPI ObjectLayer, bucketName string, configs []inventory.Config) error {
+	if len(configs) == 0 {
+		return saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketInventoryConfig, nil)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].ID < configs[j].ID })
+	data, err := xml.Marshal(bucketInventoryConfigs{Configs: configs})
+	if err != nil {
+		return err
+	}
+	return saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketInventoryConfig, data)
+}
+
+// inventoryColumn - column of the data files of inventory reports.
+type inventoryColumn struct {
+	name        string
+	parquetName string
+	typ         int64
+	value       func(objInfo ObjectInfo) interface{}
+}
+
+// inventoryString - returns a string value, nil if it is empty.
+func inventoryString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// inventoryEncryptionStatus - returns the server side encryption of an
+// object as written in inventory reports.
+func inventoryEncryptionStatus(metadata map[string]string) string {
+	switch {
+	case crypto.SSEC.IsEncrypted(metadata):
+		return "SSE-C"
+	case crypto.S3.IsEncrypted(metadata):
+		return "SSE-S3"
+	}
+	return "NOT-SSE"
+}
+
+var inventoryFieldColumns = map[inventory.Field]inventoryColumn{
+	inventory.Size: {"Size", "size", parquet.Int64Column, func(objInfo ObjectInfo) interface{} {
+		if objInfo.DeleteMarker {
+			return nil
+		}
+		return objInfo.Size
+	}},
+	inventory.LastModifiedDate: {"LastModifiedDate", "last_modified_date", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+		return objInfo.ModTime.UTC().Format(timeFormatAMZLong)
+	}},
+	inventory.ETag: {"ETag", "e_tag", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+		return inventoryString(objInfo.ETag)
+	}},
+	inventory.StorageClass: {"StorageClass", "storage_class", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+		if objInfo.DeleteMarker {
+			return nil
+		}
+		if objInfo.StorageClass == "" {
+			return globalMinioDefaultStorageClass
+		}
+		return objInfo.StorageClass
+	}},
+	inventory.IsMultipartUploaded: {"IsMultipartUploaded", "is_multipart_uploaded", parquet.BooleanColumn, func(objInfo ObjectInfo) interface{} {
+		return strings.Contains(objInfo.ETag, "-")
+	}},
+	inventory.ReplicationStatus: {"ReplicationStatus", "replication_status", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+		return inventoryString(string(getObjectReplicationStatus(objInfo.UserDefined)))
+	}},
+	inventory.EncryptionStatus: {"EncryptionStatus", "encryption_status", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+		return inventoryEncryptionStatus(objInfo.UserDefined)
+	}},
+	inventory.ObjectLockRetainUntilDate: {"ObjectLockRetainUntilDate", "object_lock_retain_until_date", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+		if retention, ok := getObjectRetention(objInfo.UserDefined); ok {
+			return retention.RetainUntilDate.UTC().Format(timeFormatAMZLong)
+		}
+		return nil
+	}},
+	inventory.ObjectLockMode: {"ObjectLockMode", "object_lock_mode", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+		if retention, ok := getObjectRetention(objInfo.UserDefined); ok {
+			return string(retention.Mode)
+		}
+		return nil
+	}},
+	inventory.ObjectLockLegalHoldStatus: {"ObjectLockLegalHoldStatus", "object_lock_legal_hold_status", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+		if legalHold, ok := getObjectLegalHold(objInfo.UserDefined); ok {
+			return string(legalHold.Status)
+		}
+		return nil
+	}},
+}
+
+// getInventoryColumns - returns the columns of the reports of an
+// inventory configuration. Versions are only listed with all versions.
+func getInventoryColumns(config inventory.Config) []inventoryColumn {
+	columns := []inventoryColumn{
+		{"Bucket", "bucket", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+			return objInfo.Bucket
+		}},
+		{"Key", "key", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+			return objInfo.Name
+		}},
+	}
+	if config.IncludedObjectVersions == inventory.AllVersions {
+		columns = append(columns,
+			inventoryColumn{"VersionId", "version_id", parquet.StringColumn, func(objInfo ObjectInfo) interface{} {
+				return inventoryString(objInfo.VersionID)
+			}},
+			inventoryColumn{"IsLatest", "is_latest", parquet.BooleanColumn, func(objInfo ObjectInfo) interface{} {
+				return objInfo.IsLatest
+			}},
+			inventoryColumn{"IsDeleteMarker", "is_delete_marker", parquet.BooleanColumn, func(objInfo ObjectInfo) interface{} {
+				return objInfo.DeleteMarker
+			}})
+	}
+	for _, field := range config.Fields() {
+		columns = append(columns, inventoryFieldColumns[field])
+	}
+	return columns
+}
+
+// inventoryRowWriter - writes the rows of a data file of an inventory report.
+type inventoryRowWriter interface {
+	Write(row []interface{}) error
+	Close() error
+}
+
+// csvInventoryWriter - writes gzip compressed CSV data files, keys are
+// URL encoded and null values are empty.
+type csvInventoryWriter struct {
+	gzipWriter *gzip.Writer
+	csvWriter  *csv.Writer
+}
+
+func (w csvInventoryWriter) Write(row []interface{}) error {
+	record := make([]string, len(row))
+	for i, v := range row {
+		switch v := v.(type) {
+		case string:
+			record[i] = v
+			if i == 1 {
+				record[i] = s3utils.EncodePath(v)
+			}
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		case bool:
+			record[i] = strconv.FormatBool(v)
+		}
+	}
+	return w.csvWriter.Write(record)
+}
+
+func (w csvInventoryWriter) Close() error {
+	w.csvWriter.Flush()
+	if err := w.csvWriter.Error(); err != nil {
+		return err
+	}
+	return w.gzipWriter.Close()
+}
+
+// newInventoryRowWriter - returns the writer of a data file of the
+// given format, and the extension of its name.
+func newInventoryRowWriter(buf *bytes.Buffer, format inventory.Format, columns []inventoryColumn) (inventoryRowWriter, string) {
+	if format == inventory.Parquet {
+		parquetColumns := make([]parquet.Column, len(columns))
+		for i, col := range columns {
+			// Only the bucket and key columns are never null.
+			parquetColumns[i] = parquet.Column{Name: col.parquetName, Type: col.typ, Optional: i > 1}
+		}
+		return parquet.NewWriter(buf, parquetColumns), ".parquet"
+	}
+	gzipWriter := gzip.NewWriter(buf)
+	return csvInventoryWriter{gzipWriter: gzipWriter, csvWriter: csv.NewWriter(gzipWriter)}, ".csv.gz"
+}
+
+// inventoryManifestFile - data file listed by the manifest of an
+// inventory report.
+type inventoryManifestFile struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	MD5Checksum string `json:"MD5checksum"`
+}
+
+// inventoryManifest - manifest of an inventory report, written once all
+// its data files are written.
+type inventoryManifest struct {
+	SourceBucket      string                  `json:"sourceBucket"`
+	DestinationBucket string                  `json:"destinationBucket"`
+	Version           string                  `json:"version"`
+	CreationTimestamp string                  `json:"creationTimestamp"`
+	FileFormat        inventory.Format        `json:"fileFormat"`
+	FileSchema        string                  `json:"fileSchema"`
+	Files             []inventoryManifestFile `json:"files"`
+}
+
+// inventoryReport - inventory report of a bucket being written.
+type inventoryReport struct {
+	objAPI      ObjectLayer
+	bucket      string
+	config      inventory.Config
+	columns     []inventoryColumn
+	destination inventory.BucketDestination
+	prefix      string
+	manifest    inventoryManifest
+
+	buf     bytes.Buffer
+	writer  inventoryRowWriter
+	ext     string
+	numRows int
+}
+
+// newInventoryReport - returns the report of an inventory configuration
+// created at the given time.
+func newInventoryReport(objAPI ObjectLayer, bucket string, config inventory.Config, now time.Time) *inventoryReport {
+	destination := config.Destination.S3BucketDestination
+	report := &inventoryReport{
+		objAPI:      objAPI,
+		bucket:      bucket,
+		config:      config,
+		columns:     getInventoryColumns(config),
+		destination: destination,
+		prefix:      path.Join(destination.Prefix, bucket, config.ID),
+		manifest: inventoryManifest{
+			SourceBucket:      bucket,
+			DestinationBucket: destination.Bucket,
+			Version:           inventoryManifestVersion,
+			CreationTimestamp: strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10),
+			FileFormat:        destination.Format,
+		},
+	}
+
+	var names []string
+	for i, col := range report.columns {
+		if destination.Format == inventory.Parquet {
+			repetition, typ := "optional", "binary"
+			if i < 2 {
+				repetition = "required"
+			}
+			switch col.typ {
+			case parquet.Int64Column:
+				typ = "int64"
+			case parquet.BooleanColumn:
+				typ = "boolean"
+			}
+			names = append(names, repetition+" "+typ+" "+col.parquetName)
+		} else {
+			names = append(names, col.name)
+		}
+	}
+	if destination.Format == inventory.Parquet {
+		report.manifest.FileSchema = "message s3.inventory { " + strings.Join(names, "; ") + "; }"
+	} else {
+		report.manifest.FileSchema = strings.Join(names, ", ")
+	}
+	return report
+}
+
+// manifestKey - returns the name of the manifest of the report in the
+// destination bucket.
+func (report *inventoryReport) manifestKey(reportTime time.Time) string {
+	return path.Join(report.prefix, reportTime.UTC().Format("2006-01-02T15-04Z"), "manifest.json")
+}
+
+// putObject - writes an object of the report to the destination bucket.
+func (report *inventoryReport) putObject(ctx context.Context, object string, data []byte, contentType string) error {
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), getMD5Hash(data), getSHA256Hash(data))
+	if err != nil {
+		return err
+	}
+	metadata := map[string]string{"content-type": contentType}
+	setObjectVersionID(report.destination.BucketName(), metadata)
+	_, err = report.objAPI.PutObject(ctx, report.destination.BucketName(), object, hashReader, metadata)
+	return err
+}
+
+// add - adds an object version to the current data file of the report,
+// the file is written once it is large enough.
+func (report *inventoryReport) add(ctx context.Context, objInfo ObjectInfo) error {
+	if report.writer == nil {
+		report.buf.Reset()
+		report.writer, report.ext = newInventoryRowWriter(&report.buf, report.destination.Format, report.columns)
+	}
+	row := make([]interface{}, len(report.columns))
+	for i, col := range report.columns {
+		row[i] = col.value(objInfo)
+	}
+	if err := report.writer.Write(row); err != nil {
+		return err
+	}
+	report.numRows++
+	if report.buf.Len() >= maxInventoryFileSize {
+		return report.flush(ctx)
+	}
+	return nil
+}
+
+// flush - writes the current data file of the report.
+func (report *inventoryReport) flush(ctx context.Context) error {
+	if report.writer == nil {
+		return nil
+	}
+	if err := report.writer.Close(); err != nil {
+		return err
+	}
+	report.writer = nil
+
+	data := report.buf.Bytes()
+	contentType := "application/octet-stream"
+	if report.destination.Format == inventory.CSV {
+		contentType = "application/x-gzip"
+	}
+	file := inventoryManifestFile{
+		Key:         path.Join(report.prefix, "data", mustGetUUID()+report.ext),
+		Size:        int64(len(data)),
+		MD5Checksum: getMD5Hash(data),
+	}
+	if err := report.putObject(ctx, file.Key, data, contentType); err != nil {
+		return err
+	}
+	report.manifest.Files = append(report.manifest.Files, file)
+	return nil
+}
+
+// list - adds the objects or object versions selected by the inventory
+// configuration to the report.
+func (report *inventoryReport) list(ctx context.Context, doneCh <-chan struct{}) error {
+	prefix := report.config.FilterPrefix()
+	if report.config.IncludedObjectVersions == inventory.AllVersions && getBucketVersioning(report.bucket) != "" {
+		keyMarker, versionIDMarker := "", ""
+		for {
+			result, err := report.objAPI.ListObjectVersions(ctx, report.bucket, prefix, keyMarker, versionIDMarker, "", maxObjectList)
+			if err != nil {
+				return err
+			}
+			for _, version := range result.Objects {
+				if !waitForScanner(doneCh) {
+					return errInventoryStopped
+				}
+				if err = report.add(ctx, version); err != nil {
+					return err
+				}
+			}
+			if !result.IsTruncated || len(result.Objects) == 0 {
+				return nil
+			}
+			keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+		}
+	}
+
+	marker := ""
+	for {
+		result, err := report.objAPI.ListObjects(ctx, report.bucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, object := range result.Objects {
+			if !waitForScanner(doneCh) {
+				return errInventoryStopped
+			}
+			object.IsLatest = true
+			if err = report.add(ctx, object); err != nil {
+				return err
+			}
+		}
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			return nil
+		}
+		marker = result.NextMarker
+		if marker == "" {
+			marker = result.Objects[len(result.Objects)-1].Name
+		}
+	}
+}
+
+// write - writes the report of the objects at the given time, unless its
+// manifest was already written. The manifest and its checksum are written
+// last, after all data files.
+func (report *inventoryReport) write(ctx context.Context, reportTime time.Time, doneCh <-chan struct{}) error {
+	manifestKey := report.manifestKey(reportTime)
+	_, err := report.objAPI.GetObjectInfo(ctx, report.destination.BucketName(), manifestKey)
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(ObjectNotFound); !ok {
+		return err
+	}
+
+	if err = report.list(ctx, doneCh); err != nil {
+		return err
+	}
+	if err = report.flush(ctx); err != nil {
+		return err
+	}
+	if report.manifest.Files == nil {
+		report.manifest.Files = []inventoryManifestFile{}
+	}
+
+	data, err := json.Marshal(report.manifest)
+	if err != nil {
+		return err
+	}
+	if err = report.putObject(ctx, manifestKey, data, "application/json"); err != nil {
+		return err
+	}
+	return report.putObject(ctx, path.Join(path.Dir(manifestKey), "manifest.checksum"), []byte(getMD5Hash(data)), "text/plain")
+}
+
+// inventoryReporter - writes the inventory reports of buckets as per their
+// schedule. Every server checks all buckets but only writes the reports
+// whose bucket and ID hash to its index among all servers.
+type inventoryReporter struct {
+	objAPI    ObjectLayer
+	nodeIndex int
+	nodeCount int
+}
+
+// isLocalReport - returns true if the reports of the inventory
+// configuration are written by this server.
+func (r inventoryReporter) isLocalReport(bucket string, config inventory.Config) bool {
+	return int(crc32.ChecksumIEEE([]byte(bucket+"/"+config.ID))%uint32(r.nodeCount)) == r.nodeIndex
+}
+
+// report - writes the inventory reports due at the given time, returns
+// errInventoryStopped if doneCh is closed meanwhile.
+func (r inventoryReporter) report(ctx context.Context, now time.Time, doneCh <-chan struct{}) error {
+	buckets, err := r.objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		for _, config := range getBucketInventoryConfigs(bucket.Name) {
+			if !config.IsEnabled || !r.isLocalReport(bucket.Name, config) {
+				continue
+			}
+			report := newInventoryReport(r.objAPI, bucket.Name, config, now)
+			err = report.write(ctx, config.ReportTime(now), doneCh)
+			if err == errInventoryStopped {
+				return err
+			}
+			if err != nil {
+				reqInfo := &logger.ReqInfo{BucketName: bucket.Name}
+				reqInfo.AppendTags("inventoryID", config.ID)
+				logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+			}
+		}
+	}
+	return nil
+}
+
+// initBucketInventory - starts writing the inventory reports of buckets
+// in background.
+func initBucketInventory(objAPI ObjectLayer) {
+	reporter := inventoryReporter{objAPI: objAPI}
+	reporter.nodeIndex, reporter.nodeCount = GetLocalPeerIndex(globalEndpoints)
+	go func() {
+		ticker := time.NewTicker(globalInventoryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+				if reporter.report(context.Background(), UTCNow(), globalServiceDoneCh) == errInventoryStopped {
+					return
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,188 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/inventory"
+	"github.com/minio/minio/pkg/s3select/parquet"
+)
+
+// Tests that every inventory configuration is reported by exactly one server.
+func TestInventoryReporterIsLocalReport(t *testing.T) {
+	reporters := []inventoryReporter{
+		{nodeIndex: 0, nodeCount: 3},
+		{nodeIndex: 1, nodeCount: 3},
+		{nodeIndex: 2, nodeCount: 3},
+	}
+	for i := 0; i < 100; i++ {
+		config := inventory.Config{ID: fmt.Sprintf("report-%d", i)}
+		count := 0
+		for _, reporter := range reporters {
+			if reporter.isLocalReport("bucket", config) {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Fatalf("%s: expected to be reported by 1 server, got %d", config.ID, count)
+		}
+	}
+}
+
+// Tests that inventory reports list the objects selected by their
+// configuration and are written once per schedule.
+func TestInventoryReport(t *testing.T) {
+	ExecObjectLayerTest(t, testInventoryReport)
+}
+
+func testInventoryReport(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	bucket := "bucket"
+	for _, b := range []string{bucket, "reports"} {
+		if err := obj.MakeBucketWithLocation(ctx, b, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+	objects := map[string]string{"logs/a b": "data", "logs/c": "more data", "other": "data"}
+	etags := map[string]string{}
+	for object, data := range objects {
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), nil)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		etags[object] = objInfo.ETag
+	}
+
+	configs := ""
+	for _, format := range []string{"CSV", "Parquet"} {
+		configs += `<InventoryConfiguration><Id>` + format + `</Id><IsEnabled>true</IsEnabled>` +
+			`<Destination><S3BucketDestination><Bucket>arn:aws:s3:::reports</Bucket><Format>` + format + `</Format><Prefix>inventory</Prefix></S3BucketDestination></Destination>` +
+			`<Filter><Prefix>logs/</Prefix></Filter><IncludedObjectVersions>Current</IncludedObjectVersions>` +
+			`<OptionalFields><Field>ETag</Field><Field>Size</Field></OptionalFields><Schedule><Frequency>Daily</Frequency></Schedule></InventoryConfiguration>`
+	}
+	globalBucketMetadataSys.Set(bucket, bucketInventoryConfig, []byte(`<InventoryConfigurations>`+configs+`</InventoryConfigurations>`))
+
+	readObject := func(object string) []byte {
+		objInfo, err := obj.GetObjectInfo(ctx, "reports", object)
+		if err != nil {
+			t.Fatalf("%s: %s: %s", instanceType, object, err)
+		}
+		var buffer bytes.Buffer
+		if err = obj.GetObject(ctx, "reports", object, 0, objInfo.Size, &buffer, ""); err != nil {
+			t.Fatalf("%s: %s: %s", instanceType, object, err)
+		}
+		return buffer.Bytes()
+	}
+
+	now := time.Date(2018, 6, 6, 15, 4, 5, 0, time.UTC)
+	reporter := inventoryReporter{objAPI: obj, nodeCount: 1}
+	if err := reporter.report(ctx, now, make(chan struct{})); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	expected := [][]interface{}{
+		{bucket, "logs/a b", int64(4), etags["logs/a b"]},
+		{bucket, "logs/c", int64(9), etags["logs/c"]},
+	}
+	for _, format := range []string{"CSV", "Parquet"} {
+		dir := path.Join("inventory", bucket, format, "2018-06-06T00-00Z")
+		data := readObject(path.Join(dir, "manifest.json"))
+		if checksum := string(readObject(path.Join(dir, "manifest.checksum"))); checksum != getMD5Hash(data) {
+			t.Fatalf("%s: %s: unexpected manifest checksum %s", instanceType, format, checksum)
+		}
+		var manifest inventoryManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			t.Fatalf("%s: %s: %s", instanceType, format, err)
+		}
+		if manifest.SourceBucket != bucket || manifest.DestinationBucket != "arn:aws:s3:::reports" || len(manifest.Files) != 1 {
+			t.Fatalf("%s: %s: unexpected manifest %v", instanceType, format, manifest)
+		}
+		fileData := readObject(manifest.Files[0].Key)
+		if getMD5Hash(fileData) != manifest.Files[0].MD5Checksum {
+			t.Fatalf("%s: %s: unexpected data file checksum", instanceType, format)
+		}
+
+		var rows [][]interface{}
+		if format == "CSV" {
+			if manifest.FileSchema != "Bucket, Key, Size, ETag" {
+				t.Fatalf("%s: %s: unexpected schema %s", instanceType, format, manifest.FileSchema)
+			}
+			gzipReader, err := gzip.NewReader(bytes.NewReader(fileData))
+			if err != nil {
+				t.Fatalf("%s: %s: %s", instanceType, format, err)
+			}
+			records, err := csv.NewReader(gzipReader).ReadAll()
+			if err != nil {
+				t.Fatalf("%s: %s: %s", instanceType, format, err)
+			}
+			for _, record := range records {
+				var size int64
+				fmt.Sscan(record[2], &size)
+				rows = append(rows, []interface{}{record[0], record[1], size, record[3]})
+			}
+			expected[0][1] = "logs/a%20b"
+		} else {
+			reader, err := parquet.NewReader(bytes.NewReader(fileData), int64(len(fileData)))
+			if err != nil {
+				t.Fatalf("%s: %s: %s", instanceType, format, err)
+			}
+			var columns [][]interface{}
+			for col := range reader.Columns() {
+				values, err := reader.ReadColumn(0, col)
+				if err != nil {
+					t.Fatalf("%s: %s: %s", instanceType, format, err)
+				}
+				columns = append(columns, values)
+			}
+			for i := range columns[0] {
+				rows = append(rows, []interface{}{columns[0][i], columns[1][i], columns[2][i], columns[3][i]})
+			}
+			expected[0][1] = "logs/a b"
+		}
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("%s: %s: expected rows %v, got %v", instanceType, format, expected, rows)
+		}
+	}
+
+	// A report already written is not written again.
+	if _, err := obj.PutObject(ctx, bucket, "logs/d", mustGetHashReader(t, bytes.NewReader([]byte("data")), 4, "", ""), nil); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err := reporter.report(ctx, now.Add(time.Hour), make(chan struct{})); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	result, err := obj.ListObjects(ctx, "reports", path.Join("inventory", bucket, "CSV", "data")+"/", "", "", maxObjectList)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Objects) != 1 {
+		t.Errorf("%s: expected 1 data file, got %d", instanceType, len(result.Objects))
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/lifecycle"
+)
+
+// Wrapper for calling bucket lifecycle handler tests for both XL multiple disks and single node setup.
+func TestBucketLifecycleHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketLifecycleHandlers, []string{"PutBucketLifecycle", "GetBucketLifecycle", "DeleteBucketLifecycle"})
+}
+
+func testBucketLifecycleHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	serve := func(method, urlStr string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := serve("GET", getBucketLifecycleURL("", bucketName), nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	testCases := []struct {
+		bucketName    string
+		data          string
+		expectedCode  int
+		expectedRules int
+	}{
+		{bucketName, `<LifecycleConfiguration><Rule><ID>logs</ID><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>`, http.StatusOK, 1},
+		{bucketName, `<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Tag><Key>temp</Key><Value>true</Value></Tag></Filter><Expiration><Days>1</Days></Expiration></Rule><Rule><Status>Enabled</Status><Filter></Filter><NoncurrentVersionExpiration><NoncurrentDays>7</NoncurrentDays></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`, http.StatusOK, 2},
+		{bucketName, `<LifecycleConfiguration><Rule><Status>Enabled</Status></Rule></LifecycleConfiguration>`, http.StatusBadRequest, 2},
+		{bucketName, `<LifecycleConfiguration><Rule>`, http.StatusBadRequest, 2},
+		{"missing-bucket", `<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, http.StatusNotFound, 2},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getBucketLifecycleURL("", testCase.bucketName), []byte(testCase.data))
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+
+		rec = serve("GET", getBucketLifecycleURL("", bucketName), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, http.StatusOK, rec.Code)
+		}
+		var lc lifecycle.Lifecycle
+		if err := xml.Unmarshal(rec.Body.Bytes(), &lc); err != nil {
+			t.Fatalf("%s: Test %d: Failed to parse lifecycle configuration: <ERROR> %v", instanceType, i+1, err)
+		}
+		if len(lc.Rules) != testCase.expectedRules {
+			t.Errorf("%s: Test %d: Expected %d rules, but instead found %d", instanceType, i+1, testCase.expectedRules, len(lc.Rules))
+		}
+	}
+
+	// Transitions move objects to known tiers, stubs are only kept by XL.
+	globalTierConfigSys = NewTierConfigSys()
+	globalTierConfigSys.tiers["WARM"] = TierConfig{Name: "WARM", Type: MinioTier}
+	defer func() { globalTierConfigSys = NewTierConfigSys() }()
+
+	transitionCases := []struct {
+		tier         string
+		expectedCode int
+	}{
+		{"WARM", http.StatusOK},
+		{"warm", http.StatusOK},
+		{"COLD", http.StatusBadRequest},
+	}
+	for i, testCase := range transitionCases {
+		expectedCode := testCase.expectedCode
+		if !obj.IsTransitionSupported() {
+			expectedCode = http.StatusNotImplemented
+		}
+		data := `<LifecycleConfiguration><Rule><Status>Enabled</Status><Transition><Days>30</Days><StorageClass>` + testCase.tier + `</StorageClass></Transition></Rule></LifecycleConfiguration>`
+		if rec := serve("PUT", getBucketLifecycleURL("", bucketName), []byte(data)); rec.Code != expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, expectedCode, rec.Code)
+		}
+	}
+
+	if rec := serve("DELETE", getBucketLifecycleURL("", bucketName), nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec := serve("GET", getBucketLifecycleURL("", bucketName), nil); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+}
+
+// Wrapper for calling object expiration header tests for both XL multiple disks and single node setup.
+func TestObjectExpirationHeader(t *testing.T) {
+	ExecObjectLayerAPITest(t, testObjectExpirationHeader, []string{"PutObject", "GetObject", "HeadObject"})
+}
+
+func testObjectExpirationHeader(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	globalBucketMetadataSys.Set(bucketName, bucketLifecycleConfig, []byte(`<LifecycleConfiguration>`+
+		`<Rule><ID>logs</ID><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>30</Days></Expiration></Rule>`+
+		`<Rule><ID>old</ID><Status>Enabled</Status><Filter><Prefix>old/</Prefix></Filter><Expiration><Date>2018-01-01T00:00:00Z</Date></Expiration></Rule>`+
+		`</LifecycleConfiguration>`))
+
+	serve := func(method, urlStr string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	testCases := []struct {
+		objectName string
+		ruleID     string
+		days       int
+	}{
+		{"logs/a", "logs", 30},
+		{"old/a", "old", 0},
+		{"other/a", "", 0},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getPutObjectURL("", bucketName, testCase.objectName), []byte("hello"))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, http.StatusOK, rec.Code)
+		}
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, testCase.objectName)
+		if err != nil {
+			t.Fatalf("%s: Test %d: Failed to get object info: <ERROR> %v", instanceType, i+1, err)
+		}
+
+		var expected string
+		switch {
+		case testCase.days > 0:
+			expiry := lifecycle.ExpectedExpiryTime(objInfo.ModTime, testCase.days)
+			expected = `expiry-date="` + expiry.Format(http.TimeFormat) + `", rule-id="` + testCase.ruleID + `"`
+		case testCase.ruleID != "":
+			expected = `expiry-date="Mon, 01 Jan 2018 00:00:00 GMT", rule-id="` + testCase.ruleID + `"`
+		}
+
+		responses := map[string]*httptest.ResponseRecorder{
+			"PUT":  rec,
+			"GET":  serve("GET", getGetObjectURL("", bucketName, testCase.objectName), nil),
+			"HEAD": serve("HEAD", getHeadObjectURL("", bucketName, testCase.objectName), nil),
+		}
+		for method, rec := range responses {
+			if header := rec.Header().Get(amzExpiration); header != expected {
+				t.Errorf("%s: Test %d: %s: Expected the expiration `%s`, but instead found `%s`", instanceType, i+1, method, expected, header)
+			}
+		}
+	}
+}
@@ -0,0 +1,378 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/lifecycle"
+)
+
+const (
+	// Bucket lifecycle configuration file.
+	bucketLifecycleConfig = "lifecycle.xml"
+
+	// Maximum size of a lifecycle configuration in a put-bucket-lifecycle request.
+	maxLifecycleConfigSize = 1024 * 1024
+
+	// Response header of object requests giving the expiry date of the
+	// object and the ID of the lifecycle rule expiring it.
+	amzExpiration = "x-amz-expiration"
+)
+
+// Interval between two sweeps of the buckets having a lifecycle configuration.
+var globalLifecycleSweepInterval = 1 * time.Hour
+
+var errLifecycleSweepStopped = errors.New("lifecycle sweep stopped")
+
+// getBucketLifecycle - returns the lifecycle configuration of given
+// bucket name, false if the bucket has none.
+func getBucketLifecycle(bucketName string) (*lifecycle.Lifecycle, bool) {
+	if globalBucketMetadataSys == nil {
+		return nil, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketLifecycleConfig)
+	if !ok {
+		return nil, false
+	}
+	var lc lifecycle.Lifecycle
+	if err := xml.Unmarshal(data, &lc); err != nil {
+		return nil, false
+	}
+	return &lc, true
+}
+
+// setObjectExpirationHeader - sets the expiration header of the
+// current version of an object if a lifecycle rule of its bucket
+// expires it.
+func setObjectExpirationHeader(w http.ResponseWriter, objInfo ObjectInfo) {
+	if objInfo.VersionID != "" && !objInfo.IsLatest {
+		return
+	}
+	lc, ok := getBucketLifecycle(objInfo.Bucket)
+	if !ok {
+		return
+	}
+	ruleID, expiry := lc.PredictExpiryTime(lifecycle.ObjectOpts{
+		Name:         objInfo.Name,
+		ModTime:      objInfo.ModTime,
+		Tags:         getObjectTags(objInfo.UserDefined).ToMap(),
+		IsLatest:     true,
+		DeleteMarker: objInfo.DeleteMarker,
+	})
+	if expiry.IsZero() {
+		return
+	}
+	w.Header().Set(amzExpiration, fmt.Sprintf(`expiry-date="%s", rule-id="%s"`, expiry.Format(http.TimeFormat), ruleID))
+}
+
+// lifecycleSweeper - applies the lifecycle configuration of buckets to
+// their objects. Every server sweeps all buckets but only handles the
+// objects whose name hashes to its index among all servers. Multipart
+// uploads are aborted by the servers storing them on their drives.
+type lifecycleSweeper struct {
+	objAPI    ObjectLayer
+	endpoints EndpointList
+	nodeIndex int
+	nodeCount int
+}
+
+// newLifecycleSweeper - returns the lifecycle sweeper of this server.
+func newLifecycleSweeper(objAPI ObjectLayer, endpoints EndpointList) lifecycleSweeper {
+	sweeper := lifecycleSweeper{objAPI: objAPI, endpoints: endpoints}
+	sweeper.nodeIndex, sweeper.nodeCount = GetLocalPeerIndex(endpoints)
+	return sweeper
+}
+
+// isLocalObject - returns true if the object is handled by this server.
+func (s lifecycleSweeper) isLocalObject(object string) bool {
+	return int(crc32.ChecksumIEEE([]byte(object))%uint32(s.nodeCount)) == s.nodeIndex
+}
+
+// sweep - applies the lifecycle configurations of all buckets once,
+// returns errLifecycleSweepStopped if doneCh is closed meanwhile.
+func (s lifecycleSweeper) sweep(ctx context.Context, doneCh <-chan struct{}) error {
+	buckets, err := s.objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		lc, ok := getBucketLifecycle(bucket.Name)
+		if !ok {
+			continue
+		}
+
+		if getBucketVersioning(bucket.Name) != "" {
+			err = s.sweepVersions(ctx, bucket.Name, lc, doneCh)
+		} else {
+			err = s.sweepObjects(ctx, bucket.Name, lc, doneCh)
+		}
+		if err == errLifecycleSweepStopped {
+			return err
+		}
+		if err != nil {
+			reqInfo := &logger.ReqInfo{BucketName: bucket.Name}
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+		}
+	}
+	return s.sweepUploads(ctx, UTCNow(), doneCh)
+}
+
+// sweepUploads - aborts the multipart uploads stored on the local drives
+// which are incomplete for longer than allowed by the lifecycle
+// configuration of their bucket at the given time. Uploads initiated by
+// older releases are aborted according to the time of their last part.
+func (s lifecycleSweeper) sweepUploads(ctx context.Context, now time.Time, doneCh <-chan struct{}) error {
+	for _, upload := range getLocalMultipartUploads(s.endpoints) {
+		if upload.Bucket == "" {
+			continue
+		}
+		lc, ok := getBucketLifecycle(upload.Bucket)
+		if !ok || !lc.IsUploadAbortDue(upload.Object, now.Add(-upload.age(now)), now) {
+			continue
+		}
+		if !waitForScanner(doneCh) {
+			return errLifecycleSweepStopped
+		}
+
+		// Servers sharing the drives of an upload may abort it first.
+		switch err := s.objAPI.AbortMultipartUpload(ctx, upload.Bucket, upload.Object, upload.UploadID); err.(type) {
+		case nil, InvalidUploadID, BucketNotFound:
+		default:
+			reqInfo := &logger.ReqInfo{BucketName: upload.Bucket, ObjectName: upload.Object}
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+		}
+	}
+	return nil
+}
+
+// sweepObjects - deletes the expired objects of an unversioned bucket.
+func (s lifecycleSweeper) sweepObjects(ctx context.Context, bucket string, lc *lifecycle.Lifecycle, doneCh <-chan struct{}) error {
+	marker := ""
+	for {
+		result, err := s.objAPI.ListObjects(ctx, bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, object := range result.Objects {
+			if !s.isLocalObject(object.Name) {
+				continue
+			}
+			if !waitForScanner(doneCh) {
+				return errLifecycleSweepStopped
+			}
+
+			transition, transitioned := getObjectTransition(object.UserDefined)
+			opts := lifecycle.ObjectOpts{
+				Name:         object.Name,
+				ModTime:      object.ModTime,
+				Tags:         getObjectTags(object.UserDefined).ToMap(),
+				IsLatest:     true,
+				Transitioned: transitioned,
+				NumVersions:  1,
+			}
+			now := UTCNow()
+			if transition.IsRestored() && now.After(transition.RestoreExpiry) {
+				s.expireRestore(ctx, object)
+			}
+			switch lc.ComputeAction(opts, now) {
+			case lifecycle.DeleteAction:
+				err = s.objAPI.DeleteObject(ctx, bucket, object.Name)
+				s.notify(ctx, bucket, object.Name, "", err)
+			case lifecycle.TransitionAction:
+				s.transition(ctx, object, lc.TransitionTier(opts, now))
+			}
+		}
+
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			return nil
+		}
+
+		marker = result.NextMarker
+		if marker == "" {
+			marker = result.Objects[len(result.Objects)-1].Name
+		}
+	}
+}
+
+// sweepVersions - expires the versions and delete markers of a
+// versioned bucket, the versions of each object are handled together.
+func (s lifecycleSweeper) sweepVersions(ctx context.Context, bucket string, lc *lifecycle.Lifecycle, doneCh <-chan struct{}) error {
+	var versions []ObjectInfo
+	keyMarker, versionIDMarker := "", ""
+	for {
+		result, err := s.objAPI.ListObjectVersions(ctx, bucket, "", keyMarker, versionIDMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, version := range result.Objects {
+			if len(versions) > 0 && versions[0].Name != version.Name {
+				if err = s.expireVersions(ctx, bucket, lc, versions, doneCh); err != nil {
+					return err
+				}
+				versions = nil
+			}
+			versions = append(versions, version)
+		}
+
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			break
+		}
+		keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+	}
+
+	if len(versions) > 0 {
+		return s.expireVersions(ctx, bucket, lc, versions, doneCh)
+	}
+	return nil
+}
+
+// expireVersions - applies the lifecycle configuration to the versions
+// of an object, ordered newest first. Versions are handled oldest first
+// so that a delete marker left alone is removed in the same sweep.
+func (s lifecycleSweeper) expireVersions(ctx context.Context, bucket string, lc *lifecycle.Lifecycle, versions []ObjectInfo, doneCh <-chan struct{}) error {
+	object := versions[0].Name
+	if !s.isLocalObject(object) {
+		return nil
+	}
+
+	numVersions := len(versions)
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !waitForScanner(doneCh) {
+			return errLifecycleSweepStopped
+		}
+
+		version := versions[i]
+		transition, transitioned := getObjectTransition(version.UserDefined)
+		opts := lifecycle.ObjectOpts{
+			Name:         object,
+			ModTime:      version.ModTime,
+			Tags:         getObjectTags(version.UserDefined).ToMap(),
+			IsLatest:     version.IsLatest,
+			DeleteMarker: version.DeleteMarker,
+			Transitioned: transitioned,
+			NumVersions:  numVersions,
+		}
+		if i > 0 {
+			opts.SuccessorModTime = versions[i-1].ModTime
+		}
+
+		var err error
+		var objInfo ObjectInfo
+		now := UTCNow()
+		if transition.IsRestored() && now.After(transition.RestoreExpiry) {
+			s.expireRestore(ctx, version)
+		}
+		switch lc.ComputeAction(opts, now) {
+		case lifecycle.DeleteAction:
+			markerVersionID, _ := newObjectVersionID(bucket)
+			objInfo, err = s.objAPI.PutDeleteMarker(ctx, bucket, object, markerVersionID)
+		case lifecycle.DeleteVersionAction:
+			objInfo, err = s.objAPI.DeleteObjectVersion(ctx, bucket, object, version.VersionID)
+			if err == nil {
+				numVersions--
+			}
+		case lifecycle.TransitionAction:
+			s.transition(ctx, version, lc.TransitionTier(opts, now))
+			continue
+		default:
+			continue
+		}
+		s.notify(ctx, bucket, object, objInfo.VersionID, err)
+	}
+	return nil
+}
+
+// notify - sends the event of an expired object, or logs why it could
+// not be expired. Objects removed or locked in the meantime are skipped.
+func (s lifecycleSweeper) notify(ctx context.Context, bucket, object, versionID string, err error) {
+	switch err.(type) {
+	case nil:
+	case ObjectNotFound, VersionNotFound, ObjectLocked:
+		return
+	default:
+		reqInfo := &logger.ReqInfo{BucketName: bucket, ObjectName: object}
+		logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+		return
+	}
+
+	sendEvent(eventArgs{
+		EventName:  event.ObjectRemovedDelete,
+		BucketName: bucket,
+		Object: ObjectInfo{
+			Name:      object,
+			VersionID: versionID,
+		},
+		UserAgent: "Internal: [Lifecycle]",
+	})
+}
+
+// transition - moves the data of the object version to the tier, or
+// logs why it could not be moved. Objects removed or overwritten in the
+// meantime are skipped.
+func (s lifecycleSweeper) transition(ctx context.Context, objInfo ObjectInfo, tier string) {
+	switch err := transitionObject(ctx, s.objAPI, objInfo, tier); err.(type) {
+	case nil, ObjectNotFound, VersionNotFound, InvalidETag:
+	default:
+		reqInfo := &logger.ReqInfo{BucketName: objInfo.Bucket, ObjectName: objInfo.Name}
+		logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+	}
+}
+
+// expireRestore - removes the expired copy of the data of a transitioned
+// object version restored from its tier, or logs why it could not be
+// removed. Objects removed in the meantime are skipped.
+func (s lifecycleSweeper) expireRestore(ctx context.Context, objInfo ObjectInfo) {
+	switch _, err := s.objAPI.RestoreObject(ctx, objInfo.Bucket, objInfo.Name, objInfo.VersionID, time.Time{}); err.(type) {
+	case nil, ObjectNotFound, VersionNotFound, ObjectNotTransitioned:
+	default:
+		reqInfo := &logger.ReqInfo{BucketName: objInfo.Bucket, ObjectName: objInfo.Name}
+		logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+	}
+}
+
+// initLifecycleSweeper - starts sweeping the buckets having a lifecycle
+// configuration in background.
+func initLifecycleSweeper(objAPI ObjectLayer) {
+	sweeper := newLifecycleSweeper(objAPI, globalEndpoints)
+	go func() {
+		ticker := time.NewTicker(globalLifecycleSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+				if sweeper.sweep(context.Background(), globalServiceDoneCh) == errLifecycleSweepStopped {
+					return
+				}
+			}
+		}
+	}()
+}
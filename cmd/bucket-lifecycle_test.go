@@ -0,0 +1,228 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// Tests that every object is handled by exactly one server.
+func TestLifecycleSweeperIsLocalObject(t *testing.T) {
+	sweepers := []lifecycleSweeper{
+		{nodeIndex: 0, nodeCount: 3},
+		{nodeIndex: 1, nodeCount: 3},
+		{nodeIndex: 2, nodeCount: 3},
+	}
+	for i := 0; i < 100; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		count := 0
+		for _, sweeper := range sweepers {
+			if sweeper.isLocalObject(object) {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Fatalf("%s: expected to be handled by 1 server, got %d", object, count)
+		}
+	}
+}
+
+// Tests that expired objects of unversioned buckets are deleted.
+func TestLifecycleSweepObjects(t *testing.T) {
+	ExecObjectLayerTest(t, testLifecycleSweepObjects)
+}
+
+func testLifecycleSweepObjects(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	objects := map[string]map[string]string{
+		"expired/a":   nil,
+		"expired/b":   nil,
+		"kept/a":      nil,
+		"tagged/temp": {objectTaggingKey: "temp=true"},
+		"tagged/kept": {objectTaggingKey: "temp=false"},
+	}
+	for object, metadata := range objects {
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader([]byte("data")), 4, "", ""), metadata); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+
+	globalBucketMetadataSys.Set(bucket, bucketLifecycleConfig, []byte(`<LifecycleConfiguration>`+
+		`<Rule><Status>Enabled</Status><Filter><Prefix>expired/</Prefix></Filter><Expiration><Date>2018-01-01T00:00:00Z</Date></Expiration></Rule>`+
+		`<Rule><Status>Enabled</Status><Filter><Tag><Key>temp</Key><Value>true</Value></Tag></Filter><Expiration><Date>2018-01-01T00:00:00Z</Date></Expiration></Rule>`+
+		`<Rule><Status>Enabled</Status><Filter><Prefix>kept/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>`+
+		`</LifecycleConfiguration>`))
+
+	sweeper := lifecycleSweeper{objAPI: obj, nodeCount: 1}
+	if err := sweeper.sweep(ctx, make(chan struct{})); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	expectedObjects := map[string]bool{
+		"expired/a":   false,
+		"expired/b":   false,
+		"kept/a":      true,
+		"tagged/temp": false,
+		"tagged/kept": true,
+	}
+	for object, exists := range expectedObjects {
+		_, err := obj.GetObjectInfo(ctx, bucket, object)
+		if exists && err != nil {
+			t.Errorf("%s: %s: expected to be kept, got %v", instanceType, object, err)
+		}
+		if _, ok := err.(ObjectNotFound); !exists && !ok {
+			t.Errorf("%s: %s: expected to be expired, got %v", instanceType, object, err)
+		}
+	}
+}
+
+// Tests that expiring objects of versioned buckets adds delete markers,
+// which are removed once no version is left.
+func TestLifecycleSweepVersions(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketMetadataSys.Set(bucket, bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+
+	putVersion := func(object string) string {
+		metadata := map[string]string{}
+		setObjectVersionID(bucket, metadata)
+		if _, perr := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader([]byte("data")), 4, "", ""), metadata); perr != nil {
+			t.Fatal(perr)
+		}
+		return metadata[objectVersionIDKey]
+	}
+	expiredVersion := putVersion("expired")
+	keptVersion := putVersion("kept")
+	markerVersionID, _ := newObjectVersionID(bucket)
+	if _, err = obj.PutDeleteMarker(ctx, bucket, "marker", markerVersionID); err != nil {
+		t.Fatal(err)
+	}
+
+	globalBucketMetadataSys.Set(bucket, bucketLifecycleConfig, []byte(`<LifecycleConfiguration>`+
+		`<Rule><Status>Enabled</Status><Filter><Prefix>expired</Prefix></Filter><Expiration><Date>2018-01-01T00:00:00Z</Date></Expiration></Rule>`+
+		`<Rule><Status>Enabled</Status><Filter></Filter><Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration></Rule>`+
+		`</LifecycleConfiguration>`))
+
+	sweeper := lifecycleSweeper{objAPI: obj, nodeCount: 1}
+	if err = sweeper.sweep(ctx, make(chan struct{})); err != nil {
+		t.Fatal(err)
+	}
+
+	// The expired object is hidden by a delete marker, its version is kept.
+	if _, err = obj.GetObjectInfo(ctx, bucket, "expired"); err == nil {
+		t.Fatal("expected expired object to be deleted")
+	}
+	if _, err = obj.GetObjectVersionInfo(ctx, bucket, "expired", expiredVersion); err != nil {
+		t.Fatalf("expected expired version to be kept, got %v", err)
+	}
+	if _, err = obj.GetObjectVersionInfo(ctx, bucket, "kept", keptVersion); err != nil {
+		t.Fatalf("expected version to be kept, got %v", err)
+	}
+
+	result, err := obj.ListObjectVersions(ctx, bucket, "marker", "", "", "", maxObjectList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 0 {
+		t.Fatalf("expected expired delete marker to be removed, got %v", result.Objects)
+	}
+}
+
+// Tests that incomplete multipart uploads are aborted some days after
+// their initiation.
+func TestLifecycleSweepUploads(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	uploadIDs := map[string]string{}
+	for _, object := range []string{"uploads/a", "kept/a"} {
+		if uploadIDs[object], err = obj.NewMultipartUpload(ctx, bucket, object, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	globalBucketMetadataSys.Set(bucket, bucketLifecycleConfig, []byte(`<LifecycleConfiguration>`+
+		`<Rule><Status>Enabled</Status><Filter><Prefix>uploads/</Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>`+
+		`</LifecycleConfiguration>`))
+
+	sweeper := lifecycleSweeper{objAPI: obj, endpoints: mustGetNewEndpointList(fsDirs...), nodeCount: 1}
+	if err = sweeper.sweepUploads(ctx, UTCNow(), make(chan struct{})); err != nil {
+		t.Fatal(err)
+	}
+	if uploads := getLocalMultipartUploads(sweeper.endpoints); len(uploads) != 2 {
+		t.Fatalf("expected recent uploads to be kept, got %v", uploads)
+	}
+
+	if err = sweeper.sweepUploads(ctx, UTCNow().Add(48*time.Hour), make(chan struct{})); err != nil {
+		t.Fatal(err)
+	}
+	uploads := getLocalMultipartUploads(sweeper.endpoints)
+	if len(uploads) != 1 || uploads[0].UploadID != uploadIDs["kept/a"] {
+		t.Fatalf("expected only upload %s to be kept, got %v", uploadIDs["kept/a"], uploads)
+	}
+	if _, err = obj.ListObjectParts(ctx, bucket, "uploads/a", uploadIDs["uploads/a"], 0, 1000); err == nil {
+		t.Fatal("expected the upload to be aborted")
+	}
+}
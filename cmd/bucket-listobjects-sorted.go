@@ -0,0 +1,149 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Value of the sort query parameter of ListObjectsV2, a Minio extension,
+// listing the newest objects first.
+const listObjectsSortModTime = "mtime"
+
+// maxSortedListObjects - the maximum number of objects under the prefix
+// of a listing sorted by modification time. Every page of the listing
+// lists all objects under the prefix, so larger prefixes are refused and
+// have to be listed by name or under longer prefixes.
+var maxSortedListObjects = 100000
+
+// errInvalidSortedListToken - the continuation token of a sorted listing is malformed.
+var errInvalidSortedListToken = errors.New("Invalid continuation token of objects sorted by modification time")
+
+// errTooManySortedListObjects - the prefix of a sorted listing has more
+// than maxSortedListObjects objects.
+var errTooManySortedListObjects = errors.New("Too many objects under the prefix to be sorted by modification time")
+
+// listObjectsFunc - lists objects as ObjectLayer.ListObjects does.
+type listObjectsFunc func(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (ListObjectsInfo, error)
+
+// isObjectNewer - returns true if a is listed before b, newer objects
+// are listed first and objects modified at the same time by name.
+func isObjectNewer(a, b ObjectInfo) bool {
+	if !a.ModTime.Equal(b.ModTime) {
+		return a.ModTime.After(b.ModTime)
+	}
+	return a.Name < b.Name
+}
+
+// getSortedListToken - returns the continuation token of a sorted
+// listing continuing after given object.
+func getSortedListToken(objInfo ObjectInfo) string {
+	token := strconv.FormatInt(objInfo.ModTime.UnixNano(), 10) + "/" + objInfo.Name
+	return base64.StdEncoding.EncodeToString([]byte(token))
+}
+
+// parseSortedListToken - returns the last object listed before the
+// continuation token of a sorted listing.
+func parseSortedListToken(token string) (ObjectInfo, error) {
+	data, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return ObjectInfo{}, errInvalidSortedListToken
+	}
+	fields := strings.SplitN(string(data), "/", 2)
+	if len(fields) != 2 {
+		return ObjectInfo{}, errInvalidSortedListToken
+	}
+	modTime, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return ObjectInfo{}, errInvalidSortedListToken
+	}
+	return ObjectInfo{Name: fields[1], ModTime: time.Unix(0, modTime).UTC()}, nil
+}
+
+// listObjectsSortedByModTime - lists up to maxKeys objects under prefix,
+// the newest first, continuing after the continuation token if any. All
+// objects under prefix are listed but only the newest maxKeys objects
+// not yet returned are kept. errTooManySortedListObjects is returned if
+// there are more than maxSortedListObjects objects under prefix.
+func listObjectsSortedByModTime(ctx context.Context, listObjects listObjectsFunc, bucket, prefix, token string, maxKeys int) (result ListObjectsV2Info, err error) {
+	var after *ObjectInfo
+	if token != "" {
+		objInfo, err := parseSortedListToken(token)
+		if err != nil {
+			return result, err
+		}
+		after = &objInfo
+	}
+
+	if maxKeys == 0 {
+		return result, nil
+	}
+	if maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	// One more object than requested is kept to know if the
+	// listing is truncated.
+	keep := maxKeys + 1
+	var objects []ObjectInfo
+	trim := func() {
+		sort.Slice(objects, func(i, j int) bool { return isObjectNewer(objects[i], objects[j]) })
+		if len(objects) > keep {
+			objects = objects[:keep]
+		}
+	}
+
+	marker := ""
+	listed := 0
+	for {
+		loi, err := listObjects(ctx, bucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return result, err
+		}
+		if listed += len(loi.Objects); listed > maxSortedListObjects {
+			return result, errTooManySortedListObjects
+		}
+		for _, objInfo := range loi.Objects {
+			if after != nil && !isObjectNewer(*after, objInfo) {
+				continue
+			}
+			objects = append(objects, objInfo)
+		}
+		if len(objects) > 2*keep {
+			trim()
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker = loi.NextMarker
+	}
+	trim()
+
+	if len(objects) > maxKeys {
+		objects = objects[:maxKeys]
+		result.IsTruncated = true
+		result.NextContinuationToken = getSortedListToken(objects[maxKeys-1])
+	}
+	result.Objects = objects
+	return result, nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling sorted ListObjectsV2 tests for both XL multiple disks and single node setup.
+func TestListObjectsSortedByModTime(t *testing.T) {
+	ExecObjectLayerAPITest(t, testListObjectsSortedByModTime, []string{"ListObjectsV2"})
+}
+
+func testListObjectsSortedByModTime(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+
+	for _, object := range []string{"b", "dir/a", "c"} {
+		if _, err := obj.PutObject(context.Background(), bucketName, object, mustGetHashReader(t, bytes.NewReader([]byte("hello")), 5, "", ""), nil); err != nil {
+			t.Fatalf("%s: Failed to create object: <ERROR> %v", instanceType, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	testCases := []struct {
+		query              map[string]string
+		expectedRespStatus int
+		expectedKeys       []string
+		expectedTruncated  bool
+	}{
+		// 1. Newest objects first.
+		{map[string]string{"sort": "mtime"}, http.StatusOK, []string{"c", "dir/a", "b"}, false},
+		// 2. Paginated listing.
+		{map[string]string{"sort": "mtime", "max-keys": "2"}, http.StatusOK, []string{"c", "dir/a"}, true},
+		// 3. Listing under a prefix.
+		{map[string]string{"sort": "mtime", "prefix": "dir/"}, http.StatusOK, []string{"dir/a"}, false},
+		// 4-6. Unsupported sort orders and arguments.
+		{map[string]string{"sort": "size"}, http.StatusNotImplemented, nil, false},
+		{map[string]string{"sort": "mtime", "delimiter": "/"}, http.StatusNotImplemented, nil, false},
+		{map[string]string{"sort": "mtime", "start-after": "b"}, http.StatusNotImplemented, nil, false},
+		// 7. Malformed continuation token.
+		{map[string]string{"sort": "mtime", "continuation-token": "invalid"}, http.StatusBadRequest, nil, false},
+	}
+
+	list := func(query map[string]string) (*httptest.ResponseRecorder, ListObjectsV2Response) {
+		queryValue := url.Values{}
+		queryValue.Set("list-type", "2")
+		for k, v := range query {
+			queryValue.Set(k, v)
+		}
+		req, err := newTestSignedRequestV4("GET", makeTestTargetURL("", bucketName, "", queryValue), 0, nil, credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		var response ListObjectsV2Response
+		if rec.Code == http.StatusOK {
+			if err = xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("%s: Failed to parse response: <ERROR> %v", instanceType, err)
+			}
+		}
+		return rec, response
+	}
+	keys := func(response ListObjectsV2Response) (keys []string) {
+		for _, object := range response.Contents {
+			keys = append(keys, object.Key)
+		}
+		return keys
+	}
+
+	for i, testCase := range testCases {
+		rec, response := list(testCase.query)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+		if !reflect.DeepEqual(keys(response), testCase.expectedKeys) {
+			t.Errorf("Test %d: %s: Expected the keys %v, but instead found %v", i+1, instanceType, testCase.expectedKeys, keys(response))
+		}
+		if response.IsTruncated != testCase.expectedTruncated {
+			t.Errorf("Test %d: %s: Expected truncated %t, but instead found %t", i+1, instanceType, testCase.expectedTruncated, response.IsTruncated)
+		}
+	}
+
+	// Continue a paginated listing.
+	_, response := list(map[string]string{"sort": "mtime", "max-keys": "2"})
+	_, response = list(map[string]string{"sort": "mtime", "max-keys": "2", "continuation-token": response.NextContinuationToken})
+	if !reflect.DeepEqual(keys(response), []string{"b"}) || response.IsTruncated {
+		t.Errorf("%s: Expected the last page to have the key b, but instead found %v", instanceType, keys(response))
+	}
+
+	// Prefixes of too many objects are not sorted.
+	defer func(max int) { maxSortedListObjects = max }(maxSortedListObjects)
+	maxSortedListObjects = 2
+	if rec, _ := list(map[string]string{"sort": "mtime"}); rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if rec, response := list(map[string]string{"sort": "mtime", "prefix": "dir/"}); rec.Code != http.StatusOK || !reflect.DeepEqual(keys(response), []string{"dir/a"}) {
+		t.Errorf("%s: Expected the keys [dir/a], but instead found `%d` %v", instanceType, rec.Code, keys(response))
+	}
+}
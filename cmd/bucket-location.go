@@ -0,0 +1,66 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"regexp"
+)
+
+// Bucket location configuration file, only stored for buckets created
+// in a region other than the region of the server.
+const bucketLocationConfig = "location.xml"
+
+// validLocation - region names, lower case words separated by hyphens.
+var validLocation = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Validates input location, a bucket is created in the configured
+// region of Minio server unless it declares a valid region of its own.
+func isValidLocation(location string) bool {
+	return location == globalServerConfig.GetRegion() || validLocation.MatchString(location)
+}
+
+// getBucketRegion - returns the region of given bucket name, the region
+// of the server if the bucket has no region of its own.
+func getBucketRegion(bucketName string) string {
+	if bucketName == "" || globalBucketMetadataSys == nil {
+		return globalServerConfig.GetRegion()
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketLocationConfig)
+	if !ok {
+		return globalServerConfig.GetRegion()
+	}
+	var location createBucketLocationConfiguration
+	if err := xml.Unmarshal(data, &location); err != nil || location.Location == "" {
+		return globalServerConfig.GetRegion()
+	}
+	return location.Location
+}
+
+// saveBucketLocation - saves the region of a new bucket if it is not
+// the region of the server.
+func saveBucketLocation(ctx context.Context, objAPI ObjectLayer, bucketName, location string) error {
+	if location == globalServerConfig.GetRegion() {
+		return nil
+	}
+	data, err := xml.Marshal(createBucketLocationConfiguration{Location: location})
+	if err != nil {
+		return err
+	}
+	return saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketLocationConfig, data)
+}
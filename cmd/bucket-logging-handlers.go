@@ -0,0 +1,155 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/logging"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// PutBucketLoggingHandler - This HTTP handler replaces the logging
+// status of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTlogging.html
+// An empty logging status disables access logging, target grants are
+// not supported.
+func (api objectAPIHandlers) PutBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketLogging")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsLoggingSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketLoggingAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// PutBucketLogging always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+
+	if r.ContentLength > maxLoggingConfigSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	config, err := logging.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		if err == logging.ErrTargetGrants {
+			writeErrorResponse(w, ErrNotImplemented, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	if config.LoggingEnabled == nil {
+		if err = saveBucketMetadataConfig(ctx, objectAPI, bucket, bucketLoggingConfig, nil); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	if _, err = objectAPI.GetBucketInfo(ctx, config.LoggingEnabled.TargetBucket); err != nil {
+		writeErrorResponse(w, ErrInvalidTargetBucketForLogging, r.URL)
+		return
+	}
+
+	// Access logs are written under the target prefix on behalf of the
+	// requester, who must be allowed to write there.
+	if !isObjectActionAllowed(r, policy.PutObjectAction, config.LoggingEnabled.TargetBucket, config.LoggingEnabled.TargetPrefix) {
+		writeErrorResponse(w, ErrAccessDenied, r.URL)
+		return
+	}
+
+	data, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err = saveBucketMetadataConfig(ctx, objectAPI, bucket, bucketLoggingConfig, data); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketLoggingHandler - This HTTP handler returns the logging status
+// of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketGETlogging.html
+// The logging status is empty if access logging is disabled.
+func (api objectAPIHandlers) GetBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketLogging")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsLoggingSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketLoggingAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	config, ok := getBucketLogging(bucket)
+	if !ok {
+		config = &logging.Config{}
+	}
+	config.XMLNS = "http://doc.s3.amazonaws.com/2006-03-01"
+
+	writeSuccessResponseXML(w, encodeResponse(config))
+}
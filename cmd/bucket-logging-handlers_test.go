@@ -0,0 +1,180 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/logging"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
+)
+
+// Wrapper for calling bucket logging handler tests for both XL multiple disks and single node setup.
+func TestBucketLoggingHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketLoggingHandlers, []string{"PutBucketLogging", "GetBucketLogging"})
+}
+
+func testBucketLoggingHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	serve := func(method, urlStr string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	getLogging := func() logging.Config {
+		rec := serve("GET", getBucketLoggingURL("", bucketName), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		var config logging.Config
+		if err := xml.Unmarshal(rec.Body.Bytes(), &config); err != nil {
+			t.Fatalf("%s: Failed to parse logging status: <ERROR> %v", instanceType, err)
+		}
+		return config
+	}
+
+	if config := getLogging(); config.LoggingEnabled != nil {
+		t.Fatalf("%s: Expected logging to be disabled, got %v", instanceType, config.LoggingEnabled)
+	}
+
+	if err := obj.MakeBucketWithLocation(context.Background(), "logs", ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	config := `<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"><LoggingEnabled><TargetBucket>logs</TargetBucket>` +
+		`<TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`
+	testCases := []struct {
+		bucketName   string
+		data         string
+		expectedCode int
+	}{
+		{bucketName, `<BucketLoggingStatus><LoggingEnabled><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, http.StatusBadRequest},
+		{bucketName, `<BucketLoggingStatus>`, http.StatusBadRequest},
+		{bucketName, `<BucketLoggingStatus><LoggingEnabled><TargetBucket>missing-bucket</TargetBucket></LoggingEnabled></BucketLoggingStatus>`, http.StatusBadRequest},
+		{bucketName, `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetGrants><Grant></Grant></TargetGrants>` +
+			`</LoggingEnabled></BucketLoggingStatus>`, http.StatusNotImplemented},
+		{"missing-bucket", config, http.StatusNotFound},
+		{bucketName, config, http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getBucketLoggingURL("", testCase.bucketName), []byte(testCase.data))
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+
+	expectedTarget := logging.LoggingEnabled{TargetBucket: "logs", TargetPrefix: "access/"}
+	if config := getLogging(); config.LoggingEnabled == nil || *config.LoggingEnabled != expectedTarget {
+		t.Fatalf("%s: Expected logging to %v, got %v", instanceType, expectedTarget, config.LoggingEnabled)
+	}
+
+	// An empty logging status disables logging.
+	if rec := serve("PUT", getBucketLoggingURL("", bucketName), []byte(`<BucketLoggingStatus></BucketLoggingStatus>`)); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if config := getLogging(); config.LoggingEnabled != nil {
+		t.Fatalf("%s: Expected logging to be disabled, got %v", instanceType, config.LoggingEnabled)
+	}
+}
+
+// Wrapper for calling bucket logging target permission tests for both XL multiple disks and single node setup.
+func TestBucketLoggingTargetAccess(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketLoggingTargetAccess, []string{"PutBucketLogging"})
+}
+
+func testBucketLoggingTargetAccess(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	origIAMSys := globalIAMSys
+	defer func() { globalIAMSys = origIAMSys }()
+	globalIAMSys = NewIAMSys()
+
+	if err := obj.MakeBucketWithLocation(context.Background(), "logs", ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	// The service account may only write access logs under "access/".
+	saCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("%s: Failed to generate credentials: <ERROR> %v", instanceType, err)
+	}
+	globalIAMSys.serviceAccounts[saCred.AccessKey] = serviceAccount{
+		Credentials: saCred,
+		ParentUser:  credentials.AccessKey,
+		Policy: &policy.Policy{
+			Version: policy.DefaultVersion,
+			Statements: []policy.Statement{
+				policy.NewStatement(
+					policy.Allow,
+					policy.NewPrincipal("*"),
+					policy.NewActionSet(policy.PutBucketLoggingAction),
+					policy.NewResourceSet(policy.NewResource(bucketName, "")),
+					condition.NewFunctions(),
+				),
+				policy.NewStatement(
+					policy.Allow,
+					policy.NewPrincipal("*"),
+					policy.NewActionSet(policy.PutObjectAction),
+					policy.NewResourceSet(policy.NewResource("logs", "access/*")),
+					condition.NewFunctions(),
+				),
+			},
+		},
+	}
+
+	testCases := []struct {
+		targetPrefix string
+		cred         auth.Credentials
+		expectedCode int
+	}{
+		{"access/", saCred, http.StatusOK},
+		{"other/", saCred, http.StatusForbidden},
+		{"other/", credentials, http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		data := []byte(`<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket>` +
+			`<TargetPrefix>` + testCase.targetPrefix + `</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`)
+		req, err := newTestSignedRequestV4("PUT", getBucketLoggingURL("", bucketName), int64(len(data)), bytes.NewReader(data),
+			testCase.cred.AccessKey, testCase.cred.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/logging"
+)
+
+const (
+	// Bucket logging configuration file.
+	bucketLoggingConfig = "logging.xml"
+
+	// Maximum size of a logging configuration in a put-bucket-logging request.
+	maxLoggingConfigSize = 64 * 1024
+
+	// Access log records are buffered in memory, a log object is
+	// written once this size is reached.
+	maxAccessLogObjectSize = 4 * 1024 * 1024
+)
+
+// Interval between two deliveries of the buffered access log records.
+var globalBucketLoggingInterval = 5 * time.Minute
+
+// Buffers the access log records of all buckets with logging enabled.
+var globalBucketLoggingSys = NewBucketLoggingSys()
+
+// getBucketLogging - returns the logging configuration of given bucket
+// name, false if the bucket has none.
+func getBucketLogging(bucketName string) (*logging.Config, bool) {
+	if globalBucketMetadataSys == nil {
+		return nil, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketLoggingConfig)
+	if !ok {
+		return nil, false
+	}
+	var config logging.Config
+	if err := xml.Unmarshal(data, &config); err != nil {
+		return nil, false
+	}
+	return &config, true
+}
+
+// accessLogTarget - source bucket of access log records along with the
+// bucket and key prefix of their log objects.
+type accessLogTarget struct {
+	sourceBucket string
+	targetBucket string
+	targetPrefix string
+}
+
+// BucketLoggingSys - access logging subsystem, buffers the access log
+// records of buckets and writes them as log objects into their target
+// bucket.
+type BucketLoggingSys struct {
+	sync.Mutex
+	logs map[accessLogTarget]*bytes.Buffer
+}
+
+// NewBucketLoggingSys - creates new bucket logging system.
+func NewBucketLoggingSys() *BucketLoggingSys {
+	return &BucketLoggingSys{logs: make(map[accessLogTarget]*bytes.Buffer)}
+}
+
+// Add - buffers an access log record for given target, the records are
+// written in background once they are large enough.
+func (sys *BucketLoggingSys) Add(target accessLogTarget, entry logging.Entry) {
+	sys.Lock()
+	buf, ok := sys.logs[target]
+	if !ok {
+		buf = new(bytes.Buffer)
+		sys.logs[target] = buf
+	}
+	buf.WriteString(entry.String())
+	buf.WriteByte('\n')
+	if buf.Len() < maxAccessLogObjectSize {
+		sys.Unlock()
+		return
+	}
+	delete(sys.logs, target)
+	sys.Unlock()
+
+	if objAPI := newObjectLayerFn(); objAPI != nil {
+		go sys.deliver(context.Background(), objAPI, target, buf.Bytes())
+	}
+}
+
+// Flush - writes all buffered access log records.
+func (sys *BucketLoggingSys) Flush(ctx context.Context, objAPI ObjectLayer) {
+	sys.Lock()
+	logs := sys.logs
+	sys.logs = make(map[accessLogTarget]*bytes.Buffer)
+	sys.Unlock()
+
+	for target, buf := range logs {
+		sys.deliver(ctx, objAPI, target, buf.Bytes())
+	}
+}
+
+// deliver - writes access log records as a new log object named
+// TargetPrefixYYYY-mm-DD-HH-MM-SS-UniqueString as per
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/ServerLogs.html
+// Records are dropped if the target bucket can't be written.
+func (sys *BucketLoggingSys) deliver(ctx context.Context, objAPI ObjectLayer, target accessLogTarget, data []byte) {
+	uniqueID := strings.ToUpper(strings.Replace(mustGetUUID(), "-", "", -1))[:16]
+	object := target.targetPrefix + UTCNow().Format("2006-01-02-15-04-05-") + uniqueID
+
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), getMD5Hash(data), getSHA256Hash(data))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	metadata := map[string]string{"content-type": "text/plain"}
+	setObjectVersionID(target.targetBucket, metadata)
+	_, err = objAPI.PutObject(ctx, target.targetBucket, object, hashReader, metadata)
+	logger.LogIf(ctx, err)
+}
+
+// initBucketLogging - starts delivering the buffered access log records
+// periodically.
+func initBucketLogging(objAPI ObjectLayer) {
+	go func() {
+		ticker := time.NewTicker(globalBucketLoggingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+				globalBucketLoggingSys.Flush(context.Background(), objAPI)
+			}
+		}
+	}()
+}
+
+// Subresources of access logged operations, in the order they are
+// looked up in the query of a request, along with their resource type
+// for bucket and object requests.
+var accessLogSubresources = []struct {
+	query      string
+	bucketType string
+	objectType string
+}{
+	{"uploadId", "", "UPLOAD"},
+	{"uploads", "UPLOADS", "UPLOADS"},
+	{"delete", "MULTI_OBJECT_DELETE", ""},
+	{"acl", "ACL", "ACL"},
+	{"cors", "CORS", ""},
+	{"encryption", "ENCRYPTION", ""},
+	{"inventory", "INVENTORY", ""},
+	{"legal-hold", "", "LEGAL_HOLD"},
+	{"lifecycle", "LIFECYCLE", ""},
+	{"location", "LOCATION", ""},
+	{"logging", "LOGGING_STATUS", ""},
+	{"notification", "NOTIFICATION", ""},
+	{"object-lock", "OBJECT_LOCK_CONFIGURATION", ""},
+	{"policy", "BUCKETPOLICY", ""},
+	{"replication", "REPLICATION", ""},
+	{"restore", "", "RESTORE"},
+	{"retention", "", "RETENTION"},
+	{"select", "", "SELECT"},
+	{"tagging", "TAGGING", "OBJECT_TAGGING"},
+	{"versioning", "VERSIONING", ""},
+	{"versions", "BUCKETVERSIONS", ""},
+	{"website", "WEBSITE", ""},
+}
+
+// getAccessLogOperation - returns the operation of a request as written
+// in access log records, i.e. REST.HTTP_method.resource_type.
+func getAccessLogOperation(r *http.Request, object string) string {
+	query := r.URL.Query()
+	resourceType := "BUCKET"
+	if object != "" {
+		resourceType = "OBJECT"
+		if r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "" {
+			resourceType = "COPY"
+		}
+	}
+	for _, subresource := range accessLogSubresources {
+		if _, ok := query[subresource.query]; !ok {
+			continue
+		}
+		if object == "" && subresource.bucketType != "" {
+			resourceType = subresource.bucketType
+			break
+		}
+		if object != "" && subresource.objectType != "" {
+			resourceType = subresource.objectType
+			if _, ok := query["partNumber"]; ok && subresource.query == "uploadId" {
+				resourceType = "PART"
+			}
+			break
+		}
+	}
+	return "REST." + r.Method + "." + resourceType
+}
+
+// OpenSSL names of the cipher suites enabled by the server, as written
+// in access log records.
+var accessLogCipherSuites = map[uint16]string{
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305:    "ECDHE-RSA-CHACHA20-POLY1305",
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305:  "ECDHE-ECDSA-CHACHA20-POLY1305",
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:   "ECDHE-RSA-AES128-GCM-SHA256",
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256: "ECDHE-ECDSA-AES128-GCM-SHA256",
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384:   "ECDHE-RSA-AES256-GCM-SHA384",
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384: "ECDHE-ECDSA-AES256-GCM-SHA384",
+}
+
+var accessLogTLSVersions = map[uint16]string{
+	tls.VersionTLS10: "TLSv1",
+	tls.VersionTLS11: "TLSv1.1",
+	tls.VersionTLS12: "TLSv1.2",
+}
+
+// getAccessLogObjectSize - returns the size of the object read or
+// written by a successful object request, 0 if it is unknown.
+func getAccessLogObjectSize(r *http.Request, w *httpResponseRecorder) int64 {
+	var size string
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		size = w.Header().Get("Content-Length")
+		if contentRange := w.Header().Get("Content-Range"); contentRange != "" {
+			size = contentRange[strings.LastIndex(contentRange, "/")+1:]
+		}
+	case http.MethodPut, http.MethodPost:
+		size = strconv.FormatInt(r.ContentLength, 10)
+		if decodedLength := r.Header.Get("X-Amz-Decoded-Content-Length"); decodedLength != "" {
+			size = decodedLength
+		}
+	}
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// newAccessLogEntry - returns the access log record of a request served
+// between start and end.
+func newAccessLogEntry(r *http.Request, w *httpResponseRecorder, bucket, object string, start, end time.Time) logging.Entry {
+	statusCode := w.respStatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	requestURI := r.RequestURI
+	if requestURI == "" {
+		requestURI = r.URL.RequestURI()
+	}
+
+	entry := logging.Entry{
+		BucketOwner: globalMinioDefaultOwnerID,
+		Bucket:      bucket,
+		Time:        start,
+		RemoteIP:    handlers.GetSourceIP(r),
+		Requester:   getReqAccessKey(r),
+		RequestID:   w.Header().Get(responseRequestIDKey),
+		Operation:   getAccessLogOperation(r, object),
+		Key:         s3utils.EncodePath(object),
+		RequestURI:  r.Method + " " + requestURI + " " + r.Proto,
+		HTTPStatus:  statusCode,
+		ErrorCode:   w.errorCode,
+		BytesSent:   int64(w.bytesWritten),
+		TotalTime:   end.Sub(start),
+		Referer:     r.Referer(),
+		UserAgent:   r.UserAgent(),
+		VersionID:   w.Header().Get(amzVersionID),
+		HostHeader:  r.Host,
+	}
+	if object != "" && statusCode < http.StatusMultipleChoices {
+		entry.ObjectSize = getAccessLogObjectSize(r, w)
+	}
+
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypeStreamingSigned:
+		entry.SignatureVersion, entry.AuthType = "SigV4", "AuthHeader"
+	case authTypePresigned:
+		entry.SignatureVersion, entry.AuthType = "SigV4", "QueryString"
+	case authTypeSignedV2:
+		entry.SignatureVersion, entry.AuthType = "SigV2", "AuthHeader"
+	case authTypePresignedV2:
+		entry.SignatureVersion, entry.AuthType = "SigV2", "QueryString"
+	case authTypePostPolicy:
+		entry.SignatureVersion, entry.AuthType = "SigV4", "HTTPPost"
+	}
+
+	if r.TLS != nil {
+		entry.CipherSuite = accessLogCipherSuites[r.TLS.CipherSuite]
+		entry.TLSVersion = accessLogTLSVersions[r.TLS.Version]
+	}
+	return entry
+}
+
+// logBucketAccess - buffers the access log record of a request served
+// between start and end, if the bucket it is sent to has logging
+// enabled.
+func logBucketAccess(r *http.Request, w *httpResponseRecorder, bucket, object string, start, end time.Time) {
+	if bucket == "" {
+		return
+	}
+	config, ok := getBucketLogging(bucket)
+	if !ok || config.LoggingEnabled == nil {
+		return
+	}
+	globalBucketLoggingSys.Add(accessLogTarget{
+		sourceBucket: bucket,
+		targetBucket: config.LoggingEnabled.TargetBucket,
+		targetPrefix: config.LoggingEnabled.TargetPrefix,
+	}, newAccessLogEntry(r, w, bucket, object, start, end))
+}
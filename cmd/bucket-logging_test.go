@@ -0,0 +1,138 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/logging"
+)
+
+func TestGetAccessLogOperation(t *testing.T) {
+	testCases := []struct {
+		method            string
+		urlStr            string
+		copySource        string
+		object            string
+		expectedOperation string
+	}{
+		{"GET", "/bucket", "", "", "REST.GET.BUCKET"},
+		{"GET", "/bucket?versions&prefix=a", "", "", "REST.GET.BUCKETVERSIONS"},
+		{"PUT", "/bucket?logging", "", "", "REST.PUT.LOGGING_STATUS"},
+		{"GET", "/bucket?policy", "", "", "REST.GET.BUCKETPOLICY"},
+		{"POST", "/bucket?delete", "", "", "REST.POST.MULTI_OBJECT_DELETE"},
+		{"GET", "/bucket?tagging", "", "", "REST.GET.TAGGING"},
+		{"GET", "/bucket/object", "", "object", "REST.GET.OBJECT"},
+		{"PUT", "/bucket/object", "/bucket/source", "object", "REST.PUT.COPY"},
+		{"GET", "/bucket/object?tagging", "", "object", "REST.GET.OBJECT_TAGGING"},
+		{"POST", "/bucket/object?uploads", "", "object", "REST.POST.UPLOADS"},
+		{"PUT", "/bucket/object?partNumber=1&uploadId=abc", "", "object", "REST.PUT.PART"},
+		{"POST", "/bucket/object?uploadId=abc", "", "object", "REST.POST.UPLOAD"},
+		{"DELETE", "/bucket/object?versionId=abc", "", "object", "REST.DELETE.OBJECT"},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(testCase.method, testCase.urlStr, nil)
+		if testCase.copySource != "" {
+			req.Header.Set("X-Amz-Copy-Source", testCase.copySource)
+		}
+		if operation := getAccessLogOperation(req, testCase.object); operation != testCase.expectedOperation {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedOperation, operation)
+		}
+	}
+}
+
+// Tests that the access log records of requests are written into the
+// target bucket of their bucket.
+func TestBucketLoggingDelivery(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketLoggingDelivery)
+}
+
+func testBucketLoggingDelivery(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+	globalBucketLoggingSys = NewBucketLoggingSys()
+
+	ctx := context.Background()
+	for _, bucket := range []string{"bucket", "other", "logs"} {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+	data, err := xml.Marshal(logging.Config{LoggingEnabled: &logging.LoggingEnabled{TargetBucket: "logs", TargetPrefix: "access/"}})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err = saveBucketMetadataConfig(ctx, obj, "bucket", bucketLoggingConfig, data); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	router := mux.NewRouter()
+	router.Path("/{bucket}/{object:.+}").HandlerFunc(collectAPIStats("GetObject", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(responseRequestIDKey, "1559F81B0C5BC3E8")
+		writeErrorResponse(w, ErrNoSuchKey, r.URL)
+	}))
+	for _, bucket := range []string{"bucket", "other"} {
+		req, err := newTestSignedRequestV4("GET", "http://localhost:9000/"+bucket+"/photos/a%20b.jpg", 0, nil, "minio", "minio123")
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		req.Header.Set("User-Agent", "Minio (linux; amd64) minio-go/6.0.7")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	globalBucketLoggingSys.Flush(ctx, obj)
+
+	result, err := obj.ListObjects(ctx, "logs", "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("%s: expected 1 log object, got %d", instanceType, len(result.Objects))
+	}
+	if !regexp.MustCompile(`^access/\d{4}-\d{2}-\d{2}-\d{2}-\d{2}-\d{2}-[0-9A-F]{16}$`).MatchString(result.Objects[0].Name) {
+		t.Fatalf("%s: unexpected log object name %s", instanceType, result.Objects[0].Name)
+	}
+
+	var buf bytes.Buffer
+	if err = obj.GetObject(ctx, "logs", result.Objects[0].Name, 0, -1, &buf, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	records := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(records) != 1 {
+		t.Fatalf("%s: expected 1 access log record, got %d", instanceType, len(records))
+	}
+	expectedRecord := regexp.MustCompile(`^` + globalMinioDefaultOwnerID + ` bucket \[[^]]+ \+0000\] [^ ]+ minio 1559F81B0C5BC3E8 REST.GET.OBJECT photos/a%20b.jpg ` +
+		`"GET /bucket/photos/a%20b.jpg HTTP/1.1" 404 NoSuchKey \d+ - \d+ - - "Minio \(linux; amd64\) minio-go/6.0.7" - - SigV4 - AuthHeader localhost:9000 -$`)
+	if !expectedRecord.MatchString(records[0]) {
+		t.Fatalf("%s: unexpected access log record %s", instanceType, records[0])
+	}
+
+	// Nothing is written if no records are buffered.
+	globalBucketLoggingSys.Flush(ctx, obj)
+	if result, err = obj.ListObjects(ctx, "logs", "", "", "", 1000); err != nil || len(result.Objects) != 1 {
+		t.Fatalf("%s: expected 1 log object, got %d, %v", instanceType, len(result.Objects), err)
+	}
+}
@@ -0,0 +1,285 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Metadata index configuration file.
+	bucketMetadataIndexConfig = "metadata-index.json"
+
+	// Metadata index of the objects of a bucket, not cached by
+	// BucketMetadataSys as it grows with the number of objects.
+	bucketMetadataIndexFile = "metadata-index-objects.json"
+
+	// Maximum size of a metadata index configuration.
+	maxBucketMetadataIndexConfigSize = 1024
+)
+
+// Interval between two scans of the buckets having a metadata index.
+var globalMetadataIndexInterval = 1 * time.Hour
+
+var errMetadataIndexScanStopped = errors.New("metadata index scan stopped")
+
+// BucketMetadataIndex - whether a bucket has a metadata index searched
+// by search requests, which is built by scanning the bucket.
+type BucketMetadataIndex struct {
+	Enabled bool `json:"enabled"`
+}
+
+// metadataIndexEntry - searchable metadata of an object, i.e. its content
+// type, user metadata and tags.
+type metadataIndexEntry struct {
+	Name     string            `json:"name"`
+	ModTime  time.Time         `json:"modTime"`
+	Size     int64             `json:"size"`
+	ETag     string            `json:"etag,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// toObjectInfo - returns the object info of an index entry to match
+// search requests.
+func (e metadataIndexEntry) toObjectInfo(bucket string) ObjectInfo {
+	return ObjectInfo{
+		Bucket:      bucket,
+		Name:        e.Name,
+		ModTime:     e.ModTime,
+		Size:        e.Size,
+		ETag:        e.ETag,
+		ContentType: e.Metadata["content-type"],
+		UserDefined: e.Metadata,
+	}
+}
+
+// newMetadataIndexEntry - returns the index entry of an object.
+func newMetadataIndexEntry(objInfo ObjectInfo) metadataIndexEntry {
+	entry := metadataIndexEntry{
+		Name:    objInfo.Name,
+		ModTime: objInfo.ModTime,
+		Size:    objInfo.Size,
+		ETag:    objInfo.ETag,
+	}
+	add := func(k, v string) {
+		if entry.Metadata == nil {
+			entry.Metadata = make(map[string]string)
+		}
+		entry.Metadata[k] = v
+	}
+	if objInfo.ContentType != "" {
+		add("content-type", objInfo.ContentType)
+	}
+	for k, v := range objInfo.UserDefined {
+		if k == objectTaggingKey || strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			add(k, v)
+		}
+	}
+	return entry
+}
+
+// metadataIndex - metadata of the objects of a bucket ordered by name,
+// as scanned at the time it was updated.
+type metadataIndex struct {
+	Updated time.Time            `json:"updated"`
+	Objects []metadataIndexEntry `json:"objects"`
+}
+
+// getBucketMetadataIndexConfig - returns the metadata index configuration
+// of given bucket name, false if the bucket has none.
+func getBucketMetadataIndexConfig(bucketName string) (BucketMetadataIndex, bool) {
+	var config BucketMetadataIndex
+	if globalBucketMetadataSys == nil {
+		return config, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketMetadataIndexConfig)
+	if !ok {
+		return config, false
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return BucketMetadataIndex{}, false
+	}
+	return config, true
+}
+
+// saveBucketMetadataIndexConfig - saves the metadata index configuration
+// of given bucket name, disabling the index removes it.
+func saveBucketMetadataIndexConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config BucketMetadataIndex) error {
+	if !config.Enabled {
+		if err := saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketMetadataIndexConfig, nil); err != nil {
+			return err
+		}
+		return removeBucketMetadataIndex(ctx, objAPI, bucketName)
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketMetadataIndexConfig, data)
+}
+
+// getBucketMetadataIndexFile - returns the path to the metadata index of given bucket name in minioMetaBucket.
+func getBucketMetadataIndexFile(bucketName string) string {
+	return path.Join(bucketConfigPrefix, bucketName, bucketMetadataIndexFile)
+}
+
+// readBucketMetadataIndex - reads the metadata index of given bucket
+// name from the backend, errConfigNotFound if it is not built yet.
+func readBucketMetadataIndex(ctx context.Context, objAPI ObjectLayer, bucketName string) (*metadataIndex, error) {
+	reader, err := readConfig(ctx, objAPI, getBucketMetadataIndexFile(bucketName))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var index metadataIndex
+	if err = json.Unmarshal(data, &index); err != nil {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// getBucketMetadataIndex - returns the metadata index of given bucket
+// name, false if its index is disabled or not yet built.
+func getBucketMetadataIndex(ctx context.Context, objAPI ObjectLayer, bucketName string) (*metadataIndex, bool) {
+	if config, _ := getBucketMetadataIndexConfig(bucketName); !config.Enabled {
+		return nil, false
+	}
+	index, err := readBucketMetadataIndex(ctx, objAPI, bucketName)
+	if err != nil {
+		if err != errConfigNotFound {
+			reqInfo := &logger.ReqInfo{BucketName: bucketName}
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+		}
+		return nil, false
+	}
+	return index, true
+}
+
+// buildBucketMetadataIndex - scans the objects of given bucket name and
+// saves their metadata index, returns errMetadataIndexScanStopped if
+// doneCh is closed meanwhile.
+func buildBucketMetadataIndex(ctx context.Context, objAPI ObjectLayer, bucketName string, doneCh <-chan struct{}) error {
+	index := metadataIndex{Updated: UTCNow(), Objects: []metadataIndexEntry{}}
+	marker := ""
+	for {
+		if !waitForScanner(doneCh) {
+			return errMetadataIndexScanStopped
+		}
+
+		result, err := objAPI.ListObjects(ctx, bucketName, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, object := range result.Objects {
+			index.Objects = append(index.Objects, newMetadataIndexEntry(object))
+		}
+
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			break
+		}
+		marker = result.NextMarker
+		if marker == "" {
+			marker = result.Objects[len(result.Objects)-1].Name
+		}
+	}
+
+	// The index is not saved if it was disabled during the scan.
+	if config, _ := getBucketMetadataIndexConfig(bucketName); !config.Enabled {
+		return nil
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return saveConfig(objAPI, getBucketMetadataIndexFile(bucketName), data)
+}
+
+// removeBucketMetadataIndex - removes the metadata index of given bucket name.
+func removeBucketMetadataIndex(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, getBucketMetadataIndexFile(bucketName)); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// scanBucketMetadataIndexes - builds the metadata indexes of all buckets
+// having one enabled, returns errMetadataIndexScanStopped if doneCh is
+// closed meanwhile.
+func scanBucketMetadataIndexes(ctx context.Context, objAPI ObjectLayer, doneCh <-chan struct{}) error {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		if config, _ := getBucketMetadataIndexConfig(bucket.Name); !config.Enabled {
+			continue
+		}
+		err = buildBucketMetadataIndex(ctx, objAPI, bucket.Name, doneCh)
+		if err == errMetadataIndexScanStopped {
+			return err
+		}
+		if err != nil {
+			reqInfo := &logger.ReqInfo{BucketName: bucket.Name}
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+		}
+	}
+	return nil
+}
+
+// initBucketMetadataIndexer - starts building the metadata indexes of
+// buckets in background. Indexes are shared by all servers and built
+// by the first one only.
+func initBucketMetadataIndexer(objAPI ObjectLayer) {
+	if nodeIndex, _ := GetLocalPeerIndex(globalEndpoints); nodeIndex != 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(globalMetadataIndexInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+				if scanBucketMetadataIndexes(context.Background(), objAPI, globalServiceDoneCh) == errMetadataIndexScanStopped {
+					return
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,241 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+)
+
+// bucketMetadataConfigs - bucket config files, stored next to the bucket
+// policy in minioMetaBucket, which are cached by BucketMetadataSys as
+// they are needed by object requests.
+var bucketMetadataConfigs = []string{
+	bucketVersioningConfig,
+	bucketObjectLockConfig,
+	bucketTaggingConfig,
+	bucketLifecycleConfig,
+	bucketReplicationConfig,
+	bucketEncryptionConfig,
+	bucketCorsConfig,
+	bucketInventoryConfig,
+	bucketWebsiteConfig,
+	bucketLoggingConfig,
+	bucketLocationConfig,
+	bucketDefaultTagsConfig,
+	bucketIntegrityConfig,
+	bucketMetadataIndexConfig,
+}
+
+// BucketMetadataSys - bucket metadata subsystem, caches the raw content
+// of the bucket config files listed in bucketMetadataConfigs.
+type BucketMetadataSys struct {
+	sync.RWMutex
+	metadataMap map[string]map[string][]byte
+}
+
+// Get - returns the content of the config file of given bucket name.
+func (sys *BucketMetadataSys) Get(bucketName, configFile string) (data []byte, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	data, ok = sys.metadataMap[bucketName][configFile]
+	return data, ok
+}
+
+// Set - replaces the config file of given bucket name, nil data removes it.
+func (sys *BucketMetadataSys) Set(bucketName, configFile string, data []byte) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.set(bucketName, configFile, data)
+}
+
+func (sys *BucketMetadataSys) set(bucketName, configFile string, data []byte) {
+	configs, ok := sys.metadataMap[bucketName]
+	if data == nil {
+		delete(configs, configFile)
+		if ok && len(configs) == 0 {
+			delete(sys.metadataMap, bucketName)
+		}
+		return
+	}
+	if !ok {
+		configs = make(map[string][]byte)
+		sys.metadataMap[bucketName] = configs
+	}
+	configs[configFile] = data
+}
+
+// Remove - removes all config files of given bucket name.
+func (sys *BucketMetadataSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.metadataMap, bucketName)
+}
+
+// Load - reloads all config files of given bucket name from the backend.
+func (sys *BucketMetadataSys) Load(objAPI ObjectLayer, bucketName string) error {
+	configs := make(map[string][]byte)
+	for _, configFile := range bucketMetadataConfigs {
+		data, err := readBucketMetadataConfig(context.Background(), objAPI, bucketName, configFile)
+		if err != nil {
+			return err
+		}
+		configs[configFile] = data
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	for configFile, data := range configs {
+		sys.set(bucketName, configFile, data)
+	}
+	return nil
+}
+
+// removeDeletedBuckets - removes config files of buckets missed by delete-bucket notifications.
+func (sys *BucketMetadataSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.metadataMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.metadataMap, bucket)
+		}
+	}
+}
+
+// Refresh BucketMetadataSys.
+func (sys *BucketMetadataSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		if err = sys.Load(objAPI, bucket.Name); err != nil {
+			logger.LogIf(context.Background(), err)
+		}
+	}
+	return nil
+}
+
+// Init - initializes bucket metadata system from the config files of all buckets.
+func (sys *BucketMetadataSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	// Load BucketMetadataSys once during boot.
+	if err := sys.refresh(objAPI); err != nil {
+		return err
+	}
+
+	// Refresh BucketMetadataSys in background.
+	go func() {
+		ticker := time.NewTicker(globalRefreshBucketMetadataInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+				sys.refresh(objAPI)
+			}
+		}
+	}()
+	return nil
+}
+
+// NewBucketMetadataSys - creates new bucket metadata system.
+func NewBucketMetadataSys() *BucketMetadataSys {
+	return &BucketMetadataSys{
+		metadataMap: make(map[string]map[string][]byte),
+	}
+}
+
+// getBucketMetadataConfigFile - returns the path to the config file of given bucket name in minioMetaBucket.
+func getBucketMetadataConfigFile(bucketName, configFile string) string {
+	return path.Join(bucketConfigPrefix, bucketName, configFile)
+}
+
+// readBucketMetadataConfig - reads the config file of given bucket name
+// from the backend, returns nil if the bucket has no such config.
+func readBucketMetadataConfig(ctx context.Context, objAPI ObjectLayer, bucketName, configFile string) ([]byte, error) {
+	reader, err := readConfig(ctx, objAPI, getBucketMetadataConfigFile(bucketName, configFile))
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ioutil.ReadAll(reader)
+}
+
+// saveBucketMetadataConfig - writes the config file of given bucket name
+// to the backend, nil data removes it. On success the config is cached
+// and peers are asked to reload it.
+func saveBucketMetadataConfig(ctx context.Context, objAPI ObjectLayer, bucketName, configFile string, data []byte) error {
+	if data == nil {
+		if err := removeBucketMetadataConfig(ctx, objAPI, bucketName, configFile); err != nil {
+			return err
+		}
+	} else if err := saveConfig(objAPI, getBucketMetadataConfigFile(bucketName, configFile), data); err != nil {
+		return err
+	}
+
+	if globalBucketMetadataSys != nil {
+		globalBucketMetadataSys.Set(bucketName, configFile, data)
+	}
+	if globalNotificationSys != nil {
+		globalNotificationSys.LoadBucketMetadata(ctx, bucketName)
+	}
+	return nil
+}
+
+// removeBucketMetadataConfig - removes the config file of given bucket name from the backend.
+func removeBucketMetadataConfig(ctx context.Context, objAPI ObjectLayer, bucketName, configFile string) error {
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, getBucketMetadataConfigFile(bucketName, configFile)); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// removeBucketMetadataConfigs - removes all config files cached by
+// BucketMetadataSys of given bucket name from the backend.
+func removeBucketMetadataConfigs(ctx context.Context, objAPI ObjectLayer, bucketName string) {
+	for _, configFile := range bucketMetadataConfigs {
+		removeBucketMetadataConfig(ctx, objAPI, bucketName, configFile)
+	}
+}
@@ -21,6 +21,10 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/logger"
@@ -38,9 +42,46 @@ const (
 
 var errNoSuchNotifications = errors.New("The specified bucket does not have bucket notifications")
 
+// NotificationARNErrorResponse - error response of a notification
+// configuration with an ARN which is not of a target of the server,
+// along with the ARNs of the targets configured on the server.
+type NotificationARNErrorResponse struct {
+	XMLName       xml.Name `xml:"Error" json:"-"`
+	Code          string
+	Message       string
+	ARN           string
+	SupportedARNs []string `xml:"SupportedARNs>ARN"`
+	BucketName    string   `xml:"BucketName,omitempty"`
+	Resource      string
+	RequestID     string `xml:"RequestId"`
+	HostID        string `xml:"HostId"`
+}
+
+// writeNotificationARNErrorResponse - writes the error response of an
+// unknown ARN of a notification configuration.
+func writeNotificationARNErrorResponse(w http.ResponseWriter, arn string, bucketName string, reqURL *url.URL) {
+	supportedARNs := globalNotificationSys.GetARNList()
+	sort.Strings(supportedARNs)
+
+	apiError := getAPIError(ErrARNNotification)
+	recordErrorCode(w, apiError.Code)
+	errorResponse := NotificationARNErrorResponse{
+		Code:          apiError.Code,
+		Message:       apiError.Description,
+		ARN:           arn,
+		SupportedARNs: supportedARNs,
+		BucketName:    bucketName,
+		Resource:      reqURL.Path,
+		RequestID:     w.Header().Get(responseRequestIDKey),
+		HostID:        "3L137",
+	}
+	writeResponse(w, apiError.HTTPStatusCode, encodeResponse(errorResponse), mimeXML)
+}
+
 // GetBucketNotificationHandler - This HTTP handler returns event notification configuration
 // as per http://docs.aws.amazon.com/AmazonS3/latest/dev/NotificationHowTo.html.
-// It returns empty configuration if its not set.
+// It returns empty configuration if its not set. Only the queue configurations of
+// targets configured on the server are returned, with the ARNs of the server region.
 func (api objectAPIHandlers) GetBucketNotificationHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "GetBucketNotification")
 
@@ -130,6 +171,16 @@ func (api objectAPIHandlers) PutBucketNotificationHandler(w http.ResponseWriter,
 	var config *event.Config
 	config, err = event.ParseConfig(io.LimitReader(r.Body, r.ContentLength), globalServerConfig.GetRegion(), globalNotificationSys.targetList)
 	if err != nil {
+		// Unknown ARNs are reported along with the ARNs of the targets.
+		switch e := err.(type) {
+		case *event.ErrARNNotFound:
+			writeNotificationARNErrorResponse(w, e.ARN.String(), bucketName, r.URL)
+			return
+		case *event.ErrInvalidARN:
+			writeNotificationARNErrorResponse(w, e.ARN, bucketName, r.URL)
+			return
+		}
+
 		apiErr := ErrMalformedXML
 		if event.IsEventError(err) {
 			apiErr = toAPIErrorCode(err)
@@ -152,7 +203,10 @@ func (api objectAPIHandlers) PutBucketNotificationHandler(w http.ResponseWriter,
 }
 
 // ListenBucketNotificationHandler - This HTTP handler sends events to the connected HTTP client.
-// Client should send prefix/suffix object name to match and events to watch as query parameters.
+// Client should send prefix/suffix object name to match and events to watch as query parameters,
+// an object matches if it has any of the prefixes and any of the suffixes. A client resuming after
+// a reconnect sends the sequencer of the last event received as token, the recent events of this
+// server since are sent first. Keep-alive records with no events are sent every ping seconds, if set.
 func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ListenBucketNotification")
 
@@ -177,34 +231,28 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 
 	values := r.URL.Query()
 
-	var prefix string
-	if len(values["prefix"]) > 1 {
-		writeErrorResponse(w, ErrFilterNamePrefix, r.URL)
+	prefixes := []string{""}
+	if len(values["prefix"]) > 0 {
+		prefixes = values["prefix"]
+	}
+	suffixes := []string{""}
+	if len(values["suffix"]) > 0 {
+		suffixes = values["suffix"]
 	}
-	if len(values["prefix"]) == 1 {
-		if err := event.ValidateFilterRuleValue(values["prefix"][0]); err != nil {
+	for _, value := range append(append([]string{}, prefixes...), suffixes...) {
+		if err := event.ValidateFilterRuleValue(value); err != nil {
 			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 			return
 		}
-
-		prefix = values["prefix"][0]
 	}
 
-	var suffix string
-	if len(values["suffix"]) > 1 {
-		writeErrorResponse(w, ErrFilterNameSuffix, r.URL)
-	}
-	if len(values["suffix"]) == 1 {
-		if err := event.ValidateFilterRuleValue(values["suffix"][0]); err != nil {
-			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
-			return
+	var patterns []string
+	for _, prefix := range prefixes {
+		for _, suffix := range suffixes {
+			patterns = append(patterns, event.NewPattern(prefix, suffix))
 		}
-
-		suffix = values["suffix"][0]
 	}
 
-	pattern := event.NewPattern(prefix, suffix)
-
 	eventNames := []event.Name{}
 	for _, s := range values["events"] {
 		eventName, err := event.ParseName(s)
@@ -216,6 +264,25 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 		eventNames = append(eventNames, eventName)
 	}
 
+	var token uint64
+	if value := values.Get("token"); value != "" {
+		var err error
+		if token, err = parseListenToken(value); err != nil {
+			writeErrorResponse(w, ErrInvalidListenToken, r.URL)
+			return
+		}
+	}
+
+	var keepAlive time.Duration
+	if value := values.Get("ping"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 1 || seconds > 3600 {
+			writeErrorResponse(w, ErrInvalidListenPing, r.URL)
+			return
+		}
+		keepAlive = time.Duration(seconds) * time.Second
+	}
+
 	if _, err := objAPI.GetBucketInfo(ctx, bucketName); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
@@ -227,15 +294,19 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 		return
 	}
 
-	target, err := target.NewHTTPClientTarget(*host, w)
+	target, err := target.NewHTTPClientTarget(*host, w, keepAlive)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
 
-	rulesMap := event.NewRulesMap(eventNames, pattern, target.ID())
+	listener := ListenBucketNotificationArgs{EventNames: eventNames, Patterns: patterns}
+	rulesMap := listener.ToRulesMap(target.ID())
 
-	if err = globalNotificationSys.AddRemoteTarget(bucketName, target, rulesMap); err != nil {
+	replay, err := globalNotificationSys.listenEvents.listen(bucketName, token, rulesMap, func() error {
+		return globalNotificationSys.AddRemoteTarget(bucketName, target, rulesMap)
+	})
+	if err != nil {
 		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
 		logger.LogIf(ctx, err)
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
@@ -250,15 +321,16 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 		return
 	}
 
-	if err = SaveListener(objAPI, bucketName, eventNames, pattern, target.ID(), *thisAddr); err != nil {
+	if err = SaveListener(objAPI, bucketName, eventNames, patterns, target.ID(), *thisAddr); err != nil {
 		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
 		logger.LogIf(ctx, err)
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
 
-	globalNotificationSys.ListenBucketNotification(ctx, bucketName, eventNames, pattern, target.ID(), *thisAddr)
+	globalNotificationSys.ListenBucketNotification(ctx, bucketName, eventNames, patterns, target.ID(), *thisAddr)
 
+	target.Start(replay)
 	<-target.DoneCh
 
 	if err = RemoveListener(objAPI, bucketName, target.ID(), *thisAddr); err != nil {
@@ -0,0 +1,108 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/event"
+)
+
+// Wrapper for calling bucket notification handler tests for both XL multiple disks and single node setup.
+func TestBucketNotificationHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketNotificationHandlers, []string{"GetBucketNotification", "PutBucketNotification"})
+}
+
+func testBucketNotificationHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+
+	tmpGlobalNotificationSys := globalNotificationSys
+	defer func() { globalNotificationSys = tmpGlobalNotificationSys }()
+	target := &testNotificationTarget{id: event.TargetID{ID: "1", Name: "webhook"}}
+	globalNotificationSys = newTestFailedEventsNotificationSys(t, target)
+
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		var reader io.ReadSeeker
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req, err := newTestRequest(method, getPutNotificationURL("", bucketName), int64(len(body)), reader)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	region := globalServerConfig.GetRegion()
+	arn := target.ID().ToARN(region).String()
+	config := `<NotificationConfiguration><QueueConfiguration><Id>1</Id><Filter><S3Key><FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule></S3Key></Filter>` +
+		`<Queue>` + arn + `</Queue><Event>s3:ObjectCreated:*</Event></QueueConfiguration></NotificationConfiguration>`
+	if rec := serve("PUT", config); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	rec := serve("GET", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var nConfig event.Config
+	if err := xml.Unmarshal(rec.Body.Bytes(), &nConfig); err != nil {
+		t.Fatalf("%s: Failed to parse the notification configuration: <ERROR> %v", instanceType, err)
+	}
+	if len(nConfig.QueueList) != 1 || nConfig.QueueList[0].ARN.String() != arn || nConfig.QueueList[0].Filter.RuleList.Pattern() != event.NewPattern("images/", "") {
+		t.Errorf("%s: Expected the queue configuration of the target, but instead found %s", instanceType, rec.Body.String())
+	}
+
+	// Unknown ARNs are reported along with the ARNs of the targets.
+	unknownARN := event.TargetID{ID: "2", Name: "amqp"}.ToARN(region).String()
+	rec = serve("PUT", strings.Replace(config, arn, unknownARN, 1))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	var errResponse NotificationARNErrorResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &errResponse); err != nil {
+		t.Fatalf("%s: Failed to parse the error response: <ERROR> %v", instanceType, err)
+	}
+	if errResponse.ARN != unknownARN || !reflect.DeepEqual(errResponse.SupportedARNs, []string{arn}) {
+		t.Errorf("%s: Expected the unknown ARN and the ARNs of the targets, but instead found %s", instanceType, rec.Body.String())
+	}
+
+	// Configurations of targets removed from the server are not returned.
+	<-globalNotificationSys.targetList.Remove(target.ID())
+	rec = serve("GET", "")
+	nConfig = event.Config{}
+	if err := xml.Unmarshal(rec.Body.Bytes(), &nConfig); err != nil {
+		t.Fatalf("%s: Failed to parse the notification configuration: <ERROR> %v", instanceType, err)
+	}
+	if len(nConfig.QueueList) != 0 {
+		t.Errorf("%s: Expected no queue configurations, but instead found %s", instanceType, rec.Body.String())
+	}
+}
@@ -85,6 +85,17 @@ func (api objectAPIHandlers) PutBucketPolicyHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	policyLock := getBucketPolicyLock(bucket)
+	if err = policyLock.GetLock(globalOperationTimeout); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	defer policyLock.Unlock()
+
+	// Keep the replaced policy to be able to restore it, failing to
+	// record it does not prevent the policy from being set.
+	logger.LogIf(ctx, addBucketPolicyVersion(ctx, objAPI, bucket))
+
 	if err = objAPI.SetBucketPolicy(ctx, bucket, bucketPolicy); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
@@ -121,6 +132,21 @@ func (api objectAPIHandlers) DeleteBucketPolicyHandler(w http.ResponseWriter, r
 		return
 	}
 
+	policyLock := getBucketPolicyLock(bucket)
+	if err := policyLock.GetLock(globalOperationTimeout); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	defer policyLock.Unlock()
+
+	if _, err := objAPI.GetBucketPolicy(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Keep the removed policy to be able to restore it.
+	logger.LogIf(ctx, addBucketPolicyVersion(ctx, objAPI, bucket))
+
 	if err := objAPI.DeleteBucketPolicy(ctx, bucket); err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
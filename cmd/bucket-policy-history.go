@@ -0,0 +1,198 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"time"
+
+	"github.com/minio/minio/pkg/policy"
+)
+
+const (
+	// Policy history configuration file.
+	bucketPolicyHistoryConfig = "policy-history.json"
+
+	// Maximum number of prior policies kept for a bucket.
+	maxBucketPolicyVersions = 10
+)
+
+// errNoSuchBucketPolicyVersion - the requested prior bucket policy is not in the history.
+var errNoSuchBucketPolicyVersion = errors.New("Specified bucket policy version does not exist")
+
+// BucketPolicyVersion - a prior policy of a bucket, replaced at given
+// time. A version without policy records that the bucket had none.
+type BucketPolicyVersion struct {
+	VersionID string          `json:"versionId"`
+	Replaced  time.Time       `json:"replaced"`
+	Policy    json.RawMessage `json:"policy,omitempty"`
+}
+
+// getBucketPolicyHistoryConfigFile - returns the path to the policy history of given bucket name in minioMetaBucket.
+func getBucketPolicyHistoryConfigFile(bucketName string) string {
+	return path.Join(bucketConfigPrefix, bucketName, bucketPolicyHistoryConfig)
+}
+
+// readBucketPolicyHistory - reads prior policies of given bucket name
+// from the backend, the most recently replaced first.
+func readBucketPolicyHistory(ctx context.Context, objAPI ObjectLayer, bucketName string) ([]BucketPolicyVersion, error) {
+	reader, err := readConfig(ctx, objAPI, getBucketPolicyHistoryConfigFile(bucketName))
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []BucketPolicyVersion
+	if err = json.Unmarshal(data, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// saveBucketPolicyHistory - writes prior policies of given bucket name to the backend.
+func saveBucketPolicyHistory(objAPI ObjectLayer, bucketName string, versions []BucketPolicyVersion) error {
+	data, err := json.Marshal(versions)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(objAPI, getBucketPolicyHistoryConfigFile(bucketName), data)
+}
+
+// addBucketPolicyVersion - records the current policy of given bucket
+// name in its history before it is replaced or removed, only the latest
+// maxBucketPolicyVersions prior policies are kept.
+func addBucketPolicyVersion(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	transactionConfigFile := getBucketPolicyHistoryConfigFile(bucketName) + ".transaction"
+
+	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
+	// and configFile, take a transaction lock to avoid data race between readConfig()
+	// and saveConfig().
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, transactionConfigFile)
+	if err := objLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer objLock.Unlock()
+
+	version := BucketPolicyVersion{
+		VersionID: mustGetUUID(),
+		Replaced:  UTCNow(),
+	}
+	bucketPolicy, err := objAPI.GetBucketPolicy(ctx, bucketName)
+	switch err.(type) {
+	case nil:
+		if version.Policy, err = json.Marshal(bucketPolicy); err != nil {
+			return err
+		}
+	case BucketPolicyNotFound:
+	default:
+		return err
+	}
+
+	versions, err := readBucketPolicyHistory(ctx, objAPI, bucketName)
+	if err != nil {
+		return err
+	}
+
+	versions = append([]BucketPolicyVersion{version}, versions...)
+	if len(versions) > maxBucketPolicyVersions {
+		versions = versions[:maxBucketPolicyVersions]
+	}
+
+	return saveBucketPolicyHistory(objAPI, bucketName, versions)
+}
+
+// restoreBucketPolicyVersion - replaces the policy of given bucket name
+// by a prior policy from its history, the replaced policy is recorded
+// in the history as well so that restoring can be reverted too.
+func restoreBucketPolicyVersion(ctx context.Context, objAPI ObjectLayer, bucketName, versionID string) error {
+	policyLock := getBucketPolicyLock(bucketName)
+	if err := policyLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer policyLock.Unlock()
+
+	versions, err := readBucketPolicyHistory(ctx, objAPI, bucketName)
+	if err != nil {
+		return err
+	}
+
+	var bucketPolicy *policy.Policy
+	found := false
+	for _, version := range versions {
+		if version.VersionID != versionID {
+			continue
+		}
+		if version.Policy != nil {
+			if bucketPolicy, err = policy.ParseConfig(bytes.NewReader(version.Policy), bucketName); err != nil {
+				return err
+			}
+		}
+		found = true
+		break
+	}
+	if !found {
+		return errNoSuchBucketPolicyVersion
+	}
+
+	if err = addBucketPolicyVersion(ctx, objAPI, bucketName); err != nil {
+		return err
+	}
+
+	if bucketPolicy == nil {
+		if err = objAPI.DeleteBucketPolicy(ctx, bucketName); err != nil {
+			if _, ok := err.(BucketPolicyNotFound); !ok {
+				return err
+			}
+		}
+		globalPolicySys.Remove(bucketName)
+		globalNotificationSys.RemoveBucketPolicy(ctx, bucketName)
+		return nil
+	}
+
+	if err = objAPI.SetBucketPolicy(ctx, bucketName, bucketPolicy); err != nil {
+		return err
+	}
+	globalPolicySys.Set(bucketName, *bucketPolicy)
+	globalNotificationSys.SetBucketPolicy(ctx, bucketName, bucketPolicy)
+	return nil
+}
+
+// removeBucketPolicyHistory - removes prior policies of given bucket name.
+func removeBucketPolicyHistory(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, getBucketPolicyHistoryConfigFile(bucketName)); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
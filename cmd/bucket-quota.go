@@ -0,0 +1,343 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Quota configuration file.
+	bucketQuotaConfig = "quota.json"
+
+	// Maximum size of a quota configuration.
+	maxBucketQuotaConfigSize = 1024
+)
+
+// Time during which the computed usage of a bucket is trusted, uploads
+// exceeding the quota according to a cached usage always recompute it.
+var bucketQuotaUsageValidity = 1 * time.Minute
+
+// BucketQuotaType - type of a bucket quota.
+type BucketQuotaType string
+
+const (
+	// HardQuota - uploads exceeding the quota are rejected.
+	HardQuota BucketQuotaType = "hard"
+
+	// FIFOQuota - oldest objects are deleted to make room for uploads.
+	FIFOQuota BucketQuotaType = "fifo"
+)
+
+// BucketQuota - quota configuration of a bucket, quota is in bytes.
+type BucketQuota struct {
+	Quota uint64          `json:"quota"`
+	Type  BucketQuotaType `json:"quotatype"`
+}
+
+// IsValid - returns true if the quota is usable.
+func (q BucketQuota) IsValid() bool {
+	return q.Quota > 0 && (q.Type == HardQuota || q.Type == FIFOQuota)
+}
+
+// bucketUsage - last computed usage of a bucket.
+type bucketUsage struct {
+	size    uint64
+	updated time.Time
+}
+
+// BucketQuotaSys - bucket quota subsystem.
+type BucketQuotaSys struct {
+	sync.RWMutex
+	quotaMap map[string]BucketQuota
+	usageMap map[string]bucketUsage
+}
+
+// Get - returns quota of given bucket name.
+func (sys *BucketQuotaSys) Get(bucketName string) (quota BucketQuota, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	quota, ok = sys.quotaMap[bucketName]
+	return quota, ok
+}
+
+// Set - sets quota to given bucket name. If quota is invalid, existing quota is removed.
+func (sys *BucketQuotaSys) Set(bucketName string, quota BucketQuota) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	if quota.IsValid() {
+		sys.quotaMap[bucketName] = quota
+	} else {
+		delete(sys.quotaMap, bucketName)
+		delete(sys.usageMap, bucketName)
+	}
+}
+
+// Remove - removes quota for given bucket name.
+func (sys *BucketQuotaSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.quotaMap, bucketName)
+	delete(sys.usageMap, bucketName)
+}
+
+// getUsage - returns cached usage of the bucket if still valid.
+func (sys *BucketQuotaSys) getUsage(bucketName string) (size uint64, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	usage, ok := sys.usageMap[bucketName]
+	if !ok || UTCNow().Sub(usage.updated) > bucketQuotaUsageValidity {
+		return 0, false
+	}
+	return usage.size, true
+}
+
+// setUsage - caches usage of the bucket, updated is only moved
+// forward when the usage was computed by listing the bucket.
+func (sys *BucketQuotaSys) setUsage(bucketName string, size uint64, computed bool) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	usage := sys.usageMap[bucketName]
+	usage.size = size
+	if computed {
+		usage.updated = UTCNow()
+	}
+	sys.usageMap[bucketName] = usage
+}
+
+// removeDeletedBuckets - removes quotas of buckets missed by delete-bucket notifications.
+func (sys *BucketQuotaSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.quotaMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.quotaMap, bucket)
+			delete(sys.usageMap, bucket)
+		}
+	}
+}
+
+// Refresh BucketQuotaSys.
+func (sys *BucketQuotaSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		quota, err := getBucketQuotaConfig(objAPI, bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketQuotaConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, quota)
+	}
+	return nil
+}
+
+// Init - initializes bucket quota system from quota.json of all buckets.
+func (sys *BucketQuotaSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	// Load BucketQuotaSys once during boot.
+	if err := sys.refresh(objAPI); err != nil {
+		return err
+	}
+
+	// Refresh BucketQuotaSys in background.
+	go func() {
+		ticker := time.NewTicker(globalRefreshBucketQuotaInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+				sys.refresh(objAPI)
+			}
+		}
+	}()
+	return nil
+}
+
+// NewBucketQuotaSys - creates new bucket quota system.
+func NewBucketQuotaSys() *BucketQuotaSys {
+	return &BucketQuotaSys{
+		quotaMap: make(map[string]BucketQuota),
+		usageMap: make(map[string]bucketUsage),
+	}
+}
+
+// getBucketQuotaConfig - get quota config for given bucket name.
+func getBucketQuotaConfig(objAPI ObjectLayer, bucketName string) (quota BucketQuota, err error) {
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfig)
+
+	reader, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketQuotaConfigNotFound{Bucket: bucketName}
+		}
+		return quota, err
+	}
+
+	if err = json.NewDecoder(reader).Decode(&quota); err != nil {
+		return quota, err
+	}
+	return quota, nil
+}
+
+func saveBucketQuotaConfig(objAPI ObjectLayer, bucketName string, quota BucketQuota) error {
+	data, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfig)
+
+	return saveConfig(objAPI, configFile, data)
+}
+
+func removeBucketQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketQuotaConfigNotFound{Bucket: bucketName}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// listAllObjects - returns all objects of the bucket.
+func listAllObjects(ctx context.Context, objAPI ObjectLayer, bucket string) (objects []ObjectInfo, err error) {
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, result.Objects...)
+		if !result.IsTruncated {
+			return objects, nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// objectsSize - returns the total size of objects.
+func objectsSize(objects []ObjectInfo) (size uint64) {
+	for _, object := range objects {
+		size += uint64(object.Size)
+	}
+	return size
+}
+
+// enforceBucketQuota - verifies that size more bytes fit in the quota of
+// the bucket, FIFO quotas make room by deleting the oldest objects. The
+// usage is tracked per server and uploads running in parallel are not
+// serialized, quotas are hence enforced on a best effort basis.
+func enforceBucketQuota(ctx context.Context, objAPI ObjectLayer, bucket string, size int64) error {
+	if globalBucketQuotaSys == nil {
+		return nil
+	}
+
+	quota, ok := globalBucketQuotaSys.Get(bucket)
+	if !ok {
+		return nil
+	}
+
+	if size < 0 {
+		size = 0
+	}
+	if uint64(size) > quota.Quota {
+		return BucketQuotaExceeded{Bucket: bucket}
+	}
+
+	if usage, ok := globalBucketQuotaSys.getUsage(bucket); ok && usage+uint64(size) <= quota.Quota {
+		globalBucketQuotaSys.setUsage(bucket, usage+uint64(size), false)
+		return nil
+	}
+
+	objects, err := listAllObjects(ctx, objAPI, bucket)
+	if err != nil {
+		return err
+	}
+
+	usage := objectsSize(objects)
+	if usage+uint64(size) > quota.Quota {
+		if quota.Type != FIFOQuota {
+			globalBucketQuotaSys.setUsage(bucket, usage, true)
+			return BucketQuotaExceeded{Bucket: bucket}
+		}
+
+		sort.Slice(objects, func(i, j int) bool {
+			return objects[i].ModTime.Before(objects[j].ModTime)
+		})
+		// Objects of versioned buckets are evicted by adding a delete
+		// marker, their versions are kept.
+		isVersioned := getBucketVersioning(bucket) != ""
+		for _, object := range objects {
+			if usage+uint64(size) <= quota.Quota {
+				break
+			}
+			if isVersioned {
+				_, err = deleteObjectVersion(ctx, objAPI, bucket, object.Name, "", nil)
+			} else {
+				err = objAPI.DeleteObject(ctx, bucket, object.Name)
+			}
+			if err != nil {
+				if _, ok := err.(ObjectNotFound); !ok {
+					globalBucketQuotaSys.setUsage(bucket, usage, true)
+					return err
+				}
+			}
+			usage -= uint64(object.Size)
+		}
+	}
+
+	globalBucketQuotaSys.setUsage(bucket, usage+uint64(size), true)
+	return nil
+}
@@ -0,0 +1,202 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// Tests quota enforcement on PutObject for both quota types.
+func TestEnforceBucketQuota(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	tmpGlobalBucketQuotaSys := globalBucketQuotaSys
+	defer func() {
+		globalBucketQuotaSys = tmpGlobalBucketQuotaSys
+	}()
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	ctx := context.Background()
+	for _, bucket := range []string{"hard", "fifo", "none"} {
+		if err = objLayer.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("unable to create bucket, %s", err)
+		}
+	}
+	globalBucketQuotaSys.Set("hard", BucketQuota{Quota: 10, Type: HardQuota})
+	globalBucketQuotaSys.Set("fifo", BucketQuota{Quota: 10, Type: FIFOQuota})
+
+	testCases := []struct {
+		bucket      string
+		object      string
+		size        int64
+		expectedErr error
+	}{
+		{"hard", "obj1", 6, nil},
+		{"hard", "obj2", 4, nil},
+		{"hard", "obj3", 1, BucketQuotaExceeded{Bucket: "hard"}},
+		{"fifo", "obj1", 6, nil},
+		{"fifo", "obj2", 4, nil},
+		// Makes room by removing obj1.
+		{"fifo", "obj3", 5, nil},
+		// Never fits in the quota.
+		{"fifo", "obj4", 11, BucketQuotaExceeded{Bucket: "fifo"}},
+		{"none", "obj1", 11, nil},
+	}
+
+	for i, testCase := range testCases {
+		data := bytes.Repeat([]byte("a"), int(testCase.size))
+		_, err = objLayer.PutObject(ctx, testCase.bucket, testCase.object, mustGetHashReader(t, bytes.NewReader(data), testCase.size, "", ""), nil)
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		// Objects of the FIFO bucket must be ordered by modification time.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	objects, err := listAllObjects(ctx, objLayer, "fifo")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(objects) != 2 || objects[0].Name != "obj2" || objects[1].Name != "obj3" {
+		t.Fatalf("expected obj2 and obj3 to remain, got: %v", objects)
+	}
+
+	// Removing the quota lifts the restriction.
+	globalBucketQuotaSys.Set("hard", BucketQuota{})
+	data := bytes.Repeat([]byte("a"), 11)
+	if _, err = objLayer.PutObject(ctx, "hard", "obj3", mustGetHashReader(t, bytes.NewReader(data), 11, "", ""), nil); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+// Tests that FIFO quotas evict objects of versioned buckets by adding
+// delete markers, their versions are kept.
+func TestEnforceBucketQuotaVersioned(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatalf("unable to initialize XL backend, %s", err)
+	}
+	defer removeRoots(fsDirs)
+
+	tmpGlobalBucketQuotaSys := globalBucketQuotaSys
+	defer func() {
+		globalBucketQuotaSys = tmpGlobalBucketQuotaSys
+	}()
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	if err = objLayer.MakeBucketWithLocation(ctx, "fifo", ""); err != nil {
+		t.Fatalf("unable to create bucket, %s", err)
+	}
+	globalBucketMetadataSys.Set("fifo", bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+	globalBucketQuotaSys.Set("fifo", BucketQuota{Quota: 10, Type: FIFOQuota})
+
+	for _, object := range []string{"obj1", "obj2"} {
+		metadata := make(map[string]string)
+		setObjectVersionID("fifo", metadata)
+		data := bytes.Repeat([]byte("a"), 5)
+		if _, err = objLayer.PutObject(ctx, "fifo", object, mustGetHashReader(t, bytes.NewReader(data), 5, "", ""), metadata); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		// Objects of the FIFO bucket must be ordered by modification time.
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err = enforceBucketQuota(ctx, objLayer, "fifo", 5); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	result, err := objLayer.ListObjectVersions(ctx, "fifo", "obj1", "", "", "", maxObjectList)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(result.Objects) != 2 || !result.Objects[0].DeleteMarker || result.Objects[1].DeleteMarker {
+		t.Fatalf("expected obj1 to be kept behind a delete marker, got %v", result.Objects)
+	}
+}
+
+// Tests bucket quota config persistence.
+func TestBucketQuotaConfig(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	ctx := context.Background()
+	if err = objLayer.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatalf("unable to create bucket, %s", err)
+	}
+
+	if _, err = getBucketQuotaConfig(objLayer, "bucket"); err != (BucketQuotaConfigNotFound{Bucket: "bucket"}) {
+		t.Fatalf("expected: %v, got: %v", BucketQuotaConfigNotFound{Bucket: "bucket"}, err)
+	}
+
+	quota := BucketQuota{Quota: 1024, Type: HardQuota}
+	if err = saveBucketQuotaConfig(objLayer, "bucket", quota); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	sys := NewBucketQuotaSys()
+	if err = sys.refresh(objLayer); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if q, ok := sys.Get("bucket"); !ok || q != quota {
+		t.Fatalf("expected: %v, got: %v", quota, q)
+	}
+
+	if err = removeBucketQuotaConfig(ctx, objLayer, "bucket"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = sys.refresh(objLayer); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, ok := sys.Get("bucket"); ok {
+		t.Fatal("expected quota to be removed")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/replication"
+)
+
+// Wrapper for calling bucket replication handler tests for both XL multiple disks and single node setup.
+func TestBucketReplicationHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketReplicationHandlers, []string{"PutBucketReplication", "GetBucketReplication", "DeleteBucketReplication", "PutBucketVersioning"})
+}
+
+func testBucketReplicationHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	arn := "arn:minio:replication::target:replica"
+	globalBucketTargetSys = NewBucketTargetSys()
+	globalBucketTargetSys.Set(bucketName, []BucketTarget{{
+		SourceBucket: bucketName,
+		Endpoint:     "http://remote:9000",
+		Credentials:  auth.Credentials{AccessKey: "accesskey", SecretKey: "secretkey"},
+		TargetBucket: "replica",
+		Type:         ReplicationService,
+		Arn:          arn,
+	}})
+	defer func() { globalBucketTargetSys = NewBucketTargetSys() }()
+
+	serve := func(method, urlStr string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if !obj.IsReplicationSupported() {
+		if rec := serve("GET", getBucketReplicationURL("", bucketName), nil); rec.Code != http.StatusNotImplemented {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotImplemented, rec.Code)
+		}
+		return
+	}
+
+	if rec := serve("GET", getBucketReplicationURL("", bucketName), nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	config := `<ReplicationConfiguration><Role>` + arn + `</Role><Rule><Status>Enabled</Status><Prefix>logs/</Prefix><Destination><Bucket>arn:aws:s3:::replica</Bucket></Destination></Rule></ReplicationConfiguration>`
+
+	// Replicated buckets must be versioned.
+	if rec := serve("PUT", getBucketReplicationURL("", bucketName), []byte(config)); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	globalBucketMetadataSys.Set(bucketName, bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+
+	testCases := []struct {
+		bucketName    string
+		data          string
+		expectedCode  int
+		expectedRules int
+	}{
+		{bucketName, config, http.StatusOK, 1},
+		{bucketName, `<ReplicationConfiguration><Role>` + arn + `</Role>` +
+			`<Rule><ID>logs</ID><Status>Enabled</Status><Priority>2</Priority><Filter><Prefix>logs/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::replica</Bucket></Destination></Rule>` +
+			`<Rule><Status>Disabled</Status><Priority>1</Priority><Filter><Tag><Key>temp</Key><Value>true</Value></Tag></Filter><Destination><Bucket>arn:aws:s3:::replica</Bucket></Destination></Rule>` +
+			`</ReplicationConfiguration>`, http.StatusOK, 2},
+		// Same priorities.
+		{bucketName, `<ReplicationConfiguration><Role>` + arn + `</Role>` +
+			`<Rule><Status>Enabled</Status><Destination><Bucket>arn:aws:s3:::replica</Bucket></Destination></Rule>` +
+			`<Rule><Status>Enabled</Status><Destination><Bucket>arn:aws:s3:::replica</Bucket></Destination></Rule>` +
+			`</ReplicationConfiguration>`, http.StatusBadRequest, 2},
+		// Unknown role.
+		{bucketName, `<ReplicationConfiguration><Role>arn:minio:replication::unknown:replica</Role><Rule><Status>Enabled</Status><Destination><Bucket>arn:aws:s3:::replica</Bucket></Destination></Rule></ReplicationConfiguration>`, http.StatusBadRequest, 2},
+		// Destination bucket isn't the bucket of the role.
+		{bucketName, `<ReplicationConfiguration><Role>` + arn + `</Role><Rule><Status>Enabled</Status><Destination><Bucket>arn:aws:s3:::other</Bucket></Destination></Rule></ReplicationConfiguration>`, http.StatusBadRequest, 2},
+		{bucketName, `<ReplicationConfiguration><Rule>`, http.StatusBadRequest, 2},
+		{"missing-bucket", config, http.StatusNotFound, 2},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getBucketReplicationURL("", testCase.bucketName), []byte(testCase.data))
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+
+		rec = serve("GET", getBucketReplicationURL("", bucketName), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, http.StatusOK, rec.Code)
+		}
+		var config replication.Config
+		if err := xml.Unmarshal(rec.Body.Bytes(), &config); err != nil {
+			t.Fatalf("%s: Test %d: Failed to parse replication configuration: <ERROR> %v", instanceType, i+1, err)
+		}
+		if len(config.Rules) != testCase.expectedRules || config.Role != arn {
+			t.Errorf("%s: Test %d: Expected %d rules, but instead found %d", instanceType, i+1, testCase.expectedRules, len(config.Rules))
+		}
+	}
+
+	// Versioning of replicated buckets can't be suspended.
+	data := `<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`
+	if rec := serve("PUT", getBucketVersioningURL("", bucketName), []byte(data)); rec.Code != http.StatusConflict {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusConflict, rec.Code)
+	}
+
+	if rec := serve("DELETE", getBucketReplicationURL("", bucketName), nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec := serve("GET", getBucketReplicationURL("", bucketName), nil); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,365 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3signer"
+	"github.com/minio/minio-go/pkg/s3utils"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+)
+
+const (
+	// Bucket replication configuration file.
+	bucketReplicationConfig = "replication.xml"
+
+	// Maximum size of a replication configuration in a put-bucket-replication request.
+	maxReplicationConfigSize = 1024 * 1024
+
+	// Header giving the replication status of an object, replicas are
+	// written with the REPLICA status.
+	amzReplicationStatus = "X-Amz-Replication-Status"
+
+	// Replication status of an object version is kept in its metadata.
+	objectReplicationStatusKey = ReservedMetadataPrefix + "Replication-Status"
+
+	// Region replicas are signed for.
+	replicationRegion = "us-east-1"
+
+	// Maximum size of an error response of the target read.
+	maxReplicaErrorSize = 64 * 1024
+
+	// Number of objects replicated at the same time and of objects
+	// waiting to be replicated by a server.
+	replicationWorkers   = 4
+	replicationQueueSize = 10000
+
+	// Number of attempts to replicate an object before its replication
+	// is recorded as failed.
+	replicationAttempts = 3
+)
+
+// Interval between the first two attempts to replicate an object, it is
+// doubled after each failed attempt.
+var globalReplicationRetryInterval = 5 * time.Second
+
+var (
+	errNoReplicationTarget  = errors.New("replication target not found")
+	errReplicationQueueFull = errors.New("replication queue is full, object replication left pending")
+)
+
+// ReplicationStatus - replication status of an object version.
+type ReplicationStatus string
+
+// Replication states, replicas are the copies written to the
+// destination bucket.
+const (
+	ReplicationPending   ReplicationStatus = "PENDING"
+	ReplicationCompleted ReplicationStatus = "COMPLETED"
+	ReplicationFailed    ReplicationStatus = "FAILED"
+	ReplicationReplica   ReplicationStatus = "REPLICA"
+)
+
+// getBucketReplication - returns the replication configuration of given
+// bucket name, false if the bucket has none.
+func getBucketReplication(bucketName string) (*replication.Config, bool) {
+	if globalBucketMetadataSys == nil {
+		return nil, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketReplicationConfig)
+	if !ok {
+		return nil, false
+	}
+	var config replication.Config
+	if err := xml.Unmarshal(data, &config); err != nil {
+		return nil, false
+	}
+	return &config, true
+}
+
+// getObjectReplicationStatus - returns the replication status recorded
+// in the metadata of an object, empty if it is not replicated.
+func getObjectReplicationStatus(metadata map[string]string) ReplicationStatus {
+	return ReplicationStatus(metadata[objectReplicationStatusKey])
+}
+
+// setObjectReplicationStatus - records the replication status in the
+// metadata of an object, an empty status removes it.
+func setObjectReplicationStatus(metadata map[string]string, status ReplicationStatus) {
+	if status == "" {
+		delete(metadata, objectReplicationStatusKey)
+		return
+	}
+	metadata[objectReplicationStatusKey] = string(status)
+}
+
+// mustReplicate - returns true if the object is replicated as per the
+// replication configuration of its bucket.
+func mustReplicate(objAPI ObjectLayer, bucket, object string, metadata map[string]string) bool {
+	if !objAPI.IsReplicationSupported() {
+		return false
+	}
+	config, ok := getBucketReplication(bucket)
+	if !ok {
+		return false
+	}
+	return config.Replicate(replication.ObjectOpts{
+		Name: object,
+		Tags: getObjectTags(metadata).ToMap(),
+	})
+}
+
+// isReplicaRequest - returns true if the request writes a replica, as
+// per the replication status in given headers of the request. The status
+// sent by requests not allowed to replicate objects, as the ones of
+// replication targets are, is ignored.
+func isReplicaRequest(r *http.Request, header http.Header, bucket, object string) bool {
+	if ReplicationStatus(header.Get(amzReplicationStatus)) != ReplicationReplica {
+		return false
+	}
+	return isObjectActionAllowed(r, policy.ReplicateObjectAction, bucket, object)
+}
+
+// setObjectReplicationMetadata - records the replication status of a new
+// object, after removing any status copied from another object. Replicas
+// keep their status and are not replicated again, objects encrypted with
+// a customer key can't be read by the replication. The header is the one
+// of the request, or the form of POST uploads.
+func setObjectReplicationMetadata(objAPI ObjectLayer, r *http.Request, header http.Header, bucket, object string, metadata map[string]string) {
+	delete(metadata, objectReplicationStatusKey)
+	switch {
+	case isReplicaRequest(r, header, bucket, object):
+		setObjectReplicationStatus(metadata, ReplicationReplica)
+	case hasSSECustomerHeader(header):
+	case mustReplicate(objAPI, bucket, object, metadata):
+		setObjectReplicationStatus(metadata, ReplicationPending)
+	}
+}
+
+// scheduleReplication - replicates again an object version whose
+// metadata changed, e.g. its tags. Replicas and objects not replicated
+// as per the replication configuration of their bucket are left as is.
+func scheduleReplication(ctx context.Context, objAPI ObjectLayer, objInfo ObjectInfo) {
+	if getObjectReplicationStatus(objInfo.UserDefined) == ReplicationReplica || crypto.SSEC.IsEncrypted(objInfo.UserDefined) {
+		return
+	}
+	if !mustReplicate(objAPI, objInfo.Bucket, objInfo.Name, objInfo.UserDefined) {
+		return
+	}
+	objInfo, err := objAPI.PutObjectReplicationStatus(ctx, objInfo.Bucket, objInfo.Name, objInfo.VersionID, objInfo.ETag, ReplicationPending)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	queueReplication(objInfo)
+}
+
+// replicationPool - replicates the queued objects in background.
+type replicationPool struct {
+	objAPI ObjectLayer
+	queue  chan ObjectInfo
+}
+
+// queueReplication - queues an object version whose replication is
+// pending. The replication of objects which can't be queued, as too many
+// objects are waiting, is left pending.
+func queueReplication(objInfo ObjectInfo) {
+	if globalReplicationPool == nil || getObjectReplicationStatus(objInfo.UserDefined) != ReplicationPending {
+		return
+	}
+	select {
+	case globalReplicationPool.queue <- objInfo:
+	default:
+		reqInfo := &logger.ReqInfo{BucketName: objInfo.Bucket, ObjectName: objInfo.Name}
+		logger.LogIf(logger.SetReqInfo(context.Background(), reqInfo), errReplicationQueueFull)
+	}
+}
+
+// run - replicates queued objects until doneCh is closed.
+func (p *replicationPool) run(doneCh <-chan struct{}) {
+	for {
+		select {
+		case <-doneCh:
+			return
+		case objInfo := <-p.queue:
+			replicateObject(context.Background(), p.objAPI, objInfo, doneCh)
+		}
+	}
+}
+
+// getReplicationTarget - returns the remote target of the replication
+// configuration of given bucket name.
+func getReplicationTarget(bucketName string) (BucketTarget, bool) {
+	config, ok := getBucketReplication(bucketName)
+	if !ok {
+		return BucketTarget{}, false
+	}
+	target, ok := globalBucketTargetSys.GetTarget(config.Role)
+	if !ok || target.SourceBucket != bucketName || target.Type != ReplicationService {
+		return BucketTarget{}, false
+	}
+	return target, true
+}
+
+// replicateObject - copies an object version to the destination bucket
+// of the replication configuration of its bucket, retrying a few times,
+// and records whether it succeeded. Objects removed or overwritten
+// meanwhile are skipped, new versions are replicated on their own.
+func replicateObject(ctx context.Context, objAPI ObjectLayer, objInfo ObjectInfo, doneCh <-chan struct{}) {
+	reqInfo := &logger.ReqInfo{BucketName: objInfo.Bucket, ObjectName: objInfo.Name}
+	ctx = logger.SetReqInfo(ctx, reqInfo)
+
+	err := errNoReplicationTarget
+	if target, ok := getReplicationTarget(objInfo.Bucket); ok {
+		interval := globalReplicationRetryInterval
+		for attempt := 1; ; attempt++ {
+			err = putReplica(ctx, objAPI, target, objInfo)
+			switch err.(type) {
+			case ObjectNotFound, VersionNotFound, InvalidETag:
+				return
+			}
+			if err == nil || attempt == replicationAttempts {
+				break
+			}
+			select {
+			case <-doneCh:
+				return
+			case <-time.After(interval):
+			}
+			interval *= 2
+		}
+	}
+
+	status := ReplicationCompleted
+	if err != nil {
+		logger.LogIf(ctx, err)
+		status = ReplicationFailed
+	}
+	switch _, err = objAPI.PutObjectReplicationStatus(ctx, objInfo.Bucket, objInfo.Name, objInfo.VersionID, objInfo.ETag, status); err.(type) {
+	case nil, ObjectNotFound, VersionNotFound, InvalidETag:
+	default:
+		logger.LogIf(ctx, err)
+	}
+}
+
+// putReplica - uploads the object version, along with its metadata and
+// tags, to the target bucket as a replica. The remote object isn't
+// versioned by the replication, it is written by a single upload.
+func putReplica(ctx context.Context, objAPI ObjectLayer, target BucketTarget, objInfo ObjectInfo) error {
+	var info ObjectInfo
+	var err error
+	if objInfo.VersionID != "" {
+		info, err = objAPI.GetObjectVersionInfo(ctx, objInfo.Bucket, objInfo.Name, objInfo.VersionID)
+	} else {
+		info, err = objAPI.GetObjectInfo(ctx, objInfo.Bucket, objInfo.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if info.DeleteMarker || info.ETag != objInfo.ETag {
+		return InvalidETag{}
+	}
+
+	// Objects encrypted with SSE-S3 are replicated decrypted, the object
+	// key is unsealed before the upload starts.
+	pipeReader, pipeWriter := io.Pipe()
+	var writer io.WriteCloser = pipeWriter
+	offset, length := int64(0), info.Size
+	if crypto.S3.IsEncrypted(info.UserDefined) {
+		if apiErr, _ := DecryptObjectInfo(&info, http.Header{}); apiErr != ErrNone {
+			return errObjectTampered
+		}
+		if writer, offset, length, err = DecryptBlocksRequest(pipeWriter, nil, info.Bucket, info.Name, 0, info.Size, info, false); err != nil {
+			return err
+		}
+	}
+	defer pipeReader.Close()
+
+	var body io.Reader = http.NoBody
+	if info.Size > 0 {
+		go func() {
+			var gerr error
+			if objInfo.VersionID != "" {
+				gerr = objAPI.GetObjectVersion(ctx, info.Bucket, info.Name, objInfo.VersionID, offset, length, writer, info.ETag)
+			} else {
+				gerr = objAPI.GetObject(ctx, info.Bucket, info.Name, offset, length, writer, info.ETag)
+			}
+			if gerr == nil {
+				gerr = writer.Close()
+			}
+			pipeWriter.CloseWithError(gerr)
+		}()
+		body = pipeReader
+	}
+
+	urlStr := strings.TrimSuffix(target.Endpoint, "/") + "/" + target.TargetBucket + "/" + s3utils.EncodePath(info.Name)
+	req, err := http.NewRequest(http.MethodPut, urlStr, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size
+	for k, v := range info.UserDefined {
+		if hasPrefix(strings.ToLower(k), "x-amz-meta-") || contains(supportedHeaders, strings.ToLower(k)) {
+			req.Header.Set(k, v)
+		}
+	}
+	if tags, ok := info.UserDefined[objectTaggingKey]; ok {
+		req.Header.Set(amzObjectTagging, tags)
+	}
+	req.Header.Set(amzReplicationStatus, string(ReplicationReplica))
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	req = s3signer.SignV4(*req, target.Credentials.AccessKey, target.Credentials.SecretKey, "", replicationRegion)
+
+	client := &http.Client{Transport: NewCustomHTTPTransport()}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp APIErrorResponse
+		if err = xml.NewDecoder(io.LimitReader(resp.Body, maxReplicaErrorSize)).Decode(&errResp); err != nil || errResp.Code == "" {
+			errResp.Code, errResp.Message = resp.Status, ""
+		}
+		return fmt.Errorf("unable to replicate %s to %s: %s %s", info.Name, target.Endpoint, errResp.Code, errResp.Message)
+	}
+	return nil
+}
+
+// initReplicationPool - starts replicating queued objects in background.
+func initReplicationPool(objAPI ObjectLayer) {
+	pool := &replicationPool{
+		objAPI: objAPI,
+		queue:  make(chan ObjectInfo, replicationQueueSize),
+	}
+	for i := 0; i < replicationWorkers; i++ {
+		go pool.run(globalServiceDoneCh)
+	}
+	globalReplicationPool = pool
+}
@@ -0,0 +1,175 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Tests that objects are replicated along with their metadata and tags
+// and that their replication status is recorded.
+func TestReplicateObject(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	remote := StartTestServer(t, "XL")
+	defer remote.Stop()
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err = remote.Obj.MakeBucketWithLocation(ctx, "replica", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+	globalBucketMetadataSys.Set(bucket, bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+	globalBucketMetadataSys.Set(bucket, bucketReplicationConfig, []byte(`<ReplicationConfiguration><Role>arn:minio:replication::target:replica</Role>`+
+		`<Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::replica</Bucket></Destination></Rule>`+
+		`</ReplicationConfiguration>`))
+
+	target := BucketTarget{
+		SourceBucket: bucket,
+		Endpoint:     remote.Server.URL,
+		Credentials:  auth.Credentials{AccessKey: remote.AccessKey, SecretKey: remote.SecretKey},
+		TargetBucket: "replica",
+		Type:         ReplicationService,
+		Arn:          "arn:minio:replication::target:replica",
+	}
+	globalBucketTargetSys = NewBucketTargetSys()
+	globalBucketTargetSys.Set(bucket, []BucketTarget{target})
+	defer func() { globalBucketTargetSys = NewBucketTargetSys() }()
+
+	// newRequest - returns a request of given headers, signed with the
+	// server credentials if asked.
+	newRequest := func(header http.Header, signed bool) *http.Request {
+		req, rerr := newTestRequest("PUT", getPutObjectURL("", bucket, "object"), 0, nil)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if signed {
+			cred := globalServerConfig.GetCredential()
+			if rerr = signRequestV4(req, cred.AccessKey, cred.SecretKey); rerr != nil {
+				t.Fatal(rerr)
+			}
+		}
+		return req
+	}
+	putObject := func(object string, data []byte, r *http.Request) ObjectInfo {
+		metadata := map[string]string{
+			"content-type":     "text/plain",
+			"x-amz-meta-color": "blue",
+		}
+		setObjectTags(metadata, "team=storage")
+		setObjectVersionID(bucket, metadata)
+		setObjectReplicationMetadata(obj, r, r.Header, bucket, object, metadata)
+		objInfo, perr := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), metadata)
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		return objInfo
+	}
+	checkStatus := func(object string, expected ReplicationStatus) {
+		info, gerr := obj.GetObjectInfo(ctx, bucket, object)
+		if gerr != nil {
+			t.Fatal(gerr)
+		}
+		if status := getObjectReplicationStatus(info.UserDefined); status != expected {
+			t.Fatalf("%s: expected replication status %q, got %q", object, expected, status)
+		}
+	}
+
+	// Only objects matching a rule are replicated, replicas and objects
+	// encrypted with a customer key are not. Requests not allowed to
+	// replicate objects can't write replicas.
+	replicaHeader := http.Header{amzReplicationStatus: []string{string(ReplicationReplica)}}
+	putObject("data/a", []byte("a"), newRequest(http.Header{}, true))
+	checkStatus("data/a", "")
+	putObject("logs/replica", []byte("a"), newRequest(replicaHeader, true))
+	checkStatus("logs/replica", ReplicationReplica)
+	putObject("logs/not-replica", []byte("a"), newRequest(replicaHeader, false))
+	checkStatus("logs/not-replica", ReplicationPending)
+	putObject("logs/encrypted", []byte("a"), newRequest(http.Header{SSECustomerAlgorithm: []string{SSECustomerAlgorithmAES256}}, true))
+	checkStatus("logs/encrypted", "")
+
+	data := []byte("hello, replicated world")
+	objInfo := putObject("logs/a", data, newRequest(http.Header{}, true))
+	checkStatus("logs/a", ReplicationPending)
+
+	doneCh := make(chan struct{})
+	replicateObject(ctx, obj, objInfo, doneCh)
+	checkStatus("logs/a", ReplicationCompleted)
+
+	replica, err := remote.Obj.GetObjectInfo(ctx, "replica", "logs/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replica.Size != int64(len(data)) || replica.ContentType != "text/plain" || replica.UserDefined["X-Amz-Meta-Color"] != "blue" {
+		t.Fatalf("unexpected replica info: %v", replica)
+	}
+	if tags := replica.UserDefined[objectTaggingKey]; tags != "team=storage" {
+		t.Fatalf("expected replica tags %q, got %q", "team=storage", tags)
+	}
+	if status := getObjectReplicationStatus(replica.UserDefined); status != ReplicationReplica {
+		t.Fatalf("expected replica status %q, got %q", ReplicationReplica, status)
+	}
+	var buffer bytes.Buffer
+	if err = remote.Obj.GetObject(ctx, "replica", "logs/a", 0, replica.Size, &buffer, ""); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buffer.Bytes(), data) {
+		t.Fatalf("expected %q, got %q", data, buffer.Bytes())
+	}
+
+	// Objects overwritten meanwhile are left to the replication of their
+	// new version.
+	stale := objInfo
+	stale.ETag = "stale"
+	replicateObject(ctx, obj, stale, doneCh)
+	checkStatus("logs/a", ReplicationCompleted)
+
+	// The replication of objects fails once all attempts failed.
+	globalReplicationRetryInterval = time.Millisecond
+	defer func() { globalReplicationRetryInterval = 5 * time.Second }()
+	target.TargetBucket = "missing-bucket"
+	globalBucketTargetSys.Set(bucket, []BucketTarget{target})
+	objInfo = putObject("logs/b", data, newRequest(http.Header{}, true))
+	replicateObject(ctx, obj, objInfo, doneCh)
+	checkStatus("logs/b", ReplicationFailed)
+}
@@ -0,0 +1,248 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+// Minio extension response headers of HeadBucket summarizing the bucket.
+const (
+	minioBucketObjectCount = "X-Minio-Bucket-Object-Count"
+	minioBucketCreated     = "X-Minio-Bucket-Created"
+	minioBucketVersioning  = "X-Minio-Bucket-Versioning"
+)
+
+// Number of objects of a bucket, as last counted by the object counter.
+const bucketObjectCountFile = "object-count.json"
+
+// Interval between two counts of the objects of all buckets.
+var globalBucketObjectCountInterval = 1 * time.Hour
+
+// Time during which the number of objects of a bucket read from the
+// backend is cached.
+var bucketObjectCountValidity = 5 * time.Minute
+
+var errBucketObjectCountStopped = errors.New("bucket object count stopped")
+
+// bucketObjectCount - number of objects of a bucket, counted in
+// background at the given time.
+type bucketObjectCount struct {
+	Count   uint64    `json:"count"`
+	Updated time.Time `json:"updated"`
+}
+
+// cachedBucketObjectCount - number of objects of a bucket read from the
+// backend at the given time.
+type cachedBucketObjectCount struct {
+	bucketObjectCount
+	found  bool
+	loaded time.Time
+}
+
+// BucketObjectCounts - caches the number of objects of buckets, which
+// are counted in background by the first server and shared by all
+// servers through the backend.
+type BucketObjectCounts struct {
+	sync.Mutex
+	buckets map[string]cachedBucketObjectCount
+}
+
+// get - returns the number of objects of the bucket last counted, if
+// any. It is read from the backend once the cached one is outdated.
+func (c *BucketObjectCounts) get(ctx context.Context, objAPI ObjectLayer, bucket string) (count uint64, ok bool) {
+	c.Lock()
+	objCount, cached := c.buckets[bucket]
+	c.Unlock()
+	if cached && UTCNow().Sub(objCount.loaded) <= bucketObjectCountValidity {
+		return objCount.Count, objCount.found
+	}
+
+	// Failures, logged by readConfig, are cached too so that the backend
+	// is read at most once per validity period.
+	objCount = cachedBucketObjectCount{loaded: UTCNow()}
+	if count, err := readBucketObjectCount(ctx, objAPI, bucket); err == nil {
+		objCount.bucketObjectCount = count
+		objCount.found = true
+	}
+
+	c.set(bucket, objCount)
+	return objCount.Count, objCount.found
+}
+
+// set - caches the number of objects of the bucket.
+func (c *BucketObjectCounts) set(bucket string, objCount cachedBucketObjectCount) {
+	c.Lock()
+	defer c.Unlock()
+	c.buckets[bucket] = objCount
+}
+
+// Remove the number of objects of a deleted bucket.
+func (c *BucketObjectCounts) deleteBucket(bucket string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.buckets, bucket)
+}
+
+func newBucketObjectCounts() *BucketObjectCounts {
+	return &BucketObjectCounts{
+		buckets: make(map[string]cachedBucketObjectCount),
+	}
+}
+
+// getBucketObjectCountFile - returns the path to the number of objects of
+// given bucket name in minioMetaBucket.
+func getBucketObjectCountFile(bucketName string) string {
+	return path.Join(bucketConfigPrefix, bucketName, bucketObjectCountFile)
+}
+
+// readBucketObjectCount - reads the number of objects of given bucket
+// name from the backend.
+func readBucketObjectCount(ctx context.Context, objAPI ObjectLayer, bucketName string) (count bucketObjectCount, err error) {
+	reader, err := readConfig(ctx, objAPI, getBucketObjectCountFile(bucketName))
+	if err != nil {
+		return count, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return count, err
+	}
+
+	err = json.Unmarshal(data, &count)
+	return count, err
+}
+
+// removeBucketObjectCount - removes the number of objects of given bucket name.
+func removeBucketObjectCount(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, getBucketObjectCountFile(bucketName)); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// countBucketObjects - counts the objects of given bucket name and saves
+// their number, returns errBucketObjectCountStopped if doneCh is closed
+// meanwhile.
+func countBucketObjects(ctx context.Context, objAPI ObjectLayer, bucketName string, doneCh <-chan struct{}) error {
+	count := bucketObjectCount{Updated: UTCNow()}
+	marker := ""
+	for {
+		if !waitForScanner(doneCh) {
+			return errBucketObjectCountStopped
+		}
+
+		result, err := objAPI.ListObjects(ctx, bucketName, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		count.Count += uint64(len(result.Objects))
+
+		if !result.IsTruncated || len(result.Objects) == 0 {
+			break
+		}
+		marker = result.NextMarker
+		if marker == "" {
+			marker = result.Objects[len(result.Objects)-1].Name
+		}
+	}
+
+	data, err := json.Marshal(count)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(objAPI, getBucketObjectCountFile(bucketName), data); err != nil {
+		return err
+	}
+
+	globalBucketObjectCounts.set(bucketName, cachedBucketObjectCount{bucketObjectCount: count, found: true, loaded: UTCNow()})
+	return nil
+}
+
+// scanBucketObjectCounts - counts the objects of all buckets, returns
+// errBucketObjectCountStopped if doneCh is closed meanwhile.
+func scanBucketObjectCounts(ctx context.Context, objAPI ObjectLayer, doneCh <-chan struct{}) error {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		err = countBucketObjects(ctx, objAPI, bucket.Name, doneCh)
+		if err == errBucketObjectCountStopped {
+			return err
+		}
+		if err != nil {
+			reqInfo := &logger.ReqInfo{BucketName: bucket.Name}
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), err)
+		}
+	}
+	return nil
+}
+
+// initBucketObjectCounter - starts counting the objects of buckets in
+// background. Counts are shared by all servers and done by the first
+// one only.
+func initBucketObjectCounter(objAPI ObjectLayer) {
+	if nodeIndex, _ := GetLocalPeerIndex(globalEndpoints); nodeIndex != 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(globalBucketObjectCountInterval)
+		defer ticker.Stop()
+		for {
+			if scanBucketObjectCounts(context.Background(), objAPI, globalServiceDoneCh) == errBucketObjectCountStopped {
+				return
+			}
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// setBucketSummaryHeaders - sets the Minio extension headers summarizing
+// the bucket, the number of objects is only set once it was counted.
+func setBucketSummaryHeaders(ctx context.Context, w http.ResponseWriter, objAPI ObjectLayer, bucketInfo BucketInfo) {
+	if count, ok := globalBucketObjectCounts.get(ctx, objAPI, bucketInfo.Name); ok {
+		w.Header().Set(minioBucketObjectCount, strconv.FormatUint(count, 10))
+	}
+	if !bucketInfo.Created.IsZero() {
+		w.Header().Set(minioBucketCreated, bucketInfo.Created.UTC().Format(http.TimeFormat))
+	}
+	if status := getBucketVersioning(bucketInfo.Name); status != "" {
+		w.Header().Set(minioBucketVersioning, status)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling HeadBucket summary tests for both XL multiple disks and single node setup.
+func TestHeadBucketSummaryHeaders(t *testing.T) {
+	ExecObjectLayerAPITest(t, testHeadBucketSummaryHeaders, []string{"HeadBucket"})
+}
+
+func testHeadBucketSummaryHeaders(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+	tmpGlobalBucketObjectCounts := globalBucketObjectCounts
+	defer func() { globalBucketObjectCounts = tmpGlobalBucketObjectCounts }()
+	globalBucketObjectCounts = newBucketObjectCounts()
+
+	for _, object := range []string{"a", "dir/b"} {
+		if _, err := obj.PutObject(context.Background(), bucketName, object, mustGetHashReader(t, bytes.NewReader([]byte("hello")), 5, "", ""), nil); err != nil {
+			t.Fatalf("%s: Failed to create object: <ERROR> %v", instanceType, err)
+		}
+	}
+	bucketInfo, err := obj.GetBucketInfo(context.Background(), bucketName)
+	if err != nil {
+		t.Fatalf("%s: Failed to get bucket info: <ERROR> %v", instanceType, err)
+	}
+
+	serve := func() *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4("HEAD", getHEADBucketURL("", bucketName), 0, nil, credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		return rec
+	}
+
+	// The objects are not counted yet.
+	rec := serve()
+	if count, ok := rec.Header()[minioBucketObjectCount]; ok {
+		t.Errorf("%s: Expected no object count, but instead found %v", instanceType, count)
+	}
+	if created := rec.Header().Get(minioBucketCreated); created != bucketInfo.Created.UTC().Format(http.TimeFormat) {
+		t.Errorf("%s: Expected the creation time `%s`, but instead found `%s`", instanceType, bucketInfo.Created.UTC().Format(http.TimeFormat), created)
+	}
+	if versioning, ok := rec.Header()[minioBucketVersioning]; ok {
+		t.Errorf("%s: Expected no versioning state, but instead found %v", instanceType, versioning)
+	}
+
+	// HeadBucket doesn't count the objects, they are counted in background.
+	if _, err = readBucketObjectCount(context.Background(), obj, bucketName); err != errConfigNotFound {
+		t.Errorf("%s: Expected error `%v`, but instead found `%v`", instanceType, errConfigNotFound, err)
+	}
+	if err = scanBucketObjectCounts(context.Background(), obj, nil); err != nil {
+		t.Fatalf("%s: Failed to count objects: <ERROR> %v", instanceType, err)
+	}
+	globalBucketMetadataSys.Set(bucketName, bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`))
+
+	rec = serve()
+	if count := rec.Header().Get(minioBucketObjectCount); count != "2" {
+		t.Errorf("%s: Expected the object count `2`, but instead found `%s`", instanceType, count)
+	}
+	if versioning := rec.Header().Get(minioBucketVersioning); versioning != "Suspended" {
+		t.Errorf("%s: Expected the versioning state `Suspended`, but instead found `%s`", instanceType, versioning)
+	}
+	if region := rec.Header().Get("X-Amz-Bucket-Region"); region != globalServerConfig.GetRegion() {
+		t.Errorf("%s: Expected the bucket region `%s`, but instead found `%s`", instanceType, globalServerConfig.GetRegion(), region)
+	}
+
+	// Counts are shared with other servers through the backend.
+	globalBucketObjectCounts = newBucketObjectCounts()
+	if count, ok := globalBucketObjectCounts.get(context.Background(), obj, bucketName); !ok || count != 2 {
+		t.Errorf("%s: Expected the object count `2`, but instead found `%d`", instanceType, count)
+	}
+
+	// Counts of deleted buckets are dropped.
+	globalBucketObjectCounts.deleteBucket(bucketName)
+	globalBucketObjectCounts.Lock()
+	_, ok := globalBucketObjectCounts.buckets[bucketName]
+	globalBucketObjectCounts.Unlock()
+	if ok {
+		t.Errorf("%s: Expected the object count of the bucket to be removed", instanceType)
+	}
+	if err = removeBucketObjectCount(context.Background(), obj, bucketName); err != nil {
+		t.Fatalf("%s: Failed to remove object count: <ERROR> %v", instanceType, err)
+	}
+	if _, err = readBucketObjectCount(context.Background(), obj, bucketName); err != errConfigNotFound {
+		t.Errorf("%s: Expected error `%v`, but instead found `%v`", instanceType, errConfigNotFound, err)
+	}
+}
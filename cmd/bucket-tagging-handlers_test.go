@@ -0,0 +1,114 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/tagging"
+)
+
+// Wrapper for calling bucket tagging handler tests for both XL multiple disks and single node setup.
+func TestBucketTaggingHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketTaggingHandlers, []string{"PutBucketTagging", "GetBucketTagging", "DeleteBucketTagging"})
+}
+
+func testBucketTaggingHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	serve := func(method, urlStr string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// No tags were ever put.
+	if rec := serve("GET", getBucketTaggingURL("", bucketName), nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	tooManyTags := ""
+	for i := 0; i <= tagging.MaxBucketTags; i++ {
+		tooManyTags += fmt.Sprintf("<Tag><Key>key%d</Key><Value>value</Value></Tag>", i)
+	}
+
+	testCases := []struct {
+		bucketName   string
+		data         string
+		expectedCode int
+		expectedTags string
+	}{
+		{bucketName, `<Tagging><TagSet><Tag><Key>cost-center</Key><Value>research</Value></Tag></TagSet></Tagging>`, http.StatusNoContent, "cost-center=research"},
+		{bucketName, `<Tagging><TagSet><Tag><Key>owner</Key><Value>team</Value></Tag><Tag><Key>env</Key><Value>prod</Value></Tag></TagSet></Tagging>`, http.StatusNoContent, "env=prod&owner=team"},
+		{bucketName, `<Tagging><TagSet>` + tooManyTags + `</TagSet></Tagging>`, http.StatusBadRequest, "env=prod&owner=team"},
+		{bucketName, `<Tagging><TagSet><Tag><Key>aws:owner</Key><Value>team</Value></Tag></TagSet></Tagging>`, http.StatusBadRequest, "env=prod&owner=team"},
+		{bucketName, `<Tagging><TagSet>`, http.StatusBadRequest, "env=prod&owner=team"},
+		{"missing-bucket", `<Tagging><TagSet></TagSet></Tagging>`, http.StatusNotFound, "env=prod&owner=team"},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getBucketTaggingURL("", testCase.bucketName), []byte(testCase.data))
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+
+		rec = serve("GET", getBucketTaggingURL("", bucketName), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, http.StatusOK, rec.Code)
+		}
+		var tags tagging.Tagging
+		if err := xml.Unmarshal(rec.Body.Bytes(), &tags); err != nil {
+			t.Fatalf("%s: Test %d: Failed to parse tagging: <ERROR> %v", instanceType, i+1, err)
+		}
+		if tags.String() != testCase.expectedTags {
+			t.Errorf("%s: Test %d: Expected tags `%s`, but instead found `%s`", instanceType, i+1, testCase.expectedTags, tags.String())
+		}
+	}
+
+	// Tags are persisted in the bucket metadata.
+	data, err := readBucketMetadataConfig(context.Background(), obj, bucketName, bucketTaggingConfig)
+	if err != nil {
+		t.Fatalf("%s: Failed to read bucket tagging: <ERROR> %v", instanceType, err)
+	}
+	if !strings.Contains(string(data), "<Key>owner</Key>") {
+		t.Errorf("%s: Expected bucket tagging to be persisted, but instead found `%s`", instanceType, string(data))
+	}
+
+	if rec := serve("DELETE", getBucketTaggingURL("", bucketName), nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec := serve("GET", getBucketTaggingURL("", bucketName), nil); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if data, err = readBucketMetadataConfig(context.Background(), obj, bucketName, bucketTaggingConfig); err != nil || data != nil {
+		t.Errorf("%s: Expected bucket tagging to be removed, but instead found `%s`, %v", instanceType, string(data), err)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+
+	"github.com/minio/minio/pkg/tagging"
+)
+
+const (
+	// Bucket tagging configuration file.
+	bucketTaggingConfig = "tagging.xml"
+
+	// Maximum size of a tagging in a put-bucket-tagging request.
+	maxBucketTaggingSize = 64 * 1024
+)
+
+// getBucketTagging - returns the tags of given bucket name, false if
+// the bucket has no tags.
+func getBucketTagging(bucketName string) (*tagging.Tagging, bool) {
+	if globalBucketMetadataSys == nil {
+		return nil, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketTaggingConfig)
+	if !ok {
+		return nil, false
+	}
+	var tags tagging.Tagging
+	if err := xml.Unmarshal(data, &tags); err != nil {
+		return nil, false
+	}
+	return &tags, true
+}
@@ -0,0 +1,360 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+)
+
+const (
+	// Remote targets configuration file.
+	bucketTargetsConfig = "bucket-targets.json"
+
+	// Maximum size of a remote target in a set-remote-target request.
+	maxRemoteTargetSize = 64 * 1024
+)
+
+var errInvalidRemoteTarget = errors.New("invalid remote target")
+
+// BucketTargetType - type of service a remote target is used for.
+type BucketTargetType string
+
+const (
+	// ReplicationService - target of bucket replication.
+	ReplicationService BucketTargetType = "replication"
+
+	// ILMService - target of lifecycle transitions.
+	ILMService BucketTargetType = "ilm"
+
+	// CopyService - source of server side copies from a remote cluster.
+	CopyService BucketTargetType = "copy"
+)
+
+// IsValid - returns true if the target type is known.
+func (t BucketTargetType) IsValid() bool {
+	return t == ReplicationService || t == ILMService || t == CopyService
+}
+
+// BucketTarget - remote bucket that data of a local bucket is sent to,
+// or copied from, other subsystems reference targets by their ARN.
+type BucketTarget struct {
+	SourceBucket string           `json:"sourcebucket"`
+	Endpoint     string           `json:"endpoint"`
+	Credentials  auth.Credentials `json:"credentials"`
+	TargetBucket string           `json:"targetbucket"`
+	Type         BucketTargetType `json:"type"`
+	Arn          string           `json:"arn,omitempty"`
+}
+
+// validate - verifies that the target is usable.
+func (t BucketTarget) validate() error {
+	u, err := url.Parse(t.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errInvalidRemoteTarget
+	}
+	if !IsValidBucketName(t.TargetBucket) || !t.Type.IsValid() {
+		return errInvalidRemoteTarget
+	}
+	if t.Credentials.AccessKey == "" || t.Credentials.SecretKey == "" {
+		return errInvalidRemoteTarget
+	}
+	return nil
+}
+
+// sameRemote - returns true if both targets point to the same remote bucket.
+func (t BucketTarget) sameRemote(o BucketTarget) bool {
+	return t.Endpoint == o.Endpoint && t.TargetBucket == o.TargetBucket && t.Type == o.Type
+}
+
+// newBucketTargetARN - returns a new ARN for a target of the given type.
+func newBucketTargetARN(targetType BucketTargetType, targetBucket string) string {
+	return "arn:minio:" + string(targetType) + "::" + mustGetUUID() + ":" + targetBucket
+}
+
+// BucketTargetSys - remote bucket targets subsystem.
+type BucketTargetSys struct {
+	sync.RWMutex
+	targetsMap map[string][]BucketTarget
+	arnMap     map[string]BucketTarget
+}
+
+// ListTargets - returns targets of given bucket name, all types are
+// returned if targetType is empty.
+func (sys *BucketTargetSys) ListTargets(bucketName string, targetType BucketTargetType) []BucketTarget {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	targets := []BucketTarget{}
+	for _, target := range sys.targetsMap[bucketName] {
+		if targetType == "" || target.Type == targetType {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// GetTarget - returns the target having given ARN.
+func (sys *BucketTargetSys) GetTarget(arn string) (target BucketTarget, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	target, ok = sys.arnMap[arn]
+	return target, ok
+}
+
+// Set - replaces all targets of given bucket name.
+func (sys *BucketTargetSys) Set(bucketName string, targets []BucketTarget) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.remove(bucketName)
+	if len(targets) == 0 {
+		return
+	}
+	sys.targetsMap[bucketName] = targets
+	for _, target := range targets {
+		sys.arnMap[target.Arn] = target
+	}
+}
+
+// Remove - removes all targets of given bucket name.
+func (sys *BucketTargetSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.remove(bucketName)
+}
+
+func (sys *BucketTargetSys) remove(bucketName string) {
+	for _, target := range sys.targetsMap[bucketName] {
+		delete(sys.arnMap, target.Arn)
+	}
+	delete(sys.targetsMap, bucketName)
+}
+
+// Load - reloads targets of given bucket name from the backend.
+func (sys *BucketTargetSys) Load(objAPI ObjectLayer, bucketName string) error {
+	targets, err := readBucketTargets(context.Background(), objAPI, bucketName)
+	if err != nil {
+		return err
+	}
+	sys.Set(bucketName, targets)
+	return nil
+}
+
+// removeDeletedBuckets - removes targets of buckets missed by delete-bucket notifications.
+func (sys *BucketTargetSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.targetsMap {
+		if !buckets.Contains(bucket) {
+			sys.remove(bucket)
+		}
+	}
+}
+
+// Refresh BucketTargetSys.
+func (sys *BucketTargetSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		if err = sys.Load(objAPI, bucket.Name); err != nil {
+			logger.LogIf(context.Background(), err)
+		}
+	}
+	return nil
+}
+
+// Init - initializes remote targets system from bucket-targets.json of all buckets.
+func (sys *BucketTargetSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	// Load BucketTargetSys once during boot.
+	if err := sys.refresh(objAPI); err != nil {
+		return err
+	}
+
+	// Refresh BucketTargetSys in background.
+	go func() {
+		ticker := time.NewTicker(globalRefreshBucketTargetsInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+				sys.refresh(objAPI)
+			}
+		}
+	}()
+	return nil
+}
+
+// NewBucketTargetSys - creates new remote targets system.
+func NewBucketTargetSys() *BucketTargetSys {
+	return &BucketTargetSys{
+		targetsMap: make(map[string][]BucketTarget),
+		arnMap:     make(map[string]BucketTarget),
+	}
+}
+
+// getBucketTargetsConfigFile - returns the path to remote targets of given bucket name in minioMetaBucket.
+func getBucketTargetsConfigFile(bucketName string) string {
+	return path.Join(bucketConfigPrefix, bucketName, bucketTargetsConfig)
+}
+
+// readBucketTargets - reads remote targets of given bucket name from the backend.
+func readBucketTargets(ctx context.Context, objAPI ObjectLayer, bucketName string) ([]BucketTarget, error) {
+	reader, err := readConfig(ctx, objAPI, getBucketTargetsConfigFile(bucketName))
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err = decryptConfigData(data); err != nil {
+		return nil, err
+	}
+
+	var targets []BucketTarget
+	if err = json.Unmarshal(data, &targets); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// saveBucketTargets - writes remote targets of given bucket name to the backend.
+func saveBucketTargets(objAPI ObjectLayer, bucketName string, targets []BucketTarget) error {
+	data, err := json.Marshal(targets)
+	if err != nil {
+		return err
+	}
+
+	if data, err = encryptConfigData(data); err != nil {
+		return err
+	}
+
+	return saveConfig(objAPI, getBucketTargetsConfigFile(bucketName), data)
+}
+
+// updateBucketTargets - reads remote targets of given bucket name from the
+// backend under a transaction lock, applies updateFn and saves the result back.
+func updateBucketTargets(objAPI ObjectLayer, bucketName string, updateFn func([]BucketTarget) ([]BucketTarget, error)) ([]BucketTarget, error) {
+	transactionConfigFile := getBucketTargetsConfigFile(bucketName) + ".transaction"
+
+	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
+	// and configFile, take a transaction lock to avoid data race between readConfig()
+	// and saveConfig().
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, transactionConfigFile)
+	if err := objLock.GetLock(globalOperationTimeout); err != nil {
+		return nil, err
+	}
+	defer objLock.Unlock()
+
+	targets, err := readBucketTargets(context.Background(), objAPI, bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	if targets, err = updateFn(targets); err != nil {
+		return nil, err
+	}
+
+	if err = saveBucketTargets(objAPI, bucketName, targets); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// setBucketTarget - adds target to the remote targets of its source bucket
+// and returns its ARN. A target pointing to an already registered remote
+// bucket replaces it and keeps its ARN.
+func setBucketTarget(objAPI ObjectLayer, target BucketTarget) (arn string, targets []BucketTarget, err error) {
+	if err = target.validate(); err != nil {
+		return "", nil, err
+	}
+
+	targets, err = updateBucketTargets(objAPI, target.SourceBucket, func(targets []BucketTarget) ([]BucketTarget, error) {
+		for i := range targets {
+			if targets[i].sameRemote(target) {
+				target.Arn = targets[i].Arn
+				targets[i] = target
+				return targets, nil
+			}
+		}
+		target.Arn = newBucketTargetARN(target.Type, target.TargetBucket)
+		return append(targets, target), nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return target.Arn, targets, nil
+}
+
+// removeBucketTarget - removes the target having given ARN from the remote targets of given bucket name.
+func removeBucketTarget(objAPI ObjectLayer, bucketName, arn string) ([]BucketTarget, error) {
+	return updateBucketTargets(objAPI, bucketName, func(targets []BucketTarget) ([]BucketTarget, error) {
+		for i := range targets {
+			if targets[i].Arn == arn {
+				return append(targets[:i], targets[i+1:]...), nil
+			}
+		}
+		return nil, BucketRemoteTargetNotFound{Bucket: bucketName}
+	})
+}
+
+func removeBucketTargetsConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, getBucketTargetsConfigFile(bucketName)); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Tests registering and removing remote targets.
+func TestBucketTargets(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	ctx := context.Background()
+	if err = objLayer.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatalf("unable to create bucket, %s", err)
+	}
+
+	target := BucketTarget{
+		SourceBucket: "bucket",
+		Endpoint:     "https://remote:9000",
+		Credentials:  auth.Credentials{AccessKey: "accesskey", SecretKey: "secretkey"},
+		TargetBucket: "replica",
+		Type:         ReplicationService,
+	}
+
+	invalidTargets := []BucketTarget{
+		{SourceBucket: "bucket", Endpoint: "ftp://remote", Credentials: target.Credentials, TargetBucket: "replica", Type: ReplicationService},
+		{SourceBucket: "bucket", Endpoint: "https://remote:9000", Credentials: target.Credentials, TargetBucket: "a", Type: ReplicationService},
+		{SourceBucket: "bucket", Endpoint: "https://remote:9000", Credentials: target.Credentials, TargetBucket: "replica", Type: "backup"},
+		{SourceBucket: "bucket", Endpoint: "https://remote:9000", TargetBucket: "replica", Type: ILMService},
+	}
+	for i, invalidTarget := range invalidTargets {
+		if _, _, err = setBucketTarget(objLayer, invalidTarget); err != errInvalidRemoteTarget {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, errInvalidRemoteTarget, err)
+		}
+	}
+
+	arn, _, err := setBucketTarget(objLayer, target)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// Registering the same remote bucket again keeps its ARN.
+	target.Credentials.SecretKey = "newsecretkey"
+	newArn, _, err := setBucketTarget(objLayer, target)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if newArn != arn {
+		t.Fatalf("expected: %s, got: %s", arn, newArn)
+	}
+
+	tier := target
+	tier.Type = ILMService
+	if _, _, err = setBucketTarget(objLayer, tier); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	sys := NewBucketTargetSys()
+	if err = sys.refresh(objLayer); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if targets := sys.ListTargets("bucket", ""); len(targets) != 2 {
+		t.Fatalf("expected: 2 targets, got: %v", targets)
+	}
+	targets := sys.ListTargets("bucket", ReplicationService)
+	if len(targets) != 1 || targets[0].Arn != arn {
+		t.Fatalf("expected: %s, got: %v", arn, targets)
+	}
+	target.Arn = arn
+	if got, ok := sys.GetTarget(arn); !ok || got != target {
+		t.Fatalf("expected: %v, got: %v", target, got)
+	}
+
+	if _, err = removeBucketTarget(objLayer, "bucket", "arn:minio:replication::unknown:replica"); err != (BucketRemoteTargetNotFound{Bucket: "bucket"}) {
+		t.Fatalf("expected: %v, got: %v", BucketRemoteTargetNotFound{Bucket: "bucket"}, err)
+	}
+	if _, err = removeBucketTarget(objLayer, "bucket", arn); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = sys.Load(objLayer, "bucket"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, ok := sys.GetTarget(arn); ok {
+		t.Fatal("expected target to be removed")
+	}
+
+	// Targets stored with former server credentials fail to load
+	// instead of being dropped, and are readable again once re-encrypted.
+	if _, _, err = setBucketTarget(objLayer, target); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	prevCred := globalServerConfig.GetCredential()
+	serverCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("unable to generate credentials, %s", err)
+	}
+	globalServerConfig.SetCredential(serverCred)
+	if _, err = readBucketTargets(ctx, objLayer, "bucket"); err == nil {
+		t.Fatal("expected targets stored with former credentials to fail to load")
+	}
+	globalServerConfig.SetCredential(prevCred)
+	if err = reencryptConfigs(ctx, objLayer, prevCred.SecretKey, serverCred.SecretKey, func() error {
+		globalServerConfig.SetCredential(serverCred)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if targets, err = readBucketTargets(ctx, objLayer, "bucket"); err != nil || len(targets) != 2 {
+		t.Fatalf("expected: 2 targets, got: %v, %v", targets, err)
+	}
+
+	if err = removeBucketTargetsConfig(ctx, objLayer, "bucket"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if targets, err = readBucketTargets(ctx, objLayer, "bucket"); err != nil || len(targets) != 0 {
+		t.Fatalf("expected no targets, got: %v, %v", targets, err)
+	}
+}
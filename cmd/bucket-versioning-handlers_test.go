@@ -0,0 +1,272 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling bucket versioning handler tests for both XL multiple disks and single node setup.
+func TestBucketVersioningHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketVersioningHandlers, []string{"PutBucketVersioning", "GetBucketVersioning", "ListObjectVersions", "PutObject", "GetObject", "DeleteObject"})
+}
+
+func testBucketVersioningHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	serve := func(method, urlStr string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	config := []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`)
+	rec := serve("PUT", getBucketVersioningURL("", bucketName), config)
+	if instanceType == FSTestStr {
+		if rec.Code != http.StatusNotImplemented {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotImplemented, rec.Code)
+		}
+		return
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	testCases := []struct {
+		bucketName         string
+		config             []byte
+		expectedRespStatus int
+	}{
+		{"non-existent-bucket", config, http.StatusNotFound},
+		{bucketName, []byte(`<VersioningConfiguration><Status>Disabled</Status></VersioningConfiguration>`), http.StatusBadRequest},
+		{bucketName, []byte(`<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Enabled</MfaDelete></VersioningConfiguration>`), http.StatusNotImplemented},
+	}
+	for i, testCase := range testCases {
+		if rec = serve("PUT", getBucketVersioningURL("", testCase.bucketName), testCase.config); rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("%s: Case %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+
+	if rec = serve("GET", getBucketVersioningURL("", bucketName), nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var versioning VersioningConfiguration
+	if err := xml.Unmarshal(rec.Body.Bytes(), &versioning); err != nil {
+		t.Fatal(err)
+	}
+	if versioning.Status != versioningEnabled {
+		t.Fatalf("%s: Expected versioning status `%s`, but instead found `%s`", instanceType, versioningEnabled, versioning.Status)
+	}
+
+	// Put two versions of an object and read the first one back.
+	var versionIDs []string
+	for _, data := range []string{"version1", "version2"} {
+		if rec = serve("PUT", getPutObjectURL("", bucketName, "object"), []byte(data)); rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		versionIDs = append(versionIDs, rec.Header().Get(amzVersionID))
+	}
+	if versionIDs[0] == "" || versionIDs[0] == versionIDs[1] {
+		t.Fatalf("%s: Unexpected version IDs %v", instanceType, versionIDs)
+	}
+	if rec = serve("GET", getObjectVersionURL("", bucketName, "object", versionIDs[0]), nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if data, _ := ioutil.ReadAll(rec.Body); string(data) != "version1" {
+		t.Fatalf("%s: Expected `version1`, but instead found `%s`", instanceType, string(data))
+	}
+	if rec = serve("GET", getObjectVersionURL("", bucketName, "object", "invalid"), nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+
+	// Deleting the object adds a delete marker, which can't be read.
+	if rec = serve("DELETE", getDeleteObjectURL("", bucketName, "object"), nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec.Header().Get(amzDeleteMarker) != "true" {
+		t.Fatalf("%s: Expected a delete marker", instanceType)
+	}
+	markerID := rec.Header().Get(amzVersionID)
+	if rec = serve("GET", getGetObjectURL("", bucketName, "object"), nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec = serve("GET", getObjectVersionURL("", bucketName, "object", markerID), nil); rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusMethodNotAllowed, rec.Code)
+	}
+
+	// Deleting the delete marker restores the object.
+	if rec = serve("DELETE", getObjectVersionURL("", bucketName, "object", markerID), nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec = serve("GET", getGetObjectURL("", bucketName, "object"), nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if rec.Header().Get(amzVersionID) != versionIDs[1] {
+		t.Fatalf("%s: Expected version `%s`, but instead found `%s`", instanceType, versionIDs[1], rec.Header().Get(amzVersionID))
+	}
+	// Versions and delete markers are listed in order, newest first.
+	if rec = serve("DELETE", getDeleteObjectURL("", bucketName, "object"), nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	markerID = rec.Header().Get(amzVersionID)
+	if rec = serve("GET", getListObjectVersionsURL("", bucketName, "", "", "", "2"), nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var listVersions struct {
+		IsTruncated         bool
+		NextKeyMarker       string
+		NextVersionIDMarker string `xml:"NextVersionIdMarker"`
+		Entries             []struct {
+			XMLName   xml.Name
+			Key       string
+			VersionID string `xml:"VersionId"`
+			IsLatest  bool
+		} `xml:",any"`
+	}
+	if err := xml.Unmarshal(rec.Body.Bytes(), &listVersions); err != nil {
+		t.Fatal(err)
+	}
+	var entries []string
+	for _, entry := range listVersions.Entries {
+		if entry.XMLName.Local == "Version" || entry.XMLName.Local == "DeleteMarker" {
+			entries = append(entries, entry.XMLName.Local+":"+entry.VersionID)
+		}
+	}
+	if len(entries) != 2 || entries[0] != "DeleteMarker:"+markerID || entries[1] != "Version:"+versionIDs[1] {
+		t.Fatalf("%s: Unexpected versions %v", instanceType, entries)
+	}
+	if !listVersions.IsTruncated || listVersions.NextKeyMarker != "object" || listVersions.NextVersionIDMarker != versionIDs[1] {
+		t.Fatalf("%s: Unexpected truncated listing %+v", instanceType, listVersions)
+	}
+	if rec = serve("GET", getListObjectVersionsURL("", bucketName, "", "object", versionIDs[1], "2"), nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if !bytes.Contains(rec.Body.Bytes(), []byte("<VersionId>"+versionIDs[0]+"</VersionId>")) {
+		t.Fatalf("%s: Expected version `%s` to be listed", instanceType, versionIDs[0])
+	}
+	if rec = serve("GET", getListObjectVersionsURL("", bucketName, "", "", versionIDs[1], "2"), nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+}
+
+// Wrapper for calling multi-object delete of versions tests for both XL multiple disks and single node setup.
+func TestDeleteMultipleObjectVersionsHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testDeleteMultipleObjectVersionsHandler, []string{"PutBucketVersioning", "PutObject", "GetObject", "DeleteMultipleObjects"})
+}
+
+func testDeleteMultipleObjectVersionsHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	serve := func(method, urlStr string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	deleteObjects := func(objects []ObjectIdentifier) DeleteObjectsResponse {
+		rec := serve("POST", getDeleteMultipleObjectsURL("", bucketName), encodeResponse(DeleteObjectsRequest{Objects: objects}))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		var response DeleteObjectsResponse
+		if err := xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+
+	rec := serve("PUT", getBucketVersioningURL("", bucketName), []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+	if instanceType == FSTestStr {
+		return
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	var versionIDs []string
+	for _, object := range []string{"object", "object", "other"} {
+		if rec = serve("PUT", getPutObjectURL("", bucketName, object), []byte("data")); rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		versionIDs = append(versionIDs, rec.Header().Get(amzVersionID))
+	}
+
+	// Versions are removed, objects are deleted by adding a delete
+	// marker, and removing a missing version succeeds.
+	missingVersionID := mustGetUUID()
+	response := deleteObjects([]ObjectIdentifier{
+		{ObjectName: "object", VersionID: versionIDs[0]},
+		{ObjectName: "other"},
+		{ObjectName: "object", VersionID: "invalid"},
+		{ObjectName: "missing", VersionID: missingVersionID},
+	})
+	if len(response.DeletedObjects) != 3 || len(response.Errors) != 1 {
+		t.Fatalf("%s: Unexpected response %+v", instanceType, response)
+	}
+	if deleted := response.DeletedObjects[0]; deleted != (DeletedObject{ObjectName: "object", VersionID: versionIDs[0]}) {
+		t.Fatalf("%s: Unexpected deleted version %+v", instanceType, deleted)
+	}
+	markerID := response.DeletedObjects[1].DeleteMarkerVersionID
+	if deleted := response.DeletedObjects[1]; deleted.ObjectName != "other" || deleted.VersionID != "" || !deleted.DeleteMarker || markerID == "" {
+		t.Fatalf("%s: Unexpected delete marker %+v", instanceType, deleted)
+	}
+	if deleted := response.DeletedObjects[2]; deleted != (DeletedObject{ObjectName: "missing", VersionID: missingVersionID}) {
+		t.Fatalf("%s: Unexpected deleted version %+v", instanceType, deleted)
+	}
+	if deleteErr := response.Errors[0]; deleteErr.Key != "object" || deleteErr.VersionID != "invalid" || deleteErr.Code != "NoSuchVersion" {
+		t.Fatalf("%s: Unexpected error %+v", instanceType, deleteErr)
+	}
+
+	if rec = serve("GET", getObjectVersionURL("", bucketName, "object", versionIDs[0]), nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec = serve("GET", getGetObjectURL("", bucketName, "object"), nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if rec = serve("GET", getGetObjectURL("", bucketName, "other"), nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	// Removing the delete marker restores the object.
+	response = deleteObjects([]ObjectIdentifier{{ObjectName: "other", VersionID: markerID}})
+	expected := DeletedObject{ObjectName: "other", VersionID: markerID, DeleteMarker: true, DeleteMarkerVersionID: markerID}
+	if len(response.DeletedObjects) != 1 || response.DeletedObjects[0] != expected || len(response.Errors) != 0 {
+		t.Fatalf("%s: Unexpected response %+v", instanceType, response)
+	}
+	if rec = serve("GET", getGetObjectURL("", bucketName, "other"), nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+)
+
+const (
+	// Bucket versioning configuration file.
+	bucketVersioningConfig = "versioning.xml"
+
+	// Maximum size of a versioning configuration in a put-bucket-versioning request.
+	maxVersioningConfigSize = 64 * 1024
+
+	// Versioning states of a bucket, a bucket which never had versioning
+	// enabled has no versioning state.
+	versioningEnabled   = "Enabled"
+	versioningSuspended = "Suspended"
+
+	// Version ID of objects written while versioning is suspended or
+	// before it was enabled.
+	nullVersionID = "null"
+
+	// Response headers of requests on versioned objects.
+	amzVersionID    = "x-amz-version-id"
+	amzDeleteMarker = "x-amz-delete-marker"
+)
+
+// Object versions are identified by a version ID kept in the object
+// metadata, delete markers are empty objects flagged as such. Noncurrent
+// versions record the name of the object they belong to.
+const (
+	objectVersionIDKey     = ReservedMetadataPrefix + "Version-Id"
+	objectVersionObjectKey = ReservedMetadataPrefix + "Version-Object"
+	objectDeleteMarkerKey  = ReservedMetadataPrefix + "Delete-Marker"
+)
+
+var (
+	errInvalidVersioningConfig = errors.New("invalid versioning configuration")
+	errVersioningMFADelete     = errors.New("MFA delete is not supported")
+)
+
+// VersioningConfiguration - versioning configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTVersioningStatus.html
+type VersioningConfiguration struct {
+	XMLName   xml.Name `xml:"VersioningConfiguration"`
+	XMLNS     string   `xml:"xmlns,attr,omitempty"`
+	Status    string   `xml:"Status,omitempty"`
+	MFADelete string   `xml:"MfaDelete,omitempty"`
+}
+
+// parseVersioningConfig - parses and validates a versioning configuration.
+func parseVersioningConfig(reader io.Reader) (*VersioningConfiguration, error) {
+	var config VersioningConfiguration
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if config.Status != versioningEnabled && config.Status != versioningSuspended {
+		return nil, errInvalidVersioningConfig
+	}
+	switch config.MFADelete {
+	case "", "Disabled":
+	case "Enabled":
+		return nil, errVersioningMFADelete
+	default:
+		return nil, errInvalidVersioningConfig
+	}
+	config.XMLNS = ""
+	return &config, nil
+}
+
+// getBucketVersioning - returns the versioning state of given bucket
+// name, empty if versioning was never enabled.
+func getBucketVersioning(bucketName string) string {
+	if globalBucketMetadataSys == nil {
+		return ""
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketVersioningConfig)
+	if !ok {
+		return ""
+	}
+	var config VersioningConfiguration
+	if err := xml.Unmarshal(data, &config); err != nil {
+		return ""
+	}
+	return config.Status
+}
+
+// newObjectVersionID - returns the version ID of a new version of an
+// object of given bucket name, false if the bucket is not versioned.
+func newObjectVersionID(bucketName string) (string, bool) {
+	switch getBucketVersioning(bucketName) {
+	case versioningEnabled:
+		return mustGetUUID(), true
+	case versioningSuspended:
+		return nullVersionID, true
+	}
+	return "", false
+}
+
+// setObjectVersionID - records the version ID of a new version of an
+// object of given bucket name in its metadata, and removes any version
+// ID copied from another object if the bucket is not versioned.
+func setObjectVersionID(bucketName string, metadata map[string]string) {
+	if versionID, ok := newObjectVersionID(bucketName); ok {
+		metadata[objectVersionIDKey] = versionID
+		return
+	}
+	delete(metadata, objectVersionIDKey)
+}
+
+// setObjectVersionHeaders - sets the version ID of the object and whether
+// it is a delete marker in the response headers.
+func setObjectVersionHeaders(w http.ResponseWriter, objInfo ObjectInfo) {
+	if objInfo.VersionID != "" {
+		w.Header().Set(amzVersionID, objInfo.VersionID)
+	}
+	if objInfo.DeleteMarker {
+		w.Header().Set(amzDeleteMarker, "true")
+	}
+}
+
+// isValidVersionID - returns true if versionID may identify a version.
+func isValidVersionID(versionID string) bool {
+	if versionID == nullVersionID {
+		return true
+	}
+	_, err := uuid.Parse(versionID)
+	return err == nil
+}
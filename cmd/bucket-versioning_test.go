@@ -0,0 +1,83 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// Tests parsing versioning configurations.
+func TestParseVersioningConfig(t *testing.T) {
+	testCases := []struct {
+		config      string
+		expectedErr error
+	}{
+		{`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`, nil},
+		{`<VersioningConfiguration><Status>Suspended</Status><MfaDelete>Disabled</MfaDelete></VersioningConfiguration>`, nil},
+		{`<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Enabled</MfaDelete></VersioningConfiguration>`, errVersioningMFADelete},
+		{`<VersioningConfiguration><Status>Enabled</Status><MfaDelete>On</MfaDelete></VersioningConfiguration>`, errInvalidVersioningConfig},
+		{`<VersioningConfiguration><Status>Disabled</Status></VersioningConfiguration>`, errInvalidVersioningConfig},
+		{`<VersioningConfiguration></VersioningConfiguration>`, errInvalidVersioningConfig},
+	}
+
+	for i, testCase := range testCases {
+		_, err := parseVersioningConfig(strings.NewReader(testCase.config))
+		if err != testCase.expectedErr {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	if _, err := parseVersioningConfig(strings.NewReader(`<VersioningConfiguration>`)); err == nil {
+		t.Error("expected malformed configuration to fail")
+	}
+}
+
+// Tests version IDs of new versions as per the versioning state of a bucket.
+func TestNewObjectVersionID(t *testing.T) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+
+	metadata := map[string]string{objectVersionIDKey: nullVersionID}
+	if _, ok := newObjectVersionID("bucket"); ok {
+		t.Fatal("expected unversioned bucket")
+	}
+	setObjectVersionID("bucket", metadata)
+	if _, ok := metadata[objectVersionIDKey]; ok {
+		t.Fatal("expected version ID to be removed")
+	}
+
+	globalBucketMetadataSys.Set("bucket", bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+	versionID, ok := newObjectVersionID("bucket")
+	if !ok || versionID == nullVersionID || !isValidVersionID(versionID) {
+		t.Fatalf("unexpected version ID %s", versionID)
+	}
+
+	globalBucketMetadataSys.Set("bucket", bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`))
+	setObjectVersionID("bucket", metadata)
+	if metadata[objectVersionIDKey] != nullVersionID {
+		t.Fatalf("expected null version ID, got %s", metadata[objectVersionIDKey])
+	}
+
+	globalBucketMetadataSys.Remove("bucket")
+	if state := getBucketVersioning("bucket"); state != "" {
+		t.Fatalf("expected no versioning state, got %s", state)
+	}
+
+	if isValidVersionID("version") {
+		t.Fatal("expected invalid version ID")
+	}
+}
@@ -0,0 +1,221 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/website"
+)
+
+// Wrapper for calling bucket website handler tests for both XL multiple disks and single node setup.
+func TestBucketWebsiteHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketWebsiteHandlers, []string{"PutBucketWebsite", "GetBucketWebsite", "DeleteBucketWebsite", "PutObject", "HeadObject"})
+}
+
+func testBucketWebsiteHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	globalWebsiteDomainName = "website.test"
+	defer func() { globalWebsiteDomainName = "" }()
+	api := objectAPIHandlers{
+		ObjectAPI: newObjectLayerFn,
+		CacheAPI:  newCacheObjectsFn,
+	}
+	websiteRouter := mux.NewRouter()
+	websiteRouter.Host("{bucket:.+}." + globalWebsiteDomainName).HandlerFunc(api.WebsiteHandler)
+
+	serve := func(method, urlStr string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	serveWebsite := func(method, bucket, path string) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, "http://"+bucket+"."+globalWebsiteDomainName+path, 0, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		if !isWebsiteRequest(req) {
+			t.Fatalf("%s: Expected %s to be a website request", instanceType, req.Host)
+		}
+		rec := httptest.NewRecorder()
+		websiteRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := serve("GET", getBucketWebsiteURL("", bucketName), nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec := serveWebsite("GET", bucketName, "/"); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	config := `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`
+	testCases := []struct {
+		bucketName   string
+		data         string
+		expectedCode int
+	}{
+		{bucketName, `<WebsiteConfiguration><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`, http.StatusBadRequest},
+		{bucketName, `<WebsiteConfiguration>`, http.StatusBadRequest},
+		{bucketName, `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule>` +
+			`<Redirect><HostName>example.com</HostName></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`, http.StatusNotImplemented},
+		{"missing-bucket", config, http.StatusNotFound},
+		{bucketName, config, http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getBucketWebsiteURL("", testCase.bucketName), []byte(testCase.data))
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+
+	rec := serve("GET", getBucketWebsiteURL("", bucketName), nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var websiteConfig website.Config
+	if err := xml.Unmarshal(rec.Body.Bytes(), &websiteConfig); err != nil {
+		t.Fatalf("%s: Failed to parse website configuration: <ERROR> %v", instanceType, err)
+	}
+	if websiteConfig.IndexDocument == nil || websiteConfig.IndexDocument.Suffix != "index.html" || websiteConfig.ErrorKey() != "error.html" {
+		t.Fatalf("%s: Unexpected website configuration %v", instanceType, websiteConfig)
+	}
+
+	for object, data := range map[string]string{"index.html": "home", "docs/index.html": "docs", "error.html": "oops", "private/index.html": "secret"} {
+		metadata := map[string]string{"content-type": "text/html"}
+		if _, err := obj.PutObject(context.Background(), bucketName, object, mustGetHashReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), metadata); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+
+	// Objects are stored with a website redirect location, which must
+	// be a path or an http or https URL.
+	redirectCases := []struct {
+		object       string
+		location     string
+		expectedCode int
+	}{
+		{"old.html", "/docs/", http.StatusOK},
+		{"moved.html", "https://example.com/new.html", http.StatusOK},
+		{"bad.html", "example.com/new.html", http.StatusBadRequest},
+		{"host.html", "//example.com/new.html", http.StatusBadRequest},
+		{"long.html", "/" + strings.Repeat("a", maxWebsiteRedirectLocationLength), http.StatusBadRequest},
+	}
+	for i, testCase := range redirectCases {
+		req, err := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, testCase.object), 4, bytes.NewReader([]byte("page")), credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		req.Header.Set(amzWebsiteRedirectLocation, testCase.location)
+		rec = httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+	if rec = serve("HEAD", getHeadObjectURL("", bucketName, "old.html"), nil); rec.Header().Get(amzWebsiteRedirectLocation) != "/docs/" {
+		t.Errorf("%s: Expected the website redirect location header, but instead found %v", instanceType, rec.Header())
+	}
+
+	// Objects are served if everyone may read them, the error document
+	// included.
+	if rec = serveWebsite("GET", bucketName, "/"); rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "AccessDenied") {
+		t.Fatalf("%s: Unexpected response `%d` %s", instanceType, rec.Code, rec.Body.String())
+	}
+	policyStr := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]},`+
+		`{"Effect":"Deny","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/private/*"]}]}`, bucketName, bucketName)
+	bucketPolicy, err := policy.ParseConfig(strings.NewReader(policyStr), bucketName)
+	if err != nil {
+		t.Fatalf("%s: Failed to parse bucket policy: <ERROR> %v", instanceType, err)
+	}
+	globalPolicySys.Set(bucketName, *bucketPolicy)
+	defer globalPolicySys.Remove(bucketName)
+
+	websiteCases := []struct {
+		method           string
+		bucketName       string
+		path             string
+		expectedCode     int
+		expectedBody     string
+		expectedLocation string
+	}{
+		{"GET", bucketName, "/", http.StatusOK, "home", ""},
+		{"GET", bucketName, "/index.html", http.StatusOK, "home", ""},
+		{"GET", bucketName, "/docs/", http.StatusOK, "docs", ""},
+		{"GET", bucketName, "/docs", http.StatusFound, "", "/docs/"},
+		{"GET", bucketName, "/old.html", http.StatusMovedPermanently, "", "/docs/"},
+		{"HEAD", bucketName, "/moved.html", http.StatusMovedPermanently, "", "https://example.com/new.html"},
+		{"GET", bucketName, "/missing.html", http.StatusNotFound, "oops", ""},
+		{"GET", bucketName, "/private/", http.StatusForbidden, "oops", ""},
+		{"HEAD", bucketName, "/docs/", http.StatusOK, "", ""},
+		{"PUT", bucketName, "/index.html", http.StatusMethodNotAllowed, "", ""},
+		{"GET", "missing-bucket", "/", http.StatusNotFound, "", ""},
+	}
+	for i, testCase := range websiteCases {
+		rec = serveWebsite(testCase.method, testCase.bucketName, testCase.path)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if testCase.expectedBody != "" && rec.Body.String() != testCase.expectedBody {
+			t.Errorf("%s: Test %d: Expected the response body %q, but instead found %q", instanceType, i+1, testCase.expectedBody, rec.Body.String())
+		}
+		if location := rec.Header().Get("Location"); location != testCase.expectedLocation {
+			t.Errorf("%s: Test %d: Expected the location %q, but instead found %q", instanceType, i+1, testCase.expectedLocation, location)
+		}
+	}
+	if rec = serveWebsite("HEAD", bucketName, "/"); rec.Body.Len() != 0 || rec.Header().Get("Content-Length") != "4" || rec.Header().Get("Content-Type") != "text/html" {
+		t.Errorf("%s: Unexpected response headers %v", instanceType, rec.Header())
+	}
+
+	// All requests are redirected to another host.
+	config = `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`
+	if rec = serve("PUT", getBucketWebsiteURL("", bucketName), []byte(config)); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	rec = serveWebsite("GET", bucketName, "/docs/a.html")
+	if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != "https://example.com/docs/a.html" {
+		t.Errorf("%s: Unexpected response `%d` %v", instanceType, rec.Code, rec.Header())
+	}
+
+	if rec = serve("DELETE", getBucketWebsiteURL("", bucketName), nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if rec = serve("GET", getBucketWebsiteURL("", bucketName), nil); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec = serveWebsite("GET", bucketName, "/"); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/ioutil"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/website"
+)
+
+const (
+	// Bucket website configuration file.
+	bucketWebsiteConfig = "website.xml"
+
+	// Maximum size of a website configuration in a put-bucket-website request.
+	maxWebsiteConfigSize = 128 * 1024
+
+	// Request and response header, and metadata key, of the location
+	// website requests to an object are redirected to.
+	amzWebsiteRedirectLocation          = "x-amz-website-redirect-location"
+	amzWebsiteRedirectLocationCanonical = "X-Amz-Website-Redirect-Location"
+
+	// Maximum length of a website redirect location.
+	maxWebsiteRedirectLocationLength = 2 * 1024
+)
+
+// isValidWebsiteRedirectLocation - returns true if the website redirect
+// location is either an absolute path within the bucket or an http or
+// https URL. Locations starting with "//" are network-path references to
+// another host and are rejected.
+func isValidWebsiteRedirectLocation(location string) bool {
+	if len(location) > maxWebsiteRedirectLocationLength {
+		return false
+	}
+	if hasPrefix(location, "/") {
+		return !hasPrefix(location, "//")
+	}
+	return hasPrefix(location, "http://") || hasPrefix(location, "https://")
+}
+
+// checkWebsiteRedirectLocation - validates the website redirect location
+// of an upload, if the request headers or form values set one.
+func checkWebsiteRedirectLocation(header http.Header) APIErrorCode {
+	if _, ok := header[amzWebsiteRedirectLocationCanonical]; !ok {
+		return ErrNone
+	}
+	if !isValidWebsiteRedirectLocation(header.Get(amzWebsiteRedirectLocationCanonical)) {
+		return ErrInvalidRedirectLocation
+	}
+	return ErrNone
+}
+
+// getBucketWebsite - returns the website configuration of given bucket
+// name, false if the bucket has none.
+func getBucketWebsite(bucketName string) (*website.Config, bool) {
+	if globalBucketMetadataSys == nil {
+		return nil, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketWebsiteConfig)
+	if !ok {
+		return nil, false
+	}
+	var config website.Config
+	if err := xml.Unmarshal(data, &config); err != nil {
+		return nil, false
+	}
+	return &config, true
+}
+
+// isWebsiteRequest - returns whether the request is sent to the website
+// endpoint of a bucket, i.e. a host under globalWebsiteDomainName.
+func isWebsiteRequest(r *http.Request) bool {
+	if globalWebsiteDomainName == "" {
+		return false
+	}
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	return hasSuffix(host, "."+globalWebsiteDomainName)
+}
+
+// getWebsiteObjectInfo - returns the info of an object served by the
+// website endpoint of a bucket. Website requests are anonymous, only
+// objects readable by everyone as per the bucket policy are served.
+func getWebsiteObjectInfo(ctx context.Context, objAPI ObjectLayer, r *http.Request, bucket, object string) (ObjectInfo, APIErrorCode) {
+	if !globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, ""),
+		IsOwner:         false,
+		ObjectName:      object,
+	}) {
+		return ObjectInfo{}, ErrAccessDenied
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object)
+	if err != nil {
+		return objInfo, toAPIErrorCode(err)
+	}
+	if objInfo.DeleteMarker || objInfo.IsDir {
+		return objInfo, ErrNoSuchKey
+	}
+	if objAPI.IsEncryptionSupported() {
+		if apiErr, _ := DecryptObjectInfo(&objInfo, r.Header); apiErr != ErrNone {
+			return objInfo, apiErr
+		}
+	}
+	return objInfo, ErrNone
+}
+
+// writeWebsiteObject - writes an object with given status code, only its
+// headers for HEAD requests.
+func writeWebsiteObject(ctx context.Context, w http.ResponseWriter, r *http.Request, objAPI ObjectLayer, objInfo ObjectInfo, statusCode int) {
+	var startOffset int64
+	length := objInfo.Size
+
+	var writer io.Writer = w
+	if objAPI.IsEncryptionSupported() && objInfo.IsEncrypted() {
+		writer = ioutil.LimitedWriter(writer, 0, length)
+		var err error
+		writer, startOffset, length, err = DecryptBlocksRequest(writer, r, objInfo.Bucket, objInfo.Name, 0, length, objInfo, false)
+		if err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+	}
+
+	setObjectHeaders(w, objInfo, nil)
+	w.WriteHeader(statusCode)
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	err := objAPI.GetObject(ctx, objInfo.Bucket, objInfo.Name, startOffset, length, writer, objInfo.ETag)
+	if err == nil {
+		if closer, ok := writer.(io.Closer); ok {
+			err = closer.Close()
+		}
+	}
+	logger.LogIf(ctx, err)
+}
+
+// writeWebsiteError - writes the error document of the website with the
+// status code of the error, or the error itself if the website has no
+// error document or it cannot be served.
+func writeWebsiteError(ctx context.Context, w http.ResponseWriter, r *http.Request, objAPI ObjectLayer, bucket string, config *website.Config, apiErr APIErrorCode) {
+	statusCode := getAPIError(apiErr).HTTPStatusCode
+	if errorKey := config.ErrorKey(); errorKey != "" && statusCode >= 400 && statusCode < 500 {
+		if objInfo, errorDocErr := getWebsiteObjectInfo(ctx, objAPI, r, bucket, errorKey); errorDocErr == ErrNone {
+			writeWebsiteObject(ctx, w, r, objAPI, objInfo, statusCode)
+			return
+		}
+	}
+	if r.Method == http.MethodHead {
+		writeErrorResponseHeadersOnly(w, apiErr)
+		return
+	}
+	writeErrorResponse(w, apiErr, r.URL)
+}
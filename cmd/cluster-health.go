@@ -0,0 +1,141 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Health states of a cluster, from best to worst.
+const (
+	// All servers and disks are online.
+	clusterHealthy = "healthy"
+
+	// Some servers or disks are offline, all erasure sets still have
+	// write quorum.
+	clusterDegraded = "degraded"
+
+	// Some erasure sets lost write quorum, objects can still be read.
+	clusterReadOnly = "read-only"
+
+	// Some erasure sets lost read quorum.
+	clusterUnavailable = "unavailable"
+)
+
+// ServerHealth holds whether a server answered the health check.
+type ServerHealth struct {
+	Addr   string `json:"addr"`
+	Online bool   `json:"online"`
+	Error  string `json:"error,omitempty"`
+}
+
+// SetHealth holds the number of online disks of an erasure set along
+// with the number of disks it needs to serve reads and writes.
+type SetHealth struct {
+	Set          int      `json:"set"`
+	Status       string   `json:"status"`
+	OnlineDisks  int      `json:"onlineDisks"`
+	TotalDisks   int      `json:"totalDisks"`
+	ReadQuorum   int      `json:"readQuorum"`
+	WriteQuorum  int      `json:"writeQuorum"`
+	OfflineDisks []string `json:"offlineDisks,omitempty"`
+}
+
+// ClusterHealth holds the health of the cluster, which is the health of
+// its worst erasure set, or degraded when all sets have quorum but some
+// servers or disks are offline.
+type ClusterHealth struct {
+	Status  string         `json:"status"`
+	Servers []ServerHealth `json:"servers"`
+	Sets    []SetHealth    `json:"sets"`
+}
+
+// clusterHealthRank - orders health states from best to worst.
+var clusterHealthRank = map[string]int{
+	clusterHealthy:     0,
+	clusterDegraded:    1,
+	clusterReadOnly:    2,
+	clusterUnavailable: 3,
+}
+
+// getSetHealth - returns the health of a set having the given number of
+// online disks.
+func getSetHealth(onlineDisks, totalDisks, readQuorum, writeQuorum int) string {
+	switch {
+	case onlineDisks < readQuorum:
+		return clusterUnavailable
+	case onlineDisks < writeQuorum:
+		return clusterReadOnly
+	case onlineDisks < totalDisks:
+		return clusterDegraded
+	}
+	return clusterHealthy
+}
+
+// getClusterHealth - computes the health of the cluster from the server
+// info replied by all servers. Endpoints are split in sets of
+// drivesPerSet disks, a disk is online when the server it belongs to
+// reports it ok.
+func getClusterHealth(endpoints EndpointList, drivesPerSet, readQuorum, writeQuorum int, servers []ServerInfo) ClusterHealth {
+	health := ClusterHealth{Status: clusterHealthy}
+
+	onlineDisks := make(map[string]bool)
+	for _, server := range servers {
+		serverHealth := ServerHealth{Addr: server.Addr, Error: server.Error}
+		if server.Error == "" && server.Data != nil {
+			serverHealth.Online = true
+			for _, disk := range server.Data.Disks {
+				onlineDisks[disk.Endpoint] = disk.State == madmin.DriveStateOk
+			}
+		} else {
+			health.Status = clusterDegraded
+		}
+		health.Servers = append(health.Servers, serverHealth)
+	}
+
+	for i := 0; drivesPerSet > 0 && i+drivesPerSet <= len(endpoints); i += drivesPerSet {
+		setHealth := SetHealth{
+			Set:         i / drivesPerSet,
+			TotalDisks:  drivesPerSet,
+			ReadQuorum:  readQuorum,
+			WriteQuorum: writeQuorum,
+		}
+		for _, endpoint := range endpoints[i : i+drivesPerSet] {
+			if onlineDisks[endpoint.String()] {
+				setHealth.OnlineDisks++
+			} else {
+				setHealth.OfflineDisks = append(setHealth.OfflineDisks, endpoint.String())
+			}
+		}
+		setHealth.Status = getSetHealth(setHealth.OnlineDisks, setHealth.TotalDisks, readQuorum, writeQuorum)
+		if clusterHealthRank[setHealth.Status] > clusterHealthRank[health.Status] {
+			health.Status = setHealth.Status
+		}
+		health.Sets = append(health.Sets, setHealth)
+	}
+	return health
+}
+
+// getClusterQuorums - returns the number of disks of a set needed to
+// read and write objects of the standard storage class.
+func getClusterQuorums(drivesPerSet int) (readQuorum, writeQuorum int) {
+	if !globalIsXL {
+		return 1, 1
+	}
+	dataDrives, _ := getRedundancyCount(standardStorageClass, drivesPerSet)
+	return dataDrives, dataDrives + 1
+}
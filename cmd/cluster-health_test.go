@@ -0,0 +1,95 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestGetClusterHealth(t *testing.T) {
+	endpoints := mustGetNewEndpointList(
+		"http://10.0.0.1:9000/d1", "http://10.0.0.1:9000/d2", "http://10.0.0.2:9000/d1", "http://10.0.0.2:9000/d2",
+		"http://10.0.0.1:9000/d3", "http://10.0.0.1:9000/d4", "http://10.0.0.2:9000/d3", "http://10.0.0.2:9000/d4",
+	)
+
+	// newServerInfo - returns the server info of a server whose disks
+	// have the given states.
+	newServerInfo := func(addr string, disks map[string]string) ServerInfo {
+		data := &ServerInfoData{}
+		for endpoint, state := range disks {
+			data.Disks = append(data.Disks, ServerDiskInfo{Endpoint: endpoint, State: state})
+		}
+		return ServerInfo{Addr: addr, Data: data}
+	}
+	server1 := func(d1State string) ServerInfo {
+		return newServerInfo("server1", map[string]string{
+			"http://10.0.0.1:9000/d1": d1State,
+			"http://10.0.0.1:9000/d2": madmin.DriveStateOk,
+			"http://10.0.0.1:9000/d3": madmin.DriveStateOk,
+			"http://10.0.0.1:9000/d4": madmin.DriveStateOk,
+		})
+	}
+	server2 := newServerInfo("server2", map[string]string{
+		"http://10.0.0.2:9000/d1": madmin.DriveStateOk,
+		"http://10.0.0.2:9000/d2": madmin.DriveStateOk,
+		"http://10.0.0.2:9000/d3": madmin.DriveStateOk,
+		"http://10.0.0.2:9000/d4": madmin.DriveStateOk,
+	})
+	server2Down := ServerInfo{Addr: "server2", Error: "connection refused"}
+
+	testCases := []struct {
+		servers        []ServerInfo
+		readQuorum     int
+		writeQuorum    int
+		expectedStatus string
+		expectedSets   []string
+	}{
+		{[]ServerInfo{server1(madmin.DriveStateOk), server2}, 2, 3, clusterHealthy, []string{clusterHealthy, clusterHealthy}},
+		{[]ServerInfo{server1(madmin.DriveStateOffline), server2}, 2, 3, clusterDegraded, []string{clusterDegraded, clusterHealthy}},
+		{[]ServerInfo{server1(madmin.DriveStateMissing), server2}, 2, 4, clusterReadOnly, []string{clusterReadOnly, clusterHealthy}},
+		{[]ServerInfo{server1(madmin.DriveStateOk), server2Down}, 2, 3, clusterReadOnly, []string{clusterReadOnly, clusterReadOnly}},
+		{[]ServerInfo{server1(madmin.DriveStateCorrupt), server2Down}, 2, 3, clusterUnavailable, []string{clusterUnavailable, clusterReadOnly}},
+	}
+
+	for i, testCase := range testCases {
+		health := getClusterHealth(endpoints, 4, testCase.readQuorum, testCase.writeQuorum, testCase.servers)
+		if health.Status != testCase.expectedStatus {
+			t.Fatalf("case %v: expected status %s, got %s", i+1, testCase.expectedStatus, health.Status)
+		}
+		if len(health.Servers) != len(testCase.servers) {
+			t.Fatalf("case %v: expected %d servers, got %v", i+1, len(testCase.servers), health.Servers)
+		}
+		for j, server := range health.Servers {
+			if server.Online != (testCase.servers[j].Error == "") {
+				t.Fatalf("case %v: unexpected health of server %v", i+1, server)
+			}
+		}
+		if len(health.Sets) != len(testCase.expectedSets) {
+			t.Fatalf("case %v: expected %d sets, got %v", i+1, len(testCase.expectedSets), health.Sets)
+		}
+		for j, set := range health.Sets {
+			if set.Status != testCase.expectedSets[j] {
+				t.Fatalf("case %v: expected set %d to be %s, got %v", i+1, j, testCase.expectedSets[j], set)
+			}
+			if set.OnlineDisks+len(set.OfflineDisks) != set.TotalDisks {
+				t.Fatalf("case %v: unexpected disks of set %v", i+1, set)
+			}
+		}
+	}
+}
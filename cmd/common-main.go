@@ -18,7 +18,10 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
@@ -29,9 +32,12 @@ import (
 	etcd "github.com/coreos/etcd/clientv3"
 
 	"github.com/minio/cli"
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/dns"
+	"github.com/minio/minio/pkg/iam/ldap"
+	"github.com/minio/minio/pkg/iam/validator"
 
 	"github.com/minio/minio-go/pkg/set"
 )
@@ -180,6 +186,9 @@ func handleCommonEnvVars() {
 
 	globalDomainName, globalIsEnvDomainName = os.LookupEnv("MINIO_DOMAIN")
 
+	// Website endpoints of buckets are served under their own domain.
+	globalWebsiteDomainName = os.Getenv("MINIO_WEBSITE_DOMAIN")
+
 	minioEndpointsEnv, ok := os.LookupEnv("MINIO_PUBLIC_IPS")
 	if ok {
 		minioEndpoints := strings.Split(minioEndpointsEnv, ",")
@@ -197,6 +206,12 @@ func handleCommonEnvVars() {
 		logger.FatalIf(err, "Unable to initialize DNS config for %s.", globalDomainName)
 	}
 
+	if masterKey := os.Getenv("MINIO_SSE_MASTER_KEY"); masterKey != "" {
+		var err error
+		globalKMSKeyID, globalKMS, err = crypto.ParseMasterKey(masterKey)
+		logger.FatalIf(err, "Unable to parse MINIO_SSE_MASTER_KEY value")
+	}
+
 	if drives := os.Getenv("MINIO_CACHE_DRIVES"); drives != "" {
 		driveList, err := parseCacheDrives(strings.Split(drives, cacheEnvDelimiter))
 		if err != nil {
@@ -232,6 +247,23 @@ func handleCommonEnvVars() {
 			globalCacheMaxUse = maxUse
 		}
 	}
+
+	if timeoutStr := os.Getenv("MINIO_ADMIN_RPC_TIMEOUT"); timeoutStr != "" {
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil || timeout <= 0 {
+			logger.Fatal(uiErrInvalidAdminRPCTimeout(err), "Unable to parse MINIO_ADMIN_RPC_TIMEOUT value (`%s`)", timeoutStr)
+		}
+		globalAdminRPCTimeout = timeout
+	}
+
+	if retriesStr := os.Getenv("MINIO_ADMIN_RPC_RETRIES"); retriesStr != "" {
+		retries, err := strconv.Atoi(retriesStr)
+		if err != nil || retries < 0 {
+			logger.Fatal(uiErrInvalidAdminRPCRetries(err), "Unable to parse MINIO_ADMIN_RPC_RETRIES value (`%s`)", retriesStr)
+		}
+		globalAdminRPCMaxRetries = retries
+	}
+
 	// In place update is true by default if the MINIO_UPDATE is not set
 	// or is not set to 'off', if MINIO_UPDATE is set to 'off' then
 	// in-place update is off.
@@ -279,4 +311,72 @@ func handleCommonEnvVars() {
 		globalIsEnvWORM = true
 		globalWORMEnabled = bool(wormFlag)
 	}
+
+	// Get strict integrity environment variable.
+	if strictIntegrity := os.Getenv("MINIO_STRICT_INTEGRITY"); strictIntegrity != "" {
+		strictIntegrityFlag, err := ParseBoolFlag(strictIntegrity)
+		if err != nil {
+			logger.Fatal(uiErrInvalidStrictIntegrityValue(nil).Msg("Unknown value `%s`", strictIntegrity), "Unable to validate MINIO_STRICT_INTEGRITY environment variable")
+		}
+		globalStrictIntegrity = bool(strictIntegrityFlag)
+	}
+}
+
+// handleIAMEnvVars - configures the identity providers temporary
+// credentials are issued for, must be called once the root CAs are
+// loaded as providers are reached over TLS.
+func handleIAMEnvVars() {
+	// Web identity tokens are validated against the keys of the
+	// OpenID Connect provider, and its client ID if set.
+	if jwksURL := os.Getenv("MINIO_IAM_JWKS_URL"); jwksURL != "" {
+		var err error
+		globalOpenIDValidator, err = validator.NewJWT(jwksURL, os.Getenv("MINIO_IAM_OPENID_CLIENT_ID"), NewCustomHTTPTransport())
+		logger.FatalIf(err, "Unable to parse MINIO_IAM_JWKS_URL value (`%s`)", jwksURL)
+	}
+
+	// Users are authenticated by binding to the LDAP server, over TLS
+	// unless turned off, and their groups looked up.
+	if serverAddr := os.Getenv("MINIO_IAM_LDAP_SERVER_ADDR"); serverAddr != "" {
+		ldapConfig := ldap.Config{
+			ServerAddr:         serverAddr,
+			TLSConfig:          &tls.Config{RootCAs: globalRootCAs},
+			UsernameFormat:     os.Getenv("MINIO_IAM_LDAP_USERNAME_FORMAT"),
+			GroupSearchBaseDN:  os.Getenv("MINIO_IAM_LDAP_GROUP_SEARCH_BASE_DN"),
+			GroupSearchFilter:  os.Getenv("MINIO_IAM_LDAP_GROUP_SEARCH_FILTER"),
+			GroupNameAttribute: os.Getenv("MINIO_IAM_LDAP_GROUP_NAME_ATTRIBUTE"),
+		}
+		if ldapTLS := os.Getenv("MINIO_IAM_LDAP_TLS"); ldapTLS != "" {
+			tlsFlag, err := ParseBoolFlag(ldapTLS)
+			logger.FatalIf(err, "Unable to parse MINIO_IAM_LDAP_TLS value (`%s`)", ldapTLS)
+			if !tlsFlag {
+				ldapConfig.TLSConfig = nil
+			}
+		}
+		logger.FatalIf(ldapConfig.Validate(), "Unable to validate the LDAP configuration")
+
+		groupPolicies := os.Getenv("MINIO_IAM_LDAP_GROUP_POLICIES")
+		var err error
+		globalLDAPGroupPolicies, err = parseIdentityPolicies(groupPolicies)
+		logger.FatalIf(err, "Unable to parse MINIO_IAM_LDAP_GROUP_POLICIES value (`%s`)", groupPolicies)
+		globalLDAPConfig = &ldapConfig
+	}
+
+	// Client certificates are verified during the TLS handshake, with
+	// the CAs of the file.
+	if caFile := os.Getenv("MINIO_IAM_CLIENT_CA_FILE"); caFile != "" {
+		if !globalIsSSL {
+			logger.Fatal(errInvalidArgument, "Unable to verify client certificates without TLS")
+		}
+		caPEM, err := ioutil.ReadFile(caFile)
+		logger.FatalIf(err, "Unable to read MINIO_IAM_CLIENT_CA_FILE (`%s`)", caFile)
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			logger.Fatal(errInvalidArgument, "Unable to parse the certificates of MINIO_IAM_CLIENT_CA_FILE (`%s`)", caFile)
+		}
+
+		certPolicies := os.Getenv("MINIO_IAM_CLIENT_CERT_POLICIES")
+		globalClientCertPolicies, err = parseIdentityPolicies(certPolicies)
+		logger.FatalIf(err, "Unable to parse MINIO_IAM_CLIENT_CERT_POLICIES value (`%s`)", certPolicies)
+		globalClientCertCAs = clientCAs
+	}
 }
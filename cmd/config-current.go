@@ -483,19 +483,31 @@ func loadConfig() error {
 // A new notification target is added like below
 // * Add a new target in pkg/event/target package.
 // * Add newly added target configuration to serverConfig.Notify.<TARGET_NAME>.
-// * Handle the configuration in this function to create/add into TargetList.
+// * Handle the configuration in newNotificationTargets to create/add into TargetList.
 func getNotificationTargets(config *serverConfig) *event.TargetList {
+	targetList, errs := newNotificationTargets(config)
+	for _, err := range errs {
+		logger.LogIf(context.Background(), err)
+	}
+	return targetList
+}
+
+// newNotificationTargets - connects to the enabled notification targets
+// of the config, targets which can't be connected to are skipped and
+// their errors returned, keyed by the config key of the target.
+func newNotificationTargets(config *serverConfig) (*event.TargetList, map[string]error) {
 	targetList := event.NewTargetList()
+	errs := make(map[string]error)
 
 	for id, args := range config.Notify.AMQP {
 		if args.Enable {
 			newTarget, err := target.NewAMQPTarget(id, args)
 			if err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.amqp."+id] = err
 				continue
 			}
 			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.amqp."+id] = err
 				continue
 			}
 		}
@@ -505,14 +517,12 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		if args.Enable {
 			newTarget, err := target.NewElasticsearchTarget(id, args)
 			if err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.elasticsearch."+id] = err
 				continue
-
 			}
 			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.elasticsearch."+id] = err
 				continue
-
 			}
 		}
 	}
@@ -521,11 +531,11 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		if args.Enable {
 			newTarget, err := target.NewKafkaTarget(id, args)
 			if err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.kafka."+id] = err
 				continue
 			}
 			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.kafka."+id] = err
 				continue
 			}
 		}
@@ -535,11 +545,11 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		if args.Enable {
 			newTarget, err := target.NewMQTTTarget(id, args)
 			if err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.mqtt."+id] = err
 				continue
 			}
 			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.mqtt."+id] = err
 				continue
 			}
 		}
@@ -549,11 +559,11 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		if args.Enable {
 			newTarget, err := target.NewMySQLTarget(id, args)
 			if err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.mysql."+id] = err
 				continue
 			}
 			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.mysql."+id] = err
 				continue
 			}
 		}
@@ -563,11 +573,11 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		if args.Enable {
 			newTarget, err := target.NewNATSTarget(id, args)
 			if err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.nats."+id] = err
 				continue
 			}
 			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.nats."+id] = err
 				continue
 			}
 		}
@@ -577,11 +587,11 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		if args.Enable {
 			newTarget, err := target.NewPostgreSQLTarget(id, args)
 			if err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.postgresql."+id] = err
 				continue
 			}
 			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.postgresql."+id] = err
 				continue
 			}
 		}
@@ -591,11 +601,11 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		if args.Enable {
 			newTarget, err := target.NewRedisTarget(id, args)
 			if err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.redis."+id] = err
 				continue
 			}
 			if err = targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.redis."+id] = err
 				continue
 			}
 		}
@@ -605,11 +615,11 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		if args.Enable {
 			newTarget := target.NewWebhookTarget(id, args)
 			if err := targetList.Add(newTarget); err != nil {
-				logger.LogIf(context.Background(), err)
+				errs["notify.webhook."+id] = err
 				continue
 			}
 		}
 	}
 
-	return targetList
+	return targetList, errs
 }
@@ -0,0 +1,189 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+
+	"github.com/minio/minio/cmd/logger"
+	sha256 "github.com/minio/sha256-simd"
+	"github.com/minio/sio"
+)
+
+// Size of the random salt prefixed to encrypted configuration data.
+const configEncryptionSaltSize = 32
+
+// configEncryptionKey - derives the key protecting configuration data
+// holding credentials from the server secret key. Such data is
+// re-encrypted by reencryptConfigs when the server credentials change.
+func configEncryptionKey(secretKey string, salt []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write(salt)
+	return mac.Sum(nil)
+}
+
+// encryptConfigData - encrypts data with a key derived from the server
+// credentials and a random salt stored in front of the data.
+func encryptConfigData(data []byte) ([]byte, error) {
+	return encryptConfigDataWithSecret(data, globalServerConfig.GetCredential().SecretKey)
+}
+
+func encryptConfigDataWithSecret(data []byte, secretKey string) ([]byte, error) {
+	salt := make([]byte, configEncryptionSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(salt)
+	if _, err := sio.Encrypt(&buf, bytes.NewReader(data), sio.Config{Key: configEncryptionKey(secretKey, salt)}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decryptConfigData - decrypts data encrypted by encryptConfigData.
+func decryptConfigData(data []byte) ([]byte, error) {
+	return decryptConfigDataWithSecret(data, globalServerConfig.GetCredential().SecretKey)
+}
+
+func decryptConfigDataWithSecret(data []byte, secretKey string) ([]byte, error) {
+	if len(data) < configEncryptionSaltSize {
+		return nil, errObjectTampered
+	}
+
+	salt := data[:configEncryptionSaltSize]
+	var buf bytes.Buffer
+	if _, err := sio.Decrypt(&buf, bytes.NewReader(data[configEncryptionSaltSize:]), sio.Config{Key: configEncryptionKey(secretKey, salt)}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// getEncryptedConfigFiles - returns the paths in minioMetaBucket of all
+// configuration data encrypted by encryptConfigData.
+func getEncryptedConfigFiles(ctx context.Context, objAPI ObjectLayer) ([]string, error) {
+	configFiles := []string{
+		getServiceAccountsConfigFile(),
+		getTierConfigFile(),
+		getBatchJobsIndexFile(),
+	}
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range buckets {
+		configFiles = append(configFiles, getBucketTargetsConfigFile(bucket.Name))
+	}
+
+	tempAccountConfigFiles, err := listTempAccountConfigFiles(ctx, objAPI)
+	if err != nil {
+		return nil, err
+	}
+	return append(configFiles, tempAccountConfigFiles...), nil
+}
+
+// reencryptConfigFile - decrypts the given config file with a key derived
+// from oldSecretKey and saves it encrypted with a key derived from
+// newSecretKey. A missing config file is left alone.
+func reencryptConfigFile(ctx context.Context, objAPI ObjectLayer, configFile, oldSecretKey, newSecretKey string) error {
+	reader, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		if IsErrIgnored(err, errConfigNotFound, errNoSuchNotifications) {
+			return nil
+		}
+		return err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	if data, err = decryptConfigDataWithSecret(data, oldSecretKey); err != nil {
+		return err
+	}
+
+	if data, err = encryptConfigDataWithSecret(data, newSecretKey); err != nil {
+		return err
+	}
+
+	return saveConfig(objAPI, configFile, data)
+}
+
+// reencryptConfigs - re-encrypts all configuration data encrypted by
+// encryptConfigData from a key derived from oldSecretKey to one derived
+// from newSecretKey, then calls commitFn to switch the server credentials.
+// The transaction locks of all config files are held until commitFn
+// returns so that no data encrypted with the former key is saved in
+// between. If re-encrypting or commitFn fails, the config files are
+// restored to the former key.
+func reencryptConfigs(ctx context.Context, objAPI ObjectLayer, oldSecretKey, newSecretKey string, commitFn func() error) error {
+	// Temporary accounts are saved in config files of their own, take
+	// the transaction lock they are saved under before listing them.
+	tempAccountsLock := globalNSMutex.NewNSLock(minioMetaBucket, getTempAccountsConfigPrefix()+".transaction")
+	if err := tempAccountsLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer tempAccountsLock.Unlock()
+
+	configFiles, err := getEncryptedConfigFiles(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
+	// and configFile, take the transaction locks the writers of the config files take.
+	for _, configFile := range configFiles {
+		objLock := globalNSMutex.NewNSLock(minioMetaBucket, configFile+".transaction")
+		if err = objLock.GetLock(globalOperationTimeout); err != nil {
+			return err
+		}
+		defer objLock.Unlock()
+	}
+
+	for i, configFile := range configFiles {
+		if err = reencryptConfigFile(ctx, objAPI, configFile, oldSecretKey, newSecretKey); err != nil {
+			restoreEncryptedConfigs(ctx, objAPI, configFiles[:i], oldSecretKey, newSecretKey)
+			return err
+		}
+	}
+
+	if err = commitFn(); err != nil {
+		restoreEncryptedConfigs(ctx, objAPI, configFiles, oldSecretKey, newSecretKey)
+		return err
+	}
+	return nil
+}
+
+// restoreEncryptedConfigs - re-encrypts the given config files, already
+// re-encrypted by reencryptConfigs, back to a key derived from
+// oldSecretKey. Errors are only logged.
+func restoreEncryptedConfigs(ctx context.Context, objAPI ObjectLayer, configFiles []string, oldSecretKey, newSecretKey string) {
+	for _, configFile := range configFiles {
+		if err := reencryptConfigFile(ctx, objAPI, configFile, newSecretKey, oldSecretKey); err != nil {
+			logger.GetReqInfo(ctx).AppendTags("configFile", configFile)
+			logger.LogIf(ctx, err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Tests encryption of configuration data.
+func TestConfigDataEncryption(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	data := []byte(`[{"endpoint":"https://remote:9000"}]`)
+	encrypted, err := encryptConfigData(data)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if bytes.Contains(encrypted, data) {
+		t.Fatal("expected data to be encrypted")
+	}
+
+	decrypted, err := decryptConfigData(encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !bytes.Equal(decrypted, data) {
+		t.Fatalf("expected: %s, got: %s", data, decrypted)
+	}
+
+	// Tampered data must be rejected.
+	encrypted[len(encrypted)-1] ^= 0xff
+	if _, err = decryptConfigData(encrypted); err == nil {
+		t.Fatal("expected tampered data to be rejected")
+	}
+	if _, err = decryptConfigData(encrypted[:configEncryptionSaltSize-1]); err == nil {
+		t.Fatal("expected truncated data to be rejected")
+	}
+}
+
+// Tests re-encrypting configuration data on credential changes.
+func TestReencryptConfigs(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	tiers := map[string]TierConfig{"WARM": {Name: "WARM", Type: S3Tier, Endpoint: "https://s3.amazonaws.com", Bucket: "archive"}}
+	if err = saveTiers(objLayer, tiers); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	ta := tempAccount{SessionToken: "token", Expiration: UTCNow().Add(time.Hour)}
+	ta.Credentials.AccessKey = "tempaccount"
+	if err = saveTempAccount(objLayer, ta); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	ctx := context.Background()
+	prevCred := globalServerConfig.GetCredential()
+	serverCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("unable to generate credentials, %s", err)
+	}
+
+	// A failed credential change restores the former encryption.
+	errCommit := errors.New("commit failed")
+	if err = reencryptConfigs(ctx, objLayer, prevCred.SecretKey, serverCred.SecretKey, func() error {
+		return errCommit
+	}); err != errCommit {
+		t.Fatalf("expected: %v, got: %v", errCommit, err)
+	}
+	if got, err := readTiers(ctx, objLayer); err != nil || len(got) != 1 {
+		t.Fatalf("expected: %v, got: %v, %v", tiers, got, err)
+	}
+
+	if err = reencryptConfigs(ctx, objLayer, prevCred.SecretKey, serverCred.SecretKey, func() error {
+		globalServerConfig.SetCredential(serverCred)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if got, err := readTiers(ctx, objLayer); err != nil || len(got) != 1 {
+		t.Fatalf("expected: %v, got: %v, %v", tiers, got, err)
+	}
+	if got, err := readTempAccounts(ctx, objLayer); err != nil || got["tempaccount"].SessionToken != ta.SessionToken {
+		t.Fatalf("expected: %v, got: %v, %v", ta, got, err)
+	}
+
+	// Data that can't be decrypted with the current credentials is
+	// left alone and fails the credential change.
+	if err = reencryptConfigs(ctx, objLayer, prevCred.SecretKey, serverCred.SecretKey, func() error {
+		return nil
+	}); err == nil {
+		t.Fatal("expected re-encrypting with a wrong key to fail")
+	}
+	if got, err := readTiers(ctx, objLayer); err != nil || len(got) != 1 {
+		t.Fatalf("expected: %v, got: %v, %v", tiers, got, err)
+	}
+}
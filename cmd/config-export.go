@@ -0,0 +1,256 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Text formats config.json can be exported to and imported from.
+const (
+	// One key=value line per setting, nested keys are joined with '.'
+	// and values are JSON literals, e.g. `notify.amqp.1.enable=false`.
+	configFormatKV = "kv"
+
+	// YAML document having the structure of config.json.
+	configFormatYAML = "yaml"
+)
+
+var errInvalidConfigFormat = errors.New("invalid config format")
+
+// exportConfig - converts config.json to the given format.
+func exportConfig(configBytes []byte, format string) ([]byte, error) {
+	switch format {
+	case configFormatKV:
+		return configToKV(configBytes)
+	case configFormatYAML:
+		return configToYAML(configBytes)
+	}
+	return nil, errInvalidConfigFormat
+}
+
+// importConfig - converts data of the given format back to config.json
+// and verifies that all keys are known settings of the current config
+// version.
+func importConfig(data []byte, format string) (configBytes []byte, err error) {
+	switch format {
+	case configFormatKV:
+		configBytes, err = configFromKV(data)
+	case configFormatYAML:
+		configBytes, err = configFromYAML(data)
+	default:
+		return nil, errInvalidConfigFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(configBytes))
+	decoder.DisallowUnknownFields()
+	var config serverConfig
+	if err = decoder.Decode(&config); err != nil {
+		return nil, err
+	}
+	return configBytes, nil
+}
+
+// decodeConfigObject - decodes config.json into generic values keeping
+// numbers as they are.
+func decodeConfigObject(configBytes []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(configBytes))
+	decoder.UseNumber()
+	var config map[string]interface{}
+	if err := decoder.Decode(&config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// configToKV - flattens config.json into key=value lines sorted by key.
+// Non empty objects are flattened, other values are written as JSON.
+func configToKV(configBytes []byte) ([]byte, error) {
+	config, err := decodeConfigObject(configBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	var flatten func(prefix string, value interface{}) error
+	flatten = func(prefix string, value interface{}) error {
+		if object, ok := value.(map[string]interface{}); ok && (len(object) > 0 || prefix == "") {
+			for key, v := range object {
+				if key == "" || strings.ContainsAny(key, ".=#\n") {
+					return fmt.Errorf("config key %q of %q can't be exported", key, prefix)
+				}
+				if prefix != "" {
+					key = prefix + "." + key
+				}
+				if err := flatten(key, v); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		jsonValue, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, prefix+"="+string(jsonValue))
+		return nil
+	}
+	if err = flatten("", config); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(lines)
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// configFromKV - rebuilds config.json from key=value lines, blank lines
+// and lines starting with '#' are ignored.
+func configFromKV(data []byte) ([]byte, error) {
+	config := make(map[string]interface{})
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		i := strings.Index(line, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("line %d: expected key=value", lineNum)
+		}
+		key, jsonValue := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+
+		decoder := json.NewDecoder(strings.NewReader(jsonValue))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("line %d: invalid value of %s: %v", lineNum, key, err)
+		}
+		if decoder.More() {
+			return nil, fmt.Errorf("line %d: invalid value of %s", lineNum, key)
+		}
+
+		object := config
+		fields := strings.Split(key, ".")
+		for _, field := range fields[:len(fields)-1] {
+			if field == "" {
+				return nil, fmt.Errorf("line %d: invalid key %s", lineNum, key)
+			}
+			child, ok := object[field]
+			if !ok {
+				child = make(map[string]interface{})
+				object[field] = child
+			}
+			if object, ok = child.(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("line %d: %s conflicts with a previous key", lineNum, key)
+			}
+		}
+
+		field := fields[len(fields)-1]
+		if field == "" {
+			return nil, fmt.Errorf("line %d: invalid key %s", lineNum, key)
+		}
+		if _, ok := object[field]; ok {
+			return nil, fmt.Errorf("line %d: %s is set more than once", lineNum, key)
+		}
+		object[field] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(config)
+}
+
+// configToYAML - converts config.json to YAML.
+func configToYAML(configBytes []byte) ([]byte, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(config)
+}
+
+// configFromYAML - converts YAML back to config.json.
+func configFromYAML(data []byte) ([]byte, error) {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	jsonValue, err := yamlToJSONValue(config)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonValue)
+}
+
+// yamlToJSONValue - converts mappings decoded by the YAML parser, whose
+// keys may be of any type, into values the JSON encoder accepts.
+func yamlToJSONValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			jsonChild, err := yamlToJSONValue(child)
+			if err != nil {
+				return nil, err
+			}
+			object[fmt.Sprint(key)] = jsonChild
+		}
+		return object, nil
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			jsonChild, err := yamlToJSONValue(child)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = jsonChild
+		}
+		return object, nil
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, child := range v {
+			jsonChild, err := yamlToJSONValue(child)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = jsonChild
+		}
+		return array, nil
+	}
+	return value, nil
+}
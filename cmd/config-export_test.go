@@ -0,0 +1,117 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests that config.json survives an export followed by an import.
+func TestExportImportConfig(t *testing.T) {
+	var expectedConfig serverConfig
+	if err := json.Unmarshal(configJSON, &expectedConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{configFormatKV, configFormatYAML} {
+		data, err := exportConfig(configJSON, format)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", format, err)
+		}
+		configBytes, err := importConfig(data, format)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", format, err)
+		}
+
+		var config serverConfig
+		if err = json.Unmarshal(configBytes, &config); err != nil {
+			t.Fatalf("%s: unexpected error %v", format, err)
+		}
+		if !reflect.DeepEqual(config, expectedConfig) {
+			t.Fatalf("%s: expected %v, got %v", format, expectedConfig, config)
+		}
+	}
+
+	if _, err := exportConfig(configJSON, "toml"); err != errInvalidConfigFormat {
+		t.Fatalf("expected %v, got %v", errInvalidConfigFormat, err)
+	}
+}
+
+func TestConfigToKV(t *testing.T) {
+	data, err := configToKV([]byte(`{"version":"27","notify":{"amqp":{},"nats":{"1":{"enable":false,"port":4222}}},"brokers":["a","b"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `brokers=["a","b"]
+notify.amqp={}
+notify.nats.1.enable=false
+notify.nats.1.port=4222
+version="27"
+`
+	if string(data) != expected {
+		t.Fatalf("expected %q, got %q", expected, string(data))
+	}
+
+	if _, err = configToKV([]byte(`{"notify":{"a.b":true}}`)); err == nil {
+		t.Fatal("expected an error exporting a key holding a '.'")
+	}
+}
+
+func TestConfigFromKV(t *testing.T) {
+	testCases := []struct {
+		data         string
+		expectedJSON string
+		expectErr    bool
+	}{
+		{"# comment\n\nversion = \"27\"\nnotify.nats.1.port=4222\n", `{"notify":{"nats":{"1":{"port":4222}}},"version":"27"}`, false},
+		{"notify.amqp={}\n", `{"notify":{"amqp":{}}}`, false},
+		{"version\n", "", true},
+		{"=\"27\"\n", "", true},
+		{"version=27a\n", "", true},
+		{"version=\"27\" \"28\"\n", "", true},
+		{"version=\"27\"\nversion=\"28\"\n", "", true},
+		{"region=\"us\"\nregion.name=\"us\"\n", "", true},
+		{"notify..enable=true\n", "", true},
+		{"notify.=true\n", "", true},
+	}
+
+	for i, testCase := range testCases {
+		configBytes, err := configFromKV([]byte(testCase.data))
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("case %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+		if err == nil && string(configBytes) != testCase.expectedJSON {
+			t.Fatalf("case %d: expected %s, got %s", i+1, testCase.expectedJSON, string(configBytes))
+		}
+	}
+}
+
+func TestImportConfigUnknownKey(t *testing.T) {
+	data, err := exportConfig(configJSON, configFormatKV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data = append(data, []byte("regoin=\"us-east-1\"\n")...)
+	if _, err = importConfig(data, configFormatKV); err == nil || !strings.Contains(err.Error(), "regoin") {
+		t.Fatalf("expected unknown key to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// ConfigValidationError holds why a subsystem of a candidate config
+// can't be used by a server. Subsystem is "config" for config.json
+// itself, "kms" or the config key of a notification target, e.g.
+// "notify.amqp.1".
+type ConfigValidationError struct {
+	Subsystem string `json:"subsystem"`
+	Error     string `json:"error"`
+}
+
+// validateConfig - parses the candidate config and checks that this
+// server can use it, i.e. that its enabled notification targets can be
+// connected to. Webhook targets are only connected to when sending
+// events. The KMS is configured by environment and checked as well,
+// since servers are restarted once the config is committed.
+func validateConfig(configBytes []byte) []ConfigValidationError {
+	var config serverConfig
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return []ConfigValidationError{{Subsystem: "config", Error: err.Error()}}
+	}
+	if err := config.Validate(); err != nil {
+		return []ConfigValidationError{{Subsystem: "config", Error: err.Error()}}
+	}
+
+	var validationErrs []ConfigValidationError
+
+	targetList, errs := newNotificationTargets(&config)
+	// Errors closing connections of valid targets don't matter.
+	for range targetList.Remove(targetList.List()...) {
+	}
+	for subsystem, err := range errs {
+		validationErrs = append(validationErrs, ConfigValidationError{Subsystem: subsystem, Error: err.Error()})
+	}
+	sort.Slice(validationErrs, func(i, j int) bool {
+		return validationErrs[i].Subsystem < validationErrs[j].Subsystem
+	})
+
+	if globalKMS != nil {
+		status, err := localAdminClient{}.KMSKeyStatus("")
+		switch {
+		case err != nil:
+			validationErrs = append(validationErrs, ConfigValidationError{Subsystem: "kms", Error: err.Error()})
+		case status.EncryptionErr != "":
+			validationErrs = append(validationErrs, ConfigValidationError{Subsystem: "kms", Error: status.EncryptionErr})
+		case status.DecryptionErr != "":
+			validationErrs = append(validationErrs, ConfigValidationError{Subsystem: "kms", Error: status.DecryptionErr})
+		}
+	}
+
+	return validationErrs
+}
+
+// validateConfigPeers - validates the candidate config on all nodes,
+// returns the validation errors reported by each node along with the
+// errors of nodes which could not be reached.
+func validateConfigPeers(peers adminPeers, configBytes []byte) ([][]ConfigValidationError, []error) {
+	validationErrs := make([][]ConfigValidationError, len(peers))
+	errs := make([]error, len(peers))
+
+	wg := sync.WaitGroup{}
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(idx int, peer adminPeer) {
+			defer wg.Done()
+			validationErrs[idx], errs[idx] = peer.cmdRunner.ValidateConfig(configBytes)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	return validationErrs, errs
+}
@@ -0,0 +1,87 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/event/target"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// newTestUnreachableTargetConfig - returns the current config along with
+// an enabled AMQP target no server listens for.
+func newTestUnreachableTargetConfig(t *testing.T) []byte {
+	data, err := json.Marshal(globalServerConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config serverConfig
+	if err = json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := xnet.ParseURL("amqp://127.0.0.1:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Notify.AMQP = map[string]target.AMQPArgs{
+		"1": {Enable: true, URL: *u, Exchange: "minio", RoutingKey: "minio", ExchangeType: "direct"},
+	}
+
+	if data, err = json.Marshal(&config); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestValidateConfigSubsystems(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	configBytes, err := json.Marshal(globalServerConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		configBytes        []byte
+		expectedSubsystems []string
+	}{
+		{configBytes, nil},
+		{[]byte(`{`), []string{"config"}},
+		{[]byte(`{"version":"1"}`), []string{"config"}},
+		{newTestUnreachableTargetConfig(t), []string{"notify.amqp.1"}},
+	}
+
+	for i, testCase := range testCases {
+		validationErrs := validateConfig(testCase.configBytes)
+		if len(validationErrs) != len(testCase.expectedSubsystems) {
+			t.Fatalf("case %v: expected errors of %v, got: %v", i+1, testCase.expectedSubsystems, validationErrs)
+		}
+		for j, validationErr := range validationErrs {
+			if validationErr.Subsystem != testCase.expectedSubsystems[j] || validationErr.Error == "" {
+				t.Fatalf("case %v: expected error of %s, got: %v", i+1, testCase.expectedSubsystems[j], validationErr)
+			}
+		}
+	}
+}
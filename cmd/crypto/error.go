@@ -57,6 +57,11 @@ var (
 	errInvalidInternalSealAlgorithm = Error{"The internal seal algorithm is invalid and not supported"}
 )
 
+var (
+	errInvalidMasterKeyFormat = errors.New("The master key must be of the form <key-id>:<hex-encoded-key>")
+	errInvalidMasterKey       = errors.New("The master key must be a hex-encoded 256 bit key")
+)
+
 var (
 	// errOutOfEntropy indicates that the a source of randomness (PRNG) wasn't able
 	// to produce enough random data. This is fatal error and should cause a panic.
@@ -19,10 +19,12 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"github.com/minio/minio/cmd/logger"
 	sha256 "github.com/minio/sha256-simd"
@@ -92,6 +94,25 @@ type masterKeyKMS struct {
 	masterKey [32]byte
 }
 
+// ParseMasterKey parses the value of a master key of the form
+// "<key-id>:<hex-encoded-256-bit-key>" and returns the key ID
+// and a KMS which uses the master key.
+func ParseMasterKey(s string) (keyID string, kms KMS, err error) {
+	v := strings.SplitN(s, ":", 2)
+	if len(v) != 2 || v[0] == "" {
+		return "", nil, errInvalidMasterKeyFormat
+	}
+
+	var masterKey [32]byte
+	if len(v[1]) != hex.EncodedLen(len(masterKey)) {
+		return "", nil, errInvalidMasterKey
+	}
+	if _, err = hex.Decode(masterKey[:], []byte(v[1])); err != nil {
+		return "", nil, errInvalidMasterKey
+	}
+	return v[0], NewKMS(masterKey), nil
+}
+
 // NewKMS returns a basic KMS implementation from a single 256 bit master key.
 //
 // The KMS accepts any keyID but binds the keyID and context cryptographically
@@ -59,6 +59,36 @@ func TestMasterKeyKMS(t *testing.T) {
 	}
 }
 
+var parseMasterKeyTests = []struct {
+	MasterKey     string
+	ExpectedKeyID string
+	ShouldFail    bool
+}{
+	{MasterKey: "my-key:" + strings.Repeat("0", 64), ExpectedKeyID: "my-key", ShouldFail: false},  // 0
+	{MasterKey: "my-key:" + strings.Repeat("aB", 32), ExpectedKeyID: "my-key", ShouldFail: false}, // 1
+	{MasterKey: "my:key:" + strings.Repeat("0", 64), ExpectedKeyID: "", ShouldFail: true},         // 2
+	{MasterKey: strings.Repeat("0", 64), ExpectedKeyID: "", ShouldFail: true},                     // 3
+	{MasterKey: ":" + strings.Repeat("0", 64), ExpectedKeyID: "", ShouldFail: true},               // 4
+	{MasterKey: "my-key:" + strings.Repeat("0", 62), ExpectedKeyID: "", ShouldFail: true},         // 5
+	{MasterKey: "my-key:" + strings.Repeat("x", 64), ExpectedKeyID: "", ShouldFail: true},         // 6
+	{MasterKey: "my-key:" + strings.Repeat("0", 64) + "00", ExpectedKeyID: "", ShouldFail: true},  // 7
+}
+
+func TestParseMasterKey(t *testing.T) {
+	for i, test := range parseMasterKeyTests {
+		keyID, kms, err := ParseMasterKey(test.MasterKey)
+		if err != nil && !test.ShouldFail {
+			t.Errorf("Test %d: Failed to parse master key: %v", i, err)
+		}
+		if err == nil && test.ShouldFail {
+			t.Errorf("Test %d: Parsed master key successfully but should have failed", i)
+		}
+		if err == nil && (keyID != test.ExpectedKeyID || kms == nil) {
+			t.Errorf("Test %d: Expected key ID '%s' - got '%s'", i, test.ExpectedKeyID, keyID)
+		}
+	}
+}
+
 var contextWriteToTests = []struct {
 	Context      Context
 	ExpectedJSON string
@@ -0,0 +1,155 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+// Size of each read and write of random drive tests.
+const drivePerfBlockSize = 4 * humanize.KiByte
+
+// Maximum size of the test file accepted by drive tests.
+var drivePerfMaxFileSize int64 = 1 * humanize.GiByte
+
+// DriveSpeedInfo holds sequential and random read/write performance of
+// a local drive. Throughput is in bytes per second, random operations
+// are of drivePerfBlockSize bytes each.
+type DriveSpeedInfo struct {
+	Path                string  `json:"path"`
+	SeqWriteThroughput  float64 `json:"seqWriteThroughput"`
+	SeqReadThroughput   float64 `json:"seqReadThroughput"`
+	RandWriteThroughput float64 `json:"randWriteThroughput"`
+	RandReadThroughput  float64 `json:"randReadThroughput"`
+	RandWriteIOPS       float64 `json:"randWriteIOPS"`
+	RandReadIOPS        float64 `json:"randReadIOPS"`
+	Error               string  `json:"error,omitempty"`
+}
+
+// ServerDrivesPerf holds drive test results of one node.
+type ServerDrivesPerf struct {
+	Error string           `json:"error"`
+	Addr  string           `json:"addr"`
+	Data  []DriveSpeedInfo `json:"data"`
+}
+
+// getDriveSpeed - benchmarks a drive using a test file of the given
+// size in its temporary area. The file is written and read sequentially
+// first, then overwritten and read at random block aligned offsets.
+func getDriveSpeed(drivePath string, size int64) (info DriveSpeedInfo) {
+	info.Path = drivePath
+
+	tmpFile, err := newDriveTestFile(drivePath, "drive-perf-")
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	defer os.Remove(tmpFile)
+
+	duration, err := writeDriveTestFile(tmpFile, size)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.SeqWriteThroughput = throughput(size, duration)
+
+	if duration, err = readDriveTestFile(tmpFile); err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.SeqReadThroughput = throughput(size, duration)
+
+	ops := size / drivePerfBlockSize
+	if duration, err = randomDriveIO(tmpFile, ops, true); err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.RandWriteThroughput = throughput(ops*drivePerfBlockSize, duration)
+	info.RandWriteIOPS = throughput(ops, duration)
+
+	if duration, err = randomDriveIO(tmpFile, ops, false); err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.RandReadThroughput = throughput(ops*drivePerfBlockSize, duration)
+	info.RandReadIOPS = throughput(ops, duration)
+
+	return info
+}
+
+// randomDriveIO - performs ops reads or writes of drivePerfBlockSize
+// bytes at random offsets among the first ops blocks of the file.
+// Writes are followed by fsync.
+func randomDriveIO(tmpFile string, ops int64, write bool) (time.Duration, error) {
+	flag := os.O_RDONLY
+	if write {
+		flag = os.O_WRONLY
+	}
+
+	buf := make([]byte, drivePerfBlockSize)
+	if write {
+		rand.Read(buf)
+	}
+
+	start := UTCNow()
+	f, err := os.OpenFile(tmpFile, flag, 0666)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	for i := int64(0); i < ops; i++ {
+		offset := rand.Int63n(ops) * drivePerfBlockSize
+		if write {
+			_, err = f.WriteAt(buf, offset)
+		} else {
+			_, err = f.ReadAt(buf, offset)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if write {
+		if err = f.Sync(); err != nil {
+			return 0, err
+		}
+	}
+
+	return UTCNow().Sub(start), nil
+}
+
+// getLocalDrivesSpeed - benchmarks all local drives in parallel.
+func getLocalDrivesSpeed(endpoints EndpointList, size int64) []DriveSpeedInfo {
+	drivePaths := localDrivePaths(endpoints)
+	drivesSpeed := make([]DriveSpeedInfo, len(drivePaths))
+	var wg sync.WaitGroup
+	for i, drivePath := range drivePaths {
+		wg.Add(1)
+		go func(idx int, drivePath string) {
+			defer wg.Done()
+			drivesSpeed[idx] = getDriveSpeed(drivePath, size)
+		}(i, drivePath)
+	}
+	wg.Wait()
+
+	return drivesSpeed
+}
@@ -0,0 +1,77 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestGetLocalDrivesSpeed(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "drive-perf-")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// A regular file can't hold the temporary area of a drive.
+	badDrive := filepath.Join(tmpDir, "file")
+	if err = ioutil.WriteFile(badDrive, []byte("data"), 0644); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	endpoints := EndpointList{
+		{URL: &url.URL{Path: filepath.Join(tmpDir, "d1")}, IsLocal: true},
+		{URL: &url.URL{Path: badDrive}, IsLocal: true},
+		// Remote drives are not tested.
+		{URL: &url.URL{Scheme: "http", Host: "remote:9000", Path: "/d1"}},
+	}
+
+	drivesSpeed := getLocalDrivesSpeed(endpoints, humanize.MiByte)
+	if len(drivesSpeed) != 2 {
+		t.Fatalf("expected: 2, got: %d", len(drivesSpeed))
+	}
+
+	for i, driveSpeed := range drivesSpeed {
+		expectErr := driveSpeed.Path == badDrive
+		if (driveSpeed.Error != "") != expectErr {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, expectErr, driveSpeed.Error)
+		}
+		if expectErr {
+			continue
+		}
+		if driveSpeed.SeqWriteThroughput <= 0 || driveSpeed.SeqReadThroughput <= 0 ||
+			driveSpeed.RandWriteThroughput <= 0 || driveSpeed.RandReadThroughput <= 0 ||
+			driveSpeed.RandWriteIOPS <= 0 || driveSpeed.RandReadIOPS <= 0 {
+			t.Fatalf("case %v: expected positive results, got: %v", i+1, driveSpeed)
+		}
+	}
+
+	// Temporary files must be cleaned up.
+	entries, err := ioutil.ReadDir(filepath.Join(tmpDir, "d1", minioMetaTmpBucket))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected temporary area to be empty, found %d entries", len(entries))
+	}
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/madmin"
@@ -79,6 +80,58 @@ func (api *DummyObjectLayer) DeleteObject(ctx context.Context, bucket, object st
 	return
 }
 
+func (api *DummyObjectLayer) AppendObject(ctx context.Context, bucket, object string, position int64, data *hash.Reader, metadata map[string]string) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) GetObjectVersion(ctx context.Context, bucket, object, versionID string, startOffset int64, length int64, writer io.Writer, etag string) (err error) {
+	return
+}
+
+func (api *DummyObjectLayer) GetObjectVersionInfo(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) PutDeleteMarker(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) DeleteObjectVersion(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) PutObjectRetention(ctx context.Context, bucket, object, versionID string, retention ObjectRetention) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) PutObjectLegalHold(ctx context.Context, bucket, object, versionID string, legalHold ObjectLegalHold) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) PutObjectTags(ctx context.Context, bucket, object, versionID, tags string) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) UpdateObjectTags(ctx context.Context, bucket, object, versionID string, updateFn func(tags string) (string, error)) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) TransitionObject(ctx context.Context, bucket, object, versionID string, transition ObjectTransition) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) RestoreObject(ctx context.Context, bucket, object, versionID string, expiry time.Time) (objInfo ObjectInfo, err error) {
+	return
+}
+
+func (api *DummyObjectLayer) PutObjectReplicationStatus(ctx context.Context, bucket, object, versionID, etag string, status ReplicationStatus) (objInfo ObjectInfo, err error) {
+	return
+}
+
 func (api *DummyObjectLayer) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
 	return
 }
@@ -151,6 +204,46 @@ func (api *DummyObjectLayer) IsNotificationSupported() (b bool) {
 	return
 }
 
+func (api *DummyObjectLayer) IsVersioningSupported() (b bool) {
+	return
+}
+
+func (api *DummyObjectLayer) IsTaggingSupported() (b bool) {
+	return
+}
+
+func (api *DummyObjectLayer) IsLifecycleSupported() (b bool) {
+	return
+}
+
+func (api *DummyObjectLayer) IsTransitionSupported() (b bool) {
+	return
+}
+
+func (api *DummyObjectLayer) IsReplicationSupported() (b bool) {
+	return
+}
+
+func (api *DummyObjectLayer) IsCorsSupported() (b bool) {
+	return
+}
+
+func (api *DummyObjectLayer) IsInventorySupported() (b bool) {
+	return
+}
+
+func (api *DummyObjectLayer) IsWebsiteSupported() (b bool) {
+	return
+}
+
+func (api *DummyObjectLayer) IsLoggingSupported() (b bool) {
+	return
+}
+
+func (api *DummyObjectLayer) IsAppendSupported() (b bool) {
+	return
+}
+
 func (api *DummyObjectLayer) IsEncryptionSupported() (b bool) {
 	return
 }
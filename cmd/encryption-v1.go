@@ -31,6 +31,7 @@ import (
 	"path"
 	"strconv"
 
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/ioutil"
 	sha256 "github.com/minio/sha256-simd"
@@ -386,6 +387,24 @@ func newEncryptReader(content io.Reader, key []byte, bucket, object string, meta
 	return reader, nil
 }
 
+// newEncryptMetadataS3 generates an object encryption key from a data key
+// of the KMS and seals it into the metadata as done for SSE-S3. The data
+// key is bound to the object path, it is unsealed when the object is read.
+func newEncryptMetadataS3(keyID, bucket, object string, metadata map[string]string) ([]byte, error) {
+	if globalKMS == nil {
+		return nil, errKMSNotConfigured
+	}
+	kmsContext := crypto.Context{bucket: path.Join(bucket, object)}
+	key, kmsKey, err := globalKMS.GenerateKey(keyID, kmsContext)
+	if err != nil {
+		return nil, err
+	}
+	objectKey := crypto.GenerateKey(key, rand.Reader)
+	sealedKey := objectKey.Seal(key, crypto.GenerateIV(rand.Reader), crypto.S3.String(), bucket, object)
+	crypto.S3.CreateMetadata(metadata, keyID, kmsKey, sealedKey)
+	return objectKey[:], nil
+}
+
 // EncryptRequest takes the client provided content and encrypts the data
 // with the client provided key. It also marks the object as client-side-encrypted
 // and sets the correct headers.
@@ -400,6 +419,9 @@ func EncryptRequest(content io.Reader, r *http.Request, bucket, object string, m
 // DecryptCopyRequest decrypts the object with the client provided key. It also removes
 // the client-side-encryption metadata from the object and sets the correct headers.
 func DecryptCopyRequest(client io.Writer, r *http.Request, bucket, object string, metadata map[string]string) (io.WriteCloser, error) {
+	if crypto.S3.IsEncrypted(metadata) {
+		return newDecryptWriterS3(client, bucket, object, 0, metadata)
+	}
 	key, err := ParseSSECopyCustomerRequest(r)
 	if err != nil {
 		return nil, err
@@ -454,6 +476,27 @@ func decryptObjectInfo(key []byte, bucket, object string, metadata map[string]st
 	return objectEncryptionKey.Bytes(), nil
 }
 
+// decryptObjectInfoS3 unseals the object encryption key of an object
+// encrypted with SSE-S3, using the data key of the KMS it was sealed with.
+func decryptObjectInfoS3(bucket, object string, metadata map[string]string) ([]byte, error) {
+	keyID, kmsKey, sealedKey, err := crypto.S3.ParseMetadata(metadata)
+	if err != nil {
+		return nil, errObjectTampered
+	}
+	if globalKMS == nil {
+		return nil, errKMSNotConfigured
+	}
+	key, err := globalKMS.UnsealKey(keyID, kmsKey, crypto.Context{bucket: path.Join(bucket, object)})
+	if err != nil {
+		return nil, errObjectTampered
+	}
+	var objectKey crypto.ObjectKey
+	if err = objectKey.Unseal(key, sealedKey, crypto.S3.String(), bucket, object); err != nil {
+		return nil, errObjectTampered
+	}
+	return objectKey[:], nil
+}
+
 func newDecryptWriter(client io.Writer, key []byte, bucket, object string, seqNumber uint32, metadata map[string]string) (io.WriteCloser, error) {
 	objectEncryptionKey, err := decryptObjectInfo(key, bucket, object, metadata)
 	if err != nil {
@@ -462,6 +505,14 @@ func newDecryptWriter(client io.Writer, key []byte, bucket, object string, seqNu
 	return newDecryptWriterWithObjectKey(client, objectEncryptionKey, seqNumber, metadata)
 }
 
+func newDecryptWriterS3(client io.Writer, bucket, object string, seqNumber uint32, metadata map[string]string) (io.WriteCloser, error) {
+	objectEncryptionKey, err := decryptObjectInfoS3(bucket, object, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return newDecryptWriterWithObjectKey(client, objectEncryptionKey, seqNumber, metadata)
+}
+
 func newDecryptWriterWithObjectKey(client io.Writer, objectEncryptionKey []byte, seqNumber uint32, metadata map[string]string) (io.WriteCloser, error) {
 	writer, err := sio.DecryptWriter(client, sio.Config{
 		Key:            objectEncryptionKey,
@@ -474,6 +525,9 @@ func newDecryptWriterWithObjectKey(client io.Writer, objectEncryptionKey []byte,
 	delete(metadata, ServerSideEncryptionIV)
 	delete(metadata, ServerSideEncryptionSealAlgorithm)
 	delete(metadata, ServerSideEncryptionSealedKey)
+	delete(metadata, crypto.S3SealedKey)
+	delete(metadata, crypto.S3KMSKeyID)
+	delete(metadata, crypto.S3KMSSealedKey)
 	delete(metadata, ReservedMetadataPrefix+"Encrypted-Multipart")
 	return writer, nil
 }
@@ -481,6 +535,9 @@ func newDecryptWriterWithObjectKey(client io.Writer, objectEncryptionKey []byte,
 // DecryptRequestWithSequenceNumber decrypts the object with the client provided key. It also removes
 // the client-side-encryption metadata from the object and sets the correct headers.
 func DecryptRequestWithSequenceNumber(client io.Writer, r *http.Request, bucket, object string, seqNumber uint32, metadata map[string]string) (io.WriteCloser, error) {
+	if crypto.S3.IsEncrypted(metadata) {
+		return newDecryptWriterS3(client, bucket, object, seqNumber, metadata)
+	}
 	key, err := ParseSSECustomerRequest(r)
 	if err != nil {
 		return nil, err
@@ -524,20 +581,24 @@ func (w *DecryptBlocksWriter) buildDecrypter(partID int) error {
 		m[k] = v
 	}
 	// Initialize the first decrypter, new decrypters will be initialized in Write() operation as needed.
-	var key []byte
+	var objectEncryptionKey []byte
 	var err error
-	if w.copySource {
-		w.req.Header.Set(SSECopyCustomerKey, w.customerKeyHeader)
-		key, err = ParseSSECopyCustomerRequest(w.req)
+	if crypto.S3.IsEncrypted(m) {
+		objectEncryptionKey, err = decryptObjectInfoS3(w.bucket, w.object, m)
 	} else {
-		w.req.Header.Set(SSECustomerKey, w.customerKeyHeader)
-		key, err = ParseSSECustomerRequest(w.req)
-	}
-	if err != nil {
-		return err
+		var key []byte
+		if w.copySource {
+			w.req.Header.Set(SSECopyCustomerKey, w.customerKeyHeader)
+			key, err = ParseSSECopyCustomerRequest(w.req)
+		} else {
+			w.req.Header.Set(SSECustomerKey, w.customerKeyHeader)
+			key, err = ParseSSECustomerRequest(w.req)
+		}
+		if err != nil {
+			return err
+		}
+		objectEncryptionKey, err = decryptObjectInfo(key, w.bucket, w.object, m)
 	}
-
-	objectEncryptionKey, err := decryptObjectInfo(key, w.bucket, w.object, m)
 	if err != nil {
 		return err
 	}
@@ -686,16 +747,15 @@ func DecryptBlocksRequest(client io.Writer, r *http.Request, bucket, object stri
 	partEncRelOffset := int64(startSeqNum) * (sseDAREPackageBlockSize + sseDAREPackageMetaSize)
 
 	w := &DecryptBlocksWriter{
-		writer:            client,
-		startSeqNum:       uint32(startSeqNum),
-		partEncRelOffset:  partEncRelOffset,
-		parts:             objInfo.Parts,
-		partIndex:         partStartIndex,
-		req:               r,
-		bucket:            bucket,
-		object:            object,
-		customerKeyHeader: r.Header.Get(SSECustomerKey),
-		copySource:        copySource,
+		writer:           client,
+		startSeqNum:      uint32(startSeqNum),
+		partEncRelOffset: partEncRelOffset,
+		parts:            objInfo.Parts,
+		partIndex:        partStartIndex,
+		req:              r,
+		bucket:           bucket,
+		object:           object,
+		copySource:       copySource,
 	}
 
 	w.metadata = map[string]string{}
@@ -708,10 +768,17 @@ func DecryptBlocksRequest(client io.Writer, r *http.Request, bucket, object stri
 	delete(objInfo.UserDefined, ServerSideEncryptionIV)
 	delete(objInfo.UserDefined, ServerSideEncryptionSealAlgorithm)
 	delete(objInfo.UserDefined, ServerSideEncryptionSealedKey)
+	delete(objInfo.UserDefined, crypto.S3SealedKey)
+	delete(objInfo.UserDefined, crypto.S3KMSKeyID)
+	delete(objInfo.UserDefined, crypto.S3KMSSealedKey)
 	delete(objInfo.UserDefined, ReservedMetadataPrefix+"Encrypted-Multipart")
 
-	if w.copySource {
-		w.customerKeyHeader = r.Header.Get(SSECopyCustomerKey)
+	// Objects encrypted with SSE-S3 are decrypted without customer key.
+	if !crypto.S3.IsEncrypted(w.metadata) {
+		w.customerKeyHeader = r.Header.Get(SSECustomerKey)
+		if w.copySource {
+			w.customerKeyHeader = r.Header.Get(SSECopyCustomerKey)
+		}
 	}
 
 	if err := w.buildDecrypter(w.parts[w.partIndex].Number); err != nil {
@@ -869,8 +936,8 @@ func (o *ObjectInfo) EncryptedSize() int64 {
 }
 
 // DecryptCopyObjectInfo tries to decrypt the provided object if it is encrypted.
-// It fails if the object is encrypted and the HTTP headers don't contain
-// SSE-C headers or the object is not encrypted but SSE-C headers are provided. (AWS behavior)
+// It fails if the object is encrypted with SSE-C and the HTTP headers don't contain
+// SSE-C headers or the object is not encrypted with SSE-C but SSE-C headers are provided. (AWS behavior)
 // DecryptObjectInfo returns 'ErrNone' if the object is not encrypted or the
 // decryption succeeded.
 //
@@ -883,7 +950,13 @@ func DecryptCopyObjectInfo(info *ObjectInfo, headers http.Header) (apiErr APIErr
 	if apiErr, encrypted = ErrNone, info.IsEncrypted(); !encrypted && hasSSECopyCustomerHeader(headers) {
 		apiErr = ErrInvalidEncryptionParameters
 	} else if encrypted {
-		if !hasSSECopyCustomerHeader(headers) {
+		// Objects encrypted with SSE-S3 are decrypted without SSE-C headers.
+		sseS3 := crypto.S3.IsEncrypted(info.UserDefined)
+		if sseS3 && hasSSECopyCustomerHeader(headers) {
+			apiErr = ErrInvalidEncryptionParameters
+			return
+		}
+		if !sseS3 && !hasSSECopyCustomerHeader(headers) {
 			apiErr = ErrSSEEncryptedObject
 			return
 		}
@@ -896,8 +969,8 @@ func DecryptCopyObjectInfo(info *ObjectInfo, headers http.Header) (apiErr APIErr
 }
 
 // DecryptObjectInfo tries to decrypt the provided object if it is encrypted.
-// It fails if the object is encrypted and the HTTP headers don't contain
-// SSE-C headers or the object is not encrypted but SSE-C headers are provided. (AWS behavior)
+// It fails if the object is encrypted with SSE-C and the HTTP headers don't contain
+// SSE-C headers or the object is not encrypted with SSE-C but SSE-C headers are provided. (AWS behavior)
 // DecryptObjectInfo returns 'ErrNone' if the object is not encrypted or the
 // decryption succeeded.
 //
@@ -910,7 +983,13 @@ func DecryptObjectInfo(info *ObjectInfo, headers http.Header) (apiErr APIErrorCo
 	if apiErr, encrypted = ErrNone, info.IsEncrypted(); !encrypted && hasSSECustomerHeader(headers) {
 		apiErr = ErrInvalidEncryptionParameters
 	} else if encrypted {
-		if !hasSSECustomerHeader(headers) {
+		// Objects encrypted with SSE-S3 are decrypted without SSE-C headers.
+		sseS3 := crypto.S3.IsEncrypted(info.UserDefined)
+		if sseS3 && hasSSECustomerHeader(headers) {
+			apiErr = ErrInvalidEncryptionParameters
+			return
+		}
+		if !sseS3 && !hasSSECustomerHeader(headers) {
 			apiErr = ErrSSEEncryptedObject
 			return
 		}
@@ -24,6 +24,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -497,6 +498,20 @@ func GetRemotePeers(endpoints EndpointList) []string {
 	return peerSet.ToSlice()
 }
 
+// GetLocalPeerIndex - returns the index of this minio service among all
+// peers sorted by name, and the number of peers.
+func GetLocalPeerIndex(endpoints EndpointList) (index, count int) {
+	localPeer := GetLocalPeer(endpoints)
+	peers := append([]string{localPeer}, GetRemotePeers(endpoints)...)
+	sort.Strings(peers)
+	for i, peer := range peers {
+		if peer == localPeer {
+			index = i
+		}
+	}
+	return index, len(peers)
+}
+
 // In federated and distributed setup, update IP addresses of the hosts passed in command line
 // if MINIO_PUBLIC_IPS are not set manually
 func updateDomainIPs(endPoints set.StringSet) {
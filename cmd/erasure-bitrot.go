@@ -0,0 +1,93 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+// Files protected by a streaming bitrot algorithm hold the checksum of
+// each erasure coded block right before the block, so that every block
+// is verified on its own while it is read:
+//
+//   <checksum of block 1><block 1><checksum of block 2><block 2>...
+//
+// All blocks have the same size except the last one which may be smaller.
+
+// streamingBitrotBlock - returns the erasure coded block preceded by its
+// checksum computed with hasher.
+func streamingBitrotBlock(hasher hash.Hash, block []byte) []byte {
+	hasher.Reset()
+	hasher.Write(block)
+	buf := make([]byte, 0, hasher.Size()+len(block))
+	buf = hasher.Sum(buf)
+	return append(buf, block...)
+}
+
+// readStreamingBitrotBlock - reads the erasure coded block at offset of
+// a file on disk into buf, which is followed by the block, and verifies
+// it. Returns the block without its checksum and hashMismatchError if
+// the block is corrupted, errFileCorrupt if the file is too short.
+func readStreamingBitrotBlock(disk StorageAPI, volume, path string, offset int64, buf []byte, algorithm BitrotAlgorithm) ([]byte, error) {
+	if _, err := disk.ReadFile(volume, path, offset, buf, nil); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, errFileCorrupt
+		}
+		return nil, err
+	}
+
+	hasher := algorithm.New()
+	checksum, block := buf[:hasher.Size()], buf[hasher.Size():]
+	hasher.Write(block)
+	if sum := hasher.Sum(nil); subtle.ConstantTimeCompare(sum, checksum) != 1 {
+		return nil, hashMismatchError{hex.EncodeToString(checksum), hex.EncodeToString(sum)}
+	}
+	return block, nil
+}
+
+// verifyStreamingBitrotFile - verifies all erasure coded blocks of the
+// file of a part of given size on disk, errFileCorrupt if the file is
+// shorter or longer than its blocks.
+func verifyStreamingBitrotFile(disk StorageAPI, volume, path string, size, blockSize int64, dataBlocks int, algorithm BitrotAlgorithm) error {
+	hashSize := int64(algorithm.New().Size())
+	chunkSize := ceilFrac(blockSize, int64(dataBlocks))
+	buf := make([]byte, hashSize+chunkSize)
+
+	var offset int64
+	for remaining := size; remaining > 0; remaining -= blockSize {
+		shardSize := chunkSize
+		if remaining < blockSize {
+			shardSize = ceilFrac(remaining, int64(dataBlocks))
+		}
+		if _, err := readStreamingBitrotBlock(disk, volume, path, offset, buf[:hashSize+shardSize], algorithm); err != nil {
+			return err
+		}
+		offset += hashSize + shardSize
+	}
+
+	fi, err := disk.StatFile(volume, path)
+	if err != nil {
+		return err
+	}
+	if fi.Size != offset {
+		return errFileCorrupt
+	}
+	return nil
+}
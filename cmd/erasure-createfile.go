@@ -26,7 +26,8 @@ import (
 
 // CreateFile creates a new bitrot encoded file spread over all available disks. CreateFile will create
 // the file at the given volume and path. It will read from src until an io.EOF occurs. The given algorithm will
-// be used to protect the erasure encoded file.
+// be used to protect the erasure encoded file, streaming algorithms protect each erasure encoded block and
+// return empty checksums.
 func (s *ErasureStorage) CreateFile(ctx context.Context, src io.Reader, volume, path string, buffer []byte, algorithm BitrotAlgorithm, writeQuorum int) (f ErasureFileInfo, err error) {
 	if !algorithm.Available() {
 		logger.LogIf(ctx, errBitrotHashAlgoInvalid)
@@ -62,7 +63,7 @@ func (s *ErasureStorage) CreateFile(ctx context.Context, src io.Reader, volume,
 		}
 
 		for i := range errChans { // span workers
-			go erasureAppendFile(ctx, s.disks[i], volume, path, hashers[i], blocks[i], errChans[i])
+			go erasureAppendFile(ctx, s.disks[i], volume, path, hashers[i], algorithm.Streaming(), blocks[i], errChans[i])
 		}
 		for i := range errChans { // wait until all workers are finished
 			errs[i] = <-errChans[i]
@@ -79,19 +80,32 @@ func (s *ErasureStorage) CreateFile(ctx context.Context, src io.Reader, volume,
 		if disk == OfflineDisk {
 			continue
 		}
+		if algorithm.Streaming() {
+			f.Checksums[i] = []byte{}
+			continue
+		}
 		f.Checksums[i] = hashers[i].Sum(nil)
 	}
 	return f, nil
 }
 
 // erasureAppendFile appends the content of buf to the file on the given disk and updates computes
-// the hash of the written data. It sends the write error (or nil) over the error channel.
-func erasureAppendFile(ctx context.Context, disk StorageAPI, volume, path string, hash hash.Hash, buf []byte, errChan chan<- error) {
+// the hash of the written data. If streaming is set the hash of buf is written before buf instead.
+// It sends the write error (or nil) over the error channel.
+func erasureAppendFile(ctx context.Context, disk StorageAPI, volume, path string, hash hash.Hash, streaming bool, buf []byte, errChan chan<- error) {
 	if disk == OfflineDisk {
 		logger.LogIf(ctx, errDiskNotFound)
 		errChan <- errDiskNotFound
 		return
 	}
+	if streaming {
+		// Empty files have no blocks to protect.
+		if len(buf) > 0 {
+			buf = streamingBitrotBlock(hash, buf)
+		}
+		errChan <- disk.AppendFile(volume, path, buf)
+		return
+	}
 	err := disk.AppendFile(volume, path, buf)
 	if err != nil {
 		errChan <- err
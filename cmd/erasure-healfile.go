@@ -45,7 +45,7 @@ import (
 // despite stale disks being faulty.
 //
 // It returns bitrot checksums for the non-nil staleDisks on which
-// healing succeeded.
+// healing succeeded, which are empty for streaming algorithms.
 func (s ErasureStorage) HealFile(ctx context.Context, staleDisks []StorageAPI, volume, path string, blocksize int64,
 	dstVol, dstPath string, size int64, alg BitrotAlgorithm, checksums [][]byte) (
 	f ErasureFileInfo, err error) {
@@ -54,6 +54,9 @@ func (s ErasureStorage) HealFile(ctx context.Context, staleDisks []StorageAPI, v
 		logger.LogIf(ctx, errBitrotHashAlgoInvalid)
 		return f, errBitrotHashAlgoInvalid
 	}
+	if alg.Streaming() {
+		return s.healStreamingFile(ctx, staleDisks, volume, path, blocksize, dstVol, dstPath, size, alg)
+	}
 
 	// Initialization
 	f.Checksums = make([][]byte, len(s.disks))
@@ -175,6 +178,82 @@ func (s ErasureStorage) HealFile(ctx context.Context, staleDisks []StorageAPI, v
 	return f, nil
 }
 
+// healStreamingFile heals a file protected by a streaming bitrot
+// algorithm, each erasure coded block is read and verified, then
+// reconstructed and written to the stale disks along with its checksum.
+func (s ErasureStorage) healStreamingFile(ctx context.Context, staleDisks []StorageAPI, volume, path string, blocksize int64,
+	dstVol, dstPath string, size int64, alg BitrotAlgorithm) (f ErasureFileInfo, err error) {
+
+	f.Checksums = make([][]byte, len(s.disks))
+	writeErrors := make([]error, len(s.disks))
+	hasher := alg.New()
+	hashSize := int64(hasher.Size())
+	chunksize := ceilFrac(blocksize, int64(s.dataBlocks))
+	failed := make([]bool, len(s.disks))
+
+	// Empty files have no blocks, stale disks get an empty file.
+	numBlocks := ceilFrac(size, blocksize)
+	if numBlocks == 0 {
+		for i, disk := range staleDisks {
+			if disk != nil {
+				writeErrors[i] = disk.AppendFile(dstVol, dstPath, nil)
+			}
+		}
+	}
+
+	for blockNumber := int64(0); blockNumber < numBlocks; blockNumber++ {
+		// The last block of the file may be smaller.
+		blockLength := blocksize
+		if remaining := size - blockNumber*blocksize; remaining < blocksize {
+			blockLength = remaining
+		}
+
+		var blocks [][]byte
+		blocks, _, err = s.readStreamingBlock(ctx, volume, path, blockNumber*(hashSize+chunksize),
+			ceilFrac(blockLength, int64(s.dataBlocks)), alg, failed)
+		if err != nil {
+			return f, err
+		}
+		if err = s.ErasureDecodeDataAndParityBlocks(ctx, blocks); err != nil {
+			return f, err
+		}
+
+		// write computed shards as chunks on file in each
+		// stale disk
+		writeSucceeded := false
+		for i, disk := range staleDisks {
+			// skip nil disk or disk that had error on
+			// previous write
+			if disk == nil || writeErrors[i] != nil {
+				continue
+			}
+
+			writeErrors[i] = disk.AppendFile(dstVol, dstPath, streamingBitrotBlock(hasher, blocks[i]))
+			if writeErrors[i] == nil {
+				writeSucceeded = true
+			}
+		}
+
+		// If all disks had write errors we quit.
+		if !writeSucceeded {
+			// build error from all write errors
+			err := joinWriteErrors(writeErrors)
+			logger.LogIf(ctx, err)
+			return f, err
+		}
+	}
+
+	f.Size = size
+	f.Algorithm = alg
+	for i, disk := range staleDisks {
+		if disk == nil || writeErrors[i] != nil {
+			continue
+		}
+		f.Checksums[i] = []byte{}
+	}
+	return f, nil
+}
+
 func joinWriteErrors(errs []error) error {
 	msgs := []string{}
 	for i, err := range errs {
@@ -88,7 +88,9 @@ func (s ErasureStorage) readConcurrent(ctx context.Context, volume, path string,
 // The algorithm and the keys/checksums are used to verify the
 // integrity of the given file. ReadFile will read data from the given
 // offset up to the given length. If parts of the file are corrupted
-// ReadFile tries to reconstruct the data.
+// ReadFile tries to reconstruct the data. The blocks of files protected
+// by a streaming algorithm are read and verified one after the other,
+// given checksums are ignored.
 func (s ErasureStorage) ReadFile(ctx context.Context, writer io.Writer, volume, path string, offset,
 	length, totalLength int64, checksums [][]byte, algorithm BitrotAlgorithm,
 	blocksize int64) (f ErasureFileInfo, err error) {
@@ -105,6 +107,9 @@ func (s ErasureStorage) ReadFile(ctx context.Context, writer io.Writer, volume,
 		logger.LogIf(ctx, errBitrotHashAlgoInvalid)
 		return f, errBitrotHashAlgoInvalid
 	}
+	if algorithm.Streaming() {
+		return s.readStreamingFile(ctx, writer, volume, path, offset, length, totalLength, algorithm, blocksize)
+	}
 
 	f.Checksums = make([][]byte, len(s.disks))
 	verifiers := make([]*BitrotVerifier, len(s.disks))
@@ -234,3 +239,122 @@ func (s ErasureStorage) ReadFile(ctx context.Context, writer io.Writer, volume,
 	}
 	return f, nil
 }
+
+// readStreamingBlock reads an erasure coded block protected by a
+// streaming bitrot algorithm from as many disks as there are data
+// blocks. Another disk is read for each disk which fails to return its
+// part of the block or returns corrupted data, such disks are marked in
+// failed and not read again for the following blocks.
+func (s ErasureStorage) readStreamingBlock(ctx context.Context, volume, path string, offset, shardSize int64,
+	algorithm BitrotAlgorithm, failed []bool) (blocks [][]byte, needsReconstruction bool, err error) {
+
+	hashSize := int64(algorithm.New().Size())
+	errChan := make(chan errIdx)
+	stageBuffers := make([][]byte, len(s.disks))
+	blocks = make([][]byte, len(s.disks))
+
+	readDisk := func(i int) {
+		var rerr error
+		stageBuffers[i], rerr = readStreamingBitrotBlock(s.disks[i], volume, path, offset, make([]byte, hashSize+shardSize), algorithm)
+		errChan <- errIdx{i, rerr}
+	}
+
+	// launchIndex is the next disk to read, skipping unavailable disks.
+	var launchIndex int
+	launch := func() bool {
+		for ; launchIndex < len(s.disks); launchIndex++ {
+			if failed[launchIndex] || s.disks[launchIndex] == OfflineDisk {
+				continue
+			}
+			go readDisk(launchIndex)
+			launchIndex++
+			return true
+		}
+		return false
+	}
+
+	var pending, successCount int
+	for pending < s.dataBlocks && launch() {
+		pending++
+	}
+	for ; pending > 0; pending-- {
+		errVal := <-errChan
+		if errVal.err != nil {
+			if _, ok := errVal.err.(hashMismatchError); ok {
+				logger.LogIf(ctx, errVal.err)
+			}
+			failed[errVal.idx] = true
+			if launch() {
+				pending++
+			}
+			continue
+		}
+		successCount++
+		blocks[errVal.idx] = stageBuffers[errVal.idx]
+	}
+	if successCount < s.dataBlocks {
+		// Not enough disks returns data.
+		err = errXLReadQuorum
+		logger.LogIf(ctx, err)
+		return nil, false, err
+	}
+
+	for i := range blocks[:s.dataBlocks] {
+		if blocks[i] == nil {
+			needsReconstruction = true
+		}
+	}
+	return blocks, needsReconstruction, nil
+}
+
+// readStreamingFile reads the requested data range of a file protected
+// by a streaming bitrot algorithm, the erasure coded blocks containing
+// the range are read, verified and written one after the other.
+func (s ErasureStorage) readStreamingFile(ctx context.Context, writer io.Writer, volume, path string, offset,
+	length, totalLength int64, algorithm BitrotAlgorithm, blocksize int64) (f ErasureFileInfo, err error) {
+
+	f.Algorithm = algorithm
+	if length == 0 {
+		return f, nil
+	}
+
+	hashSize := int64(algorithm.New().Size())
+	chunksize := ceilFrac(blocksize, int64(s.dataBlocks))
+	failed := make([]bool, len(s.disks))
+
+	startBlock, endBlock := offset/blocksize, (offset+length-1)/blocksize
+	for blockNumber := startBlock; blockNumber <= endBlock; blockNumber++ {
+		// The last block of the file may be smaller.
+		blockLength := blocksize
+		if remaining := totalLength - blockNumber*blocksize; remaining < blocksize {
+			blockLength = remaining
+		}
+
+		blocks, needsReconstruction, err := s.readStreamingBlock(ctx, volume, path, blockNumber*(hashSize+chunksize),
+			ceilFrac(blockLength, int64(s.dataBlocks)), algorithm, failed)
+		if err != nil {
+			return f, err
+		}
+		if needsReconstruction {
+			if err = s.ErasureDecodeDataBlocks(blocks); err != nil {
+				logger.LogIf(ctx, err)
+				return f, err
+			}
+		}
+
+		var writeStart int64
+		if blockNumber == startBlock {
+			writeStart = offset % blocksize
+		}
+		writeEnd := blockLength
+		if blockNumber == endBlock {
+			writeEnd = (offset+length-1)%blocksize + 1
+		}
+		n, err := writeDataBlocks(ctx, writer, blocks, s.dataBlocks, writeStart, writeEnd-writeStart)
+		if err != nil {
+			return f, err
+		}
+		f.Size += n
+	}
+	return f, nil
+}
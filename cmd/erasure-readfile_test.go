@@ -22,6 +22,8 @@ import (
 	crand "crypto/rand"
 	"io"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
@@ -158,6 +160,54 @@ func TestErasureReadFile(t *testing.T) {
 	}
 }
 
+// Test that corrupted blocks of streaming bitrot protected files are
+// reconstructed while they are read.
+func TestErasureReadFileCorruptedBlocks(t *testing.T) {
+	dataBlocks, parityBlocks := 2, 2
+	blockSize := int64(blockSizeV1)
+	setup, err := newErasureTestSetup(dataBlocks, parityBlocks, blockSize)
+	if err != nil {
+		t.Fatalf("failed to create test setup: %v", err)
+	}
+	defer setup.Remove()
+	storage, err := NewErasureStorage(context.Background(), setup.disks, dataBlocks, parityBlocks, blockSize)
+	if err != nil {
+		t.Fatalf("failed to create ErasureStorage: %v", err)
+	}
+
+	data := make([]byte, 3*blockSize)
+	if _, err = io.ReadFull(crand.Reader, data); err != nil {
+		t.Fatalf("failed to generate random test data: %v", err)
+	}
+	buffer := make([]byte, blockSize, 2*blockSize)
+	file, err := storage.CreateFile(context.Background(), bytes.NewReader(data), "testbucket", "object", buffer, HighwayHash256S, dataBlocks+1)
+	if err != nil {
+		t.Fatalf("failed to create erasure test file: %v", err)
+	}
+
+	// Corrupt the second block of the first data shard and the third
+	// block of the second one.
+	shardSize := int64(HighwayHash256S.New().Size()) + ceilFrac(blockSize, int64(dataBlocks))
+	for i, offset := range []int64{shardSize + 10, 2*shardSize + 10} {
+		f, err := os.OpenFile(filepath.Join(setup.diskPaths[i], "testbucket", "object"), os.O_RDWR, 0)
+		if err != nil {
+			t.Fatalf("failed to open erasure test file: %v", err)
+		}
+		if _, err = f.WriteAt([]byte{0xff, 0xff, 0xff, 0xff}, offset); err != nil {
+			t.Fatalf("failed to corrupt erasure test file: %v", err)
+		}
+		f.Close()
+	}
+
+	writer := bytes.NewBuffer(nil)
+	if _, err = storage.ReadFile(context.Background(), writer, "testbucket", "object", 0, int64(len(data)), int64(len(data)), file.Checksums, HighwayHash256S, blockSize); err != nil {
+		t.Fatalf("failed to read corrupted erasure test file: %v", err)
+	}
+	if !bytes.Equal(writer.Bytes(), data) {
+		t.Error("read returns wrong file content")
+	}
+}
+
 // Test erasureReadFile with random offset and lengths.
 // This test is t.Skip()ed as it a long time to run, hence should be run
 // explicitly after commenting out t.Skip()
@@ -0,0 +1,75 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+var errInvalidForceUnlock = errors.New("either a bucket or the address of a client is required to force unlock")
+
+// nsLockMapHolder - object layers having their own namespace lock map.
+type nsLockMapHolder interface {
+	getNSLockMap() *nsLockMap
+}
+
+// validateForceUnlock - verifies that either a bucket, and optionally an
+// object, or the network address of a client is given.
+func validateForceUnlock(bucket, object, client string) error {
+	if (bucket == "") == (client == "") || (object != "" && bucket == "") {
+		return errInvalidForceUnlock
+	}
+	if client != "" {
+		if _, err := xnet.ParseHost(client); err != nil {
+			return errInvalidForceUnlock
+		}
+	}
+	return nil
+}
+
+// forceUnlockLocal - releases the locks held on bucket/object, or all
+// locks held by client, on this server and returns how many were
+// released. Operations blocked on a released lock keep waiting until
+// they time out, new operations proceed normally. Locks held through
+// dsync are only released from the lock server of this server, peers
+// release their own.
+func forceUnlockLocal(objAPI ObjectLayer, bucket, object, client string) (released int) {
+	if client != "" {
+		// Only locks of distributed setups are held by remote clients.
+		if globalLockServer != nil {
+			released += globalLockServer.ll.forceUnlockClient(client)
+		}
+		return released
+	}
+
+	nsMutexes := []*nsLockMap{globalNSMutex}
+	if holder, ok := objAPI.(nsLockMapHolder); ok && holder.getNSLockMap() != globalNSMutex {
+		nsMutexes = append(nsMutexes, holder.getNSLockMap())
+	}
+	for _, nsMutex := range nsMutexes {
+		if nsMutex != nil && nsMutex.forceUnlockLocal(bucket, object) {
+			released++
+		}
+	}
+
+	if globalLockServer != nil {
+		released += globalLockServer.ll.forceUnlockResource(pathJoin(bucket, object))
+	}
+	return released
+}
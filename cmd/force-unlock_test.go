@@ -0,0 +1,116 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/dsync"
+)
+
+func TestValidateForceUnlock(t *testing.T) {
+	testCases := []struct {
+		bucket, object, client string
+		expectErr              bool
+	}{
+		{"bucket", "", "", false},
+		{"bucket", "dir/object", "", false},
+		{"", "", "192.168.1.12:9000", false},
+		{"", "", "", true},
+		{"", "object", "", true},
+		{"bucket", "", "192.168.1.12:9000", true},
+		{"", "", "http://192.168.1.12:9000", true},
+	}
+
+	for i, testCase := range testCases {
+		err := validateForceUnlock(testCase.bucket, testCase.object, testCase.client)
+		if expectErr := (err != nil); expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
+
+// Tests releasing a lock held by a hung operation of this server.
+func TestForceUnlockLocal(t *testing.T) {
+	initNSLock(false)
+
+	stuckLock := globalNSMutex.NewNSLock("bucket", "object")
+	if err := stuckLock.GetLock(newDynamicTimeout(time.Second, time.Second)); err != nil {
+		t.Fatalf("unable to lock bucket/object, %s", err)
+	}
+	if err := globalNSMutex.NewNSLock("bucket", "object").GetLock(newDynamicTimeout(10*time.Millisecond, 10*time.Millisecond)); err == nil {
+		t.Fatalf("expected bucket/object to be locked")
+	}
+
+	if released := forceUnlockLocal(nil, "bucket", "other-object", ""); released != 0 {
+		t.Fatalf("expected no released locks, got %d", released)
+	}
+	if released := forceUnlockLocal(nil, "bucket", "object", ""); released != 1 {
+		t.Fatalf("expected 1 released lock, got %d", released)
+	}
+
+	newLock := globalNSMutex.NewNSLock("bucket", "object")
+	if err := newLock.GetLock(newDynamicTimeout(10*time.Millisecond, 10*time.Millisecond)); err != nil {
+		t.Fatalf("expected bucket/object to be unlocked, %s", err)
+	}
+	newLock.Unlock()
+}
+
+// Tests releasing locks held on the lock server of this server.
+func TestForceUnlockLocalLockServer(t *testing.T) {
+	prevLockServer := globalLockServer
+	defer func() {
+		globalLockServer = prevLockServer
+	}()
+	initNSLock(false)
+
+	globalLockServer = &lockRPCReceiver{
+		ll: localLocker{
+			serviceEndpoint: lockServicePath,
+			lockMap:         make(map[string][]lockRequesterInfo),
+		},
+	}
+	locks := []dsync.LockArgs{
+		{UID: "1", Resource: "bucket/object1", ServerAddr: "node1:9000"},
+		{UID: "2", Resource: "bucket/object2", ServerAddr: "node1:9000"},
+		{UID: "3", Resource: "bucket/object2", ServerAddr: "node2:9000"},
+		{UID: "4", Resource: "bucket/object3", ServerAddr: "node2:9000"},
+	}
+	for _, args := range locks {
+		if ok, err := globalLockServer.ll.RLock(args); !ok || err != nil {
+			t.Fatalf("unable to lock %s, %v", args.Resource, err)
+		}
+	}
+
+	if released := forceUnlockLocal(nil, "", "", "node1:9000"); released != 2 {
+		t.Fatalf("expected 2 released locks, got %d", released)
+	}
+	if _, ok := globalLockServer.ll.lockMap["bucket/object1"]; ok {
+		t.Fatalf("expected bucket/object1 to be unlocked")
+	}
+	if lri := globalLockServer.ll.lockMap["bucket/object2"]; len(lri) != 1 || lri[0].uid != "3" {
+		t.Fatalf("expected only the lock of node2 on bucket/object2, got %v", lri)
+	}
+
+	if released := forceUnlockLocal(nil, "bucket", "object2", ""); released != 1 {
+		t.Fatalf("expected 1 released lock, got %d", released)
+	}
+	if len(globalLockServer.ll.lockMap) != 1 {
+		t.Fatalf("expected only bucket/object3 to be locked, got %v", globalLockServer.ll.lockMap)
+	}
+}
@@ -357,6 +357,47 @@ func fsCreateFile(ctx context.Context, filePath string, reader io.Reader, buf []
 	return bytesWritten, nil
 }
 
+// fsAppendAtFile - writes the data of reader at offset, the end of an
+// existing file. The file is truncated back to offset if fewer than size
+// bytes could be written, so that it either has all the data or none.
+func fsAppendAtFile(ctx context.Context, filePath string, offset int64, reader io.Reader, buf []byte, size int64) (bytesWritten int64, err error) {
+	if filePath == "" || reader == nil {
+		logger.LogIf(ctx, errInvalidArgument)
+		return 0, errInvalidArgument
+	}
+
+	if err = checkDiskFree(pathutil.Dir(filePath), size); err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
+	}
+
+	writer, err := lock.Open(filePath, os.O_WRONLY, 0666)
+	if err != nil {
+		return 0, osErrToFSFileErr(err)
+	}
+	defer writer.Close()
+
+	defer func() {
+		if err != nil || bytesWritten < size {
+			if terr := writer.Truncate(offset); terr != nil {
+				logger.LogIf(ctx, terr)
+			}
+		}
+	}()
+
+	if _, err = writer.Seek(offset, io.SeekStart); err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
+	}
+
+	if bytesWritten, err = io.CopyBuffer(writer, reader, buf); err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
+	}
+
+	return bytesWritten, nil
+}
+
 // fsFAllocate is similar to Fallocate but provides a convenient
 // wrapper to handle various operating system specific errors.
 func fsFAllocate(fd int, offset int64, len int64) (err error) {
@@ -229,6 +229,10 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 
 	// Initialize fs.json values.
 	fsMeta := newFSMetaV1()
+	if meta == nil {
+		meta = make(map[string]string)
+	}
+	setMultipartUploadMeta(meta, bucket, object)
 	fsMeta.Meta = meta
 
 	fsMetaBytes, err := json.Marshal(fsMeta)
@@ -628,6 +632,7 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		fsMeta.Meta = make(map[string]string)
 	}
 	fsMeta.Meta["etag"] = s3MD5
+	removeMultipartUploadMeta(fsMeta.Meta)
 	if _, err = fsMeta.WriteTo(metaFile); err != nil {
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
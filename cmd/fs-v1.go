@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -169,18 +170,17 @@ func (fs *FSObjects) Shutdown(ctx context.Context) error {
 	return fsRemoveAll(ctx, pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID))
 }
 
+// getNSLockMap - returns the namespace lock map of the object layer.
+func (fs *FSObjects) getNSLockMap() *nsLockMap {
+	return fs.nsMutex
+}
+
 // diskUsage returns du information for the posix path, in a continuous routine.
 func (fs *FSObjects) diskUsage(doneCh chan struct{}) {
 	usageFn := func(ctx context.Context, entry string) error {
-		if globalHTTPServer != nil {
-			// Wait at max 1 minute for an inprogress request
-			// before proceeding to count the usage.
-			waitCount := 60
-			// Any requests in progress, delay the usage.
-			for globalHTTPServer.GetRequestCount() > 0 && waitCount > 0 {
-				waitCount--
-				time.Sleep(1 * time.Second)
-			}
+		// Throttle the crawler according to the scanner speed.
+		if !waitForCrawler(doneCh) {
+			return errWalkAbort
 		}
 
 		select {
@@ -215,15 +215,9 @@ func (fs *FSObjects) diskUsage(doneCh chan struct{}) {
 		case <-time.After(globalUsageCheckInterval):
 			var usage uint64
 			usageFn = func(ctx context.Context, entry string) error {
-				if globalHTTPServer != nil {
-					// Wait at max 1 minute for an inprogress request
-					// before proceeding to count the usage.
-					waitCount := 60
-					// Any requests in progress, delay the usage.
-					for globalHTTPServer.GetRequestCount() > 0 && waitCount > 0 {
-						waitCount--
-						time.Sleep(1 * time.Second)
-					}
+				// Throttle the crawler according to the scanner speed.
+				if !waitForCrawler(doneCh) {
+					return errWalkAbort
 				}
 
 				var fi os.FileInfo
@@ -758,6 +752,9 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 	if err := checkPutObjectArgs(ctx, bucket, object, fs, data.Size()); err != nil {
 		return ObjectInfo{}, err
 	}
+	if err := enforceBucketQuota(ctx, fs, bucket, data.Size()); err != nil {
+		return ObjectInfo{}, err
+	}
 	// Lock the object.
 	objectLock := fs.nsMutex.NewNSLock(bucket, object)
 	if err := objectLock.GetLock(globalObjectTimeout); err != nil {
@@ -821,6 +818,14 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, errInvalidArgument
 	}
 
+	// Create the object only if it does not exist, the object lock taken
+	// by PutObject keeps it from being created meanwhile.
+	if isPutIfAbsent(ctx) {
+		if _, err = fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object)); err == nil {
+			return ObjectInfo{}, PreconditionFailed{Bucket: bucket, Object: object}
+		}
+	}
+
 	var wlk *lock.LockedFile
 	if bucket != minioMetaBucket {
 		bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
@@ -1250,6 +1255,264 @@ func (fs *FSObjects) ListObjectsV2(ctx context.Context, bucket, prefix, continua
 	return listObjectsV2Info, err
 }
 
+// GetObjectVersion - versioning is not implemented for FS.
+func (fs *FSObjects) GetObjectVersion(ctx context.Context, bucket, object, versionID string, startOffset int64, length int64, writer io.Writer, etag string) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetObjectVersionInfo - versioning is not implemented for FS.
+func (fs *FSObjects) GetObjectVersionInfo(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// PutDeleteMarker - versioning is not implemented for FS.
+func (fs *FSObjects) PutDeleteMarker(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// DeleteObjectVersion - versioning is not implemented for FS.
+func (fs *FSObjects) DeleteObjectVersion(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// ListObjectVersions - versioning is not implemented for FS.
+func (fs *FSObjects) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return result, NotImplemented{}
+}
+
+// PutObjectRetention - object lock is not implemented for FS.
+func (fs *FSObjects) PutObjectRetention(ctx context.Context, bucket, object, versionID string, retention ObjectRetention) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// PutObjectLegalHold - object lock is not implemented for FS.
+func (fs *FSObjects) PutObjectLegalHold(ctx context.Context, bucket, object, versionID string, legalHold ObjectLegalHold) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// TransitionObject - lifecycle transition is not implemented for FS.
+func (fs *FSObjects) TransitionObject(ctx context.Context, bucket, object, versionID string, transition ObjectTransition) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// PutObjectReplicationStatus - bucket replication is not implemented for FS.
+func (fs *FSObjects) PutObjectReplicationStatus(ctx context.Context, bucket, object, versionID, etag string, status ReplicationStatus) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// RestoreObject - lifecycle transition is not implemented for FS.
+func (fs *FSObjects) RestoreObject(ctx context.Context, bucket, object, versionID string, expiry time.Time) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// PutObjectTags - replaces the tags of the object, empty tags remove
+// them. Object versions are not implemented for FS.
+func (fs *FSObjects) PutObjectTags(ctx context.Context, bucket, object, versionID, tags string) (objInfo ObjectInfo, err error) {
+	return fs.UpdateObjectTags(ctx, bucket, object, versionID, func(string) (string, error) {
+		return tags, nil
+	})
+}
+
+// UpdateObjectTags - replaces the tags of the object by the ones returned
+// by updateFn from its current tags, which are read and written under
+// the object lock. Unchanged tags are not written. Object versions are
+// not implemented for FS.
+func (fs *FSObjects) UpdateObjectTags(ctx context.Context, bucket, object, versionID string, updateFn func(tags string) (string, error)) (objInfo ObjectInfo, err error) {
+	if versionID != "" {
+		logger.LogIf(ctx, NotImplemented{})
+		return objInfo, NotImplemented{}
+	}
+
+	// Lock the object before updating its metadata.
+	objectLock := fs.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalObjectTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return objInfo, toObjectErr(err, bucket)
+	}
+
+	if hasSuffix(object, slashSeparator) {
+		return objInfo, toObjectErr(errFileNotFound, bucket, object)
+	}
+
+	// Stat the file to get file size.
+	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	wlk, err := fs.rwPool.Write(fsMetaPath)
+	hasFsJSON := err == nil
+	if err == errFileNotFound {
+		// Pre-existing objects may have no `fs.json`.
+		wlk, err = fs.rwPool.Create(fsMetaPath)
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	fsMeta := fs.defaultFsJSON(object)
+	if hasFsJSON {
+		fsMeta = newFSMetaV1()
+		if _, err = fsMeta.ReadFrom(ctx, wlk); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		if fsMeta.Meta == nil {
+			fsMeta.Meta = make(map[string]string)
+		}
+	}
+
+	current := fsMeta.Meta[objectTaggingKey]
+	tags, err := updateFn(current)
+	if err != nil {
+		return objInfo, err
+	}
+	if tags != current {
+		setObjectTags(fsMeta.Meta, tags)
+		if _, err = fsMeta.WriteTo(wlk); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+	}
+
+	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+}
+
+// AppendObject - appends data to the object if its size is position, or
+// creates the object with metadata if it does not exist and position
+// is 0. The data is written at the end of the object file, which is
+// truncated back if the data is incomplete, and recorded as a new part.
+func (fs *FSObjects) AppendObject(ctx context.Context, bucket, object string, position int64, data *hash.Reader, metadata map[string]string) (objInfo ObjectInfo, err error) {
+	if err = checkPutObjectArgs(ctx, bucket, object, fs, data.Size()); err != nil {
+		return objInfo, err
+	}
+	if err = enforceBucketQuota(ctx, fs, bucket, data.Size()); err != nil {
+		return objInfo, err
+	}
+
+	// Lock the object.
+	objectLock := fs.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalObjectTimeout); err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return objInfo, toObjectErr(err, bucket)
+	}
+
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	fi, err := fsStatFile(ctx, fsObjPath)
+	if err == errFileNotFound && !hasSuffix(object, slashSeparator) {
+		if position != 0 {
+			return objInfo, InvalidAppendPosition{Bucket: bucket, Object: object}
+		}
+		return fs.putObject(ctx, bucket, object, data, metadata)
+	}
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	wlk, err := fs.rwPool.Write(fsMetaPath)
+	hasFsJSON := err == nil
+	if err == errFileNotFound {
+		// Pre-existing objects may have no `fs.json`.
+		wlk, err = fs.rwPool.Create(fsMetaPath)
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	fsMeta := fs.defaultFsJSON(object)
+	if hasFsJSON {
+		fsMeta = newFSMetaV1()
+		if _, err = fsMeta.ReadFrom(ctx, wlk); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		if fsMeta.Meta == nil {
+			fsMeta.Meta = make(map[string]string)
+		}
+	}
+
+	if err = checkAppendObject(fsMeta.ToObjectInfo(bucket, object, fi), position); err != nil {
+		return objInfo, err
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		return objInfo, ObjectAlreadyExists{Bucket: bucket, Object: object}
+	}
+
+	if data.Size() == 0 {
+		return fsMeta.ToObjectInfo(bucket, object, fi), nil
+	}
+
+	bufSize := int64(readSizeV1)
+	if size := data.Size(); bufSize > size {
+		bufSize = size
+	}
+	bytesWritten, err := fsAppendAtFile(ctx, fsObjPath, position, data, make([]byte, bufSize), data.Size())
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer
+	// bytes than specified in request header.
+	if bytesWritten < data.Size() {
+		return objInfo, IncompleteBody{}
+	}
+
+	// The object as it was is the first part of objects which were
+	// not uploaded in parts.
+	if len(fsMeta.Parts) == 0 && position > 0 {
+		fsMeta.Parts = []objectPartInfo{{Number: 1, Name: "part.1", ETag: fsMeta.Meta["etag"], Size: position}}
+	}
+	partNumber := 1
+	if len(fsMeta.Parts) > 0 {
+		partNumber = fsMeta.Parts[len(fsMeta.Parts)-1].Number + 1
+	}
+	fsMeta.Parts = append(fsMeta.Parts, objectPartInfo{
+		Number: partNumber,
+		Name:   fmt.Sprintf("part.%d", partNumber),
+		ETag:   hex.EncodeToString(data.MD5Current()),
+		Size:   bytesWritten,
+	})
+	setAppendObjectMeta(fsMeta.Meta, data, len(fsMeta.Parts))
+	if _, err = fsMeta.WriteTo(wlk); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if fi, err = fsStatFile(ctx, fsObjPath); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+}
+
 // IsNotificationSupported returns whether bucket notification is applicable for this layer.
 func (fs *FSObjects) IsNotificationSupported() bool {
 	return true
@@ -1259,3 +1522,53 @@ func (fs *FSObjects) IsNotificationSupported() bool {
 func (fs *FSObjects) IsEncryptionSupported() bool {
 	return true
 }
+
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (fs *FSObjects) IsVersioningSupported() bool {
+	return false
+}
+
+// IsTaggingSupported returns whether object tagging is applicable for this layer.
+func (fs *FSObjects) IsTaggingSupported() bool {
+	return true
+}
+
+// IsLifecycleSupported returns whether bucket lifecycle is applicable for this layer.
+func (fs *FSObjects) IsLifecycleSupported() bool {
+	return true
+}
+
+// IsTransitionSupported returns whether lifecycle transition is applicable for this layer.
+func (fs *FSObjects) IsTransitionSupported() bool {
+	return false
+}
+
+// IsReplicationSupported returns whether bucket replication is applicable for this layer.
+func (fs *FSObjects) IsReplicationSupported() bool {
+	return false
+}
+
+// IsCorsSupported returns whether bucket CORS is applicable for this layer.
+func (fs *FSObjects) IsCorsSupported() bool {
+	return true
+}
+
+// IsInventorySupported returns whether bucket inventory is applicable for this layer.
+func (fs *FSObjects) IsInventorySupported() bool {
+	return true
+}
+
+// IsWebsiteSupported returns whether bucket website hosting is applicable for this layer.
+func (fs *FSObjects) IsWebsiteSupported() bool {
+	return true
+}
+
+// IsLoggingSupported returns whether bucket access logging is applicable for this layer.
+func (fs *FSObjects) IsLoggingSupported() bool {
+	return true
+}
+
+// IsAppendSupported returns whether appending to objects is applicable for this layer.
+func (fs *FSObjects) IsAppendSupported() bool {
+	return true
+}
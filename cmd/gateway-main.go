@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/url"
 	"os"
@@ -167,6 +168,9 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	globalPublicCerts, globalRootCAs, globalTLSCerts, globalIsSSL, err = getSSLConfig()
 	logger.FatalIf(err, "Invalid SSL certificate file")
 
+	// Configure the identity providers, reached with the root CAs.
+	handleIAMEnvVars()
+
 	// Set system resources to maximum.
 	logger.LogIf(context.Background(), setMaxResources())
 
@@ -178,6 +182,18 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	// Create new policy system.
 	globalPolicySys = NewPolicySys()
 
+	// Create new bucket quota system, quotas are not supported by gateways.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	// Create new remote targets system, remote targets are not supported by gateways.
+	globalBucketTargetSys = NewBucketTargetSys()
+
+	// Create new bucket metadata system, bucket versioning is not supported by gateways.
+	globalBucketMetadataSys = NewBucketMetadataSys()
+
+	// Create new tiers system, tiers are not supported by gateways.
+	globalTierConfigSys = NewTierConfigSys()
+
 	router := mux.NewRouter().SkipClean(true)
 
 	// Add healthcheck router
@@ -202,6 +218,12 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	globalHTTPServer = xhttp.NewServer([]string{gatewayAddr}, criticalErrorHandler{registerHandlers(router, globalHandlers...)}, getCert)
 	globalHTTPServer.UpdateBytesReadFunc = globalConnStats.incInputBytes
 	globalHTTPServer.UpdateBytesWrittenFunc = globalConnStats.incOutputBytes
+
+	// Verify the client certificates exchanged for temporary credentials.
+	if globalClientCertCAs != nil {
+		globalHTTPServer.TLSConfig.ClientCAs = globalClientCertCAs
+		globalHTTPServer.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
 	go func() {
 		globalHTTPServerErrorCh <- globalHTTPServer.Start()
 	}()
@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/hash"
@@ -136,6 +138,84 @@ func (a GatewayUnsupported) CopyObject(ctx context.Context, srcBucket string, sr
 	return objInfo, NotImplemented{}
 }
 
+// GetObjectVersion - versioning is not implemented for gateways.
+func (a GatewayUnsupported) GetObjectVersion(ctx context.Context, bucket, object, versionID string, startOffset int64, length int64, writer io.Writer, etag string) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetObjectVersionInfo - versioning is not implemented for gateways.
+func (a GatewayUnsupported) GetObjectVersionInfo(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// PutDeleteMarker - versioning is not implemented for gateways.
+func (a GatewayUnsupported) PutDeleteMarker(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// DeleteObjectVersion - versioning is not implemented for gateways.
+func (a GatewayUnsupported) DeleteObjectVersion(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// ListObjectVersions - versioning is not implemented for gateways.
+func (a GatewayUnsupported) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return result, NotImplemented{}
+}
+
+// PutObjectRetention - object lock is not implemented for gateways.
+func (a GatewayUnsupported) PutObjectRetention(ctx context.Context, bucket, object, versionID string, retention ObjectRetention) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// PutObjectLegalHold - object lock is not implemented for gateways.
+func (a GatewayUnsupported) PutObjectLegalHold(ctx context.Context, bucket, object, versionID string, legalHold ObjectLegalHold) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// AppendObject - appending to objects is not implemented for gateways.
+func (a GatewayUnsupported) AppendObject(ctx context.Context, bucket, object string, position int64, data *hash.Reader, metadata map[string]string) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// PutObjectTags - object tagging is not implemented for gateways.
+func (a GatewayUnsupported) PutObjectTags(ctx context.Context, bucket, object, versionID, tags string) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// UpdateObjectTags - object tagging is not implemented for gateways.
+func (a GatewayUnsupported) UpdateObjectTags(ctx context.Context, bucket, object, versionID string, updateFn func(tags string) (string, error)) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// TransitionObject - lifecycle transition is not implemented for gateways.
+func (a GatewayUnsupported) TransitionObject(ctx context.Context, bucket, object, versionID string, transition ObjectTransition) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// RestoreObject - lifecycle transition is not implemented for gateways.
+func (a GatewayUnsupported) RestoreObject(ctx context.Context, bucket, object, versionID string, expiry time.Time) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// PutObjectReplicationStatus - bucket replication is not implemented for gateways.
+func (a GatewayUnsupported) PutObjectReplicationStatus(ctx context.Context, bucket, object, versionID, etag string, status ReplicationStatus) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
 // RefreshBucketPolicy refreshes cache policy with what's on disk.
 func (a GatewayUnsupported) RefreshBucketPolicy(ctx context.Context, bucket string) error {
 	logger.LogIf(ctx, NotImplemented{})
@@ -151,3 +231,53 @@ func (a GatewayUnsupported) IsNotificationSupported() bool {
 func (a GatewayUnsupported) IsEncryptionSupported() bool {
 	return false
 }
+
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (a GatewayUnsupported) IsVersioningSupported() bool {
+	return false
+}
+
+// IsTaggingSupported returns whether object tagging is applicable for this layer.
+func (a GatewayUnsupported) IsTaggingSupported() bool {
+	return false
+}
+
+// IsLifecycleSupported returns whether bucket lifecycle is applicable for this layer.
+func (a GatewayUnsupported) IsLifecycleSupported() bool {
+	return false
+}
+
+// IsTransitionSupported returns whether lifecycle transition is applicable for this layer.
+func (a GatewayUnsupported) IsTransitionSupported() bool {
+	return false
+}
+
+// IsReplicationSupported returns whether bucket replication is applicable for this layer.
+func (a GatewayUnsupported) IsReplicationSupported() bool {
+	return false
+}
+
+// IsCorsSupported returns whether bucket CORS is applicable for this layer.
+func (a GatewayUnsupported) IsCorsSupported() bool {
+	return false
+}
+
+// IsInventorySupported returns whether bucket inventory is applicable for this layer.
+func (a GatewayUnsupported) IsInventorySupported() bool {
+	return false
+}
+
+// IsWebsiteSupported returns whether bucket website hosting is applicable for this layer.
+func (a GatewayUnsupported) IsWebsiteSupported() bool {
+	return false
+}
+
+// IsLoggingSupported returns whether bucket access logging is applicable for this layer.
+func (a GatewayUnsupported) IsLoggingSupported() bool {
+	return false
+}
+
+// IsAppendSupported returns whether appending to objects is applicable for this layer.
+func (a GatewayUnsupported) IsAppendSupported() bool {
+	return false
+}
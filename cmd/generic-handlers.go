@@ -230,12 +230,24 @@ func guessIsRPCReq(req *http.Request) bool {
 	return req.Method == http.MethodPost
 }
 
+// guessIsInternodeReq - returns true if the request is an RPC call
+// made by another server.
+func guessIsInternodeReq(req *http.Request) bool {
+	if !guessIsRPCReq(req) {
+		return false
+	}
+	urlPath := req.URL.Path
+	return urlPath == adminServicePath || urlPath == peerServicePath ||
+		urlPath == lockServicePath || hasPrefix(urlPath, storageServicePath+"/")
+}
+
 func (h redirectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	aType := getRequestAuthType(r)
 	// Re-direct only for JWT and anonymous requests from browser.
 	if aType == authTypeJWT || aType == authTypeAnonymous {
-		// Re-direction is handled specifically for browser requests.
-		if guessIsBrowserReq(r) && globalIsBrowserEnabled {
+		// Re-direction is handled specifically for browser requests,
+		// websites of buckets are served as is.
+		if guessIsBrowserReq(r) && globalIsBrowserEnabled && !isWebsiteRequest(r) {
 			// Fetch the redirect location if any.
 			redirectLocation := getRedirectLocation(r.URL.Path)
 			if redirectLocation != "" {
@@ -281,6 +293,25 @@ func isAdminReq(r *http.Request) bool {
 	return strings.HasPrefix(r.URL.Path, adminAPIPathPrefix+"/")
 }
 
+// Rejects S3 requests while the server is frozen or in maintenance.
+type serviceFreezeHandler struct {
+	handler http.Handler
+}
+
+func setServiceFreezeHandler(h http.Handler) http.Handler {
+	return serviceFreezeHandler{h}
+}
+
+func (h serviceFreezeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Requests to the reserved bucket path, i.e. admin, RPC, health
+	// check, metrics and browser requests, are served while frozen.
+	if (isServiceFrozen() || isInMaintenance()) && !hasPrefix(r.URL.Path, minioReservedBucketPath+"/") {
+		writeErrorResponse(w, ErrSlowDown, r.URL)
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
 // Adds verification for incoming paths.
 type minioReservedBucketHandler struct {
 	handler http.Handler
@@ -297,7 +328,7 @@ func (h minioReservedBucketHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 	default:
 		// For all other requests reject access to reserved
 		// buckets
-		bucketName, _ := urlPath2BucketObjectName(r.URL.Path)
+		bucketName, _ := getRequestBucketObjectName(r)
 		if isMinioReservedBucket(bucketName) || isMinioMetaBucket(bucketName) {
 			writeErrorResponse(w, ErrAllAccessDisabled, r.URL)
 			return
@@ -390,7 +421,9 @@ var defaultAllowableHTTPMethods = []string{
 	http.MethodOptions,
 }
 
-// setCorsHandler handler for CORS (Cross Origin Resource Sharing)
+// setCorsHandler handler for CORS (Cross Origin Resource Sharing),
+// requests on buckets are allowed as per their CORS configuration
+// while the browser and admin APIs allow all origins.
 func setCorsHandler(h http.Handler) http.Handler {
 	commonS3Headers := []string{
 		"Date",
@@ -411,7 +444,7 @@ func setCorsHandler(h http.Handler) http.Handler {
 		ExposedHeaders:   commonS3Headers,
 		AllowCredentials: true,
 	})
-	return c.Handler(h)
+	return bucketCorsHandler{handler: h, defaultHandler: c.Handler(h)}
 }
 
 // setIgnoreResourcesHandler -
@@ -440,8 +473,8 @@ func ignoreNotImplementedBucketResources(req *http.Request) bool {
 // Checks requests for not implemented Object resources
 func ignoreNotImplementedObjectResources(req *http.Request) bool {
 	for name := range req.URL.Query() {
-		// Enable GetObjectACL dummy call specifically.
-		if name == "acl" && req.Method == http.MethodGet {
+		// Enable GetObjectACL and PutObjectACL calls specifically.
+		if name == "acl" && (req.Method == http.MethodGet || req.Method == http.MethodPut) {
 			return false
 		}
 		if notimplementedObjectResourceNames[name] {
@@ -454,16 +487,7 @@ func ignoreNotImplementedObjectResources(req *http.Request) bool {
 // List of not implemented bucket queries
 var notimplementedBucketResourceNames = map[string]bool{
 	"acl":            true,
-	"cors":           true,
-	"lifecycle":      true,
-	"logging":        true,
-	"replication":    true,
-	"tagging":        true,
-	"versions":       true,
 	"requestPayment": true,
-	"versioning":     true,
-	"website":        true,
-	"inventory":      true,
 	"metrics":        true,
 	"accelerate":     true,
 }
@@ -473,14 +497,11 @@ var notimplementedObjectResourceNames = map[string]bool{
 	"torrent": true,
 	"acl":     true,
 	"policy":  true,
-	"tagging": true,
-	"restore": true,
-	"select":  true,
 }
 
 // Resource handler ServeHTTP() wrapper
 func (h resourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	bucketName, objectName := urlPath2BucketObjectName(r.URL.Path)
+	bucketName, objectName := getRequestBucketObjectName(r)
 
 	// If bucketName is present and not objectName check for bucket level resource queries.
 	if bucketName != "" && objectName == "" {
@@ -496,8 +517,8 @@ func (h resourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	// A put method on path "/" doesn't make sense, ignore it.
-	if r.Method == http.MethodPut && r.URL.Path == "/" {
+	// A put method without a bucket doesn't make sense, ignore it.
+	if r.Method == http.MethodPut && bucketName == "" {
 		writeErrorResponse(w, ErrNotImplemented, r.URL)
 		return
 	}
@@ -511,11 +532,24 @@ func (h resourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 type httpResponseRecorder struct {
 	http.ResponseWriter
 	respStatusCode int
+	bytesWritten   uint64
+	errorCode      string
+}
+
+// recordErrorCode - records the code of the S3 error written to w, if w
+// is a httpResponseRecorder.
+func recordErrorCode(w http.ResponseWriter, code string) {
+	if rww, ok := w.(*httpResponseRecorder); ok {
+		rww.errorCode = code
+	}
 }
 
-// Wraps ResponseWriter's Write()
+// Wraps ResponseWriter's Write() and record
+// the number of bytes written
 func (rww *httpResponseRecorder) Write(b []byte) (int, error) {
-	return rww.ResponseWriter.Write(b)
+	n, err := rww.ResponseWriter.Write(b)
+	rww.bytesWritten += uint64(n)
+	return n, err
 }
 
 // Wraps ResponseWriter's Flush()
@@ -548,6 +582,12 @@ func (h httpStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Wraps w to record http response information
 	ww := &httpResponseRecorder{ResponseWriter: w}
 
+	// Wraps request body to record the number of bytes read
+	body := &countingReadCloser{ReadCloser: r.Body}
+	if r.Body != nil {
+		r.Body = body
+	}
+
 	// Time start before the call is about to start.
 	tBefore := UTCNow()
 
@@ -565,6 +605,17 @@ func (h httpStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Update http statistics
 	globalHTTPStats.updateStats(r, ww, durationSecs)
+
+	// Update per bucket bandwidth statistics
+	globalBucketBandwidthStats.updateStats(r, ww, body.bytesRead)
+
+	// Update internode network statistics
+	if guessIsInternodeReq(r) {
+		globalConnStats.incInternodeBytes(body.bytesRead, ww.bytesWritten)
+	}
+
+	logger.Event(r.Context(), logger.HTTPSubsystem, logger.DebugLvl, "%s %s %d %s",
+		r.Method, r.URL.Path, ww.respStatusCode, tAfter.Sub(tBefore))
 }
 
 // pathValidityHandler validates all the incoming paths for
@@ -629,7 +680,7 @@ func (f bucketForwardingHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		f.handler.ServeHTTP(w, r)
 		return
 	}
-	bucket, object := urlPath2BucketObjectName(r.URL.Path)
+	bucket, object := getRequestBucketObjectName(r)
 	// ListBucket requests should be handled at current endpoint as
 	// all buckets data can be fetched from here.
 	if r.Method == http.MethodGet && bucket == "" && object == "" {
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 )
@@ -90,6 +91,33 @@ func TestGuessIsRPC(t *testing.T) {
 	}
 }
 
+// Tests request guess function for RPC calls between servers.
+func TestGuessIsInternode(t *testing.T) {
+	testCases := []struct {
+		method      string
+		path        string
+		isInternode bool
+	}{
+		{http.MethodPost, adminServicePath, true},
+		{http.MethodPost, peerServicePath, true},
+		{http.MethodPost, lockServicePath, true},
+		{http.MethodPost, storageServicePath + "/mnt/disk1", true},
+		{http.MethodPost, adminAPIPathPrefix + "/v1/service", false},
+		{http.MethodPost, "/bucket/object", false},
+		{http.MethodGet, adminServicePath, false},
+	}
+
+	for i, testCase := range testCases {
+		r, err := http.NewRequest(testCase.method, "http://localhost:9000"+testCase.path, nil)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if isInternode := guessIsInternodeReq(r); isInternode != testCase.isInternode {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.isInternode, isInternode)
+		}
+	}
+}
+
 // Tests browser request guess function.
 func TestGuessIsBrowser(t *testing.T) {
 	if guessIsBrowserReq(nil) {
@@ -179,3 +207,85 @@ func TestContainsReservedMetadata(t *testing.T) {
 		}
 	}
 }
+
+// Tests that S3 requests are rejected while the server is frozen.
+func TestSetServiceFreezeHandler(t *testing.T) {
+	prevGlobalServerConfig := globalServerConfig
+	globalServerConfig = newServerConfig()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+		unfreezeServices()
+	}()
+
+	handler := setServiceFreezeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		frozen       bool
+		path         string
+		expectedCode int
+	}{
+		{false, "/bucket/object", http.StatusOK},
+		{true, "/bucket/object", http.StatusServiceUnavailable},
+		{true, "/", http.StatusServiceUnavailable},
+		{true, adminAPIPathPrefix + "/v1/service", http.StatusOK},
+		{true, healthCheckPathPrefix + healthCheckReadinessPath, http.StatusOK},
+		{false, "/", http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		if testCase.frozen {
+			freezeServices()
+		} else {
+			unfreezeServices()
+		}
+
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:9000"+testCase.path, nil)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("Test %d: expected %d, got %d", i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+}
+
+// Tests that resource handler checks the bucket of path-style and
+// virtual-host-style requests.
+func TestSetIgnoreResourcesHandler(t *testing.T) {
+	defer func(domain string) { globalDomainName = domain }(globalDomainName)
+	globalDomainName = "mydomain.com"
+
+	handler := setIgnoreResourcesHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		method       string
+		url          string
+		expectedCode int
+	}{
+		{http.MethodPut, "http://mydomain.com:9000/", http.StatusNotImplemented},
+		{http.MethodPut, "http://mydomain.com:9000/bucket", http.StatusOK},
+		{http.MethodPut, "http://bucket.mydomain.com:9000/", http.StatusOK},
+		{http.MethodGet, "http://bucket.mydomain.com:9000/?website", http.StatusOK},
+		{http.MethodGet, "http://bucket.mydomain.com:9000/?logging", http.StatusOK},
+		{http.MethodGet, "http://bucket.mydomain.com:9000/?requestPayment", http.StatusNotImplemented},
+		{http.MethodGet, "http://bucket.mydomain.com:9000/object?torrent", http.StatusNotImplemented},
+	}
+
+	for i, testCase := range testCases {
+		req, err := http.NewRequest(testCase.method, testCase.url, nil)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("Test %d: expected %d, got %d", i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+}
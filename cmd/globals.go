@@ -27,10 +27,13 @@ import (
 	etcd "github.com/coreos/etcd/clientv3"
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
+	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/certs"
 	"github.com/minio/minio/pkg/dns"
+	"github.com/minio/minio/pkg/iam/ldap"
+	"github.com/minio/minio/pkg/iam/validator"
 )
 
 // minio configuration related constants.
@@ -78,6 +81,12 @@ const (
 	globalMultipartCleanupInterval = time.Hour * 24 // 24 hrs.
 	// Refresh interval to update in-memory bucket policy cache.
 	globalRefreshBucketPolicyInterval = 5 * time.Minute
+	// Refresh interval to update in-memory bucket quota cache.
+	globalRefreshBucketQuotaInterval = 5 * time.Minute
+	// Refresh interval to update in-memory remote targets cache.
+	globalRefreshBucketTargetsInterval = 5 * time.Minute
+	// Refresh interval to update in-memory bucket metadata cache.
+	globalRefreshBucketMetadataInterval = 5 * time.Minute
 
 	// Limit of location constraint XML for unauthenticted PUT bucket operations.
 	maxLocationConstraintSize = 3 * humanize.MiByte
@@ -124,8 +133,15 @@ var (
 	// Holds the host that was passed using --address
 	globalMinioHost = ""
 
-	globalNotificationSys *NotificationSys
-	globalPolicySys       *PolicySys
+	globalNotificationSys   *NotificationSys
+	globalPolicySys         *PolicySys
+	globalIAMSys            *IAMSys
+	globalBucketQuotaSys    *BucketQuotaSys
+	globalBucketTargetSys   *BucketTargetSys
+	globalBucketMetadataSys *BucketMetadataSys
+	globalTierConfigSys     *TierConfigSys
+	globalBatchJobSys       *BatchJobSys
+	globalReplicationPool   *replicationPool
 
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
@@ -156,6 +172,18 @@ var (
 	// Global HTTP request statisitics
 	globalHTTPStats = newHTTPStats()
 
+	// Global per bucket bandwidth statistics
+	globalBucketBandwidthStats = newBucketBandwidthStats()
+
+	// Global number of objects per bucket
+	globalBucketObjectCounts = newBucketObjectCounts()
+
+	// Global per S3 API call statistics
+	globalAPICallStats = newAPICallStats()
+
+	// Global S3 API calls in progress
+	globalSessions = newSessionTracker()
+
 	// Time when object layer was initialized on start up.
 	globalBootTime time.Time
 
@@ -166,11 +194,33 @@ var (
 	globalDomainName      string        // Root domain for virtual host style requests
 	globalDomainIPs       set.StringSet // Root domain IP address(s) for a distributed Minio deployment
 
+	globalWebsiteDomainName string // Root domain for the website endpoints of buckets
+
+	// Validator of the web identity tokens exchanged by AssumeRoleWithWebIdentity,
+	// set when an OpenID Connect provider is configured.
+	globalOpenIDValidator *validator.JWT
+
+	// LDAP server the users of AssumeRoleWithLDAPIdentity are authenticated
+	// against, and canned policies of its groups.
+	globalLDAPConfig        *ldap.Config
+	globalLDAPGroupPolicies map[string]string
+
+	// CAs the client certificates of AssumeRoleWithClientCertificate are
+	// verified with, and canned policies of their names.
+	globalClientCertCAs      *x509.CertPool
+	globalClientCertPolicies map[string]string
+
 	globalListingTimeout   = newDynamicTimeout( /*30*/ 600*time.Second /*5*/, 600*time.Second) // timeout for listing related ops
 	globalObjectTimeout    = newDynamicTimeout( /*1*/ 10*time.Minute /*10*/, 600*time.Second)  // timeout for Object API related ops
 	globalOperationTimeout = newDynamicTimeout(10*time.Minute /*30*/, 600*time.Second)         // default timeout for general ops
 	globalHealingTimeout   = newDynamicTimeout(30*time.Minute /*1*/, 30*time.Minute)           // timeout for healing related ops
 
+	// Deadline of admin RPC calls, set by MINIO_ADMIN_RPC_TIMEOUT.
+	globalAdminRPCTimeout = 30 * time.Second
+	// Number of times idempotent admin RPC calls are retried after
+	// network errors, set by MINIO_ADMIN_RPC_RETRIES.
+	globalAdminRPCMaxRetries = 2
+
 	// Storage classes
 	// Set to indicate if storage class is set up
 	globalIsStorageClass bool
@@ -183,6 +233,9 @@ var (
 	// Is worm enabled
 	globalWORMEnabled bool
 
+	// Are uploads without Content-MD5 or signed SHA256 sum rejected
+	globalStrictIntegrity bool
+
 	// Is Disk Caching set up
 	globalIsDiskCacheEnabled bool
 
@@ -203,6 +256,11 @@ var (
 	// Current RPC version
 	globalRPCAPIVersion = RPCVersion{3, 0, 0}
 
+	// KMS used for SSE-S3, configured via MINIO_SSE_MASTER_KEY.
+	globalKMS crypto.KMS
+	// Default master key ID of the configured KMS.
+	globalKMSKeyID string
+
 	// Allocated etcd endpoint for config and bucket DNS.
 	globalEtcdClient *etcd.Client
 
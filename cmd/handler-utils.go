@@ -51,12 +51,6 @@ func parseLocationConstraint(r *http.Request) (location string, s3Error APIError
 	return location, ErrNone
 }
 
-// Validates input location is same as configured region
-// of Minio server.
-func isValidLocation(location string) bool {
-	return globalServerConfig.GetRegion() == "" || globalServerConfig.GetRegion() == location
-}
-
 // Supported headers that needs to be extracted.
 var supportedHeaders = []string{
 	"content-type",
@@ -65,6 +59,7 @@ var supportedHeaders = []string{
 	"content-encoding",
 	"content-disposition",
 	amzStorageClass,
+	amzWebsiteRedirectLocation,
 	"expires",
 	// Add more supported headers here.
 }
@@ -108,6 +103,22 @@ func path2BucketAndObject(path string) (bucket, object string) {
 	return bucket, object
 }
 
+// getCopySource - returns the bucket, object and version ID, if any, of
+// the source object of a copy request from its X-Amz-Copy-Source header.
+func getCopySource(h http.Header) (bucket, object, versionID string) {
+	cpSrcPath := h.Get("X-Amz-Copy-Source")
+	if i := strings.LastIndex(cpSrcPath, "?versionId="); i != -1 {
+		versionID = cpSrcPath[i+len("?versionId="):]
+		cpSrcPath = cpSrcPath[:i]
+	}
+	// Save unescaped string as is.
+	if unescaped, err := url.QueryUnescape(cpSrcPath); err == nil {
+		cpSrcPath = unescaped
+	}
+	bucket, object = path2BucketAndObject(cpSrcPath)
+	return bucket, object, versionID
+}
+
 // userMetadataKeyPrefixes contains the prefixes of used-defined metadata keys.
 // All values stored with a key starting with one of the following prefixes
 // must be extracted from the header.
@@ -167,9 +178,9 @@ func extractMetadataFromMap(ctx context.Context, v map[string][]string, m map[st
 }
 
 // The Query string for the redirect URL the client is
-// redirected on successful upload.
-func getRedirectPostRawQuery(objInfo ObjectInfo) string {
-	redirectValues := make(url.Values)
+// redirected on successful upload, added to the query
+// of the redirect URL.
+func getRedirectPostRawQuery(redirectValues url.Values, objInfo ObjectInfo) string {
 	redirectValues.Set("bucket", objInfo.Bucket)
 	redirectValues.Set("key", objInfo.Name)
 	redirectValues.Set("etag", "\""+objInfo.ETag+"\"")
@@ -327,6 +338,16 @@ func getResource(path string, host string, domain string) (string, error) {
 	return slashSeparator + pathJoin(bucket, path), nil
 }
 
+// getRequestBucketObjectName - returns the bucket and object names of a
+// request, taking the bucket from the host of virtual-host-style requests.
+func getRequestBucketObjectName(r *http.Request) (bucketName, objectName string) {
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainName)
+	if err != nil {
+		resource = r.URL.Path
+	}
+	return urlPath2BucketObjectName(resource)
+}
+
 // If none of the http routes match respond with MethodNotAllowed
 func notFoundHandler(w http.ResponseWriter, r *http.Request) {
 	writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
@@ -22,6 +22,7 @@ import (
 	"encoding/xml"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"reflect"
 	"strings"
@@ -215,3 +216,31 @@ func TestGetResource(t *testing.T) {
 		}
 	}
 }
+
+// Tests getting the bucket and object names of path-style and
+// virtual-host-style requests.
+func TestGetRequestBucketObjectName(t *testing.T) {
+	defer func(domain string) { globalDomainName = domain }(globalDomainName)
+	globalDomainName = "mydomain.com"
+
+	testCases := []struct {
+		host           string
+		path           string
+		expectedBucket string
+		expectedObject string
+	}{
+		{"mydomain.com", "/", "", ""},
+		{"mydomain.com", "/bucket", "bucket", ""},
+		{"mydomain.com", "/bucket/a/b", "bucket", "a/b"},
+		{"bucket.mydomain.com", "/", "bucket", ""},
+		{"bucket.mydomain.com:9000", "/a/b", "bucket", "a/b"},
+		{"127.0.0.1:9000", "/bucket/a", "bucket", "a"},
+	}
+	for i, test := range testCases {
+		r := &http.Request{Host: test.host, URL: &url.URL{Path: test.path}}
+		bucket, object := getRequestBucketObjectName(r)
+		if bucket != test.expectedBucket || object != test.expectedObject {
+			t.Errorf("test %d: expected %s/%s got %s/%s", i+1, test.expectedBucket, test.expectedObject, bucket, object)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/sys"
+)
+
+var (
+	// Size of the file written to and read from each local drive
+	// during health diagnostics.
+	healthInfoDrivePayloadSize int64 = 16 * humanize.MiByte
+
+	// Size of the payload sent to each remote peer during health
+	// diagnostics, also the default payload size of network tests.
+	healthInfoNetPayloadSize int64 = 4 * humanize.MiByte
+
+	// Maximum payload size accepted by network tests.
+	netPerfMaxPayloadSize int64 = 128 * humanize.MiByte
+)
+
+// CPUInfo holds processor information of a server.
+type CPUInfo struct {
+	NumCPU       int    `json:"numCPU"`
+	Arch         string `json:"arch"`
+	NumGoroutine int    `json:"numGoroutine"`
+}
+
+// MemInfo holds memory information of a server.
+type MemInfo struct {
+	TotalRAM  uint64 `json:"totalRAM"`
+	HeapAlloc uint64 `json:"heapAlloc"`
+	Sys       uint64 `json:"sys"`
+	Error     string `json:"error,omitempty"`
+}
+
+// OSInfo holds operating system information of a server.
+type OSInfo struct {
+	OS        string `json:"os"`
+	Hostname  string `json:"hostname"`
+	GoVersion string `json:"goVersion"`
+	Version   string `json:"version"`
+	CommitID  string `json:"commitID"`
+}
+
+// DrivePerfInfo holds sequential write and read throughput of a
+// local drive in bytes per second.
+type DrivePerfInfo struct {
+	Path            string  `json:"path"`
+	WriteThroughput float64 `json:"writeThroughput"`
+	ReadThroughput  float64 `json:"readThroughput"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// NetPerfInfo holds the round trip latency and the throughput in
+// bytes per second measured while sending a payload to a remote peer.
+type NetPerfInfo struct {
+	Addr       string        `json:"addr"`
+	Latency    time.Duration `json:"latency"`
+	Throughput float64       `json:"throughput"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// ServerNetPerf holds network test result of one node, i.e. the
+// performance of the links from the node to all other nodes.
+type ServerNetPerf struct {
+	Error string        `json:"error"`
+	Addr  string        `json:"addr"`
+	Data  []NetPerfInfo `json:"data"`
+}
+
+// ServerHealthInfoData holds diagnostics of a given server.
+type ServerHealthInfoData struct {
+	CPU    CPUInfo         `json:"cpu"`
+	Mem    MemInfo         `json:"mem"`
+	OS     OSInfo          `json:"os"`
+	Drives []DrivePerfInfo `json:"drives"`
+	Net    []NetPerfInfo   `json:"net"`
+}
+
+// ServerHealthInfo holds health diagnostics result of one node.
+type ServerHealthInfo struct {
+	Error string                `json:"error"`
+	Addr  string                `json:"addr"`
+	Data  *ServerHealthInfoData `json:"data"`
+}
+
+// getLocalCPUInfo - returns processor information of this server.
+func getLocalCPUInfo() CPUInfo {
+	return CPUInfo{
+		NumCPU:       runtime.NumCPU(),
+		Arch:         runtime.GOARCH,
+		NumGoroutine: runtime.NumGoroutine(),
+	}
+}
+
+// getLocalMemInfo - returns memory information of this server.
+func getLocalMemInfo() MemInfo {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	memInfo := MemInfo{
+		HeapAlloc: memStats.HeapAlloc,
+		Sys:       memStats.Sys,
+	}
+
+	stats, err := sys.GetStats()
+	if err != nil {
+		memInfo.Error = err.Error()
+		return memInfo
+	}
+	memInfo.TotalRAM = stats.TotalRAM
+	return memInfo
+}
+
+// getLocalOSInfo - returns operating system information of this server.
+func getLocalOSInfo() OSInfo {
+	hostname, _ := os.Hostname()
+	return OSInfo{
+		OS:        runtime.GOOS,
+		Hostname:  hostname,
+		GoVersion: runtime.Version(),
+		Version:   Version,
+		CommitID:  CommitID,
+	}
+}
+
+// getDrivePerf - writes a file of the given size into the temporary
+// area of the drive, reads it back and reports the throughput.
+func getDrivePerf(drivePath string, size int64) (info DrivePerfInfo) {
+	info.Path = drivePath
+
+	tmpFile, err := newDriveTestFile(drivePath, "health-info-")
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	defer os.Remove(tmpFile)
+
+	duration, err := writeDriveTestFile(tmpFile, size)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.WriteThroughput = throughput(size, duration)
+
+	if duration, err = readDriveTestFile(tmpFile); err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.ReadThroughput = throughput(size, duration)
+
+	return info
+}
+
+// newDriveTestFile - returns the path of a new test file in the
+// temporary area of the drive, user data is never touched.
+func newDriveTestFile(drivePath, prefix string) (string, error) {
+	tmpDir := filepath.Join(drivePath, minioMetaTmpBucket)
+	if err := os.MkdirAll(tmpDir, 0777); err != nil {
+		return "", err
+	}
+	return filepath.Join(tmpDir, prefix+mustGetUUID()), nil
+}
+
+// writeDriveTestFile - creates the test file with size bytes of random
+// data using a sequential write followed by fsync.
+func writeDriveTestFile(tmpFile string, size int64) (time.Duration, error) {
+	start := UTCNow()
+	f, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = io.CopyN(f, rand.Reader, size); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return UTCNow().Sub(start), nil
+}
+
+// readDriveTestFile - reads the whole test file sequentially.
+func readDriveTestFile(tmpFile string) (time.Duration, error) {
+	start := UTCNow()
+	f, err := os.Open(tmpFile)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if _, err = io.Copy(ioutil.Discard, f); err != nil {
+		return 0, err
+	}
+	return UTCNow().Sub(start), nil
+}
+
+// localDrivePaths - returns paths of all local drives.
+func localDrivePaths(endpoints EndpointList) []string {
+	var drivePaths []string
+	for _, endpoint := range endpoints {
+		if endpoint.IsLocal {
+			drivePaths = append(drivePaths, endpoint.Path)
+		}
+	}
+	return drivePaths
+}
+
+// getLocalDrivesPerf - measures throughput of all local drives in parallel.
+func getLocalDrivesPerf(endpoints EndpointList, size int64) []DrivePerfInfo {
+	drivePaths := localDrivePaths(endpoints)
+	drivesPerf := make([]DrivePerfInfo, len(drivePaths))
+	var wg sync.WaitGroup
+	for i, drivePath := range drivePaths {
+		wg.Add(1)
+		go func(idx int, drivePath string) {
+			defer wg.Done()
+			drivesPerf[idx] = getDrivePerf(drivePath, size)
+		}(i, drivePath)
+	}
+	wg.Wait()
+
+	return drivesPerf
+}
+
+// getPeersNetPerf - sends a payload of the given size to all remote
+// peers one after another and reports the latency and throughput of
+// each link. Latency is the round trip time of an empty payload.
+func getPeersNetPerf(peers adminPeers, size int64) []NetPerfInfo {
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(rand.Reader, payload); err != nil {
+		return nil
+	}
+
+	var netPerf []NetPerfInfo
+	for _, peer := range peers {
+		if peer.isLocal {
+			continue
+		}
+
+		info := NetPerfInfo{Addr: peer.addr}
+		start := UTCNow()
+		if err := peer.cmdRunner.SendPayload(nil); err != nil {
+			info.Error = err.Error()
+			netPerf = append(netPerf, info)
+			continue
+		}
+		info.Latency = UTCNow().Sub(start)
+
+		start = UTCNow()
+		if err := peer.cmdRunner.SendPayload(payload); err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Throughput = throughput(size, UTCNow().Sub(start))
+		}
+		netPerf = append(netPerf, info)
+	}
+
+	return netPerf
+}
+
+// throughput - returns bytes per second.
+func throughput(size int64, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return float64(size) / duration.Seconds()
+}
+
+// newHealthInfoArchive - bundles health diagnostics of all nodes into
+// a zip archive holding one JSON document per node.
+func newHealthInfoArchive(healthInfo []ServerHealthInfo) ([]byte, error) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+
+	for _, info := range healthInfo {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		// Peer addresses are of the form host:port, avoid ':' in file names.
+		w, err := zipWriter.Create("health-info/" + strings.Replace(info.Addr, ":", "_", -1) + ".json")
+		if err != nil {
+			return nil, err
+		}
+		if _, err = w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,142 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestGetLocalDrivesPerf(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "health-info-")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// A regular file can't hold the temporary area of a drive.
+	badDrive := filepath.Join(tmpDir, "file")
+	if err = ioutil.WriteFile(badDrive, []byte("data"), 0644); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	endpoints := EndpointList{
+		{URL: &url.URL{Path: filepath.Join(tmpDir, "d1")}, IsLocal: true},
+		{URL: &url.URL{Path: filepath.Join(tmpDir, "d2")}, IsLocal: true},
+		{URL: &url.URL{Path: badDrive}, IsLocal: true},
+		// Remote drives are not tested.
+		{URL: &url.URL{Scheme: "http", Host: "remote:9000", Path: "/d1"}},
+	}
+
+	drivesPerf := getLocalDrivesPerf(endpoints, humanize.MiByte)
+	if len(drivesPerf) != 3 {
+		t.Fatalf("expected: 3, got: %d", len(drivesPerf))
+	}
+
+	for i, drivePerf := range drivesPerf {
+		expectErr := drivePerf.Path == badDrive
+		if (drivePerf.Error != "") != expectErr {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, expectErr, drivePerf.Error)
+		}
+		if !expectErr && (drivePerf.WriteThroughput <= 0 || drivePerf.ReadThroughput <= 0) {
+			t.Fatalf("case %v: expected positive throughput, got: %v", i+1, drivePerf)
+		}
+	}
+
+	// Temporary files must be cleaned up.
+	entries, err := ioutil.ReadDir(filepath.Join(tmpDir, "d1", minioMetaTmpBucket))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected temporary area to be empty, found %d entries", len(entries))
+	}
+}
+
+func TestGetPeersNetPerf(t *testing.T) {
+	httpServer, rpcClient, prevGlobalServerConfig := newAdminRPCHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	peers := adminPeers{
+		{addr: "local", cmdRunner: localAdminClient{}, isLocal: true},
+		{addr: httpServer.Listener.Addr().String(), cmdRunner: rpcClient},
+	}
+
+	netPerf := getPeersNetPerf(peers, humanize.KiByte)
+	if len(netPerf) != 1 {
+		t.Fatalf("expected: 1, got: %d", len(netPerf))
+	}
+	if netPerf[0].Addr != peers[1].addr {
+		t.Fatalf("expected: %s, got: %s", peers[1].addr, netPerf[0].Addr)
+	}
+	if netPerf[0].Error != "" || netPerf[0].Latency <= 0 || netPerf[0].Throughput <= 0 {
+		t.Fatalf("unexpected result %v", netPerf[0])
+	}
+}
+
+func TestNewHealthInfoArchive(t *testing.T) {
+	healthInfo := []ServerHealthInfo{
+		{Addr: "127.0.0.1:9000", Data: &ServerHealthInfoData{CPU: getLocalCPUInfo()}},
+		{Addr: "127.0.0.2:9000", Error: "unreachable"},
+	}
+
+	archive, err := newHealthInfoArchive(healthInfo)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expectedNames := []string{"health-info/127.0.0.1_9000.json", "health-info/127.0.0.2_9000.json"}
+	if len(zipReader.File) != len(expectedNames) {
+		t.Fatalf("expected: %d files, got: %d", len(expectedNames), len(zipReader.File))
+	}
+
+	for i, file := range zipReader.File {
+		if file.Name != expectedNames[i] {
+			t.Fatalf("case %v: expected: %s, got: %s", i+1, expectedNames[i], file.Name)
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		var info ServerHealthInfo
+		err = json.NewDecoder(rc).Decode(&info)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if info.Addr != healthInfo[i].Addr || info.Error != healthInfo[i].Error {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, healthInfo[i], info)
+		}
+	}
+}
@@ -34,7 +34,12 @@ const (
 // Readiness probes are used to detect situations where application is under heavy load
 // and temporarily unable to serve. In a orchestrated setup like Kubernetes, containers reporting
 // that they are not ready do not receive traffic through Kubernetes Services.
+// A server in maintenance is never ready.
 func ReadinessCheckHandler(w http.ResponseWriter, r *http.Request) {
+	if isInMaintenance() {
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
 	if err := goroutineCountCheck(minioHealthGoroutineThreshold); err != nil {
 		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
 		return
@@ -30,8 +30,10 @@ import (
 // Count total input/output transferred bytes during
 // the server's life.
 type ConnStats struct {
-	totalInputBytes  atomic.Uint64
-	totalOutputBytes atomic.Uint64
+	totalInputBytes      atomic.Uint64
+	totalOutputBytes     atomic.Uint64
+	internodeInputBytes  atomic.Uint64
+	internodeOutputBytes atomic.Uint64
 }
 
 // Increase total input bytes
@@ -44,6 +46,12 @@ func (s *ConnStats) incOutputBytes(n int) {
 	s.totalOutputBytes.Add(uint64(n))
 }
 
+// Increase internode input and output bytes
+func (s *ConnStats) incInternodeBytes(input, output uint64) {
+	s.internodeInputBytes.Add(input)
+	s.internodeOutputBytes.Add(output)
+}
+
 // Return total input bytes
 func (s *ConnStats) getTotalInputBytes() uint64 {
 	return s.totalInputBytes.Load()
@@ -57,8 +65,10 @@ func (s *ConnStats) getTotalOutputBytes() uint64 {
 // Return connection stats (total input/output bytes)
 func (s *ConnStats) toServerConnStats() ServerConnStats {
 	return ServerConnStats{
-		TotalInputBytes:  s.getTotalInputBytes(),
-		TotalOutputBytes: s.getTotalOutputBytes(),
+		TotalInputBytes:      s.getTotalInputBytes(),
+		TotalOutputBytes:     s.getTotalOutputBytes(),
+		InternodeInputBytes:  s.internodeInputBytes.Load(),
+		InternodeOutputBytes: s.internodeOutputBytes.Load(),
 	}
 }
 
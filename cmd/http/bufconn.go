@@ -18,6 +18,7 @@ package http
 
 import (
 	"bufio"
+	"crypto/tls"
 	"net"
 	"time"
 )
@@ -99,3 +100,19 @@ func newBufConn(c net.Conn, readTimeout, writeTimeout time.Duration,
 		updateBytesWrittenFunc: updateBytesWrittenFunc,
 	}
 }
+
+// isTLS - returns whether the connection is over TLS.
+func (c *BufConn) isTLS() bool {
+	_, ok := c.QuirkConn.Conn.(*tls.Conn)
+	return ok
+}
+
+// ConnectionState - returns the state of the TLS connection, false if the
+// connection is not over TLS.
+func (c *BufConn) ConnectionState() (tls.ConnectionState, bool) {
+	tlsConn, ok := c.QuirkConn.Conn.(*tls.Conn)
+	if !ok {
+		return tls.ConnectionState{}, false
+	}
+	return tlsConn.ConnectionState(), true
+}
@@ -19,6 +19,7 @@ package http
 import (
 	"crypto/tls"
 	"errors"
+	"net"
 	"net/http"
 	"sync"
 	"sync/atomic"
@@ -60,6 +61,7 @@ type Server struct {
 	listener               *httpListener // HTTP listener for all 'Addrs' field.
 	inShutdown             uint32        // indicates whether the server is in shutdown or not
 	requestCount           int32         // counter holds no. of request in progress.
+	tlsConns               sync.Map      // open TLS connections by remote address.
 }
 
 // GetRequestCount - returns number of request in progress.
@@ -110,12 +112,32 @@ func (srv *Server) Start() (err error) {
 			return
 		}
 
+		// The TLS connection is wrapped by the listener, set the TLS
+		// state of the request from its connection.
+		if c, ok := srv.tlsConns.Load(r.RemoteAddr); ok && r.TLS == nil {
+			if state, ok := c.(*BufConn).ConnectionState(); ok {
+				r.TLS = &state
+			}
+		}
+
 		// Handle request using passed handler.
 		handler.ServeHTTP(w, r)
 	})
 
 	srv.listenerMutex.Lock()
 	srv.Handler = wrappedHandler
+	srv.ConnState = func(c net.Conn, state http.ConnState) {
+		bufconn, ok := c.(*BufConn)
+		if !ok || !bufconn.isTLS() {
+			return
+		}
+		switch state {
+		case http.StateNew:
+			srv.tlsConns.Store(c.RemoteAddr().String(), bufconn)
+		case http.StateHijacked, http.StateClosed:
+			srv.tlsConns.Delete(c.RemoteAddr().String())
+		}
+	}
 	srv.listener = listener
 	srv.listenerMutex.Unlock()
 
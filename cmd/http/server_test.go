@@ -19,6 +19,7 @@ package http
 import (
 	"crypto/tls"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
@@ -171,3 +172,52 @@ func TestServerTLSCiphers(t *testing.T) {
 		}()
 	}
 }
+
+func TestServerTLSConnectionState(t *testing.T) {
+	addr := "127.0.0.1:" + getNextPort()
+
+	server := NewServer([]string{addr},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil {
+				fmt.Fprintf(w, "no TLS")
+				return
+			}
+			fmt.Fprintf(w, "%d", len(r.TLS.PeerCertificates))
+		}), getCert)
+	server.TLSConfig.ClientAuth = tls.RequestClientCert
+
+	go func() {
+		server.Start()
+	}()
+	defer server.Shutdown()
+
+	clientCert, err := getTLSCert()
+	if err != nil {
+		t.Fatalf("Unable to parse private/certificate data. %v\n", err)
+	}
+	client := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+				Certificates:       []tls.Certificate{clientCert},
+			},
+		},
+	}
+
+	// There is no guaranteed way to know whether the HTTP server is started successfully.
+	// The only option is to connect and check.  Hence below sleep is used as workaround.
+	time.Sleep(1 * time.Second)
+
+	resp, err := client.Get("https://" + addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "1" {
+		t.Fatalf("expected one peer certificate, got: %s", body)
+	}
+}
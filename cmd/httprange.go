@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"fmt"
+	"mime/multipart"
+	"net/textproto"
 	"regexp"
 	"strconv"
 	"strings"
@@ -25,6 +27,10 @@ import (
 
 const (
 	byteRangePrefix = "bytes="
+
+	// Maximum number of ranges of a multi-range request, requests
+	// with more ranges are served the whole object.
+	maxRequestRanges = 100
 )
 
 // Valid byte position regexp
@@ -47,6 +53,38 @@ func (hrange httpRange) getLength() int64 {
 	return 1 + hrange.offsetEnd - hrange.offsetBegin
 }
 
+// mimeHeader - returns the header of the range in a multipart/byteranges
+// response.
+func (hrange httpRange) mimeHeader(contentType string) textproto.MIMEHeader {
+	header := textproto.MIMEHeader{"Content-Range": {hrange.String()}}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return header
+}
+
+// countingWriter - counts the bytes written to it.
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
+
+// getRangesMIMESize - returns the content length of a multipart/byteranges
+// response of the ranges with given content type and boundary.
+func getRangesMIMESize(hranges []*httpRange, contentType, boundary string) int64 {
+	var w countingWriter
+	mw := multipart.NewWriter(&w)
+	mw.SetBoundary(boundary)
+	for _, hrange := range hranges {
+		mw.CreatePart(hrange.mimeHeader(contentType))
+		w += countingWriter(hrange.getLength())
+	}
+	mw.Close()
+	return int64(w)
+}
+
 func parseRequestRange(rangeString string, resourceSize int64) (hrange *httpRange, err error) {
 	// Return error if given range string doesn't start with byte range prefix.
 	if !strings.HasPrefix(rangeString, byteRangePrefix) {
@@ -132,3 +170,43 @@ func parseRequestRange(rangeString string, resourceSize int64) (hrange *httpRang
 
 	return &httpRange{offsetBegin, offsetEnd, resourceSize}, nil
 }
+
+// parseRequestRanges - parses a Range header of one or more comma
+// separated byte ranges. Unsatisfiable ranges are skipped as long as one
+// of the ranges is satisfiable, errInvalidRange is returned otherwise.
+// Multiple ranges larger than the whole resource are rejected like
+// malformed ranges, so that the whole resource is sent instead.
+func parseRequestRanges(rangeString string, resourceSize int64) (hranges []*httpRange, err error) {
+	// Return error if given range string doesn't start with byte range prefix.
+	if !strings.HasPrefix(rangeString, byteRangePrefix) {
+		return nil, fmt.Errorf("'%s' does not start with '%s'", rangeString, byteRangePrefix)
+	}
+
+	rangeSpecs := strings.Split(strings.TrimPrefix(rangeString, byteRangePrefix), ",")
+	if len(rangeSpecs) > maxRequestRanges {
+		return nil, fmt.Errorf("'%s' has more than %d ranges", rangeString, maxRequestRanges)
+	}
+
+	var size int64
+	for _, rangeSpec := range rangeSpecs {
+		hrange, err := parseRequestRange(byteRangePrefix+strings.TrimSpace(rangeSpec), resourceSize)
+		if err == errInvalidRange {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		hranges = append(hranges, hrange)
+		size += hrange.getLength()
+	}
+
+	if len(hranges) == 0 {
+		return nil, errInvalidRange
+	}
+
+	if len(hranges) > 1 && size > resourceSize {
+		return nil, fmt.Errorf("'%s' ranges are larger than the resource", rangeString)
+	}
+
+	return hranges, nil
+}
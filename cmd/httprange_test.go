@@ -88,3 +88,48 @@ func TestParseRequestRange(t *testing.T) {
 		}
 	}
 }
+
+// Test parseRequestRanges()
+func TestParseRequestRanges(t *testing.T) {
+	testCases := []struct {
+		rangeString string
+		ranges      []string
+		expectedErr bool
+	}{
+		{"bytes=2-5", []string{"bytes 2-5/10"}, false},
+		{"bytes=0-1,4-5", []string{"bytes 0-1/10", "bytes 4-5/10"}, false},
+		{"bytes=0-1, -2", []string{"bytes 0-1/10", "bytes 8-9/10"}, false},
+		{"bytes=0-1,10-12,7-", []string{"bytes 0-1/10", "bytes 7-9/10"}, false},
+		{"bytes=10-12,20-", nil, false},
+		{"bytes=0-1,x-2", nil, true},
+		{"bytes=0-5,2-", nil, true},
+		{"2-5", nil, true},
+	}
+
+	for i, testCase := range testCases {
+		hranges, err := parseRequestRanges(testCase.rangeString, 10)
+		if testCase.ranges == nil && !testCase.expectedErr {
+			if err != errInvalidRange {
+				t.Fatalf("case %d: expected: %s, got: %v", i+1, errInvalidRange, err)
+			}
+			continue
+		}
+		if testCase.expectedErr {
+			if err == nil || err == errInvalidRange {
+				t.Fatalf("case %d: expected a parse error, got: %v", i+1, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %d: expected: <nil>, got: %s", i+1, err)
+		}
+		if len(hranges) != len(testCase.ranges) {
+			t.Fatalf("case %d: expected: %d ranges, got: %d", i+1, len(testCase.ranges), len(hranges))
+		}
+		for j, hrange := range hranges {
+			if hrange.String() != testCase.ranges[j] {
+				t.Fatalf("case %d: expected: %s, got: %s", i+1, testCase.ranges[j], hrange)
+			}
+		}
+	}
+}
@@ -0,0 +1,674 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/policy"
+)
+
+const (
+	iamConfigPrefix              = "iam"
+	iamServiceAccountsConfigFile = "service-accounts.json"
+	iamTempAccountsConfigPrefix  = "sts"
+
+	// Refresh interval to update in-memory service accounts cache.
+	globalRefreshIAMInterval = 5 * time.Minute
+
+	// Maximum size of an IAM archive accepted by import.
+	maxIAMArchiveSize = 16 * humanize.MiByte
+)
+
+// List of IAM related errors.
+var (
+	errNoSuchServiceAccount = errors.New("Specified service account does not exist")
+	errInvalidParentUser    = errors.New("Parent user of a service account must be an existing user")
+	errInvalidIAMArchive    = errors.New("IAM archive is invalid")
+)
+
+// serviceAccount - child credentials derived from a parent user,
+// optionally restricted by an inline policy.
+type serviceAccount struct {
+	Credentials auth.Credentials `json:"credentials"`
+	ParentUser  string           `json:"parentUser"`
+	Policy      *policy.Policy   `json:"policy,omitempty"`
+}
+
+// tempAccount - temporary credentials issued by the STS APIs, only valid
+// along with their session token until they expire. The policy of the
+// identity the credentials are issued for is further restricted by the
+// session policy of the request, if any.
+type tempAccount struct {
+	serviceAccount
+	SessionPolicy *policy.Policy `json:"sessionPolicy,omitempty"`
+	SessionToken  string         `json:"sessionToken"`
+	Expiration    time.Time      `json:"expiration"`
+}
+
+// IsExpired - returns whether the temporary credentials have expired.
+func (ta tempAccount) IsExpired() bool {
+	return UTCNow().After(ta.Expiration)
+}
+
+// getNewSessionToken - generates a random session token for temporary
+// credentials.
+func getNewSessionToken() (string, error) {
+	token := make([]byte, 48)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// IAMSys - identity and access management subsystem.
+type IAMSys struct {
+	sync.RWMutex
+	serviceAccounts map[string]serviceAccount
+	tempAccounts    map[string]tempAccount
+}
+
+// getServiceAccountsConfigFile - returns the path to service accounts config in minioMetaBucket.
+func getServiceAccountsConfigFile() string {
+	return path.Join(iamConfigPrefix, iamServiceAccountsConfigFile)
+}
+
+// readServiceAccounts - reads all service accounts from the backend. The
+// secret keys are stored encrypted with the server credentials.
+func readServiceAccounts(ctx context.Context, objAPI ObjectLayer) (map[string]serviceAccount, error) {
+	serviceAccounts := make(map[string]serviceAccount)
+
+	reader, err := readConfig(ctx, objAPI, getServiceAccountsConfigFile())
+	if err != nil {
+		if IsErrIgnored(err, errDiskNotFound, errConfigNotFound, errNoSuchNotifications) {
+			return serviceAccounts, nil
+		}
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err = decryptConfigData(data); err != nil {
+		logger.LogIf(ctx, err)
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &serviceAccounts); err != nil {
+		logger.LogIf(ctx, err)
+		return nil, err
+	}
+
+	return serviceAccounts, nil
+}
+
+// saveServiceAccounts - writes all service accounts encrypted to the backend.
+func saveServiceAccounts(objAPI ObjectLayer, serviceAccounts map[string]serviceAccount) error {
+	data, err := json.Marshal(serviceAccounts)
+	if err != nil {
+		return err
+	}
+
+	if data, err = encryptConfigData(data); err != nil {
+		return err
+	}
+
+	return saveConfig(objAPI, getServiceAccountsConfigFile(), data)
+}
+
+// updateServiceAccounts - reads service accounts from the backend under a
+// transaction lock, applies updateFn and saves the result back.
+func updateServiceAccounts(objAPI ObjectLayer, updateFn func(map[string]serviceAccount) error) (map[string]serviceAccount, error) {
+	configFile := getServiceAccountsConfigFile()
+	transactionConfigFile := configFile + ".transaction"
+
+	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
+	// and configFile, take a transaction lock to avoid data race between readConfig()
+	// and saveConfig().
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, transactionConfigFile)
+	if err := objLock.GetLock(globalOperationTimeout); err != nil {
+		return nil, err
+	}
+	defer objLock.Unlock()
+
+	serviceAccounts, err := readServiceAccounts(context.Background(), objAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = updateFn(serviceAccounts); err != nil {
+		return nil, err
+	}
+
+	if err = saveServiceAccounts(objAPI, serviceAccounts); err != nil {
+		return nil, err
+	}
+
+	return serviceAccounts, nil
+}
+
+// getTempAccountsConfigPrefix - returns the path prefix of temporary accounts config in minioMetaBucket.
+func getTempAccountsConfigPrefix() string {
+	return path.Join(iamConfigPrefix, iamTempAccountsConfigPrefix) + slashSeparator
+}
+
+// getTempAccountConfigFile - returns the path to the config of a temporary account in minioMetaBucket.
+func getTempAccountConfigFile(accessKey string) string {
+	return path.Join(iamConfigPrefix, iamTempAccountsConfigPrefix, accessKey+".json")
+}
+
+// listTempAccountConfigFiles - returns the paths in minioMetaBucket of
+// the configs of all temporary accounts.
+func listTempAccountConfigFiles(ctx context.Context, objAPI ObjectLayer) ([]string, error) {
+	var configFiles []string
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, minioMetaBucket, getTempAccountsConfigPrefix(), marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range result.Objects {
+			configFiles = append(configFiles, object.Name)
+		}
+		if !result.IsTruncated {
+			return configFiles, nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// readTempAccountConfig - reads the temporary account saved in the given
+// config file.
+func readTempAccountConfig(ctx context.Context, objAPI ObjectLayer, configFile string) (ta tempAccount, err error) {
+	reader, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		return ta, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return ta, err
+	}
+
+	if data, err = decryptConfigData(data); err != nil {
+		logger.LogIf(ctx, err)
+		return ta, err
+	}
+
+	if err = json.Unmarshal(data, &ta); err != nil {
+		logger.LogIf(ctx, err)
+		return ta, err
+	}
+
+	return ta, nil
+}
+
+// saveTempAccount - saves a temporary account in a config file of its own.
+func saveTempAccount(objAPI ObjectLayer, ta tempAccount) error {
+	// Take the transaction lock of temporary accounts shared, only
+	// reencryptConfigs takes it exclusively.
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, getTempAccountsConfigPrefix()+".transaction")
+	if err := objLock.GetRLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer objLock.RUnlock()
+
+	data, err := json.Marshal(ta)
+	if err != nil {
+		return err
+	}
+
+	if data, err = encryptConfigData(data); err != nil {
+		return err
+	}
+
+	return saveConfig(objAPI, getTempAccountConfigFile(ta.Credentials.AccessKey), data)
+}
+
+// readTempAccounts - reads all temporary accounts from the backend,
+// expired ones are left out and removed from the backend.
+func readTempAccounts(ctx context.Context, objAPI ObjectLayer) (map[string]tempAccount, error) {
+	tempAccounts := make(map[string]tempAccount)
+
+	configFiles, err := listTempAccountConfigFiles(ctx, objAPI)
+	if err != nil {
+		if IsErrIgnored(err, errDiskNotFound) {
+			return tempAccounts, nil
+		}
+		return nil, err
+	}
+
+	for _, configFile := range configFiles {
+		ta, err := readTempAccountConfig(ctx, objAPI, configFile)
+		if err != nil {
+			// Removed since it was listed.
+			if IsErrIgnored(err, errConfigNotFound, errNoSuchNotifications) {
+				continue
+			}
+			return nil, err
+		}
+
+		if ta.IsExpired() {
+			if err = objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil && !isErrObjectNotFound(err) {
+				logger.LogIf(ctx, err)
+			}
+			continue
+		}
+
+		tempAccounts[ta.Credentials.AccessKey] = ta
+	}
+
+	return tempAccounts, nil
+}
+
+// Load - replaces all in-memory service accounts and temporary accounts
+// with those stored in the backend.
+func (sys *IAMSys) Load(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	serviceAccounts, err := readServiceAccounts(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+
+	tempAccounts, err := readTempAccounts(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.serviceAccounts = serviceAccounts
+	sys.tempAccounts = tempAccounts
+	logger.Event(context.Background(), logger.IAMSubsystem, logger.DebugLvl, "Loaded %d service accounts and %d temporary accounts",
+		len(serviceAccounts), len(tempAccounts))
+	return nil
+}
+
+// LoadTempAccount - replaces the in-memory temporary account for the
+// given access key with the one stored in the backend, if any.
+func (sys *IAMSys) LoadTempAccount(objAPI ObjectLayer, accessKey string) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	ta, err := readTempAccountConfig(context.Background(), objAPI, getTempAccountConfigFile(accessKey))
+	if err != nil && !IsErrIgnored(err, errConfigNotFound, errNoSuchNotifications) {
+		return err
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if err != nil || ta.IsExpired() {
+		delete(sys.tempAccounts, accessKey)
+		return nil
+	}
+	sys.tempAccounts[accessKey] = ta
+	return nil
+}
+
+// Init - initializes IAM system from service-accounts.json.
+func (sys *IAMSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	// Load IAMSys once during boot.
+	if err := sys.Load(objAPI); err != nil {
+		return err
+	}
+
+	// Refresh IAMSys in background.
+	go func() {
+		ticker := time.NewTicker(globalRefreshIAMInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+				logger.LogIf(context.Background(), sys.Load(objAPI))
+			}
+		}
+	}()
+	return nil
+}
+
+// NewServiceAccount - creates a new service account for the given parent
+// user, persists it and returns its credentials.
+func (sys *IAMSys) NewServiceAccount(objAPI ObjectLayer, parentUser string, sessionPolicy *policy.Policy) (auth.Credentials, error) {
+	if objAPI == nil {
+		return auth.Credentials{}, errServerNotInitialized
+	}
+
+	// Service accounts can only be derived from the server credential.
+	if parentUser != globalServerConfig.GetCredential().AccessKey {
+		return auth.Credentials{}, errInvalidParentUser
+	}
+
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	sa := serviceAccount{
+		Credentials: cred,
+		ParentUser:  parentUser,
+		Policy:      sessionPolicy,
+	}
+
+	serviceAccounts, err := updateServiceAccounts(objAPI, func(serviceAccounts map[string]serviceAccount) error {
+		serviceAccounts[cred.AccessKey] = sa
+		return nil
+	})
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	sys.Lock()
+	sys.serviceAccounts = serviceAccounts
+	sys.Unlock()
+
+	return cred, nil
+}
+
+// NewTempAccount - issues temporary credentials for the given parent
+// user valid for the given duration, optionally restricted by the policy
+// of the identity they are issued for and by a session policy.
+func (sys *IAMSys) NewTempAccount(objAPI ObjectLayer, parentUser string, duration time.Duration, identityPolicy, sessionPolicy *policy.Policy) (tempAccount, error) {
+	if objAPI == nil {
+		return tempAccount{}, errServerNotInitialized
+	}
+
+	// Temporary credentials can only be issued for the server credential.
+	if parentUser != globalServerConfig.GetCredential().AccessKey {
+		return tempAccount{}, errInvalidParentUser
+	}
+
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		return tempAccount{}, err
+	}
+
+	sessionToken, err := getNewSessionToken()
+	if err != nil {
+		return tempAccount{}, err
+	}
+
+	ta := tempAccount{
+		serviceAccount: serviceAccount{
+			Credentials: cred,
+			ParentUser:  parentUser,
+			Policy:      identityPolicy,
+		},
+		SessionPolicy: sessionPolicy,
+		SessionToken:  sessionToken,
+		Expiration:    UTCNow().Add(duration).Truncate(time.Second),
+	}
+
+	if err = saveTempAccount(objAPI, ta); err != nil {
+		return tempAccount{}, err
+	}
+
+	sys.Lock()
+	sys.tempAccounts[cred.AccessKey] = ta
+	sys.Unlock()
+
+	return ta, nil
+}
+
+// DeleteServiceAccount - removes a service account from the backend and from memory.
+func (sys *IAMSys) DeleteServiceAccount(objAPI ObjectLayer, accessKey string) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	serviceAccounts, err := updateServiceAccounts(objAPI, func(serviceAccounts map[string]serviceAccount) error {
+		if _, ok := serviceAccounts[accessKey]; !ok {
+			return errNoSuchServiceAccount
+		}
+		delete(serviceAccounts, accessKey)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sys.Lock()
+	sys.serviceAccounts = serviceAccounts
+	sys.Unlock()
+
+	return nil
+}
+
+// ListServiceAccounts - returns all service accounts sorted by access key,
+// secret keys are not part of the result.
+func (sys *IAMSys) ListServiceAccounts() []serviceAccount {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	serviceAccounts := make([]serviceAccount, 0, len(sys.serviceAccounts))
+	for _, sa := range sys.serviceAccounts {
+		sa.Credentials.SecretKey = ""
+		serviceAccounts = append(serviceAccounts, sa)
+	}
+
+	sort.Slice(serviceAccounts, func(i, j int) bool {
+		return serviceAccounts[i].Credentials.AccessKey < serviceAccounts[j].Credentials.AccessKey
+	})
+
+	return serviceAccounts
+}
+
+// ExportIAM - returns a zip archive of all IAM data, i.e. the service
+// accounts along with their secret keys. Users other than the server
+// credential, groups and canned policies don't exist on this server.
+func (sys *IAMSys) ExportIAM() ([]byte, error) {
+	sys.RLock()
+	data, err := json.MarshalIndent(sys.serviceAccounts, "", "  ")
+	sys.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	w, err := zipWriter.Create(getServiceAccountsConfigFile())
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readIAMArchive - returns the service accounts of a zip archive made by
+// ExportIAM. Archives holding unknown files are rejected rather than
+// partially imported.
+func readIAMArchive(archive []byte) (map[string]serviceAccount, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, errInvalidIAMArchive
+	}
+
+	serviceAccounts := make(map[string]serviceAccount)
+	for _, file := range zipReader.File {
+		if file.Name != getServiceAccountsConfigFile() {
+			return nil, errInvalidIAMArchive
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, errInvalidIAMArchive
+		}
+		data, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil || json.Unmarshal(data, &serviceAccounts) != nil {
+			return nil, errInvalidIAMArchive
+		}
+	}
+
+	for accessKey, sa := range serviceAccounts {
+		if accessKey != sa.Credentials.AccessKey || !sa.Credentials.IsValid() {
+			return nil, errInvalidIAMArchive
+		}
+		if sa.Policy != nil && sa.Policy.IsEmpty() {
+			return nil, errInvalidIAMArchive
+		}
+	}
+	return serviceAccounts, nil
+}
+
+// ImportIAM - adds the service accounts of a zip archive made by
+// ExportIAM, existing service accounts having the same access key are
+// replaced. Returns the number of imported service accounts.
+func (sys *IAMSys) ImportIAM(objAPI ObjectLayer, archive []byte) (int, error) {
+	if objAPI == nil {
+		return 0, errServerNotInitialized
+	}
+
+	imported, err := readIAMArchive(archive)
+	if err != nil {
+		return 0, err
+	}
+
+	// Service accounts can only be derived from the server credential.
+	rootAccessKey := globalServerConfig.GetCredential().AccessKey
+	for _, sa := range imported {
+		if sa.ParentUser != rootAccessKey {
+			return 0, errInvalidParentUser
+		}
+	}
+
+	serviceAccounts, err := updateServiceAccounts(objAPI, func(serviceAccounts map[string]serviceAccount) error {
+		for accessKey, sa := range imported {
+			serviceAccounts[accessKey] = sa
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sys.Lock()
+	sys.serviceAccounts = serviceAccounts
+	sys.Unlock()
+
+	return len(imported), nil
+}
+
+// GetServiceAccount - returns the service account for the given access key.
+func (sys *IAMSys) GetServiceAccount(accessKey string) (serviceAccount, bool) {
+	if sys == nil {
+		return serviceAccount{}, false
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	sa, ok := sys.serviceAccounts[accessKey]
+	return sa, ok
+}
+
+// GetTempAccount - returns the temporary account for the given access
+// key, expired or not.
+func (sys *IAMSys) GetTempAccount(accessKey string) (tempAccount, bool) {
+	if sys == nil {
+		return tempAccount{}, false
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	ta, ok := sys.tempAccounts[accessKey]
+	return ta, ok
+}
+
+// IsAllowed - checks whether the request made with the given access key
+// is permitted by the inline policy of its service account, or by both
+// the identity and session policies of its temporary account. Requests
+// made with the server credential or with accounts without a policy
+// inherit the permissions of the parent user.
+func (sys *IAMSys) IsAllowed(accessKey string, args policy.Args) bool {
+	var policies []*policy.Policy
+	if sa, ok := sys.GetServiceAccount(accessKey); ok {
+		policies = append(policies, sa.Policy)
+	} else if ta, ok := sys.GetTempAccount(accessKey); ok {
+		policies = append(policies, ta.Policy, ta.SessionPolicy)
+	}
+
+	args.AccountName = accessKey
+	args.IsOwner = false
+	for _, p := range policies {
+		if p != nil && !p.IsAllowed(args) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewIAMSys - creates new IAM system.
+func NewIAMSys() *IAMSys {
+	return &IAMSys{
+		serviceAccounts: make(map[string]serviceAccount),
+		tempAccounts:    make(map[string]tempAccount),
+	}
+}
+
+// getCredential - returns the credential for the given access key, the
+// credential is either the server credential, one of the service accounts
+// or unexpired temporary credentials.
+func getCredential(accessKey string) (auth.Credentials, bool) {
+	cred := globalServerConfig.GetCredential()
+	if accessKey == cred.AccessKey {
+		return cred, true
+	}
+
+	if sa, ok := globalIAMSys.GetServiceAccount(accessKey); ok {
+		return sa.Credentials, true
+	}
+
+	if ta, ok := globalIAMSys.GetTempAccount(accessKey); ok && !ta.IsExpired() {
+		return ta.Credentials, true
+	}
+
+	return auth.Credentials{}, false
+}
@@ -0,0 +1,455 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
+)
+
+func TestIAMSysServiceAccounts(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	sys := NewIAMSys()
+	if err = sys.Init(objLayer); err != nil {
+		t.Fatalf("unable to initialize IAM system, %s", err)
+	}
+
+	rootAccessKey := globalServerConfig.GetCredential().AccessKey
+	if _, err = sys.NewServiceAccount(objLayer, "unknownuser", nil); err != errInvalidParentUser {
+		t.Fatalf("expected: %v, got: %v", errInvalidParentUser, err)
+	}
+
+	getObjectPolicy := policy.Policy{
+		Version: policy.DefaultVersion,
+		Statements: []policy.Statement{
+			policy.NewStatement(
+				policy.Allow,
+				policy.NewPrincipal("*"),
+				policy.NewActionSet(policy.GetObjectAction),
+				policy.NewResourceSet(policy.NewResource("mybucket", "*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	fullCred, err := sys.NewServiceAccount(objLayer, rootAccessKey, nil)
+	if err != nil {
+		t.Fatalf("unable to create service account, %s", err)
+	}
+	readCred, err := sys.NewServiceAccount(objLayer, rootAccessKey, &getObjectPolicy)
+	if err != nil {
+		t.Fatalf("unable to create service account, %s", err)
+	}
+
+	// Secret keys must not be stored in plaintext.
+	data, err := readConfig(context.Background(), objLayer, getServiceAccountsConfigFile())
+	if err != nil {
+		t.Fatalf("unable to read service accounts, %s", err)
+	}
+	if bytes.Contains(data.Bytes(), []byte(readCred.SecretKey)) {
+		t.Fatalf("secret key of %s must be stored encrypted", readCred.AccessKey)
+	}
+
+	// Service accounts must be visible to a freshly loaded IAM system.
+	loadedSys := NewIAMSys()
+	if err = loadedSys.Load(objLayer); err != nil {
+		t.Fatalf("unable to load IAM system, %s", err)
+	}
+	serviceAccounts := loadedSys.ListServiceAccounts()
+	if len(serviceAccounts) != 2 {
+		t.Fatalf("expected: 2 service accounts, got: %d", len(serviceAccounts))
+	}
+	for _, sa := range serviceAccounts {
+		if sa.Credentials.SecretKey != "" {
+			t.Fatalf("secret key of %s must not be listed", sa.Credentials.AccessKey)
+		}
+		if sa.ParentUser != rootAccessKey {
+			t.Fatalf("expected parent user: %s, got: %s", rootAccessKey, sa.ParentUser)
+		}
+	}
+	if sa, ok := loadedSys.GetServiceAccount(readCred.AccessKey); !ok || !sa.Credentials.Equal(readCred) {
+		t.Fatalf("expected: %v, got: %v", readCred, sa.Credentials)
+	}
+
+	getObjectArgs := policy.Args{
+		Action:     policy.GetObjectAction,
+		BucketName: "mybucket",
+		ObjectName: "myobject",
+		IsOwner:    true,
+	}
+	putObjectArgs := getObjectArgs
+	putObjectArgs.Action = policy.PutObjectAction
+
+	testCases := []struct {
+		accessKey      string
+		args           policy.Args
+		expectedResult bool
+	}{
+		{rootAccessKey, putObjectArgs, true},
+		{fullCred.AccessKey, putObjectArgs, true},
+		{readCred.AccessKey, getObjectArgs, true},
+		{readCred.AccessKey, putObjectArgs, false},
+	}
+
+	for i, testCase := range testCases {
+		if result := loadedSys.IsAllowed(testCase.accessKey, testCase.args); result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	if err = sys.DeleteServiceAccount(objLayer, fullCred.AccessKey); err != nil {
+		t.Fatalf("unable to delete service account, %s", err)
+	}
+	if err = sys.DeleteServiceAccount(objLayer, fullCred.AccessKey); err != errNoSuchServiceAccount {
+		t.Fatalf("expected: %v, got: %v", errNoSuchServiceAccount, err)
+	}
+	if _, ok := sys.GetServiceAccount(fullCred.AccessKey); ok {
+		t.Fatalf("service account %s must be deleted", fullCred.AccessKey)
+	}
+
+	// Accounts stored with former server credentials fail to load
+	// instead of being dropped, and are readable again once re-encrypted.
+	if _, err = sys.NewTempAccount(objLayer, rootAccessKey, time.Hour, nil, nil); err != nil {
+		t.Fatalf("unable to create temporary account, %s", err)
+	}
+	prevCred := globalServerConfig.GetCredential()
+	newCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("unable to generate credentials, %s", err)
+	}
+	globalServerConfig.SetCredential(newCred)
+	if err = loadedSys.Load(objLayer); err == nil {
+		t.Fatal("expected accounts stored with former credentials to fail to load")
+	}
+	if _, ok := loadedSys.GetServiceAccount(readCred.AccessKey); !ok {
+		t.Fatalf("service account %s must be kept", readCred.AccessKey)
+	}
+	if _, err = sys.NewServiceAccount(objLayer, newCred.AccessKey, nil); err == nil {
+		t.Fatal("expected accounts stored with former credentials not to be overwritten")
+	}
+	globalServerConfig.SetCredential(prevCred)
+	if err = reencryptConfigs(context.Background(), objLayer, prevCred.SecretKey, newCred.SecretKey, func() error {
+		globalServerConfig.SetCredential(newCred)
+		return nil
+	}); err != nil {
+		t.Fatalf("unable to re-encrypt IAM system, %s", err)
+	}
+	if err = loadedSys.Load(objLayer); err != nil {
+		t.Fatalf("unable to load IAM system, %s", err)
+	}
+	if _, ok := loadedSys.GetServiceAccount(readCred.AccessKey); !ok {
+		t.Fatalf("service account %s must be loaded", readCred.AccessKey)
+	}
+	if len(loadedSys.tempAccounts) != 1 {
+		t.Fatalf("expected: 1 temporary account, got: %d", len(loadedSys.tempAccounts))
+	}
+}
+
+func TestIAMSysExportImport(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	sys := NewIAMSys()
+	if err = sys.Init(objLayer); err != nil {
+		t.Fatalf("unable to initialize IAM system, %s", err)
+	}
+
+	rootAccessKey := globalServerConfig.GetCredential().AccessKey
+	cred, err := sys.NewServiceAccount(objLayer, rootAccessKey, nil)
+	if err != nil {
+		t.Fatalf("unable to create service account, %s", err)
+	}
+
+	archive, err := sys.ExportIAM()
+	if err != nil {
+		t.Fatalf("unable to export IAM, %s", err)
+	}
+
+	// Import into a fresh backend, as done when migrating to
+	// another cluster.
+	otherObjLayer, otherFSDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(otherFSDir)
+
+	otherSys := NewIAMSys()
+	if err = otherSys.Init(otherObjLayer); err != nil {
+		t.Fatalf("unable to initialize IAM system, %s", err)
+	}
+	if imported, err := otherSys.ImportIAM(otherObjLayer, archive); err != nil || imported != 1 {
+		t.Fatalf("expected 1 imported service account, got: %d, %v", imported, err)
+	}
+
+	loadedSys := NewIAMSys()
+	if err = loadedSys.Load(otherObjLayer); err != nil {
+		t.Fatalf("unable to load IAM system, %s", err)
+	}
+	if sa, ok := loadedSys.GetServiceAccount(cred.AccessKey); !ok || !sa.Credentials.Equal(cred) || sa.ParentUser != rootAccessKey {
+		t.Fatalf("expected: %v, got: %v", cred, sa)
+	}
+
+	// Archives of service accounts derived from another user can't be
+	// imported.
+	otherCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("unable to generate credentials, %s", err)
+	}
+	globalServerConfig.SetCredential(otherCred)
+	if _, err = otherSys.ImportIAM(otherObjLayer, archive); err != errInvalidParentUser {
+		t.Fatalf("expected: %v, got: %v", errInvalidParentUser, err)
+	}
+
+	if _, err = otherSys.ImportIAM(otherObjLayer, []byte("not a zip archive")); err != errInvalidIAMArchive {
+		t.Fatalf("expected: %v, got: %v", errInvalidIAMArchive, err)
+	}
+}
+
+func TestIAMSysTempAccounts(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	sys := NewIAMSys()
+	if err = sys.Init(objLayer); err != nil {
+		t.Fatalf("unable to initialize IAM system, %s", err)
+	}
+
+	rootAccessKey := globalServerConfig.GetCredential().AccessKey
+	if _, err = sys.NewTempAccount(objLayer, "unknownuser", time.Hour, nil, nil); err != errInvalidParentUser {
+		t.Fatalf("expected: %v, got: %v", errInvalidParentUser, err)
+	}
+
+	getObjectPolicy := policy.Policy{
+		Version: policy.DefaultVersion,
+		Statements: []policy.Statement{
+			policy.NewStatement(
+				policy.Allow,
+				policy.NewPrincipal("*"),
+				policy.NewActionSet(policy.GetObjectAction),
+				policy.NewResourceSet(policy.NewResource("mybucket", "*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	ta, err := sys.NewTempAccount(objLayer, rootAccessKey, time.Hour, nil, &getObjectPolicy)
+	if err != nil {
+		t.Fatalf("unable to create temporary account, %s", err)
+	}
+	if ta.SessionToken == "" || ta.IsExpired() {
+		t.Fatalf("unexpected temporary account %v", ta)
+	}
+	if !sys.IsAllowed(ta.Credentials.AccessKey, policy.Args{Action: policy.GetObjectAction, BucketName: "mybucket", ObjectName: "object"}) {
+		t.Fatalf("expected the session policy to allow getting objects")
+	}
+	if sys.IsAllowed(ta.Credentials.AccessKey, policy.Args{Action: policy.PutObjectAction, BucketName: "mybucket", ObjectName: "object"}) {
+		t.Fatalf("expected the session policy to deny putting objects")
+	}
+
+	// The session policy further restricts the policy of the identity,
+	// only actions allowed by both are permitted.
+	newObjectPolicy := func(actions ...policy.Action) *policy.Policy {
+		return &policy.Policy{
+			Version: policy.DefaultVersion,
+			Statements: []policy.Statement{
+				policy.NewStatement(
+					policy.Allow,
+					policy.NewPrincipal("*"),
+					policy.NewActionSet(actions...),
+					policy.NewResourceSet(policy.NewResource("mybucket", "*")),
+					condition.NewFunctions(),
+				),
+			},
+		}
+	}
+	restricted, err := sys.NewTempAccount(objLayer, rootAccessKey, time.Hour,
+		newObjectPolicy(policy.GetObjectAction, policy.PutObjectAction),
+		newObjectPolicy(policy.GetObjectAction, policy.DeleteObjectAction))
+	if err != nil {
+		t.Fatalf("unable to create temporary account, %s", err)
+	}
+	for action, expected := range map[policy.Action]bool{
+		policy.GetObjectAction:    true,
+		policy.PutObjectAction:    false,
+		policy.DeleteObjectAction: false,
+	} {
+		args := policy.Args{Action: action, BucketName: "mybucket", ObjectName: "object"}
+		if allowed := sys.IsAllowed(restricted.Credentials.AccessKey, args); allowed != expected {
+			t.Fatalf("%v: expected allowed: %v, got: %v", action, expected, allowed)
+		}
+	}
+
+	expired, err := sys.NewTempAccount(objLayer, rootAccessKey, time.Hour, nil, nil)
+	if err != nil {
+		t.Fatalf("unable to create temporary account, %s", err)
+	}
+	expired.Expiration = UTCNow().Add(-time.Minute)
+	if err = saveTempAccount(objLayer, expired); err != nil {
+		t.Fatalf("unable to save temporary account, %s", err)
+	}
+
+	// Each temporary account is saved in a config file of its own.
+	configFiles, err := listTempAccountConfigFiles(context.Background(), objLayer)
+	if err != nil {
+		t.Fatalf("unable to list temporary accounts, %s", err)
+	}
+	if len(configFiles) != 3 {
+		t.Fatalf("expected 3 temporary account config files, got %v", configFiles)
+	}
+
+	// Temporary accounts are loaded by other servers, expired ones
+	// are left out and removed from the backend.
+	otherSys := NewIAMSys()
+	if err = otherSys.Load(objLayer); err != nil {
+		t.Fatalf("unable to load IAM system, %s", err)
+	}
+	loaded, ok := otherSys.GetTempAccount(ta.Credentials.AccessKey)
+	if !ok || loaded.SessionToken != ta.SessionToken || !loaded.Expiration.Equal(ta.Expiration) || loaded.SessionPolicy == nil {
+		t.Fatalf("unexpected temporary account %v", loaded)
+	}
+	if _, ok = otherSys.GetTempAccount(expired.Credentials.AccessKey); ok {
+		t.Fatalf("expected the expired temporary account to be left out")
+	}
+	if configFiles, err = listTempAccountConfigFiles(context.Background(), objLayer); err != nil || len(configFiles) != 2 {
+		t.Fatalf("expected 2 temporary account config files, got %v, %v", configFiles, err)
+	}
+
+	// A single temporary account issued by another server is loaded
+	// on its own.
+	issued, err := sys.NewTempAccount(objLayer, rootAccessKey, time.Hour, nil, nil)
+	if err != nil {
+		t.Fatalf("unable to create temporary account, %s", err)
+	}
+	if err = otherSys.LoadTempAccount(objLayer, issued.Credentials.AccessKey); err != nil {
+		t.Fatalf("unable to load temporary account, %s", err)
+	}
+	if loaded, ok = otherSys.GetTempAccount(issued.Credentials.AccessKey); !ok || loaded.SessionToken != issued.SessionToken {
+		t.Fatalf("unexpected temporary account %v", loaded)
+	}
+	if err = otherSys.LoadTempAccount(objLayer, "unknownuser"); err != nil {
+		t.Fatalf("unable to load temporary account, %s", err)
+	}
+	if _, ok = otherSys.GetTempAccount("unknownuser"); ok {
+		t.Fatalf("expected unknown temporary account to be left out")
+	}
+
+	// Temporary accounts are not service accounts.
+	if len(otherSys.ListServiceAccounts()) != 0 {
+		t.Fatalf("expected no service accounts, got %v", otherSys.ListServiceAccounts())
+	}
+}
+
+func TestGetCredential(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	origIAMSys := globalIAMSys
+	defer func() { globalIAMSys = origIAMSys }()
+
+	saCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("unable to generate credentials, %s", err)
+	}
+	globalIAMSys = NewIAMSys()
+	globalIAMSys.serviceAccounts[saCred.AccessKey] = serviceAccount{Credentials: saCred}
+
+	tempCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("unable to generate credentials, %s", err)
+	}
+	globalIAMSys.tempAccounts[tempCred.AccessKey] = tempAccount{
+		serviceAccount: serviceAccount{Credentials: tempCred},
+		Expiration:     UTCNow().Add(time.Hour),
+	}
+
+	expiredCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("unable to generate credentials, %s", err)
+	}
+	globalIAMSys.tempAccounts[expiredCred.AccessKey] = tempAccount{
+		serviceAccount: serviceAccount{Credentials: expiredCred},
+		Expiration:     UTCNow().Add(-time.Hour),
+	}
+
+	rootCred := globalServerConfig.GetCredential()
+	testCases := []struct {
+		accessKey     string
+		expectedCred  string
+		expectedFound bool
+	}{
+		{rootCred.AccessKey, rootCred.SecretKey, true},
+		{saCred.AccessKey, saCred.SecretKey, true},
+		{tempCred.AccessKey, tempCred.SecretKey, true},
+		{expiredCred.AccessKey, "", false},
+		{"unknownkey", "", false},
+	}
+
+	for i, testCase := range testCases {
+		cred, found := getCredential(testCase.accessKey)
+		if found != testCase.expectedFound {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedFound, found)
+		}
+		if cred.SecretKey != testCase.expectedCred {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedCred, cred.SecretKey)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// InspectPartInfo holds the name and size of a file found in the
+// directory of an object on a drive.
+type InspectPartInfo struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// InspectDriveData holds the raw erasure metadata of an object and the
+// layout of its parts on a local drive.
+type InspectDriveData struct {
+	Path   string            `json:"path"`
+	XLMeta []byte            `json:"xlMeta,omitempty"`
+	Parts  []InspectPartInfo `json:"parts,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// ServerInspectData holds the inspect result of one node.
+type ServerInspectData struct {
+	Error string             `json:"error"`
+	Addr  string             `json:"addr"`
+	Data  []InspectDriveData `json:"data"`
+}
+
+// getInspectDriveData - reads xl.json of the object and lists the files
+// of the object directory on the given drive, data is never read.
+func getInspectDriveData(drivePath, bucket, object string) (info InspectDriveData) {
+	info.Path = drivePath
+
+	objectDir := filepath.Join(drivePath, bucket, filepath.FromSlash(object))
+	entries, err := ioutil.ReadDir(objectDir)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	for _, entry := range entries {
+		info.Parts = append(info.Parts, InspectPartInfo{Name: entry.Name(), Size: entry.Size()})
+	}
+
+	if info.XLMeta, err = ioutil.ReadFile(filepath.Join(objectDir, xlMetaJSONFile)); err != nil {
+		info.Error = err.Error()
+	}
+	return info
+}
+
+// getLocalInspectData - collects erasure metadata of the object from all local drives.
+func getLocalInspectData(endpoints EndpointList, bucket, object string) []InspectDriveData {
+	var drivesData []InspectDriveData
+	for _, drivePath := range localDrivePaths(endpoints) {
+		drivesData = append(drivesData, getInspectDriveData(drivePath, bucket, object))
+	}
+	return drivesData
+}
+
+// newInspectArchive - bundles inspect results of all nodes into a zip
+// archive encrypted with a key derived from the server credentials, see
+// encryptConfigData. For each node the archive holds a JSON document
+// with the part layout of every drive and the raw xl.json of every
+// drive it was found on.
+func newInspectArchive(inspectData []ServerInspectData) ([]byte, error) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+
+	for _, server := range inspectData {
+		// Peer addresses are of the form host:port, avoid ':' in file names.
+		serverDir := "inspect/" + strings.Replace(server.Addr, ":", "_", -1)
+
+		layout := server
+		layout.Data = make([]InspectDriveData, len(server.Data))
+		for i, drive := range server.Data {
+			if drive.XLMeta != nil {
+				w, err := zipWriter.Create(fmt.Sprintf("%s/drive%d/%s", serverDir, i+1, xlMetaJSONFile))
+				if err != nil {
+					return nil, err
+				}
+				if _, err = w.Write(drive.XLMeta); err != nil {
+					return nil, err
+				}
+			}
+			drive.XLMeta = nil
+			layout.Data[i] = drive
+		}
+
+		data, err := json.MarshalIndent(layout, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		w, err := zipWriter.Create(serverDir + ".json")
+		if err != nil {
+			return nil, err
+		}
+		if _, err = w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return encryptConfigData(buf.Bytes())
+}
@@ -0,0 +1,138 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetLocalInspectData(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "inspect-")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Only the first drive holds the object.
+	objectDir := filepath.Join(tmpDir, "d1", "bucket", "dir", "object")
+	if err = os.MkdirAll(objectDir, 0777); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	xlMeta := []byte(`{"version":"1.0.1","format":"xl"}`)
+	if err = ioutil.WriteFile(filepath.Join(objectDir, xlMetaJSONFile), xlMeta, 0644); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(objectDir, "part.1"), []byte("data"), 0644); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	endpoints := EndpointList{
+		{URL: &url.URL{Path: filepath.Join(tmpDir, "d1")}, IsLocal: true},
+		{URL: &url.URL{Path: filepath.Join(tmpDir, "d2")}, IsLocal: true},
+		// Remote drives are not inspected.
+		{URL: &url.URL{Scheme: "http", Host: "remote:9000", Path: "/d1"}},
+	}
+
+	drivesData := getLocalInspectData(endpoints, "bucket", "dir/object")
+	if len(drivesData) != 2 {
+		t.Fatalf("expected: 2, got: %d", len(drivesData))
+	}
+
+	if drivesData[0].Error != "" || !bytes.Equal(drivesData[0].XLMeta, xlMeta) {
+		t.Fatalf("unexpected result %v", drivesData[0])
+	}
+	expectedParts := []InspectPartInfo{{Name: "part.1", Size: 4}, {Name: xlMetaJSONFile, Size: int64(len(xlMeta))}}
+	if len(drivesData[0].Parts) != len(expectedParts) {
+		t.Fatalf("expected: %v, got: %v", expectedParts, drivesData[0].Parts)
+	}
+	for i, part := range expectedParts {
+		if drivesData[0].Parts[i] != part {
+			t.Fatalf("expected: %v, got: %v", part, drivesData[0].Parts[i])
+		}
+	}
+
+	if drivesData[1].Error == "" || drivesData[1].XLMeta != nil {
+		t.Fatalf("expected missing object error, got: %v", drivesData[1])
+	}
+}
+
+func TestNewInspectArchive(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	inspectData := []ServerInspectData{
+		{Addr: "127.0.0.1:9000", Data: []InspectDriveData{
+			{Path: "/d1", XLMeta: []byte(`{"format":"xl"}`)},
+			{Path: "/d2", Error: "file not found"},
+		}},
+		{Addr: "127.0.0.2:9000", Error: "unreachable"},
+	}
+
+	archive, err := newInspectArchive(inspectData)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if archive, err = decryptConfigData(archive); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expectedNames := []string{
+		"inspect/127.0.0.1_9000/drive1/xl.json",
+		"inspect/127.0.0.1_9000.json",
+		"inspect/127.0.0.2_9000.json",
+	}
+	if len(zipReader.File) != len(expectedNames) {
+		t.Fatalf("expected: %d files, got: %d", len(expectedNames), len(zipReader.File))
+	}
+	for i, file := range zipReader.File {
+		if file.Name != expectedNames[i] {
+			t.Fatalf("case %v: expected: %s, got: %s", i+1, expectedNames[i], file.Name)
+		}
+	}
+
+	rc, err := zipReader.File[1].Open()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer rc.Close()
+	var layout ServerInspectData
+	if err = json.NewDecoder(rc).Decode(&layout); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(layout.Data) != 2 || layout.Data[0].XLMeta != nil || layout.Data[1].Error != "file not found" {
+		t.Fatalf("unexpected layout %v", layout)
+	}
+	// Input must not be modified.
+	if inspectData[0].Data[0].XLMeta == nil {
+		t.Fatal("expected input to be left untouched")
+	}
+}
@@ -18,23 +18,33 @@ package cmd
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	sha256 "github.com/minio/sha256-simd"
 )
 
 // localAdminClient - represents admin operation to be executed locally.
 type localAdminClient struct{}
 
-// SignalService - sends a restart or stop signal to the local server
+// SignalService - sends a restart or stop signal to the local server,
+// freeze and unfreeze signals are applied right away.
 func (lc localAdminClient) SignalService(s serviceSignal) error {
 	switch s {
 	case serviceRestart, serviceStop:
 		globalServiceSignalCh <- s
+	case serviceFreeze:
+		freezeServices()
+	case serviceUnFreeze:
+		unfreezeServices()
 	default:
 		return errUnsupportedSignal
 	}
@@ -74,9 +84,242 @@ func (lc localAdminClient) ServerInfo() (sid ServerInfoData, e error) {
 			SQSARN:   globalNotificationSys.GetARNList(),
 			Region:   globalServerConfig.GetRegion(),
 		},
+		Disks: getLocalDisksInfo(globalEndpoints),
 	}, nil
 }
 
+// BandwidthInfo - Returns the per bucket bandwidth of this server.
+func (lc localAdminClient) BandwidthInfo() (sbd ServerBandwidthData, e error) {
+	if globalBootTime.IsZero() || globalBucketBandwidthStats == nil {
+		return sbd, errServerNotInitialized
+	}
+
+	return globalBucketBandwidthStats.toServerBandwidthData(UTCNow().Sub(globalBootTime)), nil
+}
+
+// TopAPI - Returns the per API call statistics of this server.
+func (lc localAdminClient) TopAPI() (map[string]APIStats, error) {
+	if globalAPICallStats == nil {
+		return nil, errServerNotInitialized
+	}
+
+	return globalAPICallStats.toServerAPIStats(UTCNow()), nil
+}
+
+// RuntimeInfo - Returns the Go runtime metrics of this server.
+func (lc localAdminClient) RuntimeInfo() (ServerRuntimeData, error) {
+	return getLocalRuntimeInfo(), nil
+}
+
+// KMSKeyStatus - generates a data key with the given master key and
+// decrypts it again to verify that the local KMS is usable.
+func (lc localAdminClient) KMSKeyStatus(keyID string) (status KMSKeyStatus, e error) {
+	if globalKMS == nil {
+		return status, errKMSNotConfigured
+	}
+	if keyID == "" {
+		keyID = globalKMSKeyID
+	}
+	status.KeyID = keyID
+
+	kmsContext := crypto.Context{"MinIO admin API": "KMSKeyStatus"}
+	key, sealedKey, err := globalKMS.GenerateKey(keyID, kmsContext)
+	if err != nil {
+		status.EncryptionErr = err.Error()
+		return status, nil
+	}
+
+	unsealedKey, err := globalKMS.UnsealKey(keyID, sealedKey, kmsContext)
+	if err != nil {
+		status.DecryptionErr = err.Error()
+		return status, nil
+	}
+	if subtle.ConstantTimeCompare(key[:], unsealedKey[:]) != 1 {
+		status.DecryptionErr = "The generated and the decrypted data key do not match"
+	}
+	return status, nil
+}
+
+// HealthInfo - collects system information and runs drive and
+// network throughput tests on this server.
+func (lc localAdminClient) HealthInfo() (shid ServerHealthInfoData, e error) {
+	if globalBootTime.IsZero() {
+		return shid, errServerNotInitialized
+	}
+
+	return ServerHealthInfoData{
+		CPU:    getLocalCPUInfo(),
+		Mem:    getLocalMemInfo(),
+		OS:     getLocalOSInfo(),
+		Drives: getLocalDrivesPerf(globalEndpoints, healthInfoDrivePayloadSize),
+		Net:    getPeersNetPerf(globalAdminPeers, healthInfoNetPayloadSize),
+	}, nil
+}
+
+// SendPayload - payload is already received, nothing to do locally.
+func (lc localAdminClient) SendPayload(payload []byte) error {
+	return nil
+}
+
+// NetPerf - runs network tests from this server to all other servers.
+func (lc localAdminClient) NetPerf(size int64) ([]NetPerfInfo, error) {
+	if size <= 0 || size > netPerfMaxPayloadSize {
+		return nil, errInvalidArgument
+	}
+
+	return getPeersNetPerf(globalAdminPeers, size), nil
+}
+
+// DrivePerf - runs drive tests on the local drives of this server.
+func (lc localAdminClient) DrivePerf(size int64) ([]DriveSpeedInfo, error) {
+	if size < drivePerfBlockSize || size > drivePerfMaxFileSize {
+		return nil, errInvalidArgument
+	}
+
+	return getLocalDrivesSpeed(globalEndpoints, size), nil
+}
+
+// Inspect - collects erasure metadata of an object from the local drives of this server.
+func (lc localAdminClient) Inspect(bucket, object string) ([]InspectDriveData, error) {
+	// Names are validated again as they are used to build drive paths.
+	if !IsValidBucketName(bucket) || !IsValidObjectName(object) {
+		return nil, errInvalidArgument
+	}
+
+	return getLocalInspectData(globalEndpoints, bucket, object), nil
+}
+
+// ServerUpdate - downloads the binary at updateURL and replaces the
+// local binary with it, the server must be restarted to run it.
+func (lc localAdminClient) ServerUpdate(updateURL string, sha256Sum []byte) error {
+	if len(sha256Sum) != sha256.Size {
+		return errInvalidArgument
+	}
+	// Containers are updated by replacing their image.
+	if minioBinaryPath == "" && (IsDocker() || IsKubernetes() || IsDCOS()) {
+		return errServerUpdateNotSupported
+	}
+
+	client := &http.Client{
+		// Reply before the admin RPC call of the peer times out.
+		Timeout: serverUpdateTimeout,
+	}
+	return applyUpdate(client, updateURL, sha256Sum)
+}
+
+// ReloadCerts - loads public.crt and private.key of the local server
+// again, the current certificate is kept if they are invalid.
+func (lc localAdminClient) ReloadCerts() error {
+	if globalTLSCerts == nil {
+		return errTLSNotConfigured
+	}
+	return globalTLSCerts.Reload()
+}
+
+// SetLogLevel - changes the log verbosity of a subsystem of the local server.
+func (lc localAdminClient) SetLogLevel(subsystem logger.Subsystem, level logger.Level) error {
+	return logger.SetLevel(subsystem, level)
+}
+
+// StartBatchJob - starts processing the share of a batch job of the local server.
+func (lc localAdminClient) StartBatchJob(job BatchJob, nodeIndex int) error {
+	if globalBatchJobSys == nil {
+		return errServerNotInitialized
+	}
+	return globalBatchJobSys.Start(newObjectLayerFn(), job, nodeIndex)
+}
+
+// DescribeBatchJob - returns the progress of a batch job on the local server.
+func (lc localAdminClient) DescribeBatchJob(id string) (BatchJobProgress, error) {
+	if globalBatchJobSys == nil {
+		return BatchJobProgress{}, errServerNotInitialized
+	}
+	return globalBatchJobSys.Describe(id)
+}
+
+// CancelBatchJob - stops a batch job on the local server.
+func (lc localAdminClient) CancelBatchJob(id string) error {
+	if globalBatchJobSys == nil {
+		return errServerNotInitialized
+	}
+	return globalBatchJobSys.Cancel(id)
+}
+
+// ListBatchJobs - returns the progress of all batch jobs of the local server.
+func (lc localAdminClient) ListBatchJobs() ([]BatchJobProgress, error) {
+	if globalBatchJobSys == nil {
+		return nil, errServerNotInitialized
+	}
+	return globalBatchJobSys.List(), nil
+}
+
+// SetScannerSpeed - changes the pace of background scans of the local server.
+func (lc localAdminClient) SetScannerSpeed(speed ScannerSpeed) error {
+	return setScannerSpeed(speed)
+}
+
+// ListMultipartUploads - returns the multipart uploads found on the local drives.
+func (lc localAdminClient) ListMultipartUploads() ([]MultipartUploadInfo, error) {
+	return getLocalMultipartUploads(globalEndpoints), nil
+}
+
+// AbortStaleMultipartUploads - removes multipart uploads older than
+// olderThan from the local drives and returns how many were removed.
+func (lc localAdminClient) AbortStaleMultipartUploads(olderThan time.Duration) (int, error) {
+	if olderThan <= 0 {
+		return 0, errInvalidArgument
+	}
+	return abortLocalStaleMultipartUploads(globalEndpoints, olderThan), nil
+}
+
+// ListFailedEvents - returns the events the local server failed to deliver.
+func (lc localAdminClient) ListFailedEvents() ([]FailedEvent, error) {
+	if globalNotificationSys == nil {
+		return nil, errServerNotInitialized
+	}
+	return globalNotificationSys.ListFailedEvents(), nil
+}
+
+// ReplayFailedEvents - sends the failed event having the given ID, or all
+// failed events if id is empty, of the local server again to their targets.
+func (lc localAdminClient) ReplayFailedEvents(id string) (FailedEventsReplay, error) {
+	if globalNotificationSys == nil {
+		return FailedEventsReplay{}, errServerNotInitialized
+	}
+	return globalNotificationSys.ReplayFailedEvents(id)
+}
+
+// ForceUnlock - releases the locks held on bucket/object, or all locks
+// held by client, on the local server and returns how many were released.
+func (lc localAdminClient) ForceUnlock(bucket, object, client string) (int, error) {
+	if err := validateForceUnlock(bucket, object, client); err != nil {
+		return 0, err
+	}
+	return forceUnlockLocal(newObjectLayerFn(), bucket, object, client), nil
+}
+
+// ListSessions - returns the S3 API calls in progress on the local server.
+func (lc localAdminClient) ListSessions() ([]SessionInfo, error) {
+	if globalSessions == nil {
+		return nil, errServerNotInitialized
+	}
+	return globalSessions.list(UTCNow()), nil
+}
+
+// MaintenanceEnter - puts the local server in maintenance.
+func (lc localAdminClient) MaintenanceEnter(drainTimeout time.Duration) (int, error) {
+	if globalSessions == nil {
+		return 0, errServerNotInitialized
+	}
+	return enterMaintenance(drainTimeout), nil
+}
+
+// MaintenanceExit - makes the local server leave maintenance.
+func (lc localAdminClient) MaintenanceExit() error {
+	exitMaintenance()
+	return nil
+}
+
 // GetConfig - returns config.json of the local server.
 func (lc localAdminClient) GetConfig() ([]byte, error) {
 	if globalServerConfig == nil {
@@ -110,3 +353,9 @@ func (lc localAdminClient) CommitConfig(tmpFileName string) error {
 	logger.LogIf(ctx, err)
 	return err
 }
+
+// ValidateConfig - checks that the local server can use the candidate
+// config before it is committed.
+func (lc localAdminClient) ValidateConfig(configBytes []byte) ([]ConfigValidationError, error) {
+	return validateConfig(configBytes), nil
+}
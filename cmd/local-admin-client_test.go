@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"os"
 	"testing"
 )
 
@@ -32,6 +33,92 @@ func TestLocalAdminClientServerInfo(t *testing.T) {
 	testAdminCmdRunnerServerInfo(t, &localAdminClient{})
 }
 
+func TestLocalAdminClientTopAPI(t *testing.T) {
+	testAdminCmdRunnerTopAPI(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientBandwidthInfo(t *testing.T) {
+	testAdminCmdRunnerBandwidthInfo(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientKMSKeyStatus(t *testing.T) {
+	testAdminCmdRunnerKMSKeyStatus(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientHealthInfo(t *testing.T) {
+	testAdminCmdRunnerHealthInfo(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientSendPayload(t *testing.T) {
+	testAdminCmdRunnerSendPayload(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientNetPerf(t *testing.T) {
+	testAdminCmdRunnerNetPerf(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientDrivePerf(t *testing.T) {
+	testAdminCmdRunnerDrivePerf(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientInspect(t *testing.T) {
+	testAdminCmdRunnerInspect(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientServerUpdate(t *testing.T) {
+	testAdminCmdRunnerServerUpdate(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientSetLogLevel(t *testing.T) {
+	testAdminCmdRunnerSetLogLevel(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientSetScannerSpeed(t *testing.T) {
+	testAdminCmdRunnerSetScannerSpeed(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientBatchJobs(t *testing.T) {
+	testAdminCmdRunnerBatchJobs(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientMultipartUploads(t *testing.T) {
+	testAdminCmdRunnerMultipartUploads(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientFailedEvents(t *testing.T) {
+	testAdminCmdRunnerFailedEvents(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientForceUnlock(t *testing.T) {
+	testAdminCmdRunnerForceUnlock(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientRuntimeInfo(t *testing.T) {
+	testAdminCmdRunnerRuntimeInfo(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientListSessions(t *testing.T) {
+	testAdminCmdRunnerListSessions(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientValidateConfig(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	testAdminCmdRunnerValidateConfig(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientMaintenance(t *testing.T) {
+	testAdminCmdRunnerMaintenance(t, &localAdminClient{})
+}
+
+func TestLocalAdminClientReloadCerts(t *testing.T) {
+	testAdminCmdRunnerReloadCerts(t, &localAdminClient{})
+}
+
 func TestLocalAdminClientGetConfig(t *testing.T) {
 	testAdminCmdRunnerGetConfig(t, &localAdminClient{})
 }
@@ -149,3 +149,38 @@ func (l *localLocker) ForceUnlock(args dsync.LockArgs) (reply bool, err error) {
 	}
 	return true, nil
 }
+
+// forceUnlockResource - removes all locks held on resource and returns
+// how many were removed.
+func (l *localLocker) forceUnlockResource(resource string) int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	released := len(l.lockMap[resource])
+	delete(l.lockMap, resource)
+	return released
+}
+
+// forceUnlockClient - removes all locks held by the client having the
+// given network address, e.g. a server which is down, and returns how
+// many were removed.
+func (l *localLocker) forceUnlockClient(node string) int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	released := 0
+	for resource, lri := range l.lockMap {
+		kept := lri[:0]
+		for _, entry := range lri {
+			if entry.node == node {
+				released++
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		if len(kept) == 0 {
+			delete(l.lockMap, resource)
+		} else {
+			l.lockMap[resource] = kept
+		}
+	}
+	return released
+}
@@ -37,6 +37,13 @@ func (c *ConsoleTarget) send(entry logEntry) error {
 		return nil
 	}
 
+	// Subsystem events carry a message but no error trace.
+	if entry.Trace == nil {
+		fmt.Printf("%s %s %s: %s\n", time.Now().Format(loggerTimeFormat),
+			entry.Level, entry.Subsystem, entry.Message)
+		return nil
+	}
+
 	trace := make([]string, len(entry.Trace.Source))
 
 	// Add a sequence number and formatting for each stack trace
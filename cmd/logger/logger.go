@@ -43,6 +43,7 @@ const (
 	InformationLvl Level = iota + 1
 	ErrorLvl
 	FatalLvl
+	DebugLvl
 )
 
 const loggerTimeFormat string = "15:04:05 MST 01/02/2006"
@@ -82,6 +83,8 @@ func (level Level) String() string {
 		lvlStr = "ERROR"
 	case FatalLvl:
 		lvlStr = "FATAL"
+	case DebugLvl:
+		lvlStr = "DEBUG"
 	}
 	return lvlStr
 }
@@ -123,6 +126,7 @@ type api struct {
 type logEntry struct {
 	DeploymentID string      `json:"deploymentid,omitempty"`
 	Level        string      `json:"level"`
+	Subsystem    string      `json:"subsystem,omitempty"`
 	Time         string      `json:"time"`
 	API          *api        `json:"api,omitempty"`
 	RemoteHost   string      `json:"remotehost,omitempty"`
@@ -0,0 +1,168 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Subsystem - part of the server whose log verbosity can be changed
+// while the server is running.
+type Subsystem string
+
+// Subsystems having their own log verbosity.
+const (
+	HTTPSubsystem    Subsystem = "http"
+	StorageSubsystem Subsystem = "storage"
+	RPCSubsystem     Subsystem = "rpc"
+	IAMSubsystem     Subsystem = "iam"
+)
+
+// Subsystems - all subsystems having their own log verbosity.
+var Subsystems = []Subsystem{HTTPSubsystem, StorageSubsystem, RPCSubsystem, IAMSubsystem}
+
+var (
+	errInvalidSubsystem = errors.New("invalid log subsystem")
+	errInvalidLevel     = errors.New("invalid log level")
+)
+
+// Verbosity of each subsystem, errors are always logged.
+var subsystemLevels = struct {
+	sync.RWMutex
+	levels map[Subsystem]Level
+}{
+	levels: map[Subsystem]Level{
+		HTTPSubsystem:    ErrorLvl,
+		StorageSubsystem: ErrorLvl,
+		RPCSubsystem:     ErrorLvl,
+		IAMSubsystem:     ErrorLvl,
+	},
+}
+
+// ParseSubsystem - returns the subsystem of the given name.
+func ParseSubsystem(s string) (Subsystem, error) {
+	for _, subsystem := range Subsystems {
+		if string(subsystem) == s {
+			return subsystem, nil
+		}
+	}
+	return "", errInvalidSubsystem
+}
+
+// ParseLevel - returns the verbosity of the given name, one of
+// "error", "info" or "debug".
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "error":
+		return ErrorLvl, nil
+	case "info":
+		return InformationLvl, nil
+	case "debug":
+		return DebugLvl, nil
+	}
+	return 0, errInvalidLevel
+}
+
+// verbosity - orders levels from the least to the most verbose.
+func (level Level) verbosity() int {
+	switch level {
+	case ErrorLvl:
+		return 0
+	case InformationLvl:
+		return 1
+	case DebugLvl:
+		return 2
+	}
+	return -1
+}
+
+// SetLevel - changes the verbosity of the subsystem to level.
+func SetLevel(subsystem Subsystem, level Level) error {
+	if _, err := ParseSubsystem(string(subsystem)); err != nil {
+		return err
+	}
+	if level.verbosity() < 0 {
+		return errInvalidLevel
+	}
+
+	subsystemLevels.Lock()
+	subsystemLevels.levels[subsystem] = level
+	subsystemLevels.Unlock()
+	return nil
+}
+
+// GetLevels - returns the verbosity of all subsystems.
+func GetLevels() map[Subsystem]Level {
+	subsystemLevels.RLock()
+	defer subsystemLevels.RUnlock()
+
+	levels := make(map[Subsystem]Level, len(subsystemLevels.levels))
+	for subsystem, level := range subsystemLevels.levels {
+		levels[subsystem] = level
+	}
+	return levels
+}
+
+// IsEnabled - returns true if messages of the given level are logged
+// for the subsystem.
+func IsEnabled(subsystem Subsystem, level Level) bool {
+	subsystemLevels.RLock()
+	defer subsystemLevels.RUnlock()
+
+	current, ok := subsystemLevels.levels[subsystem]
+	return ok && level.verbosity() <= current.verbosity()
+}
+
+// Event logs a message of the subsystem if its verbosity allows level,
+// msg is formatted along with data like fmt.Sprintf does.
+func Event(ctx context.Context, subsystem Subsystem, level Level, msg string, data ...interface{}) {
+	if Disable || !IsEnabled(subsystem, level) {
+		return
+	}
+
+	req := GetReqInfo(ctx)
+	if req == nil {
+		req = &ReqInfo{API: "SYSTEM"}
+	}
+
+	API := "SYSTEM"
+	if req.API != "" {
+		API = req.API
+	}
+
+	entry := logEntry{
+		DeploymentID: deploymentID,
+		Level:        level.String(),
+		Subsystem:    string(subsystem),
+		RemoteHost:   req.RemoteHost,
+		RequestID:    req.RequestID,
+		UserAgent:    req.UserAgent,
+		Time:         time.Now().UTC().Format(time.RFC3339Nano),
+		API:          &api{Name: API, Args: &args{Bucket: req.BucketName, Object: req.ObjectName}},
+		Message:      fmt.Sprintf(msg, data...),
+	}
+
+	// Iterate over all logger targets to send the log entry
+	for _, t := range Targets {
+		t.send(entry)
+	}
+}
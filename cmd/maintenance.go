@@ -0,0 +1,93 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// Time waited for S3 calls in progress to complete when a node
+	// enters maintenance, unless the admin request sets another one.
+	defaultMaintenanceDrainTimeout = time.Minute
+
+	// Maximum time waited for S3 calls in progress, less than the
+	// deadline of the MaintenanceEnter RPC.
+	maxMaintenanceDrainTimeout = 10 * time.Minute
+
+	// Interval at which S3 calls in progress are counted while
+	// draining.
+	maintenanceDrainPollInterval = 100 * time.Millisecond
+)
+
+var errInvalidMaintenanceDrainTimeout = errors.New("drain timeout must be a positive duration of at most 10 minutes")
+
+// Set to 1 while the server is in maintenance, see enterMaintenance.
+var globalMaintenanceMode int32
+
+// MaintenanceStatus holds whether a node is in maintenance along with
+// the number of S3 calls still in progress once draining ended.
+type MaintenanceStatus struct {
+	Addr          string `json:"addr"`
+	InMaintenance bool   `json:"inMaintenance"`
+	InFlight      int    `json:"inFlight"`
+}
+
+// enterMaintenance - makes this server reject new S3 calls and fail its
+// readiness probe so that load balancers stop sending it traffic, then
+// waits up to drainTimeout for calls in progress to complete. Returns
+// the number of calls still in progress. Admin, RPC and health check
+// requests are still served, other nodes keep using the local drives
+// until the server is stopped. The maintenance mode is not persisted
+// and is cleared on restart.
+func enterMaintenance(drainTimeout time.Duration) int {
+	atomic.StoreInt32(&globalMaintenanceMode, 1)
+
+	deadline := UTCNow().Add(drainTimeout)
+	for {
+		inFlight := globalSessions.count()
+		if inFlight == 0 || !UTCNow().Before(deadline) || !isInMaintenance() {
+			return inFlight
+		}
+		time.Sleep(maintenanceDrainPollInterval)
+	}
+}
+
+// exitMaintenance - resumes serving S3 calls and readiness probes.
+func exitMaintenance() {
+	atomic.StoreInt32(&globalMaintenanceMode, 0)
+}
+
+// isInMaintenance - returns true if the server is in maintenance.
+func isInMaintenance() bool {
+	return atomic.LoadInt32(&globalMaintenanceMode) == 1
+}
+
+// parseMaintenanceDrainTimeout - parses the drain timeout of an admin
+// request, an empty value means defaultMaintenanceDrainTimeout.
+func parseMaintenanceDrainTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultMaintenanceDrainTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 || timeout > maxMaintenanceDrainTimeout {
+		return 0, errInvalidMaintenanceDrainTimeout
+	}
+	return timeout, nil
+}
@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEnterMaintenance(t *testing.T) {
+	tmpGlobalSessions := globalSessions
+	defer func() {
+		globalSessions = tmpGlobalSessions
+		exitMaintenance()
+	}()
+	globalSessions = newSessionTracker()
+
+	prevGlobalServerConfig := globalServerConfig
+	globalServerConfig = newServerConfig()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+
+	id := globalSessions.start(SessionInfo{RemoteHost: "10.0.0.1:1000", API: "PutObject", Started: UTCNow()})
+
+	// The call in progress outlasts the drain timeout.
+	if inFlight := enterMaintenance(10 * time.Millisecond); inFlight != 1 {
+		t.Fatalf("expected 1 call in progress, got %d", inFlight)
+	}
+	if !isInMaintenance() {
+		t.Fatal("expected the server to be in maintenance")
+	}
+
+	rec := httptest.NewRecorder()
+	ReadinessCheckHandler(rec, httptest.NewRequest(http.MethodGet, healthCheckPathPrefix+healthCheckReadinessPath, nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected readiness probe to fail with %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+
+	handler := setServiceFreezeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost:9000/bucket/object", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected S3 call to fail with %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+
+	// The call in progress completes while draining.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		globalSessions.done(id)
+	}()
+	if inFlight := enterMaintenance(5 * time.Second); inFlight != 0 {
+		t.Fatalf("expected no call in progress, got %d", inFlight)
+	}
+
+	exitMaintenance()
+	if isInMaintenance() {
+		t.Fatal("expected the server to leave maintenance")
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost:9000/bucket/object", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected S3 call to succeed, got %d", rec.Code)
+	}
+}
+
+func TestParseMaintenanceDrainTimeout(t *testing.T) {
+	testCases := []struct {
+		value           string
+		expectedTimeout time.Duration
+		expectedErr     error
+	}{
+		{"", defaultMaintenanceDrainTimeout, nil},
+		{"30s", 30 * time.Second, nil},
+		{"10m", 10 * time.Minute, nil},
+		{"11m", 0, errInvalidMaintenanceDrainTimeout},
+		{"0s", 0, errInvalidMaintenanceDrainTimeout},
+		{"-1s", 0, errInvalidMaintenanceDrainTimeout},
+		{"forever", 0, errInvalidMaintenanceDrainTimeout},
+	}
+
+	for i, testCase := range testCases {
+		timeout, err := parseMaintenanceDrainTimeout(testCase.value)
+		if err != testCase.expectedErr || timeout != testCase.expectedTimeout {
+			t.Errorf("case %d: expected %v, %v, got %v, %v", i+1, testCase.expectedTimeout, testCase.expectedErr, timeout, err)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Multipart uploads are stored under a hash of their bucket and object
+// name, both are recorded in the upload metadata along with the time the
+// upload was initiated so that uploads can be listed without knowing the
+// object name. They are removed when the upload is completed.
+const (
+	multipartUploadObjectKey    = ReservedMetadataPrefix + "Multipart-Object"
+	multipartUploadInitiatedKey = ReservedMetadataPrefix + "Multipart-Initiated"
+)
+
+// setMultipartUploadMeta - records the name and the initiation time of a
+// new multipart upload in its metadata.
+func setMultipartUploadMeta(meta map[string]string, bucket, object string) {
+	meta[multipartUploadObjectKey] = pathJoin(bucket, object)
+	meta[multipartUploadInitiatedKey] = UTCNow().Format(time.RFC3339Nano)
+}
+
+// removeMultipartUploadMeta - removes the metadata recorded by
+// setMultipartUploadMeta from the metadata of a completed object.
+func removeMultipartUploadMeta(meta map[string]string) {
+	delete(meta, multipartUploadObjectKey)
+	delete(meta, multipartUploadInitiatedKey)
+}
+
+// MultipartUploadInfo holds an in-progress multipart upload found on
+// the drives of a node. Bucket, Object and Initiated are unknown for
+// uploads initiated by older releases. Modified is the time the last
+// part was uploaded.
+type MultipartUploadInfo struct {
+	Bucket    string    `json:"bucket,omitempty"`
+	Object    string    `json:"object,omitempty"`
+	UploadID  string    `json:"uploadId"`
+	Initiated time.Time `json:"initiated"`
+	Modified  time.Time `json:"modified"`
+	Parts     int       `json:"parts"`
+}
+
+// age - returns for how long the upload exists, or is inactive if its
+// initiation time is unknown.
+func (info MultipartUploadInfo) age(now time.Time) time.Duration {
+	if !info.Initiated.IsZero() {
+		return now.Sub(info.Initiated)
+	}
+	return now.Sub(info.Modified)
+}
+
+// ServerMultipartUploads holds the multipart uploads found by one node.
+type ServerMultipartUploads struct {
+	Error string                `json:"error"`
+	Addr  string                `json:"addr"`
+	Data  []MultipartUploadInfo `json:"data"`
+}
+
+// ClusterMultipartUploads holds all multipart uploads of the cluster,
+// oldest first, along with the uploads found by each node.
+type ClusterMultipartUploads struct {
+	Uploads []MultipartUploadInfo    `json:"uploads"`
+	Servers []ServerMultipartUploads `json:"servers"`
+}
+
+// multipartUploadMeta - part of xl.json and fs.json of an upload needed
+// to describe it.
+type multipartUploadMeta struct {
+	Meta  map[string]string `json:"meta"`
+	Parts []objectPartInfo  `json:"parts"`
+}
+
+// getMultipartUploadInfo - describes the upload stored in uploadDir on
+// a local drive, i.e. ".minio.sys/multipart/<hash>/<upload-id>".
+func getMultipartUploadInfo(uploadDir string) (info MultipartUploadInfo, err error) {
+	info.UploadID = filepath.Base(uploadDir)
+
+	// Erasure coded uploads keep the list of their parts in xl.json,
+	// FS uploads keep each part in its own file next to fs.json.
+	metaFile, isXL := filepath.Join(uploadDir, xlMetaJSONFile), true
+	fi, err := os.Stat(metaFile)
+	if os.IsNotExist(err) {
+		metaFile, isXL = filepath.Join(uploadDir, fsMetaJSONFile), false
+		fi, err = os.Stat(metaFile)
+	}
+	if err != nil {
+		return info, err
+	}
+	info.Modified = fi.ModTime().UTC()
+
+	data, err := ioutil.ReadFile(metaFile)
+	if err != nil {
+		return info, err
+	}
+	var uploadMeta multipartUploadMeta
+	if err = json.Unmarshal(data, &uploadMeta); err != nil {
+		return info, err
+	}
+
+	if isXL {
+		info.Parts = len(uploadMeta.Parts)
+	} else {
+		entries, err := ioutil.ReadDir(uploadDir)
+		if err != nil {
+			return info, err
+		}
+		for _, entry := range entries {
+			if entry.Name() != fsMetaJSONFile {
+				info.Parts++
+			}
+		}
+	}
+
+	if name, ok := uploadMeta.Meta[multipartUploadObjectKey]; ok {
+		if i := strings.Index(name, slashSeparator); i > 0 {
+			info.Bucket, info.Object = name[:i], name[i+1:]
+		}
+	}
+	if initiated, ok := uploadMeta.Meta[multipartUploadInitiatedKey]; ok {
+		info.Initiated, _ = time.Parse(time.RFC3339Nano, initiated)
+	}
+	return info, nil
+}
+
+// walkLocalMultipartUploads - calls walkFn with the directory and the
+// description of each upload found on the given local drives.
+func walkLocalMultipartUploads(drivePaths []string, walkFn func(uploadDir string, info MultipartUploadInfo)) {
+	for _, drivePath := range drivePaths {
+		multipartDir := filepath.Join(drivePath, filepath.FromSlash(minioMetaMultipartBucket))
+		shaDirs, err := ioutil.ReadDir(multipartDir)
+		if err != nil {
+			continue
+		}
+		for _, shaDir := range shaDirs {
+			uploadDirs, err := ioutil.ReadDir(filepath.Join(multipartDir, shaDir.Name()))
+			if err != nil {
+				continue
+			}
+			for _, uploadDir := range uploadDirs {
+				dir := filepath.Join(multipartDir, shaDir.Name(), uploadDir.Name())
+				info, err := getMultipartUploadInfo(dir)
+				if err != nil {
+					continue
+				}
+				walkFn(dir, info)
+			}
+		}
+	}
+}
+
+// getLocalMultipartUploads - lists the uploads found on all local drives,
+// an erasure coded upload is found on each drive of its set.
+func getLocalMultipartUploads(endpoints EndpointList) []MultipartUploadInfo {
+	uploads := make(map[string]MultipartUploadInfo)
+	walkLocalMultipartUploads(localDrivePaths(endpoints), func(uploadDir string, info MultipartUploadInfo) {
+		uploads[info.UploadID] = mergeMultipartUploadInfo(uploads[info.UploadID], info)
+	})
+	return sortMultipartUploads(uploads)
+}
+
+// abortLocalStaleMultipartUploads - removes the uploads older than
+// olderThan from all local drives and returns how many were removed.
+func abortLocalStaleMultipartUploads(endpoints EndpointList, olderThan time.Duration) int {
+	now := UTCNow()
+	aborted := make(map[string]struct{})
+	walkLocalMultipartUploads(localDrivePaths(endpoints), func(uploadDir string, info MultipartUploadInfo) {
+		if info.age(now) <= olderThan {
+			return
+		}
+		// Any error is ignored like the periodic cleanup of stale
+		// uploads does, leftovers are removed by the next call.
+		if err := os.RemoveAll(uploadDir); err == nil {
+			aborted[info.UploadID] = struct{}{}
+		}
+	})
+	return len(aborted)
+}
+
+// mergeMultipartUploadInfo - merges descriptions of the same upload read
+// from different drives, some of which may lag behind.
+func mergeMultipartUploadInfo(a, b MultipartUploadInfo) MultipartUploadInfo {
+	if a.UploadID == "" {
+		return b
+	}
+	if a.Bucket == "" {
+		a.Bucket, a.Object = b.Bucket, b.Object
+	}
+	if a.Initiated.IsZero() {
+		a.Initiated = b.Initiated
+	}
+	if b.Modified.After(a.Modified) {
+		a.Modified = b.Modified
+	}
+	if b.Parts > a.Parts {
+		a.Parts = b.Parts
+	}
+	return a
+}
+
+// sortMultipartUploads - returns the uploads oldest first.
+func sortMultipartUploads(uploads map[string]MultipartUploadInfo) []MultipartUploadInfo {
+	now := UTCNow()
+	sorted := make([]MultipartUploadInfo, 0, len(uploads))
+	for _, info := range uploads {
+		sorted = append(sorted, info)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if ai, aj := sorted[i].age(now), sorted[j].age(now); ai != aj {
+			return ai > aj
+		}
+		return sorted[i].UploadID < sorted[j].UploadID
+	})
+	return sorted
+}
+
+// aggregateMultipartUploads - merges the uploads found by all nodes.
+func aggregateMultipartUploads(servers []ServerMultipartUploads) []MultipartUploadInfo {
+	uploads := make(map[string]MultipartUploadInfo)
+	for _, server := range servers {
+		for _, info := range server.Data {
+			uploads[info.UploadID] = mergeMultipartUploadInfo(uploads[info.UploadID], info)
+		}
+	}
+	return sortMultipartUploads(uploads)
+}
@@ -0,0 +1,146 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// Tests listing and aborting the multipart uploads stored on local drives.
+func TestLocalMultipartUploads(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable to initialize config, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+	initNSLock(false)
+
+	fsObj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+	testLocalMultipartUploads(t, fsObj, mustGetNewEndpointList(fsDir))
+
+	xlObj, xlDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatalf("unable to initialize XL backend, %s", err)
+	}
+	defer removeRoots(xlDirs)
+	testLocalMultipartUploads(t, xlObj, mustGetNewEndpointList(xlDirs...))
+}
+
+func testLocalMultipartUploads(t *testing.T, obj ObjectLayer, endpoints EndpointList) {
+	ctx := context.Background()
+	if err := obj.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatalf("unable to create bucket, %s", err)
+	}
+
+	var uploadIDs []string
+	for _, object := range []string{"object", "dir/object"} {
+		uploadID, err := obj.NewMultipartUpload(ctx, "bucket", object, nil)
+		if err != nil {
+			t.Fatalf("unable to initiate multipart upload, %s", err)
+		}
+		uploadIDs = append(uploadIDs, uploadID)
+	}
+
+	data := []byte("hello, world")
+	partInfo, err := obj.PutObjectPart(ctx, "bucket", "object", uploadIDs[0], 1, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""))
+	if err != nil {
+		t.Fatalf("unable to upload part, %s", err)
+	}
+
+	uploads := getLocalMultipartUploads(endpoints)
+	if len(uploads) != 2 {
+		t.Fatalf("expected 2 uploads, got %v", uploads)
+	}
+	// Uploads are listed oldest first.
+	if uploads[0].UploadID != uploadIDs[0] || uploads[0].Object != "object" || uploads[0].Parts != 1 {
+		t.Fatalf("expected upload %s of object with 1 part, got %v", uploadIDs[0], uploads[0])
+	}
+	if uploads[1].UploadID != uploadIDs[1] || uploads[1].Object != "dir/object" || uploads[1].Parts != 0 {
+		t.Fatalf("expected upload %s of dir/object without parts, got %v", uploadIDs[1], uploads[1])
+	}
+	for _, upload := range uploads {
+		if upload.Bucket != "bucket" || upload.Initiated.IsZero() {
+			t.Fatalf("expected upload of bucket with its initiation time, got %v", upload)
+		}
+	}
+
+	// Completed uploads are neither listed nor keep the upload metadata.
+	objInfo, err := obj.CompleteMultipartUpload(ctx, "bucket", "object", uploadIDs[0], []CompletePart{{PartNumber: 1, ETag: partInfo.ETag}})
+	if err != nil {
+		t.Fatalf("unable to complete multipart upload, %s", err)
+	}
+	for _, key := range []string{multipartUploadObjectKey, multipartUploadInitiatedKey} {
+		if _, ok := objInfo.UserDefined[key]; ok {
+			t.Fatalf("expected %s to be removed from the completed object", key)
+		}
+	}
+	if uploads = getLocalMultipartUploads(endpoints); len(uploads) != 1 || uploads[0].UploadID != uploadIDs[1] {
+		t.Fatalf("expected only upload %s, got %v", uploadIDs[1], uploads)
+	}
+
+	if aborted := abortLocalStaleMultipartUploads(endpoints, time.Hour); aborted != 0 {
+		t.Fatalf("expected no aborted uploads, got %d", aborted)
+	}
+	if aborted := abortLocalStaleMultipartUploads(endpoints, time.Nanosecond); aborted != 1 {
+		t.Fatalf("expected 1 aborted upload, got %d", aborted)
+	}
+	if uploads = getLocalMultipartUploads(endpoints); len(uploads) != 0 {
+		t.Fatalf("expected no uploads, got %v", uploads)
+	}
+}
+
+// Tests merging the uploads found by different nodes.
+func TestAggregateMultipartUploads(t *testing.T) {
+	now := UTCNow()
+	servers := []ServerMultipartUploads{
+		{Addr: "node1", Data: []MultipartUploadInfo{
+			{UploadID: "b", Modified: now.Add(-time.Hour), Parts: 1},
+			{Bucket: "bucket", Object: "new", UploadID: "c", Initiated: now, Modified: now},
+		}},
+		{Addr: "node2", Data: []MultipartUploadInfo{
+			{Bucket: "bucket", Object: "old", UploadID: "b", Initiated: now.Add(-2 * time.Hour), Modified: now, Parts: 3},
+			{UploadID: "a", Modified: now.Add(-time.Minute)},
+		}},
+	}
+
+	uploads := aggregateMultipartUploads(servers)
+	expected := []MultipartUploadInfo{
+		{Bucket: "bucket", Object: "old", UploadID: "b", Initiated: now.Add(-2 * time.Hour), Modified: now, Parts: 3},
+		{UploadID: "a", Modified: now.Add(-time.Minute)},
+		{Bucket: "bucket", Object: "new", UploadID: "c", Initiated: now, Modified: now},
+	}
+	if len(uploads) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, uploads)
+	}
+	for i := range expected {
+		if !uploads[i].Initiated.Equal(expected[i].Initiated) || !uploads[i].Modified.Equal(expected[i].Modified) {
+			t.Fatalf("case %d: expected %v, got %v", i+1, expected[i], uploads[i])
+		}
+		uploads[i].Initiated, uploads[i].Modified = expected[i].Initiated, expected[i].Modified
+		if uploads[i] != expected[i] {
+			t.Fatalf("case %d: expected %v, got %v", i+1, expected[i], uploads[i])
+		}
+	}
+}
@@ -266,6 +266,21 @@ func (n *nsLockMap) ForceUnlock(volume, path string) {
 	}
 }
 
+// forceUnlockLocal - removes the lock of the given name from this map
+// only, unlike ForceUnlock locks held through dsync are kept. Returns
+// false if the name is not locked.
+func (n *nsLockMap) forceUnlockLocal(volume, path string) bool {
+	n.lockMapMutex.Lock()
+	defer n.lockMapMutex.Unlock()
+
+	param := nsParam{volume, path}
+	if _, found := n.lockMap[param]; !found {
+		return false
+	}
+	delete(n.lockMap, param)
+	return true
+}
+
 // lockInstance - frontend/top-level interface for namespace locks.
 type lockInstance struct {
 	ns                  *nsLockMap
@@ -0,0 +1,192 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+)
+
+const (
+	// Failed events of each node are saved in minioMetaBucket under
+	// this prefix.
+	failedEventsPrefix = "failed-events"
+
+	// Maximum number of failed events kept by a node, the oldest events
+	// are dropped first.
+	maxFailedEvents = 10000
+)
+
+// FailedEvent - event which could not be delivered to a notification
+// target of this server.
+type FailedEvent struct {
+	ID       string         `json:"id"`
+	TargetID event.TargetID `json:"targetId"`
+	Event    event.Event    `json:"event"`
+	Error    string         `json:"error"`
+	Failed   time.Time      `json:"failed"`
+	Attempts int            `json:"attempts"`
+}
+
+// FailedEventsReplay - outcome of replaying failed events of a server.
+type FailedEventsReplay struct {
+	Replayed int `json:"replayed"`
+	Failed   int `json:"failed"`
+}
+
+// failedEventStore - failed events of this server, oldest first.
+type failedEventStore struct {
+	sync.Mutex
+	objAPI ObjectLayer
+	file   string
+	events []FailedEvent
+}
+
+// getFailedEventsFile - returns the path to the failed events of the node
+// having the given address in minioMetaBucket.
+func getFailedEventsFile(addr string) string {
+	return path.Join(failedEventsPrefix, getSHA256Hash([]byte(addr))+".json")
+}
+
+// load - reads the failed events saved by the node having the given
+// address, events are only kept in memory until load is called.
+func (store *failedEventStore) load(objAPI ObjectLayer, addr string) error {
+	file := getFailedEventsFile(addr)
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{})
+
+	var events []FailedEvent
+	reader, err := readConfig(ctx, objAPI, file)
+	if err == nil {
+		if err = json.NewDecoder(reader).Decode(&events); err != nil {
+			return err
+		}
+	} else if !IsErrIgnored(err, errConfigNotFound, errNoSuchNotifications) {
+		return err
+	}
+
+	store.Lock()
+	defer store.Unlock()
+	store.objAPI, store.file = objAPI, file
+	store.events = append(events, store.events...)
+	store.trim()
+	return nil
+}
+
+// trim - drops the oldest events above maxFailedEvents.
+func (store *failedEventStore) trim() {
+	if dropped := len(store.events) - maxFailedEvents; dropped > 0 {
+		logger.LogIf(context.Background(), fmt.Errorf("dropping %d failed events, more than %d events failed", dropped, maxFailedEvents))
+		store.events = append([]FailedEvent(nil), store.events[dropped:]...)
+	}
+}
+
+// save - persists the failed events, must be called with the store
+// locked.
+func (store *failedEventStore) save() error {
+	if store.objAPI == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(store.events)
+	if err != nil {
+		return err
+	}
+	return saveConfig(store.objAPI, store.file, data)
+}
+
+// add - records events which failed to be delivered.
+func (store *failedEventStore) add(events ...FailedEvent) {
+	store.Lock()
+	defer store.Unlock()
+
+	store.events = append(store.events, events...)
+	store.trim()
+	logger.LogIf(context.Background(), store.save())
+}
+
+// list - returns all failed events, oldest first.
+func (store *failedEventStore) list() []FailedEvent {
+	store.Lock()
+	defer store.Unlock()
+	return append([]FailedEvent(nil), store.events...)
+}
+
+// replay - sends the failed event having the given ID, or all failed
+// events if id is empty, again to their targets using send. Delivered
+// events are removed, once sending to a target fails again remaining
+// events of that target are kept without being sent.
+func (store *failedEventStore) replay(id string, send func(event.Event, event.TargetID) error) (result FailedEventsReplay, err error) {
+	store.Lock()
+	defer store.Unlock()
+
+	downTargets := make(map[event.TargetID]struct{})
+	events := store.events[:0]
+	for _, failedEvent := range store.events {
+		if id != "" && failedEvent.ID != id {
+			events = append(events, failedEvent)
+			continue
+		}
+
+		if _, ok := downTargets[failedEvent.TargetID]; ok {
+			result.Failed++
+			events = append(events, failedEvent)
+			continue
+		}
+
+		failedEvent.Attempts++
+		if err := send(failedEvent.Event, failedEvent.TargetID); err != nil {
+			failedEvent.Error = err.Error()
+			downTargets[failedEvent.TargetID] = struct{}{}
+			result.Failed++
+			events = append(events, failedEvent)
+			continue
+		}
+		result.Replayed++
+	}
+	store.events = events
+
+	if result.Replayed == 0 && result.Failed == 0 {
+		return result, nil
+	}
+	return result, store.save()
+}
+
+// ListFailedEvents - returns the events this server failed to deliver.
+func (sys *NotificationSys) ListFailedEvents() []FailedEvent {
+	return sys.failedEvents.list()
+}
+
+// ReplayFailedEvents - sends the failed event having the given ID, or all
+// failed events if id is empty, again to their targets.
+func (sys *NotificationSys) ReplayFailedEvents(id string) (FailedEventsReplay, error) {
+	return sys.failedEvents.replay(id, func(eventData event.Event, targetID event.TargetID) error {
+		if !sys.targetList.Exists(targetID) {
+			return fmt.Errorf("notification target %s not found", targetID)
+		}
+		if terr, ok := <-sys.targetList.Send(eventData, targetID); ok {
+			return terr.Err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,153 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+// testNotificationTarget - target whose availability can be toggled.
+type testNotificationTarget struct {
+	sync.Mutex
+	id   event.TargetID
+	down bool
+	sent []event.Event
+}
+
+func (target *testNotificationTarget) ID() event.TargetID {
+	return target.id
+}
+
+func (target *testNotificationTarget) Send(eventData event.Event) error {
+	target.Lock()
+	defer target.Unlock()
+	if target.down {
+		return errors.New("target is down")
+	}
+	target.sent = append(target.sent, eventData)
+	return nil
+}
+
+func (target *testNotificationTarget) Close() error {
+	return nil
+}
+
+func (target *testNotificationTarget) setDown(down bool) {
+	target.Lock()
+	target.down = down
+	target.Unlock()
+}
+
+// newTestFailedEventsNotificationSys - returns a notification system
+// sending events to the given target.
+func newTestFailedEventsNotificationSys(t *testing.T, target event.Target) *NotificationSys {
+	sys := &NotificationSys{
+		targetList:                 event.NewTargetList(),
+		bucketRulesMap:             make(map[string]event.RulesMap),
+		bucketRemoteTargetRulesMap: make(map[string]map[event.TargetID]event.RulesMap),
+	}
+	if err := sys.targetList.Add(target); err != nil {
+		t.Fatalf("unable to add target, %s", err)
+	}
+	return sys
+}
+
+func TestNotificationSysFailedEvents(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	target := &testNotificationTarget{id: event.TargetID{ID: "1", Name: "webhook"}, down: true}
+	sys := newTestFailedEventsNotificationSys(t, target)
+	if err = sys.failedEvents.load(objLayer, "127.0.0.1:9000"); err != nil {
+		t.Fatalf("unable to load failed events, %s", err)
+	}
+
+	for _, object := range []string{"object1", "object2"} {
+		eventData := event.Event{EventName: event.ObjectCreatedPut, S3: event.Metadata{Object: event.Object{Key: object}}}
+		if errs := sys.send("bucket", eventData, target.id); len(errs) != 1 {
+			t.Fatalf("expected 1 error sending to a target which is down, got %v", errs)
+		}
+	}
+
+	events := sys.ListFailedEvents()
+	if len(events) != 2 || events[0].Event.S3.Object.Key != "object1" || events[1].Event.S3.Object.Key != "object2" {
+		t.Fatalf("expected failed events of object1 and object2, got %v", events)
+	}
+	if events[0].TargetID != target.id || events[0].Attempts != 1 || events[0].Error == "" {
+		t.Fatalf("unexpected failed event %v", events[0])
+	}
+
+	// Failed events are kept across restarts.
+	restarted := newTestFailedEventsNotificationSys(t, target)
+	if err = restarted.failedEvents.load(objLayer, "127.0.0.1:9000"); err != nil {
+		t.Fatalf("unable to load failed events, %s", err)
+	}
+	if loaded := restarted.ListFailedEvents(); len(loaded) != 2 || loaded[0].ID != events[0].ID || loaded[1].ID != events[1].ID {
+		t.Fatalf("expected failed events %v, got %v", events, loaded)
+	}
+
+	// Replay stops at the first failure of a target.
+	replay, err := sys.ReplayFailedEvents("")
+	if err != nil {
+		t.Fatalf("unable to replay failed events, %s", err)
+	}
+	if replay != (FailedEventsReplay{Failed: 2}) {
+		t.Fatalf("expected 2 failed events, got %v", replay)
+	}
+	if events = sys.ListFailedEvents(); events[0].Attempts != 2 || events[1].Attempts != 1 {
+		t.Fatalf("expected only the first event to be sent again, got %v", events)
+	}
+
+	target.setDown(false)
+	if replay, err = sys.ReplayFailedEvents(events[1].ID); err != nil || replay != (FailedEventsReplay{Replayed: 1}) {
+		t.Fatalf("expected 1 replayed event, got %v, %v", replay, err)
+	}
+	if len(target.sent) != 1 || target.sent[0].S3.Object.Key != "object2" {
+		t.Fatalf("expected event of object2 to be sent, got %v", target.sent)
+	}
+	if replay, err = sys.ReplayFailedEvents(""); err != nil || replay != (FailedEventsReplay{Replayed: 1}) {
+		t.Fatalf("expected 1 replayed event, got %v, %v", replay, err)
+	}
+	if events = sys.ListFailedEvents(); len(events) != 0 {
+		t.Fatalf("expected no failed events, got %v", events)
+	}
+
+	// Events of unknown targets can't be replayed.
+	sys.failedEvents.add(FailedEvent{ID: mustGetUUID(), TargetID: event.TargetID{ID: "2", Name: "amqp"}})
+	if replay, err = sys.ReplayFailedEvents(""); err != nil || replay != (FailedEventsReplay{Failed: 1}) {
+		t.Fatalf("expected 1 failed event, got %v, %v", replay, err)
+	}
+}
+
+func TestFailedEventStoreTrim(t *testing.T) {
+	var store failedEventStore
+	for i := 0; i < maxFailedEvents+2; i++ {
+		store.add(FailedEvent{ID: mustGetUUID()})
+	}
+	events := store.list()
+	if len(events) != maxFailedEvents {
+		t.Fatalf("expected %d failed events, got %d", maxFailedEvents, len(events))
+	}
+}
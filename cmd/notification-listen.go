@@ -0,0 +1,114 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+// Maximum number of recent events of a bucket kept for listeners
+// resuming after a reconnect.
+const maxListenEventLogSize = 1000
+
+// listenEvent - event kept in the listen event log.
+type listenEvent struct {
+	sequencer  uint64
+	objectName string
+	event      event.Event
+}
+
+// listenEventLog - recent events of the buckets listened to on this
+// server. A listener resumes from the sequencer of the last event it
+// received, the events of the bucket since are replayed to it. Events
+// are kept from the first time a bucket is listened to.
+type listenEventLog struct {
+	sync.Mutex
+	bucketEvents map[string][]listenEvent
+}
+
+// parseListenToken - parses the token of a listener resuming, the
+// sequencer of the last event it received.
+func parseListenToken(token string) (uint64, error) {
+	return strconv.ParseUint(token, 16, 64)
+}
+
+// add - converts the event arguments to an event kept in the log, if the
+// bucket is listened to.
+func (log *listenEventLog) add(args eventArgs) (eventData event.Event, ok bool) {
+	if log == nil {
+		return eventData, false
+	}
+
+	log.Lock()
+	defer log.Unlock()
+
+	events, ok := log.bucketEvents[args.BucketName]
+	if !ok {
+		return eventData, false
+	}
+
+	eventData = args.ToEvent()
+	sequencer, err := parseListenToken(eventData.S3.Object.Sequencer)
+	if err != nil {
+		return eventData, true
+	}
+
+	if len(events) == maxListenEventLogSize {
+		events = events[1:]
+	}
+	log.bucketEvents[args.BucketName] = append(events, listenEvent{sequencer, args.Object.Name, eventData})
+	return eventData, true
+}
+
+// listen - registers a listener of the bucket, and returns the events
+// of the bucket after the token matching the rules of the listener.
+// Events added once the listener is registered are sent to it, events
+// added in between may be both returned and sent.
+func (log *listenEventLog) listen(bucketName string, token uint64, rulesMap event.RulesMap, register func() error) ([]event.Event, error) {
+	if log == nil {
+		return nil, register()
+	}
+
+	log.Lock()
+	if _, ok := log.bucketEvents[bucketName]; !ok {
+		log.bucketEvents[bucketName] = []listenEvent{}
+	}
+	log.Unlock()
+
+	if err := register(); err != nil {
+		return nil, err
+	}
+
+	log.Lock()
+	defer log.Unlock()
+
+	replay := []event.Event{}
+	for _, e := range log.bucketEvents[bucketName] {
+		if e.sequencer > token && len(rulesMap.Match(e.event.EventName, e.objectName)) > 0 {
+			replay = append(replay, e.event)
+		}
+	}
+	return replay, nil
+}
+
+// newListenEventLog - creates an empty listen event log.
+func newListenEventLog() *listenEventLog {
+	return &listenEventLog{bucketEvents: make(map[string][]listenEvent)}
+}
@@ -0,0 +1,193 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestListenEventLog(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("Unable to initialize server config. %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	log := newListenEventLog()
+	targetID := event.TargetID{ID: "1", Name: "httpclient"}
+
+	// Events are only kept once the bucket is listened to.
+	if _, ok := log.add(eventArgs{EventName: event.ObjectCreatedPut, BucketName: "bucket", Object: ObjectInfo{Name: "a.jpg"}}); ok {
+		t.Fatalf("Expected the event of a bucket not listened to not to be kept")
+	}
+
+	rulesMap := ListenBucketNotificationArgs{
+		EventNames: []event.Name{event.ObjectCreatedAll},
+		Patterns:   []string{event.NewPattern("images/", ""), event.NewPattern("docs/", "")},
+	}.ToRulesMap(targetID)
+	registered := false
+	replay, err := log.listen("bucket", 0, rulesMap, func() error {
+		registered = true
+		return nil
+	})
+	if err != nil || !registered || len(replay) != 0 {
+		t.Fatalf("Expected the listener to be registered with no events to replay, but instead found %v %v", err, replay)
+	}
+
+	var sequencers []string
+	for _, args := range []eventArgs{
+		{EventName: event.ObjectCreatedPut, BucketName: "bucket", Object: ObjectInfo{Name: "images/a.jpg"}},
+		{EventName: event.ObjectRemovedDelete, BucketName: "bucket", Object: ObjectInfo{Name: "images/a.jpg"}},
+		{EventName: event.ObjectCreatedPut, BucketName: "bucket", Object: ObjectInfo{Name: "other/b.jpg"}},
+		{EventName: event.ObjectCreatedCopy, BucketName: "bucket", Object: ObjectInfo{Name: "docs/c.txt"}},
+		{EventName: event.ObjectCreatedPut, BucketName: "other-bucket", Object: ObjectInfo{Name: "images/d.jpg"}},
+	} {
+		eventData, ok := log.add(args)
+		if ok {
+			sequencers = append(sequencers, eventData.S3.Object.Sequencer)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if len(sequencers) != 4 {
+		t.Fatalf("Expected the 4 events of the bucket to be kept, but instead found %d", len(sequencers))
+	}
+
+	token, err := parseListenToken(sequencers[0])
+	if err != nil {
+		t.Fatalf("Failed to parse the sequencer of an event: <ERROR> %v", err)
+	}
+	testCases := []struct {
+		token        uint64
+		expectedKeys []string
+	}{
+		{0, []string{"images%2Fa.jpg", "docs%2Fc.txt"}},
+		{token, []string{"docs%2Fc.txt"}},
+	}
+	for i, testCase := range testCases {
+		replay, err = log.listen("bucket", testCase.token, rulesMap, func() error { return nil })
+		if err != nil {
+			t.Fatalf("Test %d: Failed to listen: <ERROR> %v", i+1, err)
+		}
+		keys := []string{}
+		for _, eventData := range replay {
+			keys = append(keys, eventData.S3.Object.Key)
+		}
+		if !reflect.DeepEqual(keys, testCase.expectedKeys) {
+			t.Errorf("Test %d: Expected the events of %v, but instead found %v", i+1, testCase.expectedKeys, keys)
+		}
+	}
+
+	// Only the most recent events are kept.
+	for i := 0; i < maxListenEventLogSize; i++ {
+		log.add(eventArgs{EventName: event.ObjectCreatedPut, BucketName: "bucket", Object: ObjectInfo{Name: "other/e.jpg"}})
+	}
+	if replay, _ = log.listen("bucket", 0, rulesMap, func() error { return nil }); len(replay) != 0 {
+		t.Errorf("Expected the oldest events to be dropped, but instead found %d events", len(replay))
+	}
+}
+
+// Wrapper for calling ListenBucketNotification tests for both XL multiple disks and single node setup.
+func TestListenBucketNotificationResume(t *testing.T) {
+	ExecObjectLayerAPITest(t, testListenBucketNotificationResume, []string{"ListenBucketNotification"})
+}
+
+func testListenBucketNotificationResume(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+	tmpGlobalNotificationSys := globalNotificationSys
+	defer func() { globalNotificationSys = tmpGlobalNotificationSys }()
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+
+	ts := httptest.NewServer(apiRouter)
+	defer ts.Close()
+	defer ts.CloseClientConnections()
+	client := http.Client{Timeout: 10 * time.Second}
+
+	type record struct {
+		Records []event.Event
+	}
+	listen := func(values url.Values) (*http.Response, *bufio.Reader) {
+		values["events"] = []string{"s3:ObjectCreated:*"}
+		req, err := newTestRequest("GET", makeTestTargetURL(ts.URL, bucketName, "", values), 0, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("%s: Failed to listen: <ERROR> %v", instanceType, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, resp.StatusCode)
+		}
+		return resp, bufio.NewReader(resp.Body)
+	}
+	readRecord := func(reader *bufio.Reader) record {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("%s: Failed to read a record: <ERROR> %v", instanceType, err)
+		}
+		var r record
+		if err = json.Unmarshal(line, &r); err != nil {
+			t.Fatalf("%s: Failed to parse the record %s: <ERROR> %v", instanceType, line, err)
+		}
+		return r
+	}
+
+	// The keep-alive record of a listener has no events.
+	resp, reader := listen(url.Values{"ping": {"1"}})
+	if r := readRecord(reader); len(r.Records) != 0 {
+		t.Fatalf("%s: Expected a keep-alive record, but instead found %v", instanceType, r)
+	}
+	resp.Body.Close()
+
+	for _, objectName := range []string{"images/a.jpg", "other/b.jpg", "docs/c.jpg", "docs/d.txt"} {
+		sendEvent(eventArgs{EventName: event.ObjectCreatedPut, BucketName: bucketName, Object: ObjectInfo{Name: objectName}})
+		time.Sleep(time.Millisecond)
+	}
+
+	// Events since the token are replayed before the events sent live,
+	// whitespace is written to keep the connection alive without ping.
+	resp, reader = listen(url.Values{"prefix": {"images/", "docs/"}, "suffix": {".jpg"}, "token": {"0"}})
+	defer resp.Body.Close()
+	sendEvent(eventArgs{EventName: event.ObjectCreatedPut, BucketName: bucketName, Object: ObjectInfo{Name: "images/e.jpg"}})
+
+	keys := []string{}
+	for len(keys) < 3 {
+		for _, eventData := range readRecord(reader).Records {
+			keys = append(keys, eventData.S3.Object.Key)
+		}
+	}
+	expectedKeys := []string{"images%2Fa.jpg", "docs%2Fc.jpg", "images%2Fe.jpg"}
+	if !reflect.DeepEqual(keys, expectedKeys) {
+		t.Errorf("%s: Expected the events of %v, but instead found %v", instanceType, expectedKeys, keys)
+	}
+}
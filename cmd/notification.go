@@ -43,6 +43,8 @@ type NotificationSys struct {
 	bucketRulesMap             map[string]event.RulesMap
 	bucketRemoteTargetRulesMap map[string]map[event.TargetID]event.RulesMap
 	peerRPCClientMap           map[xnet.Host]*PeerRPCClient
+	failedEvents               failedEventStore
+	listenEvents               *listenEventLog
 }
 
 // GetARNList - returns available ARNs.
@@ -112,6 +114,60 @@ func (sys *NotificationSys) SetCredentials(credentials auth.Credentials) map[xne
 	return errors
 }
 
+// LoadServiceAccounts - calls LoadServiceAccounts RPC call on all peers.
+func (sys *NotificationSys) LoadServiceAccounts(ctx context.Context) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.LoadServiceAccounts(); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// LoadTempAccount - calls LoadTempAccount RPC call on all peers.
+func (sys *NotificationSys) LoadTempAccount(ctx context.Context, accessKey string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.LoadTempAccount(accessKey); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// LoadTiers - calls LoadTiers RPC call on all peers.
+func (sys *NotificationSys) LoadTiers(ctx context.Context) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.LoadTiers(); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
 // SetBucketPolicy - calls SetBucketPolicy RPC call on all peers.
 func (sys *NotificationSys) SetBucketPolicy(ctx context.Context, bucketName string, bucketPolicy *policy.Policy) {
 	go func() {
@@ -130,6 +186,60 @@ func (sys *NotificationSys) SetBucketPolicy(ctx context.Context, bucketName stri
 	}()
 }
 
+// SetBucketQuota - calls SetBucketQuota RPC call on all peers.
+func (sys *NotificationSys) SetBucketQuota(ctx context.Context, bucketName string, quota BucketQuota) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketQuota(bucketName, quota); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// LoadBucketTargets - calls LoadBucketTargets RPC call on all peers.
+func (sys *NotificationSys) LoadBucketTargets(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.LoadBucketTargets(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// LoadBucketMetadata - calls LoadBucketMetadata RPC call on all peers.
+func (sys *NotificationSys) LoadBucketMetadata(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.LoadBucketMetadata(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
 // RemoveBucketPolicy - calls RemoveBucketPolicy RPC call on all peers.
 func (sys *NotificationSys) RemoveBucketPolicy(ctx context.Context, bucketName string) {
 	go func() {
@@ -167,7 +277,7 @@ func (sys *NotificationSys) PutBucketNotification(ctx context.Context, bucketNam
 }
 
 // ListenBucketNotification - calls ListenBucketNotification RPC call on all peers.
-func (sys *NotificationSys) ListenBucketNotification(ctx context.Context, bucketName string, eventNames []event.Name, pattern string,
+func (sys *NotificationSys) ListenBucketNotification(ctx context.Context, bucketName string, eventNames []event.Name, patterns []string,
 	targetID event.TargetID, localPeer xnet.Host) {
 	go func() {
 		var wg sync.WaitGroup
@@ -175,7 +285,7 @@ func (sys *NotificationSys) ListenBucketNotification(ctx context.Context, bucket
 			wg.Add(1)
 			go func(addr xnet.Host, client *PeerRPCClient) {
 				defer wg.Done()
-				if err := client.ListenBucketNotification(bucketName, eventNames, pattern, targetID, localPeer); err != nil {
+				if err := client.ListenBucketNotification(bucketName, eventNames, patterns, targetID, localPeer); err != nil {
 					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
 					logger.LogIf(ctx, err)
 				}
@@ -286,7 +396,7 @@ func (sys *NotificationSys) initListeners(ctx context.Context, objAPI ObjectLaye
 		}
 
 		target := NewPeerRPCClientTarget(bucketName, args.TargetID, rpcClient)
-		rulesMap := event.NewRulesMap(args.EventNames, args.Pattern, target.ID())
+		rulesMap := args.ToRulesMap(target.ID())
 		if err = sys.AddRemoteTarget(bucketName, target, rulesMap); err != nil {
 			logger.GetReqInfo(ctx).AppendTags("targetName", target.id.Name)
 			logger.LogIf(ctx, err)
@@ -304,7 +414,8 @@ func (sys *NotificationSys) initListeners(ctx context.Context, objAPI ObjectLaye
 	return saveConfig(objAPI, configFile, data)
 }
 
-// Init - initializes notification system from notification.xml and listener.json of all buckets.
+// Init - initializes notification system from notification.xml and listener.json of all buckets
+// and loads events which failed to be delivered before.
 func (sys *NotificationSys) Init(objAPI ObjectLayer) error {
 	if objAPI == nil {
 		return errInvalidArgument
@@ -331,7 +442,7 @@ func (sys *NotificationSys) Init(objAPI ObjectLayer) error {
 		}
 	}
 
-	return nil
+	return sys.failedEvents.load(objAPI, GetLocalPeer(globalEndpoints))
 }
 
 // AddRulesMap - adds rules map for bucket name.
@@ -404,12 +515,28 @@ func (sys *NotificationSys) RemoveRemoteTarget(bucketName string, targetID event
 }
 
 func (sys *NotificationSys) send(bucketName string, eventData event.Event, targetIDs ...event.TargetID) (errs []event.TargetIDErr) {
+	var failedEvents []FailedEvent
 	errCh := sys.targetList.Send(eventData, targetIDs...)
 	for terr := range errCh {
 		errs = append(errs, terr)
 		if sys.RemoteTargetExist(bucketName, terr.ID) {
 			sys.RemoveRemoteTarget(bucketName, terr.ID)
+			continue
 		}
+
+		// Keep events of configured targets to be replayed later.
+		failedEvents = append(failedEvents, FailedEvent{
+			ID:       mustGetUUID(),
+			TargetID: terr.ID,
+			Event:    eventData,
+			Error:    terr.Err.Error(),
+			Failed:   UTCNow(),
+			Attempts: 1,
+		})
+	}
+
+	if len(failedEvents) > 0 {
+		sys.failedEvents.add(failedEvents...)
 	}
 
 	return errs
@@ -417,6 +544,10 @@ func (sys *NotificationSys) send(bucketName string, eventData event.Event, targe
 
 // Send - sends event data to all matching targets.
 func (sys *NotificationSys) Send(args eventArgs) []event.TargetIDErr {
+	// Events of buckets listened to are logged before they are sent,
+	// for listeners resuming after a reconnect.
+	eventData, ok := sys.listenEvents.add(args)
+
 	sys.RLock()
 	targetIDSet := sys.bucketRulesMap[args.BucketName].Match(args.EventName, args.Object.Name)
 	sys.RUnlock()
@@ -424,8 +555,11 @@ func (sys *NotificationSys) Send(args eventArgs) []event.TargetIDErr {
 		return nil
 	}
 
+	if !ok {
+		eventData = args.ToEvent()
+	}
 	targetIDs := targetIDSet.ToSlice()
-	return sys.send(args.BucketName, args.ToEvent(), targetIDs...)
+	return sys.send(args.BucketName, eventData, targetIDs...)
 }
 
 // NewNotificationSys - creates new notification system object.
@@ -439,6 +573,7 @@ func NewNotificationSys(config *serverConfig, endpoints EndpointList) *Notificat
 		bucketRulesMap:             make(map[string]event.RulesMap),
 		bucketRemoteTargetRulesMap: make(map[string]map[event.TargetID]event.RulesMap),
 		peerRPCClientMap:           peerRPCClientMap,
+		listenEvents:               newListenEventLog(),
 	}
 }
 
@@ -501,11 +636,25 @@ func (args eventArgs) ToEvent() event.Event {
 		},
 	}
 
+	if args.Object.VersionID != "" {
+		newEvent.S3.Object.VersionID = args.Object.VersionID
+	}
+
 	if args.EventName != event.ObjectRemovedDelete {
 		newEvent.S3.Object.ETag = args.Object.ETag
 		newEvent.S3.Object.Size = args.Object.Size
 		newEvent.S3.Object.ContentType = args.Object.ContentType
-		newEvent.S3.Object.UserMetadata = args.Object.UserDefined
+		newEvent.S3.Object.UserMetadata = make(map[string]string)
+		for k, v := range args.Object.UserDefined {
+			// Internal metadata values are not sent to targets.
+			if hasPrefix(k, ReservedMetadataPrefix) {
+				continue
+			}
+			newEvent.S3.Object.UserMetadata[k] = v
+		}
+		if _, ok := args.Object.UserDefined[objectTaggingKey]; ok {
+			newEvent.S3.Object.UserTags = getObjectTags(args.Object.UserDefined).ToMap()
+		}
 	}
 
 	return newEvent
@@ -576,9 +725,16 @@ func readNotificationConfig(ctx context.Context, objAPI ObjectLayer, bucketName
 		return nil, err
 	}
 
-	config, err := event.ParseConfig(reader, globalServerConfig.GetRegion(), globalNotificationSys.targetList)
-	logger.LogIf(ctx, err)
-	return config, err
+	var config event.Config
+	if err = xml.NewDecoder(reader).Decode(&config); err != nil {
+		logger.LogIf(ctx, err)
+		return nil, err
+	}
+
+	// Queue configurations of targets no longer configured on the
+	// server are ignored, they are kept until the configuration is
+	// replaced.
+	return config.ActiveConfig(globalServerConfig.GetRegion(), globalNotificationSys.targetList), nil
 }
 
 func saveNotificationConfig(objAPI ObjectLayer, bucketName string, config *event.Config) error {
@@ -592,7 +748,7 @@ func saveNotificationConfig(objAPI ObjectLayer, bucketName string, config *event
 }
 
 // SaveListener - saves HTTP client currently listening for events to listener.json.
-func SaveListener(objAPI ObjectLayer, bucketName string, eventNames []event.Name, pattern string, targetID event.TargetID, addr xnet.Host) error {
+func SaveListener(objAPI ObjectLayer, bucketName string, eventNames []event.Name, patterns []string, targetID event.TargetID, addr xnet.Host) error {
 	// listener.json is available/applicable only in DistXL mode.
 	if !globalIsDistXL {
 		return nil
@@ -628,7 +784,8 @@ func SaveListener(objAPI ObjectLayer, bucketName string, eventNames []event.Name
 
 	listenerList = append(listenerList, ListenBucketNotificationArgs{
 		EventNames: eventNames,
-		Pattern:    pattern,
+		Pattern:    patterns[0],
+		Patterns:   patterns,
 		TargetID:   targetID,
 		Addr:       addr,
 	})
@@ -0,0 +1,72 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+// Tests that events carry the version, user metadata and tags of objects.
+func TestEventArgsToEvent(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("Unable to initialize server config. %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objInfo := ObjectInfo{
+		Name:      "a.jpg",
+		VersionID: "e0b6b2d3-0c4f-4d8e-a0b5-5b3c8c6e2d41",
+		UserDefined: map[string]string{
+			"content-type":             "image/jpeg",
+			"X-Amz-Meta-Camera":        "nikon",
+			objectTaggingKey:           "project=alpha&team=web",
+			objectReplicationStatusKey: "PENDING",
+		},
+	}
+	testCases := []struct {
+		eventName            event.Name
+		object               ObjectInfo
+		expectedVersionID    string
+		expectedUserMetadata map[string]string
+		expectedUserTags     map[string]string
+	}{
+		{event.ObjectCreatedPut, objInfo, objInfo.VersionID,
+			map[string]string{"content-type": "image/jpeg", "X-Amz-Meta-Camera": "nikon"},
+			map[string]string{"project": "alpha", "team": "web"}},
+		{event.ObjectCreatedPut, ObjectInfo{Name: "a.jpg", UserDefined: map[string]string{"content-type": "image/jpeg"}}, "1",
+			map[string]string{"content-type": "image/jpeg"}, nil},
+		{event.ObjectRemovedDelete, objInfo, objInfo.VersionID, nil, nil},
+	}
+
+	for i, testCase := range testCases {
+		newEvent := eventArgs{EventName: testCase.eventName, BucketName: "bucket", Object: testCase.object}.ToEvent()
+		if newEvent.S3.Object.VersionID != testCase.expectedVersionID {
+			t.Errorf("Test %d: Expected version ID %s, but instead found %s", i+1, testCase.expectedVersionID, newEvent.S3.Object.VersionID)
+		}
+		if !reflect.DeepEqual(newEvent.S3.Object.UserMetadata, testCase.expectedUserMetadata) {
+			t.Errorf("Test %d: Expected user metadata %v, but instead found %v", i+1, testCase.expectedUserMetadata, newEvent.S3.Object.UserMetadata)
+		}
+		if !reflect.DeepEqual(newEvent.S3.Object.UserTags, testCase.expectedUserTags) {
+			t.Errorf("Test %d: Expected user tags %v, but instead found %v", i+1, testCase.expectedUserTags, newEvent.S3.Object.UserTags)
+		}
+	}
+}
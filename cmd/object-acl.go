@@ -0,0 +1,355 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/policy"
+)
+
+const (
+	// Canned ACLs supported by PutObjectACL, they are mapped onto
+	// statements of the bucket policy.
+	objectACLPrivate    = "private"
+	objectACLPublicRead = "public-read"
+
+	// Grantee URI of anonymous users.
+	allUsersGranteeURI = "http://acs.amazonaws.com/groups/global/AllUsers"
+
+	// Maximum size of an access control policy in a put-object-acl request.
+	maxObjectACLSize = 64 * 1024
+
+	// Request header giving the canned ACL of a put-object-acl request
+	// or of an upload.
+	amzACL = "x-amz-acl"
+
+	// Statement ID marking the bucket policy statements of object ACLs.
+	objectACLStatementID = "ObjectACL"
+
+	// Canned ACL requested when initiating a multipart upload, applied once
+	// the upload is completed.
+	objectACLKey = ReservedMetadataPrefix + "Acl"
+)
+
+// errPolicyTooLarge - the bucket policy would exceed maxBucketPolicySize
+// with the statement of one more object ACL.
+var errPolicyTooLarge = errors.New("Bucket policy exceeds the maximum allowed size")
+
+// getCannedACL - returns the supported canned ACL of the x-amz-acl header
+// of a put-object-acl request, private if none. As the bucket
+// owner is the only account, the bucket-owner-* canned ACLs are the
+// private one.
+func getCannedACL(acl string) (string, APIErrorCode) {
+	switch acl {
+	case "", objectACLPrivate, "bucket-owner-read", "bucket-owner-full-control":
+		return objectACLPrivate, ErrNone
+	case objectACLPublicRead:
+		return acl, ErrNone
+	}
+	return "", ErrNotImplemented
+}
+
+// hasGrantHeader - returns whether any x-amz-grant-* header is set, ACL
+// grants other than the ones of the canned ACLs are not supported.
+func hasGrantHeader(h http.Header) bool {
+	for header := range h {
+		if strings.HasPrefix(strings.ToLower(header), "x-amz-grant-") {
+			return true
+		}
+	}
+	return false
+}
+
+// getObjectACL - returns the canned ACL requested by the x-amz-acl header or
+// by the access control policy in the request body. Only grants of the
+// private and public-read canned ACLs are supported.
+func getObjectACL(r *http.Request) (string, APIErrorCode) {
+	if hasGrantHeader(r.Header) {
+		return "", ErrNotImplemented
+	}
+
+	if acl := r.Header.Get(amzACL); acl != "" {
+		return getCannedACL(acl)
+	}
+
+	// An access control policy always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		return "", ErrMissingContentLength
+	}
+
+	if r.ContentLength > maxObjectACLSize {
+		return "", ErrEntityTooLarge
+	}
+
+	var aclPolicy accessControlPolicy
+	if err := xmlDecoder(r.Body, &aclPolicy, r.ContentLength); err != nil {
+		return "", ErrMalformedXML
+	}
+
+	acl := objectACLPrivate
+	for _, grant := range aclPolicy.AccessControlList.Grants {
+		switch {
+		case grant.Grantee.URI == "" && grant.Permission == "FULL_CONTROL":
+			// Grant of the owner.
+		case grant.Grantee.URI == allUsersGranteeURI && grant.Permission == "READ":
+			acl = objectACLPublicRead
+		default:
+			return "", ErrNotImplemented
+		}
+	}
+
+	return acl, ErrNone
+}
+
+// newObjectACLStatement - returns the bucket policy statement of the
+// public-read ACL of an object.
+func newObjectACLStatement(bucket, object string) policy.Statement {
+	statement := policy.NewStatement(
+		policy.Allow,
+		policy.NewPrincipal("*"),
+		policy.NewActionSet(policy.GetObjectAction),
+		policy.NewResourceSet(policy.NewResource(bucket, object)),
+		nil,
+	)
+	statement.SID = objectACLStatementID
+	return statement
+}
+
+// isObjectACLStatement - returns whether the statement is the one of the
+// public-read ACL of the object.
+func isObjectACLStatement(statement policy.Statement, bucket, object string) bool {
+	_, ok := statement.Resources[policy.NewResource(bucket, object)]
+	return ok &&
+		statement.SID == objectACLStatementID &&
+		statement.Effect == policy.Allow &&
+		len(statement.Principal.AWS) == 1 && statement.Principal.AWS.Contains("*") &&
+		len(statement.Actions) == 1 && statement.Actions.Contains(policy.GetObjectAction) &&
+		len(statement.Resources) == 1 &&
+		len(statement.Conditions) == 0
+}
+
+// setObjectACL - returns the bucket policy with the statement of the
+// public-read ACL of the object added or removed as per the given ACL,
+// and whether it differs from the given one. Other statements are kept,
+// so an object may remain readable by anonymous users after setting the
+// private ACL.
+func setObjectACL(bucketPolicy *policy.Policy, bucket, object, acl string) (*policy.Policy, bool) {
+	newPolicy := &policy.Policy{Version: policy.DefaultVersion}
+	if bucketPolicy != nil {
+		newPolicy.ID = bucketPolicy.ID
+		newPolicy.Version = bucketPolicy.Version
+	}
+
+	var found, changed bool
+	if bucketPolicy != nil {
+		for _, statement := range bucketPolicy.Statements {
+			if isObjectACLStatement(statement, bucket, object) {
+				if acl == objectACLPrivate || found {
+					changed = true
+					continue
+				}
+				found = true
+			}
+			newPolicy.Statements = append(newPolicy.Statements, statement)
+		}
+	}
+
+	if acl == objectACLPublicRead && !found {
+		newPolicy.Statements = append(newPolicy.Statements, newObjectACLStatement(bucket, object))
+		changed = true
+	}
+
+	return newPolicy, changed
+}
+
+// hasObjectACL - returns whether the cached bucket policy of given bucket
+// name holds the statement of the public-read ACL of the object.
+func (sys *PolicySys) hasObjectACL(bucketName, object string) bool {
+	if sys == nil {
+		return false
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	for _, statement := range sys.bucketPolicyMap[bucketName].Statements {
+		if isObjectACLStatement(statement, bucketName, object) {
+			return true
+		}
+	}
+	return false
+}
+
+// objectACLUpdate - change of the bucket policy setting the canned ACL of
+// an object. It is prepared with the bucket policy lock held before the
+// object is written, so that the object is only stored when its ACL fits
+// in the bucket policy, and applied once the object is written.
+type objectACLUpdate struct {
+	bucket       string
+	bucketPolicy *policy.Policy
+	policyLock   RWLocker
+}
+
+// newObjectACLUpdate - takes the bucket policy lock and prepares the
+// change of the bucket policy setting the canned ACL of an object. As it
+// is done for every upload and removal of an object, the bucket policy
+// is only read when it may need to be changed. The returned update must
+// be released by calling Unlock.
+func newObjectACLUpdate(ctx context.Context, objAPI ObjectLayer, bucket, object, acl string) (*objectACLUpdate, error) {
+	u := &objectACLUpdate{bucket: bucket}
+	if acl == objectACLPrivate && !globalPolicySys.hasObjectACL(bucket, object) {
+		return u, nil
+	}
+
+	policyLock := getBucketPolicyLock(bucket)
+	if err := policyLock.GetLock(globalOperationTimeout); err != nil {
+		return nil, err
+	}
+
+	bucketPolicy, err := objAPI.GetBucketPolicy(ctx, bucket)
+	if err != nil {
+		if _, ok := err.(BucketPolicyNotFound); !ok {
+			policyLock.Unlock()
+			return nil, err
+		}
+	}
+
+	bucketPolicy, changed := setObjectACL(bucketPolicy, bucket, object, acl)
+	if !changed {
+		policyLock.Unlock()
+		return u, nil
+	}
+
+	// Every object ACL adds a statement, keep the bucket policy within
+	// the size accepted by PutBucketPolicy.
+	if !bucketPolicy.IsEmpty() {
+		data, err := json.Marshal(bucketPolicy)
+		if err != nil {
+			policyLock.Unlock()
+			return nil, err
+		}
+		if len(data) > maxBucketPolicySize {
+			policyLock.Unlock()
+			return nil, errPolicyTooLarge
+		}
+	}
+
+	u.bucketPolicy = bucketPolicy
+	u.policyLock = policyLock
+	return u, nil
+}
+
+// Apply - saves the prepared bucket policy, it is removed once it has no
+// statements anymore.
+func (u *objectACLUpdate) Apply(ctx context.Context, objAPI ObjectLayer) error {
+	if u.bucketPolicy == nil {
+		return nil
+	}
+
+	// Keep the replaced policy to be able to restore it.
+	logger.LogIf(ctx, addBucketPolicyVersion(ctx, objAPI, u.bucket))
+
+	if u.bucketPolicy.IsEmpty() {
+		if err := objAPI.DeleteBucketPolicy(ctx, u.bucket); err != nil {
+			if _, ok := err.(BucketPolicyNotFound); !ok {
+				return err
+			}
+		}
+
+		globalPolicySys.Remove(u.bucket)
+		globalNotificationSys.RemoveBucketPolicy(ctx, u.bucket)
+		return nil
+	}
+
+	if err := objAPI.SetBucketPolicy(ctx, u.bucket, u.bucketPolicy); err != nil {
+		return err
+	}
+
+	globalPolicySys.Set(u.bucket, *u.bucketPolicy)
+	globalNotificationSys.SetBucketPolicy(ctx, u.bucket, u.bucketPolicy)
+	return nil
+}
+
+// Unlock - releases the bucket policy lock taken by newObjectACLUpdate.
+func (u *objectACLUpdate) Unlock() {
+	if u.policyLock != nil {
+		u.policyLock.Unlock()
+		u.policyLock = nil
+	}
+}
+
+// updateObjectACL - sets the canned ACL of an object in the bucket policy
+// of its bucket.
+func updateObjectACL(ctx context.Context, objAPI ObjectLayer, bucket, object, acl string) error {
+	u, err := newObjectACLUpdate(ctx, objAPI, bucket, object, acl)
+	if err != nil {
+		return err
+	}
+	defer u.Unlock()
+
+	return u.Apply(ctx, objAPI)
+}
+
+// getUploadCannedACL - returns the canned ACL of an upload requested by
+// the x-amz-acl header or the acl field of a POST upload. Canned ACLs
+// other than public-read are ignored and the object is private, as it
+// was before object ACLs were supported.
+func getUploadCannedACL(acl string) string {
+	if acl == objectACLPublicRead {
+		return objectACLPublicRead
+	}
+	return objectACLPrivate
+}
+
+// getUploadACL - returns the canned ACL requested by the x-amz-acl header
+// of an upload or copy request. Making the object readable by anonymous
+// users requires the permission to set the bucket policy, like
+// PutObjectACL.
+func getUploadACL(r *http.Request, bucket, object string) (string, APIErrorCode) {
+	acl := getUploadCannedACL(r.Header.Get(amzACL))
+	if acl != objectACLPublicRead {
+		return acl, ErrNone
+	}
+
+	// Wildcards of the object name would match other objects in the
+	// bucket policy.
+	if strings.ContainsAny(object, "*?") {
+		return "", ErrNotImplemented
+	}
+
+	if !isObjectActionAllowed(r, policy.PutBucketPolicyAction, bucket, "") {
+		return "", ErrAccessDenied
+	}
+	return acl, ErrNone
+}
+
+// isObjectPublicRead - returns whether the object is unconditionally
+// readable by anonymous users as per the bucket policy.
+func isObjectPublicRead(bucket, object string) bool {
+	return globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ConditionValues: map[string][]string{},
+		ObjectName:      object,
+	})
+}
@@ -87,11 +87,29 @@ func deleteBucketMetadata(ctx context.Context, bucket string, objAPI ObjectLayer
 	// Delete bucket access policy, if present - ignore any errors.
 	removePolicyConfig(ctx, objAPI, bucket)
 
+	// Delete prior bucket access policies, if present - ignore any errors.
+	removeBucketPolicyHistory(ctx, objAPI, bucket)
+
 	// Delete notification config, if present - ignore any errors.
 	removeNotificationConfig(ctx, objAPI, bucket)
 
 	// Delete listener config, if present - ignore any errors.
 	removeListenerConfig(ctx, objAPI, bucket)
+
+	// Delete quota config, if present - ignore any errors.
+	removeBucketQuotaConfig(ctx, objAPI, bucket)
+
+	// Delete remote targets config, if present - ignore any errors.
+	removeBucketTargetsConfig(ctx, objAPI, bucket)
+
+	// Delete metadata index, if present - ignore any errors.
+	removeBucketMetadataIndex(ctx, objAPI, bucket)
+
+	// Delete object count, if present - ignore any errors.
+	removeBucketObjectCount(ctx, objAPI, bucket)
+
+	// Delete bucket metadata configs, e.g. versioning, if present - ignore any errors.
+	removeBucketMetadataConfigs(ctx, objAPI, bucket)
 }
 
 // Depending on the disk type network or local, initialize storage API.
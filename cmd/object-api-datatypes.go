@@ -111,6 +111,15 @@ type ObjectInfo struct {
 	metadataOnly bool
 	// Date and time when the object was last accessed.
 	AccTime time.Time
+
+	// Version ID of the object in a versioned bucket.
+	VersionID string
+
+	// IsLatest indicates if this is the latest version of the object.
+	IsLatest bool
+
+	// DeleteMarker indicates if this version is a delete marker.
+	DeleteMarker bool
 }
 
 // ListPartsInfo - represents list of all parts.
@@ -244,6 +253,27 @@ type ListObjectsV2Info struct {
 	Prefixes []string
 }
 
+// ListObjectVersionsInfo - container for list object versions.
+type ListObjectVersionsInfo struct {
+	// Indicates whether the returned list object versions response is
+	// truncated. The list can be truncated if the number of versions
+	// exceeds the limit allowed or specified by max keys.
+	IsTruncated bool
+
+	// When response is truncated, you can use the key name and version ID
+	// in these fields as key marker and version ID marker in the subsequent
+	// request to get next set of versions.
+	NextKeyMarker       string
+	NextVersionIDMarker string
+
+	// List of versions and delete markers for this request, the versions
+	// of each object are ordered newest first.
+	Objects []ObjectInfo
+
+	// List of prefixes for this request.
+	Prefixes []string
+}
+
 // PartInfo - represents individual part metadata.
 type PartInfo struct {
 	// Part number that identifies the part. This is a positive integer between
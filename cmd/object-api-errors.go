@@ -161,6 +161,69 @@ func (e ObjectNotFound) Error() string {
 	return "Object not found: " + e.Bucket + "#" + e.Object
 }
 
+// VersionNotFound version of an object does not exist.
+type VersionNotFound struct {
+	Bucket    string
+	Object    string
+	VersionID string
+}
+
+func (e VersionNotFound) Error() string {
+	return "Version not found: " + e.Bucket + "#" + e.Object + " (" + e.VersionID + ")"
+}
+
+// MethodNotAllowed method is not allowed on the object, e.g. reading a
+// delete marker.
+type MethodNotAllowed GenericError
+
+func (e MethodNotAllowed) Error() string {
+	return "Method not allowed: " + e.Bucket + "#" + e.Object
+}
+
+// ObjectLocked object version is protected by object lock and
+// can't be deleted or overwritten.
+type ObjectLocked GenericError
+
+func (e ObjectLocked) Error() string {
+	return "Object is WORM protected and cannot be overwritten: " + e.Bucket + "#" + e.Object
+}
+
+// PreconditionFailed object exists while it was asked to be created only
+// if it does not.
+type PreconditionFailed GenericError
+
+func (e PreconditionFailed) Error() string {
+	return "At least one of the pre-conditions you specified did not hold: " + e.Bucket + "#" + e.Object
+}
+
+// InvalidAppendPosition data can only be appended at the end of an
+// object, at its size.
+type InvalidAppendPosition struct {
+	Bucket string
+	Object string
+	Size   int64
+}
+
+func (e InvalidAppendPosition) Error() string {
+	return fmt.Sprintf("Data can only be appended at position %d of %s#%s", e.Size, e.Bucket, e.Object)
+}
+
+// TooManyAppendedParts data can't be appended to an object already
+// having the maximum number of parts.
+type TooManyAppendedParts GenericError
+
+func (e TooManyAppendedParts) Error() string {
+	return fmt.Sprintf("Object %s#%s already has the maximum of %d parts", e.Bucket, e.Object, globalMaxPartID)
+}
+
+// ObjectNotTransitioned object version is stored locally, it can't be
+// restored from a tier.
+type ObjectNotTransitioned GenericError
+
+func (e ObjectNotTransitioned) Error() string {
+	return "Object is not transitioned to a tier: " + e.Bucket + "#" + e.Object
+}
+
 // ObjectAlreadyExists object already exists.
 type ObjectAlreadyExists GenericError
 
@@ -223,6 +286,27 @@ func (e BucketPolicyNotFound) Error() string {
 	return "No bucket policy found for bucket: " + e.Bucket
 }
 
+// BucketQuotaConfigNotFound - no bucket quota found.
+type BucketQuotaConfigNotFound GenericError
+
+func (e BucketQuotaConfigNotFound) Error() string {
+	return "No quota config found for bucket: " + e.Bucket
+}
+
+// BucketRemoteTargetNotFound - no remote target found.
+type BucketRemoteTargetNotFound GenericError
+
+func (e BucketRemoteTargetNotFound) Error() string {
+	return "No remote target found for bucket: " + e.Bucket
+}
+
+// BucketQuotaExceeded - upload exceeds the bucket quota.
+type BucketQuotaExceeded GenericError
+
+func (e BucketQuotaExceeded) Error() string {
+	return "Bucket quota exceeded for bucket: " + e.Bucket
+}
+
 /// Bucket related errors.
 
 // BucketNameInvalid - bucketname provided is invalid.
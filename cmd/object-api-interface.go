@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/madmin"
@@ -45,6 +46,29 @@ type ObjectLayer interface {
 	PutObject(ctx context.Context, bucket, object string, data *hash.Reader, metadata map[string]string) (objInfo ObjectInfo, err error)
 	CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo) (objInfo ObjectInfo, err error)
 	DeleteObject(ctx context.Context, bucket, object string) error
+	AppendObject(ctx context.Context, bucket, object string, position int64, data *hash.Reader, metadata map[string]string) (objInfo ObjectInfo, err error)
+
+	// Versioning operations.
+	GetObjectVersion(ctx context.Context, bucket, object, versionID string, startOffset int64, length int64, writer io.Writer, etag string) (err error)
+	GetObjectVersionInfo(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error)
+	PutDeleteMarker(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error)
+	DeleteObjectVersion(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error)
+	ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error)
+
+	// Object lock operations.
+	PutObjectRetention(ctx context.Context, bucket, object, versionID string, retention ObjectRetention) (objInfo ObjectInfo, err error)
+	PutObjectLegalHold(ctx context.Context, bucket, object, versionID string, legalHold ObjectLegalHold) (objInfo ObjectInfo, err error)
+
+	// Object tagging operations.
+	PutObjectTags(ctx context.Context, bucket, object, versionID, tags string) (objInfo ObjectInfo, err error)
+	UpdateObjectTags(ctx context.Context, bucket, object, versionID string, updateFn func(tags string) (string, error)) (objInfo ObjectInfo, err error)
+
+	// Object transition operations.
+	TransitionObject(ctx context.Context, bucket, object, versionID string, transition ObjectTransition) (objInfo ObjectInfo, err error)
+	RestoreObject(ctx context.Context, bucket, object, versionID string, expiry time.Time) (objInfo ObjectInfo, err error)
+
+	// Object replication operations.
+	PutObjectReplicationStatus(ctx context.Context, bucket, object, versionID, etag string, status ReplicationStatus) (objInfo ObjectInfo, err error)
 
 	// Multipart operations.
 	ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error)
@@ -72,4 +96,14 @@ type ObjectLayer interface {
 	// Supported operations check
 	IsNotificationSupported() bool
 	IsEncryptionSupported() bool
+	IsVersioningSupported() bool
+	IsTaggingSupported() bool
+	IsLifecycleSupported() bool
+	IsTransitionSupported() bool
+	IsReplicationSupported() bool
+	IsCorsSupported() bool
+	IsInventorySupported() bool
+	IsWebsiteSupported() bool
+	IsLoggingSupported() bool
+	IsAppendSupported() bool
 }
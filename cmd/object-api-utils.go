@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"crypto/md5"
 	"encoding/hex"
 	"fmt"
 	"math/rand"
@@ -177,7 +178,9 @@ func mustGetUUID() string {
 	return uuid.String()
 }
 
-// Create an s3 compatible MD5sum for complete multipart transaction.
+// Create an s3 compatible MD5sum for complete multipart transaction,
+// the MD5 sum of the MD5 sums of the parts followed by the number of
+// parts. The ETag of every part must be a MD5 sum.
 func getCompleteMultipartMD5(ctx context.Context, parts []CompletePart) (string, error) {
 	var finalMD5Bytes []byte
 	for _, part := range parts {
@@ -186,6 +189,10 @@ func getCompleteMultipartMD5(ctx context.Context, parts []CompletePart) (string,
 			logger.LogIf(ctx, err)
 			return "", err
 		}
+		if len(md5Bytes) != md5.Size {
+			logger.LogIf(ctx, InvalidPart{})
+			return "", InvalidPart{}
+		}
 		finalMD5Bytes = append(finalMD5Bytes, md5Bytes...)
 	}
 	s3MD5 := fmt.Sprintf("%s-%d", getMD5Hash(finalMD5Bytes), len(parts))
@@ -141,6 +141,9 @@ func TestGetCompleteMultipartMD5(t *testing.T) {
 		// Wrong MD5 hash string
 		{[]CompletePart{{ETag: "wrong-md5-hash-string"}}, "", "encoding/hex: invalid byte: U+0077 'w'"},
 
+		// ETag which is not a MD5 hash string.
+		{[]CompletePart{{ETag: "cf1f738a5924e645913c984e0fe3d708cf1f738a"}}, "", InvalidPart{}.Error()},
+
 		// Single CompletePart with valid MD5 hash string.
 		{[]CompletePart{{ETag: "cf1f738a5924e645913c984e0fe3d708"}}, "10dc1617fbcf0bd0858048cb96e6bd77-1", ""},
 
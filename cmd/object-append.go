@@ -0,0 +1,93 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/pkg/hash"
+)
+
+// Minio extension request header of PutObject, the data of the request
+// is appended to the object if its size is the given position. An object
+// is created if it does not exist and the position is 0.
+const minioAppendPosition = "X-Minio-Append-Position"
+
+// getAppendPosition - returns the append position of a PutObject
+// request, -1 if the data is not to be appended.
+func getAppendPosition(header http.Header) (int64, APIErrorCode) {
+	value, ok := header[minioAppendPosition]
+	if !ok {
+		return -1, ErrNone
+	}
+	if len(value) != 1 {
+		return -1, ErrInvalidAppendPosition
+	}
+	position, err := strconv.ParseInt(value[0], 10, 64)
+	if err != nil || position < 0 {
+		return -1, ErrInvalidAppendPosition
+	}
+	return position, ErrNone
+}
+
+// checkAppendObject - returns an error if data may not be appended to
+// the object at the position. Only the data of plain objects stored on
+// the backend is appended to, the current version of versioned objects
+// is not modified in place.
+func checkAppendObject(objInfo ObjectInfo, position int64) error {
+	if objInfo.IsDir || objInfo.IsEncrypted() || objInfo.UserDefined[objectVersionIDKey] != "" {
+		return NotImplemented{}
+	}
+	if _, ok := getObjectTransition(objInfo.UserDefined); ok {
+		return NotImplemented{}
+	}
+	if err := checkObjectLocked(objInfo); err != nil {
+		return err
+	}
+	if objInfo.Size != position {
+		return InvalidAppendPosition{Bucket: objInfo.Bucket, Object: objInfo.Name, Size: objInfo.Size}
+	}
+	return nil
+}
+
+// getAppendETag - returns the ETag of an object once data is appended
+// to it, in the multipart ETag format as the object has a part per
+// append. It is the MD5 sum of the ETag of the object and the MD5 sum
+// of the appended data, followed by the number of parts.
+func getAppendETag(etag string, data *hash.Reader, partsCount int) string {
+	if i := strings.Index(etag, "-"); i >= 0 {
+		etag = etag[:i]
+	}
+	sum, err := hex.DecodeString(etag)
+	if err != nil {
+		sum = []byte(etag)
+	}
+	md5Sum := md5.Sum(append(sum, data.MD5Current()...))
+	return hex.EncodeToString(md5Sum[:]) + "-" + strconv.Itoa(partsCount)
+}
+
+// setAppendObjectMeta - updates the metadata of an object once data is
+// appended to it. The additional checksum of the object is removed,
+// it is not the checksum of the whole object anymore.
+func setAppendObjectMeta(metadata map[string]string, data *hash.Reader, partsCount int) {
+	metadata["etag"] = getAppendETag(metadata["etag"], data, partsCount)
+	delete(metadata, objectChecksumKey)
+}
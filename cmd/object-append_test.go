@@ -0,0 +1,182 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestGetAppendPosition(t *testing.T) {
+	testCases := []struct {
+		values           []string
+		expectedPosition int64
+		expectedErr      APIErrorCode
+	}{
+		{nil, -1, ErrNone},
+		{[]string{"0"}, 0, ErrNone},
+		{[]string{"1024"}, 1024, ErrNone},
+		{[]string{"-1"}, -1, ErrInvalidAppendPosition},
+		{[]string{"a"}, -1, ErrInvalidAppendPosition},
+		{[]string{"1", "2"}, -1, ErrInvalidAppendPosition},
+	}
+	for i, testCase := range testCases {
+		header := http.Header{}
+		if testCase.values != nil {
+			header[minioAppendPosition] = testCase.values
+		}
+		position, apiErr := getAppendPosition(header)
+		if apiErr != testCase.expectedErr {
+			t.Errorf("Test %d: Expected error %v, but instead found %v", i+1, testCase.expectedErr, apiErr)
+		}
+		if position != testCase.expectedPosition {
+			t.Errorf("Test %d: Expected position %d, but instead found %d", i+1, testCase.expectedPosition, position)
+		}
+	}
+}
+
+func TestCheckAppendObject(t *testing.T) {
+	testCases := []struct {
+		objInfo     ObjectInfo
+		position    int64
+		expectedErr error
+	}{
+		{ObjectInfo{Size: 10}, 10, nil},
+		{ObjectInfo{Bucket: "bucket", Name: "object", Size: 10}, 5, InvalidAppendPosition{Bucket: "bucket", Object: "object", Size: 10}},
+		{ObjectInfo{IsDir: true}, 0, NotImplemented{}},
+		{ObjectInfo{UserDefined: map[string]string{objectVersionIDKey: mustGetUUID()}}, 0, NotImplemented{}},
+	}
+	for i, testCase := range testCases {
+		if err := checkAppendObject(testCase.objInfo, testCase.position); err != testCase.expectedErr {
+			t.Errorf("Test %d: Expected error %v, but instead found %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+// Wrapper for calling append PutObject tests for both XL multiple disks and single node setup.
+func TestAppendObjectHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testAppendObjectHandler, []string{"PutObject", "GetObject"})
+}
+
+func testAppendObjectHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+
+	objectName := "log"
+	appendObject := func(position string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestRequest("PUT", getPutObjectURL("", bucketName, objectName), int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		req.Header.Set(minioAppendPosition, position)
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	testCases := []struct {
+		position     string
+		data         string
+		expectedCode int
+		expectedData string
+	}{
+		// The object is created at position 0.
+		{"0", "hello", http.StatusOK, "hello"},
+		{"5", " world", http.StatusOK, "hello world"},
+		{"11", "", http.StatusOK, "hello world"},
+		{"11", "!", http.StatusOK, "hello world!"},
+		// Data is not appended at any other position than the size.
+		{"0", "hello", http.StatusConflict, "hello world!"},
+		{"20", "hello", http.StatusConflict, "hello world!"},
+		{"-1", "hello", http.StatusBadRequest, "hello world!"},
+		{"a", "hello", http.StatusBadRequest, "hello world!"},
+	}
+	for i, testCase := range testCases {
+		rec := appendObject(testCase.position, []byte(testCase.data))
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		var buffer bytes.Buffer
+		if err := obj.GetObject(context.Background(), bucketName, objectName, 0, -1, &buffer, ""); err != nil {
+			t.Fatalf("%s: Test %d: Failed to get object: <ERROR> %v", instanceType, i+1, err)
+		}
+		if buffer.String() != testCase.expectedData {
+			t.Errorf("%s: Test %d: Expected the object data `%s`, but instead found `%s`", instanceType, i+1, testCase.expectedData, buffer.String())
+		}
+	}
+
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName)
+	if err != nil {
+		t.Fatalf("%s: Failed to get object info: <ERROR> %v", instanceType, err)
+	}
+	if !strings.HasSuffix(objInfo.ETag, "-3") {
+		t.Errorf("%s: Expected the ETag of an object of 3 parts, but instead found `%s`", instanceType, objInfo.ETag)
+	}
+	if len(objInfo.Parts) != 3 || objInfo.Parts[2].Size != 1 {
+		t.Errorf("%s: Expected the appended data as parts, but instead found %v", instanceType, objInfo.Parts)
+	}
+}
+
+// Tests that data can't be appended to an object having the maximum
+// number of parts.
+func TestXLAppendObjectPartsLimit(t *testing.T) {
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(context.Background(), bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucket, object, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pInfo, err := obj.PutObjectPart(context.Background(), bucket, object, uploadID, globalMaxPartID, mustGetHashReader(t, bytes.NewReader([]byte("hello")), 5, "", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.CompleteMultipartUpload(context.Background(), bucket, object, uploadID, []CompletePart{{PartNumber: pInfo.PartNumber, ETag: pInfo.ETag}}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = obj.AppendObject(context.Background(), bucket, object, 5, mustGetHashReader(t, bytes.NewReader([]byte(" world")), 6, "", ""), nil)
+	if _, ok := err.(TooManyAppendedParts); !ok {
+		t.Fatalf("Expected TooManyAppendedParts, but instead found %v", err)
+	}
+
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.Size != 5 || len(objInfo.Parts) != 1 {
+		t.Errorf("Expected the object to be left unchanged, but instead found %d bytes in %d parts", objInfo.Size, len(objInfo.Parts))
+	}
+}
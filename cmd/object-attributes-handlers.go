@@ -0,0 +1,137 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// GetObjectAttributesHandler - returns the requested attributes of an
+// object version as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectAttributes.html
+// The parts of an object are only returned if it was uploaded in parts.
+func (api objectAPIHandlers) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectAttributes")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	var action policy.Action = policy.GetObjectAttributesAction
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" {
+		if !isValidVersionID(versionID) {
+			writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+			return
+		}
+		action = policy.GetObjectVersionAttributesAction
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, action, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	attributes := parseObjectAttributes(r.Header.Get(amzObjectAttributes))
+	if attributes == nil {
+		writeErrorResponse(w, ErrInvalidObjectAttributes, r.URL)
+		return
+	}
+
+	maxParts := maxPartsList
+	if value := r.Header.Get(amzMaxParts); value != "" {
+		var err error
+		if maxParts, err = strconv.Atoi(value); err != nil || maxParts < 0 {
+			writeErrorResponse(w, ErrInvalidMaxParts, r.URL)
+			return
+		}
+		if maxParts > maxPartsList {
+			maxParts = maxPartsList
+		}
+	}
+
+	var partNumberMarker int
+	if value := r.Header.Get(amzPartNumberMarker); value != "" {
+		var err error
+		if partNumberMarker, err = strconv.Atoi(value); err != nil || partNumberMarker < 0 {
+			writeErrorResponse(w, ErrInvalidPartNumberMarker, r.URL)
+			return
+		}
+	}
+
+	var objInfo ObjectInfo
+	var err error
+	if versionID != "" {
+		objInfo, err = objectAPI.GetObjectVersionInfo(ctx, bucket, object, versionID)
+	} else {
+		objInfo, err = objectAPI.GetObjectInfo(ctx, bucket, object)
+	}
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	if objInfo.DeleteMarker {
+		setObjectVersionHeaders(w, objInfo)
+		writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	// Encrypted objects need the same SSE-C headers as to be read.
+	if objectAPI.IsEncryptionSupported() {
+		if apiErr, _ := DecryptObjectInfo(&objInfo, r.Header); apiErr != ErrNone {
+			writeErrorResponse(w, apiErr, r.URL)
+			return
+		}
+	}
+
+	response := GetObjectAttributesResponse{}
+	if attributes[objectAttributeETag] {
+		response.ETag = objInfo.ETag
+	}
+	if attributes[objectAttributeChecksum] {
+		response.Checksum = getObjectAttributesChecksum(objInfo)
+	}
+	if attributes[objectAttributeParts] && isMultipartObject(objInfo) {
+		if response.ObjectParts, err = getObjectAttributesParts(objInfo, partNumberMarker, maxParts); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+	}
+	if attributes[objectAttributeClass] {
+		response.StorageClass = objInfo.StorageClass
+		if response.StorageClass == "" {
+			response.StorageClass = globalMinioDefaultStorageClass
+		}
+	}
+	if attributes[objectAttributeSize] {
+		response.ObjectSize = &objInfo.Size
+	}
+
+	w.Header().Set("Last-Modified", objInfo.ModTime.UTC().Format(http.TimeFormat))
+	setObjectVersionHeaders(w, objInfo)
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
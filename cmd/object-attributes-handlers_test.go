@@ -0,0 +1,197 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestParseObjectAttributes(t *testing.T) {
+	testCases := []struct {
+		value              string
+		expectedAttributes map[string]bool
+	}{
+		{"ETag", map[string]bool{"ETag": true}},
+		{"ETag, ObjectSize,StorageClass", map[string]bool{"ETag": true, "ObjectSize": true, "StorageClass": true}},
+		{"Checksum,ObjectParts,", map[string]bool{"Checksum": true, "ObjectParts": true}},
+		{"", nil},
+		{" , ", nil},
+		{"ETag,Size", nil},
+		{"etag", nil},
+	}
+	for i, testCase := range testCases {
+		if attributes := parseObjectAttributes(testCase.value); !reflect.DeepEqual(attributes, testCase.expectedAttributes) {
+			t.Errorf("Test %d: Expected %v, but instead found %v", i+1, testCase.expectedAttributes, attributes)
+		}
+	}
+}
+
+// Wrapper for calling GetObjectAttributes tests for both XL multiple disks and single node setup.
+func TestGetObjectAttributesHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testGetObjectAttributesHandler, []string{"GetObjectAttributes", "PutObject"})
+}
+
+func testGetObjectAttributesHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+
+	serve := func(method, urlStr string, headers map[string]string, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	getAttributes := func(objectName string, headers map[string]string) GetObjectAttributesResponse {
+		rec := serve("GET", getObjectAttributesURL("", bucketName, objectName), headers, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`: %s", instanceType, http.StatusOK, rec.Code, rec.Body.String())
+		}
+		if rec.Header().Get("Last-Modified") == "" {
+			t.Errorf("%s: Expected the Last-Modified header to be set", instanceType)
+		}
+		var response GetObjectAttributesResponse
+		if err := xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%s: Failed to parse the response: <ERROR> %v", instanceType, err)
+		}
+		return response
+	}
+
+	// An object with an additional checksum.
+	objectName := "object"
+	rec := serve("PUT", getPutObjectURL("", bucketName, objectName), map[string]string{amzChecksumCRC32: "DUoRhQ=="}, []byte("hello world"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName)
+	if err != nil {
+		t.Fatalf("%s: Failed to get object info: <ERROR> %v", instanceType, err)
+	}
+	response := getAttributes(objectName, map[string]string{amzObjectAttributes: "ETag,Checksum,ObjectParts,StorageClass,ObjectSize"})
+	if response.ETag != objInfo.ETag {
+		t.Errorf("%s: Expected the ETag `%s`, but instead found `%s`", instanceType, objInfo.ETag, response.ETag)
+	}
+	if response.Checksum == nil || response.Checksum.ChecksumCRC32 != "DUoRhQ==" {
+		t.Errorf("%s: Expected the CRC32 checksum `DUoRhQ==`, but instead found %v", instanceType, response.Checksum)
+	}
+	if response.ObjectParts != nil {
+		t.Errorf("%s: Expected no parts of a single part object, but instead found %v", instanceType, response.ObjectParts)
+	}
+	if response.StorageClass != globalMinioDefaultStorageClass {
+		t.Errorf("%s: Expected the storage class `%s`, but instead found `%s`", instanceType, globalMinioDefaultStorageClass, response.StorageClass)
+	}
+	if response.ObjectSize == nil || *response.ObjectSize != 11 {
+		t.Errorf("%s: Expected the object size `11`, but instead found %v", instanceType, response.ObjectSize)
+	}
+
+	// Only the requested attributes are returned.
+	response = getAttributes(objectName, map[string]string{amzObjectAttributes: "ObjectSize"})
+	if response.ETag != "" || response.Checksum != nil || response.StorageClass != "" || response.ObjectSize == nil {
+		t.Errorf("%s: Expected only the object size, but instead found %v", instanceType, response)
+	}
+
+	// A multipart object.
+	multipartName := "multipart-object"
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, multipartName, nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create a multipart upload: <ERROR> %v", instanceType, err)
+	}
+	partSizes := []int64{globalMinPartSize, globalMinPartSize, 1}
+	var parts []CompletePart
+	for i, size := range partSizes {
+		data := bytes.Repeat([]byte("a"), int(size))
+		partInfo, err := obj.PutObjectPart(context.Background(), bucketName, multipartName, uploadID, i+1, mustGetHashReader(t, bytes.NewReader(data), size, "", ""))
+		if err != nil {
+			t.Fatalf("%s: Failed to put part %d: <ERROR> %v", instanceType, i+1, err)
+		}
+		parts = append(parts, CompletePart{PartNumber: i + 1, ETag: partInfo.ETag})
+	}
+	objInfo, err = obj.CompleteMultipartUpload(context.Background(), bucketName, multipartName, uploadID, parts)
+	if err != nil {
+		t.Fatalf("%s: Failed to complete the multipart upload: <ERROR> %v", instanceType, err)
+	}
+
+	response = getAttributes(multipartName, map[string]string{amzObjectAttributes: "ETag,ObjectParts,ObjectSize"})
+	if response.ETag != objInfo.ETag {
+		t.Errorf("%s: Expected the ETag `%s`, but instead found `%s`", instanceType, objInfo.ETag, response.ETag)
+	}
+	if response.ObjectSize == nil || *response.ObjectSize != 2*globalMinPartSize+1 {
+		t.Errorf("%s: Expected the object size `%d`, but instead found %v", instanceType, 2*globalMinPartSize+1, response.ObjectSize)
+	}
+	expectedParts := &objectAttributesParts{
+		TotalPartsCount:      3,
+		NextPartNumberMarker: 3,
+		MaxParts:             maxPartsList,
+		Parts: []objectAttributesPart{
+			{PartNumber: 1, Size: globalMinPartSize},
+			{PartNumber: 2, Size: globalMinPartSize},
+			{PartNumber: 3, Size: 1},
+		},
+	}
+	if !reflect.DeepEqual(response.ObjectParts, expectedParts) {
+		t.Errorf("%s: Expected the parts %v, but instead found %v", instanceType, expectedParts, response.ObjectParts)
+	}
+
+	// The parts are listed in pages.
+	response = getAttributes(multipartName, map[string]string{amzObjectAttributes: "ObjectParts", amzMaxParts: "1", amzPartNumberMarker: "1"})
+	expectedParts = &objectAttributesParts{
+		TotalPartsCount:      3,
+		PartNumberMarker:     1,
+		NextPartNumberMarker: 2,
+		MaxParts:             1,
+		IsTruncated:          true,
+		Parts:                []objectAttributesPart{{PartNumber: 2, Size: globalMinPartSize}},
+	}
+	if !reflect.DeepEqual(response.ObjectParts, expectedParts) {
+		t.Errorf("%s: Expected the parts %v, but instead found %v", instanceType, expectedParts, response.ObjectParts)
+	}
+
+	errorCases := []struct {
+		objectName   string
+		headers      map[string]string
+		expectedCode int
+	}{
+		{objectName, nil, http.StatusBadRequest},
+		{objectName, map[string]string{amzObjectAttributes: "ETag,Owner"}, http.StatusBadRequest},
+		{objectName, map[string]string{amzObjectAttributes: "ObjectParts", amzMaxParts: "-1"}, http.StatusBadRequest},
+		{objectName, map[string]string{amzObjectAttributes: "ObjectParts", amzPartNumberMarker: "a"}, http.StatusBadRequest},
+		{"missing-object", map[string]string{amzObjectAttributes: "ETag"}, http.StatusNotFound},
+	}
+	for i, testCase := range errorCases {
+		if rec = serve("GET", getObjectAttributesURL("", bucketName, testCase.objectName), testCase.headers, nil); rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+const (
+	// Request headers of GetObjectAttributes.
+	amzObjectAttributes     = "x-amz-object-attributes"
+	amzMaxParts             = "x-amz-max-parts"
+	amzPartNumberMarker     = "x-amz-part-number-marker"
+	objectAttributeETag     = "ETag"
+	objectAttributeChecksum = "Checksum"
+	objectAttributeParts    = "ObjectParts"
+	objectAttributeClass    = "StorageClass"
+	objectAttributeSize     = "ObjectSize"
+)
+
+// Additional checksum of an object in a GetObjectAttributes response.
+type objectAttributesChecksum struct {
+	ChecksumCRC32  string `xml:"ChecksumCRC32,omitempty"`
+	ChecksumCRC32C string `xml:"ChecksumCRC32C,omitempty"`
+	ChecksumSHA1   string `xml:"ChecksumSHA1,omitempty"`
+	ChecksumSHA256 string `xml:"ChecksumSHA256,omitempty"`
+}
+
+// Part of a multipart object in a GetObjectAttributes response.
+type objectAttributesPart struct {
+	PartNumber int   `xml:"PartNumber"`
+	Size       int64 `xml:"Size"`
+}
+
+// Parts of a multipart object in a GetObjectAttributes response.
+type objectAttributesParts struct {
+	TotalPartsCount      int                    `xml:"TotalPartsCount"`
+	PartNumberMarker     int                    `xml:"PartNumberMarker"`
+	NextPartNumberMarker int                    `xml:"NextPartNumberMarker"`
+	MaxParts             int                    `xml:"MaxParts"`
+	IsTruncated          bool                   `xml:"IsTruncated"`
+	Parts                []objectAttributesPart `xml:"Part"`
+}
+
+// GetObjectAttributesResponse - format for get object attributes response,
+// only the requested attributes are set.
+type GetObjectAttributesResponse struct {
+	XMLName      xml.Name                  `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesOutput" json:"-"`
+	ETag         string                    `xml:"ETag,omitempty"`
+	Checksum     *objectAttributesChecksum `xml:"Checksum,omitempty"`
+	ObjectParts  *objectAttributesParts    `xml:"ObjectParts,omitempty"`
+	StorageClass string                    `xml:"StorageClass,omitempty"`
+	ObjectSize   *int64                    `xml:"ObjectSize,omitempty"`
+}
+
+// parseObjectAttributes - returns the attributes of the comma separated
+// list of a x-amz-object-attributes header, nil if it is empty or it has
+// an unknown attribute.
+func parseObjectAttributes(value string) map[string]bool {
+	attributes := make(map[string]bool)
+	for _, attribute := range strings.Split(value, ",") {
+		attribute = strings.TrimSpace(attribute)
+		switch attribute {
+		case objectAttributeETag, objectAttributeChecksum, objectAttributeParts, objectAttributeClass, objectAttributeSize:
+			attributes[attribute] = true
+		case "":
+		default:
+			return nil
+		}
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	return attributes
+}
+
+// isMultipartObject - returns whether the object was uploaded in parts,
+// its ETag has a part count then.
+func isMultipartObject(objInfo ObjectInfo) bool {
+	return strings.Contains(objInfo.ETag, "-") && len(objInfo.Parts) > 0
+}
+
+// getObjectAttributesParts - returns at most maxParts parts of a
+// multipart object after partNumberMarker. The sizes of the parts of
+// encrypted objects are their decrypted sizes.
+func getObjectAttributesParts(objInfo ObjectInfo, partNumberMarker, maxParts int) (*objectAttributesParts, error) {
+	parts := &objectAttributesParts{
+		TotalPartsCount:  len(objInfo.Parts),
+		PartNumberMarker: partNumberMarker,
+		MaxParts:         maxParts,
+	}
+	for _, part := range objInfo.Parts {
+		if part.Number <= partNumberMarker {
+			continue
+		}
+		if len(parts.Parts) == maxParts {
+			parts.IsTruncated = true
+			break
+		}
+		size, err := getObjectPartSize(objInfo, part)
+		if err != nil {
+			return nil, err
+		}
+		parts.Parts = append(parts.Parts, objectAttributesPart{
+			PartNumber: part.Number,
+			Size:       size,
+		})
+		parts.NextPartNumberMarker = part.Number
+	}
+	return parts, nil
+}
+
+// getObjectAttributesChecksum - returns the additional checksum of an
+// object, nil if it has none.
+func getObjectAttributesChecksum(objInfo ObjectInfo) *objectAttributesChecksum {
+	checksumHeader, checksum := getObjectChecksum(objInfo.UserDefined)
+	switch checksumHeader {
+	case amzChecksumCRC32:
+		return &objectAttributesChecksum{ChecksumCRC32: checksum}
+	case amzChecksumCRC32C:
+		return &objectAttributesChecksum{ChecksumCRC32C: checksum}
+	case amzChecksumSHA1:
+		return &objectAttributesChecksum{ChecksumSHA1: checksum}
+	case amzChecksumSHA256:
+		return &objectAttributesChecksum{ChecksumSHA256: checksum}
+	}
+	return nil
+}
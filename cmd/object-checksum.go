@@ -0,0 +1,87 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	// Request and response headers giving the base64 encoded additional
+	// checksum of an object or a part, one per algorithm.
+	amzChecksumCRC32  = "x-amz-checksum-crc32"
+	amzChecksumCRC32C = "x-amz-checksum-crc32c"
+	amzChecksumSHA1   = "x-amz-checksum-sha1"
+	amzChecksumSHA256 = "x-amz-checksum-sha256"
+
+	// Additional checksum of an object, the algorithm and the base64
+	// encoded checksum separated by a colon, is kept in its metadata.
+	objectChecksumKey = ReservedMetadataPrefix + "Checksum"
+)
+
+// Checksum algorithms of the additional checksum headers.
+var checksumHeaderAlgorithms = map[string]string{
+	amzChecksumCRC32:  hash.ChecksumCRC32,
+	amzChecksumCRC32C: hash.ChecksumCRC32C,
+	amzChecksumSHA1:   hash.ChecksumSHA1,
+	amzChecksumSHA256: hash.ChecksumSHA256,
+}
+
+// getChecksumHeader - returns the additional checksum header of an
+// algorithm.
+func getChecksumHeader(algorithm string) string {
+	return "x-amz-checksum-" + strings.ToLower(algorithm)
+}
+
+// getRequestChecksum - returns the algorithm and the checksum of the
+// additional checksum header of a request, if any. At most one checksum
+// header may be set.
+func getRequestChecksum(header http.Header) (algorithm, checksum string, apiErr APIErrorCode) {
+	for checksumHeader, checksumAlgorithm := range checksumHeaderAlgorithms {
+		if _, ok := header[http.CanonicalHeaderKey(checksumHeader)]; !ok {
+			continue
+		}
+		if algorithm != "" {
+			return "", "", ErrInvalidChecksum
+		}
+		algorithm, checksum = checksumAlgorithm, header.Get(checksumHeader)
+	}
+	return algorithm, checksum, ErrNone
+}
+
+// getObjectChecksum - returns the additional checksum header and the
+// checksum recorded in the metadata of an object, if any.
+func getObjectChecksum(metadata map[string]string) (checksumHeader, checksum string) {
+	value, ok := metadata[objectChecksumKey]
+	if !ok {
+		return "", ""
+	}
+	i := strings.Index(value, ":")
+	if i < 0 {
+		return "", ""
+	}
+	return getChecksumHeader(value[:i]), value[i+1:]
+}
+
+// setObjectChecksum - records the additional checksum of an object in
+// its metadata.
+func setObjectChecksum(metadata map[string]string, algorithm, checksum string) {
+	metadata[objectChecksumKey] = algorithm + ":" + checksum
+}
@@ -0,0 +1,113 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling additional checksum tests for both XL multiple disks and single node setup.
+func TestObjectChecksumHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testObjectChecksumHandlers, []string{"PutObject", "HeadObject", "GetObject", "PutObjectPart"})
+}
+
+func testObjectChecksumHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	data := []byte("hello world")
+	serve := func(method, urlStr string, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(body)), bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	testCases := []struct {
+		object        string
+		headers       map[string]string
+		expectedCode  int
+		expectedError string
+	}{
+		{"crc32", map[string]string{amzChecksumCRC32: "DUoRhQ=="}, http.StatusOK, ""},
+		{"crc32c", map[string]string{amzChecksumCRC32C: "yZRlqg=="}, http.StatusOK, ""},
+		{"sha256", map[string]string{amzChecksumSHA256: "uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek="}, http.StatusOK, ""},
+		{"bad-crc32c", map[string]string{amzChecksumCRC32C: "DUoRhQ=="}, http.StatusBadRequest, "BadDigest"},
+		{"invalid-sha1", map[string]string{amzChecksumSHA1: "DUoRhQ=="}, http.StatusBadRequest, "InvalidRequest"},
+		{"two-checksums", map[string]string{amzChecksumCRC32: "DUoRhQ==", amzChecksumCRC32C: "yZRlqg=="}, http.StatusBadRequest, "InvalidRequest"},
+	}
+	for i, testCase := range testCases {
+		rec := serve("PUT", getPutObjectURL("", bucketName, testCase.object), data, testCase.headers)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if testCase.expectedError != "" {
+			if !strings.Contains(rec.Body.String(), testCase.expectedError) {
+				t.Errorf("%s: Test %d: Expected the error %s, but instead found %s", instanceType, i+1, testCase.expectedError, rec.Body.String())
+			}
+			if _, err := obj.GetObjectInfo(context.Background(), bucketName, testCase.object); err == nil {
+				t.Errorf("%s: Test %d: Expected the object not to be created", instanceType, i+1)
+			}
+			continue
+		}
+		for header, checksum := range testCase.headers {
+			if value := rec.Header().Get(header); value != checksum {
+				t.Errorf("%s: Test %d: Expected the checksum %s, but instead found %s", instanceType, i+1, checksum, value)
+			}
+			rec = serve("HEAD", getHeadObjectURL("", bucketName, testCase.object), nil, nil)
+			if value := rec.Header().Get(header); value != checksum {
+				t.Errorf("%s: Test %d: Expected the checksum %s, but instead found %s", instanceType, i+1, checksum, value)
+			}
+			rec = serve("GET", getGetObjectURL("", bucketName, testCase.object), nil, nil)
+			if value := rec.Header().Get(header); value != checksum || rec.Body.String() != string(data) {
+				t.Errorf("%s: Test %d: Expected the checksum %s, but instead found %s", instanceType, i+1, checksum, value)
+			}
+			// The checksum is not sent for ranges of the object.
+			rec = serve("GET", getGetObjectURL("", bucketName, testCase.object), nil, map[string]string{"Range": "bytes=0-4"})
+			if value := rec.Header().Get(header); rec.Code != http.StatusPartialContent || value != "" {
+				t.Errorf("%s: Test %d: Unexpected ranged response `%d`, checksum %s", instanceType, i+1, rec.Code, value)
+			}
+		}
+	}
+
+	// Parts are verified against their checksum.
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, "multipart", nil)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	partURL := getPutObjectPartURL("", bucketName, "multipart", uploadID, "1")
+	if rec := serve("PUT", partURL, data, map[string]string{amzChecksumCRC32C: "yZRlqg=="}); rec.Code != http.StatusOK || rec.Header().Get(amzChecksumCRC32C) != "yZRlqg==" {
+		t.Errorf("%s: Unexpected response `%d` %v", instanceType, rec.Code, rec.Header())
+	}
+	if rec := serve("PUT", partURL, data, map[string]string{amzChecksumCRC32C: "DUoRhQ=="}); rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+}
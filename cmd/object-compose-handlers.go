@@ -0,0 +1,215 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// ComposeObjectHandler - Minio extension which creates an object of the
+// concatenated data of up to 32 source objects of its bucket, entirely
+// server-side. The metadata of the object is taken from the request
+// headers as for PutObject, the metadata of the sources is not copied.
+func (api objectAPIHandlers) ComposeObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ComposeObject")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	// The keys of objects encrypted with SSE-C are not part of a
+	// compose request.
+	if hasSSECustomerHeader(r.Header) {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	// Validate storage class metadata if present
+	if _, ok := r.Header[amzStorageClassCanonical]; ok {
+		if !isValidStorageClassMeta(r.Header.Get(amzStorageClassCanonical)) {
+			writeErrorResponse(w, ErrInvalidStorageClass, r.URL)
+			return
+		}
+	}
+
+	// ComposeObject always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+
+	if r.ContentLength > maxComposeRequestSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	var composeRequest ComposeRequest
+	if err := xml.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&composeRequest); err != nil || len(composeRequest.Sources) == 0 {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+	if len(composeRequest.Sources) > maxComposeSources {
+		writeErrorResponse(w, ErrTooManyComposeSources, r.URL)
+		return
+	}
+
+	var size int64
+	sources := make([]ObjectInfo, len(composeRequest.Sources))
+	for i, source := range composeRequest.Sources {
+		if source.Object == "" || (source.VersionID != "" && !isValidVersionID(source.VersionID)) {
+			writeErrorResponse(w, ErrMalformedXML, r.URL)
+			return
+		}
+		// The object is locked while it is written, its data cannot
+		// be read at the same time.
+		if isStringEqual(source.Object, object) {
+			writeErrorResponse(w, ErrComposeSourceIsDestination, r.URL)
+			return
+		}
+		var action policy.Action = policy.GetObjectAction
+		if source.VersionID != "" {
+			action = policy.GetObjectVersionAction
+		}
+		if !isObjectActionAllowed(r, action, bucket, source.Object) {
+			writeErrorResponse(w, ErrAccessDenied, r.URL)
+			return
+		}
+		srcInfo, s3Error := getComposeSourceInfo(ctx, objectAPI, bucket, source)
+		if s3Error != ErrNone {
+			writeErrorResponse(w, s3Error, r.URL)
+			return
+		}
+		sources[i] = srcInfo
+		size += srcInfo.Size
+	}
+
+	/// maximum Upload size for objects in a single operation
+	if isMaxObjectSize(size) {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		if _, err := objectAPI.GetObjectInfo(ctx, bucket, object); err == nil {
+			writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
+			return
+		}
+	}
+
+	metadata, err := extractMetadata(ctx, r)
+	if err != nil {
+		writeErrorResponse(w, ErrInternalError, r.URL)
+		return
+	}
+	setObjectVersionID(bucket, metadata)
+	if s3Error := setObjectLockMetadata(bucket, r.Header, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	if s3Error := setObjectTaggingMetadata(objectAPI, bucket, r.Header, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	setObjectReplicationMetadata(objectAPI, r, r.Header, bucket, object, metadata)
+
+	composeReader := newComposeReader(ctx, objectAPI, sources)
+	defer composeReader.Close()
+
+	var reader io.Reader = composeReader
+	hashReader, err := hash.NewReader(reader, size, "", "")
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if objectAPI.IsEncryptionSupported() {
+		if encryption, ok := getBucketEncryption(bucket); ok && !hasSuffix(object, slashSeparator) { // handle default bucket encryption
+			reader, err = newBucketEncryptReader(hashReader, encryption, bucket, object, metadata)
+			if err != nil {
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
+			info := ObjectInfo{Size: size}
+			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "") // do not try to verify encrypted content
+			if err != nil {
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
+		}
+	}
+
+	putObject := objectAPI.PutObject
+	if api.CacheAPI() != nil && !crypto.IsEncrypted(metadata) {
+		putObject = api.CacheAPI().PutObject
+	}
+
+	// Create the object..
+	objInfo, err := putObject(ctx, bucket, object, hashReader, metadata)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	setObjectVersionHeaders(w, objInfo)
+	if objectAPI.IsEncryptionSupported() {
+		setBucketEncryptionHeaders(w, metadata)
+	}
+
+	response := generateComposeObjectResponse(objInfo.ETag, objInfo.ModTime)
+	writeSuccessResponseXML(w, encodeResponse(response))
+
+	// Get host and port from Request.RemoteAddr.
+	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
+	if err != nil {
+		host, port = "", ""
+	}
+
+	queueReplication(objInfo)
+
+	// Notify object created event.
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedCopy,
+		BucketName: bucket,
+		Object:     objInfo,
+		ReqParams:  extractReqParams(r),
+		UserAgent:  r.UserAgent(),
+		Host:       host,
+		Port:       port,
+	})
+}
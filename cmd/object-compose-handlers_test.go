@@ -0,0 +1,121 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling ComposeObject tests for both XL multiple disks and single node setup.
+func TestComposeObjectHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testComposeObjectHandler, []string{"ComposeObject"})
+}
+
+func testComposeObjectHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+
+	sourcesData := map[string][]byte{
+		"a":     bytes.Repeat([]byte("a"), 1024),
+		"b":     []byte("hello"),
+		"empty": {},
+	}
+	etags := make(map[string]string)
+	for name, data := range sourcesData {
+		objInfo, err := obj.PutObject(context.Background(), bucketName, name, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+		}
+		etags[name] = objInfo.ETag
+	}
+
+	compose := func(objectName, body string, headers map[string]string) *httptest.ResponseRecorder {
+		req, err := newTestRequest("PUT", getComposeObjectURL("", bucketName, objectName), int64(len(body)), strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	body := `<ComposeRequest><Source><Object>a</Object></Source><Source><Object>empty</Object></Source>` +
+		`<Source><Object>b</Object><ETag>"` + etags["b"] + `"</ETag></Source><Source><Object>a</Object></Source></ComposeRequest>`
+	rec := compose("composed", body, map[string]string{"Content-Type": "text/plain", "X-Amz-Meta-Test": "composed"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var response ComposeObjectResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("%s: Failed to parse the compose response: <ERROR> %v", instanceType, err)
+	}
+
+	expectedData := append(append(append([]byte{}, sourcesData["a"]...), sourcesData["b"]...), sourcesData["a"]...)
+	var buffer bytes.Buffer
+	if err := obj.GetObject(context.Background(), bucketName, "composed", 0, -1, &buffer, ""); err != nil {
+		t.Fatalf("%s: Failed to get object: <ERROR> %v", instanceType, err)
+	}
+	if !bytes.Equal(buffer.Bytes(), expectedData) {
+		t.Errorf("%s: Expected the concatenated data of the sources", instanceType)
+	}
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, "composed")
+	if err != nil {
+		t.Fatalf("%s: Failed to get object info: <ERROR> %v", instanceType, err)
+	}
+	if response.ETag != "\""+objInfo.ETag+"\"" {
+		t.Errorf("%s: Expected the ETag `%s`, but instead found `%s`", instanceType, objInfo.ETag, response.ETag)
+	}
+	if objInfo.ContentType != "text/plain" || objInfo.UserDefined["X-Amz-Meta-Test"] != "composed" {
+		t.Errorf("%s: Expected the metadata of the request, but instead found %v", instanceType, objInfo.UserDefined)
+	}
+
+	tooManySources := "<ComposeRequest>" + strings.Repeat("<Source><Object>a</Object></Source>", maxComposeSources+1) + "</ComposeRequest>"
+	testCases := []struct {
+		objectName   string
+		body         string
+		headers      map[string]string
+		expectedCode int
+	}{
+		{"composed", "<ComposeRequest></ComposeRequest>", nil, http.StatusBadRequest},
+		{"composed", "<ComposeRequest><Source><Object>a", nil, http.StatusBadRequest},
+		{"composed", tooManySources, nil, http.StatusBadRequest},
+		{"a", "<ComposeRequest><Source><Object>a</Object></Source></ComposeRequest>", nil, http.StatusBadRequest},
+		{"composed", "<ComposeRequest><Source><Object>missing</Object></Source></ComposeRequest>", nil, http.StatusNotFound},
+		{"composed", "<ComposeRequest><Source><Object>a</Object><ETag>" + etags["b"] + "</ETag></Source></ComposeRequest>", nil, http.StatusPreconditionFailed},
+		{"composed", "<ComposeRequest><Source><Object>a</Object></Source></ComposeRequest>", map[string]string{SSECustomerAlgorithm: "AES256"}, http.StatusNotImplemented},
+	}
+	for i, testCase := range testCases {
+		if rec = compose(testCase.objectName, testCase.body, testCase.headers); rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio/pkg/ioutil"
+)
+
+const (
+	// Maximum number of source objects of a compose request.
+	maxComposeSources = 32
+
+	// Maximum size of a compose request.
+	maxComposeRequestSize = 64 * 1024
+)
+
+// ComposeSource - source object of a compose request, the current version
+// of the object is composed if no version ID is given. The object must
+// have the ETag, if any.
+type ComposeSource struct {
+	Object    string `xml:"Object"`
+	VersionID string `xml:"VersionId,omitempty"`
+	ETag      string `xml:"ETag,omitempty"`
+}
+
+// ComposeRequest - Minio extension request to compose an object of
+// the concatenated data of source objects of its bucket.
+type ComposeRequest struct {
+	XMLName xml.Name        `xml:"ComposeRequest"`
+	Sources []ComposeSource `xml:"Source"`
+}
+
+// ComposeObjectResponse container returns ETag and LastModified of the
+// successfully composed object.
+type ComposeObjectResponse struct {
+	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ComposeObjectResult" json:"-"`
+	LastModified string   // time string of format "2006-01-02T15:04:05.000Z"
+	ETag         string   // md5sum of the composed object.
+}
+
+// generates ComposeObjectResponse from etag and lastModified time.
+func generateComposeObjectResponse(etag string, lastModified time.Time) ComposeObjectResponse {
+	return ComposeObjectResponse{
+		ETag:         "\"" + etag + "\"",
+		LastModified: lastModified.UTC().Format(timeFormatAMZLong),
+	}
+}
+
+// getComposeSourceInfo - returns the object info of a source object,
+// with the size of its decrypted data if it is encrypted with SSE-S3.
+// Objects encrypted with SSE-C are not composed, the compose request
+// has no keys of the source objects.
+func getComposeSourceInfo(ctx context.Context, objectAPI ObjectLayer, bucket string, source ComposeSource) (objInfo ObjectInfo, s3Error APIErrorCode) {
+	var err error
+	if source.VersionID != "" {
+		objInfo, err = objectAPI.GetObjectVersionInfo(ctx, bucket, source.Object, source.VersionID)
+	} else {
+		objInfo, err = objectAPI.GetObjectInfo(ctx, bucket, source.Object)
+	}
+	if err != nil {
+		return objInfo, toAPIErrorCode(err)
+	}
+	if objInfo.DeleteMarker {
+		return objInfo, ErrNoSuchKey
+	}
+	if source.ETag != "" && !isETagEqual(source.ETag, objInfo.ETag) {
+		return objInfo, ErrPreconditionFailed
+	}
+	if objectAPI.IsEncryptionSupported() {
+		if s3Error, _ = DecryptObjectInfo(&objInfo, http.Header{}); s3Error != ErrNone {
+			return objInfo, s3Error
+		}
+	}
+	return objInfo, ErrNone
+}
+
+// newComposeReader - returns a reader of the concatenated data of the
+// source objects, read one after the other. The data is streamed from
+// the backend, nothing is buffered but the data of the pipe.
+func newComposeReader(ctx context.Context, objectAPI ObjectLayer, sources []ObjectInfo) io.ReadCloser {
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		for _, srcInfo := range sources {
+			if err := getComposeSource(ctx, objectAPI, pipeWriter, srcInfo); err != nil {
+				pipeWriter.CloseWithError(err)
+				return
+			}
+		}
+		pipeWriter.Close()
+	}()
+	return pipeReader
+}
+
+// getComposeSource - writes the data of the source object to the writer,
+// decrypted if it is encrypted with SSE-S3.
+func getComposeSource(ctx context.Context, objectAPI ObjectLayer, w io.Writer, srcInfo ObjectInfo) error {
+	if srcInfo.Size == 0 {
+		return nil
+	}
+	var writer io.WriteCloser = ioutil.NopCloser(w)
+	offset, length := int64(0), srcInfo.Size
+	if srcInfo.IsEncrypted() {
+		var err error
+		if writer, offset, length, err = DecryptBlocksRequest(writer, nil, srcInfo.Bucket, srcInfo.Name, 0, srcInfo.Size, srcInfo, false); err != nil {
+			return err
+		}
+	}
+	var err error
+	if srcInfo.VersionID != "" {
+		err = objectAPI.GetObjectVersion(ctx, srcInfo.Bucket, srcInfo.Name, srcInfo.VersionID, offset, length, writer, srcInfo.ETag)
+	} else {
+		err = objectAPI.GetObject(ctx, srcInfo.Bucket, srcInfo.Name, offset, length, writer, srcInfo.ETag)
+	}
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
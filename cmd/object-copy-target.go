@@ -0,0 +1,84 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	miniogo "github.com/minio/minio-go"
+)
+
+// Minio extension request header of CopyObject, the ARN of a copy
+// target of the destination bucket. The source object is read from the
+// remote bucket of the target, using its credentials, instead of from
+// a local bucket.
+const minioCopySourceTarget = "X-Minio-Copy-Source-Target"
+
+// getCopySourceTarget - returns the copy target of the destination
+// bucket having given ARN, srcBucket must be its remote bucket.
+func getCopySourceTarget(arn, dstBucket, srcBucket string) (BucketTarget, bool) {
+	if globalBucketTargetSys == nil {
+		return BucketTarget{}, false
+	}
+	target, ok := globalBucketTargetSys.GetTarget(arn)
+	if !ok || target.SourceBucket != dstBucket || target.Type != CopyService || target.TargetBucket != srcBucket {
+		return BucketTarget{}, false
+	}
+	return target, true
+}
+
+// getRemoteCopySource - starts reading the source object of a copy from
+// the remote bucket of the target, returns its info and its data. Only
+// the user metadata and the supported headers of the remote object are
+// copied, its storage class and its tags are not.
+func getRemoteCopySource(ctx context.Context, target BucketTarget, srcObject string) (io.ReadCloser, ObjectInfo, error) {
+	u, err := url.Parse(target.Endpoint)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	client, err := miniogo.NewCore(u.Host, target.Credentials.AccessKey, target.Credentials.SecretKey, u.Scheme == "https")
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	reader, info, err := client.GetObject(target.TargetBucket, srcObject, miniogo.GetObjectOptions{})
+	if err != nil {
+		return nil, ObjectInfo{}, ErrorRespToObjectError(err, target.TargetBucket, srcObject)
+	}
+
+	metadata := make(map[string]string)
+	if err = extractMetadataFromMap(ctx, info.Metadata, metadata); err != nil {
+		reader.Close()
+		return nil, ObjectInfo{}, err
+	}
+	delete(metadata, amzStorageClass)
+	if info.ContentType != "" {
+		metadata["content-type"] = info.ContentType
+	}
+
+	return reader, ObjectInfo{
+		Bucket:      target.TargetBucket,
+		Name:        srcObject,
+		ModTime:     info.LastModified,
+		Size:        info.Size,
+		ETag:        info.ETag,
+		ContentType: info.ContentType,
+		UserDefined: metadata,
+	}, nil
+}
@@ -0,0 +1,142 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling CopyObject tests reading the source object from
+// a remote target for both XL multiple disks and single node setup.
+func TestCopyObjectFromTargetHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testCopyObjectFromTargetHandler, []string{"CopyObject", "GetObject", "GetBucketLocation"})
+}
+
+func testCopyObjectFromTargetHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	// The remote cluster is served by the same object layer.
+	remote := httptest.NewServer(apiRouter)
+	defer remote.Close()
+
+	remoteBucket := "remote-bucket"
+	if err := obj.MakeBucketWithLocation(context.Background(), remoteBucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	data := []byte("hello, remote world")
+	srcInfo, err := obj.PutObject(context.Background(), remoteBucket, "object", mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""),
+		map[string]string{"content-type": "text/plain", "X-Amz-Meta-Color": "blue"})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	target := BucketTarget{
+		SourceBucket: bucketName,
+		Endpoint:     remote.URL,
+		Credentials:  credentials,
+		TargetBucket: remoteBucket,
+		Type:         CopyService,
+		Arn:          "arn:minio:copy::target:" + remoteBucket,
+	}
+	globalBucketTargetSys = NewBucketTargetSys()
+	defer func() { globalBucketTargetSys = NewBucketTargetSys() }()
+	globalBucketTargetSys.Set(bucketName, []BucketTarget{target})
+
+	copyObject := func(copySource, arn string, headers map[string]string) *httptest.ResponseRecorder {
+		req, err := newTestRequest("PUT", getCopyObjectURL("", bucketName, "copy"), 0, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		req.Header.Set("X-Amz-Copy-Source", copySource)
+		req.Header.Set(minioCopySourceTarget, arn)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The object and its metadata are copied from the remote bucket.
+	rec := copyObject(remoteBucket+"/object", target.Arn, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`: %s", instanceType, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, "copy")
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if objInfo.Size != int64(len(data)) || objInfo.ETag != srcInfo.ETag || objInfo.ContentType != "text/plain" || objInfo.UserDefined["X-Amz-Meta-Color"] != "blue" {
+		t.Errorf("%s: Unexpected info of the copy: %v", instanceType, objInfo)
+	}
+	var buffer bytes.Buffer
+	if err = obj.GetObject(context.Background(), bucketName, "copy", 0, objInfo.Size, &buffer, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !bytes.Equal(buffer.Bytes(), data) {
+		t.Errorf("%s: Expected the data `%s`, but instead found `%s`", instanceType, data, buffer.Bytes())
+	}
+
+	// The metadata of the copy is replaced by the request headers.
+	rec = copyObject(remoteBucket+"/object", target.Arn, map[string]string{
+		"X-Amz-Metadata-Directive": "REPLACE",
+		"X-Amz-Meta-Color":         "red",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`: %s", instanceType, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if objInfo, err = obj.GetObjectInfo(context.Background(), bucketName, "copy"); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if objInfo.UserDefined["X-Amz-Meta-Color"] != "red" {
+		t.Errorf("%s: Expected the replaced metadata, but instead found %v", instanceType, objInfo.UserDefined)
+	}
+
+	testCases := []struct {
+		copySource   string
+		arn          string
+		headers      map[string]string
+		expectedCode int
+	}{
+		// Unknown target.
+		{remoteBucket + "/object", "arn:minio:copy::unknown:" + remoteBucket, nil, http.StatusBadRequest},
+		// The source bucket is not the remote bucket of the target.
+		{bucketName + "/object", target.Arn, nil, http.StatusBadRequest},
+		// The source object does not exist.
+		{remoteBucket + "/missing", target.Arn, nil, http.StatusNotFound},
+		// The preconditions are verified against the remote object.
+		{remoteBucket + "/object", target.Arn, map[string]string{"X-Amz-Copy-Source-If-Match": "invalid"}, http.StatusPreconditionFailed},
+	}
+	for i, testCase := range testCases {
+		if rec = copyObject(testCase.copySource, testCase.arn, testCase.headers); rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`: %s", instanceType, i+1, testCase.expectedCode, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
 )
@@ -43,17 +44,13 @@ func checkCopyObjectPartPreconditions(w http.ResponseWriter, r *http.Request, ob
 //  x-amz-copy-source-if-unmodified-since
 //  x-amz-copy-source-if-match
 //  x-amz-copy-source-if-none-match
+// As in S3 a satisfied x-amz-copy-source-if-match takes precedence over
+// an unsatisfied x-amz-copy-source-if-unmodified-since.
 func checkCopyObjectPreconditions(w http.ResponseWriter, r *http.Request, objInfo ObjectInfo) bool {
-	// Return false for methods other than GET and HEAD.
+	// Return false for methods other than PUT.
 	if r.Method != "PUT" {
 		return false
 	}
-	// If the object doesn't have a modtime (IsZero), or the modtime
-	// is obviously garbage (Unix time == 0), then ignore modtimes
-	// and don't process the If-Modified-Since header.
-	if objInfo.ModTime.IsZero() || objInfo.ModTime.Equal(time.Unix(0, 0)) {
-		return false
-	}
 
 	// Headers to be set of object content is not going to be written to the client.
 	writeHeaders := func() {
@@ -67,6 +64,39 @@ func checkCopyObjectPreconditions(w http.ResponseWriter, r *http.Request, objInf
 			w.Header().Set("ETag", "\""+objInfo.ETag+"\"")
 		}
 	}
+	preconditionFailed := func() bool {
+		writeHeaders()
+		writeErrorResponse(w, ErrPreconditionFailed, r.URL)
+		return true
+	}
+
+	// x-amz-copy-source-if-match : Return the object only if its entity tag (ETag) is
+	// one of the specified ones; otherwise return a 412 (precondition failed).
+	ifMatchETagHeader := r.Header.Get("x-amz-copy-source-if-match")
+	if ifMatchETagHeader != "" {
+		if objInfo.ETag != "" && !isETagInList(objInfo.ETag, ifMatchETagHeader) {
+			// If the object ETag does not match with the specified ETag.
+			return preconditionFailed()
+		}
+	}
+
+	// x-amz-copy-source-if-none-match : Return the object only if its entity tag (ETag)
+	// is none of the specified ones otherwise, return a 412 (precondition failed).
+	ifNoneMatchETagHeader := r.Header.Get("x-amz-copy-source-if-none-match")
+	if ifNoneMatchETagHeader != "" {
+		if objInfo.ETag != "" && isETagInList(objInfo.ETag, ifNoneMatchETagHeader) {
+			// If the object ETag matches with the specified ETag.
+			return preconditionFailed()
+		}
+	}
+
+	// If the object doesn't have a modtime (IsZero), or the modtime
+	// is obviously garbage (Unix time == 0), then ignore modtimes
+	// and don't process the modified since headers.
+	if objInfo.ModTime.IsZero() || objInfo.ModTime.Equal(time.Unix(0, 0)) {
+		return false
+	}
+
 	// x-amz-copy-source-if-modified-since: Return the object only if it has been modified
 	// since the specified time otherwise return 412 (precondition failed).
 	ifModifiedSinceHeader := r.Header.Get("x-amz-copy-source-if-modified-since")
@@ -74,9 +104,7 @@ func checkCopyObjectPreconditions(w http.ResponseWriter, r *http.Request, objInf
 		if givenTime, err := time.Parse(http.TimeFormat, ifModifiedSinceHeader); err == nil {
 			if !ifModifiedSince(objInfo.ModTime, givenTime) {
 				// If the object is not modified since the specified time.
-				writeHeaders()
-				writeErrorResponse(w, ErrPreconditionFailed, r.URL)
-				return true
+				return preconditionFailed()
 			}
 		}
 	}
@@ -84,42 +112,60 @@ func checkCopyObjectPreconditions(w http.ResponseWriter, r *http.Request, objInf
 	// x-amz-copy-source-if-unmodified-since : Return the object only if it has not been
 	// modified since the specified time, otherwise return a 412 (precondition failed).
 	ifUnmodifiedSinceHeader := r.Header.Get("x-amz-copy-source-if-unmodified-since")
-	if ifUnmodifiedSinceHeader != "" {
+	if ifUnmodifiedSinceHeader != "" && ifMatchETagHeader == "" {
 		if givenTime, err := time.Parse(http.TimeFormat, ifUnmodifiedSinceHeader); err == nil {
 			if ifModifiedSince(objInfo.ModTime, givenTime) {
 				// If the object is modified since the specified time.
-				writeHeaders()
-				writeErrorResponse(w, ErrPreconditionFailed, r.URL)
-				return true
+				return preconditionFailed()
 			}
 		}
 	}
 
-	// x-amz-copy-source-if-match : Return the object only if its entity tag (ETag) is the
-	// same as the one specified; otherwise return a 412 (precondition failed).
-	ifMatchETagHeader := r.Header.Get("x-amz-copy-source-if-match")
-	if ifMatchETagHeader != "" {
-		if objInfo.ETag != "" && !isETagEqual(objInfo.ETag, ifMatchETagHeader) {
-			// If the object ETag does not match with the specified ETag.
-			writeHeaders()
-			writeErrorResponse(w, ErrPreconditionFailed, r.URL)
-			return true
-		}
+	// Object content should be written to http.ResponseWriter
+	return false
+}
+
+// putIfAbsentKey - context key marking uploads which create the object
+// only if it does not exist.
+type putIfAbsentKey struct{}
+
+// withPutIfAbsent - returns a context asking the object layer to create
+// the object only if it does not exist, which is checked while the object
+// is locked for writing.
+func withPutIfAbsent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, putIfAbsentKey{}, true)
+}
+
+// isPutIfAbsent - returns true if the context asks to create the object
+// only if it does not exist.
+func isPutIfAbsent(ctx context.Context) bool {
+	ifAbsent, _ := ctx.Value(putIfAbsentKey{}).(bool)
+	return ifAbsent
+}
+
+// checkPutObjectPreconditions - validates the preconditions of PutObject,
+// only `If-None-Match: *` is supported which creates the object if it
+// does not exist, the request fails with 412 (precondition failed) if
+// it does. Objects whose current version is a delete marker do not exist.
+// The returned context carries the precondition to the object layer, the
+// existence is checked here as well to fail before the upload is read.
+func checkPutObjectPreconditions(ctx context.Context, objectAPI ObjectLayer, r *http.Request, bucket, object string) (context.Context, APIErrorCode) {
+	ifNoneMatch, ok := r.Header["If-None-Match"]
+	if !ok {
+		return ctx, ErrNone
+	}
+	if len(ifNoneMatch) != 1 || strings.TrimSpace(ifNoneMatch[0]) != "*" {
+		return ctx, ErrNotImplemented
 	}
 
-	// If-None-Match : Return the object only if its entity tag (ETag) is different from the
-	// one specified otherwise, return a 304 (not modified).
-	ifNoneMatchETagHeader := r.Header.Get("x-amz-copy-source-if-none-match")
-	if ifNoneMatchETagHeader != "" {
-		if objInfo.ETag != "" && isETagEqual(objInfo.ETag, ifNoneMatchETagHeader) {
-			// If the object ETag matches with the specified ETag.
-			writeHeaders()
-			writeErrorResponse(w, ErrPreconditionFailed, r.URL)
-			return true
-		}
+	_, err := objectAPI.GetObjectInfo(ctx, bucket, object)
+	switch err.(type) {
+	case nil:
+		return ctx, ErrPreconditionFailed
+	case ObjectNotFound, MethodNotAllowed:
+		return withPutIfAbsent(ctx), ErrNone
 	}
-	// Object content should be written to http.ResponseWriter
-	return false
+	return ctx, toAPIErrorCode(err)
 }
 
 // Validates the preconditions. Returns true if GET/HEAD operation should not proceed.
@@ -230,6 +276,18 @@ func isETagEqual(left, right string) bool {
 	return canonicalizeETag(left) == canonicalizeETag(right)
 }
 
+// isETagInList returns true if the ETag is one of the comma separated
+// ETags of a conditional header, or if the header is the "*" wildcard.
+func isETagInList(etag, list string) bool {
+	for _, listETag := range strings.Split(list, ",") {
+		listETag = strings.TrimSpace(listETag)
+		if listETag == "*" || isETagEqual(etag, listETag) {
+			return true
+		}
+	}
+	return false
+}
+
 // deleteObject is a convenient wrapper to delete an object, this
 // is a common function to be called from object handlers and
 // web handlers.
@@ -243,6 +301,9 @@ func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer,
 		return err
 	}
 
+	// The ACL of the object is not kept for a later one of the same name.
+	logger.LogIf(ctx, updateObjectACL(ctx, obj, bucket, object, objectACLPrivate))
+
 	// Get host and port from Request.RemoteAddr.
 	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
 
@@ -261,3 +322,49 @@ func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer,
 
 	return nil
 }
+
+// deleteObjectVersion - deletes an object of a versioned bucket. Without
+// a version ID the current version is kept as a noncurrent version and a
+// delete marker is added, otherwise the version is permanently removed.
+// Returns the delete marker or the removed version. The request is nil
+// for objects deleted by the server itself.
+func deleteObjectVersion(ctx context.Context, obj ObjectLayer, bucket, object, versionID string, r *http.Request) (objInfo ObjectInfo, err error) {
+	if versionID == "" {
+		markerVersionID, _ := newObjectVersionID(bucket)
+		objInfo, err = obj.PutDeleteMarker(ctx, bucket, object, markerVersionID)
+	} else {
+		objInfo, err = obj.DeleteObjectVersion(ctx, bucket, object, versionID)
+	}
+	if err != nil {
+		return objInfo, err
+	}
+	if versionID == "" {
+		// The ACL belongs to the current version, which the delete
+		// marker replaces.
+		logger.LogIf(ctx, updateObjectACL(ctx, obj, bucket, object, objectACLPrivate))
+	}
+
+	// Get host and port from Request.RemoteAddr, objects deleted by
+	// the server itself have no request.
+	var host, port, userAgent string
+	if r != nil {
+		host, port, _ = net.SplitHostPort(handlers.GetSourceIP(r))
+		userAgent = r.UserAgent()
+	}
+
+	// Notify object deleted event.
+	sendEvent(eventArgs{
+		EventName:  event.ObjectRemovedDelete,
+		BucketName: bucket,
+		Object: ObjectInfo{
+			Name:      object,
+			VersionID: objInfo.VersionID,
+		},
+		ReqParams: extractReqParams(r),
+		UserAgent: userAgent,
+		Host:      host,
+		Port:      port,
+	})
+
+	return objInfo, nil
+}
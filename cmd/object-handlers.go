@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 	goioutil "io/ioutil"
+	"mime/multipart"
 	"net"
 	"net/http"
 	"net/url"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/gorilla/mux"
 	miniogo "github.com/minio/minio-go"
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/dns"
 	"github.com/minio/minio/pkg/event"
@@ -63,6 +65,82 @@ func setHeadGetRespHeaders(w http.ResponseWriter, reqParams url.Values) {
 	}
 }
 
+// writeObjectRanges - writes the ranges of an object as a
+// multipart/byteranges response. Encrypted objects are decrypted range
+// by range, every range with its own copy of the sealed key and the
+// customer key since decryption removes both.
+func writeObjectRanges(ctx context.Context, w http.ResponseWriter, r *http.Request, getObject func(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string) error,
+	bucket, object string, objInfo ObjectInfo, hranges []*httpRange, encrypted bool) error {
+	customerKey := r.Header.Get(SSECustomerKey)
+	metadata := objInfo.UserDefined
+	decryptInfo := func() ObjectInfo {
+		info := objInfo
+		info.UserDefined = make(map[string]string, len(metadata))
+		for k, v := range metadata {
+			info.UserDefined[k] = v
+		}
+		if customerKey != "" {
+			r.Header.Set(SSECustomerKey, customerKey)
+		}
+		return info
+	}
+
+	if encrypted {
+		// Check the keys before any part of the response is written.
+		if _, _, _, err := DecryptBlocksRequest(goioutil.Discard, r, bucket, object, 0, objInfo.Size, decryptInfo(), false); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return err
+		}
+
+		if customerKey != "" {
+			w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
+			w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+		}
+	}
+
+	setObjectHeaders(w, objInfo, nil)
+	setHeadGetRespHeaders(w, r.URL.Query())
+	// The checksum is of the whole object, not of the byte ranges.
+	if checksumHeader, _ := getObjectChecksum(objInfo.UserDefined); checksumHeader != "" {
+		w.Header().Del(checksumHeader)
+	}
+
+	mw := multipart.NewWriter(w)
+	contentType := w.Header().Get("Content-Type")
+	w.Header().Set("Content-Type", "multipart/byteranges; boundary="+mw.Boundary())
+	w.Header().Set("Content-Length", strconv.FormatInt(getRangesMIMESize(hranges, contentType, mw.Boundary()), 10))
+	w.WriteHeader(http.StatusPartialContent)
+
+	for _, hrange := range hranges {
+		part, err := mw.CreatePart(hrange.mimeHeader(contentType))
+		if err != nil {
+			return err
+		}
+
+		startOffset, length := hrange.offsetBegin, hrange.getLength()
+		writer := part
+		if encrypted {
+			writer = ioutil.LimitedWriter(writer, startOffset%(64*1024), length)
+			if writer, startOffset, length, err = DecryptBlocksRequest(writer, r, bucket, object, startOffset, length, decryptInfo(), false); err != nil {
+				logger.LogIf(ctx, err)
+				return err
+			}
+		}
+
+		if err = getObject(ctx, bucket, object, startOffset, length, writer, objInfo.ETag); err == nil {
+			if closer, ok := writer.(io.Closer); ok {
+				err = closer.Close()
+			}
+		}
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return err
+		}
+	}
+
+	return mw.Close()
+}
+
 // GetObjectHandler - GET Object
 // ----------
 // This implementation of the GET operation retrieves object. To use GET,
@@ -82,12 +160,28 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Parts of objects are only known by the object layer.
+	partNumber := r.URL.Query().Get("partNumber")
 	getObjectInfo := objectAPI.GetObjectInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && partNumber == "" {
 		getObjectInfo = api.CacheAPI().GetObjectInfo
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+	// Versions other than the current version are read from the object layer.
+	var action policy.Action = policy.GetObjectAction
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" {
+		if !isValidVersionID(versionID) {
+			writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+			return
+		}
+		action = policy.GetObjectVersionAction
+		getObjectInfo = func(ctx context.Context, bucket, object string) (ObjectInfo, error) {
+			return objectAPI.GetObjectVersionInfo(ctx, bucket, object, versionID)
+		}
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, action, bucket, object); s3Error != ErrNone {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGET.html
 			// If the object you request does not exist, the error Amazon S3 returns depends on whether you also have the s3:ListBucket permission.
@@ -115,18 +209,29 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Delete markers have no content.
+	if objInfo.DeleteMarker {
+		setObjectVersionHeaders(w, objInfo)
+		writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	var encrypted bool
 	if objectAPI.IsEncryptionSupported() {
-		if apiErr, _ := DecryptObjectInfo(&objInfo, r.Header); apiErr != ErrNone {
+		var apiErr APIErrorCode
+		if apiErr, encrypted = DecryptObjectInfo(&objInfo, r.Header); apiErr != ErrNone {
 			writeErrorResponse(w, apiErr, r.URL)
 			return
 		}
 	}
 
-	// Get request range.
+	// Get request ranges, a single range is sent as is and multiple
+	// ranges as a multipart/byteranges response.
 	var hrange *httpRange
+	var hranges []*httpRange
 	rangeHeader := r.Header.Get("Range")
 	if rangeHeader != "" {
-		if hrange, err = parseRequestRange(rangeHeader, objInfo.Size); err != nil {
+		if hranges, err = parseRequestRanges(rangeHeader, objInfo.Size); err != nil {
 			// Handle only errInvalidRange
 			// Ignore other parse error and treat it as regular Get request like Amazon S3.
 			if err == errInvalidRange {
@@ -137,6 +242,23 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 			// log the error.
 			logger.LogIf(ctx, err)
 		}
+		if len(hranges) == 1 {
+			hrange = hranges[0]
+		}
+	}
+
+	// Get the byte range of a requested part.
+	var partsCount int
+	if partNumber != "" {
+		if rangeHeader != "" {
+			writeErrorResponse(w, ErrRangeWithPartNumber, r.URL)
+			return
+		}
+		var apiErr APIErrorCode
+		if hrange, partsCount, apiErr = getPartNumberRange(partNumber, objInfo); apiErr != ErrNone {
+			writeErrorResponse(w, apiErr, r.URL)
+			return
+		}
 	}
 
 	// Validate pre-conditions if any.
@@ -144,18 +266,31 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Get the object.
-	var startOffset int64
-	length := objInfo.Size
-	if hrange != nil {
-		startOffset = hrange.offsetBegin
-		length = hrange.getLength()
+	getObject := objectAPI.GetObject
+	if versionID != "" {
+		getObject = func(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string) error {
+			return objectAPI.GetObjectVersion(ctx, bucket, object, versionID, startOffset, length, writer, etag)
+		}
+	} else if api.CacheAPI() != nil && !encrypted && partNumber == "" {
+		getObject = api.CacheAPI().GetObject
 	}
 
-	var writer io.Writer
-	writer = w
-	if objectAPI.IsEncryptionSupported() {
-		if hasSSECustomerHeader(r.Header) {
+	if len(hranges) > 1 {
+		if err = writeObjectRanges(ctx, w, r, getObject, bucket, object, objInfo, hranges, encrypted); err != nil {
+			return
+		}
+	} else {
+		// Get the object.
+		var startOffset int64
+		length := objInfo.Size
+		if hrange != nil {
+			startOffset = hrange.offsetBegin
+			length = hrange.getLength()
+		}
+
+		var writer io.Writer
+		writer = w
+		if encrypted {
 			// Response writer should be limited early on for decryption upto required length,
 			// additionally also skipping mod(offset)64KiB boundaries.
 			writer = ioutil.LimitedWriter(writer, startOffset%(64*1024), length)
@@ -166,34 +301,35 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 				return
 			}
 
-			w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
-			w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+			if hasSSECustomerHeader(r.Header) {
+				w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
+				w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+			}
 		}
-	}
-
-	setObjectHeaders(w, objInfo, hrange)
-	setHeadGetRespHeaders(w, r.URL.Query())
-	httpWriter := ioutil.WriteOnClose(writer)
-
-	getObject := objectAPI.GetObject
-	if api.CacheAPI() != nil && !hasSSECustomerHeader(r.Header) {
-		getObject = api.CacheAPI().GetObject
-	}
 
-	// Reads the object at startOffset and writes to mw.
-	if err = getObject(ctx, bucket, object, startOffset, length, httpWriter, objInfo.ETag); err != nil {
-		if !httpWriter.HasWritten() { // write error response only if no data has been written to client yet
-			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		if partsCount > 0 {
+			w.Header().Set(amzMpPartsCount, strconv.Itoa(partsCount))
 		}
-		httpWriter.Close()
-		return
-	}
-
-	if err = httpWriter.Close(); err != nil {
-		if !httpWriter.HasWritten() { // write error response only if no data has been written to client yet
-			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		setObjectHeaders(w, objInfo, hrange)
+		setHeadGetRespHeaders(w, r.URL.Query())
+		writePartialContentStatus(w, hrange)
+		httpWriter := ioutil.WriteOnClose(writer)
+
+		// Reads the object at startOffset and writes to mw.
+		if err = getObject(ctx, bucket, object, startOffset, length, httpWriter, objInfo.ETag); err != nil {
+			if !httpWriter.HasWritten() { // write error response only if no data has been written to client yet
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			}
+			httpWriter.Close()
 			return
 		}
+
+		if err = httpWriter.Close(); err != nil {
+			if !httpWriter.HasWritten() { // write error response only if no data has been written to client yet
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
+		}
 	}
 
 	// Get host and port from Request.RemoteAddr.
@@ -231,12 +367,28 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Parts of objects are only known by the object layer.
+	partNumber := r.URL.Query().Get("partNumber")
 	getObjectInfo := objectAPI.GetObjectInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && partNumber == "" {
 		getObjectInfo = api.CacheAPI().GetObjectInfo
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+	// Versions other than the current version are read from the object layer.
+	var action policy.Action = policy.GetObjectAction
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" {
+		if !isValidVersionID(versionID) {
+			writeErrorResponseHeadersOnly(w, ErrInvalidVersionID)
+			return
+		}
+		action = policy.GetObjectVersionAction
+		getObjectInfo = func(ctx context.Context, bucket, object string) (ObjectInfo, error) {
+			return objectAPI.GetObjectVersionInfo(ctx, bucket, object, versionID)
+		}
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, action, bucket, object); s3Error != ErrNone {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectHEAD.html
 			// If the object you request does not exist, the error Amazon S3 returns depends on whether you also have the s3:ListBucket permission.
@@ -264,6 +416,13 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Delete markers have no content.
+	if objInfo.DeleteMarker {
+		setObjectVersionHeaders(w, objInfo)
+		writeErrorResponseHeadersOnly(w, ErrMethodNotAllowed)
+		return
+	}
+
 	if objectAPI.IsEncryptionSupported() {
 		if apiErr, encrypted := DecryptObjectInfo(&objInfo, r.Header); apiErr != ErrNone {
 			writeErrorResponse(w, apiErr, r.URL)
@@ -273,8 +432,25 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 				return
 			}
-			w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
-			w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+			if hasSSECustomerHeader(r.Header) {
+				w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
+				w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+			}
+		}
+	}
+
+	// Get the byte range of a requested part.
+	var hrange *httpRange
+	var partsCount int
+	if partNumber != "" {
+		if r.Header.Get("Range") != "" {
+			writeErrorResponseHeadersOnly(w, ErrRangeWithPartNumber)
+			return
+		}
+		var apiErr APIErrorCode
+		if hrange, partsCount, apiErr = getPartNumberRange(partNumber, objInfo); apiErr != ErrNone {
+			writeErrorResponseHeadersOnly(w, apiErr)
+			return
 		}
 	}
 
@@ -283,14 +459,23 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Set standard object headers.
-	setObjectHeaders(w, objInfo, nil)
+	if partsCount > 0 {
+		w.Header().Set(amzMpPartsCount, strconv.Itoa(partsCount))
+	}
+
+	// Set standard object headers, a requested part is sent as a
+	// partial content response.
+	setObjectHeaders(w, objInfo, hrange)
 
 	// Set any additional requested response headers.
 	setHeadGetRespHeaders(w, r.URL.Query())
 
 	// Successful response.
-	w.WriteHeader(http.StatusOK)
+	if hrange == nil {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		writePartialContentStatus(w, hrange)
+	}
 
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
@@ -326,6 +511,13 @@ func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, userMeta m
 		return extractMetadata(ctx, r)
 	}
 
+	// The website redirect location of the source object is not
+	// copied, it is only set by the request header.
+	delete(defaultMeta, amzWebsiteRedirectLocation)
+	if location := r.Header.Get(amzWebsiteRedirectLocation); location != "" {
+		defaultMeta[amzWebsiteRedirectLocation] = location
+	}
+
 	// if x-amz-metadata-directive says COPY then we
 	// return the default metadata.
 	if isMetadataCopy(r.Header) {
@@ -359,32 +551,78 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	// TODO: Reject requests where body/payload is present, for now we don't even read it.
 
-	// Copy source path.
-	cpSrcPath, err := url.QueryUnescape(r.Header.Get("X-Amz-Copy-Source"))
-	if err != nil {
-		// Save unescaped string as is.
-		cpSrcPath = r.Header.Get("X-Amz-Copy-Source")
-	}
-
-	srcBucket, srcObject := path2BucketAndObject(cpSrcPath)
+	srcBucket, srcObject, srcVersionID := getCopySource(r.Header)
 	// If source object is empty or bucket is empty, reply back invalid copy source.
 	if srcObject == "" || srcBucket == "" {
 		writeErrorResponse(w, ErrInvalidCopySource, r.URL)
 		return
 	}
 
+	// The source object must be readable by the request too.
+	if !isCopySourceAllowed(r, srcBucket, srcObject, srcVersionID) {
+		writeErrorResponse(w, ErrAccessDenied, r.URL)
+		return
+	}
+	// Only the latest version of an object can be copied.
+	if srcVersionID != "" {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	acl, s3Error := getUploadACL(r, dstBucket, dstObject)
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
 	// Check if metadata directive is valid.
 	if !isMetadataDirectiveValid(r.Header) {
 		writeErrorResponse(w, ErrInvalidMetadataDirective, r.URL)
 		return
 	}
 
-	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
-	srcInfo, err := objectAPI.GetObjectInfo(ctx, srcBucket, srcObject)
+	// Validate storage class metadata if present
+	if _, ok := r.Header[amzStorageClassCanonical]; ok {
+		if !isValidStorageClassMeta(r.Header.Get(amzStorageClassCanonical)) {
+			writeErrorResponse(w, ErrInvalidStorageClass, r.URL)
+			return
+		}
+	}
+
+	// Validate website redirect location metadata if present
+	if s3Error := checkWebsiteRedirectLocation(r.Header); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	// Check if tagging directive is valid.
+	if !isTaggingDirectiveValid(r.Header) {
+		writeErrorResponse(w, ErrInvalidTaggingDirective, r.URL)
+		return
+	}
+
+	// The source object is read from a remote bucket if a copy target of
+	// the destination bucket is given.
+	var srcInfo ObjectInfo
+	var srcReader io.ReadCloser
+	var err error
+	if arn := r.Header.Get(minioCopySourceTarget); arn != "" {
+		target, ok := getCopySourceTarget(arn, dstBucket, srcBucket)
+		if !ok {
+			writeErrorResponse(w, ErrInvalidCopySourceTarget, r.URL)
+			return
+		}
+		if srcReader, srcInfo, err = getRemoteCopySource(ctx, target, srcObject); err == nil {
+			defer srcReader.Close()
+		}
+	} else {
+		srcInfo, err = objectAPI.GetObjectInfo(ctx, srcBucket, srcObject)
+	}
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+	cpSrcDstSame := srcReader == nil && isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
@@ -436,10 +674,16 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	size := srcInfo.Size
 
 	var encMetadata = make(map[string]string)
+	var srcDecrypted bool
 	if objectAPI.IsEncryptionSupported() {
 		var oldKey, newKey []byte
 		sseCopyC := hasSSECopyCustomerHeader(r.Header)
 		sseC := hasSSECustomerHeader(r.Header)
+		// A copy onto its source is a metadata update, objects encrypted
+		// with SSE-S3 keep their data, sealed key and encryption headers.
+		sseS3Src := crypto.S3.IsEncrypted(srcInfo.UserDefined)
+		encryption, sseS3 := getBucketEncryption(dstBucket)
+		sseS3 = sseS3 && !sseC && !cpSrcDstSame
 		if sseC {
 			newKey, err = ParseSSECustomerRequest(r)
 			if err != nil {
@@ -470,8 +714,14 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 			// Since we are rotating the keys, make sure to update the metadata.
 			srcInfo.metadataOnly = true
+		} else if sseS3Src && cpSrcDstSame {
+			for k, v := range srcInfo.UserDefined {
+				if hasPrefix(k, ReservedMetadataPrefix+"Server-Side-Encryption") || k == crypto.SSEMultipart || k == crypto.SSEHeader || k == amzServerSideEncryptionKMSKeyID {
+					encMetadata[k] = v
+				}
+			}
 		} else {
-			if sseCopyC {
+			if sseCopyC || sseS3Src {
 				// Source is encrypted make sure to save the encrypted size.
 				writer = ioutil.LimitedWriter(writer, 0, srcInfo.Size)
 				writer, srcInfo.Size, err = DecryptAllBlocksCopyRequest(writer, r, srcBucket, srcObject, srcInfo)
@@ -484,12 +734,17 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 				// we are creating a new object at this point, even
 				// if source and destination are same objects.
 				srcInfo.metadataOnly = false
-				if sseC {
+				srcDecrypted = true
+				if sseC || sseS3 {
 					size = srcInfo.Size
 				}
 			}
-			if sseC {
-				reader, err = newEncryptReader(reader, newKey, dstBucket, dstObject, encMetadata)
+			if sseC || sseS3 {
+				if sseC {
+					reader, err = newEncryptReader(reader, newKey, dstBucket, dstObject, encMetadata)
+				} else {
+					reader, err = newBucketEncryptReader(reader, encryption, dstBucket, dstObject, encMetadata)
+				}
 				if err != nil {
 					pipeWriter.CloseWithError(err)
 					writeErrorResponse(w, toAPIErrorCode(err), r.URL)
@@ -499,7 +754,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 				// we are creating a new object at this point, even
 				// if source and destination are same objects.
 				srcInfo.metadataOnly = false
-				if !sseCopyC {
+				if !srcDecrypted {
 					size = srcInfo.EncryptedSize()
 				}
 			}
@@ -513,6 +768,12 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 	srcInfo.Writer = writer
 
+	srcVersionID, srcVersioned := srcInfo.UserDefined[objectVersionIDKey]
+	srcRetention, srcRetained := getObjectRetention(srcInfo.UserDefined)
+	srcLegalHold, srcHeld := getObjectLegalHold(srcInfo.UserDefined)
+	srcTags := srcInfo.UserDefined[objectTaggingKey]
+	srcTransition, _ := getObjectTransition(srcInfo.UserDefined)
+	srcStorageClass := srcInfo.UserDefined[amzStorageClass]
 	srcInfo.UserDefined, err = getCpObjMetadataFromHeader(ctx, r, srcInfo.UserDefined)
 	if err != nil {
 		pipeWriter.CloseWithError(err)
@@ -520,12 +781,71 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// The storage class of the source object is kept unless replaced by
+	// the x-amz-storage-class header. The data of an object is written
+	// again when its storage class changes, to use the parity of the
+	// new storage class.
+	if sc := r.Header.Get(amzStorageClassCanonical); sc != "" {
+		srcInfo.UserDefined[amzStorageClass] = sc
+	}
+	if srcInfo.metadataOnly && !isStorageClassEqual(srcStorageClass, srcInfo.UserDefined[amzStorageClass]) {
+		if srcInfo.IsEncrypted() {
+			pipeWriter.CloseWithError(fmt.Errorf("storage class change of encrypted object"))
+			writeErrorResponse(w, ErrNotImplemented, r.URL)
+			return
+		}
+		srcInfo.metadataOnly = false
+	}
+
+	// The copy of a decrypted source is not reported as encrypted, unless
+	// encrypted again.
+	if srcDecrypted {
+		removeBucketEncryptMetadata(srcInfo.UserDefined)
+	}
+
 	// We need to preserve the encryption headers set in EncryptRequest,
 	// so we do not want to override them, copy them instead.
 	for k, v := range encMetadata {
 		srcInfo.UserDefined[k] = v
 	}
 
+	// A copy is a new version of the destination object, unless only the
+	// metadata of the source object is replaced. The data of a copy is
+	// stored locally.
+	if srcInfo.metadataOnly {
+		setObjectTransition(srcInfo.UserDefined, srcTransition)
+		if srcVersioned {
+			srcInfo.UserDefined[objectVersionIDKey] = srcVersionID
+		}
+		if srcRetained {
+			setObjectRetention(srcInfo.UserDefined, srcRetention)
+		}
+		if srcHeld {
+			setObjectLegalHold(srcInfo.UserDefined, srcLegalHold)
+		}
+	} else {
+		setObjectTransition(srcInfo.UserDefined, ObjectTransition{})
+		setObjectVersionID(dstBucket, srcInfo.UserDefined)
+		if s3Error := setObjectLockMetadata(dstBucket, r.Header, srcInfo.UserDefined); s3Error != ErrNone {
+			pipeWriter.CloseWithError(errInvalidRetention)
+			writeErrorResponse(w, s3Error, r.URL)
+			return
+		}
+	}
+
+	// The tags of the source object are copied unless replaced by the
+	// x-amz-tagging header.
+	if isTaggingReplace(r.Header) {
+		if s3Error := setObjectTaggingMetadata(objectAPI, dstBucket, r.Header, srcInfo.UserDefined); s3Error != ErrNone {
+			pipeWriter.CloseWithError(fmt.Errorf("invalid tagging"))
+			writeErrorResponse(w, s3Error, r.URL)
+			return
+		}
+	} else {
+		setObjectTags(srcInfo.UserDefined, srcTags)
+	}
+	setObjectReplicationMetadata(objectAPI, r, r.Header, dstBucket, dstObject, srcInfo.UserDefined)
+
 	// Check if x-amz-metadata-directive was not set to REPLACE and source,
 	// desination are same objects. Apply this restriction also when
 	// metadataOnly is true indicating that we are not overwriting the object.
@@ -563,7 +883,35 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		return miniogo.NewCore(endpoint, accessKey, secretKey, globalIsSSL)
 	}
 
-	if isRemoteCallRequired(ctx, srcBucket, dstBucket, objectAPI) {
+	if srcReader != nil {
+		go func() {
+			if _, gerr := io.Copy(srcInfo.Writer, srcReader); gerr != nil {
+				pipeWriter.CloseWithError(gerr)
+				return
+			}
+			// Close writer explicitly to indicate data has been written
+			srcInfo.Writer.Close()
+		}()
+
+		// Check the ACL fits in the bucket policy before writing the object.
+		aclUpdate, err := newObjectACLUpdate(ctx, objectAPI, dstBucket, dstObject, acl)
+		if err != nil {
+			pipeWriter.CloseWithError(err)
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+		defer aclUpdate.Unlock()
+
+		// The copy of a remote object is written as a new object.
+		objInfo, err = objectAPI.PutObject(ctx, dstBucket, dstObject, srcInfo.Reader, srcInfo.UserDefined)
+		if err != nil {
+			pipeWriter.CloseWithError(err)
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+		logger.LogIf(ctx, aclUpdate.Apply(ctx, objectAPI))
+		aclUpdate.Unlock()
+	} else if isRemoteCallRequired(ctx, srcBucket, dstBucket, objectAPI) {
 		if globalDNSConfig == nil {
 			writeErrorResponse(w, ErrNoSuchBucket, r.URL)
 			return
@@ -598,6 +946,15 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			objInfo.ModTime = remoteObjInfo.LastModified
 		}
 	} else {
+		// Check the ACL fits in the bucket policy before writing the object.
+		aclUpdate, err := newObjectACLUpdate(ctx, objectAPI, dstBucket, dstObject, acl)
+		if err != nil {
+			pipeWriter.CloseWithError(err)
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+		defer aclUpdate.Unlock()
+
 		// Copy source object to destination, if source and destination
 		// object is same then only metadata is updated.
 		objInfo, err = objectAPI.CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo)
@@ -606,6 +963,8 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 			return
 		}
+		logger.LogIf(ctx, aclUpdate.Apply(ctx, objectAPI))
+		aclUpdate.Unlock()
 	}
 
 	pipeReader.Close()
@@ -613,6 +972,11 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	response := generateCopyObjectResponse(objInfo.ETag, objInfo.ModTime)
 	encodedSuccessResponse := encodeResponse(response)
 
+	if objInfo.VersionID != "" {
+		w.Header().Set(amzVersionID, objInfo.VersionID)
+	}
+	setBucketEncryptionHeaders(w, objInfo.UserDefined)
+
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 
@@ -622,6 +986,8 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		host, port = "", ""
 	}
 
+	queueReplication(objInfo)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:  event.ObjectCreatedCopy,
@@ -664,6 +1030,31 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	// Validate website redirect location metadata if present
+	if s3Error := checkWebsiteRedirectLocation(r.Header); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	// Data is appended to the object at the append position, if any.
+	// Objects of versioned buckets and encrypted objects are not
+	// appended to.
+	appendPosition, s3Error := getAppendPosition(r.Header)
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	if appendPosition >= 0 {
+		if !objectAPI.IsAppendSupported() || getBucketVersioning(bucket) != "" || hasSSECustomerHeader(r.Header) {
+			writeErrorResponse(w, ErrNotImplemented, r.URL)
+			return
+		}
+		if _, ok := getBucketEncryption(bucket); ok {
+			writeErrorResponse(w, ErrNotImplemented, r.URL)
+			return
+		}
+	}
+
 	// Get Content-Md5 sent by client and verify if valid
 	md5Bytes, err := checkValidMD5(r.Header)
 	if err != nil {
@@ -698,6 +1089,12 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Maximum upload size signed in the request, if any.
+	if apiErr := checkMaxContentLength(r, size); apiErr != ErrNone {
+		writeErrorResponse(w, apiErr, r.URL)
+		return
+	}
+
 	metadata, err := extractMetadata(ctx, r)
 	if err != nil {
 		writeErrorResponse(w, ErrInternalError, r.URL)
@@ -724,6 +1121,25 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	setObjectVersionID(bucket, metadata)
+	if s3Error := setObjectLockMetadata(bucket, r.Header, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	if s3Error := setObjectTaggingMetadata(objectAPI, bucket, r.Header, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	setObjectReplicationMetadata(objectAPI, r, r.Header, bucket, object, metadata)
+	checksumAlgorithm, checksum, s3Error := getRequestChecksum(r.Header)
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	if checksumAlgorithm != "" {
+		setObjectChecksum(metadata, checksumAlgorithm, checksum)
+	}
+
 	var (
 		md5hex    = hex.EncodeToString(md5Bytes)
 		sha256hex = ""
@@ -763,7 +1179,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 
 	case authTypePresigned, authTypeSigned:
-		if s3Err = reqSignatureV4Verify(r, globalServerConfig.GetRegion()); s3Err != ErrNone {
+		if s3Err = reqSignatureV4Verify(r, getBucketRegion(bucket)); s3Err != ErrNone {
 			writeErrorResponse(w, s3Err, r.URL)
 			return
 		}
@@ -772,11 +1188,39 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	if !isPutObjectAllowed(r, bucket, object) {
+		writeErrorResponse(w, ErrAccessDenied, r.URL)
+		return
+	}
+
+	acl, s3Err := getUploadACL(r, bucket, object)
+	if s3Err != ErrNone {
+		writeErrorResponse(w, s3Err, r.URL)
+		return
+	}
+
+	if s3Err = checkRequestIntegrity(bucket, rAuthType, md5hex, sha256hex); s3Err != ErrNone {
+		writeErrorResponse(w, s3Err, r.URL)
+		return
+	}
+
 	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex)
 	if err != nil {
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+	if checksumAlgorithm != "" {
+		if err = hashReader.SetChecksum(checksumAlgorithm, checksum); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+	}
+
+	// Create the object only if it does not exist, with `If-None-Match: *`.
+	if ctx, s3Error = checkPutObjectPreconditions(ctx, objectAPI, r, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
@@ -799,13 +1243,40 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 				return
 			}
+		} else if encryption, ok := getBucketEncryption(bucket); ok && !hasSuffix(object, slashSeparator) { // handle default bucket encryption
+			reader, err = newBucketEncryptReader(hashReader, encryption, bucket, object, metadata)
+			if err != nil {
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
+			info := ObjectInfo{Size: size}
+			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "") // do not try to verify encrypted content
+			if err != nil {
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
 		}
 	}
 
-	if api.CacheAPI() != nil && !hasSSECustomerHeader(r.Header) {
+	if appendPosition >= 0 {
+		putObject = func(ctx context.Context, bucket, object string, data *hash.Reader, metadata map[string]string) (ObjectInfo, error) {
+			return objectAPI.AppendObject(ctx, bucket, object, appendPosition, data, metadata)
+		}
+	} else if api.CacheAPI() != nil && !crypto.IsEncrypted(metadata) {
 		putObject = api.CacheAPI().PutObject
 	}
 
+	// The replaced object loses its ACL, an appended one keeps it. Check
+	// the ACL fits in the bucket policy before writing the object.
+	aclUpdate := &objectACLUpdate{}
+	if appendPosition < 0 {
+		if aclUpdate, err = newObjectACLUpdate(ctx, objectAPI, bucket, object, acl); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+		defer aclUpdate.Unlock()
+	}
+
 	// Create the object..
 	objInfo, err := putObject(ctx, bucket, object, hashReader, metadata)
 	if err != nil {
@@ -813,12 +1284,21 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	logger.LogIf(ctx, aclUpdate.Apply(ctx, objectAPI))
+	aclUpdate.Unlock()
+
 	w.Header().Set("ETag", "\""+objInfo.ETag+"\"")
+	setObjectVersionHeaders(w, objInfo)
+	setObjectExpirationHeader(w, objInfo)
+	if checksumAlgorithm != "" {
+		w.Header().Set(getChecksumHeader(checksumAlgorithm), checksum)
+	}
 	if objectAPI.IsEncryptionSupported() {
 		if hasSSECustomerHeader(r.Header) {
 			w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
 			w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
 		}
+		setBucketEncryptionHeaders(w, metadata)
 	}
 
 	writeSuccessResponseHeadersOnly(w)
@@ -829,6 +1309,8 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		host, port = "", ""
 	}
 
+	queueReplication(objInfo)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:  event.ObjectCreatedPut,
@@ -879,6 +1361,12 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		}
 	}
 
+	// Validate website redirect location metadata if present
+	if s3Error := checkWebsiteRedirectLocation(r.Header); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
 	var encMetadata = map[string]string{}
 
 	if objectAPI.IsEncryptionSupported() {
@@ -897,6 +1385,12 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 			// Set this for multipart only operations, we need to differentiate during
 			// decryption if the file was actually multipart or not.
 			encMetadata[ReservedMetadataPrefix+"Encrypted-Multipart"] = ""
+		} else if encryption, ok := getBucketEncryption(bucket); ok {
+			if _, err := newBucketEncryptMetadata(encryption, bucket, object, encMetadata); err != nil {
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
+			encMetadata[ReservedMetadataPrefix+"Encrypted-Multipart"] = ""
 		}
 	}
 
@@ -913,6 +1407,27 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		metadata[k] = v
 	}
 
+	// The ACL is applied once the upload is completed.
+	acl, s3Error := getUploadACL(r, bucket, object)
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	if acl == objectACLPublicRead {
+		metadata[objectACLKey] = acl
+	}
+
+	setObjectVersionID(bucket, metadata)
+	if s3Error := setObjectLockMetadata(bucket, r.Header, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	if s3Error := setObjectTaggingMetadata(objectAPI, bucket, r.Header, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	setObjectReplicationMetadata(objectAPI, r, r.Header, bucket, object, metadata)
+
 	newMultipartUpload := objectAPI.NewMultipartUpload
 	if api.CacheAPI() != nil {
 		newMultipartUpload = api.CacheAPI().NewMultipartUpload
@@ -925,6 +1440,11 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 
 	response := generateInitiateMultipartUploadResponse(bucket, object, uploadID)
 	encodedSuccessResponse := encodeResponse(response)
+	if hasSSECustomerHeader(r.Header) {
+		w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
+		w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+	}
+	setBucketEncryptionHeaders(w, metadata)
 
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
@@ -949,20 +1469,24 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// Copy source path.
-	cpSrcPath, err := url.QueryUnescape(r.Header.Get("X-Amz-Copy-Source"))
-	if err != nil {
-		// Save unescaped string as is.
-		cpSrcPath = r.Header.Get("X-Amz-Copy-Source")
-	}
-
-	srcBucket, srcObject := path2BucketAndObject(cpSrcPath)
+	srcBucket, srcObject, srcVersionID := getCopySource(r.Header)
 	// If source object is empty or bucket is empty, reply back invalid copy source.
 	if srcObject == "" || srcBucket == "" {
 		writeErrorResponse(w, ErrInvalidCopySource, r.URL)
 		return
 	}
 
+	// The source object must be readable by the request too.
+	if !isCopySourceAllowed(r, srcBucket, srcObject, srcVersionID) {
+		writeErrorResponse(w, ErrAccessDenied, r.URL)
+		return
+	}
+	// Only the latest version of an object can be copied.
+	if srcVersionID != "" {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
 	uploadID := r.URL.Query().Get("uploadId")
 	partIDString := r.URL.Query().Get("partNumber")
 
@@ -1052,8 +1576,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 			return
 		}
-		sseCopyC := hasSSECopyCustomerHeader(r.Header)
-		if sseCopyC {
+		if srcInfo.IsEncrypted() {
 			// Response writer should be limited early on for decryption upto required length,
 			// additionally also skipping mod(offset)64KiB boundaries.
 			writer = ioutil.LimitedWriter(writer, startOffset%(64*1024), length)
@@ -1065,20 +1588,24 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 			}
 		}
 		if li.IsEncrypted() {
-			if !hasSSECustomerHeader(r.Header) {
-				writeErrorResponse(w, ErrSSEMultipartEncrypted, r.URL)
-				return
-			}
-			var key []byte
-			key, err = ParseSSECustomerRequest(r)
-			if err != nil {
-				pipeWriter.CloseWithError(err)
-				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
-				return
-			}
-
 			var objectEncryptionKey []byte
-			objectEncryptionKey, err = decryptObjectInfo(key, dstBucket, dstObject, li.UserDefined)
+			if crypto.S3.IsEncrypted(li.UserDefined) {
+				objectEncryptionKey, err = decryptObjectInfoS3(dstBucket, dstObject, li.UserDefined)
+			} else {
+				if !hasSSECustomerHeader(r.Header) {
+					pipeWriter.CloseWithError(errEncryptedObject)
+					writeErrorResponse(w, ErrSSEMultipartEncrypted, r.URL)
+					return
+				}
+				var key []byte
+				key, err = ParseSSECustomerRequest(r)
+				if err != nil {
+					pipeWriter.CloseWithError(err)
+					writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+					return
+				}
+				objectEncryptionKey, err = decryptObjectInfo(key, dstBucket, dstObject, li.UserDefined)
+			}
 			if err != nil {
 				pipeWriter.CloseWithError(err)
 				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
@@ -1124,6 +1651,10 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 
 	response := generateCopyObjectPartResponse(partInfo.ETag, partInfo.LastModified)
 	encodedSuccessResponse := encodeResponse(response)
+	if hasSSECustomerHeader(r.Header) {
+		w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
+		w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+	}
 
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
@@ -1184,6 +1715,12 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Maximum upload size signed in the request, if any.
+	if apiErr := checkMaxContentLength(r, size); apiErr != ErrNone {
+		writeErrorResponse(w, apiErr, r.URL)
+		return
+	}
+
 	uploadID := r.URL.Query().Get("uploadId")
 	partIDString := r.URL.Query().Get("partNumber")
 
@@ -1199,6 +1736,12 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	checksumAlgorithm, checksum, s3Error := getRequestChecksum(r.Header)
+	if s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
 	var (
 		md5hex    = hex.EncodeToString(md5Bytes)
 		sha256hex = ""
@@ -1237,7 +1780,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 			return
 		}
 	case authTypePresigned, authTypeSigned:
-		if s3Error := reqSignatureV4Verify(r, globalServerConfig.GetRegion()); s3Error != ErrNone {
+		if s3Error := reqSignatureV4Verify(r, getBucketRegion(bucket)); s3Error != ErrNone {
 			writeErrorResponse(w, s3Error, r.URL)
 			return
 		}
@@ -1247,12 +1790,28 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		}
 	}
 
+	if !isPutObjectAllowed(r, bucket, object) {
+		writeErrorResponse(w, ErrAccessDenied, r.URL)
+		return
+	}
+
+	if s3Error := checkRequestIntegrity(bucket, rAuthType, md5hex, sha256hex); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
 	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex)
 	if err != nil {
 		// Verify if the underlying error is signature mismatch.
 		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		return
 	}
+	if checksumAlgorithm != "" {
+		if err = hashReader.SetChecksum(checksumAlgorithm, checksum); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+	}
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
@@ -1270,20 +1829,23 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 			return
 		}
 		if li.IsEncrypted() {
-			if !hasSSECustomerHeader(r.Header) {
-				writeErrorResponse(w, ErrSSEMultipartEncrypted, r.URL)
-				return
-			}
-			var key []byte
-			key, err = ParseSSECustomerRequest(r)
-			if err != nil {
-				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
-				return
-			}
-
 			// Calculating object encryption key
 			var objectEncryptionKey []byte
-			objectEncryptionKey, err = decryptObjectInfo(key, bucket, object, li.UserDefined)
+			if crypto.S3.IsEncrypted(li.UserDefined) {
+				objectEncryptionKey, err = decryptObjectInfoS3(bucket, object, li.UserDefined)
+			} else {
+				if !hasSSECustomerHeader(r.Header) {
+					writeErrorResponse(w, ErrSSEMultipartEncrypted, r.URL)
+					return
+				}
+				var key []byte
+				key, err = ParseSSECustomerRequest(r)
+				if err != nil {
+					writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+					return
+				}
+				objectEncryptionKey, err = decryptObjectInfo(key, bucket, object, li.UserDefined)
+			}
 			if err != nil {
 				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 				return
@@ -1324,6 +1886,13 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	if partInfo.ETag != "" {
 		w.Header().Set("ETag", "\""+partInfo.ETag+"\"")
 	}
+	if checksumAlgorithm != "" {
+		w.Header().Set(getChecksumHeader(checksumAlgorithm), checksum)
+	}
+	if hasSSECustomerHeader(r.Header) {
+		w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
+		w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+	}
 
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -1455,6 +2024,13 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		writeErrorResponse(w, ErrInvalidPartOrder, r.URL)
 		return
 	}
+	// Parts are listed once, in ascending order of part numbers.
+	for i := 1; i < len(complMultipartUpload.Parts); i++ {
+		if complMultipartUpload.Parts[i].PartNumber == complMultipartUpload.Parts[i-1].PartNumber {
+			writeErrorResponse(w, ErrInvalidPartOrder, r.URL)
+			return
+		}
+	}
 
 	// Complete parts.
 	var completeParts []CompletePart
@@ -1463,6 +2039,24 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		completeParts = append(completeParts, part)
 	}
 
+	// The canned ACL requested when initiating the upload is applied to
+	// the object, check it fits in the bucket policy before completing.
+	li, err := objectAPI.ListObjectParts(ctx, bucket, object, uploadID, 0, 1)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	acl := objectACLPrivate
+	if li.UserDefined[objectACLKey] == objectACLPublicRead {
+		acl = objectACLPublicRead
+	}
+	aclUpdate, err := newObjectACLUpdate(ctx, objectAPI, bucket, object, acl)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	defer aclUpdate.Unlock()
+
 	completeMultiPartUpload := objectAPI.CompleteMultipartUpload
 	if api.CacheAPI() != nil {
 		completeMultiPartUpload = api.CacheAPI().CompleteMultipartUpload
@@ -1473,13 +2067,23 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		case PartTooSmall:
 			// Write part too small error.
 			writePartSmallErrorResponse(w, r, oErr)
+		case hex.InvalidByteError:
+			// The ETag of a part is not a MD5 sum.
+			writeErrorResponse(w, ErrInvalidPart, r.URL)
 		default:
+			if err == hex.ErrLength {
+				writeErrorResponse(w, ErrInvalidPart, r.URL)
+				return
+			}
 			// Handle all other generic issues.
 			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
 		}
 		return
 	}
 
+	logger.LogIf(ctx, aclUpdate.Apply(ctx, objectAPI))
+	aclUpdate.Unlock()
+
 	// Get object location.
 	location := getObjectLocation(r, globalDomainName, bucket, object)
 	// Generate complete multipart response.
@@ -1492,6 +2096,12 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 
 	// Set etag.
 	w.Header().Set("ETag", "\""+objInfo.ETag+"\"")
+	setObjectVersionHeaders(w, objInfo)
+	if hasSSECustomerHeader(r.Header) {
+		w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
+		w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+	}
+	setBucketEncryptionHeaders(w, objInfo.UserDefined)
 
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
@@ -1502,6 +2112,8 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		host, port = "", ""
 	}
 
+	queueReplication(objInfo)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:  event.ObjectCreatedCompleteMultipartUpload,
@@ -1530,11 +2142,30 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, object); s3Error != ErrNone {
+	var action policy.Action = policy.DeleteObjectAction
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" {
+		if !isValidVersionID(versionID) {
+			writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+			return
+		}
+		action = policy.DeleteObjectVersionAction
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, action, bucket, object); s3Error != ErrNone {
 		writeErrorResponse(w, s3Error, r.URL)
 		return
 	}
 
+	// Bypassing governance retention requires its own permission.
+	if isGovernanceBypassRequested(r.Header) {
+		if !isObjectActionAllowed(r, policy.BypassGovernanceRetentionAction, bucket, object) {
+			writeErrorResponse(w, ErrAccessDenied, r.URL)
+			return
+		}
+		ctx = withGovernanceBypass(ctx)
+	}
+
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
 		// Not required to check whether given object exists or not, because
@@ -1564,6 +2195,21 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		}
 	}
 
+	// Objects of versioned buckets are deleted by adding a delete marker,
+	// versions are only removed when deleted by their version ID.
+	if versionID != "" || getBucketVersioning(bucket) != "" {
+		objInfo, err := deleteObjectVersion(ctx, objectAPI, bucket, object, versionID, r)
+		if err != nil {
+			if _, ok := err.(VersionNotFound); !ok {
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
+		}
+		setObjectVersionHeaders(w, objInfo)
+		writeSuccessNoContent(w)
+		return
+	}
+
 	// http://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectDELETE.html
 	// Ignore delete object errors while replying to client, since we are
 	// suppposed to reply only 204. Additionally log the error for
@@ -19,19 +19,27 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/base64"
 	"encoding/xml"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
 )
 
 // Type to capture different modifications to API request to simulate failure cases.
@@ -465,6 +473,210 @@ func testAPIGetObjectHandler(obj ObjectLayer, instanceType, bucketName string, a
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling GetObject API handler tests of multiple byte ranges for both XL multiple disks and FS single drive setup.
+func TestAPIGetObjectRangesHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectRangesHandler, []string{"PutObject", "GetObject"})
+}
+
+func testAPIGetObjectRangesHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func(isSSL bool) { globalIsSSL = isSSL }(globalIsSSL)
+	globalIsSSL = true
+
+	ssecHeader := http.Header{
+		SSECustomerAlgorithm: {SSECustomerAlgorithmAES256},
+		SSECustomerKey:       {"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0cHJvdmlkZWQ="},
+		SSECustomerKeyMD5:    {"7PpPLAK26ONlVUGOWlusfg=="},
+	}
+	serve := func(method, urlStr string, data []byte, header http.Header) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range header {
+			for _, value := range v {
+				req.Header.Add(k, value)
+			}
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	data := generateBytesData(200 * humanize.KiByte)
+	objects := []struct {
+		objectName string
+		header     http.Header
+	}{
+		{"plain-object", nil},
+		{"ssec-object", ssecHeader},
+	}
+	for _, object := range objects {
+		header := http.Header{"Content-Type": {"text/plain"}}
+		for k, v := range object.header {
+			header[k] = v
+		}
+		if rec := serve("PUT", getPutObjectURL("", bucketName, object.objectName), data, header); rec.Code != http.StatusOK {
+			t.Fatalf("%s: %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, object.objectName, http.StatusOK, rec.Code)
+		}
+	}
+
+	testCases := []struct {
+		byteRange          string
+		expectedRespStatus int
+		expectedRanges     []string
+		expectedParts      [][]byte
+	}{
+		{"bytes=10-99,70000-70099,-100", http.StatusPartialContent, []string{"bytes 10-99/204800", "bytes 70000-70099/204800", "bytes 204700-204799/204800"},
+			[][]byte{data[10:100], data[70000:70100], data[204700:]}},
+		{"bytes=0-0, 300000-, 65535-65537", http.StatusPartialContent, []string{"bytes 0-0/204800", "bytes 65535-65537/204800"},
+			[][]byte{data[0:1], data[65535:65538]}},
+		{"bytes=300000-,400000-", http.StatusRequestedRangeNotSatisfiable, nil, nil},
+		{"bytes=0-,100-", http.StatusOK, nil, [][]byte{data}},
+	}
+	for _, object := range objects {
+		for i, testCase := range testCases {
+			header := http.Header{"Range": {testCase.byteRange}}
+			for k, v := range object.header {
+				header[k] = v
+			}
+			rec := serve("GET", getGetObjectURL("", bucketName, object.objectName), nil, header)
+			if rec.Code != testCase.expectedRespStatus {
+				t.Fatalf("%s: %s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, object.objectName, i+1, testCase.expectedRespStatus, rec.Code)
+			}
+			if testCase.expectedRanges == nil {
+				if testCase.expectedParts != nil && !bytes.Equal(rec.Body.Bytes(), testCase.expectedParts[0]) {
+					t.Errorf("%s: %s: Test %d: Object content differs from expected value.", instanceType, object.objectName, i+1)
+				}
+				continue
+			}
+
+			if contentLength := strconv.Itoa(rec.Body.Len()); rec.Header().Get("Content-Length") != contentLength {
+				t.Errorf("%s: %s: Test %d: Expected the content length %s, but instead found %s", instanceType, object.objectName, i+1, contentLength, rec.Header().Get("Content-Length"))
+			}
+			mediaType, params, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+			if err != nil || mediaType != "multipart/byteranges" {
+				t.Fatalf("%s: %s: Test %d: Unexpected content type %s", instanceType, object.objectName, i+1, rec.Header().Get("Content-Type"))
+			}
+			mr := multipart.NewReader(rec.Body, params["boundary"])
+			for j, expectedRange := range testCase.expectedRanges {
+				part, err := mr.NextPart()
+				if err != nil {
+					t.Fatalf("%s: %s: Test %d: Failed to read part %d: <ERROR> %v", instanceType, object.objectName, i+1, j+1, err)
+				}
+				if part.Header.Get("Content-Range") != expectedRange || part.Header.Get("Content-Type") != "text/plain" {
+					t.Errorf("%s: %s: Test %d: Unexpected headers of part %d: %v", instanceType, object.objectName, i+1, j+1, part.Header)
+				}
+				content, err := ioutil.ReadAll(part)
+				if err != nil {
+					t.Fatalf("%s: %s: Test %d: Failed to read part %d: <ERROR> %v", instanceType, object.objectName, i+1, j+1, err)
+				}
+				if !bytes.Equal(content, testCase.expectedParts[j]) {
+					t.Errorf("%s: %s: Test %d: Content of part %d differs from expected value.", instanceType, object.objectName, i+1, j+1)
+				}
+			}
+			if _, err = mr.NextPart(); err != io.EOF {
+				t.Errorf("%s: %s: Test %d: Expected %d parts, got more", instanceType, object.objectName, i+1, len(testCase.expectedRanges))
+			}
+		}
+	}
+
+	// Encrypted objects need their key, also for multiple ranges.
+	if rec := serve("GET", getGetObjectURL("", bucketName, "ssec-object"), nil, http.Header{"Range": {"bytes=0-1,4-5"}}); rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+}
+
+// Wrapper for calling GetObject and HeadObject API handler tests of response header
+// overrides for both XL multiple disks and FS single drive setup.
+func TestAPIGetObjectResponseHeadersHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectResponseHeadersHandler, []string{"GetObject", "HeadObject"})
+}
+
+func testAPIGetObjectResponseHeadersHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+
+	objectName := "report.csv"
+	data := []byte("a,b,c")
+	if _, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), map[string]string{"content-type": "text/csv"}); err != nil {
+		t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+	}
+
+	overrides := url.Values{}
+	overrides.Set("response-content-type", "application/octet-stream")
+	overrides.Set("response-content-disposition", `attachment; filename="report.csv"`)
+	overrides.Set("response-cache-control", "no-cache")
+	overrides.Set("response-content-language", "en")
+	overrides.Set("response-content-encoding", "identity")
+	overrides.Set("response-expires", "Thu, 01 Dec 1994 16:00:00 GMT")
+
+	testCases := []struct {
+		method             string
+		presign            func(req *http.Request, accessKeyID, secretAccessKey string, expires int64) error
+		byteRange          string
+		partNumber         string
+		expectedRespStatus int
+	}{
+		// Test case - 1.
+		// Presigned GET as by download links.
+		{"GET", preSignV4, "", "", http.StatusOK},
+		// Test case - 2.
+		{"GET", preSignV2, "", "", http.StatusOK},
+		// Test case - 3.
+		// Signed GET of a range of the object.
+		{"GET", nil, "bytes=0-2", "", http.StatusPartialContent},
+		// Test case - 4.
+		{"HEAD", preSignV4, "", "", http.StatusOK},
+		// Test case - 5.
+		// Signed HEAD of a part of the object.
+		{"HEAD", nil, "", "1", http.StatusPartialContent},
+	}
+	for i, testCase := range testCases {
+		queryValue := url.Values{}
+		for k, v := range overrides {
+			queryValue[k] = v
+		}
+		if testCase.partNumber != "" {
+			queryValue.Set("partNumber", testCase.partNumber)
+		}
+		req, err := newTestRequest(testCase.method, makeTestTargetURL("", bucketName, objectName, queryValue), 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		if testCase.byteRange != "" {
+			req.Header.Set("Range", testCase.byteRange)
+		}
+		if testCase.presign != nil {
+			err = testCase.presign(req, credentials.AccessKey, credentials.SecretKey, 60)
+		} else {
+			err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey)
+		}
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to sign HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		// Headers set once the status is written are not sent.
+		respHeader := rec.Result().Header
+		for param, header := range supportedHeadGetReqParams {
+			if value := respHeader.Get(header); value != overrides.Get(param) {
+				t.Errorf("Test %d: %s: Expected the %s header `%s`, but instead found `%s`", i+1, instanceType, header, overrides.Get(param), value)
+			}
+		}
+	}
+}
+
 // Wrapper for calling PutObject API handler tests using streaming signature v4 for both XL multiple disks and FS single drive setup.
 func TestAPIPutObjectStreamSigV4Handler(t *testing.T) {
 	defer DetectTestLeak(t)()
@@ -776,6 +988,72 @@ func testAPIPutObjectStreamSigV4Handler(obj ObjectLayer, instanceType, bucketNam
 	}
 }
 
+// Wrapper for calling PutObject API handler tests of streaming signature v4 with trailing
+// checksum for both XL multiple disks and FS single drive setup.
+func TestAPIPutObjectStreamSigV4TrailerHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectStreamSigV4TrailerHandler, []string{"PutObject"})
+}
+
+func testAPIPutObjectStreamSigV4TrailerHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	data := bytes.Repeat([]byte("a"), 65*humanize.KiByte)
+	crc := crc32.ChecksumIEEE(data)
+	checksum := base64.StdEncoding.EncodeToString([]byte{byte(crc >> 24), byte(crc >> 16), byte(crc >> 8), byte(crc)})
+
+	testCases := []struct {
+		objectName         string
+		trailer            string
+		checksum           string
+		malformSignature   bool
+		expectedRespStatus int
+	}{
+		// Test case - 1.
+		// Valid trailing checksum.
+		{"object-1", "x-amz-checksum-crc32", checksum, false, http.StatusOK},
+		// Test case - 2.
+		// Checksum of other data.
+		{"object-2", "x-amz-checksum-crc32", "AAAAAA==", false, http.StatusBadRequest},
+		// Test case - 3.
+		// Checksum of invalid size.
+		{"object-3", "x-amz-checksum-crc32", "AAAA", false, http.StatusBadRequest},
+		// Test case - 4.
+		// Trailer signature mismatch.
+		{"object-4", "x-amz-checksum-crc32", checksum, true, http.StatusForbidden},
+		// Test case - 5.
+		// Trailer which is not a checksum header.
+		{"object-5", "content-md5", checksum, false, http.StatusBadRequest},
+	}
+	for i, testCase := range testCases {
+		req, err := newTestStreamingSignedTrailerRequest("PUT",
+			getPutObjectURL("", bucketName, testCase.objectName),
+			int64(len(data)), 64*humanize.KiByte, bytes.NewReader(data),
+			credentials.AccessKey, credentials.SecretKey, testCase.trailer, testCase.checksum)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for Put Object: <ERROR> %v", i+1, err)
+		}
+		if testCase.malformSignature {
+			stream, _ := ioutil.ReadAll(req.Body)
+			stream[len(stream)-5] = 'z'
+			req.Body = ioutil.NopCloser(bytes.NewReader(stream))
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d %s: Expected the response status to be `%d`, but instead found `%d`",
+				i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		_, err = obj.GetObjectInfo(context.Background(), bucketName, testCase.objectName)
+		if testCase.expectedRespStatus == http.StatusOK && err != nil {
+			t.Errorf("Test %d: %s: Failed to fetch the object: <ERROR> %s", i+1, instanceType, err)
+		}
+		if testCase.expectedRespStatus != http.StatusOK && err == nil {
+			t.Errorf("Test %d: %s: Expected the object not to be created", i+1, instanceType)
+		}
+	}
+}
+
 // Wrapper for calling PutObject API handler tests for both XL multiple disks and FS single drive setup.
 func TestAPIPutObjectHandler(t *testing.T) {
 	defer DetectTestLeak(t)()
@@ -1022,6 +1300,83 @@ func testAPIPutObjectHandler(obj ObjectLayer, instanceType, bucketName string, a
 
 }
 
+// Wrapper for calling conditional PutObject API handler tests for both XL multiple disks and FS single drive setup.
+func TestAPIPutObjectIfNoneMatchHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectIfNoneMatchHandler, []string{"PutObject"})
+}
+
+func testAPIPutObjectIfNoneMatchHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	objectName := "lock"
+	testCases := []struct {
+		ifNoneMatch        string
+		data               string
+		expectedRespStatus int
+		expectedData       string
+	}{
+		// Test case - 1.
+		// The object is created if it does not exist.
+		{"*", "owner-1", http.StatusOK, "owner-1"},
+		// Test case - 2.
+		// The object is not overwritten once it exists.
+		{"*", "owner-2", http.StatusPreconditionFailed, "owner-1"},
+		// Test case - 3.
+		// Only the wildcard is supported.
+		{`"8d777f385d3dfec8815d20f7496026dc"`, "owner-2", http.StatusNotImplemented, "owner-1"},
+		// Test case - 4.
+		// Unconditional requests overwrite the object.
+		{"", "owner-2", http.StatusOK, "owner-2"},
+	}
+	for i, testCase := range testCases {
+		req, err := newTestRequest("PUT", getPutObjectURL("", bucketName, objectName), int64(len(testCase.data)), bytes.NewReader([]byte(testCase.data)))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for Put Object: <ERROR> %v", i+1, err)
+		}
+		if testCase.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", testCase.ifNoneMatch)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("Test %d: Failed to sign HTTP request for Put Object: <ERROR> %v", i+1, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d %s: Expected the response status to be `%d`, but instead found `%d`",
+				i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+
+		var buffer bytes.Buffer
+		if err = obj.GetObject(context.Background(), bucketName, objectName, 0, -1, &buffer, ""); err != nil {
+			t.Fatalf("Test %d: %s: Failed to fetch the object: <ERROR> %s", i+1, instanceType, err)
+		}
+		if buffer.String() != testCase.expectedData {
+			t.Errorf("Test %d: %s: Expected the object data `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedData, buffer.String())
+		}
+	}
+
+	// The object layer checks the precondition again while the object is
+	// locked, in case it was created after the request was checked.
+	data := []byte("owner-3")
+	_, err := obj.PutObject(withPutIfAbsent(context.Background()), bucketName, objectName,
+		mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil)
+	if _, ok := err.(PreconditionFailed); !ok {
+		t.Errorf("%s: Expected error `%v`, but instead found `%v`", instanceType, PreconditionFailed{Bucket: bucketName, Object: objectName}, err)
+	}
+	var buffer bytes.Buffer
+	if err = obj.GetObject(context.Background(), bucketName, objectName, 0, -1, &buffer, ""); err != nil {
+		t.Fatalf("%s: Failed to fetch the object: <ERROR> %s", instanceType, err)
+	}
+	if buffer.String() != "owner-2" {
+		t.Errorf("%s: Expected the object data `%s`, but instead found `%s`", instanceType, "owner-2", buffer.String())
+	}
+	if _, err = obj.PutObject(withPutIfAbsent(context.Background()), bucketName, "new-object",
+		mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+		t.Errorf("%s: Expected the object to be created, but failed with <ERROR> %v", instanceType, err)
+	}
+}
+
 // Tests sanity of attempting to copying each parts at offsets from an existing
 // file and create a new object. Also validates if the written is same as what we
 // expected.
@@ -1513,20 +1868,26 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 			t.Fatalf("Put Object case %d:  Error uploading object: <ERROR> %v", i+1, err)
 		}
 	}
+	srcInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName)
+	if err != nil {
+		t.Fatalf("Error getting object info: <ERROR> %v", err)
+	}
 
 	// test cases with inputs and expected result for Copy Object.
 	testCases := []struct {
-		bucketName           string
-		newObjectName        string // name of the newly copied object.
-		copySourceHeader     string // data for "X-Amz-Copy-Source" header. Contains the object to be copied in the URL.
-		copyModifiedHeader   string // data for "X-Amz-Copy-Source-If-Modified-Since" header
-		copyUnmodifiedHeader string // data for "X-Amz-Copy-Source-If-Unmodified-Since" header
-		metadataGarbage      bool
-		metadataReplace      bool
-		metadataCopy         bool
-		metadata             map[string]string
-		accessKey            string
-		secretKey            string
+		bucketName            string
+		newObjectName         string // name of the newly copied object.
+		copySourceHeader      string // data for "X-Amz-Copy-Source" header. Contains the object to be copied in the URL.
+		copyModifiedHeader    string // data for "X-Amz-Copy-Source-If-Modified-Since" header
+		copyUnmodifiedHeader  string // data for "X-Amz-Copy-Source-If-Unmodified-Since" header
+		copyIfMatchHeader     string // data for "X-Amz-Copy-Source-If-Match" header
+		copyIfNoneMatchHeader string // data for "X-Amz-Copy-Source-If-None-Match" header
+		metadataGarbage       bool
+		metadataReplace       bool
+		metadataCopy          bool
+		metadata              map[string]string
+		accessKey             string
+		secretKey             string
 		// expected output.
 		expectedRespStatus int
 	}{
@@ -1729,6 +2090,90 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 			secretKey:            credentials.SecretKey,
 			expectedRespStatus:   http.StatusOK,
 		},
+		// Test case - 17, copy with satisfying if-match header.
+		{
+			bucketName:         bucketName,
+			newObjectName:      "newObject1",
+			copySourceHeader:   url.QueryEscape("/" + bucketName + "/" + objectName),
+			copyIfMatchHeader:  "\"" + srcInfo.ETag + "\"",
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusOK,
+		},
+		// Test case - 18, copy with unsatisfying if-match header.
+		{
+			bucketName:         bucketName,
+			newObjectName:      "newObject1",
+			copySourceHeader:   url.QueryEscape("/" + bucketName + "/" + objectName),
+			copyIfMatchHeader:  "\"d41d8cd98f00b204e9800998ecf8427e\"",
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusPreconditionFailed,
+		},
+		// Test case - 19, copy with if-match header listing the source ETag.
+		{
+			bucketName:         bucketName,
+			newObjectName:      "newObject1",
+			copySourceHeader:   url.QueryEscape("/" + bucketName + "/" + objectName),
+			copyIfMatchHeader:  "\"d41d8cd98f00b204e9800998ecf8427e\", \"" + srcInfo.ETag + "\"",
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusOK,
+		},
+		// Test case - 20, copy with satisfying if-none-match header.
+		{
+			bucketName:            bucketName,
+			newObjectName:         "newObject1",
+			copySourceHeader:      url.QueryEscape("/" + bucketName + "/" + objectName),
+			copyIfNoneMatchHeader: "\"d41d8cd98f00b204e9800998ecf8427e\"",
+			accessKey:             credentials.AccessKey,
+			secretKey:             credentials.SecretKey,
+			expectedRespStatus:    http.StatusOK,
+		},
+		// Test case - 21, copy with unsatisfying if-none-match header.
+		{
+			bucketName:            bucketName,
+			newObjectName:         "newObject1",
+			copySourceHeader:      url.QueryEscape("/" + bucketName + "/" + objectName),
+			copyIfNoneMatchHeader: srcInfo.ETag,
+			accessKey:             credentials.AccessKey,
+			secretKey:             credentials.SecretKey,
+			expectedRespStatus:    http.StatusPreconditionFailed,
+		},
+		// Test case - 22, copy with wildcard if-none-match header.
+		{
+			bucketName:            bucketName,
+			newObjectName:         "newObject1",
+			copySourceHeader:      url.QueryEscape("/" + bucketName + "/" + objectName),
+			copyIfNoneMatchHeader: "*",
+			accessKey:             credentials.AccessKey,
+			secretKey:             credentials.SecretKey,
+			expectedRespStatus:    http.StatusPreconditionFailed,
+		},
+		// Test case - 23, satisfying if-match header takes precedence over
+		// unsatisfying unmodified header.
+		{
+			bucketName:           bucketName,
+			newObjectName:        "newObject1",
+			copySourceHeader:     url.QueryEscape("/" + bucketName + "/" + objectName),
+			copyIfMatchHeader:    srcInfo.ETag,
+			copyUnmodifiedHeader: "Mon, 02 Jan 2007 15:04:05 GMT",
+			accessKey:            credentials.AccessKey,
+			secretKey:            credentials.SecretKey,
+			expectedRespStatus:   http.StatusOK,
+		},
+		// Test case - 24, unsatisfying if-none-match header with satisfying
+		// modified header.
+		{
+			bucketName:            bucketName,
+			newObjectName:         "newObject1",
+			copySourceHeader:      url.QueryEscape("/" + bucketName + "/" + objectName),
+			copyIfNoneMatchHeader: srcInfo.ETag,
+			copyModifiedHeader:    "Mon, 02 Jan 2006 15:04:05 GMT",
+			accessKey:             credentials.AccessKey,
+			secretKey:             credentials.SecretKey,
+			expectedRespStatus:    http.StatusPreconditionFailed,
+		},
 	}
 
 	for i, testCase := range testCases {
@@ -1753,6 +2198,12 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 		if testCase.copyUnmodifiedHeader != "" {
 			req.Header.Set("X-Amz-Copy-Source-If-Unmodified-Since", testCase.copyUnmodifiedHeader)
 		}
+		if testCase.copyIfMatchHeader != "" {
+			req.Header.Set("X-Amz-Copy-Source-If-Match", testCase.copyIfMatchHeader)
+		}
+		if testCase.copyIfNoneMatchHeader != "" {
+			req.Header.Set("X-Amz-Copy-Source-If-None-Match", testCase.copyIfNoneMatchHeader)
+		}
 		// Add custom metadata.
 		for k, v := range testCase.metadata {
 			req.Header.Set(k, v)
@@ -1804,6 +2255,12 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 		if testCase.copyUnmodifiedHeader != "" {
 			reqV2.Header.Set("X-Amz-Copy-Source-If-Unmodified-Since", testCase.copyUnmodifiedHeader)
 		}
+		if testCase.copyIfMatchHeader != "" {
+			reqV2.Header.Set("X-Amz-Copy-Source-If-Match", testCase.copyIfMatchHeader)
+		}
+		if testCase.copyIfNoneMatchHeader != "" {
+			reqV2.Header.Set("X-Amz-Copy-Source-If-None-Match", testCase.copyIfNoneMatchHeader)
+		}
 
 		// Add custom metadata.
 		for k, v := range testCase.metadata {
@@ -1845,9 +2302,11 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 	// Its necessary to set the "X-Amz-Copy-Source" header for the request to be accepted by the handler.
 	anonReq.Header.Set("X-Amz-Copy-Source", url.QueryEscape("/"+bucketName+"/"+anonObject))
 	// ExecObjectLayerAPIAnonTest - Calls the HTTP API handler using the anonymous request, validates the ErrAccessDeniedResponse,
-	// sets the bucket policy using the policy statement generated from `getWriteOnlyObjectStatement` so that the
-	// unsigned request goes through and its validated again.
-	ExecObjectLayerAPIAnonTest(t, obj, "TestAPICopyObjectHandler", bucketName, newCopyAnonObject, instanceType, apiRouter, anonReq, getAnonWriteOnlyObjectPolicy(bucketName, newCopyAnonObject))
+	// sets the bucket policy using the policy statements generated from `getWriteOnlyObjectStatement` and
+	// `getReadOnlyObjectStatement` so that the unsigned request goes through and its validated again.
+	anonCopyPolicy := getAnonWriteOnlyObjectPolicy(bucketName, newCopyAnonObject)
+	anonCopyPolicy.Statements = append(anonCopyPolicy.Statements, getAnonReadOnlyObjectPolicy(bucketName, anonObject).Statements...)
+	ExecObjectLayerAPIAnonTest(t, obj, "TestAPICopyObjectHandler", bucketName, newCopyAnonObject, instanceType, apiRouter, anonReq, anonCopyPolicy)
 
 	// HTTP request to test the case of `objectLayer` being set to `nil`.
 	// There is no need to use an existing bucket or valid input for creating the request,
@@ -1873,6 +2332,191 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 
 }
 
+// Wrapper for calling CopyObject API handler tests changing the storage class of an object
+// for both XL multiple disks and FS single drive setup.
+func TestAPICopyObjectStorageClassHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPICopyObjectStorageClassHandler, []string{"CopyObject"})
+}
+
+func testAPICopyObjectStorageClassHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+	resetGlobalStorageEnvs()
+
+	objectName := "object"
+	data := bytes.Repeat([]byte("a"), 1*humanize.KiByte)
+	if _, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+		t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+	}
+
+	testCases := []struct {
+		storageClass         string
+		expectedRespStatus   int
+		expectedStorageClass string
+	}{
+		// Test case - 1.
+		// The storage class of the object is replaced.
+		{reducedRedundancyStorageClass, http.StatusOK, reducedRedundancyStorageClass},
+		// Test case - 2.
+		// Copying the object onto itself without a change is illegal.
+		{reducedRedundancyStorageClass, http.StatusBadRequest, reducedRedundancyStorageClass},
+		// Test case - 3.
+		// Invalid storage class.
+		{"GLACIER", http.StatusBadRequest, reducedRedundancyStorageClass},
+		// Test case - 4.
+		// The standard storage class is restored.
+		{standardStorageClass, http.StatusOK, standardStorageClass},
+	}
+	for i, testCase := range testCases {
+		req, err := newTestSignedRequestV4("PUT", getCopyObjectURL("", bucketName, objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for copy Object: <ERROR> %v", i+1, err)
+		}
+		req.Header.Set("X-Amz-Copy-Source", url.QueryEscape("/"+bucketName+"/"+objectName))
+		req.Header.Set("X-Amz-Storage-Class", testCase.storageClass)
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("Test %d: Failed to sign HTTP request: <ERROR> %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to fetch the object: <ERROR> %s", i+1, instanceType, err)
+		}
+		if objInfo.StorageClass != testCase.expectedStorageClass {
+			t.Errorf("Test %d: %s: Expected storage class %s, but instead found %s", i+1, instanceType, testCase.expectedStorageClass, objInfo.StorageClass)
+		}
+
+		// The data is erasure coded with the parity of the storage class.
+		if sets, ok := obj.(*xlSets); ok {
+			set := sets.getHashedSet(objectName)
+			metaArr, errs := readAllXLMetadata(context.Background(), set.getDisks(), bucketName, objectName)
+			_, expectedParity := getRedundancyCount(testCase.expectedStorageClass, len(set.getDisks()))
+			for j := range metaArr {
+				if errs[j] == nil && metaArr[j].Erasure.ParityBlocks != expectedParity {
+					t.Fatalf("Test %d: %s: Expected %d parity blocks, but instead found %d", i+1, instanceType, expectedParity, metaArr[j].Erasure.ParityBlocks)
+				}
+			}
+		}
+
+		buffer := new(bytes.Buffer)
+		if err = obj.GetObject(context.Background(), bucketName, objectName, 0, int64(len(data)), buffer, ""); err != nil {
+			t.Fatalf("Test %d: %s: Failed to read the object: <ERROR> %s", i+1, instanceType, err)
+		}
+		if !bytes.Equal(buffer.Bytes(), data) {
+			t.Errorf("Test %d: %s: Data Mismatch: Data fetched back from the object doesn't match the original one.", i+1, instanceType)
+		}
+	}
+}
+
+// Wrapper for calling copy source authorization tests for both XL multiple disks and single node setup.
+func TestAPICopyObjectSourceAccessHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPICopyObjectSourceAccessHandler, []string{"CopyObject", "CopyObjectPart"})
+}
+
+func testAPICopyObjectSourceAccessHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+	origIAMSys := globalIAMSys
+	defer func() { globalIAMSys = origIAMSys }()
+	globalIAMSys = NewIAMSys()
+
+	// The service account may write anywhere in the bucket but only
+	// read objects under the "public/" prefix.
+	saCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("%s: Failed to generate credentials: <ERROR> %v", instanceType, err)
+	}
+	globalIAMSys.serviceAccounts[saCred.AccessKey] = serviceAccount{
+		Credentials: saCred,
+		ParentUser:  credentials.AccessKey,
+		Policy: &policy.Policy{
+			Version: policy.DefaultVersion,
+			Statements: []policy.Statement{
+				policy.NewStatement(
+					policy.Allow,
+					policy.NewPrincipal("*"),
+					policy.NewActionSet(policy.PutObjectAction),
+					policy.NewResourceSet(policy.NewResource(bucketName, "*")),
+					condition.NewFunctions(),
+				),
+				policy.NewStatement(
+					policy.Allow,
+					policy.NewPrincipal("*"),
+					policy.NewActionSet(policy.GetObjectAction),
+					policy.NewResourceSet(policy.NewResource(bucketName, "public/*")),
+					condition.NewFunctions(),
+				),
+			},
+		},
+	}
+
+	data := []byte("hello, world")
+	for _, object := range []string{"public/object", "private/object"} {
+		if _, err = obj.PutObject(context.Background(), bucketName, object, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+			t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+		}
+	}
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, "multipart", nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create multipart upload: <ERROR> %v", instanceType, err)
+	}
+
+	testCases := []struct {
+		copySource         string
+		uploadID           string
+		cred               auth.Credentials
+		expectedRespStatus int
+	}{
+		// Test case - 1.
+		// Readable source objects are copied.
+		{url.QueryEscape("/" + bucketName + "/public/object"), "", saCred, http.StatusOK},
+		{url.QueryEscape("/" + bucketName + "/public/object"), uploadID, saCred, http.StatusOK},
+		// Test case - 2.
+		// Source objects which can't be read are not copied.
+		{url.QueryEscape("/" + bucketName + "/private/object"), "", saCred, http.StatusForbidden},
+		{url.QueryEscape("/" + bucketName + "/private/object"), uploadID, saCred, http.StatusForbidden},
+		// Test case - 3.
+		// Reading a source object version needs its own permission.
+		{url.QueryEscape("/"+bucketName+"/public/object") + "?versionId=" + mustGetUUID(), "", saCred, http.StatusForbidden},
+		{url.QueryEscape("/"+bucketName+"/public/object") + "?versionId=" + mustGetUUID(), uploadID, saCred, http.StatusForbidden},
+		// Test case - 4.
+		// Only the latest version of a source object can be copied.
+		{url.QueryEscape("/"+bucketName+"/public/object") + "?versionId=" + mustGetUUID(), "", credentials, http.StatusNotImplemented},
+	}
+
+	for i, testCase := range testCases {
+		reqURL := getCopyObjectURL("", bucketName, "copy")
+		if testCase.uploadID != "" {
+			reqURL = getCopyObjectPartURL("", bucketName, "multipart", testCase.uploadID, "1")
+		}
+		req, err := newTestSignedRequestV4("PUT", reqURL, 0, nil, testCase.cred.AccessKey, testCase.cred.SecretKey)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for copy Object: <ERROR> %v", i+1, err)
+		}
+		req.Header.Set("X-Amz-Copy-Source", testCase.copySource)
+		if err = signRequestV4(req, testCase.cred.AccessKey, testCase.cred.SecretKey); err != nil {
+			t.Fatalf("Test %d: Failed to sign HTTP request: <ERROR> %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+}
+
 // Wrapper for calling NewMultipartUpload tests for both XL multiple disks and single node setup.
 // First register the HTTP handler for NewMutlipartUpload, then a HTTP request for NewMultipart upload is made.
 // The UploadID from the response body is parsed and its existence is asserted with an attempt to ListParts using it.
@@ -2203,6 +2847,22 @@ func testAPICompleteMultipartHandler(obj ObjectLayer, instanceType, bucketName s
 				{ETag: validPartMD5, PartNumber: 2},
 			},
 		},
+		// inputParts - 6.
+		// Case with a part listed twice.
+		{
+			[]CompletePart{
+				{ETag: validPartMD5, PartNumber: 5},
+				{ETag: validPartMD5, PartNumber: 5},
+				{ETag: validPartMD5, PartNumber: 6},
+			},
+		},
+		// inputParts - 7.
+		// Case with an ETag which is not a MD5 hash string.
+		{
+			[]CompletePart{
+				{ETag: "abcz", PartNumber: 5},
+			},
+		},
 	}
 
 	// on successful complete multipart operation the s3MD5 for the parts uploaded will be returned.
@@ -2316,6 +2976,35 @@ func testAPICompleteMultipartHandler(obj ObjectLayer, instanceType, bucketName s
 			expectedRespStatus: http.StatusBadRequest,
 		},
 		// Test case - 7.
+		// A part is listed twice.
+		// This should return ErrInvalidPartOrder in the response body.
+		{
+			bucket:    bucketName,
+			object:    objectName,
+			uploadID:  uploadIDs[0],
+			parts:     inputParts[6].parts,
+			accessKey: credentials.AccessKey,
+			secretKey: credentials.SecretKey,
+
+			expectedContent: encodeResponse(getAPIErrorResponse(getAPIError(ErrInvalidPartOrder),
+				getGetObjectURL("", bucketName, objectName), "")),
+			expectedRespStatus: http.StatusBadRequest,
+		},
+		// Test case - 8.
+		// The ETag of a part is not a MD5 hash string.
+		{
+			bucket:    bucketName,
+			object:    objectName,
+			uploadID:  uploadIDs[0],
+			parts:     inputParts[7].parts,
+			accessKey: credentials.AccessKey,
+			secretKey: credentials.SecretKey,
+
+			expectedContent: encodeResponse(getAPIErrorResponse(getAPIError(ErrInvalidPart),
+				getGetObjectURL("", bucketName, objectName), "")),
+			expectedRespStatus: http.StatusBadRequest,
+		},
+		// Test case - 9.
 		// Test case with proper parts.
 		// Should successed and the content in the response body is asserted.
 		{
@@ -2330,7 +3019,7 @@ func testAPICompleteMultipartHandler(obj ObjectLayer, instanceType, bucketName s
 				getGetObjectURL("", bucketName, objectName), "")),
 			expectedRespStatus: http.StatusForbidden,
 		},
-		// Test case - 8.
+		// Test case - 10.
 		// Test case with proper parts.
 		// Should successed and the content in the response body is asserted.
 		{
@@ -3225,6 +3914,129 @@ func testAPIPutObjectPartHandler(obj ObjectLayer, instanceType, bucketName strin
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling SSE-C multipart upload handler tests for both XL multiple disks and FS single drive setup.
+func TestAPISSECMultipartHandlers(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPISSECMultipartHandlers, []string{"CopyObjectPart", "PutObjectPart", "NewMultipart", "CompleteMultipart", "PutObject", "GetObject"})
+}
+
+func testAPISSECMultipartHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func(isSSL bool) { globalIsSSL = isSSL }(globalIsSSL)
+	globalIsSSL = true
+
+	ssecHeader := func(key string, copySource bool) http.Header {
+		algorithm, customerKey, customerKeyMD5 := SSECustomerAlgorithm, SSECustomerKey, SSECustomerKeyMD5
+		if copySource {
+			algorithm, customerKey, customerKeyMD5 = SSECopyCustomerAlgorithm, SSECopyCustomerKey, SSECopyCustomerKeyMD5
+		}
+		keyMD5 := md5.Sum([]byte(key))
+		header := http.Header{}
+		header.Set(algorithm, SSECustomerAlgorithmAES256)
+		header.Set(customerKey, base64.StdEncoding.EncodeToString([]byte(key)))
+		header.Set(customerKeyMD5, base64.StdEncoding.EncodeToString(keyMD5[:]))
+		return header
+	}
+	uploadKey, sourceKey := "32byteslongsecretkeymustprovided", "anotherthirtytwobytesecretkey!!!"
+	serve := func(method, urlStr string, data []byte, headers ...http.Header) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for _, header := range headers {
+			for k, v := range header {
+				req.Header[k] = v
+			}
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The source of the copied part is encrypted with another key.
+	sourceData := generateBytesData(100 * humanize.KiByte)
+	if rec := serve("PUT", getPutObjectURL("", bucketName, "source"), sourceData, ssecHeader(sourceKey, false)); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`: %s", instanceType, http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	rec := serve("POST", getNewMultipartURL("", bucketName, "object"), nil, ssecHeader(uploadKey, false))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if rec.Header().Get(SSECustomerAlgorithm) != SSECustomerAlgorithmAES256 || rec.Header().Get(SSECustomerKeyMD5) != ssecHeader(uploadKey, false).Get(SSECustomerKeyMD5) {
+		t.Errorf("%s: Expected the SSE-C response headers, but instead found %v", instanceType, rec.Header())
+	}
+	var upload InitiateMultipartUploadResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &upload); err != nil {
+		t.Fatalf("%s: Failed to parse the response: <ERROR> %v", instanceType, err)
+	}
+
+	// Parts must be sent with the key of the upload.
+	partData := generateBytesData(int(globalMinPartSize))
+	partURL := getPutObjectPartURL("", bucketName, "object", upload.UploadID, "1")
+	if rec = serve("PUT", partURL, partData); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if rec = serve("PUT", partURL, partData, ssecHeader(sourceKey, false)); rec.Code == http.StatusOK {
+		t.Fatalf("%s: Expected a part sent with another key to be rejected", instanceType)
+	}
+	if rec = serve("PUT", partURL, partData, ssecHeader(uploadKey, false)); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if rec.Header().Get(SSECustomerAlgorithm) != SSECustomerAlgorithmAES256 {
+		t.Errorf("%s: Expected the SSE-C response headers, but instead found %v", instanceType, rec.Header())
+	}
+	parts := []CompletePart{{PartNumber: 1, ETag: strings.Trim(rec.Header().Get("ETag"), "\"")}}
+
+	// The copied part is decrypted with the key of its source and
+	// encrypted again with the key of the upload.
+	copyURL := getCopyObjectPartURL("", bucketName, "object", upload.UploadID, "2")
+	copySource := http.Header{"X-Amz-Copy-Source": {url.QueryEscape("/" + bucketName + "/source")}}
+	if rec = serve("PUT", copyURL, nil, copySource, ssecHeader(uploadKey, false)); rec.Code == http.StatusOK {
+		t.Fatalf("%s: Expected a part copied without the key of its source to be rejected", instanceType)
+	}
+	if rec = serve("PUT", copyURL, nil, copySource, ssecHeader(sourceKey, true)); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if rec = serve("PUT", copyURL, nil, copySource, ssecHeader(sourceKey, true), ssecHeader(uploadKey, false)); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`: %s", instanceType, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get(SSECustomerAlgorithm) != SSECustomerAlgorithmAES256 {
+		t.Errorf("%s: Expected the SSE-C response headers, but instead found %v", instanceType, rec.Header())
+	}
+	var copyResponse CopyObjectPartResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &copyResponse); err != nil {
+		t.Fatalf("%s: Failed to parse the response: <ERROR> %v", instanceType, err)
+	}
+	parts = append(parts, CompletePart{PartNumber: 2, ETag: strings.Trim(copyResponse.ETag, "\"")})
+
+	completeBytes, err := xml.Marshal(CompleteMultipartUpload{Parts: parts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec = serve("POST", getCompleteMultipartUploadURL("", bucketName, "object", upload.UploadID), completeBytes, ssecHeader(uploadKey, false)); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`: %s", instanceType, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get(SSECustomerAlgorithm) != SSECustomerAlgorithmAES256 {
+		t.Errorf("%s: Expected the SSE-C response headers, but instead found %v", instanceType, rec.Header())
+	}
+
+	// The object is only read with the key of the upload.
+	if rec = serve("GET", getGetObjectURL("", bucketName, "object"), nil, ssecHeader(sourceKey, false)); rec.Code == http.StatusOK {
+		t.Fatalf("%s: Expected the object not to be read with another key", instanceType)
+	}
+	if rec = serve("GET", getGetObjectURL("", bucketName, "object"), nil, ssecHeader(uploadKey, false)); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if !bytes.Equal(rec.Body.Bytes(), append(partData, sourceData...)) {
+		t.Errorf("%s: Object content differs from expected value", instanceType)
+	}
+}
+
 // TestAPIListObjectPartsHandlerPreSign - Tests validate the response of ListObjectParts HTTP handler
 //  when signature type of the HTTP request is `Presigned`.
 func TestAPIListObjectPartsHandlerPreSign(t *testing.T) {
@@ -0,0 +1,401 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// PutObjectRetentionHandler - sets the retention of an object version as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTRetention.html
+func (api objectAPIHandlers) PutObjectRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutObjectRetention")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsVersioningSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectRetentionAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	// Bypassing governance retention requires its own permission.
+	if isGovernanceBypassRequested(r.Header) {
+		if !isObjectActionAllowed(r, policy.BypassGovernanceRetentionAction, bucket, object) {
+			writeErrorResponse(w, ErrAccessDenied, r.URL)
+			return
+		}
+		ctx = withGovernanceBypass(ctx)
+	}
+
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" && !isValidVersionID(versionID) {
+		writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if !isObjectLockEnabled(bucket) {
+		writeErrorResponse(w, ErrInvalidBucketObjectLockConfiguration, r.URL)
+		return
+	}
+
+	// PutObjectRetention always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+
+	if r.ContentLength > maxObjectRetentionSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	retention, err := parseObjectRetention(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		if err == errPastRetainUntilDate {
+			writeErrorResponse(w, ErrPastObjectLockRetainDate, r.URL)
+			return
+		}
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	objInfo, err := objectAPI.PutObjectRetention(ctx, bucket, object, versionID, *retention)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	setObjectVersionHeaders(w, objInfo)
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetObjectRetentionHandler - returns the retention of an object version as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGETRetention.html
+func (api objectAPIHandlers) GetObjectRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectRetention")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsVersioningSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectRetentionAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" && !isValidVersionID(versionID) {
+		writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+		return
+	}
+
+	var objInfo ObjectInfo
+	var err error
+	if versionID != "" {
+		objInfo, err = objectAPI.GetObjectVersionInfo(ctx, bucket, object, versionID)
+	} else {
+		objInfo, err = objectAPI.GetObjectInfo(ctx, bucket, object)
+	}
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	if objInfo.DeleteMarker {
+		setObjectVersionHeaders(w, objInfo)
+		writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	retention, ok := getObjectRetention(objInfo.UserDefined)
+	if !ok {
+		writeErrorResponse(w, ErrNoSuchObjectLockConfiguration, r.URL)
+		return
+	}
+	retention.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	setObjectVersionHeaders(w, objInfo)
+	writeSuccessResponseXML(w, encodeResponse(retention))
+}
+
+// PutObjectLegalHoldHandler - puts an object version on legal hold or
+// removes its legal hold as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTLegalHold.html
+func (api objectAPIHandlers) PutObjectLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutObjectLegalHold")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsVersioningSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectLegalHoldAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" && !isValidVersionID(versionID) {
+		writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if !isObjectLockEnabled(bucket) {
+		writeErrorResponse(w, ErrInvalidBucketObjectLockConfiguration, r.URL)
+		return
+	}
+
+	// PutObjectLegalHold always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+
+	if r.ContentLength > maxObjectLegalHoldSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	legalHold, err := parseObjectLegalHold(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	objInfo, err := objectAPI.PutObjectLegalHold(ctx, bucket, object, versionID, *legalHold)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	setObjectVersionHeaders(w, objInfo)
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetObjectLegalHoldHandler - returns the legal hold status of an object version as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGETLegalHold.html
+func (api objectAPIHandlers) GetObjectLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectLegalHold")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsVersioningSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectLegalHoldAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" && !isValidVersionID(versionID) {
+		writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+		return
+	}
+
+	var objInfo ObjectInfo
+	var err error
+	if versionID != "" {
+		objInfo, err = objectAPI.GetObjectVersionInfo(ctx, bucket, object, versionID)
+	} else {
+		objInfo, err = objectAPI.GetObjectInfo(ctx, bucket, object)
+	}
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	if objInfo.DeleteMarker {
+		setObjectVersionHeaders(w, objInfo)
+		writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	legalHold, ok := getObjectLegalHold(objInfo.UserDefined)
+	if !ok {
+		writeErrorResponse(w, ErrNoSuchObjectLockConfiguration, r.URL)
+		return
+	}
+	legalHold.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	setObjectVersionHeaders(w, objInfo)
+	writeSuccessResponseXML(w, encodeResponse(legalHold))
+}
+
+// PutBucketObjectLockConfigHandler - sets the default retention of new
+// object versions of a bucket with object lock as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTObjectLockConfiguration.html
+// Object versions already stored keep their retention.
+func (api objectAPIHandlers) PutBucketObjectLockConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketObjectLockConfig")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsVersioningSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketObjectLockAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if !isObjectLockEnabled(bucket) {
+		writeErrorResponse(w, ErrInvalidBucketObjectLockConfiguration, r.URL)
+		return
+	}
+
+	// PutBucketObjectLockConfig always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+
+	if r.ContentLength > maxObjectLockConfigSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	config, err := parseObjectLockConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	data, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	if err = saveBucketMetadataConfig(ctx, objectAPI, bucket, bucketObjectLockConfig, data); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketObjectLockConfigHandler - returns the object lock configuration
+// of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketGETObjectLockConfiguration.html
+func (api objectAPIHandlers) GetBucketObjectLockConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketObjectLockConfig")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsVersioningSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketObjectLockAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	config, ok := getBucketObjectLockConfig(bucket)
+	if !ok {
+		writeErrorResponse(w, ErrObjectLockConfigurationNotFound, r.URL)
+		return
+	}
+	config.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	writeSuccessResponseXML(w, encodeResponse(config))
+}
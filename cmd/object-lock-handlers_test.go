@@ -0,0 +1,287 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling object retention and legal hold handler tests for both XL multiple disks and single node setup.
+func TestObjectLockHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testObjectLockHandlers, []string{"PutObjectRetention", "GetObjectRetention", "PutObjectLegalHold", "GetObjectLegalHold", "PutBucketVersioning", "PutObject", "DeleteObject", "PutBucket"})
+}
+
+func testObjectLockHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	serve := func(method, urlStr string, header http.Header, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	lockedBucket := getRandomBucketName()
+	lockHeader := http.Header{}
+	lockHeader.Set(amzBucketObjectLockEnabled, "true")
+	rec := serve("PUT", getMakeBucketURL("", lockedBucket), lockHeader, nil)
+	if instanceType == FSTestStr {
+		if rec.Code != http.StatusNotImplemented {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotImplemented, rec.Code)
+		}
+		return
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	// Versioning of the bucket can't be suspended.
+	suspend := []byte(`<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`)
+	if rec = serve("PUT", getBucketVersioningURL("", lockedBucket), nil, suspend); rec.Code != http.StatusConflict {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusConflict, rec.Code)
+	}
+
+	// Put an object version locked in governance mode.
+	retainUntilDate := UTCNow().Add(time.Hour).Truncate(time.Second)
+	header := http.Header{}
+	header.Set(amzObjectLockMode, string(RetentionGovernance))
+	header.Set(amzObjectLockRetainUntilDate, retainUntilDate.Format(time.RFC3339))
+	if rec = serve("PUT", getPutObjectURL("", bucketName, "object"), header, []byte("data")); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if rec = serve("PUT", getPutObjectURL("", lockedBucket, "object"), header, []byte("data")); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	versionID := rec.Header().Get(amzVersionID)
+
+	if rec = serve("GET", getObjectRetentionURL("", lockedBucket, "object"), nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var retention ObjectRetention
+	if err := xml.Unmarshal(rec.Body.Bytes(), &retention); err != nil {
+		t.Fatal(err)
+	}
+	if retention.Mode != RetentionGovernance || !retention.RetainUntilDate.Equal(retainUntilDate) {
+		t.Fatalf("%s: Unexpected retention %+v", instanceType, retention)
+	}
+
+	testCases := []struct {
+		bucketName         string
+		retention          string
+		expectedRespStatus int
+	}{
+		{bucketName, `<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>` + retainUntilDate.Add(time.Hour).Format(time.RFC3339) + `</RetainUntilDate></Retention>`, http.StatusBadRequest},
+		{lockedBucket, `<Retention><Mode>LOCKED</Mode></Retention>`, http.StatusBadRequest},
+		{lockedBucket, `<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>` + retainUntilDate.Add(-time.Minute).Format(time.RFC3339) + `</RetainUntilDate></Retention>`, http.StatusForbidden},
+		{lockedBucket, `<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>` + retainUntilDate.Add(time.Hour).Format(time.RFC3339) + `</RetainUntilDate></Retention>`, http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		if rec = serve("PUT", getObjectRetentionURL("", testCase.bucketName, "object"), nil, []byte(testCase.retention)); rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("%s: Case %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+
+	// The locked version can't be deleted, not even bypassing governance
+	// retention as it is now in compliance mode.
+	bypassHeader := http.Header{}
+	bypassHeader.Set(amzBypassGovernanceRetention, "true")
+	if rec = serve("DELETE", getObjectVersionURL("", lockedBucket, "object", versionID), nil, nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+	if rec = serve("DELETE", getObjectVersionURL("", lockedBucket, "object", versionID), bypassHeader, nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+
+	// Versions in governance mode can have their retention shortened and
+	// be deleted when bypassing governance retention.
+	header = http.Header{}
+	header.Set(amzObjectLockMode, string(RetentionGovernance))
+	header.Set(amzObjectLockRetainUntilDate, retainUntilDate.Format(time.RFC3339))
+	if rec = serve("PUT", getPutObjectURL("", lockedBucket, "governed"), header, []byte("data")); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	governedVersionID := rec.Header().Get(amzVersionID)
+	shorter := []byte(`<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>` + retainUntilDate.Add(-time.Minute).Format(time.RFC3339) + `</RetainUntilDate></Retention>`)
+	if rec = serve("PUT", getObjectRetentionURL("", lockedBucket, "governed"), nil, shorter); rec.Code != http.StatusForbidden {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+	if rec = serve("PUT", getObjectRetentionURL("", lockedBucket, "governed"), bypassHeader, shorter); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if rec = serve("DELETE", getObjectVersionURL("", lockedBucket, "governed", governedVersionID), nil, nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+	if rec = serve("DELETE", getObjectVersionURL("", lockedBucket, "governed", governedVersionID), bypassHeader, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+
+	// Put another version on legal hold, then remove its legal hold.
+	header = http.Header{}
+	header.Set(amzObjectLockLegalHold, string(LegalHoldOn))
+	if rec = serve("PUT", getPutObjectURL("", lockedBucket, "held"), header, []byte("data")); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	heldVersionID := rec.Header().Get(amzVersionID)
+	if rec = serve("GET", getObjectRetentionURL("", lockedBucket, "held"), nil, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+	if rec = serve("GET", getObjectLegalHoldURL("", lockedBucket, "held"), nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var legalHold ObjectLegalHold
+	if err := xml.Unmarshal(rec.Body.Bytes(), &legalHold); err != nil {
+		t.Fatal(err)
+	}
+	if legalHold.Status != LegalHoldOn {
+		t.Fatalf("%s: Unexpected legal hold %+v", instanceType, legalHold)
+	}
+	if rec = serve("DELETE", getObjectVersionURL("", lockedBucket, "held", heldVersionID), nil, nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+
+	legalHoldCases := []struct {
+		bucketName         string
+		legalHold          string
+		expectedRespStatus int
+	}{
+		{bucketName, `<LegalHold><Status>OFF</Status></LegalHold>`, http.StatusBadRequest},
+		{lockedBucket, `<LegalHold><Status>HOLD</Status></LegalHold>`, http.StatusBadRequest},
+		{lockedBucket, `<LegalHold><Status>OFF</Status></LegalHold>`, http.StatusOK},
+	}
+	for i, testCase := range legalHoldCases {
+		if rec = serve("PUT", getObjectLegalHoldURL("", testCase.bucketName, "held"), nil, []byte(testCase.legalHold)); rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("%s: Case %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+	if rec = serve("DELETE", getObjectVersionURL("", lockedBucket, "held", heldVersionID), nil, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+}
+
+// Wrapper for calling bucket object lock configuration handler tests for both XL multiple disks and single node setup.
+func TestBucketObjectLockConfigHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketObjectLockConfigHandlers, []string{"PutBucketObjectLockConfig", "GetBucketObjectLockConfig", "GetObjectRetention", "PutObject", "PutBucket"})
+}
+
+func testBucketObjectLockConfigHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	serve := func(method, urlStr string, header http.Header, data []byte) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	config := []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`)
+	if instanceType == FSTestStr {
+		if rec := serve("PUT", getBucketObjectLockConfigURL("", bucketName), nil, config); rec.Code != http.StatusNotImplemented {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotImplemented, rec.Code)
+		}
+		return
+	}
+
+	lockedBucket := getRandomBucketName()
+	lockHeader := http.Header{}
+	lockHeader.Set(amzBucketObjectLockEnabled, "true")
+	if rec := serve("PUT", getMakeBucketURL("", lockedBucket), lockHeader, nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	// Buckets created without object lock have no configuration.
+	if rec := serve("GET", getBucketObjectLockConfigURL("", bucketName), nil, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+
+	testCases := []struct {
+		bucketName         string
+		config             string
+		expectedRespStatus int
+	}{
+		{bucketName, string(config), http.StatusBadRequest},
+		{lockedBucket, `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode></DefaultRetention></Rule></ObjectLockConfiguration>`, http.StatusBadRequest},
+		{lockedBucket, `<ObjectLockConfiguration><Rule>`, http.StatusBadRequest},
+		{"missing-bucket", string(config), http.StatusNotFound},
+		{lockedBucket, string(config), http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		if rec := serve("PUT", getBucketObjectLockConfigURL("", testCase.bucketName), nil, []byte(testCase.config)); rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("%s: Case %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+
+	rec := serve("GET", getBucketObjectLockConfigURL("", lockedBucket), nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var lockConfig ObjectLockConfiguration
+	if err := xml.Unmarshal(rec.Body.Bytes(), &lockConfig); err != nil {
+		t.Fatal(err)
+	}
+	if lockConfig.ObjectLockEnabled != objectLockEnabled || lockConfig.Rule == nil ||
+		lockConfig.Rule.DefaultRetention != (DefaultRetention{Mode: RetentionGovernance, Days: 1}) {
+		t.Fatalf("%s: Unexpected object lock configuration %+v", instanceType, lockConfig)
+	}
+
+	// New object versions stored without retention headers get the default retention.
+	before := UTCNow().Truncate(time.Second)
+	if rec = serve("PUT", getPutObjectURL("", lockedBucket, "object"), nil, []byte("data")); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if rec = serve("GET", getObjectRetentionURL("", lockedBucket, "object"), nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var retention ObjectRetention
+	if err := xml.Unmarshal(rec.Body.Bytes(), &retention); err != nil {
+		t.Fatal(err)
+	}
+	if retention.Mode != RetentionGovernance || retention.RetainUntilDate.Before(before.AddDate(0, 0, 1)) ||
+		retention.RetainUntilDate.After(UTCNow().AddDate(0, 0, 1)) {
+		t.Fatalf("%s: Unexpected retention %+v", instanceType, retention)
+	}
+}
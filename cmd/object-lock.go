@@ -0,0 +1,448 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// Bucket object lock configuration file.
+	bucketObjectLockConfig = "object-lock.xml"
+
+	// Maximum size of a retention in a put-object-retention request.
+	maxObjectRetentionSize = 64 * 1024
+
+	// Maximum size of a legal hold in a put-object-legal-hold request.
+	maxObjectLegalHoldSize = 64 * 1024
+
+	// Maximum size of an object lock configuration in a
+	// put-object-lock-configuration request.
+	maxObjectLockConfigSize = 64 * 1024
+
+	// Request header enabling object lock on a new bucket.
+	amzBucketObjectLockEnabled = "x-amz-bucket-object-lock-enabled"
+
+	// Request and response headers, and metadata keys, of the
+	// retention of an object version.
+	amzObjectLockMode            = "x-amz-object-lock-mode"
+	amzObjectLockRetainUntilDate = "x-amz-object-lock-retain-until-date"
+
+	// Request and response header, and metadata key, of the legal hold
+	// of an object version.
+	amzObjectLockLegalHold = "x-amz-object-lock-legal-hold"
+
+	// Request header bypassing the governance mode retention of an
+	// object version, requires the s3:BypassGovernanceRetention permission.
+	amzBypassGovernanceRetention = "x-amz-bypass-governance-retention"
+
+	objectLockEnabled = "Enabled"
+)
+
+// RetentionMode - retention mode of an object version. Versions in
+// governance and compliance mode alike can't be deleted or overwritten
+// until their retain until date, and their retention can only be extended.
+// Requests allowed to bypass governance retention may still delete
+// versions in governance mode or shorten their retention.
+type RetentionMode string
+
+// Supported retention modes.
+const (
+	RetentionGovernance RetentionMode = "GOVERNANCE"
+	RetentionCompliance RetentionMode = "COMPLIANCE"
+)
+
+// IsValid - returns true if the retention mode is supported.
+func (mode RetentionMode) IsValid() bool {
+	return mode == RetentionGovernance || mode == RetentionCompliance
+}
+
+// LegalHoldStatus - legal hold status of an object version. Versions on
+// legal hold can't be deleted or overwritten until the legal hold is
+// removed, regardless of their retention.
+type LegalHoldStatus string
+
+// Supported legal hold statuses.
+const (
+	LegalHoldOn  LegalHoldStatus = "ON"
+	LegalHoldOff LegalHoldStatus = "OFF"
+)
+
+// IsValid - returns true if the legal hold status is supported.
+func (status LegalHoldStatus) IsValid() bool {
+	return status == LegalHoldOn || status == LegalHoldOff
+}
+
+var (
+	errInvalidRetention    = errors.New("invalid retention")
+	errPastRetainUntilDate = errors.New("retain until date must be in the future")
+	errInvalidLegalHold    = errors.New("invalid legal hold")
+	errInvalidObjectLock   = errors.New("invalid object lock configuration")
+)
+
+// ObjectLockConfiguration - object lock configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTObjectLockConfiguration.html
+// Object lock can only be enabled when creating a bucket.
+type ObjectLockConfiguration struct {
+	XMLName           xml.Name        `xml:"ObjectLockConfiguration"`
+	XMLNS             string          `xml:"xmlns,attr,omitempty"`
+	ObjectLockEnabled string          `xml:"ObjectLockEnabled,omitempty"`
+	Rule              *ObjectLockRule `xml:"Rule,omitempty"`
+}
+
+// ObjectLockRule - rule of an object lock configuration, giving the
+// default retention of new object versions of the bucket.
+type ObjectLockRule struct {
+	DefaultRetention DefaultRetention `xml:"DefaultRetention"`
+}
+
+// DefaultRetention - retention of new object versions stored without
+// retention headers, for a number of days or years after they are stored.
+type DefaultRetention struct {
+	Mode  RetentionMode `xml:"Mode"`
+	Days  int           `xml:"Days,omitempty"`
+	Years int           `xml:"Years,omitempty"`
+}
+
+// RetainUntilDate - returns the retain until date of an object version
+// stored at the given time.
+func (retention DefaultRetention) RetainUntilDate(modTime time.Time) time.Time {
+	return modTime.UTC().AddDate(retention.Years, 0, retention.Days)
+}
+
+// ObjectRetention - retention of an object version as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTRetention.html
+type ObjectRetention struct {
+	XMLName         xml.Name      `xml:"Retention"`
+	XMLNS           string        `xml:"xmlns,attr,omitempty"`
+	Mode            RetentionMode `xml:"Mode"`
+	RetainUntilDate time.Time     `xml:"RetainUntilDate"`
+}
+
+// ObjectLegalHold - legal hold of an object version as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTLegalHold.html
+type ObjectLegalHold struct {
+	XMLName xml.Name        `xml:"LegalHold"`
+	XMLNS   string          `xml:"xmlns,attr,omitempty"`
+	Status  LegalHoldStatus `xml:"Status"`
+}
+
+// IsActive - returns true if the retention prevents deleting or
+// overwriting the object version at the given time.
+func (retention ObjectRetention) IsActive(now time.Time) bool {
+	return retention.Mode.IsValid() && retention.RetainUntilDate.After(now)
+}
+
+// parseObjectRetention - parses and validates a retention, whose retain
+// until date must be in the future.
+func parseObjectRetention(reader io.Reader) (*ObjectRetention, error) {
+	var retention ObjectRetention
+	if err := xml.NewDecoder(reader).Decode(&retention); err != nil {
+		return nil, err
+	}
+	if !retention.Mode.IsValid() || retention.RetainUntilDate.IsZero() {
+		return nil, errInvalidRetention
+	}
+	if !retention.RetainUntilDate.After(UTCNow()) {
+		return nil, errPastRetainUntilDate
+	}
+	retention.XMLNS = ""
+	retention.RetainUntilDate = retention.RetainUntilDate.UTC()
+	return &retention, nil
+}
+
+// parseObjectLockConfig - parses and validates the object lock
+// configuration of a bucket, which can't disable object lock. A default
+// retention is either for days or for years.
+func parseObjectLockConfig(reader io.Reader) (*ObjectLockConfiguration, error) {
+	var config ObjectLockConfiguration
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if config.ObjectLockEnabled != objectLockEnabled {
+		return nil, errInvalidObjectLock
+	}
+	if config.Rule != nil {
+		retention := config.Rule.DefaultRetention
+		if !retention.Mode.IsValid() || retention.Days < 0 || retention.Years < 0 ||
+			(retention.Days > 0) == (retention.Years > 0) {
+			return nil, errInvalidObjectLock
+		}
+	}
+	config.XMLNS = ""
+	return &config, nil
+}
+
+// getObjectRetention - returns the retention recorded in the metadata of
+// an object version, false if it has none.
+func getObjectRetention(metadata map[string]string) (ObjectRetention, bool) {
+	mode := RetentionMode(metadata[amzObjectLockMode])
+	retainUntilDate, err := time.Parse(time.RFC3339, metadata[amzObjectLockRetainUntilDate])
+	if !mode.IsValid() || err != nil {
+		return ObjectRetention{}, false
+	}
+	return ObjectRetention{Mode: mode, RetainUntilDate: retainUntilDate}, true
+}
+
+// setObjectRetention - records the retention in the metadata of an object version.
+func setObjectRetention(metadata map[string]string, retention ObjectRetention) {
+	metadata[amzObjectLockMode] = string(retention.Mode)
+	metadata[amzObjectLockRetainUntilDate] = retention.RetainUntilDate.UTC().Format(time.RFC3339)
+}
+
+// removeObjectRetention - removes the retention from the metadata of an
+// object, e.g. when copied from another object.
+func removeObjectRetention(metadata map[string]string) {
+	delete(metadata, amzObjectLockMode)
+	delete(metadata, amzObjectLockRetainUntilDate)
+}
+
+// parseObjectLegalHold - parses and validates a legal hold.
+func parseObjectLegalHold(reader io.Reader) (*ObjectLegalHold, error) {
+	var legalHold ObjectLegalHold
+	if err := xml.NewDecoder(reader).Decode(&legalHold); err != nil {
+		return nil, err
+	}
+	if !legalHold.Status.IsValid() {
+		return nil, errInvalidLegalHold
+	}
+	legalHold.XMLNS = ""
+	return &legalHold, nil
+}
+
+// getObjectLegalHold - returns the legal hold recorded in the metadata of
+// an object version, false if it was never put on legal hold.
+func getObjectLegalHold(metadata map[string]string) (ObjectLegalHold, bool) {
+	status := LegalHoldStatus(metadata[amzObjectLockLegalHold])
+	if !status.IsValid() {
+		return ObjectLegalHold{}, false
+	}
+	return ObjectLegalHold{Status: status}, true
+}
+
+// setObjectLegalHold - records the legal hold in the metadata of an object version.
+func setObjectLegalHold(metadata map[string]string, legalHold ObjectLegalHold) {
+	metadata[amzObjectLockLegalHold] = string(legalHold.Status)
+}
+
+// governanceBypassKey - context key marking requests allowed to bypass
+// the governance mode retention of object versions.
+type governanceBypassKey struct{}
+
+// withGovernanceBypass - returns a context allowing the governance mode
+// retention of object versions to be bypassed.
+func withGovernanceBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, governanceBypassKey{}, true)
+}
+
+// isGovernanceBypassed - returns true if the context allows bypassing
+// the governance mode retention of object versions.
+func isGovernanceBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(governanceBypassKey{}).(bool)
+	return bypass
+}
+
+// isGovernanceBypassRequested - returns true if the request headers ask
+// to bypass the governance mode retention of object versions.
+func isGovernanceBypassRequested(header http.Header) bool {
+	return strings.EqualFold(header.Get(amzBypassGovernanceRetention), "true")
+}
+
+// checkObjectRetentionUpdate - a retention in effect can only be extended
+// and compliance mode can't be changed to governance mode. A retention
+// in governance mode can be changed at will if the context allows
+// bypassing governance retention.
+func checkObjectRetentionUpdate(ctx context.Context, objInfo ObjectInfo, retention ObjectRetention) error {
+	current, ok := getObjectRetention(objInfo.UserDefined)
+	if !ok || !current.IsActive(UTCNow()) {
+		return nil
+	}
+	if current.Mode == RetentionGovernance && isGovernanceBypassed(ctx) {
+		return nil
+	}
+	if retention.RetainUntilDate.Before(current.RetainUntilDate) ||
+		(current.Mode == RetentionCompliance && retention.Mode != RetentionCompliance) {
+		return ObjectLocked{Bucket: objInfo.Bucket, Object: objInfo.Name}
+	}
+	return nil
+}
+
+// checkObjectLocked - returns ObjectLocked if the object version is on
+// legal hold or may not be deleted or overwritten yet.
+func checkObjectLocked(objInfo ObjectInfo) error {
+	return checkObjectLock(objInfo, false)
+}
+
+// checkObjectDeletable - returns ObjectLocked if the object version may
+// not be deleted yet. Versions retained in governance mode may be deleted
+// if the context allows bypassing governance retention.
+func checkObjectDeletable(ctx context.Context, objInfo ObjectInfo) error {
+	return checkObjectLock(objInfo, isGovernanceBypassed(ctx))
+}
+
+// checkObjectLock - returns ObjectLocked if the object version is on
+// legal hold or its retention is in effect, ignoring a retention in
+// governance mode if bypassGovernance is set.
+func checkObjectLock(objInfo ObjectInfo, bypassGovernance bool) error {
+	if legalHold, ok := getObjectLegalHold(objInfo.UserDefined); ok && legalHold.Status == LegalHoldOn {
+		return ObjectLocked{Bucket: objInfo.Bucket, Object: objInfo.Name}
+	}
+	retention, ok := getObjectRetention(objInfo.UserDefined)
+	if !ok || !retention.IsActive(UTCNow()) {
+		return nil
+	}
+	if bypassGovernance && retention.Mode == RetentionGovernance {
+		return nil
+	}
+	return ObjectLocked{Bucket: objInfo.Bucket, Object: objInfo.Name}
+}
+
+// getBucketObjectLockConfig - returns the object lock configuration of
+// given bucket name, false if object lock was not enabled when creating
+// the bucket.
+func getBucketObjectLockConfig(bucketName string) (*ObjectLockConfiguration, bool) {
+	if globalBucketMetadataSys == nil {
+		return nil, false
+	}
+	data, ok := globalBucketMetadataSys.Get(bucketName, bucketObjectLockConfig)
+	if !ok {
+		return nil, false
+	}
+	var config ObjectLockConfiguration
+	if err := xml.Unmarshal(data, &config); err != nil {
+		return nil, false
+	}
+	if config.ObjectLockEnabled != objectLockEnabled {
+		return nil, false
+	}
+	return &config, true
+}
+
+// isObjectLockEnabled - returns true if object lock was enabled when
+// creating the bucket of given name.
+func isObjectLockEnabled(bucketName string) bool {
+	_, ok := getBucketObjectLockConfig(bucketName)
+	return ok
+}
+
+// getDefaultRetention - returns the default retention of the bucket of
+// given name for an object version stored at the given time, nil if the
+// bucket has none.
+func getDefaultRetention(bucketName string, now time.Time) *ObjectRetention {
+	config, ok := getBucketObjectLockConfig(bucketName)
+	if !ok || config.Rule == nil {
+		return nil
+	}
+	retention := config.Rule.DefaultRetention
+	return &ObjectRetention{Mode: retention.Mode, RetainUntilDate: retention.RetainUntilDate(now)}
+}
+
+// enableBucketObjectLock - enables object lock on a new bucket, which
+// also enables versioning of the bucket for good.
+func enableBucketObjectLock(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	data, err := xml.Marshal(VersioningConfiguration{Status: versioningEnabled})
+	if err != nil {
+		return err
+	}
+	if err = saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketVersioningConfig, data); err != nil {
+		return err
+	}
+	if data, err = xml.Marshal(ObjectLockConfiguration{ObjectLockEnabled: objectLockEnabled}); err != nil {
+		return err
+	}
+	return saveBucketMetadataConfig(ctx, objAPI, bucketName, bucketObjectLockConfig, data)
+}
+
+// parseObjectLockHeaders - parses the retention given by request headers
+// of a new object version, nil if none is given.
+func parseObjectLockHeaders(bucketName string, header http.Header) (*ObjectRetention, APIErrorCode) {
+	mode, retainUntilDate := header.Get(amzObjectLockMode), header.Get(amzObjectLockRetainUntilDate)
+	if mode == "" && retainUntilDate == "" {
+		return nil, ErrNone
+	}
+	if mode == "" || retainUntilDate == "" {
+		return nil, ErrObjectLockInvalidHeaders
+	}
+	if !isObjectLockEnabled(bucketName) {
+		return nil, ErrInvalidBucketObjectLockConfiguration
+	}
+
+	retention := ObjectRetention{Mode: RetentionMode(strings.ToUpper(mode))}
+	if !retention.Mode.IsValid() {
+		return nil, ErrUnknownWORMModeDirective
+	}
+	var err error
+	if retention.RetainUntilDate, err = time.Parse(time.RFC3339, retainUntilDate); err != nil {
+		return nil, ErrInvalidRetentionDate
+	}
+	if !retention.RetainUntilDate.After(UTCNow()) {
+		return nil, ErrPastObjectLockRetainDate
+	}
+	return &retention, ErrNone
+}
+
+// parseObjectLegalHoldHeader - parses the legal hold given by request
+// headers of a new object version, nil if none is given.
+func parseObjectLegalHoldHeader(bucketName string, header http.Header) (*ObjectLegalHold, APIErrorCode) {
+	status := header.Get(amzObjectLockLegalHold)
+	if status == "" {
+		return nil, ErrNone
+	}
+	if !isObjectLockEnabled(bucketName) {
+		return nil, ErrInvalidBucketObjectLockConfiguration
+	}
+
+	legalHold := ObjectLegalHold{Status: LegalHoldStatus(strings.ToUpper(status))}
+	if !legalHold.Status.IsValid() {
+		return nil, ErrUnknownLegalHoldStatus
+	}
+	return &legalHold, ErrNone
+}
+
+// setObjectLockMetadata - records the retention and legal hold given by
+// request headers in the metadata of a new object version, after removing
+// any retention and legal hold copied from another object. Versions
+// stored without retention headers get the default retention of the
+// bucket, if any.
+func setObjectLockMetadata(bucketName string, header http.Header, metadata map[string]string) APIErrorCode {
+	removeObjectRetention(metadata)
+	delete(metadata, amzObjectLockLegalHold)
+	retention, s3Error := parseObjectLockHeaders(bucketName, header)
+	if s3Error != ErrNone {
+		return s3Error
+	}
+	if retention == nil {
+		retention = getDefaultRetention(bucketName, UTCNow())
+	}
+	if retention != nil {
+		setObjectRetention(metadata, *retention)
+	}
+	legalHold, s3Error := parseObjectLegalHoldHeader(bucketName, header)
+	if s3Error != ErrNone {
+		return s3Error
+	}
+	if legalHold != nil {
+		setObjectLegalHold(metadata, *legalHold)
+	}
+	return ErrNone
+}
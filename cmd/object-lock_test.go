@@ -0,0 +1,299 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests parsing retentions of object versions.
+func TestParseObjectRetention(t *testing.T) {
+	future := UTCNow().Add(time.Hour).Format(time.RFC3339)
+	past := UTCNow().Add(-time.Hour).Format(time.RFC3339)
+
+	testCases := []struct {
+		retention   string
+		expectedErr error
+	}{
+		{`<Retention xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Mode>GOVERNANCE</Mode><RetainUntilDate>` + future + `</RetainUntilDate></Retention>`, nil},
+		{`<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>` + future + `</RetainUntilDate></Retention>`, nil},
+		{`<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>` + past + `</RetainUntilDate></Retention>`, errPastRetainUntilDate},
+		{`<Retention><Mode>LOCKED</Mode><RetainUntilDate>` + future + `</RetainUntilDate></Retention>`, errInvalidRetention},
+		{`<Retention><Mode>GOVERNANCE</Mode></Retention>`, errInvalidRetention},
+	}
+
+	for i, testCase := range testCases {
+		_, err := parseObjectRetention(strings.NewReader(testCase.retention))
+		if err != testCase.expectedErr {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+// Tests parsing legal holds of object versions.
+func TestParseObjectLegalHold(t *testing.T) {
+	testCases := []struct {
+		legalHold      string
+		expectedStatus LegalHoldStatus
+		expectedErr    error
+	}{
+		{`<LegalHold xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>ON</Status></LegalHold>`, LegalHoldOn, nil},
+		{`<LegalHold><Status>OFF</Status></LegalHold>`, LegalHoldOff, nil},
+		{`<LegalHold><Status>on</Status></LegalHold>`, "", errInvalidLegalHold},
+		{`<LegalHold></LegalHold>`, "", errInvalidLegalHold},
+	}
+
+	for i, testCase := range testCases {
+		legalHold, err := parseObjectLegalHold(strings.NewReader(testCase.legalHold))
+		if err != testCase.expectedErr {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && legalHold.Status != testCase.expectedStatus {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedStatus, legalHold.Status)
+		}
+	}
+}
+
+// Tests parsing object lock configurations of buckets.
+func TestParseObjectLockConfig(t *testing.T) {
+	testCases := []struct {
+		config           string
+		expectedDuration [2]int
+		expectedErr      error
+	}{
+		{`<ObjectLockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`, [2]int{0, 0}, nil},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>30</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, [2]int{30, 0}, nil},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`, [2]int{0, 1}, nil},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>30</Days><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`, [2]int{}, errInvalidObjectLock},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode></DefaultRetention></Rule></ObjectLockConfiguration>`, [2]int{}, errInvalidObjectLock},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>-1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, [2]int{}, errInvalidObjectLock},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>LOCKED</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, [2]int{}, errInvalidObjectLock},
+		// Object lock can't be disabled.
+		{`<ObjectLockConfiguration><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, [2]int{}, errInvalidObjectLock},
+	}
+
+	for i, testCase := range testCases {
+		config, err := parseObjectLockConfig(strings.NewReader(testCase.config))
+		if err != testCase.expectedErr {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		var duration [2]int
+		if config.Rule != nil {
+			duration = [2]int{config.Rule.DefaultRetention.Days, config.Rule.DefaultRetention.Years}
+		}
+		if duration != testCase.expectedDuration {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedDuration, duration)
+		}
+	}
+}
+
+// Tests that new object versions get the default retention of their bucket.
+func TestSetObjectLockMetadataDefaultRetention(t *testing.T) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+	globalBucketMetadataSys.Set("locked", bucketObjectLockConfig, []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+	globalBucketMetadataSys.Set("default", bucketObjectLockConfig, []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>2</Days></DefaultRetention></Rule></ObjectLockConfiguration>`))
+
+	future := UTCNow().Add(time.Hour).Truncate(time.Second)
+	testCases := []struct {
+		bucketName      string
+		mode, date      string
+		expectedMode    RetentionMode
+		expectedMinDate time.Time
+		expectedMaxDate time.Time
+	}{
+		{"unlocked", "", "", "", time.Time{}, time.Time{}},
+		{"locked", "", "", "", time.Time{}, time.Time{}},
+		{"default", "", "", RetentionGovernance, UTCNow().AddDate(0, 0, 2).Add(-time.Minute), UTCNow().AddDate(0, 0, 2).Add(time.Minute)},
+		// Retention headers take precedence over the default retention.
+		{"default", "COMPLIANCE", future.Format(time.RFC3339), RetentionCompliance, future, future},
+	}
+
+	for i, testCase := range testCases {
+		header := http.Header{}
+		if testCase.mode != "" {
+			header.Set(amzObjectLockMode, testCase.mode)
+			header.Set(amzObjectLockRetainUntilDate, testCase.date)
+		}
+		metadata := map[string]string{}
+		if errCode := setObjectLockMetadata(testCase.bucketName, header, metadata); errCode != ErrNone {
+			t.Fatalf("case %v: unexpected error %v", i+1, errCode)
+		}
+		retention, ok := getObjectRetention(metadata)
+		if ok != (testCase.expectedMode != "") {
+			t.Fatalf("case %v: unexpected retention %v", i+1, metadata)
+		}
+		if !ok {
+			continue
+		}
+		if retention.Mode != testCase.expectedMode || retention.RetainUntilDate.Before(testCase.expectedMinDate) || retention.RetainUntilDate.After(testCase.expectedMaxDate) {
+			t.Errorf("case %v: unexpected retention %+v", i+1, retention)
+		}
+	}
+}
+
+// Tests parsing retentions given by request headers.
+func TestParseObjectLockHeaders(t *testing.T) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+	globalBucketMetadataSys.Set("locked", bucketObjectLockConfig, []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+
+	future := UTCNow().Add(time.Hour).Format(time.RFC3339)
+	past := UTCNow().Add(-time.Hour).Format(time.RFC3339)
+
+	testCases := []struct {
+		bucketName      string
+		mode, date      string
+		expectRetention bool
+		expectedErr     APIErrorCode
+	}{
+		{"locked", "", "", false, ErrNone},
+		{"locked", "governance", future, true, ErrNone},
+		{"locked", "COMPLIANCE", future, true, ErrNone},
+		{"locked", "COMPLIANCE", "", false, ErrObjectLockInvalidHeaders},
+		{"locked", "", future, false, ErrObjectLockInvalidHeaders},
+		{"locked", "LOCKED", future, false, ErrUnknownWORMModeDirective},
+		{"locked", "COMPLIANCE", "tomorrow", false, ErrInvalidRetentionDate},
+		{"locked", "COMPLIANCE", past, false, ErrPastObjectLockRetainDate},
+		{"unlocked", "COMPLIANCE", future, false, ErrInvalidBucketObjectLockConfiguration},
+		{"unlocked", "", "", false, ErrNone},
+	}
+
+	for i, testCase := range testCases {
+		header := http.Header{}
+		if testCase.mode != "" {
+			header.Set(amzObjectLockMode, testCase.mode)
+		}
+		if testCase.date != "" {
+			header.Set(amzObjectLockRetainUntilDate, testCase.date)
+		}
+		retention, errCode := parseObjectLockHeaders(testCase.bucketName, header)
+		if errCode != testCase.expectedErr {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, errCode)
+		}
+		if (retention != nil) != testCase.expectRetention {
+			t.Errorf("case %v: unexpected retention %v", i+1, retention)
+		}
+	}
+
+	legalHoldCases := []struct {
+		bucketName     string
+		status         string
+		expectedStatus LegalHoldStatus
+		expectedErr    APIErrorCode
+	}{
+		{"locked", "", "", ErrNone},
+		{"locked", "on", LegalHoldOn, ErrNone},
+		{"locked", "OFF", LegalHoldOff, ErrNone},
+		{"locked", "HOLD", "", ErrUnknownLegalHoldStatus},
+		{"unlocked", "ON", "", ErrInvalidBucketObjectLockConfiguration},
+	}
+
+	for i, testCase := range legalHoldCases {
+		header := http.Header{}
+		if testCase.status != "" {
+			header.Set(amzObjectLockLegalHold, testCase.status)
+		}
+		legalHold, errCode := parseObjectLegalHoldHeader(testCase.bucketName, header)
+		if errCode != testCase.expectedErr {
+			t.Errorf("legal hold case %v: expected: %v, got: %v", i+1, testCase.expectedErr, errCode)
+		}
+		var status LegalHoldStatus
+		if legalHold != nil {
+			status = legalHold.Status
+		}
+		if status != testCase.expectedStatus {
+			t.Errorf("legal hold case %v: expected: %v, got: %v", i+1, testCase.expectedStatus, status)
+		}
+	}
+}
+
+// Tests that retentions in effect can only be extended.
+func TestCheckObjectRetentionUpdate(t *testing.T) {
+	now := UTCNow().Truncate(time.Second)
+	locked := func(mode RetentionMode, until time.Time) ObjectInfo {
+		objInfo := ObjectInfo{Bucket: "bucket", Name: "object", UserDefined: map[string]string{}}
+		setObjectRetention(objInfo.UserDefined, ObjectRetention{Mode: mode, RetainUntilDate: until})
+		return objInfo
+	}
+
+	bypass := withGovernanceBypass(context.Background())
+
+	testCases := []struct {
+		ctx       context.Context
+		objInfo   ObjectInfo
+		retention ObjectRetention
+		locked    bool
+	}{
+		{context.Background(), ObjectInfo{}, ObjectRetention{Mode: RetentionGovernance, RetainUntilDate: now.Add(time.Hour)}, false},
+		{context.Background(), locked(RetentionGovernance, now.Add(time.Hour)), ObjectRetention{Mode: RetentionGovernance, RetainUntilDate: now.Add(2 * time.Hour)}, false},
+		{context.Background(), locked(RetentionGovernance, now.Add(time.Hour)), ObjectRetention{Mode: RetentionCompliance, RetainUntilDate: now.Add(time.Hour)}, false},
+		{context.Background(), locked(RetentionGovernance, now.Add(time.Hour)), ObjectRetention{Mode: RetentionGovernance, RetainUntilDate: now.Add(time.Minute)}, true},
+		{context.Background(), locked(RetentionCompliance, now.Add(time.Hour)), ObjectRetention{Mode: RetentionGovernance, RetainUntilDate: now.Add(2 * time.Hour)}, true},
+		{context.Background(), locked(RetentionCompliance, now.Add(-time.Hour)), ObjectRetention{Mode: RetentionGovernance, RetainUntilDate: now.Add(time.Minute)}, false},
+		{bypass, locked(RetentionGovernance, now.Add(time.Hour)), ObjectRetention{Mode: RetentionGovernance, RetainUntilDate: now.Add(time.Minute)}, false},
+		{bypass, locked(RetentionCompliance, now.Add(time.Hour)), ObjectRetention{Mode: RetentionCompliance, RetainUntilDate: now.Add(time.Minute)}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := checkObjectRetentionUpdate(testCase.ctx, testCase.objInfo, testCase.retention)
+		if _, ok := err.(ObjectLocked); ok != testCase.locked {
+			t.Errorf("case %v: expected locked: %v, got: %v", i+1, testCase.locked, err)
+		}
+	}
+}
+
+// Tests that only versions in governance mode can be deleted when
+// bypassing governance retention.
+func TestCheckObjectDeletable(t *testing.T) {
+	now := UTCNow().Truncate(time.Second)
+	locked := func(mode RetentionMode, legalHold LegalHoldStatus) ObjectInfo {
+		objInfo := ObjectInfo{Bucket: "bucket", Name: "object", UserDefined: map[string]string{}}
+		setObjectRetention(objInfo.UserDefined, ObjectRetention{Mode: mode, RetainUntilDate: now.Add(time.Hour)})
+		setObjectLegalHold(objInfo.UserDefined, ObjectLegalHold{Status: legalHold})
+		return objInfo
+	}
+	bypass := withGovernanceBypass(context.Background())
+
+	testCases := []struct {
+		ctx     context.Context
+		objInfo ObjectInfo
+		locked  bool
+	}{
+		{context.Background(), ObjectInfo{}, false},
+		{context.Background(), locked(RetentionGovernance, LegalHoldOff), true},
+		{context.Background(), locked(RetentionCompliance, LegalHoldOff), true},
+		{bypass, ObjectInfo{}, false},
+		{bypass, locked(RetentionGovernance, LegalHoldOff), false},
+		{bypass, locked(RetentionGovernance, LegalHoldOn), true},
+		{bypass, locked(RetentionCompliance, LegalHoldOff), true},
+	}
+
+	for i, testCase := range testCases {
+		err := checkObjectDeletable(testCase.ctx, testCase.objInfo)
+		if _, ok := err.(ObjectLocked); ok != testCase.locked {
+			t.Errorf("case %v: expected locked: %v, got: %v", i+1, testCase.locked, err)
+		}
+	}
+}
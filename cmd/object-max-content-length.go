@@ -0,0 +1,49 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Query parameter of put-object and put-object-part requests giving the
+// maximum size of the upload. It is part of the canonical query of
+// signature V4, a presigned URL cannot be used to upload more than the
+// signed size. Signature V2 does not sign it, it is rejected by presigned
+// V2 requests.
+const minioMaxContentLength = "X-Minio-Max-Content-Length"
+
+// checkMaxContentLength - returns an error if the size of an upload is
+// larger than the maximum content length of the request, if any.
+func checkMaxContentLength(r *http.Request, size int64) APIErrorCode {
+	maxStr := r.URL.Query().Get(minioMaxContentLength)
+	if maxStr == "" {
+		return ErrNone
+	}
+	if getRequestAuthType(r) == authTypePresignedV2 {
+		return ErrUnsignedMaxContentLength
+	}
+	max, err := strconv.ParseInt(maxStr, 10, 64)
+	if err != nil || max < 0 {
+		return ErrInvalidMaxContentLength
+	}
+	if size > max {
+		return ErrEntityTooLarge
+	}
+	return ErrNone
+}
@@ -0,0 +1,97 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling presigned put object tests with a maximum content
+// length for both XL multiple disks and single node setup.
+func TestPutObjectMaxContentLength(t *testing.T) {
+	ExecObjectLayerAPITest(t, testPutObjectMaxContentLength, []string{"PutObject"})
+}
+
+func testPutObjectMaxContentLength(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	data := []byte("hello world")
+	testCases := []struct {
+		maxContentLength string
+		tamperedLength   string
+		expectedCode     int
+		expectedError    string
+	}{
+		{"", "", http.StatusOK, ""},
+		{"11", "", http.StatusOK, ""},
+		{"1048576", "", http.StatusOK, ""},
+		{"5", "", http.StatusBadRequest, "EntityTooLarge"},
+		{"-1", "", http.StatusBadRequest, "InvalidArgument"},
+		{"five", "", http.StatusBadRequest, "InvalidArgument"},
+		// The maximum content length is signed, it cannot be raised.
+		{"5", "1048576", http.StatusForbidden, "SignatureDoesNotMatch"},
+	}
+	for i, testCase := range testCases {
+		queryValues := url.Values{}
+		if testCase.maxContentLength != "" {
+			queryValues.Set(minioMaxContentLength, testCase.maxContentLength)
+		}
+		req, err := newTestRequest("PUT", makeTestTargetURL("", bucketName, "object", queryValues), int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Test %d: Failed to create HTTP request: <ERROR> %v", instanceType, i+1, err)
+		}
+		if err = preSignV4(req, credentials.AccessKey, credentials.SecretKey, 60); err != nil {
+			t.Fatalf("%s: Test %d: Failed to presign HTTP request: <ERROR> %v", instanceType, i+1, err)
+		}
+		if testCase.tamperedLength != "" {
+			query := req.URL.Query()
+			query.Set(minioMaxContentLength, testCase.tamperedLength)
+			req.URL.RawQuery = query.Encode()
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if testCase.expectedError != "" && !strings.Contains(rec.Body.String(), testCase.expectedError) {
+			t.Errorf("%s: Test %d: Expected the error %s, but instead found %s", instanceType, i+1, testCase.expectedError, rec.Body.String())
+		}
+	}
+
+	// Signature V2 does not sign the maximum content length, presigned
+	// V2 requests having one are rejected.
+	queryValues := url.Values{}
+	queryValues.Set(minioMaxContentLength, "1048576")
+	req, err := newTestRequest("PUT", makeTestTargetURL("", bucketName, "object", queryValues), int64(len(data)), bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+	}
+	if err = preSignV2(req, credentials.AccessKey, credentials.SecretKey, 60); err != nil {
+		t.Fatalf("%s: Failed to presign HTTP request: <ERROR> %v", instanceType, err)
+	}
+	rec := httptest.NewRecorder()
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "X-Minio-Max-Content-Length is not signed") {
+		t.Errorf("%s: Expected the V2 presigned request to be rejected, but instead found `%d` %s", instanceType, rec.Code, rec.Body.String())
+	}
+}
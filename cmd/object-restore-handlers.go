@@ -0,0 +1,111 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/lifecycle"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// RestoreObjectHandler - restores the data of a transitioned object
+// version from its tier for a number of days as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPOSTrestore.html
+// Responds 202 Accepted once restored, 200 OK if the version was already
+// restored and only its expiry changed.
+func (api objectAPIHandlers) RestoreObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RestoreObject")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsTransitionSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.RestoreObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" && !isValidVersionID(versionID) {
+		writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+		return
+	}
+
+	// RestoreObject always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+
+	if r.ContentLength > maxRestoreRequestSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	var restoreRequest RestoreRequest
+	if err := xml.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&restoreRequest); err != nil || restoreRequest.Days < 1 {
+		writeErrorResponse(w, ErrMalformedXML, r.URL)
+		return
+	}
+
+	// Select queries on the restored data are not supported.
+	if restoreRequest.Type != "" {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	var objInfo ObjectInfo
+	var err error
+	if versionID != "" {
+		objInfo, err = objectAPI.GetObjectVersionInfo(ctx, bucket, object, versionID)
+	} else {
+		objInfo, err = objectAPI.GetObjectInfo(ctx, bucket, object)
+	}
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	transition, _ := getObjectTransition(objInfo.UserDefined)
+
+	expiry := lifecycle.ExpectedExpiryTime(UTCNow(), restoreRequest.Days)
+	if objInfo, err = objectAPI.RestoreObject(ctx, bucket, object, versionID, expiry); err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	setObjectVersionHeaders(w, objInfo)
+	if transition.IsRestored() {
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+	writeResponse(w, http.StatusAccepted, nil, mimeNone)
+}
@@ -0,0 +1,121 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Tests the restore of transitioned objects through the S3 API.
+func TestRestoreObjectHandler(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	// The server is its own tier.
+	remote := StartTestServer(t, "XL")
+	defer remote.Stop()
+	obj := remote.Obj
+	credentials := auth.Credentials{AccessKey: remote.AccessKey, SecretKey: remote.SecretKey}
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	if err = obj.MakeBucketWithLocation(ctx, "tier-bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	globalTierConfigSys = NewTierConfigSys()
+	globalTierConfigSys.tiers["WARM"] = TierConfig{
+		Name:        "WARM",
+		Type:        MinioTier,
+		Endpoint:    remote.Server.URL,
+		Bucket:      "tier-bucket",
+		Credentials: credentials,
+	}
+	defer func() { globalTierConfigSys = NewTierConfigSys() }()
+
+	bucket := "bucket"
+	data := []byte("hello, restored world")
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"object", "local"} {
+		objInfo, perr := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil)
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		if object == "object" {
+			if perr = transitionObject(ctx, obj, objInfo, "WARM"); perr != nil {
+				t.Fatal(perr)
+			}
+		}
+	}
+
+	apiRouter := initTestAPIEndPoints(obj, []string{"RestoreObject", "HeadObject"})
+	serve := func(method, urlStr string, body string) *httptest.ResponseRecorder {
+		req, rerr := newTestSignedRequestV4(method, urlStr, int64(len(body)), strings.NewReader(body), credentials.AccessKey, credentials.SecretKey)
+		if rerr != nil {
+			t.Fatalf("Failed to create HTTP request: <ERROR> %v", rerr)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	restoreRequest := `<RestoreRequest><Days>2</Days></RestoreRequest>`
+	testCases := []struct {
+		object       string
+		data         string
+		expectedCode int
+	}{
+		{"object", `<RestoreRequest><Days>0</Days></RestoreRequest>`, http.StatusBadRequest},
+		{"object", `<RestoreRequest>`, http.StatusBadRequest},
+		{"object", `<RestoreRequest><Days>2</Days><Type>SELECT</Type></RestoreRequest>`, http.StatusNotImplemented},
+		{"missing", restoreRequest, http.StatusNotFound},
+		{"local", restoreRequest, http.StatusForbidden},
+		{"object", restoreRequest, http.StatusAccepted},
+		{"object", restoreRequest, http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := serve("POST", getRestoreObjectURL("", bucket, testCase.object), testCase.data)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("Test %d: Expected the response status to be `%d`, but instead found `%d`", i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+
+	rec := serve("HEAD", getHeadObjectURL("", bucket, "object"), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+	if restore := rec.Header().Get(amzRestore); !strings.HasPrefix(restore, `ongoing-request="false", expiry-date=`) {
+		t.Errorf("Unexpected %s header %q", amzRestore, restore)
+	}
+	if rec = serve("HEAD", getHeadObjectURL("", bucket, "local"), ""); rec.Header().Get(amzRestore) != "" {
+		t.Errorf("Unexpected %s header %q", amzRestore, rec.Header().Get(amzRestore))
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// SearchObjectsHandler - Minio extension which returns the objects of a
+// bucket having all tags given as tag=<key>=<value> and matching all
+// metadata predicates given as metadata=<key><op><value>, with op one
+// of =, !=, <, <=, > and >=, or metadata=<key> for present metadata.
+// Results are ordered by name and continued after the marker.
+//
+// Buckets with a metadata index enabled are searched through the index,
+// objects created since it was last built are not found yet. Other
+// buckets are listed entirely.
+func (api objectAPIHandlers) SearchObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SearchObjects")
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.ListBucketAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	urlValues := r.URL.Query()
+	search, err := parseObjectSearch(urlValues)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	marker := urlValues.Get("marker")
+	maxKeys := maxObjectList
+	if urlValues.Get("max-keys") != "" {
+		if maxKeys, err = strconv.Atoi(urlValues.Get("max-keys")); err != nil || maxKeys < 0 {
+			writeErrorResponse(w, ErrInvalidMaxKeys, r.URL)
+			return
+		}
+	}
+
+	// Only the objects which the requester may read are returned as
+	// their metadata is matched.
+	filter := func(objInfo ObjectInfo) bool {
+		return isObjectActionAllowed(r, policy.GetObjectAction, bucket, objInfo.Name)
+	}
+	searchInfo, err := searchObjects(ctx, objectAPI, bucket, search, marker, maxKeys, filter)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	for i := range searchInfo.Objects {
+		if searchInfo.Objects[i].IsEncrypted() {
+			searchInfo.Objects[i].Size, err = searchInfo.Objects[i].DecryptedSize()
+			if err != nil {
+				writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+				return
+			}
+		}
+	}
+
+	response := generateSearchObjectsResponse(bucket, search.prefix, marker, maxKeys, searchInfo)
+
+	// Write success response.
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
@@ -0,0 +1,320 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Maximum number of tag and metadata predicates of a search request.
+	maxSearchPredicates = 10
+
+	// Comparison operators of metadata predicates, two characters
+	// operators are matched first.
+	searchOpNotEqual     = "!="
+	searchOpGreaterEqual = ">="
+	searchOpLessEqual    = "<="
+	searchOpEqual        = "="
+	searchOpGreater      = ">"
+	searchOpLess         = "<"
+)
+
+// errInvalidSearchQuery - a tag or metadata predicate of a search request is malformed.
+var errInvalidSearchQuery = errors.New("Invalid tag or metadata predicate of the search request")
+
+// metadataPredicate - condition on a metadata value of an object, an
+// empty operator only requires the metadata to be present.
+type metadataPredicate struct {
+	key   string
+	op    string
+	value string
+}
+
+// parseMetadataPredicate - parses a predicate of the form <key><op><value>
+// or <key>. Keys of user metadata may omit their x-amz-meta- prefix.
+func parseMetadataPredicate(s string) (metadataPredicate, error) {
+	var p metadataPredicate
+	i := strings.IndexAny(s, "!=<>")
+	if i < 0 {
+		p.key = s
+	} else {
+		p.key = s[:i]
+		for _, op := range []string{searchOpNotEqual, searchOpGreaterEqual, searchOpLessEqual, searchOpEqual, searchOpGreater, searchOpLess} {
+			if strings.HasPrefix(s[i:], op) {
+				p.op, p.value = op, s[i+len(op):]
+				break
+			}
+		}
+		if p.op == "" {
+			return p, errInvalidSearchQuery
+		}
+	}
+
+	p.key = strings.ToLower(p.key)
+	if p.key == "" {
+		return p, errInvalidSearchQuery
+	}
+	if p.key != "content-type" && !strings.HasPrefix(p.key, "x-amz-meta-") {
+		p.key = "x-amz-meta-" + p.key
+	}
+	return p, nil
+}
+
+// compareSearchValues - compares two metadata values numerically when
+// both are numbers, lexically otherwise.
+func compareSearchValues(a, b string) int {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	if errX == nil && errY == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// match - returns true if the metadata value satisfies the predicate.
+func (p metadataPredicate) match(value string, ok bool) bool {
+	if !ok {
+		// Objects without the metadata only match inequalities.
+		return p.op == searchOpNotEqual
+	}
+	switch p.op {
+	case "":
+		return true
+	case searchOpEqual:
+		return value == p.value
+	case searchOpNotEqual:
+		return value != p.value
+	}
+	c := compareSearchValues(value, p.value)
+	switch p.op {
+	case searchOpGreater:
+		return c > 0
+	case searchOpGreaterEqual:
+		return c >= 0
+	case searchOpLess:
+		return c < 0
+	}
+	return c <= 0
+}
+
+// objectSearch - search request of objects of a bucket whose name has
+// prefix, which have all tags and match all metadata predicates.
+type objectSearch struct {
+	prefix     string
+	tags       map[string]string
+	predicates []metadataPredicate
+}
+
+// parseObjectSearch - parses the query of a search request, tags are
+// given as tag=<key>=<value> and metadata as metadata=<predicate>, both
+// any number of times up to maxSearchPredicates.
+func parseObjectSearch(values url.Values) (s objectSearch, err error) {
+	s.prefix = values.Get("prefix")
+	if len(values["tag"])+len(values["metadata"]) > maxSearchPredicates {
+		return s, errInvalidSearchQuery
+	}
+
+	for _, tag := range values["tag"] {
+		kv := strings.SplitN(tag, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return s, errInvalidSearchQuery
+		}
+		if s.tags == nil {
+			s.tags = make(map[string]string)
+		}
+		s.tags[kv[0]] = kv[1]
+	}
+
+	for _, predicate := range values["metadata"] {
+		p, err := parseMetadataPredicate(predicate)
+		if err != nil {
+			return s, err
+		}
+		s.predicates = append(s.predicates, p)
+	}
+	return s, nil
+}
+
+// getObjectMetadataValue - returns the metadata value of an object for
+// given lower case key.
+func getObjectMetadataValue(objInfo ObjectInfo, key string) (string, bool) {
+	if key == "content-type" && objInfo.ContentType != "" {
+		return objInfo.ContentType, true
+	}
+	for k, v := range objInfo.UserDefined {
+		if strings.ToLower(k) == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// match - returns true if the object is a result of the search.
+func (s objectSearch) match(objInfo ObjectInfo) bool {
+	if !strings.HasPrefix(objInfo.Name, s.prefix) {
+		return false
+	}
+	if len(s.tags) > 0 {
+		tags := getObjectTags(objInfo.UserDefined).ToMap()
+		for k, v := range s.tags {
+			if value, ok := tags[k]; !ok || value != v {
+				return false
+			}
+		}
+	}
+	for _, p := range s.predicates {
+		if !p.match(getObjectMetadataValue(objInfo, p.key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchObjectsInfo - objects found by a search request, ordered by name.
+// IndexUpdated is the time of the metadata index searched, if any.
+type SearchObjectsInfo struct {
+	IsTruncated  bool
+	NextMarker   string
+	Objects      []ObjectInfo
+	IndexUpdated time.Time
+}
+
+// searchObjects - returns up to maxKeys objects matching the search
+// after marker. The metadata index of the bucket is searched if it is
+// enabled and built, every object found there is checked again as it
+// may have changed since. Otherwise all objects under the prefix are
+// listed. filter drops the objects the requester may not read.
+func searchObjects(ctx context.Context, objAPI ObjectLayer, bucket string, s objectSearch, marker string, maxKeys int, filter func(ObjectInfo) bool) (result SearchObjectsInfo, err error) {
+	if maxKeys == 0 {
+		return result, nil
+	}
+	if maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	// add - appends a matching object to the result, returns false once
+	// the result is full and truncated.
+	add := func(objInfo ObjectInfo) bool {
+		if !filter(objInfo) {
+			return true
+		}
+		if len(result.Objects) == maxKeys {
+			result.IsTruncated = true
+			result.NextMarker = result.Objects[maxKeys-1].Name
+			return false
+		}
+		result.Objects = append(result.Objects, objInfo)
+		return true
+	}
+
+	if index, ok := getBucketMetadataIndex(ctx, objAPI, bucket); ok {
+		result.IndexUpdated = index.Updated
+		i := sort.Search(len(index.Objects), func(i int) bool { return index.Objects[i].Name > marker })
+		for ; i < len(index.Objects); i++ {
+			if !s.match(index.Objects[i].toObjectInfo(bucket)) {
+				continue
+			}
+			objInfo, err := objAPI.GetObjectInfo(ctx, bucket, index.Objects[i].Name)
+			if err != nil {
+				if isErrObjectNotFound(err) {
+					continue
+				}
+				return result, err
+			}
+			if s.match(objInfo) && !add(objInfo) {
+				break
+			}
+		}
+		return result, nil
+	}
+
+	for {
+		loi, err := objAPI.ListObjects(ctx, bucket, s.prefix, marker, "", maxObjectList)
+		if err != nil {
+			return result, err
+		}
+		for _, objInfo := range loi.Objects {
+			if s.match(objInfo) && !add(objInfo) {
+				return result, nil
+			}
+		}
+		if !loi.IsTruncated || len(loi.Objects) == 0 {
+			return result, nil
+		}
+		marker = loi.NextMarker
+		if marker == "" {
+			marker = loi.Objects[len(loi.Objects)-1].Name
+		}
+	}
+}
+
+// SearchObjectsResponse - format for search objects response.
+type SearchObjectsResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ SearchObjectsResult" json:"-"`
+
+	Name         string
+	Prefix       string
+	Marker       string
+	NextMarker   string `xml:"NextMarker,omitempty"`
+	MaxKeys      int
+	IsTruncated  bool
+	IndexUpdated string `xml:"IndexUpdated,omitempty"`
+
+	Contents []Object
+}
+
+// generateSearchObjectsResponse - generates the response of a search request.
+func generateSearchObjectsResponse(bucket, prefix, marker string, maxKeys int, info SearchObjectsInfo) SearchObjectsResponse {
+	response := SearchObjectsResponse{
+		Name:        bucket,
+		Prefix:      prefix,
+		Marker:      marker,
+		NextMarker:  info.NextMarker,
+		MaxKeys:     maxKeys,
+		IsTruncated: info.IsTruncated,
+	}
+	if !info.IndexUpdated.IsZero() {
+		response.IndexUpdated = info.IndexUpdated.UTC().Format(timeFormatAMZLong)
+	}
+	for _, object := range info.Objects {
+		content := Object{
+			Key:          object.Name,
+			LastModified: object.ModTime.UTC().Format(timeFormatAMZLong),
+			Size:         object.Size,
+			StorageClass: object.StorageClass,
+		}
+		if object.ETag != "" {
+			content.ETag = "\"" + object.ETag + "\""
+		}
+		response.Contents = append(response.Contents, content)
+	}
+	return response
+}
@@ -0,0 +1,175 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Tests parsing and matching of metadata predicates.
+func TestMetadataPredicate(t *testing.T) {
+	testCases := []struct {
+		predicate     string
+		expectedErr   error
+		value         string
+		present       bool
+		expectedMatch bool
+	}{
+		{"color=red", nil, "red", true, true},
+		{"X-Amz-Meta-Color=red", nil, "blue", true, false},
+		{"color!=red", nil, "", false, true},
+		{"color", nil, "", true, true},
+		{"color", nil, "", false, false},
+		{"size>9", nil, "10", true, true},
+		{"size<=9", nil, "10", true, false},
+		{"name>=b", nil, "a", true, false},
+		{"=red", errInvalidSearchQuery, "", false, false},
+		{"color!red", errInvalidSearchQuery, "", false, false},
+	}
+
+	for i, testCase := range testCases {
+		p, err := parseMetadataPredicate(testCase.predicate)
+		if err != testCase.expectedErr {
+			t.Fatalf("Test %d: Expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if p.key != "x-amz-meta-color" && p.key != "x-amz-meta-size" && p.key != "x-amz-meta-name" {
+			t.Errorf("Test %d: Unexpected metadata key %s", i+1, p.key)
+		}
+		if match := p.match(testCase.value, testCase.present); match != testCase.expectedMatch {
+			t.Errorf("Test %d: Expected match %t, got %t", i+1, testCase.expectedMatch, match)
+		}
+	}
+}
+
+// Wrapper for calling SearchObjects tests for both XL multiple disks and single node setup.
+func TestSearchObjectsHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testSearchObjectsHandler, []string{"SearchObjects"})
+}
+
+func testSearchObjectsHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	objects := []struct {
+		name     string
+		metadata map[string]string
+	}{
+		{"a", map[string]string{"content-type": "image/png", "X-Amz-Meta-Camera": "nikon", objectTaggingKey: "project=alpha"}},
+		{"b", map[string]string{"content-type": "image/jpeg", "X-Amz-Meta-Camera": "canon", objectTaggingKey: "project=alpha&team=web"}},
+		{"c", map[string]string{"content-type": "text/plain"}},
+		{"dir/d", map[string]string{"content-type": "image/png", "X-Amz-Meta-Camera": "nikon"}},
+	}
+	for _, object := range objects {
+		if _, err := obj.PutObject(context.Background(), bucketName, object.name, mustGetHashReader(t, bytes.NewReader([]byte("hello")), 5, "", ""), object.metadata); err != nil {
+			t.Fatalf("%s: Failed to create object: <ERROR> %v", instanceType, err)
+		}
+	}
+
+	search := func(query url.Values) (*httptest.ResponseRecorder, []string, SearchObjectsResponse) {
+		query.Set("search", "")
+		req, err := newTestSignedRequestV4("GET", makeTestTargetURL("", bucketName, "", query), 0, nil, credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		var response SearchObjectsResponse
+		var keys []string
+		if rec.Code == http.StatusOK {
+			if err = xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("%s: Failed to parse response: <ERROR> %v", instanceType, err)
+			}
+			for _, object := range response.Contents {
+				keys = append(keys, object.Key)
+			}
+		}
+		return rec, keys, response
+	}
+
+	testCases := []struct {
+		query              url.Values
+		expectedRespStatus int
+		expectedKeys       []string
+	}{
+		// 1. Tag equality.
+		{url.Values{"tag": {"project=alpha"}}, http.StatusOK, []string{"a", "b"}},
+		// 2. Tags and metadata.
+		{url.Values{"tag": {"project=alpha"}, "metadata": {"camera=nikon"}}, http.StatusOK, []string{"a"}},
+		// 3. Content type and prefix.
+		{url.Values{"metadata": {"content-type=image/png"}, "prefix": {"dir/"}}, http.StatusOK, []string{"dir/d"}},
+		// 4. Objects without the metadata.
+		{url.Values{"metadata": {"camera!=nikon"}}, http.StatusOK, []string{"b", "c"}},
+		// 5. Present metadata continued after a marker.
+		{url.Values{"metadata": {"camera"}, "marker": {"a"}}, http.StatusOK, []string{"b", "dir/d"}},
+		// 6. Malformed predicate.
+		{url.Values{"tag": {"project"}}, http.StatusBadRequest, nil},
+	}
+
+	run := func(index bool) {
+		for i, testCase := range testCases {
+			rec, keys, response := search(testCase.query)
+			if rec.Code != testCase.expectedRespStatus {
+				t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+			}
+			if !reflect.DeepEqual(keys, testCase.expectedKeys) {
+				t.Errorf("Test %d: %s: Expected the keys %v, but instead found %v", i+1, instanceType, testCase.expectedKeys, keys)
+			}
+			if rec.Code == http.StatusOK && (response.IndexUpdated != "") != index {
+				t.Errorf("Test %d: %s: Expected the index to be searched: %t", i+1, instanceType, index)
+			}
+		}
+	}
+
+	// Search without index lists all objects.
+	run(false)
+
+	query := url.Values{"metadata": {"content-type=image/png"}, "max-keys": {"1"}}
+	_, keys, response := search(query)
+	if !reflect.DeepEqual(keys, []string{"a"}) || !response.IsTruncated || response.NextMarker != "a" {
+		t.Errorf("%s: Expected a truncated page of the key a, but instead found %v", instanceType, keys)
+	}
+
+	// Search through the index.
+	globalBucketMetadataSys.Set(bucketName, bucketMetadataIndexConfig, []byte(`{"enabled":true}`))
+	if err := buildBucketMetadataIndex(context.Background(), obj, bucketName, nil); err != nil {
+		t.Fatalf("%s: Failed to build metadata index: <ERROR> %v", instanceType, err)
+	}
+	run(true)
+
+	// Objects deleted since the index was built are not returned.
+	if err := obj.DeleteObject(context.Background(), bucketName, "a"); err != nil {
+		t.Fatalf("%s: Failed to delete object: <ERROR> %v", instanceType, err)
+	}
+	if _, keys, _ = search(url.Values{"tag": {"project=alpha"}}); !reflect.DeepEqual(keys, []string{"b"}) {
+		t.Errorf("%s: Expected the key b, but instead found %v", instanceType, keys)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	goioutil "io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/ioutil"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/s3select"
+)
+
+// Maximum size of the body of a select-object-content request.
+const maxSelectRequestSize = 256 * 1024
+
+// writeSelectErrorResponse - writes the error of an invalid select
+// request, its code and message are those of the select error.
+func writeSelectErrorResponse(w http.ResponseWriter, err s3select.SelectError, reqURL *url.URL) {
+	apiError := APIError{
+		Code:           err.ErrorCode(),
+		Description:    err.ErrorMessage(),
+		HTTPStatusCode: err.HTTPStatusCode(),
+	}
+	recordErrorCode(w, apiError.Code)
+	errorResponse := getAPIErrorResponse(apiError, reqURL.Path, w.Header().Get(responseRequestIDKey))
+	writeResponse(w, apiError.HTTPStatusCode, encodeResponse(errorResponse), mimeXML)
+}
+
+// SelectObjectContentHandler - filters the content of a CSV, JSON or
+// Parquet object by a SQL expression as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectSELECTContent.html
+// The matching records are streamed to the client as an event stream,
+// errors found after the response started are sent as error messages.
+func (api objectAPIHandlers) SelectObjectContentHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SelectObjectContent")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	// SelectObjectContent always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+
+	if r.ContentLength > maxSelectRequestSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	objInfo, err := objectAPI.GetObjectInfo(ctx, bucket, object)
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	// Delete markers have no content.
+	if objInfo.DeleteMarker {
+		setObjectVersionHeaders(w, objInfo)
+		writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	s3Select, err := s3select.NewS3Select(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		if serr, ok := err.(s3select.SelectError); ok {
+			writeSelectErrorResponse(w, serr, r.URL)
+		} else {
+			writeErrorResponse(w, ErrMalformedXML, r.URL)
+		}
+		return
+	}
+
+	var encrypted bool
+	if objectAPI.IsEncryptionSupported() {
+		var apiErr APIErrorCode
+		if apiErr, encrypted = DecryptObjectInfo(&objInfo, r.Header); apiErr != ErrNone {
+			writeErrorResponse(w, apiErr, r.URL)
+			return
+		}
+	}
+
+	// Decryption removes the customer key from the request headers and
+	// the sealed key from the metadata, every range is decrypted with
+	// its own copy of both.
+	customerKey := r.Header.Get(SSECustomerKey)
+	metadata := objInfo.UserDefined
+	decryptInfo := func() ObjectInfo {
+		info := objInfo
+		info.UserDefined = make(map[string]string, len(metadata))
+		for k, v := range metadata {
+			info.UserDefined[k] = v
+		}
+		if customerKey != "" {
+			r.Header.Set(SSECustomerKey, customerKey)
+		}
+		return info
+	}
+
+	// getObject - returns a reader of a range of the object, which is
+	// read as the request is evaluated. Reading stops when the reader
+	// is closed, once the limit of records is reached.
+	getObject := func(offset, length int64) (io.ReadCloser, error) {
+		pr, pw := io.Pipe()
+		var writer io.Writer = pw
+		if encrypted {
+			writer = ioutil.LimitedWriter(writer, offset%(64*1024), length)
+
+			var err error
+			writer, offset, length, err = DecryptBlocksRequest(writer, r, bucket, object, offset, length, decryptInfo(), false)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		go func() {
+			gerr := objectAPI.GetObject(ctx, bucket, object, offset, length, writer, objInfo.ETag)
+			if closer, ok := writer.(io.Closer); ok && gerr == nil {
+				gerr = closer.Close()
+			}
+			pw.CloseWithError(gerr)
+		}()
+		return pr, nil
+	}
+
+	if encrypted {
+		// The object key is verified before the response starts.
+		if _, _, _, err = DecryptBlocksRequest(goioutil.Discard, r, bucket, object, 0, objInfo.Size, decryptInfo(), false); err != nil {
+			writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+			return
+		}
+
+		if customerKey != "" {
+			w.Header().Set(SSECustomerAlgorithm, r.Header.Get(SSECustomerAlgorithm))
+			w.Header().Set(SSECustomerKeyMD5, r.Header.Get(SSECustomerKeyMD5))
+		}
+	}
+
+	if err = s3Select.Evaluate(getObject, objInfo.Size, w); err != nil {
+		if _, ok := err.(s3select.SelectError); !ok {
+			logger.LogIf(ctx, err)
+		}
+	}
+}
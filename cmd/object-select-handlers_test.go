@@ -0,0 +1,95 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Wrapper for calling select object content handler tests for both XL multiple disks and single node setup.
+func TestSelectObjectContentHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testSelectObjectContentHandler, []string{"SelectObjectContent"})
+}
+
+func testSelectObjectContentHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+
+	data := []byte("name,age\nAlice,31\nBob,25\nCarol,40\n")
+	objectName := "people.csv"
+	if _, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+		t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+	}
+
+	newRequest := func(expression string) []byte {
+		return []byte(`<SelectObjectContentRequest><Expression>` + expression + `</Expression><ExpressionType>SQL</ExpressionType>` +
+			`<InputSerialization><CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV></InputSerialization>` +
+			`<OutputSerialization><CSV></CSV></OutputSerialization></SelectObjectContentRequest>`)
+	}
+
+	testCases := []struct {
+		objectName      string
+		data            []byte
+		accessKey       string
+		secretKey       string
+		expectedCode    int
+		expectedContent []string
+	}{
+		{objectName, newRequest("SELECT name FROM S3Object WHERE CAST(age AS INT) &gt; 30"), credentials.AccessKey, credentials.SecretKey, http.StatusOK,
+			[]string{"Alice\nCarol\n", "<BytesReturned>12</BytesReturned>", "End"}},
+		{objectName, newRequest("SELECT COUNT(*) FROM S3Object"), credentials.AccessKey, credentials.SecretKey, http.StatusOK,
+			[]string{"3\n", "End"}},
+		// Errors evaluating the expression are sent as error messages.
+		{objectName, newRequest("SELECT CAST(name AS INT) FROM S3Object"), credentials.AccessKey, credentials.SecretKey, http.StatusOK,
+			[]string{"CastFailed"}},
+		// Parquet objects are read by ranges, starting with the footer.
+		{objectName, []byte(`<SelectObjectContentRequest><Expression>SELECT * FROM S3Object</Expression><ExpressionType>SQL</ExpressionType>` +
+			`<InputSerialization><Parquet></Parquet></InputSerialization><OutputSerialization><CSV></CSV></OutputSerialization></SelectObjectContentRequest>`),
+			credentials.AccessKey, credentials.SecretKey, http.StatusOK, []string{"ParquetParsingError"}},
+		{objectName, newRequest("SELECT * FROM table"), credentials.AccessKey, credentials.SecretKey, http.StatusBadRequest,
+			[]string{"<Code>ParseUnsupportedSyntax</Code>"}},
+		{objectName, []byte(`<SelectObjectContentRequest>`), credentials.AccessKey, credentials.SecretKey, http.StatusBadRequest,
+			[]string{"<Code>MalformedXML</Code>"}},
+		{"missing.csv", newRequest("SELECT * FROM S3Object"), credentials.AccessKey, credentials.SecretKey, http.StatusNotFound, nil},
+		{objectName, newRequest("SELECT * FROM S3Object"), "", "", http.StatusForbidden, nil},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("POST", getSelectObjectContentURL("", bucketName, testCase.objectName),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), testCase.accessKey, testCase.secretKey)
+		if err != nil {
+			t.Fatalf("%s: Test %d: Failed to create HTTP request: <ERROR> %v", instanceType, i+1, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		for _, content := range testCase.expectedContent {
+			if !strings.Contains(rec.Body.String(), content) {
+				t.Errorf("%s: Test %d: Expected the response to contain `%s`, but instead found `%s`", instanceType, i+1, content, rec.Body.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/tagging"
+)
+
+// PutObjectTaggingHandler - replaces the tags of an object version as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTtagging.html
+func (api objectAPIHandlers) PutObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutObjectTagging")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsTaggingSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectTaggingAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" && !isValidVersionID(versionID) {
+		writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+		return
+	}
+
+	// PutObjectTagging always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(w, ErrMissingContentLength, r.URL)
+		return
+	}
+
+	if r.ContentLength > maxObjectTaggingSize {
+		writeErrorResponse(w, ErrEntityTooLarge, r.URL)
+		return
+	}
+
+	tags, err := tagging.ParseTagging(io.LimitReader(r.Body, r.ContentLength), tagging.MaxObjectTags)
+	if err != nil {
+		apiErr := ErrMalformedXML
+		if tagging.IsTaggingError(err) {
+			apiErr = toAPIErrorCode(err)
+		}
+
+		writeErrorResponse(w, apiErr, r.URL)
+		return
+	}
+
+	objInfo, err := objectAPI.PutObjectTags(ctx, bucket, object, versionID, tags.String())
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	setObjectVersionHeaders(w, objInfo)
+	writeSuccessResponseHeadersOnly(w)
+
+	// Tags are replicated along with the object.
+	scheduleReplication(ctx, objectAPI, objInfo)
+}
+
+// GetObjectTaggingHandler - returns the tags of an object version as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGETtagging.html
+// An empty tag set is returned if the object has no tags.
+func (api objectAPIHandlers) GetObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectTagging")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsTaggingSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectTaggingAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" && !isValidVersionID(versionID) {
+		writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+		return
+	}
+
+	var objInfo ObjectInfo
+	var err error
+	if versionID != "" {
+		objInfo, err = objectAPI.GetObjectVersionInfo(ctx, bucket, object, versionID)
+	} else {
+		objInfo, err = objectAPI.GetObjectInfo(ctx, bucket, object)
+	}
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+	if objInfo.DeleteMarker {
+		setObjectVersionHeaders(w, objInfo)
+		writeErrorResponse(w, ErrMethodNotAllowed, r.URL)
+		return
+	}
+
+	tags := getObjectTags(objInfo.UserDefined)
+	tags.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	setObjectVersionHeaders(w, objInfo)
+	writeSuccessResponseXML(w, encodeResponse(tags))
+}
+
+// DeleteObjectTaggingHandler - removes the tags of an object version as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectDELETEtagging.html
+func (api objectAPIHandlers) DeleteObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteObjectTagging")
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(w, ErrServerNotInitialized, r.URL)
+		return
+	}
+
+	if !objectAPI.IsTaggingSupported() {
+		writeErrorResponse(w, ErrNotImplemented, r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteObjectTaggingAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" && !isValidVersionID(versionID) {
+		writeErrorResponse(w, ErrInvalidVersionID, r.URL)
+		return
+	}
+
+	objInfo, err := objectAPI.PutObjectTags(ctx, bucket, object, versionID, "")
+	if err != nil {
+		writeErrorResponse(w, toAPIErrorCode(err), r.URL)
+		return
+	}
+
+	setObjectVersionHeaders(w, objInfo)
+	writeSuccessNoContent(w)
+
+	// Tags are replicated along with the object.
+	scheduleReplication(ctx, objectAPI, objInfo)
+}
@@ -0,0 +1,183 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/tagging"
+)
+
+// Wrapper for calling object tagging handler tests for both XL multiple disks and single node setup.
+func TestObjectTaggingHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testObjectTaggingHandlers, []string{"PutObjectTagging", "GetObjectTagging", "DeleteObjectTagging", "CopyObject", "PutObject", "HeadObject"})
+}
+
+func testObjectTaggingHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+
+	serve := func(method, urlStr string, header http.Header, data []byte, signed bool) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if signed {
+			if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+				t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+			}
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	getTags := func(objectName string) string {
+		rec := serve("GET", getObjectTaggingURL("", bucketName, objectName), nil, nil, true)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		var tags tagging.Tagging
+		if err := xml.Unmarshal(rec.Body.Bytes(), &tags); err != nil {
+			t.Fatalf("%s: Failed to parse tagging: <ERROR> %v", instanceType, err)
+		}
+		return tags.String()
+	}
+
+	// Put an object with tags.
+	objectName := "tagged-object"
+	header := http.Header{}
+	header.Set(amzObjectTagging, "project=minio&env=test")
+	if rec := serve("PUT", getPutObjectURL("", bucketName, objectName), header, []byte("hello"), true); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	rec := serve("HEAD", getHeadObjectURL("", bucketName, objectName), nil, nil, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if count := rec.Header().Get(amzTaggingCount); count != "2" {
+		t.Errorf("%s: Expected tagging count `2`, but instead found `%s`", instanceType, count)
+	}
+	if tags := getTags(objectName); tags != "env=test&project=minio" {
+		t.Errorf("%s: Expected tags `env=test&project=minio`, but instead found `%s`", instanceType, tags)
+	}
+
+	// Invalid tags are rejected.
+	header.Set(amzObjectTagging, "aws:project=minio")
+	if rec = serve("PUT", getPutObjectURL("", bucketName, "invalid-object"), header, []byte("hello"), true); rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+
+	testCases := []struct {
+		data         string
+		expectedCode int
+		expectedTags string
+	}{
+		{`<Tagging><TagSet><Tag><Key>owner</Key><Value>team</Value></Tag></TagSet></Tagging>`, http.StatusOK, "owner=team"},
+		{`<Tagging><TagSet><Tag><Key>a</Key><Value>b</Value></Tag><Tag><Key>a</Key><Value>c</Value></Tag></TagSet></Tagging>`, http.StatusBadRequest, "owner=team"},
+		{`<Tagging><TagSet><Tag><Key>a*</Key><Value>b</Value></Tag></TagSet></Tagging>`, http.StatusBadRequest, "owner=team"},
+		{`<Tagging><TagSet>`, http.StatusBadRequest, "owner=team"},
+		{`<Tagging><TagSet></TagSet></Tagging>`, http.StatusOK, ""},
+	}
+	for i, testCase := range testCases {
+		rec = serve("PUT", getObjectTaggingURL("", bucketName, objectName), nil, []byte(testCase.data), true)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if tags := getTags(objectName); tags != testCase.expectedTags {
+			t.Errorf("%s: Test %d: Expected tags `%s`, but instead found `%s`", instanceType, i+1, testCase.expectedTags, tags)
+		}
+	}
+
+	// Tags of a copy are copied from the source object unless replaced.
+	tagsData := []byte(`<Tagging><TagSet><Tag><Key>owner</Key><Value>team</Value></Tag></TagSet></Tagging>`)
+	if rec = serve("PUT", getObjectTaggingURL("", bucketName, objectName), nil, tagsData, true); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	copyCases := []struct {
+		directive    string
+		tags         string
+		expectedCode int
+		expectedTags string
+	}{
+		{"", "", http.StatusOK, "owner=team"},
+		{"COPY", "a=b", http.StatusOK, "owner=team"},
+		{"REPLACE", "a=b", http.StatusOK, "a=b"},
+		{"REPLACE", "", http.StatusOK, ""},
+		{"MERGE", "a=b", http.StatusBadRequest, ""},
+	}
+	for i, testCase := range copyCases {
+		copyName := fmt.Sprintf("copy-object-%d", i+1)
+		header = http.Header{}
+		header.Set("X-Amz-Copy-Source", "/"+bucketName+"/"+objectName)
+		if testCase.directive != "" {
+			header.Set(amzTaggingDirective, testCase.directive)
+		}
+		if testCase.tags != "" {
+			header.Set(amzObjectTagging, testCase.tags)
+		}
+		rec = serve("PUT", getCopyObjectURL("", bucketName, copyName), header, nil, true)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+			continue
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+		if tags := getTags(copyName); tags != testCase.expectedTags {
+			t.Errorf("%s: Test %d: Expected tags `%s`, but instead found `%s`", instanceType, i+1, testCase.expectedTags, tags)
+		}
+	}
+
+	// Anonymous requests are allowed by a bucket policy on the tags of the object.
+	policyStr := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObjectTagging"],"Resource":["arn:aws:s3:::%s/*"],"Condition":{"StringEquals":{"s3:ExistingObjectTag/owner":"team"}}}]}`, bucketName)
+	bucketPolicy, err := policy.ParseConfig(strings.NewReader(policyStr), bucketName)
+	if err != nil {
+		t.Fatalf("%s: Failed to parse bucket policy: <ERROR> %v", instanceType, err)
+	}
+	globalPolicySys.Set(bucketName, *bucketPolicy)
+	defer globalPolicySys.Remove(bucketName)
+
+	if rec = serve("GET", getObjectTaggingURL("", bucketName, objectName), nil, nil, false); rec.Code != http.StatusOK {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if rec = serve("GET", getObjectTaggingURL("", bucketName, "copy-object-3"), nil, nil, false); rec.Code != http.StatusForbidden {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+
+	// Delete the tags of the object.
+	if rec = serve("DELETE", getObjectTaggingURL("", bucketName, objectName), nil, nil, true); rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+	if tags := getTags(objectName); tags != "" {
+		t.Errorf("%s: Expected no tags, but instead found `%s`", instanceType, tags)
+	}
+	if rec = serve("GET", getObjectTaggingURL("", bucketName, "missing-object"), nil, nil, true); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
+	"github.com/minio/minio/pkg/tagging"
+)
+
+const (
+	// Maximum size of a tagging in a put-object-tagging request.
+	maxObjectTaggingSize = 64 * 1024
+
+	// Request header giving the tags of a new object, URL query encoded.
+	amzObjectTagging = "x-amz-tagging"
+
+	// Request header of copy-object requests replacing the tags of
+	// the source object by the tags given by the x-amz-tagging header.
+	amzTaggingDirective = "x-amz-tagging-directive"
+
+	// Response header giving the number of tags of an object.
+	amzTaggingCount = "x-amz-tagging-count"
+
+	// Tags of an object, URL query encoded, are kept in its metadata.
+	objectTaggingKey = ReservedMetadataPrefix + "Tags"
+)
+
+// getObjectTags - returns the tags recorded in the metadata of an object.
+func getObjectTags(metadata map[string]string) *tagging.Tagging {
+	tags, err := tagging.ParseTags(metadata[objectTaggingKey], tagging.MaxObjectTags)
+	if err != nil {
+		return &tagging.Tagging{}
+	}
+	return tags
+}
+
+// setObjectTags - records the tags in the metadata of an object, an
+// empty tag set removes them.
+func setObjectTags(metadata map[string]string, tags string) {
+	if tags == "" {
+		delete(metadata, objectTaggingKey)
+		return
+	}
+	metadata[objectTaggingKey] = tags
+}
+
+// isTaggingDirectiveValid - the tagging directive of copy-object requests
+// is either COPY, the default, or REPLACE.
+func isTaggingDirectiveValid(h http.Header) bool {
+	_, ok := h[http.CanonicalHeaderKey(amzTaggingDirective)]
+	if ok {
+		return isTaggingCopy(h) || isTaggingReplace(h)
+	}
+	return true
+}
+
+// Check if the tagging COPY is requested.
+func isTaggingCopy(h http.Header) bool {
+	return h.Get(amzTaggingDirective) == "COPY"
+}
+
+// Check if the tagging REPLACE is requested.
+func isTaggingReplace(h http.Header) bool {
+	return h.Get(amzTaggingDirective) == "REPLACE"
+}
+
+// setObjectTaggingMetadata - records the tags given by the x-amz-tagging
+// header in the metadata of a new object, after removing any tags copied
+// from another object. Objects without the header get the default tags
+// of the bucket, if any.
+func setObjectTaggingMetadata(objAPI ObjectLayer, bucket string, header http.Header, metadata map[string]string) APIErrorCode {
+	delete(metadata, objectTaggingKey)
+	if _, ok := header[http.CanonicalHeaderKey(amzObjectTagging)]; !ok {
+		setBucketDefaultTags(objAPI, bucket, metadata)
+		return ErrNone
+	}
+	if !objAPI.IsTaggingSupported() {
+		return ErrNotImplemented
+	}
+
+	tags, err := tagging.ParseTags(header.Get(amzObjectTagging), tagging.MaxObjectTags)
+	if err != nil {
+		return ErrInvalidTag
+	}
+	setObjectTags(metadata, tags.String())
+	return ErrNone
+}
+
+// setTagConditionValues - sets the policy condition values of the tags
+// for the given tag condition key, e.g. RequestObjectTag/<tag-key>.
+func setTagConditionValues(conditionValues map[string][]string, key condition.Key, tags *tagging.Tagging) {
+	for _, tag := range tags.TagSet.Tags {
+		conditionValues[key.Name()+"/"+tag.Key] = []string{tag.Value}
+	}
+}
+
+// setRequestObjectTagValues - sets the policy condition values of the
+// tags given by the request.
+func setRequestObjectTagValues(conditionValues map[string][]string, tags *tagging.Tagging) {
+	keys := []string{}
+	for _, tag := range tags.TagSet.Tags {
+		keys = append(keys, tag.Key)
+	}
+	conditionValues[condition.Key(condition.S3RequestObjectTagKeys).Name()] = keys
+	setTagConditionValues(conditionValues, condition.S3RequestObjectTag, tags)
+}
+
+// readRequestTagging - returns the tagging of a put-object-tagging
+// request, nil if invalid, leaving the request body for the HTTP handler.
+func readRequestTagging(r *http.Request) *tagging.Tagging {
+	// To extract tags from XML in request body, get copy of request body.
+	payload, err := ioutil.ReadAll(io.LimitReader(r.Body, maxObjectTaggingSize))
+	if err != nil {
+		return nil
+	}
+
+	// Populate payload again to handle it in HTTP handler.
+	r.Body = ioutil.NopCloser(bytes.NewReader(payload))
+
+	// Invalid tags are rejected by the HTTP handler.
+	tags, err := tagging.ParseTagging(bytes.NewReader(payload), tagging.MaxObjectTags)
+	if err != nil {
+		return nil
+	}
+	return tags
+}
+
+// isExistingObjectTagAction - returns true if policy conditions of the
+// action may use the tags of the object, s3:ExistingObjectTag/<tag-key>.
+func isExistingObjectTagAction(action policy.Action) bool {
+	switch action {
+	case policy.GetObjectAction, policy.GetObjectVersionAction:
+		fallthrough
+	case policy.GetObjectAttributesAction, policy.GetObjectVersionAttributesAction:
+		fallthrough
+	case policy.GetObjectTaggingAction, policy.PutObjectTaggingAction, policy.DeleteObjectTaggingAction:
+		return true
+	}
+	return false
+}
+
+// setExistingObjectTagValues - sets the policy condition values of the
+// tags of the object of the request. The object is only looked up when
+// the bucket has a policy or the request is signed by a service account
+// with an inline policy, the only policies which may use them.
+func setExistingObjectTagValues(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string, conditionValues map[string][]string) {
+	if objectName == "" || !isExistingObjectTagAction(action) {
+		return
+	}
+	if !globalPolicySys.hasPolicy(bucketName) {
+		if sa, ok := globalIAMSys.GetServiceAccount(getReqAccessKey(r)); !ok || sa.Policy == nil {
+			return
+		}
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return
+	}
+
+	var objInfo ObjectInfo
+	var err error
+	if versionID := r.URL.Query().Get("versionId"); versionID != "" && objAPI.IsVersioningSupported() {
+		objInfo, err = objAPI.GetObjectVersionInfo(ctx, bucketName, objectName, versionID)
+	} else {
+		objInfo, err = objAPI.GetObjectInfo(ctx, bucketName, objectName)
+	}
+	// Missing objects are reported by the HTTP handler.
+	if err != nil {
+		return
+	}
+	setTagConditionValues(conditionValues, condition.S3ExistingObjectTag, getObjectTags(objInfo.UserDefined))
+}
@@ -0,0 +1,177 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/tagging"
+)
+
+// Object layer supporting object tagging, for tests.
+type taggingObjectLayer struct {
+	DummyObjectLayer
+}
+
+func (api *taggingObjectLayer) IsTaggingSupported() bool {
+	return true
+}
+
+// Tests validate the tagging directive of copy-object requests.
+func TestIsTaggingDirectiveValid(t *testing.T) {
+	testCases := []struct {
+		directive   []string
+		expectedRes bool
+	}{
+		{nil, true},
+		{[]string{"COPY"}, true},
+		{[]string{"REPLACE"}, true},
+		{[]string{""}, false},
+		{[]string{"copy"}, false},
+		{[]string{"MERGE"}, false},
+	}
+
+	for i, testCase := range testCases {
+		header := http.Header{}
+		if testCase.directive != nil {
+			header[http.CanonicalHeaderKey(amzTaggingDirective)] = testCase.directive
+		}
+		if res := isTaggingDirectiveValid(header); res != testCase.expectedRes {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.expectedRes, res)
+		}
+	}
+}
+
+// Tests recording the tags of the x-amz-tagging header in the object metadata.
+func TestSetObjectTaggingMetadata(t *testing.T) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+	globalBucketMetadataSys.Set("tagged", bucketDefaultTagsConfig, []byte(`{"tags":{"project":"minio"}}`))
+
+	testCases := []struct {
+		objAPI           ObjectLayer
+		bucket           string
+		tags             []string
+		metadata         map[string]string
+		expectedMetadata map[string]string
+		expectedErr      APIErrorCode
+	}{
+		// No header, copied tags are removed.
+		{&taggingObjectLayer{}, "bucket", nil, map[string]string{objectTaggingKey: "a=b"}, map[string]string{}, ErrNone},
+		{&taggingObjectLayer{}, "bucket", []string{"project=minio&env=test"}, map[string]string{},
+			map[string]string{objectTaggingKey: "env=test&project=minio"}, ErrNone},
+		{&taggingObjectLayer{}, "bucket", []string{""}, map[string]string{objectTaggingKey: "a=b"}, map[string]string{}, ErrNone},
+		{&taggingObjectLayer{}, "bucket", []string{"a=b&a=c"}, map[string]string{}, map[string]string{}, ErrInvalidTag},
+		{&taggingObjectLayer{}, "bucket", []string{"aws:a=b"}, map[string]string{}, map[string]string{}, ErrInvalidTag},
+		// Object layers without tagging support only accept requests without tags.
+		{&DummyObjectLayer{}, "bucket", nil, map[string]string{}, map[string]string{}, ErrNone},
+		{&DummyObjectLayer{}, "bucket", []string{"a=b"}, map[string]string{}, map[string]string{}, ErrNotImplemented},
+		// Objects without tags get the default tags of the bucket.
+		{&taggingObjectLayer{}, "tagged", nil, map[string]string{objectTaggingKey: "a=b"}, map[string]string{objectTaggingKey: "project=minio"}, ErrNone},
+		{&taggingObjectLayer{}, "tagged", []string{"env=test"}, map[string]string{}, map[string]string{objectTaggingKey: "env=test"}, ErrNone},
+		{&taggingObjectLayer{}, "tagged", []string{""}, map[string]string{}, map[string]string{}, ErrNone},
+		{&DummyObjectLayer{}, "tagged", nil, map[string]string{}, map[string]string{}, ErrNone},
+	}
+
+	for i, testCase := range testCases {
+		header := http.Header{}
+		if testCase.tags != nil {
+			header[http.CanonicalHeaderKey(amzObjectTagging)] = testCase.tags
+		}
+		err := setObjectTaggingMetadata(testCase.objAPI, testCase.bucket, header, testCase.metadata)
+		if err != testCase.expectedErr {
+			t.Errorf("Test %d: Expected error %v, got %v", i+1, testCase.expectedErr, err)
+			continue
+		}
+		if err == ErrNone && !reflect.DeepEqual(testCase.metadata, testCase.expectedMetadata) {
+			t.Errorf("Test %d: Expected metadata %v, got %v", i+1, testCase.expectedMetadata, testCase.metadata)
+		}
+	}
+}
+
+// Tests the policy condition values of the tags of a request.
+func TestSetRequestObjectTagValues(t *testing.T) {
+	tags, err := tagging.ParseTags("project=minio&env=test", tagging.MaxObjectTags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conditionValues := map[string][]string{}
+	setRequestObjectTagValues(conditionValues, tags)
+	expectedValues := map[string][]string{
+		"RequestObjectTagKeys":     {"env", "project"},
+		"RequestObjectTag/env":     {"test"},
+		"RequestObjectTag/project": {"minio"},
+	}
+	if !reflect.DeepEqual(conditionValues, expectedValues) {
+		t.Errorf("Expected %v, got %v", expectedValues, conditionValues)
+	}
+}
+
+// Wrapper for calling UpdateObjectTags tests for both XL multiple disks and single node setup.
+func TestObjectUpdateTags(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectUpdateTags)
+}
+
+// Tests the tags of an object are updated from its current tags.
+func testObjectUpdateTags(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	metadata := map[string]string{objectTaggingKey: "a=1"}
+	if _, err := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader([]byte("data")), 4, "", ""), metadata); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	errUpdate := errors.New("update failed")
+	testCases := []struct {
+		current      string
+		tags         string
+		err          error
+		expectedTags string
+	}{
+		{"a=1", "a=1&b=2", nil, "a=1&b=2"},
+		{"a=1&b=2", "a=1&b=2", nil, "a=1&b=2"},
+		{"a=1&b=2", "", errUpdate, "a=1&b=2"},
+		{"a=1&b=2", "", nil, ""},
+	}
+	for i, testCase := range testCases {
+		_, err := obj.UpdateObjectTags(ctx, bucket, object, "", func(current string) (string, error) {
+			if current != testCase.current {
+				t.Errorf("%s: Test %d: Expected the current tags %q, got %q", instanceType, i+1, testCase.current, current)
+			}
+			return testCase.tags, testCase.err
+		})
+		if err != testCase.err {
+			t.Fatalf("%s: Test %d: Expected the error %v, got %v", instanceType, i+1, testCase.err, err)
+		}
+		objInfo, err := obj.GetObjectInfo(ctx, bucket, object)
+		if err != nil {
+			t.Fatalf("%s: Test %d: %s", instanceType, i+1, err)
+		}
+		if tags := objInfo.UserDefined[objectTaggingKey]; tags != testCase.expectedTags {
+			t.Errorf("%s: Test %d: Expected the tags %q, got %q", instanceType, i+1, testCase.expectedTags, tags)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"time"
+
+	miniogo "github.com/minio/minio-go"
+)
+
+const (
+	// Tier holding the data of a transitioned object version.
+	objectTransitionTierKey = ReservedMetadataPrefix + "Transition-Tier"
+
+	// Name of the remote object holding the data of a transitioned
+	// object version in the bucket of its tier.
+	objectTransitionObjectKey = ReservedMetadataPrefix + "Transition-Object"
+
+	// Expiry of the local copy of the data of a restored object version.
+	objectRestoreExpiryKey = ReservedMetadataPrefix + "Restore-Expiry"
+
+	// Restore status of restored object versions in HEAD and GET responses.
+	amzRestore = "X-Amz-Restore"
+
+	// Maximum size of a restore request in a restore-object request.
+	maxRestoreRequestSize = 64 * 1024
+)
+
+// RestoreRequest - restore request of a transitioned object as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPOSTrestore.html
+// The data is restored before the request returns, the tier of the
+// retrieval is ignored.
+type RestoreRequest struct {
+	XMLName              xml.Name `xml:"RestoreRequest"`
+	Days                 int      `xml:"Days"`
+	Type                 string   `xml:"Type,omitempty"`
+	GlacierJobParameters *struct {
+		Tier string `xml:"Tier"`
+	} `xml:"GlacierJobParameters,omitempty"`
+}
+
+// ObjectTransition - remote location of the data of an object version
+// moved to a tier, only a stub holding its metadata is kept locally.
+type ObjectTransition struct {
+	Tier   string
+	Object string
+
+	// ETag of the object version when its data was uploaded to the tier,
+	// the object version is not transitioned if it changed meanwhile.
+	ETag string
+
+	// Expiry of the copy of the data restored from the tier, reads are
+	// served locally until the copy is removed. Zero if not restored.
+	RestoreExpiry time.Time
+}
+
+// IsRestored - returns whether the data of the object version is
+// restored from its tier.
+func (transition ObjectTransition) IsRestored() bool {
+	return !transition.RestoreExpiry.IsZero()
+}
+
+// getObjectTransition - returns the transition recorded in the metadata
+// of an object version, false if its data is stored locally.
+func getObjectTransition(metadata map[string]string) (ObjectTransition, bool) {
+	transition := ObjectTransition{
+		Tier:   metadata[objectTransitionTierKey],
+		Object: metadata[objectTransitionObjectKey],
+	}
+	if transition.Tier == "" || transition.Object == "" {
+		return ObjectTransition{}, false
+	}
+	if expiry, err := time.Parse(time.RFC3339, metadata[objectRestoreExpiryKey]); err == nil {
+		transition.RestoreExpiry = expiry
+	}
+	return transition, true
+}
+
+// setObjectTransition - records the transition in the metadata of an
+// object version, an empty transition removes it.
+func setObjectTransition(metadata map[string]string, transition ObjectTransition) {
+	if transition.Tier == "" {
+		delete(metadata, objectTransitionTierKey)
+		delete(metadata, objectTransitionObjectKey)
+		delete(metadata, objectRestoreExpiryKey)
+		return
+	}
+	metadata[objectTransitionTierKey] = transition.Tier
+	metadata[objectTransitionObjectKey] = transition.Object
+	if transition.IsRestored() {
+		metadata[objectRestoreExpiryKey] = transition.RestoreExpiry.UTC().Format(time.RFC3339)
+	} else {
+		delete(metadata, objectRestoreExpiryKey)
+	}
+}
+
+// newTierClient - returns a client of the remote storage of the tier.
+func newTierClient(tier TierConfig) (*miniogo.Client, error) {
+	u, err := url.Parse(tier.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return miniogo.NewWithRegion(u.Host, tier.Credentials.AccessKey, tier.Credentials.SecretKey, u.Scheme == "https", tier.Region)
+}
+
+// transitionObject - uploads the data of the object version to the tier
+// and replaces it by a stub. The data is uploaded as stored, encrypted
+// objects remain encrypted in the tier.
+func transitionObject(ctx context.Context, objAPI ObjectLayer, objInfo ObjectInfo, tierName string) error {
+	tier, ok := globalTierConfigSys.Get(tierName)
+	if !ok {
+		return errNoSuchTier
+	}
+	client, err := newTierClient(tier)
+	if err != nil {
+		return err
+	}
+
+	remoteObject := pathJoin(tier.Prefix, objInfo.Bucket, mustGetUUID())
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		if objInfo.VersionID != "" {
+			pipeWriter.CloseWithError(objAPI.GetObjectVersion(ctx, objInfo.Bucket, objInfo.Name, objInfo.VersionID, 0, objInfo.Size, pipeWriter, objInfo.ETag))
+		} else {
+			pipeWriter.CloseWithError(objAPI.GetObject(ctx, objInfo.Bucket, objInfo.Name, 0, objInfo.Size, pipeWriter, objInfo.ETag))
+		}
+	}()
+	defer pipeReader.Close()
+
+	if _, err = client.PutObjectWithContext(ctx, tier.Bucket, remoteObject, pipeReader, objInfo.Size, miniogo.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	}); err != nil {
+		return err
+	}
+
+	_, err = objAPI.TransitionObject(ctx, objInfo.Bucket, objInfo.Name, objInfo.VersionID, ObjectTransition{
+		Tier:   tier.Name,
+		Object: remoteObject,
+		ETag:   objInfo.ETag,
+	})
+	if err != nil {
+		// The object version stays local, remove its remote copy.
+		client.RemoveObject(tier.Bucket, remoteObject)
+	}
+	return err
+}
+
+// getTransitionedObject - reads the data of a transitioned object
+// version from its tier.
+func getTransitionedObject(ctx context.Context, transition ObjectTransition, startOffset, length int64, writer io.Writer) error {
+	if length == 0 {
+		return nil
+	}
+	tier, ok := globalTierConfigSys.Get(transition.Tier)
+	if !ok {
+		return errNoSuchTier
+	}
+	client, err := newTierClient(tier)
+	if err != nil {
+		return err
+	}
+
+	opts := miniogo.GetObjectOptions{}
+	if err = opts.SetRange(startOffset, startOffset+length-1); err != nil {
+		return err
+	}
+	reader, err := client.GetObjectWithContext(ctx, tier.Bucket, transition.Object, opts)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.CopyN(writer, reader, length)
+	return err
+}
@@ -0,0 +1,277 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Tests that transitioned objects are read from their tier and only a
+// stub is kept locally.
+func TestTransitionObject(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	remote := StartTestServer(t, "XL")
+	defer remote.Stop()
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	if err = remote.Obj.MakeBucketWithLocation(ctx, "tier-bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	globalTierConfigSys = NewTierConfigSys()
+	globalTierConfigSys.tiers["WARM"] = TierConfig{
+		Name:        "WARM",
+		Type:        MinioTier,
+		Endpoint:    remote.Server.URL,
+		Bucket:      "tier-bucket",
+		Prefix:      "data",
+		Credentials: auth.Credentials{AccessKey: remote.AccessKey, SecretKey: remote.SecretKey},
+	}
+	defer func() { globalTierConfigSys = NewTierConfigSys() }()
+
+	bucket, object := "bucket", "object"
+	data := []byte("hello, transitioned world")
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	objInfo, err := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = transitionObject(ctx, obj, objInfo, "WARM"); err != nil {
+		t.Fatal(err)
+	}
+
+	checkObject := func() {
+		info, gerr := obj.GetObjectInfo(ctx, bucket, object)
+		if gerr != nil {
+			t.Fatal(gerr)
+		}
+		if info.StorageClass != "WARM" || info.Size != int64(len(data)) || info.ETag != objInfo.ETag {
+			t.Fatalf("unexpected object info of transitioned object: %v", info)
+		}
+
+		var buffer bytes.Buffer
+		if gerr = obj.GetObject(ctx, bucket, object, 0, info.Size, &buffer, ""); gerr != nil {
+			t.Fatal(gerr)
+		}
+		if !bytes.Equal(buffer.Bytes(), data) {
+			t.Fatalf("expected %q, got %q", data, buffer.Bytes())
+		}
+		buffer.Reset()
+		if gerr = obj.GetObject(ctx, bucket, object, 7, 12, &buffer, ""); gerr != nil {
+			t.Fatal(gerr)
+		}
+		if !bytes.Equal(buffer.Bytes(), data[7:19]) {
+			t.Fatalf("expected %q, got %q", data[7:19], buffer.Bytes())
+		}
+	}
+	checkObject()
+
+	for _, dir := range fsDirs {
+		if _, err = os.Stat(filepath.Join(dir, bucket, object, "part.1")); !os.IsNotExist(err) {
+			t.Fatalf("expected parts of transitioned object to be removed, got %v", err)
+		}
+	}
+
+	// An object is transitioned once, the remote copy of a second
+	// attempt is removed.
+	if err = transitionObject(ctx, obj, objInfo, "WARM"); err != (InvalidETag{}) {
+		t.Fatalf("expected %v, got %v", InvalidETag{}, err)
+	}
+	result, err := remote.Obj.ListObjects(ctx, "tier-bucket", "data/bucket/", "", "", maxObjectList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("expected 1 remote object, got %d", len(result.Objects))
+	}
+
+	// Healing a stub only heals its metadata.
+	if err = os.Remove(filepath.Join(fsDirs[0], bucket, object, xlMetaJSONFile)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.HealObject(ctx, bucket, object, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(filepath.Join(fsDirs[0], bucket, object, xlMetaJSONFile)); err != nil {
+		t.Fatal(err)
+	}
+	checkObject()
+
+	// Objects are transitioned by the lifecycle sweep.
+	if _, err = obj.PutObject(ctx, bucket, "logs/a", mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketMetadataSys.Set(bucket, bucketLifecycleConfig, []byte(`<LifecycleConfiguration>`+
+		`<Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Transition><Date>2018-01-01T00:00:00Z</Date><StorageClass>WARM</StorageClass></Transition></Rule>`+
+		`</LifecycleConfiguration>`))
+	sweeper := lifecycleSweeper{objAPI: obj, nodeCount: 1}
+	if err = sweeper.sweep(ctx, make(chan struct{})); err != nil {
+		t.Fatal(err)
+	}
+	if objInfo, err = obj.GetObjectInfo(ctx, bucket, "logs/a"); err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.StorageClass != "WARM" {
+		t.Fatalf("expected object to be transitioned, got storage class %s", objInfo.StorageClass)
+	}
+}
+
+// Tests that restored transitioned objects are read locally until their
+// restore expires.
+func TestRestoreObject(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	remote := StartTestServer(t, "XL")
+	defer remote.Stop()
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	if err = remote.Obj.MakeBucketWithLocation(ctx, "tier-bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	tier := TierConfig{
+		Name:        "WARM",
+		Type:        MinioTier,
+		Endpoint:    remote.Server.URL,
+		Bucket:      "tier-bucket",
+		Credentials: auth.Credentials{AccessKey: remote.AccessKey, SecretKey: remote.SecretKey},
+	}
+	globalTierConfigSys = NewTierConfigSys()
+	globalTierConfigSys.tiers["WARM"] = tier
+	defer func() { globalTierConfigSys = NewTierConfigSys() }()
+
+	bucket, object := "bucket", "object"
+	data := bytes.Repeat([]byte("hello, restored world"), 1024)
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	objInfo, err := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiry := UTCNow().Add(24 * time.Hour).Truncate(time.Second)
+	if _, err = obj.RestoreObject(ctx, bucket, object, "", expiry); err != (ObjectNotTransitioned{Bucket: bucket, Object: object}) {
+		t.Fatalf("expected %v, got %v", ObjectNotTransitioned{Bucket: bucket, Object: object}, err)
+	}
+
+	if err = transitionObject(ctx, obj, objInfo, "WARM"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := obj.RestoreObject(ctx, bucket, object, "", expiry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transition, ok := getObjectTransition(info.UserDefined)
+	if !ok || !transition.IsRestored() || !transition.RestoreExpiry.Equal(expiry) {
+		t.Fatalf("unexpected transition of restored object: %v", transition)
+	}
+
+	partsExist := func(expected bool) {
+		for _, dir := range fsDirs {
+			_, serr := os.Stat(filepath.Join(dir, bucket, object, "part.1"))
+			if expected && serr != nil || !expected && !os.IsNotExist(serr) {
+				t.Fatalf("expected parts to exist %t, got %v", expected, serr)
+			}
+		}
+	}
+	checkObject := func() {
+		var buffer bytes.Buffer
+		if gerr := obj.GetObject(ctx, bucket, object, 0, int64(len(data)), &buffer, ""); gerr != nil {
+			t.Fatal(gerr)
+		}
+		if !bytes.Equal(buffer.Bytes(), data) {
+			t.Fatal("unexpected data of restored object")
+		}
+	}
+	partsExist(true)
+
+	// Restored objects are read and healed without their tier.
+	globalTierConfigSys.tiers["WARM"] = TierConfig{Name: "WARM", Type: MinioTier, Endpoint: "http://127.0.0.1:1", Bucket: "tier-bucket"}
+	checkObject()
+	if err = os.Remove(filepath.Join(fsDirs[0], bucket, object, "part.1")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.HealObject(ctx, bucket, object, false); err != nil {
+		t.Fatal(err)
+	}
+	partsExist(true)
+	checkObject()
+
+	// Restoring again only updates the expiry.
+	expiry = UTCNow().Add(-time.Hour).Truncate(time.Second)
+	if info, err = obj.RestoreObject(ctx, bucket, object, "", expiry); err != nil {
+		t.Fatal(err)
+	}
+	if transition, _ = getObjectTransition(info.UserDefined); !transition.RestoreExpiry.Equal(expiry) {
+		t.Fatalf("expected restore expiry %v, got %v", expiry, transition.RestoreExpiry)
+	}
+	partsExist(true)
+
+	// Expired restores are removed by the lifecycle sweep.
+	globalTierConfigSys.tiers["WARM"] = tier
+	globalBucketMetadataSys.Set(bucket, bucketLifecycleConfig, []byte(`<LifecycleConfiguration>`+
+		`<Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>`+
+		`</LifecycleConfiguration>`))
+	sweeper := lifecycleSweeper{objAPI: obj, nodeCount: 1}
+	if err = sweeper.sweep(ctx, make(chan struct{})); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = obj.GetObjectInfo(ctx, bucket, object); err != nil {
+		t.Fatal(err)
+	}
+	if transition, ok = getObjectTransition(info.UserDefined); !ok || transition.IsRestored() {
+		t.Fatalf("expected restore of object to be expired, got %v", transition)
+	}
+	partsExist(false)
+	checkObject()
+}
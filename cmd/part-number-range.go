@@ -0,0 +1,78 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/minio/sio"
+)
+
+// Response header giving the number of parts of a multipart object,
+// sent when a part of it is requested.
+const amzMpPartsCount = "x-amz-mp-parts-count"
+
+// getObjectPartSize - returns the size of a part of an object, its
+// decrypted size if the object is encrypted.
+func getObjectPartSize(objInfo ObjectInfo, part objectPartInfo) (int64, error) {
+	if !objInfo.IsEncryptedMultipart() {
+		return part.Size, nil
+	}
+	size, err := sio.DecryptedSize(uint64(part.Size))
+	if err != nil {
+		return 0, errObjectTampered
+	}
+	return int64(size), nil
+}
+
+// getPartNumberRange - returns the byte range of the part of the
+// partNumber query parameter of a GET or HEAD object request, and the
+// number of parts of the object if it is a multipart object. Objects
+// which were not uploaded in parts only have the whole object as part 1.
+// objInfo.Size must be the decrypted size of encrypted objects.
+func getPartNumberRange(partNumberString string, objInfo ObjectInfo) (hrange *httpRange, partsCount int, apiErr APIErrorCode) {
+	partNumber, err := strconv.Atoi(partNumberString)
+	if err != nil || partNumber < 1 || isMaxPartID(partNumber) {
+		return nil, 0, ErrInvalidPartNumberArgument
+	}
+
+	if !isMultipartObject(objInfo) {
+		if partNumber != 1 {
+			return nil, 0, ErrInvalidPartNumber
+		}
+		if objInfo.Size == 0 {
+			return nil, 0, ErrNone
+		}
+		return &httpRange{0, objInfo.Size - 1, objInfo.Size}, 0, ErrNone
+	}
+
+	var offset int64
+	for _, part := range objInfo.Parts {
+		size, err := getObjectPartSize(objInfo, part)
+		if err != nil {
+			return nil, 0, toAPIErrorCode(err)
+		}
+		if part.Number == partNumber {
+			if size == 0 {
+				return nil, 0, ErrInvalidPartNumber
+			}
+			return &httpRange{offset, offset + size - 1, objInfo.Size}, len(objInfo.Parts), ErrNone
+		}
+		offset += size
+	}
+	return nil, 0, ErrInvalidPartNumber
+}
@@ -0,0 +1,180 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestGetPartNumberRange(t *testing.T) {
+	singlePart := ObjectInfo{ETag: "5d41402abc4b2a76b9719d911017c592", Size: 10}
+	multipart := ObjectInfo{
+		ETag: "5d41402abc4b2a76b9719d911017c592-3",
+		Size: 25,
+		Parts: []objectPartInfo{
+			{Number: 1, Size: 10},
+			{Number: 2, Size: 10},
+			{Number: 4, Size: 5},
+		},
+	}
+	testCases := []struct {
+		partNumber         string
+		objInfo            ObjectInfo
+		expectedRange      *httpRange
+		expectedPartsCount int
+		expectedErr        APIErrorCode
+	}{
+		{"1", singlePart, &httpRange{0, 9, 10}, 0, ErrNone},
+		{"2", singlePart, nil, 0, ErrInvalidPartNumber},
+		{"1", ObjectInfo{}, nil, 0, ErrNone},
+		{"1", multipart, &httpRange{0, 9, 25}, 3, ErrNone},
+		{"2", multipart, &httpRange{10, 19, 25}, 3, ErrNone},
+		{"4", multipart, &httpRange{20, 24, 25}, 3, ErrNone},
+		{"3", multipart, nil, 0, ErrInvalidPartNumber},
+		{"5", multipart, nil, 0, ErrInvalidPartNumber},
+		{"0", multipart, nil, 0, ErrInvalidPartNumberArgument},
+		{"10001", multipart, nil, 0, ErrInvalidPartNumberArgument},
+		{"a", multipart, nil, 0, ErrInvalidPartNumberArgument},
+	}
+	for i, testCase := range testCases {
+		hrange, partsCount, apiErr := getPartNumberRange(testCase.partNumber, testCase.objInfo)
+		if apiErr != testCase.expectedErr {
+			t.Errorf("Test %d: Expected error %v, but instead found %v", i+1, testCase.expectedErr, apiErr)
+		}
+		if !reflect.DeepEqual(hrange, testCase.expectedRange) {
+			t.Errorf("Test %d: Expected range %v, but instead found %v", i+1, testCase.expectedRange, hrange)
+		}
+		if partsCount != testCase.expectedPartsCount {
+			t.Errorf("Test %d: Expected parts count %d, but instead found %d", i+1, testCase.expectedPartsCount, partsCount)
+		}
+	}
+}
+
+// Wrapper for calling part number GET and HEAD tests for both XL multiple disks and single node setup.
+func TestPartNumberObjectHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testPartNumberObjectHandlers, []string{"GetObject", "HeadObject"})
+}
+
+func testPartNumberObjectHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = NewPolicySys() }()
+
+	serve := func(method, objectName, partNumber string, headers map[string]string) *httptest.ResponseRecorder {
+		queryValue := url.Values{}
+		queryValue.Set("partNumber", partNumber)
+		req, err := newTestRequest(method, makeTestTargetURL("", bucketName, objectName, queryValue), 0, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A multipart object with parts of distinct content.
+	objectName := "multipart-object"
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, objectName, nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create a multipart upload: <ERROR> %v", instanceType, err)
+	}
+	partsData := [][]byte{
+		bytes.Repeat([]byte("a"), int(globalMinPartSize)),
+		bytes.Repeat([]byte("b"), int(globalMinPartSize)),
+		[]byte("c"),
+	}
+	var parts []CompletePart
+	for i, data := range partsData {
+		partInfo, err := obj.PutObjectPart(context.Background(), bucketName, objectName, uploadID, i+1, mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""))
+		if err != nil {
+			t.Fatalf("%s: Failed to put part %d: <ERROR> %v", instanceType, i+1, err)
+		}
+		parts = append(parts, CompletePart{PartNumber: i + 1, ETag: partInfo.ETag})
+	}
+	if _, err = obj.CompleteMultipartUpload(context.Background(), bucketName, objectName, uploadID, parts); err != nil {
+		t.Fatalf("%s: Failed to complete the multipart upload: <ERROR> %v", instanceType, err)
+	}
+
+	testCases := []struct {
+		partNumber    string
+		expectedData  []byte
+		expectedRange string
+	}{
+		{"1", partsData[0], "bytes 0-5242879/10485761"},
+		{"2", partsData[1], "bytes 5242880-10485759/10485761"},
+		{"3", partsData[2], "bytes 10485760-10485760/10485761"},
+	}
+	for i, testCase := range testCases {
+		for _, method := range []string{"GET", "HEAD"} {
+			rec := serve(method, objectName, testCase.partNumber, nil)
+			if rec.Code != http.StatusPartialContent {
+				t.Fatalf("%s: Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, method, http.StatusPartialContent, rec.Code)
+			}
+			if count := rec.Header().Get(amzMpPartsCount); count != "3" {
+				t.Errorf("%s: Test %d: %s: Expected the parts count `3`, but instead found `%s`", instanceType, i+1, method, count)
+			}
+			if contentRange := rec.Header().Get("Content-Range"); contentRange != testCase.expectedRange {
+				t.Errorf("%s: Test %d: %s: Expected the content range `%s`, but instead found `%s`", instanceType, i+1, method, testCase.expectedRange, contentRange)
+			}
+			if method == "GET" && !bytes.Equal(rec.Body.Bytes(), testCase.expectedData) {
+				t.Errorf("%s: Test %d: Expected the data of the part", instanceType, i+1)
+			}
+		}
+	}
+
+	// A single part object only has the whole object as part 1.
+	if _, err = obj.PutObject(context.Background(), bucketName, "object", mustGetHashReader(t, bytes.NewReader([]byte("hello")), 5, "", ""), nil); err != nil {
+		t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+	}
+	rec := serve("GET", "object", "1", nil)
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != "hello" || rec.Header().Get(amzMpPartsCount) != "" {
+		t.Errorf("%s: Expected the whole object as part 1, but instead found `%d` %s", instanceType, rec.Code, rec.Body.String())
+	}
+
+	errorCases := []struct {
+		method       string
+		objectName   string
+		partNumber   string
+		headers      map[string]string
+		expectedCode int
+	}{
+		{"GET", "object", "2", nil, http.StatusRequestedRangeNotSatisfiable},
+		{"HEAD", objectName, "4", nil, http.StatusRequestedRangeNotSatisfiable},
+		{"GET", objectName, "0", nil, http.StatusBadRequest},
+		{"HEAD", objectName, "a", nil, http.StatusBadRequest},
+		{"GET", objectName, "1", map[string]string{"Range": "bytes=0-1"}, http.StatusBadRequest},
+	}
+	for i, testCase := range errorCases {
+		if rec = serve(testCase.method, testCase.objectName, testCase.partNumber, testCase.headers); rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+	}
+}
@@ -58,6 +58,34 @@ func (rpcClient *PeerRPCClient) RemoveBucketPolicy(bucketName string) error {
 	return rpcClient.Call(peerServiceName+".RemoveBucketPolicy", &args, &reply)
 }
 
+// SetBucketQuota - calls set bucket quota RPC.
+func (rpcClient *PeerRPCClient) SetBucketQuota(bucketName string, quota BucketQuota) error {
+	args := SetBucketQuotaArgs{
+		BucketName: bucketName,
+		Quota:      quota,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketQuota", &args, &reply)
+}
+
+// LoadBucketTargets - calls load remote targets RPC.
+func (rpcClient *PeerRPCClient) LoadBucketTargets(bucketName string) error {
+	args := LoadBucketTargetsArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".LoadBucketTargets", &args, &reply)
+}
+
+// LoadBucketMetadata - calls load bucket metadata RPC.
+func (rpcClient *PeerRPCClient) LoadBucketMetadata(bucketName string) error {
+	args := LoadBucketMetadataArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".LoadBucketMetadata", &args, &reply)
+}
+
 // PutBucketNotification - calls put bukcet notification RPC.
 func (rpcClient *PeerRPCClient) PutBucketNotification(bucketName string, rulesMap event.RulesMap) error {
 	args := PutBucketNotificationArgs{
@@ -70,11 +98,12 @@ func (rpcClient *PeerRPCClient) PutBucketNotification(bucketName string, rulesMa
 
 // ListenBucketNotification - calls listen bucket notification RPC.
 func (rpcClient *PeerRPCClient) ListenBucketNotification(bucketName string, eventNames []event.Name,
-	pattern string, targetID event.TargetID, addr xnet.Host) error {
+	patterns []string, targetID event.TargetID, addr xnet.Host) error {
 	args := ListenBucketNotificationArgs{
 		BucketName: bucketName,
 		EventNames: eventNames,
-		Pattern:    pattern,
+		Pattern:    patterns[0],
+		Patterns:   patterns,
 		TargetID:   targetID,
 		Addr:       addr,
 	}
@@ -124,6 +153,31 @@ func (rpcClient *PeerRPCClient) SetCredentials(credentials auth.Credentials) err
 	return rpcClient.Call(peerServiceName+".SetCredentials", &args, &reply)
 }
 
+// LoadServiceAccounts - calls load service accounts RPC.
+func (rpcClient *PeerRPCClient) LoadServiceAccounts() error {
+	args := AuthArgs{}
+	reply := VoidReply{}
+
+	return rpcClient.Call(peerServiceName+".LoadServiceAccounts", &args, &reply)
+}
+
+// LoadTempAccount - calls load temporary account RPC.
+func (rpcClient *PeerRPCClient) LoadTempAccount(accessKey string) error {
+	args := LoadTempAccountArgs{
+		AccessKey: accessKey,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".LoadTempAccount", &args, &reply)
+}
+
+// LoadTiers - calls load tiers RPC.
+func (rpcClient *PeerRPCClient) LoadTiers() error {
+	args := AuthArgs{}
+	reply := VoidReply{}
+
+	return rpcClient.Call(peerServiceName+".LoadTiers", &args, &reply)
+}
+
 // NewPeerRPCClient - returns new peer RPC client.
 func NewPeerRPCClient(host *xnet.Host) (*PeerRPCClient, error) {
 	scheme := "http"
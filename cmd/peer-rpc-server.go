@@ -48,6 +48,10 @@ type DeleteBucketArgs struct {
 func (receiver *peerRPCReceiver) DeleteBucket(args *DeleteBucketArgs, reply *VoidReply) error {
 	globalNotificationSys.RemoveNotification(args.BucketName)
 	globalPolicySys.Remove(args.BucketName)
+	globalBucketQuotaSys.Remove(args.BucketName)
+	globalBucketTargetSys.Remove(args.BucketName)
+	globalBucketMetadataSys.Remove(args.BucketName)
+	globalBucketBandwidthStats.deleteBucket(args.BucketName)
 	return nil
 }
 
@@ -64,6 +68,61 @@ func (receiver *peerRPCReceiver) SetBucketPolicy(args *SetBucketPolicyArgs, repl
 	return nil
 }
 
+// SetBucketQuotaArgs - set bucket quota RPC arguments.
+type SetBucketQuotaArgs struct {
+	AuthArgs
+	BucketName string
+	Quota      BucketQuota
+}
+
+// SetBucketQuota - handles set bucket quota RPC call which adds or removes bucket quota in globalBucketQuotaSys.
+func (receiver *peerRPCReceiver) SetBucketQuota(args *SetBucketQuotaArgs, reply *VoidReply) error {
+	globalBucketQuotaSys.Set(args.BucketName, args.Quota)
+	return nil
+}
+
+// LoadBucketTargetsArgs - load remote targets RPC arguments.
+type LoadBucketTargetsArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// LoadBucketTargets - handles load remote targets RPC call which reloads
+// remote targets of a bucket from the backend into globalBucketTargetSys.
+func (receiver *peerRPCReceiver) LoadBucketTargets(args *LoadBucketTargetsArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if globalBucketTargetSys == nil {
+		return errServerNotInitialized
+	}
+
+	return globalBucketTargetSys.Load(objAPI, args.BucketName)
+}
+
+// LoadBucketMetadataArgs - load bucket metadata RPC arguments.
+type LoadBucketMetadataArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// LoadBucketMetadata - handles load bucket metadata RPC call which reloads
+// config files of a bucket from the backend into globalBucketMetadataSys.
+func (receiver *peerRPCReceiver) LoadBucketMetadata(args *LoadBucketMetadataArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if globalBucketMetadataSys == nil {
+		return errServerNotInitialized
+	}
+
+	return globalBucketMetadataSys.Load(objAPI, args.BucketName)
+}
+
 // RemoveBucketPolicyArgs - delete bucket policy RPC arguments.
 type RemoveBucketPolicyArgs struct {
 	AuthArgs
@@ -95,10 +154,26 @@ type ListenBucketNotificationArgs struct {
 	BucketName string         `json:"-"`
 	EventNames []event.Name   `json:"eventNames"`
 	Pattern    string         `json:"pattern"`
+	Patterns   []string       `json:"patterns,omitempty"`
 	TargetID   event.TargetID `json:"targetId"`
 	Addr       xnet.Host      `json:"addr"`
 }
 
+// ToRulesMap - returns the rules map of the listener, events match any
+// of its patterns. Listeners saved with a single pattern have no list
+// of patterns.
+func (args ListenBucketNotificationArgs) ToRulesMap(targetID event.TargetID) event.RulesMap {
+	if len(args.Patterns) == 0 {
+		return event.NewRulesMap(args.EventNames, args.Pattern, targetID)
+	}
+
+	rulesMap := make(event.RulesMap)
+	for _, pattern := range args.Patterns {
+		rulesMap.Add(event.NewRulesMap(args.EventNames, pattern, targetID))
+	}
+	return rulesMap
+}
+
 // ListenBucketNotification - handles listen bucket notification RPC call. It creates PeerRPCClient target which pushes requested events to target in remote peer.
 func (receiver *peerRPCReceiver) ListenBucketNotification(args *ListenBucketNotificationArgs, reply *VoidReply) error {
 	rpcClient := globalNotificationSys.GetPeerRPCClient(args.Addr)
@@ -107,7 +182,7 @@ func (receiver *peerRPCReceiver) ListenBucketNotification(args *ListenBucketNoti
 	}
 
 	target := NewPeerRPCClientTarget(args.BucketName, args.TargetID, rpcClient)
-	rulesMap := event.NewRulesMap(args.EventNames, args.Pattern, target.ID())
+	rulesMap := args.ToRulesMap(target.ID())
 	if err := globalNotificationSys.AddRemoteTarget(args.BucketName, target, rulesMap); err != nil {
 		reqInfo := &logger.ReqInfo{BucketName: target.bucketName}
 		reqInfo.AppendTags("target", target.id.Name)
@@ -189,6 +264,57 @@ func (receiver *peerRPCReceiver) SetCredentials(args *SetCredentialsArgs, reply
 	return nil
 }
 
+// LoadServiceAccounts - handles load service accounts RPC call which
+// reloads service accounts from the backend into globalIAMSys.
+func (receiver *peerRPCReceiver) LoadServiceAccounts(args *AuthArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if globalIAMSys == nil {
+		return errServerNotInitialized
+	}
+
+	return globalIAMSys.Load(objAPI)
+}
+
+// LoadTempAccountArgs - load temporary account RPC arguments.
+type LoadTempAccountArgs struct {
+	AuthArgs
+	AccessKey string
+}
+
+// LoadTempAccount - handles load temporary account RPC call which reloads
+// a temporary account from the backend into globalIAMSys.
+func (receiver *peerRPCReceiver) LoadTempAccount(args *LoadTempAccountArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if globalIAMSys == nil {
+		return errServerNotInitialized
+	}
+
+	return globalIAMSys.LoadTempAccount(objAPI, args.AccessKey)
+}
+
+// LoadTiers - handles load tiers RPC call which reloads tiers from the
+// backend into globalTierConfigSys.
+func (receiver *peerRPCReceiver) LoadTiers(args *AuthArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if globalTierConfigSys == nil {
+		return errServerNotInitialized
+	}
+
+	return globalTierConfigSys.Load(objAPI)
+}
+
 // NewPeerRPCServer - returns new peer RPC server.
 func NewPeerRPCServer() (*xrpc.Server, error) {
 	rpcServer := xrpc.NewServer()
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"path"
+	"strconv"
 	"sync"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/tagging"
 )
 
 // PolicySys - policy subsystem.
@@ -67,6 +69,15 @@ func (sys *PolicySys) Set(bucketName string, policy policy.Policy) {
 	}
 }
 
+// hasPolicy - returns true if given bucket name has a policy.
+func (sys *PolicySys) hasPolicy(bucketName string) bool {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	_, ok := sys.bucketPolicyMap[bucketName]
+	return ok
+}
+
 // Remove - removes policy for given bucket name.
 func (sys *PolicySys) Remove(bucketName string) {
 	sys.Lock()
@@ -179,11 +190,19 @@ func getConditionValues(request *http.Request, locationConstraint string) map[st
 	}
 
 	args["SourceIp"] = []string{handlers.GetSourceIP(request)}
+	args["SecureTransport"] = []string{strconv.FormatBool(request.TLS != nil)}
 
 	if locationConstraint != "" {
 		args["LocationConstraint"] = []string{locationConstraint}
 	}
 
+	// Invalid tags are rejected by the HTTP handler.
+	if header := request.Header.Get(amzObjectTagging); header != "" {
+		if tags, err := tagging.ParseTags(header, tagging.MaxObjectTags); err == nil {
+			setRequestObjectTagValues(args, tags)
+		}
+	}
+
 	return args
 }
 
@@ -204,6 +223,13 @@ func getPolicyConfig(objAPI ObjectLayer, bucketName string) (*policy.Policy, err
 	return policy.ParseConfig(reader, bucketName)
 }
 
+// getBucketPolicyLock - returns the lock serializing the updates of the
+// bucket policy of given bucket name, which read and then save it.
+func getBucketPolicyLock(bucketName string) RWLocker {
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketPolicyConfig)
+	return globalNSMutex.NewNSLock(minioMetaBucket, configFile+".transaction")
+}
+
 func savePolicyConfig(objAPI ObjectLayer, bucketName string, bucketPolicy *policy.Policy) error {
 	data, err := json.Marshal(bucketPolicy)
 	if err != nil {
@@ -269,3 +295,116 @@ func BucketAccessPolicyToPolicy(policyInfo *miniogopolicy.BucketAccessPolicy) (*
 
 	return &bucketPolicy, nil
 }
+
+// Canned anonymous access of a bucket or a prefix of a bucket, each is an
+// equivalent bucket policy.
+const (
+	bucketAccessNone     = "none"
+	bucketAccessDownload = "download"
+	bucketAccessUpload   = "upload"
+	bucketAccessPublic   = "public"
+)
+
+// Bucket policies of the canned anonymous accesses.
+var bucketAccessPolicies = map[string]miniogopolicy.BucketPolicy{
+	bucketAccessNone:     miniogopolicy.BucketPolicyNone,
+	bucketAccessDownload: miniogopolicy.BucketPolicyReadOnly,
+	bucketAccessUpload:   miniogopolicy.BucketPolicyWriteOnly,
+	bucketAccessPublic:   miniogopolicy.BucketPolicyReadWrite,
+}
+
+// BucketAccess - canned anonymous access of a bucket or a prefix.
+type BucketAccess struct {
+	Access string `json:"access"`
+}
+
+// IsValid - returns whether the access is a known canned access.
+func (a BucketAccess) IsValid() bool {
+	_, ok := bucketAccessPolicies[a.Access]
+	return ok
+}
+
+// getBucketAccess - returns the canned anonymous access of a prefix of a
+// bucket granted by its bucket policy, `none` if the policy grants other
+// permissions than the ones of a canned access.
+func getBucketAccess(ctx context.Context, objAPI ObjectLayer, bucketName, prefix string) (BucketAccess, error) {
+	bucketPolicy, err := objAPI.GetBucketPolicy(ctx, bucketName)
+	if err != nil {
+		if _, ok := err.(BucketPolicyNotFound); !ok {
+			return BucketAccess{}, err
+		}
+	}
+
+	policyInfo, err := PolicyToBucketAccessPolicy(bucketPolicy)
+	if err != nil {
+		// This should not happen.
+		return BucketAccess{}, err
+	}
+
+	policyType := miniogopolicy.GetPolicy(policyInfo.Statements, bucketName, prefix)
+	for access, accessPolicy := range bucketAccessPolicies {
+		if accessPolicy == policyType {
+			return BucketAccess{Access: access}, nil
+		}
+	}
+	return BucketAccess{Access: bucketAccessNone}, nil
+}
+
+// setBucketPolicyType - updates the bucket policy of a bucket with the
+// statements granting the anonymous access of policyType to a prefix,
+// other statements of the bucket policy are kept. The bucket policy
+// is removed once it has no statements anymore, BucketPolicyNotFound is
+// returned if there was none.
+func setBucketPolicyType(ctx context.Context, objAPI ObjectLayer, bucketName, prefix string, policyType miniogopolicy.BucketPolicy) error {
+	policyLock := getBucketPolicyLock(bucketName)
+	if err := policyLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer policyLock.Unlock()
+
+	bucketPolicy, err := objAPI.GetBucketPolicy(ctx, bucketName)
+	if err != nil {
+		if _, ok := err.(BucketPolicyNotFound); !ok {
+			return err
+		}
+	}
+
+	policyInfo, err := PolicyToBucketAccessPolicy(bucketPolicy)
+	if err != nil {
+		// This should not happen.
+		return err
+	}
+
+	policyInfo.Statements = miniogopolicy.SetPolicy(policyInfo.Statements, policyType, bucketName, prefix)
+
+	// Keep the replaced policy to be able to restore it, failing to
+	// record it does not prevent the policy from being updated.
+	if bucketPolicy != nil || len(policyInfo.Statements) != 0 {
+		logger.LogIf(ctx, addBucketPolicyVersion(ctx, objAPI, bucketName))
+	}
+
+	if len(policyInfo.Statements) == 0 {
+		if err = objAPI.DeleteBucketPolicy(ctx, bucketName); err != nil {
+			return err
+		}
+
+		globalPolicySys.Remove(bucketName)
+		return nil
+	}
+
+	bucketPolicy, err = BucketAccessPolicyToPolicy(policyInfo)
+	if err != nil {
+		// This should not happen.
+		return err
+	}
+
+	// Parse validate and save bucket policy.
+	if err = objAPI.SetBucketPolicy(ctx, bucketName, bucketPolicy); err != nil {
+		return err
+	}
+
+	globalPolicySys.Set(bucketName, *bucketPolicy)
+	globalNotificationSys.SetBucketPolicy(ctx, bucketName, bucketPolicy)
+
+	return nil
+}
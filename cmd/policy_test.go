@@ -17,6 +17,9 @@
 package cmd
 
 import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -180,7 +183,7 @@ func TestPolicySysIsAllowed(t *testing.T) {
 		Action:      policy.PutObjectAction,
 		BucketName:  "mybucket",
 		ConditionValues: map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"SourceIp":          {"192.168.1.10"},
 		},
 		ObjectName: "myobject",
@@ -207,7 +210,7 @@ func TestPolicySysIsAllowed(t *testing.T) {
 		Action:      policy.PutObjectAction,
 		BucketName:  "mybucket",
 		ConditionValues: map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"SourceIp":          {"192.168.1.10"},
 		},
 		IsOwner:    true,
@@ -264,6 +267,87 @@ func TestPolicySysIsAllowed(t *testing.T) {
 	}
 }
 
+func TestPolicySysIsAllowedConditions(t *testing.T) {
+	secureTransportFunc, err := condition.NewBoolFunc(condition.AWSSecureTransport, true)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	prefixFunc, err := condition.NewStringEqualsFunc(condition.S3Prefix, "public/")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	maxKeysFunc, err := condition.NewNumericLessThanEqualsFunc(condition.S3MaxKeys, 100)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	sseFunc, err := condition.NewStringEqualsFunc(condition.S3XAmzServerSideEncryption, "AES256")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	policySys := NewPolicySys()
+	policySys.Set("mybucket", policy.Policy{
+		Version: policy.DefaultVersion,
+		Statements: []policy.Statement{
+			policy.NewStatement(
+				policy.Allow,
+				policy.NewPrincipal("*"),
+				policy.NewActionSet(policy.ListBucketAction),
+				policy.NewResourceSet(policy.NewResource("mybucket", "")),
+				condition.NewFunctions(secureTransportFunc, prefixFunc, maxKeysFunc),
+			),
+			policy.NewStatement(
+				policy.Allow,
+				policy.NewPrincipal("*"),
+				policy.NewActionSet(policy.PutObjectAction),
+				policy.NewResourceSet(policy.NewResource("mybucket", "*")),
+				condition.NewFunctions(sseFunc),
+			),
+		},
+	})
+
+	testCases := []struct {
+		action         policy.Action
+		url            string
+		header         http.Header
+		secure         bool
+		expectedResult bool
+	}{
+		{policy.ListBucketAction, "/mybucket?prefix=public/&max-keys=10", nil, true, true},
+		{policy.ListBucketAction, "/mybucket?prefix=public/&max-keys=10", nil, false, false},
+		{policy.ListBucketAction, "/mybucket?prefix=private/&max-keys=10", nil, true, false},
+		{policy.ListBucketAction, "/mybucket?prefix=public/&max-keys=1000", nil, true, false},
+		{policy.ListBucketAction, "/mybucket?prefix=public/", nil, true, false},
+		{policy.PutObjectAction, "/mybucket/myobject", http.Header{"X-Amz-Server-Side-Encryption": {"AES256"}}, false, true},
+		{policy.PutObjectAction, "/mybucket/myobject", nil, false, false},
+		{policy.PutObjectAction, "/mybucket/myobject?x-amz-server-side-encryption=AES256", nil, false, false},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodGet, testCase.url, nil)
+		for key, values := range testCase.header {
+			req.Header[key] = values
+		}
+		if testCase.secure {
+			req.TLS = &tls.ConnectionState{}
+		}
+
+		args := policy.Args{
+			Action:          testCase.action,
+			BucketName:      "mybucket",
+			ConditionValues: getConditionValues(req, ""),
+			ObjectName:      "myobject",
+		}
+		if testCase.action == policy.ListBucketAction {
+			args.ObjectName = ""
+		}
+
+		if result := policySys.IsAllowed(args); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func getReadOnlyStatement(bucketName, prefix string) []miniogopolicy.Statement {
 	return []miniogopolicy.Statement{
 		{
@@ -347,15 +347,9 @@ func (s *posix) diskUsage(doneCh chan struct{}) {
 	defer ticker.Stop()
 
 	usageFn := func(ctx context.Context, entry string) error {
-		if globalHTTPServer != nil {
-			// Wait at max 1 minute for an inprogress request
-			// before proceeding to count the usage.
-			waitCount := 60
-			// Any requests in progress, delay the usage.
-			for globalHTTPServer.GetRequestCount() > 0 && waitCount > 0 {
-				waitCount--
-				time.Sleep(1 * time.Second)
-			}
+		// Throttle the crawler according to the scanner speed.
+		if !waitForCrawler(s.stopUsageCh) {
+			return errWalkAbort
 		}
 
 		select {
@@ -388,15 +382,9 @@ func (s *posix) diskUsage(doneCh chan struct{}) {
 		case <-time.After(globalUsageCheckInterval):
 			var usage uint64
 			usageFn = func(ctx context.Context, entry string) error {
-				if globalHTTPServer != nil {
-					// Wait at max 1 minute for an inprogress request
-					// before proceeding to count the usage.
-					waitCount := 60
-					// Any requests in progress, delay the usage.
-					for globalHTTPServer.GetRequestCount() > 0 && waitCount > 0 {
-						waitCount--
-						time.Sleep(1 * time.Second)
-					}
+				// Throttle the crawler according to the scanner speed.
+				if !waitForCrawler(s.stopUsageCh) {
+					return errWalkAbort
 				}
 
 				select {
@@ -27,10 +27,14 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
 )
 
 const (
@@ -436,7 +440,7 @@ func testPostPolicyBucketHandlerRedirect(obj ObjectLayer, instanceType string, t
 	targetObj := keyName + "/upload.txt"
 
 	// The url of success_action_redirect field
-	redirectURL, err := url.Parse("http://www.google.com")
+	redirectURL, err := url.Parse("http://www.google.com/upload?source=form")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -488,8 +492,11 @@ func testPostPolicyBucketHandlerRedirect(obj ObjectLayer, instanceType string, t
 		t.Error("Unexpected error: ", err)
 	}
 
-	redirectURL.RawQuery = getRedirectPostRawQuery(info)
+	redirectURL.RawQuery = getRedirectPostRawQuery(redirectURL.Query(), info)
 	expectedLocation := redirectURL.String()
+	if !strings.Contains(expectedLocation, "source=form") {
+		t.Errorf("Expected the location %s to keep the query of the redirect", expectedLocation)
+	}
 
 	// Check the new location url
 	if rec.HeaderMap.Get("Location") != expectedLocation {
@@ -498,10 +505,196 @@ func testPostPolicyBucketHandlerRedirect(obj ObjectLayer, instanceType string, t
 
 }
 
+// Wrapper for calling TestPostPolicyBucketHandlerServiceAccount tests for both XL multiple disks and single node setup.
+func TestPostPolicyBucketHandlerServiceAccount(t *testing.T) {
+	ExecObjectLayerTest(t, testPostPolicyBucketHandlerServiceAccount)
+}
+
+// testPostPolicyBucketHandlerServiceAccount tests POST Object signed by a
+// service account restricted by its inline policy.
+func testPostPolicyBucketHandlerServiceAccount(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("Initializing config.json failed")
+	}
+	defer os.RemoveAll(root)
+
+	origIAMSys := globalIAMSys
+	defer func() { globalIAMSys = origIAMSys }()
+	globalIAMSys = NewIAMSys()
+
+	bucketName := getRandomBucketName()
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+
+	// The service account may only upload under the "public/" prefix.
+	saCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("%s: Failed to generate credentials: <ERROR> %v", instanceType, err)
+	}
+	globalIAMSys.serviceAccounts[saCred.AccessKey] = serviceAccount{
+		Credentials: saCred,
+		ParentUser:  globalServerConfig.GetCredential().AccessKey,
+		Policy: &policy.Policy{
+			Version: policy.DefaultVersion,
+			Statements: []policy.Statement{policy.NewStatement(
+				policy.Allow,
+				policy.NewPrincipal("*"),
+				policy.NewActionSet(policy.PutObjectAction),
+				policy.NewResourceSet(policy.NewResource(bucketName, "public/*")),
+				condition.NewFunctions(),
+			)},
+		},
+	}
+
+	apiRouter := initTestAPIEndPoints(obj, []string{"PostPolicy"})
+
+	testCases := []struct {
+		objectName         string
+		expectedRespStatus int
+	}{
+		{"public/object", http.StatusNoContent},
+		{"private/object", http.StatusForbidden},
+	}
+
+	for i, testCase := range testCases {
+		req, perr := newPostRequestV4("", bucketName, testCase.objectName, []byte("Hello, World"), saCred.AccessKey, saCred.SecretKey)
+		if perr != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PostPolicyHandler: <ERROR> %v", i+1, instanceType, perr)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+
+	// The key of the uploaded object is made of the key form field and
+	// the name of the uploaded file.
+	if _, err = obj.GetObjectInfo(context.Background(), bucketName, "private/object/upload.txt"); err == nil {
+		t.Errorf("%s: Expected the denied upload not to create the object", instanceType)
+	}
+}
+
+// Wrapper for calling TestPostPolicyBucketHandlerObjectLock tests for both XL multiple disks and single node setup.
+func TestPostPolicyBucketHandlerObjectLock(t *testing.T) {
+	ExecObjectLayerTest(t, testPostPolicyBucketHandlerObjectLock)
+}
+
+// testPostPolicyBucketHandlerObjectLock tests POST Object into a bucket
+// with object lock enabled and a default retention.
+func testPostPolicyBucketHandlerObjectLock(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	if instanceType == FSTestStr {
+		return
+	}
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("Initializing config.json failed")
+	}
+	defer os.RemoveAll(root)
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	bucketName := getRandomBucketName()
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+	globalBucketMetadataSys.Set(bucketName, bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+	globalBucketMetadataSys.Set(bucketName, bucketObjectLockConfig, []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`))
+
+	apiRouter := initTestAPIEndPoints(obj, []string{"PostPolicy"})
+	credentials := globalServerConfig.GetCredential()
+
+	// The key of the uploaded object is made of the key form field and
+	// the name of the uploaded file.
+	objectName := "object/upload.txt"
+
+	// Uploading the same key twice keeps the first upload as a
+	// noncurrent version, both are retained by default.
+	versionIDs := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		req, perr := newPostRequestV4("", bucketName, "object", []byte("Hello, World"), credentials.AccessKey, credentials.SecretKey)
+		if perr != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PostPolicyHandler: <ERROR> %v", i+1, instanceType, perr)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusNoContent, rec.Code)
+		}
+		versionID := rec.Header().Get(amzVersionID)
+		if versionID == "" || versionIDs[versionID] {
+			t.Fatalf("Test %d: %s: Unexpected version ID %q", i+1, instanceType, versionID)
+		}
+		versionIDs[versionID] = true
+
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to fetch the object: <ERROR> %v", i+1, instanceType, err)
+		}
+		if retention, ok := getObjectRetention(objInfo.UserDefined); !ok || retention.Mode != RetentionCompliance {
+			t.Errorf("Test %d: %s: Expected the default retention to be applied, found %v", i+1, instanceType, objInfo.UserDefined)
+		}
+	}
+
+	for versionID := range versionIDs {
+		if _, err = obj.GetObjectVersionInfo(context.Background(), bucketName, objectName, versionID); err != nil {
+			t.Errorf("%s: Expected version %s to be kept: <ERROR> %v", instanceType, versionID, err)
+		}
+	}
+}
+
+// Wrapper for calling TestPostPolicyBucketHandlerWebsiteRedirect tests for both XL multiple disks and single node setup.
+func TestPostPolicyBucketHandlerWebsiteRedirect(t *testing.T) {
+	ExecObjectLayerTest(t, testPostPolicyBucketHandlerWebsiteRedirect)
+}
+
+// testPostPolicyBucketHandlerWebsiteRedirect tests POST Object validates the website redirect location.
+func testPostPolicyBucketHandlerWebsiteRedirect(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("Initializing config.json failed")
+	}
+	defer os.RemoveAll(root)
+
+	bucketName := getRandomBucketName()
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+
+	apiRouter := initTestAPIEndPoints(obj, []string{"PostPolicy"})
+	credentials := globalServerConfig.GetCredential()
+
+	testCases := []struct {
+		location     string
+		expectedCode int
+	}{
+		{"/docs/", http.StatusNoContent},
+		{"example.com/docs/", http.StatusBadRequest},
+		{"//example.com/docs/", http.StatusBadRequest},
+	}
+	for i, testCase := range testCases {
+		now := UTCNow()
+		policy := buildGenericPolicy(now, credentials.AccessKey, globalMinioDefaultRegion, bucketName, "object", false)
+		req, perr := newPostRequestV4Generic("", bucketName, "object", []byte("Hello, World"), credentials.AccessKey, credentials.SecretKey, globalMinioDefaultRegion,
+			now, policy, map[string]string{amzWebsiteRedirectLocation: testCase.location}, false, false)
+		if perr != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PostPolicyHandler: <ERROR> %v", i+1, instanceType, perr)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedCode, rec.Code)
+		}
+	}
+}
+
 // postPresignSignatureV4 - presigned signature for PostPolicy requests.
 func postPresignSignatureV4(policyBase64 string, t time.Time, secretAccessKey, location string) string {
 	// Get signining key.
-	signingkey := getSigningKey(secretAccessKey, t, location)
+	signingkey := getSigningKey(secretAccessKey, t, location, serviceS3)
 	// Calculate signature.
 	signature := getSignature(signingkey, policyBase64)
 	return signature
@@ -103,8 +103,9 @@ type contentLengthRange struct {
 type PostPolicyForm struct {
 	Expiration time.Time // Expiration date and time of the POST policy.
 	Conditions struct {  // Conditional policy structure.
-		Policies map[string]struct {
+		Policies []struct {
 			Operator string
+			Key      string
 			Value    string
 		}
 		ContentLengthRange contentLengthRange
@@ -132,11 +133,6 @@ func parsePostPolicyForm(policy string) (ppf PostPolicyForm, e error) {
 	if err != nil {
 		return ppf, err
 	}
-	parsedPolicy.Conditions.Policies = make(map[string]struct {
-		Operator string
-		Value    string
-	})
-
 	// Parse conditions.
 	for _, val := range rawPolicy.Conditions {
 		switch condt := val.(type) {
@@ -148,13 +144,15 @@ func parsePostPolicyForm(policy string) (ppf PostPolicyForm, e error) {
 				}
 				// {"acl": "public-read" } is an alternate way to indicate - [ "eq", "$acl", "public-read" ]
 				// In this case we will just collapse this into "eq" for all use cases.
-				parsedPolicy.Conditions.Policies["$"+strings.ToLower(k)] = struct {
+				parsedPolicy.Conditions.Policies = append(parsedPolicy.Conditions.Policies, struct {
 					Operator string
+					Key      string
 					Value    string
 				}{
 					Operator: policyCondEqual,
+					Key:      "$" + strings.ToLower(k),
 					Value:    toString(v),
-				}
+				})
 			}
 		case []interface{}: // Handle array types.
 			if len(condt) != 3 { // Return error if we have insufficient elements.
@@ -169,13 +167,15 @@ func parsePostPolicyForm(policy string) (ppf PostPolicyForm, e error) {
 					}
 				}
 				operator, matchType, value := toLowerString(condt[0]), toLowerString(condt[1]), toString(condt[2])
-				parsedPolicy.Conditions.Policies[matchType] = struct {
+				parsedPolicy.Conditions.Policies = append(parsedPolicy.Conditions.Policies, struct {
 					Operator string
+					Key      string
 					Value    string
 				}{
 					Operator: operator,
+					Key:      matchType,
 					Value:    value,
-				}
+				})
 			case policyCondContentLength:
 				min, err := toInteger(condt[1])
 				if err != nil {
@@ -187,6 +187,10 @@ func parsePostPolicyForm(policy string) (ppf PostPolicyForm, e error) {
 					return parsedPolicy, err
 				}
 
+				if min < 0 || min > max {
+					return parsedPolicy, fmt.Errorf("Invalid content-length-range %d, %d found in POST policy form", min, max)
+				}
+
 				parsedPolicy.Conditions.ContentLengthRange = contentLengthRange{
 					Min:   min,
 					Max:   max,
@@ -228,28 +232,23 @@ func checkPostPolicy(formValues http.Header, postPolicyForm PostPolicyForm) APIE
 	// Flag to indicate if all policies conditions are satisfied
 	condPassed := true
 
-	// Iterate over policy conditions and check them against received form fields
-	for cond, v := range postPolicyForm.Conditions.Policies {
+	// Iterate over policy conditions and check them against received form fields,
+	// a form field may have several conditions which must all be satisfied
+	for _, v := range postPolicyForm.Conditions.Policies {
 		// Form fields names are in canonical format, convert conditions names
 		// to canonical for simplification purpose, so `$key` will become `Key`
-		formCanonicalName := http.CanonicalHeaderKey(strings.TrimPrefix(cond, "$"))
+		formCanonicalName := http.CanonicalHeaderKey(strings.TrimPrefix(v.Key, "$"))
 		// Operator for the current policy condition
 		op := v.Operator
-		// If the current policy condition is known
-		if startsWithSupported, condFound := startsWithConds[cond]; condFound {
-			// Check if the current condition supports starts-with operator
+		// Check if the current condition supports starts-with operator, unknown
+		// conditions such as X-Amz-Meta-* and X-Amz-* support both operators
+		if startsWithSupported, condFound := startsWithConds[v.Key]; condFound {
 			if op == policyCondStartsWith && !startsWithSupported {
 				return ErrAccessDenied
 			}
-			// Check if current policy condition is satisfied
-			condPassed = checkPolicyCond(op, formValues.Get(formCanonicalName), v.Value)
-		} else {
-			// This covers all conditions X-Amz-Meta-* and X-Amz-*
-			if strings.HasPrefix(cond, "$x-amz-meta-") || strings.HasPrefix(cond, "$x-amz-") {
-				// Check if policy condition is satisfied
-				condPassed = checkPolicyCond(op, formValues.Get(formCanonicalName), v.Value)
-			}
 		}
+		// Check if current policy condition is satisfied
+		condPassed = checkPolicyCond(op, formValues.Get(formCanonicalName), v.Value)
 		// Check if current policy condition is satisfied, quit immediately otherwise
 		if !condPassed {
 			return ErrAccessDenied
@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"net/http"
 	"testing"
+	"time"
 
 	minio "github.com/minio/minio-go"
 )
@@ -101,3 +102,66 @@ func TestPostPolicyForm(t *testing.T) {
 		}
 	}
 }
+
+// Test policy conditions on the same form field and on unknown form fields.
+func TestCheckPostPolicyConditions(t *testing.T) {
+	expiration := UTCNow().Add(time.Hour).Format(time.RFC3339Nano)
+	testCases := []struct {
+		conditions string
+		key        string
+		language   string
+		errCode    APIErrorCode
+	}{
+		{`["starts-with", "$key", "user/"], ["starts-with", "$key", "user/user1/"]`, "user/user1/file", "en", ErrNone},
+		{`["starts-with", "$key", "user/"], ["starts-with", "$key", "user/user1/"]`, "user/user2/file", "en", ErrAccessDenied},
+		{`["starts-with", "$key", ""]`, "any/file", "en", ErrNone},
+		{`["eq", "$content-language", "en"]`, "file", "en", ErrNone},
+		{`["eq", "$content-language", "en"]`, "file", "fr", ErrAccessDenied},
+		{`{"content-language": "en"}`, "file", "fr", ErrAccessDenied},
+		{`["starts-with", "$success_action_status", "2"]`, "file", "en", ErrAccessDenied},
+	}
+	for i, tt := range testCases {
+		postPolicyForm, err := parsePostPolicyForm(`{"expiration": "` + expiration + `", "conditions": [` + tt.conditions + `]}`)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		formValues := make(http.Header)
+		formValues.Set("Key", tt.key)
+		formValues.Set("Content-Language", tt.language)
+		formValues.Set("Success_action_status", "201")
+		if errCode := checkPostPolicy(formValues, postPolicyForm); errCode != tt.errCode {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, tt.errCode, errCode)
+		}
+	}
+}
+
+// Test parsing of content-length-range conditions.
+func TestParsePostPolicyFormContentLengthRange(t *testing.T) {
+	expiration := UTCNow().Add(time.Hour).Format(time.RFC3339Nano)
+	testCases := []struct {
+		condition     string
+		expectedRange contentLengthRange
+		expectErr     bool
+	}{
+		{`["content-length-range", 1, 1024]`, contentLengthRange{Min: 1, Max: 1024, Valid: true}, false},
+		{`["content-length-range", "0", "10"]`, contentLengthRange{Min: 0, Max: 10, Valid: true}, false},
+		{`["content-length-range", 1024, 1]`, contentLengthRange{}, true},
+		{`["content-length-range", -1, 1]`, contentLengthRange{}, true},
+		{`["content-length-range", "one", 1]`, contentLengthRange{}, true},
+	}
+	for i, tt := range testCases {
+		postPolicyForm, err := parsePostPolicyForm(`{"expiration": "` + expiration + `", "conditions": [` + tt.condition + `]}`)
+		if tt.expectErr {
+			if err == nil {
+				t.Errorf("Test %d: Expected an error, got none", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if postPolicyForm.Conditions.ContentLengthRange != tt.expectedRange {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, tt.expectedRange, postPolicyForm.Conditions.ContentLengthRange)
+		}
+	}
+}
@@ -55,6 +55,8 @@ var globalHandlers = []HandlerFunc{
 	setBucketForwardingHandler,
 	// Ratelimit the incoming requests using a token bucket algorithm
 	setRateLimitHandler,
+	// Reject S3 requests while the server is frozen or in maintenance.
+	setServiceFreezeHandler,
 	// Validate all the incoming paths.
 	setPathValidityHandler,
 	// Network statistics
@@ -118,6 +120,10 @@ func configureServerHandler(endpoints EndpointList) (http.Handler, error) {
 		}
 	}
 
+	// Add STS router, before the API router which would take
+	// its form posts to the root path otherwise.
+	registerSTSRouter(router)
+
 	// Add API router.
 	registerAPIRouter(router)
 
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net"
@@ -24,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/minio/minio/cmd/logger"
 	xrpc "github.com/minio/minio/cmd/rpc"
 	xnet "github.com/minio/minio/pkg/net"
 )
@@ -175,15 +177,32 @@ func (client *RPCClient) setRetryTicker(ticker *time.Ticker) {
 	client.retryTicker = ticker
 }
 
-// Call - calls servicemethod on remote server.
+// Call - calls servicemethod on remote server. After a network error,
+// further calls fail with errRPCRetry until DefaultRPCTimeout elapses.
 func (client *RPCClient) Call(serviceMethod string, args interface {
 	SetAuthArgs(args AuthArgs)
 }, reply interface{}) (err error) {
+	return client.call(context.Background(), serviceMethod, args, reply, true)
+}
+
+// CallWithContext - calls servicemethod on remote server, the call is
+// aborted when ctx is done. Unlike Call, network errors are returned
+// as is and do not hold back further calls, callers keep track of
+// unreachable servers themselves.
+func (client *RPCClient) CallWithContext(ctx context.Context, serviceMethod string, args interface {
+	SetAuthArgs(args AuthArgs)
+}, reply interface{}) (err error) {
+	return client.call(ctx, serviceMethod, args, reply, false)
+}
+
+func (client *RPCClient) call(ctx context.Context, serviceMethod string, args interface {
+	SetAuthArgs(args AuthArgs)
+}, reply interface{}, useRetryTicker bool) (err error) {
 	lockedCall := func() error {
 		client.RLock()
 		defer client.RUnlock()
 
-		if client.retryTicker != nil {
+		if useRetryTicker && client.retryTicker != nil {
 			select {
 			case <-client.retryTicker.C:
 			default:
@@ -193,13 +212,13 @@ func (client *RPCClient) Call(serviceMethod string, args interface {
 
 		// Make RPC call.
 		args.SetAuthArgs(AuthArgs{client.authToken, client.args.RPCVersion, time.Now().UTC()})
-		return client.rpcClient.Call(serviceMethod, args, reply)
+		return client.rpcClient.CallWithContext(ctx, serviceMethod, args, reply)
 	}
 
 	call := func() error {
 		err = lockedCall()
 
-		if err == errRPCRetry {
+		if err == errRPCRetry || !useRetryTicker {
 			return err
 		}
 
@@ -218,13 +237,17 @@ func (client *RPCClient) Call(serviceMethod string, args interface {
 		return nil
 	}
 	if err.Error() != errAuthentication.Error() {
+		logger.Event(ctx, logger.RPCSubsystem, logger.DebugLvl, "%s call to %s failed: %v", serviceMethod, client.args.ServiceURL, err)
 		return err
 	}
 
 	client.Lock()
 	client.authToken = client.args.NewAuthTokenFunc()
 	client.Unlock()
-	return call()
+	if err = call(); err != nil {
+		logger.Event(ctx, logger.RPCSubsystem, logger.DebugLvl, "%s call to %s failed: %v", serviceMethod, client.args.ServiceURL, err)
+	}
+	return err
 }
 
 // Close - closes underneath RPC client.
@@ -43,6 +43,12 @@ type Client struct {
 
 // Call - calls service method on RPC server.
 func (client *Client) Call(serviceMethod string, args, reply interface{}) error {
+	return client.CallWithContext(context.Background(), serviceMethod, args, reply)
+}
+
+// CallWithContext - calls service method on RPC server, the call is
+// aborted when ctx is done.
+func (client *Client) CallWithContext(ctx context.Context, serviceMethod string, args, reply interface{}) error {
 	replyKind := reflect.TypeOf(reply).Kind()
 	if replyKind != reflect.Ptr {
 		return fmt.Errorf("rpc reply must be a pointer type, but found %v", replyKind)
@@ -63,7 +69,12 @@ func (client *Client) Call(serviceMethod string, args, reply interface{}) error
 		return err
 	}
 
-	response, err := client.httpClient.Post(client.serviceURL.String(), "", &buf)
+	request, err := http.NewRequest(http.MethodPost, client.serviceURL.String(), &buf)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.httpClient.Do(request.WithContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -17,6 +17,7 @@
 package rpc
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -70,3 +71,36 @@ func TestClientCall(t *testing.T) {
 		}
 	}
 }
+
+func TestClientCallWithContext(t *testing.T) {
+	rpcServer := NewServer()
+	if err := rpcServer.RegisterName("Arith", &Arith{}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rpcServer.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	url, err := xnet.ParseURL(httpServer.URL)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	rpcClient := NewClient(url, nil, DefaultRPCTimeout)
+
+	var reply int
+	if err = rpcClient.CallWithContext(context.Background(), "Arith.Multiply", &Args{7, 8}, &reply); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if reply != 56 {
+		t.Fatalf("expected: 56, got: %v", reply)
+	}
+
+	// Calls are aborted once the context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = rpcClient.CallWithContext(ctx, "Arith.Multiply", &Args{7, 8}, &reply); err == nil {
+		t.Fatalf("expected error for cancelled call")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/minio/minio/pkg/sys"
+)
+
+// ServerRuntimeData holds Go runtime metrics of a server. GCPauseMax is
+// the longest of the last 256 garbage collection pauses. Error is set
+// when the number of open file descriptors is unknown.
+type ServerRuntimeData struct {
+	Goroutines   int           `json:"goroutines"`
+	HeapAlloc    uint64        `json:"heapAlloc"`
+	HeapInuse    uint64        `json:"heapInuse"`
+	HeapObjects  uint64        `json:"heapObjects"`
+	Sys          uint64        `json:"sys"`
+	NumGC        uint32        `json:"numGC"`
+	LastGC       time.Time     `json:"lastGC"`
+	GCPauseTotal time.Duration `json:"gcPauseTotal"`
+	GCPauseLast  time.Duration `json:"gcPauseLast"`
+	GCPauseMax   time.Duration `json:"gcPauseMax"`
+	OpenFDs      int           `json:"openFDs"`
+	MaxOpenFDs   uint64        `json:"maxOpenFDs"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// ServerRuntimeInfo holds Go runtime metrics of one node.
+type ServerRuntimeInfo struct {
+	Error string             `json:"error"`
+	Addr  string             `json:"addr"`
+	Data  *ServerRuntimeData `json:"data"`
+}
+
+// ClusterRuntimeInfo holds Go runtime metrics of each node along with
+// their totals across all nodes which replied.
+type ClusterRuntimeInfo struct {
+	Goroutines int                 `json:"goroutines"`
+	HeapInuse  uint64              `json:"heapInuse"`
+	OpenFDs    int                 `json:"openFDs"`
+	Servers    []ServerRuntimeInfo `json:"servers"`
+}
+
+// getLocalRuntimeInfo - returns Go runtime metrics of this server.
+func getLocalRuntimeInfo() ServerRuntimeData {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	data := ServerRuntimeData{
+		Goroutines:   runtime.NumGoroutine(),
+		HeapAlloc:    memStats.HeapAlloc,
+		HeapInuse:    memStats.HeapInuse,
+		HeapObjects:  memStats.HeapObjects,
+		Sys:          memStats.Sys,
+		NumGC:        memStats.NumGC,
+		GCPauseTotal: time.Duration(memStats.PauseTotalNs),
+	}
+
+	if memStats.NumGC > 0 {
+		data.LastGC = time.Unix(0, int64(memStats.LastGC)).UTC()
+		// PauseNs is a circular buffer of the most recent pauses.
+		numPauses := len(memStats.PauseNs)
+		data.GCPauseLast = time.Duration(memStats.PauseNs[(int(memStats.NumGC)+numPauses-1)%numPauses])
+		if int(memStats.NumGC) < numPauses {
+			numPauses = int(memStats.NumGC)
+		}
+		for _, pause := range memStats.PauseNs[:numPauses] {
+			if time.Duration(pause) > data.GCPauseMax {
+				data.GCPauseMax = time.Duration(pause)
+			}
+		}
+	}
+
+	var err error
+	if data.OpenFDs, err = sys.GetOpenFileCount(); err != nil {
+		data.Error = err.Error()
+	}
+	data.MaxOpenFDs, _, _ = sys.GetMaxOpenFileLimit()
+	return data
+}
+
+// aggregateRuntimeInfo - sums up the runtime metrics of all nodes.
+func aggregateRuntimeInfo(servers []ServerRuntimeInfo) ClusterRuntimeInfo {
+	info := ClusterRuntimeInfo{Servers: servers}
+	for _, server := range servers {
+		if server.Data == nil {
+			continue
+		}
+		info.Goroutines += server.Data.Goroutines
+		info.HeapInuse += server.Data.HeapInuse
+		info.OpenFDs += server.Data.OpenFDs
+	}
+	return info
+}
@@ -0,0 +1,53 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestGetLocalRuntimeInfo(t *testing.T) {
+	runtime.GC()
+
+	data := getLocalRuntimeInfo()
+	if data.Goroutines <= 0 || data.HeapInuse == 0 || data.Sys == 0 {
+		t.Fatalf("expected goroutines and memory in use, got %v", data)
+	}
+	if data.NumGC == 0 || data.LastGC.IsZero() || data.GCPauseMax < data.GCPauseLast || data.GCPauseTotal < data.GCPauseMax {
+		t.Fatalf("expected garbage collection stats, got %v", data)
+	}
+	if runtime.GOOS == "linux" && (data.Error != "" || data.OpenFDs <= 0) {
+		t.Fatalf("expected open file descriptors, got %v", data)
+	}
+}
+
+func TestAggregateRuntimeInfo(t *testing.T) {
+	servers := []ServerRuntimeInfo{
+		{Addr: "10.0.0.1:9000", Data: &ServerRuntimeData{Goroutines: 10, HeapInuse: 1000, OpenFDs: 5}},
+		{Addr: "10.0.0.2:9000", Error: "connection refused"},
+		{Addr: "10.0.0.3:9000", Data: &ServerRuntimeData{Goroutines: 20, HeapInuse: 3000, OpenFDs: 7}},
+	}
+
+	info := aggregateRuntimeInfo(servers)
+	if info.Goroutines != 30 || info.HeapInuse != 4000 || info.OpenFDs != 12 {
+		t.Fatalf("unexpected totals %v", info)
+	}
+	if len(info.Servers) != len(servers) {
+		t.Fatalf("expected %d servers, got %d", len(servers), len(info.Servers))
+	}
+}
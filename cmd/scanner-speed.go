@@ -0,0 +1,127 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ScannerSpeed - pace of background scans, i.e. the disk usage crawler
+// and heal sequences.
+type ScannerSpeed string
+
+const (
+	// ScannerSpeedSlow - waits for client requests to complete and
+	// pauses after each scanned entry.
+	ScannerSpeedSlow ScannerSpeed = "slow"
+
+	// ScannerSpeedDefault - the disk usage crawler waits for client
+	// requests to complete, heal sequences run at full speed.
+	ScannerSpeedDefault ScannerSpeed = "default"
+
+	// ScannerSpeedFast - all scans run at full speed.
+	ScannerSpeedFast ScannerSpeed = "fast"
+
+	// ScannerSpeedOff - all scans are paused until the speed is changed.
+	ScannerSpeedOff ScannerSpeed = "off"
+)
+
+const (
+	// Pause after each entry scanned at slow speed.
+	scannerSlowDelay = 10 * time.Millisecond
+
+	// Interval at which paused scans check for a speed change.
+	scannerPauseInterval = time.Second
+)
+
+var errInvalidScannerSpeed = errors.New("invalid scanner speed")
+
+// IsValid - returns true if the scanner speed is known.
+func (speed ScannerSpeed) IsValid() bool {
+	switch speed {
+	case ScannerSpeedSlow, ScannerSpeedDefault, ScannerSpeedFast, ScannerSpeedOff:
+		return true
+	}
+	return false
+}
+
+// Scanner speed of this server, not persisted across restarts.
+var globalScannerSpeed = struct {
+	sync.RWMutex
+	speed ScannerSpeed
+}{speed: ScannerSpeedDefault}
+
+// setScannerSpeed - changes the pace of background scans of this server.
+func setScannerSpeed(speed ScannerSpeed) error {
+	if !speed.IsValid() {
+		return errInvalidScannerSpeed
+	}
+
+	globalScannerSpeed.Lock()
+	globalScannerSpeed.speed = speed
+	globalScannerSpeed.Unlock()
+	return nil
+}
+
+// getScannerSpeed - returns the pace of background scans of this server.
+func getScannerSpeed() ScannerSpeed {
+	globalScannerSpeed.RLock()
+	defer globalScannerSpeed.RUnlock()
+	return globalScannerSpeed.speed
+}
+
+// waitForScanner - called by background scans before processing their
+// next entry, blocks while scans are off and pauses at slow speed.
+// Returns false if doneCh is closed while waiting.
+func waitForScanner(doneCh <-chan struct{}) bool {
+	for {
+		switch getScannerSpeed() {
+		case ScannerSpeedOff:
+			select {
+			case <-doneCh:
+				return false
+			case <-time.After(scannerPauseInterval):
+			}
+		case ScannerSpeedSlow:
+			select {
+			case <-doneCh:
+				return false
+			case <-time.After(scannerSlowDelay):
+				return true
+			}
+		default:
+			return true
+		}
+	}
+}
+
+// waitForCrawler - called by the disk usage crawler before visiting its
+// next entry. Unless running fast, the crawler waits at most 1 minute
+// for in-progress client requests. Returns false if doneCh is closed
+// while waiting.
+func waitForCrawler(doneCh <-chan struct{}) bool {
+	if getScannerSpeed() != ScannerSpeedFast && globalHTTPServer != nil {
+		// Any requests in progress, delay the usage.
+		for waitCount := 60; globalHTTPServer.GetRequestCount() > 0 && waitCount > 0; waitCount-- {
+			time.Sleep(1 * time.Second)
+		}
+	}
+
+	return waitForScanner(doneCh)
+}
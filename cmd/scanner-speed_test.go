@@ -0,0 +1,71 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitForScanner(t *testing.T) {
+	defer setScannerSpeed(ScannerSpeedDefault)
+
+	doneCh := make(chan struct{})
+	for _, speed := range []ScannerSpeed{ScannerSpeedSlow, ScannerSpeedDefault, ScannerSpeedFast} {
+		if err := setScannerSpeed(speed); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if !waitForScanner(doneCh) || !waitForCrawler(doneCh) {
+			t.Fatalf("%s: expected scan to proceed", speed)
+		}
+	}
+
+	if err := setScannerSpeed("turbo"); err != errInvalidScannerSpeed {
+		t.Fatalf("expected: %v, got: %v", errInvalidScannerSpeed, err)
+	}
+	if speed := getScannerSpeed(); speed != ScannerSpeedFast {
+		t.Fatalf("expected: %s, got: %s", ScannerSpeedFast, speed)
+	}
+
+	// Paused scans resume once the speed is changed.
+	if err := setScannerSpeed(ScannerSpeedOff); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	resultCh := make(chan bool)
+	go func() {
+		resultCh <- waitForScanner(doneCh)
+	}()
+	select {
+	case <-resultCh:
+		t.Fatal("expected scan to be paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+	setScannerSpeed(ScannerSpeedDefault)
+	if !<-resultCh {
+		t.Fatal("expected scan to proceed")
+	}
+
+	// Paused scans stop once doneCh is closed.
+	setScannerSpeed(ScannerSpeedOff)
+	go func() {
+		resultCh <- waitForScanner(doneCh)
+	}()
+	close(doneCh)
+	if <-resultCh {
+		t.Fatal("expected scan to stop")
+	}
+}
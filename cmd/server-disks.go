@@ -0,0 +1,83 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// getDiskState - returns the state of the XL format of a local disk.
+// Unlike loadFormatXL, the disk is not checked for user data when
+// format.json is missing.
+func getDiskState(diskPath string) string {
+	buf, err := ioutil.ReadFile(filepath.Join(diskPath, minioMetaBucket, formatConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return madmin.DriveStateMissing
+		}
+		return madmin.DriveStateOffline
+	}
+
+	format := &formatXLV3{}
+	if err = json.Unmarshal(buf, format); err != nil {
+		return madmin.DriveStateCorrupt
+	}
+	return madmin.DriveStateOk
+}
+
+// getLocalDiskInfo - returns space usage and state of a local disk.
+func getLocalDiskInfo(endpoint Endpoint, isHealing bool) ServerDiskInfo {
+	info := ServerDiskInfo{
+		Endpoint: endpoint.String(),
+		State:    madmin.DriveStateOk,
+	}
+
+	di, err := getDiskInfo(endpoint.Path)
+	if err != nil {
+		info.State = madmin.DriveStateOffline
+		info.Error = err.Error()
+		return info
+	}
+	info.Total = di.Total
+	info.Used = di.Total - di.Free
+	info.Available = di.Free
+
+	// Only disks of erasure coded setups are formatted and healed.
+	if globalIsXL {
+		info.State = getDiskState(endpoint.Path)
+		info.Healing = isHealing && (info.State == madmin.DriveStateMissing || info.State == madmin.DriveStateCorrupt)
+	}
+	return info
+}
+
+// getLocalDisksInfo - returns space usage and state of all local disks.
+func getLocalDisksInfo(endpoints EndpointList) []ServerDiskInfo {
+	isHealing := globalAllHealState.isHealing()
+
+	var disksInfo []ServerDiskInfo
+	for _, endpoint := range endpoints {
+		if endpoint.IsLocal {
+			disksInfo = append(disksInfo, getLocalDiskInfo(endpoint, isHealing))
+		}
+	}
+	return disksInfo
+}
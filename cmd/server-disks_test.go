@@ -0,0 +1,94 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests space usage and state reported for local disks.
+func TestGetLocalDisksInfo(t *testing.T) {
+	tmpGlobalIsXL := globalIsXL
+	defer func() {
+		globalIsXL = tmpGlobalIsXL
+	}()
+	globalIsXL = true
+
+	rootPath, err := ioutil.TempDir(globalTestTmpDir, "minio-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	formatData, err := json.Marshal(newFormatXLV3(1, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		format        []byte
+		expectedState string
+	}{
+		{"ok", formatData, madmin.DriveStateOk},
+		{"corrupt", []byte("{"), madmin.DriveStateCorrupt},
+		{"missing", nil, madmin.DriveStateMissing},
+	}
+
+	var args []string
+	for _, testCase := range testCases {
+		diskPath := filepath.Join(rootPath, testCase.name)
+		if err = os.MkdirAll(filepath.Join(diskPath, minioMetaBucket), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if testCase.format != nil {
+			if err = ioutil.WriteFile(filepath.Join(diskPath, minioMetaBucket, formatConfigFile), testCase.format, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		args = append(args, diskPath)
+	}
+	args = append(args, filepath.Join(rootPath, "offline"))
+
+	disksInfo := getLocalDisksInfo(mustGetNewEndpointList(args...))
+	if len(disksInfo) != len(args) {
+		t.Fatalf("expected %d disks, got %d", len(args), len(disksInfo))
+	}
+
+	for i, testCase := range testCases {
+		info := disksInfo[i]
+		if info.State != testCase.expectedState {
+			t.Errorf("case %v: expected state %v, got %v", i+1, testCase.expectedState, info.State)
+		}
+		if info.Total == 0 || info.Used+info.Available != info.Total || info.Error != "" {
+			t.Errorf("case %v: unexpected disk usage %v", i+1, info)
+		}
+		if info.Healing {
+			t.Errorf("case %v: unexpected healing state", i+1)
+		}
+	}
+
+	if info := disksInfo[len(args)-1]; info.State != madmin.DriveStateOffline || info.Error == "" {
+		t.Errorf("expected offline disk, got %v", info)
+	}
+}
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
@@ -87,6 +88,9 @@ ENVIRONMENT VARIABLES:
   WORM:
      MINIO_WORM: To turn on Write-Once-Read-Many in server, set this value to "on".
 
+  INTEGRITY:
+     MINIO_STRICT_INTEGRITY: To reject uploads without Content-MD5 or signed SHA256 sum, set this value to "on".
+
   BUCKET-DNS:
      MINIO_DOMAIN:    To enable bucket DNS requests, set this value to Minio host domain name.
      MINIO_PUBLIC_IPS: To enable bucket DNS requests, set this value to list of Minio host public IP(s) delimited by ",".
@@ -225,6 +229,9 @@ func serverMain(ctx *cli.Context) {
 	globalPublicCerts, globalRootCAs, globalTLSCerts, globalIsSSL, err = getSSLConfig()
 	logger.FatalIf(err, "Unable to load the TLS configuration")
 
+	// Configure the identity providers, reached with the root CAs.
+	handleIAMEnvVars()
+
 	// Is distributed setup, error out if no certificates are found for HTTPS endpoints.
 	if globalIsDistXL {
 		if globalEndpoints.IsHTTPS() && !globalIsSSL {
@@ -281,6 +288,12 @@ func serverMain(ctx *cli.Context) {
 	globalHTTPServer = xhttp.NewServer([]string{globalMinioAddr}, criticalErrorHandler{handler}, getCert)
 	globalHTTPServer.UpdateBytesReadFunc = globalConnStats.incInputBytes
 	globalHTTPServer.UpdateBytesWrittenFunc = globalConnStats.incOutputBytes
+
+	// Verify the client certificates exchanged for temporary credentials.
+	if globalClientCertCAs != nil {
+		globalHTTPServer.TLSConfig.ClientCAs = globalClientCertCAs
+		globalHTTPServer.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
 	go func() {
 		globalHTTPServerErrorCh <- globalHTTPServer.Start()
 	}()
@@ -316,6 +329,54 @@ func serverMain(ctx *cli.Context) {
 		logger.Fatal(err, "Unable to initialize policy system")
 	}
 
+	// Create new bucket quota system.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	// Initialize bucket quota system.
+	if err := globalBucketQuotaSys.Init(newObjectLayerFn()); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket quota system")
+	}
+
+	// Create new remote targets system.
+	globalBucketTargetSys = NewBucketTargetSys()
+
+	// Initialize remote targets system.
+	if err := globalBucketTargetSys.Init(newObjectLayerFn()); err != nil {
+		logger.Fatal(err, "Unable to initialize remote targets system")
+	}
+
+	// Create new bucket metadata system.
+	globalBucketMetadataSys = NewBucketMetadataSys()
+
+	// Initialize bucket metadata system.
+	if err := globalBucketMetadataSys.Init(newObjectLayerFn()); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket metadata system")
+	}
+
+	// Create new tiers system.
+	globalTierConfigSys = NewTierConfigSys()
+
+	// Initialize tiers system.
+	if err := globalTierConfigSys.Init(newObjectLayerFn()); err != nil {
+		logger.Fatal(err, "Unable to initialize tiers system")
+	}
+
+	// Create new batch jobs system.
+	globalBatchJobSys = NewBatchJobSys()
+
+	// Initialize batch jobs system, resuming interrupted jobs.
+	if err := globalBatchJobSys.Init(newObjectLayerFn(), getLocalAdminPeerAddr()); err != nil {
+		logger.Fatal(err, "Unable to initialize batch jobs system")
+	}
+
+	// Create new IAM system.
+	globalIAMSys = NewIAMSys()
+
+	// Initialize IAM system.
+	if err := globalIAMSys.Init(newObjectLayerFn()); err != nil {
+		logger.Fatal(err, "Unable to initialize IAM system")
+	}
+
 	// Create new notification system.
 	globalNotificationSys = NewNotificationSys(globalServerConfig, globalEndpoints)
 
@@ -324,6 +385,24 @@ func serverMain(ctx *cli.Context) {
 		logger.Fatal(err, "Unable to initialize notification system")
 	}
 
+	// Start expiring objects as per the lifecycle of their bucket.
+	initLifecycleSweeper(newObjectLayerFn())
+
+	// Start replicating objects as per the replication of their bucket.
+	initReplicationPool(newObjectLayerFn())
+
+	// Start writing the inventory reports of buckets as per their schedule.
+	initBucketInventory(newObjectLayerFn())
+
+	// Start delivering the access logs of buckets to their target bucket.
+	initBucketLogging(newObjectLayerFn())
+
+	// Start building the metadata indexes of buckets searched by search requests.
+	initBucketMetadataIndexer(newObjectLayerFn())
+
+	// Start counting the objects of buckets returned by HeadBucket.
+	initBucketObjectCounter(newObjectLayerFn())
+
 	// Prints the formatted startup message once object layer is initialized.
 	apiEndpoints := getAPIEndpoints(globalMinioAddr)
 	printStartupMessage(apiEndpoints)
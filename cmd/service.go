@@ -19,18 +19,41 @@ package cmd
 import (
 	"os"
 	"os/exec"
+	"sync/atomic"
 )
 
 // Type of service signals currently supported.
 type serviceSignal int
 
 const (
-	serviceStatus  = iota // Gets status about the service.
-	serviceRestart        // Restarts the service.
-	serviceStop           // Stops the server.
+	serviceStatus   = iota // Gets status about the service.
+	serviceRestart         // Restarts the service.
+	serviceStop            // Stops the server.
+	serviceFreeze          // Rejects new S3 calls until unfrozen.
+	serviceUnFreeze        // Resumes serving S3 calls.
 	// Add new service requests here.
 )
 
+// Set to 1 while the server is frozen, see freezeServices.
+var globalServiceFrozen int32
+
+// freezeServices - makes the server reject new S3 calls with SlowDown
+// until unfreezeServices is called, calls in progress are completed.
+// The frozen state is not persisted and is cleared on restart.
+func freezeServices() {
+	atomic.StoreInt32(&globalServiceFrozen, 1)
+}
+
+// unfreezeServices - resumes serving S3 calls.
+func unfreezeServices() {
+	atomic.StoreInt32(&globalServiceFrozen, 0)
+}
+
+// isServiceFrozen - returns true if the server rejects S3 calls.
+func isServiceFrozen() bool {
+	return atomic.LoadInt32(&globalServiceFrozen) == 1
+}
+
 // Global service signal channel.
 var globalServiceSignalCh chan serviceSignal
 
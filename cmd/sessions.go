@@ -0,0 +1,179 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/handlers"
+)
+
+// SessionInfo holds an S3 API call in progress on a node. AccessKey is
+// empty for anonymous calls.
+type SessionInfo struct {
+	RemoteHost string        `json:"remoteHost"`
+	AccessKey  string        `json:"accessKey,omitempty"`
+	API        string        `json:"api"`
+	Bucket     string        `json:"bucket,omitempty"`
+	Object     string        `json:"object,omitempty"`
+	Started    time.Time     `json:"started"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// ServerSessions holds the S3 API calls in progress on one node.
+type ServerSessions struct {
+	Error string        `json:"error"`
+	Addr  string        `json:"addr"`
+	Data  []SessionInfo `json:"data"`
+}
+
+// ClientSessions holds the number of S3 API calls in progress across
+// all nodes made by one client, i.e. a remote IP and access key.
+type ClientSessions struct {
+	RemoteIP    string        `json:"remoteIP"`
+	AccessKey   string        `json:"accessKey,omitempty"`
+	Sessions    int           `json:"sessions"`
+	MaxDuration time.Duration `json:"maxDuration"`
+}
+
+// ClusterSessions holds the clients having calls in progress, busiest
+// clients first, along with the calls in progress on each node.
+type ClusterSessions struct {
+	Clients []ClientSessions `json:"clients"`
+	Servers []ServerSessions `json:"servers"`
+}
+
+// sessionTracker - S3 API calls in progress on this server.
+type sessionTracker struct {
+	sync.Mutex
+	nextID   uint64
+	sessions map[uint64]SessionInfo
+}
+
+// Account a new S3 API call, returns the ID to pass to done.
+func (t *sessionTracker) start(info SessionInfo) uint64 {
+	t.Lock()
+	defer t.Unlock()
+	t.nextID++
+	t.sessions[t.nextID] = info
+	return t.nextID
+}
+
+// Account the end of the S3 API call having the given ID.
+func (t *sessionTracker) done(id uint64) {
+	t.Lock()
+	delete(t.sessions, id)
+	t.Unlock()
+}
+
+// Return the number of calls in progress.
+func (t *sessionTracker) count() int {
+	t.Lock()
+	defer t.Unlock()
+	return len(t.sessions)
+}
+
+// Return the calls in progress as of the given time, longest first.
+func (t *sessionTracker) list(now time.Time) []SessionInfo {
+	t.Lock()
+	sessions := make([]SessionInfo, 0, len(t.sessions))
+	for _, info := range t.sessions {
+		info.Duration = now.Sub(info.Started)
+		sessions = append(sessions, info)
+	}
+	t.Unlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].Started != sessions[j].Started {
+			return sessions[i].Started.Before(sessions[j].Started)
+		}
+		return sessions[i].RemoteHost < sessions[j].RemoteHost
+	})
+	return sessions
+}
+
+// Prepare new sessionTracker structure
+func newSessionTracker() *sessionTracker {
+	return &sessionTracker{
+		sessions: make(map[uint64]SessionInfo),
+	}
+}
+
+// newSessionInfo - describes the call of the named S3 API made by r.
+func newSessionInfo(api string, r *http.Request, started time.Time) SessionInfo {
+	vars := mux.Vars(r)
+	return SessionInfo{
+		RemoteHost: handlers.GetSourceIP(r),
+		AccessKey:  getReqAccessKey(r),
+		API:        api,
+		Bucket:     vars["bucket"],
+		Object:     vars["object"],
+		Started:    started,
+	}
+}
+
+// remoteIP - returns the IP of a remote host address, which may lack
+// the port when it comes from proxy headers.
+func remoteIP(remoteHost string) string {
+	if host, _, err := net.SplitHostPort(remoteHost); err == nil {
+		return host
+	}
+	return remoteHost
+}
+
+// aggregateSessions - counts the calls in progress of each client across
+// all nodes and returns the clients having the most calls first.
+func aggregateSessions(servers []ServerSessions) []ClientSessions {
+	type clientKey struct{ remoteIP, accessKey string }
+	clients := make(map[clientKey]ClientSessions)
+	for _, server := range servers {
+		for _, info := range server.Data {
+			key := clientKey{remoteIP(info.RemoteHost), info.AccessKey}
+			client := clients[key]
+			client.RemoteIP, client.AccessKey = key.remoteIP, key.accessKey
+			client.Sessions++
+			if info.Duration > client.MaxDuration {
+				client.MaxDuration = info.Duration
+			}
+			clients[key] = client
+		}
+	}
+
+	sorted := make([]ClientSessions, 0, len(clients))
+	for _, client := range clients {
+		sorted = append(sorted, client)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Sessions != b.Sessions {
+			return a.Sessions > b.Sessions
+		}
+		if a.MaxDuration != b.MaxDuration {
+			return a.MaxDuration > b.MaxDuration
+		}
+		if a.RemoteIP != b.RemoteIP {
+			return a.RemoteIP < b.RemoteIP
+		}
+		return a.AccessKey < b.AccessKey
+	})
+	return sorted
+}
@@ -0,0 +1,105 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Tests that calls passing through collectAPIStats are listed while in progress.
+func TestCollectAPIStatsSessions(t *testing.T) {
+	tmpGlobalSessions := globalSessions
+	defer func() {
+		globalSessions = tmpGlobalSessions
+	}()
+	globalSessions = newSessionTracker()
+
+	router := mux.NewRouter()
+	router.Methods(http.MethodGet).Path("/{bucket}/{object:.+}").HandlerFunc(collectAPIStats("GetObject", func(w http.ResponseWriter, r *http.Request) {
+		sessions := globalSessions.list(UTCNow())
+		if len(sessions) != 1 {
+			t.Fatalf("expected 1 session in progress, got %v", sessions)
+		}
+		session := sessions[0]
+		if session.API != "GetObject" || session.Bucket != "bucket" || session.Object != "dir/object" ||
+			session.RemoteHost != "10.0.0.1" || session.AccessKey != "" {
+			t.Errorf("unexpected session %v", session)
+		}
+	}))
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:9000/bucket/dir/object", nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if sessions := globalSessions.list(UTCNow()); len(sessions) != 0 {
+		t.Fatalf("expected no sessions in progress, got %v", sessions)
+	}
+}
+
+func TestSessionTrackerList(t *testing.T) {
+	tracker := newSessionTracker()
+	now := UTCNow()
+
+	tracker.start(SessionInfo{RemoteHost: "10.0.0.1:1000", API: "PutObject", Started: now.Add(-time.Second)})
+	id := tracker.start(SessionInfo{RemoteHost: "10.0.0.2:1000", API: "GetObject", Started: now.Add(-time.Minute)})
+	tracker.start(SessionInfo{RemoteHost: "10.0.0.3:1000", API: "ListObjectsV1", Started: now.Add(-time.Hour)})
+	tracker.done(id)
+
+	sessions := tracker.list(now)
+	if len(sessions) != 2 || sessions[0].API != "ListObjectsV1" || sessions[1].API != "PutObject" {
+		t.Fatalf("expected ListObjectsV1 and PutObject sessions, got %v", sessions)
+	}
+	if sessions[0].Duration != time.Hour || sessions[1].Duration != time.Second {
+		t.Fatalf("unexpected durations %v", sessions)
+	}
+}
+
+func TestAggregateSessions(t *testing.T) {
+	servers := []ServerSessions{
+		{Addr: "node1", Data: []SessionInfo{
+			{RemoteHost: "10.0.0.1:1000", AccessKey: "alice", Duration: time.Second},
+			{RemoteHost: "10.0.0.2:1000", AccessKey: "bob", Duration: time.Hour},
+		}},
+		{Addr: "node2", Data: []SessionInfo{
+			{RemoteHost: "10.0.0.1:2000", AccessKey: "alice", Duration: time.Minute},
+			{RemoteHost: "10.0.0.1", Duration: time.Second},
+		}},
+	}
+
+	expected := []ClientSessions{
+		{RemoteIP: "10.0.0.1", AccessKey: "alice", Sessions: 2, MaxDuration: time.Minute},
+		{RemoteIP: "10.0.0.2", AccessKey: "bob", Sessions: 1, MaxDuration: time.Hour},
+		{RemoteIP: "10.0.0.1", Sessions: 1, MaxDuration: time.Second},
+	}
+	clients := aggregateSessions(servers)
+	if len(clients) != len(expected) {
+		t.Fatalf("expected: %v, got: %v", expected, clients)
+	}
+	for i := range expected {
+		if clients[i] != expected[i] {
+			t.Fatalf("case %d: expected: %v, got: %v", i+1, expected[i], clients[i])
+		}
+	}
+}
@@ -52,6 +52,9 @@ func handleSignals() {
 		logger.LogIf(context.Background(), err)
 
 		if objAPI := newObjectLayerFn(); objAPI != nil {
+			// Deliver the access logs buffered since the last delivery.
+			globalBucketLoggingSys.Flush(context.Background(), objAPI)
+
 			oerr = objAPI.Shutdown(context.Background())
 			logger.LogIf(context.Background(), oerr)
 		}
@@ -75,6 +75,18 @@ func isValidStorageClassMeta(sc string) bool {
 	return sc == reducedRedundancyStorageClass || sc == standardStorageClass
 }
 
+// isStorageClassEqual - returns whether two storage classes of object
+// metadata are the same, no storage class is the standard storage class.
+func isStorageClassEqual(sc1, sc2 string) bool {
+	if sc1 == "" {
+		sc1 = standardStorageClass
+	}
+	if sc2 == "" {
+		sc2 = standardStorageClass
+	}
+	return sc1 == sc2
+}
+
 func (sc *storageClass) UnmarshalText(b []byte) error {
 	scStr := string(b)
 	if scStr == "" {
@@ -343,3 +343,23 @@ func TestIsValidStorageClassMeta(t *testing.T) {
 		}
 	}
 }
+
+// Test isStorageClassEqual method with the storage classes of object metadata
+func TestIsStorageClassEqual(t *testing.T) {
+	tests := []struct {
+		sc1, sc2 string
+		want     bool
+	}{
+		{"", "", true},
+		{"", standardStorageClass, true},
+		{standardStorageClass, "", true},
+		{reducedRedundancyStorageClass, reducedRedundancyStorageClass, true},
+		{"", reducedRedundancyStorageClass, false},
+		{reducedRedundancyStorageClass, standardStorageClass, false},
+	}
+	for i, tt := range tests {
+		if got := isStorageClassEqual(tt.sc1, tt.sc2); got != tt.want {
+			t.Errorf("Test %d, Expected %t, got %t", i+1, tt.want, got)
+		}
+	}
+}
@@ -139,6 +139,7 @@ func (client *StorageRPCClient) call(handler string, args interface {
 
 	if isNetworkDisconnectError(err) {
 		client.connected = false
+		logger.Event(context.Background(), logger.StorageSubsystem, logger.InformationLvl, "Disk %s is offline: %v", client, err)
 	}
 
 	return toStorageErr(err)
@@ -21,30 +21,39 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"hash"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/auth"
+	miniohash "github.com/minio/minio/pkg/hash"
 	sha256 "github.com/minio/sha256-simd"
 )
 
 // Streaming AWS Signature Version '4' constants.
 const (
-	emptySHA256              = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
-	streamingContentSHA256   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
-	signV4ChunkedAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
-	streamingContentEncoding = "aws-chunked"
+	emptySHA256                   = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	streamingContentSHA256        = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingContentSHA256Trailer = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+	signV4ChunkedAlgorithm        = "AWS4-HMAC-SHA256-PAYLOAD"
+	signV4TrailerAlgorithm        = "AWS4-HMAC-SHA256-TRAILER"
+	streamingContentEncoding      = "aws-chunked"
+
+	// Request header naming the trailing header sent after the
+	// final chunk, followed by the signature of the trailer.
+	amzTrailer             = "X-Amz-Trailer"
+	amzTrailerSignatureStr = "x-amz-trailer-signature"
 )
 
 // getChunkSignature - get chunk signature.
-func getChunkSignature(seedSignature string, region string, date time.Time, hashedChunk string) string {
-	// Access credentials.
-	cred := globalServerConfig.GetCredential()
-
+func getChunkSignature(cred auth.Credentials, seedSignature string, region string, date time.Time, hashedChunk string) string {
 	// Calculate string to sign.
 	stringToSign := signV4ChunkedAlgorithm + "\n" +
 		date.Format(iso8601Format) + "\n" +
@@ -54,7 +63,7 @@ func getChunkSignature(seedSignature string, region string, date time.Time, hash
 		hashedChunk
 
 	// Get hmac signing key.
-	signingKey := getSigningKey(cred.SecretKey, date, region)
+	signingKey := getSigningKey(cred.SecretKey, date, region, serviceS3)
 
 	// Calculate signature.
 	newSignature := getSignature(signingKey, stringToSign)
@@ -62,14 +71,43 @@ func getChunkSignature(seedSignature string, region string, date time.Time, hash
 	return newSignature
 }
 
+// getTrailerSignature - get the signature of the trailing headers, chained
+// to the signature of the final chunk.
+func getTrailerSignature(cred auth.Credentials, seedSignature string, region string, date time.Time, hashedTrailer string) string {
+	// Calculate string to sign.
+	stringToSign := signV4TrailerAlgorithm + "\n" +
+		date.Format(iso8601Format) + "\n" +
+		getScope(date, region) + "\n" +
+		seedSignature + "\n" +
+		hashedTrailer
+
+	// Get hmac signing key.
+	signingKey := getSigningKey(cred.SecretKey, date, region, serviceS3)
+
+	return getSignature(signingKey, stringToSign)
+}
+
+// getStreamingTrailer - returns the trailing checksum header declared by a
+// streaming request with trailer and its algorithm, only a single
+// additional checksum header is supported as trailer.
+func getStreamingTrailer(header http.Header) (trailer, algorithm string, errCode APIErrorCode) {
+	values := header[amzTrailer]
+	if len(values) != 1 {
+		return "", "", ErrInvalidTrailer
+	}
+	trailer = strings.ToLower(strings.TrimSpace(values[0]))
+	algorithm, ok := checksumHeaderAlgorithms[trailer]
+	if !ok {
+		return "", "", ErrInvalidTrailer
+	}
+	return trailer, algorithm, ErrNone
+}
+
 // calculateSeedSignature - Calculate seed signature in accordance with
 //     - http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html
 // returns signature, error otherwise if the signature mismatches or any other
 // error while parsing and validating.
-func calculateSeedSignature(r *http.Request) (signature string, region string, date time.Time, errCode APIErrorCode) {
-	// Access credentials.
-	cred := globalServerConfig.GetCredential()
-
+func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature string, region string, date time.Time, errCode APIErrorCode) {
 	// Copy request.
 	req := *r
 
@@ -77,27 +115,27 @@ func calculateSeedSignature(r *http.Request) (signature string, region string, d
 	v4Auth := req.Header.Get("Authorization")
 
 	// Parse signature version '4' header.
-	signV4Values, errCode := parseSignV4(v4Auth, globalServerConfig.GetRegion())
+	signV4Values, errCode := parseSignV4(v4Auth, getBucketRegion(mux.Vars(r)["bucket"]), serviceS3)
 	if errCode != ErrNone {
-		return "", "", time.Time{}, errCode
+		return cred, "", "", time.Time{}, errCode
 	}
 
-	// Payload streaming.
-	payload := streamingContentSHA256
-
 	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD'
-	if payload != req.Header.Get("X-Amz-Content-Sha256") {
-		return "", "", time.Time{}, ErrContentSHA256Mismatch
+	// or 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER'
+	payload := req.Header.Get("X-Amz-Content-Sha256")
+	if payload != streamingContentSHA256 && payload != streamingContentSHA256Trailer {
+		return cred, "", "", time.Time{}, ErrContentSHA256Mismatch
 	}
 
 	// Extract all the signed headers along with its values.
 	extractedSignedHeaders, errCode := extractSignedHeaders(signV4Values.SignedHeaders, r)
 	if errCode != ErrNone {
-		return "", "", time.Time{}, errCode
+		return cred, "", "", time.Time{}, errCode
 	}
 	// Verify if the access key id matches.
-	if signV4Values.Credential.accessKey != cred.AccessKey {
-		return "", "", time.Time{}, ErrInvalidAccessKeyID
+	cred, ok := getCredential(signV4Values.Credential.accessKey)
+	if !ok {
+		return cred, "", "", time.Time{}, ErrInvalidAccessKeyID
 	}
 
 	// Verify if region is valid.
@@ -107,14 +145,14 @@ func calculateSeedSignature(r *http.Request) (signature string, region string, d
 	var dateStr string
 	if dateStr = req.Header.Get(http.CanonicalHeaderKey("x-amz-date")); dateStr == "" {
 		if dateStr = r.Header.Get("Date"); dateStr == "" {
-			return "", "", time.Time{}, ErrMissingDateHeader
+			return cred, "", "", time.Time{}, ErrMissingDateHeader
 		}
 	}
 	// Parse date header.
 	var err error
 	date, err = time.Parse(iso8601Format, dateStr)
 	if err != nil {
-		return "", "", time.Time{}, ErrMalformedDate
+		return cred, "", "", time.Time{}, ErrMalformedDate
 	}
 
 	// Query string.
@@ -127,18 +165,18 @@ func calculateSeedSignature(r *http.Request) (signature string, region string, d
 	stringToSign := getStringToSign(canonicalRequest, date, signV4Values.Credential.getScope())
 
 	// Get hmac signing key.
-	signingKey := getSigningKey(cred.SecretKey, signV4Values.Credential.scope.date, region)
+	signingKey := getSigningKey(cred.SecretKey, signV4Values.Credential.scope.date, region, serviceS3)
 
 	// Calculate signature.
 	newSignature := getSignature(signingKey, stringToSign)
 
 	// Verify if signature match.
 	if !compareSignatureV4(newSignature, signV4Values.Signature) {
-		return "", "", time.Time{}, ErrSignatureDoesNotMatch
+		return cred, "", "", time.Time{}, ErrSignatureDoesNotMatch
 	}
 
 	// Return caculated signature.
-	return newSignature, region, date, ErrNone
+	return cred, newSignature, region, date, ErrNone
 }
 
 const maxLineLength = 4 * humanize.KiByte // assumed <= bufio.defaultBufSize 4KiB
@@ -151,28 +189,39 @@ var errMalformedEncoding = errors.New("malformed chunked encoding")
 
 // newSignV4ChunkedReader returns a new s3ChunkedReader that translates the data read from r
 // out of HTTP "chunked" format before returning it.
-// The s3ChunkedReader returns io.EOF when the final 0-length chunk is read.
+// The s3ChunkedReader returns io.EOF when the final 0-length chunk is read,
+// once the trailing checksum header following it is verified, if any.
 //
 // NewChunkedReader is not needed by normal applications. The http package
 // automatically decodes chunking when reading response bodies.
 func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
-	seedSignature, region, seedDate, errCode := calculateSeedSignature(req)
+	cred, seedSignature, region, seedDate, errCode := calculateSeedSignature(req)
 	if errCode != ErrNone {
 		return nil, errCode
 	}
-	return &s3ChunkedReader{
+	cr := &s3ChunkedReader{
+		cred:              cred,
 		reader:            bufio.NewReader(req.Body),
 		seedSignature:     seedSignature,
 		seedDate:          seedDate,
 		region:            region,
 		chunkSHA256Writer: sha256.New(),
 		state:             readChunkHeader,
-	}, ErrNone
+	}
+	if req.Header.Get("X-Amz-Content-Sha256") == streamingContentSHA256Trailer {
+		cr.trailer, cr.checksumAlgorithm, errCode = getStreamingTrailer(req.Header)
+		if errCode != ErrNone {
+			return nil, errCode
+		}
+		cr.checksumHash = miniohash.NewChecksumHash(cr.checksumAlgorithm)
+	}
+	return cr, ErrNone
 }
 
 // Represents the overall state that is required for decoding a
 // AWS Signature V4 chunked reader.
 type s3ChunkedReader struct {
+	cred              auth.Credentials
 	reader            *bufio.Reader
 	seedSignature     string
 	seedDate          time.Time
@@ -183,6 +232,10 @@ type s3ChunkedReader struct {
 	chunkSHA256Writer hash.Hash // Calculates sha256 of chunk data.
 	n                 uint64    // Unread bytes in chunk
 	err               error
+
+	trailer           string    // Trailing checksum header, if any.
+	checksumAlgorithm string    // Algorithm of the trailing checksum.
+	checksumHash      hash.Hash // Calculates the trailing checksum of all the data.
 }
 
 // Read chunk reads the chunk token signature portion.
@@ -212,6 +265,7 @@ const (
 	readChunkTrailer
 	readChunk
 	verifyChunk
+	readTrailer
 	eofChunk
 )
 
@@ -226,6 +280,8 @@ func (cs chunkState) String() string {
 		stateString = "readChunk"
 	case verifyChunk:
 		stateString = "verifyChunk"
+	case readTrailer:
+		stateString = "readTrailer"
 	case eofChunk:
 		stateString = "eofChunk"
 
@@ -248,6 +304,10 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			if cr.n == 0 && cr.err == io.EOF {
 				cr.state = readChunkTrailer
 				cr.lastChunk = true
+				// The trailing headers directly follow the final chunk header.
+				if cr.trailer != "" {
+					cr.state = verifyChunk
+				}
 				continue
 			}
 			if cr.err != nil {
@@ -283,6 +343,9 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 
 			// Calculate sha256.
 			cr.chunkSHA256Writer.Write(rbuf[:n0])
+			if cr.checksumHash != nil {
+				cr.checksumHash.Write(rbuf[:n0])
+			}
 			// Update the bytes read into request buffer so far.
 			n += n0
 			buf = buf[n0:]
@@ -298,7 +361,7 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			// Calculate the hashed chunk.
 			hashedChunk := hex.EncodeToString(cr.chunkSHA256Writer.Sum(nil))
 			// Calculate the chunk signature.
-			newSignature := getChunkSignature(cr.seedSignature, cr.region, cr.seedDate, hashedChunk)
+			newSignature := getChunkSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, hashedChunk)
 			if !compareSignatureV4(cr.chunkSignature, newSignature) {
 				// Chunk signature doesn't match we return signature does not match.
 				cr.err = errSignatureMismatch
@@ -308,17 +371,94 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			// this follows the chaining.
 			cr.seedSignature = newSignature
 			cr.chunkSHA256Writer.Reset()
-			if cr.lastChunk {
+			switch {
+			case cr.lastChunk && cr.trailer != "":
+				cr.state = readTrailer
+			case cr.lastChunk:
 				cr.state = eofChunk
-			} else {
+			default:
 				cr.state = readChunkHeader
 			}
+		case readTrailer:
+			if cr.err = cr.readS3Trailer(); cr.err != nil {
+				return 0, cr.err
+			}
+			cr.state = eofChunk
 		case eofChunk:
 			return n, io.EOF
 		}
 	}
 }
 
+// readS3Trailer - reads the trailing checksum header and the trailer
+// signature following the final chunk, of the form
+//     x-amz-checksum-crc32:<base64>\r\n
+//     x-amz-trailer-signature:<signature>\r\n
+//     \r\n
+// and verifies them against the signature chain and the data read.
+func (cr *s3ChunkedReader) readS3Trailer() error {
+	name, checksum, err := readTrailerLine(cr.reader)
+	if err != nil {
+		return err
+	}
+	if name != cr.trailer {
+		return errMalformedEncoding
+	}
+	name, signature, err := readTrailerLine(cr.reader)
+	if err != nil {
+		return err
+	}
+	if name != amzTrailerSignatureStr {
+		return errMalformedEncoding
+	}
+	if err = readCRLF(cr.reader); err != nil {
+		return errMalformedEncoding
+	}
+
+	// The trailing headers are signed as `name:value\n`.
+	hashedTrailer := getSHA256Hash([]byte(cr.trailer + ":" + checksum + "\n"))
+	newSignature := getTrailerSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, hashedTrailer)
+	if !compareSignatureV4(signature, newSignature) {
+		return errSignatureMismatch
+	}
+
+	sum, err := base64.StdEncoding.DecodeString(checksum)
+	if err != nil || len(sum) != cr.checksumHash.Size() {
+		return miniohash.InvalidChecksum{Algorithm: cr.checksumAlgorithm}
+	}
+	if calculated := cr.checksumHash.Sum(nil); !bytes.Equal(sum, calculated) {
+		return miniohash.ChecksumMismatch{
+			Algorithm:          cr.checksumAlgorithm,
+			ExpectedChecksum:   checksum,
+			CalculatedChecksum: base64.StdEncoding.EncodeToString(calculated),
+		}
+	}
+	return nil
+}
+
+// readTrailerLine - reads a `name:value` trailing header line, the name
+// is returned in lower case.
+func readTrailerLine(b *bufio.Reader) (name, value string, err error) {
+	buf, err := b.ReadSlice('\n')
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		} else if err == bufio.ErrBufferFull {
+			err = errLineTooLong
+		}
+		return "", "", err
+	}
+	if len(buf) >= maxLineLength {
+		return "", "", errLineTooLong
+	}
+	line := string(trimTrailingWhitespace(buf))
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return "", "", errMalformedEncoding
+	}
+	return strings.ToLower(strings.TrimSpace(line[:i])), strings.TrimSpace(line[i+1:]), nil
+}
+
 // readCRLF - check if reader only has '\r\n' CRLF character.
 // returns malformed encoding if it doesn't.
 func readCRLF(reader io.Reader) error {
@@ -160,6 +160,37 @@ func TestReadCRLF(t *testing.T) {
 	}
 }
 
+// Test reading a trailing header line.
+func TestReadTrailerLine(t *testing.T) {
+	type testCase struct {
+		reader        *bufio.Reader
+		expectedName  string
+		expectedValue string
+		expectedErr   error
+	}
+	tests := []testCase{
+		// Test - 1 valid trailer line ending with CRLF.
+		{bufio.NewReader(bytes.NewReader([]byte("x-amz-checksum-crc32:AAAAAA==\r\n"))), "x-amz-checksum-crc32", "AAAAAA==", nil},
+		// Test - 2 valid trailer line ending with LF, the name is lower cased.
+		{bufio.NewReader(bytes.NewReader([]byte("X-Amz-Trailer-Signature: abcd\n"))), "x-amz-trailer-signature", "abcd", nil},
+		// Test - 3 trailer line without value.
+		{bufio.NewReader(bytes.NewReader([]byte("x-amz-checksum-crc32\r\n"))), "", "", errMalformedEncoding},
+		// Test - 4 trailer line without line ending.
+		{bufio.NewReader(bytes.NewReader([]byte("x-amz-checksum-crc32:AAAAAA=="))), "", "", io.ErrUnexpectedEOF},
+		// Test - 5 trailer line too long.
+		{bufio.NewReader(bytes.NewReader(append(bytes.Repeat([]byte("a"), maxLineLength), '\n'))), "", "", errLineTooLong},
+	}
+	for i, tt := range tests {
+		name, value, err := readTrailerLine(tt.reader)
+		if err != tt.expectedErr {
+			t.Errorf("Test %d: Expected %s, got %s", i+1, tt.expectedErr, err)
+		}
+		if name != tt.expectedName || value != tt.expectedValue {
+			t.Errorf("Test %d: Expected %s:%s, got %s:%s", i+1, tt.expectedName, tt.expectedValue, name, value)
+		}
+	}
+}
+
 // Tests parsing hex number into its uint64 decimal equivalent.
 func TestParseHexUint(t *testing.T) {
 	type testCase struct {
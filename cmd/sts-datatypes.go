@@ -0,0 +1,114 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+)
+
+// STSCredentials - temporary credentials returned by AssumeRole.
+type STSCredentials struct {
+	AccessKeyID     string    `xml:"AccessKeyId"`
+	SecretAccessKey string    `xml:"SecretAccessKey"`
+	SessionToken    string    `xml:"SessionToken"`
+	Expiration      time.Time `xml:"Expiration"`
+}
+
+// AssumeRoleResponse - response of the AssumeRole API as per
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRole.html
+type AssumeRoleResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleResponse"`
+
+	Result struct {
+		Credentials STSCredentials `xml:"Credentials"`
+	} `xml:"AssumeRoleResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId"`
+	} `xml:"ResponseMetadata"`
+}
+
+// AssumeRoleWithWebIdentityResponse - response of the AssumeRoleWithWebIdentity API as per
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRoleWithWebIdentity.html
+type AssumeRoleWithWebIdentityResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithWebIdentityResponse"`
+
+	Result struct {
+		Credentials                 STSCredentials `xml:"Credentials"`
+		SubjectFromWebIdentityToken string         `xml:"SubjectFromWebIdentityToken"`
+	} `xml:"AssumeRoleWithWebIdentityResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId"`
+	} `xml:"ResponseMetadata"`
+}
+
+// AssumeRoleWithLDAPIdentityResponse - response of the AssumeRoleWithLDAPIdentity
+// API, shaped after the responses of the other STS APIs.
+type AssumeRoleWithLDAPIdentityResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithLDAPIdentityResponse"`
+
+	Result struct {
+		Credentials STSCredentials `xml:"Credentials"`
+	} `xml:"AssumeRoleWithLDAPIdentityResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId"`
+	} `xml:"ResponseMetadata"`
+}
+
+// AssumeRoleWithClientCertificateResponse - response of the
+// AssumeRoleWithClientCertificate API, shaped after the responses of the
+// other STS APIs.
+type AssumeRoleWithClientCertificateResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithClientCertificateResponse"`
+
+	Result struct {
+		Credentials STSCredentials `xml:"Credentials"`
+	} `xml:"AssumeRoleWithClientCertificateResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId"`
+	} `xml:"ResponseMetadata"`
+}
+
+// STSErrorResponse - error response of the STS APIs.
+type STSErrorResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ ErrorResponse"`
+
+	Error struct {
+		Type    string `xml:"Type"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Error"`
+	RequestID string `xml:"RequestId"`
+}
+
+// writeSTSErrorResponse - writes error response in the STS format,
+// errors are either the fault of the sender or of the server.
+func writeSTSErrorResponse(w http.ResponseWriter, errorCode APIErrorCode) {
+	apiError := getAPIError(errorCode)
+
+	var errorResponse STSErrorResponse
+	errorResponse.Error.Type = "Sender"
+	if apiError.HTTPStatusCode >= http.StatusInternalServerError {
+		errorResponse.Error.Type = "Receiver"
+	}
+	errorResponse.Error.Code = apiError.Code
+	errorResponse.Error.Message = apiError.Description
+	errorResponse.RequestID = w.Header().Get(responseRequestIDKey)
+
+	writeResponse(w, apiError.HTTPStatusCode, encodeResponse(errorResponse), mimeXML)
+}
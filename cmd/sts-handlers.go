@@ -0,0 +1,555 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/iam/ldap"
+	"github.com/minio/minio/pkg/iam/validator"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
+)
+
+const (
+	// STS API version.
+	stsAPIVersion = "2011-06-15"
+
+	// STS form parameters.
+	stsAction          = "Action"
+	stsVersion         = "Version"
+	stsDurationSeconds = "DurationSeconds"
+	stsPolicy          = "Policy"
+
+	// AssumeRoleWithWebIdentity form parameter and token claim.
+	stsWebIdentityToken = "WebIdentityToken"
+	stsPolicyClaim      = "policy"
+
+	// AssumeRoleWithLDAPIdentity form parameters.
+	stsLDAPUsername = "LDAPUsername"
+	stsLDAPPassword = "LDAPPassword"
+
+	// Header, or query parameter of presigned requests, carrying the
+	// session token of temporary credentials.
+	amzSecurityToken = "X-Amz-Security-Token"
+
+	// STS actions.
+	assumeRole                 = "AssumeRole"
+	assumeRoleWithWebIdentity  = "AssumeRoleWithWebIdentity"
+	assumeRoleWithLDAPIdentity = "AssumeRoleWithLDAPIdentity"
+	assumeRoleWithClientCert   = "AssumeRoleWithClientCertificate"
+
+	// Default, minimum and maximum validity of temporary credentials.
+	defaultSTSDuration = time.Hour
+	minSTSDuration     = 15 * time.Minute
+	maxSTSDuration     = 12 * time.Hour
+
+	// Maximum size of a session policy.
+	maxSTSPolicySize = 2048
+
+	// Maximum size of the form of an STS request.
+	maxSTSRequestSize = 16 * 1024
+)
+
+// Canned policies the policy claim of a web identity token may name,
+// "readwrite" grants the permissions of the server credential.
+var stsCannedPolicies = map[string][]policy.Statement{
+	"readwrite": nil,
+	"readonly": {
+		newSTSStatement(policy.NewResource("*", ""),
+			policy.GetBucketLocationAction, policy.HeadBucketAction,
+			policy.ListAllMyBucketsAction, policy.ListBucketAction),
+		newSTSStatement(policy.NewResource("*", "*"), policy.GetObjectAction),
+	},
+	"writeonly": {
+		newSTSStatement(policy.NewResource("*", "*"), policy.PutObjectAction),
+	},
+}
+
+// newSTSStatement - returns a statement allowing the actions on the resource.
+func newSTSStatement(resource policy.Resource, actions ...policy.Action) policy.Statement {
+	return policy.NewStatement(
+		policy.Allow,
+		policy.NewPrincipal("*"),
+		policy.NewActionSet(actions...),
+		policy.NewResourceSet(resource),
+		condition.NewFunctions(),
+	)
+}
+
+// getClaimsPolicy - returns the policy of temporary credentials issued
+// for a web identity token, derived from the canned policies named by the
+// policy claim of the token. The claim is either a comma separated list
+// or an array of names, returns false if it names no known policy.
+func getClaimsPolicy(claims map[string]interface{}) (*policy.Policy, bool) {
+	var names []string
+	switch claim := claims[stsPolicyClaim].(type) {
+	case string:
+		names = strings.Split(claim, ",")
+	case []interface{}:
+		for _, name := range claim {
+			if s, ok := name.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+	return getCannedPolicy(names)
+}
+
+// getIdentitiesPolicy - returns the policy of temporary credentials
+// issued for the identities of a user, such as its LDAP groups, derived
+// from the canned policies they are mapped to. Returns false if no
+// identity is mapped.
+func getIdentitiesPolicy(identityPolicies map[string]string, identities []string) (*policy.Policy, bool) {
+	var names []string
+	for _, identity := range identities {
+		if name, ok := identityPolicies[identity]; ok {
+			names = append(names, name)
+		}
+	}
+	return getCannedPolicy(names)
+}
+
+// parseIdentityPolicies - parses the canned policies of identities, a
+// semicolon separated list of identity=policy pairs. Identities such as
+// LDAP groups may be named by their DN, the policy follows the last '='.
+func parseIdentityPolicies(s string) (map[string]string, error) {
+	identityPolicies := make(map[string]string)
+	for _, pair := range strings.Split(s, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("malformed identity policy %q", pair)
+		}
+		identity, name := strings.TrimSpace(pair[:i]), strings.TrimSpace(pair[i+1:])
+		if _, ok := stsCannedPolicies[name]; !ok {
+			return nil, fmt.Errorf("unknown canned policy %q of %q", name, identity)
+		}
+		identityPolicies[identity] = name
+	}
+	return identityPolicies, nil
+}
+
+// getClientCertIdentities - returns the identities a client certificate
+// may be mapped to, its common name and subject alternative names.
+func getClientCertIdentities(cert *x509.Certificate) []string {
+	var identities []string
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	identities = append(identities, cert.DNSNames...)
+	identities = append(identities, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities
+}
+
+// getCannedPolicy - returns the union of the named canned policies, nil if
+// one of them grants full permissions. Returns false if no name is known.
+func getCannedPolicy(names []string) (*policy.Policy, bool) {
+	var statements []policy.Statement
+	for _, name := range names {
+		cannedStatements, ok := stsCannedPolicies[strings.TrimSpace(name)]
+		if !ok {
+			continue
+		}
+		if cannedStatements == nil {
+			return nil, true
+		}
+		statements = append(statements, cannedStatements...)
+	}
+	if len(statements) == 0 {
+		return nil, false
+	}
+
+	return &policy.Policy{
+		Version:    policy.DefaultVersion,
+		Statements: statements,
+	}, true
+}
+
+// stsAPIHandlers implements the STS APIs.
+type stsAPIHandlers struct{}
+
+// registerSTSRouter - registers the STS APIs, STS requests are form
+// posts to the root path without any query.
+func registerSTSRouter(router *mux.Router) {
+	sts := &stsAPIHandlers{}
+
+	router.Methods(http.MethodPost).Path("/").MatcherFunc(func(r *http.Request, rm *mux.RouteMatch) bool {
+		return strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") && r.URL.RawQuery == ""
+	}).HandlerFunc(httpTraceHdrs(sts.STSHandler))
+}
+
+// getSTSDuration - returns the validity of the temporary credentials
+// requested by DurationSeconds, one hour by default.
+func getSTSDuration(form url.Values) (time.Duration, APIErrorCode) {
+	durationStr := form.Get(stsDurationSeconds)
+	if durationStr == "" {
+		return defaultSTSDuration, ErrNone
+	}
+	seconds, err := strconv.ParseInt(durationStr, 10, 64)
+	if err != nil || seconds < int64(minSTSDuration/time.Second) || seconds > int64(maxSTSDuration/time.Second) {
+		return 0, ErrSTSInvalidParameterValue
+	}
+	return time.Duration(seconds) * time.Second, ErrNone
+}
+
+// getSTSSessionPolicy - returns the session policy of the request, which
+// further restricts the temporary credentials, nil if there is none.
+func getSTSSessionPolicy(form url.Values) (*policy.Policy, APIErrorCode) {
+	policyStr := form.Get(stsPolicy)
+	if policyStr == "" {
+		return nil, ErrNone
+	}
+	if len(policyStr) > maxSTSPolicySize {
+		return nil, ErrSTSInvalidParameterValue
+	}
+	sessionPolicy := &policy.Policy{}
+	if err := json.Unmarshal([]byte(policyStr), sessionPolicy); err != nil || sessionPolicy.IsEmpty() {
+		return nil, ErrSTSMalformedPolicyDocument
+	}
+	return sessionPolicy, ErrNone
+}
+
+// newSTSCredentials - issues temporary credentials valid for the given
+// duration, optionally restricted by the policy of the identity they are
+// issued for and by the session policy of the request.
+func newSTSCredentials(ctx context.Context, objectAPI ObjectLayer, duration time.Duration, identityPolicy, sessionPolicy *policy.Policy) (STSCredentials, APIErrorCode) {
+	ta, err := globalIAMSys.NewTempAccount(objectAPI, globalServerConfig.GetCredential().AccessKey, duration, identityPolicy, sessionPolicy)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return STSCredentials{}, toAPIErrorCode(err)
+	}
+
+	// Notify all other Minio peers to load the new temporary account.
+	globalNotificationSys.LoadTempAccount(ctx, ta.Credentials.AccessKey)
+
+	return STSCredentials{
+		AccessKeyID:     ta.Credentials.AccessKey,
+		SecretAccessKey: ta.Credentials.SecretKey,
+		SessionToken:    ta.SessionToken,
+		Expiration:      ta.Expiration,
+	}, ErrNone
+}
+
+// STSHandler - POST /
+// ----------
+// Reads the form of an STS request and serves the requested action,
+// either AssumeRole, AssumeRoleWithWebIdentity, AssumeRoleWithLDAPIdentity
+// or AssumeRoleWithClientCertificate.
+func (sts *stsAPIHandlers) STSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "STS")
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalIAMSys == nil {
+		writeSTSErrorResponse(w, ErrServerNotInitialized)
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxSTSRequestSize+1))
+	if err != nil {
+		writeSTSErrorResponse(w, ErrIncompleteBody)
+		return
+	}
+	if len(body) > maxSTSRequestSize {
+		writeSTSErrorResponse(w, ErrEntityTooLarge)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeSTSErrorResponse(w, ErrSTSInvalidParameterValue)
+		return
+	}
+
+	if form.Get(stsAction) == "" || form.Get(stsVersion) == "" {
+		writeSTSErrorResponse(w, ErrSTSMissingParameter)
+		return
+	}
+	if form.Get(stsVersion) != stsAPIVersion {
+		writeSTSErrorResponse(w, ErrSTSInvalidParameterValue)
+		return
+	}
+
+	switch form.Get(stsAction) {
+	case assumeRole:
+		sts.assumeRole(ctx, w, r, objectAPI, body, form)
+	case assumeRoleWithWebIdentity:
+		sts.assumeRoleWithWebIdentity(ctx, w, objectAPI, form)
+	case assumeRoleWithLDAPIdentity:
+		sts.assumeRoleWithLDAPIdentity(ctx, w, objectAPI, form)
+	case assumeRoleWithClientCert:
+		sts.assumeRoleWithClientCertificate(ctx, w, r, objectAPI, form)
+	default:
+		writeSTSErrorResponse(w, ErrSTSInvalidAction)
+	}
+}
+
+// assumeRole - issues temporary credentials for the server credential as per
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRole.html
+// The request must be signed for the "sts" service, the credentials are
+// valid for DurationSeconds and optionally restricted by the session
+// policy of the request.
+func (sts *stsAPIHandlers) assumeRole(ctx context.Context, w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer, body []byte, form url.Values) {
+	if !isRequestSignatureV4(r) {
+		writeSTSErrorResponse(w, ErrSignatureVersionNotSupported)
+		return
+	}
+
+	// The form is part of the signature, unless the client chose to
+	// leave the payload unsigned.
+	hashedPayload := getSHA256Hash(body)
+	if sha256sum := r.Header.Get("X-Amz-Content-Sha256"); sha256sum == unsignedPayload {
+		hashedPayload = unsignedPayload
+	} else if sha256sum != "" && sha256sum != hashedPayload {
+		writeSTSErrorResponse(w, ErrContentSHA256Mismatch)
+		return
+	}
+	if s3Error := doesSignatureMatch(hashedPayload, r, globalServerConfig.GetRegion(), serviceSTS); s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	// Only the server credential may assume a role, temporary
+	// credentials and service accounts can't.
+	signV4Values, _ := parseSignV4(r.Header.Get("Authorization"), "", serviceSTS)
+	if signV4Values.Credential.accessKey != globalServerConfig.GetCredential().AccessKey {
+		writeSTSErrorResponse(w, ErrAccessDenied)
+		return
+	}
+
+	duration, s3Error := getSTSDuration(form)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	sessionPolicy, s3Error := getSTSSessionPolicy(form)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	credentials, s3Error := newSTSCredentials(ctx, objectAPI, duration, nil, sessionPolicy)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	var response AssumeRoleResponse
+	response.Result.Credentials = credentials
+	response.ResponseMetadata.RequestID = w.Header().Get(responseRequestIDKey)
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+// assumeRoleWithWebIdentity - issues temporary credentials for the
+// subject of a web identity token as per
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRoleWithWebIdentity.html
+// The request is not signed, the token must be signed by the configured
+// OpenID Connect provider and its policy claim determines the policy of
+// the credentials, which the session policy of the request may restrict.
+func (sts *stsAPIHandlers) assumeRoleWithWebIdentity(ctx context.Context, w http.ResponseWriter, objectAPI ObjectLayer, form url.Values) {
+	if globalOpenIDValidator == nil {
+		writeSTSErrorResponse(w, ErrSTSNotInitialized)
+		return
+	}
+
+	token := form.Get(stsWebIdentityToken)
+	if token == "" {
+		writeSTSErrorResponse(w, ErrSTSMissingParameter)
+		return
+	}
+
+	duration, s3Error := getSTSDuration(form)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	sessionPolicy, s3Error := getSTSSessionPolicy(form)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	claims, err := globalOpenIDValidator.Validate(token)
+	if err != nil {
+		if err == validator.ErrTokenExpired {
+			writeSTSErrorResponse(w, ErrExpiredToken)
+			return
+		}
+		writeSTSErrorResponse(w, ErrSTSInvalidIdentityToken)
+		return
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		writeSTSErrorResponse(w, ErrSTSInvalidIdentityToken)
+		return
+	}
+
+	identityPolicy, ok := getClaimsPolicy(claims)
+	if !ok {
+		writeSTSErrorResponse(w, ErrAccessDenied)
+		return
+	}
+
+	credentials, s3Error := newSTSCredentials(ctx, objectAPI, duration, identityPolicy, sessionPolicy)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	var response AssumeRoleWithWebIdentityResponse
+	response.Result.Credentials = credentials
+	response.Result.SubjectFromWebIdentityToken = subject
+	response.ResponseMetadata.RequestID = w.Header().Get(responseRequestIDKey)
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+// assumeRoleWithLDAPIdentity - issues temporary credentials for a user of
+// the configured LDAP server. The request is not signed, the user is
+// authenticated by binding with its username and password and the canned
+// policies its groups are mapped to determine the policy of the
+// credentials.
+func (sts *stsAPIHandlers) assumeRoleWithLDAPIdentity(ctx context.Context, w http.ResponseWriter, objectAPI ObjectLayer, form url.Values) {
+	if globalLDAPConfig == nil {
+		writeSTSErrorResponse(w, ErrSTSNotInitialized)
+		return
+	}
+
+	username, password := form.Get(stsLDAPUsername), form.Get(stsLDAPPassword)
+	if username == "" || password == "" {
+		writeSTSErrorResponse(w, ErrSTSMissingParameter)
+		return
+	}
+
+	duration, s3Error := getSTSDuration(form)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	sessionPolicy, s3Error := getSTSSessionPolicy(form)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	_, groups, err := globalLDAPConfig.Authenticate(username, password)
+	if err != nil {
+		if ldap.IsInvalidCredentials(err) {
+			writeSTSErrorResponse(w, ErrAccessDenied)
+			return
+		}
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, ErrInternalError)
+		return
+	}
+
+	identityPolicy, ok := getIdentitiesPolicy(globalLDAPGroupPolicies, groups)
+	if !ok {
+		writeSTSErrorResponse(w, ErrAccessDenied)
+		return
+	}
+
+	credentials, s3Error := newSTSCredentials(ctx, objectAPI, duration, identityPolicy, sessionPolicy)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	var response AssumeRoleWithLDAPIdentityResponse
+	response.Result.Credentials = credentials
+	response.ResponseMetadata.RequestID = w.Header().Get(responseRequestIDKey)
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+// assumeRoleWithClientCertificate - issues temporary credentials for a
+// client authenticated by a TLS client certificate signed by the
+// configured CAs. The canned policies the common name and subject
+// alternative names of the certificate are mapped to determine the policy
+// of the credentials, which expire no later than the certificate.
+func (sts *stsAPIHandlers) assumeRoleWithClientCertificate(ctx context.Context, w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer, form url.Values) {
+	if globalClientCertCAs == nil {
+		writeSTSErrorResponse(w, ErrSTSNotInitialized)
+		return
+	}
+
+	// The certificate is verified during the TLS handshake.
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.PeerCertificates) == 0 {
+		writeSTSErrorResponse(w, ErrAccessDenied)
+		return
+	}
+	cert := r.TLS.PeerCertificates[0]
+
+	duration, s3Error := getSTSDuration(form)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	sessionPolicy, s3Error := getSTSSessionPolicy(form)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+	if validity := cert.NotAfter.Sub(UTCNow()); validity < duration {
+		duration = validity
+	}
+	if duration <= 0 {
+		writeSTSErrorResponse(w, ErrAccessDenied)
+		return
+	}
+
+	identityPolicy, ok := getIdentitiesPolicy(globalClientCertPolicies, getClientCertIdentities(cert))
+	if !ok {
+		writeSTSErrorResponse(w, ErrAccessDenied)
+		return
+	}
+
+	credentials, s3Error := newSTSCredentials(ctx, objectAPI, duration, identityPolicy, sessionPolicy)
+	if s3Error != ErrNone {
+		writeSTSErrorResponse(w, s3Error)
+		return
+	}
+
+	var response AssumeRoleWithClientCertificateResponse
+	response.Result.Credentials = credentials
+	response.ResponseMetadata.RequestID = w.Header().Get(responseRequestIDKey)
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
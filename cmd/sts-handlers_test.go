@@ -0,0 +1,608 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/iam/ldap"
+	"github.com/minio/minio/pkg/iam/validator"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// Wrapper for calling STS AssumeRole handler tests for both XL multiple disks and single node setup.
+func TestSTSAssumeRoleHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testSTSAssumeRoleHandler, []string{"PutObject", "GetObject"})
+}
+
+func testSTSAssumeRoleHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalIAMSys = NewIAMSys()
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+
+	stsRouter := mux.NewRouter()
+	registerSTSRouter(stsRouter)
+
+	serveSTS := func(form url.Values, accessKey, secretKey, sessionToken string, stype serviceType) *httptest.ResponseRecorder {
+		data := []byte(form.Encode())
+		req, err := newTestRequest("POST", "http://127.0.0.1:9000/", int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if sessionToken != "" {
+			req.Header.Set(amzSecurityToken, sessionToken)
+		}
+		if err = signRequestV4ForService(req, accessKey, secretKey, stype); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		stsRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	newForm := func(action, version, duration, policy string) url.Values {
+		form := url.Values{}
+		form.Set(stsAction, action)
+		form.Set(stsVersion, version)
+		if duration != "" {
+			form.Set(stsDurationSeconds, duration)
+		}
+		if policy != "" {
+			form.Set(stsPolicy, policy)
+		}
+		return form
+	}
+
+	getObjectPolicy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName)
+	testCases := []struct {
+		form         url.Values
+		stype        serviceType
+		expectedCode int
+		expectedErr  string
+	}{
+		{newForm(assumeRole, stsAPIVersion, "", ""), serviceS3, http.StatusBadRequest, "AuthorizationQueryParametersError"},
+		{newForm("", stsAPIVersion, "", ""), serviceSTS, http.StatusBadRequest, "MissingParameter"},
+		{newForm("GetCallerIdentity", stsAPIVersion, "", ""), serviceSTS, http.StatusBadRequest, "InvalidAction"},
+		{newForm(assumeRole, "2010-01-01", "", ""), serviceSTS, http.StatusBadRequest, "InvalidParameterValue"},
+		{newForm(assumeRole, stsAPIVersion, "60", ""), serviceSTS, http.StatusBadRequest, "InvalidParameterValue"},
+		{newForm(assumeRole, stsAPIVersion, "86400", ""), serviceSTS, http.StatusBadRequest, "InvalidParameterValue"},
+		{newForm(assumeRole, stsAPIVersion, "", `{"Version":"2012-10-17"`), serviceSTS, http.StatusBadRequest, "MalformedPolicyDocument"},
+		{newForm(assumeRole, stsAPIVersion, "900", getObjectPolicy), serviceSTS, http.StatusOK, ""},
+	}
+	for i, testCase := range testCases {
+		rec := serveSTS(testCase.form, credentials.AccessKey, credentials.SecretKey, "", testCase.stype)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if testCase.expectedErr != "" && !strings.Contains(rec.Body.String(), "<Code>"+testCase.expectedErr+"</Code>") {
+			t.Errorf("%s: Test %d: Expected the error %s, but instead found %s", instanceType, i+1, testCase.expectedErr, rec.Body.String())
+		}
+	}
+
+	rec := serveSTS(newForm(assumeRole, stsAPIVersion, "900", getObjectPolicy), credentials.AccessKey, credentials.SecretKey, "", serviceSTS)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d` %s", instanceType, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response AssumeRoleResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("%s: Failed to parse AssumeRole response: <ERROR> %v", instanceType, err)
+	}
+	tempCred := response.Result.Credentials
+	if tempCred.AccessKeyID == "" || tempCred.SecretAccessKey == "" || tempCred.SessionToken == "" {
+		t.Fatalf("%s: Unexpected temporary credentials %v", instanceType, tempCred)
+	}
+	if validity := tempCred.Expiration.Sub(UTCNow()); validity <= 0 || validity > 900*time.Second {
+		t.Fatalf("%s: Unexpected expiration %v", instanceType, tempCred.Expiration)
+	}
+
+	// Temporary credentials can't assume a role themselves.
+	rec = serveSTS(newForm(assumeRole, stsAPIVersion, "", ""), tempCred.AccessKeyID, tempCred.SecretAccessKey, tempCred.SessionToken, serviceSTS)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+
+	data := []byte("hello")
+	if _, err := obj.PutObject(context.Background(), bucketName, "object", mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	handler := setAuthHandler(apiRouter)
+	serve := func(method, urlStr, accessKey, secretKey, sessionToken string) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		if sessionToken != "" {
+			req.Header.Set(amzSecurityToken, sessionToken)
+		}
+		if err = signRequestV4(req, accessKey, secretKey); err != nil {
+			t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	objectURL := getGetObjectURL("", bucketName, "object")
+	s3Cases := []struct {
+		method       string
+		accessKey    string
+		secretKey    string
+		sessionToken string
+		expectedCode int
+		expectedErr  string
+	}{
+		{"GET", tempCred.AccessKeyID, tempCred.SecretAccessKey, tempCred.SessionToken, http.StatusOK, ""},
+		{"GET", tempCred.AccessKeyID, tempCred.SecretAccessKey, "", http.StatusBadRequest, "InvalidToken"},
+		{"GET", tempCred.AccessKeyID, tempCred.SecretAccessKey, "invalid-token", http.StatusBadRequest, "InvalidToken"},
+		{"GET", credentials.AccessKey, credentials.SecretKey, tempCred.SessionToken, http.StatusBadRequest, "InvalidToken"},
+		// The session policy only allows to get objects.
+		{"PUT", tempCred.AccessKeyID, tempCred.SecretAccessKey, tempCred.SessionToken, http.StatusForbidden, "AccessDenied"},
+	}
+	for i, testCase := range s3Cases {
+		rec = serve(testCase.method, objectURL, testCase.accessKey, testCase.secretKey, testCase.sessionToken)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if testCase.expectedErr != "" && !strings.Contains(rec.Body.String(), "<Code>"+testCase.expectedErr+"</Code>") {
+			t.Errorf("%s: Test %d: Expected the error %s, but instead found %s", instanceType, i+1, testCase.expectedErr, rec.Body.String())
+		}
+	}
+
+	// Expired temporary credentials are rejected.
+	ta := globalIAMSys.tempAccounts[tempCred.AccessKeyID]
+	ta.Expiration = UTCNow().Add(-time.Minute)
+	globalIAMSys.tempAccounts[tempCred.AccessKeyID] = ta
+	rec = serve("GET", objectURL, tempCred.AccessKeyID, tempCred.SecretAccessKey, tempCred.SessionToken)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "<Code>ExpiredToken</Code>") {
+		t.Errorf("%s: Unexpected response `%d` %s", instanceType, rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetClaimsPolicy(t *testing.T) {
+	testCases := []struct {
+		claims           map[string]interface{}
+		expectedOk       bool
+		expectedPolicy   bool
+		expectedGet      bool
+		expectedPut      bool
+		expectedNumStmts int
+	}{
+		{map[string]interface{}{}, false, false, false, false, 0},
+		{map[string]interface{}{"policy": "unknown"}, false, false, false, false, 0},
+		{map[string]interface{}{"policy": "readwrite"}, true, false, true, true, 0},
+		{map[string]interface{}{"policy": "readonly"}, true, true, true, false, 2},
+		{map[string]interface{}{"policy": "writeonly, unknown"}, true, true, false, true, 1},
+		{map[string]interface{}{"policy": []interface{}{"readonly", "writeonly"}}, true, true, true, true, 3},
+		{map[string]interface{}{"policy": []interface{}{"readonly", "readwrite"}}, true, false, true, true, 0},
+	}
+
+	for i, testCase := range testCases {
+		sessionPolicy, ok := getClaimsPolicy(testCase.claims)
+		if ok != testCase.expectedOk {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedOk, ok)
+		}
+		if (sessionPolicy != nil) != testCase.expectedPolicy {
+			t.Fatalf("case %v: unexpected policy %v", i+1, sessionPolicy)
+		}
+		if sessionPolicy == nil {
+			continue
+		}
+		if len(sessionPolicy.Statements) != testCase.expectedNumStmts {
+			t.Fatalf("case %v: expected %v statements, got %v", i+1, testCase.expectedNumStmts, len(sessionPolicy.Statements))
+		}
+		if err := sessionPolicy.Validate(""); err != nil {
+			t.Fatalf("case %v: invalid policy, %s", i+1, err)
+		}
+		getArgs := policy.Args{Action: policy.GetObjectAction, BucketName: "mybucket", ObjectName: "object"}
+		if allowed := sessionPolicy.IsAllowed(getArgs); allowed != testCase.expectedGet {
+			t.Fatalf("case %v: expected GetObject allowed: %v, got: %v", i+1, testCase.expectedGet, allowed)
+		}
+		putArgs := policy.Args{Action: policy.PutObjectAction, BucketName: "mybucket", ObjectName: "object"}
+		if allowed := sessionPolicy.IsAllowed(putArgs); allowed != testCase.expectedPut {
+			t.Fatalf("case %v: expected PutObject allowed: %v, got: %v", i+1, testCase.expectedPut, allowed)
+		}
+	}
+}
+
+// Wrapper for calling STS AssumeRoleWithWebIdentity handler tests for both XL multiple disks and single node setup.
+func TestSTSAssumeRoleWithWebIdentityHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testSTSAssumeRoleWithWebIdentityHandler, []string{"PutObject", "GetObject"})
+}
+
+func testSTSAssumeRoleWithWebIdentityHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	globalIAMSys = NewIAMSys()
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+
+	stsRouter := mux.NewRouter()
+	registerSTSRouter(stsRouter)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	jwks := validator.JWKS{Keys: []validator.JWK{{
+		Kty: "RSA",
+		Kid: "key",
+		N:   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+	}}}
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jwks)
+	}))
+	defer jwksServer.Close()
+
+	newToken := func(claims jwtgo.MapClaims) string {
+		token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, claims)
+		token.Header["kid"] = "key"
+		s, err := token.SignedString(rsaKey)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		return s
+	}
+	serveSTS := func(token, sessionPolicy string) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Set(stsAction, assumeRoleWithWebIdentity)
+		form.Set(stsVersion, stsAPIVersion)
+		if token != "" {
+			form.Set(stsWebIdentityToken, token)
+		}
+		if sessionPolicy != "" {
+			form.Set(stsPolicy, sessionPolicy)
+		}
+		data := []byte(form.Encode())
+		req, err := newTestRequest("POST", "http://127.0.0.1:9000/", int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		stsRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	exp := UTCNow().Add(time.Hour).Unix()
+	readOnlyToken := newToken(jwtgo.MapClaims{"sub": "user", "aud": "minio", "exp": exp, "policy": "readonly"})
+
+	// Web identities are only accepted once an OpenID Connect
+	// provider is configured.
+	globalOpenIDValidator = nil
+	if rec := serveSTS(readOnlyToken, ""); rec.Code != http.StatusNotImplemented {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotImplemented, rec.Code)
+	}
+	globalOpenIDValidator, err = validator.NewJWT(jwksServer.URL, "minio", nil)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	defer func() { globalOpenIDValidator = nil }()
+
+	testCases := []struct {
+		token        string
+		expectedCode int
+		expectedErr  string
+	}{
+		{"", http.StatusBadRequest, "MissingParameter"},
+		{"not-a-token", http.StatusBadRequest, "InvalidIdentityToken"},
+		{newToken(jwtgo.MapClaims{"sub": "user", "aud": "other", "exp": exp, "policy": "readonly"}), http.StatusBadRequest, "InvalidIdentityToken"},
+		{newToken(jwtgo.MapClaims{"sub": "user", "aud": "minio", "exp": UTCNow().Add(-time.Hour).Unix(), "policy": "readonly"}), http.StatusBadRequest, "ExpiredToken"},
+		{newToken(jwtgo.MapClaims{"aud": "minio", "exp": exp, "policy": "readonly"}), http.StatusBadRequest, "InvalidIdentityToken"},
+		{newToken(jwtgo.MapClaims{"sub": "user", "aud": "minio", "exp": exp}), http.StatusForbidden, "AccessDenied"},
+		{readOnlyToken, http.StatusOK, ""},
+	}
+	for i, testCase := range testCases {
+		rec := serveSTS(testCase.token, "")
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if testCase.expectedErr != "" && !strings.Contains(rec.Body.String(), "<Code>"+testCase.expectedErr+"</Code>") {
+			t.Errorf("%s: Test %d: Expected the error %s, but instead found %s", instanceType, i+1, testCase.expectedErr, rec.Body.String())
+		}
+	}
+
+	data := []byte("hello")
+	if _, err = obj.PutObject(context.Background(), bucketName, "object", mustGetHashReader(t, bytes.NewReader(data), int64(len(data)), "", ""), nil); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	// The temporary credentials are restricted to the readonly policy,
+	// and further to the session policy of the request.
+	otherObjectPolicy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject","s3:PutObject"],"Resource":["arn:aws:s3:::%s/other"]}]}`, bucketName)
+	handler := setAuthHandler(apiRouter)
+	for _, testCase := range []struct {
+		sessionPolicy string
+		expectedGet   int
+		expectedPut   int
+	}{
+		{"", http.StatusOK, http.StatusForbidden},
+		{otherObjectPolicy, http.StatusForbidden, http.StatusForbidden},
+	} {
+		rec := serveSTS(readOnlyToken, testCase.sessionPolicy)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d` %s", instanceType, http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response AssumeRoleWithWebIdentityResponse
+		if err = xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%s: Failed to parse AssumeRoleWithWebIdentity response: <ERROR> %v", instanceType, err)
+		}
+		tempCred := response.Result.Credentials
+		if response.Result.SubjectFromWebIdentityToken != "user" || tempCred.SessionToken == "" {
+			t.Fatalf("%s: Unexpected response %v", instanceType, response)
+		}
+
+		for method, expectedCode := range map[string]int{"GET": testCase.expectedGet, "PUT": testCase.expectedPut} {
+			req, err := newTestRequest(method, getGetObjectURL("", bucketName, "object"), int64(len(data)), bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+			}
+			req.Header.Set(amzSecurityToken, tempCred.SessionToken)
+			if err = signRequestV4(req, tempCred.AccessKeyID, tempCred.SecretAccessKey); err != nil {
+				t.Fatalf("%s: Failed to sign HTTP request: <ERROR> %v", instanceType, err)
+			}
+			rec = httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != expectedCode {
+				t.Errorf("%s: %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, method, expectedCode, rec.Code)
+			}
+		}
+	}
+
+	// Malformed session policies are rejected.
+	if rec := serveSTS(readOnlyToken, `{"Version":"2012-10-17"`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestParseIdentityPolicies(t *testing.T) {
+	testCases := []struct {
+		policies         string
+		expectedPolicies map[string]string
+		expectErr        bool
+	}{
+		{"", map[string]string{}, false},
+		{"admins=readwrite; developers=readonly;", map[string]string{"admins": "readwrite", "developers": "readonly"}, false},
+		{"cn=auditors,ou=groups,dc=example=readonly", map[string]string{"cn=auditors,ou=groups,dc=example": "readonly"}, false},
+		{"admins", nil, true},
+		{"=readonly", nil, true},
+		{"admins=unknown", nil, true},
+	}
+
+	for i, testCase := range testCases {
+		policies, err := parseIdentityPolicies(testCase.policies)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if !reflect.DeepEqual(policies, testCase.expectedPolicies) && !testCase.expectErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedPolicies, policies)
+		}
+	}
+
+	identityPolicies := map[string]string{"admins": "readwrite", "developers": "readonly"}
+	if _, ok := getIdentitiesPolicy(identityPolicies, []string{"users"}); ok {
+		t.Fatalf("expected no policy for unmapped identities")
+	}
+	if sessionPolicy, ok := getIdentitiesPolicy(identityPolicies, []string{"users", "developers"}); !ok || sessionPolicy == nil {
+		t.Fatalf("expected the readonly policy, got: %v", sessionPolicy)
+	}
+	if sessionPolicy, ok := getIdentitiesPolicy(identityPolicies, []string{"developers", "admins"}); !ok || sessionPolicy != nil {
+		t.Fatalf("expected full permissions, got: %v", sessionPolicy)
+	}
+}
+
+// Wrapper for calling STS AssumeRoleWithLDAPIdentity handler tests for both XL multiple disks and single node setup.
+func TestSTSAssumeRoleWithLDAPIdentityHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testSTSAssumeRoleWithLDAPIdentityHandler, []string{"GetObject"})
+}
+
+func testSTSAssumeRoleWithLDAPIdentityHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalIAMSys = NewIAMSys()
+
+	stsRouter := mux.NewRouter()
+	registerSTSRouter(stsRouter)
+
+	serveSTS := func(username, password string) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Set(stsAction, assumeRoleWithLDAPIdentity)
+		form.Set(stsVersion, stsAPIVersion)
+		form.Set(stsLDAPUsername, username)
+		form.Set(stsLDAPPassword, password)
+		data := []byte(form.Encode())
+		req, err := newTestRequest("POST", "http://127.0.0.1:9000/", int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		stsRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	globalLDAPConfig = nil
+	if rec := serveSTS("alice", "secret"); rec.Code != http.StatusNotImplemented {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotImplemented, rec.Code)
+	}
+
+	// No LDAP server listens at the address.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	listener.Close()
+	globalLDAPConfig = &ldap.Config{ServerAddr: listener.Addr().String(), UsernameFormat: "uid=%s,dc=example"}
+	defer func() { globalLDAPConfig = nil }()
+
+	testCases := []struct {
+		username     string
+		password     string
+		expectedCode int
+		expectedErr  string
+	}{
+		{"", "secret", http.StatusBadRequest, "MissingParameter"},
+		{"alice", "", http.StatusBadRequest, "MissingParameter"},
+		{"alice", "secret", http.StatusInternalServerError, "InternalError"},
+	}
+	for i, testCase := range testCases {
+		rec := serveSTS(testCase.username, testCase.password)
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedCode, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), "<Code>"+testCase.expectedErr+"</Code>") {
+			t.Errorf("%s: Test %d: Expected the error %s, but instead found %s", instanceType, i+1, testCase.expectedErr, rec.Body.String())
+		}
+	}
+}
+
+// Wrapper for calling STS AssumeRoleWithClientCertificate handler tests for both XL multiple disks and single node setup.
+func TestSTSAssumeRoleWithClientCertificateHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testSTSAssumeRoleWithClientCertificateHandler, []string{"GetObject"})
+}
+
+func testSTSAssumeRoleWithClientCertificateHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalIAMSys = NewIAMSys()
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+
+	stsRouter := mux.NewRouter()
+	registerSTSRouter(stsRouter)
+
+	newCert := func(template *x509.Certificate) *x509.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		return cert
+	}
+	notAfter := UTCNow().Add(30 * time.Minute).Truncate(time.Second)
+	newClientCert := func(commonName string, dnsNames ...string) *x509.Certificate {
+		return newCert(&x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: commonName},
+			DNSNames:     dnsNames,
+			NotBefore:    UTCNow().Add(-time.Hour),
+			NotAfter:     notAfter,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+	}
+
+	serveSTS := func(state *tls.ConnectionState) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Set(stsAction, assumeRoleWithClientCert)
+		form.Set(stsVersion, stsAPIVersion)
+		data := []byte(form.Encode())
+		req, err := newTestRequest("POST", "http://127.0.0.1:9000/", int64(len(data)), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.TLS = state
+		rec := httptest.NewRecorder()
+		stsRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	verified := func(cert *x509.Certificate) *tls.ConnectionState {
+		return &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert}},
+		}
+	}
+
+	appCert := newClientCert("app", "app.example.com")
+	globalClientCertCAs = nil
+	if rec := serveSTS(verified(appCert)); rec.Code != http.StatusNotImplemented {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotImplemented, rec.Code)
+	}
+
+	globalClientCertCAs = x509.NewCertPool()
+	globalClientCertPolicies = map[string]string{"app.example.com": "readonly"}
+	defer func() {
+		globalClientCertCAs = nil
+		globalClientCertPolicies = nil
+	}()
+
+	testCases := []struct {
+		state        *tls.ConnectionState
+		expectedCode int
+	}{
+		// Plain HTTP.
+		{nil, http.StatusForbidden},
+		// No client certificate.
+		{&tls.ConnectionState{}, http.StatusForbidden},
+		// Unverified client certificate.
+		{&tls.ConnectionState{PeerCertificates: []*x509.Certificate{appCert}}, http.StatusForbidden},
+		// Unmapped client certificate.
+		{verified(newClientCert("other", "other.example.com")), http.StatusForbidden},
+		{verified(appCert), http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := serveSTS(testCase.state)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("%s: Test %d: Expected the response status to be `%d`, but instead found `%d` %s", instanceType, i+1, testCase.expectedCode, rec.Code, rec.Body.String())
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var response AssumeRoleWithClientCertificateResponse
+		if err := xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%s: Test %d: Failed to parse response: <ERROR> %v", instanceType, i+1, err)
+		}
+		// The credentials expire along with the certificate.
+		if expiration := response.Result.Credentials.Expiration; expiration.After(notAfter) {
+			t.Fatalf("%s: Test %d: Expected the credentials to expire by %v, but instead found %v", instanceType, i+1, notAfter, expiration)
+		}
+		ta, ok := globalIAMSys.GetTempAccount(response.Result.Credentials.AccessKeyID)
+		if !ok || ta.Policy == nil {
+			t.Fatalf("%s: Test %d: Expected temporary credentials with the readonly policy", instanceType, i+1)
+		}
+	}
+}
@@ -359,6 +359,18 @@ func UnstartedTestServer(t TestErrHandler, instanceType string) TestServer {
 	// Create new policy system.
 	globalPolicySys = NewPolicySys()
 
+	// Create new bucket quota system.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	// Create new remote targets system.
+	globalBucketTargetSys = NewBucketTargetSys()
+
+	// Create new bucket metadata system.
+	globalBucketMetadataSys = NewBucketMetadataSys()
+
+	// Create new tiers system.
+	globalTierConfigSys = NewTierConfigSys()
+
 	return testServer
 }
 
@@ -937,6 +949,61 @@ func newTestStreamingSignedRequest(method, urlStr string, contentLength, chunkSi
 	return req, err
 }
 
+// Returns new HTTP request object signed with streaming signature v4,
+// the trailing header `trailer` with value `checksum` follows the final chunk.
+func newTestStreamingSignedTrailerRequest(method, urlStr string, contentLength, chunkSize int64, body io.ReadSeeker, accessKey, secretKey, trailer, checksum string) (*http.Request, error) {
+	req, err := newTestStreamingRequest(method, urlStr, contentLength, chunkSize, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-amz-content-sha256", "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER")
+	req.Header.Set("x-amz-trailer", trailer)
+
+	currTime := UTCNow()
+	signature, err := signStreamingRequest(req, accessKey, secretKey, currTime)
+	if err != nil {
+		return nil, err
+	}
+	if req, err = assembleStreamingChunks(req, body, chunkSize, secretKey, signature, currTime); err != nil {
+		return nil, err
+	}
+
+	// The trailing headers replace the CRLF of the final chunk and
+	// are signed with the signature of the final chunk as seed.
+	stream, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	stream = stream[:len(stream)-2]
+	signature = string(stream[len(stream)-66 : len(stream)-2])
+
+	regionStr := globalServerConfig.GetRegion()
+	scope := strings.Join([]string{
+		currTime.Format(yyyymmdd),
+		regionStr,
+		"s3",
+		"aws4_request",
+	}, "/")
+
+	stringToSign := "AWS4-HMAC-SHA256-TRAILER" + "\n"
+	stringToSign = stringToSign + currTime.Format(iso8601Format) + "\n"
+	stringToSign = stringToSign + scope + "\n"
+	stringToSign = stringToSign + signature + "\n"
+	stringToSign = stringToSign + getSHA256Hash([]byte(trailer+":"+checksum+"\n"))
+
+	date := sumHMAC([]byte("AWS4"+secretKey), []byte(currTime.Format(yyyymmdd)))
+	region := sumHMAC(date, []byte(regionStr))
+	service := sumHMAC(region, []byte("s3"))
+	signingKey := sumHMAC(service, []byte("aws4_request"))
+
+	signature = hex.EncodeToString(sumHMAC(signingKey, []byte(stringToSign)))
+
+	stream = append(stream, []byte(trailer+":"+checksum+"\r\n")...)
+	stream = append(stream, []byte("x-amz-trailer-signature:"+signature+"\r\n\r\n")...)
+	req.Body = ioutil.NopCloser(bytes.NewReader(stream))
+	return req, nil
+}
+
 // preSignV4 presign the request, in accordance with
 // http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html.
 func preSignV4(req *http.Request, accessKeyID, secretAccessKey string, expires int64) error {
@@ -966,7 +1033,7 @@ func preSignV4(req *http.Request, accessKeyID, secretAccessKey string, expires i
 	queryStr := strings.Replace(query.Encode(), "+", "%20", -1)
 	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, req.URL.Path, req.Method)
 	stringToSign := getStringToSign(canonicalRequest, date, scope)
-	signingKey := getSigningKey(secretAccessKey, date, region)
+	signingKey := getSigningKey(secretAccessKey, date, region, serviceS3)
 	signature := getSignature(signingKey, stringToSign)
 
 	req.URL.RawQuery = query.Encode()
@@ -1046,6 +1113,11 @@ func signRequestV2(req *http.Request, accessKey, secretKey string) error {
 
 // Sign given request using Signature V4.
 func signRequestV4(req *http.Request, accessKey, secretKey string) error {
+	return signRequestV4ForService(req, accessKey, secretKey, serviceS3)
+}
+
+// Sign given request using Signature V4 for the given service.
+func signRequestV4ForService(req *http.Request, accessKey, secretKey string, stype serviceType) error {
 	// Get hashed payload.
 	hashedPayload := req.Header.Get("x-amz-content-sha256")
 	if hashedPayload == "" {
@@ -1127,7 +1199,7 @@ func signRequestV4(req *http.Request, accessKey, secretKey string) error {
 	scope := strings.Join([]string{
 		currTime.Format(yyyymmdd),
 		region,
-		"s3",
+		string(stype),
 		"aws4_request",
 	}, "/")
 
@@ -1137,7 +1209,7 @@ func signRequestV4(req *http.Request, accessKey, secretKey string) error {
 
 	date := sumHMAC([]byte("AWS4"+secretKey), []byte(currTime.Format(yyyymmdd)))
 	regionHMAC := sumHMAC(date, []byte(region))
-	service := sumHMAC(regionHMAC, []byte("s3"))
+	service := sumHMAC(regionHMAC, []byte(stype))
 	signingKey := sumHMAC(service, []byte("aws4_request"))
 
 	signature := hex.EncodeToString(sumHMAC(signingKey, []byte(stringToSign)))
@@ -1523,6 +1595,162 @@ func getPutPolicyURL(endPoint, bucketName string) string {
 	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
 }
 
+// return URL for the versioning configuration of a bucket.
+func getBucketVersioningURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("versioning", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for restoring a transitioned object.
+func getRestoreObjectURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("restore", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for the CORS configuration of a bucket.
+func getBucketCorsURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("cors", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the website configuration of a bucket.
+func getBucketWebsiteURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("website", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the logging status of a bucket.
+func getBucketLoggingURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("logging", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for an inventory configuration of a bucket.
+func getBucketInventoryURL(endPoint, bucketName, id string) string {
+	queryValue := url.Values{}
+	queryValue.Set("inventory", "")
+	queryValue.Set("id", id)
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for listing the inventory configurations of a bucket.
+func getListBucketInventoryURL(endPoint, bucketName, continuationToken string) string {
+	queryValue := url.Values{}
+	queryValue.Set("inventory", "")
+	if continuationToken != "" {
+		queryValue.Set("continuation-token", continuationToken)
+	}
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the encryption configuration of a bucket.
+func getBucketEncryptionURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("encryption", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the lifecycle configuration of a bucket.
+func getBucketLifecycleURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("lifecycle", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the replication configuration of a bucket.
+func getBucketReplicationURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("replication", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the tags of a bucket.
+func getBucketTaggingURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("tagging", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for listing the versions of the objects of a bucket.
+func getListObjectVersionsURL(endPoint, bucketName, prefix, keyMarker, versionIDMarker, maxKeys string) string {
+	queryValue := url.Values{}
+	queryValue.Set("versions", "")
+	queryValue.Set("prefix", prefix)
+	queryValue.Set("key-marker", keyMarker)
+	queryValue.Set("version-id-marker", versionIDMarker)
+	queryValue.Set("max-keys", maxKeys)
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the retention of an object.
+func getObjectRetentionURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("retention", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for the object lock configuration of a bucket.
+func getBucketObjectLockConfigURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("object-lock", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the legal hold of an object.
+func getObjectLegalHoldURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("legal-hold", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for the tags of an object.
+func getObjectTaggingURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("tagging", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for getting the attributes of an object.
+func getObjectAttributesURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("attributes", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for composing an object.
+func getComposeObjectURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("compose", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for getting and setting the ACL of an object.
+func getObjectACLURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("acl", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for select object content.
+func getSelectObjectContentURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("select", "")
+	queryValue.Set("select-type", "2")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for a version of an object.
+func getObjectVersionURL(endPoint, bucketName, objectName, versionID string) string {
+	queryValue := url.Values{}
+	queryValue.Set("versionId", versionID)
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
 // return URL for fetching bucket policy.
 func getGetPolicyURL(endPoint, bucketName string) string {
 	queryValue := url.Values{}
@@ -1707,10 +1935,11 @@ func newTestObjectLayer(endpoints EndpointList) (newObject ObjectLayer, err erro
 
 	// Initialize xl objects.
 	xl := &xlObjects{
-		listPool:     listPool,
-		storageDisks: storageDisks,
-		nsMutex:      newNSLock(false),
-		bp:           bpool.NewBytePoolCap(4, blockSizeV1, blockSizeV1*2),
+		listPool:       listPool,
+		storageDisks:   storageDisks,
+		nsMutex:        newNSLock(false),
+		bp:             bpool.NewBytePoolCap(4, blockSizeV1, blockSizeV1*2),
+		multipartNames: newMultipartNames(),
 	}
 
 	xl.getDisks = func() []StorageAPI {
@@ -1723,6 +1952,18 @@ func newTestObjectLayer(endpoints EndpointList) (newObject ObjectLayer, err erro
 	// Create new policy system.
 	globalPolicySys = NewPolicySys()
 
+	// Create new bucket quota system.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	// Create new remote targets system.
+	globalBucketTargetSys = NewBucketTargetSys()
+
+	// Create new bucket metadata system.
+	globalBucketMetadataSys = NewBucketMetadataSys()
+
+	// Create new tiers system.
+	globalTierConfigSys = NewTierConfigSys()
+
 	return xl, nil
 }
 
@@ -2158,12 +2399,150 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "GetBucketPolicy":
 			// Register Get Bucket policy HTTP Handler.
 			bucket.Methods("GET").HandlerFunc(api.GetBucketPolicyHandler).Queries("policy", "")
+		case "PutBucketVersioning":
+			// Register PutBucketVersioning handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketVersioningHandler).Queries("versioning", "")
+		case "GetBucketVersioning":
+			// Register GetBucketVersioning handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketVersioningHandler).Queries("versioning", "")
+		case "PutBucketEncryption":
+			// Register PutBucketEncryption handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketEncryptionHandler).Queries("encryption", "")
+		case "GetBucketEncryption":
+			// Register GetBucketEncryption handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketEncryptionHandler).Queries("encryption", "")
+		case "DeleteBucketEncryption":
+			// Register DeleteBucketEncryption handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketEncryptionHandler).Queries("encryption", "")
+		case "PutBucketCors":
+			// Register PutBucketCors handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketCorsHandler).Queries("cors", "")
+		case "GetBucketCors":
+			// Register GetBucketCors handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketCorsHandler).Queries("cors", "")
+		case "DeleteBucketCors":
+			// Register DeleteBucketCors handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketCorsHandler).Queries("cors", "")
+		case "PutBucketWebsite":
+			// Register PutBucketWebsite handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketWebsiteHandler).Queries("website", "")
+		case "GetBucketWebsite":
+			// Register GetBucketWebsite handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketWebsiteHandler).Queries("website", "")
+		case "DeleteBucketWebsite":
+			// Register DeleteBucketWebsite handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketWebsiteHandler).Queries("website", "")
+		case "PutBucketLogging":
+			// Register PutBucketLogging handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketLoggingHandler).Queries("logging", "")
+		case "GetBucketLogging":
+			// Register GetBucketLogging handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketLoggingHandler).Queries("logging", "")
+		case "PutBucketInventoryConfiguration":
+			// Register PutBucketInventoryConfiguration handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketInventoryConfigurationHandler).Queries("inventory", "", "id", "{id:.*}")
+		case "GetBucketInventoryConfiguration":
+			// Register GetBucketInventoryConfiguration handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketInventoryConfigurationHandler).Queries("inventory", "", "id", "{id:.*}")
+		case "ListBucketInventoryConfigurations":
+			// Register ListBucketInventoryConfigurations handler.
+			bucket.Methods("GET").HandlerFunc(api.ListBucketInventoryConfigurationsHandler).Queries("inventory", "")
+		case "DeleteBucketInventoryConfiguration":
+			// Register DeleteBucketInventoryConfiguration handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketInventoryConfigurationHandler).Queries("inventory", "", "id", "{id:.*}")
+		case "RestoreObject":
+			// Register RestoreObject handler.
+			bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(api.RestoreObjectHandler).Queries("restore", "")
+		case "PutBucketLifecycle":
+			// Register PutBucketLifecycle handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketLifecycleHandler).Queries("lifecycle", "")
+		case "GetBucketLifecycle":
+			// Register GetBucketLifecycle handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketLifecycleHandler).Queries("lifecycle", "")
+		case "DeleteBucketLifecycle":
+			// Register DeleteBucketLifecycle handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketLifecycleHandler).Queries("lifecycle", "")
+		case "PutBucketReplication":
+			// Register PutBucketReplication handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketReplicationHandler).Queries("replication", "")
+		case "GetBucketReplication":
+			// Register GetBucketReplication handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketReplicationHandler).Queries("replication", "")
+		case "DeleteBucketReplication":
+			// Register DeleteBucketReplication handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketReplicationHandler).Queries("replication", "")
+		case "PutBucketTagging":
+			// Register PutBucketTagging handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketTaggingHandler).Queries("tagging", "")
+		case "GetBucketTagging":
+			// Register GetBucketTagging handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketTaggingHandler).Queries("tagging", "")
+		case "DeleteBucketTagging":
+			// Register DeleteBucketTagging handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketTaggingHandler).Queries("tagging", "")
+		case "ListObjectVersions":
+			// Register ListObjectVersions handler.
+			bucket.Methods("GET").HandlerFunc(api.ListObjectVersionsHandler).Queries("versions", "")
+		case "SearchObjects":
+			// Register SearchObjects handler.
+			bucket.Methods("GET").HandlerFunc(api.SearchObjectsHandler).Queries("search", "")
+		case "ListObjectsV2":
+			// Register ListObjectsV2 handler.
+			bucket.Methods("GET").HandlerFunc(api.ListObjectsV2Handler).Queries("list-type", "2")
+		case "PutObjectRetention":
+			// Register PutObjectRetention handler.
+			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectRetentionHandler).Queries("retention", "")
+		case "GetObjectRetention":
+			// Register GetObjectRetention handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectRetentionHandler).Queries("retention", "")
+		case "PutObjectLegalHold":
+			// Register PutObjectLegalHold handler.
+			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectLegalHoldHandler).Queries("legal-hold", "")
+		case "GetObjectLegalHold":
+			// Register GetObjectLegalHold handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectLegalHoldHandler).Queries("legal-hold", "")
+		case "PutBucketObjectLockConfig":
+			// Register PutBucketObjectLockConfig handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketObjectLockConfigHandler).Queries("object-lock", "")
+		case "GetBucketObjectLockConfig":
+			// Register GetBucketObjectLockConfig handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketObjectLockConfigHandler).Queries("object-lock", "")
+		case "PutObjectTagging":
+			// Register PutObjectTagging handler.
+			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectTaggingHandler).Queries("tagging", "")
+		case "GetObjectTagging":
+			// Register GetObjectTagging handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectTaggingHandler).Queries("tagging", "")
+		case "GetObjectAttributes":
+			// Register GetObjectAttributes handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectAttributesHandler).Queries("attributes", "")
+		case "ComposeObject":
+			// Register ComposeObject handler.
+			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.ComposeObjectHandler).Queries("compose", "")
+		case "PutObjectACL":
+			// Register PutObjectACL handler.
+			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectACLHandler).Queries("acl", "")
+		case "GetObjectACL":
+			// Register GetObjectACL handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectACLHandler).Queries("acl", "")
+		case "DeleteObjectTagging":
+			// Register DeleteObjectTagging handler.
+			bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(api.DeleteObjectTaggingHandler).Queries("tagging", "")
+		case "SelectObjectContent":
+			// Register SelectObjectContent handler.
+			bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(api.SelectObjectContentHandler).Queries("select", "", "select-type", "2")
+		case "PutBucket":
+			// Register PutBucket handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketHandler)
 		case "GetBucketLocation":
 			// Register GetBucketLocation handler.
 			bucket.Methods("GET").HandlerFunc(api.GetBucketLocationHandler).Queries("location", "")
 		case "HeadBucket":
 			// Register HeadBucket handler.
 			bucket.Methods("HEAD").HandlerFunc(api.HeadBucketHandler)
+		case "DeleteBucket":
+			// Register DeleteBucket handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketHandler)
 		case "DeleteMultipleObjects":
 			// Register DeleteMultipleObjects handler.
 			bucket.Methods("POST").HandlerFunc(api.DeleteMultipleObjectsHandler).Queries("delete", "")
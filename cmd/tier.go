@@ -0,0 +1,332 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+)
+
+const (
+	tierConfigPrefix = "tier"
+	tierConfigFile   = "tier-config.json"
+
+	// Maximum size of a tier in an add-tier or edit-tier request.
+	maxTierConfigSize = 64 * 1024
+
+	// Maximum length of a tier name.
+	maxTierNameLength = 64
+
+	// Refresh interval to update in-memory tiers cache.
+	globalRefreshTiersInterval = 5 * time.Minute
+)
+
+// List of tier related errors.
+var (
+	errInvalidTier       = errors.New("Specified tier is invalid")
+	errTierAlreadyExists = errors.New("Specified tier already exists")
+	errNoSuchTier        = errors.New("Specified tier does not exist")
+)
+
+// TierType - type of the remote storage backing a tier.
+type TierType string
+
+const (
+	// S3Tier - tier backed by AWS S3 or any S3 compatible storage.
+	S3Tier TierType = "s3"
+
+	// MinioTier - tier backed by a Minio server.
+	MinioTier TierType = "minio"
+)
+
+// TierConfig - remote storage that lifecycle transition rules move
+// objects to, rules reference tiers by their name. Objects are stored
+// under prefix in the remote bucket.
+type TierConfig struct {
+	Name        string           `json:"name"`
+	Type        TierType         `json:"type"`
+	Endpoint    string           `json:"endpoint"`
+	Bucket      string           `json:"bucket"`
+	Prefix      string           `json:"prefix,omitempty"`
+	Region      string           `json:"region,omitempty"`
+	Credentials auth.Credentials `json:"credentials"`
+}
+
+// isValidTierName - returns true if name only holds upper case letters,
+// digits, '-' and '_'.
+func isValidTierName(name string) bool {
+	if name == "" || len(name) > maxTierNameLength {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// validate - verifies that the tier is usable.
+func (t TierConfig) validate() error {
+	if !isValidTierName(t.Name) || (t.Type != S3Tier && t.Type != MinioTier) {
+		return errInvalidTier
+	}
+	u, err := url.Parse(t.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errInvalidTier
+	}
+	if !IsValidBucketName(t.Bucket) || (t.Prefix != "" && !IsValidObjectPrefix(t.Prefix)) {
+		return errInvalidTier
+	}
+	if t.Credentials.AccessKey == "" || t.Credentials.SecretKey == "" {
+		return errInvalidTier
+	}
+	return nil
+}
+
+// TierConfigSys - storage tiers subsystem.
+type TierConfigSys struct {
+	sync.RWMutex
+	tiers map[string]TierConfig
+}
+
+// Get - returns the tier having given name.
+func (sys *TierConfigSys) Get(name string) (tier TierConfig, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	tier, ok = sys.tiers[strings.ToUpper(name)]
+	return tier, ok
+}
+
+// ListTiers - returns all tiers sorted by name.
+func (sys *TierConfigSys) ListTiers() []TierConfig {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	tiers := []TierConfig{}
+	for _, tier := range sys.tiers {
+		tiers = append(tiers, tier)
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].Name < tiers[j].Name
+	})
+	return tiers
+}
+
+// Load - replaces all in-memory tiers with those stored in the backend.
+func (sys *TierConfigSys) Load(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	tiers, err := readTiers(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.tiers = tiers
+	return nil
+}
+
+// Init - initializes tiers system from tier-config.json.
+func (sys *TierConfigSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	// Load TierConfigSys once during boot.
+	if err := sys.Load(objAPI); err != nil {
+		return err
+	}
+
+	// Refresh TierConfigSys in background.
+	go func() {
+		ticker := time.NewTicker(globalRefreshTiersInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-globalServiceDoneCh:
+				return
+			case <-ticker.C:
+				logger.LogIf(context.Background(), sys.Load(objAPI))
+			}
+		}
+	}()
+	return nil
+}
+
+// AddTier - validates and persists a new tier, tier names are case
+// insensitive and stored in upper case.
+func (sys *TierConfigSys) AddTier(objAPI ObjectLayer, tier TierConfig) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	tier.Name = strings.ToUpper(tier.Name)
+	if err := tier.validate(); err != nil {
+		return err
+	}
+
+	tiers, err := updateTiers(objAPI, func(tiers map[string]TierConfig) error {
+		if _, ok := tiers[tier.Name]; ok {
+			return errTierAlreadyExists
+		}
+		tiers[tier.Name] = tier
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sys.Lock()
+	sys.tiers = tiers
+	sys.Unlock()
+
+	return nil
+}
+
+// EditTier - replaces the credentials of an existing tier, the remote
+// location of a tier can't change as it may already hold objects.
+func (sys *TierConfigSys) EditTier(objAPI ObjectLayer, name string, creds auth.Credentials) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	name = strings.ToUpper(name)
+	tiers, err := updateTiers(objAPI, func(tiers map[string]TierConfig) error {
+		tier, ok := tiers[name]
+		if !ok {
+			return errNoSuchTier
+		}
+		tier.Credentials = creds
+		if err := tier.validate(); err != nil {
+			return err
+		}
+		tiers[name] = tier
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sys.Lock()
+	sys.tiers = tiers
+	sys.Unlock()
+
+	return nil
+}
+
+// NewTierConfigSys - creates new tiers system.
+func NewTierConfigSys() *TierConfigSys {
+	return &TierConfigSys{
+		tiers: make(map[string]TierConfig),
+	}
+}
+
+// getTierConfigFile - returns the path to tiers config in minioMetaBucket.
+func getTierConfigFile() string {
+	return path.Join(tierConfigPrefix, tierConfigFile)
+}
+
+// readTiers - reads all tiers from the backend.
+func readTiers(ctx context.Context, objAPI ObjectLayer) (map[string]TierConfig, error) {
+	tiers := make(map[string]TierConfig)
+
+	reader, err := readConfig(ctx, objAPI, getTierConfigFile())
+	if err != nil {
+		if IsErrIgnored(err, errDiskNotFound, errConfigNotFound) {
+			return tiers, nil
+		}
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err = decryptConfigData(data); err != nil {
+		logger.LogIf(ctx, err)
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &tiers); err != nil {
+		logger.LogIf(ctx, err)
+		return nil, err
+	}
+
+	return tiers, nil
+}
+
+// saveTiers - writes all tiers encrypted to the backend.
+func saveTiers(objAPI ObjectLayer, tiers map[string]TierConfig) error {
+	data, err := json.Marshal(tiers)
+	if err != nil {
+		return err
+	}
+
+	if data, err = encryptConfigData(data); err != nil {
+		return err
+	}
+
+	return saveConfig(objAPI, getTierConfigFile(), data)
+}
+
+// updateTiers - reads tiers from the backend under a transaction lock,
+// applies updateFn and saves the result back.
+func updateTiers(objAPI ObjectLayer, updateFn func(map[string]TierConfig) error) (map[string]TierConfig, error) {
+	transactionConfigFile := getTierConfigFile() + ".transaction"
+
+	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
+	// and configFile, take a transaction lock to avoid data race between readConfig()
+	// and saveConfig().
+	objLock := globalNSMutex.NewNSLock(minioMetaBucket, transactionConfigFile)
+	if err := objLock.GetLock(globalOperationTimeout); err != nil {
+		return nil, err
+	}
+	defer objLock.Unlock()
+
+	tiers, err := readTiers(context.Background(), objAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = updateFn(tiers); err != nil {
+		return nil, err
+	}
+
+	if err = saveTiers(objAPI, tiers); err != nil {
+		return nil, err
+	}
+
+	return tiers, nil
+}
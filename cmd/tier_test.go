@@ -0,0 +1,136 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Tests adding, listing and editing tiers.
+func TestTierConfigSys(t *testing.T) {
+	initNSLock(false)
+
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatalf("unable to initialize FS backend, %s", err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	creds := auth.Credentials{AccessKey: "accesskey", SecretKey: "secretkey"}
+	tier := TierConfig{
+		Name:        "warm",
+		Type:        S3Tier,
+		Endpoint:    "https://s3.amazonaws.com",
+		Bucket:      "archive",
+		Prefix:      "minio/",
+		Credentials: creds,
+	}
+
+	sys := NewTierConfigSys()
+	testCases := []struct {
+		tier        TierConfig
+		expectedErr error
+	}{
+		// 1. Valid tier, its name is stored in upper case.
+		{tier, nil},
+		// 2. Tier names are case insensitive.
+		{TierConfig{Name: "WARM", Type: MinioTier, Endpoint: "http://remote:9000", Bucket: "archive", Credentials: creds}, errTierAlreadyExists},
+		// 3. Invalid name.
+		{TierConfig{Name: "cold/tier", Type: S3Tier, Endpoint: "https://s3.amazonaws.com", Bucket: "archive", Credentials: creds}, errInvalidTier},
+		// 4. Unknown tier type.
+		{TierConfig{Name: "cold", Type: "tape", Endpoint: "https://s3.amazonaws.com", Bucket: "archive", Credentials: creds}, errInvalidTier},
+		// 5. Invalid endpoint.
+		{TierConfig{Name: "cold", Type: S3Tier, Endpoint: "s3.amazonaws.com", Bucket: "archive", Credentials: creds}, errInvalidTier},
+		// 6. Invalid bucket.
+		{TierConfig{Name: "cold", Type: S3Tier, Endpoint: "https://s3.amazonaws.com", Bucket: "a", Credentials: creds}, errInvalidTier},
+		// 7. Missing credentials.
+		{TierConfig{Name: "cold", Type: S3Tier, Endpoint: "https://s3.amazonaws.com", Bucket: "archive"}, errInvalidTier},
+		// 8. Valid MinIO tier.
+		{TierConfig{Name: "cold", Type: MinioTier, Endpoint: "http://remote:9000", Bucket: "archive", Credentials: creds}, nil},
+	}
+
+	for i, testCase := range testCases {
+		if err = sys.AddTier(objLayer, testCase.tier); err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	newCreds := auth.Credentials{AccessKey: "accesskey", SecretKey: "newsecretkey"}
+	if err = sys.EditTier(objLayer, "hot", newCreds); err != errNoSuchTier {
+		t.Fatalf("expected: %v, got: %v", errNoSuchTier, err)
+	}
+	if err = sys.EditTier(objLayer, "warm", auth.Credentials{}); err != errInvalidTier {
+		t.Fatalf("expected: %v, got: %v", errInvalidTier, err)
+	}
+	if err = sys.EditTier(objLayer, "warm", newCreds); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// Tiers persisted in the backend are loaded by other servers.
+	newSys := NewTierConfigSys()
+	if err = newSys.Load(objLayer); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	tiers := newSys.ListTiers()
+	if len(tiers) != 2 || tiers[0].Name != "COLD" || tiers[1].Name != "WARM" {
+		t.Fatalf("expected tiers COLD and WARM, got: %v", tiers)
+	}
+
+	tier.Name = "WARM"
+	tier.Credentials = newCreds
+	if got, ok := newSys.Get("warm"); !ok || got != tier {
+		t.Fatalf("expected: %v, got: %v", tier, got)
+	}
+
+	// Tiers stored with former server credentials fail to load instead
+	// of being dropped, and are readable again once re-encrypted.
+	prevCred := globalServerConfig.GetCredential()
+	serverCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatalf("unable to generate credentials, %s", err)
+	}
+	globalServerConfig.SetCredential(serverCred)
+	if err = newSys.Load(objLayer); err == nil {
+		t.Fatal("expected tiers stored with former credentials to fail to load")
+	}
+	if tiers = newSys.ListTiers(); len(tiers) != 2 {
+		t.Fatalf("expected in-memory tiers to be kept, got: %v", tiers)
+	}
+	globalServerConfig.SetCredential(prevCred)
+	if err = reencryptConfigs(context.Background(), objLayer, prevCred.SecretKey, serverCred.SecretKey, func() error {
+		globalServerConfig.SetCredential(serverCred)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = newSys.Load(objLayer); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if got, ok := newSys.Get("warm"); !ok || got != tier {
+		t.Fatalf("expected: %v, got: %v", tier, got)
+	}
+}
@@ -70,3 +70,17 @@ var errFirstDiskWait = errors.New("Waiting on other disks")
 
 // error returned when a bucket already exists
 var errBucketAlreadyExists = errors.New("Your previous request to create the named bucket succeeded and you already own it")
+
+// errKMSNotConfigured - returned when no KMS is configured on the server.
+var errKMSNotConfigured = errors.New("KMS is not configured")
+
+// errServerUpdateNotSupported - returned when the server binary is managed
+// by a container orchestrator and can't be replaced in-place.
+var errServerUpdateNotSupported = errors.New("In-place update is not supported in container environments")
+
+// errTLSNotConfigured - returned when the server doesn't serve TLS.
+var errTLSNotConfigured = errors.New("TLS is not configured")
+
+// errObjectTagsUnchanged - returned internally when updated object tags
+// are unchanged and need not be written.
+var errObjectTagsUnchanged = errors.New("Object tags are unchanged")
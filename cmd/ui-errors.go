@@ -35,6 +35,12 @@ var (
 		"WORM can only accept `on` and `off` values. To enable WORM, set this value to `on`",
 	)
 
+	uiErrInvalidStrictIntegrityValue = newUIErrFn(
+		"Invalid strict integrity value",
+		"Please check the passed value",
+		"Strict integrity can only accept `on` and `off` values. To reject uploads without integrity information, set this value to `on`",
+	)
+
 	uiErrInvalidCacheDrivesValue = newUIErrFn(
 		"Invalid cache drive value",
 		"Please check the value in this ENV variable",
@@ -59,6 +65,18 @@ var (
 		"MINIO_CACHE_MAXUSE: Valid cache max-use value between 0-100.",
 	)
 
+	uiErrInvalidAdminRPCTimeout = newUIErrFn(
+		"Invalid admin RPC timeout value",
+		"Please check the passed value",
+		"MINIO_ADMIN_RPC_TIMEOUT: Valid timeout is a positive duration such as `30s` or `2m`.",
+	)
+
+	uiErrInvalidAdminRPCRetries = newUIErrFn(
+		"Invalid admin RPC retries value",
+		"Please check the passed value",
+		"MINIO_ADMIN_RPC_RETRIES: Valid number of retries is 0 or more.",
+	)
+
 	uiErrInvalidCredentials = newUIErrFn(
 		"Invalid credentials",
 		"Please provide correct credentials",
@@ -87,6 +87,14 @@ var (
 		minioReleaseURL + "minio.exe.sha256sum",
 		minioReleaseURL + "minio.exe.shasum",
 	}
+
+	// Path of the binary replaced by updates, empty means the
+	// binary of the running process.
+	minioBinaryPath = ""
+
+	// Time allowed to download a binary pushed by the admin update
+	// API, shorter than the timeout of admin RPC calls.
+	serverUpdateTimeout = 50 * time.Second
 )
 
 // minioVersionToReleaseTime - parses a standard official release
@@ -468,24 +476,36 @@ func doUpdate(sha256Hex string, latestReleaseTime time.Time, ok bool) (updateSta
 		return updateStatusMsg, err
 	}
 
-	resp, err := http.Get(getDownloadURL(releaseTimeToReleaseTag(latestReleaseTime)))
-	if err != nil {
+	if err = applyUpdate(http.DefaultClient, getDownloadURL(releaseTimeToReleaseTag(latestReleaseTime)), sha256Sum); err != nil {
 		return updateStatusMsg, err
 	}
+
+	return greenColorSprintf("Minio updated to version RELEASE.%s successfully.",
+		latestReleaseTime.Format(minioReleaseTagTimeLayout)), nil
+}
+
+// applyUpdate - downloads the binary at updateURL and replaces the minio
+// binary with it, the binary is left untouched if its SHA256 checksum
+// doesn't match sha256Sum.
+func applyUpdate(client *http.Client, updateURL string, sha256Sum []byte) error {
+	resp, err := client.Get(updateURL)
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Error downloading URL %s. Response: %v", updateURL, resp.Status)
+	}
+
 	// FIXME: add support for gpg verification as well.
-	if err = update.Apply(resp.Body,
+	return update.Apply(resp.Body,
 		update.Options{
-			Hash:     crypto.SHA256,
-			Checksum: sha256Sum,
+			TargetPath: minioBinaryPath,
+			Hash:       crypto.SHA256,
+			Checksum:   sha256Sum,
 		},
-	); err != nil {
-		return updateStatusMsg, err
-	}
-
-	return greenColorSprintf("Minio updated to version RELEASE.%s successfully.",
-		latestReleaseTime.Format(minioReleaseTagTimeLayout)), nil
+	)
 }
 
 func shouldUpdate(quiet bool, sha256Hex string, latestReleaseTime time.Time) (ok bool) {
@@ -17,14 +17,18 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 	"time"
+
+	sha256 "github.com/minio/sha256-simd"
 )
 
 func TestMinioVersionToReleaseTime(t *testing.T) {
@@ -297,3 +301,79 @@ func TestParseReleaseData(t *testing.T) {
 		}
 	}
 }
+
+// Binary served by newTestUpdateServer.
+var testUpdateBinary = []byte("#!/bin/sh\necho updated\n")
+
+// newTestUpdateServer - returns a server which serves testUpdateBinary
+// at /minio and responds 404 Not Found to any other path.
+func newTestUpdateServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio" {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		w.Write(testUpdateBinary)
+	}))
+}
+
+// newTestMinioBinary - creates a fake minio binary replaced by updates,
+// the returned function restores the previous binary path.
+func newTestMinioBinary(t *testing.T) (binaryPath string, restore func()) {
+	tmpDir, err := ioutil.TempDir("", "minio-update-")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	binaryPath = filepath.Join(tmpDir, "minio")
+	if err = ioutil.WriteFile(binaryPath, []byte("old binary"), 0755); err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	prevMinioBinaryPath := minioBinaryPath
+	minioBinaryPath = binaryPath
+	return binaryPath, func() {
+		minioBinaryPath = prevMinioBinaryPath
+		os.RemoveAll(tmpDir)
+	}
+}
+
+func TestApplyUpdate(t *testing.T) {
+	httpServer := newTestUpdateServer()
+	defer httpServer.Close()
+
+	binaryPath, restore := newTestMinioBinary(t)
+	defer restore()
+
+	sha256Sum := sha256.Sum256(testUpdateBinary)
+	badSha256Sum := sha256.Sum256([]byte("other binary"))
+
+	testCases := []struct {
+		updateURL      string
+		sha256Sum      []byte
+		expectErr      bool
+		expectedBinary []byte
+	}{
+		// Checksum mismatch leaves the binary untouched.
+		{httpServer.URL + "/minio", badSha256Sum[:], true, []byte("old binary")},
+		// Download failure leaves the binary untouched.
+		{httpServer.URL + "/missing", sha256Sum[:], true, []byte("old binary")},
+		{httpServer.URL + "/minio", sha256Sum[:], false, testUpdateBinary},
+	}
+
+	for i, testCase := range testCases {
+		err := applyUpdate(http.DefaultClient, testCase.updateURL, testCase.sha256Sum)
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+
+		binary, err := ioutil.ReadFile(binaryPath)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if !bytes.Equal(binary, testCase.expectedBinary) {
+			t.Fatalf("case %v: expected: %q, got: %q", i+1, testCase.expectedBinary, binary)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"archive/zip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -37,6 +38,7 @@ import (
 	miniogopolicy "github.com/minio/minio-go/pkg/policy"
 	"github.com/minio/minio-go/pkg/s3utils"
 	"github.com/minio/minio/browser"
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/dns"
@@ -390,6 +392,16 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 		return toJSONError(errInvalidArgument)
 	}
 
+	// Objects of versioned buckets are kept as noncurrent versions
+	// behind a delete marker.
+	removeObject := func(objectName string) error {
+		if getBucketVersioning(args.BucketName) != "" {
+			_, err := deleteObjectVersion(context.Background(), objectAPI, args.BucketName, objectName, "", r)
+			return err
+		}
+		return deleteObject(nil, objectAPI, web.CacheAPI(), args.BucketName, objectName, r)
+	}
+
 	var err error
 next:
 	for _, objectName := range args.Objects {
@@ -402,7 +414,7 @@ next:
 				}
 			}
 
-			if err = deleteObject(nil, objectAPI, web.CacheAPI(), args.BucketName, objectName, r); err != nil {
+			if err = removeObject(objectName); err != nil {
 				break next
 			}
 			continue
@@ -418,7 +430,7 @@ next:
 			}
 			marker = lo.NextMarker
 			for _, obj := range lo.Objects {
-				err = deleteObject(nil, objectAPI, web.CacheAPI(), args.BucketName, obj.Name, r)
+				err = removeObject(obj.Name)
 				if err != nil {
 					break next
 				}
@@ -593,10 +605,6 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	putObject := objectAPI.PutObject
-	if web.CacheAPI() != nil {
-		putObject = web.CacheAPI().PutObject
-	}
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
 	object := vars["object"]
@@ -633,6 +641,13 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponse(w, ErrInternalError, r.URL)
 		return
 	}
+	setBucketDefaultTags(objectAPI, bucket, metadata)
+	setObjectVersionID(bucket, metadata)
+	if s3Error := setObjectLockMetadata(bucket, r.Header, metadata); s3Error != ErrNone {
+		writeErrorResponse(w, s3Error, r.URL)
+		return
+	}
+	setObjectReplicationMetadata(objectAPI, r, r.Header, bucket, object, metadata)
 
 	hashReader, err := hash.NewReader(r.Body, size, "", "")
 	if err != nil {
@@ -648,12 +663,34 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if objectAPI.IsEncryptionSupported() {
+		if encryption, ok := getBucketEncryption(bucket); ok && !hasSuffix(object, slashSeparator) { // handle default bucket encryption
+			reader, err := newBucketEncryptReader(hashReader, encryption, bucket, object, metadata)
+			if err != nil {
+				writeWebErrorResponse(w, err)
+				return
+			}
+			info := ObjectInfo{Size: size}
+			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "") // do not try to verify encrypted content
+			if err != nil {
+				writeWebErrorResponse(w, err)
+				return
+			}
+		}
+	}
+
+	putObject := objectAPI.PutObject
+	if web.CacheAPI() != nil && !crypto.IsEncrypted(metadata) {
+		putObject = web.CacheAPI().PutObject
+	}
 	objInfo, err := putObject(context.Background(), bucket, object, hashReader, metadata)
 	if err != nil {
 		writeWebErrorResponse(w, err)
 		return
 	}
 
+	queueReplication(objInfo)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:  event.ObjectCreatedPut,
@@ -690,19 +727,57 @@ func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	getObject := objectAPI.GetObject
+	getObjectInfo := objectAPI.GetObjectInfo
 	if web.CacheAPI() != nil {
-		getObject = web.CacheAPI().GetObject
+		getObjectInfo = web.CacheAPI().GetObjectInfo
 	}
+	objInfo, err := getObjectInfo(context.Background(), bucket, object)
+	if err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+	var encrypted bool
+	if objectAPI.IsEncryptionSupported() {
+		var apiErr APIErrorCode
+		if apiErr, encrypted = DecryptObjectInfo(&objInfo, r.Header); apiErr != ErrNone {
+			writeErrorResponse(w, apiErr, r.URL)
+			return
+		}
+	}
+
 	// Add content disposition.
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", path.Base(object)))
 
-	if err := getObject(context.Background(), bucket, object, 0, -1, w, ""); err != nil {
+	if err = web.getObject(r, bucket, object, objInfo, encrypted, w); err != nil {
 		/// No need to print error, response writer already written to.
 		return
 	}
 }
 
+// getObject - writes the content of an object to the writer, given its
+// info as returned by DecryptObjectInfo. Objects encrypted as per the
+// default encryption of their bucket are decrypted, they are not cached.
+func (web *webAPIHandlers) getObject(r *http.Request, bucket, object string, objInfo ObjectInfo, encrypted bool, writer io.Writer) error {
+	objectAPI := web.ObjectAPI()
+	if !encrypted {
+		getObject := objectAPI.GetObject
+		if web.CacheAPI() != nil {
+			getObject = web.CacheAPI().GetObject
+		}
+		return getObject(context.Background(), bucket, object, 0, objInfo.Size, writer, "")
+	}
+
+	decWriter, startOffset, length, err := DecryptBlocksRequest(writer, r, bucket, object, 0, objInfo.Size, objInfo, false)
+	if err != nil {
+		return err
+	}
+	if err = objectAPI.GetObject(context.Background(), bucket, object, startOffset, length, decWriter, ""); err != nil {
+		decWriter.Close()
+		return err
+	}
+	return decWriter.Close()
+}
+
 // DownloadZipArgs - Argument for downloading a bunch of files as a zip file.
 // JSON will look like:
 // '{"bucketname":"testbucket","prefix":"john/pics/","objects":["hawaii/","maldives/","sanjose.jpg"]}'
@@ -719,10 +794,6 @@ func (web *webAPIHandlers) DownloadZip(w http.ResponseWriter, r *http.Request) {
 		writeWebErrorResponse(w, errServerNotInitialized)
 		return
 	}
-	getObject := objectAPI.GetObject
-	if web.CacheAPI() != nil {
-		getObject = web.CacheAPI().GetObject
-	}
 	listObjects := objectAPI.ListObjects
 	if web.CacheAPI() != nil {
 		listObjects = web.CacheAPI().ListObjects
@@ -767,6 +838,13 @@ func (web *webAPIHandlers) DownloadZip(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return err
 			}
+			var encrypted bool
+			if objectAPI.IsEncryptionSupported() {
+				var apiErr APIErrorCode
+				if apiErr, encrypted = DecryptObjectInfo(&info, r.Header); apiErr != ErrNone {
+					return errEncryptedObject
+				}
+			}
 			header := &zip.FileHeader{
 				Name:               strings.TrimPrefix(objectName, args.Prefix),
 				Method:             zip.Deflate,
@@ -778,7 +856,7 @@ func (web *webAPIHandlers) DownloadZip(w http.ResponseWriter, r *http.Request) {
 				writeWebErrorResponse(w, errUnexpected)
 				return err
 			}
-			return getObject(context.Background(), args.BucketName, objectName, 0, info.Size, writer, "")
+			return web.getObject(r, args.BucketName, objectName, info, encrypted, writer)
 		}
 
 		if !hasSuffix(object, slashSeparator) {
@@ -938,46 +1016,10 @@ func (web *webAPIHandlers) SetBucketPolicy(r *http.Request, args *SetBucketPolic
 		}
 	}
 
-	ctx := context.Background()
-
-	bucketPolicy, err := objectAPI.GetBucketPolicy(ctx, args.BucketName)
-	if err != nil {
-		if _, ok := err.(BucketPolicyNotFound); !ok {
-			return toJSONError(err, args.BucketName)
-		}
-	}
-
-	policyInfo, err := PolicyToBucketAccessPolicy(bucketPolicy)
-	if err != nil {
-		// This should not happen.
+	if err := setBucketPolicyType(context.Background(), objectAPI, args.BucketName, args.Prefix, policyType); err != nil {
 		return toJSONError(err, args.BucketName)
 	}
 
-	policyInfo.Statements = miniogopolicy.SetPolicy(policyInfo.Statements, policyType, args.BucketName, args.Prefix)
-
-	if len(policyInfo.Statements) == 0 {
-		if err = objectAPI.DeleteBucketPolicy(ctx, args.BucketName); err != nil {
-			return toJSONError(err, args.BucketName)
-		}
-
-		globalPolicySys.Remove(args.BucketName)
-		return nil
-	}
-
-	bucketPolicy, err = BucketAccessPolicyToPolicy(policyInfo)
-	if err != nil {
-		// This should not happen.
-		return toJSONError(err, args.BucketName)
-	}
-
-	// Parse validate and save bucket policy.
-	if err := objectAPI.SetBucketPolicy(ctx, args.BucketName, bucketPolicy); err != nil {
-		return toJSONError(err, args.BucketName)
-	}
-
-	globalPolicySys.Set(args.BucketName, *bucketPolicy)
-	globalNotificationSys.SetBucketPolicy(ctx, args.BucketName, bucketPolicy)
-
 	return nil
 }
 
@@ -1051,13 +1093,130 @@ func presignedGet(host, bucket, object string, expiry int64) string {
 	extractedSignedHeaders.Set("host", host)
 	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, path, "GET")
 	stringToSign := getStringToSign(canonicalRequest, date, getScope(date, region))
-	signingKey := getSigningKey(secretKey, date, region)
+	signingKey := getSigningKey(secretKey, date, region, serviceS3)
 	signature := getSignature(signingKey, stringToSign)
 
 	// Construct the final presigned URL.
 	return host + s3utils.EncodePath(path) + "?" + queryStr + "&" + "X-Amz-Signature=" + signature
 }
 
+// PresignedPostArgs - presigned-post API args.
+type PresignedPostArgs struct {
+	// Host of the URL the form is posted to.
+	HostName string `json:"host"`
+
+	// Bucket name the object is uploaded to.
+	BucketName string `json:"bucket"`
+
+	// Object name of the uploaded object, it may contain ${filename}
+	// to be replaced by the name of the uploaded file.
+	ObjectName string `json:"object"`
+
+	// Additional POST policy conditions, in the POST policy format.
+	Conditions []interface{} `json:"conditions"`
+
+	// Expiry in seconds.
+	Expiry int64 `json:"expiry"`
+}
+
+// PresignedPostRep - presigned-post form reply.
+type PresignedPostRep struct {
+	UIVersion string `json:"uiVersion"`
+	// URL the form is posted to.
+	URL string `json:"url"`
+	// Form fields to post along with the file.
+	FormData map[string]string `json:"formData"`
+}
+
+// PresignedPost - returns a signed POST policy form, to upload an object
+// with a browser based POST request.
+func (web *webAPIHandlers) PresignedPost(r *http.Request, args *PresignedPostArgs, reply *PresignedPostRep) error {
+	if !isHTTPRequestValid(r) {
+		return toJSONError(errAuthentication)
+	}
+
+	if args.BucketName == "" || args.ObjectName == "" {
+		return &json2.Error{
+			Message: "Bucket and Object are mandatory arguments.",
+		}
+	}
+	postURL, formData, err := presignedPost(args.HostName, args.BucketName, args.ObjectName, args.Conditions, args.Expiry)
+	if err != nil {
+		return &json2.Error{
+			Message: fmt.Sprintf("Invalid POST policy conditions: %v", err),
+		}
+	}
+	reply.UIVersion = browser.UIVersion
+	reply.URL = postURL
+	reply.FormData = formData
+	return nil
+}
+
+// Returns the URL and signed form fields of a POST policy form. Form
+// fields are added for the conditions requiring an exact value.
+func presignedPost(host, bucket, object string, conditions []interface{}, expiry int64) (string, map[string]string, error) {
+	cred := globalServerConfig.GetCredential()
+	region := getBucketRegion(bucket)
+
+	date := UTCNow()
+	dateStr := date.Format(iso8601Format)
+	credential := fmt.Sprintf("%s/%s", cred.AccessKey, getScope(date, region))
+
+	// Default set to be expire in 7days.
+	if expiry >= 604800 || expiry <= 0 {
+		expiry = 604800
+	}
+	expiration := date.Add(time.Duration(expiry) * time.Second)
+
+	formData := map[string]string{"key": object}
+	for _, condition := range conditions {
+		switch cond := condition.(type) {
+		case map[string]interface{}:
+			for k, v := range cond {
+				formData[k] = toString(v)
+			}
+		case []interface{}:
+			if len(cond) == 3 && toLowerString(cond[0]) == policyCondEqual {
+				formData[strings.TrimPrefix(toString(cond[1]), "$")] = toString(cond[2])
+			}
+		}
+	}
+
+	// The key of an object named after the uploaded file is only known
+	// to start with the part before ${filename}.
+	keyCondition := []interface{}{policyCondEqual, "$key", object}
+	if i := strings.Index(object, "${filename}"); i >= 0 {
+		keyCondition = []interface{}{policyCondStartsWith, "$key", object[:i]}
+	}
+	policyConditions := []interface{}{
+		[]interface{}{policyCondEqual, "$bucket", bucket},
+		keyCondition,
+		[]interface{}{policyCondEqual, "$x-amz-algorithm", signV4Algorithm},
+		[]interface{}{policyCondEqual, "$x-amz-credential", credential},
+		[]interface{}{policyCondEqual, "$x-amz-date", dateStr},
+	}
+	policyBytes, err := json.Marshal(map[string]interface{}{
+		"expiration": expiration.Format(time.RFC3339),
+		"conditions": append(policyConditions, conditions...),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err = parsePostPolicyForm(string(policyBytes)); err != nil {
+		return "", nil, err
+	}
+
+	encodedPolicy := base64.StdEncoding.EncodeToString(policyBytes)
+	signingKey := getSigningKey(cred.SecretKey, date, region, serviceS3)
+	formData["policy"] = encodedPolicy
+	formData["x-amz-algorithm"] = signV4Algorithm
+	formData["x-amz-credential"] = credential
+	formData["x-amz-date"] = dateStr
+	formData["x-amz-signature"] = getSignature(signingKey, encodedPolicy)
+
+	return host + s3utils.EncodePath("/"+bucket), formData, nil
+}
+
 // toJSONError converts regular errors into more user friendly
 // and consumable error message for the browser UI.
 func toJSONError(err error, params ...string) (jerr *json2.Error) {
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -37,6 +38,7 @@ import (
 	jwtgo "github.com/dgrijalva/jwt-go"
 	humanize "github.com/dustin/go-humanize"
 	miniogopolicy "github.com/minio/minio-go/pkg/policy"
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/policy"
 	"github.com/minio/minio/pkg/policy/condition"
@@ -941,6 +943,261 @@ func testUploadWebHandler(obj ObjectLayer, instanceType string, t TestErrHandler
 	}
 }
 
+// uploadWebTestObject - uploads content as the object with the web
+// upload handler, returns the response.
+func uploadWebTestObject(apiRouter http.Handler, authorization, bucketName, objectName string, content []byte) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/minio/upload/"+bucketName+"/"+objectName, bytes.NewReader(content))
+	req.Header.Set("Authorization", "Bearer "+authorization)
+	apiRouter.ServeHTTP(rec, req)
+	return rec
+}
+
+// Wrapper for calling Upload and RemoveObject web handlers on a
+// versioned bucket.
+func TestWebHandlerVersionedBucket(t *testing.T) {
+	ExecObjectLayerTest(t, testWebHandlerVersionedBucket)
+}
+
+// testWebHandlerVersionedBucket - Test Upload and RemoveObject web
+// handlers keep the versions of objects of versioned buckets.
+func testWebHandlerVersionedBucket(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	if instanceType == FSTestStr {
+		return
+	}
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalServerConfig.GetCredential()
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	objectName := "object"
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	globalBucketMetadataSys.Set(bucketName, bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+
+	// Overwriting the object keeps the first upload as a noncurrent version.
+	for _, content := range []string{"first", "second"} {
+		if rec := uploadWebTestObject(apiRouter, authorization, bucketName, objectName, []byte(content)); rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	req, err := newTestWebRPCRequest("Web.RemoveObject", authorization, RemoveObjectArgs{BucketName: bucketName, Objects: []string{objectName}})
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if err = getTestWebRPCResponse(rec, &WebGenericRep{}); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+
+	// Removing the object adds a delete marker in front of both versions.
+	if _, err = obj.GetObjectInfo(context.Background(), bucketName, objectName); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected the object to be removed, found %v", err)
+	}
+	result, err := obj.ListObjectVersions(context.Background(), bucketName, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(result.Objects) != 3 || !result.Objects[0].DeleteMarker || result.Objects[1].DeleteMarker || result.Objects[2].DeleteMarker {
+		t.Fatalf("Expected a delete marker and two versions, found %v", result.Objects)
+	}
+}
+
+// Wrapper for calling Upload Handler on replicated buckets
+func TestWebHandlerReplicatedBucket(t *testing.T) {
+	ExecObjectLayerTest(t, testWebHandlerReplicatedBucket)
+}
+
+// testWebHandlerReplicatedBucket - Test Upload web handler records the
+// replication status of objects matching a replication rule.
+func testWebHandlerReplicatedBucket(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	if instanceType == FSTestStr {
+		return
+	}
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalServerConfig.GetCredential()
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	globalBucketMetadataSys.Set(bucketName, bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+	globalBucketMetadataSys.Set(bucketName, bucketReplicationConfig, []byte(`<ReplicationConfiguration><Role>arn:minio:replication::target:replica</Role>`+
+		`<Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::replica</Bucket></Destination></Rule>`+
+		`</ReplicationConfiguration>`))
+
+	testCases := []struct {
+		objectName     string
+		expectedStatus ReplicationStatus
+	}{
+		{"data/a", ""},
+		{"logs/a", ReplicationPending},
+	}
+	for i, testCase := range testCases {
+		if rec := uploadWebTestObject(apiRouter, authorization, bucketName, testCase.objectName, []byte("a")); rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: Expected the response status to be 200, but instead found `%d`", i+1, rec.Code)
+		}
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, testCase.objectName)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if status := getObjectReplicationStatus(objInfo.UserDefined); status != testCase.expectedStatus {
+			t.Fatalf("Test %d: Expected replication status %q, found %q", i+1, testCase.expectedStatus, status)
+		}
+	}
+}
+
+// Wrapper for calling Upload Handler on object lock buckets
+func TestWebHandlerObjectLockBucket(t *testing.T) {
+	ExecObjectLayerTest(t, testWebHandlerObjectLockBucket)
+}
+
+// testWebHandlerObjectLockBucket - Test Upload web handler applies the
+// default retention of the bucket and the legal hold of the request.
+func testWebHandlerObjectLockBucket(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	if instanceType == FSTestStr {
+		return
+	}
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalServerConfig.GetCredential()
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	objectName := "object"
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	globalBucketMetadataSys.Set(bucketName, bucketVersioningConfig, []byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+	globalBucketMetadataSys.Set(bucketName, bucketObjectLockConfig, []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled>`+
+		`<Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`))
+
+	rec := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/minio/upload/"+bucketName+"/"+objectName, bytes.NewReader([]byte("a")))
+	req.Header.Set("Authorization", "Bearer "+authorization)
+	req.Header.Set(amzObjectLockLegalHold, string(LegalHoldOn))
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+	}
+
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if retention, ok := getObjectRetention(objInfo.UserDefined); !ok || retention.Mode != RetentionGovernance {
+		t.Fatalf("Expected the default retention of the bucket, found %v", retention)
+	}
+	if legalHold, ok := getObjectLegalHold(objInfo.UserDefined); !ok || legalHold.Status != LegalHoldOn {
+		t.Fatalf("Expected the legal hold of the request, found %v", legalHold)
+	}
+}
+
+// Wrapper for calling Upload and Download web handlers on an encrypted
+// bucket.
+func TestWebHandlerEncryptedBucket(t *testing.T) {
+	ExecObjectLayerTest(t, testWebHandlerEncryptedBucket)
+}
+
+// testWebHandlerEncryptedBucket - Test Upload web handler encrypts objects
+// as per the default encryption of the bucket and the Download and
+// DownloadZip web handlers decrypt them.
+func testWebHandlerEncryptedBucket(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+	tmpGlobalKMS, tmpGlobalKMSKeyID := globalKMS, globalKMSKeyID
+	defer func() { globalKMS, globalKMSKeyID = tmpGlobalKMS, tmpGlobalKMSKeyID }()
+	globalKMS, globalKMSKeyID = crypto.NewKMS([32]byte{}), "default-key"
+
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalServerConfig.GetCredential()
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+	token, err := authenticateURL(credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucketName := getRandomBucketName()
+	objectName := "object"
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	globalBucketMetadataSys.Set(bucketName, bucketEncryptionConfig, []byte(`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault>`+
+		`<SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`))
+
+	content := bytes.Repeat([]byte("encrypted-by-default"), 10000)
+	if rec := uploadWebTestObject(apiRouter, authorization, bucketName, objectName, content); rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+	}
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !crypto.S3.IsEncrypted(objInfo.UserDefined) {
+		t.Fatal("Expected the object to be encrypted")
+	}
+
+	rec := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/minio/download/"+bucketName+"/"+objectName+"?token="+token, nil)
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+	}
+	if !bytes.Equal(rec.Body.Bytes(), content) {
+		t.Fatal("Expected the downloaded object to be decrypted")
+	}
+
+	argsData, err := json.Marshal(DownloadZipArgs{Objects: []string{objectName}, BucketName: bucketName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/minio/zip?token="+token, bytes.NewReader(argsData))
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reader.File) != 1 {
+		t.Fatalf("Expected one file in the zip, found %d", len(reader.File))
+	}
+	fileReader, err := reader.File[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fileReader.Close()
+	if data, err := ioutil.ReadAll(fileReader); err != nil || !bytes.Equal(data, content) {
+		t.Fatalf("Expected the zipped object to be decrypted, %v", err)
+	}
+}
+
 // Wrapper for calling Download Handler
 func TestWebHandlerDownload(t *testing.T) {
 	ExecObjectLayerTest(t, testDownloadWebHandler)
@@ -1249,6 +1506,114 @@ func testWebPresignedGetHandler(obj ObjectLayer, instanceType string, t TestErrH
 	}
 }
 
+// Wrapper for calling PresignedPost handler
+func TestWebHandlerPresignedPostHandler(t *testing.T) {
+	ExecObjectLayerTest(t, testWebPresignedPostHandler)
+}
+
+func testWebPresignedPostHandler(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	// Register the API end points with XL/FS object layer.
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalServerConfig.GetCredential()
+
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	presignPostReq := PresignedPostArgs{
+		BucketName: bucketName,
+		ObjectName: "uploads/${filename}",
+		Conditions: []interface{}{
+			[]interface{}{"starts-with", "$Content-Type", "text/"},
+			map[string]interface{}{"x-amz-meta-uuid": "1234"},
+			[]interface{}{"content-length-range", 1, 1024},
+		},
+		Expiry: 1000,
+	}
+	presignPostRep := &PresignedPostRep{}
+	rec := httptest.NewRecorder()
+	req, err := newTestWebRPCRequest("Web.PresignedPost", authorization, presignPostReq)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+	}
+	if err = getTestWebRPCResponse(rec, &presignPostRep); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if presignPostRep.FormData["x-amz-meta-uuid"] != "1234" || presignPostRep.FormData["key"] != "uploads/${filename}" {
+		t.Fatalf("Unexpected form data %v", presignPostRep.FormData)
+	}
+
+	// Post the form with a file, filling in the content type.
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range presignPostRep.FormData {
+		w.WriteField(k, v)
+	}
+	w.WriteField("Content-Type", "text/plain")
+	writer, err := w.CreateFormFile("file", "upload.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.Write([]byte("hello"))
+	w.Close()
+
+	// Register the API end points with XL/FS object layer.
+	apiRouter = initTestAPIEndPoints(obj, []string{"PostPolicy"})
+	arec := httptest.NewRecorder()
+	req, err = http.NewRequest("POST", presignPostRep.URL, &buf)
+	if err != nil {
+		t.Fatal("Failed to initialized a new request", err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	apiRouter.ServeHTTP(arec, req)
+	if arec.Code != http.StatusNoContent {
+		t.Fatalf("Expected the response status to be 204, but instead found `%d`: %s", arec.Code, arec.Body.String())
+	}
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, "uploads/upload.txt")
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	if objInfo.Size != 5 || objInfo.ContentType != "text/plain" || objInfo.UserDefined["X-Amz-Meta-Uuid"] != "1234" {
+		t.Fatalf("Unexpected object info %+v", objInfo)
+	}
+
+	// Register the API end points with XL/FS object layer.
+	apiRouter = initTestWebRPCEndPoint(obj)
+
+	testCases := []struct {
+		args          PresignedPostArgs
+		expectedError string
+	}{
+		{PresignedPostArgs{}, "Bucket and Object are mandatory arguments."},
+		{PresignedPostArgs{BucketName: bucketName, ObjectName: "object", Conditions: []interface{}{[]interface{}{"content-length-range", 10, 1}}}, "Invalid POST policy conditions: Invalid content-length-range 10, 1 found in POST policy form"},
+	}
+	for i, testCase := range testCases {
+		rec = httptest.NewRecorder()
+		req, err = newTestWebRPCRequest("Web.PresignedPost", authorization, testCase.args)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request: <ERROR> %v", i+1, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: Expected the response status to be 200, but instead found `%d`", i+1, rec.Code)
+		}
+		err = getTestWebRPCResponse(rec, &PresignedPostRep{})
+		if err == nil || err.Error() != testCase.expectedError {
+			t.Fatalf("Test %d: Expected error `%s`, got %v", i+1, testCase.expectedError, err)
+		}
+	}
+}
+
 // Wrapper for calling GetBucketPolicy Handler
 func TestWebHandlerGetBucketPolicyHandler(t *testing.T) {
 	ExecObjectLayerTest(t, testWebGetBucketPolicyHandler)
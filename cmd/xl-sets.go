@@ -253,9 +253,10 @@ func newXLSets(endpoints EndpointList, format *formatXLV3, setCount int, drivesP
 
 		// Initialize xl objects for a given set.
 		s.sets[i] = &xlObjects{
-			getDisks: s.GetDisks(i),
-			nsMutex:  mutex,
-			bp:       bp,
+			getDisks:       s.GetDisks(i),
+			nsMutex:        mutex,
+			bp:             bp,
+			multipartNames: newMultipartNames(),
 		}
 		go s.sets[i].cleanupStaleMultipartUploads(context.Background(), globalMultipartCleanupInterval, globalMultipartExpiry, globalServiceDoneCh)
 	}
@@ -343,6 +344,11 @@ func (s *xlSets) StorageInfo(ctx context.Context) StorageInfo {
 	return storageInfo
 }
 
+// getNSLockMap - returns the namespace lock map shared by all sets.
+func (s *xlSets) getNSLockMap() *nsLockMap {
+	return s.sets[0].nsMutex
+}
+
 // Shutdown shutsdown all erasure coded sets in parallel
 // returns error upon first error.
 func (s *xlSets) Shutdown(ctx context.Context) error {
@@ -489,10 +495,68 @@ func (s *xlSets) IsEncryptionSupported() bool {
 	return s.getHashedSet("").IsEncryptionSupported()
 }
 
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (s *xlSets) IsVersioningSupported() bool {
+	return s.getHashedSet("").IsVersioningSupported()
+}
+
+// IsTaggingSupported returns whether object tagging is applicable for this layer.
+func (s *xlSets) IsTaggingSupported() bool {
+	return s.getHashedSet("").IsTaggingSupported()
+}
+
+// IsLifecycleSupported returns whether bucket lifecycle is applicable for this layer.
+func (s *xlSets) IsLifecycleSupported() bool {
+	return s.getHashedSet("").IsLifecycleSupported()
+}
+
+// IsTransitionSupported returns whether lifecycle transition is applicable for this layer.
+func (s *xlSets) IsTransitionSupported() bool {
+	return s.getHashedSet("").IsTransitionSupported()
+}
+
+// IsReplicationSupported returns whether bucket replication is applicable for this layer.
+func (s *xlSets) IsReplicationSupported() bool {
+	return s.getHashedSet("").IsReplicationSupported()
+}
+
+// IsCorsSupported returns whether bucket CORS is applicable for this layer.
+func (s *xlSets) IsCorsSupported() bool {
+	return s.getHashedSet("").IsCorsSupported()
+}
+
+// IsInventorySupported returns whether bucket inventory is applicable for this layer.
+func (s *xlSets) IsInventorySupported() bool {
+	return s.getHashedSet("").IsInventorySupported()
+}
+
+// IsWebsiteSupported returns whether bucket website hosting is applicable for this layer.
+func (s *xlSets) IsWebsiteSupported() bool {
+	return s.getHashedSet("").IsWebsiteSupported()
+}
+
+// IsLoggingSupported returns whether bucket access logging is applicable for this layer.
+func (s *xlSets) IsLoggingSupported() bool {
+	return s.getHashedSet("").IsLoggingSupported()
+}
+
+// IsAppendSupported returns whether appending to objects is applicable for this layer.
+func (s *xlSets) IsAppendSupported() bool {
+	return s.getHashedSet("").IsAppendSupported()
+}
+
 // DeleteBucket - deletes a bucket on all sets simultaneously,
 // even if one of the sets fail to delete buckets, we proceed to
 // undo a successful operation.
 func (s *xlSets) DeleteBucket(ctx context.Context, bucket string) error {
+	// Noncurrent versions of objects must be removed first, check all
+	// sets before deleting the bucket from any of them.
+	for _, set := range s.sets {
+		if set.hasObjectVersions(bucket) {
+			return BucketNotEmpty{Bucket: bucket}
+		}
+	}
+
 	g := errgroup.WithNErrs(len(s.sets))
 
 	// Delete buckets in parallel across all sets.
@@ -556,9 +620,20 @@ func (s *xlSets) GetObject(ctx context.Context, bucket, object string, startOffs
 
 // PutObject - writes an object to hashedSet based on the object name.
 func (s *xlSets) PutObject(ctx context.Context, bucket string, object string, data *hash.Reader, metadata map[string]string) (objInfo ObjectInfo, err error) {
+	if err = enforceBucketQuota(ctx, s, bucket, data.Size()); err != nil {
+		return objInfo, err
+	}
 	return s.getHashedSet(object).PutObject(ctx, bucket, object, data, metadata)
 }
 
+// AppendObject - appends data to an object in the hashedSet based on the object name.
+func (s *xlSets) AppendObject(ctx context.Context, bucket, object string, position int64, data *hash.Reader, metadata map[string]string) (objInfo ObjectInfo, err error) {
+	if err = enforceBucketQuota(ctx, s, bucket, data.Size()); err != nil {
+		return objInfo, err
+	}
+	return s.getHashedSet(object).AppendObject(ctx, bucket, object, position, data, metadata)
+}
+
 // GetObjectInfo - reads object metadata from the hashedSet based on the object name.
 func (s *xlSets) GetObjectInfo(ctx context.Context, bucket, object string) (objInfo ObjectInfo, err error) {
 	return s.getHashedSet(object).GetObjectInfo(ctx, bucket, object)
@@ -569,6 +644,73 @@ func (s *xlSets) DeleteObject(ctx context.Context, bucket string, object string)
 	return s.getHashedSet(object).DeleteObject(ctx, bucket, object)
 }
 
+// GetObjectVersion - reads a version of an object from the hashedSet based on the object name.
+func (s *xlSets) GetObjectVersion(ctx context.Context, bucket, object, versionID string, startOffset int64, length int64, writer io.Writer, etag string) error {
+	return s.getHashedSet(object).GetObjectVersion(ctx, bucket, object, versionID, startOffset, length, writer, etag)
+}
+
+// GetObjectVersionInfo - reads metadata of a version of an object from the hashedSet based on the object name.
+func (s *xlSets) GetObjectVersionInfo(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).GetObjectVersionInfo(ctx, bucket, object, versionID)
+}
+
+// ListObjectVersions - lists the versions and delete markers of the objects of the bucket across all sets.
+func (s *xlSets) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	var versionedObjects []string
+	for _, set := range s.sets {
+		versionedObjects = append(versionedObjects, set.listVersionedObjects(ctx, bucket)...)
+	}
+	getVersions := func(object string) ([]ObjectInfo, error) {
+		return s.getHashedSet(object).listObjectVersions(ctx, bucket, object)
+	}
+	return listObjectVersions(ctx, s, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys, versionedObjects, getVersions)
+}
+
+// PutObjectRetention - sets the retention of a version of an object on the hashedSet based on the object name.
+func (s *xlSets) PutObjectRetention(ctx context.Context, bucket, object, versionID string, retention ObjectRetention) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).PutObjectRetention(ctx, bucket, object, versionID, retention)
+}
+
+// PutObjectLegalHold - sets the legal hold of a version of an object on the hashedSet based on the object name.
+func (s *xlSets) PutObjectLegalHold(ctx context.Context, bucket, object, versionID string, legalHold ObjectLegalHold) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).PutObjectLegalHold(ctx, bucket, object, versionID, legalHold)
+}
+
+// PutObjectTags - replaces the tags of a version of an object on the hashedSet based on the object name.
+func (s *xlSets) PutObjectTags(ctx context.Context, bucket, object, versionID, tags string) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).PutObjectTags(ctx, bucket, object, versionID, tags)
+}
+
+// UpdateObjectTags - updates the tags of a version of an object on the hashedSet based on the object name.
+func (s *xlSets) UpdateObjectTags(ctx context.Context, bucket, object, versionID string, updateFn func(tags string) (string, error)) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).UpdateObjectTags(ctx, bucket, object, versionID, updateFn)
+}
+
+// TransitionObject - replaces a version of an object by a stub on the hashedSet based on the object name.
+func (s *xlSets) TransitionObject(ctx context.Context, bucket, object, versionID string, transition ObjectTransition) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).TransitionObject(ctx, bucket, object, versionID, transition)
+}
+
+// RestoreObject - restores the data of a transitioned version of an object on the hashedSet based on the object name.
+func (s *xlSets) RestoreObject(ctx context.Context, bucket, object, versionID string, expiry time.Time) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).RestoreObject(ctx, bucket, object, versionID, expiry)
+}
+
+// PutObjectReplicationStatus - records the replication status of a version of an object on the hashedSet based on the object name.
+func (s *xlSets) PutObjectReplicationStatus(ctx context.Context, bucket, object, versionID, etag string, status ReplicationStatus) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).PutObjectReplicationStatus(ctx, bucket, object, versionID, etag, status)
+}
+
+// PutDeleteMarker - adds a delete marker to an object on the hashedSet based on the object name.
+func (s *xlSets) PutDeleteMarker(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).PutDeleteMarker(ctx, bucket, object, versionID)
+}
+
+// DeleteObjectVersion - removes a version of an object from the hashedSet based on the object name.
+func (s *xlSets) DeleteObjectVersion(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).DeleteObjectVersion(ctx, bucket, object, versionID)
+}
+
 // CopyObject - copies objects from one hashedSet to another hashedSet, on server side.
 func (s *xlSets) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo) (objInfo ObjectInfo, err error) {
 	srcSet := s.getHashedSet(srcObject)
@@ -783,10 +925,23 @@ func (s *xlSets) ListObjects(ctx context.Context, bucket, prefix, marker, delimi
 	return result, nil
 }
 
+// ListMultipartUploads - lists the pending multipart uploads of all the sets.
 func (s *xlSets) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
-	// In list multipart uploads we are going to treat input prefix as the object,
-	// this means that we are not supporting directory navigation.
-	return s.getHashedSet(prefix).ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	if err = checkListMultipartArgs(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, s); err != nil {
+		return result, err
+	}
+
+	// Uploads of objects starting with prefix are hashed to any set, each
+	// set returns the uploads of the page it may contribute to.
+	var uploads []MultipartInfo
+	for _, set := range s.sets {
+		setUploads, err := set.listMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+		if err != nil {
+			return result, err
+		}
+		uploads = append(uploads, setUploads...)
+	}
+	return getListMultipartsInfo(uploads, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads), nil
 }
 
 // Initiate a new multipart upload on a hashedSet based on object name.
@@ -227,6 +227,11 @@ func (xl xlObjects) DeleteBucket(ctx context.Context, bucket string) error {
 	}
 	defer bucketLock.Unlock()
 
+	// Noncurrent versions of objects must be removed first.
+	if xl.hasObjectVersions(bucket) {
+		return BucketNotEmpty{Bucket: bucket}
+	}
+
 	// Collect if all disks report volume not found.
 	var wg = &sync.WaitGroup{}
 	var dErrs = make([]error, len(xl.getDisks()))
@@ -301,3 +306,28 @@ func (xl xlObjects) IsNotificationSupported() bool {
 func (xl xlObjects) IsEncryptionSupported() bool {
 	return true
 }
+
+// IsLifecycleSupported returns whether bucket lifecycle is applicable for this layer.
+func (xl xlObjects) IsLifecycleSupported() bool {
+	return true
+}
+
+// IsCorsSupported returns whether bucket CORS is applicable for this layer.
+func (xl xlObjects) IsCorsSupported() bool {
+	return true
+}
+
+// IsInventorySupported returns whether bucket inventory is applicable for this layer.
+func (xl xlObjects) IsInventorySupported() bool {
+	return true
+}
+
+// IsWebsiteSupported returns whether bucket website hosting is applicable for this layer.
+func (xl xlObjects) IsWebsiteSupported() bool {
+	return true
+}
+
+// IsLoggingSupported returns whether bucket access logging is applicable for this layer.
+func (xl xlObjects) IsLoggingSupported() bool {
+	return true
+}
@@ -173,20 +173,34 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 			continue
 		}
 
+		// Transitioned objects have no parts left locally, unless
+		// they are restored.
+		if transition, ok := getObjectTransition(partsMetadata[i].Meta); ok && !transition.IsRestored() {
+			availableDisks[i] = onlineDisk
+			continue
+		}
+
 		// disk has a valid xl.json but may not have all the
 		// parts. This is considered an outdated disk, since
 		// it needs healing too.
 		for _, part := range partsMetadata[i].Parts {
 			partPath := filepath.Join(object, part.Name)
 			checksumInfo := partsMetadata[i].Erasure.GetChecksumInfo(part.Name)
-			verifier := NewBitrotVerifier(checksumInfo.Algorithm, checksumInfo.Hash)
 
-			// verification happens even if a 0-length
-			// buffer is passed
-			_, hErr := onlineDisk.ReadFile(bucket, partPath, 0, buffer, verifier)
+			var hErr error
+			if checksumInfo.Algorithm.Streaming() {
+				erasure := partsMetadata[i].Erasure
+				hErr = verifyStreamingBitrotFile(onlineDisk, bucket, partPath, part.Size, erasure.BlockSize, erasure.DataBlocks, checksumInfo.Algorithm)
+			} else {
+				verifier := NewBitrotVerifier(checksumInfo.Algorithm, checksumInfo.Hash)
+
+				// verification happens even if a 0-length
+				// buffer is passed
+				_, hErr = onlineDisk.ReadFile(bucket, partPath, 0, buffer, verifier)
+			}
 
-			isCorrupt := false
-			if hErr != nil {
+			isCorrupt := hErr == errFileCorrupt
+			if hErr != nil && !isCorrupt {
 				isCorrupt = strings.HasPrefix(hErr.Error(), "Bitrot verification mismatch - expected ")
 			}
 			switch {
@@ -322,10 +322,36 @@ func TestDisksWithAllParts(t *testing.T) {
 	diskFailures[3] = "part.1"
 	diskFailures[15] = "part.2"
 
+	// Blocks of streaming bitrot protected parts are verified against
+	// the checksums stored along with them, so corrupt the part itself.
+	corruptPartFile := func(diskIndex int, partName string, restore bool) {
+		f, err := os.OpenFile(filepath.Join(disks[diskIndex], bucket, object, partName), os.O_RDWR, 0)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", partName, err)
+		}
+		defer f.Close()
+		b := make([]byte, 1)
+		if _, err = f.ReadAt(b, 0); err != nil {
+			t.Fatalf("Failed to read %s: %v", partName, err)
+		}
+		if restore {
+			b[0]--
+		} else {
+			b[0]++
+		}
+		if _, err = f.WriteAt(b, 0); err != nil {
+			t.Fatalf("Failed to write %s: %v", partName, err)
+		}
+	}
+
 	for diskIndex, partName := range diskFailures {
 		for index, info := range partsMetadata[diskIndex].Erasure.Checksums {
 			if info.Name == partName {
-				partsMetadata[diskIndex].Erasure.Checksums[index].Hash[0]++
+				if info.Algorithm.Streaming() {
+					corruptPartFile(diskIndex, partName, false)
+				} else {
+					partsMetadata[diskIndex].Erasure.Checksums[index].Hash[0]++
+				}
 			}
 		}
 	}
@@ -361,6 +387,11 @@ func TestDisksWithAllParts(t *testing.T) {
 
 	// Test that all disks are returned without any failures with
 	// unmodified meta data
+	for diskIndex, partName := range diskFailures {
+		if partsMetadata[diskIndex].Erasure.GetChecksumInfo(partName).Algorithm.Streaming() {
+			corruptPartFile(diskIndex, partName, true)
+		}
+	}
 	partsMetadata, errs = readAllXLMetadata(ctx, xlDisks, bucket, object)
 	if err != nil {
 		t.Fatalf("Failed to read xl meta data %v", err)
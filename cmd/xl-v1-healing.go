@@ -429,6 +429,10 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 					pathJoin(object, entry))
 			}
 		}
+
+		// The directory of a transitioned object holds no part
+		// and is left empty, remove it as well.
+		_ = disk.DeleteFile(bucket, object)
 	}
 
 	// Reorder so that we have data disks first and parity disks next.
@@ -453,7 +457,12 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 		return result, toObjectErr(err, bucket, object)
 	}
 	checksums := make([][]byte, len(latestDisks))
-	for partIndex := 0; partIndex < len(latestMeta.Parts); partIndex++ {
+
+	// Transitioned objects have no parts left locally unless they are
+	// restored, only their `xl.json` is healed.
+	transition, transitioned := getObjectTransition(latestMeta.Meta)
+	transitioned = transitioned && !transition.IsRestored()
+	for partIndex := 0; partIndex < len(latestMeta.Parts) && !transitioned; partIndex++ {
 		partName := latestMeta.Parts[partIndex].Name
 		partSize := latestMeta.Parts[partIndex].Size
 		erasure := latestMeta.Erasure
@@ -502,7 +511,9 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 			continue
 		}
 		partsMetadata[index] = latestMeta
-		partsMetadata[index].Erasure.Checksums = checksumInfos[index]
+		if !transitioned {
+			partsMetadata[index].Erasure.Checksums = checksumInfos[index]
+		}
 	}
 
 	// Generate and write `xl.json` generated from other disks.
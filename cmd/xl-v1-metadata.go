@@ -49,15 +49,20 @@ const (
 	HighwayHash256
 	// BLAKE2b512 represents the BLAKE2b-256 hash function
 	BLAKE2b512
+	// HighwayHash256S represents the streaming HighwayHash-256 hash
+	// function, each erasure coded block is preceded by its checksum
+	// on disk and verified while it is read.
+	HighwayHash256S
 )
 
 // DefaultBitrotAlgorithm is the default algorithm used for bitrot protection.
-var DefaultBitrotAlgorithm = HighwayHash256
+var DefaultBitrotAlgorithm = HighwayHash256S
 
 var bitrotAlgorithms = map[BitrotAlgorithm]string{
-	SHA256:         "sha256",
-	BLAKE2b512:     "blake2b",
-	HighwayHash256: "highwayhash256",
+	SHA256:          "sha256",
+	BLAKE2b512:      "blake2b",
+	HighwayHash256:  "highwayhash256",
+	HighwayHash256S: "highwayhash256S",
 }
 
 // New returns a new hash.Hash calculating the given bitrot algorithm.
@@ -70,7 +75,7 @@ func (a BitrotAlgorithm) New() hash.Hash {
 	case BLAKE2b512:
 		b2, _ := blake2b.New512(nil) // New512 never returns an error if the key is nil
 		return b2
-	case HighwayHash256:
+	case HighwayHash256, HighwayHash256S:
 		hh, _ := highwayhash.New(magicHighwayHash256Key) // New will never return error since key is 256 bit
 		return hh
 	}
@@ -84,6 +89,12 @@ func (a BitrotAlgorithm) Available() bool {
 	return ok
 }
 
+// Streaming reports whether the given algorithm protects each erasure
+// coded block of a file separately instead of the whole file.
+func (a BitrotAlgorithm) Streaming() bool {
+	return a == HighwayHash256S
+}
+
 // String returns the string identifier for a given bitrot algorithm.
 // If the algorithm is not supported String panics.
 func (a BitrotAlgorithm) String() string {
@@ -123,6 +134,8 @@ func (t byObjectPartNumber) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
 func (t byObjectPartNumber) Less(i, j int) bool { return t[i].Number < t[j].Number }
 
 // ChecksumInfo - carries checksums of individual scattered parts per disk.
+// Hash is empty for streaming algorithms, the checksums of the blocks of
+// a part are stored along with them.
 type ChecksumInfo struct {
 	Name      string
 	Algorithm BitrotAlgorithm
@@ -306,8 +319,15 @@ func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
 	// All the parts per object.
 	objInfo.Parts = m.Parts
 
-	// Update storage class
-	if sc, ok := m.Meta[amzStorageClass]; ok {
+	// Version of the object in a versioned bucket.
+	objInfo.VersionID = m.Meta[objectVersionIDKey]
+	objInfo.DeleteMarker = m.Meta[objectDeleteMarkerKey] == "true"
+
+	// Update storage class, transitioned objects are in the storage
+	// class of their tier.
+	if transition, ok := getObjectTransition(m.Meta); ok {
+		objInfo.StorageClass = transition.Tier
+	} else if sc, ok := m.Meta[amzStorageClass]; ok {
 		objInfo.StorageClass = sc
 	} else {
 		objInfo.StorageClass = globalMinioDefaultStorageClass
@@ -135,49 +135,314 @@ func commitXLMetadata(ctx context.Context, disks []StorageAPI, srcBucket, srcPre
 	return evalDisks(disks, mErrs), err
 }
 
-// ListMultipartUploads - lists all the pending multipart
-// uploads for a particular object in a bucket.
+// ListMultipartUploads - lists all the pending multipart uploads of
+// objects in a bucket starting with prefix.
 //
-// Implements minimal S3 compatible ListMultipartUploads API. We do
-// not support prefix based listing, this is a deliberate attempt
-// towards simplification of multipart APIs.
+// Uploads are listed in the order of their object name and then of their
+// initiation time. Listing resumes after keyMarker, or after the upload
+// uploadIDMarker of keyMarker, and uploads of objects sharing a common
+// prefix up to the delimiter are rolled up into it.
 // The resulting ListMultipartsInfo structure is unmarshalled directly as XML.
-func (xl xlObjects) ListMultipartUploads(ctx context.Context, bucket, object, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, e error) {
-	if err := checkListMultipartArgs(ctx, bucket, object, keyMarker, uploadIDMarker, delimiter, xl); err != nil {
+func (xl xlObjects) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, e error) {
+	if err := checkListMultipartArgs(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, xl); err != nil {
 		return result, err
 	}
 
-	result.MaxUploads = maxUploads
-	result.KeyMarker = keyMarker
-	result.Prefix = object
-	result.Delimiter = delimiter
+	uploads, err := xl.listMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	if err != nil {
+		return result, err
+	}
+	return getListMultipartsInfo(uploads, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads), nil
+}
 
-	for _, disk := range xl.getLoadBalancedDisks() {
-		if disk == nil {
-			continue
+// multipartObject - the pending uploads of an object.
+type multipartObject struct {
+	name      string
+	shaDir    string
+	uploadIDs []string
+}
+
+// multipartNames - the names, as bucket/object, of the objects of the
+// upload directories read by listMultipartUploads. The name of an
+// upload directory never changes as it is the hash of the name, so it
+// is only read once while the directory exists.
+type multipartNames struct {
+	mu    sync.Mutex
+	names map[string]string
+}
+
+func newMultipartNames() *multipartNames {
+	return &multipartNames{names: make(map[string]string)}
+}
+
+// get - returns the name of the object of the upload directory shaDir,
+// false if it was not read yet.
+func (m *multipartNames) get(shaDir string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name, ok := m.names[shaDir]
+	return name, ok
+}
+
+// set - records the name of the object of the upload directory shaDir.
+func (m *multipartNames) set(shaDir, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.names[shaDir] = name
+}
+
+// retain - forgets the names of the upload directories not in shaDirs,
+// which were removed once their last upload ended.
+func (m *multipartNames) retain(shaDirs map[string]struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for shaDir := range m.names {
+		if _, ok := shaDirs[shaDir]; !ok {
+			delete(m.names, shaDir)
 		}
-		uploadIDs, err := disk.ListDir(minioMetaMultipartBucket, xl.getMultipartSHADir(bucket, object), -1)
-		if err != nil {
-			if err == errFileNotFound {
-				return result, nil
+	}
+}
+
+// listMultipartUploads - returns the pending multipart uploads of objects
+// in a bucket starting with prefix which are listed after keyMarker and
+// uploadIDMarker, in the listing order. Uploads of objects rolled up
+// into a common prefix up to the delimiter are returned as a single
+// upload of the common prefix, and at most maxUploads+1 uploads and
+// common prefixes are returned.
+//
+// Uploads are stored under the SHA-256 of their bucket and object name,
+// so the name of the object of every upload directory is read from the
+// metadata of one of its uploads, once, and kept in xl.multipartNames.
+// The upload directories of other buckets and of objects not listed are
+// then skipped without reading them. The metadata of the uploads
+// themselves is only read, with read quorum, for the objects being
+// listed. Uploads initiated by older releases do not record their object
+// name and are only listed if prefix is the name of their object.
+func (xl xlObjects) listMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (uploads []MultipartInfo, err error) {
+	listDir := listDirFactory(ctx, func(string, string) bool { return false }, xl.getDisks()...)
+
+	shaDirs, _ := listDir(minioMetaMultipartBucket, "", "")
+	prefixSHADir := xl.getMultipartSHADir(bucket, prefix)
+
+	// listedObject - returns the name of the object of the uploads of
+	// shaDir, false if they are not listed.
+	listedObject := func(shaDir, name string) (string, bool) {
+		var object string
+		switch {
+		case name == "":
+			if shaDir != prefixSHADir {
+				return "", false
+			}
+			object = prefix
+		case hasPrefix(name, bucket+slashSeparator):
+			object = strings.TrimPrefix(name, bucket+slashSeparator)
+		default:
+			return "", false
+		}
+		if !hasPrefix(object, prefix) || object < keyMarker {
+			return "", false
+		}
+		if object == keyMarker && uploadIDMarker == "" {
+			return "", false
+		}
+		return object, true
+	}
+
+	var objects []multipartObject
+	existing := make(map[string]struct{}, len(shaDirs))
+	for _, shaDir := range shaDirs {
+		shaDir = strings.TrimSuffix(shaDir, slashSeparator)
+		existing[shaDir] = struct{}{}
+
+		name, known := xl.multipartNames.get(shaDir)
+		if known {
+			if _, ok := listedObject(shaDir, name); !ok {
+				continue
 			}
-			logger.LogIf(ctx, err)
-			return result, err
+		}
+
+		uploadIDs, _ := listDir(minioMetaMultipartBucket, shaDir, "")
+		// The last upload of the object may have just completed.
+		if len(uploadIDs) == 0 {
+			continue
 		}
 		for i := range uploadIDs {
 			uploadIDs[i] = strings.TrimSuffix(uploadIDs[i], slashSeparator)
 		}
-		sort.Strings(uploadIDs)
-		for _, uploadID := range uploadIDs {
-			if len(result.Uploads) == maxUploads {
-				break
+
+		if !known {
+			var ok bool
+			if name, ok = xl.getMultipartObject(ctx, shaDir, uploadIDs); !ok {
+				continue
 			}
-			result.Uploads = append(result.Uploads, MultipartInfo{Object: object, UploadID: uploadID})
+			// Uploads of older releases may be followed by uploads
+			// recording the name, it is read again until then.
+			if name != "" {
+				xl.multipartNames.set(shaDir, name)
+			}
+		}
+		object, ok := listedObject(shaDir, name)
+		if !ok {
+			continue
 		}
-		break
+		objects = append(objects, multipartObject{object, shaDir, uploadIDs})
 	}
+	xl.multipartNames.retain(existing)
 
-	return result, nil
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].name < objects[j].name
+	})
+
+	var lastPrefix string
+	for _, object := range objects {
+		if len(uploads) > maxUploads {
+			break
+		}
+		if delimiter != "" {
+			if i := strings.Index(object.name[len(prefix):], delimiter); i >= 0 {
+				commonPrefix := object.name[:len(prefix)+i+len(delimiter)]
+				// Skip a common prefix already listed by this page or the previous one.
+				if commonPrefix != lastPrefix && commonPrefix != keyMarker {
+					uploads = append(uploads, MultipartInfo{Object: commonPrefix})
+					lastPrefix = commonPrefix
+				}
+				continue
+			}
+		}
+
+		objectUploads, err := xl.readMultipartUploads(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+		if object.name == keyMarker {
+			next := len(objectUploads)
+			for i, upload := range objectUploads {
+				if upload.UploadID == uploadIDMarker {
+					next = i + 1
+					break
+				}
+			}
+			objectUploads = objectUploads[next:]
+		}
+		uploads = append(uploads, objectUploads...)
+	}
+	return uploads, nil
+}
+
+// getMultipartObject - returns the name, as bucket/object, of the object
+// of the uploads in shaDir, read from the metadata of the first of them
+// found on any disk. The name is empty for uploads initiated by older
+// releases, and false is returned if the uploads were all removed.
+func (xl xlObjects) getMultipartObject(ctx context.Context, shaDir string, uploadIDs []string) (string, bool) {
+	for _, uploadID := range uploadIDs {
+		_, meta, err := xl.readXLMetaStat(ctx, minioMetaMultipartBucket, pathJoin(shaDir, uploadID))
+		if err != nil {
+			continue
+		}
+		return meta[multipartUploadObjectKey], true
+	}
+	return "", false
+}
+
+// readMultipartUploads - returns the uploads of object sorted by their
+// initiation time, each read with read quorum. Uploads removed while
+// listing are skipped.
+func (xl xlObjects) readMultipartUploads(ctx context.Context, object multipartObject) (uploads []MultipartInfo, err error) {
+	for _, uploadID := range object.uploadIDs {
+		uploadIDPath := pathJoin(object.shaDir, uploadID)
+
+		// Read metadata associated with the upload from all disks.
+		metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), minioMetaMultipartBucket, uploadIDPath)
+
+		readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+		if err == errFileNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, toObjectErr(err, minioMetaMultipartBucket, uploadIDPath)
+		}
+		if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+			if reducedErr == errFileNotFound {
+				continue
+			}
+			return nil, toObjectErr(reducedErr, minioMetaMultipartBucket, uploadIDPath)
+		}
+
+		// Pick latest valid metadata.
+		modTime, _ := commonTime(listObjectModtimes(metaArr, errs))
+		xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime)
+		if err != nil {
+			return nil, err
+		}
+
+		upload := MultipartInfo{Object: object.name, UploadID: uploadID}
+		if upload.Initiated, err = time.Parse(time.RFC3339Nano, xlMeta.Meta[multipartUploadInitiatedKey]); err != nil {
+			upload.Initiated = xlMeta.Stat.ModTime
+		}
+		uploads = append(uploads, upload)
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		if !uploads[i].Initiated.Equal(uploads[j].Initiated) {
+			return uploads[i].Initiated.Before(uploads[j].Initiated)
+		}
+		return uploads[i].UploadID < uploads[j].UploadID
+	})
+	return uploads, nil
+}
+
+// getListMultipartsInfo - returns the page of uploads listed by
+// ListMultipartUploads from the uploads returned by listMultipartUploads,
+// possibly of several sets. Each common prefix counts as a single upload
+// towards maxUploads.
+func getListMultipartsInfo(uploads []MultipartInfo, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) ListMultipartsInfo {
+	result := ListMultipartsInfo{
+		KeyMarker:      keyMarker,
+		UploadIDMarker: uploadIDMarker,
+		MaxUploads:     maxUploads,
+		Prefix:         prefix,
+		Delimiter:      delimiter,
+	}
+
+	sort.Slice(uploads, func(i, j int) bool {
+		if uploads[i].Object != uploads[j].Object {
+			return uploads[i].Object < uploads[j].Object
+		}
+		if !uploads[i].Initiated.Equal(uploads[j].Initiated) {
+			return uploads[i].Initiated.Before(uploads[j].Initiated)
+		}
+		return uploads[i].UploadID < uploads[j].UploadID
+	})
+
+	var lastPrefix string
+	for _, upload := range uploads {
+		if delimiter != "" {
+			if i := strings.Index(upload.Object[len(prefix):], delimiter); i >= 0 {
+				commonPrefix := upload.Object[:len(prefix)+i+len(delimiter)]
+				// Skip a common prefix listed by another set.
+				if commonPrefix == lastPrefix {
+					continue
+				}
+				if len(result.Uploads)+len(result.CommonPrefixes) == maxUploads {
+					result.IsTruncated = true
+					break
+				}
+				result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix)
+				result.NextKeyMarker, result.NextUploadIDMarker = commonPrefix, ""
+				lastPrefix = commonPrefix
+				continue
+			}
+		}
+		if len(result.Uploads)+len(result.CommonPrefixes) == maxUploads {
+			result.IsTruncated = true
+			break
+		}
+		result.Uploads = append(result.Uploads, upload)
+		result.NextKeyMarker, result.NextUploadIDMarker = upload.Object, upload.UploadID
+	}
+	if !result.IsTruncated {
+		result.NextKeyMarker, result.NextUploadIDMarker = "", ""
+	}
+	return result
 }
 
 // newMultipartUpload - wrapper for initializing a new multipart
@@ -210,6 +475,7 @@ func (xl xlObjects) newMultipartUpload(ctx context.Context, bucket string, objec
 		meta["content-type"] = contentType
 	}
 	xlMeta.Stat.ModTime = UTCNow()
+	setMultipartUploadMeta(meta, bucket, object)
 	xlMeta.Meta = meta
 
 	uploadID := mustGetUUID()
@@ -699,6 +965,7 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 
 	// Save successfully calculated md5sum.
 	xlMeta.Meta["etag"] = s3MD5
+	removeMultipartUploadMeta(xlMeta.Meta)
 
 	tempUploadIDPath := uploadID
 
@@ -721,7 +988,12 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, toObjectErr(rErr, minioMetaMultipartBucket, uploadIDPath)
 	}
 
-	if xl.isObject(bucket, object) {
+	if versionID, ok := xlMeta.Meta[objectVersionIDKey]; ok {
+		// Keep an existing object as a noncurrent version.
+		if err = xl.archiveObject(ctx, bucket, object, versionID); err != nil {
+			return oi, toObjectErr(err, bucket, object)
+		}
+	} else if xl.isObject(bucket, object) {
 		// Rename if an object already exists to temporary location.
 		newUniqueID := mustGetUUID()
 
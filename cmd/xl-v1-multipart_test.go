@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -71,3 +72,155 @@ func TestXLCleanupStaleMultipartUploads(t *testing.T) {
 		}
 	}
 }
+
+// Tests listing multipart uploads of many objects page by page, for a
+// single erasure set and across sets.
+func TestXLListMultipartUploads(t *testing.T) {
+	root, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(root)
+
+	var objs []*xlObjects
+	for i := 0; i < 2; i++ {
+		obj, fsDirs, err := prepareXL16()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer removeRoots(fsDirs)
+		objs = append(objs, obj.(*xlObjects))
+	}
+
+	testXLListMultipartUploads(objs[0], "XL", "bucket", t)
+	testXLListMultipartUploads(&xlSets{sets: objs, distributionAlgo: "CRCMOD"}, "XLSets", "sets-bucket", t)
+
+	// Uploads missing on some of the disks are listed with read quorum.
+	xl := objs[0]
+	uploadID, err := xl.NewMultipartUpload(context.Background(), "bucket", "z", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, disk := range xl.getDisks()[:4] {
+		if err = disk.DeleteFile(minioMetaMultipartBucket, pathJoin(xl.getUploadIDDir("bucket", "z", uploadID), xlMetaJSONFile)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result, err := xl.ListMultipartUploads(context.Background(), "bucket", "z", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Uploads) != 1 || result.Uploads[0].UploadID != uploadID {
+		t.Errorf("Expected the upload %s, but instead found %v", uploadID, result.Uploads)
+	}
+
+	// Only the uploads of the page and the next one are read.
+	uploads, err := xl.listMultipartUploads(context.Background(), "bucket", "", "", "", "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uploads) != 2 {
+		t.Errorf("Expected 2 uploads, but instead found %d", len(uploads))
+	}
+
+	// The object names of the upload directories are only read once,
+	// and forgotten once the directories are removed.
+	shaDir := xl.getMultipartSHADir("bucket", "z")
+	if name, ok := xl.multipartNames.get(shaDir); !ok || name != "bucket/z" {
+		t.Errorf("Expected the name bucket/z to be kept, but instead found %q", name)
+	}
+	xl.multipartNames.set(shaDir, "bucket/y")
+	if uploads, err = xl.listMultipartUploads(context.Background(), "bucket", "y", "", "", "", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if len(uploads) != 1 || uploads[0].Object != "y" || uploads[0].UploadID != uploadID {
+		t.Errorf("Expected the upload %s to be listed with the kept name, but instead found %v", uploadID, uploads)
+	}
+	if err = xl.AbortMultipartUpload(context.Background(), "bucket", "z", uploadID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = xl.listMultipartUploads(context.Background(), "bucket", "", "", "", "", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if name, ok := xl.multipartNames.get(shaDir); ok {
+		t.Errorf("Expected the name of a removed upload directory to be forgotten, but instead found %q", name)
+	}
+}
+
+func testXLListMultipartUploads(obj ObjectLayer, instanceType, bucketName string, t *testing.T) {
+	if err := obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err := obj.MakeBucketWithLocation(context.Background(), bucketName+"-other", ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	// Uploads of another bucket are never listed.
+	if _, err := obj.NewMultipartUpload(context.Background(), bucketName+"-other", "a/1", nil); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	var uploads []string
+	for _, object := range []string{"a/1", "a/2", "b", "b", "c/d/e", "c/f"} {
+		uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, object, nil)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		uploads = append(uploads, object+" "+uploadID)
+		// Uploads of an object are listed by initiation time.
+		time.Sleep(time.Millisecond)
+	}
+
+	// listPages - returns the uploads and common prefixes of all the
+	// pages, and the number of pages.
+	listPages := func(prefix, delimiter string, maxUploads int) (entries []string, pages int) {
+		var keyMarker, uploadIDMarker string
+		for {
+			result, err := obj.ListMultipartUploads(context.Background(), bucketName, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+			if err != nil {
+				t.Fatalf("%s: %s", instanceType, err)
+			}
+			pages++
+			if len(result.Uploads)+len(result.CommonPrefixes) > maxUploads {
+				t.Fatalf("%s: Expected at most %d uploads, but instead found %d", instanceType, maxUploads, len(result.Uploads)+len(result.CommonPrefixes))
+			}
+			for _, upload := range result.Uploads {
+				if upload.Initiated.IsZero() {
+					t.Errorf("%s: Expected the initiation time of %s", instanceType, upload.Object)
+				}
+				entries = append(entries, upload.Object+" "+upload.UploadID)
+			}
+			entries = append(entries, result.CommonPrefixes...)
+			if !result.IsTruncated {
+				return entries, pages
+			}
+			keyMarker, uploadIDMarker = result.NextKeyMarker, result.NextUploadIDMarker
+		}
+	}
+
+	testCases := []struct {
+		prefix          string
+		delimiter       string
+		maxUploads      int
+		expectedEntries []string
+		expectedPages   int
+	}{
+		{"", "", 1000, uploads, 1},
+		{"", "", 1, uploads, 6},
+		{"", "", 4, uploads, 2},
+		{"a/", "", 1000, uploads[:2], 1},
+		{"b", "", 1, uploads[2:4], 2},
+		{"", "/", 1000, []string{uploads[2], uploads[3], "a/", "c/"}, 1},
+		{"", "/", 1, []string{"a/", uploads[2], uploads[3], "c/"}, 4},
+		{"c/", "/", 1000, []string{uploads[5], "c/d/"}, 1},
+		{"d", "", 1000, nil, 1},
+	}
+	for i, testCase := range testCases {
+		entries, pages := listPages(testCase.prefix, testCase.delimiter, testCase.maxUploads)
+		if !reflect.DeepEqual(entries, testCase.expectedEntries) {
+			t.Errorf("%s: Test %d: Expected uploads %v, but instead found %v", instanceType, i+1, testCase.expectedEntries, entries)
+		}
+		if pages != testCase.expectedPages {
+			t.Errorf("%s: Test %d: Expected %d pages, but instead found %d", instanceType, i+1, testCase.expectedPages, pages)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+)
+
+// AppendObject - appends data to the object if its size is position, or
+// creates the object with metadata if it does not exist and position
+// is 0. The data is erasure coded into a new part of the object which
+// is only visible once `xl.json` is committed with it.
+func (xl xlObjects) AppendObject(ctx context.Context, bucket, object string, position int64, data *hash.Reader, metadata map[string]string) (objInfo ObjectInfo, err error) {
+	if err = checkPutObjectArgs(ctx, bucket, object, xl, data.Size()); err != nil {
+		return objInfo, err
+	}
+
+	// Lock the object.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalObjectTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if objInfo, err = xl.getObjectInfo(ctx, bucket, object); err != nil {
+		err = toObjectErr(err, bucket, object)
+		if !isErrObjectNotFound(err) {
+			return objInfo, err
+		}
+		if position != 0 {
+			return objInfo, InvalidAppendPosition{Bucket: bucket, Object: object}
+		}
+		return xl.putObject(ctx, bucket, object, data, metadata)
+	}
+	if err = checkAppendObject(objInfo, position); err != nil {
+		return objInfo, err
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		logger.LogIf(ctx, ObjectAlreadyExists{Bucket: bucket, Object: object})
+		return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+	}
+
+	if data.Size() == 0 {
+		return objInfo, nil
+	}
+
+	if err = xl.appendObjectPart(ctx, bucket, object, data); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	if objInfo, err = xl.getObjectInfo(ctx, bucket, object); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	return objInfo, nil
+}
+
+// appendObjectPart - erasure codes the data into a new last part of the
+// object, written with the distribution of its other parts.
+func (xl xlObjects) appendObjectPart(ctx context.Context, bucket, object string, data *hash.Reader) error {
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+	_, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return err
+	}
+	if err = reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); err != nil {
+		return err
+	}
+
+	onlineDisks, modTime := listOnlineDisks(xl.getDisks(), metaArr, errs)
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime)
+	if err != nil {
+		return err
+	}
+
+	// Order disks and metadata as the parts were written.
+	onlineDisks = shuffleDisks(onlineDisks, xlMeta.Erasure.Distribution)
+	metaArr = shufflePartsMetadata(metaArr, xlMeta.Erasure.Distribution)
+
+	storage, err := NewErasureStorage(ctx, onlineDisks, xlMeta.Erasure.DataBlocks, xlMeta.Erasure.ParityBlocks, xlMeta.Erasure.BlockSize)
+	if err != nil {
+		return err
+	}
+
+	partNumber := 1
+	if len(xlMeta.Parts) > 0 {
+		partNumber = xlMeta.Parts[len(xlMeta.Parts)-1].Number + 1
+	}
+	if isMaxPartID(partNumber) {
+		return TooManyAppendedParts{Bucket: bucket, Object: object}
+	}
+	partName := fmt.Sprintf("part.%d", partNumber)
+
+	tempObj := mustGetUUID()
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj)
+	tempPart := pathJoin(tempObj, partName)
+
+	if err = xl.prepareFile(ctx, minioMetaTmpBucket, tempPart, data.Size(), storage.disks, xlMeta.Erasure.BlockSize, xlMeta.Erasure.DataBlocks, writeQuorum); err != nil {
+		return err
+	}
+
+	var buffer []byte
+	if size := data.Size(); size < blockSizeV1 {
+		// No need to allocate fully blockSizeV1 buffer if the incoming data is smaller.
+		buffer = make([]byte, size, 2*size)
+	} else {
+		buffer = xl.bp.Get()
+		defer xl.bp.Put(buffer)
+	}
+
+	file, err := storage.CreateFile(ctx, data, minioMetaTmpBucket, tempPart, buffer, DefaultBitrotAlgorithm, writeQuorum)
+	if err != nil {
+		return err
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer bytes
+	// than specified in request header.
+	if file.Size < data.Size() {
+		logger.LogIf(ctx, IncompleteBody{})
+		return IncompleteBody{}
+	}
+
+	partsCount := len(xlMeta.Parts) + 1
+	modTime = UTCNow()
+	for index := range metaArr {
+		if storage.disks[index] == nil {
+			continue
+		}
+		metaArr[index].AddObjectPart(partNumber, partName, hex.EncodeToString(data.MD5Current()), file.Size)
+		metaArr[index].Erasure.AddChecksumInfo(ChecksumInfo{partName, file.Algorithm, file.Checksums[index]})
+		metaArr[index].Stat.Size += file.Size
+		metaArr[index].Stat.ModTime = modTime
+		setAppendObjectMeta(metaArr[index].Meta, data, partsCount)
+	}
+
+	// Stage the new `xl.json` next to the part, as putObject does, so
+	// that only the renames into the object remain to be done.
+	if onlineDisks, err = writeUniqueXLMetadata(ctx, storage.disks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return err
+	}
+
+	// The object already exists so it can't be renamed at once, the part
+	// is renamed first and is only referenced once `xl.json` is renamed.
+	// A part left behind by a crash in between has the name of the next
+	// appended part, it is overwritten by it or removed with the object.
+	if onlineDisks, err = renamePart(ctx, onlineDisks, minioMetaTmpBucket, tempPart, bucket, pathJoin(object, partName), writeQuorum); err != nil {
+		return err
+	}
+	if _, err = renameXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum); err != nil {
+		// Remove the part which is not referenced by `xl.json`.
+		for _, disk := range onlineDisks {
+			if disk != nil {
+				disk.DeleteFile(bucket, pathJoin(object, partName))
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// IsAppendSupported returns whether appending to objects is applicable for this layer.
+func (xl xlObjects) IsAppendSupported() bool {
+	return true
+}
@@ -0,0 +1,40 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "context"
+
+// PutObjectRetention - sets the retention of a version of the object,
+// its latest version if versionID is empty.
+func (xl xlObjects) PutObjectRetention(ctx context.Context, bucket, object, versionID string, retention ObjectRetention) (ObjectInfo, error) {
+	checkFn := func(objInfo ObjectInfo) error {
+		return checkObjectRetentionUpdate(ctx, objInfo, retention)
+	}
+	updateFn := func(metadata map[string]string) {
+		setObjectRetention(metadata, retention)
+	}
+	return xl.updateObjectVersionMeta(ctx, bucket, object, versionID, checkFn, updateFn)
+}
+
+// PutObjectLegalHold - puts a version of the object on legal hold or
+// removes its legal hold, its latest version if versionID is empty.
+func (xl xlObjects) PutObjectLegalHold(ctx context.Context, bucket, object, versionID string, legalHold ObjectLegalHold) (ObjectInfo, error) {
+	updateFn := func(metadata map[string]string) {
+		setObjectLegalHold(metadata, legalHold)
+	}
+	return xl.updateObjectVersionMeta(ctx, bucket, object, versionID, nil, updateFn)
+}
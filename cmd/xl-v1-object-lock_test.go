@@ -0,0 +1,164 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// Tests that versions under retention can't be deleted until their
+// retain until date.
+func TestXLObjectRetention(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err = enableBucketObjectLock(ctx, obj, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	now := UTCNow().Truncate(time.Second)
+	putVersion := func(retention ObjectRetention) string {
+		versionID := mustGetUUID()
+		metadata := map[string]string{objectVersionIDKey: versionID}
+		setObjectRetention(metadata, retention)
+		if _, perr := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader([]byte(versionID)), int64(len(versionID)), "", ""), metadata); perr != nil {
+			t.Fatal(perr)
+		}
+		return versionID
+	}
+	expectLocked := func(err error) {
+		t.Helper()
+		if _, ok := err.(ObjectLocked); !ok {
+			t.Fatalf("expected ObjectLocked, got %v", err)
+		}
+	}
+
+	expired := putVersion(ObjectRetention{Mode: RetentionCompliance, RetainUntilDate: now.Add(-time.Hour)})
+	locked := putVersion(ObjectRetention{Mode: RetentionGovernance, RetainUntilDate: now.Add(time.Hour)})
+
+	_, err = obj.DeleteObjectVersion(ctx, bucket, object, locked)
+	expectLocked(err)
+	expectLocked(obj.DeleteObject(ctx, bucket, object))
+
+	// Retentions in effect can only be extended.
+	_, err = obj.PutObjectRetention(ctx, bucket, object, locked, ObjectRetention{Mode: RetentionGovernance, RetainUntilDate: now.Add(time.Minute)})
+	expectLocked(err)
+	objInfo, err := obj.PutObjectRetention(ctx, bucket, object, "", ObjectRetention{Mode: RetentionCompliance, RetainUntilDate: now.Add(2 * time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retention, ok := getObjectRetention(objInfo.UserDefined); !ok || retention.Mode != RetentionCompliance || !retention.RetainUntilDate.Equal(now.Add(2*time.Hour)) {
+		t.Fatalf("unexpected retention %v", objInfo.UserDefined)
+	}
+	_, err = obj.PutObjectRetention(ctx, bucket, object, locked, ObjectRetention{Mode: RetentionGovernance, RetainUntilDate: now.Add(3 * time.Hour)})
+	expectLocked(err)
+
+	// Delete markers can always be added and removed.
+	marker := mustGetUUID()
+	if _, err = obj.PutDeleteMarker(ctx, bucket, object, marker); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.DeleteObjectVersion(ctx, bucket, object, marker); err != nil {
+		t.Fatal(err)
+	}
+
+	// Versions whose retention expired can be deleted.
+	if _, err = obj.DeleteObjectVersion(ctx, bucket, object, expired); err != nil {
+		t.Fatal(err)
+	}
+	if objInfo, err = obj.GetObjectVersionInfo(ctx, bucket, object, locked); err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.DeleteMarker {
+		t.Fatalf("unexpected delete marker %+v", objInfo)
+	}
+}
+
+// Tests that versions on legal hold can't be deleted until the legal
+// hold is removed.
+func TestXLObjectLegalHold(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err = enableBucketObjectLock(ctx, obj, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	versionID := mustGetUUID()
+	metadata := map[string]string{objectVersionIDKey: versionID}
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader([]byte("data")), 4, "", ""), metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	objInfo, err := obj.PutObjectLegalHold(ctx, bucket, object, versionID, ObjectLegalHold{Status: LegalHoldOn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if legalHold, ok := getObjectLegalHold(objInfo.UserDefined); !ok || legalHold.Status != LegalHoldOn {
+		t.Fatalf("unexpected legal hold %v", objInfo.UserDefined)
+	}
+	if _, err = obj.DeleteObjectVersion(ctx, bucket, object, versionID); err == nil {
+		t.Fatal("expected version on legal hold not to be deleted")
+	} else if _, ok := err.(ObjectLocked); !ok {
+		t.Fatalf("expected ObjectLocked, got %v", err)
+	}
+
+	if _, err = obj.PutObjectLegalHold(ctx, bucket, object, "", ObjectLegalHold{Status: LegalHoldOff}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.DeleteObjectVersion(ctx, bucket, object, versionID); err != nil {
+		t.Fatal(err)
+	}
+}
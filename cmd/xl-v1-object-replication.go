@@ -0,0 +1,41 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "context"
+
+// PutObjectReplicationStatus - records the replication status of a
+// version of the object, its latest version if versionID is empty. The
+// status is not recorded if the version was overwritten since its
+// replication started, i.e. if its ETag changed.
+func (xl xlObjects) PutObjectReplicationStatus(ctx context.Context, bucket, object, versionID, etag string, status ReplicationStatus) (ObjectInfo, error) {
+	checkFn := func(objInfo ObjectInfo) error {
+		if objInfo.ETag != etag {
+			return InvalidETag{}
+		}
+		return nil
+	}
+	updateFn := func(metadata map[string]string) {
+		setObjectReplicationStatus(metadata, status)
+	}
+	return xl.updateObjectVersionMeta(ctx, bucket, object, versionID, checkFn, updateFn)
+}
+
+// IsReplicationSupported returns whether bucket replication is applicable for this layer.
+func (xl xlObjects) IsReplicationSupported() bool {
+	return true
+}
@@ -0,0 +1,55 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "context"
+
+// PutObjectTags - replaces the tags of a version of the object, its
+// latest version if versionID is empty, empty tags remove them.
+func (xl xlObjects) PutObjectTags(ctx context.Context, bucket, object, versionID, tags string) (ObjectInfo, error) {
+	updateFn := func(metadata map[string]string) {
+		setObjectTags(metadata, tags)
+	}
+	return xl.updateObjectVersionMeta(ctx, bucket, object, versionID, nil, updateFn)
+}
+
+// UpdateObjectTags - replaces the tags of a version of the object by the
+// ones returned by updateFn from its current tags, which are read and
+// written under the object lock. Unchanged tags are not written.
+func (xl xlObjects) UpdateObjectTags(ctx context.Context, bucket, object, versionID string, updateFn func(tags string) (string, error)) (ObjectInfo, error) {
+	var tags string
+	checkFn := func(objInfo ObjectInfo) (err error) {
+		current := objInfo.UserDefined[objectTaggingKey]
+		if tags, err = updateFn(current); err == nil && tags == current {
+			err = errObjectTagsUnchanged
+		}
+		return err
+	}
+	setFn := func(metadata map[string]string) {
+		setObjectTags(metadata, tags)
+	}
+	objInfo, err := xl.updateObjectVersionMeta(ctx, bucket, object, versionID, checkFn, setFn)
+	if err == errObjectTagsUnchanged {
+		err = nil
+	}
+	return objInfo, err
+}
+
+// IsTaggingSupported returns whether object tagging is applicable for this layer.
+func (xl xlObjects) IsTaggingSupported() bool {
+	return true
+}
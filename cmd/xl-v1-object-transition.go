@@ -0,0 +1,231 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+// TransitionObject - records that the data of a version of the object,
+// its latest version if versionID is empty, was moved to a tier and
+// removes its parts. `xl.json` is kept as a stub, reads of the version
+// are served from the tier.
+func (xl xlObjects) TransitionObject(ctx context.Context, bucket, object, versionID string, transition ObjectTransition) (objInfo ObjectInfo, err error) {
+	// Lock the object before replacing its parts.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	if versionID == "" {
+		if objInfo, err = xl.getObjectInfo(ctx, bucket, object); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		objInfo.VersionID, objInfo.IsLatest = getVersionID(objInfo.UserDefined), true
+	} else if objInfo, err = xl.getObjectVersionInfo(ctx, bucket, object, versionID); err != nil {
+		return objInfo, err
+	}
+	if objInfo.DeleteMarker {
+		return objInfo, MethodNotAllowed{Bucket: bucket, Object: object}
+	}
+
+	// The object version was overwritten or transitioned meanwhile.
+	if _, ok := getObjectTransition(objInfo.UserDefined); ok || objInfo.ETag != transition.ETag {
+		return objInfo, InvalidETag{}
+	}
+
+	updateFn := func(metadata map[string]string) {
+		setObjectTransition(metadata, transition)
+	}
+	volume, path := getObjectVersionLocation(objInfo)
+	if err = xl.updateObjectMeta(ctx, volume, path, updateFn); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	updateFn(objInfo.UserDefined)
+
+	xl.deleteObjectParts(ctx, volume, path, objInfo.Parts)
+
+	objInfo.StorageClass = transition.Tier
+	return objInfo, nil
+}
+
+// deleteObjectParts - removes the part files of an object whose data is
+// stored in a tier. Parts left behind on offline disks are never read
+// again and are removed along with the object.
+func (xl xlObjects) deleteObjectParts(ctx context.Context, volume, path string, parts []objectPartInfo) {
+	for _, disk := range xl.getDisks() {
+		if disk == nil {
+			continue
+		}
+		for _, part := range parts {
+			if derr := disk.DeleteFile(volume, pathJoin(path, part.Name)); derr != nil && derr != errFileNotFound {
+				logger.LogIf(ctx, derr)
+			}
+		}
+	}
+}
+
+// RestoreObject - restores the data of a transitioned version of the
+// object, its latest version if versionID is empty, from its tier until
+// expiry. The parts are written back as they were before the transition
+// and reads of the version are served locally. Restoring a restored
+// version only updates its expiry, a zero expiry removes the parts again.
+func (xl xlObjects) RestoreObject(ctx context.Context, bucket, object, versionID string, expiry time.Time) (objInfo ObjectInfo, err error) {
+	// Lock the object before replacing its parts.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	if versionID == "" {
+		if objInfo, err = xl.getObjectInfo(ctx, bucket, object); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		objInfo.VersionID, objInfo.IsLatest = getVersionID(objInfo.UserDefined), true
+	} else if objInfo, err = xl.getObjectVersionInfo(ctx, bucket, object, versionID); err != nil {
+		return objInfo, err
+	}
+	if objInfo.DeleteMarker {
+		return objInfo, MethodNotAllowed{Bucket: bucket, Object: object}
+	}
+
+	transition, ok := getObjectTransition(objInfo.UserDefined)
+	if !ok {
+		return objInfo, ObjectNotTransitioned{Bucket: bucket, Object: object}
+	}
+	restored := transition.IsRestored()
+	if !restored && expiry.IsZero() {
+		return objInfo, nil
+	}
+
+	volume, path := getObjectVersionLocation(objInfo)
+	transition.RestoreExpiry = expiry
+	updateFn := func(metadata map[string]string) {
+		setObjectTransition(metadata, transition)
+	}
+
+	// Only the expiry changes, or the restored copy is removed once its
+	// expiry is recorded.
+	if restored {
+		if err = xl.updateObjectMeta(ctx, volume, path, updateFn); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		if expiry.IsZero() {
+			xl.deleteObjectParts(ctx, volume, path, objInfo.Parts)
+		}
+		updateFn(objInfo.UserDefined)
+		return objInfo, nil
+	}
+
+	if err = xl.restoreObjectParts(ctx, volume, path, transition, updateFn); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	updateFn(objInfo.UserDefined)
+	return objInfo, nil
+}
+
+// restoreObjectParts - reads the data of the transitioned object from its
+// tier and erasure codes it into the parts recorded in `xl.json`, which
+// is updated with updateFn once all parts are written back.
+func (xl xlObjects) restoreObjectParts(ctx context.Context, volume, path string, transition ObjectTransition, updateFn func(map[string]string)) error {
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), volume, path)
+	_, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return err
+	}
+	if err = reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); err != nil {
+		return err
+	}
+
+	onlineDisks, modTime := listOnlineDisks(xl.getDisks(), metaArr, errs)
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime)
+	if err != nil {
+		return err
+	}
+
+	// Order disks and metadata as the parts were written.
+	onlineDisks = shuffleDisks(onlineDisks, xlMeta.Erasure.Distribution)
+	metaArr = shufflePartsMetadata(metaArr, xlMeta.Erasure.Distribution)
+
+	storage, err := NewErasureStorage(ctx, onlineDisks, xlMeta.Erasure.DataBlocks, xlMeta.Erasure.ParityBlocks, xlMeta.Erasure.BlockSize)
+	if err != nil {
+		return err
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.CloseWithError(getTransitionedObject(ctx, transition, 0, xlMeta.Stat.Size, pipeWriter))
+	}()
+	defer pipeReader.Close()
+
+	tempObj := mustGetUUID()
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj)
+
+	buffer := xl.bp.Get()
+	defer xl.bp.Put(buffer)
+
+	for _, part := range xlMeta.Parts {
+		file, cerr := storage.CreateFile(ctx, io.LimitReader(pipeReader, part.Size), minioMetaTmpBucket,
+			pathJoin(tempObj, part.Name), buffer, DefaultBitrotAlgorithm, writeQuorum)
+		if cerr != nil {
+			return cerr
+		}
+		if file.Size < part.Size {
+			logger.LogIf(ctx, IncompleteBody{})
+			return IncompleteBody{}
+		}
+		for index := range metaArr {
+			metaArr[index].Erasure.AddChecksumInfo(ChecksumInfo{part.Name, file.Algorithm, file.Checksums[index]})
+		}
+	}
+
+	for _, part := range xlMeta.Parts {
+		if onlineDisks, err = renamePart(ctx, onlineDisks, minioMetaTmpBucket, pathJoin(tempObj, part.Name), volume, pathJoin(path, part.Name), writeQuorum); err != nil {
+			return err
+		}
+	}
+
+	for index := range metaArr {
+		if onlineDisks[index] != nil {
+			updateFn(metaArr[index].Meta)
+		}
+	}
+	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return err
+	}
+	_, err = renameXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, volume, path, writeQuorum)
+	return err
+}
+
+// IsTransitionSupported returns whether lifecycle transition is applicable for this layer.
+func (xl xlObjects) IsTransitionSupported() bool {
+	return true
+}
@@ -61,6 +61,10 @@ func (xl xlObjects) prepareFile(ctx context.Context, bucket, object string, size
 	pErrs := make([]error, len(onlineDisks))
 	// Calculate the real size of the part in one disk.
 	actualSize := xl.sizeOnDisk(size, blockSize, dataBlocks)
+	// Streaming bitrot protection stores the checksum of each block too.
+	if DefaultBitrotAlgorithm.Streaming() && size > 0 {
+		actualSize += ceilFrac(size, blockSize) * int64(DefaultBitrotAlgorithm.New().Size())
+	}
 	// Prepare object creation in a all disks
 	for index, disk := range onlineDisks {
 		if disk != nil {
@@ -243,6 +247,12 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return InvalidRange{startOffset, length, xlMeta.Stat.Size}
 	}
 
+	// The data of transitioned objects is read from their tier, unless
+	// it is restored.
+	if transition, ok := getObjectTransition(xlMeta.Meta); ok && !transition.IsRestored() {
+		return toObjectErr(getTransitionedObject(ctx, transition, startOffset, length, writer), bucket, object)
+	}
+
 	// Get start part index and offset.
 	partIndex, partOffset, err := xlMeta.ObjectToPartOffset(ctx, startOffset)
 	if err != nil {
@@ -586,6 +596,19 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		return ObjectInfo{}, toObjectErr(errFileAccessDenied, bucket, object)
 	}
 
+	// Create the object only if it does not exist, the object lock taken
+	// by PutObject keeps it from being created meanwhile. The object exists
+	// only if a read quorum of disks has its `xl.json`, a stale `xl.json`
+	// left on some disks does not count.
+	if isPutIfAbsent(ctx) {
+		if _, err = xl.getObjectInfo(ctx, bucket, object); err == nil {
+			return ObjectInfo{}, PreconditionFailed{Bucket: bucket, Object: object}
+		}
+		if err = toObjectErr(err, bucket, object); !isErrObjectNotFound(err) {
+			return ObjectInfo{}, err
+		}
+	}
+
 	// Limit the reader to its provided size if specified.
 	var reader io.Reader = data
 
@@ -693,7 +716,24 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		}
 	}
 
-	if xl.isObject(bucket, object) {
+	if versionID, ok := metadata[objectVersionIDKey]; ok && !isMinioMetaBucketName(bucket) {
+		// Keep an existing object as a noncurrent version.
+		if err = xl.archiveObject(ctx, bucket, object, versionID); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	} else if xl.isObject(bucket, object) {
+		// Objects of buckets with object lock enabled may be protected,
+		// even from writers which don't keep them as noncurrent versions.
+		if isObjectLockEnabled(bucket) {
+			objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+			if err != nil {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+			if err = checkObjectLocked(objInfo); err != nil {
+				return ObjectInfo{}, err
+			}
+		}
+
 		// Rename if an object already exists to temporary location.
 		newUniqueID := mustGetUUID()
 
@@ -749,6 +789,7 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		ContentType:     xlMeta.Meta["content-type"],
 		ContentEncoding: xlMeta.Meta["content-encoding"],
 		UserDefined:     xlMeta.Meta,
+		VersionID:       xlMeta.Meta[objectVersionIDKey],
 	}
 
 	// Success, return object info.
@@ -844,6 +885,17 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 		return ObjectNotFound{bucket, object}
 	} // else proceed to delete the object.
 
+	// Objects of buckets with object lock enabled may be protected.
+	if isObjectLockEnabled(bucket) {
+		objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+		if err != nil {
+			return toObjectErr(err, bucket, object)
+		}
+		if err = checkObjectDeletable(ctx, objInfo); err != nil {
+			return err
+		}
+	}
+
 	// Delete the object on all disks.
 	if err = xl.deleteObject(ctx, bucket, object); err != nil {
 		return toObjectErr(err, bucket, object)
@@ -260,6 +260,87 @@ func TestPutObjectNoQuorum(t *testing.T) {
 	removeRoots(fsDirs)
 }
 
+// Tests that locked objects are not replaced by writes which don't keep
+// them as noncurrent versions.
+func TestPutObjectLocked(t *testing.T) {
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	defer func() { globalBucketMetadataSys = NewBucketMetadataSys() }()
+
+	bucket := "bucket"
+	object := "object"
+	if err = obj.MakeBucketWithLocation(context.Background(), bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketMetadataSys.Set(bucket, bucketObjectLockConfig, []byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+
+	metadata := make(map[string]string)
+	setObjectLegalHold(metadata, ObjectLegalHold{Status: LegalHoldOn})
+	if _, err = obj.PutObject(context.Background(), bucket, object, mustGetHashReader(t, bytes.NewReader([]byte("abcd")), int64(len("abcd")), "", ""), metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = obj.PutObject(context.Background(), bucket, object, mustGetHashReader(t, bytes.NewReader([]byte("efgh")), int64(len("efgh")), "", ""), nil)
+	if _, ok := err.(ObjectLocked); !ok {
+		t.Fatalf("Expected putObject to fail with ObjectLocked, but failed with %v", err)
+	}
+
+	buffer := new(bytes.Buffer)
+	if err = obj.GetObject(context.Background(), bucket, object, 0, 4, buffer, ""); err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != "abcd" {
+		t.Fatalf("Expected the locked object to be kept, found %q", buffer.String())
+	}
+}
+
+// Tests that put-if-absent creates an object whose `xl.json` is only
+// left on a minority of disks.
+func TestPutObjectIfAbsentStaleXLMeta(t *testing.T) {
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	object := "object"
+	if err = obj.MakeBucketWithLocation(context.Background(), bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(context.Background(), bucket, object, mustGetHashReader(t, bytes.NewReader([]byte("abcd")), int64(len("abcd")), "", ""), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Keep a stale `xl.json` on the first disk only.
+	for _, fsDir := range fsDirs[1:] {
+		if err = os.RemoveAll(path.Join(fsDir, bucket, object)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err = obj.PutObject(withPutIfAbsent(context.Background()), bucket, object, mustGetHashReader(t, bytes.NewReader([]byte("efgh")), int64(len("efgh")), "", ""), nil); err != nil {
+		t.Fatalf("Expected the object to be created, but failed with %v", err)
+	}
+	buffer := new(bytes.Buffer)
+	if err = obj.GetObject(context.Background(), bucket, object, 0, 4, buffer, ""); err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != "efgh" {
+		t.Fatalf("Expected the created object, found %q", buffer.String())
+	}
+
+	_, err = obj.PutObject(withPutIfAbsent(context.Background()), bucket, object, mustGetHashReader(t, bytes.NewReader([]byte("ijkl")), int64(len("ijkl")), "", ""), nil)
+	if _, ok := err.(PreconditionFailed); !ok {
+		t.Fatalf("Expected putObject to fail with PreconditionFailed, but failed with %v", err)
+	}
+}
+
 // Tests both object and bucket healing.
 func TestHealing(t *testing.T) {
 	rootPath, err := newTestConfig(globalMinioDefaultRegion)
@@ -0,0 +1,656 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/pkg/hash"
+)
+
+// The current version of an object in a versioned bucket is kept at its
+// usual location, noncurrent versions and delete markers are kept in
+// minioMetaBucket under this prefix, i.e. at
+// "versions/<bucket>/<sha256 of object>/<version-id>". An object whose
+// latest version is a delete marker has no current version.
+const objectVersionsPrefix = "versions"
+
+// getObjectVersionsDir - returns the path to the noncurrent versions of
+// the object in minioMetaBucket.
+func getObjectVersionsDir(bucket, object string) string {
+	return pathJoin(objectVersionsPrefix, bucket, getSHA256Hash([]byte(object)))
+}
+
+// getObjectVersionPath - returns the path to a noncurrent version of the
+// object in minioMetaBucket.
+func getObjectVersionPath(bucket, object, versionID string) string {
+	return pathJoin(getObjectVersionsDir(bucket, object), versionID)
+}
+
+// getVersionID - returns the version ID recorded in metadata, objects
+// written before versioning was enabled have the null version ID.
+func getVersionID(metadata map[string]string) string {
+	if versionID := metadata[objectVersionIDKey]; versionID != "" {
+		return versionID
+	}
+	return nullVersionID
+}
+
+// toVersionErr - converts a not found error of a version to VersionNotFound.
+func toVersionErr(err error, bucket, object, versionID string) error {
+	switch toObjectErr(err, bucket, object).(type) {
+	case ObjectNotFound, BucketNotFound:
+		return VersionNotFound{Bucket: bucket, Object: object, VersionID: versionID}
+	}
+	return err
+}
+
+// getObjectVersionLocation - returns the volume and path of a version of
+// an object, the latest version unless a delete marker is the current
+// version and is kept at the usual location of the object.
+func getObjectVersionLocation(objInfo ObjectInfo) (string, string) {
+	if objInfo.IsLatest && !objInfo.DeleteMarker {
+		return objInfo.Bucket, objInfo.Name
+	}
+	return minioMetaBucket, getObjectVersionPath(objInfo.Bucket, objInfo.Name, objInfo.VersionID)
+}
+
+// getWriteQuorum - returns the write quorum of an existing object.
+func (xl xlObjects) getWriteQuorum(ctx context.Context, bucket, object string) (int, error) {
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+	_, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return 0, err
+	}
+	return writeQuorum, reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum)
+}
+
+// updateObjectMeta - applies updateFn to `xl.json` of the object on all
+// disks and saves it back.
+func (xl xlObjects) updateObjectMeta(ctx context.Context, bucket, object string, updateFn func(map[string]string)) error {
+	disks := xl.getDisks()
+	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+	_, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return err
+	}
+	if err = reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); err != nil {
+		return err
+	}
+
+	// Only update disks having a valid `xl.json`.
+	onlineDisks := make([]StorageAPI, len(disks))
+	for index := range metaArr {
+		if errs[index] == nil {
+			onlineDisks[index] = disks[index]
+			updateFn(metaArr[index].Meta)
+		}
+	}
+
+	tempObj := mustGetUUID()
+	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return err
+	}
+	_, err = renameXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum)
+	return err
+}
+
+// updateObjectVersionMeta - applies updateFn to the metadata of a version
+// of the object, its latest version if versionID is empty, once checkFn,
+// if any, accepts the version. Delete markers have no metadata to update.
+func (xl xlObjects) updateObjectVersionMeta(ctx context.Context, bucket, object, versionID string,
+	checkFn func(ObjectInfo) error, updateFn func(map[string]string)) (objInfo ObjectInfo, err error) {
+	// Lock the object before updating its metadata.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	if versionID == "" {
+		if objInfo, err = xl.getObjectInfo(ctx, bucket, object); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		objInfo.VersionID, objInfo.IsLatest = getVersionID(objInfo.UserDefined), true
+	} else if objInfo, err = xl.getObjectVersionInfo(ctx, bucket, object, versionID); err != nil {
+		return objInfo, err
+	}
+	if objInfo.DeleteMarker {
+		return objInfo, MethodNotAllowed{Bucket: bucket, Object: object}
+	}
+
+	if checkFn != nil {
+		if err = checkFn(objInfo); err != nil {
+			return objInfo, err
+		}
+	}
+
+	volume, path := getObjectVersionLocation(objInfo)
+	if err = xl.updateObjectMeta(ctx, volume, path, updateFn); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	updateFn(objInfo.UserDefined)
+	return objInfo, nil
+}
+
+// listDirEntries - returns the entries of the directory found on any
+// disk, without their trailing slash.
+func (xl xlObjects) listDirEntries(volume, dirPath string) []string {
+	entries := set.NewStringSet()
+	for _, disk := range xl.getDisks() {
+		if disk == nil {
+			continue
+		}
+		diskEntries, err := disk.ListDir(volume, dirPath, -1)
+		if err != nil {
+			continue
+		}
+		for _, entry := range diskEntries {
+			entries.Add(strings.TrimSuffix(entry, slashSeparator))
+		}
+	}
+	return entries.ToSlice()
+}
+
+// listObjectVersionIDs - returns the IDs of all noncurrent versions of
+// the object found on any disk.
+func (xl xlObjects) listObjectVersionIDs(bucket, object string) []string {
+	return xl.listDirEntries(minioMetaBucket, getObjectVersionsDir(bucket, object))
+}
+
+// getNoncurrentVersionInfo - returns the noncurrent version of the object
+// having the given version ID.
+func (xl xlObjects) getNoncurrentVersionInfo(ctx context.Context, bucket, object, versionID string) (ObjectInfo, error) {
+	objInfo, err := xl.getObjectInfo(ctx, minioMetaBucket, getObjectVersionPath(bucket, object, versionID))
+	if err != nil {
+		return objInfo, toVersionErr(err, bucket, object, versionID)
+	}
+	objInfo.Bucket, objInfo.Name, objInfo.VersionID = bucket, object, versionID
+	return objInfo, nil
+}
+
+// listNoncurrentVersions - returns the noncurrent versions and delete
+// markers of the object, newest first.
+func (xl xlObjects) listNoncurrentVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	var versions []ObjectInfo
+	for _, versionID := range xl.listObjectVersionIDs(bucket, object) {
+		objInfo, err := xl.getNoncurrentVersionInfo(ctx, bucket, object, versionID)
+		if err != nil {
+			if _, ok := err.(VersionNotFound); ok {
+				continue
+			}
+			return nil, err
+		}
+		versions = append(versions, objInfo)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].ModTime.After(versions[j].ModTime)
+	})
+	return versions, nil
+}
+
+// hasObjectVersions - returns true if any object of the bucket has a
+// noncurrent version or a delete marker.
+func (xl xlObjects) hasObjectVersions(bucket string) bool {
+	// Invalid bucket names would resolve outside of the versions of
+	// the bucket.
+	if !IsValidBucketName(bucket) {
+		return false
+	}
+	for _, disk := range xl.getDisks() {
+		if disk == nil {
+			continue
+		}
+		entries, err := disk.ListDir(minioMetaBucket, pathJoin(objectVersionsPrefix, bucket), 1)
+		if err == nil && len(entries) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveObject - makes way for a new version of the object having
+// newVersionID. The current version becomes a noncurrent version, unless
+// both have the null version ID in which case it is overwritten as only
+// a single null version may exist.
+func (xl xlObjects) archiveObject(ctx context.Context, bucket, object, newVersionID string) error {
+	if newVersionID == nullVersionID {
+		nullVersionPath := getObjectVersionPath(bucket, object, nullVersionID)
+		if xl.isObject(minioMetaBucket, nullVersionPath) {
+			if objInfo, err := xl.getNoncurrentVersionInfo(ctx, bucket, object, nullVersionID); err == nil {
+				if err = checkObjectLocked(objInfo); err != nil {
+					return err
+				}
+			}
+			if err := xl.deleteObject(ctx, minioMetaBucket, nullVersionPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !xl.isObject(bucket, object) {
+		return nil
+	}
+
+	objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+	if err != nil {
+		return err
+	}
+	versionID := getVersionID(objInfo.UserDefined)
+	if versionID == newVersionID {
+		if err = checkObjectLocked(objInfo); err != nil {
+			return err
+		}
+		return xl.deleteObject(ctx, bucket, object)
+	}
+
+	writeQuorum, err := xl.getWriteQuorum(ctx, bucket, object)
+	if err != nil {
+		return err
+	}
+	versionPath := getObjectVersionPath(bucket, object, versionID)
+	if _, err = renameObject(ctx, xl.getDisks(), bucket, object, minioMetaBucket, versionPath, writeQuorum); err != nil {
+		return err
+	}
+
+	// Record the name of the object as it can't be derived from the
+	// path of the version.
+	return xl.updateObjectMeta(ctx, minioMetaBucket, versionPath, func(meta map[string]string) {
+		meta[objectVersionIDKey] = versionID
+		meta[objectVersionObjectKey] = object
+	})
+}
+
+// restoreLatestVersion - makes the newest noncurrent version of the
+// object its current version, if the object has no current version
+// and the newest noncurrent version isn't a delete marker.
+func (xl xlObjects) restoreLatestVersion(ctx context.Context, bucket, object string) error {
+	if xl.isObject(bucket, object) {
+		return nil
+	}
+
+	versions, err := xl.listNoncurrentVersions(ctx, bucket, object)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 || versions[0].DeleteMarker {
+		return nil
+	}
+
+	versionPath := getObjectVersionPath(bucket, object, versions[0].VersionID)
+	writeQuorum, err := xl.getWriteQuorum(ctx, minioMetaBucket, versionPath)
+	if err != nil {
+		return err
+	}
+	_, err = renameObject(ctx, xl.getDisks(), minioMetaBucket, versionPath, bucket, object, writeQuorum)
+	return err
+}
+
+// getObjectVersionInfo - returns the version of the object having the
+// given version ID, which is either its current version or one of its
+// noncurrent versions.
+func (xl xlObjects) getObjectVersionInfo(ctx context.Context, bucket, object, versionID string) (ObjectInfo, error) {
+	if _, err := xl.getBucketInfo(ctx, bucket); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket)
+	}
+
+	if xl.isObject(bucket, object) {
+		objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+		if err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		if getVersionID(objInfo.UserDefined) == versionID {
+			objInfo.VersionID, objInfo.IsLatest = versionID, true
+			return objInfo, nil
+		}
+		if objInfo, err = xl.getNoncurrentVersionInfo(ctx, bucket, object, versionID); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		return objInfo, nil
+	}
+
+	// Without a current version the newest noncurrent version, which
+	// must be a delete marker, is the latest version.
+	versions, err := xl.listNoncurrentVersions(ctx, bucket, object)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	for i, version := range versions {
+		if version.VersionID == versionID {
+			version.IsLatest = i == 0
+			return version, nil
+		}
+	}
+	return ObjectInfo{}, VersionNotFound{Bucket: bucket, Object: object, VersionID: versionID}
+}
+
+// GetObjectVersionInfo - reads the metadata of a version of the object.
+func (xl xlObjects) GetObjectVersionInfo(ctx context.Context, bucket, object, versionID string) (ObjectInfo, error) {
+	// Lock the object before reading.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+		return ObjectInfo{}, err
+	}
+	defer objectLock.RUnlock()
+
+	if err := checkGetObjArgs(ctx, bucket, object); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return xl.getObjectVersionInfo(ctx, bucket, object, versionID)
+}
+
+// GetObjectVersion - reads a version of the object, reading a delete
+// marker is not allowed.
+func (xl xlObjects) GetObjectVersion(ctx context.Context, bucket, object, versionID string, startOffset int64, length int64, writer io.Writer, etag string) error {
+	// Lock the object before reading.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer objectLock.RUnlock()
+
+	if err := checkGetObjArgs(ctx, bucket, object); err != nil {
+		return err
+	}
+
+	objInfo, err := xl.getObjectVersionInfo(ctx, bucket, object, versionID)
+	if err != nil {
+		return err
+	}
+	if objInfo.DeleteMarker {
+		return MethodNotAllowed{Bucket: bucket, Object: object}
+	}
+	if objInfo.IsLatest {
+		return xl.getObject(ctx, bucket, object, startOffset, length, writer, etag)
+	}
+
+	versionPath := getObjectVersionPath(bucket, object, versionID)
+	if err = xl.getObject(ctx, minioMetaBucket, versionPath, startOffset, length, writer, etag); err != nil {
+		return toVersionErr(err, bucket, object, versionID)
+	}
+	return nil
+}
+
+// PutDeleteMarker - deletes the current version of the object by making
+// it a noncurrent version and adding a delete marker having the given
+// version ID as the latest version.
+func (xl xlObjects) PutDeleteMarker(ctx context.Context, bucket, object, versionID string) (ObjectInfo, error) {
+	// Acquire a write lock before deleting the object.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetLock(globalOperationTimeout); err != nil {
+		return ObjectInfo{}, err
+	}
+	defer objectLock.Unlock()
+
+	if err := checkDelObjArgs(ctx, bucket, object); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	if _, err := xl.getBucketInfo(ctx, bucket); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket)
+	}
+
+	if err := xl.archiveObject(ctx, bucket, object, versionID); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	hashReader, err := hash.NewReader(bytes.NewReader(nil), 0, "", "")
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	objInfo, err := xl.putObject(ctx, minioMetaBucket, getObjectVersionPath(bucket, object, versionID), hashReader, map[string]string{
+		objectVersionIDKey:     versionID,
+		objectVersionObjectKey: object,
+		objectDeleteMarkerKey:  "true",
+	})
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	objInfo.Bucket, objInfo.Name = bucket, object
+	objInfo.VersionID, objInfo.IsLatest, objInfo.DeleteMarker = versionID, true, true
+	return objInfo, nil
+}
+
+// DeleteObjectVersion - permanently removes a version of the object and
+// returns it. Once the latest version is removed the newest remaining
+// version, unless it is a delete marker, becomes the current version.
+func (xl xlObjects) DeleteObjectVersion(ctx context.Context, bucket, object, versionID string) (ObjectInfo, error) {
+	// Acquire a write lock before deleting the object.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetLock(globalOperationTimeout); err != nil {
+		return ObjectInfo{}, err
+	}
+	defer objectLock.Unlock()
+
+	if err := checkDelObjArgs(ctx, bucket, object); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	objInfo, err := xl.getObjectVersionInfo(ctx, bucket, object, versionID)
+	if err != nil {
+		return objInfo, err
+	}
+
+	if err = checkObjectDeletable(ctx, objInfo); err != nil {
+		return objInfo, err
+	}
+
+	volume, path := getObjectVersionLocation(objInfo)
+	if err = xl.deleteObject(ctx, volume, path); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if err = xl.restoreLatestVersion(ctx, bucket, object); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	return objInfo, nil
+}
+
+// listVersionedObjects - returns the names of the objects of the bucket
+// having noncurrent versions or delete markers, which are recorded in
+// the metadata of any of their versions.
+func (xl xlObjects) listVersionedObjects(ctx context.Context, bucket string) []string {
+	var objects []string
+	bucketVersionsDir := pathJoin(objectVersionsPrefix, bucket)
+	for _, objectDir := range xl.listDirEntries(minioMetaBucket, bucketVersionsDir) {
+		versionsDir := pathJoin(bucketVersionsDir, objectDir)
+		for _, versionID := range xl.listDirEntries(minioMetaBucket, versionsDir) {
+			objInfo, err := xl.getObjectInfo(ctx, minioMetaBucket, pathJoin(versionsDir, versionID))
+			if err == nil && objInfo.UserDefined[objectVersionObjectKey] != "" {
+				objects = append(objects, objInfo.UserDefined[objectVersionObjectKey])
+				break
+			}
+		}
+	}
+	return objects
+}
+
+// listObjectVersions - returns the current version, if any, followed by
+// the noncurrent versions and delete markers of the object, newest first.
+func (xl xlObjects) listObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	// Lock the object before reading.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+		return nil, err
+	}
+	defer objectLock.RUnlock()
+
+	versions, err := xl.listNoncurrentVersions(ctx, bucket, object)
+	if err != nil {
+		return nil, toObjectErr(err, bucket, object)
+	}
+	if xl.isObject(bucket, object) {
+		objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+		if err != nil {
+			return nil, toObjectErr(err, bucket, object)
+		}
+		objInfo.VersionID = getVersionID(objInfo.UserDefined)
+		versions = append([]ObjectInfo{objInfo}, versions...)
+	}
+	if len(versions) > 0 {
+		versions[0].IsLatest = true
+	}
+	return versions, nil
+}
+
+// ListObjectVersions - lists the versions and delete markers of the objects of the bucket.
+func (xl xlObjects) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	getVersions := func(object string) ([]ObjectInfo, error) {
+		return xl.listObjectVersions(ctx, bucket, object)
+	}
+	return listObjectVersions(ctx, xl, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys,
+		xl.listVersionedObjects(ctx, bucket), getVersions)
+}
+
+// listObjectVersions - lists the versions of the objects of the bucket in
+// the order of their names, the versions of each object newest first, as
+// returned by getVersions. Objects having only noncurrent versions aren't
+// found by ListObjects, versionedObjects holds the names of such objects.
+// Listing resumes after the version ID marker of the key marker, or after
+// the key marker if no version ID marker is given.
+func listObjectVersions(ctx context.Context, obj ObjectLayer, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int,
+	versionedObjects []string, getVersions func(object string) ([]ObjectInfo, error)) (result ListObjectVersionsInfo, err error) {
+	if err = checkListObjsArgs(ctx, bucket, prefix, keyMarker, delimiter, obj); err != nil {
+		return result, err
+	}
+
+	if maxKeys == 0 {
+		return result, nil
+	}
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	// Adds a version or a common prefix to the result, false if the
+	// result is full in which case it is marked as truncated.
+	var nextKeyMarker, nextVersionIDMarker string
+	add := func(version *ObjectInfo, commonPrefix string) bool {
+		if len(result.Objects)+len(result.Prefixes) == maxKeys {
+			result.IsTruncated = true
+			result.NextKeyMarker, result.NextVersionIDMarker = nextKeyMarker, nextVersionIDMarker
+			return false
+		}
+		if version != nil {
+			result.Objects = append(result.Objects, *version)
+			nextKeyMarker, nextVersionIDMarker = version.Name, version.VersionID
+		} else {
+			result.Prefixes = append(result.Prefixes, commonPrefix)
+			nextKeyMarker, nextVersionIDMarker = commonPrefix, ""
+		}
+		return true
+	}
+
+	// Versions of the key marker older than the version ID marker come first.
+	if versionIDMarker != "" {
+		versions, err := getVersions(keyMarker)
+		if err != nil {
+			return result, err
+		}
+		for i, version := range versions {
+			if version.VersionID != versionIDMarker {
+				continue
+			}
+			for j := range versions[i+1:] {
+				if !add(&versions[i+1+j], "") {
+					return result, nil
+				}
+			}
+			break
+		}
+	}
+
+	var names []string
+	for _, name := range versionedObjects {
+		if hasPrefix(name, prefix) && name > keyMarker {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// Merge the names of current objects, listed page by page, with the
+	// names of versioned objects.
+	var objects []string
+	listMarker, listTruncated := keyMarker, true
+	var lastPrefix string
+	for {
+		if len(objects) == 0 && listTruncated {
+			loi, err := obj.ListObjects(ctx, bucket, prefix, listMarker, "", maxObjectList)
+			if err != nil {
+				return result, err
+			}
+			for _, objInfo := range loi.Objects {
+				objects = append(objects, objInfo.Name)
+			}
+			listTruncated = loi.IsTruncated && len(objects) > 0
+			if len(objects) > 0 {
+				listMarker = objects[len(objects)-1]
+			}
+		}
+
+		var name string
+		switch {
+		case len(objects) == 0 && len(names) == 0:
+			return result, nil
+		case len(names) == 0 || (len(objects) > 0 && objects[0] < names[0]):
+			name, objects = objects[0], objects[1:]
+		case len(objects) == 0 || names[0] < objects[0]:
+			name, names = names[0], names[1:]
+		default:
+			name, objects, names = objects[0], objects[1:], names[1:]
+		}
+
+		if delimiter != "" {
+			if i := strings.Index(name[len(prefix):], delimiter); i >= 0 {
+				commonPrefix := name[:len(prefix)+i+len(delimiter)]
+				if commonPrefix == keyMarker || commonPrefix == lastPrefix {
+					continue
+				}
+				if !add(nil, commonPrefix) {
+					return result, nil
+				}
+				lastPrefix = commonPrefix
+				continue
+			}
+		}
+
+		versions, err := getVersions(name)
+		if err != nil {
+			return result, err
+		}
+		for i := range versions {
+			if !add(&versions[i], "") {
+				return result, nil
+			}
+		}
+	}
+}
+
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (xl xlObjects) IsVersioningSupported() bool {
+	return true
+}
@@ -0,0 +1,241 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+)
+
+// Tests putting, reading and deleting versions of an object.
+func TestXLObjectVersions(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	putVersion := func(data, versionID string) {
+		metadata := map[string]string{objectVersionIDKey: versionID}
+		objInfo, perr := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), metadata)
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		if objInfo.VersionID != versionID {
+			t.Fatalf("expected version %s, got %s", versionID, objInfo.VersionID)
+		}
+	}
+	getVersion := func(versionID string) string {
+		var buffer bytes.Buffer
+		if gerr := obj.GetObjectVersion(ctx, bucket, object, versionID, 0, -1, &buffer, ""); gerr != nil {
+			t.Fatalf("version %s: %v", versionID, gerr)
+		}
+		return buffer.String()
+	}
+
+	v1, v2 := mustGetUUID(), mustGetUUID()
+	putVersion("version1", v1)
+	putVersion("version2", v2)
+
+	if data := getVersion(v1); data != "version1" {
+		t.Fatalf("expected version1, got %s", data)
+	}
+	if data := getVersion(v2); data != "version2" {
+		t.Fatalf("expected version2, got %s", data)
+	}
+
+	objInfo, err := obj.GetObjectVersionInfo(ctx, bucket, object, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.IsLatest || objInfo.Name != object {
+		t.Fatalf("unexpected noncurrent version %+v", objInfo)
+	}
+	if objInfo, err = obj.GetObjectVersionInfo(ctx, bucket, object, v2); err != nil {
+		t.Fatal(err)
+	}
+	if !objInfo.IsLatest {
+		t.Fatal("expected current version to be latest")
+	}
+
+	if _, err = obj.GetObjectVersionInfo(ctx, bucket, object, mustGetUUID()); err == nil {
+		t.Fatal("expected unknown version to fail")
+	} else if _, ok := err.(VersionNotFound); !ok {
+		t.Fatalf("expected VersionNotFound, got %v", err)
+	}
+
+	// A delete marker hides the object but keeps its versions.
+	marker := mustGetUUID()
+	if objInfo, err = obj.PutDeleteMarker(ctx, bucket, object, marker); err != nil {
+		t.Fatal(err)
+	}
+	if !objInfo.DeleteMarker || objInfo.VersionID != marker {
+		t.Fatalf("unexpected delete marker %+v", objInfo)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, object); err == nil {
+		t.Fatal("expected object to be deleted")
+	} else if _, ok := err.(ObjectNotFound); !ok {
+		t.Fatalf("expected ObjectNotFound, got %v", err)
+	}
+	if err = obj.DeleteBucket(ctx, bucket); err == nil {
+		t.Fatal("expected bucket with versions not to be deleted")
+	} else if _, ok := err.(BucketNotEmpty); !ok {
+		t.Fatalf("expected BucketNotEmpty, got %v", err)
+	}
+
+	// Removing the delete marker restores the latest version.
+	if _, err = obj.DeleteObjectVersion(ctx, bucket, object, marker); err != nil {
+		t.Fatal(err)
+	}
+	if objInfo, err = obj.GetObjectInfo(ctx, bucket, object); err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.VersionID != v2 {
+		t.Fatalf("expected version %s, got %s", v2, objInfo.VersionID)
+	}
+
+	// Only a single null version is kept.
+	putVersion("null1", nullVersionID)
+	putVersion("null2", nullVersionID)
+	if data := getVersion(nullVersionID); data != "null2" {
+		t.Fatalf("expected null2, got %s", data)
+	}
+	if data := getVersion(v2); data != "version2" {
+		t.Fatalf("expected version2, got %s", data)
+	}
+
+	for _, versionID := range []string{nullVersionID, v2, v1} {
+		if _, err = obj.DeleteObjectVersion(ctx, bucket, object, versionID); err != nil {
+			t.Fatalf("version %s: %v", versionID, err)
+		}
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, object); err == nil {
+		t.Fatal("expected all versions to be deleted")
+	}
+	if err = obj.DeleteBucket(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Tests listing versions of objects page by page.
+func TestXLListObjectVersions(t *testing.T) {
+	rootPath, err := newTestConfig(globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	obj, fsDirs, err := prepareXL32()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(object string) string {
+		versionID := mustGetUUID()
+		metadata := map[string]string{objectVersionIDKey: versionID}
+		if _, perr := obj.PutObject(ctx, bucket, object, mustGetHashReader(t, bytes.NewReader([]byte(object)), int64(len(object)), "", ""), metadata); perr != nil {
+			t.Fatal(perr)
+		}
+		return versionID
+	}
+
+	// "a" has two versions, "b" only has a noncurrent version and a
+	// delete marker, "dir/c" has a single version.
+	a1, a2 := put("a"), put("a")
+	b1 := put("b")
+	b2 := mustGetUUID()
+	if _, err = obj.PutDeleteMarker(ctx, bucket, "b", b2); err != nil {
+		t.Fatal(err)
+	}
+	c1 := put("dir/c")
+
+	expected := []struct {
+		name, versionID        string
+		isLatest, deleteMarker bool
+	}{
+		{"a", a2, true, false},
+		{"a", a1, false, false},
+		{"b", b2, true, true},
+		{"b", b1, false, false},
+		{"dir/c", c1, true, false},
+	}
+
+	for _, maxKeys := range []int{1, 2, 1000} {
+		var versions []ObjectInfo
+		keyMarker, versionIDMarker := "", ""
+		for {
+			result, lerr := obj.ListObjectVersions(ctx, bucket, "", keyMarker, versionIDMarker, "", maxKeys)
+			if lerr != nil {
+				t.Fatal(lerr)
+			}
+			if len(result.Objects) > maxKeys {
+				t.Fatalf("max keys %d: got %d versions", maxKeys, len(result.Objects))
+			}
+			versions = append(versions, result.Objects...)
+			if !result.IsTruncated {
+				break
+			}
+			keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+		}
+		if len(versions) != len(expected) {
+			t.Fatalf("max keys %d: expected %d versions, got %d", maxKeys, len(expected), len(versions))
+		}
+		for i, version := range versions {
+			if version.Name != expected[i].name || version.VersionID != expected[i].versionID ||
+				version.IsLatest != expected[i].isLatest || version.DeleteMarker != expected[i].deleteMarker {
+				t.Errorf("max keys %d: case %d: unexpected version %s %s %v %v", maxKeys, i+1,
+					version.Name, version.VersionID, version.IsLatest, version.DeleteMarker)
+			}
+		}
+	}
+
+	result, err := obj.ListObjectVersions(ctx, bucket, "", "", "", "/", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 4 || len(result.Prefixes) != 1 || result.Prefixes[0] != "dir/" {
+		t.Fatalf("unexpected listing with delimiter %+v", result)
+	}
+
+	if result, err = obj.ListObjectVersions(ctx, bucket, "dir/", "", "", "", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Name != "dir/c" {
+		t.Fatalf("unexpected listing with prefix %+v", result)
+	}
+}
@@ -46,6 +46,9 @@ type xlObjects struct {
 
 	// TODO: ListObjects pool management, should be removed in future.
 	listPool *treeWalkPool
+
+	// Names of the objects of the multipart upload directories.
+	multipartNames *multipartNames
 }
 
 // Shutdown function for object storage interface.
@@ -102,20 +102,28 @@ func (c *Certs) run() {
 			certChanged := base == filepath.Base(c.certFile)
 			keyChanged := base == filepath.Base(c.keyFile)
 			if certChanged || keyChanged {
-				cert, err := c.loadCert(c.certFile, c.keyFile)
-				if err != nil {
-					// ignore the error continue to use
-					// old certificates.
-					continue
-				}
-				c.Lock()
-				c.cert = cert
-				c.Unlock()
+				// ignore the error continue to use
+				// old certificates.
+				c.Reload()
 			}
 		}
 	}
 }
 
+// Reload loads the certificate and key files again. If loading
+// fails the error is returned and the old certificate and key
+// continue to be used.
+func (c *Certs) Reload() error {
+	cert, err := c.loadCert(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	c.Lock()
+	c.cert = cert
+	c.Unlock()
+	return nil
+}
+
 // GetCertificateFunc provides a GetCertificate type for custom client implementations.
 type GetCertificateFunc func(hello *tls.ClientHelloInfo) (*tls.Certificate, error)
 
@@ -122,3 +122,46 @@ func TestStop(t *testing.T) {
 		t.Error("certificate shouldn't match, but matched")
 	}
 }
+
+func TestReload(t *testing.T) {
+	expectedCert, err := tls.LoadX509KeyPair("server2.crt", "server2.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := certs.New("server.crt", "server.key", tls.LoadX509KeyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Stop watching to make sure only Reload() loads the new certificate.
+	c.Stop()
+
+	updateCerts("server2.crt", "server2.key")
+	defer updateCerts("server1.crt", "server1.key")
+
+	if err = c.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	hello := &tls.ClientHelloInfo{}
+	gcert, err := c.GetCertificate(hello)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gcert.Certificate, expectedCert.Certificate) {
+		t.Error("certificate doesn't match expected certificate")
+	}
+
+	// A mismatching key must keep the old certificate.
+	updateCerts("server2.crt", "server1.key")
+	if err = c.Reload(); err == nil {
+		t.Fatal("Expected to fail but got success")
+	}
+	if gcert, err = c.GetCertificate(hello); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gcert.Certificate, expectedCert.Certificate) {
+		t.Error("certificate doesn't match expected certificate")
+	}
+}
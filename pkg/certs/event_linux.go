@@ -21,6 +21,7 @@ package certs
 import "github.com/rjeczalik/notify"
 
 var (
-	// eventWrite contains the notify events that will cause a write
-	eventWrite = []notify.Event{notify.InCloseWrite}
+	// eventWrite contains the notify events that will cause a write,
+	// files renamed into place by certificate renewal tools included.
+	eventWrite = []notify.Event{notify.InCloseWrite, notify.InMovedTo}
 )
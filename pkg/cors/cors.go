@@ -0,0 +1,185 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+// Maximum number of rules of a CORS configuration.
+const maxRules = 100
+
+// Errors returned when validating a CORS configuration.
+var (
+	ErrInvalidRules         = errors.New("CORS configuration must have between 1 and 100 rules")
+	ErrMissingOrigin        = errors.New("CORS rule must have at least one allowed origin")
+	ErrInvalidOrigin        = errors.New("allowed origin can have at most one wildcard")
+	ErrInvalidMethod        = errors.New("allowed method must be one of GET, PUT, HEAD, POST or DELETE")
+	ErrInvalidHeader        = errors.New("allowed header can have at most one wildcard")
+	ErrInvalidMaxAgeSeconds = errors.New("max age seconds must not be negative")
+)
+
+// Methods which a CORS rule may allow.
+var supportedMethods = map[string]bool{
+	"GET":    true,
+	"PUT":    true,
+	"HEAD":   true,
+	"POST":   true,
+	"DELETE": true,
+}
+
+// Rule - origins, methods and headers of cross-origin requests allowed on
+// a bucket.
+type Rule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Validate - checks that the rule allows at least one origin and method,
+// and that origins and headers have at most one wildcard.
+func (rule Rule) Validate() error {
+	if len(rule.AllowedOrigins) == 0 {
+		return ErrMissingOrigin
+	}
+	for _, origin := range rule.AllowedOrigins {
+		if origin == "" || strings.Count(origin, "*") > 1 {
+			return ErrInvalidOrigin
+		}
+	}
+	if len(rule.AllowedMethods) == 0 {
+		return ErrInvalidMethod
+	}
+	for _, method := range rule.AllowedMethods {
+		if !supportedMethods[method] {
+			return ErrInvalidMethod
+		}
+	}
+	for _, header := range rule.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return ErrInvalidHeader
+		}
+	}
+	if rule.MaxAgeSeconds < 0 {
+		return ErrInvalidMaxAgeSeconds
+	}
+	return nil
+}
+
+// MatchOrigin - returns whether the rule allows given origin.
+func (rule Rule) MatchOrigin(origin string) bool {
+	for _, pattern := range rule.AllowedOrigins {
+		if wildcard.MatchSimple(pattern, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAnyOrigin - returns whether the rule allows requests from any
+// origin with the "*" wildcard.
+func (rule Rule) AllowsAnyOrigin() bool {
+	for _, origin := range rule.AllowedOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchMethod - returns whether the rule allows given method.
+func (rule Rule) MatchMethod(method string) bool {
+	for _, allowed := range rule.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchHeaders - returns whether the rule allows all given headers,
+// header names are compared case insensitively.
+func (rule Rule) MatchHeaders(headers []string) bool {
+	for _, header := range headers {
+		header = strings.ToLower(header)
+		matched := false
+		for _, pattern := range rule.AllowedHeaders {
+			if wildcard.MatchSimple(strings.ToLower(pattern), header) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Config - CORS configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTcors.html
+type Config struct {
+	XMLName xml.Name `xml:"CORSConfiguration"`
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	Rules   []Rule   `xml:"CORSRule"`
+}
+
+// Validate - checks that the configuration has between 1 and maxRules
+// valid rules.
+func (config Config) Validate() error {
+	if len(config.Rules) == 0 || len(config.Rules) > maxRules {
+		return ErrInvalidRules
+	}
+	for _, rule := range config.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match - returns the first rule allowing a request from given origin
+// with given method and headers, nil if there is none.
+func (config Config) Match(origin, method string, headers []string) *Rule {
+	for i, rule := range config.Rules {
+		if rule.MatchOrigin(origin) && rule.MatchMethod(method) && rule.MatchHeaders(headers) {
+			return &config.Rules[i]
+		}
+	}
+	return nil
+}
+
+// ParseConfig - parses and validates a CORS configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.XMLNS = ""
+	return &config, nil
+}
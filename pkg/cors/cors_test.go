@@ -0,0 +1,96 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	errMalformed := errors.New("malformed")
+	rule := `<CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule>`
+	testCases := []struct {
+		config      string
+		expectedErr error
+	}{
+		{`<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` + rule + `</CORSConfiguration>`, nil},
+		{`<CORSConfiguration><CORSRule><ID>web</ID><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedMethod>DELETE</AllowedMethod>` +
+			`<AllowedHeader>x-amz-*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`, nil},
+		{`<CORSConfiguration></CORSConfiguration>`, ErrInvalidRules},
+		{`<CORSConfiguration>` + strings.Repeat(rule, maxRules+1) + `</CORSConfiguration>`, ErrInvalidRules},
+		{`<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`, ErrMissingOrigin},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*.*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`, ErrInvalidOrigin},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`, ErrInvalidMethod},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>OPTIONS</AllowedMethod></CORSRule></CORSConfiguration>`, ErrInvalidMethod},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedHeader>*-*</AllowedHeader></CORSRule></CORSConfiguration>`, ErrInvalidHeader},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><MaxAgeSeconds>-1</MaxAgeSeconds></CORSRule></CORSConfiguration>`, ErrInvalidMaxAgeSeconds},
+		{`<CORSConfiguration><CORSRule>`, errMalformed},
+	}
+
+	for i, testCase := range testCases {
+		config, err := ParseConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr == errMalformed {
+			if err == nil {
+				t.Fatalf("case %v: expected an error, got none", i+1)
+			}
+			continue
+		}
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && config.XMLNS != "" {
+			t.Fatalf("case %v: expected no namespace, got %v", i+1, config.XMLNS)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	config := Config{
+		Rules: []Rule{
+			{ID: "read", AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET", "HEAD"}},
+			{ID: "write", AllowedOrigins: []string{"https://*.example.com"}, AllowedMethods: []string{"PUT", "POST"}, AllowedHeaders: []string{"Content-*", "x-amz-date"}},
+		},
+	}
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+	}{
+		{"http://any.where", "GET", nil, "read"},
+		{"https://www.example.com", "HEAD", nil, "read"},
+		{"https://www.example.com", "PUT", nil, "write"},
+		{"https://www.example.com", "PUT", []string{"content-type", "X-Amz-Date"}, "write"},
+		{"https://www.example.com", "PUT", []string{"authorization"}, ""},
+		{"http://www.example.com", "PUT", nil, ""},
+		{"http://any.where", "GET", []string{"content-type"}, ""},
+		{"https://www.example.com", "DELETE", nil, ""},
+	}
+
+	for i, testCase := range testCases {
+		rule := config.Match(testCase.origin, testCase.method, testCase.headers)
+		var id string
+		if rule != nil {
+			id = rule.ID
+		}
+		if id != testCase.expectedID {
+			t.Fatalf("case %v: expected: %q, got: %q", i+1, testCase.expectedID, id)
+		}
+	}
+}
@@ -259,6 +259,23 @@ func (conf *Config) SetRegion(region string) {
 	}
 }
 
+// ActiveConfig - returns the queue configurations of the targets in the
+// target list, with the ARNs of the region. Queue configurations of
+// targets no longer configured on the server are left out.
+func (conf Config) ActiveConfig(region string, targetList *TargetList) *Config {
+	config := &Config{}
+	for _, queue := range conf.QueueList {
+		if !targetList.Exists(queue.ARN.TargetID) {
+			continue
+		}
+
+		queue.SetRegion(region)
+		config.QueueList = append(config.QueueList, queue)
+	}
+
+	return config
+}
+
 // ToRulesMap - converts all queue configuration to RulesMap.
 func (conf *Config) ToRulesMap() RulesMap {
 	rulesMap := make(RulesMap)
@@ -732,6 +732,82 @@ func TestConfigSetRegion(t *testing.T) {
 	}
 }
 
+func TestConfigActiveConfig(t *testing.T) {
+	data := []byte(`
+<NotificationConfiguration>
+   <QueueConfiguration>
+      <Id>1</Id>
+      <Filter>
+           <S3Key>
+               <FilterRule>
+                   <Name>prefix</Name>
+                   <Value>images/</Value>
+               </FilterRule>
+           </S3Key>
+      </Filter>
+      <Queue>arn:minio:sqs::1:webhook</Queue>
+      <Event>s3:ObjectCreated:Put</Event>
+   </QueueConfiguration>
+   <QueueConfiguration>
+      <Id>2</Id>
+      <Filter></Filter>
+      <Queue>arn:minio:sqs:us-east-1:2:amqp</Queue>
+      <Event>s3:ObjectRemoved:*</Event>
+   </QueueConfiguration>
+</NotificationConfiguration>
+`)
+	config := &Config{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		panic(err)
+	}
+
+	targetList1 := NewTargetList()
+
+	targetList2 := NewTargetList()
+	if err := targetList2.Add(&ExampleTarget{TargetID{"1", "webhook"}, false, false}); err != nil {
+		panic(err)
+	}
+
+	targetList3 := NewTargetList()
+	if err := targetList3.Add(&ExampleTarget{TargetID{"1", "webhook"}, false, false}); err != nil {
+		panic(err)
+	}
+	if err := targetList3.Add(&ExampleTarget{TargetID{"2", "amqp"}, false, false}); err != nil {
+		panic(err)
+	}
+
+	testCases := []struct {
+		region         string
+		targetList     *TargetList
+		expectedResult []ARN
+	}{
+		{"us-east-1", targetList1, nil},
+		{"us-east-1", targetList2, []ARN{{TargetID{"1", "webhook"}, "us-east-1"}}},
+		{"eu-west-1", targetList3, []ARN{{TargetID{"1", "webhook"}, "eu-west-1"}, {TargetID{"2", "amqp"}, "eu-west-1"}}},
+	}
+
+	for i, testCase := range testCases {
+		var result []ARN
+		activeConfig := config.ActiveConfig(testCase.region, testCase.targetList)
+		for _, queue := range activeConfig.QueueList {
+			result = append(result, queue.ARN)
+		}
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("test %v: data: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// Filters of the queues are kept, the configuration is not modified.
+	activeConfig := config.ActiveConfig("us-east-1", targetList2)
+	if pattern := activeConfig.QueueList[0].Filter.RuleList.Pattern(); pattern != NewPattern("images/", "") {
+		t.Fatalf("filter: expected: %v, got: %v", NewPattern("images/", ""), pattern)
+	}
+	if region := config.QueueList[0].ARN.region; region != "" {
+		t.Fatalf("region: expected: \"\", got: %v", region)
+	}
+}
+
 func TestConfigToRulesMap(t *testing.T) {
 	var data []byte
 	data = []byte(`
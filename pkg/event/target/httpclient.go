@@ -32,7 +32,8 @@ import (
 type HTTPClientTarget struct {
 	id        event.TargetID
 	w         http.ResponseWriter
-	eventCh   chan []byte
+	keepAlive time.Duration
+	eventCh   chan event.Event
 	DoneCh    chan struct{}
 	stopCh    chan struct{}
 	isStopped uint32
@@ -44,7 +45,10 @@ func (target HTTPClientTarget) ID() event.TargetID {
 	return target.id
 }
 
-func (target *HTTPClientTarget) start() {
+// Start - starts sending events to the HTTP client, after the events to
+// replay. Events sent before the target is started wait for the events
+// to replay, the events already replayed are not sent again.
+func (target *HTTPClientTarget) Start(replay []event.Event) {
 	go func() {
 		defer func() {
 			atomic.AddUint32(&target.isRunning, 1)
@@ -53,8 +57,14 @@ func (target *HTTPClientTarget) start() {
 			close(target.DoneCh)
 		}()
 
-		write := func(event []byte) error {
-			if _, err := target.w.Write(event); err != nil {
+		write := func(records []event.Event) error {
+			data, err := json.Marshal(struct{ Records []event.Event }{records})
+			if err != nil {
+				return err
+			}
+			data = append(data, byte('\n'))
+
+			if _, err = target.w.Write(data); err != nil {
 				return err
 			}
 
@@ -62,7 +72,30 @@ func (target *HTTPClientTarget) start() {
 			return nil
 		}
 
-		keepAliveTicker := time.NewTicker(500 * time.Millisecond)
+		replayed := make(map[string]struct{}, len(replay))
+		for _, eventData := range replay {
+			if err := write([]event.Event{eventData}); err != nil {
+				// Got write error to the client.  Exit the goroutine.
+				return
+			}
+			replayed[eventData.S3.Object.Sequencer] = struct{}{}
+		}
+
+		// Keep-alive records have no events, a single space is
+		// written to keep the connection alive otherwise.
+		keepAlive := target.keepAlive
+		keepAliveRecord := func() error { return write([]event.Event{}) }
+		if keepAlive == 0 {
+			keepAlive = 500 * time.Millisecond
+			keepAliveRecord = func() error {
+				if _, err := target.w.Write([]byte(" ")); err != nil {
+					return err
+				}
+				target.w.(http.Flusher).Flush()
+				return nil
+			}
+		}
+		keepAliveTicker := time.NewTicker(keepAlive)
 		defer keepAliveTicker.Stop()
 
 		for {
@@ -70,17 +103,20 @@ func (target *HTTPClientTarget) start() {
 			case <-target.stopCh:
 				// We are asked to stop.
 				return
-			case event, ok := <-target.eventCh:
+			case eventData, ok := <-target.eventCh:
 				if !ok {
 					// Got read error.  Exit the goroutine.
 					return
 				}
-				if err := write(event); err != nil {
+				if _, ok = replayed[eventData.S3.Object.Sequencer]; ok {
+					continue
+				}
+				if err := write([]event.Event{eventData}); err != nil {
 					// Got write error to the client.  Exit the goroutine.
 					return
 				}
 			case <-keepAliveTicker.C:
-				if err := write([]byte(" ")); err != nil {
+				if err := keepAliveRecord(); err != nil {
 					// Got write error to the client.  Exit the goroutine.
 					return
 				}
@@ -95,17 +131,13 @@ func (target *HTTPClientTarget) Send(eventData event.Event) error {
 		return errors.New("closed http connection")
 	}
 
-	data, err := json.Marshal(struct{ Records []event.Event }{[]event.Event{eventData}})
-	if err != nil {
-		return err
-	}
-	data = append(data, byte('\n'))
-
 	select {
-	case target.eventCh <- data:
+	case target.eventCh <- eventData:
 		return nil
 	case <-target.DoneCh:
 		return errors.New("error in sending event")
+	case <-target.stopCh:
+		return errors.New("closed http connection")
 	}
 }
 
@@ -128,19 +160,21 @@ func getNewUUID() (string, error) {
 	return uuid.String(), nil
 }
 
-// NewHTTPClientTarget - creates new HTTP client target.
-func NewHTTPClientTarget(host xnet.Host, w http.ResponseWriter) (*HTTPClientTarget, error) {
+// NewHTTPClientTarget - creates new HTTP client target, which sends a
+// keep-alive record every keepAlive interval if it is set. Events are
+// sent once the target is started.
+func NewHTTPClientTarget(host xnet.Host, w http.ResponseWriter, keepAlive time.Duration) (*HTTPClientTarget, error) {
 	uuid, err := getNewUUID()
 	if err != nil {
 		return nil, err
 	}
 	c := &HTTPClientTarget{
-		id:      event.TargetID{"httpclient" + "+" + uuid + "+" + host.Name, host.Port.String()},
-		w:       w,
-		eventCh: make(chan []byte),
-		DoneCh:  make(chan struct{}),
-		stopCh:  make(chan struct{}),
+		id:        event.TargetID{"httpclient" + "+" + uuid + "+" + host.Name, host.Port.String()},
+		w:         w,
+		keepAlive: keepAlive,
+		eventCh:   make(chan event.Event),
+		DoneCh:    make(chan struct{}),
+		stopCh:    make(chan struct{}),
 	}
-	c.start()
 	return c, nil
 }
@@ -0,0 +1,82 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hash
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"hash"
+	"hash/crc32"
+
+	sha256 "github.com/minio/sha256-simd"
+)
+
+// Algorithms of the additional checksums of an object.
+const (
+	ChecksumCRC32  = "CRC32"
+	ChecksumCRC32C = "CRC32C"
+	ChecksumSHA1   = "SHA1"
+	ChecksumSHA256 = "SHA256"
+)
+
+// NewChecksumHash returns the hash.Hash of a checksum algorithm, nil if
+// the algorithm is unknown.
+func NewChecksumHash(algorithm string) hash.Hash {
+	switch algorithm {
+	case ChecksumCRC32:
+		return crc32.NewIEEE()
+	case ChecksumCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case ChecksumSHA1:
+		return sha1.New()
+	case ChecksumSHA256:
+		return sha256.New()
+	}
+	return nil
+}
+
+// SetChecksum sets the base64 encoded additional checksum of given
+// algorithm which the content of the Reader is verified against at EOF.
+func (r *Reader) SetChecksum(algorithm, checksumBase64 string) error {
+	h := NewChecksumHash(algorithm)
+	if h == nil {
+		return InvalidChecksum{algorithm}
+	}
+	checksum, err := base64.StdEncoding.DecodeString(checksumBase64)
+	if err != nil || len(checksum) != h.Size() {
+		return InvalidChecksum{algorithm}
+	}
+	r.checksumAlgorithm, r.checksum, r.checksumHash = algorithm, checksum, h
+	return nil
+}
+
+// verifyChecksum verifies if the computed additional checksum is equal
+// to the one set, if any.
+func (r *Reader) verifyChecksum() error {
+	if r.checksumHash == nil {
+		return nil
+	}
+	if sum := r.checksumHash.Sum(nil); !bytes.Equal(r.checksum, sum) {
+		return ChecksumMismatch{
+			Algorithm:          r.checksumAlgorithm,
+			ExpectedChecksum:   base64.StdEncoding.EncodeToString(r.checksum),
+			CalculatedChecksum: base64.StdEncoding.EncodeToString(sum),
+		}
+	}
+	return nil
+}
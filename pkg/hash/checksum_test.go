@@ -0,0 +1,59 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hash
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+// Tests verification of the additional checksums of all algorithms.
+func TestHashReaderChecksum(t *testing.T) {
+	testCases := []struct {
+		algorithm string
+		checksum  string
+		setErr    error
+		readErr   error
+	}{
+		{ChecksumCRC32, "7YLNEQ==", nil, nil},
+		{ChecksumCRC32C, "ksgKMQ==", nil, nil},
+		{ChecksumSHA1, "gf6L/odXbD7LIkJvjleEc4KRes8=", nil, nil},
+		{ChecksumSHA256, "iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk=", nil, nil},
+		{ChecksumCRC32, "ksgKMQ==", nil, ChecksumMismatch{ChecksumCRC32, "ksgKMQ==", "7YLNEQ=="}},
+		{ChecksumCRC32C, "7YLNEQ==", nil, ChecksumMismatch{ChecksumCRC32C, "7YLNEQ==", "ksgKMQ=="}},
+		{ChecksumCRC32, "gf6L/odXbD7LIkJvjleEc4KRes8=", InvalidChecksum{ChecksumCRC32}, nil},
+		{ChecksumSHA1, "not base64", InvalidChecksum{ChecksumSHA1}, nil},
+		{"MD4", "7YLNEQ==", InvalidChecksum{"MD4"}, nil},
+	}
+	for i, testCase := range testCases {
+		r, err := NewReader(bytes.NewReader([]byte("abcd")), 4, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = r.SetChecksum(testCase.algorithm, testCase.checksum); err != testCase.setErr {
+			t.Fatalf("Test %d: Expected error %v, got %v", i+1, testCase.setErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if _, err = io.Copy(ioutil.Discard, r); err != testCase.readErr {
+			t.Errorf("Test %d: Expected error %v, got %v", i+1, testCase.readErr, err)
+		}
+	}
+}
@@ -35,3 +35,23 @@ type BadDigest struct {
 func (e BadDigest) Error() string {
 	return "Bad digest: Expected " + e.ExpectedMD5 + " is not valid with what we calculated " + e.CalculatedMD5
 }
+
+// ChecksumMismatch - additional checksum you specified did not match what we received.
+type ChecksumMismatch struct {
+	Algorithm          string
+	ExpectedChecksum   string
+	CalculatedChecksum string
+}
+
+func (e ChecksumMismatch) Error() string {
+	return "Bad " + e.Algorithm + " checksum: Expected " + e.ExpectedChecksum + " is not valid with what we calculated " + e.CalculatedChecksum
+}
+
+// InvalidChecksum - additional checksum you specified is not a valid checksum of its algorithm.
+type InvalidChecksum struct {
+	Algorithm string
+}
+
+func (e InvalidChecksum) Error() string {
+	return "Invalid " + e.Algorithm + " checksum"
+}
@@ -38,6 +38,10 @@ type Reader struct {
 
 	md5sum, sha256sum   []byte // Byte values of md5sum, sha256sum of client sent values.
 	md5Hash, sha256Hash hash.Hash
+
+	checksumAlgorithm string // Algorithm of the additional checksum, if any.
+	checksum          []byte // Byte value of the additional checksum of client sent value.
+	checksumHash      hash.Hash
 }
 
 // NewReader returns a new hash Reader which computes the MD5 sum and
@@ -81,6 +85,9 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 		if r.sha256Hash != nil {
 			r.sha256Hash.Write(p[:n])
 		}
+		if r.checksumHash != nil {
+			r.checksumHash.Write(p[:n])
+		}
 	}
 
 	// At io.EOF verify if the checksums are right.
@@ -131,8 +138,8 @@ func (r *Reader) SHA256HexString() string {
 	return hex.EncodeToString(r.sha256sum)
 }
 
-// Verify verifies if the computed MD5 sum and SHA256 sum are
-// equal to the ones specified when creating the Reader.
+// Verify verifies if the computed MD5 sum, SHA256 sum and additional
+// checksum are equal to the ones specified for the Reader.
 func (r *Reader) Verify() error {
 	if r.sha256Hash != nil && len(r.sha256sum) > 0 {
 		if sum := r.sha256Hash.Sum(nil); !bytes.Equal(r.sha256sum, sum) {
@@ -144,5 +151,5 @@ func (r *Reader) Verify() error {
 			return BadDigest{hex.EncodeToString(r.md5sum), hex.EncodeToString(sum)}
 		}
 	}
-	return nil
+	return r.verifyChecksum()
 }
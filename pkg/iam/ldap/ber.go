@@ -0,0 +1,206 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ldap
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// Classes of BER identifiers.
+const (
+	classUniversal   = 0x00
+	classApplication = 0x40
+	classContext     = 0x80
+)
+
+// Universal BER tags used by LDAP.
+const (
+	tagBoolean     = 0x01
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x10
+	tagSet         = 0x11
+)
+
+const (
+	// Bit of the identifier of constructed packets.
+	constructed = 0x20
+
+	// Maximum size of a received packet.
+	maxPacketSize = 16 * 1024 * 1024
+)
+
+var errMalformedPacket = errors.New("malformed BER packet")
+
+// packet - BER encoded element, constructed packets hold children and
+// primitive packets hold a value. Only the subset of BER used by LDAP is
+// supported, tags are lower than 31.
+type packet struct {
+	class       byte
+	constructed bool
+	tag         byte
+	value       []byte
+	children    []*packet
+}
+
+func newPacket(class, tag byte, value []byte) *packet {
+	return &packet{class: class, tag: tag, value: value}
+}
+
+func newConstructed(class, tag byte, children ...*packet) *packet {
+	return &packet{class: class, constructed: true, tag: tag, children: children}
+}
+
+func newSequence(children ...*packet) *packet {
+	return newConstructed(classUniversal, tagSequence, children...)
+}
+
+func newString(s string) *packet {
+	return newPacket(classUniversal, tagOctetString, []byte(s))
+}
+
+func newInteger(tag byte, i int64) *packet {
+	// Minimal two's complement encoding.
+	var value []byte
+	for {
+		value = append([]byte{byte(i)}, value...)
+		if (i < 0x80 && i >= -0x80) || len(value) == 8 {
+			break
+		}
+		i >>= 8
+	}
+	return newPacket(classUniversal, tag, value)
+}
+
+func newBoolean(b bool) *packet {
+	if b {
+		return newPacket(classUniversal, tagBoolean, []byte{0xff})
+	}
+	return newPacket(classUniversal, tagBoolean, []byte{0x00})
+}
+
+// is - returns whether the packet has the given class and tag.
+func (p *packet) is(class, tag byte) bool {
+	return p.class == class && p.tag == tag
+}
+
+// int - decodes the value of an integer or enumerated packet.
+func (p *packet) int() (int64, error) {
+	if p.constructed || len(p.value) == 0 || len(p.value) > 8 {
+		return 0, errMalformedPacket
+	}
+	i := int64(int8(p.value[0]))
+	for _, b := range p.value[1:] {
+		i = i<<8 | int64(b)
+	}
+	return i, nil
+}
+
+// encode - returns the BER encoding of the packet.
+func (p *packet) encode() []byte {
+	value := p.value
+	identifier := p.class | p.tag
+	if p.constructed {
+		identifier |= constructed
+		value = nil
+		for _, child := range p.children {
+			value = append(value, child.encode()...)
+		}
+	}
+
+	data := []byte{identifier}
+	if n := len(value); n < 0x80 {
+		data = append(data, byte(n))
+	} else {
+		var length []byte
+		for ; n > 0; n >>= 8 {
+			length = append([]byte{byte(n)}, length...)
+		}
+		data = append(data, 0x80|byte(len(length)))
+		data = append(data, length...)
+	}
+	return append(data, value...)
+}
+
+// readPacket - reads and decodes a packet.
+func readPacket(r io.Reader) (*packet, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	if header[0]&0x1f == 0x1f {
+		return nil, errMalformedPacket
+	}
+
+	length := int(header[1])
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 {
+			return nil, errMalformedPacket
+		}
+		lengthBytes := make([]byte, n)
+		if _, err := io.ReadFull(r, lengthBytes); err != nil {
+			return nil, err
+		}
+		length = 0
+		for _, b := range lengthBytes {
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxPacketSize {
+		return nil, errMalformedPacket
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return decodePacket(header[0], data)
+}
+
+// decodePacket - decodes the contents of a packet with the given
+// identifier.
+func decodePacket(identifier byte, data []byte) (*packet, error) {
+	p := &packet{
+		class:       identifier & 0xc0,
+		constructed: identifier&constructed != 0,
+		tag:         identifier & 0x1f,
+	}
+	if !p.constructed {
+		p.value = data
+		return p, nil
+	}
+
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		child, err := readPacket(r)
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				err = errMalformedPacket
+			}
+			return nil, err
+		}
+		p.children = append(p.children, child)
+	}
+	return p, nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ldap
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPacketEncodeDecode(t *testing.T) {
+	testCases := []*packet{
+		newInteger(tagInteger, 0),
+		newInteger(tagInteger, 127),
+		newInteger(tagInteger, 128),
+		newInteger(tagInteger, -129),
+		newInteger(tagInteger, 1<<40),
+		newSequence(newString("dc=example,dc=com"), newBoolean(true), newString(string(make([]byte, 300)))),
+		newConstructed(classApplication, opBindRequest, newInteger(tagInteger, 3), newPacket(classContext, 0, []byte("secret"))),
+	}
+
+	for i, testCase := range testCases {
+		p, err := readPacket(bytes.NewReader(testCase.encode()))
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+		if !bytes.Equal(p.encode(), testCase.encode()) {
+			t.Fatalf("case %v: expected: %x, got: %x", i+1, testCase.encode(), p.encode())
+		}
+		if !testCase.constructed {
+			expected, _ := testCase.int()
+			if got, _ := p.int(); got != expected {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, expected, got)
+			}
+		}
+	}
+
+	// Truncated and oversized packets.
+	for i, data := range [][]byte{{0x30, 0x05, 0x02, 0x01}, {0x30, 0x84, 0x7f, 0xff, 0xff, 0xff}, {0x30, 0x03, 0x02, 0x05, 0x00}} {
+		if _, err := readPacket(bytes.NewReader(data)); err == nil {
+			t.Fatalf("case %v: expected to fail", i+1)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Default timeout of the operations of an authentication.
+const defaultTimeout = 30 * time.Second
+
+// Config - LDAP server users are authenticated against.
+//
+// UsernameFormat is the DN of users, where %s is replaced by the
+// username. GroupSearchFilter finds the groups of a user below
+// GroupSearchBaseDN, where %s is replaced by the username and %d by the
+// DN of the user. Groups are named by their GroupNameAttribute, or by
+// their DN if it is empty.
+type Config struct {
+	ServerAddr         string
+	TLSConfig          *tls.Config
+	UsernameFormat     string
+	GroupSearchBaseDN  string
+	GroupSearchFilter  string
+	GroupNameAttribute string
+	Timeout            time.Duration
+}
+
+// Validate - checks whether the configuration is complete.
+func (cfg Config) Validate() error {
+	if _, _, err := net.SplitHostPort(cfg.ServerAddr); err != nil {
+		return fmt.Errorf("invalid LDAP server address %q: %v", cfg.ServerAddr, err)
+	}
+	if !strings.Contains(cfg.UsernameFormat, "%s") {
+		return fmt.Errorf("LDAP username format %q must contain %%s", cfg.UsernameFormat)
+	}
+	if cfg.GroupSearchFilter != "" {
+		if cfg.GroupSearchBaseDN == "" {
+			return fmt.Errorf("LDAP group search base DN must be set along with the group search filter")
+		}
+		if _, err := compileFilter(cfg.GroupSearchFilter); err != nil {
+			return fmt.Errorf("invalid LDAP group search filter: %v", err)
+		}
+	}
+	return nil
+}
+
+// Authenticate - binds as the user with the password and returns the DN
+// and the groups of the user.
+func (cfg Config) Authenticate(username, password string) (userDN string, groups []string, err error) {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	conn, err := Dial(cfg.ServerAddr, cfg.TLSConfig, timeout)
+	if err != nil {
+		return "", nil, err
+	}
+	defer conn.Close()
+
+	userDN = strings.Replace(cfg.UsernameFormat, "%s", EscapeDN(username), -1)
+	if err = conn.Bind(userDN, password); err != nil {
+		return "", nil, err
+	}
+	if cfg.GroupSearchFilter == "" {
+		return userDN, nil, nil
+	}
+
+	filter := strings.NewReplacer("%s", EscapeFilter(username), "%d", EscapeFilter(userDN)).Replace(cfg.GroupSearchFilter)
+	var attributes []string
+	if cfg.GroupNameAttribute != "" {
+		attributes = []string{cfg.GroupNameAttribute}
+	} else {
+		// No attributes as per https://tools.ietf.org/html/rfc4511#section-4.5.1.8
+		attributes = []string{"1.1"}
+	}
+	entries, err := conn.Search(cfg.GroupSearchBaseDN, ScopeWholeSubtree, filter, attributes)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, entry := range entries {
+		if cfg.GroupNameAttribute == "" {
+			groups = append(groups, entry.DN)
+			continue
+		}
+		groups = append(groups, entry.GetAttributeValues(cfg.GroupNameAttribute)...)
+	}
+	return userDN, groups, nil
+}
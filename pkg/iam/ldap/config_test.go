@@ -0,0 +1,130 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ldap
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+)
+
+// serveLDAP - serves binds of the user uid=alice,dc=example with the
+// password secret and searches of the groups of its members.
+func serveLDAP(t *testing.T, conn net.Conn) {
+	defer conn.Close()
+
+	reply := func(messageID *packet, op *packet) {
+		conn.Write(newSequence(messageID, op).encode())
+	}
+	ldapResult := func(tag byte, code int64) *packet {
+		return newConstructed(classApplication, tag, newInteger(tagEnumerated, code), newString(""), newString(""))
+	}
+
+	for {
+		message, err := readPacket(conn)
+		if err != nil {
+			return
+		}
+		messageID, op := message.children[0], message.children[1]
+		switch op.tag {
+		case opBindRequest:
+			code := int64(ResultInvalidCredentials)
+			if string(op.children[1].value) == "uid=alice,dc=example" && string(op.children[2].value) == "secret" {
+				code = ResultSuccess
+			}
+			reply(messageID, ldapResult(opBindResponse, code))
+		case opSearchRequest:
+			filter, _ := compileFilter("(&(objectClass=groupOfNames)(member=uid=alice,dc=example))")
+			if string(op.children[0].value) == "ou=groups,dc=example" && bytes.Equal(op.children[6].encode(), filter.encode()) {
+				for _, group := range []string{"admins", "developers"} {
+					reply(messageID, newConstructed(classApplication, opSearchResultItem,
+						newString("cn="+group+",ou=groups,dc=example"),
+						newSequence(newSequence(newString("CN"), newConstructed(classUniversal, tagSet, newString(group)))),
+					))
+				}
+			}
+			reply(messageID, ldapResult(opSearchResultDone, ResultSuccess))
+		case opUnbindRequest:
+			return
+		default:
+			t.Errorf("unexpected LDAP operation %v", op.tag)
+			return
+		}
+	}
+}
+
+func TestConfigAuthenticate(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveLDAP(t, conn)
+		}
+	}()
+
+	cfg := Config{
+		ServerAddr:         listener.Addr().String(),
+		UsernameFormat:     "uid=%s,dc=example",
+		GroupSearchBaseDN:  "ou=groups,dc=example",
+		GroupSearchFilter:  "(&(objectClass=groupOfNames)(member=%d))",
+		GroupNameAttribute: "cn",
+	}
+	if err = cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	userDN, groups, err := cfg.Authenticate("alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userDN != "uid=alice,dc=example" || !reflect.DeepEqual(groups, []string{"admins", "developers"}) {
+		t.Fatalf("unexpected user %v and groups %v", userDN, groups)
+	}
+
+	cfg.GroupNameAttribute = ""
+	if _, groups, err = cfg.Authenticate("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(groups, []string{"cn=admins,ou=groups,dc=example", "cn=developers,ou=groups,dc=example"}) {
+		t.Fatalf("unexpected groups %v", groups)
+	}
+
+	for _, credentials := range [][2]string{{"alice", "wrong"}, {"alice", ""}, {"alice,dc=example", "secret"}} {
+		if _, _, err = cfg.Authenticate(credentials[0], credentials[1]); !IsInvalidCredentials(err) {
+			t.Fatalf("%v: expected invalid credentials, got: %v", credentials, err)
+		}
+	}
+
+	for _, invalid := range []Config{
+		{ServerAddr: "localhost", UsernameFormat: "uid=%s"},
+		{ServerAddr: "localhost:389", UsernameFormat: "uid=alice"},
+		{ServerAddr: "localhost:389", UsernameFormat: "uid=%s", GroupSearchFilter: "(cn=*)"},
+		{ServerAddr: "localhost:389", UsernameFormat: "uid=%s", GroupSearchBaseDN: "dc=example", GroupSearchFilter: "cn=*"},
+	} {
+		if err = invalid.Validate(); err == nil {
+			t.Fatalf("%v: expected to fail", invalid)
+		}
+	}
+}
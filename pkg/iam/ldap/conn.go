@@ -0,0 +1,264 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Application tags of LDAP protocol operations as per
+// https://tools.ietf.org/html/rfc4511#section-4.2
+const (
+	opBindRequest      = 0
+	opBindResponse     = 1
+	opUnbindRequest    = 2
+	opSearchRequest    = 3
+	opSearchResultItem = 4
+	opSearchResultDone = 5
+	opSearchResultRef  = 19
+)
+
+// LDAP result codes.
+const (
+	ResultSuccess            = 0
+	ResultInvalidCredentials = 49
+)
+
+// Search scopes.
+const (
+	ScopeBaseObject   = 0
+	ScopeSingleLevel  = 1
+	ScopeWholeSubtree = 2
+)
+
+var errUnexpectedResponse = errors.New("unexpected LDAP response")
+
+// Error - LDAP operation failure returned by the server.
+type Error struct {
+	ResultCode int64
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("LDAP result code %d", e.ResultCode)
+	}
+	return fmt.Sprintf("LDAP result code %d: %s", e.ResultCode, e.Message)
+}
+
+// IsInvalidCredentials - returns whether err is a failed bind due to
+// an unknown user or a wrong password.
+func IsInvalidCredentials(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.ResultCode == ResultInvalidCredentials
+}
+
+// Entry - entry returned by a search.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Conn - connection to an LDAP server, operations are synchronous and
+// a connection must not be used concurrently.
+type Conn struct {
+	conn      net.Conn
+	timeout   time.Duration
+	messageID int64
+}
+
+// Dial - connects to the LDAP server at addr, over TLS if tlsConfig is
+// set. Every operation must complete within timeout.
+func Dial(addr string, tlsConfig *tls.Config, timeout time.Duration) (*Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	var err error
+	if tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{conn: conn, timeout: timeout}, nil
+}
+
+// Close - unbinds and closes the connection.
+func (c *Conn) Close() error {
+	c.send(newPacket(classApplication, opUnbindRequest, nil))
+	return c.conn.Close()
+}
+
+// send - sends an LDAP message with the given protocol operation and
+// returns its message ID.
+func (c *Conn) send(op *packet) (int64, error) {
+	c.messageID++
+	message := newSequence(newInteger(tagInteger, c.messageID), op)
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return 0, err
+	}
+	_, err := c.conn.Write(message.encode())
+	return c.messageID, err
+}
+
+// receive - returns the protocol operation of the next LDAP message,
+// which must answer the given message ID.
+func (c *Conn) receive(messageID int64) (*packet, error) {
+	message, err := readPacket(c.conn)
+	if err != nil {
+		return nil, err
+	}
+	if !message.is(classUniversal, tagSequence) || len(message.children) < 2 {
+		return nil, errUnexpectedResponse
+	}
+	if id, err := message.children[0].int(); err != nil || id != messageID {
+		return nil, errUnexpectedResponse
+	}
+	return message.children[1], nil
+}
+
+// result - returns the error of an LDAPResult, if any.
+func result(op *packet) error {
+	if len(op.children) < 3 || !op.children[0].is(classUniversal, tagEnumerated) {
+		return errUnexpectedResponse
+	}
+	code, err := op.children[0].int()
+	if err != nil {
+		return errUnexpectedResponse
+	}
+	if code != ResultSuccess {
+		return &Error{ResultCode: code, Message: string(op.children[2].value)}
+	}
+	return nil
+}
+
+// Bind - authenticates as dn with a simple bind. An empty password
+// would perform an unauthenticated bind, which always succeeds, and is
+// rejected.
+func (c *Conn) Bind(dn, password string) error {
+	if password == "" {
+		return &Error{ResultCode: ResultInvalidCredentials, Message: "empty password"}
+	}
+
+	messageID, err := c.send(newConstructed(classApplication, opBindRequest,
+		newInteger(tagInteger, 3),
+		newString(dn),
+		newPacket(classContext, 0, []byte(password)),
+	))
+	if err != nil {
+		return err
+	}
+
+	op, err := c.receive(messageID)
+	if err != nil {
+		return err
+	}
+	if !op.is(classApplication, opBindResponse) {
+		return errUnexpectedResponse
+	}
+	return result(op)
+}
+
+// Search - returns the entries below baseDN matching the filter, with the
+// given attributes only.
+func (c *Conn) Search(baseDN string, scope int64, filter string, attributes []string) ([]Entry, error) {
+	filterPacket, err := compileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	attributesPacket := newSequence()
+	for _, attribute := range attributes {
+		attributesPacket.children = append(attributesPacket.children, newString(attribute))
+	}
+
+	messageID, err := c.send(newConstructed(classApplication, opSearchRequest,
+		newString(baseDN),
+		newInteger(tagEnumerated, scope),
+		newInteger(tagEnumerated, 0), // never dereference aliases
+		newInteger(tagInteger, 0),    // no size limit
+		newInteger(tagInteger, 0),    // no time limit
+		newBoolean(false),
+		filterPacket,
+		attributesPacket,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for {
+		op, err := c.receive(messageID)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case op.is(classApplication, opSearchResultItem):
+			entry, err := decodeEntry(op)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case op.is(classApplication, opSearchResultRef):
+			// Referrals to other servers are not followed.
+		case op.is(classApplication, opSearchResultDone):
+			if err = result(op); err != nil {
+				return nil, err
+			}
+			return entries, nil
+		default:
+			return nil, errUnexpectedResponse
+		}
+	}
+}
+
+// decodeEntry - decodes a SearchResultEntry.
+func decodeEntry(op *packet) (Entry, error) {
+	if len(op.children) != 2 {
+		return Entry{}, errUnexpectedResponse
+	}
+	entry := Entry{
+		DN:         string(op.children[0].value),
+		Attributes: make(map[string][]string),
+	}
+	for _, attribute := range op.children[1].children {
+		if len(attribute.children) != 2 {
+			return Entry{}, errUnexpectedResponse
+		}
+		name := string(attribute.children[0].value)
+		for _, value := range attribute.children[1].children {
+			entry.Attributes[name] = append(entry.Attributes[name], string(value.value))
+		}
+	}
+	return entry, nil
+}
+
+// GetAttributeValues - returns the values of the attribute, attribute
+// names are case insensitive.
+func (e Entry) GetAttributeValues(name string) []string {
+	for attribute, values := range e.Attributes {
+		if strings.EqualFold(attribute, name) {
+			return values
+		}
+	}
+	return nil
+}
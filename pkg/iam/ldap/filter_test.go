@@ -0,0 +1,75 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ldap
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompileFilter(t *testing.T) {
+	equality := func(attribute, value string) *packet {
+		return newConstructed(classContext, filterEqualityMatch, newString(attribute), newString(value))
+	}
+
+	testCases := []struct {
+		filter         string
+		expectedPacket *packet
+	}{
+		{"(cn=admins)", equality("cn", "admins")},
+		{"(cn=*)", newPacket(classContext, filterPresent, []byte("cn"))},
+		{"(cn=a\\2ab\\29)", equality("cn", "a*b)")},
+		{"(uidNumber>=1000)", newConstructed(classContext, filterGreaterOrEqual, newString("uidNumber"), newString("1000"))},
+		{"(&(objectClass=groupOfNames)(member=uid=alice,dc=example))", newConstructed(classContext, filterAnd,
+			equality("objectClass", "groupOfNames"), equality("member", "uid=alice,dc=example"))},
+		{"(|(cn=a)(!(cn=b)))", newConstructed(classContext, filterOr,
+			equality("cn", "a"), newConstructed(classContext, filterNot, equality("cn", "b")))},
+		{"cn=admins", nil},
+		{"(cn=admins", nil},
+		{"(cn=admins))", nil},
+		{"(&)", nil},
+		{"(cn=ad*)", nil},
+		{"(=admins)", nil},
+		{"(cn=\\2)", nil},
+		{"(cn:dn:=admins)", nil},
+	}
+
+	for i, testCase := range testCases {
+		p, err := compileFilter(testCase.filter)
+		if testCase.expectedPacket == nil {
+			if err == nil {
+				t.Fatalf("case %v: expected to fail", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %v: %v", i+1, err)
+		}
+		if !bytes.Equal(p.encode(), testCase.expectedPacket.encode()) {
+			t.Fatalf("case %v: expected: %x, got: %x", i+1, testCase.expectedPacket.encode(), p.encode())
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	if s := EscapeFilter("a*(b)\\"); s != "a\\2a\\28b\\29\\5c" {
+		t.Fatalf("unexpected filter escape %v", s)
+	}
+	if s := EscapeDN(" a,b=c+d#"); s != "\\ a\\,b\\=c\\+d#" {
+		t.Fatalf("unexpected DN escape %v", s)
+	}
+}
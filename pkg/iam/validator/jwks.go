@@ -0,0 +1,103 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// JWK - JSON web key as per https://tools.ietf.org/html/rfc7517
+// Only public RSA and EC keys are supported.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// RSA public key parameters.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC public key parameters.
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS - set of JSON web keys, as served by the jwks_uri of an
+// OpenID Connect provider.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+var errMalformedJWKRSAKey = errors.New("malformed JWK RSA key")
+var errMalformedJWKECKey = errors.New("malformed JWK EC key")
+
+// decodeBigInt - decodes a base64url encoded unsigned big-endian integer.
+func decodeBigInt(s string) (*big.Int, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+	return new(big.Int).SetBytes(data), true
+}
+
+// DecodePublicKey - decodes the public key of a JSON web key.
+func (key JWK) DecodePublicKey() (crypto.PublicKey, error) {
+	switch key.Kty {
+	case "RSA":
+		n, ok := decodeBigInt(key.N)
+		if !ok {
+			return nil, errMalformedJWKRSAKey
+		}
+		e, ok := decodeBigInt(key.E)
+		if !ok || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errMalformedJWKRSAKey
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch key.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unknown JWK EC curve %v", key.Crv)
+		}
+		x, ok := decodeBigInt(key.X)
+		if !ok {
+			return nil, errMalformedJWKECKey
+		}
+		y, ok := decodeBigInt(key.Y)
+		if !ok || !curve.IsOnCurve(x, y) {
+			return nil, errMalformedJWKECKey
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unknown JWK key type %v", key.Kty)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validator
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+)
+
+const (
+	// Minimum interval between two fetches of the public keys, the keys
+	// are fetched again when a token is signed by an unknown key.
+	minRefreshInterval = time.Minute
+
+	// Maximum size of a JSON web key set.
+	maxJWKSSize = 1024 * 1024
+)
+
+// Errors returned when validating a token.
+var (
+	ErrTokenExpired = errors.New("token is expired")
+	ErrInvalidToken = errors.New("token is invalid")
+)
+
+// JWT - validates JSON web tokens signed by the keys of an OpenID
+// Connect provider and issued for a client.
+type JWT struct {
+	sync.RWMutex
+
+	jwksURL    *url.URL
+	clientID   string
+	client     *http.Client
+	publicKeys map[string]crypto.PublicKey
+	lastFetch  time.Time
+}
+
+// NewJWT - returns a validator of the tokens signed by the keys served
+// at jwksURL, tokens must be issued for clientID unless it is empty.
+// The keys are fetched on first use.
+func NewJWT(jwksURL, clientID string, transport http.RoundTripper) (*JWT, error) {
+	u, err := url.Parse(jwksURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported JWKS URL scheme %v", u.Scheme)
+	}
+	return &JWT{
+		jwksURL:    u,
+		clientID:   clientID,
+		client:     &http.Client{Transport: transport, Timeout: 30 * time.Second},
+		publicKeys: make(map[string]crypto.PublicKey),
+	}, nil
+}
+
+// PopulatePublicKeys - fetches the public keys from the JWKS URL and
+// replaces the known keys.
+func (j *JWT) PopulatePublicKeys() error {
+	j.Lock()
+	j.lastFetch = time.Now()
+	j.Unlock()
+
+	resp, err := j.client.Get(j.jwksURL.String())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to fetch JWKS from %v: %v", j.jwksURL, resp.Status)
+	}
+
+	var jwks JWKS
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxJWKSSize)).Decode(&jwks); err != nil {
+		return err
+	}
+
+	publicKeys := make(map[string]crypto.PublicKey)
+	for _, key := range jwks.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		publicKey, err := key.DecodePublicKey()
+		if err != nil {
+			return err
+		}
+		publicKeys[key.Kid] = publicKey
+	}
+
+	j.Lock()
+	j.publicKeys = publicKeys
+	j.Unlock()
+	return nil
+}
+
+// getPublicKey - returns the public key with the given key ID, the keys
+// are fetched again if the key is unknown.
+func (j *JWT) getPublicKey(kid string) (crypto.PublicKey, error) {
+	j.RLock()
+	publicKey, ok := j.publicKeys[kid]
+	lastFetch := j.lastFetch
+	j.RUnlock()
+	if ok {
+		return publicKey, nil
+	}
+
+	if time.Since(lastFetch) < minRefreshInterval {
+		return nil, fmt.Errorf("unknown token signing key %v", kid)
+	}
+	if err := j.PopulatePublicKeys(); err != nil {
+		return nil, err
+	}
+
+	j.RLock()
+	publicKey, ok = j.publicKeys[kid]
+	j.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown token signing key %v", kid)
+	}
+	return publicKey, nil
+}
+
+// hasAudience - returns whether the audience claim of the token, a
+// string or an array of strings, holds the client ID.
+func hasAudience(claims jwtgo.MapClaims, clientID string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate - verifies the signature, expiry and audience of a token and
+// returns its claims. Only asymmetric signatures are accepted and tokens
+// must expire.
+func (j *JWT) Validate(token string) (jwtgo.MapClaims, error) {
+	parser := jwtgo.Parser{
+		ValidMethods: []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"},
+	}
+
+	claims := jwtgo.MapClaims{}
+	_, err := parser.ParseWithClaims(token, claims, func(t *jwtgo.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return j.getPublicKey(kid)
+	})
+	if err != nil {
+		if vErr, ok := err.(*jwtgo.ValidationError); ok && vErr.Errors == jwtgo.ValidationErrorExpired {
+			return nil, ErrTokenExpired
+		}
+		return nil, ErrInvalidToken
+	}
+
+	if _, ok := claims["exp"]; !ok {
+		return nil, ErrInvalidToken
+	}
+	if j.clientID != "" && !hasAudience(claims, j.clientID) {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
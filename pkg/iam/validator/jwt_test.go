@@ -0,0 +1,99 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+)
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func TestJWTValidate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks := JWKS{Keys: []JWK{
+		{Kty: "RSA", Use: "sig", Kid: "rsa", N: encodeBigInt(rsaKey.N), E: encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+		{Kty: "EC", Kid: "ec", Crv: "P-256", X: encodeBigInt(ecKey.X), Y: encodeBigInt(ecKey.Y)},
+	}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jwks)
+	}))
+	defer server.Close()
+
+	validator, err := NewJWT(server.URL, "minio", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(method jwtgo.SigningMethod, kid string, key interface{}, claims jwtgo.MapClaims) string {
+		token := jwtgo.NewWithClaims(method, claims)
+		token.Header["kid"] = kid
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	testCases := []struct {
+		token       string
+		expectedErr error
+	}{
+		{sign(jwtgo.SigningMethodRS256, "rsa", rsaKey, jwtgo.MapClaims{"sub": "user", "aud": "minio", "exp": exp}), nil},
+		{sign(jwtgo.SigningMethodES256, "ec", ecKey, jwtgo.MapClaims{"sub": "user", "aud": []string{"other", "minio"}, "exp": exp}), nil},
+		{sign(jwtgo.SigningMethodRS256, "rsa", rsaKey, jwtgo.MapClaims{"sub": "user", "aud": "minio", "exp": time.Now().Add(-time.Hour).Unix()}), ErrTokenExpired},
+		{sign(jwtgo.SigningMethodRS256, "rsa", rsaKey, jwtgo.MapClaims{"sub": "user", "aud": "other", "exp": exp}), ErrInvalidToken},
+		{sign(jwtgo.SigningMethodRS256, "rsa", rsaKey, jwtgo.MapClaims{"sub": "user", "aud": "minio"}), ErrInvalidToken},
+		{sign(jwtgo.SigningMethodRS256, "unknown", rsaKey, jwtgo.MapClaims{"sub": "user", "aud": "minio", "exp": exp}), ErrInvalidToken},
+		// The token is signed by the EC key but refers to the RSA key.
+		{sign(jwtgo.SigningMethodES256, "rsa", ecKey, jwtgo.MapClaims{"sub": "user", "aud": "minio", "exp": exp}), ErrInvalidToken},
+		{sign(jwtgo.SigningMethodHS256, "rsa", []byte("secret"), jwtgo.MapClaims{"sub": "user", "aud": "minio", "exp": exp}), ErrInvalidToken},
+		{"not-a-token", ErrInvalidToken},
+	}
+
+	for i, testCase := range testCases {
+		claims, err := validator.Validate(testCase.token)
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && claims["sub"] != "user" {
+			t.Fatalf("case %v: unexpected claims %v", i+1, claims)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// Destination buckets are given by their ARN.
+	destinationARNPrefix = "arn:aws:s3:::"
+
+	// Maximum length of a configuration ID.
+	maxIDLength = 64
+)
+
+// Errors returned when validating an inventory configuration.
+var (
+	ErrInvalidID              = errors.New("inventory ID must have 1 to 64 letters, digits, '-', '_' or '.'")
+	ErrInvalidDestination     = errors.New("destination bucket must be given as arn:aws:s3:::bucket")
+	ErrInvalidFormat          = errors.New("inventory format must be one of CSV, ORC or Parquet")
+	ErrInvalidIncludedVersion = errors.New("included object versions must be either All or Current")
+	ErrInvalidFrequency       = errors.New("inventory frequency must be either Daily or Weekly")
+	ErrInvalidField           = errors.New("optional fields must be supported and unique")
+)
+
+var validID = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// Format - format of the data files of an inventory report.
+type Format string
+
+// Inventory report formats.
+const (
+	CSV     Format = "CSV"
+	ORC     Format = "ORC"
+	Parquet Format = "Parquet"
+)
+
+// Frequency - how often an inventory report is written.
+type Frequency string
+
+// Inventory report frequencies.
+const (
+	Daily  Frequency = "Daily"
+	Weekly Frequency = "Weekly"
+)
+
+// Object versions listed by an inventory report.
+const (
+	AllVersions    = "All"
+	CurrentVersion = "Current"
+)
+
+// Field - optional field of the objects listed by an inventory report.
+type Field string
+
+// Optional fields, in the order of the columns of the reports.
+const (
+	Size                      Field = "Size"
+	LastModifiedDate          Field = "LastModifiedDate"
+	ETag                      Field = "ETag"
+	StorageClass              Field = "StorageClass"
+	IsMultipartUploaded       Field = "IsMultipartUploaded"
+	ReplicationStatus         Field = "ReplicationStatus"
+	EncryptionStatus          Field = "EncryptionStatus"
+	ObjectLockRetainUntilDate Field = "ObjectLockRetainUntilDate"
+	ObjectLockMode            Field = "ObjectLockMode"
+	ObjectLockLegalHoldStatus Field = "ObjectLockLegalHoldStatus"
+)
+
+var supportedFields = []Field{
+	Size,
+	LastModifiedDate,
+	ETag,
+	StorageClass,
+	IsMultipartUploaded,
+	ReplicationStatus,
+	EncryptionStatus,
+	ObjectLockRetainUntilDate,
+	ObjectLockMode,
+	ObjectLockLegalHoldStatus,
+}
+
+// Encryption - encryption of the files of an inventory report.
+type Encryption struct {
+	SSES3  *struct{} `xml:"SSE-S3,omitempty"`
+	SSEKMS *struct {
+		KeyID string `xml:"KeyId"`
+	} `xml:"SSE-KMS,omitempty"`
+}
+
+// BucketDestination - bucket and prefix inventory reports are written to.
+type BucketDestination struct {
+	AccountID  string      `xml:"AccountId,omitempty"`
+	Bucket     string      `xml:"Bucket"`
+	Format     Format      `xml:"Format"`
+	Prefix     string      `xml:"Prefix,omitempty"`
+	Encryption *Encryption `xml:"Encryption,omitempty"`
+}
+
+// BucketName - returns the name of the destination bucket.
+func (destination BucketDestination) BucketName() string {
+	return strings.TrimPrefix(destination.Bucket, destinationARNPrefix)
+}
+
+// Destination - destination of inventory reports.
+type Destination struct {
+	S3BucketDestination BucketDestination `xml:"S3BucketDestination"`
+}
+
+// Filter - selects the objects listed by prefix.
+type Filter struct {
+	Prefix string `xml:"Prefix"`
+}
+
+// OptionalFields - optional fields of the objects listed.
+type OptionalFields struct {
+	Fields []Field `xml:"Field"`
+}
+
+// Schedule - schedule of the inventory reports.
+type Schedule struct {
+	Frequency Frequency `xml:"Frequency"`
+}
+
+// Config - inventory configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTInventoryConfig.html
+type Config struct {
+	XMLName                xml.Name        `xml:"InventoryConfiguration"`
+	XMLNS                  string          `xml:"xmlns,attr,omitempty"`
+	Destination            Destination     `xml:"Destination"`
+	IsEnabled              bool            `xml:"IsEnabled"`
+	Filter                 *Filter         `xml:"Filter,omitempty"`
+	ID                     string          `xml:"Id"`
+	IncludedObjectVersions string          `xml:"IncludedObjectVersions"`
+	OptionalFields         *OptionalFields `xml:"OptionalFields,omitempty"`
+	Schedule               Schedule        `xml:"Schedule"`
+}
+
+// Validate - checks the ID, destination, schedule, included versions and
+// optional fields.
+func (config Config) Validate() error {
+	if len(config.ID) > maxIDLength || !validID.MatchString(config.ID) {
+		return ErrInvalidID
+	}
+	destination := config.Destination.S3BucketDestination
+	if !strings.HasPrefix(destination.Bucket, destinationARNPrefix) || destination.BucketName() == "" {
+		return ErrInvalidDestination
+	}
+	switch destination.Format {
+	case CSV, ORC, Parquet:
+	default:
+		return ErrInvalidFormat
+	}
+	if config.IncludedObjectVersions != AllVersions && config.IncludedObjectVersions != CurrentVersion {
+		return ErrInvalidIncludedVersion
+	}
+	if config.Schedule.Frequency != Daily && config.Schedule.Frequency != Weekly {
+		return ErrInvalidFrequency
+	}
+	if config.OptionalFields != nil {
+		fields := make(map[Field]bool, len(config.OptionalFields.Fields))
+		for _, field := range config.OptionalFields.Fields {
+			if fields[field] || !isSupportedField(field) {
+				return ErrInvalidField
+			}
+			fields[field] = true
+		}
+	}
+	return nil
+}
+
+func isSupportedField(field Field) bool {
+	for _, f := range supportedFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterPrefix - returns the prefix of the objects listed.
+func (config Config) FilterPrefix() string {
+	if config.Filter == nil {
+		return ""
+	}
+	return config.Filter.Prefix
+}
+
+// Fields - returns the optional fields of the objects listed, in the
+// order of the columns of the reports.
+func (config Config) Fields() []Field {
+	if config.OptionalFields == nil {
+		return nil
+	}
+	var fields []Field
+	for _, f := range supportedFields {
+		for _, field := range config.OptionalFields.Fields {
+			if f == field {
+				fields = append(fields, f)
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// ReportTime - returns the time of the latest report due at the given
+// time, the start of the day or, for weekly reports, of the week
+// starting on Sunday.
+func (config Config) ReportTime(now time.Time) time.Time {
+	now = now.UTC()
+	t := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if config.Schedule.Frequency == Weekly {
+		t = t.AddDate(0, 0, -int(t.Weekday()))
+	}
+	return t
+}
+
+// ParseConfig - parses and validates an inventory configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.XMLNS = ""
+	return &config, nil
+}
+
+// ListResult - inventory configurations of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketListInventoryConfigs.html
+type ListResult struct {
+	XMLName               xml.Name `xml:"ListInventoryConfigurationsResult"`
+	XMLNS                 string   `xml:"xmlns,attr,omitempty"`
+	Configs               []Config `xml:"InventoryConfiguration"`
+	ContinuationToken     string   `xml:"ContinuationToken,omitempty"`
+	IsTruncated           bool     `xml:"IsTruncated"`
+	NextContinuationToken string   `xml:"NextContinuationToken,omitempty"`
+}
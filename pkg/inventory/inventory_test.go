@@ -0,0 +1,106 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testConfig(id, bucket, format, versions, fields, frequency string) string {
+	return `<InventoryConfiguration><Id>` + id + `</Id><IsEnabled>true</IsEnabled>` +
+		`<Destination><S3BucketDestination><Bucket>` + bucket + `</Bucket><Format>` + format + `</Format><Prefix>reports</Prefix></S3BucketDestination></Destination>` +
+		`<Filter><Prefix>logs/</Prefix></Filter><IncludedObjectVersions>` + versions + `</IncludedObjectVersions>` +
+		`<OptionalFields>` + fields + `</OptionalFields><Schedule><Frequency>` + frequency + `</Frequency></Schedule></InventoryConfiguration>`
+}
+
+func TestParseConfig(t *testing.T) {
+	errMalformed := errors.New("malformed")
+	fields := `<Field>ETag</Field><Field>Size</Field>`
+	testCases := []struct {
+		config      string
+		expectedErr error
+	}{
+		{testConfig("report-1", "arn:aws:s3:::reports", "CSV", "All", fields, "Daily"), nil},
+		{testConfig("report_1.a", "arn:aws:s3:::reports", "Parquet", "Current", "", "Weekly"), nil},
+		{testConfig("", "arn:aws:s3:::reports", "CSV", "All", fields, "Daily"), ErrInvalidID},
+		{testConfig("report 1", "arn:aws:s3:::reports", "CSV", "All", fields, "Daily"), ErrInvalidID},
+		{testConfig(strings.Repeat("a", maxIDLength+1), "arn:aws:s3:::reports", "CSV", "All", fields, "Daily"), ErrInvalidID},
+		{testConfig("report", "reports", "CSV", "All", fields, "Daily"), ErrInvalidDestination},
+		{testConfig("report", "arn:aws:s3:::", "CSV", "All", fields, "Daily"), ErrInvalidDestination},
+		{testConfig("report", "arn:aws:s3:::reports", "JSON", "All", fields, "Daily"), ErrInvalidFormat},
+		{testConfig("report", "arn:aws:s3:::reports", "CSV", "Latest", fields, "Daily"), ErrInvalidIncludedVersion},
+		{testConfig("report", "arn:aws:s3:::reports", "CSV", "All", fields, "Monthly"), ErrInvalidFrequency},
+		{testConfig("report", "arn:aws:s3:::reports", "CSV", "All", `<Field>Owner</Field>`, "Daily"), ErrInvalidField},
+		{testConfig("report", "arn:aws:s3:::reports", "CSV", "All", `<Field>Size</Field><Field>Size</Field>`, "Daily"), ErrInvalidField},
+		{`<InventoryConfiguration>`, errMalformed},
+	}
+
+	for i, testCase := range testCases {
+		config, err := ParseConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr == errMalformed {
+			if err == nil {
+				t.Fatalf("case %v: expected an error, got none", i+1)
+			}
+			continue
+		}
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && config.Destination.S3BucketDestination.BucketName() != "reports" {
+			t.Fatalf("case %v: unexpected destination bucket %v", i+1, config.Destination.S3BucketDestination.BucketName())
+		}
+	}
+}
+
+func TestConfigFields(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(testConfig("report", "arn:aws:s3:::reports", "CSV", "All",
+		`<Field>EncryptionStatus</Field><Field>ETag</Field><Field>Size</Field>`, "Daily")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := config.Fields(); !reflect.DeepEqual(fields, []Field{Size, ETag, EncryptionStatus}) {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+	if prefix := config.FilterPrefix(); prefix != "logs/" {
+		t.Fatalf("unexpected filter prefix %v", prefix)
+	}
+}
+
+func TestConfigReportTime(t *testing.T) {
+	// 2018-06-06 is a Wednesday.
+	now := time.Date(2018, 6, 6, 15, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		frequency Frequency
+		now       time.Time
+		expected  time.Time
+	}{
+		{Daily, now, time.Date(2018, 6, 6, 0, 0, 0, 0, time.UTC)},
+		{Weekly, now, time.Date(2018, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{Weekly, time.Date(2018, 6, 3, 0, 0, 0, 0, time.UTC), time.Date(2018, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{Weekly, time.Date(2018, 6, 2, 23, 0, 0, 0, time.UTC), time.Date(2018, 5, 27, 0, 0, 0, 0, time.UTC)},
+	}
+	for i, testCase := range testCases {
+		config := Config{Schedule: Schedule{Frequency: testCase.frequency}}
+		if reportTime := config.ReportTime(testCase.now); !reportTime.Equal(testCase.expected) {
+			t.Errorf("case %v: expected %v, got %v", i+1, testCase.expected, reportTime)
+		}
+	}
+}
@@ -0,0 +1,466 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/pkg/tagging"
+)
+
+const (
+	// Maximum number of rules of a lifecycle configuration.
+	maxRules = 1000
+
+	// Maximum length of a rule ID.
+	maxRuleIDLength = 255
+)
+
+// Errors returned when validating a lifecycle configuration.
+var (
+	ErrNoRules               = errors.New("lifecycle configuration has no rules")
+	ErrTooManyRules          = errors.New("lifecycle configuration has too many rules")
+	ErrInvalidRuleID         = errors.New("rule ID must be at most 255 characters")
+	ErrDuplicateRuleID       = errors.New("rule ID must be unique")
+	ErrInvalidRuleStatus     = errors.New("rule status must be either Enabled or Disabled")
+	ErrInvalidFilter         = errors.New("rule filter must have at most one of Prefix, Tag or And")
+	ErrMissingAction         = errors.New("rule must have at least one action")
+	ErrInvalidExpiration     = errors.New("expiration must have exactly one of Days, Date or ExpiredObjectDeleteMarker")
+	ErrInvalidExpirationDays = errors.New("expiration days must be a positive integer")
+	ErrInvalidExpirationDate = errors.New("expiration date must be at midnight UTC")
+	ErrDeleteMarkerWithTags  = errors.New("rule filtering on tags may not expire delete markers")
+	ErrInvalidNoncurrentDays = errors.New("noncurrent version expiration days must be a positive integer")
+	ErrInvalidTransition     = errors.New("transition must have exactly one of Days or Date")
+	ErrInvalidTransitionDays = errors.New("transition days must be a positive integer")
+	ErrInvalidTransitionDate = errors.New("transition date must be at midnight UTC")
+	ErrMissingStorageClass   = errors.New("transition must have a storage class")
+	ErrInvalidAbortDays      = errors.New("abort incomplete multipart upload days must be a positive integer")
+	ErrAbortUploadWithTags   = errors.New("rule filtering on tags may not abort incomplete multipart uploads")
+)
+
+// Status - status of a lifecycle rule.
+type Status string
+
+// Lifecycle rule states.
+const (
+	Enabled  Status = "Enabled"
+	Disabled Status = "Disabled"
+)
+
+// And - filter of a rule on a prefix and several tags.
+type And struct {
+	Prefix string        `xml:"Prefix,omitempty"`
+	Tags   []tagging.Tag `xml:"Tag,omitempty"`
+}
+
+// Filter - selects the objects a rule applies to, by prefix, tag or both.
+type Filter struct {
+	Prefix string       `xml:"Prefix,omitempty"`
+	Tag    *tagging.Tag `xml:"Tag,omitempty"`
+	And    *And         `xml:"And,omitempty"`
+}
+
+// Validate - checks that at most one of Prefix, Tag or And is given.
+func (filter Filter) Validate() error {
+	if filter.And != nil {
+		if filter.Prefix != "" || filter.Tag != nil {
+			return ErrInvalidFilter
+		}
+		return tagging.Tagging{TagSet: tagging.TagSet{Tags: filter.And.Tags}}.Validate(tagging.MaxObjectTags)
+	}
+	if filter.Tag != nil {
+		if filter.Prefix != "" {
+			return ErrInvalidFilter
+		}
+		return filter.Tag.Validate()
+	}
+	return nil
+}
+
+// Expiration - expires current object versions after a number of days
+// or at a date, or removes delete markers left without any version.
+type Expiration struct {
+	Days                      int        `xml:"Days,omitempty"`
+	Date                      *time.Time `xml:"Date,omitempty"`
+	ExpiredObjectDeleteMarker bool       `xml:"ExpiredObjectDeleteMarker,omitempty"`
+}
+
+// Validate - checks that exactly one expiration is given.
+func (expiration Expiration) Validate() error {
+	count := 0
+	if expiration.Days != 0 {
+		if expiration.Days < 0 {
+			return ErrInvalidExpirationDays
+		}
+		count++
+	}
+	if expiration.Date != nil {
+		date := expiration.Date.UTC()
+		if !date.Equal(date.Truncate(24 * time.Hour)) {
+			return ErrInvalidExpirationDate
+		}
+		count++
+	}
+	if expiration.ExpiredObjectDeleteMarker {
+		count++
+	}
+	if count != 1 {
+		return ErrInvalidExpiration
+	}
+	return nil
+}
+
+// Transition - moves the data of current object versions to the remote
+// tier named by StorageClass after a number of days or at a date.
+type Transition struct {
+	Days         int        `xml:"Days,omitempty"`
+	Date         *time.Time `xml:"Date,omitempty"`
+	StorageClass string     `xml:"StorageClass"`
+}
+
+// Validate - checks that exactly one of Days or Date, and a storage
+// class are given.
+func (transition Transition) Validate() error {
+	if transition.Days < 0 {
+		return ErrInvalidTransitionDays
+	}
+	if transition.Date != nil {
+		date := transition.Date.UTC()
+		if !date.Equal(date.Truncate(24 * time.Hour)) {
+			return ErrInvalidTransitionDate
+		}
+	}
+	if (transition.Days != 0) == (transition.Date != nil) {
+		return ErrInvalidTransition
+	}
+	if transition.StorageClass == "" {
+		return ErrMissingStorageClass
+	}
+	return nil
+}
+
+// due - returns true if an object modified at modTime is transitioned
+// at the given time.
+func (transition Transition) due(modTime, now time.Time) bool {
+	if transition.Date != nil {
+		return !now.Before(*transition.Date)
+	}
+	return !now.Before(ExpectedExpiryTime(modTime, transition.Days))
+}
+
+// NoncurrentVersionExpiration - permanently removes object versions a
+// number of days after they became noncurrent.
+type NoncurrentVersionExpiration struct {
+	NoncurrentDays int `xml:"NoncurrentDays"`
+}
+
+// AbortIncompleteMultipartUpload - aborts the multipart uploads which
+// are not completed a number of days after they were initiated.
+type AbortIncompleteMultipartUpload struct {
+	DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+}
+
+// Rule - lifecycle rule applying actions to the objects selected by its
+// filter. The Prefix element is deprecated in favour of Filter.
+type Rule struct {
+	ID                             string                          `xml:"ID,omitempty"`
+	Status                         Status                          `xml:"Status"`
+	Prefix                         string                          `xml:"Prefix,omitempty"`
+	Filter                         *Filter                         `xml:"Filter,omitempty"`
+	Transition                     *Transition                     `xml:"Transition,omitempty"`
+	Expiration                     *Expiration                     `xml:"Expiration,omitempty"`
+	NoncurrentVersionExpiration    *NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty"`
+	AbortIncompleteMultipartUpload *AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+}
+
+// Validate - checks the rule ID, status, filter and actions.
+func (rule Rule) Validate() error {
+	if len(rule.ID) > maxRuleIDLength {
+		return ErrInvalidRuleID
+	}
+	if rule.Status != Enabled && rule.Status != Disabled {
+		return ErrInvalidRuleStatus
+	}
+	if rule.Filter != nil {
+		if rule.Prefix != "" {
+			return ErrInvalidFilter
+		}
+		if err := rule.Filter.Validate(); err != nil {
+			return err
+		}
+	}
+	if rule.Expiration == nil && rule.NoncurrentVersionExpiration == nil && rule.Transition == nil &&
+		rule.AbortIncompleteMultipartUpload == nil {
+		return ErrMissingAction
+	}
+	if rule.Transition != nil {
+		if err := rule.Transition.Validate(); err != nil {
+			return err
+		}
+	}
+	if rule.Expiration != nil {
+		if err := rule.Expiration.Validate(); err != nil {
+			return err
+		}
+		if rule.Expiration.ExpiredObjectDeleteMarker && len(rule.FilterTags()) > 0 {
+			return ErrDeleteMarkerWithTags
+		}
+	}
+	if rule.NoncurrentVersionExpiration != nil && rule.NoncurrentVersionExpiration.NoncurrentDays <= 0 {
+		return ErrInvalidNoncurrentDays
+	}
+	if rule.AbortIncompleteMultipartUpload != nil {
+		// Multipart uploads have no tags to filter on.
+		if rule.AbortIncompleteMultipartUpload.DaysAfterInitiation <= 0 {
+			return ErrInvalidAbortDays
+		}
+		if len(rule.FilterTags()) > 0 {
+			return ErrAbortUploadWithTags
+		}
+	}
+	return nil
+}
+
+// FilterPrefix - returns the prefix of the objects the rule applies to.
+func (rule Rule) FilterPrefix() string {
+	switch {
+	case rule.Filter == nil:
+		return rule.Prefix
+	case rule.Filter.And != nil:
+		return rule.Filter.And.Prefix
+	}
+	return rule.Filter.Prefix
+}
+
+// FilterTags - returns the tags of the objects the rule applies to.
+func (rule Rule) FilterTags() []tagging.Tag {
+	switch {
+	case rule.Filter == nil:
+		return nil
+	case rule.Filter.And != nil:
+		return rule.Filter.And.Tags
+	case rule.Filter.Tag != nil:
+		return []tagging.Tag{*rule.Filter.Tag}
+	}
+	return nil
+}
+
+// matches - returns true if the rule is enabled and applies to the object.
+func (rule Rule) matches(obj ObjectOpts) bool {
+	if rule.Status != Enabled || !strings.HasPrefix(obj.Name, rule.FilterPrefix()) {
+		return false
+	}
+	for _, tag := range rule.FilterTags() {
+		if value, ok := obj.Tags[tag.Key]; !ok || value != tag.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Lifecycle - lifecycle configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTlifecycle.html
+type Lifecycle struct {
+	XMLName xml.Name `xml:"LifecycleConfiguration"`
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// Validate - checks every rule, and that rule IDs are unique.
+func (lc Lifecycle) Validate() error {
+	if len(lc.Rules) == 0 {
+		return ErrNoRules
+	}
+	if len(lc.Rules) > maxRules {
+		return ErrTooManyRules
+	}
+	ids := make(map[string]struct{}, len(lc.Rules))
+	for _, rule := range lc.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if rule.ID == "" {
+			continue
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return ErrDuplicateRuleID
+		}
+		ids[rule.ID] = struct{}{}
+	}
+	return nil
+}
+
+// Action - action to apply to an object version.
+type Action int
+
+const (
+	// NoneAction - the object version is kept.
+	NoneAction Action = iota
+	// DeleteAction - the current version of the object is deleted, a
+	// delete marker is added instead in versioned buckets.
+	DeleteAction
+	// DeleteVersionAction - the object version or delete marker is
+	// permanently removed.
+	DeleteVersionAction
+	// TransitionAction - the data of the current version of the object
+	// is moved to a remote tier.
+	TransitionAction
+)
+
+// ObjectOpts - object version lifecycle rules are applied to.
+type ObjectOpts struct {
+	Name         string
+	ModTime      time.Time
+	Tags         map[string]string
+	IsLatest     bool
+	DeleteMarker bool
+
+	// True if the data of the version was already moved to a remote tier.
+	Transitioned bool
+
+	// Number of versions and delete markers of the object.
+	NumVersions int
+
+	// Time at which a noncurrent version was replaced by a newer one.
+	SuccessorModTime time.Time
+}
+
+// ExpectedExpiryTime - returns the time at which an object modified at
+// modTime expires after days, rounded up to the next midnight UTC.
+func ExpectedExpiryTime(modTime time.Time, days int) time.Time {
+	t := modTime.UTC().Add(time.Duration(days+1) * 24 * time.Hour)
+	return t.Truncate(24 * time.Hour)
+}
+
+// ComputeAction - returns the action the rules of the configuration
+// apply to the object version at the given time. Expiration takes
+// precedence over transition.
+func (lc Lifecycle) ComputeAction(obj ObjectOpts, now time.Time) Action {
+	action := NoneAction
+	for _, rule := range lc.Rules {
+		if !rule.matches(obj) {
+			continue
+		}
+
+		switch {
+		case !obj.IsLatest:
+			expiration := rule.NoncurrentVersionExpiration
+			if expiration != nil && !obj.SuccessorModTime.IsZero() &&
+				!now.Before(ExpectedExpiryTime(obj.SuccessorModTime, expiration.NoncurrentDays)) {
+				return DeleteVersionAction
+			}
+		case obj.DeleteMarker:
+			// Delete markers left without any version have expired.
+			if rule.Expiration != nil && rule.Expiration.ExpiredObjectDeleteMarker && obj.NumVersions == 1 {
+				return DeleteVersionAction
+			}
+		case rule.Expiration != nil:
+			if date := rule.Expiration.Date; date != nil && !now.Before(*date) {
+				return DeleteAction
+			}
+			if days := rule.Expiration.Days; days > 0 && !now.Before(ExpectedExpiryTime(obj.ModTime, days)) {
+				return DeleteAction
+			}
+		}
+
+		if rule.isTransitionDue(obj, now) {
+			action = TransitionAction
+		}
+	}
+	return action
+}
+
+// PredictExpiryTime - returns the ID of the rule which expires the
+// current version of the object first and the time it expires at, a
+// zero time if no rule expires it.
+func (lc Lifecycle) PredictExpiryTime(obj ObjectOpts) (ruleID string, expiry time.Time) {
+	if !obj.IsLatest || obj.DeleteMarker {
+		return "", time.Time{}
+	}
+	for _, rule := range lc.Rules {
+		if rule.Expiration == nil || !rule.matches(obj) {
+			continue
+		}
+
+		var t time.Time
+		switch {
+		case rule.Expiration.Date != nil:
+			t = rule.Expiration.Date.UTC()
+		case rule.Expiration.Days > 0:
+			t = ExpectedExpiryTime(obj.ModTime, rule.Expiration.Days)
+		default:
+			continue
+		}
+		if expiry.IsZero() || t.Before(expiry) {
+			ruleID, expiry = rule.ID, t
+		}
+	}
+	return ruleID, expiry
+}
+
+// IsUploadAbortDue - returns true if a rule aborts the multipart upload
+// of the object initiated at the given time.
+func (lc Lifecycle) IsUploadAbortDue(object string, initiated, now time.Time) bool {
+	for _, rule := range lc.Rules {
+		abort := rule.AbortIncompleteMultipartUpload
+		if abort == nil || !rule.matches(ObjectOpts{Name: object}) {
+			continue
+		}
+		if !now.Before(ExpectedExpiryTime(initiated, abort.DaysAfterInitiation)) {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTier - returns the storage class of the first rule which
+// transitions the object version at the given time, empty if none.
+func (lc Lifecycle) TransitionTier(obj ObjectOpts, now time.Time) string {
+	for _, rule := range lc.Rules {
+		if rule.matches(obj) && rule.isTransitionDue(obj, now) {
+			return rule.Transition.StorageClass
+		}
+	}
+	return ""
+}
+
+// isTransitionDue - returns true if the rule transitions the object
+// version at the given time, only current versions still stored
+// locally are transitioned.
+func (rule Rule) isTransitionDue(obj ObjectOpts, now time.Time) bool {
+	if rule.Transition == nil || !obj.IsLatest || obj.DeleteMarker || obj.Transitioned {
+		return false
+	}
+	return rule.Transition.due(obj.ModTime, now)
+}
+
+// ParseLifecycleConfig - parses and validates a lifecycle configuration.
+func ParseLifecycleConfig(reader io.Reader) (*Lifecycle, error) {
+	var lc Lifecycle
+	if err := xml.NewDecoder(reader).Decode(&lc); err != nil {
+		return nil, err
+	}
+	if err := lc.Validate(); err != nil {
+		return nil, err
+	}
+	lc.XMLNS = ""
+	return &lc, nil
+}
@@ -0,0 +1,246 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/tagging"
+)
+
+func TestParseLifecycleConfig(t *testing.T) {
+	errMalformed := errors.New("malformed")
+	testCases := []struct {
+		config      string
+		expectedErr error
+	}{
+		{`<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ID>logs</ID><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>`, nil},
+		{`<LifecycleConfiguration><Rule><Status>Disabled</Status><Prefix>tmp/</Prefix><Expiration><Date>2019-01-01T00:00:00.000Z</Date></Expiration></Rule></LifecycleConfiguration>`, nil},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><And><Prefix>a/</Prefix><Tag><Key>k</Key><Value>v</Value></Tag><Tag><Key>l</Key><Value>w</Value></Tag></And></Filter><NoncurrentVersionExpiration><NoncurrentDays>7</NoncurrentDays></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`, nil},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter></Filter><Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration></Rule></LifecycleConfiguration>`, nil},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition><Expiration><Days>365</Days></Expiration></Rule></LifecycleConfiguration>`, nil},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Prefix>uploads/</Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`, nil},
+		{`<LifecycleConfiguration></LifecycleConfiguration>`, ErrNoRules},
+		{`<LifecycleConfiguration>` + strings.Repeat(`<Rule><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule>`, maxRules+1) + `</LifecycleConfiguration>`, ErrTooManyRules},
+		{`<LifecycleConfiguration><Rule><ID>` + strings.Repeat("a", 256) + `</ID><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, ErrInvalidRuleID},
+		{`<LifecycleConfiguration><Rule><ID>a</ID><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule><Rule><ID>a</ID><Status>Enabled</Status><Expiration><Days>2</Days></Expiration></Rule></LifecycleConfiguration>`, ErrDuplicateRuleID},
+		{`<LifecycleConfiguration><Rule><Status>enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, ErrInvalidRuleStatus},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Prefix>a</Prefix><Filter><Prefix>b</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, ErrInvalidFilter},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Prefix>b</Prefix><Tag><Key>k</Key><Value>v</Value></Tag></Filter><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, ErrInvalidFilter},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Tag><Key>aws:k</Key><Value>v</Value></Tag></Filter><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, tagging.ErrInvalidTagKey},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><And><Tag><Key>k</Key><Value>v</Value></Tag><Tag><Key>k</Key><Value>w</Value></Tag></And></Filter><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, tagging.ErrDuplicateTagKey},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status></Rule></LifecycleConfiguration>`, ErrMissingAction},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration></Expiration></Rule></LifecycleConfiguration>`, ErrInvalidExpiration},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration><Days>1</Days><Date>2019-01-01T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`, ErrInvalidExpiration},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration><Days>-1</Days></Expiration></Rule></LifecycleConfiguration>`, ErrInvalidExpirationDays},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration><Date>2019-01-01T10:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>`, ErrInvalidExpirationDate},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter><Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration></Rule></LifecycleConfiguration>`, ErrDeleteMarkerWithTags},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>0</NoncurrentDays></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`, ErrInvalidNoncurrentDays},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Transition><Days>0</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`, ErrInvalidTransition},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Transition><Days>1</Days><Date>2019-01-01T00:00:00Z</Date><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`, ErrInvalidTransition},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Transition><Days>-1</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`, ErrInvalidTransitionDays},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Transition><Date>2019-01-01T10:00:00Z</Date><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`, ErrInvalidTransitionDate},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Transition><Days>1</Days></Transition></Rule></LifecycleConfiguration>`, ErrMissingStorageClass},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>0</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`, ErrInvalidAbortDays},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`, ErrAbortUploadWithTags},
+		{`<LifecycleConfiguration><Rule>`, errMalformed},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseLifecycleConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr == errMalformed {
+			if err == nil {
+				t.Fatalf("case %v: expected an error, got none", i+1)
+			}
+			continue
+		}
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestExpectedExpiryTime(t *testing.T) {
+	testCases := []struct {
+		modTime        time.Time
+		days           int
+		expectedResult time.Time
+	}{
+		{time.Date(2018, 3, 10, 14, 30, 0, 0, time.UTC), 1, time.Date(2018, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2018, 3, 10, 0, 0, 0, 0, time.UTC), 30, time.Date(2018, 4, 10, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2018, 3, 10, 23, 0, 0, 0, time.FixedZone("UTC-2", -2*3600)), 1, time.Date(2018, 3, 13, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for i, testCase := range testCases {
+		if result := ExpectedExpiryTime(testCase.modTime, testCase.days); !result.Equal(testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestComputeAction(t *testing.T) {
+	lc, err := ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration>` +
+		`<Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>` +
+		`<Rule><Status>Enabled</Status><Filter><And><Prefix>data/</Prefix><Tag><Key>temp</Key><Value>true</Value></Tag></And></Filter><Expiration><Days>1</Days></Expiration></Rule>` +
+		`<Rule><Status>Disabled</Status><Filter><Prefix>disabled/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>` +
+		`<Rule><Status>Enabled</Status><Filter><Prefix>old/</Prefix></Filter><Expiration><Date>2018-01-01T00:00:00Z</Date></Expiration></Rule>` +
+		`<Rule><Status>Enabled</Status><Filter></Filter><Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration><NoncurrentVersionExpiration><NoncurrentDays>2</NoncurrentDays></NoncurrentVersionExpiration></Rule>` +
+		`</LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2018, 3, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	lastWeek := now.Add(-7 * 24 * time.Hour)
+	testCases := []struct {
+		obj            ObjectOpts
+		expectedResult Action
+	}{
+		{ObjectOpts{Name: "logs/a", ModTime: lastWeek, IsLatest: true, NumVersions: 1}, DeleteAction},
+		// Expires at midnight after one full day.
+		{ObjectOpts{Name: "logs/a", ModTime: yesterday, IsLatest: true, NumVersions: 1}, NoneAction},
+		{ObjectOpts{Name: "other/a", ModTime: lastWeek, IsLatest: true, NumVersions: 1}, NoneAction},
+		{ObjectOpts{Name: "data/a", ModTime: lastWeek, IsLatest: true, NumVersions: 1}, NoneAction},
+		{ObjectOpts{Name: "data/a", ModTime: lastWeek, Tags: map[string]string{"temp": "false"}, IsLatest: true, NumVersions: 1}, NoneAction},
+		{ObjectOpts{Name: "data/a", ModTime: lastWeek, Tags: map[string]string{"temp": "true", "x": "y"}, IsLatest: true, NumVersions: 1}, DeleteAction},
+		{ObjectOpts{Name: "disabled/a", ModTime: lastWeek, IsLatest: true, NumVersions: 1}, NoneAction},
+		{ObjectOpts{Name: "old/a", ModTime: now, IsLatest: true, NumVersions: 1}, DeleteAction},
+		// Delete markers are only removed once no version is left.
+		{ObjectOpts{Name: "other/a", ModTime: lastWeek, IsLatest: true, DeleteMarker: true, NumVersions: 1}, DeleteVersionAction},
+		{ObjectOpts{Name: "other/a", ModTime: lastWeek, IsLatest: true, DeleteMarker: true, NumVersions: 2}, NoneAction},
+		// Noncurrent versions expire after they were replaced.
+		{ObjectOpts{Name: "other/a", ModTime: lastWeek, NumVersions: 2, SuccessorModTime: lastWeek}, DeleteVersionAction},
+		{ObjectOpts{Name: "other/a", ModTime: lastWeek, NumVersions: 2, SuccessorModTime: yesterday}, NoneAction},
+		{ObjectOpts{Name: "other/a", ModTime: lastWeek, DeleteMarker: true, NumVersions: 2, SuccessorModTime: lastWeek}, DeleteVersionAction},
+	}
+
+	for i, testCase := range testCases {
+		if result := lc.ComputeAction(testCase.obj, now); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestPredictExpiryTime(t *testing.T) {
+	lc, err := ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration>` +
+		`<Rule><ID>logs</ID><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>30</Days></Expiration></Rule>` +
+		`<Rule><ID>logs-tmp</ID><Status>Enabled</Status><Filter><Prefix>logs/tmp/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>` +
+		`<Rule><ID>disabled</ID><Status>Disabled</Status><Filter><Prefix>disabled/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>` +
+		`<Rule><ID>old</ID><Status>Enabled</Status><Filter><Prefix>old/</Prefix></Filter><Expiration><Date>2018-01-01T00:00:00Z</Date></Expiration></Rule>` +
+		`<Rule><ID>markers</ID><Status>Enabled</Status><Filter></Filter><Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration></Rule>` +
+		`</LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modTime := time.Date(2018, 3, 10, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		obj            ObjectOpts
+		expectedRuleID string
+		expectedExpiry time.Time
+	}{
+		{ObjectOpts{Name: "logs/a", ModTime: modTime, IsLatest: true}, "logs", time.Date(2018, 4, 10, 0, 0, 0, 0, time.UTC)},
+		// The rule expiring the object first is returned.
+		{ObjectOpts{Name: "logs/tmp/a", ModTime: modTime, IsLatest: true}, "logs-tmp", time.Date(2018, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{ObjectOpts{Name: "old/a", ModTime: modTime, IsLatest: true}, "old", time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ObjectOpts{Name: "disabled/a", ModTime: modTime, IsLatest: true}, "", time.Time{}},
+		{ObjectOpts{Name: "other/a", ModTime: modTime, IsLatest: true}, "", time.Time{}},
+		{ObjectOpts{Name: "logs/a", ModTime: modTime}, "", time.Time{}},
+		{ObjectOpts{Name: "logs/a", ModTime: modTime, IsLatest: true, DeleteMarker: true}, "", time.Time{}},
+	}
+
+	for i, testCase := range testCases {
+		ruleID, expiry := lc.PredictExpiryTime(testCase.obj)
+		if ruleID != testCase.expectedRuleID || !expiry.Equal(testCase.expectedExpiry) {
+			t.Fatalf("case %v: expected: %v %v, got: %v %v", i+1, testCase.expectedRuleID, testCase.expectedExpiry, ruleID, expiry)
+		}
+	}
+}
+
+func TestIsUploadAbortDue(t *testing.T) {
+	lc, err := ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration>` +
+		`<Rule><Status>Enabled</Status><Filter><Prefix>uploads/</Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>` +
+		`<Rule><Status>Disabled</Status><Filter><Prefix>disabled/</Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>` +
+		`<Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>` +
+		`</LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initiated := time.Date(2018, 3, 10, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		object   string
+		now      time.Time
+		expected bool
+	}{
+		{"uploads/a", time.Date(2018, 3, 17, 23, 59, 59, 0, time.UTC), false},
+		// Uploads are aborted at the midnight following the number of days.
+		{"uploads/a", time.Date(2018, 3, 18, 0, 0, 0, 0, time.UTC), true},
+		{"disabled/a", time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"logs/a", time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for i, testCase := range testCases {
+		if due := lc.IsUploadAbortDue(testCase.object, initiated, testCase.now); due != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, due)
+		}
+	}
+}
+
+func TestComputeTransitionAction(t *testing.T) {
+	lc, err := ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration>` +
+		`<Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Transition><Days>1</Days><StorageClass>WARM</StorageClass></Transition><Expiration><Days>5</Days></Expiration></Rule>` +
+		`<Rule><Status>Enabled</Status><Filter><Prefix>old/</Prefix></Filter><Transition><Date>2018-01-01T00:00:00Z</Date><StorageClass>COLD</StorageClass></Transition></Rule>` +
+		`</LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2018, 3, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	twoDaysAgo := now.Add(-2 * 24 * time.Hour)
+	lastWeek := now.Add(-7 * 24 * time.Hour)
+	testCases := []struct {
+		obj            ObjectOpts
+		expectedResult Action
+		expectedTier   string
+	}{
+		{ObjectOpts{Name: "logs/a", ModTime: twoDaysAgo, IsLatest: true, NumVersions: 1}, TransitionAction, "WARM"},
+		{ObjectOpts{Name: "logs/a", ModTime: yesterday, IsLatest: true, NumVersions: 1}, NoneAction, ""},
+		// Expiration takes precedence over transition.
+		{ObjectOpts{Name: "logs/a", ModTime: lastWeek, IsLatest: true, NumVersions: 1}, DeleteAction, "WARM"},
+		// Transitioned versions, noncurrent versions and delete markers are not transitioned.
+		{ObjectOpts{Name: "logs/a", ModTime: twoDaysAgo, IsLatest: true, Transitioned: true, NumVersions: 1}, NoneAction, ""},
+		{ObjectOpts{Name: "logs/a", ModTime: twoDaysAgo, NumVersions: 2, SuccessorModTime: yesterday}, NoneAction, ""},
+		{ObjectOpts{Name: "logs/a", ModTime: twoDaysAgo, IsLatest: true, DeleteMarker: true, NumVersions: 1}, NoneAction, ""},
+		{ObjectOpts{Name: "old/a", ModTime: now, IsLatest: true, NumVersions: 1}, TransitionAction, "COLD"},
+		{ObjectOpts{Name: "other/a", ModTime: lastWeek, IsLatest: true, NumVersions: 1}, NoneAction, ""},
+	}
+
+	for i, testCase := range testCases {
+		if result := lc.ComputeAction(testCase.obj, now); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if tier := lc.TransitionTier(testCase.obj, now); tier != testCase.expectedTier {
+			t.Fatalf("case %v: expected tier: %v, got: %v", i+1, testCase.expectedTier, tier)
+		}
+	}
+}
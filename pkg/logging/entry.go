@@ -0,0 +1,122 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time format of access log records.
+const timeFormat = "[02/Jan/2006:15:04:05 -0700]"
+
+// Entry - access log record of one request as per
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/LogFormat.html
+// Empty fields are written as "-".
+type Entry struct {
+	BucketOwner      string
+	Bucket           string
+	Time             time.Time
+	RemoteIP         string
+	Requester        string
+	RequestID        string
+	Operation        string
+	Key              string
+	RequestURI       string
+	HTTPStatus       int
+	ErrorCode        string
+	BytesSent        int64
+	ObjectSize       int64
+	TotalTime        time.Duration
+	TurnAroundTime   time.Duration
+	Referer          string
+	UserAgent        string
+	VersionID        string
+	HostID           string
+	SignatureVersion string
+	CipherSuite      string
+	AuthType         string
+	HostHeader       string
+	TLSVersion       string
+}
+
+// field - returns s, "-" if it is empty.
+func field(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// quotedField - returns s double quoted, "-" if it is empty.
+func quotedField(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
+
+// intField - returns i, "-" if it is not positive.
+func intField(i int64) string {
+	if i <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(i, 10)
+}
+
+// durationField - returns d in milliseconds, "-" if it is not positive.
+func durationField(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(int64(d/time.Millisecond), 10)
+}
+
+// String - returns the access log record, without a trailing newline.
+func (e Entry) String() string {
+	status := "-"
+	if e.HTTPStatus > 0 {
+		status = strconv.Itoa(e.HTTPStatus)
+	}
+	return strings.Join([]string{
+		field(e.BucketOwner),
+		field(e.Bucket),
+		e.Time.UTC().Format(timeFormat),
+		field(e.RemoteIP),
+		field(e.Requester),
+		field(e.RequestID),
+		field(e.Operation),
+		field(e.Key),
+		quotedField(e.RequestURI),
+		status,
+		field(e.ErrorCode),
+		intField(e.BytesSent),
+		intField(e.ObjectSize),
+		durationField(e.TotalTime),
+		durationField(e.TurnAroundTime),
+		quotedField(e.Referer),
+		quotedField(e.UserAgent),
+		field(e.VersionID),
+		field(e.HostID),
+		field(e.SignatureVersion),
+		field(e.CipherSuite),
+		field(e.AuthType),
+		field(e.HostHeader),
+		field(e.TLSVersion),
+	}, " ")
+}
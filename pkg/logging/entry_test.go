@@ -0,0 +1,69 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntryString(t *testing.T) {
+	requestTime := time.Date(2018, time.October, 2, 15, 4, 5, 0, time.FixedZone("CEST", 2*60*60))
+	testCases := []struct {
+		entry          Entry
+		expectedResult string
+	}{
+		{Entry{
+			BucketOwner:      "02d6176db174dc93cb1b899f7c6078f08654445fe8cf1b6ce98d8855f66bdbf4",
+			Bucket:           "mybucket",
+			Time:             requestTime,
+			RemoteIP:         "192.0.2.3",
+			Requester:        "minio",
+			RequestID:        "1559F81B0C5BC3E8",
+			Operation:        "REST.GET.OBJECT",
+			Key:              "photos/2018/a%20b.jpg",
+			RequestURI:       "GET /mybucket/photos/2018/a%20b.jpg HTTP/1.1",
+			HTTPStatus:       200,
+			BytesSent:        2662992,
+			ObjectSize:       3462992,
+			TotalTime:        70 * time.Millisecond,
+			Referer:          "http://www.example.com/",
+			UserAgent:        `Minio (linux; amd64) minio-go/6.0.7 "test"`,
+			SignatureVersion: "SigV4",
+			CipherSuite:      "ECDHE-RSA-AES128-GCM-SHA256",
+			AuthType:         "AuthHeader",
+			HostHeader:       "localhost:9000",
+			TLSVersion:       "TLSv1.2",
+		}, `02d6176db174dc93cb1b899f7c6078f08654445fe8cf1b6ce98d8855f66bdbf4 mybucket [02/Oct/2018:13:04:05 +0000] 192.0.2.3 minio 1559F81B0C5BC3E8 REST.GET.OBJECT photos/2018/a%20b.jpg "GET /mybucket/photos/2018/a%20b.jpg HTTP/1.1" 200 - 2662992 3462992 70 - "http://www.example.com/" "Minio (linux; amd64) minio-go/6.0.7 \"test\"" - - SigV4 ECDHE-RSA-AES128-GCM-SHA256 AuthHeader localhost:9000 TLSv1.2`},
+		{Entry{
+			Bucket:     "mybucket",
+			Time:       requestTime,
+			RemoteIP:   "192.0.2.3",
+			Operation:  "REST.GET.BUCKET",
+			RequestURI: "GET /mybucket HTTP/1.1",
+			HTTPStatus: 403,
+			ErrorCode:  "AccessDenied",
+			BytesSent:  243,
+		}, `- mybucket [02/Oct/2018:13:04:05 +0000] 192.0.2.3 - - REST.GET.BUCKET - "GET /mybucket HTTP/1.1" 403 AccessDenied 243 - - - - - - - - - - - -`},
+	}
+
+	for i, testCase := range testCases {
+		if result := testCase.entry.String(); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
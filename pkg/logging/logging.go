@@ -0,0 +1,77 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// Errors returned when validating a logging configuration.
+var (
+	ErrMissingTargetBucket = errors.New("logging configuration must have a target bucket")
+	ErrTargetGrants        = errors.New("target grants of logging configurations are not supported")
+)
+
+// TargetGrants - permissions granted on the log objects, kept as is.
+type TargetGrants struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+// LoggingEnabled - bucket and key prefix the log objects are written to.
+type LoggingEnabled struct {
+	TargetBucket string        `xml:"TargetBucket"`
+	TargetPrefix string        `xml:"TargetPrefix"`
+	TargetGrants *TargetGrants `xml:"TargetGrants,omitempty"`
+}
+
+// Config - logging status of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTlogging.html
+// Logging is disabled if LoggingEnabled is not set.
+type Config struct {
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// Validate - checks that an enabled logging has a target bucket.
+func (config Config) Validate() error {
+	if config.LoggingEnabled == nil {
+		return nil
+	}
+	if config.LoggingEnabled.TargetBucket == "" {
+		return ErrMissingTargetBucket
+	}
+	if config.LoggingEnabled.TargetGrants != nil {
+		return ErrTargetGrants
+	}
+	return nil
+}
+
+// ParseConfig - parses and validates a logging configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.XMLNS = ""
+	return &config, nil
+}
@@ -0,0 +1,69 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	errMalformed := errors.New("malformed")
+	testCases := []struct {
+		config         string
+		expectedErr    error
+		expectedTarget *LoggingEnabled
+	}{
+		{`<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"></BucketLoggingStatus>`, nil, nil},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>mybucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, nil,
+			&LoggingEnabled{TargetBucket: "logs", TargetPrefix: "mybucket/"}},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket></LoggingEnabled></BucketLoggingStatus>`, nil,
+			&LoggingEnabled{TargetBucket: "logs"}},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetPrefix>mybucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, ErrMissingTargetBucket, nil},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetGrants><Grant></Grant></TargetGrants></LoggingEnabled></BucketLoggingStatus>`, ErrTargetGrants, nil},
+		{`<BucketLoggingStatus>`, errMalformed, nil},
+	}
+
+	for i, testCase := range testCases {
+		config, err := ParseConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr == errMalformed {
+			if err == nil {
+				t.Fatalf("case %v: expected an error, got none", i+1)
+			}
+			continue
+		}
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if config.XMLNS != "" {
+			t.Fatalf("case %v: expected no namespace, got: %v", i+1, config.XMLNS)
+		}
+		if testCase.expectedTarget == nil {
+			if config.LoggingEnabled != nil {
+				t.Fatalf("case %v: expected logging disabled, got: %v", i+1, config.LoggingEnabled)
+			}
+			continue
+		}
+		if config.LoggingEnabled == nil || *config.LoggingEnabled != *testCase.expectedTarget {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedTarget, config.LoggingEnabled)
+		}
+	}
+}
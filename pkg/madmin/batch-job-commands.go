@@ -0,0 +1,211 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BatchJobType represents the operation a batch job applies to objects
+type BatchJobType string
+
+const (
+	// BatchJobKeyRotate rotates the SSE-C key of encrypted objects
+	BatchJobKeyRotate BatchJobType = "keyrotate"
+	// BatchJobReplicate copies objects to a replication target
+	BatchJobReplicate BatchJobType = "replicate"
+	// BatchJobExpire deletes objects older than a given age
+	BatchJobExpire BatchJobType = "expire"
+	// BatchJobTag sets and removes tags of objects
+	BatchJobTag BatchJobType = "tag"
+)
+
+// BatchJobRequest represents a batch job processing all objects under
+// prefix in bucket. OldKey and NewKey are the 32 bytes SSE-C keys of
+// keyrotate jobs, TargetArn the remote target of replicate jobs,
+// OlderThan the minimum age of objects deleted by expire jobs and Tags
+// and RemoveTags the tags set and the tag keys removed by tag jobs
+type BatchJobRequest struct {
+	Type       BatchJobType      `json:"type"`
+	Bucket     string            `json:"bucket"`
+	Prefix     string            `json:"prefix,omitempty"`
+	OldKey     []byte            `json:"oldKey,omitempty"`
+	NewKey     []byte            `json:"newKey,omitempty"`
+	TargetArn  string            `json:"targetArn,omitempty"`
+	OlderThan  time.Duration     `json:"olderThan,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	RemoveTags []string          `json:"removeTags,omitempty"`
+}
+
+// BatchJobStatus represents the state of a batch job on a server
+type BatchJobStatus string
+
+// Batch job states
+const (
+	BatchJobRunning   BatchJobStatus = "running"
+	BatchJobCompleted BatchJobStatus = "completed"
+	BatchJobFailed    BatchJobStatus = "failed"
+	BatchJobCanceled  BatchJobStatus = "canceled"
+)
+
+// BatchJobProgress holds the progress of a batch job on one server
+type BatchJobProgress struct {
+	ID         string         `json:"id"`
+	Type       BatchJobType   `json:"type"`
+	Addr       string         `json:"addr"`
+	NodeIndex  int            `json:"nodeIndex"`
+	Status     BatchJobStatus `json:"status"`
+	Processed  uint64         `json:"processed"`
+	Skipped    uint64         `json:"skipped"`
+	Failed     uint64         `json:"failed"`
+	LastObject string         `json:"lastObject,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	Updated    time.Time      `json:"updated"`
+}
+
+// ServerBatchJobProgress holds the progress of a batch job reported by one server
+type ServerBatchJobProgress struct {
+	Error string            `json:"error"`
+	Addr  string            `json:"addr"`
+	Data  *BatchJobProgress `json:"data"`
+}
+
+// BatchJobInfo holds a batch job along with its progress on each server,
+// SSE-C keys are never returned
+type BatchJobInfo struct {
+	ID      string                   `json:"id"`
+	Request BatchJobRequest          `json:"request"`
+	Started time.Time                `json:"started"`
+	Servers []ServerBatchJobProgress `json:"servers"`
+}
+
+// CancelBatchJobResult holds the batch job cancellation result of one server
+type CancelBatchJobResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+}
+
+// StartBatchJob - starts a batch job on all servers.
+func (adm *AdminClient) StartBatchJob(req BatchJobRequest) (info BatchJobInfo, err error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return info, err
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/batch-job/start",
+		content: data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return info, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return info, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(respBytes, &info)
+	return info, err
+}
+
+// DescribeBatchJob - returns a batch job along with its progress on each server.
+func (adm *AdminClient) DescribeBatchJob(id string) (info BatchJobInfo, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("id", id)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/batch-job/describe",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return info, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return info, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(respBytes, &info)
+	return info, err
+}
+
+// CancelBatchJob - stops a batch job on all servers.
+func (adm *AdminClient) CancelBatchJob(id string) (results []CancelBatchJobResult, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("id", id)
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/batch-job/cancel",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBytes, &results)
+	return results, err
+}
+
+// ListBatchJobs - returns all batch jobs, most recently started first.
+func (adm *AdminClient) ListBatchJobs() (infos []BatchJobInfo, err error) {
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath: "/v1/batch-job/list",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBytes, &infos)
+	return infos, err
+}
@@ -0,0 +1,107 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// AccessType represents a canned anonymous access of a bucket
+type AccessType string
+
+const (
+	// AccessNone denies anonymous access
+	AccessNone AccessType = "none"
+	// AccessDownload allows anonymous downloads and listings
+	AccessDownload AccessType = "download"
+	// AccessUpload allows anonymous uploads
+	AccessUpload AccessType = "upload"
+	// AccessPublic allows anonymous downloads, listings and uploads
+	AccessPublic AccessType = "public"
+)
+
+// BucketAccess holds the canned anonymous access of a bucket or a prefix
+type BucketAccess struct {
+	Access AccessType `json:"access"`
+}
+
+// SetBucketAccess - grants the canned anonymous access to the objects of
+// a bucket under a prefix, an empty prefix is the whole bucket. The
+// bucket policy is updated with the statements of the access.
+func (adm *AdminClient) SetBucketAccess(bucket, prefix string, access AccessType) error {
+	data, err := json.Marshal(BucketAccess{Access: access})
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("prefix", prefix)
+
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath:     "/v1/set-bucket-access",
+		queryValues: queryValues,
+		content:     data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// GetBucketAccess - returns the canned anonymous access to the objects of
+// a bucket under a prefix.
+func (adm *AdminClient) GetBucketAccess(bucket, prefix string) (a BucketAccess, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("prefix", prefix)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/get-bucket-access",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return a, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return a, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return a, err
+	}
+
+	if err = json.Unmarshal(respBytes, &a); err != nil {
+		return a, err
+	}
+
+	return a, nil
+}
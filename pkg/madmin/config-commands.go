@@ -24,16 +24,25 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
 	"github.com/minio/minio/pkg/quick"
 )
 
+// ConfigValidationError - represents why a node can't use a subsystem
+// of the config, e.g. a notification target it can't connect to.
+type ConfigValidationError struct {
+	Subsystem string `json:"subsystem"`
+	Error     string `json:"error"`
+}
+
 // NodeSummary - represents the result of an operation part of
 // set-config on a node.
 type NodeSummary struct {
-	Name   string `json:"name"`
-	ErrSet bool   `json:"errSet"`
-	ErrMsg string `json:"errMsg"`
+	Name           string                  `json:"name"`
+	ErrSet         bool                    `json:"errSet"`
+	ErrMsg         string                  `json:"errMsg"`
+	ValidationErrs []ConfigValidationError `json:"validationErrors,omitempty"`
 }
 
 // SetConfigResult - represents detailed results of a set-config
@@ -129,3 +138,86 @@ func (adm *AdminClient) SetConfig(config io.Reader) (r SetConfigResult, err erro
 	err = json.Unmarshal(jsonBytes, &r)
 	return r, err
 }
+
+// Text formats accepted by ExportConfig and ImportConfig.
+const (
+	// ConfigFormatKV - one key=value line per setting, nested keys are
+	// joined with '.' and values are JSON literals.
+	ConfigFormatKV = "kv"
+
+	// ConfigFormatYAML - YAML document having the structure of config.json.
+	ConfigFormatYAML = "yaml"
+)
+
+// ExportConfig - returns the config.json of a minio setup converted to
+// the given format.
+func (adm *AdminClient) ExportConfig(format string) ([]byte, error) {
+	// No TLS?
+	if !adm.secure {
+		return nil, fmt.Errorf("credentials/configuration cannot be retrieved over an insecure connection")
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("format", format)
+
+	// Execute GET on /minio/admin/v1/config/export to export config of a setup.
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/config/export",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// ImportConfig - sets config.json of a minio setup from config of the
+// given format, as returned by ExportConfig, and restarts the setup for
+// the configuration change to take effect.
+func (adm *AdminClient) ImportConfig(config io.Reader, format string) (r SetConfigResult, err error) {
+	const maxConfigSize = 256 * 1024 // 256KiB
+
+	if !adm.secure { // No TLS?
+		return r, fmt.Errorf("credentials/configuration cannot be updated over an insecure connection")
+	}
+
+	configBytes, err := ioutil.ReadAll(io.LimitReader(config, maxConfigSize+1))
+	if err != nil {
+		return r, err
+	}
+	if len(configBytes) > maxConfigSize {
+		return r, fmt.Errorf("too large file")
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("format", format)
+
+	// Execute PUT on /minio/admin/v1/config/import to set config.
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath:     "/v1/config/import",
+		queryValues: queryValues,
+		content:     configBytes,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return r, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return r, httpRespToErrorResponse(resp)
+	}
+
+	jsonBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return r, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &r)
+	return r, err
+}
@@ -0,0 +1,90 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// BucketDefaultTags holds the tags of the objects uploaded to a bucket
+// without tags of their own
+type BucketDefaultTags struct {
+	Tags map[string]string `json:"tags"`
+}
+
+// SetBucketDefaultTags - sets the tags of the objects uploaded to a
+// bucket without tags, no tags removes the default tags of the bucket.
+func (adm *AdminClient) SetBucketDefaultTags(bucket string, tags map[string]string) error {
+	data, err := json.Marshal(BucketDefaultTags{Tags: tags})
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath:     "/v1/set-bucket-default-tags",
+		queryValues: queryValues,
+		content:     data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// GetBucketDefaultTags - returns the tags of the objects uploaded to a
+// bucket without tags.
+func (adm *AdminClient) GetBucketDefaultTags(bucket string) (d BucketDefaultTags, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/get-bucket-default-tags",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return d, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return d, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return d, err
+	}
+
+	if err = json.Unmarshal(respBytes, &d); err != nil {
+		return d, err
+	}
+
+	return d, nil
+}
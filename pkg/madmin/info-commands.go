@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -74,10 +76,13 @@ type ServerProperties struct {
 	SQSARN   []string      `json:"sqsARN"`
 }
 
-// ServerConnStats holds network information
+// ServerConnStats holds network information, internode counters
+// hold bytes of RPC calls the server served to other servers
 type ServerConnStats struct {
-	TotalInputBytes  uint64 `json:"transferred"`
-	TotalOutputBytes uint64 `json:"received"`
+	TotalInputBytes      uint64 `json:"transferred"`
+	TotalOutputBytes     uint64 `json:"received"`
+	InternodeInputBytes  uint64 `json:"internodeInputBytes"`
+	InternodeOutputBytes uint64 `json:"internodeOutputBytes"`
 }
 
 // ServerHTTPMethodStats holds total number of HTTP operations from/to the server,
@@ -102,6 +107,18 @@ type ServerHTTPStats struct {
 	SuccessDELETEStats ServerHTTPMethodStats `json:"successDELETEs"`
 }
 
+// ServerDiskInfo holds space usage and state of a local disk of a
+// server, State is one of DriveState* values
+type ServerDiskInfo struct {
+	Endpoint  string `json:"endpoint"`
+	State     string `json:"state"`
+	Healing   bool   `json:"healing"`
+	Total     uint64 `json:"total"`
+	Used      uint64 `json:"used"`
+	Available uint64 `json:"available"`
+	Error     string `json:"error,omitempty"`
+}
+
 // ServerInfoData holds storage, connections and other
 // information of a given server
 type ServerInfoData struct {
@@ -109,6 +126,7 @@ type ServerInfoData struct {
 	ConnStats   ServerConnStats  `json:"network"`
 	HTTPStats   ServerHTTPStats  `json:"http"`
 	Properties  ServerProperties `json:"server"`
+	Disks       []ServerDiskInfo `json:"disks"`
 }
 
 // ServerInfo holds server information result of one node
@@ -147,3 +165,431 @@ func (adm *AdminClient) ServerInfo() ([]ServerInfo, error) {
 
 	return serversInfo, nil
 }
+
+// Cluster health states, from best to worst
+const (
+	ClusterHealthy     = "healthy"
+	ClusterDegraded    = "degraded"
+	ClusterReadOnly    = "read-only"
+	ClusterUnavailable = "unavailable"
+)
+
+// ServerHealth holds whether a server answered the health check
+type ServerHealth struct {
+	Addr   string `json:"addr"`
+	Online bool   `json:"online"`
+	Error  string `json:"error,omitempty"`
+}
+
+// SetHealth holds the number of online disks of an erasure set along
+// with the number of disks it needs to serve reads and writes
+type SetHealth struct {
+	Set          int      `json:"set"`
+	Status       string   `json:"status"`
+	OnlineDisks  int      `json:"onlineDisks"`
+	TotalDisks   int      `json:"totalDisks"`
+	ReadQuorum   int      `json:"readQuorum"`
+	WriteQuorum  int      `json:"writeQuorum"`
+	OfflineDisks []string `json:"offlineDisks,omitempty"`
+}
+
+// ClusterHealth holds the health of the cluster along with the health
+// of each server and erasure set, Status is one of Cluster* values
+type ClusterHealth struct {
+	Status  string         `json:"status"`
+	Servers []ServerHealth `json:"servers"`
+	Sets    []SetHealth    `json:"sets"`
+}
+
+// ClusterHealth - Connect to a minio server and call Cluster Health
+// Management API to fetch whether the cluster is healthy, degraded,
+// read-only or unavailable
+func (adm *AdminClient) ClusterHealth() (health ClusterHealth, err error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/health"})
+	defer closeResponse(resp)
+	if err != nil {
+		return health, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return health, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return health, err
+	}
+
+	err = json.Unmarshal(respBytes, &health)
+	return health, err
+}
+
+// BucketBandwidth holds bytes received and sent for a bucket, throughput
+// is expressed in bytes per second averaged over the server uptime
+type BucketBandwidth struct {
+	BytesIn          uint64  `json:"bytesIn"`
+	BytesOut         uint64  `json:"bytesOut"`
+	InputThroughput  float64 `json:"inputThroughput"`
+	OutputThroughput float64 `json:"outputThroughput"`
+}
+
+// ServerBandwidthData holds per bucket bandwidth of a given server
+type ServerBandwidthData struct {
+	Uptime  time.Duration              `json:"uptime"`
+	Buckets map[string]BucketBandwidth `json:"buckets"`
+}
+
+// ServerBandwidth holds bandwidth information result of one node
+type ServerBandwidth struct {
+	Error string               `json:"error"`
+	Addr  string               `json:"addr"`
+	Data  *ServerBandwidthData `json:"data"`
+}
+
+// ClusterBandwidthInfo holds per bucket bandwidth aggregated across
+// all servers along with the bandwidth reported by each server
+type ClusterBandwidthInfo struct {
+	Buckets map[string]BucketBandwidth `json:"buckets"`
+	Servers []ServerBandwidth          `json:"servers"`
+}
+
+// BandwidthInfo - Connect to a minio server and call Bandwidth Info Management API
+// to fetch cluster-wide per bucket bandwidth represented by ClusterBandwidthInfo structure
+func (adm *AdminClient) BandwidthInfo() (ClusterBandwidthInfo, error) {
+	var bandwidthInfo ClusterBandwidthInfo
+
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/bandwidth"})
+	defer closeResponse(resp)
+	if err != nil {
+		return bandwidthInfo, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return bandwidthInfo, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return bandwidthInfo, err
+	}
+
+	err = json.Unmarshal(respBytes, &bandwidthInfo)
+	return bandwidthInfo, err
+}
+
+// APIStats holds call statistics of one S3 API, recent counters cover
+// the last minute and other counters the whole uptime of the servers
+type APIStats struct {
+	InFlight       int64         `json:"inFlight"`
+	Calls          uint64        `json:"calls"`
+	ClientErrors   uint64        `json:"clientErrors"`
+	ServerErrors   uint64        `json:"serverErrors"`
+	TotalDuration  time.Duration `json:"totalDuration"`
+	MaxDuration    time.Duration `json:"maxDuration"`
+	RecentCalls    uint64        `json:"recentCalls"`
+	RecentErrors   uint64        `json:"recentErrors"`
+	RecentDuration time.Duration `json:"recentDuration"`
+}
+
+// NamedAPIStats holds call statistics of the API with the given name
+type NamedAPIStats struct {
+	Name string `json:"name"`
+	APIStats
+}
+
+// ServerAPIStats holds API call statistics result of one node
+type ServerAPIStats struct {
+	Error string              `json:"error"`
+	Addr  string              `json:"addr"`
+	Data  map[string]APIStats `json:"data"`
+}
+
+// ClusterAPIStats holds API call statistics aggregated across all
+// servers, busiest APIs first, along with the statistics of each server
+type ClusterAPIStats struct {
+	APIs    []NamedAPIStats  `json:"apis"`
+	Servers []ServerAPIStats `json:"servers"`
+}
+
+// TopAPI - Connect to a minio server and call Top API Management API to
+// fetch cluster-wide per S3 API call statistics, busiest APIs of the
+// last minute first. A positive count limits the number of aggregated APIs
+func (adm *AdminClient) TopAPI(count int) (ClusterAPIStats, error) {
+	var apiStats ClusterAPIStats
+
+	queryValues := url.Values{}
+	if count > 0 {
+		queryValues.Set("count", strconv.Itoa(count))
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/top/api",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return apiStats, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return apiStats, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return apiStats, err
+	}
+
+	err = json.Unmarshal(respBytes, &apiStats)
+	return apiStats, err
+}
+
+// ServerRuntimeData holds Go runtime metrics of a server, GCPauseMax is
+// the longest of the last 256 garbage collection pauses. Error is set
+// when the number of open file descriptors is unknown
+type ServerRuntimeData struct {
+	Goroutines   int           `json:"goroutines"`
+	HeapAlloc    uint64        `json:"heapAlloc"`
+	HeapInuse    uint64        `json:"heapInuse"`
+	HeapObjects  uint64        `json:"heapObjects"`
+	Sys          uint64        `json:"sys"`
+	NumGC        uint32        `json:"numGC"`
+	LastGC       time.Time     `json:"lastGC"`
+	GCPauseTotal time.Duration `json:"gcPauseTotal"`
+	GCPauseLast  time.Duration `json:"gcPauseLast"`
+	GCPauseMax   time.Duration `json:"gcPauseMax"`
+	OpenFDs      int           `json:"openFDs"`
+	MaxOpenFDs   uint64        `json:"maxOpenFDs"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// ServerRuntimeInfo holds Go runtime metrics of one server
+type ServerRuntimeInfo struct {
+	Error string             `json:"error"`
+	Addr  string             `json:"addr"`
+	Data  *ServerRuntimeData `json:"data"`
+}
+
+// ClusterRuntimeInfo holds Go runtime metrics of each server along with
+// their totals across all servers which replied
+type ClusterRuntimeInfo struct {
+	Goroutines int                 `json:"goroutines"`
+	HeapInuse  uint64              `json:"heapInuse"`
+	OpenFDs    int                 `json:"openFDs"`
+	Servers    []ServerRuntimeInfo `json:"servers"`
+}
+
+// RuntimeInfo - Connect to a minio server and call Runtime Info Management
+// API to fetch Go runtime metrics of all servers
+func (adm *AdminClient) RuntimeInfo() (ClusterRuntimeInfo, error) {
+	var runtimeInfo ClusterRuntimeInfo
+
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/runtime"})
+	defer closeResponse(resp)
+	if err != nil {
+		return runtimeInfo, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return runtimeInfo, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return runtimeInfo, err
+	}
+
+	err = json.Unmarshal(respBytes, &runtimeInfo)
+	return runtimeInfo, err
+}
+
+// SessionInfo holds an S3 API call in progress on a server, AccessKey is
+// empty for anonymous calls
+type SessionInfo struct {
+	RemoteHost string        `json:"remoteHost"`
+	AccessKey  string        `json:"accessKey,omitempty"`
+	API        string        `json:"api"`
+	Bucket     string        `json:"bucket,omitempty"`
+	Object     string        `json:"object,omitempty"`
+	Started    time.Time     `json:"started"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// ServerSessions holds the S3 API calls in progress on one server
+type ServerSessions struct {
+	Error string        `json:"error"`
+	Addr  string        `json:"addr"`
+	Data  []SessionInfo `json:"data"`
+}
+
+// ClientSessions holds the number of S3 API calls in progress across all
+// servers made by one client, i.e. a remote IP and access key
+type ClientSessions struct {
+	RemoteIP    string        `json:"remoteIP"`
+	AccessKey   string        `json:"accessKey,omitempty"`
+	Sessions    int           `json:"sessions"`
+	MaxDuration time.Duration `json:"maxDuration"`
+}
+
+// ClusterSessions holds the clients having calls in progress, busiest
+// clients first, along with the calls in progress on each server
+type ClusterSessions struct {
+	Clients []ClientSessions `json:"clients"`
+	Servers []ServerSessions `json:"servers"`
+}
+
+// ListSessions - Connect to a minio server and call List Sessions Management
+// API to fetch the S3 API calls in progress on all servers
+func (adm *AdminClient) ListSessions() (ClusterSessions, error) {
+	var sessions ClusterSessions
+
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/sessions"})
+	defer closeResponse(resp)
+	if err != nil {
+		return sessions, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return sessions, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return sessions, err
+	}
+
+	err = json.Unmarshal(respBytes, &sessions)
+	return sessions, err
+}
+
+// HealthInfo - Connect to a minio server and call Health Info Management API
+// which runs health diagnostics on all servers, the result is a zip archive
+// holding one JSON document per server
+func (adm *AdminClient) HealthInfo() ([]byte, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/healthinfo"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// DriveSpeedInfo holds sequential and random read/write performance
+// of a drive, throughput is in bytes per second
+type DriveSpeedInfo struct {
+	Path                string  `json:"path"`
+	SeqWriteThroughput  float64 `json:"seqWriteThroughput"`
+	SeqReadThroughput   float64 `json:"seqReadThroughput"`
+	RandWriteThroughput float64 `json:"randWriteThroughput"`
+	RandReadThroughput  float64 `json:"randReadThroughput"`
+	RandWriteIOPS       float64 `json:"randWriteIOPS"`
+	RandReadIOPS        float64 `json:"randReadIOPS"`
+	Error               string  `json:"error,omitempty"`
+}
+
+// ServerDrivesPerf holds drive test results of one server
+type ServerDrivesPerf struct {
+	Error string           `json:"error"`
+	Addr  string           `json:"addr"`
+	Data  []DriveSpeedInfo `json:"data"`
+}
+
+// DrivePerf - Connect to a minio server and call Drive Performance Management API
+// which benchmarks all drives of all servers using a test file of the given size.
+// A size of zero uses the default test file size of the server
+func (adm *AdminClient) DrivePerf(size int64) ([]ServerDrivesPerf, error) {
+	queryValues := url.Values{}
+	if size > 0 {
+		queryValues.Set("size", strconv.FormatInt(size, 10))
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/driveperf",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var drivesPerf []ServerDrivesPerf
+	if err = json.Unmarshal(respBytes, &drivesPerf); err != nil {
+		return nil, err
+	}
+
+	return drivesPerf, nil
+}
+
+// NetPerfInfo holds the round trip latency and the throughput in bytes
+// per second of the link from a server to a remote server
+type NetPerfInfo struct {
+	Addr       string        `json:"addr"`
+	Latency    time.Duration `json:"latency"`
+	Throughput float64       `json:"throughput"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// ServerNetPerf holds network test result of one server, i.e. the
+// performance of the links from the server to all other servers
+type ServerNetPerf struct {
+	Error string        `json:"error"`
+	Addr  string        `json:"addr"`
+	Data  []NetPerfInfo `json:"data"`
+}
+
+// NetPerf - Connect to a minio server and call Network Performance Management API
+// which sends a payload of the given size between all pairs of servers. A size of
+// zero uses the default payload size of the server
+func (adm *AdminClient) NetPerf(size int64) ([]ServerNetPerf, error) {
+	queryValues := url.Values{}
+	if size > 0 {
+		queryValues.Set("size", strconv.FormatInt(size, 10))
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/netperf",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var netPerf []ServerNetPerf
+	if err = json.Unmarshal(respBytes, &netPerf); err != nil {
+		return nil, err
+	}
+
+	return netPerf, nil
+}
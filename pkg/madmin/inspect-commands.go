@@ -0,0 +1,77 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	sha256 "github.com/minio/sha256-simd"
+	"github.com/minio/sio"
+)
+
+// Size of the random salt prefixed to encrypted inspect data
+const inspectSaltSize = 32
+
+// Inspect - Connect to a minio server and call Inspect Management API
+// which collects the raw xl.json and the part layout of an object from
+// every drive of all servers. The zip archive sent by the server is
+// encrypted with a key derived from the secret key of the client and
+// returned decrypted.
+func (adm *AdminClient) Inspect(bucket, object string) ([]byte, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("object", object)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/inspect",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < inspectSaltSize {
+		return nil, errors.New("Inspect data is truncated")
+	}
+
+	salt := data[:inspectSaltSize]
+	mac := hmac.New(sha256.New, []byte(adm.secretAccessKey))
+	mac.Write(salt)
+
+	var buf bytes.Buffer
+	if _, err = sio.Decrypt(&buf, bytes.NewReader(data[inspectSaltSize:]), sio.Config{Key: mac.Sum(nil)}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
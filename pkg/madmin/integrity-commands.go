@@ -0,0 +1,91 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// BucketIntegrity holds whether uploads to a bucket must be verified
+// with a Content-MD5 or a signed SHA256 sum of their data
+type BucketIntegrity struct {
+	Strict bool `json:"strict"`
+}
+
+// SetBucketIntegrity - sets whether uploads to a bucket must be
+// verified with a Content-MD5 or a signed SHA256 sum of their data.
+func (adm *AdminClient) SetBucketIntegrity(bucket string, strict bool) error {
+	data, err := json.Marshal(BucketIntegrity{Strict: strict})
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath:     "/v1/set-bucket-integrity",
+		queryValues: queryValues,
+		content:     data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// GetBucketIntegrity - returns whether uploads to a bucket must be
+// verified.
+func (adm *AdminClient) GetBucketIntegrity(bucket string) (i BucketIntegrity, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/get-bucket-integrity",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return i, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return i, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return i, err
+	}
+
+	if err = json.Unmarshal(respBytes, &i); err != nil {
+		return i, err
+	}
+
+	return i, nil
+}
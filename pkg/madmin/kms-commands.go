@@ -0,0 +1,76 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// KMSKeyStatus holds the result of generating and decrypting a
+// data key with a KMS master key
+type KMSKeyStatus struct {
+	KeyID         string `json:"keyID"`
+	EncryptionErr string `json:"encryptionError,omitempty"`
+	DecryptionErr string `json:"decryptionError,omitempty"`
+}
+
+// ServerKMSKeyStatus holds KMS key status result of one node
+type ServerKMSKeyStatus struct {
+	Error string        `json:"error"`
+	Addr  string        `json:"addr"`
+	Data  *KMSKeyStatus `json:"data"`
+}
+
+// GetKeyStatus - verifies on all servers that the KMS can generate and
+// decrypt a data key with the given master key. An empty keyID checks
+// the default master key of each server.
+func (adm *AdminClient) GetKeyStatus(keyID string) ([]ServerKMSKeyStatus, error) {
+	queryValues := url.Values{}
+	if keyID != "" {
+		queryValues.Set("key-id", keyID)
+	}
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/kms/key/status",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyStatus []ServerKMSKeyStatus
+	if err = json.Unmarshal(respBytes, &keyStatus); err != nil {
+		return nil, err
+	}
+
+	return keyStatus, nil
+}
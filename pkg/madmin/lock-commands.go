@@ -0,0 +1,75 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ForceUnlockResult holds the number of locks released by one server
+type ForceUnlockResult struct {
+	Error    string `json:"error"`
+	Addr     string `json:"addr"`
+	Released int    `json:"released"`
+}
+
+// ForceUnlock - releases the lock held on bucket, or on object of bucket
+// if object is not empty, on all servers.
+func (adm *AdminClient) ForceUnlock(bucket, object string) ([]ForceUnlockResult, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	if object != "" {
+		queryValues.Set("object", object)
+	}
+	return adm.forceUnlock(queryValues)
+}
+
+// ForceUnlockClient - releases all locks held by the client having the
+// given address, e.g. a server which went down, on all servers.
+func (adm *AdminClient) ForceUnlockClient(client string) ([]ForceUnlockResult, error) {
+	queryValues := url.Values{}
+	queryValues.Set("client", client)
+	return adm.forceUnlock(queryValues)
+}
+
+func (adm *AdminClient) forceUnlock(queryValues url.Values) ([]ForceUnlockResult, error) {
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/force-unlock",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ForceUnlockResult
+	err = json.Unmarshal(respBytes, &results)
+	return results, err
+}
@@ -0,0 +1,82 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// MaintenanceStatus holds whether a server is in maintenance along with
+// the number of S3 calls still in progress once draining ended
+type MaintenanceStatus struct {
+	Addr          string `json:"addr"`
+	InMaintenance bool   `json:"inMaintenance"`
+	InFlight      int    `json:"inFlight"`
+}
+
+// MaintenanceEnter - puts the server having the given address in
+// maintenance, it rejects new S3 calls and fails its readiness probe
+// then waits up to drainTimeout for calls in progress to complete. A
+// zero drainTimeout waits for one minute.
+func (adm *AdminClient) MaintenanceEnter(node string, drainTimeout time.Duration) (MaintenanceStatus, error) {
+	queryValues := url.Values{}
+	queryValues.Set("node", node)
+	queryValues.Set("action", "enter")
+	if drainTimeout > 0 {
+		queryValues.Set("drain", drainTimeout.String())
+	}
+	return adm.maintenance(queryValues)
+}
+
+// MaintenanceExit - makes the server having the given address leave
+// maintenance and serve S3 calls again.
+func (adm *AdminClient) MaintenanceExit(node string) (MaintenanceStatus, error) {
+	queryValues := url.Values{}
+	queryValues.Set("node", node)
+	queryValues.Set("action", "exit")
+	return adm.maintenance(queryValues)
+}
+
+func (adm *AdminClient) maintenance(queryValues url.Values) (MaintenanceStatus, error) {
+	var status MaintenanceStatus
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/maintenance",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(respBytes, &status)
+	return status, err
+}
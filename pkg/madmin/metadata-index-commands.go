@@ -0,0 +1,96 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BucketMetadataIndexStatus holds whether the metadata index of a bucket
+// is enabled, the time it was last built and its number of objects
+type BucketMetadataIndexStatus struct {
+	Enabled bool      `json:"enabled"`
+	Updated time.Time `json:"updated,omitempty"`
+	Objects int       `json:"objects"`
+}
+
+// SetBucketMetadataIndex - enables or disables the metadata index of a
+// bucket searched by search requests.
+func (adm *AdminClient) SetBucketMetadataIndex(bucket string, enabled bool) error {
+	data, err := json.Marshal(struct {
+		Enabled bool `json:"enabled"`
+	}{enabled})
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath:     "/v1/set-bucket-metadata-index",
+		queryValues: queryValues,
+		content:     data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// GetBucketMetadataIndex - returns the status of the metadata index of
+// a bucket.
+func (adm *AdminClient) GetBucketMetadataIndex(bucket string) (s BucketMetadataIndexStatus, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/get-bucket-metadata-index",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return s, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return s, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return s, err
+	}
+
+	if err = json.Unmarshal(respBytes, &s); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
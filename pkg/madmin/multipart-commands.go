@@ -0,0 +1,111 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// MultipartUploadInfo represents an in-progress multipart upload, the
+// bucket, object and initiation time are unknown for uploads initiated
+// by older server releases
+type MultipartUploadInfo struct {
+	Bucket    string    `json:"bucket,omitempty"`
+	Object    string    `json:"object,omitempty"`
+	UploadID  string    `json:"uploadId"`
+	Initiated time.Time `json:"initiated"`
+	Modified  time.Time `json:"modified"`
+	Parts     int       `json:"parts"`
+}
+
+// ServerMultipartUploads holds the multipart uploads found by one server
+type ServerMultipartUploads struct {
+	Error string                `json:"error"`
+	Addr  string                `json:"addr"`
+	Data  []MultipartUploadInfo `json:"data"`
+}
+
+// ClusterMultipartUploads holds all multipart uploads of the cluster,
+// oldest first, along with the uploads found by each server
+type ClusterMultipartUploads struct {
+	Uploads []MultipartUploadInfo    `json:"uploads"`
+	Servers []ServerMultipartUploads `json:"servers"`
+}
+
+// AbortStaleMultipartUploadsResult holds the number of multipart uploads
+// removed from the drives of one server
+type AbortStaleMultipartUploadsResult struct {
+	Error   string `json:"error"`
+	Addr    string `json:"addr"`
+	Aborted int    `json:"aborted"`
+}
+
+// ListMultipartUploads - returns all in-progress multipart uploads of the cluster.
+func (adm *AdminClient) ListMultipartUploads() (uploads ClusterMultipartUploads, err error) {
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath: "/v1/multipart-uploads",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return uploads, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return uploads, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return uploads, err
+	}
+
+	err = json.Unmarshal(respBytes, &uploads)
+	return uploads, err
+}
+
+// AbortStaleMultipartUploads - removes multipart uploads initiated longer
+// than olderThan ago on all servers.
+func (adm *AdminClient) AbortStaleMultipartUploads(olderThan time.Duration) (results []AbortStaleMultipartUploadsResult, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("older-than", olderThan.String())
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/multipart-uploads/abort-stale",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBytes, &results)
+	return results, err
+}
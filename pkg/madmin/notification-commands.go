@@ -0,0 +1,108 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// FailedEvent represents a bucket notification event a server failed
+// to deliver to one of its targets
+type FailedEvent struct {
+	ID       string          `json:"id"`
+	TargetID string          `json:"targetId"`
+	Event    json.RawMessage `json:"event"`
+	Error    string          `json:"error"`
+	Failed   time.Time       `json:"failed"`
+	Attempts int             `json:"attempts"`
+}
+
+// ServerFailedEvents holds the events one server failed to deliver
+type ServerFailedEvents struct {
+	Error string        `json:"error"`
+	Addr  string        `json:"addr"`
+	Data  []FailedEvent `json:"data"`
+}
+
+// ReplayFailedEventsResult holds the number of failed events of one
+// server delivered again, and of those which failed again
+type ReplayFailedEventsResult struct {
+	Error    string `json:"error"`
+	Addr     string `json:"addr"`
+	Replayed int    `json:"replayed"`
+	Failed   int    `json:"failed"`
+}
+
+// ListFailedEvents - returns the bucket notification events all servers failed to deliver.
+func (adm *AdminClient) ListFailedEvents() ([]ServerFailedEvents, error) {
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath: "/v1/failed-events",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var servers []ServerFailedEvents
+	err = json.Unmarshal(respBytes, &servers)
+	return servers, err
+}
+
+// ReplayFailedEvents - sends the failed event having the given ID, or all
+// failed events if id is empty, again to their targets.
+func (adm *AdminClient) ReplayFailedEvents(id string) ([]ReplayFailedEventsResult, error) {
+	queryValues := url.Values{}
+	if id != "" {
+		queryValues.Set("id", id)
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/failed-events/replay",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ReplayFailedEventsResult
+	err = json.Unmarshal(respBytes, &results)
+	return results, err
+}
@@ -0,0 +1,89 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BucketPolicyVersion holds a prior access policy of a bucket, a
+// version without policy records that the bucket had none
+type BucketPolicyVersion struct {
+	VersionID string          `json:"versionId"`
+	Replaced  time.Time       `json:"replaced"`
+	Policy    json.RawMessage `json:"policy,omitempty"`
+}
+
+// ListBucketPolicyVersions - returns the prior access policies of a
+// bucket, the most recently replaced first.
+func (adm *AdminClient) ListBucketPolicyVersions(bucket string) (versions []BucketPolicyVersion, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/list-bucket-policy-versions",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(respBytes, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// RestoreBucketPolicyVersion - replaces the access policy of a bucket
+// by one of its prior policies.
+func (adm *AdminClient) RestoreBucketPolicyVersion(bucket, versionID string) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("versionId", versionID)
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/restore-bucket-policy-version",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
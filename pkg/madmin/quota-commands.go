@@ -0,0 +1,105 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// QuotaType represents bucket quota type
+type QuotaType string
+
+const (
+	// HardQuota specifies a hard quota of usage for bucket, uploads
+	// exceeding the quota are rejected
+	HardQuota QuotaType = "hard"
+	// FIFOQuota specifies a quota limit beyond which older objects are
+	// deleted from bucket to make room for new uploads
+	FIFOQuota QuotaType = "fifo"
+)
+
+// BucketQuota holds bucket quota restrictions, quota is in bytes
+type BucketQuota struct {
+	Quota uint64    `json:"quota"`
+	Type  QuotaType `json:"quotatype,omitempty"`
+}
+
+// SetBucketQuota - sets the quota of a bucket on all servers, a quota
+// of zero removes the quota of the bucket.
+func (adm *AdminClient) SetBucketQuota(bucket string, quota uint64, quotaType QuotaType) error {
+	data, err := json.Marshal(BucketQuota{
+		Quota: quota,
+		Type:  quotaType,
+	})
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath:     "/v1/set-bucket-quota",
+		queryValues: queryValues,
+		content:     data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// GetBucketQuota - returns the quota of a bucket.
+func (adm *AdminClient) GetBucketQuota(bucket string) (q BucketQuota, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/get-bucket-quota",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return q, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return q, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return q, err
+	}
+
+	if err = json.Unmarshal(respBytes, &q); err != nil {
+		return q, err
+	}
+
+	return q, nil
+}
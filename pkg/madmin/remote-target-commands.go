@@ -0,0 +1,150 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ServiceType represents the service a remote target is used for
+type ServiceType string
+
+const (
+	// ReplicationService specifies a target of bucket replication
+	ReplicationService ServiceType = "replication"
+	// ILMService specifies a target of lifecycle transitions
+	ILMService ServiceType = "ilm"
+	// CopyService specifies a source of server side copies
+	CopyService ServiceType = "copy"
+)
+
+// Credentials holds the access and secret keys of a remote target
+type Credentials struct {
+	AccessKey string `json:"accessKey,omitempty"`
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+// BucketTarget represents a remote bucket that data of a local bucket
+// is sent to, a target is referenced by its ARN
+type BucketTarget struct {
+	SourceBucket string      `json:"sourcebucket"`
+	Endpoint     string      `json:"endpoint"`
+	Credentials  Credentials `json:"credentials"`
+	TargetBucket string      `json:"targetbucket"`
+	Type         ServiceType `json:"type"`
+	Arn          string      `json:"arn,omitempty"`
+}
+
+// SetRemoteTarget - registers a remote target of a bucket and returns
+// its ARN. Registering an already known remote bucket updates its
+// credentials and keeps its ARN.
+func (adm *AdminClient) SetRemoteTarget(bucket string, target *BucketTarget) (string, error) {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return "", err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath:     "/v1/set-remote-target",
+		queryValues: queryValues,
+		content:     data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Arn string `json:"arn"`
+	}
+	if err = json.Unmarshal(respBytes, &result); err != nil {
+		return "", err
+	}
+
+	return result.Arn, nil
+}
+
+// ListRemoteTargets - returns remote targets of a bucket, all types are
+// returned if serviceType is empty. Secret keys are never returned.
+func (adm *AdminClient) ListRemoteTargets(bucket string, serviceType ServiceType) (targets []BucketTarget, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("type", string(serviceType))
+
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath:     "/v1/list-remote-targets",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(respBytes, &targets); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// RemoveRemoteTarget - removes the remote target having given ARN from a bucket.
+func (adm *AdminClient) RemoveRemoteTarget(bucket, arn string) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("arn", arn)
+
+	resp, err := adm.executeMethod("DELETE", requestData{
+		relPath:     "/v1/remove-remote-target",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
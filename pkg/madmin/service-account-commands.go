@@ -0,0 +1,175 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// AddServiceAccountReq - represents POST body for add service account API.
+type AddServiceAccountReq struct {
+	Parent string          `json:"parent,omitempty"`
+	Policy json.RawMessage `json:"policy,omitempty"`
+}
+
+// ServiceAccountCreds - credentials of a newly created service account.
+type ServiceAccountCreds struct {
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
+
+// ServiceAccountInfo - represents a service account, secret key is
+// never returned by the server.
+type ServiceAccountInfo struct {
+	AccessKey  string          `json:"accessKey"`
+	ParentUser string          `json:"parentUser"`
+	Policy     json.RawMessage `json:"policy,omitempty"`
+}
+
+// AddServiceAccount - creates a new service account derived from the
+// given parent user, optionally restricted by an inline policy. An
+// empty parent user implies the credential of the admin client.
+func (adm *AdminClient) AddServiceAccount(parent string, policy []byte) (creds ServiceAccountCreds, err error) {
+	// No TLS?
+	if !adm.secure {
+		return creds, fmt.Errorf("credentials cannot be retrieved over an insecure connection")
+	}
+
+	body, err := json.Marshal(AddServiceAccountReq{
+		Parent: parent,
+		Policy: policy,
+	})
+	if err != nil {
+		return creds, err
+	}
+
+	// Execute POST on /minio/admin/v1/service-accounts to create a service account.
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/service-accounts",
+		content: body,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return creds, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return creds, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return creds, err
+	}
+
+	err = json.Unmarshal(respBytes, &creds)
+	return creds, err
+}
+
+// ListServiceAccounts - lists all service accounts.
+func (adm *AdminClient) ListServiceAccounts() ([]ServiceAccountInfo, error) {
+	// Execute GET on /minio/admin/v1/service-accounts to list service accounts.
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/service-accounts"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []ServiceAccountInfo
+	if err = json.Unmarshal(respBytes, &infos); err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+// DeleteServiceAccount - deletes the service account with the given access key.
+func (adm *AdminClient) DeleteServiceAccount(accessKey string) error {
+	// Execute DELETE on /minio/admin/v1/service-accounts/<access-key> to delete a service account.
+	resp, err := adm.executeMethod("DELETE", requestData{relPath: "/v1/service-accounts/" + accessKey})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ExportIAM - returns a zip archive of all service accounts along with
+// their secret keys, which can be imported by ImportIAM.
+func (adm *AdminClient) ExportIAM() ([]byte, error) {
+	// No TLS?
+	if !adm.secure {
+		return nil, fmt.Errorf("credentials cannot be retrieved over an insecure connection")
+	}
+
+	// Execute GET on /minio/admin/v1/iam/export to export service accounts.
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/iam/export"})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// ImportIAM - adds the service accounts of a zip archive made by
+// ExportIAM, existing service accounts having the same access key are
+// replaced.
+func (adm *AdminClient) ImportIAM(archive []byte) error {
+	// No TLS?
+	if !adm.secure {
+		return fmt.Errorf("credentials cannot be sent over an insecure connection")
+	}
+
+	// Execute PUT on /minio/admin/v1/iam/import to import service accounts.
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath: "/v1/iam/import",
+		content: archive,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
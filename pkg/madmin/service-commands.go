@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -67,8 +68,15 @@ type ServiceActionValue string
 const (
 	// ServiceActionValueRestart represents restart action
 	ServiceActionValueRestart ServiceActionValue = "restart"
+	// ServiceActionValueRollingRestart represents restart action
+	// restarting servers one after another
+	ServiceActionValueRollingRestart = "rolling-restart"
 	// ServiceActionValueStop represents stop action
 	ServiceActionValueStop = "stop"
+	// ServiceActionValueFreeze represents freeze action
+	ServiceActionValueFreeze = "freeze"
+	// ServiceActionValueUnfreeze represents unfreeze action
+	ServiceActionValueUnfreeze = "unfreeze"
 )
 
 // ServiceAction - represents POST body for service action APIs
@@ -76,8 +84,9 @@ type ServiceAction struct {
 	Action ServiceActionValue `json:"action"`
 }
 
-// ServiceSendAction - Call Service Restart/Stop API to restart/stop a
-// Minio server
+// ServiceSendAction - Call Service Restart/Stop/Freeze/Unfreeze API to
+// restart/stop a Minio server, or make all servers reject or resume S3
+// calls
 func (adm *AdminClient) ServiceSendAction(action ServiceActionValue) error {
 	body, err := json.Marshal(ServiceAction{action})
 	if err != nil {
@@ -99,3 +108,152 @@ func (adm *AdminClient) ServiceSendAction(action ServiceActionValue) error {
 	}
 	return nil
 }
+
+// ServerUpdateResult - holds the update result of one server
+type ServerUpdateResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+}
+
+// ServerUpdateStatus - contains the response of server update API,
+// servers are only restarted when all of them have been updated
+type ServerUpdateStatus struct {
+	Restarted bool                 `json:"restarted"`
+	Servers   []ServerUpdateResult `json:"servers"`
+}
+
+// ServerUpdate - Call Server Update API to replace the binary of all
+// Minio servers with the binary at updateURL, whose SHA256 checksum
+// is sha256Hex, then restart them
+func (adm *AdminClient) ServerUpdate(updateURL, sha256Hex string) (us ServerUpdateStatus, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("updateURL", updateURL)
+	queryValues.Set("sha256", sha256Hex)
+
+	// Request API to update servers
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/update",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return us, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return us, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return us, err
+	}
+
+	err = json.Unmarshal(respBytes, &us)
+	return us, err
+}
+
+// ReloadCertsResult - holds the certificate reload result of one server
+type ReloadCertsResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+}
+
+// ReloadCerts - Call Reload Certs API to make all Minio servers load
+// their TLS certificate and private key again, servers failing to load
+// them keep serving their current certificate
+func (adm *AdminClient) ReloadCerts() (results []ReloadCertsResult, err error) {
+	// Request API to reload certificates of servers
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/reload-certs",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBytes, &results)
+	return results, err
+}
+
+// SetLogLevelResult - holds the log verbosity change result of one server
+type SetLogLevelResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+}
+
+// SetLogLevel - Call Set Log Level API to change the log verbosity of
+// subsystem (http, storage, rpc or iam) to level (error, info or debug)
+// on all Minio servers until they are restarted
+func (adm *AdminClient) SetLogLevel(subsystem, level string) (results []SetLogLevelResult, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("subsystem", subsystem)
+	queryValues.Set("level", level)
+
+	// Request API to change log verbosity of servers
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/log-level",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBytes, &results)
+	return results, err
+}
+
+// SetScannerSpeedResult - holds the scanner speed change result of one server
+type SetScannerSpeedResult struct {
+	Error string `json:"error"`
+	Addr  string `json:"addr"`
+}
+
+// SetScannerSpeed - Call Set Scanner Speed API to change the pace of the
+// disk usage crawler and heal sequences to speed (slow, default, fast or
+// off) on all Minio servers until they are restarted
+func (adm *AdminClient) SetScannerSpeed(speed string) (results []SetScannerSpeedResult, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("speed", speed)
+
+	// Request API to change scanner speed of servers
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     "/v1/scanner-speed",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(respBytes, &results)
+	return results, err
+}
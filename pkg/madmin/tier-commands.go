@@ -0,0 +1,119 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+)
+
+// TierType represents the type of the remote storage backing a tier
+type TierType string
+
+const (
+	// S3Tier specifies a tier backed by AWS S3 or any S3 compatible storage
+	S3Tier TierType = "s3"
+	// MinioTier specifies a tier backed by a Minio server
+	MinioTier TierType = "minio"
+)
+
+// TierConfig represents a remote storage that lifecycle transition
+// rules move objects to, objects are stored under prefix in bucket
+type TierConfig struct {
+	Name        string      `json:"name"`
+	Type        TierType    `json:"type"`
+	Endpoint    string      `json:"endpoint"`
+	Bucket      string      `json:"bucket"`
+	Prefix      string      `json:"prefix,omitempty"`
+	Region      string      `json:"region,omitempty"`
+	Credentials Credentials `json:"credentials"`
+}
+
+// AddTier - adds a new tier on all servers.
+func (adm *AdminClient) AddTier(cfg *TierConfig) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod("PUT", requestData{
+		relPath: "/v1/tier",
+		content: data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListTiers - returns all tiers, secret keys are never returned.
+func (adm *AdminClient) ListTiers() (tiers []TierConfig, err error) {
+	resp, err := adm.executeMethod("GET", requestData{
+		relPath: "/v1/tier",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(respBytes, &tiers); err != nil {
+		return nil, err
+	}
+
+	return tiers, nil
+}
+
+// EditTier - replaces the credentials of a tier on all servers.
+func (adm *AdminClient) EditTier(name string, creds Credentials) error {
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath: "/v1/tier/" + name,
+		content: data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
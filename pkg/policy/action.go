@@ -32,6 +32,11 @@ const (
 	// AbortMultipartUploadAction - AbortMultipartUpload Rest API action.
 	AbortMultipartUploadAction Action = "s3:AbortMultipartUpload"
 
+	// BypassGovernanceRetentionAction - permission to bypass the governance
+	// mode retention of object versions when deleting them or updating their
+	// retention.
+	BypassGovernanceRetentionAction = "s3:BypassGovernanceRetention"
+
 	// CreateBucketAction - CreateBucket Rest API action.
 	CreateBucketAction = "s3:CreateBucket"
 
@@ -41,21 +46,84 @@ const (
 	// DeleteBucketPolicyAction - DeleteBucketPolicy Rest API action.
 	DeleteBucketPolicyAction = "s3:DeleteBucketPolicy"
 
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite Rest API action.
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
 	// DeleteObjectAction - DeleteObject Rest API action.
 	DeleteObjectAction = "s3:DeleteObject"
 
+	// DeleteObjectTaggingAction - DeleteObjectTagging Rest API action.
+	DeleteObjectTaggingAction = "s3:DeleteObjectTagging"
+
+	// DeleteObjectVersionAction - DeleteObject Rest API action on a specific object version.
+	DeleteObjectVersionAction = "s3:DeleteObjectVersion"
+
+	// ForceDeleteBucketAction - DeleteBucket Rest API action removing all
+	// objects of the bucket first. This is Minio extension.
+	ForceDeleteBucketAction = "s3:ForceDeleteBucket"
+
+	// GetBucketCorsAction - GetBucketCors Rest API action.
+	GetBucketCorsAction = "s3:GetBucketCORS"
+
+	// GetBucketEncryptionAction - GetBucketEncryption Rest API action.
+	GetBucketEncryptionAction = "s3:GetEncryptionConfiguration"
+
+	// GetBucketInventoryAction - GetBucketInventoryConfiguration and
+	// ListBucketInventoryConfigurations Rest API action.
+	GetBucketInventoryAction = "s3:GetInventoryConfiguration"
+
+	// GetBucketLifecycleAction - GetBucketLifecycleConfiguration Rest API action.
+	GetBucketLifecycleAction = "s3:GetLifecycleConfiguration"
+
 	// GetBucketLocationAction - GetBucketLocation Rest API action.
 	GetBucketLocationAction = "s3:GetBucketLocation"
 
+	// GetBucketLoggingAction - GetBucketLogging Rest API action.
+	GetBucketLoggingAction = "s3:GetBucketLogging"
+
 	// GetBucketNotificationAction - GetBucketNotification Rest API action.
 	GetBucketNotificationAction = "s3:GetBucketNotification"
 
+	// GetBucketObjectLockAction - GetObjectLockConfiguration Rest API action.
+	GetBucketObjectLockAction = "s3:GetBucketObjectLockConfiguration"
+
 	// GetBucketPolicyAction - GetBucketPolicy Rest API action.
 	GetBucketPolicyAction = "s3:GetBucketPolicy"
 
+	// GetBucketReplicationAction - GetBucketReplication Rest API action.
+	GetBucketReplicationAction = "s3:GetReplicationConfiguration"
+
+	// GetBucketTaggingAction - GetBucketTagging Rest API action.
+	GetBucketTaggingAction = "s3:GetBucketTagging"
+
+	// GetBucketVersioningAction - GetBucketVersioning Rest API action.
+	GetBucketVersioningAction = "s3:GetBucketVersioning"
+
+	// GetBucketWebsiteAction - GetBucketWebsite Rest API action.
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+
 	// GetObjectAction - GetObject Rest API action.
 	GetObjectAction = "s3:GetObject"
 
+	// GetObjectAttributesAction - GetObjectAttributes Rest API action.
+	GetObjectAttributesAction = "s3:GetObjectAttributes"
+
+	// GetObjectLegalHoldAction - GetObjectLegalHold Rest API action.
+	GetObjectLegalHoldAction = "s3:GetObjectLegalHold"
+
+	// GetObjectRetentionAction - GetObjectRetention Rest API action.
+	GetObjectRetentionAction = "s3:GetObjectRetention"
+
+	// GetObjectTaggingAction - GetObjectTagging Rest API action.
+	GetObjectTaggingAction = "s3:GetObjectTagging"
+
+	// GetObjectVersionAction - GetObject Rest API action on a specific object version.
+	GetObjectVersionAction = "s3:GetObjectVersion"
+
+	// GetObjectVersionAttributesAction - GetObjectAttributes Rest API
+	// action on a specific object version.
+	GetObjectVersionAttributesAction = "s3:GetObjectVersionAttributes"
+
 	// HeadBucketAction - HeadBucket Rest API action. This action is unused in minio.
 	HeadBucketAction = "s3:HeadBucket"
 
@@ -65,6 +133,9 @@ const (
 	// ListBucketAction - ListBucket Rest API action.
 	ListBucketAction = "s3:ListBucket"
 
+	// ListBucketVersionsAction - ListObjectVersions Rest API action.
+	ListBucketVersionsAction = "s3:ListBucketVersions"
+
 	// ListBucketMultipartUploadsAction - ListMultipartUploads Rest API action.
 	ListBucketMultipartUploadsAction = "s3:ListBucketMultipartUploads"
 
@@ -75,14 +146,65 @@ const (
 	// ListMultipartUploadPartsAction - ListParts Rest API action.
 	ListMultipartUploadPartsAction = "s3:ListMultipartUploadParts"
 
+	// PutBucketCorsAction - PutBucketCors and DeleteBucketCors Rest API
+	// action.
+	PutBucketCorsAction = "s3:PutBucketCORS"
+
+	// PutBucketEncryptionAction - PutBucketEncryption and
+	// DeleteBucketEncryption Rest API action.
+	PutBucketEncryptionAction = "s3:PutEncryptionConfiguration"
+
+	// PutBucketInventoryAction - PutBucketInventoryConfiguration and
+	// DeleteBucketInventoryConfiguration Rest API action.
+	PutBucketInventoryAction = "s3:PutInventoryConfiguration"
+
+	// PutBucketLifecycleAction - PutBucketLifecycleConfiguration and
+	// DeleteBucketLifecycle Rest API action.
+	PutBucketLifecycleAction = "s3:PutLifecycleConfiguration"
+
+	// PutBucketLoggingAction - PutBucketLogging Rest API action.
+	PutBucketLoggingAction = "s3:PutBucketLogging"
+
 	// PutBucketNotificationAction - PutObjectNotification Rest API action.
 	PutBucketNotificationAction = "s3:PutBucketNotification"
 
+	// PutBucketObjectLockAction - PutObjectLockConfiguration Rest API action.
+	PutBucketObjectLockAction = "s3:PutBucketObjectLockConfiguration"
+
 	// PutBucketPolicyAction - PutBucketPolicy Rest API action.
 	PutBucketPolicyAction = "s3:PutBucketPolicy"
 
+	// PutBucketReplicationAction - PutBucketReplication and
+	// DeleteBucketReplication Rest API action.
+	PutBucketReplicationAction = "s3:PutReplicationConfiguration"
+
+	// PutBucketTaggingAction - PutBucketTagging and DeleteBucketTagging Rest API action.
+	PutBucketTaggingAction = "s3:PutBucketTagging"
+
+	// PutBucketVersioningAction - PutBucketVersioning Rest API action.
+	PutBucketVersioningAction = "s3:PutBucketVersioning"
+
+	// PutBucketWebsiteAction - PutBucketWebsite Rest API action.
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+
 	// PutObjectAction - PutObject Rest API action.
 	PutObjectAction = "s3:PutObject"
+
+	// PutObjectLegalHoldAction - PutObjectLegalHold Rest API action.
+	PutObjectLegalHoldAction = "s3:PutObjectLegalHold"
+
+	// PutObjectRetentionAction - PutObjectRetention Rest API action.
+	PutObjectRetentionAction = "s3:PutObjectRetention"
+
+	// PutObjectTaggingAction - PutObjectTagging Rest API action.
+	PutObjectTaggingAction = "s3:PutObjectTagging"
+
+	// ReplicateObjectAction - permission to write replicas of objects,
+	// granted to the credentials used by replication.
+	ReplicateObjectAction = "s3:ReplicateObject"
+
+	// RestoreObjectAction - RestoreObject Rest API action.
+	RestoreObjectAction = "s3:RestoreObject"
 )
 
 // isObjectAction - returns whether action is object type or not.
@@ -90,7 +212,19 @@ func (action Action) isObjectAction() bool {
 	switch action {
 	case AbortMultipartUploadAction, DeleteObjectAction, GetObjectAction:
 		fallthrough
-	case ListMultipartUploadPartsAction, PutObjectAction:
+	case DeleteObjectVersionAction, GetObjectVersionAction:
+		fallthrough
+	case GetObjectRetentionAction, PutObjectRetentionAction:
+		fallthrough
+	case GetObjectLegalHoldAction, PutObjectLegalHoldAction:
+		fallthrough
+	case BypassGovernanceRetentionAction, ReplicateObjectAction:
+		fallthrough
+	case GetObjectTaggingAction, PutObjectTaggingAction, DeleteObjectTaggingAction:
+		fallthrough
+	case GetObjectAttributesAction, GetObjectVersionAttributesAction:
+		fallthrough
+	case ListMultipartUploadPartsAction, PutObjectAction, RestoreObjectAction:
 		return true
 	}
 
@@ -113,6 +247,44 @@ func (action Action) IsValid() bool {
 	case ListMultipartUploadPartsAction, PutBucketNotificationAction:
 		fallthrough
 	case PutBucketPolicyAction, PutObjectAction:
+		fallthrough
+	case DeleteObjectVersionAction, GetBucketVersioningAction:
+		fallthrough
+	case GetObjectVersionAction, ListBucketVersionsAction:
+		fallthrough
+	case PutBucketVersioningAction, GetObjectRetentionAction:
+		fallthrough
+	case PutObjectRetentionAction, GetObjectLegalHoldAction:
+		fallthrough
+	case PutObjectLegalHoldAction, GetObjectTaggingAction:
+		fallthrough
+	case PutObjectTaggingAction, DeleteObjectTaggingAction:
+		fallthrough
+	case GetBucketTaggingAction, PutBucketTaggingAction:
+		fallthrough
+	case GetBucketLifecycleAction, PutBucketLifecycleAction:
+		fallthrough
+	case GetBucketReplicationAction, PutBucketReplicationAction:
+		fallthrough
+	case GetBucketEncryptionAction, PutBucketEncryptionAction:
+		fallthrough
+	case GetBucketCorsAction, PutBucketCorsAction:
+		fallthrough
+	case RestoreObjectAction:
+		fallthrough
+	case GetBucketInventoryAction, PutBucketInventoryAction:
+		fallthrough
+	case GetBucketWebsiteAction, PutBucketWebsiteAction, DeleteBucketWebsiteAction:
+		fallthrough
+	case GetBucketLoggingAction, PutBucketLoggingAction:
+		fallthrough
+	case GetBucketObjectLockAction, PutBucketObjectLockAction:
+		fallthrough
+	case GetObjectAttributesAction, GetObjectVersionAttributesAction:
+		fallthrough
+	case BypassGovernanceRetentionAction, ForceDeleteBucketAction:
+		fallthrough
+	case ReplicateObjectAction:
 		return true
 	}
 
@@ -161,54 +333,199 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	AbortMultipartUploadAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	BypassGovernanceRetentionAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	CreateBucketAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	DeleteBucketPolicyAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	DeleteBucketWebsiteAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	DeleteObjectAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	DeleteObjectTaggingAction: condition.NewKeySet(
+		condition.S3ExistingObjectTag,
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	DeleteObjectVersionAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	ForceDeleteBucketAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketCorsAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketEncryptionAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketInventoryAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketLifecycleAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	GetBucketLocationAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketLoggingAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	GetBucketNotificationAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketObjectLockAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	GetBucketPolicyAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketReplicationAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketTaggingAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketVersioningAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetBucketWebsiteAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	GetObjectAction: condition.NewKeySet(
 		condition.S3XAmzServerSideEncryption,
 		condition.S3XAmzServerSideEncryptionAwsKMSKeyID,
 		condition.S3XAmzStorageClass,
+		condition.S3ExistingObjectTag,
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetObjectLegalHoldAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetObjectRetentionAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetObjectAttributesAction: condition.NewKeySet(
+		condition.S3ExistingObjectTag,
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetObjectTaggingAction: condition.NewKeySet(
+		condition.S3ExistingObjectTag,
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetObjectVersionAction: condition.NewKeySet(
+		condition.S3XAmzServerSideEncryption,
+		condition.S3XAmzServerSideEncryptionAwsKMSKeyID,
+		condition.S3XAmzStorageClass,
+		condition.S3ExistingObjectTag,
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	GetObjectVersionAttributesAction: condition.NewKeySet(
+		condition.S3ExistingObjectTag,
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	HeadBucketAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	ListAllMyBucketsAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	ListBucketAction: condition.NewKeySet(
@@ -217,31 +534,106 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 		condition.S3MaxKeys,
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	ListBucketVersionsAction: condition.NewKeySet(
+		condition.S3Prefix,
+		condition.S3Delimiter,
+		condition.S3MaxKeys,
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	ListBucketMultipartUploadsAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	ListenBucketNotificationAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	ListMultipartUploadPartsAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketCorsAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketEncryptionAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketInventoryAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketLifecycleAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketLoggingAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	PutBucketNotificationAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketObjectLockAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	PutBucketPolicyAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketReplicationAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketTaggingAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketVersioningAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutBucketWebsiteAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 
 	PutObjectAction: condition.NewKeySet(
@@ -250,7 +642,43 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 		condition.S3XAmzServerSideEncryptionAwsKMSKeyID,
 		condition.S3XAmzMetadataDirective,
 		condition.S3XAmzStorageClass,
+		condition.S3RequestObjectTagKeys,
+		condition.S3RequestObjectTag,
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutObjectLegalHoldAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutObjectRetentionAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	PutObjectTaggingAction: condition.NewKeySet(
+		condition.S3RequestObjectTagKeys,
+		condition.S3RequestObjectTag,
+		condition.S3ExistingObjectTag,
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	ReplicateObjectAction: condition.NewKeySet(
+		condition.AWSReferer,
+		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
+	),
+
+	RestoreObjectAction: condition.NewKeySet(
 		condition.AWSReferer,
 		condition.AWSSourceIP,
+		condition.AWSSecureTransport,
 	),
 }
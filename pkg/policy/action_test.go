@@ -32,7 +32,33 @@ func TestActionIsObjectAction(t *testing.T) {
 		{GetObjectAction, true},
 		{ListMultipartUploadPartsAction, true},
 		{PutObjectAction, true},
+		{GetObjectVersionAction, true},
+		{DeleteObjectVersionAction, true},
+		{GetObjectRetentionAction, true},
+		{PutObjectRetentionAction, true},
+		{GetObjectLegalHoldAction, true},
+		{PutObjectLegalHoldAction, true},
+		{BypassGovernanceRetentionAction, true},
+		{ReplicateObjectAction, true},
+		{GetObjectTaggingAction, true},
+		{PutObjectTaggingAction, true},
+		{DeleteObjectTaggingAction, true},
+		{RestoreObjectAction, true},
+		{GetObjectAttributesAction, true},
+		{GetObjectVersionAttributesAction, true},
 		{CreateBucketAction, false},
+		{PutBucketVersioningAction, false},
+		{ListBucketVersionsAction, false},
+		{PutBucketTaggingAction, false},
+		{PutBucketLifecycleAction, false},
+		{PutBucketReplicationAction, false},
+		{PutBucketEncryptionAction, false},
+		{PutBucketCorsAction, false},
+		{PutBucketInventoryAction, false},
+		{PutBucketWebsiteAction, false},
+		{PutBucketLoggingAction, false},
+		{PutBucketObjectLockAction, false},
+		{ForceDeleteBucketAction, false},
 	}
 
 	for i, testCase := range testCases {
@@ -50,6 +76,36 @@ func TestActionIsValid(t *testing.T) {
 		expectedResult bool
 	}{
 		{AbortMultipartUploadAction, true},
+		{GetBucketVersioningAction, true},
+		{ListBucketVersionsAction, true},
+		{PutObjectRetentionAction, true},
+		{PutObjectLegalHoldAction, true},
+		{DeleteObjectTaggingAction, true},
+		{GetBucketTaggingAction, true},
+		{PutBucketTaggingAction, true},
+		{GetBucketLifecycleAction, true},
+		{PutBucketLifecycleAction, true},
+		{GetBucketReplicationAction, true},
+		{PutBucketReplicationAction, true},
+		{GetBucketEncryptionAction, true},
+		{PutBucketEncryptionAction, true},
+		{GetBucketCorsAction, true},
+		{PutBucketCorsAction, true},
+		{RestoreObjectAction, true},
+		{GetBucketInventoryAction, true},
+		{PutBucketInventoryAction, true},
+		{GetBucketWebsiteAction, true},
+		{PutBucketWebsiteAction, true},
+		{DeleteBucketWebsiteAction, true},
+		{GetBucketLoggingAction, true},
+		{PutBucketLoggingAction, true},
+		{GetBucketObjectLockAction, true},
+		{PutBucketObjectLockAction, true},
+		{GetObjectAttributesAction, true},
+		{GetObjectVersionAttributesAction, true},
+		{BypassGovernanceRetentionAction, true},
+		{ForceDeleteBucketAction, true},
+		{ReplicateObjectAction, true},
 		{Action("foo"), false},
 	}
 
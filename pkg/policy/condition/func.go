@@ -71,7 +71,13 @@ func (functions Functions) MarshalJSON() ([]byte, error) {
 	nm := make(map[name]map[Key]ValueSet)
 
 	for _, f := range functions {
-		nm[f.name()] = f.toMap()
+		if _, ok := nm[f.name()]; !ok {
+			nm[f.name()] = make(map[Key]ValueSet)
+		}
+
+		for key, values := range f.toMap() {
+			nm[f.name()][key] = values
+		}
 	}
 
 	return json.Marshal(nm)
@@ -145,10 +151,20 @@ func (functions *Functions) UnmarshalJSON(data []byte) error {
 				if f, err = newNotIPAddressFunc(key, values); err != nil {
 					return err
 				}
+			case numericEquals, numericNotEquals, numericLessThan, numericLessThanEquals:
+				fallthrough
+			case numericGreaterThan, numericGreaterThanEquals:
+				if f, err = newNumericFunc(n, key, values); err != nil {
+					return err
+				}
 			case null:
 				if f, err = newNullFunc(key, values); err != nil {
 					return err
 				}
+			case boolean:
+				if f, err = newBooleanFunc(key, values); err != nil {
+					return err
+				}
 			default:
 				return fmt.Errorf("%v is not handled", n)
 			}
@@ -51,26 +51,26 @@ func TestFunctionsEvaluate(t *testing.T) {
 		expectedResult bool
 	}{
 		{case1Function, map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"SourceIp":          {"192.168.1.10"},
 		}, true},
 		{case1Function, map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"SourceIp":          {"192.168.1.10"},
 			"Refer":             {"http://example.org/"},
 		}, true},
-		{case1Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject"}}, false},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject"}}, false},
 		{case1Function, map[string][]string{"SourceIp": {"192.168.1.10"}}, false},
 		{case1Function, map[string][]string{
-			"x-amz-copy-source": {"mybucket/yourobject"},
+			"X-Amz-Copy-Source": {"mybucket/yourobject"},
 			"SourceIp":          {"192.168.1.10"},
 		}, false},
 		{case1Function, map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"SourceIp":          {"192.168.2.10"},
 		}, false},
 		{case1Function, map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"Refer":             {"http://example.org/"},
 		}, false},
 	}
@@ -163,6 +163,13 @@ func TestFunctionsMarshalJSON(t *testing.T) {
 
 	case2Result := []byte(`{"Null":{"s3:x-amz-server-side-encryption-aws-kms-key-id":[true]}}`)
 
+	func8, err := newNullFunc(S3XAmzCopySource, NewValueSet(NewBoolValue(false)))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case3Result := []byte(`{"Null":{"s3:x-amz-copy-source":[false],"s3:x-amz-server-side-encryption-aws-kms-key-id":[true]}}`)
+
 	testCases := []struct {
 		functions      Functions
 		expectedResult []byte
@@ -170,6 +177,7 @@ func TestFunctionsMarshalJSON(t *testing.T) {
 	}{
 		{NewFunctions(func1, func2, func3, func4, func5, func6, func7), case1Result, false},
 		{NewFunctions(func6), case2Result, false},
+		{NewFunctions(func6, func8), case3Result, false},
 		{NewFunctions(), []byte(`{}`), false},
 		{nil, []byte(`{}`), false},
 	}
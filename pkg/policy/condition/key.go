@@ -19,6 +19,7 @@ package condition
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -63,11 +64,28 @@ const (
 	// S3MaxKeys - key representing max-keys query parameter of ListBucket API only.
 	S3MaxKeys = "s3:max-keys"
 
+	// S3RequestObjectTagKeys - key representing the tag keys given by the
+	// tagging of PutObject and PutObjectTagging APIs.
+	S3RequestObjectTagKeys = "s3:RequestObjectTagKeys"
+
+	// S3RequestObjectTag - key representing the value of a tag given by the
+	// tagging of PutObject and PutObjectTagging APIs, used suffixed with the
+	// tag key, e.g. "s3:RequestObjectTag/project".
+	S3RequestObjectTag = "s3:RequestObjectTag"
+
+	// S3ExistingObjectTag - key representing the value of a tag of the
+	// object of object APIs, used suffixed with the tag key, e.g.
+	// "s3:ExistingObjectTag/project".
+	S3ExistingObjectTag = "s3:ExistingObjectTag"
+
 	// AWSReferer - key representing Referer header of any API.
 	AWSReferer = "aws:Referer"
 
 	// AWSSourceIP - key representing client's IP address (not intermittent proxies) of any API.
 	AWSSourceIP = "aws:SourceIp"
+
+	// AWSSecureTransport - key representing whether any API is requested over TLS.
+	AWSSecureTransport = "aws:SecureTransport"
 )
 
 // IsValid - checks if key is valid or not.
@@ -77,11 +95,27 @@ func (key Key) IsValid() bool {
 		fallthrough
 	case S3XAmzMetadataDirective, S3XAmzStorageClass, S3LocationConstraint, S3Prefix:
 		fallthrough
-	case S3Delimiter, S3MaxKeys, AWSReferer, AWSSourceIP:
+	case S3Delimiter, S3MaxKeys, AWSReferer, AWSSourceIP, AWSSecureTransport:
+		fallthrough
+	case S3RequestObjectTagKeys:
 		return true
 	}
 
-	return false
+	return key.Base() != key
+}
+
+// Base - returns the key without the tag key suffix of
+// "s3:RequestObjectTag/<tag-key>" and "s3:ExistingObjectTag/<tag-key>"
+// keys, any other key is returned as is.
+func (key Key) Base() Key {
+	if i := strings.Index(string(key), "/"); i >= 0 && i < len(key)-1 {
+		switch base := key[:i]; base {
+		case S3RequestObjectTag, S3ExistingObjectTag:
+			return base
+		}
+	}
+
+	return key
 }
 
 // MarshalJSON - encodes Key to JSON data.
@@ -104,6 +138,18 @@ func (key Key) Name() string {
 	return strings.TrimPrefix(keyString, "s3:")
 }
 
+// values - returns the request values of key in given values. "x-amz-*"
+// keys are HTTP headers, so they are looked up by the canonical header name
+// only and never by a query parameter of the same name.
+func (key Key) values(values map[string][]string) []string {
+	name := key.Name()
+	if strings.HasPrefix(name, "x-amz-") {
+		return values[http.CanonicalHeaderKey(name)]
+	}
+
+	return values[name]
+}
+
 // UnmarshalJSON - decodes JSON data to Key.
 func (key *Key) UnmarshalJSON(data []byte) error {
 	var s string
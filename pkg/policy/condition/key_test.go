@@ -38,6 +38,13 @@ func TestKeyIsValid(t *testing.T) {
 		{S3MaxKeys, true},
 		{AWSReferer, true},
 		{AWSSourceIP, true},
+		{AWSSecureTransport, true},
+		{S3RequestObjectTagKeys, true},
+		{Key("s3:RequestObjectTag/project"), true},
+		{Key("s3:ExistingObjectTag/project"), true},
+		{S3ExistingObjectTag, false},
+		{Key("s3:ExistingObjectTag/"), false},
+		{Key("s3:prefix/project"), false},
 		{Key("foo"), false},
 	}
 
@@ -83,6 +90,7 @@ func TestKeyName(t *testing.T) {
 	}{
 		{S3XAmzCopySource, "x-amz-copy-source"},
 		{AWSReferer, "Referer"},
+		{Key("s3:ExistingObjectTag/project"), "ExistingObjectTag/project"},
 	}
 
 	for i, testCase := range testCases {
@@ -94,6 +102,26 @@ func TestKeyName(t *testing.T) {
 	}
 }
 
+func TestKeyBase(t *testing.T) {
+	testCases := []struct {
+		key            Key
+		expectedResult Key
+	}{
+		{S3XAmzCopySource, S3XAmzCopySource},
+		{Key("s3:RequestObjectTag/project"), S3RequestObjectTag},
+		{Key("s3:ExistingObjectTag/a/b"), S3ExistingObjectTag},
+		{S3ExistingObjectTag, S3ExistingObjectTag},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.key.Base()
+
+		if testCase.expectedResult != result {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestKeyUnmarshalJSON(t *testing.T) {
 	testCases := []struct {
 		data        []byte
@@ -24,19 +24,32 @@ import (
 type name string
 
 const (
-	stringEquals    name = "StringEquals"
-	stringNotEquals      = "StringNotEquals"
-	stringLike           = "StringLike"
-	stringNotLike        = "StringNotLike"
-	ipAddress            = "IpAddress"
-	notIPAddress         = "NotIpAddress"
-	null                 = "Null"
+	stringEquals             name = "StringEquals"
+	stringNotEquals               = "StringNotEquals"
+	stringLike                    = "StringLike"
+	stringNotLike                 = "StringNotLike"
+	numericEquals                 = "NumericEquals"
+	numericNotEquals              = "NumericNotEquals"
+	numericLessThan               = "NumericLessThan"
+	numericLessThanEquals         = "NumericLessThanEquals"
+	numericGreaterThan            = "NumericGreaterThan"
+	numericGreaterThanEquals      = "NumericGreaterThanEquals"
+	ipAddress                     = "IpAddress"
+	notIPAddress                  = "NotIpAddress"
+	null                          = "Null"
+	boolean                       = "Bool"
 )
 
 // IsValid - checks if name is valid or not.
 func (n name) IsValid() bool {
 	switch n {
-	case stringEquals, stringNotEquals, stringLike, stringNotLike, ipAddress, notIPAddress, null:
+	case stringEquals, stringNotEquals, stringLike, stringNotLike:
+		fallthrough
+	case numericEquals, numericNotEquals, numericLessThan, numericLessThanEquals:
+		fallthrough
+	case numericGreaterThan, numericGreaterThanEquals:
+		fallthrough
+	case ipAddress, notIPAddress, null, boolean:
 		return true
 	}
 
@@ -34,6 +34,13 @@ func TestNameIsValid(t *testing.T) {
 		{ipAddress, true},
 		{notIPAddress, true},
 		{null, true},
+		{numericEquals, true},
+		{numericNotEquals, true},
+		{numericLessThan, true},
+		{numericLessThanEquals, true},
+		{numericGreaterThan, true},
+		{numericGreaterThanEquals, true},
+		{boolean, true},
 		{name("foo"), false},
 	}
 
@@ -0,0 +1,144 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// numericFunc - Numeric condition functions. They compare the integer value by
+// Key in given values with condition value.
+// For example,
+//   - if n = NumericLessThanEquals, Key = S3MaxKeys and Value = 100, at evaluate()
+//     it returns whether max-keys in value map is less than or equal to 100.
+type numericFunc struct {
+	n     name
+	k     Key
+	value int
+}
+
+// evaluate() - evaluates to check whether the integer value by Key in given values
+// compares with condition value. A missing or non-integer value never matches.
+func (f numericFunc) evaluate(values map[string][]string) bool {
+	requestValue := f.k.values(values)
+	if len(requestValue) == 0 {
+		return false
+	}
+
+	i, err := strconv.Atoi(requestValue[0])
+	if err != nil {
+		return false
+	}
+
+	switch f.n {
+	case numericEquals:
+		return i == f.value
+	case numericNotEquals:
+		return i != f.value
+	case numericLessThan:
+		return i < f.value
+	case numericLessThanEquals:
+		return i <= f.value
+	case numericGreaterThan:
+		return i > f.value
+	case numericGreaterThanEquals:
+		return i >= f.value
+	}
+
+	return false
+}
+
+// key() - returns condition key which is used by this condition function.
+func (f numericFunc) key() Key {
+	return f.k
+}
+
+// name() - returns "Numeric*" condition name.
+func (f numericFunc) name() name {
+	return f.n
+}
+
+func (f numericFunc) String() string {
+	return fmt.Sprintf("%v:%v:%v", f.n, f.k, f.value)
+}
+
+// toMap - returns map representation of this function.
+func (f numericFunc) toMap() map[Key]ValueSet {
+	if !f.k.IsValid() {
+		return nil
+	}
+
+	return map[Key]ValueSet{
+		f.k: NewValueSet(NewIntValue(f.value)),
+	}
+}
+
+func newNumericFunc(n name, key Key, values ValueSet) (Function, error) {
+	if len(values) != 1 {
+		return nil, fmt.Errorf("only one value is allowed for %v condition", n)
+	}
+
+	var value int
+	for v := range values {
+		switch v.GetType() {
+		case reflect.Int:
+			value, _ = v.GetInt()
+		case reflect.String:
+			var err error
+			s, _ := v.GetString()
+			if value, err = strconv.Atoi(s); err != nil {
+				return nil, fmt.Errorf("value must be an integer string for %v condition", n)
+			}
+		default:
+			return nil, fmt.Errorf("value must be an integer for %v condition", n)
+		}
+	}
+
+	return &numericFunc{n, key, value}, nil
+}
+
+// NewNumericEqualsFunc - returns new NumericEquals function.
+func NewNumericEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericEquals, key, value}, nil
+}
+
+// NewNumericNotEqualsFunc - returns new NumericNotEquals function.
+func NewNumericNotEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericNotEquals, key, value}, nil
+}
+
+// NewNumericLessThanFunc - returns new NumericLessThan function.
+func NewNumericLessThanFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericLessThan, key, value}, nil
+}
+
+// NewNumericLessThanEqualsFunc - returns new NumericLessThanEquals function.
+func NewNumericLessThanEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericLessThanEquals, key, value}, nil
+}
+
+// NewNumericGreaterThanFunc - returns new NumericGreaterThan function.
+func NewNumericGreaterThanFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericGreaterThan, key, value}, nil
+}
+
+// NewNumericGreaterThanEqualsFunc - returns new NumericGreaterThanEquals function.
+func NewNumericGreaterThanEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericGreaterThanEquals, key, value}, nil
+}
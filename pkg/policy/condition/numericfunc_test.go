@@ -0,0 +1,158 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNumericFuncEvaluate(t *testing.T) {
+	testCases := []struct {
+		n              name
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{numericEquals, map[string][]string{"max-keys": {"100"}}, true},
+		{numericEquals, map[string][]string{"max-keys": {"99"}}, false},
+		{numericEquals, map[string][]string{}, false},
+		{numericNotEquals, map[string][]string{"max-keys": {"100"}}, false},
+		{numericNotEquals, map[string][]string{"max-keys": {"99"}}, true},
+		{numericNotEquals, map[string][]string{}, false},
+		{numericNotEquals, map[string][]string{"max-keys": {"foo"}}, false},
+		{numericLessThan, map[string][]string{"max-keys": {"99"}}, true},
+		{numericLessThan, map[string][]string{"max-keys": {"100"}}, false},
+		{numericLessThanEquals, map[string][]string{"max-keys": {"100"}}, true},
+		{numericLessThanEquals, map[string][]string{"max-keys": {"101"}}, false},
+		{numericLessThanEquals, map[string][]string{"max-keys": {"foo"}}, false},
+		{numericLessThanEquals, map[string][]string{}, false},
+		{numericGreaterThan, map[string][]string{"max-keys": {"101"}}, true},
+		{numericGreaterThan, map[string][]string{"max-keys": {"100"}}, false},
+		{numericGreaterThanEquals, map[string][]string{"max-keys": {"100"}}, true},
+		{numericGreaterThanEquals, map[string][]string{"max-keys": {"99"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		function, err := newNumericFunc(testCase.n, S3MaxKeys, NewValueSet(NewIntValue(100)))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		result := function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNumericFuncToMap(t *testing.T) {
+	case1Function, err := newNumericFunc(numericLessThanEquals, S3MaxKeys, NewValueSet(NewStringValue("100")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case1Result := map[Key]ValueSet{
+		S3MaxKeys: NewValueSet(NewIntValue(100)),
+	}
+
+	testCases := []struct {
+		f              Function
+		expectedResult map[Key]ValueSet
+	}{
+		{case1Function, case1Result},
+		{&numericFunc{}, nil},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.f.toMap()
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: result: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNewNumericFunc(t *testing.T) {
+	case1Function, err := NewNumericLessThanEqualsFunc(S3MaxKeys, 100)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case2Function, err := NewNumericGreaterThanFunc(S3MaxKeys, 10)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		n              name
+		values         ValueSet
+		expectedResult Function
+		expectErr      bool
+	}{
+		{numericLessThanEquals, NewValueSet(NewIntValue(100)), case1Function, false},
+		{numericGreaterThan, NewValueSet(NewStringValue("10")), case2Function, false},
+		// Multiple values error.
+		{numericEquals, NewValueSet(NewIntValue(10), NewIntValue(100)), nil, true},
+		// Invalid integer string error.
+		{numericEquals, NewValueSet(NewStringValue("foo")), nil, true},
+		// Invalid value error.
+		{numericEquals, NewValueSet(NewBoolValue(true)), nil, true},
+	}
+
+	for i, testCase := range testCases {
+		result, err := newNumericFunc(testCase.n, S3MaxKeys, testCase.values)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if !reflect.DeepEqual(result, testCase.expectedResult) {
+				t.Fatalf("case %v: result: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+			}
+		}
+	}
+}
+
+func TestNumericFuncUnmarshalJSON(t *testing.T) {
+	data := []byte(`{"NumericLessThanEquals": {"s3:max-keys": "100"}, "Bool": {"aws:SecureTransport": "true"}}`)
+
+	var functions Functions
+	if err := json.Unmarshal(data, &functions); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{map[string][]string{"max-keys": {"100"}, "SecureTransport": {"true"}}, true},
+		{map[string][]string{"max-keys": {"1000"}, "SecureTransport": {"true"}}, false},
+		{map[string][]string{"max-keys": {"100"}, "SecureTransport": {"false"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := functions.Evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -44,7 +44,7 @@ type stringEqualsFunc struct {
 // evaluate() - evaluates to check whether value by Key in given values is in
 // condition values.
 func (f stringEqualsFunc) evaluate(values map[string][]string) bool {
-	requestValue := values[f.k.Name()]
+	requestValue := f.k.values(values)
 	return !f.values.Intersection(set.CreateStringSet(requestValue...)).IsEmpty()
 }
 
@@ -47,18 +47,19 @@ func TestStringEqualsFuncEvaluate(t *testing.T) {
 		values         map[string][]string
 		expectedResult bool
 	}{
-		{case1Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject"}}, true},
-		{case1Function, map[string][]string{"x-amz-copy-source": {"yourbucket/myobject"}}, false},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject"}}, true},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"yourbucket/myobject"}}, false},
 		{case1Function, map[string][]string{}, false},
 		{case1Function, map[string][]string{"delimiter": {"/"}}, false},
 
-		{case2Function, map[string][]string{"x-amz-server-side-encryption": {"AES256"}}, true},
-		{case2Function, map[string][]string{"x-amz-server-side-encryption": {"aws:kms"}}, false},
+		{case2Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES256"}}, true},
+		{case2Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"aws:kms"}}, false},
+		{case2Function, map[string][]string{"x-amz-server-side-encryption": {"AES256"}}, false},
 		{case2Function, map[string][]string{}, false},
 		{case2Function, map[string][]string{"delimiter": {"/"}}, false},
 
-		{case3Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE"}}, true},
-		{case3Function, map[string][]string{"x-amz-metadata-directive": {"COPY"}}, false},
+		{case3Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE"}}, true},
+		{case3Function, map[string][]string{"X-Amz-Metadata-Directive": {"COPY"}}, false},
 		{case3Function, map[string][]string{}, false},
 		{case3Function, map[string][]string{"delimiter": {"/"}}, false},
 
@@ -272,18 +273,18 @@ func TestStringNotEqualsFuncEvaluate(t *testing.T) {
 		values         map[string][]string
 		expectedResult bool
 	}{
-		{case1Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject"}}, false},
-		{case1Function, map[string][]string{"x-amz-copy-source": {"yourbucket/myobject"}}, true},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject"}}, false},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"yourbucket/myobject"}}, true},
 		{case1Function, map[string][]string{}, true},
 		{case1Function, map[string][]string{"delimiter": {"/"}}, true},
 
-		{case2Function, map[string][]string{"x-amz-server-side-encryption": {"AES256"}}, false},
-		{case2Function, map[string][]string{"x-amz-server-side-encryption": {"aws:kms"}}, true},
+		{case2Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES256"}}, false},
+		{case2Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"aws:kms"}}, true},
 		{case2Function, map[string][]string{}, true},
 		{case2Function, map[string][]string{"delimiter": {"/"}}, true},
 
-		{case3Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE"}}, false},
-		{case3Function, map[string][]string{"x-amz-metadata-directive": {"COPY"}}, true},
+		{case3Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE"}}, false},
+		{case3Function, map[string][]string{"X-Amz-Metadata-Directive": {"COPY"}}, true},
 		{case3Function, map[string][]string{}, true},
 		{case3Function, map[string][]string{"delimiter": {"/"}}, true},
 
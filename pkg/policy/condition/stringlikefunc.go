@@ -45,7 +45,7 @@ type stringLikeFunc struct {
 // evaluate() - evaluates to check whether value by Key in given values is wildcard
 // matching in condition values.
 func (f stringLikeFunc) evaluate(values map[string][]string) bool {
-	for _, v := range values[f.k.Name()] {
+	for _, v := range f.k.values(values) {
 		if !f.values.FuncMatch(wildcard.Match, v).IsEmpty() {
 			return true
 		}
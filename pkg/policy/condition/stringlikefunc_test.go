@@ -67,39 +67,39 @@ func TestStringLikeFuncEvaluate(t *testing.T) {
 		values         map[string][]string
 		expectedResult bool
 	}{
-		{case1Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject"}}, true},
-		{case1Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject.png"}}, true},
-		{case1Function, map[string][]string{"x-amz-copy-source": {"yourbucket/myobject"}}, false},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject"}}, true},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject.png"}}, true},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"yourbucket/myobject"}}, false},
 		{case1Function, map[string][]string{}, false},
 		{case1Function, map[string][]string{"delimiter": {"/"}}, false},
 
-		{case2Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject"}}, true},
-		{case2Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject.png"}}, false},
-		{case2Function, map[string][]string{"x-amz-copy-source": {"yourbucket/myobject"}}, false},
+		{case2Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject"}}, true},
+		{case2Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject.png"}}, false},
+		{case2Function, map[string][]string{"X-Amz-Copy-Source": {"yourbucket/myobject"}}, false},
 		{case2Function, map[string][]string{}, false},
 		{case2Function, map[string][]string{"delimiter": {"/"}}, false},
 
-		{case3Function, map[string][]string{"x-amz-server-side-encryption": {"AES256"}}, true},
-		{case3Function, map[string][]string{"x-amz-server-side-encryption": {"AES512"}}, true},
-		{case3Function, map[string][]string{"x-amz-server-side-encryption": {"aws:kms"}}, false},
+		{case3Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES256"}}, true},
+		{case3Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES512"}}, true},
+		{case3Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"aws:kms"}}, false},
 		{case3Function, map[string][]string{}, false},
 		{case3Function, map[string][]string{"delimiter": {"/"}}, false},
 
-		{case4Function, map[string][]string{"x-amz-server-side-encryption": {"AES256"}}, true},
-		{case4Function, map[string][]string{"x-amz-server-side-encryption": {"AES512"}}, false},
-		{case4Function, map[string][]string{"x-amz-server-side-encryption": {"aws:kms"}}, false},
+		{case4Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES256"}}, true},
+		{case4Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES512"}}, false},
+		{case4Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"aws:kms"}}, false},
 		{case4Function, map[string][]string{}, false},
 		{case4Function, map[string][]string{"delimiter": {"/"}}, false},
 
-		{case5Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE"}}, true},
-		{case5Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE/COPY"}}, true},
-		{case5Function, map[string][]string{"x-amz-metadata-directive": {"COPY"}}, false},
+		{case5Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE"}}, true},
+		{case5Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE/COPY"}}, true},
+		{case5Function, map[string][]string{"X-Amz-Metadata-Directive": {"COPY"}}, false},
 		{case5Function, map[string][]string{}, false},
 		{case5Function, map[string][]string{"delimiter": {"/"}}, false},
 
-		{case6Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE"}}, true},
-		{case6Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE/COPY"}}, false},
-		{case6Function, map[string][]string{"x-amz-metadata-directive": {"COPY"}}, false},
+		{case6Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE"}}, true},
+		{case6Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE/COPY"}}, false},
+		{case6Function, map[string][]string{"X-Amz-Metadata-Directive": {"COPY"}}, false},
 		{case6Function, map[string][]string{}, false},
 		{case6Function, map[string][]string{"delimiter": {"/"}}, false},
 
@@ -340,39 +340,39 @@ func TestStringNotLikeFuncEvaluate(t *testing.T) {
 		values         map[string][]string
 		expectedResult bool
 	}{
-		{case1Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject"}}, false},
-		{case1Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject.png"}}, false},
-		{case1Function, map[string][]string{"x-amz-copy-source": {"yourbucket/myobject"}}, true},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject"}}, false},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject.png"}}, false},
+		{case1Function, map[string][]string{"X-Amz-Copy-Source": {"yourbucket/myobject"}}, true},
 		{case1Function, map[string][]string{}, true},
 		{case1Function, map[string][]string{"delimiter": {"/"}}, true},
 
-		{case2Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject"}}, false},
-		{case2Function, map[string][]string{"x-amz-copy-source": {"mybucket/myobject.png"}}, true},
-		{case2Function, map[string][]string{"x-amz-copy-source": {"yourbucket/myobject"}}, true},
+		{case2Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject"}}, false},
+		{case2Function, map[string][]string{"X-Amz-Copy-Source": {"mybucket/myobject.png"}}, true},
+		{case2Function, map[string][]string{"X-Amz-Copy-Source": {"yourbucket/myobject"}}, true},
 		{case2Function, map[string][]string{}, true},
 		{case2Function, map[string][]string{"delimiter": {"/"}}, true},
 
-		{case3Function, map[string][]string{"x-amz-server-side-encryption": {"AES256"}}, false},
-		{case3Function, map[string][]string{"x-amz-server-side-encryption": {"AES512"}}, false},
-		{case3Function, map[string][]string{"x-amz-server-side-encryption": {"aws:kms"}}, true},
+		{case3Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES256"}}, false},
+		{case3Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES512"}}, false},
+		{case3Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"aws:kms"}}, true},
 		{case3Function, map[string][]string{}, true},
 		{case3Function, map[string][]string{"delimiter": {"/"}}, true},
 
-		{case4Function, map[string][]string{"x-amz-server-side-encryption": {"AES256"}}, false},
-		{case4Function, map[string][]string{"x-amz-server-side-encryption": {"AES512"}}, true},
-		{case4Function, map[string][]string{"x-amz-server-side-encryption": {"aws:kms"}}, true},
+		{case4Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES256"}}, false},
+		{case4Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"AES512"}}, true},
+		{case4Function, map[string][]string{"X-Amz-Server-Side-Encryption": {"aws:kms"}}, true},
 		{case4Function, map[string][]string{}, true},
 		{case4Function, map[string][]string{"delimiter": {"/"}}, true},
 
-		{case5Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE"}}, false},
-		{case5Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE/COPY"}}, false},
-		{case5Function, map[string][]string{"x-amz-metadata-directive": {"COPY"}}, true},
+		{case5Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE"}}, false},
+		{case5Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE/COPY"}}, false},
+		{case5Function, map[string][]string{"X-Amz-Metadata-Directive": {"COPY"}}, true},
 		{case5Function, map[string][]string{}, true},
 		{case5Function, map[string][]string{"delimiter": {"/"}}, true},
 
-		{case6Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE"}}, false},
-		{case6Function, map[string][]string{"x-amz-metadata-directive": {"REPLACE/COPY"}}, true},
-		{case6Function, map[string][]string{"x-amz-metadata-directive": {"COPY"}}, true},
+		{case6Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE"}}, false},
+		{case6Function, map[string][]string{"X-Amz-Metadata-Directive": {"REPLACE/COPY"}}, true},
+		{case6Function, map[string][]string{"X-Amz-Metadata-Directive": {"COPY"}}, true},
 		{case6Function, map[string][]string{}, true},
 		{case6Function, map[string][]string{"delimiter": {"/"}}, true},
 
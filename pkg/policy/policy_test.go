@@ -98,7 +98,7 @@ func TestPolicyIsAllowed(t *testing.T) {
 		Action:      PutObjectAction,
 		BucketName:  "mybucket",
 		ConditionValues: map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"SourceIp":          {"192.168.1.10"},
 		},
 		ObjectName: "myobject",
@@ -125,7 +125,7 @@ func TestPolicyIsAllowed(t *testing.T) {
 		Action:      PutObjectAction,
 		BucketName:  "mybucket",
 		ConditionValues: map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"SourceIp":          {"192.168.1.10"},
 		},
 		IsOwner:    true,
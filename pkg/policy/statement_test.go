@@ -82,7 +82,7 @@ func TestStatementIsAllowed(t *testing.T) {
 		Action:      PutObjectAction,
 		BucketName:  "mybucket",
 		ConditionValues: map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"SourceIp":          {"192.168.1.10"},
 		},
 		ObjectName: "myobject",
@@ -109,7 +109,7 @@ func TestStatementIsAllowed(t *testing.T) {
 		Action:      PutObjectAction,
 		BucketName:  "mybucket",
 		ConditionValues: map[string][]string{
-			"x-amz-copy-source": {"mybucket/myobject"},
+			"X-Amz-Copy-Source": {"mybucket/myobject"},
 			"SourceIp":          {"192.168.1.10"},
 		},
 		IsOwner:    true,
@@ -189,6 +189,14 @@ func TestStatementIsValid(t *testing.T) {
 		t.Fatalf("unexpected error. %v\n", err)
 	}
 
+	func3, err := condition.NewStringEqualsFunc(
+		condition.Key("s3:ExistingObjectTag/project"),
+		"minio",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
 	testCases := []struct {
 		statement Statement
 		expectErr bool
@@ -256,6 +264,21 @@ func TestStatementIsValid(t *testing.T) {
 			NewResourceSet(NewResource("mybucket", "myobject*")),
 			condition.NewFunctions(func1),
 		), false},
+		// Tag condition keys.
+		{NewStatement(
+			Allow,
+			NewPrincipal("*"),
+			NewActionSet(GetObjectAction, GetObjectTaggingAction),
+			NewResourceSet(NewResource("mybucket", "myobject*")),
+			condition.NewFunctions(func3),
+		), false},
+		{NewStatement(
+			Allow,
+			NewPrincipal("*"),
+			NewActionSet(GetObjectAction, PutObjectAction),
+			NewResourceSet(NewResource("mybucket", "myobject*")),
+			condition.NewFunctions(func3),
+		), true},
 	}
 
 	for i, testCase := range testCases {
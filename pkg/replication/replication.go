@@ -0,0 +1,262 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/minio/minio/pkg/tagging"
+)
+
+const (
+	// Maximum number of rules of a replication configuration.
+	maxRules = 1000
+
+	// Maximum length of a rule ID.
+	maxRuleIDLength = 255
+
+	// Destination buckets are given by their ARN.
+	destinationARNPrefix = "arn:aws:s3:::"
+)
+
+// Errors returned when validating a replication configuration.
+var (
+	ErrMissingRole         = errors.New("replication configuration must have a role")
+	ErrNoRules             = errors.New("replication configuration has no rules")
+	ErrTooManyRules        = errors.New("replication configuration has too many rules")
+	ErrInvalidRuleID       = errors.New("rule ID must be at most 255 characters")
+	ErrDuplicateRuleID     = errors.New("rule ID must be unique")
+	ErrInvalidRuleStatus   = errors.New("rule status must be either Enabled or Disabled")
+	ErrInvalidFilter       = errors.New("rule filter must have at most one of Prefix, Tag or And")
+	ErrInvalidPriority     = errors.New("rule priority must not be negative")
+	ErrDuplicatePriority   = errors.New("rule priority must be unique")
+	ErrInvalidDestination  = errors.New("destination bucket must be given as arn:aws:s3:::bucket")
+	ErrDestinationMismatch = errors.New("rules must have the same destination bucket")
+)
+
+// Status - status of a replication rule.
+type Status string
+
+// Replication rule states.
+const (
+	Enabled  Status = "Enabled"
+	Disabled Status = "Disabled"
+)
+
+// And - filter of a rule on a prefix and several tags.
+type And struct {
+	Prefix string        `xml:"Prefix,omitempty"`
+	Tags   []tagging.Tag `xml:"Tag,omitempty"`
+}
+
+// Filter - selects the objects a rule applies to, by prefix, tag or both.
+type Filter struct {
+	Prefix string       `xml:"Prefix,omitempty"`
+	Tag    *tagging.Tag `xml:"Tag,omitempty"`
+	And    *And         `xml:"And,omitempty"`
+}
+
+// Validate - checks that at most one of Prefix, Tag or And is given.
+func (filter Filter) Validate() error {
+	if filter.And != nil {
+		if filter.Prefix != "" || filter.Tag != nil {
+			return ErrInvalidFilter
+		}
+		return tagging.Tagging{TagSet: tagging.TagSet{Tags: filter.And.Tags}}.Validate(tagging.MaxObjectTags)
+	}
+	if filter.Tag != nil {
+		if filter.Prefix != "" {
+			return ErrInvalidFilter
+		}
+		return filter.Tag.Validate()
+	}
+	return nil
+}
+
+// Destination - bucket objects are replicated to.
+type Destination struct {
+	Bucket       string `xml:"Bucket"`
+	StorageClass string `xml:"StorageClass,omitempty"`
+}
+
+// BucketName - returns the name of the destination bucket.
+func (destination Destination) BucketName() string {
+	return strings.TrimPrefix(destination.Bucket, destinationARNPrefix)
+}
+
+// Rule - replication rule of the objects selected by its filter. The
+// Prefix element is deprecated in favour of Filter.
+type Rule struct {
+	ID          string      `xml:"ID,omitempty"`
+	Status      Status      `xml:"Status"`
+	Priority    int         `xml:"Priority,omitempty"`
+	Prefix      string      `xml:"Prefix,omitempty"`
+	Filter      *Filter     `xml:"Filter,omitempty"`
+	Destination Destination `xml:"Destination"`
+}
+
+// Validate - checks the rule ID, status, filter and destination.
+func (rule Rule) Validate() error {
+	if len(rule.ID) > maxRuleIDLength {
+		return ErrInvalidRuleID
+	}
+	if rule.Status != Enabled && rule.Status != Disabled {
+		return ErrInvalidRuleStatus
+	}
+	if rule.Priority < 0 {
+		return ErrInvalidPriority
+	}
+	if rule.Filter != nil {
+		if rule.Prefix != "" {
+			return ErrInvalidFilter
+		}
+		if err := rule.Filter.Validate(); err != nil {
+			return err
+		}
+	}
+	if !strings.HasPrefix(rule.Destination.Bucket, destinationARNPrefix) || rule.Destination.BucketName() == "" {
+		return ErrInvalidDestination
+	}
+	return nil
+}
+
+// FilterPrefix - returns the prefix of the objects the rule applies to.
+func (rule Rule) FilterPrefix() string {
+	switch {
+	case rule.Filter == nil:
+		return rule.Prefix
+	case rule.Filter.And != nil:
+		return rule.Filter.And.Prefix
+	}
+	return rule.Filter.Prefix
+}
+
+// FilterTags - returns the tags of the objects the rule applies to.
+func (rule Rule) FilterTags() []tagging.Tag {
+	switch {
+	case rule.Filter == nil:
+		return nil
+	case rule.Filter.And != nil:
+		return rule.Filter.And.Tags
+	case rule.Filter.Tag != nil:
+		return []tagging.Tag{*rule.Filter.Tag}
+	}
+	return nil
+}
+
+// matches - returns true if the rule applies to the object, whatever
+// its status.
+func (rule Rule) matches(obj ObjectOpts) bool {
+	if !strings.HasPrefix(obj.Name, rule.FilterPrefix()) {
+		return false
+	}
+	for _, tag := range rule.FilterTags() {
+		if value, ok := obj.Tags[tag.Key]; !ok || value != tag.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Config - replication configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTreplication.html
+// Role is the ARN of the remote target objects are replicated to.
+type Config struct {
+	XMLName xml.Name `xml:"ReplicationConfiguration"`
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	Role    string   `xml:"Role"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// Validate - checks every rule, that rule IDs and priorities are unique
+// and that all rules replicate to the same bucket.
+func (config Config) Validate() error {
+	if config.Role == "" {
+		return ErrMissingRole
+	}
+	if len(config.Rules) == 0 {
+		return ErrNoRules
+	}
+	if len(config.Rules) > maxRules {
+		return ErrTooManyRules
+	}
+	ids := make(map[string]struct{}, len(config.Rules))
+	priorities := make(map[int]struct{}, len(config.Rules))
+	for _, rule := range config.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if rule.Destination.Bucket != config.Rules[0].Destination.Bucket {
+			return ErrDestinationMismatch
+		}
+		if _, ok := priorities[rule.Priority]; ok && len(config.Rules) > 1 {
+			return ErrDuplicatePriority
+		}
+		priorities[rule.Priority] = struct{}{}
+		if rule.ID == "" {
+			continue
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return ErrDuplicateRuleID
+		}
+		ids[rule.ID] = struct{}{}
+	}
+	return nil
+}
+
+// DestinationBucket - returns the name of the bucket objects are
+// replicated to.
+func (config Config) DestinationBucket() string {
+	if len(config.Rules) == 0 {
+		return ""
+	}
+	return config.Rules[0].Destination.BucketName()
+}
+
+// ObjectOpts - object replication rules are applied to.
+type ObjectOpts struct {
+	Name string
+	Tags map[string]string
+}
+
+// Replicate - returns true if the object is replicated, as decided by
+// the matching rule of highest priority.
+func (config Config) Replicate(obj ObjectOpts) bool {
+	var match *Rule
+	for i, rule := range config.Rules {
+		if rule.matches(obj) && (match == nil || rule.Priority > match.Priority) {
+			match = &config.Rules[i]
+		}
+	}
+	return match != nil && match.Status == Enabled
+}
+
+// ParseConfig - parses and validates a replication configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.XMLNS = ""
+	return &config, nil
+}
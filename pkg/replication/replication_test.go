@@ -0,0 +1,101 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/tagging"
+)
+
+const testRole = `<Role>arn:minio:replication::id:target</Role>`
+
+func TestParseConfig(t *testing.T) {
+	errMalformed := errors.New("malformed")
+	destination := `<Destination><Bucket>arn:aws:s3:::target</Bucket></Destination>`
+	testCases := []struct {
+		config      string
+		expectedErr error
+	}{
+		{`<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` + testRole + `<Rule><ID>all</ID><Status>Enabled</Status>` + destination + `</Rule></ReplicationConfiguration>`, nil},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>Disabled</Status><Prefix>tmp/</Prefix>` + destination + `</Rule></ReplicationConfiguration>`, nil},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>Enabled</Status><Priority>1</Priority><Filter><And><Prefix>a/</Prefix><Tag><Key>k</Key><Value>v</Value></Tag></And></Filter>` + destination + `</Rule>` +
+			`<Rule><Status>Enabled</Status><Priority>2</Priority><Filter><Tag><Key>l</Key><Value>w</Value></Tag></Filter>` + destination + `</Rule></ReplicationConfiguration>`, nil},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status>` + destination + `</Rule></ReplicationConfiguration>`, ErrMissingRole},
+		{`<ReplicationConfiguration>` + testRole + `</ReplicationConfiguration>`, ErrNoRules},
+		{`<ReplicationConfiguration>` + testRole + strings.Repeat(`<Rule><Status>Enabled</Status>`+destination+`</Rule>`, maxRules+1) + `</ReplicationConfiguration>`, ErrTooManyRules},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><ID>` + strings.Repeat("a", 256) + `</ID><Status>Enabled</Status>` + destination + `</Rule></ReplicationConfiguration>`, ErrInvalidRuleID},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><ID>a</ID><Status>Enabled</Status><Priority>1</Priority>` + destination + `</Rule><Rule><ID>a</ID><Status>Enabled</Status><Priority>2</Priority>` + destination + `</Rule></ReplicationConfiguration>`, ErrDuplicateRuleID},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>enabled</Status>` + destination + `</Rule></ReplicationConfiguration>`, ErrInvalidRuleStatus},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>Enabled</Status><Prefix>a</Prefix><Filter><Prefix>b</Prefix></Filter>` + destination + `</Rule></ReplicationConfiguration>`, ErrInvalidFilter},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>Enabled</Status><Filter><Tag><Key>aws:k</Key><Value>v</Value></Tag></Filter>` + destination + `</Rule></ReplicationConfiguration>`, tagging.ErrInvalidTagKey},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>Enabled</Status><Priority>-1</Priority>` + destination + `</Rule></ReplicationConfiguration>`, ErrInvalidPriority},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>Enabled</Status>` + destination + `</Rule><Rule><Status>Enabled</Status>` + destination + `</Rule></ReplicationConfiguration>`, ErrDuplicatePriority},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>Enabled</Status><Destination><Bucket>target</Bucket></Destination></Rule></ReplicationConfiguration>`, ErrInvalidDestination},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>Enabled</Status></Rule></ReplicationConfiguration>`, ErrInvalidDestination},
+		{`<ReplicationConfiguration>` + testRole + `<Rule><Status>Enabled</Status><Priority>1</Priority>` + destination + `</Rule>` +
+			`<Rule><Status>Enabled</Status><Priority>2</Priority><Destination><Bucket>arn:aws:s3:::other</Bucket></Destination></Rule></ReplicationConfiguration>`, ErrDestinationMismatch},
+		{`<ReplicationConfiguration><Rule>`, errMalformed},
+	}
+
+	for i, testCase := range testCases {
+		config, err := ParseConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr == errMalformed {
+			if err == nil {
+				t.Fatalf("case %v: expected an error, got none", i+1)
+			}
+			continue
+		}
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && config.DestinationBucket() != "target" {
+			t.Fatalf("case %v: expected destination bucket target, got %v", i+1, config.DestinationBucket())
+		}
+	}
+}
+
+func TestReplicate(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<ReplicationConfiguration>` + testRole +
+		`<Rule><Status>Enabled</Status><Priority>1</Priority><Filter><Prefix>logs/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule>` +
+		`<Rule><Status>Disabled</Status><Priority>2</Priority><Filter><And><Prefix>logs/tmp/</Prefix><Tag><Key>keep</Key><Value>no</Value></Tag></And></Filter><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule>` +
+		`<Rule><Status>Enabled</Status><Priority>3</Priority><Filter><Tag><Key>replicate</Key><Value>yes</Value></Tag></Filter><Destination><Bucket>arn:aws:s3:::target</Bucket></Destination></Rule>` +
+		`</ReplicationConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		obj      ObjectOpts
+		expected bool
+	}{
+		{ObjectOpts{Name: "logs/a"}, true},
+		{ObjectOpts{Name: "data/a"}, false},
+		{ObjectOpts{Name: "logs/tmp/a"}, true},
+		// The disabled rule of higher priority applies.
+		{ObjectOpts{Name: "logs/tmp/a", Tags: map[string]string{"keep": "no"}}, false},
+		{ObjectOpts{Name: "logs/tmp/a", Tags: map[string]string{"keep": "no", "replicate": "yes"}}, true},
+		{ObjectOpts{Name: "data/a", Tags: map[string]string{"replicate": "yes"}}, true},
+	}
+	for i, testCase := range testCases {
+		if result := config.Replicate(testCase.obj); result != testCase.expected {
+			t.Fatalf("case %v: expected %v, got %v", i+1, testCase.expected, result)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+// Values of FileHeaderInfo.
+const (
+	fileHeaderUse    = "USE"
+	fileHeaderIgnore = "IGNORE"
+	fileHeaderNone   = "NONE"
+)
+
+// Values of QuoteFields.
+const (
+	quoteFieldsAlways   = "ALWAYS"
+	quoteFieldsAsNeeded = "ASNEEDED"
+)
+
+// csvRecord - record of a CSV file, columns are named by the header of
+// the file if it is used, by their position as _1, _2 ... otherwise.
+type csvRecord struct {
+	names  []string
+	fields []string
+}
+
+func (r *csvRecord) name(i int) string {
+	if i < len(r.names) {
+		return r.names[i]
+	}
+	return "_" + strconv.Itoa(i+1)
+}
+
+func (r *csvRecord) Get(name string, caseSensitive bool) (interface{}, bool) {
+	for i := range r.fields {
+		if n := r.name(i); n == name || !caseSensitive && strings.EqualFold(n, name) {
+			return r.fields[i], true
+		}
+	}
+	return nil, false
+}
+
+func (r *csvRecord) Object() sql.Object {
+	obj := make(sql.Object, len(r.fields))
+	for i, field := range r.fields {
+		obj[i] = sql.Field{Name: r.name(i), Value: field}
+	}
+	return obj
+}
+
+// csvReader - reads records of CSV input. Fields may be quoted by the
+// quote character, quotes within quoted fields are escaped by the quote
+// escape character or by doubling them.
+type csvReader struct {
+	r *bufio.Reader
+
+	recordDelimiter []rune
+	fieldDelimiter  rune
+	quote           rune
+	quoteEscape     rune
+	comment         rune
+
+	names []string
+}
+
+func newCSVReader(r io.Reader, args *CSVInput) (*csvReader, error) {
+	cr := &csvReader{
+		r:               bufio.NewReader(r),
+		recordDelimiter: []rune(args.RecordDelimiter),
+		fieldDelimiter:  firstRune(args.FieldDelimiter),
+		quote:           firstRune(args.QuoteCharacter),
+		quoteEscape:     firstRune(args.QuoteEscapeCharacter),
+		comment:         firstRune(args.Comments),
+	}
+
+	switch args.FileHeaderInfo {
+	case fileHeaderUse, fileHeaderIgnore:
+		fields, err := cr.readFields()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if args.FileHeaderInfo == fileHeaderUse {
+			cr.names = fields
+		}
+	}
+	return cr, nil
+}
+
+func firstRune(s string) rune {
+	c, _ := utf8.DecodeRuneInString(s)
+	if s == "" {
+		return -1
+	}
+	return c
+}
+
+// isRecordDelimiter - returns true if c starts the record delimiter,
+// which is then consumed. Lines ending in CRLF are accepted for the
+// default delimiter. Delimiters are at most two runes long.
+func (cr *csvReader) isRecordDelimiter(c rune) bool {
+	delimiter := cr.recordDelimiter
+	if len(delimiter) == 1 && delimiter[0] == '\n' && c == '\r' {
+		delimiter = []rune("\r\n")
+	}
+	if c != delimiter[0] {
+		return false
+	}
+	if len(delimiter) == 1 {
+		return true
+	}
+	next, _, err := cr.r.ReadRune()
+	if err == nil && next == delimiter[1] {
+		return true
+	}
+	if err == nil {
+		cr.r.UnreadRune()
+	}
+	return false
+}
+
+func (cr *csvReader) readFields() ([]string, error) {
+	for {
+		fields, err := cr.readLine()
+		if err != nil {
+			return nil, err
+		}
+		// Blank lines are skipped.
+		if len(fields) == 1 && fields[0] == "" {
+			continue
+		}
+		return fields, nil
+	}
+}
+
+// readLine - reads the fields of the next line, skipping comments.
+func (cr *csvReader) readLine() ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	read, quoted, fieldStart := false, false, true
+
+	for {
+		c, _, err := cr.r.ReadRune()
+		if err == io.EOF {
+			if !read {
+				return nil, io.EOF
+			}
+			if quoted {
+				return nil, errCSVParsingError(errors.New("unterminated quoted field"))
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !read && c == cr.comment {
+			if err = cr.skipLine(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		read = true
+
+		if quoted {
+			if c == cr.quoteEscape && cr.quoteEscape != cr.quote {
+				next, _, nerr := cr.r.ReadRune()
+				if nerr == nil && (next == cr.quote || next == cr.quoteEscape) {
+					field.WriteRune(next)
+					continue
+				}
+				if nerr == nil {
+					cr.r.UnreadRune()
+				}
+				field.WriteRune(c)
+				continue
+			}
+			if c == cr.quote {
+				next, _, nerr := cr.r.ReadRune()
+				if nerr == nil && next == cr.quote {
+					field.WriteRune(c)
+					continue
+				}
+				if nerr == nil {
+					cr.r.UnreadRune()
+				}
+				quoted = false
+				continue
+			}
+			field.WriteRune(c)
+			continue
+		}
+
+		switch {
+		case fieldStart && c == cr.quote:
+			quoted = true
+		case c == cr.fieldDelimiter:
+			fields = append(fields, field.String())
+			field.Reset()
+			fieldStart = true
+			continue
+		case cr.isRecordDelimiter(c):
+			return append(fields, field.String()), nil
+		default:
+			field.WriteRune(c)
+		}
+		fieldStart = false
+	}
+	return append(fields, field.String()), nil
+}
+
+func (cr *csvReader) skipLine() error {
+	for {
+		c, _, err := cr.r.ReadRune()
+		if err != nil {
+			return err
+		}
+		if cr.isRecordDelimiter(c) {
+			return nil
+		}
+	}
+}
+
+func (cr *csvReader) Read() (sql.Record, error) {
+	fields, err := cr.readFields()
+	if err != nil {
+		return nil, err
+	}
+	return &csvRecord{names: cr.names, fields: fields}, nil
+}
+
+// csvWriter - writes records as CSV, quoting fields always or only if
+// they contain delimiters or quotes.
+type csvWriter struct {
+	recordDelimiter string
+	fieldDelimiter  string
+	quote           string
+	quoteEscape     string
+	quoteAlways     bool
+}
+
+func newCSVWriter(args *CSVOutput) *csvWriter {
+	return &csvWriter{
+		recordDelimiter: args.RecordDelimiter,
+		fieldDelimiter:  args.FieldDelimiter,
+		quote:           args.QuoteCharacter,
+		quoteEscape:     args.QuoteEscapeCharacter,
+		quoteAlways:     args.QuoteFields == quoteFieldsAlways,
+	}
+}
+
+func (cw *csvWriter) Write(buf *bytes.Buffer, obj sql.Object) error {
+	for i, field := range obj {
+		if i > 0 {
+			buf.WriteString(cw.fieldDelimiter)
+		}
+		value := sql.FormatValue(field.Value)
+		if !cw.quoteAlways && !strings.Contains(value, cw.fieldDelimiter) && !strings.Contains(value, cw.quote) &&
+			!strings.Contains(value, cw.recordDelimiter) && !strings.ContainsAny(value, "\r\n") {
+			buf.WriteString(value)
+			continue
+		}
+		buf.WriteString(cw.quote)
+		buf.WriteString(strings.Replace(value, cw.quote, cw.quoteEscape+cw.quote, -1))
+		buf.WriteString(cw.quote)
+	}
+	buf.WriteString(cw.recordDelimiter)
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import "net/http"
+
+// SelectError - error of a select request, reported with its S3 error
+// code. Errors of the SQL expression implement it as well.
+type SelectError interface {
+	error
+	ErrorCode() string
+	ErrorMessage() string
+	HTTPStatusCode() int
+}
+
+type s3Error struct {
+	code       string
+	message    string
+	statusCode int
+}
+
+func (err *s3Error) Error() string {
+	return err.message
+}
+
+func (err *s3Error) ErrorCode() string {
+	return err.code
+}
+
+func (err *s3Error) ErrorMessage() string {
+	return err.message
+}
+
+func (err *s3Error) HTTPStatusCode() int {
+	return err.statusCode
+}
+
+func errMalformedXML(err error) *s3Error {
+	return &s3Error{"MalformedXML", "The XML provided was not well-formed or did not validate against our published schema: " + err.Error(), http.StatusBadRequest}
+}
+
+func errMissingRequiredParameter(name string) *s3Error {
+	return &s3Error{"MissingRequiredParameter", "The SelectRequest entity is missing a required parameter " + name + ".", http.StatusBadRequest}
+}
+
+func errInvalidExpressionType() *s3Error {
+	return &s3Error{"InvalidExpressionType", "The ExpressionType is invalid. Only SQL expressions are supported.", http.StatusBadRequest}
+}
+
+func errInvalidCompressionFormat() *s3Error {
+	return &s3Error{"InvalidCompressionFormat", "The file is not in a supported compression format. Only GZIP and BZIP2 are supported.", http.StatusBadRequest}
+}
+
+func errInvalidDataSource() *s3Error {
+	return &s3Error{"InvalidDataSource", "Invalid data source type. Only CSV, JSON and Parquet are supported.", http.StatusBadRequest}
+}
+
+func errInvalidFileHeaderInfo() *s3Error {
+	return &s3Error{"InvalidFileHeaderInfo", "The FileHeaderInfo is invalid. Only NONE, USE, and IGNORE are supported.", http.StatusBadRequest}
+}
+
+func errInvalidJSONType() *s3Error {
+	return &s3Error{"InvalidJsonType", "The JsonType is invalid. Only DOCUMENT and LINES are supported.", http.StatusBadRequest}
+}
+
+func errInvalidQuoteFields() *s3Error {
+	return &s3Error{"InvalidQuoteFields", "The QuoteFields is invalid. Only ALWAYS and ASNEEDED are supported.", http.StatusBadRequest}
+}
+
+func errInvalidRequestParameter(message string) *s3Error {
+	return &s3Error{"InvalidRequestParameter", message, http.StatusBadRequest}
+}
+
+func errCSVParsingError(err error) *s3Error {
+	return &s3Error{"CSVParsingError", "Encountered an error parsing the CSV file: " + err.Error(), http.StatusBadRequest}
+}
+
+func errJSONParsingError(err error) *s3Error {
+	return &s3Error{"JSONParsingError", "Encountered an error parsing the JSON file: " + err.Error(), http.StatusBadRequest}
+}
+
+func errParquetParsingError(err error) *s3Error {
+	return &s3Error{"ParquetParsingError", "Encountered an error parsing the Parquet file: " + err.Error(), http.StatusBadRequest}
+}
+
+func errParquetUnsupportedCompressionCodec() *s3Error {
+	return &s3Error{"ParquetUnsupportedCompressionCodec", "The specified Parquet compression codec is not supported.", http.StatusBadRequest}
+}
+
+func errUnsupportedParquetType(err error) *s3Error {
+	return &s3Error{"UnsupportedParquetType", "The specified Parquet type is not supported: " + err.Error(), http.StatusBadRequest}
+}
+
+func errInternalError(err error) *s3Error {
+	return &s3Error{"InternalError", err.Error(), http.StatusInternalServerError}
+}
@@ -0,0 +1,170 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+// Values of the JSON input type.
+const (
+	jsonTypeDocument = "DOCUMENT"
+	jsonTypeLines    = "LINES"
+)
+
+// jsonRecord - record of JSON input, values which are not objects are
+// named _1.
+type jsonRecord struct {
+	obj sql.Object
+}
+
+func (r *jsonRecord) Get(name string, caseSensitive bool) (interface{}, bool) {
+	return r.obj.Get(name, caseSensitive)
+}
+
+func (r *jsonRecord) Object() sql.Object {
+	return r.obj
+}
+
+// jsonReader - reads records of JSON input, each top level value is a
+// record. Elements of top level arrays of documents are records as
+// well.
+type jsonReader struct {
+	decoder  *json.Decoder
+	document bool
+	inArray  bool
+}
+
+func newJSONReader(r io.Reader, args *JSONInput) *jsonReader {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return &jsonReader{decoder: decoder, document: args.Type == jsonTypeDocument}
+}
+
+func (jr *jsonReader) Read() (sql.Record, error) {
+	for {
+		if jr.inArray && !jr.decoder.More() {
+			jr.inArray = false
+			if _, err := jr.decoder.Token(); err != nil {
+				return nil, errJSONParsingError(err)
+			}
+			continue
+		}
+
+		tok, err := jr.decoder.Token()
+		if err == io.EOF && !jr.inArray {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, errJSONParsingError(err)
+		}
+		if delim, ok := tok.(json.Delim); ok && delim == '[' && jr.document && !jr.inArray {
+			jr.inArray = true
+			continue
+		}
+
+		value, err := jr.readValue(tok)
+		if err != nil {
+			return nil, errJSONParsingError(err)
+		}
+		obj, ok := value.(sql.Object)
+		if !ok {
+			obj = sql.Object{{Name: "_1", Value: value}}
+		}
+		return &jsonRecord{obj: obj}, nil
+	}
+}
+
+// readValue - reads the value starting with tok, objects keep the order
+// of their fields and numbers are int64 or float64.
+func (jr *jsonReader) readValue(tok json.Token) (interface{}, error) {
+	switch tok := tok.(type) {
+	case json.Delim:
+		switch tok {
+		case '{':
+			obj := sql.Object{}
+			for jr.decoder.More() {
+				nameTok, err := jr.decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				name, ok := nameTok.(string)
+				if !ok {
+					return nil, errors.New("invalid object key")
+				}
+				valueTok, err := jr.decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := jr.readValue(valueTok)
+				if err != nil {
+					return nil, err
+				}
+				obj = append(obj, sql.Field{Name: name, Value: value})
+			}
+			_, err := jr.decoder.Token()
+			return obj, err
+		case '[':
+			array := []interface{}{}
+			for jr.decoder.More() {
+				elemTok, err := jr.decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				elem, err := jr.readValue(elemTok)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, elem)
+			}
+			_, err := jr.decoder.Token()
+			return array, err
+		}
+		return nil, errors.New("unexpected delimiter " + tok.String())
+	case json.Number:
+		if i, err := tok.Int64(); err == nil {
+			return i, nil
+		}
+		return tok.Float64()
+	}
+	return tok, nil
+}
+
+// jsonWriter - writes records as JSON objects followed by the record
+// delimiter.
+type jsonWriter struct {
+	recordDelimiter string
+}
+
+func newJSONWriter(args *JSONOutput) *jsonWriter {
+	return &jsonWriter{recordDelimiter: args.RecordDelimiter}
+}
+
+func (jw *jsonWriter) Write(buf *bytes.Buffer, obj sql.Object) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	buf.WriteString(jw.recordDelimiter)
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+)
+
+// Messages of the response are framed in the event stream encoding:
+//
+//   total length (4 bytes) | headers length (4 bytes) | prelude CRC (4 bytes) |
+//   headers | payload | message CRC (4 bytes)
+//
+// Integers are big endian, CRCs are CRC32 IEEE checksums of all
+// preceding bytes of the message.
+
+// Type of string header values.
+const headerValueTypeString = 7
+
+type header struct {
+	name, value string
+}
+
+func newMessage(headers []header, payload []byte) []byte {
+	var headerBuf bytes.Buffer
+	for _, h := range headers {
+		headerBuf.WriteByte(byte(len(h.name)))
+		headerBuf.WriteString(h.name)
+		headerBuf.WriteByte(headerValueTypeString)
+		binary.Write(&headerBuf, binary.BigEndian, uint16(len(h.value)))
+		headerBuf.WriteString(h.value)
+	}
+
+	var buf bytes.Buffer
+	totalLength := 4 + 4 + 4 + headerBuf.Len() + len(payload) + 4
+	binary.Write(&buf, binary.BigEndian, uint32(totalLength))
+	binary.Write(&buf, binary.BigEndian, uint32(headerBuf.Len()))
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(buf.Bytes()))
+	buf.Write(headerBuf.Bytes())
+	buf.Write(payload)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(buf.Bytes()))
+	return buf.Bytes()
+}
+
+func newRecordsMessage(payload []byte) []byte {
+	return newMessage([]header{
+		{":event-type", "Records"},
+		{":content-type", "application/octet-stream"},
+		{":message-type", "event"},
+	}, payload)
+}
+
+func newStatsPayload(name string, bytesScanned, bytesProcessed, bytesReturned int64) []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?><%s><BytesScanned>%d</BytesScanned><BytesProcessed>%d</BytesProcessed><BytesReturned>%d</BytesReturned></%s>`,
+		name, bytesScanned, bytesProcessed, bytesReturned, name))
+}
+
+func newProgressMessage(bytesScanned, bytesProcessed, bytesReturned int64) []byte {
+	return newMessage([]header{
+		{":event-type", "Progress"},
+		{":content-type", "text/xml"},
+		{":message-type", "event"},
+	}, newStatsPayload("Progress", bytesScanned, bytesProcessed, bytesReturned))
+}
+
+func newStatsMessage(bytesScanned, bytesProcessed, bytesReturned int64) []byte {
+	return newMessage([]header{
+		{":event-type", "Stats"},
+		{":content-type", "text/xml"},
+		{":message-type", "event"},
+	}, newStatsPayload("Stats", bytesScanned, bytesProcessed, bytesReturned))
+}
+
+func newEndMessage() []byte {
+	return newMessage([]header{
+		{":event-type", "End"},
+		{":message-type", "event"},
+	}, nil)
+}
+
+func newErrorMessage(errorCode, errorMessage string) []byte {
+	return newMessage([]header{
+		{":error-code", errorCode},
+		{":error-message", errorMessage},
+		{":message-type", "error"},
+	}, nil)
+}
+
+// messageWriter - writes messages to the response, flushing each of
+// them to the client.
+type messageWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (mw *messageWriter) write(message []byte) error {
+	if mw.err != nil {
+		return mw.err
+	}
+	if _, mw.err = mw.w.Write(message); mw.err != nil {
+		return mw.err
+	}
+	if flusher, ok := mw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
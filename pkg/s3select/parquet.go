@@ -0,0 +1,189 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"io"
+	"strings"
+
+	"github.com/minio/minio/pkg/s3select/parquet"
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+// objectReaderAt - reads ranges of the object, counting the bytes read
+// as scanned.
+type objectReaderAt struct {
+	getObject ObjectReader
+	size      int64
+	counter   *countingReader
+}
+
+// objectReadError - error reading the object, as opposed to an error of
+// its content.
+type objectReadError struct {
+	err error
+}
+
+func (err objectReadError) Error() string {
+	return err.err.Error()
+}
+
+func (r *objectReaderAt) ReadAt(p []byte, offset int64) (int, error) {
+	if offset < 0 || offset+int64(len(p)) > r.size {
+		return 0, io.ErrUnexpectedEOF
+	}
+	rc, err := r.getObject(offset, int64(len(p)))
+	if err != nil {
+		return 0, objectReadError{err}
+	}
+	defer rc.Close()
+
+	n, err := io.ReadFull(rc, p)
+	r.counter.n += int64(n)
+	if err != nil {
+		return n, objectReadError{err}
+	}
+	return n, nil
+}
+
+// parquetRecordReader - reads records of Parquet input. Only the
+// columns referenced by the statement are read, and row groups whose
+// statistics rule out the WHERE clause are skipped.
+type parquetRecordReader struct {
+	reader    *parquet.Reader
+	statement *sql.Select
+	names     []string
+	// Indices of the columns read.
+	columns []int
+
+	rowGroup int
+	values   [][]interface{}
+	row      int
+	numRows  int
+}
+
+func newParquetReader(r io.ReaderAt, size int64, statement *sql.Select) (*parquetRecordReader, error) {
+	reader, err := parquet.NewReader(r, size)
+	if err != nil {
+		return nil, parquetError(err)
+	}
+
+	pr := &parquetRecordReader{reader: reader, statement: statement, names: reader.Columns()}
+	referenced, all := statement.Columns()
+	for i, name := range pr.names {
+		if all || containsFold(referenced, name) {
+			pr.columns = append(pr.columns, i)
+		}
+	}
+	return pr, nil
+}
+
+// containsFold - returns true if names contains name, ignoring case.
+// Reading columns which only differ by case is harmless.
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parquetError - converts errors of the parquet package to errors of
+// the select request.
+func parquetError(err error) error {
+	if rerr, ok := err.(objectReadError); ok {
+		return rerr.err
+	}
+	switch err {
+	case parquet.ErrUnsupportedCompressionCodec:
+		return errParquetUnsupportedCompressionCodec()
+	case parquet.ErrUnsupportedSchema, parquet.ErrUnsupportedEncoding:
+		return errUnsupportedParquetType(err)
+	}
+	return errParquetParsingError(err)
+}
+
+// mayMatch - returns false if the statistics of the row group rule out
+// the WHERE clause.
+func (pr *parquetRecordReader) mayMatch(rowGroup int) bool {
+	return pr.statement.MayMatch(func(name string, caseSensitive bool) (sql.ColumnStats, bool) {
+		col := -1
+		for i, n := range pr.names {
+			if n == name || !caseSensitive && strings.EqualFold(n, name) {
+				if col >= 0 {
+					// Ambiguous column names are not pruned.
+					return sql.ColumnStats{}, false
+				}
+				col = i
+			}
+		}
+		if col < 0 {
+			return sql.ColumnStats{}, false
+		}
+		stats := pr.reader.Stats(rowGroup, col)
+		return sql.ColumnStats{
+			Min:       stats.Min,
+			Max:       stats.Max,
+			NullCount: stats.NullCount,
+			Count:     pr.reader.NumRows(rowGroup),
+		}, true
+	})
+}
+
+// nextRowGroup - reads the columns of the next row group which may
+// match, returns io.EOF after the last one.
+func (pr *parquetRecordReader) nextRowGroup() error {
+	for ; pr.rowGroup < pr.reader.NumRowGroups(); pr.rowGroup++ {
+		if !pr.mayMatch(pr.rowGroup) {
+			continue
+		}
+
+		numRows := pr.reader.NumRows(pr.rowGroup)
+		pr.values = make([][]interface{}, len(pr.columns))
+		for i, col := range pr.columns {
+			values, err := pr.reader.ReadColumn(pr.rowGroup, col)
+			if err != nil {
+				return parquetError(err)
+			}
+			if int64(len(values)) != numRows {
+				return errParquetParsingError(io.ErrUnexpectedEOF)
+			}
+			pr.values[i] = values
+		}
+		pr.row, pr.numRows = 0, int(numRows)
+		pr.rowGroup++
+		return nil
+	}
+	return io.EOF
+}
+
+func (pr *parquetRecordReader) Read() (sql.Record, error) {
+	for pr.row >= pr.numRows {
+		if err := pr.nextRowGroup(); err != nil {
+			return nil, err
+		}
+	}
+
+	obj := make(sql.Object, len(pr.columns))
+	for i, col := range pr.columns {
+		obj[i] = sql.Field{Name: pr.names[col], Value: pr.values[i][pr.row]}
+	}
+	pr.row++
+	// Rows are records of named fields, like objects of JSON input.
+	return &jsonRecord{obj: obj}, nil
+}
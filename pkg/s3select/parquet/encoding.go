@@ -0,0 +1,187 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+// plainDecoder - decodes PLAIN encoded values of a column.
+type plainDecoder struct {
+	col  column
+	data []byte
+	pos  int
+	// Position of the next bit of booleans.
+	bit int
+}
+
+func (d *plainDecoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, errInvalidFile
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// decode - returns the next value, int32 and int64 values are returned
+// as int64, floats as float64 and byte arrays as strings.
+func (d *plainDecoder) decode() (interface{}, error) {
+	switch d.col.typ {
+	case typeBoolean:
+		if d.pos >= len(d.data) {
+			return nil, errInvalidFile
+		}
+		v := d.data[d.pos]>>uint(d.bit)&1 == 1
+		if d.bit++; d.bit == 8 {
+			d.bit = 0
+			d.pos++
+		}
+		return v, nil
+	case typeInt32:
+		b, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return int64(int32(binary.LittleEndian.Uint32(b))), nil
+	case typeInt64:
+		b, err := d.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return int64(binary.LittleEndian.Uint64(b)), nil
+	case typeFloat:
+		b, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), nil
+	case typeDouble:
+		b, err := d.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case typeByteArray:
+		b, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		if b, err = d.next(int(binary.LittleEndian.Uint32(b))); err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case typeFixedLenByteArray:
+		b, err := d.next(d.col.typeLength)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return nil, ErrUnsupportedSchema
+}
+
+// decodeStatistic - decodes a min or max value of the statistics of a
+// column chunk.
+func decodeStatistic(col column, data []byte) (interface{}, error) {
+	if col.typ == typeByteArray {
+		return string(data), nil
+	}
+	d := &plainDecoder{col: col, data: data}
+	return d.decode()
+}
+
+// bitWidth - returns the number of bits needed to encode values up to
+// max.
+func bitWidth(max int) int {
+	return bits.Len(uint(max))
+}
+
+// rleDecoder - decodes values of the RLE and bit packing hybrid
+// encoding, used for definition levels and dictionary indices.
+type rleDecoder struct {
+	data     []byte
+	pos      int
+	bitWidth int
+
+	// Remaining values of the current run.
+	count  int
+	packed bool
+	value  int
+	// Bit position of the next packed value.
+	bitPos int
+}
+
+func (d *rleDecoder) readHeader() error {
+	header, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 {
+		return errInvalidFile
+	}
+	d.pos += n
+
+	if header&1 == 1 {
+		// Bit packed run of groups of 8 values.
+		d.packed = true
+		d.count = int(header>>1) * 8
+		d.bitPos = d.pos * 8
+		length := int(header>>1) * d.bitWidth
+		if length < 0 || len(d.data)-d.pos < length {
+			// The last run may be truncated to the values it holds.
+			length = len(d.data) - d.pos
+		}
+		d.pos += length
+		return nil
+	}
+
+	d.packed = false
+	d.count = int(header >> 1)
+	width := (d.bitWidth + 7) / 8
+	if len(d.data)-d.pos < width {
+		return errInvalidFile
+	}
+	d.value = 0
+	for i := 0; i < width; i++ {
+		d.value |= int(d.data[d.pos+i]) << uint(8*i)
+	}
+	d.pos += width
+	return nil
+}
+
+func (d *rleDecoder) next() (int, error) {
+	for d.count == 0 {
+		if err := d.readHeader(); err != nil {
+			return 0, err
+		}
+	}
+	d.count--
+	if !d.packed {
+		return d.value, nil
+	}
+
+	value := 0
+	for i := 0; i < d.bitWidth; i++ {
+		byteIndex := (d.bitPos + i) / 8
+		if byteIndex >= len(d.data) {
+			return 0, errInvalidFile
+		}
+		value |= int(d.data[byteIndex]>>uint((d.bitPos+i)%8)&1) << uint(i)
+	}
+	d.bitPos += d.bitWidth
+	return value, nil
+}
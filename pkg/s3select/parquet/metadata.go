@@ -0,0 +1,173 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+// Physical types of columns.
+const (
+	typeBoolean           = 0
+	typeInt32             = 1
+	typeInt64             = 2
+	typeInt96             = 3
+	typeFloat             = 4
+	typeDouble            = 5
+	typeByteArray         = 6
+	typeFixedLenByteArray = 7
+)
+
+// Repetition of schema elements.
+const (
+	repetitionRequired = 0
+	repetitionOptional = 1
+	repetitionRepeated = 2
+)
+
+// Compression codecs of column chunks.
+const (
+	codecUncompressed = 0
+	codecSnappy       = 1
+	codecGzip         = 2
+)
+
+// Page types.
+const (
+	pageData       = 0
+	pageDictionary = 2
+	pageDataV2     = 3
+)
+
+// Encodings of values and levels.
+const (
+	encodingPlain           = 0
+	encodingPlainDictionary = 2
+	encodingRLE             = 3
+	encodingRLEDictionary   = 8
+)
+
+// column - leaf column of the schema.
+type column struct {
+	name       string
+	typ        int64
+	typeLength int
+	optional   bool
+}
+
+// columnChunk - location and statistics of the values of a column in a
+// row group.
+type columnChunk struct {
+	codec                int64
+	numValues            int64
+	offset               int64
+	totalCompressedSize  int64
+	statistics           thriftFields
+	hasDictionaryPage    bool
+	dataPageOffset       int64
+	dictionaryPageOffset int64
+}
+
+type rowGroup struct {
+	numRows int64
+	columns []columnChunk
+}
+
+type fileMetadata struct {
+	columns   []column
+	numRows   int64
+	rowGroups []rowGroup
+}
+
+// parseFileMetadata - parses the FileMetaData struct of the footer. Only
+// flat schemas of required and optional columns are supported.
+func parseFileMetadata(data []byte) (*fileMetadata, error) {
+	d := &thriftDecoder{data: data}
+	fields, err := d.readStruct(0)
+	if err != nil {
+		return nil, err
+	}
+
+	md := &fileMetadata{numRows: fields.int(3)}
+	schema := fields.list(2)
+	if len(schema) == 0 {
+		return nil, errInvalidFile
+	}
+	for _, e := range schema[1:] {
+		element, ok := e.(thriftFields)
+		if !ok {
+			return nil, errInvalidFile
+		}
+		if element.int(5) > 0 || !element.has(1) {
+			return nil, ErrUnsupportedSchema
+		}
+		repetition := element.int(3)
+		if repetition == repetitionRepeated {
+			return nil, ErrUnsupportedSchema
+		}
+		col := column{
+			name:       string(element.bytes(4)),
+			typ:        element.int(1),
+			typeLength: int(element.int(2)),
+			optional:   repetition == repetitionOptional,
+		}
+		if col.typ == typeInt96 || col.typ < typeBoolean || col.typ > typeFixedLenByteArray {
+			return nil, ErrUnsupportedSchema
+		}
+		md.columns = append(md.columns, col)
+	}
+	if root, ok := schema[0].(thriftFields); !ok || root.int(5) != int64(len(md.columns)) {
+		return nil, errInvalidFile
+	}
+
+	for _, g := range fields.list(4) {
+		group, ok := g.(thriftFields)
+		if !ok {
+			return nil, errInvalidFile
+		}
+		rg := rowGroup{numRows: group.int(3)}
+		chunks := group.list(1)
+		if len(chunks) != len(md.columns) {
+			return nil, errInvalidFile
+		}
+		for _, c := range chunks {
+			chunk, ok := c.(thriftFields)
+			if !ok {
+				return nil, errInvalidFile
+			}
+			if len(chunk.bytes(1)) > 0 {
+				return nil, ErrUnsupportedSchema
+			}
+			meta := chunk.structure(3)
+			if meta == nil {
+				return nil, errInvalidFile
+			}
+			cc := columnChunk{
+				codec:                meta.int(4),
+				numValues:            meta.int(5),
+				totalCompressedSize:  meta.int(7),
+				dataPageOffset:       meta.int(9),
+				dictionaryPageOffset: meta.int(11),
+				statistics:           meta.structure(12),
+			}
+			cc.offset = cc.dataPageOffset
+			if meta.has(11) && cc.dictionaryPageOffset > 0 && cc.dictionaryPageOffset < cc.dataPageOffset {
+				cc.offset = cc.dictionaryPageOffset
+				cc.hasDictionaryPage = true
+			}
+			rg.columns = append(rg.columns, cc)
+		}
+		md.rowGroups = append(md.rowGroups, rg)
+	}
+	return md, nil
+}
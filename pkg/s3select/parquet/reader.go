@@ -0,0 +1,362 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package parquet reads the values of flat parquet files column by
+// column, one row group at a time, and writes such files row by row.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/snappy"
+)
+
+// Parquet files start and end with this magic.
+const magic = "PAR1"
+
+// Maximum size of the metadata in the footer of a file.
+const maxMetadataSize = 64 * 1024 * 1024
+
+// Errors returned reading parquet files.
+var (
+	ErrUnsupportedSchema           = errors.New("unsupported parquet schema")
+	ErrUnsupportedCompressionCodec = errors.New("unsupported parquet compression codec")
+	ErrUnsupportedEncoding         = errors.New("unsupported parquet encoding")
+
+	errInvalidFile = errors.New("invalid parquet file")
+)
+
+// Stats - statistics of the values of a column in a row group, Min and
+// Max are nil if unknown and NullCount is -1 if unknown.
+type Stats struct {
+	Min, Max  interface{}
+	NullCount int64
+	NumValues int64
+}
+
+// Reader - reads a parquet file.
+type Reader struct {
+	r        io.ReaderAt
+	metadata *fileMetadata
+}
+
+// NewReader - returns a reader of the parquet file of the given size,
+// its metadata is read from the footer.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < int64(2*len(magic)+4) {
+		return nil, errInvalidFile
+	}
+	footer := make([]byte, 4+len(magic))
+	if _, err := r.ReadAt(footer, size-int64(len(footer))); err != nil {
+		return nil, err
+	}
+	if string(footer[4:]) != magic {
+		return nil, errInvalidFile
+	}
+
+	metadataSize := int64(binary.LittleEndian.Uint32(footer))
+	if metadataSize > maxMetadataSize || metadataSize > size-int64(len(footer)+len(magic)) {
+		return nil, errInvalidFile
+	}
+	data := make([]byte, metadataSize)
+	if _, err := r.ReadAt(data, size-int64(len(footer))-metadataSize); err != nil {
+		return nil, err
+	}
+	metadata, err := parseFileMetadata(data)
+	if err != nil {
+		if err == errInvalidThrift {
+			err = errInvalidFile
+		}
+		return nil, err
+	}
+	return &Reader{r: r, metadata: metadata}, nil
+}
+
+// Columns - returns the names of the columns of the file.
+func (r *Reader) Columns() []string {
+	names := make([]string, len(r.metadata.columns))
+	for i, col := range r.metadata.columns {
+		names[i] = col.name
+	}
+	return names
+}
+
+// NumRowGroups - returns the number of row groups of the file.
+func (r *Reader) NumRowGroups() int {
+	return len(r.metadata.rowGroups)
+}
+
+// NumRows - returns the number of rows of a row group.
+func (r *Reader) NumRows(rowGroup int) int64 {
+	return r.metadata.rowGroups[rowGroup].numRows
+}
+
+// Stats - returns the statistics of a column in a row group.
+func (r *Reader) Stats(rowGroup, col int) Stats {
+	chunk := r.metadata.rowGroups[rowGroup].columns[col]
+	stats := Stats{NullCount: -1, NumValues: chunk.numValues}
+	statistics := chunk.statistics
+	if statistics == nil {
+		return stats
+	}
+	if statistics.has(3) {
+		stats.NullCount = statistics.int(3)
+	}
+
+	// min_value and max_value are ordered by the logical type, the
+	// deprecated min and max only for types where signed and unsigned
+	// order agree.
+	column := r.metadata.columns[col]
+	minID, maxID := int16(6), int16(5)
+	if !statistics.has(minID) || !statistics.has(maxID) {
+		if column.typ == typeByteArray || column.typ == typeFixedLenByteArray {
+			return stats
+		}
+		minID, maxID = 2, 1
+	}
+	if statistics.has(minID) && statistics.has(maxID) {
+		min, minErr := decodeStatistic(column, statistics.bytes(minID))
+		max, maxErr := decodeStatistic(column, statistics.bytes(maxID))
+		if minErr == nil && maxErr == nil {
+			stats.Min, stats.Max = min, max
+		}
+	}
+	return stats
+}
+
+// ReadColumn - returns the values of a column in a row group, nulls are
+// nil.
+func (r *Reader) ReadColumn(rowGroup, col int) ([]interface{}, error) {
+	group := r.metadata.rowGroups[rowGroup]
+	chunk := group.columns[col]
+	if chunk.totalCompressedSize <= 0 || chunk.totalCompressedSize > maxMetadataSize*16 {
+		return nil, errInvalidFile
+	}
+	data := make([]byte, chunk.totalCompressedSize)
+	if _, err := r.r.ReadAt(data, chunk.offset); err != nil {
+		return nil, err
+	}
+
+	cr := &columnReader{
+		col:     r.metadata.columns[col],
+		codec:   chunk.codec,
+		data:    data,
+		numRows: group.numRows,
+	}
+	values, err := cr.read()
+	if err == errInvalidThrift {
+		err = errInvalidFile
+	}
+	return values, err
+}
+
+// columnReader - decodes the pages of a column chunk.
+type columnReader struct {
+	col        column
+	codec      int64
+	data       []byte
+	pos        int
+	numRows    int64
+	dictionary []interface{}
+}
+
+func (cr *columnReader) decompress(data []byte, uncompressedSize int64) ([]byte, error) {
+	switch cr.codec {
+	case codecUncompressed:
+		return data, nil
+	case codecSnappy:
+		return snappy.Decode(nil, data)
+	case codecGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.ReadAll(io.LimitReader(zr, uncompressedSize))
+	}
+	return nil, ErrUnsupportedCompressionCodec
+}
+
+func (cr *columnReader) read() ([]interface{}, error) {
+	// The number of rows is not trusted for the initial allocation.
+	size := cr.numRows
+	if size > int64(len(cr.data)) {
+		size = int64(len(cr.data))
+	}
+	values := make([]interface{}, 0, size)
+	for int64(len(values)) < cr.numRows {
+		d := &thriftDecoder{data: cr.data, pos: cr.pos}
+		header, err := d.readStruct(0)
+		if err != nil {
+			return nil, err
+		}
+		size := header.int(3)
+		if size < 0 || int64(len(cr.data)-d.pos) < size {
+			return nil, errInvalidFile
+		}
+		page := cr.data[d.pos : d.pos+int(size)]
+		cr.pos = d.pos + int(size)
+
+		switch header.int(1) {
+		case pageDictionary:
+			if err = cr.readDictionary(header, page); err != nil {
+				return nil, err
+			}
+		case pageData:
+			if values, err = cr.readDataPage(header, page, values); err != nil {
+				return nil, err
+			}
+		case pageDataV2:
+			if values, err = cr.readDataPageV2(header, page, values); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return values, nil
+}
+
+func (cr *columnReader) readDictionary(header thriftFields, page []byte) error {
+	dictHeader := header.structure(7)
+	if dictHeader == nil {
+		return errInvalidFile
+	}
+	data, err := cr.decompress(page, header.int(2))
+	if err != nil {
+		return err
+	}
+	numValues := dictHeader.int(1)
+	if numValues < 0 || numValues > int64(len(data))*8 {
+		return errInvalidFile
+	}
+	d := &plainDecoder{col: cr.col, data: data}
+	cr.dictionary = make([]interface{}, numValues)
+	for i := range cr.dictionary {
+		if cr.dictionary[i], err = d.decode(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readDataPage - reads a data page, definition levels are prefixed by
+// their length and compressed along with the values.
+func (cr *columnReader) readDataPage(header thriftFields, page []byte, values []interface{}) ([]interface{}, error) {
+	dataHeader := header.structure(5)
+	if dataHeader == nil {
+		return nil, errInvalidFile
+	}
+	data, err := cr.decompress(page, header.int(2))
+	if err != nil {
+		return nil, err
+	}
+
+	var levels *rleDecoder
+	if cr.col.optional {
+		if dataHeader.int(3) != encodingRLE {
+			return nil, ErrUnsupportedEncoding
+		}
+		if len(data) < 4 {
+			return nil, errInvalidFile
+		}
+		length := int64(binary.LittleEndian.Uint32(data))
+		if length > int64(len(data)-4) {
+			return nil, errInvalidFile
+		}
+		levels = &rleDecoder{data: data[4 : 4+length], bitWidth: 1}
+		data = data[4+length:]
+	}
+	return cr.decodeValues(dataHeader.int(2), dataHeader.int(1), levels, data, values)
+}
+
+// readDataPageV2 - reads a data page of the second version, where
+// definition levels are never compressed and come first.
+func (cr *columnReader) readDataPageV2(header thriftFields, page []byte, values []interface{}) ([]interface{}, error) {
+	dataHeader := header.structure(8)
+	if dataHeader == nil {
+		return nil, errInvalidFile
+	}
+	repetitionLength, definitionLength := dataHeader.int(6), dataHeader.int(5)
+	if repetitionLength < 0 || definitionLength < 0 || repetitionLength+definitionLength > int64(len(page)) {
+		return nil, errInvalidFile
+	}
+
+	var levels *rleDecoder
+	if cr.col.optional {
+		levels = &rleDecoder{data: page[repetitionLength : repetitionLength+definitionLength], bitWidth: 1}
+	}
+	data := page[repetitionLength+definitionLength:]
+	if compressed, ok := dataHeader.bool(7); compressed || !ok {
+		var err error
+		if data, err = cr.decompress(data, header.int(2)-repetitionLength-definitionLength); err != nil {
+			return nil, err
+		}
+	}
+	return cr.decodeValues(dataHeader.int(4), dataHeader.int(1), levels, data, values)
+}
+
+// decodeValues - appends numValues values of a page to values, values
+// are null where the definition level is 0.
+func (cr *columnReader) decodeValues(encoding, numValues int64, levels *rleDecoder, data []byte, values []interface{}) ([]interface{}, error) {
+	if numValues < 0 || int64(len(values))+numValues > cr.numRows {
+		return nil, errInvalidFile
+	}
+
+	var next func() (interface{}, error)
+	switch encoding {
+	case encodingPlain:
+		next = (&plainDecoder{col: cr.col, data: data}).decode
+	case encodingPlainDictionary, encodingRLEDictionary:
+		if cr.dictionary == nil || len(data) == 0 {
+			return nil, errInvalidFile
+		}
+		indices := &rleDecoder{data: data[1:], bitWidth: int(data[0])}
+		next = func() (interface{}, error) {
+			i, err := indices.next()
+			if err != nil {
+				return nil, err
+			}
+			if i >= len(cr.dictionary) {
+				return nil, errInvalidFile
+			}
+			return cr.dictionary[i], nil
+		}
+	default:
+		return nil, ErrUnsupportedEncoding
+	}
+
+	for i := int64(0); i < numValues; i++ {
+		if levels != nil {
+			level, err := levels.next()
+			if err != nil {
+				return nil, err
+			}
+			if level == 0 {
+				values = append(values, nil)
+				continue
+			}
+		}
+		v, err := next()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+var testColumns = []column{
+	{name: "name", typ: typeByteArray},
+	{name: "age", typ: typeInt32, optional: true},
+	{name: "score", typ: typeDouble},
+	{name: "active", typ: typeBoolean, optional: true},
+	{name: "id", typ: typeInt64},
+}
+
+var testRowGroups = [][][]interface{}{
+	{
+		{"Alice", "Bob", "Alice"},
+		{int64(31), nil, int64(25)},
+		{1.5, -2.0, 1.5},
+		{true, false, nil},
+		{int64(1), int64(2), int64(3)},
+	},
+	{
+		{"Carol"},
+		{nil},
+		{10.25},
+		{true},
+		{int64(1) << 40},
+	},
+}
+
+func TestReader(t *testing.T) {
+	testCases := []testFile{
+		{codec: codecUncompressed},
+		{codec: codecSnappy},
+		{codec: codecGzip},
+		{codec: codecUncompressed, dictionary: true},
+		{codec: codecSnappy, dictionary: true},
+		{codec: codecUncompressed, dataPageV2: true},
+		{codec: codecGzip, dictionary: true, dataPageV2: true},
+	}
+
+	for i, testCase := range testCases {
+		testCase.columns, testCase.rowGroups = testColumns, testRowGroups
+		data := testCase.write()
+		r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if names := r.Columns(); !reflect.DeepEqual(names, []string{"name", "age", "score", "active", "id"}) {
+			t.Fatalf("case %v: unexpected columns %v", i+1, names)
+		}
+		if r.NumRowGroups() != 2 || r.NumRows(0) != 3 || r.NumRows(1) != 1 {
+			t.Fatalf("case %v: unexpected row groups", i+1)
+		}
+		for rg, group := range testRowGroups {
+			for col, expected := range group {
+				values, err := r.ReadColumn(rg, col)
+				if err != nil {
+					t.Fatalf("case %v: row group %v column %v: unexpected error: %v", i+1, rg, col, err)
+				}
+				if !reflect.DeepEqual(values, expected) {
+					t.Fatalf("case %v: row group %v column %v: expected %v, got %v", i+1, rg, col, expected, values)
+				}
+			}
+		}
+	}
+}
+
+func TestReaderStats(t *testing.T) {
+	data := testFile{columns: testColumns, rowGroups: testRowGroups}.write()
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		rowGroup, column int
+		expected         Stats
+	}{
+		{0, 0, Stats{Min: "Alice", Max: "Bob", NullCount: 0, NumValues: 3}},
+		{0, 1, Stats{Min: int64(25), Max: int64(31), NullCount: 1, NumValues: 3}},
+		{0, 2, Stats{Min: -2.0, Max: 1.5, NullCount: 0, NumValues: 3}},
+		{1, 1, Stats{NullCount: 1, NumValues: 1}},
+		{1, 4, Stats{Min: int64(1) << 40, Max: int64(1) << 40, NullCount: 0, NumValues: 1}},
+	}
+	for i, testCase := range testCases {
+		if stats := r.Stats(testCase.rowGroup, testCase.column); !reflect.DeepEqual(stats, testCase.expected) {
+			t.Fatalf("case %v: expected %v, got %v", i+1, testCase.expected, stats)
+		}
+	}
+}
+
+func TestNewReaderErrors(t *testing.T) {
+	data := testFile{columns: testColumns, rowGroups: testRowGroups}.write()
+	nested := testFile{columns: testColumns, rowGroups: testRowGroups, nested: true}.write()
+
+	testCases := []struct {
+		data        []byte
+		expectedErr error
+	}{
+		{[]byte("PAR1"), errInvalidFile},
+		{[]byte("name,age\nAlice,31\n"), errInvalidFile},
+		{data[:len(data)-1], errInvalidFile},
+		{append([]byte{}, data[len(data)-12:]...), errInvalidFile},
+		{nested, ErrUnsupportedSchema},
+	}
+	for i, testCase := range testCases {
+		if _, err := NewReader(bytes.NewReader(testCase.data), int64(len(testCase.data))); err != testCase.expectedErr {
+			t.Fatalf("case %v: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestReadColumnUnsupportedCodec(t *testing.T) {
+	// LZO compressed chunks are not supported.
+	data := testFile{columns: testColumns[:1], rowGroups: [][][]interface{}{{{"Alice"}}}, codec: 3}.write()
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.ReadColumn(0, 0); err != ErrUnsupportedCompressionCodec {
+		t.Fatalf("expected error %v, got %v", ErrUnsupportedCompressionCodec, err)
+	}
+}
+
+func TestRLEDecoder(t *testing.T) {
+	// A run of five 3s followed by a bit packed group of 0 to 7.
+	data := append([]byte{5 << 1, 3}, bitPack([]int{0, 1, 2, 3, 4, 5, 6, 7}, 3)...)
+	d := &rleDecoder{data: data, bitWidth: 3}
+	expected := []int{3, 3, 3, 3, 3, 0, 1, 2, 3, 4, 5, 6, 7}
+	for i, e := range expected {
+		v, err := d.next()
+		if err != nil {
+			t.Fatalf("value %v: unexpected error: %v", i+1, err)
+		}
+		if v != e {
+			t.Fatalf("value %v: expected %v, got %v", i+1, e, v)
+		}
+	}
+	if _, err := d.next(); err != errInvalidFile {
+		t.Fatalf("expected error %v, got %v", errInvalidFile, err)
+	}
+}
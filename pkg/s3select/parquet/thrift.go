@@ -0,0 +1,232 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Types of the thrift compact protocol.
+const (
+	thriftStop   = 0
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftByte   = 3
+	thriftI16    = 4
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftDouble = 7
+	thriftBinary = 8
+	thriftList   = 9
+	thriftSet    = 10
+	thriftMap    = 11
+	thriftStruct = 12
+)
+
+// Limits of decoded structs, guarding against corrupt metadata.
+const (
+	maxThriftDepth  = 64
+	maxThriftLength = 1 << 30
+)
+
+var errInvalidThrift = errors.New("invalid thrift encoding")
+
+// thriftFields - fields of a decoded thrift struct by field id. Values
+// are bool, int64, float64, []byte, []interface{} and thriftFields.
+type thriftFields map[int16]interface{}
+
+func (fields thriftFields) int(id int16) int64 {
+	i, _ := fields[id].(int64)
+	return i
+}
+
+func (fields thriftFields) has(id int16) bool {
+	_, ok := fields[id]
+	return ok
+}
+
+func (fields thriftFields) bool(id int16) (value, ok bool) {
+	value, ok = fields[id].(bool)
+	return value, ok
+}
+
+func (fields thriftFields) bytes(id int16) []byte {
+	b, _ := fields[id].([]byte)
+	return b
+}
+
+func (fields thriftFields) structure(id int16) thriftFields {
+	s, _ := fields[id].(thriftFields)
+	return s
+}
+
+func (fields thriftFields) list(id int16) []interface{} {
+	l, _ := fields[id].([]interface{})
+	return l
+}
+
+// thriftDecoder - decodes structs encoded with the thrift compact
+// protocol, the encoding of the parquet metadata and page headers.
+type thriftDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *thriftDecoder) readByte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, errInvalidThrift
+	}
+	b := d.data[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *thriftDecoder) readVarint() (uint64, error) {
+	v, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 {
+		return 0, errInvalidThrift
+	}
+	d.pos += n
+	return v, nil
+}
+
+func (d *thriftDecoder) readZigzag() (int64, error) {
+	v, err := d.readVarint()
+	return int64(v>>1) ^ -int64(v&1), err
+}
+
+func (d *thriftDecoder) readLength() (int, error) {
+	n, err := d.readVarint()
+	if err != nil || n > maxThriftLength || int(n) > len(d.data)-d.pos {
+		return 0, errInvalidThrift
+	}
+	return int(n), nil
+}
+
+func (d *thriftDecoder) readStruct(depth int) (thriftFields, error) {
+	if depth > maxThriftDepth {
+		return nil, errInvalidThrift
+	}
+	fields := thriftFields{}
+	var id int16
+	for {
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		typ := b & 0x0f
+		if typ == thriftStop {
+			return fields, nil
+		}
+		if delta := int16(b >> 4); delta != 0 {
+			id += delta
+		} else {
+			fid, err := d.readZigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(fid)
+		}
+
+		// Booleans of struct fields are encoded in their type.
+		switch typ {
+		case thriftTrue:
+			fields[id] = true
+			continue
+		case thriftFalse:
+			fields[id] = false
+			continue
+		}
+		if fields[id], err = d.readValue(typ, depth); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *thriftDecoder) readValue(typ byte, depth int) (interface{}, error) {
+	switch typ {
+	case thriftTrue, thriftFalse:
+		// Booleans of lists are encoded as a byte.
+		b, err := d.readByte()
+		return b == thriftTrue, err
+	case thriftByte:
+		b, err := d.readByte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return d.readZigzag()
+	case thriftDouble:
+		if len(d.data)-d.pos < 8 {
+			return nil, errInvalidThrift
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.data[d.pos:]))
+		d.pos += 8
+		return v, nil
+	case thriftBinary:
+		n, err := d.readLength()
+		if err != nil {
+			return nil, err
+		}
+		b := d.data[d.pos : d.pos+n]
+		d.pos += n
+		return b, nil
+	case thriftList, thriftSet:
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		size, elemType := int(b>>4), b&0x0f
+		if size == 15 {
+			if size, err = d.readLength(); err != nil {
+				return nil, err
+			}
+		}
+		list := make([]interface{}, 0, size)
+		for i := 0; i < size; i++ {
+			v, err := d.readValue(elemType, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case thriftMap:
+		// Maps are not used by the parquet metadata read, their
+		// entries are skipped.
+		size, err := d.readLength()
+		if err != nil || size == 0 {
+			return nil, err
+		}
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < size; i++ {
+			if _, err = d.readValue(b>>4, depth+1); err != nil {
+				return nil, err
+			}
+			if _, err = d.readValue(b&0x0f, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case thriftStruct:
+		return d.readStruct(depth + 1)
+	}
+	return nil, errInvalidThrift
+}
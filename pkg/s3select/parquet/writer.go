@@ -0,0 +1,430 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/golang/snappy"
+)
+
+// Column types of the files written by a Writer, their values are bool,
+// int64 and string.
+const (
+	BooleanColumn = typeBoolean
+	Int64Column   = typeInt64
+	StringColumn  = typeByteArray
+)
+
+// Number of rows of the row groups written by a Writer.
+const writerRowGroupSize = 10000
+
+// ErrInvalidRow - returned writing a row whose values don't match the
+// columns of the file.
+var ErrInvalidRow = errors.New("parquet row does not match the columns")
+
+// Column - column of a file written by a Writer, values of optional
+// columns may be nil.
+type Column struct {
+	Name     string
+	Type     int64
+	Optional bool
+}
+
+// Writer - writes a flat parquet file row by row. Rows are buffered and
+// written as snappy compressed row groups, the metadata is written on
+// Close.
+type Writer struct {
+	w         io.Writer
+	offset    int64
+	columns   []column
+	values    [][]interface{}
+	numRows   int64
+	rowGroups []interface{}
+	err       error
+}
+
+// NewWriter - returns a writer of a parquet file of the given columns.
+func NewWriter(w io.Writer, columns []Column) *Writer {
+	writer := &Writer{w: w, values: make([][]interface{}, len(columns))}
+	for _, col := range columns {
+		writer.columns = append(writer.columns, column{name: col.Name, typ: col.Type, optional: col.Optional})
+	}
+	return writer
+}
+
+// Write - adds a row of values given in the order of the columns.
+func (w *Writer) Write(row []interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(row) != len(w.columns) || len(row) == 0 {
+		return ErrInvalidRow
+	}
+	for i, v := range row {
+		var ok bool
+		switch w.columns[i].typ {
+		case typeBoolean:
+			_, ok = v.(bool)
+		case typeInt64:
+			_, ok = v.(int64)
+		case typeByteArray:
+			_, ok = v.(string)
+		}
+		if !ok && (v != nil || !w.columns[i].optional) {
+			return ErrInvalidRow
+		}
+	}
+	for i, v := range row {
+		w.values[i] = append(w.values[i], v)
+	}
+	if len(w.values[0]) == writerRowGroupSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close - writes the buffered rows and the metadata of the file, it
+// doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	schema := []interface{}{[]thriftField{{4, "schema"}, {5, int32(len(w.columns))}}}
+	for _, col := range w.columns {
+		repetition := int32(repetitionRequired)
+		if col.optional {
+			repetition = repetitionOptional
+		}
+		schema = append(schema, []thriftField{{1, int32(col.typ)}, {3, repetition}, {4, col.name}})
+	}
+	fields := []thriftField{
+		{1, int32(1)},
+		{2, schema},
+		{3, w.numRows},
+	}
+	if len(w.rowGroups) > 0 {
+		fields = append(fields, thriftField{4, w.rowGroups})
+	}
+	var metadata bytes.Buffer
+	writeThriftStruct(&metadata, fields)
+	binary.Write(&metadata, binary.LittleEndian, uint32(metadata.Len()))
+	metadata.WriteString(magic)
+	err := w.write(metadata.Bytes())
+	if err == nil {
+		w.err = errors.New("parquet writer is closed")
+	}
+	return err
+}
+
+func (w *Writer) write(data []byte) error {
+	if w.err != nil {
+		return w.err
+	}
+	n, err := w.w.Write(data)
+	w.offset += int64(n)
+	w.err = err
+	return err
+}
+
+// flush - writes the buffered rows as a row group, after the magic
+// starting the file.
+func (w *Writer) flush() error {
+	if w.offset == 0 {
+		if err := w.write([]byte(magic)); err != nil {
+			return err
+		}
+	}
+	if len(w.columns) == 0 || len(w.values[0]) == 0 {
+		return nil
+	}
+
+	rows := int64(len(w.values[0]))
+	var buf bytes.Buffer
+	var chunks []interface{}
+	for i, col := range w.columns {
+		chunks = append(chunks, writeColumnChunk(&buf, w.offset, col, w.values[i]))
+		w.values[i] = w.values[i][:0]
+	}
+	w.numRows += rows
+	w.rowGroups = append(w.rowGroups, []thriftField{{1, chunks}, {2, int64(buf.Len())}, {3, rows}})
+	return w.write(buf.Bytes())
+}
+
+// writeColumnChunk - writes the values of a column as a single PLAIN
+// encoded data page at the given offset of the file, returns the
+// ColumnChunk struct of the metadata.
+func writeColumnChunk(buf *bytes.Buffer, offset int64, col column, values []interface{}) []thriftField {
+	var levels []int
+	var nonNull []interface{}
+	var nullCount int64
+	var min, max interface{}
+	for _, v := range values {
+		if v == nil {
+			levels = append(levels, 0)
+			nullCount++
+			continue
+		}
+		levels = append(levels, 1)
+		nonNull = append(nonNull, v)
+		if min == nil || compareValues(v, min) < 0 {
+			min = v
+		}
+		if max == nil || compareValues(v, max) > 0 {
+			max = v
+		}
+	}
+
+	page := plainEncode(col, nonNull)
+	if col.optional {
+		definitionLevels := bitPack(levels, 1)
+		length := make([]byte, 4)
+		binary.LittleEndian.PutUint32(length, uint32(len(definitionLevels)))
+		page = append(append(length, definitionLevels...), page...)
+	}
+	compressed := compress(codecSnappy, page)
+	dataOffset := offset + int64(buf.Len())
+	writePage(buf, []thriftField{
+		{1, int32(pageData)},
+		{2, int32(len(page))},
+		{3, int32(len(compressed))},
+		{5, []thriftField{
+			{1, int32(len(values))},
+			{2, int32(encodingPlain)},
+			{3, int32(encodingRLE)},
+			{4, int32(encodingRLE)},
+		}},
+	}, compressed)
+
+	statistics := []thriftField{{3, nullCount}}
+	if min != nil {
+		statistics = append(statistics,
+			thriftField{5, string(statisticEncode(col, max))},
+			thriftField{6, string(statisticEncode(col, min))})
+	}
+	size := offset + int64(buf.Len()) - dataOffset
+	return []thriftField{{2, dataOffset}, {3, []thriftField{
+		{1, int32(col.typ)},
+		{2, []interface{}{int32(encodingPlain), int32(encodingRLE)}},
+		{3, []interface{}{col.name}},
+		{4, int32(codecSnappy)},
+		{5, int64(len(values))},
+		{6, int64(len(page)) + size - int64(len(compressed))},
+		{7, size},
+		{9, dataOffset},
+		{12, statistics},
+	}}}
+}
+
+// thriftField - field of a thrift struct to encode. Values are bool,
+// int32, int64, string, []thriftField for structs and []interface{}
+// for lists.
+type thriftField struct {
+	id    int16
+	value interface{}
+}
+
+func thriftType(v interface{}) byte {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return thriftTrue
+		}
+		return thriftFalse
+	case int32:
+		return thriftI32
+	case int64:
+		return thriftI64
+	case string:
+		return thriftBinary
+	case []thriftField:
+		return thriftStruct
+	case []interface{}:
+		return thriftList
+	}
+	panic("unsupported thrift value")
+}
+
+func writeZigzag(buf *bytes.Buffer, v int64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func writeThriftStruct(buf *bytes.Buffer, fields []thriftField) {
+	var last int16
+	for _, field := range fields {
+		typ := thriftType(field.value)
+		if delta := field.id - last; delta > 0 && delta <= 15 {
+			buf.WriteByte(byte(delta)<<4 | typ)
+		} else {
+			buf.WriteByte(typ)
+			writeZigzag(buf, int64(field.id))
+		}
+		last = field.id
+		if _, ok := field.value.(bool); !ok {
+			writeThriftValue(buf, field.value)
+		}
+	}
+	buf.WriteByte(thriftStop)
+}
+
+func writeThriftValue(buf *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			buf.WriteByte(thriftTrue)
+		} else {
+			buf.WriteByte(thriftFalse)
+		}
+	case int32:
+		writeZigzag(buf, int64(v))
+	case int64:
+		writeZigzag(buf, v)
+	case string:
+		writeUvarint(buf, uint64(len(v)))
+		buf.WriteString(v)
+	case []thriftField:
+		writeThriftStruct(buf, v)
+	case []interface{}:
+		var typ byte = thriftStruct
+		if len(v) > 0 {
+			typ = thriftType(v[0])
+		}
+		if len(v) < 15 {
+			buf.WriteByte(byte(len(v))<<4 | typ)
+		} else {
+			buf.WriteByte(0xf0 | typ)
+			writeUvarint(buf, uint64(len(v)))
+		}
+		for _, item := range v {
+			writeThriftValue(buf, item)
+		}
+	}
+}
+
+// plainEncode - PLAIN encodes non null values of a column.
+func plainEncode(col column, values []interface{}) []byte {
+	var buf bytes.Buffer
+	var bits byte
+	for i, v := range values {
+		switch col.typ {
+		case typeBoolean:
+			if v.(bool) {
+				bits |= 1 << uint(i%8)
+			}
+			if i%8 == 7 || i == len(values)-1 {
+				buf.WriteByte(bits)
+				bits = 0
+			}
+		case typeInt32:
+			binary.Write(&buf, binary.LittleEndian, int32(v.(int64)))
+		case typeInt64:
+			binary.Write(&buf, binary.LittleEndian, v.(int64))
+		case typeFloat:
+			binary.Write(&buf, binary.LittleEndian, math.Float32bits(float32(v.(float64))))
+		case typeDouble:
+			binary.Write(&buf, binary.LittleEndian, math.Float64bits(v.(float64)))
+		case typeByteArray:
+			binary.Write(&buf, binary.LittleEndian, uint32(len(v.(string))))
+			buf.WriteString(v.(string))
+		}
+	}
+	return buf.Bytes()
+}
+
+// bitPack - encodes values as a single bit packed run of the hybrid
+// encoding.
+func bitPack(values []int, width int) []byte {
+	var buf bytes.Buffer
+	groups := (len(values) + 7) / 8
+	writeUvarint(&buf, uint64(groups<<1|1))
+	packed := make([]byte, groups*width)
+	for i, v := range values {
+		for b := 0; b < width; b++ {
+			if v>>uint(b)&1 == 1 {
+				bit := i*width + b
+				packed[bit/8] |= 1 << uint(bit%8)
+			}
+		}
+	}
+	buf.Write(packed)
+	return buf.Bytes()
+}
+
+func compress(codec int64, data []byte) []byte {
+	switch codec {
+	case codecSnappy:
+		return snappy.Encode(nil, data)
+	case codecGzip:
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(data)
+		zw.Close()
+		return buf.Bytes()
+	}
+	return data
+}
+
+func writePage(buf *bytes.Buffer, header []thriftField, data []byte) {
+	writeThriftStruct(buf, header)
+	buf.Write(data)
+}
+
+func statisticEncode(col column, v interface{}) []byte {
+	if col.typ == typeByteArray {
+		return []byte(v.(string))
+	}
+	return plainEncode(col, []interface{}{v})
+}
+
+// compareValues - returns the order of two non null values of a column.
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(int64); {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case float64:
+		return int(math.Copysign(1, a-b.(float64)))
+	case string:
+		return bytes.Compare([]byte(a), []byte(b.(string)))
+	case bool:
+		if a == b.(bool) {
+			return 0
+		}
+		if a {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,284 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// testFile - content of a parquet file to write, values of row groups
+// are given by column.
+type testFile struct {
+	columns    []column
+	rowGroups  [][][]interface{}
+	codec      int64
+	dictionary bool
+	dataPageV2 bool
+	// Whether the columns are children of a group.
+	nested bool
+}
+
+func (f testFile) writeChunk(buf *bytes.Buffer, col column, values []interface{}) []thriftField {
+	var levels []int
+	var nonNull []interface{}
+	var nullCount int64
+	var min, max interface{}
+	for _, v := range values {
+		if v == nil {
+			levels = append(levels, 0)
+			nullCount++
+			continue
+		}
+		levels = append(levels, 1)
+		nonNull = append(nonNull, v)
+		if min == nil || compareValues(v, min) < 0 {
+			min = v
+		}
+		if max == nil || compareValues(v, max) > 0 {
+			max = v
+		}
+	}
+
+	offset := int64(buf.Len())
+	dictionaryOffset := int64(-1)
+	encoding := int32(encodingPlain)
+	data := plainEncode(col, nonNull)
+	if f.dictionary {
+		var dictionary []interface{}
+		var indices []int
+		for _, v := range nonNull {
+			i := 0
+			for i < len(dictionary) && dictionary[i] != v {
+				i++
+			}
+			if i == len(dictionary) {
+				dictionary = append(dictionary, v)
+			}
+			indices = append(indices, i)
+		}
+		page := plainEncode(col, dictionary)
+		compressed := compress(f.codec, page)
+		dictionaryOffset = int64(buf.Len())
+		writePage(buf, []thriftField{
+			{1, int32(pageDictionary)},
+			{2, int32(len(page))},
+			{3, int32(len(compressed))},
+			{7, []thriftField{{1, int32(len(dictionary))}, {2, int32(encodingPlain)}}},
+		}, compressed)
+
+		width := bitWidth(len(dictionary) - 1)
+		data = append([]byte{byte(width)}, bitPack(indices, width)...)
+		encoding = encodingRLEDictionary
+	}
+
+	var definitionLevels []byte
+	if col.optional {
+		definitionLevels = bitPack(levels, 1)
+	}
+	dataOffset := int64(buf.Len())
+	if f.dataPageV2 {
+		compressed := compress(f.codec, data)
+		writePage(buf, []thriftField{
+			{1, int32(pageDataV2)},
+			{2, int32(len(definitionLevels) + len(data))},
+			{3, int32(len(definitionLevels) + len(compressed))},
+			{8, []thriftField{
+				{1, int32(len(values))},
+				{2, int32(nullCount)},
+				{3, int32(len(values))},
+				{4, encoding},
+				{5, int32(len(definitionLevels))},
+				{6, int32(0)},
+			}},
+		}, append(definitionLevels, compressed...))
+	} else {
+		page := data
+		if col.optional {
+			length := make([]byte, 4)
+			binary.LittleEndian.PutUint32(length, uint32(len(definitionLevels)))
+			page = append(append(length, definitionLevels...), data...)
+		}
+		compressed := compress(f.codec, page)
+		writePage(buf, []thriftField{
+			{1, int32(pageData)},
+			{2, int32(len(page))},
+			{3, int32(len(compressed))},
+			{5, []thriftField{
+				{1, int32(len(values))},
+				{2, encoding},
+				{3, int32(encodingRLE)},
+				{4, int32(encodingRLE)},
+			}},
+		}, compressed)
+	}
+
+	statistics := []thriftField{{3, nullCount}}
+	if min != nil {
+		statistics = append(statistics,
+			thriftField{5, string(statisticEncode(col, max))},
+			thriftField{6, string(statisticEncode(col, min))})
+	}
+	size := int64(buf.Len()) - offset
+	meta := []thriftField{
+		{1, int32(col.typ)},
+		{2, []interface{}{encoding}},
+		{3, []interface{}{col.name}},
+		{4, int32(f.codec)},
+		{5, int64(len(values))},
+		{6, size},
+		{7, size},
+		{9, dataOffset},
+	}
+	if dictionaryOffset >= 0 {
+		meta = append(meta, thriftField{11, dictionaryOffset})
+	}
+	meta = append(meta, thriftField{12, statistics})
+	return []thriftField{{2, offset}, {3, meta}}
+}
+
+// write - returns the content of the parquet file.
+func (f testFile) write() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(magic)
+
+	schema := []interface{}{[]thriftField{{4, "schema"}, {5, int32(len(f.columns))}}}
+	if f.nested {
+		schema = append(schema, []thriftField{{3, int32(repetitionRequired)}, {4, "group"}, {5, int32(len(f.columns))}})
+		schema[0] = []thriftField{{4, "schema"}, {5, int32(1)}}
+	}
+	for _, col := range f.columns {
+		repetition := int32(repetitionRequired)
+		if col.optional {
+			repetition = repetitionOptional
+		}
+		schema = append(schema, []thriftField{{1, int32(col.typ)}, {3, repetition}, {4, col.name}})
+	}
+
+	var numRows int64
+	var rowGroups []interface{}
+	for _, group := range f.rowGroups {
+		var chunks []interface{}
+		for i, col := range f.columns {
+			chunks = append(chunks, f.writeChunk(&buf, col, group[i]))
+		}
+		rows := int64(len(group[0]))
+		numRows += rows
+		rowGroups = append(rowGroups, []thriftField{{1, chunks}, {2, int64(0)}, {3, rows}})
+	}
+
+	var metadata bytes.Buffer
+	writeThriftStruct(&metadata, []thriftField{
+		{1, int32(1)},
+		{2, schema},
+		{3, numRows},
+		{4, rowGroups},
+	})
+	buf.Write(metadata.Bytes())
+	binary.Write(&buf, binary.LittleEndian, uint32(metadata.Len()))
+	buf.WriteString(magic)
+	return buf.Bytes()
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []Column{
+		{Name: "key", Type: StringColumn},
+		{Name: "size", Type: Int64Column, Optional: true},
+		{Name: "latest", Type: BooleanColumn},
+	})
+	if err := w.Write([]interface{}{"key", int64(1)}); err != ErrInvalidRow {
+		t.Fatalf("expected %v, got %v", ErrInvalidRow, err)
+	}
+	if err := w.Write([]interface{}{nil, int64(1), true}); err != ErrInvalidRow {
+		t.Fatalf("expected %v, got %v", ErrInvalidRow, err)
+	}
+	if err := w.Write([]interface{}{"key", 1, true}); err != ErrInvalidRow {
+		t.Fatalf("expected %v, got %v", ErrInvalidRow, err)
+	}
+
+	// The rows fill a row group and a half.
+	numRows := writerRowGroupSize * 3 / 2
+	var rows [][]interface{}
+	for i := 0; i < numRows; i++ {
+		row := []interface{}{fmt.Sprintf("object-%05d", i), int64(i), i%2 == 0}
+		if i%3 == 0 {
+			row[1] = nil
+		}
+		rows = append(rows, row)
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.Columns(), []string{"key", "size", "latest"}) {
+		t.Fatalf("unexpected columns %v", r.Columns())
+	}
+	if r.NumRowGroups() != 2 || r.NumRows(0) != writerRowGroupSize {
+		t.Fatalf("unexpected row groups %d of %d rows", r.NumRowGroups(), r.NumRows(0))
+	}
+	i := 0
+	for rowGroup := 0; rowGroup < r.NumRowGroups(); rowGroup++ {
+		var columns [][]interface{}
+		for col := range rows[0] {
+			values, err := r.ReadColumn(rowGroup, col)
+			if err != nil {
+				t.Fatal(err)
+			}
+			columns = append(columns, values)
+		}
+		for j := range columns[0] {
+			row := []interface{}{columns[0][j], columns[1][j], columns[2][j]}
+			if !reflect.DeepEqual(row, rows[i]) {
+				t.Fatalf("row %d: expected %v, got %v", i, rows[i], row)
+			}
+			i++
+		}
+	}
+	if i != numRows {
+		t.Fatalf("expected %d rows, got %d", numRows, i)
+	}
+
+	// Sizes of the second row group are 10000 to 14999, multiples of 3
+	// are null.
+	stats := r.Stats(1, 1)
+	if stats.Min != int64(10000) || stats.Max != int64(14999) || stats.NullCount != 1666 {
+		t.Fatalf("unexpected statistics %v", stats)
+	}
+
+	// Files without rows only have the metadata.
+	buf.Reset()
+	if err = NewWriter(&buf, []Column{{Name: "key", Type: StringColumn}}).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if r, err = NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len())); err != nil {
+		t.Fatal(err)
+	}
+	if r.NumRowGroups() != 0 {
+		t.Fatalf("expected no row groups, got %d", r.NumRowGroups())
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// Snappy compressed parquet file with statistics, of the required name
+// and city, and the optional age columns, in two row groups:
+//
+//	Alice,31,Lisbon
+//	Bob,25,Porto
+//	---
+//	Carol,40,Lisbon
+//	Dave,,Faro
+const testParquet = `
+UEFSMRUAFSAVJCwVBBUAFQYVBgAAEDwFAAAAQWxpY2UDAAAAQm9iFQAVLBUwLBUEFQAVBhUGAAAW
+VAIAAAADAx8AAAAAAAAAGQAAAAAAAAAVABUmFSosFQQVABUGFQYAABNIBgAAAExpc2JvbgUAAABQ
+b3J0bxUAFSIVJiwVBBUAFQYVBgAAEUAFAAAAQ2Fyb2wEAAAARGF2ZRUAFRwVICwVBBUAFQYVBgAA
+DjQCAAAAAwEoAAAAAAAAABUAFSQVKCwVBBUAFQYVBgAAEkQGAAAATGlzYm9uBAAAAEZhcm8VAhlM
+SAZzY2hlbWEVBgAVDCUAGARuYW1lABUEJQIYA2FnZQAVDCUAGARjaXR5ABYIGSwZPCYIHBUMGRUA
+GRgEbmFtZRUCFgQWRhZGJgg8NgAoA0JvYhgFQWxpY2UAAAAmThwVBBkVABkYA2FnZRUCFgQWUhZS
+Jk48NgAoCB8AAAAAAAAAGAgZAAAAAAAAAAAAACagARwVDBkVABkYBGNpdHkVAhYEFkwWTCagATw2
+ACgFUG9ydG8YBkxpc2JvbgAAABYAFgQAGTwm7AEcFQwZFQAZGARuYW1lFQIWBBZIFkgm7AE8NgAo
+BERhdmUYBUNhcm9sAAAAJrQCHBUEGRUAGRgDYWdlFQIWBBZCFkImtAI8NgIoCCgAAAAAAAAAGAgo
+AAAAAAAAAAAAACb2AhwVDBkVABkYBGNpdHkVAhYEFkoWSib2Ajw2ACgGTGlzYm9uGARGYXJvAAAA
+FgAWBAAAYAEAAFBBUjE=`
+
+func decodeTestParquet(t *testing.T) []byte {
+	data, err := base64.StdEncoding.DecodeString(strings.Replace(testParquet, "\n", "", -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestS3SelectEvaluateParquet(t *testing.T) {
+	data := decodeTestParquet(t)
+	testCases := []struct {
+		expression string
+		output     string
+		expected   string
+	}{
+		{"SELECT * FROM S3Object", `<CSV></CSV>`, "Alice,31,Lisbon\nBob,25,Porto\nCarol,40,Lisbon\nDave,,Faro\n"},
+		{"SELECT * FROM S3Object WHERE age IS NULL", `<JSON></JSON>`, `{"name":"Dave","age":null,"city":"Faro"}` + "\n"},
+		{"SELECT name FROM S3Object s WHERE s.age > 30", `<CSV></CSV>`, "Alice\nCarol\n"},
+		{"SELECT UPPER(name) FROM S3Object WHERE city = 'Lisbon' LIMIT 1", `<CSV></CSV>`, "ALICE\n"},
+		{"SELECT COUNT(*), MAX(age) FROM S3Object WHERE NAME != 'Dave'", `<CSV></CSV>`, "3,40\n"},
+		{"SELECT name FROM S3Object WHERE age > 50", `<CSV></CSV>`, ""},
+	}
+
+	for i, testCase := range testCases {
+		records, last := evaluate(t, newSelectRequest(testCase.expression, `<Parquet></Parquet>`, testCase.output), data)
+		if last.headers[":event-type"] != "End" {
+			t.Fatalf("case %v: unexpected last message %v: %s", i+1, last.headers, last.payload)
+		}
+		if records != testCase.expected {
+			t.Fatalf("case %v: expected %q, got %q", i+1, testCase.expected, records)
+		}
+	}
+}
+
+// TestS3SelectEvaluateParquetScanned - only the referenced columns of
+// row groups which may match are read.
+func TestS3SelectEvaluateParquetScanned(t *testing.T) {
+	data := decodeTestParquet(t)
+	bytesScanned := func(expression string) string {
+		s3Select, err := NewS3Select(strings.NewReader(newSelectRequest(expression, `<Parquet></Parquet>`, `<CSV></CSV>`)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err = s3Select.Evaluate(objectReader(data), int64(len(data)), &buf); err != nil {
+			t.Fatal(err)
+		}
+		messages := decodeMessages(t, buf.Bytes())
+		stats := messages[len(messages)-2].payload
+		return regexp.MustCompile(`<BytesScanned>(\d+)</BytesScanned>`).FindStringSubmatch(string(stats))[1]
+	}
+
+	// The footer of 360 bytes is read in any case, column chunks are
+	// about 35 bytes each.
+	testCases := []struct {
+		expression string
+		expected   string
+	}{
+		{"SELECT * FROM S3Object", "580"},
+		{"SELECT name FROM S3Object", "431"},
+		{"SELECT name FROM S3Object WHERE age > 35", "429"},
+		{"SELECT name FROM S3Object WHERE age > 50", "360"},
+	}
+	for i, testCase := range testCases {
+		if scanned := bytesScanned(testCase.expression); scanned != testCase.expected {
+			t.Fatalf("case %v: expected %v bytes scanned, got %v", i+1, testCase.expected, scanned)
+		}
+	}
+}
+
+func TestS3SelectEvaluateParquetError(t *testing.T) {
+	data := decodeTestParquet(t)
+	testCases := []struct {
+		data         []byte
+		expectedCode string
+	}{
+		{[]byte(testCSV), "ParquetParsingError"},
+		{data[:len(data)-8], "ParquetParsingError"},
+		{data[100:], "ParquetParsingError"},
+	}
+
+	for i, testCase := range testCases {
+		_, last := evaluate(t, newSelectRequest("SELECT * FROM S3Object", `<Parquet></Parquet>`, `<CSV></CSV>`), testCase.data)
+		if last.headers[":message-type"] != "error" || last.headers[":error-code"] != testCase.expectedCode {
+			t.Fatalf("case %v: expected error %v, got %v", i+1, testCase.expectedCode, last.headers)
+		}
+	}
+
+	// Failures reading the object are internal errors.
+	s3Select, err := NewS3Select(strings.NewReader(newSelectRequest("SELECT * FROM S3Object", `<Parquet></Parquet>`, `<CSV></CSV>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	getObject := func(offset, length int64) (io.ReadCloser, error) {
+		return nil, io.ErrClosedPipe
+	}
+	if err = s3Select.Evaluate(getObject, int64(len(data)), &buf); err != io.ErrClosedPipe {
+		t.Fatalf("expected error %v, got %v", io.ErrClosedPipe, err)
+	}
+}
@@ -0,0 +1,452 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package s3select implements the SelectObjectContent API, which
+// filters the content of CSV, JSON and Parquet objects by a SQL
+// expression and streams the matching records to the client.
+package s3select
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+// Records are sent in messages of at most this size, except for
+// records larger than it.
+const maxRecordsMessageSize = 128 * 1024
+
+// Values of CompressionType.
+const (
+	compressionNone  = "NONE"
+	compressionGZIP  = "GZIP"
+	compressionBZIP2 = "BZIP2"
+)
+
+// CSVInput - CSV input serialization of a select request.
+type CSVInput struct {
+	FileHeaderInfo             string `xml:"FileHeaderInfo"`
+	Comments                   string `xml:"Comments"`
+	QuoteEscapeCharacter       string `xml:"QuoteEscapeCharacter"`
+	RecordDelimiter            string `xml:"RecordDelimiter"`
+	FieldDelimiter             string `xml:"FieldDelimiter"`
+	QuoteCharacter             string `xml:"QuoteCharacter"`
+	AllowQuotedRecordDelimiter bool   `xml:"AllowQuotedRecordDelimiter"`
+}
+
+// JSONInput - JSON input serialization of a select request.
+type JSONInput struct {
+	Type string `xml:"Type"`
+}
+
+// ParquetInput - Parquet input serialization of a select request, it
+// has no parameters.
+type ParquetInput struct{}
+
+// InputSerialization - format of the object.
+type InputSerialization struct {
+	CompressionType string        `xml:"CompressionType"`
+	CSV             *CSVInput     `xml:"CSV"`
+	JSON            *JSONInput    `xml:"JSON"`
+	Parquet         *ParquetInput `xml:"Parquet"`
+}
+
+// CSVOutput - CSV output serialization of a select request.
+type CSVOutput struct {
+	QuoteFields          string `xml:"QuoteFields"`
+	QuoteEscapeCharacter string `xml:"QuoteEscapeCharacter"`
+	RecordDelimiter      string `xml:"RecordDelimiter"`
+	FieldDelimiter       string `xml:"FieldDelimiter"`
+	QuoteCharacter       string `xml:"QuoteCharacter"`
+}
+
+// JSONOutput - JSON output serialization of a select request.
+type JSONOutput struct {
+	RecordDelimiter string `xml:"RecordDelimiter"`
+}
+
+// OutputSerialization - format of the returned records, which are
+// compressed with CompressionType.
+type OutputSerialization struct {
+	CompressionType string      `xml:"CompressionType"`
+	CSV             *CSVOutput  `xml:"CSV"`
+	JSON            *JSONOutput `xml:"JSON"`
+}
+
+// RequestProgress - whether progress messages are sent.
+type RequestProgress struct {
+	Enabled bool `xml:"Enabled"`
+}
+
+// S3Select - select request, the body of SelectObjectContent.
+type S3Select struct {
+	XMLName             xml.Name            `xml:"SelectObjectContentRequest"`
+	Expression          string              `xml:"Expression"`
+	ExpressionType      string              `xml:"ExpressionType"`
+	InputSerialization  InputSerialization  `xml:"InputSerialization"`
+	OutputSerialization OutputSerialization `xml:"OutputSerialization"`
+	RequestProgress     RequestProgress     `xml:"RequestProgress"`
+
+	statement *sql.Select
+}
+
+// ObjectReader - returns a reader of length bytes of the object
+// starting at offset.
+type ObjectReader func(offset, length int64) (io.ReadCloser, error)
+
+// recordReader - reads the records of the object, returns io.EOF after
+// the last record.
+type recordReader interface {
+	Read() (sql.Record, error)
+}
+
+// recordWriter - serializes a record to buf.
+type recordWriter interface {
+	Write(buf *bytes.Buffer, obj sql.Object) error
+}
+
+// NewS3Select - parses and validates a select request, errors are of
+// type SelectError.
+func NewS3Select(r io.Reader) (*S3Select, error) {
+	s3Select := &S3Select{}
+	if err := xml.NewDecoder(r).Decode(s3Select); err != nil {
+		return nil, errMalformedXML(err)
+	}
+	if err := s3Select.validate(); err != nil {
+		return nil, err
+	}
+
+	statement, err := sql.ParseSelect(s3Select.Expression)
+	if err != nil {
+		return nil, err
+	}
+	s3Select.statement = statement
+	return s3Select, nil
+}
+
+// isDelimiter - returns true if s has between 1 and n runes.
+func isDelimiter(s string, n int) bool {
+	count := utf8.RuneCountInString(s)
+	return count >= 1 && count <= n
+}
+
+func defaultString(s *string, value string) {
+	if *s == "" {
+		*s = value
+	}
+}
+
+// validate - validates the request and sets defaults of the
+// serializations.
+func (s3Select *S3Select) validate() error {
+	if s3Select.Expression == "" {
+		return errMissingRequiredParameter("Expression")
+	}
+	if s3Select.ExpressionType != "SQL" {
+		return errInvalidExpressionType()
+	}
+
+	input := &s3Select.InputSerialization
+	input.CompressionType = strings.ToUpper(input.CompressionType)
+	switch input.CompressionType {
+	case "":
+		input.CompressionType = compressionNone
+	case compressionNone, compressionGZIP, compressionBZIP2:
+	default:
+		return errInvalidCompressionFormat()
+	}
+
+	formats := 0
+	for _, format := range []bool{input.CSV != nil, input.JSON != nil, input.Parquet != nil} {
+		if format {
+			formats++
+		}
+	}
+	if formats != 1 {
+		return errInvalidDataSource()
+	}
+
+	switch {
+	case input.CSV != nil:
+		csvInput := input.CSV
+		csvInput.FileHeaderInfo = strings.ToUpper(csvInput.FileHeaderInfo)
+		defaultString(&csvInput.FileHeaderInfo, fileHeaderNone)
+		defaultString(&csvInput.RecordDelimiter, "\n")
+		defaultString(&csvInput.FieldDelimiter, ",")
+		defaultString(&csvInput.QuoteCharacter, `"`)
+		defaultString(&csvInput.QuoteEscapeCharacter, `"`)
+		defaultString(&csvInput.Comments, "#")
+		switch csvInput.FileHeaderInfo {
+		case fileHeaderUse, fileHeaderIgnore, fileHeaderNone:
+		default:
+			return errInvalidFileHeaderInfo()
+		}
+		if !isDelimiter(csvInput.RecordDelimiter, 2) || !isDelimiter(csvInput.FieldDelimiter, 1) ||
+			!isDelimiter(csvInput.QuoteCharacter, 1) || !isDelimiter(csvInput.QuoteEscapeCharacter, 1) ||
+			!isDelimiter(csvInput.Comments, 1) {
+			return errInvalidRequestParameter("The CSV delimiters, quote, quote escape and comment characters must be single characters, the record delimiter at most two.")
+		}
+	case input.JSON != nil:
+		input.JSON.Type = strings.ToUpper(input.JSON.Type)
+		defaultString(&input.JSON.Type, jsonTypeDocument)
+		switch input.JSON.Type {
+		case jsonTypeDocument, jsonTypeLines:
+		default:
+			return errInvalidJSONType()
+		}
+	case input.Parquet != nil:
+		// Pages of Parquet objects are compressed by their own codecs.
+		if input.CompressionType != compressionNone {
+			return errInvalidCompressionFormat()
+		}
+	}
+
+	output := &s3Select.OutputSerialization
+	output.CompressionType = strings.ToUpper(output.CompressionType)
+	switch output.CompressionType {
+	case "":
+		output.CompressionType = compressionNone
+	case compressionNone, compressionGZIP:
+	default:
+		return errInvalidRequestParameter("The OutputSerialization CompressionType is invalid. Only NONE and GZIP are supported.")
+	}
+	switch {
+	case output.CSV != nil && output.JSON == nil:
+		csvOutput := output.CSV
+		csvOutput.QuoteFields = strings.ToUpper(csvOutput.QuoteFields)
+		defaultString(&csvOutput.QuoteFields, quoteFieldsAsNeeded)
+		defaultString(&csvOutput.RecordDelimiter, "\n")
+		defaultString(&csvOutput.FieldDelimiter, ",")
+		defaultString(&csvOutput.QuoteCharacter, `"`)
+		defaultString(&csvOutput.QuoteEscapeCharacter, `"`)
+		switch csvOutput.QuoteFields {
+		case quoteFieldsAlways, quoteFieldsAsNeeded:
+		default:
+			return errInvalidQuoteFields()
+		}
+	case output.JSON != nil && output.CSV == nil:
+		defaultString(&output.JSON.RecordDelimiter, "\n")
+	default:
+		return errInvalidRequestParameter("The OutputSerialization must have exactly one of CSV and JSON.")
+	}
+	return nil
+}
+
+// countingReader - counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+func (s3Select *S3Select) newRecordReader(r io.Reader) (recordReader, error) {
+	if s3Select.InputSerialization.CSV != nil {
+		return newCSVReader(r, s3Select.InputSerialization.CSV)
+	}
+	return newJSONReader(r, s3Select.InputSerialization.JSON), nil
+}
+
+func (s3Select *S3Select) newRecordWriter() recordWriter {
+	if s3Select.OutputSerialization.CSV != nil {
+		return newCSVWriter(s3Select.OutputSerialization.CSV)
+	}
+	return newJSONWriter(s3Select.OutputSerialization.JSON)
+}
+
+// Evaluate - evaluates the request on the content of the object of the
+// given size, writes the matching records followed by the statistics
+// of the request to w as messages of an event stream. Errors are
+// written to w as error messages, the returned error is the error
+// processing the request or writing to w.
+func (s3Select *S3Select) Evaluate(getObject ObjectReader, size int64, w io.Writer) error {
+	mw := &messageWriter{w: w}
+	scanned := &countingReader{}
+	processed := scanned
+
+	var records recordReader
+	if s3Select.InputSerialization.Parquet != nil {
+		var err error
+		if records, err = newParquetReader(&objectReaderAt{getObject: getObject, size: size, counter: scanned}, size, s3Select.statement); err != nil {
+			return s3Select.writeError(mw, err)
+		}
+	} else {
+		rc, err := getObject(0, size)
+		if err != nil {
+			return s3Select.writeError(mw, err)
+		}
+		defer rc.Close()
+		scanned.r = rc
+
+		var reader io.Reader = scanned
+		switch s3Select.InputSerialization.CompressionType {
+		case compressionGZIP:
+			if reader, err = gzip.NewReader(scanned); err != nil {
+				return s3Select.writeError(mw, errInvalidCompressionFormat())
+			}
+			processed = &countingReader{r: reader}
+		case compressionBZIP2:
+			processed = &countingReader{r: bzip2.NewReader(scanned)}
+		}
+
+		if records, err = s3Select.newRecordReader(processed); err != nil {
+			return s3Select.writeError(mw, err)
+		}
+	}
+
+	var err error
+	var bytesReturned int64
+	var buf bytes.Buffer
+	writeRecords := func(data []byte) error {
+		bytesReturned += int64(len(data))
+		return mw.write(newRecordsMessage(data))
+	}
+
+	// Compressed records are a single stream across the messages, the
+	// compressor is flushed for every message and closed once all the
+	// records are written.
+	var compressed bytes.Buffer
+	var gzipWriter *gzip.Writer
+	if s3Select.OutputSerialization.CompressionType == compressionGZIP {
+		gzipWriter = gzip.NewWriter(&compressed)
+	}
+
+	flush := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+		payload := &buf
+		if gzipWriter != nil {
+			if _, err := buf.WriteTo(gzipWriter); err != nil {
+				return err
+			}
+			if err := gzipWriter.Flush(); err != nil {
+				return err
+			}
+			payload = &compressed
+		}
+		if err := writeRecords(payload.Bytes()); err != nil {
+			return err
+		}
+		payload.Reset()
+		if s3Select.RequestProgress.Enabled {
+			return mw.write(newProgressMessage(scanned.n, processed.n, bytesReturned))
+		}
+		return nil
+	}
+
+	if err = s3Select.process(records, &buf, flush); err != nil {
+		return s3Select.writeError(mw, err)
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+	if gzipWriter != nil {
+		if err = gzipWriter.Close(); err != nil {
+			return err
+		}
+		if err = writeRecords(compressed.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err = mw.write(newStatsMessage(scanned.n, processed.n, bytesReturned)); err != nil {
+		return err
+	}
+	return mw.write(newEndMessage())
+}
+
+// process - writes the matching records to buf, calling flush whenever
+// it grows beyond the maximum message size.
+func (s3Select *S3Select) process(records recordReader, buf *bytes.Buffer, flush func() error) error {
+	statement := s3Select.statement
+	writer := s3Select.newRecordWriter()
+	limit := statement.Limit()
+
+	var count int64
+	for limit < 0 || count < limit || statement.IsAggregate() {
+		record, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		matches, err := statement.Filter(record)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			continue
+		}
+
+		if statement.IsAggregate() {
+			if err = statement.Aggregate(record); err != nil {
+				return err
+			}
+			continue
+		}
+
+		obj, err := statement.Eval(record)
+		if err != nil {
+			return err
+		}
+		if err = writer.Write(buf, obj); err != nil {
+			return err
+		}
+		count++
+		if buf.Len() >= maxRecordsMessageSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if statement.IsAggregate() && limit != 0 {
+		obj, err := statement.AggregateResult()
+		if err != nil {
+			return err
+		}
+		return writer.Write(buf, obj)
+	}
+	return nil
+}
+
+// writeError - writes an error message for err. Errors of the client
+// connection are returned as they are.
+func (s3Select *S3Select) writeError(mw *messageWriter, err error) error {
+	if mw.err != nil {
+		return mw.err
+	}
+	selectErr, ok := err.(SelectError)
+	if !ok {
+		selectErr = errInternalError(err)
+	}
+	if werr := mw.write(newErrorMessage(selectErr.ErrorCode(), selectErr.ErrorMessage())); werr != nil {
+		return werr
+	}
+	return err
+}
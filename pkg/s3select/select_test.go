@@ -0,0 +1,266 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+const testCSV = `name,age,city
+# comment
+Alice,31,Lisbon
+Bob,25,"Porto, PT"
+"Carol ""C""",40,Lisbon
+`
+
+const testJSON = `{"name": "Alice", "age": 31, "address": {"city": "Lisbon"}}
+{"name": "Bob", "age": 25, "address": {"city": "Porto"}}
+{"name": "Carol", "age": 40.5, "tags": ["a", "b"]}
+`
+
+func newSelectRequest(expression, input, output string) string {
+	return `<SelectObjectContentRequest><Expression>` + expression + `</Expression><ExpressionType>SQL</ExpressionType>` +
+		`<InputSerialization>` + input + `</InputSerialization><OutputSerialization>` + output + `</OutputSerialization>` +
+		`<RequestProgress><Enabled>true</Enabled></RequestProgress></SelectObjectContentRequest>`
+}
+
+type testMessage struct {
+	headers map[string]string
+	payload []byte
+}
+
+// decodeMessages - decodes and verifies the messages of an event stream.
+func decodeMessages(t *testing.T, data []byte) []testMessage {
+	var messages []testMessage
+	for len(data) > 0 {
+		totalLength := binary.BigEndian.Uint32(data[0:4])
+		headersLength := binary.BigEndian.Uint32(data[4:8])
+		if crc32.ChecksumIEEE(data[0:8]) != binary.BigEndian.Uint32(data[8:12]) {
+			t.Fatal("prelude CRC mismatch")
+		}
+		message := data[:totalLength]
+		if crc32.ChecksumIEEE(message[:totalLength-4]) != binary.BigEndian.Uint32(message[totalLength-4:]) {
+			t.Fatal("message CRC mismatch")
+		}
+
+		headers := map[string]string{}
+		h := message[12 : 12+headersLength]
+		for len(h) > 0 {
+			nameLength := int(h[0])
+			name := string(h[1 : 1+nameLength])
+			if h[1+nameLength] != headerValueTypeString {
+				t.Fatalf("unexpected header value type %d", h[1+nameLength])
+			}
+			valueLength := int(binary.BigEndian.Uint16(h[2+nameLength:]))
+			headers[name] = string(h[4+nameLength : 4+nameLength+valueLength])
+			h = h[4+nameLength+valueLength:]
+		}
+
+		messages = append(messages, testMessage{headers, message[12+headersLength : totalLength-4]})
+		data = data[totalLength:]
+	}
+	return messages
+}
+
+// objectReader - returns an object reader of data.
+func objectReader(data []byte) ObjectReader {
+	return func(offset, length int64) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	}
+}
+
+// evaluate - returns the records returned by the request and the last
+// message of the stream.
+func evaluate(t *testing.T, request string, input []byte) (string, testMessage) {
+	s3Select, err := NewS3Select(strings.NewReader(request))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	s3Select.Evaluate(objectReader(input), int64(len(input)), &buf)
+
+	var records string
+	messages := decodeMessages(t, buf.Bytes())
+	for _, message := range messages {
+		if message.headers[":event-type"] == "Records" {
+			records += string(message.payload)
+		}
+	}
+	return records, messages[len(messages)-1]
+}
+
+func TestNewS3Select(t *testing.T) {
+	csvInput, csvOutput := `<CSV></CSV>`, `<CSV></CSV>`
+	testCases := []struct {
+		request      string
+		expectedCode string
+	}{
+		{newSelectRequest("SELECT * FROM S3Object", csvInput, csvOutput), ""},
+		{newSelectRequest("SELECT * FROM S3Object", `<CompressionType>GZIP</CompressionType><JSON><Type>LINES</Type></JSON>`, `<JSON></JSON>`), ""},
+		{newSelectRequest("SELECT * FROM S3Object", `<Parquet></Parquet>`, csvOutput), ""},
+		{newSelectRequest("SELECT * FROM S3Object", csvInput, `<CompressionType>gzip</CompressionType><JSON></JSON>`), ""},
+		{`<SelectObjectContentRequest>`, "MalformedXML"},
+		{newSelectRequest("", csvInput, csvOutput), "MissingRequiredParameter"},
+		{strings.Replace(newSelectRequest("SELECT * FROM S3Object", csvInput, csvOutput), ">SQL<", ">XPATH<", 1), "InvalidExpressionType"},
+		{newSelectRequest("SELECT * FROM S3Object", `<CompressionType>ZIP</CompressionType>`+csvInput, csvOutput), "InvalidCompressionFormat"},
+		{newSelectRequest("SELECT * FROM S3Object", ``, csvOutput), "InvalidDataSource"},
+		{newSelectRequest("SELECT * FROM S3Object", csvInput+`<JSON></JSON>`, csvOutput), "InvalidDataSource"},
+		{newSelectRequest("SELECT * FROM S3Object", `<JSON></JSON><Parquet></Parquet>`, csvOutput), "InvalidDataSource"},
+		{newSelectRequest("SELECT * FROM S3Object", `<CompressionType>GZIP</CompressionType><Parquet></Parquet>`, csvOutput), "InvalidCompressionFormat"},
+		{newSelectRequest("SELECT * FROM S3Object", `<CSV><FileHeaderInfo>FIRST</FileHeaderInfo></CSV>`, csvOutput), "InvalidFileHeaderInfo"},
+		{newSelectRequest("SELECT * FROM S3Object", `<CSV><FieldDelimiter>::</FieldDelimiter></CSV>`, csvOutput), "InvalidRequestParameter"},
+		{newSelectRequest("SELECT * FROM S3Object", `<JSON><Type>ARRAY</Type></JSON>`, csvOutput), "InvalidJsonType"},
+		{newSelectRequest("SELECT * FROM S3Object", csvInput, `<CSV><QuoteFields>NEVER</QuoteFields></CSV>`), "InvalidQuoteFields"},
+		{newSelectRequest("SELECT * FROM S3Object", csvInput, ``), "InvalidRequestParameter"},
+		{newSelectRequest("SELECT * FROM S3Object", csvInput, `<CompressionType>BZIP2</CompressionType><CSV></CSV>`), "InvalidRequestParameter"},
+		{newSelectRequest("SELECT * FROM", csvInput, csvOutput), "ParseUnexpectedToken"},
+	}
+
+	for i, testCase := range testCases {
+		_, err := NewS3Select(strings.NewReader(testCase.request))
+		if testCase.expectedCode == "" {
+			if err != nil {
+				t.Fatalf("case %v: unexpected error: %v", i+1, err)
+			}
+			continue
+		}
+		if serr, ok := err.(SelectError); !ok || serr.ErrorCode() != testCase.expectedCode {
+			t.Fatalf("case %v: expected error code %v, got %v", i+1, testCase.expectedCode, err)
+		}
+	}
+}
+
+func TestS3SelectEvaluate(t *testing.T) {
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write([]byte(testCSV))
+	gw.Close()
+
+	testCases := []struct {
+		expression string
+		input      string
+		output     string
+		data       []byte
+		expected   string
+	}{
+		{"SELECT * FROM S3Object", `<CSV><FileHeaderInfo>IGNORE</FileHeaderInfo></CSV>`, `<CSV></CSV>`, []byte(testCSV),
+			"Alice,31,Lisbon\nBob,25,\"Porto, PT\"\n\"Carol \"\"C\"\"\",40,Lisbon\n"},
+		{"SELECT s.name, s.age FROM S3Object s WHERE s.city = 'Lisbon' AND CAST(s.age AS INT) > 35", `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`, `<JSON></JSON>`, []byte(testCSV),
+			`{"name":"Carol \"C\"","age":"40"}` + "\n"},
+		{"SELECT _1 FROM S3Object LIMIT 2", `<CSV></CSV>`, `<CSV><QuoteFields>ALWAYS</QuoteFields><RecordDelimiter>;</RecordDelimiter></CSV>`, []byte(testCSV),
+			`"name";"Alice";`},
+		{"SELECT COUNT(*), SUM(CAST(age AS INT)) FROM S3Object", `<CompressionType>GZIP</CompressionType><CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`, `<CSV></CSV>`, gzipped.Bytes(),
+			"3,96\n"},
+		{"SELECT name FROM S3Object WHERE city LIKE 'P%'", `<CSV><FileHeaderInfo>USE</FileHeaderInfo><RecordDelimiter>|</RecordDelimiter><FieldDelimiter>;</FieldDelimiter><QuoteCharacter>'</QuoteCharacter><QuoteEscapeCharacter>\</QuoteEscapeCharacter></CSV>`, `<CSV></CSV>`,
+			[]byte(`name;city|'Bob \'B\'';Porto|Alice;Lisbon`), "Bob 'B'\n"},
+		{"SELECT * FROM S3Object[*] s WHERE s.address.city = 'Lisbon' OR s.tags[1] = 'b'", `<JSON><Type>LINES</Type></JSON>`, `<JSON></JSON>`, []byte(testJSON),
+			`{"name":"Alice","age":31,"address":{"city":"Lisbon"}}` + "\n" + `{"name":"Carol","age":40.5,"tags":["a","b"]}` + "\n"},
+		{"SELECT name, age * 2 AS double FROM S3Object WHERE age > 30", `<JSON><Type>DOCUMENT</Type></JSON>`, `<CSV></CSV>`, []byte(`[{"name": "Alice", "age": 31}, {"name": "Bob", "age": 25}]`),
+			"Alice,62\n"},
+		{"SELECT MAX(age), AVG(age) FROM S3Object", `<JSON><Type>LINES</Type></JSON>`, `<JSON><RecordDelimiter>,</RecordDelimiter></JSON>`, []byte(testJSON),
+			`{"_1":40.5,"_2":32.166666666666664},`},
+	}
+
+	for i, testCase := range testCases {
+		records, last := evaluate(t, newSelectRequest(testCase.expression, testCase.input, testCase.output), testCase.data)
+		if last.headers[":event-type"] != "End" {
+			t.Fatalf("case %v: expected End message, got %v", i+1, last.headers)
+		}
+		if records != testCase.expected {
+			t.Fatalf("case %v: expected %q, got %q", i+1, testCase.expected, records)
+		}
+	}
+}
+
+func TestS3SelectEvaluateStats(t *testing.T) {
+	s3Select, err := NewS3Select(strings.NewReader(newSelectRequest("SELECT name FROM S3Object", `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`, `<CSV></CSV>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = s3Select.Evaluate(objectReader([]byte(testCSV)), int64(len(testCSV)), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var eventTypes []string
+	var stats string
+	for _, message := range decodeMessages(t, buf.Bytes()) {
+		eventTypes = append(eventTypes, message.headers[":event-type"])
+		if message.headers[":event-type"] == "Stats" {
+			stats = string(message.payload)
+		}
+	}
+	if strings.Join(eventTypes, ",") != "Records,Progress,Stats,End" {
+		t.Fatalf("unexpected messages %v", eventTypes)
+	}
+	expected := `<Stats><BytesScanned>83</BytesScanned><BytesProcessed>83</BytesProcessed><BytesReturned>24</BytesReturned></Stats>`
+	if !strings.HasSuffix(stats, expected) {
+		t.Fatalf("expected stats %s, got %s", expected, stats)
+	}
+}
+
+func TestS3SelectEvaluateGzipOutput(t *testing.T) {
+	rows := bytes.Repeat([]byte("name,age,city\n"), 2*maxRecordsMessageSize/14)
+	request := newSelectRequest("SELECT * FROM S3Object", `<CSV></CSV>`, `<CompressionType>GZIP</CompressionType><CSV></CSV>`)
+	records, last := evaluate(t, request, rows)
+	if last.headers[":event-type"] != "End" {
+		t.Fatalf("expected End message, got %v", last.headers)
+	}
+
+	gr, err := gzip.NewReader(strings.NewReader(records))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, rows) {
+		t.Fatalf("expected %d bytes of records, got %d", len(rows), len(data))
+	}
+	if len(records) >= len(rows) {
+		t.Fatalf("expected the records to be compressed, got %d bytes", len(records))
+	}
+}
+
+func TestS3SelectEvaluateError(t *testing.T) {
+	testCases := []struct {
+		expression   string
+		input        string
+		data         string
+		expectedCode string
+	}{
+		{"SELECT CAST(name AS INT) FROM S3Object", `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`, testCSV, "CastFailed"},
+		{"SELECT * FROM S3Object", `<CSV></CSV>`, `"unterminated`, "CSVParsingError"},
+		{"SELECT * FROM S3Object", `<JSON><Type>LINES</Type></JSON>`, `{"name": }`, "JSONParsingError"},
+		{"SELECT * FROM S3Object", `<CompressionType>GZIP</CompressionType><CSV></CSV>`, testCSV, "InvalidCompressionFormat"},
+	}
+
+	for i, testCase := range testCases {
+		_, last := evaluate(t, newSelectRequest(testCase.expression, testCase.input, `<CSV></CSV>`), []byte(testCase.data))
+		if last.headers[":message-type"] != "error" || last.headers[":error-code"] != testCase.expectedCode {
+			t.Fatalf("case %v: expected error %v, got %v", i+1, testCase.expectedCode, last.headers)
+		}
+	}
+}
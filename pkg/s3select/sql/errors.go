@@ -0,0 +1,83 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Error - error parsing or evaluating a SQL expression, reported with
+// its S3 error code.
+type Error struct {
+	code    string
+	message string
+}
+
+func (err *Error) Error() string {
+	return err.message
+}
+
+// ErrorCode - returns the S3 error code.
+func (err *Error) ErrorCode() string {
+	return err.code
+}
+
+// ErrorMessage - returns the S3 error message.
+func (err *Error) ErrorMessage() string {
+	return err.message
+}
+
+// HTTPStatusCode - returns the HTTP status code of the error.
+func (err *Error) HTTPStatusCode() int {
+	return http.StatusBadRequest
+}
+
+func errLexer(pos int, message string) *Error {
+	return &Error{"LexerInvalidChar", fmt.Sprintf("Invalid character at position %d: %s.", pos+1, message)}
+}
+
+func errUnexpectedToken(tok token) *Error {
+	if tok.typ == tokenEOF {
+		return &Error{"ParseUnexpectedToken", "Unexpected end of the SQL expression."}
+	}
+	return &Error{"ParseUnexpectedToken", fmt.Sprintf("Unexpected token '%s' at position %d.", tok.value, tok.pos+1)}
+}
+
+func errUnsupportedSyntax(message string) *Error {
+	return &Error{"ParseUnsupportedSyntax", message}
+}
+
+func errUnsupportedFunction(name string) *Error {
+	return &Error{"UnsupportedFunction", fmt.Sprintf("Function %s is not supported.", name)}
+}
+
+func errInvalidArguments(message string) *Error {
+	return &Error{"EvaluatorInvalidArguments", message}
+}
+
+func errCastFailed(value interface{}, typ string) *Error {
+	return &Error{"CastFailed", fmt.Sprintf("Value %v can not be cast to %s.", value, typ)}
+}
+
+func errInvalidColumnIndex(name string) *Error {
+	return &Error{"InvalidColumnIndex", fmt.Sprintf("The column index %s is invalid.", name)}
+}
+
+func errUnsupportedSQLOperation(message string) *Error {
+	return &Error{"UnsupportedSqlOperation", message}
+}
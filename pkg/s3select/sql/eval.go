@@ -0,0 +1,570 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// expr - expression evaluated on a record.
+type expr interface {
+	eval(r Record) (interface{}, error)
+}
+
+type literalExpr struct {
+	value interface{}
+}
+
+func (e *literalExpr) eval(r Record) (interface{}, error) {
+	return e.value, nil
+}
+
+// pathElement - name of a field or index of an array, index is -1 for
+// names.
+type pathElement struct {
+	name   string
+	quoted bool
+	index  int
+}
+
+type columnExpr struct {
+	path []pathElement
+}
+
+func (e *columnExpr) eval(r Record) (interface{}, error) {
+	first := e.path[0]
+	if first.index >= 0 {
+		return nil, errInvalidColumnIndex("[" + strconv.Itoa(first.index) + "]")
+	}
+	value, ok := r.Get(first.name, first.quoted)
+	if !ok {
+		// Columns are referenced by position as _1, _2 ...
+		if isPositional(first.name) {
+			index, _ := strconv.Atoi(first.name[1:])
+			if obj := r.Object(); index < 1 || index > len(obj) {
+				return nil, errInvalidColumnIndex(first.name)
+			}
+			value = r.Object()[index-1].Value
+		}
+	}
+
+	// Missing fields of nested values are null.
+	for _, elem := range e.path[1:] {
+		switch v := value.(type) {
+		case Object:
+			value, _ = v.Get(elem.name, elem.quoted)
+			if elem.index >= 0 {
+				value = nil
+			}
+		case []interface{}:
+			value = nil
+			if elem.index >= 0 && elem.index < len(v) {
+				value = v[elem.index]
+			}
+		default:
+			value = nil
+		}
+	}
+	return value, nil
+}
+
+// name - returns the output name of the column, the last name of its
+// path.
+func (e *columnExpr) name() string {
+	for i := len(e.path) - 1; i >= 0; i-- {
+		if e.path[i].index < 0 {
+			return e.path[i].name
+		}
+	}
+	return ""
+}
+
+type unaryExpr struct {
+	op      string
+	operand expr
+}
+
+func (e *unaryExpr) eval(r Record) (interface{}, error) {
+	v, err := e.operand.eval(r)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	if e.op == "NOT" {
+		b, err := toBool(v)
+		if err != nil || b == nil {
+			return nil, err
+		}
+		return !b.(bool), nil
+	}
+
+	n, ok := toNumber(v)
+	if !ok {
+		return nil, errInvalidArguments("Expected a number, got " + FormatValue(v) + ".")
+	}
+	if i, ok := n.(int64); ok {
+		return -i, nil
+	}
+	return -n.(float64), nil
+}
+
+type binaryExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e *binaryExpr) eval(r Record) (interface{}, error) {
+	if e.op == "AND" || e.op == "OR" {
+		return e.evalLogical(r)
+	}
+
+	a, err := e.left.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	b, err := e.right.eval(r)
+	if err != nil || a == nil || b == nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "||":
+		return FormatValue(a) + FormatValue(b), nil
+	case "+", "-", "*", "/", "%":
+		return arithmetic(e.op, a, b)
+	}
+
+	result, ok := compare(a, b)
+	if !ok {
+		// Values of different types are never equal.
+		switch e.op {
+		case "=":
+			return false, nil
+		case "!=":
+			return true, nil
+		}
+		return nil, errInvalidArguments("Can not compare " + FormatValue(a) + " and " + FormatValue(b) + ".")
+	}
+	switch e.op {
+	case "=":
+		return result == 0, nil
+	case "!=":
+		return result != 0, nil
+	case "<":
+		return result < 0, nil
+	case "<=":
+		return result <= 0, nil
+	case ">":
+		return result > 0, nil
+	}
+	return result >= 0, nil
+}
+
+// evalLogical - evaluates AND and OR with three valued logic, null is
+// unknown.
+func (e *binaryExpr) evalLogical(r Record) (interface{}, error) {
+	a, err := e.left.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	if a, err = toBool(a); err != nil {
+		return nil, err
+	}
+	// Short circuit if the result is known.
+	if a != nil && a.(bool) == (e.op == "OR") {
+		return a, nil
+	}
+
+	b, err := e.right.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	if b, err = toBool(b); err != nil {
+		return nil, err
+	}
+	if b != nil && b.(bool) == (e.op == "OR") {
+		return b, nil
+	}
+	if a == nil || b == nil {
+		return nil, nil
+	}
+	return a.(bool) && b.(bool) || a.(bool) && e.op == "OR", nil
+}
+
+func arithmetic(op string, a, b interface{}) (interface{}, error) {
+	an, aok := toNumber(a)
+	bn, bok := toNumber(b)
+	if !aok || !bok {
+		return nil, errInvalidArguments("Expected numbers, got " + FormatValue(a) + " and " + FormatValue(b) + ".")
+	}
+
+	ai, aIsInt := an.(int64)
+	bi, bIsInt := bn.(int64)
+	if aIsInt && bIsInt {
+		switch op {
+		case "+":
+			return ai + bi, nil
+		case "-":
+			return ai - bi, nil
+		case "*":
+			return ai * bi, nil
+		}
+		if bi == 0 {
+			return nil, errInvalidArguments("Division by zero.")
+		}
+		if op == "/" {
+			return ai / bi, nil
+		}
+		return ai % bi, nil
+	}
+
+	af, bf := toFloat(an), toFloat(bn)
+	switch op {
+	case "+":
+		return af + bf, nil
+	case "-":
+		return af - bf, nil
+	case "*":
+		return af * bf, nil
+	}
+	if bf == 0 {
+		return nil, errInvalidArguments("Division by zero.")
+	}
+	if op == "/" {
+		return af / bf, nil
+	}
+	return math.Mod(af, bf), nil
+}
+
+type isNullExpr struct {
+	not   bool
+	value expr
+}
+
+func (e *isNullExpr) eval(r Record) (interface{}, error) {
+	v, err := e.value.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	return (v == nil) != e.not, nil
+}
+
+type likeExpr struct {
+	not                    bool
+	value, pattern, escape expr
+
+	// Compiled regular expression of the last pattern.
+	lastPattern string
+	re          *regexp.Regexp
+}
+
+func (e *likeExpr) eval(r Record) (interface{}, error) {
+	v, err := e.value.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := e.pattern.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	escape := interface{}("")
+	if e.escape != nil {
+		if escape, err = e.escape.eval(r); err != nil {
+			return nil, err
+		}
+	}
+	if v == nil || pattern == nil || escape == nil {
+		return nil, nil
+	}
+
+	key := FormatValue(pattern) + "\x00" + FormatValue(escape)
+	if e.re == nil || key != e.lastPattern {
+		if e.re, err = likeRegexp(FormatValue(pattern), FormatValue(escape)); err != nil {
+			return nil, err
+		}
+		e.lastPattern = key
+	}
+	return e.re.MatchString(FormatValue(v)) != e.not, nil
+}
+
+// likeRegexp - converts a LIKE pattern to a regular expression, `%`
+// matches any sequence of characters and `_` any single character.
+func likeRegexp(pattern, escape string) (*regexp.Regexp, error) {
+	if utf8.RuneCountInString(escape) > 1 {
+		return nil, errInvalidArguments("The ESCAPE character must be a single character.")
+	}
+	escapeRune, _ := utf8.DecodeRuneInString(escape)
+
+	var sb strings.Builder
+	sb.WriteString("(?s)^")
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+			escaped = false
+		case escape != "" && c == escapeRune:
+			escaped = true
+		case c == '%':
+			sb.WriteString(".*")
+		case c == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if escaped {
+		return nil, errInvalidArguments("The LIKE pattern must not end with the ESCAPE character.")
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+type betweenExpr struct {
+	not              bool
+	value, low, high expr
+}
+
+func (e *betweenExpr) eval(r Record) (interface{}, error) {
+	v, err := (&binaryExpr{op: "AND",
+		left:  &binaryExpr{op: ">=", left: e.value, right: e.low},
+		right: &binaryExpr{op: "<=", left: e.value, right: e.high},
+	}).eval(r)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return v.(bool) != e.not, nil
+}
+
+type inExpr struct {
+	not   bool
+	value expr
+	list  []expr
+}
+
+func (e *inExpr) eval(r Record) (interface{}, error) {
+	v, err := e.value.eval(r)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	for _, item := range e.list {
+		w, err := item.eval(r)
+		if err != nil {
+			return nil, err
+		}
+		if result, ok := compare(v, w); w != nil && ok && result == 0 {
+			return !e.not, nil
+		}
+	}
+	return e.not, nil
+}
+
+type castExpr struct {
+	value expr
+	typ   string
+}
+
+func (e *castExpr) eval(r Record) (interface{}, error) {
+	v, err := e.value.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	return cast(v, e.typ)
+}
+
+type funcExpr struct {
+	name string
+	args []expr
+}
+
+func (e *funcExpr) eval(r Record) (interface{}, error) {
+	args := make([]interface{}, len(e.args))
+	for i, arg := range e.args {
+		v, err := arg.eval(r)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	switch e.name {
+	case "COALESCE":
+		for _, v := range args {
+			if v != nil {
+				return v, nil
+			}
+		}
+		return nil, nil
+	case "NULLIF":
+		if result, ok := compare(args[0], args[1]); args[0] != nil && args[1] != nil && ok && result == 0 {
+			return nil, nil
+		}
+		return args[0], nil
+	}
+
+	if args[0] == nil {
+		return nil, nil
+	}
+	s := FormatValue(args[0])
+	switch e.name {
+	case "LOWER":
+		return strings.ToLower(s), nil
+	case "UPPER":
+		return strings.ToUpper(s), nil
+	}
+	// CHAR_LENGTH and CHARACTER_LENGTH.
+	return int64(utf8.RuneCountInString(s)), nil
+}
+
+type trimExpr struct {
+	mode              string
+	characters, value expr
+}
+
+func (e *trimExpr) eval(r Record) (interface{}, error) {
+	v, err := e.value.eval(r)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	cutset := interface{}(" ")
+	if e.characters != nil {
+		if cutset, err = e.characters.eval(r); err != nil || cutset == nil {
+			return nil, err
+		}
+	}
+
+	s := FormatValue(v)
+	switch e.mode {
+	case "LEADING":
+		return strings.TrimLeft(s, FormatValue(cutset)), nil
+	case "TRAILING":
+		return strings.TrimRight(s, FormatValue(cutset)), nil
+	}
+	return strings.Trim(s, FormatValue(cutset)), nil
+}
+
+type substringExpr struct {
+	value, start, length expr
+}
+
+func (e *substringExpr) eval(r Record) (interface{}, error) {
+	v, err := e.value.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	start, err := e.start.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	var length interface{} = int64(math.MaxInt32)
+	if e.length != nil {
+		if length, err = e.length.eval(r); err != nil {
+			return nil, err
+		}
+	}
+	if v == nil || start == nil || length == nil {
+		return nil, nil
+	}
+
+	if start, err = cast(start, "INT"); err != nil {
+		return nil, errInvalidArguments("The start of SUBSTRING must be an integer.")
+	}
+	if length, err = cast(length, "INT"); err != nil || length.(int64) < 0 {
+		return nil, errInvalidArguments("The length of SUBSTRING must be a non-negative integer.")
+	}
+
+	// Positions start at 1, characters before it are counted in the
+	// length.
+	runes := []rune(FormatValue(v))
+	from, to := start.(int64)-1, start.(int64)-1+length.(int64)
+	if from < 0 {
+		from = 0
+	}
+	if to > int64(len(runes)) {
+		to = int64(len(runes))
+	}
+	if from >= to {
+		return "", nil
+	}
+	return string(runes[from:to]), nil
+}
+
+// aggregateExpr - aggregate function, the value accumulated over all
+// records is returned by eval.
+type aggregateExpr struct {
+	name string
+	arg  expr
+
+	count int64
+	sum   interface{}
+	value interface{}
+}
+
+// process - accumulates the value of a record.
+func (e *aggregateExpr) process(r Record) error {
+	if e.arg == nil {
+		e.count++
+		return nil
+	}
+	v, err := e.arg.eval(r)
+	if err != nil || v == nil {
+		return err
+	}
+
+	switch e.name {
+	case "COUNT":
+	case "SUM", "AVG":
+		if e.sum == nil {
+			e.sum = int64(0)
+		}
+		if e.sum, err = arithmetic("+", e.sum, v); err != nil {
+			return err
+		}
+	case "MIN", "MAX":
+		if e.value == nil {
+			e.value = v
+			break
+		}
+		result, ok := compare(v, e.value)
+		if !ok {
+			return errInvalidArguments("Can not compare " + FormatValue(v) + " and " + FormatValue(e.value) + ".")
+		}
+		if (e.name == "MIN") == (result < 0) && result != 0 {
+			e.value = v
+		}
+	}
+	e.count++
+	return nil
+}
+
+func (e *aggregateExpr) eval(r Record) (interface{}, error) {
+	switch e.name {
+	case "COUNT":
+		return e.count, nil
+	case "SUM":
+		return e.sum, nil
+	case "AVG":
+		if e.count == 0 {
+			return nil, nil
+		}
+		return toFloat(e.sum) / float64(e.count), nil
+	}
+	return e.value, nil
+}
@@ -0,0 +1,155 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenKeyword
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+// Keywords of the supported SQL, identifiers matching them case
+// insensitively are keywords unless quoted.
+var keywords = map[string]struct{}{
+	"SELECT": {}, "FROM": {}, "WHERE": {}, "LIMIT": {}, "AS": {},
+	"AND": {}, "OR": {}, "NOT": {}, "IS": {}, "NULL": {}, "TRUE": {}, "FALSE": {},
+	"LIKE": {}, "ESCAPE": {}, "BETWEEN": {}, "IN": {}, "CAST": {}, "FOR": {},
+}
+
+type token struct {
+	typ   tokenType
+	value string
+	pos   int
+}
+
+// lexer - splits a SQL expression into tokens.
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func (l *lexer) skipSpaces() {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+}
+
+// readQuoted - reads a string or quoted identifier delimited by quote,
+// the quote is escaped by doubling it.
+func (l *lexer) readQuoted(quote rune) (string, error) {
+	start := l.pos
+	l.pos++
+	var sb strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		l.pos++
+		if c != quote {
+			sb.WriteRune(c)
+			continue
+		}
+		if l.pos < len(l.input) && l.input[l.pos] == quote {
+			sb.WriteRune(c)
+			l.pos++
+			continue
+		}
+		return sb.String(), nil
+	}
+	return "", errLexer(start, "unterminated quoted string")
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpaces()
+	if l.pos >= len(l.input) {
+		return token{typ: tokenEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case c == '\'':
+		s, err := l.readQuoted('\'')
+		return token{typ: tokenString, value: s, pos: start}, err
+	case c == '"':
+		s, err := l.readQuoted('"')
+		return token{typ: tokenQuotedIdent, value: s, pos: start}, err
+	case unicode.IsDigit(c) || (c == '.' && l.pos+1 < len(l.input) && unicode.IsDigit(l.input[l.pos+1])):
+		for l.pos < len(l.input) && (unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == '.') {
+			l.pos++
+		}
+		if l.pos < len(l.input) && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+			l.pos++
+			if l.pos < len(l.input) && (l.input[l.pos] == '+' || l.input[l.pos] == '-') {
+				l.pos++
+			}
+			for l.pos < len(l.input) && unicode.IsDigit(l.input[l.pos]) {
+				l.pos++
+			}
+		}
+		return token{typ: tokenNumber, value: string(l.input[start:l.pos]), pos: start}, nil
+	case unicode.IsLetter(c) || c == '_':
+		for l.pos < len(l.input) && (unicode.IsLetter(l.input[l.pos]) || unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == '_') {
+			l.pos++
+		}
+		value := string(l.input[start:l.pos])
+		if _, ok := keywords[strings.ToUpper(value)]; ok {
+			return token{typ: tokenKeyword, value: strings.ToUpper(value), pos: start}, nil
+		}
+		return token{typ: tokenIdent, value: value, pos: start}, nil
+	}
+
+	// Two character operators first.
+	if l.pos+1 < len(l.input) {
+		switch op := string(l.input[l.pos : l.pos+2]); op {
+		case "<=", ">=", "<>", "!=", "||":
+			l.pos += 2
+			return token{typ: tokenOperator, value: op, pos: start}, nil
+		}
+	}
+	switch c {
+	case '=', '<', '>', '+', '-', '*', '/', '%', '(', ')', ',', '.', '[', ']':
+		l.pos++
+		return token{typ: tokenOperator, value: string(c), pos: start}, nil
+	}
+	return token{}, errLexer(start, "unexpected character '"+string(c)+"'")
+}
+
+// tokenize - returns all tokens of the expression, ending with tokenEOF.
+func tokenize(expression string) ([]token, error) {
+	l := &lexer{input: []rune(expression)}
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.typ == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
@@ -0,0 +1,703 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Name of the table of all select expressions.
+const tableName = "S3OBJECT"
+
+// Scalar functions and the number of arguments they accept.
+var scalarFunctions = map[string][2]int{
+	"LOWER":            {1, 1},
+	"UPPER":            {1, 1},
+	"CHAR_LENGTH":      {1, 1},
+	"CHARACTER_LENGTH": {1, 1},
+	"COALESCE":         {1, -1},
+	"NULLIF":           {2, 2},
+}
+
+var aggregateFunctions = map[string]struct{}{
+	"COUNT": {}, "SUM": {}, "AVG": {}, "MIN": {}, "MAX": {},
+}
+
+var castTypes = map[string]string{
+	"INT": "INT", "INTEGER": "INT",
+	"FLOAT": "FLOAT", "DECIMAL": "FLOAT", "NUMERIC": "FLOAT",
+	"STRING": "STRING", "VARCHAR": "STRING", "CHAR": "STRING",
+	"BOOL": "BOOL", "BOOLEAN": "BOOL",
+}
+
+// projection - expression of the select list and its output name.
+type projection struct {
+	expr  expr
+	alias string
+}
+
+// Select - parsed select statement, e.g. `SELECT s.name FROM S3Object s
+// WHERE s.age > 30 LIMIT 10`.
+type Select struct {
+	projections []projection
+	tableAlias  string
+	where       expr
+	limit       int64
+	aggregates  []*aggregateExpr
+	columns     []*columnExpr
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+
+	columns    []*columnExpr
+	aggregates []*aggregateExpr
+	aggDepth   int
+	// Number of column references outside of aggregate functions.
+	bareColumns int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if tok.typ != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword - returns true if the next token is one of the keywords.
+func (p *parser) isKeyword(keywords ...string) bool {
+	tok := p.peek()
+	if tok.typ != tokenKeyword {
+		return false
+	}
+	for _, keyword := range keywords {
+		if tok.value == keyword {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) isOperator(op string) bool {
+	tok := p.peek()
+	return tok.typ == tokenOperator && tok.value == op
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if !p.isKeyword(keyword) {
+		return errUnexpectedToken(p.peek())
+	}
+	p.advance()
+	return nil
+}
+
+func (p *parser) expectOperator(op string) error {
+	if !p.isOperator(op) {
+		return errUnexpectedToken(p.peek())
+	}
+	p.advance()
+	return nil
+}
+
+// isIdent - returns true if the next token is an unquoted identifier
+// equal to name, case insensitively.
+func (p *parser) isIdent(name string) bool {
+	tok := p.peek()
+	return tok.typ == tokenIdent && strings.EqualFold(tok.value, name)
+}
+
+// ParseSelect - parses a select expression.
+func ParseSelect(expression string) (*Select, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	return p.parseSelect()
+}
+
+func (p *parser) parseSelect() (*Select, error) {
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+
+	stmt := &Select{limit: -1}
+	if p.isOperator("*") {
+		p.advance()
+	} else {
+		for {
+			p.bareColumns = 0
+			numAggregates := len(p.aggregates)
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			// Either all or none of the projections aggregate.
+			isAggregate := len(p.aggregates) > numAggregates
+			if isAggregate && p.bareColumns > 0 || len(stmt.projections) > 0 && isAggregate != (numAggregates > 0) {
+				return nil, errUnsupportedSQLOperation("Aggregate functions can not be mixed with other expressions in the select list.")
+			}
+
+			proj := projection{expr: e}
+			if p.isKeyword("AS") {
+				p.advance()
+			}
+			if tok := p.peek(); tok.typ == tokenIdent || tok.typ == tokenQuotedIdent {
+				proj.alias = p.advance().value
+			}
+			stmt.projections = append(stmt.projections, proj)
+
+			if !p.isOperator(",") {
+				break
+			}
+			p.advance()
+		}
+		stmt.aggregates = p.aggregates
+	}
+
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	if err := p.parseFrom(stmt); err != nil {
+		return nil, err
+	}
+
+	if p.isKeyword("WHERE") {
+		p.advance()
+		numAggregates := len(p.aggregates)
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if len(p.aggregates) > numAggregates {
+			return nil, errUnsupportedSQLOperation("Aggregate functions are not allowed in the WHERE clause.")
+		}
+		stmt.where = e
+	}
+
+	if p.isKeyword("LIMIT") {
+		p.advance()
+		tok := p.advance()
+		limit, err := strconv.ParseInt(tok.value, 10, 64)
+		if tok.typ != tokenNumber || err != nil || limit < 0 {
+			return nil, errUnexpectedToken(tok)
+		}
+		stmt.limit = limit
+	}
+
+	if tok := p.peek(); tok.typ != tokenEOF {
+		return nil, errUnexpectedToken(tok)
+	}
+
+	// References to columns may be qualified by the table name or alias.
+	for _, column := range p.columns {
+		if len(column.path) > 1 && column.path[0].index < 0 && !column.path[0].quoted &&
+			(strings.EqualFold(column.path[0].name, stmt.tableAlias) || strings.EqualFold(column.path[0].name, tableName)) {
+			column.path = column.path[1:]
+		}
+	}
+	stmt.columns = p.columns
+	return stmt, nil
+}
+
+// parseFrom - parses the table of the statement, S3Object optionally
+// followed by [*] and an alias.
+func (p *parser) parseFrom(stmt *Select) error {
+	if tok := p.peek(); tok.typ != tokenIdent {
+		return errUnexpectedToken(tok)
+	}
+	if !p.isIdent(tableName) {
+		return errUnsupportedSyntax("The FROM clause must reference S3Object.")
+	}
+	p.advance()
+
+	if p.isOperator("[") {
+		p.advance()
+		if err := p.expectOperator("*"); err != nil {
+			return err
+		}
+		if err := p.expectOperator("]"); err != nil {
+			return err
+		}
+	}
+
+	if p.isKeyword("AS") {
+		p.advance()
+		if tok := p.peek(); tok.typ != tokenIdent {
+			return errUnexpectedToken(tok)
+		}
+	}
+	if tok := p.peek(); tok.typ == tokenIdent {
+		stmt.tableAlias = p.advance().value
+	}
+	return nil
+}
+
+func (p *parser) parseExpr() (expr, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("OR") {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("AND") {
+		p.advance()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.isKeyword("NOT") {
+		p.advance()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "NOT", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.typ == tokenOperator {
+		switch tok.value {
+		case "=", "!=", "<>", "<", "<=", ">", ">=":
+			p.advance()
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			op := tok.value
+			if op == "<>" {
+				op = "!="
+			}
+			return &binaryExpr{op: op, left: left, right: right}, nil
+		}
+	}
+
+	if p.isKeyword("IS") {
+		p.advance()
+		not := p.isKeyword("NOT")
+		if not {
+			p.advance()
+		}
+		if err = p.expectKeyword("NULL"); err != nil {
+			return nil, err
+		}
+		return &isNullExpr{not: not, value: left}, nil
+	}
+
+	not := p.isKeyword("NOT")
+	if not {
+		p.advance()
+	}
+	switch {
+	case p.isKeyword("LIKE"):
+		p.advance()
+		pattern, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		e := &likeExpr{not: not, value: left, pattern: pattern}
+		if p.isKeyword("ESCAPE") {
+			p.advance()
+			if e.escape, err = p.parseAdditive(); err != nil {
+				return nil, err
+			}
+		}
+		return e, nil
+	case p.isKeyword("BETWEEN"):
+		p.advance()
+		low, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		high, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &betweenExpr{not: not, value: left, low: low, high: high}, nil
+	case p.isKeyword("IN"):
+		p.advance()
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &inExpr{not: not, value: left, list: list}, nil
+	}
+	if not {
+		return nil, errUnexpectedToken(p.peek())
+	}
+	return left, nil
+}
+
+// parseList - parses a parenthesized list of expressions.
+func (p *parser) parseList() ([]expr, error) {
+	if err := p.expectOperator("("); err != nil {
+		return nil, err
+	}
+	var list []expr
+	for {
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+		if !p.isOperator(",") {
+			break
+		}
+		p.advance()
+	}
+	if err := p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (p *parser) parseAdditive() (expr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("+") || p.isOperator("-") || p.isOperator("||") {
+		op := p.advance().value
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("*") || p.isOperator("/") || p.isOperator("%") {
+		op := p.advance().value
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.isOperator("-") || p.isOperator("+") {
+		op := p.advance().value
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "+" {
+			return operand, nil
+		}
+		return &unaryExpr{op: "-", operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	tok := p.peek()
+	switch tok.typ {
+	case tokenString:
+		p.advance()
+		return &literalExpr{value: tok.value}, nil
+	case tokenNumber:
+		p.advance()
+		if i, err := strconv.ParseInt(tok.value, 10, 64); err == nil {
+			return &literalExpr{value: i}, nil
+		}
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, errUnexpectedToken(tok)
+		}
+		return &literalExpr{value: f}, nil
+	case tokenKeyword:
+		switch tok.value {
+		case "NULL":
+			p.advance()
+			return &literalExpr{value: nil}, nil
+		case "TRUE", "FALSE":
+			p.advance()
+			return &literalExpr{value: tok.value == "TRUE"}, nil
+		case "CAST":
+			p.advance()
+			return p.parseCast()
+		}
+	case tokenOperator:
+		if tok.value == "(" {
+			p.advance()
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expectOperator(")"); err != nil {
+				return nil, err
+			}
+			return e, nil
+		}
+	case tokenIdent:
+		if next := p.tokens[p.pos+1]; next.typ == tokenOperator && next.value == "(" {
+			return p.parseFunction()
+		}
+		return p.parseColumn()
+	case tokenQuotedIdent:
+		return p.parseColumn()
+	}
+	return nil, errUnexpectedToken(tok)
+}
+
+// parseColumn - parses a reference to a column, nested fields of JSON
+// records are referenced by name or index, e.g. s.address.lines[0].
+func (p *parser) parseColumn() (expr, error) {
+	column := &columnExpr{}
+	for {
+		tok := p.advance()
+		if tok.typ != tokenIdent && tok.typ != tokenQuotedIdent {
+			return nil, errUnexpectedToken(tok)
+		}
+		column.path = append(column.path, pathElement{name: tok.value, quoted: tok.typ == tokenQuotedIdent, index: -1})
+
+		for p.isOperator("[") {
+			p.advance()
+			tok = p.advance()
+			index, err := strconv.Atoi(tok.value)
+			if tok.typ != tokenNumber || err != nil || index < 0 {
+				return nil, errUnexpectedToken(tok)
+			}
+			if err = p.expectOperator("]"); err != nil {
+				return nil, err
+			}
+			column.path = append(column.path, pathElement{index: index})
+		}
+
+		if !p.isOperator(".") {
+			break
+		}
+		p.advance()
+	}
+
+	p.columns = append(p.columns, column)
+	if p.aggDepth == 0 {
+		p.bareColumns++
+	}
+	return column, nil
+}
+
+func (p *parser) parseCast() (expr, error) {
+	if err := p.expectOperator("("); err != nil {
+		return nil, err
+	}
+	value, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
+	tok := p.advance()
+	typ, ok := castTypes[strings.ToUpper(tok.value)]
+	if tok.typ != tokenIdent || !ok {
+		return nil, errUnsupportedSyntax("Unsupported type '" + tok.value + "' in CAST.")
+	}
+	if err = p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	return &castExpr{value: value, typ: typ}, nil
+}
+
+func (p *parser) parseFunction() (expr, error) {
+	name := strings.ToUpper(p.advance().value)
+	p.advance()
+
+	switch name {
+	case "TRIM":
+		return p.parseTrim()
+	case "SUBSTRING":
+		return p.parseSubstring()
+	}
+
+	if _, ok := aggregateFunctions[name]; ok {
+		return p.parseAggregate(name)
+	}
+
+	nargs, ok := scalarFunctions[name]
+	if !ok {
+		return nil, errUnsupportedFunction(name)
+	}
+	var args []expr
+	if !p.isOperator(")") {
+		for {
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, e)
+			if !p.isOperator(",") {
+				break
+			}
+			p.advance()
+		}
+	}
+	if err := p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	if len(args) < nargs[0] || (nargs[1] >= 0 && len(args) > nargs[1]) {
+		return nil, errInvalidArguments("Invalid number of arguments for function " + name + ".")
+	}
+	return &funcExpr{name: name, args: args}, nil
+}
+
+func (p *parser) parseAggregate(name string) (expr, error) {
+	if p.aggDepth > 0 {
+		return nil, errUnsupportedSQLOperation("Aggregate functions can not be nested.")
+	}
+	agg := &aggregateExpr{name: name}
+	if name == "COUNT" && p.isOperator("*") {
+		p.advance()
+	} else {
+		p.aggDepth++
+		arg, err := p.parseExpr()
+		p.aggDepth--
+		if err != nil {
+			return nil, err
+		}
+		agg.arg = arg
+	}
+	if err := p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	p.aggregates = append(p.aggregates, agg)
+	return agg, nil
+}
+
+// parseTrim - parses TRIM([[LEADING|TRAILING|BOTH] [characters] FROM] value).
+func (p *parser) parseTrim() (expr, error) {
+	e := &trimExpr{mode: "BOTH"}
+	if p.isIdent("LEADING") || p.isIdent("TRAILING") || p.isIdent("BOTH") {
+		e.mode = strings.ToUpper(p.advance().value)
+		if !p.isKeyword("FROM") {
+			characters, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			e.characters = characters
+		}
+		if err := p.expectKeyword("FROM"); err != nil {
+			return nil, err
+		}
+	}
+
+	value, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if e.characters == nil && p.isKeyword("FROM") {
+		p.advance()
+		e.characters = value
+		if value, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	e.value = value
+
+	if err = p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// parseSubstring - parses SUBSTRING(value FROM start [FOR length]) and
+// SUBSTRING(value, start [, length]).
+func (p *parser) parseSubstring() (expr, error) {
+	e := &substringExpr{}
+	var err error
+	if e.value, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+
+	fromKeyword, forKeyword := "FROM", "FOR"
+	if p.isOperator(",") {
+		fromKeyword, forKeyword = ",", ","
+	}
+	if fromKeyword == "," {
+		err = p.expectOperator(",")
+	} else {
+		err = p.expectKeyword("FROM")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if e.start, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+
+	if (forKeyword == "," && p.isOperator(",")) || (forKeyword == "FOR" && p.isKeyword("FOR")) {
+		p.advance()
+		if e.length, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
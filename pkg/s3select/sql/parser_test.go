@@ -0,0 +1,267 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSelect(t *testing.T) {
+	testCases := []struct {
+		expression   string
+		expectedCode string
+	}{
+		{"SELECT * FROM S3Object", ""},
+		{"select * from s3object[*] s where s.age > 30 limit 10", ""},
+		{"SELECT s.name AS n, s.address.lines[0] FROM S3Object AS s", ""},
+		{`SELECT "Name", _2 FROM S3Object WHERE "Name" LIKE 'a\%%' ESCAPE '\'`, ""},
+		{"SELECT COUNT(*), SUM(CAST(age AS INT)) / COUNT(age) FROM S3Object WHERE age IS NOT NULL", ""},
+		{"SELECT TRIM(LEADING '0' FROM id), SUBSTRING(name FROM 2 FOR 3), SUBSTRING(name, 2) FROM S3Object", ""},
+		{"SELECT * FROM S3Object WHERE a BETWEEN 1 AND 2 AND b NOT IN ('x', 'y') OR NOT c = -1.5e2", ""},
+		{"SELECT * FROM S3Object WHERE name = 'unterminated", "LexerInvalidChar"},
+		{"SELECT * FROM S3Object WHERE a ~ b", "LexerInvalidChar"},
+		{"SELECT FROM S3Object", "ParseUnexpectedToken"},
+		{"SELECT * FROM S3Object WHERE", "ParseUnexpectedToken"},
+		{"SELECT * FROM S3Object LIMIT -1", "ParseUnexpectedToken"},
+		{"SELECT * FROM S3Object garbage garbage", "ParseUnexpectedToken"},
+		{"SELECT * FROM table", "ParseUnsupportedSyntax"},
+		{"SELECT CAST(a AS DATE) FROM S3Object", "ParseUnsupportedSyntax"},
+		{"SELECT MD5(a) FROM S3Object", "UnsupportedFunction"},
+		{"SELECT LOWER(a, b) FROM S3Object", "EvaluatorInvalidArguments"},
+		{"SELECT a, COUNT(*) FROM S3Object", "UnsupportedSqlOperation"},
+		{"SELECT COUNT(*), a FROM S3Object", "UnsupportedSqlOperation"},
+		{"SELECT SUM(a) + a FROM S3Object", "UnsupportedSqlOperation"},
+		{"SELECT SUM(COUNT(a)) FROM S3Object", "UnsupportedSqlOperation"},
+		{"SELECT * FROM S3Object WHERE COUNT(*) > 1", "UnsupportedSqlOperation"},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseSelect(testCase.expression)
+		if testCase.expectedCode == "" {
+			if err != nil {
+				t.Fatalf("case %v: unexpected error: %v", i+1, err)
+			}
+			continue
+		}
+		if serr, ok := err.(*Error); !ok || serr.ErrorCode() != testCase.expectedCode {
+			t.Fatalf("case %v: expected error code %v, got %v", i+1, testCase.expectedCode, err)
+		}
+	}
+}
+
+func TestSelectEval(t *testing.T) {
+	record := &testRecord{Object{
+		{"name", "Alice"},
+		{"age", "31"},
+		{"score", 7.5},
+		{"active", true},
+		{"missing", nil},
+		{"address", Object{{"city", "Lisbon"}, {"lines", []interface{}{"Rua A", "1"}}}},
+	}}
+
+	testCases := []struct {
+		expression string
+		matches    bool
+		expected   Object
+	}{
+		{"SELECT * FROM S3Object", true, record.obj},
+		{"SELECT s.name, s.address.city, s.address.lines[1] AS n FROM S3Object s WHERE s.age > 30", true,
+			Object{{"name", "Alice"}, {"city", "Lisbon"}, {"n", "1"}}},
+		{"SELECT NAME FROM S3Object WHERE age < 30", false, Object{{"NAME", "Alice"}}},
+		{`SELECT "NAME" FROM S3Object`, true, Object{{"NAME", nil}}},
+		{"SELECT _1, _3 FROM S3Object", true, Object{{"_1", "Alice"}, {"_3", 7.5}}},
+		{"SELECT age + 1, age * score, age / 2, age % 2, -score, name || '!' FROM S3Object", true,
+			Object{{"_1", int64(32)}, {"_2", 232.5}, {"_3", int64(15)}, {"_4", int64(1)}, {"_5", -7.5}, {"_6", "Alice!"}}},
+		{"SELECT UPPER(name), LOWER(name), CHAR_LENGTH(name), TRIM(BOTH 'A' FROM name), SUBSTRING(name, 2, 3) FROM S3Object", true,
+			Object{{"_1", "ALICE"}, {"_2", "alice"}, {"_3", int64(5)}, {"_4", "lice"}, {"_5", "lic"}}},
+		{"SELECT COALESCE(missing, name), NULLIF(age, '31'), CAST(age AS FLOAT), CAST(score AS INT), CAST(active AS STRING) FROM S3Object", true,
+			Object{{"_1", "Alice"}, {"_2", nil}, {"_3", float64(31)}, {"_4", int64(7)}, {"_5", "true"}}},
+		{"SELECT name FROM S3Object WHERE name LIKE 'A_i%' AND age BETWEEN 30 AND 40 AND age IN (29, 31)", true, Object{{"name", "Alice"}}},
+		{"SELECT name FROM S3Object WHERE name NOT LIKE 'A%' OR age NOT BETWEEN 30 AND 40", false, Object{{"name", "Alice"}}},
+		{"SELECT name FROM S3Object WHERE missing IS NULL AND name IS NOT NULL AND active", true, Object{{"name", "Alice"}}},
+		// Comparisons with null are unknown.
+		{"SELECT name FROM S3Object WHERE missing = 1 OR NOT missing = 1", false, Object{{"name", "Alice"}}},
+		{"SELECT name FROM S3Object WHERE missing = 1 OR active", true, Object{{"name", "Alice"}}},
+	}
+
+	for i, testCase := range testCases {
+		stmt, err := ParseSelect(testCase.expression)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		matches, err := stmt.Filter(record)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if matches != testCase.matches {
+			t.Fatalf("case %v: expected matches %v, got %v", i+1, testCase.matches, matches)
+		}
+		obj, err := stmt.Eval(record)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(obj, testCase.expected) {
+			t.Fatalf("case %v: expected %v, got %v", i+1, testCase.expected, obj)
+		}
+	}
+}
+
+func TestSelectEvalErrors(t *testing.T) {
+	record := &testRecord{Object{{"name", "Alice"}, {"age", "31"}}}
+	testCases := []struct {
+		expression   string
+		expectedCode string
+	}{
+		{"SELECT age / 0 FROM S3Object", "EvaluatorInvalidArguments"},
+		{"SELECT name + 1 FROM S3Object", "EvaluatorInvalidArguments"},
+		{"SELECT CAST(name AS INT) FROM S3Object", "CastFailed"},
+		{"SELECT _3 FROM S3Object", "InvalidColumnIndex"},
+	}
+
+	for i, testCase := range testCases {
+		stmt, err := ParseSelect(testCase.expression)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		_, err = stmt.Eval(record)
+		if serr, ok := err.(*Error); !ok || serr.ErrorCode() != testCase.expectedCode {
+			t.Fatalf("case %v: expected error code %v, got %v", i+1, testCase.expectedCode, err)
+		}
+	}
+}
+
+func TestSelectAggregate(t *testing.T) {
+	stmt, err := ParseSelect("SELECT COUNT(*), COUNT(age), SUM(age), AVG(age), MIN(CAST(age AS INT)), MAX(name) AS last FROM S3Object WHERE name <> 'Carol'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stmt.IsAggregate() {
+		t.Fatal("expected an aggregate statement")
+	}
+
+	records := []*testRecord{
+		{Object{{"name", "Alice"}, {"age", "31"}}},
+		{Object{{"name", "Bob"}, {"age", nil}}},
+		{Object{{"name", "Carol"}, {"age", "50"}}},
+		{Object{{"name", "Dave"}, {"age", "9"}}},
+	}
+	for _, record := range records {
+		matches, err := stmt.Filter(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !matches {
+			continue
+		}
+		if err = stmt.Aggregate(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	obj, err := stmt.AggregateResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Object{{"_1", int64(3)}, {"_2", int64(2)}, {"_3", int64(40)}, {"_4", float64(20)}, {"_5", int64(9)}, {"last", "Dave"}}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	testCases := []struct {
+		expression    string
+		expectedNames []string
+		expectedAll   bool
+	}{
+		{"SELECT * FROM S3Object WHERE age > 30", nil, true},
+		{"SELECT name, s.address.city FROM S3Object s WHERE age > 30", []string{"name", "address", "age"}, false},
+		{"SELECT COUNT(*) FROM S3Object", nil, false},
+		{"SELECT UPPER(\"Name\") FROM S3Object", []string{"Name"}, false},
+		{"SELECT _1, name FROM S3Object", nil, true},
+		{"SELECT tags[0] FROM S3Object", []string{"tags"}, false},
+	}
+
+	for i, testCase := range testCases {
+		stmt, err := ParseSelect(testCase.expression)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		names, all := stmt.Columns()
+		if !reflect.DeepEqual(names, testCase.expectedNames) || all != testCase.expectedAll {
+			t.Fatalf("case %v: expected %v %v, got %v %v", i+1, testCase.expectedNames, testCase.expectedAll, names, all)
+		}
+	}
+}
+
+func TestSelectMayMatch(t *testing.T) {
+	stats := func(name string, caseSensitive bool) (ColumnStats, bool) {
+		switch strings.ToLower(name) {
+		case "age":
+			return ColumnStats{Min: int64(20), Max: int64(40), NullCount: 0, Count: 10}, true
+		case "name":
+			return ColumnStats{Min: "Alice", Max: "Carol", NullCount: 10, Count: 10}, true
+		}
+		return ColumnStats{}, false
+	}
+
+	testCases := []struct {
+		where    string
+		expected bool
+	}{
+		{"age > 30", true},
+		{"age > 40", false},
+		{"age >= 40", true},
+		{"40 < age", false},
+		{"age = 19", false},
+		{"age != 30", true},
+		{"age < 20 OR age > 40", false},
+		{"age < 20 OR city = 'Lisbon'", true},
+		{"age > 30 AND age < 10", false},
+		{"age BETWEEN 41 AND 50", false},
+		{"age NOT BETWEEN 20 AND 40", true},
+		{"age IS NULL", false},
+		{"name IS NOT NULL", false},
+		{"name < 'Bob'", true},
+		{"name > 'Dave'", false},
+		{"NOT age > 40", true},
+	}
+
+	for i, testCase := range testCases {
+		stmt, err := ParseSelect("SELECT * FROM S3Object WHERE " + testCase.where)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if result := stmt.MayMatch(stats); result != testCase.expected {
+			t.Fatalf("case %v: expected %v, got %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+type testRecord struct {
+	obj Object
+}
+
+func (r *testRecord) Get(name string, caseSensitive bool) (interface{}, bool) {
+	return r.obj.Get(name, caseSensitive)
+}
+
+func (r *testRecord) Object() Object {
+	return r.obj
+}
@@ -0,0 +1,217 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IsAggregate - returns true if the select list consists of aggregate
+// functions, a single record is then returned by AggregateResult.
+func (stmt *Select) IsAggregate() bool {
+	return len(stmt.aggregates) > 0
+}
+
+// Limit - returns the maximum number of records to return, -1 if there
+// is no limit.
+func (stmt *Select) Limit() int64 {
+	return stmt.limit
+}
+
+// Filter - returns true if the record matches the WHERE clause.
+func (stmt *Select) Filter(r Record) (bool, error) {
+	if stmt.where == nil {
+		return true, nil
+	}
+	v, err := stmt.where.eval(r)
+	if err != nil {
+		return false, err
+	}
+	if v, err = toBool(v); err != nil {
+		return false, err
+	}
+	return v == true, nil
+}
+
+// Eval - returns the projection of a record.
+func (stmt *Select) Eval(r Record) (Object, error) {
+	if len(stmt.projections) == 0 {
+		return r.Object(), nil
+	}
+	return stmt.project(r)
+}
+
+// Aggregate - accumulates the aggregate functions of the select list
+// over a record.
+func (stmt *Select) Aggregate(r Record) error {
+	for _, agg := range stmt.aggregates {
+		if err := agg.process(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AggregateResult - returns the record of the aggregate functions over
+// all records passed to Aggregate.
+func (stmt *Select) AggregateResult() (Object, error) {
+	return stmt.project(nil)
+}
+
+func (stmt *Select) project(r Record) (Object, error) {
+	obj := make(Object, len(stmt.projections))
+	for i, proj := range stmt.projections {
+		v, err := proj.expr.eval(r)
+		if err != nil {
+			return nil, err
+		}
+
+		name := proj.alias
+		if column, ok := proj.expr.(*columnExpr); ok && name == "" {
+			name = column.name()
+		}
+		if name == "" {
+			name = "_" + strconv.Itoa(i+1)
+		}
+		obj[i] = Field{Name: name, Value: v}
+	}
+	return obj, nil
+}
+
+// Columns - returns the names of the columns referenced by the
+// statement, all is true if every column of the record may be needed.
+// Readers of columnar formats only read the returned columns.
+func (stmt *Select) Columns() (names []string, all bool) {
+	if len(stmt.projections) == 0 {
+		return nil, true
+	}
+	for _, column := range stmt.columns {
+		first := column.path[0]
+		if first.index >= 0 || isPositional(first.name) {
+			return nil, true
+		}
+		names = append(names, first.name)
+	}
+	return names, false
+}
+
+// isPositional - returns true if name references a column by position.
+func isPositional(name string) bool {
+	_, err := strconv.Atoi(strings.TrimPrefix(name, "_"))
+	return strings.HasPrefix(name, "_") && err == nil
+}
+
+// ColumnStats - statistics of the values of a column in a block of
+// records. Min and Max are nil if unknown, NullCount is -1 if unknown.
+type ColumnStats struct {
+	Min, Max  interface{}
+	NullCount int64
+	Count     int64
+}
+
+// MayMatch - returns false if no record of a block of records can match
+// the WHERE clause, given the statistics of its columns. stats returns
+// false for columns without statistics.
+func (stmt *Select) MayMatch(stats func(name string, caseSensitive bool) (ColumnStats, bool)) bool {
+	if stmt.where == nil {
+		return true
+	}
+	return mayMatch(stmt.where, stats)
+}
+
+func mayMatch(e expr, stats func(name string, caseSensitive bool) (ColumnStats, bool)) bool {
+	columnStats := func(e expr) (ColumnStats, bool) {
+		column, ok := e.(*columnExpr)
+		if !ok || len(column.path) != 1 || column.path[0].index >= 0 {
+			return ColumnStats{}, false
+		}
+		return stats(column.path[0].name, column.path[0].quoted)
+	}
+
+	switch e := e.(type) {
+	case *binaryExpr:
+		switch e.op {
+		case "AND":
+			return mayMatch(e.left, stats) && mayMatch(e.right, stats)
+		case "OR":
+			return mayMatch(e.left, stats) || mayMatch(e.right, stats)
+		case "=", "!=", "<", "<=", ">", ">=":
+			op, left, right := e.op, e.left, e.right
+			if _, ok := left.(*literalExpr); ok {
+				op, left, right = reverseComparisons[op], right, left
+			}
+			literal, ok := right.(*literalExpr)
+			if !ok || literal.value == nil {
+				return true
+			}
+			s, ok := columnStats(left)
+			if !ok {
+				return true
+			}
+			return s.mayCompare(op, literal.value)
+		}
+	case *betweenExpr:
+		if !e.not {
+			return mayMatch(&binaryExpr{op: "AND",
+				left:  &binaryExpr{op: ">=", left: e.value, right: e.low},
+				right: &binaryExpr{op: "<=", left: e.value, right: e.high},
+			}, stats)
+		}
+	case *isNullExpr:
+		s, ok := columnStats(e.value)
+		if !ok || s.NullCount < 0 {
+			return true
+		}
+		if e.not {
+			return s.NullCount < s.Count
+		}
+		return s.NullCount > 0
+	}
+	return true
+}
+
+// Comparisons with swapped operands.
+var reverseComparisons = map[string]string{
+	"=": "=", "!=": "!=", "<": ">", "<=": ">=", ">": "<", ">=": "<=",
+}
+
+// mayCompare - returns false if no value in the range of the statistics
+// compares to value by op.
+func (s ColumnStats) mayCompare(op string, value interface{}) bool {
+	if s.Min == nil || s.Max == nil {
+		return true
+	}
+	minResult, minOK := compare(value, s.Min)
+	maxResult, maxOK := compare(value, s.Max)
+	if !minOK || !maxOK {
+		return true
+	}
+	switch op {
+	case "=":
+		return minResult >= 0 && maxResult <= 0
+	case "!=":
+		return minResult != 0 || maxResult != 0
+	case "<":
+		return minResult > 0
+	case "<=":
+		return minResult >= 0
+	case ">":
+		return maxResult < 0
+	}
+	return maxResult <= 0
+}
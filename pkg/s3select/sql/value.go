@@ -0,0 +1,247 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Values of expressions are nil, bool, int64, float64, string, Object
+// and []interface{}.
+
+// Field - named value of an object.
+type Field struct {
+	Name  string
+	Value interface{}
+}
+
+// Object - fields of a record or of a nested JSON object, in the order
+// they were read.
+type Object []Field
+
+// Get - returns the value of the named field. Names are matched case
+// insensitively unless caseSensitive is set.
+func (obj Object) Get(name string, caseSensitive bool) (interface{}, bool) {
+	for _, field := range obj {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+	if !caseSensitive {
+		for _, field := range obj {
+			if strings.EqualFold(field.Name, name) {
+				return field.Value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON - encodes the object keeping the order of its fields.
+func (obj Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range obj {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(field.Name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Record - record of the input an expression is evaluated on.
+type Record interface {
+	// Get - returns the value of the named column.
+	Get(name string, caseSensitive bool) (interface{}, bool)
+	// Object - returns all columns of the record, selected by `*`.
+	Object() Object
+}
+
+// FormatValue - returns the text representation of a value, as written
+// to CSV output.
+func FormatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// toNumber - converts v to int64 or float64, strings are parsed.
+func toNumber(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case int64, float64:
+		return v, true
+	case string:
+		s := strings.TrimSpace(v)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, true
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+func toFloat(v interface{}) float64 {
+	if i, ok := v.(int64); ok {
+		return float64(i)
+	}
+	return v.(float64)
+}
+
+func toBool(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b, nil
+		}
+	}
+	return nil, errInvalidArguments("Expected a boolean value, got " + FormatValue(v) + ".")
+}
+
+// compare - compares a and b, returns ok false if they are not
+// comparable. Strings are compared as numbers to numbers.
+func compare(a, b interface{}) (result int, ok bool) {
+	_, aIsString := a.(string)
+	_, bIsString := b.(string)
+	if aIsString && bIsString {
+		return strings.Compare(a.(string), b.(string)), true
+	}
+
+	if ab, isBool := a.(bool); isBool {
+		bb, isBool := b.(bool)
+		if !isBool {
+			return 0, false
+		}
+		switch {
+		case ab == bb:
+			return 0, true
+		case !ab:
+			return -1, true
+		}
+		return 1, true
+	}
+
+	an, aok := toNumber(a)
+	bn, bok := toNumber(b)
+	if !aok || !bok {
+		return 0, false
+	}
+	ai, aIsInt := an.(int64)
+	bi, bIsInt := bn.(int64)
+	if aIsInt && bIsInt {
+		switch {
+		case ai < bi:
+			return -1, true
+		case ai > bi:
+			return 1, true
+		}
+		return 0, true
+	}
+	af, bf := toFloat(an), toFloat(bn)
+	switch {
+	case af < bf:
+		return -1, true
+	case af > bf:
+		return 1, true
+	}
+	return 0, true
+}
+
+// cast - converts v to one of INT, FLOAT, STRING and BOOL.
+func cast(v interface{}, typ string) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch typ {
+	case "INT":
+		switch v := v.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(v), nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case string:
+			if n, ok := toNumber(v); ok {
+				return cast(n, typ)
+			}
+		}
+	case "FLOAT":
+		switch v := v.(type) {
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil && !math.IsInf(f, 0) {
+				return f, nil
+			}
+		}
+	case "STRING":
+		return FormatValue(v), nil
+	case "BOOL":
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case float64:
+			return v != 0, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+	}
+	return nil, errCastFailed(FormatValue(v), typ)
+}
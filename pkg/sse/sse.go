@@ -0,0 +1,99 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sse
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// Errors returned when validating a bucket encryption configuration.
+var (
+	ErrInvalidRules          = errors.New("encryption configuration must have exactly one rule")
+	ErrInvalidAlgorithm      = errors.New("SSE algorithm must be either AES256 or aws:kms")
+	ErrInvalidKMSMasterKeyID = errors.New("KMS master key ID is only allowed with the aws:kms algorithm")
+)
+
+// Algorithm - server-side encryption algorithm of new objects.
+type Algorithm string
+
+// Supported server-side encryption algorithms, objects are encrypted
+// with a data key of the KMS in both cases.
+const (
+	AES256 Algorithm = "AES256"
+	AWSKMS Algorithm = "aws:kms"
+)
+
+// ApplyServerSideEncryptionByDefault - encryption applied to new objects
+// stored without encryption headers.
+type ApplyServerSideEncryptionByDefault struct {
+	SSEAlgorithm   Algorithm `xml:"SSEAlgorithm"`
+	KMSMasterKeyID string    `xml:"KMSMasterKeyID,omitempty"`
+}
+
+// Rule - default encryption rule of a bucket.
+type Rule struct {
+	DefaultEncryption ApplyServerSideEncryptionByDefault `xml:"ApplyServerSideEncryptionByDefault"`
+}
+
+// Config - default encryption configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTencryption.html
+type Config struct {
+	XMLName xml.Name `xml:"ServerSideEncryptionConfiguration"`
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// Validate - checks that the configuration has a single rule with a
+// supported algorithm.
+func (config Config) Validate() error {
+	if len(config.Rules) != 1 {
+		return ErrInvalidRules
+	}
+	switch rule := config.Rules[0].DefaultEncryption; rule.SSEAlgorithm {
+	case AES256:
+		if rule.KMSMasterKeyID != "" {
+			return ErrInvalidKMSMasterKeyID
+		}
+	case AWSKMS:
+	default:
+		return ErrInvalidAlgorithm
+	}
+	return nil
+}
+
+// DefaultEncryption - returns the encryption applied to new objects.
+func (config Config) DefaultEncryption() ApplyServerSideEncryptionByDefault {
+	if len(config.Rules) == 0 {
+		return ApplyServerSideEncryptionByDefault{}
+	}
+	return config.Rules[0].DefaultEncryption
+}
+
+// ParseConfig - parses and validates a bucket encryption configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.XMLNS = ""
+	return &config, nil
+}
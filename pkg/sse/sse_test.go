@@ -0,0 +1,65 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	errMalformed := errors.New("malformed")
+	testCases := []struct {
+		config            string
+		expectedErr       error
+		expectedAlgorithm Algorithm
+		expectedKeyID     string
+	}{
+		{`<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`, nil, AES256, ""},
+		{`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`, nil, AWSKMS, "my-key"},
+		{`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`, nil, AWSKMS, ""},
+		{`<ServerSideEncryptionConfiguration></ServerSideEncryptionConfiguration>`, ErrInvalidRules, "", ""},
+		{`<ServerSideEncryptionConfiguration>` + strings.Repeat(`<Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule>`, 2) + `</ServerSideEncryptionConfiguration>`, ErrInvalidRules, "", ""},
+		{`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>DES</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`, ErrInvalidAlgorithm, "", ""},
+		{`<ServerSideEncryptionConfiguration><Rule></Rule></ServerSideEncryptionConfiguration>`, ErrInvalidAlgorithm, "", ""},
+		{`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`, ErrInvalidKMSMasterKeyID, "", ""},
+		{`<ServerSideEncryptionConfiguration><Rule>`, errMalformed, "", ""},
+	}
+
+	for i, testCase := range testCases {
+		config, err := ParseConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr == errMalformed {
+			if err == nil {
+				t.Fatalf("case %v: expected an error, got none", i+1)
+			}
+			continue
+		}
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if config.XMLNS != "" {
+			t.Fatalf("case %v: expected no namespace, got %v", i+1, config.XMLNS)
+		}
+		if rule := config.DefaultEncryption(); rule.SSEAlgorithm != testCase.expectedAlgorithm || rule.KMSMasterKeyID != testCase.expectedKeyID {
+			t.Fatalf("case %v: expected %v %v, got %v %v", i+1, testCase.expectedAlgorithm, testCase.expectedKeyID, rule.SSEAlgorithm, rule.KMSMasterKeyID)
+		}
+	}
+}
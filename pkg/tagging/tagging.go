@@ -0,0 +1,178 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tagging
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits of tags as per
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/object-tagging.html
+const (
+	// MaxObjectTags - maximum number of tags of an object.
+	MaxObjectTags = 10
+
+	// MaxBucketTags - maximum number of tags of a bucket.
+	MaxBucketTags = 50
+
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// Errors returned when validating tags.
+var (
+	ErrTooManyTags     = errors.New("too many tags")
+	ErrInvalidTagKey   = errors.New("invalid tag key")
+	ErrInvalidTagValue = errors.New("invalid tag value")
+	ErrDuplicateTagKey = errors.New("duplicate tag key")
+)
+
+// IsTaggingError - checks whether given error is a tag validation error or not.
+func IsTaggingError(err error) bool {
+	switch err {
+	case ErrTooManyTags, ErrInvalidTagKey, ErrInvalidTagValue, ErrDuplicateTagKey:
+		return true
+	}
+
+	return false
+}
+
+// Tag - a key and value pair.
+type Tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// isValidTagString - tag keys and values may only contain letters,
+// numbers, spaces and the characters + - = . _ : / @
+func isValidTagString(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsSpace(r) && !strings.ContainsRune("+-=._:/@", r) {
+			return false
+		}
+	}
+	return utf8.ValidString(s)
+}
+
+// Validate - checks the tag key and value, keys prefixed by "aws:" are
+// reserved.
+func (tag Tag) Validate() error {
+	if tag.Key == "" || utf8.RuneCountInString(tag.Key) > maxTagKeyLength ||
+		strings.HasPrefix(tag.Key, "aws:") || !isValidTagString(tag.Key) {
+		return ErrInvalidTagKey
+	}
+	if utf8.RuneCountInString(tag.Value) > maxTagValueLength || !isValidTagString(tag.Value) {
+		return ErrInvalidTagValue
+	}
+	return nil
+}
+
+// TagSet - set of tags.
+type TagSet struct {
+	Tags []Tag `xml:"Tag"`
+}
+
+// Tagging - tags of an object or a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTtagging.html
+type Tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	TagSet  TagSet   `xml:"TagSet"`
+}
+
+// Validate - checks every tag, and that there are at most maxTags tags
+// with distinct keys.
+func (tagging Tagging) Validate(maxTags int) error {
+	if len(tagging.TagSet.Tags) > maxTags {
+		return ErrTooManyTags
+	}
+	keys := make(map[string]struct{}, len(tagging.TagSet.Tags))
+	for _, tag := range tagging.TagSet.Tags {
+		if err := tag.Validate(); err != nil {
+			return err
+		}
+		if _, ok := keys[tag.Key]; ok {
+			return ErrDuplicateTagKey
+		}
+		keys[tag.Key] = struct{}{}
+	}
+	return nil
+}
+
+// ToMap - returns the tags as a map of tag keys to tag values.
+func (tagging Tagging) ToMap() map[string]string {
+	tags := make(map[string]string, len(tagging.TagSet.Tags))
+	for _, tag := range tagging.TagSet.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags
+}
+
+// String - returns the tags URL query encoded, sorted by key, as in the
+// x-amz-tagging header.
+func (tagging Tagging) String() string {
+	values := url.Values{}
+	for _, tag := range tagging.TagSet.Tags {
+		values.Set(tag.Key, tag.Value)
+	}
+	return values.Encode()
+}
+
+// ParseTagging - parses and validates tags in XML.
+func ParseTagging(reader io.Reader, maxTags int) (*Tagging, error) {
+	var tagging Tagging
+	if err := xml.NewDecoder(reader).Decode(&tagging); err != nil {
+		return nil, err
+	}
+	if err := tagging.Validate(maxTags); err != nil {
+		return nil, err
+	}
+	tagging.XMLNS = ""
+	return &tagging, nil
+}
+
+// ParseTags - parses and validates URL query encoded tags, e.g. the value
+// of the x-amz-tagging header. Tags are sorted by key.
+func ParseTags(s string, maxTags int) (*Tagging, error) {
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+
+	tagging := Tagging{}
+	for key, value := range values {
+		if len(value) > 1 {
+			return nil, ErrDuplicateTagKey
+		}
+		tagging.TagSet.Tags = append(tagging.TagSet.Tags, Tag{Key: key, Value: value[0]})
+	}
+	sort.Slice(tagging.TagSet.Tags, func(i, j int) bool {
+		return tagging.TagSet.Tags[i].Key < tagging.TagSet.Tags[j].Key
+	})
+
+	if err = tagging.Validate(maxTags); err != nil {
+		return nil, err
+	}
+	return &tagging, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tagging
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTagging(t *testing.T) {
+	testCases := []struct {
+		tagging        string
+		expectedResult map[string]string
+		expectedErr    error
+	}{
+		{`<Tagging xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><TagSet><Tag><Key>project</Key><Value>minio</Value></Tag></TagSet></Tagging>`, map[string]string{"project": "minio"}, nil},
+		{`<Tagging><TagSet></TagSet></Tagging>`, map[string]string{}, nil},
+		{`<Tagging><TagSet><Tag><Key>a</Key><Value></Value></Tag><Tag><Key>b c</Key><Value>d=e</Value></Tag></TagSet></Tagging>`, map[string]string{"a": "", "b c": "d=e"}, nil},
+		{`<Tagging><TagSet><Tag><Key>a</Key><Value>1</Value></Tag><Tag><Key>a</Key><Value>2</Value></Tag></TagSet></Tagging>`, nil, ErrDuplicateTagKey},
+		{`<Tagging><TagSet><Tag><Key></Key><Value>1</Value></Tag></TagSet></Tagging>`, nil, ErrInvalidTagKey},
+		{`<Tagging><TagSet><Tag><Key>aws:key</Key><Value>1</Value></Tag></TagSet></Tagging>`, nil, ErrInvalidTagKey},
+		{`<Tagging><TagSet><Tag><Key>key</Key><Value>a&amp;b</Value></Tag></TagSet></Tagging>`, nil, ErrInvalidTagValue},
+		{`<Tagging><TagSet><Tag><Key>key</Key><Value>` + strings.Repeat("v", 257) + `</Value></Tag></TagSet></Tagging>`, nil, ErrInvalidTagValue},
+		{`<Tagging><TagSet>` + strings.Repeat(`<Tag><Key>key</Key><Value>1</Value></Tag>`, 11) + `</TagSet></Tagging>`, nil, ErrTooManyTags},
+	}
+
+	for i, testCase := range testCases {
+		tagging, err := ParseTagging(strings.NewReader(testCase.tagging), MaxObjectTags)
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil {
+			if result := tagging.ToMap(); !reflect.DeepEqual(result, testCase.expectedResult) {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+			}
+		}
+	}
+}
+
+func TestIsTaggingError(t *testing.T) {
+	testCases := []struct {
+		err            error
+		expectedResult bool
+	}{
+		{ErrTooManyTags, true},
+		{ErrDuplicateTagKey, true},
+		{io.EOF, false},
+		{nil, false},
+	}
+
+	for i, testCase := range testCases {
+		if result := IsTaggingError(testCase.err); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	testCases := []struct {
+		tags           string
+		expectedResult string
+		expectedErr    bool
+	}{
+		{"", "", false},
+		{"project=minio", "project=minio", false},
+		{"b=2&a=1", "a=1&b=2", false},
+		{"key%20one=value%2Fone", "key+one=value%2Fone", false},
+		{"a=1&a=2", "", true},
+		{"=1", "", true},
+		{"a=%ZZ", "", true},
+	}
+
+	for i, testCase := range testCases {
+		tagging, err := ParseTags(testCase.tags, MaxObjectTags)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil {
+			if result := tagging.String(); result != testCase.expectedResult {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Errors returned when validating a website configuration.
+var (
+	ErrMissingIndexDocument = errors.New("website configuration must have an index document or redirect all requests")
+	ErrInvalidIndexSuffix   = errors.New("index document suffix must not be empty nor contain a slash")
+	ErrInvalidErrorKey      = errors.New("error document key must not be empty")
+	ErrInvalidRedirect      = errors.New("redirect of all requests must have a host name and a protocol of http or https")
+	ErrConflictingRedirect  = errors.New("redirect of all requests must be the only element of the website configuration")
+)
+
+// IndexDocument - object returned for requests to a directory, the
+// suffix is appended to the directory name.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - object returned on 4XX errors.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// Redirect - host, and optionally protocol, all requests are redirected to.
+type Redirect struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// RoutingRules - conditional redirects, kept as is.
+type RoutingRules struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+// Config - website configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTwebsite.html
+type Config struct {
+	XMLName               xml.Name       `xml:"WebsiteConfiguration"`
+	XMLNS                 string         `xml:"xmlns,attr,omitempty"`
+	RedirectAllRequestsTo *Redirect      `xml:"RedirectAllRequestsTo,omitempty"`
+	IndexDocument         *IndexDocument `xml:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument `xml:"ErrorDocument,omitempty"`
+	RoutingRules          *RoutingRules  `xml:"RoutingRules,omitempty"`
+}
+
+// Validate - checks that the configuration either redirects all requests
+// or has a valid index document.
+func (config Config) Validate() error {
+	if redirect := config.RedirectAllRequestsTo; redirect != nil {
+		if config.IndexDocument != nil || config.ErrorDocument != nil || config.RoutingRules != nil {
+			return ErrConflictingRedirect
+		}
+		if redirect.HostName == "" || (redirect.Protocol != "" && redirect.Protocol != "http" && redirect.Protocol != "https") {
+			return ErrInvalidRedirect
+		}
+		return nil
+	}
+	if config.IndexDocument == nil {
+		return ErrMissingIndexDocument
+	}
+	if config.IndexDocument.Suffix == "" || strings.Contains(config.IndexDocument.Suffix, "/") {
+		return ErrInvalidIndexSuffix
+	}
+	if config.ErrorDocument != nil && config.ErrorDocument.Key == "" {
+		return ErrInvalidErrorKey
+	}
+	return nil
+}
+
+// IndexKey - returns the object name for a request to given key, the
+// index document of the directory if the key is empty or ends with a
+// slash.
+func (config Config) IndexKey(key string) string {
+	if config.IndexDocument != nil && (key == "" || strings.HasSuffix(key, "/")) {
+		return key + config.IndexDocument.Suffix
+	}
+	return key
+}
+
+// ErrorKey - returns the object name of the error document, empty if
+// there is none.
+func (config Config) ErrorKey() string {
+	if config.ErrorDocument == nil {
+		return ""
+	}
+	return config.ErrorDocument.Key
+}
+
+// ParseConfig - parses and validates a website configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.XMLNS = ""
+	return &config, nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Minio Cloud Storage, (C) 2018 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	errMalformed := errors.New("malformed")
+	testCases := []struct {
+		config      string
+		expectedErr error
+	}{
+		{`<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument></WebsiteConfiguration>`, nil},
+		{`<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`, nil},
+		{`<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`, nil},
+		{`<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo></WebsiteConfiguration>`, nil},
+		{`<WebsiteConfiguration></WebsiteConfiguration>`, ErrMissingIndexDocument},
+		{`<WebsiteConfiguration><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`, ErrMissingIndexDocument},
+		{`<WebsiteConfiguration><IndexDocument><Suffix></Suffix></IndexDocument></WebsiteConfiguration>`, ErrInvalidIndexSuffix},
+		{`<WebsiteConfiguration><IndexDocument><Suffix>docs/index.html</Suffix></IndexDocument></WebsiteConfiguration>`, ErrInvalidIndexSuffix},
+		{`<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key></Key></ErrorDocument></WebsiteConfiguration>`, ErrInvalidErrorKey},
+		{`<WebsiteConfiguration><RedirectAllRequestsTo><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`, ErrInvalidRedirect},
+		{`<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>ftp</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`, ErrInvalidRedirect},
+		{`<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo>` +
+			`<IndexDocument><Suffix>index.html</Suffix></IndexDocument></WebsiteConfiguration>`, ErrConflictingRedirect},
+		{`<WebsiteConfiguration>`, errMalformed},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.config))
+		if testCase.expectedErr == errMalformed {
+			if err == nil {
+				t.Fatalf("case %v: expected an error, got none", i+1)
+			}
+			continue
+		}
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestConfigIndexKey(t *testing.T) {
+	config := Config{IndexDocument: &IndexDocument{Suffix: "index.html"}}
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{"", "index.html"},
+		{"docs/", "docs/index.html"},
+		{"docs", "docs"},
+		{"docs/page.html", "docs/page.html"},
+	}
+	for i, testCase := range testCases {
+		if key := config.IndexKey(testCase.key); key != testCase.expected {
+			t.Errorf("case %v: expected %v, got %v", i+1, testCase.expected, key)
+		}
+	}
+}